@@ -17,6 +17,7 @@ func newDeployCmd() *cobra.Command {
 	var provision bool
 	var parallel int
 	var monitor bool
+	var retries int
 
 	cmd := &cobra.Command{
 		Use:   "deploy [network]",
@@ -34,6 +35,7 @@ func newDeployCmd() *cobra.Command {
 				return err
 			}
 			lab.Force = force
+			lab.DeployRetries = retries
 
 			if host != "" {
 				lab.FilterHost(host)
@@ -71,6 +73,7 @@ func newDeployCmd() *cobra.Command {
 	cmd.Flags().BoolVar(&provision, "provision", false, "provision devices after deploy")
 	cmd.Flags().IntVar(&parallel, "parallel", 1, "parallel provisioning threads")
 	cmd.Flags().BoolVarP(&monitor, "monitor", "m", false, "show live status during deploy")
+	cmd.Flags().IntVar(&retries, "retries", newtlab.DefaultDeployRetries, "retries on transient launch failures (port in use, locked disk); 0 disables")
 	return cmd
 }
 
@@ -110,7 +113,14 @@ func deployWithMonitor(cmd *cobra.Command, lab *newtlab.Lab, provision bool, par
 
 func printDeploySummary(lab *newtlab.Lab) {
 	state := lab.State
-	fmt.Printf("\n%s Deployed %s (%d nodes)\n\n", green("✓"), lab.NetworkID, len(state.Nodes))
+	fmt.Printf("\n%s Deployed %s (%d nodes)", green("✓"), lab.NetworkID, len(state.Nodes))
+	switch {
+	case state.DeployRetries == 1:
+		fmt.Print(" after 1 retry")
+	case state.DeployRetries > 1:
+		fmt.Printf(" after %d retries", state.DeployRetries)
+	}
+	fmt.Print("\n\n")
 	t := cli.NewTable("NODE", "STATUS", "SSH PORT", "CONSOLE").WithPrefix("  ")
 	nodeNames := make([]string, 0, len(state.Nodes))
 	for name := range state.Nodes {
@@ -95,8 +95,8 @@ var aclShowCmd = &cobra.Command{
 
 		for _, rule := range detail.Rules {
 			t.Row(rule.Name, rule.Priority, rule.Action,
-				defaultStr(rule.SrcIP, "-"),
-				defaultStr(rule.DstIP, "-"),
+				defaultStr(defaultStr(rule.SrcIP, rule.SrcIPv6), "-"),
+				defaultStr(defaultStr(rule.DstIP, rule.DstIPv6), "-"),
 				defaultStr(rule.Protocol, "-"),
 				defaultStr(rule.DstPort, "-"))
 		}
@@ -147,13 +147,17 @@ Examples:
 }
 
 var (
-	rulePriority int
-	ruleSrcIP    string
-	ruleDstIP    string
-	ruleProtocol string
-	ruleSrcPort  string
-	ruleDstPort  string
-	ruleAction   string
+	rulePriority   int
+	ruleSrcIP      string
+	ruleDstIP      string
+	ruleProtocol   string
+	ruleSrcPort    string
+	ruleDstPort    string
+	ruleAction     string
+	ruleSrcIPv6    string
+	ruleDstIPv6    string
+	ruleICMPv6Type string
+	ruleEtherType  string
 )
 
 var aclAddRuleCmd = &cobra.Command{
@@ -164,7 +168,12 @@ var aclAddRuleCmd = &cobra.Command{
 Requires -D (device) flag.
 
 Examples:
-  newtron -D leaf1-ny acl add-rule CUSTOM-ACL RULE_10 --priority 9999 --src-ip 10.0.0.0/8 --action permit`,
+  newtron -D leaf1-ny acl add-rule CUSTOM-ACL RULE_10 --priority 9999 --src-ip 10.0.0.0/8 --action permit
+  newtron -D leaf1-ny acl add-rule CUSTOM-V6 RULE_20 --src-ipv6 2001:db8::/32 --action deny
+
+A rule matches one address family: IPv4 (--src-ip/--dst-ip) or IPv6
+(--src-ipv6/--dst-ipv6/--icmpv6-type), as SONiC ACL tables are
+family-scoped.`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		aclName := args[0]
@@ -178,14 +187,18 @@ Examples:
 			return err
 		}
 		return displayWriteResult(app.client.AddACLRule(app.deviceName, aclName, newtron.ACLRuleAddRequest{
-			RuleName: ruleName,
-			Priority: rulePriority,
-			SrcIP:    ruleSrcIP,
-			DstIP:    ruleDstIP,
-			Protocol: ruleProtocol,
-			SrcPort:  ruleSrcPort,
-			DstPort:  ruleDstPort,
-			Action:   ruleAction,
+			RuleName:   ruleName,
+			Priority:   rulePriority,
+			SrcIP:      ruleSrcIP,
+			DstIP:      ruleDstIP,
+			Protocol:   ruleProtocol,
+			SrcPort:    ruleSrcPort,
+			DstPort:    ruleDstPort,
+			Action:     ruleAction,
+			SrcIPv6:    ruleSrcIPv6,
+			DstIPv6:    ruleDstIPv6,
+			ICMPv6Type: ruleICMPv6Type,
+			EtherType:  ruleEtherType,
 		}, execOpts()))
 	},
 }
@@ -216,14 +229,18 @@ Examples:
 			return err
 		}
 		return displayWriteResult(app.client.UpdateACLRule(app.deviceName, aclName, newtron.ACLRuleUpdateRequest{
-			RuleName: ruleName,
-			Priority: rulePriority,
-			SrcIP:    ruleSrcIP,
-			DstIP:    ruleDstIP,
-			Protocol: ruleProtocol,
-			SrcPort:  ruleSrcPort,
-			DstPort:  ruleDstPort,
-			Action:   ruleAction,
+			RuleName:   ruleName,
+			Priority:   rulePriority,
+			SrcIP:      ruleSrcIP,
+			DstIP:      ruleDstIP,
+			Protocol:   ruleProtocol,
+			SrcPort:    ruleSrcPort,
+			DstPort:    ruleDstPort,
+			Action:     ruleAction,
+			SrcIPv6:    ruleSrcIPv6,
+			DstIPv6:    ruleDstIPv6,
+			ICMPv6Type: ruleICMPv6Type,
+			EtherType:  ruleEtherType,
 		}, execOpts()))
 	},
 }
@@ -323,10 +340,14 @@ func init() {
 	aclAddRuleCmd.Flags().IntVar(&rulePriority, "priority", 9999, "Rule priority (higher = evaluated first)")
 	aclAddRuleCmd.Flags().StringVar(&ruleSrcIP, "src-ip", "", "Source IP/CIDR")
 	aclAddRuleCmd.Flags().StringVar(&ruleDstIP, "dst-ip", "", "Destination IP/CIDR")
-	aclAddRuleCmd.Flags().StringVar(&ruleProtocol, "protocol", "", "IP protocol (tcp, udp, icmp, or number)")
+	aclAddRuleCmd.Flags().StringVar(&ruleProtocol, "protocol", "", "IP protocol (tcp, udp, icmp, icmpv6, or number)")
 	aclAddRuleCmd.Flags().StringVar(&ruleSrcPort, "src-port", "", "Source port or range")
 	aclAddRuleCmd.Flags().StringVar(&ruleDstPort, "dst-port", "", "Destination port or range")
 	aclAddRuleCmd.Flags().StringVar(&ruleAction, "action", "", "Action (permit, deny)")
+	aclAddRuleCmd.Flags().StringVar(&ruleSrcIPv6, "src-ipv6", "", "Source IPv6 CIDR")
+	aclAddRuleCmd.Flags().StringVar(&ruleDstIPv6, "dst-ipv6", "", "Destination IPv6 CIDR")
+	aclAddRuleCmd.Flags().StringVar(&ruleICMPv6Type, "icmpv6-type", "", "ICMPv6 type (0-255)")
+	aclAddRuleCmd.Flags().StringVar(&ruleEtherType, "ether-type", "", "Ethertype, e.g. 0x86DD")
 
 	aclBindCmd.Flags().StringVar(&aclBindDirection, "direction", "ingress", "Direction (ingress, egress)")
 
@@ -337,10 +358,14 @@ func init() {
 	aclUpdateRuleCmd.Flags().IntVar(&rulePriority, "priority", 9999, "Rule priority (higher = evaluated first)")
 	aclUpdateRuleCmd.Flags().StringVar(&ruleSrcIP, "src-ip", "", "Source IP/CIDR")
 	aclUpdateRuleCmd.Flags().StringVar(&ruleDstIP, "dst-ip", "", "Destination IP/CIDR")
-	aclUpdateRuleCmd.Flags().StringVar(&ruleProtocol, "protocol", "", "IP protocol (tcp, udp, icmp, or number)")
+	aclUpdateRuleCmd.Flags().StringVar(&ruleProtocol, "protocol", "", "IP protocol (tcp, udp, icmp, icmpv6, or number)")
 	aclUpdateRuleCmd.Flags().StringVar(&ruleSrcPort, "src-port", "", "Source port or range")
 	aclUpdateRuleCmd.Flags().StringVar(&ruleDstPort, "dst-port", "", "Destination port or range")
 	aclUpdateRuleCmd.Flags().StringVar(&ruleAction, "action", "", "Action (permit, deny)")
+	aclUpdateRuleCmd.Flags().StringVar(&ruleSrcIPv6, "src-ipv6", "", "Source IPv6 CIDR")
+	aclUpdateRuleCmd.Flags().StringVar(&ruleDstIPv6, "dst-ipv6", "", "Destination IPv6 CIDR")
+	aclUpdateRuleCmd.Flags().StringVar(&ruleICMPv6Type, "icmpv6-type", "", "ICMPv6 type (0-255)")
+	aclUpdateRuleCmd.Flags().StringVar(&ruleEtherType, "ether-type", "", "Ethertype, e.g. 0x86DD")
 	aclCmd.AddCommand(aclUpdateRuleCmd)
 	aclCmd.AddCommand(aclDeleteRuleCmd)
 	aclCmd.AddCommand(aclDeleteCmd)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
 
 	"github.com/spf13/cobra"
 
@@ -12,16 +13,18 @@ import (
 
 var bgpCmd = &cobra.Command{
 	Use:   "bgp",
-	Short: "BGP visibility (read-only)",
-	Long: `View BGP configuration and operational state.
+	Short: "BGP visibility and multipath tuning",
+	Long: `View BGP configuration and operational state, and tune the
+device-global multipath width.
 
-BGP is visibility-only in this noun group. Peer management lives in
-'vrf add-neighbor' (direct, interface-level) and 'evpn setup' (overlay).
+Peer management does not live in this noun group: see 'vrf add-neighbor'
+(direct, interface-level) and 'evpn setup' (overlay).
 
 Requires -D (device) flag.
 
 Examples:
-  newtron leaf1 bgp status`,
+  newtron leaf1 bgp status
+  newtron -D leaf1 bgp set-max-paths 8 -x`,
 }
 
 // ============================================================================
@@ -104,7 +107,7 @@ Examples:
 		// --- Configured Neighbors Table ---
 		if len(status.Neighbors) > 0 {
 			fmt.Println("\nConfigured Neighbors:")
-			t := cli.NewTable("NEIGHBOR", "TYPE", "REMOTE AS", "LOCAL ADDR", "DESCRIPTION", "ADMIN").WithPrefix("  ")
+			t := cli.NewTable("NEIGHBOR", "TYPE", "REMOTE AS", "LOCAL ADDR", "DESCRIPTION", "MAX PREFIX", "ADMIN").WithPrefix("  ")
 
 			for _, nb := range status.Neighbors {
 				localAddr := nb.LocalAddr
@@ -113,7 +116,11 @@ Examples:
 				}
 				adminStatus := formatAdminStatus(nb.Admin)
 				description := dash(nb.Name)
-				t.Row(nb.Address, nb.Type, nb.RemoteAS, localAddr, description, adminStatus)
+				maxPrefix := dash(nb.MaxPrefix)
+				if nb.MaxPrefixAction != "" {
+					maxPrefix += " (" + nb.MaxPrefixAction + ")"
+				}
+				t.Row(nb.Address, nb.Type, nb.RemoteAS, localAddr, description, maxPrefix, adminStatus)
 			}
 			t.Flush()
 		}
@@ -174,6 +181,8 @@ Examples:
 	},
 }
 
+var bgpCheckVRF string
+
 var bgpCheckCmd = &cobra.Command{
 	Use:   "check",
 	Short: "Check BGP session health",
@@ -181,19 +190,22 @@ var bgpCheckCmd = &cobra.Command{
 
 Returns the status of all configured BGP sessions — whether they are
 established, down, or misconfigured. Useful for quick health validation
-after provisioning or as a monitoring probe.
+after provisioning or as a monitoring probe. --vrf limits the check to one
+VRF's sessions, so a down customer-VRF peer is not hidden among healthy
+underlay peers.
 
 Requires -D (device) flag.
 
 Examples:
   newtron leaf1 bgp check
+  newtron leaf1 bgp check --vrf Vrf_CUST1
   newtron leaf1 bgp check --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := requireDevice(); err != nil {
 			return err
 		}
 
-		results, err := app.client.CheckBGPSessions(app.deviceName)
+		results, err := app.client.CheckBGPSessions(app.deviceName, bgpCheckVRF)
 		if err != nil {
 			return err
 		}
@@ -244,7 +256,7 @@ Examples:
 			return err
 		}
 
-		results, err := app.client.CheckBGPSessions(app.deviceName)
+		results, err := app.client.CheckBGPSessions(app.deviceName, "")
 		if err != nil {
 			return err
 		}
@@ -277,8 +289,55 @@ Examples:
 	},
 }
 
+var bgpSetMaxPathsCmd = &cobra.Command{
+	Use:   "set-max-paths <n>",
+	Short: "Set the BGP multipath (ECMP) width",
+	Long: `Set how many equal-cost BGP paths the default VRF installs per prefix
+(FRR maximum-paths, eBGP and iBGP). Device-global: setting it again replaces
+the previous width; the prior value is shown as the change's "from" row.
+A width beyond the platform's max_ecmp_paths is refused.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny bgp set-max-paths 8 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid path count %q", args[0])
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.SetBGPMaxPaths(app.deviceName, paths, execOpts()))
+	},
+}
+
+var bgpClearMaxPathsCmd = &cobra.Command{
+	Use:   "clear-max-paths",
+	Short: "Remove the BGP multipath width",
+	Long: `Remove the multipath width, returning BGP to FRR's default.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny bgp clear-max-paths -x`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.ClearBGPMaxPaths(app.deviceName, execOpts()))
+	},
+}
+
 func init() {
 	bgpCmd.AddCommand(bgpStatusCmd)
 	bgpCmd.AddCommand(bgpCheckCmd)
 	bgpCmd.AddCommand(bgpNeighborCmd)
+	bgpCmd.AddCommand(bgpSetMaxPathsCmd)
+	bgpCmd.AddCommand(bgpClearMaxPathsCmd)
+
+	bgpCheckCmd.Flags().StringVar(&bgpCheckVRF, "vrf", "", "Check only this VRF's sessions (default: all VRFs)")
 }
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -22,7 +23,8 @@ Requires -D (device) flag.
 Examples:
   newtron -D leaf1 configdb keys VLAN
   newtron -D leaf1 configdb query BGP_GLOBALS default
-  newtron -D leaf1 configdb exists INTERFACE Ethernet0`,
+  newtron -D leaf1 configdb exists INTERFACE Ethernet0
+  newtron -D leaf1 configdb export -o leaf1.json`,
 }
 
 var configdbKeysCmd = &cobra.Command{
@@ -171,6 +173,95 @@ Examples:
 	},
 }
 
+var configdbExportOutput string
+
+var configdbExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the device's CONFIG_DB as config_db.json",
+	Long: `Write the device's entire CONFIG_DB in SONiC's config_db.json shape —
+the file 'config save' writes: list fields as JSON arrays, field-less rows
+as {}, keys sorted.
+
+The output is stable: importing it with 'configdb import' writes nothing,
+and exporting again yields the same bytes.
+
+Requires -D (device) flag. With --topology or --loopback the file is
+rendered offline from the node's intents — what the device's CONFIG_DB
+would hold once they are applied to an empty one — and the device is
+never contacted.
+
+Examples:
+  newtron -D leaf1 configdb export
+  newtron -D leaf1 configdb export -o leaf1-config_db.json
+  newtron -D leaf1 --topology configdb export -o leaf1-rendered.json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		var buf bytes.Buffer
+		if err := app.client.ExportConfigDB(app.deviceName, &buf); err != nil {
+			return err
+		}
+
+		if configdbExportOutput == "" {
+			_, err := buf.WriteTo(os.Stdout)
+			return err
+		}
+		if err := os.WriteFile(configdbExportOutput, buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+		fmt.Printf("CONFIG_DB exported to %s\n", configdbExportOutput)
+		return nil
+	},
+}
+
+var configdbImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Apply a config_db.json file to the device's CONFIG_DB",
+	Long: `Apply a config_db.json file to the device's CONFIG_DB, table by table.
+
+Every table the file names ends up holding exactly the file's rows; tables
+it does not name are left alone. A full export therefore restores the whole
+CONFIG_DB, and a file holding one table replaces just that table. Rows that
+already match are not written, and changed rows are updated in place. PORT
+rows are never deleted. Use '-' to read the file from stdin.
+
+Unlike reload-config, no services restart: daemons see the changes as
+ordinary CONFIG_DB updates. Nothing is saved to disk — follow with
+save-config to persist.
+
+Requires -D (device) flag and a live device.
+
+Examples:
+  newtron -D leaf1 configdb import leaf1-config_db.json
+  newtron -D leaf1 configdb export | newtron -D leaf2 configdb import -`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		in := os.Stdin
+		if args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			in = f
+		}
+
+		if err := app.client.ImportConfigDB(app.deviceName, in); err != nil {
+			return err
+		}
+
+		fmt.Println("CONFIG_DB imported.")
+		return nil
+	},
+}
+
 func init() {
 	configdbSnapshotCmd.Flags().BoolVar(&configdbSnapshotOwnedOnly, "owned-only", false, "Return only newtron-owned tables (the managed subset) instead of the full device CONFIG_DB")
 
@@ -178,4 +269,8 @@ func init() {
 	configdbCmd.AddCommand(configdbKeysCmd)
 	configdbCmd.AddCommand(configdbQueryCmd)
 	configdbCmd.AddCommand(configdbExistsCmd)
+
+	configdbExportCmd.Flags().StringVarP(&configdbExportOutput, "output", "o", "", "Write to this file instead of stdout")
+	configdbCmd.AddCommand(configdbExportCmd)
+	configdbCmd.AddCommand(configdbImportCmd)
 }
@@ -1,15 +1,25 @@
 package main
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
 
+	"github.com/aldrin-isaac/newtron/pkg/cli"
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/api"
 )
 
 var deviceCmd = &cobra.Command{
 	Use:   "device",
 	Short: "Device-level operations",
-	Long: `Device-level operations (setup, metadata).
+	Long: `Device-level operations (setup, metadata, counter polling, banner,
+mirror sessions).
 
 The 'setup' command creates the device root intent and configures baseline
 infrastructure (metadata, loopback, BGP). This is required before any
@@ -18,7 +28,11 @@ service operations — the intent DAG requires a 'device' root.
 Examples:
   newtron leaf1 device setup -x
   newtron leaf1 device setup --hostname leaf1 --type LeafRouter -x
-  newtron leaf1 device setup --vtep-source 10.0.0.1 -x`,
+  newtron leaf1 device setup --vtep-source 10.0.0.1 -x
+  newtron leaf1 device set-counter-polling ACL --interval 5s -x
+  newtron leaf1 device set-banner --login "Authorized access only." -x
+  newtron leaf1 device create-mirror-session COLLECTOR --dst-ip 172.16.0.10 --port Ethernet0 --vrf Vrf_MGMT -x
+  newtron leaf1 device breakout-modes`,
 }
 
 // setup flags
@@ -92,6 +106,224 @@ Examples:
 	},
 }
 
+// counter polling flags
+var (
+	counterPollDisable  bool
+	counterPollInterval time.Duration
+)
+
+var deviceSetCounterPollingCmd = &cobra.Command{
+	Use:   "set-counter-polling <counter-type>",
+	Short: "Enable or disable flex counter polling for a counter group",
+	Long: `Enable (or, with --disable, disable) flex counter polling for one
+counter group and set its polling interval. Counters in an unpolled group
+never move — ACL counters in particular are not polled until enabled.
+
+Counter types: PORT, RIF, QUEUE, PG_DROP, PORT_BUFFER_DROP, PG_WATERMARK,
+QUEUE_WATERMARK, BUFFER_POOL_WATERMARK, ACL, TUNNEL, FLOW_CNT_TRAP,
+FLOW_CNT_ROUTE (case-insensitive). The interval must be 100ms-60s; omitted,
+the group's SONiC default is used.
+
+Examples:
+  newtron leaf1 device set-counter-polling ACL --interval 5s -x
+  newtron leaf1 device set-counter-polling queue --disable -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.SetCounterPolling(app.deviceName, args[0], !counterPollDisable, counterPollInterval, execOpts()))
+	},
+}
+
+var deviceClearCounterPollingCmd = &cobra.Command{
+	Use:   "clear-counter-polling <counter-type>",
+	Short: "Return a counter group to its factory polling state",
+	Long: `Undo set-counter-polling for one counter group. Groups SONiC polls at
+boot (PORT, QUEUE, ...) go back to enabled at their default interval; the
+others (ACL, TUNNEL, flow counters) are unconfigured again.
+
+Examples:
+  newtron leaf1 device clear-counter-polling ACL -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.ClearCounterPolling(app.deviceName, args[0], execOpts()))
+	},
+}
+
+// banner flags
+var (
+	bannerLogin  string
+	bannerMOTD   string
+	bannerLogout string
+)
+
+var deviceSetBannerCmd = &cobra.Command{
+	Use:   "set-banner",
+	Short: "Set the login banner and message of the day",
+	Long: `Set the device's login banner (shown before authentication), message of
+the day (shown after login), and logout message. At least one is required.
+Setting the banner again replaces all three — an omitted message is blank.
+
+Examples:
+  newtron leaf1 device set-banner --login "Authorized access only." -x
+  newtron leaf1 device set-banner --login "Authorized access only." \
+      --motd $'leaf1 — production fabric\nChanges via newtron only.' -x`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		cfg := newtron.BannerConfig{Login: bannerLogin, MOTD: bannerMOTD, Logout: bannerLogout}
+		return displayWriteResult(app.client.SetBanner(app.deviceName, cfg, execOpts()))
+	},
+}
+
+var deviceClearBannerCmd = &cobra.Command{
+	Use:   "clear-banner",
+	Short: "Remove the banner, restoring the image defaults",
+	Long: `Undo set-banner: the device shows its image's default login banner and
+message of the day again.
+
+Examples:
+  newtron leaf1 device clear-banner -x`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.ClearBanner(app.deviceName, execOpts()))
+	},
+}
+
+var (
+	mirrorDstIP     string
+	mirrorSrcIP     string
+	mirrorPorts     []string
+	mirrorDirection string
+	mirrorVRF       string
+	mirrorDSCP      int
+	mirrorTTL       int
+	mirrorGREType   string
+)
+
+var deviceCreateMirrorSessionCmd = &cobra.Command{
+	Use:   "create-mirror-session <name>",
+	Short: "Mirror ports to a remote collector over ERSPAN",
+	Long: `Mirror ports' traffic to a remote collector over ERSPAN: copies are
+GRE-encapsulated from the source address to the destination and routed there
+in the session's VRF.
+
+Writes one MIRROR_SESSION row. The destination must be reachable in the VRF —
+a connected subnet or static route covering it, or BGP running in the VRF —
+and a non-default VRF must exist. Creating the session again with different
+settings replaces them; to move it to another VRF, delete it first.
+
+Options:
+  --dst-ip <ip>        Collector address (required)
+  --port <name>        Port to mirror (repeatable, required)
+  --src-ip <ip>        GRE source address (default: the device loopback)
+  --direction <dir>    rx, tx, or both (default: both)
+  --vrf <name>         VRF the collector is reached in (default: the default VRF)
+  --dscp <0-63>        DSCP of the GRE packets (default: 0)
+  --ttl <1-255>        TTL of the GRE packets (default: 255)
+  --gre-type <hex>     GRE protocol type (default: 0x88be, ERSPAN II)
+
+Examples:
+  newtron leaf1 device create-mirror-session COLLECTOR --dst-ip 10.1.0.1 --port Ethernet0 -x
+  newtron leaf1 device create-mirror-session COLLECTOR --dst-ip 172.16.0.10 \
+      --port Ethernet0 --port Ethernet4 --direction rx --vrf Vrf_MGMT --dscp 8 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		if mirrorDstIP == "" {
+			return fmt.Errorf("--dst-ip is required")
+		}
+		if len(mirrorPorts) == 0 {
+			return fmt.Errorf("at least one --port is required")
+		}
+		return displayWriteResult(app.client.CreateMirrorSession(app.deviceName, api.MirrorSessionRequest{
+			Name:      args[0],
+			DstIP:     mirrorDstIP,
+			SrcIP:     mirrorSrcIP,
+			Ports:     mirrorPorts,
+			Direction: mirrorDirection,
+			VRF:       mirrorVRF,
+			DSCP:      mirrorDSCP,
+			TTL:       mirrorTTL,
+			GREType:   mirrorGREType,
+		}, execOpts()))
+	},
+}
+
+var deviceDeleteMirrorSessionCmd = &cobra.Command{
+	Use:   "delete-mirror-session <name>",
+	Short: "Remove a mirror session",
+	Long: `Undo create-mirror-session: the MIRROR_SESSION row is removed and the
+ports stop being mirrored.
+
+Examples:
+  newtron leaf1 device delete-mirror-session COLLECTOR -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.DeleteMirrorSession(app.deviceName, args[0], execOpts()))
+	},
+}
+
+var deviceBreakoutModesCmd = &cobra.Command{
+	Use:     "breakout-modes",
+	Aliases: []string{"show-breakout-modes"},
+	Short:   "Show the breakout modes each port supports",
+	Long: `Show the breakout modes each parent port accepts, from the platform's
+port data (platform.json breakout_modes). On a connected device the list is
+narrowed to the ports CONFIG_DB BREAKOUT_CFG names, and each port's current
+mode is always included.
+
+Examples:
+  newtron leaf1 device breakout-modes
+  newtron leaf1 device breakout-modes --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		modes, err := app.client.BreakoutModes(app.deviceName)
+		if err != nil {
+			return fmt.Errorf("getting breakout modes: %w", err)
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(modes)
+		}
+
+		if len(modes) == 0 {
+			fmt.Println("No breakout-capable ports")
+			return nil
+		}
+
+		ports := make([]string, 0, len(modes))
+		for port := range modes {
+			ports = append(ports, port)
+		}
+		sort.Strings(ports)
+		t := cli.NewTable("PORT", "MODES")
+		for _, port := range ports {
+			t.Row(port, strings.Join(modes[port], ", "))
+		}
+		t.Flush()
+		return nil
+	},
+}
+
 func init() {
 	deviceSetupCmd.Flags().StringVar(&setupHostname, "hostname", "", "Device hostname (default: device name)")
 	deviceSetupCmd.Flags().StringVar(&setupBGPASN, "bgp-asn", "", "BGP autonomous system number")
@@ -99,5 +331,28 @@ func init() {
 	deviceSetupCmd.Flags().StringVar(&setupHWSKU, "hwsku", "", "Hardware SKU (e.g., Force10-S6000)")
 	deviceSetupCmd.Flags().StringVar(&setupVTEP, "vtep-source", "", "VTEP source IP for VXLAN overlay")
 
+	deviceSetCounterPollingCmd.Flags().BoolVar(&counterPollDisable, "disable", false, "Disable polling instead of enabling it")
+	deviceSetCounterPollingCmd.Flags().DurationVar(&counterPollInterval, "interval", 0, "Polling interval, e.g. 5s (default: the group's SONiC default)")
+
+	deviceSetBannerCmd.Flags().StringVar(&bannerLogin, "login", "", "Login banner, shown before authentication")
+	deviceSetBannerCmd.Flags().StringVar(&bannerMOTD, "motd", "", "Message of the day, shown after login")
+	deviceSetBannerCmd.Flags().StringVar(&bannerLogout, "logout", "", "Logout message")
+
+	deviceCreateMirrorSessionCmd.Flags().StringVar(&mirrorDstIP, "dst-ip", "", "Collector address (required)")
+	deviceCreateMirrorSessionCmd.Flags().StringVar(&mirrorSrcIP, "src-ip", "", "GRE source address (default: the device loopback)")
+	deviceCreateMirrorSessionCmd.Flags().StringArrayVar(&mirrorPorts, "port", nil, "Port to mirror (repeatable)")
+	deviceCreateMirrorSessionCmd.Flags().StringVar(&mirrorDirection, "direction", "", "rx, tx, or both (default: both)")
+	deviceCreateMirrorSessionCmd.Flags().StringVar(&mirrorVRF, "vrf", "", "VRF the collector is reached in (default: the default VRF)")
+	deviceCreateMirrorSessionCmd.Flags().IntVar(&mirrorDSCP, "dscp", 0, "DSCP of the GRE packets, 0-63")
+	deviceCreateMirrorSessionCmd.Flags().IntVar(&mirrorTTL, "ttl", 0, "TTL of the GRE packets (default: 255)")
+	deviceCreateMirrorSessionCmd.Flags().StringVar(&mirrorGREType, "gre-type", "", "GRE protocol type (default: 0x88be)")
+
 	deviceCmd.AddCommand(deviceSetupCmd)
+	deviceCmd.AddCommand(deviceSetCounterPollingCmd)
+	deviceCmd.AddCommand(deviceClearCounterPollingCmd)
+	deviceCmd.AddCommand(deviceSetBannerCmd)
+	deviceCmd.AddCommand(deviceClearBannerCmd)
+	deviceCmd.AddCommand(deviceCreateMirrorSessionCmd)
+	deviceCmd.AddCommand(deviceDeleteMirrorSessionCmd)
+	deviceCmd.AddCommand(deviceBreakoutModesCmd)
 }
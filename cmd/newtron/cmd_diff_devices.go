@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aldrin-isaac/newtron/pkg/cli"
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+var diffIgnore []string
+
+var diffDevicesCmd = &cobra.Command{
+	Use:   "diff-devices <device-a> <device-b>",
+	Short: "Compare two devices' CONFIG_DBs with per-device values normalized",
+	Long: `Compare the newtron-owned CONFIG_DB of two devices that should be
+configured alike — MC-LAG peers, ECMP-equivalent leaves — and report where
+they differ.
+
+Values expected to differ between any two devices are normalized first and
+appear as placeholders: <hostname>, <loopback_ip>, <router_id>,
+<vtep_source_ip>, <mgmt_ip>, and <asn> (whole values only). What remains
+is a real discrepancy.
+
+--ignore leaves out expected differences (repeatable):
+  TABLE         a whole table            (BGP_NEIGHBOR)
+  TABLE|KEY     one entry                (INTERFACE|Ethernet0|10.1.0.0/31)
+  PREFIX*       entries by prefix        (INTERFACE|Ethernet0*)
+Keys are matched after normalization, so patterns may use placeholders.
+
+Examples:
+  newtron diff-devices leaf1 leaf2
+  newtron diff-devices leaf1 leaf2 --ignore BGP_NEIGHBOR --ignore 'INTERFACE|Ethernet0*'
+  newtron diff-devices leaf1 leaf2 --json`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		diff, err := app.client.DiffDevices(args[0], args[1], diffIgnore)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(diff)
+		}
+
+		label := diff.DeviceA + " vs " + diff.DeviceB
+		if len(diff.Entries) == 0 {
+			fmt.Printf("\nDevice Diff for %s: %s\n", bold(label), green("CONSISTENT"))
+			fmt.Println("No differences in newtron-owned tables.")
+			return nil
+		}
+
+		fmt.Printf("\nDevice Diff for %s: %s\n", bold(label), red("DIFFERENT"))
+		printDeviceDiffEntries(diff)
+		return nil
+	},
+}
+
+func init() {
+	diffDevicesCmd.Flags().StringArrayVar(&diffIgnore, "ignore", nil, "Table, TABLE|KEY, or trailing-* prefix to leave out (repeatable)")
+}
+
+// printDeviceDiffEntries renders a device diff grouped like a drift report:
+// entries only on one side, then per-field differences.
+func printDeviceDiffEntries(diff *newtron.DeviceDiff) {
+	var onlyA, onlyB, modified []newtron.DeviceDiffEntry
+	for _, e := range diff.Entries {
+		switch e.Type {
+		case newtron.DeviceDiffOnlyA:
+			onlyA = append(onlyA, e)
+		case newtron.DeviceDiffOnlyB:
+			onlyB = append(onlyB, e)
+		case newtron.DeviceDiffModified:
+			modified = append(modified, e)
+		}
+	}
+
+	for _, side := range []struct {
+		device  string
+		entries []newtron.DeviceDiffEntry
+		fields  func(newtron.DeviceDiffEntry) map[string]string
+	}{
+		{diff.DeviceA, onlyA, func(e newtron.DeviceDiffEntry) map[string]string { return e.A }},
+		{diff.DeviceB, onlyB, func(e newtron.DeviceDiffEntry) map[string]string { return e.B }},
+	} {
+		if len(side.entries) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s (%d):\n", yellow("Only on "+side.device), len(side.entries))
+		t := cli.NewTable("TABLE", "KEY", "FIELDS")
+		for _, e := range side.entries {
+			t.Row(e.Table, e.Key, formatFields(side.fields(e)))
+		}
+		t.Flush()
+	}
+
+	if len(modified) > 0 {
+		fmt.Printf("\n%s (%d):\n", yellow("Modified entries"), len(modified))
+		t := cli.NewTable("TABLE", "KEY", "FIELD", diff.DeviceA, diff.DeviceB)
+		for _, e := range modified {
+			for _, field := range e.Fields {
+				t.Row(e.Table, e.Key, field, dash(e.A[field]), dash(e.B[field]))
+			}
+		}
+		t.Flush()
+	}
+}
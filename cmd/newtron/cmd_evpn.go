@@ -24,6 +24,8 @@ define L3 and L2 VPN parameters respectively. They do not require a device.
 
 Examples:
   newtron leaf1 evpn status
+  newtron leaf1 evpn vxlan-stats
+  newtron leaf1 evpn arp-suppression
   newtron evpn ipvpn list
   newtron evpn ipvpn create customer-vpn --l3vni 10001 -x
   newtron evpn macvpn list
@@ -127,6 +129,130 @@ var evpnStatusCmd = &cobra.Command{
 	},
 }
 
+// ============================================================================
+// evpn vxlan-stats — per-tunnel encap/decap counters
+// ============================================================================
+
+var evpnVXLANStatsCmd = &cobra.Command{
+	Use:   "vxlan-stats",
+	Short: "Show VXLAN tunnel encap/decap counters",
+	Long: `Show encap/decap packet and byte counters for each VXLAN tunnel — the
+local source VTEP and, under EVPN, one tunnel per remote VTEP — with the VNIs
+each carries. Counters are per tunnel, not per VNI.
+
+Tunnel counters are polled only with the TUNNEL counter group enabled:
+  newtron leaf1 device set-counter-polling TUNNEL -x
+
+Examples:
+  newtron leaf1 evpn vxlan-stats
+  newtron leaf1 evpn vxlan-stats --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		stats, err := app.client.VXLANStats(app.deviceName)
+		if err != nil {
+			return fmt.Errorf("getting VXLAN stats: %w", err)
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(stats)
+		}
+
+		if len(stats) == 0 {
+			fmt.Println("No VXLAN tunnels")
+			return nil
+		}
+
+		uncounted := false
+		t := cli.NewTable("TUNNEL", "REMOTE VTEP", "VNIS", "ENCAP PKTS", "ENCAP BYTES", "DECAP PKTS", "DECAP BYTES")
+		for _, st := range stats {
+			remote := st.RemoteVTEP
+			if remote == "" {
+				remote = "(local)"
+			}
+			vnis := make([]string, len(st.VNIs))
+			for i, v := range st.VNIs {
+				vnis[i] = fmt.Sprint(v)
+			}
+			if !st.Counted {
+				uncounted = true
+				t.Row(st.Tunnel, remote, strings.Join(vnis, ","), "-", "-", "-", "-")
+				continue
+			}
+			t.Row(st.Tunnel, remote, strings.Join(vnis, ","),
+				fmt.Sprint(st.EncapPackets), fmt.Sprint(st.EncapBytes),
+				fmt.Sprint(st.DecapPackets), fmt.Sprint(st.DecapBytes))
+		}
+		t.Flush()
+		if uncounted {
+			fmt.Printf("\n%s tunnels without counters: enable the TUNNEL counter group (device set-counter-polling TUNNEL)\n",
+				yellow("note:"))
+		}
+		return nil
+	},
+}
+
+// ============================================================================
+// evpn arp-suppression — per-VLAN suppression, intended vs in effect
+// ============================================================================
+
+var evpnARPSuppressionCmd = &cobra.Command{
+	Use:   "arp-suppression",
+	Short: "Show per-VLAN ARP suppression state",
+	Long: `Show each VLAN's EVPN ARP suppression at three layers: INTENDED (newtron's
+intents write it), CONFIGURED (the device's CONFIG_DB SUPPRESS_VLAN_NEIGH has
+suppress=on), and APPLIED (vlanmgrd has taken it up into APPL_DB
+SUPPRESS_VLAN_NEIGH_TABLE). A VLAN configured but not applied still floods ARP.
+
+Examples:
+  newtron leaf1 evpn arp-suppression
+  newtron leaf1 evpn arp-suppression --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		states, err := app.client.ARPSuppression(app.deviceName)
+		if err != nil {
+			return fmt.Errorf("getting ARP suppression state: %w", err)
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(states)
+		}
+
+		if len(states) == 0 {
+			fmt.Println("No VLANs")
+			return nil
+		}
+
+		onOff := func(b bool) string {
+			if b {
+				return "on"
+			}
+			return "off"
+		}
+		pending := false
+		t := cli.NewTable("VLAN", "INTENDED", "CONFIGURED", "APPLIED")
+		for _, st := range states {
+			applied := onOff(st.Applied)
+			if st.Configured && !st.Applied {
+				pending = true
+				applied = yellow(applied)
+			}
+			t.Row(st.VLAN, onOff(st.Intended), onOff(st.Configured), applied)
+		}
+		t.Flush()
+		if pending {
+			fmt.Printf("\n%s configured but not applied: vlanmgrd has not taken the VLAN up (does it have a VXLAN map?)\n",
+				yellow("note:"))
+		}
+		return nil
+	},
+}
+
 // ============================================================================
 // evpn ipvpn — spec authoring commands for IP-VPN definitions
 // ============================================================================
@@ -377,7 +503,11 @@ var evpnMacvpnShowCmd = &cobra.Command{
 			fmt.Printf("Anycast IP: %s\n", macvpn.AnycastIP)
 		}
 		if macvpn.AnycastMAC != "" {
-			fmt.Printf("Anycast MAC: %s\n", macvpn.AnycastMAC)
+			scope := "SAG_GLOBAL"
+			if macvpn.AnycastMACPerVLAN {
+				scope = "per-VLAN SAG"
+			}
+			fmt.Printf("Anycast MAC: %s (%s)\n", macvpn.AnycastMAC, scope)
 		}
 		if len(macvpn.RouteTargets) > 0 {
 			fmt.Printf("Route Targets: %s\n", strings.Join(macvpn.RouteTargets, ", "))
@@ -396,6 +526,7 @@ var (
 	macvpnRouteTargets string
 	macvpnARPSuppress  bool
 	macvpnDescription  string
+	macvpnPerVLANMAC   bool
 )
 
 var evpnMacvpnCreateCmd = &cobra.Command{
@@ -407,7 +538,8 @@ This is a spec authoring command that does not require a device connection.
 
 Examples:
   newtron evpn macvpn create servers-vlan100 --vni 1100 --vlan-id 100 -x
-  newtron evpn macvpn create servers-vlan100 --vni 1100 --vlan-id 100 --anycast-ip 10.1.100.1/24 --arp-suppress -x`,
+  newtron evpn macvpn create servers-vlan100 --vni 1100 --vlan-id 100 --anycast-ip 10.1.100.1/24 --arp-suppress -x
+  newtron evpn macvpn create web-vlan200 --vni 1200 --vlan-id 200 --anycast-ip 10.1.200.1/24 --anycast-mac 00:00:00:00:02:00 --anycast-mac-per-vlan -x`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
@@ -417,13 +549,14 @@ Examples:
 		}
 
 		req := newtron.CreateMACVPNRequest{
-			Name:           name,
-			VNI:            macvpnVNI,
-			VlanID:         macvpnVlanID,
-			AnycastIP:      macvpnAnycastIP,
-			AnycastMAC:     macvpnAnycastMAC,
-			ARPSuppression: macvpnARPSuppress,
-			Description:    macvpnDescription,
+			Name:              name,
+			VNI:               macvpnVNI,
+			VlanID:            macvpnVlanID,
+			AnycastIP:         macvpnAnycastIP,
+			AnycastMAC:        macvpnAnycastMAC,
+			ARPSuppression:    macvpnARPSuppress,
+			Description:       macvpnDescription,
+			AnycastMACPerVLAN: macvpnPerVLANMAC,
 		}
 		if macvpnRouteTargets != "" {
 			req.RouteTargets = strings.Split(macvpnRouteTargets, ",")
@@ -440,6 +573,9 @@ Examples:
 		if req.AnycastMAC != "" {
 			fmt.Printf("  Anycast MAC: %s\n", req.AnycastMAC)
 		}
+		if req.AnycastMACPerVLAN {
+			fmt.Println("  Anycast MAC scope: per-VLAN SAG")
+		}
 		if len(req.RouteTargets) > 0 {
 			fmt.Printf("  Route Targets: %v\n", req.RouteTargets)
 		}
@@ -608,6 +744,7 @@ func init() {
 	evpnMacvpnCreateCmd.Flags().IntVar(&macvpnVlanID, "vlan-id", 0, "VLAN ID for the MAC-VPN")
 	evpnMacvpnCreateCmd.Flags().StringVar(&macvpnAnycastIP, "anycast-ip", "", "Anycast gateway IP (CIDR)")
 	evpnMacvpnCreateCmd.Flags().StringVar(&macvpnAnycastMAC, "anycast-mac", "", "Anycast gateway MAC")
+	evpnMacvpnCreateCmd.Flags().BoolVar(&macvpnPerVLANMAC, "anycast-mac-per-vlan", false, "Configure the anycast MAC per VLAN (SAG) instead of device-wide (SAG_GLOBAL)")
 	evpnMacvpnCreateCmd.Flags().StringVar(&macvpnRouteTargets, "route-targets", "", "Comma-separated route targets")
 	evpnMacvpnCreateCmd.Flags().BoolVar(&macvpnARPSuppress, "arp-suppress", false, "Enable ARP suppression")
 	evpnMacvpnCreateCmd.Flags().StringVar(&macvpnDescription, "description", "", "MAC-VPN description")
@@ -637,6 +774,8 @@ func init() {
 
 	// evpn subcommands
 	evpnCmd.AddCommand(evpnStatusCmd)
+	evpnCmd.AddCommand(evpnVXLANStatsCmd)
+	evpnCmd.AddCommand(evpnARPSuppressionCmd)
 	evpnCmd.AddCommand(evpnIpvpnCmd)
 	evpnCmd.AddCommand(evpnMacvpnCmd)
 	evpnCmd.AddCommand(evpnAddPeerCmd)
@@ -23,7 +23,8 @@ Requires -D (device) flag.
 
 Examples:
   newtron -D switch1 health check
-  newtron -D switch1 health check --json`,
+  newtron -D switch1 health check --json
+  newtron -D switch1 health environment`,
 }
 
 var healthCheckCmd = &cobra.Command{
@@ -84,6 +85,90 @@ var healthCheckCmd = &cobra.Command{
 	},
 }
 
+var healthEnvironmentCmd = &cobra.Command{
+	Use:   "environment",
+	Short: "Show PSU, fan, and thermal sensor state",
+	Long: `Show the device's platform sensors as pmon publishes them to STATE_DB
+(PSU_INFO, FAN_INFO, TEMPERATURE_INFO). Virtual platforms have none.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		env, err := app.client.Environment(app.deviceName)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(env)
+		}
+
+		if len(env.PSUs) == 0 && len(env.Fans) == 0 && len(env.Thermals) == 0 {
+			fmt.Printf("No platform sensors on %s (virtual platform)\n", app.deviceName)
+			return nil
+		}
+
+		if len(env.PSUs) > 0 {
+			fmt.Println("PSUs:")
+			t := cli.NewTable("NAME", "MODEL", "PRESENT", "STATUS", "VOLTAGE", "POWER")
+			for _, p := range env.PSUs {
+				t.Row(p.Name, dash(p.Model), formatSensorBool(p.Present, "yes", "no"),
+					formatSensorBool(p.PowerGood, "OK", "NOT OK"),
+					fmt.Sprintf("%.2f V", p.Voltage), fmt.Sprintf("%.1f W", p.Power))
+			}
+			t.Flush()
+			fmt.Println()
+		}
+
+		if len(env.Fans) > 0 {
+			fmt.Println("Fans:")
+			t := cli.NewTable("NAME", "DRAWER", "PRESENT", "STATUS", "SPEED")
+			for _, f := range env.Fans {
+				speed := fmt.Sprintf("%d%%", f.Speed)
+				switch {
+				case f.UnderSpeed:
+					speed = red(speed + " (under)")
+				case f.OverSpeed:
+					speed = red(speed + " (over)")
+				}
+				t.Row(f.Name, dash(f.Drawer), formatSensorBool(f.Present, "yes", "no"),
+					formatSensorBool(f.OK, "OK", "NOT OK"), speed)
+			}
+			t.Flush()
+			fmt.Println()
+		}
+
+		if len(env.Thermals) > 0 {
+			fmt.Println("Thermals:")
+			t := cli.NewTable("NAME", "TEMP", "HIGH", "CRIT HIGH", "WARNING")
+			for _, th := range env.Thermals {
+				t.Row(th.Name, fmt.Sprintf("%.1f", th.Temperature),
+					formatThreshold(th.HighThreshold), formatThreshold(th.CriticalHighThreshold),
+					formatSensorBool(!th.Warning, "no", "YES"))
+			}
+			t.Flush()
+		}
+
+		return nil
+	},
+}
+
+// formatSensorBool renders a sensor flag green when healthy, red otherwise.
+func formatSensorBool(ok bool, good, bad string) string {
+	if ok {
+		return green(good)
+	}
+	return red(bad)
+}
+
+func formatThreshold(v float64) string {
+	if v == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", v)
+}
+
 func formatHealthStatus(status string) string {
 	switch status {
 	case "pass":
@@ -99,4 +184,5 @@ func formatHealthStatus(status string) string {
 
 func init() {
 	healthCmd.AddCommand(healthCheckCmd)
+	healthCmd.AddCommand(healthEnvironmentCmd)
 }
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aldrin-isaac/newtron/pkg/cli"
+)
+
+var impactKind string
+
+var impactCmd = &cobra.Command{
+	Use:   "impact <spec-name>",
+	Short: "Show what editing a shared spec would touch",
+	Long: `Show the blast radius of editing a network-scope spec before you change it:
+every spec that references it — directly, or through other specs (a prefix
+list used by a filter used by a service) — and every device interface where
+it or one of those specs is applied, per the topology steps.
+
+Each line shows the reference chain that reaches it, so the path from the
+edited spec to an affected interface is explicit.
+
+Spec names are unique per kind only. When the name is used by more than one
+kind, choose one with --kind (service, ipvpn, macvpn, filter, qos-policy,
+route-policy, prefix-list).
+
+Examples:
+  newtron impact bogons
+  newtron impact irb --kind ipvpn
+  newtron impact transit --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		impact, err := app.client.SpecImpact(impactKind, args[0])
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(impact)
+		}
+
+		fmt.Printf("\nImpact of editing %s\n", bold(impact.Kind+"/"+impact.Name))
+
+		fmt.Println("\nReferencing specs:")
+		if len(impact.Dependents) == 0 {
+			fmt.Println("  (none)")
+		} else {
+			t := cli.NewTable("KIND", "NAME", "FIELD", "VIA")
+			for _, d := range impact.Dependents {
+				t.Row(d.Kind, d.Name, d.Field, strings.Join(d.Via, " → "))
+			}
+			t.Flush()
+		}
+
+		fmt.Println("\nApplied on:")
+		if len(impact.Bindings) == 0 {
+			fmt.Println("  (nothing — no topology step applies it or a referencing spec)")
+			return nil
+		}
+		t := cli.NewTable("DEVICE", "INTERFACE", "SPEC", "VIA")
+		devices := map[string]bool{}
+		for _, b := range impact.Bindings {
+			iface := b.Interface
+			if iface == "" {
+				iface = "-"
+			}
+			t.Row(b.Device, iface, b.Spec, strings.Join(b.Via, " → "))
+			devices[b.Device] = true
+		}
+		t.Flush()
+
+		names := make([]string, 0, len(devices))
+		for d := range devices {
+			names = append(names, d)
+		}
+		sort.Strings(names)
+		fmt.Printf("\n%d binding(s) on %d device(s): %s\n", len(impact.Bindings), len(names), strings.Join(names, ", "))
+		return nil
+	},
+}
+
+func init() {
+	impactCmd.Flags().StringVar(&impactKind, "kind", "", "Spec kind, when the name is used by more than one (service, ipvpn, macvpn, filter, qos-policy, route-policy, prefix-list)")
+}
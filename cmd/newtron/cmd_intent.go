@@ -127,6 +127,67 @@ Examples:
 	},
 }
 
+// ============================================================================
+// intent import — brownfield adoption report
+// ============================================================================
+
+var intentImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Map a hand-configured device's interfaces onto service definitions",
+	Long: `Read a device configured without newtron and report, per interface,
+whether an existing service definition reproduces its config.
+
+Recognized interfaces come with the apply-service step that adopts them —
+add it to topology.json (or run it) to bring the interface under newtron.
+Unrecognized ones say what they look like and why nothing matched. Read-only:
+nothing is written to the device.
+
+Examples:
+  newtron leaf1 intent import
+  newtron leaf1 intent import --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		imp, err := app.client.ImportIntent(app.deviceName)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(imp)
+		}
+
+		fmt.Printf("\nImport Report for %s: %d recognized, %d unrecognized\n",
+			bold(app.deviceName), len(imp.Recognized), len(imp.Unrecognized))
+		if len(imp.Recognized) > 0 {
+			fmt.Printf("\n%s\n", green("Recognized:"))
+			t := cli.NewTable("INTERFACE", "SERVICE", "TYPE", "PARAMS", "NOTE")
+			for _, b := range imp.Recognized {
+				var params []string
+				for k, v := range b.Step.Params {
+					if k != "service" {
+						params = append(params, fmt.Sprintf("%s=%v", k, v))
+					}
+				}
+				sort.Strings(params)
+				t.Row(b.Interface, b.Service, b.ServiceType, strings.Join(params, " "), b.Note)
+			}
+			t.Flush()
+		}
+		if len(imp.Unrecognized) > 0 {
+			fmt.Printf("\n%s\n", yellow("Unrecognized:"))
+			t := cli.NewTable("INTERFACE", "TYPE", "NOTE")
+			for _, b := range imp.Unrecognized {
+				t.Row(b.Interface, dash(b.ServiceType), b.Note)
+			}
+			t.Flush()
+		}
+		return nil
+	},
+}
+
 // ============================================================================
 // intent reconcile — deliver projection to device to eliminate drift
 // ============================================================================
@@ -612,6 +673,7 @@ func init() {
 	intentCmd.AddCommand(intentSnapshotCmd)
 	intentCmd.AddCommand(intentSnapshotDiffCmd)
 	intentCmd.AddCommand(intentDriftCmd)
+	intentCmd.AddCommand(intentImportCmd)
 	intentCmd.AddCommand(intentReconcileCmd)
 	intentCmd.AddCommand(intentSaveCmd)
 	intentCmd.AddCommand(intentReloadCmd)
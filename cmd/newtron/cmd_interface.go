@@ -445,10 +445,191 @@ Examples:
 	},
 }
 
+var interfaceSetVlanTranslationCmd = &cobra.Command{
+	Use:   "set-vlan-translation <interface> <customer-vlan> <provider-vlan>",
+	Short: "Translate a customer VLAN to a provider VLAN on a port",
+	Long: `Rewrite a customer VLAN arriving on a bridged port to a provider VLAN.
+
+The provider VLAN must already exist. Routed ports are refused. Each
+customer VLAN is translated independently; remove one with
+clear-vlan-translation.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D pe1 interface set-vlan-translation Ethernet0 1010 200 -x`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		intfName := args[0]
+		inVLAN, err := strconv.Atoi(args[1])
+		if err != nil || inVLAN <= 0 {
+			return fmt.Errorf("customer-vlan must be a positive integer")
+		}
+		outVLAN, err := strconv.Atoi(args[2])
+		if err != nil || outVLAN <= 0 {
+			return fmt.Errorf("provider-vlan must be a positive integer")
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.SetVLANTranslation(app.deviceName, intfName, inVLAN, outVLAN, execOpts()))
+	},
+}
+
+var interfaceClearVlanTranslationCmd = &cobra.Command{
+	Use:   "clear-vlan-translation <interface> <customer-vlan>",
+	Short: "Remove a customer VLAN translation from a port",
+	Long: `Remove the translation for one customer VLAN. Other translations on
+the port are untouched.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D pe1 interface clear-vlan-translation Ethernet0 1010 -x`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		intfName := args[0]
+		inVLAN, err := strconv.Atoi(args[1])
+		if err != nil || inVLAN <= 0 {
+			return fmt.Errorf("customer-vlan must be a positive integer")
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.ClearVLANTranslation(app.deviceName, intfName, inVLAN, execOpts()))
+	},
+}
+
+var interfaceSetEgressShaperCmd = &cobra.Command{
+	Use:   "set-egress-shaper <interface> <rate-kbps>",
+	Short: "Rate-limit egress traffic on a port",
+	Long: `Shape all egress traffic on a port to a rate in kilobits per second.
+
+The rate must not exceed the port speed. Re-running with a new rate changes
+it in place. A bound QoS policy is unaffected; remove the shaper with
+clear-egress-shaper and check it with egress-shaper.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 interface set-egress-shaper Ethernet0 2000000 -x`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		intfName := args[0]
+		rate, err := strconv.Atoi(args[1])
+		if err != nil || rate <= 0 {
+			return fmt.Errorf("rate-kbps must be a positive integer")
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.SetEgressShaper(app.deviceName, intfName, rate, execOpts()))
+	},
+}
+
+var interfaceClearEgressShaperCmd = &cobra.Command{
+	Use:   "clear-egress-shaper <interface>",
+	Short: "Remove the egress rate limit from a port",
+	Long: `Remove a port's egress shaper. A bound QoS policy is unaffected.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 interface clear-egress-shaper Ethernet0 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.ClearEgressShaper(app.deviceName, args[0], execOpts()))
+	},
+}
+
+var interfaceEgressShaperCmd = &cobra.Command{
+	Use:   "egress-shaper <interface>",
+	Short: "Show a port's egress shaper rate",
+	Long: `Show the egress shaper on a port: the rate newtron intends and the rate
+configured in the device's CONFIG_DB. A mismatch, or a shaper that is not
+attached to the port, means the last set-egress-shaper has not landed.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 interface egress-shaper Ethernet0`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		sh, err := app.client.EgressShaper(app.deviceName, args[0])
+		if err != nil {
+			return err
+		}
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(sh)
+		}
+		if sh.RateKbps == 0 && sh.ConfiguredKbps == 0 {
+			fmt.Printf("No egress shaper on %s\n", sh.Interface)
+			return nil
+		}
+		fmt.Printf("Interface: %s\n", bold(sh.Interface))
+		fmt.Printf("Intended:   %d kbps\n", sh.RateKbps)
+		fmt.Printf("Configured: %d kbps\n", sh.ConfiguredKbps)
+		if sh.RateKbps != sh.ConfiguredKbps || !sh.Attached {
+			fmt.Println(yellow("Shaper not in sync with intent — the device does not carry the intended rate"))
+		}
+		return nil
+	},
+}
+
+var interfaceIsolateCmd = &cobra.Command{
+	Use:   "isolate <interface>",
+	Short: "Take a flapping link out of forwarding",
+	Long: `Isolate a link: shut its direct BGP peer so routes and ECMP next-hops are
+withdrawn first, detach it from its PortChannel, and set it admin down.
+
+The isolation is recorded with the state it displaced, so unisolate restores
+the prior admin status, LAG membership, and BGP peer. While isolated, the
+port's LAG membership and BGP peer cannot be removed and its admin status
+cannot be set. The result lists every change made.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 interface isolate Ethernet0 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.Isolate(app.deviceName, args[0], execOpts()))
+	},
+}
+
+var interfaceUnisolateCmd = &cobra.Command{
+	Use:   "unisolate <interface>",
+	Short: "Return an isolated link to forwarding",
+	Long: `Undo isolate: restore the port's prior admin status, rejoin its PortChannel,
+and re-enable its BGP peer.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 interface unisolate Ethernet0 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.Unisolate(app.deviceName, args[0], execOpts()))
+	},
+}
+
 func init() {
 	interfaceCmd.AddCommand(interfaceListCmd)
 	interfaceCmd.AddCommand(interfaceShowCmd)
 	interfaceCmd.AddCommand(interfaceStatusCmd)
+	interfaceCmd.AddCommand(interfaceFlapsCmd)
 	interfaceCmd.AddCommand(interfaceGetCmd)
 	interfaceCmd.AddCommand(interfaceSetCmd)
 	interfaceCmd.AddCommand(interfaceClearCmd)
@@ -456,6 +637,13 @@ func init() {
 	interfaceCmd.AddCommand(interfaceListAclsCmd)
 	interfaceCmd.AddCommand(interfaceListMembersCmd)
 	interfaceCmd.AddCommand(interfaceRemoveTrunkVlanCmd)
+	interfaceCmd.AddCommand(interfaceSetVlanTranslationCmd)
+	interfaceCmd.AddCommand(interfaceClearVlanTranslationCmd)
+	interfaceCmd.AddCommand(interfaceSetEgressShaperCmd)
+	interfaceCmd.AddCommand(interfaceClearEgressShaperCmd)
+	interfaceCmd.AddCommand(interfaceEgressShaperCmd)
+	interfaceCmd.AddCommand(interfaceIsolateCmd)
+	interfaceCmd.AddCommand(interfaceUnisolateCmd)
 }
 
 var interfaceStatusCmd = &cobra.Command{
@@ -554,3 +742,43 @@ Examples:
 		return nil
 	},
 }
+
+var interfaceFlapsCmd = &cobra.Command{
+	Use:   "flaps <interface>",
+	Short: "Show a port's link-flap count and last down/up times",
+	Long: `Show how many times a physical port's link has gone down since the port
+was created, and when it last went down and came back, as portsyncd records
+them in APPL_DB PORT_TABLE. A link that flapped and recovered looks healthy in
+"interface status"; its flap count does not.
+
+Requires -D (device) flag and a live device.
+
+Examples:
+  newtron -D leaf1 interface flaps Ethernet0`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		h, err := app.client.FlapHistory(app.deviceName, args[0])
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(h)
+		}
+
+		fmt.Printf("Interface: %s\n", bold(h.Interface))
+		if !h.Supported {
+			fmt.Println("Flap tracking: not recorded by this image")
+			return nil
+		}
+		fmt.Printf("Flaps: %d\n", h.FlapCount)
+		for _, ev := range h.Events {
+			fmt.Printf("Last %s: %s\n", ev.Status, ev.Time)
+		}
+		return nil
+	},
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -26,6 +27,8 @@ Examples:
   newtron -D leaf1-ny lag create PortChannel100 --members Ethernet0,Ethernet4
   newtron -D leaf1-ny lag add-interface PortChannel100 Ethernet8
   newtron -D leaf1-ny lag remove-interface PortChannel100 Ethernet8
+  newtron -D leaf1-ny lag set-hash-policy SRC_IP,DST_IP,L4_SRC_PORT,L4_DST_PORT
+  newtron -D leaf1-ny lag hash-distribution PortChannel100
   newtron -D leaf1-ny lag status`,
 }
 
@@ -190,6 +193,56 @@ Examples:
 	},
 }
 
+var lagHashWindow time.Duration
+
+var lagHashDistributionCmd = &cobra.Command{
+	Use:   "hash-distribution <lag-name>",
+	Short: "Show how a LAG spreads its traffic across members",
+	Long: `Sample the TX packet counters of every LAG member over a window and
+show each member's share of what the LAG sent. A LAG can be up with every
+member active and still send everything over one link (hash polarization);
+the skew line says how far the busiest member sits above an even split.
+
+The command waits for the whole window (default 10s, at most 1m), so send
+traffic while it runs.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny lag hash-distribution PortChannel100
+  newtron -D leaf1-ny lag hash-distribution PortChannel100 --window 30s`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		d, err := app.client.LAGHashDistribution(app.deviceName, args[0], lagHashWindow)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(d)
+		}
+
+		fmt.Printf("LAG: %s (%d packets sent in %s)\n\n", bold(d.Name), d.TxPackets, d.Window)
+		t := cli.NewTable("MEMBER", "TX PACKETS", "SHARE")
+		for _, m := range d.Members {
+			t.Row(m.Name, fmt.Sprintf("%d", m.TxPackets), fmt.Sprintf("%.1f%%", m.Share))
+		}
+		t.Flush()
+
+		fmt.Println()
+		if d.TxPackets == 0 {
+			fmt.Println("Skew: - (no traffic in the window)")
+			return nil
+		}
+		fmt.Printf("Skew: %.1f points above an even split\n", d.Skew)
+		return nil
+	},
+}
+
 var (
 	lagMembers  string
 	lagMinLinks int
@@ -293,11 +346,56 @@ Examples:
 	},
 }
 
+var lagSetHashPolicyCmd = &cobra.Command{
+	Use:   "set-hash-policy <field,...>",
+	Short: "Select the LAG/ECMP hash fields",
+	Long: `Select the packet fields the device hashes on to spread flows across
+LAG members and ECMP next-hops. Device-global: setting it again replaces
+the previous field set. The hash seed is derived per device.
+
+Fields: IN_PORT, DST_MAC, SRC_MAC, ETHERTYPE, VLAN_ID, IP_PROTOCOL, DST_IP,
+SRC_IP, L4_DST_PORT, L4_SRC_PORT, IPV6_FLOW_LABEL and their INNER_ variants.
+Fields the platform cannot hash on are refused.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny lag set-hash-policy SRC_IP,DST_IP,L4_SRC_PORT,L4_DST_PORT -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fields := strings.Split(args[0], ",")
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.SetLAGHashPolicy(app.deviceName, fields, execOpts()))
+	},
+}
+
+var lagClearHashPolicyCmd = &cobra.Command{
+	Use:   "clear-hash-policy",
+	Short: "Remove the LAG/ECMP hash field selection",
+	Long: `Remove the hash field selection and reset the hash seeds to the
+platform default.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny lag clear-hash-policy -x`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.ClearLAGHashPolicy(app.deviceName, execOpts()))
+	},
+}
+
 func init() {
 	lagCreateCmd.Flags().StringVar(&lagMembers, "members", "", "Comma-separated list of member interfaces (required)")
 	lagCreateCmd.Flags().IntVar(&lagMinLinks, "min-links", 1, "Minimum links required")
 	lagCreateCmd.Flags().BoolVar(&lagFastRate, "fast-rate", true, "Use LACP fast rate (1s vs 30s)")
 	lagCreateCmd.Flags().IntVar(&lagMTU, "mtu", 9100, "MTU size")
+	lagHashDistributionCmd.Flags().DurationVar(&lagHashWindow, "window", 0, "Sample window (default 10s, at most 1m)")
 
 	lagCmd.AddCommand(lagListCmd)
 	lagCmd.AddCommand(lagShowCmd)
@@ -306,4 +404,7 @@ func init() {
 	lagCmd.AddCommand(lagDeleteCmd)
 	lagCmd.AddCommand(lagAddInterfaceCmd)
 	lagCmd.AddCommand(lagRemoveInterfaceCmd)
+	lagCmd.AddCommand(lagSetHashPolicyCmd)
+	lagCmd.AddCommand(lagClearHashPolicyCmd)
+	lagCmd.AddCommand(lagHashDistributionCmd)
 }
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aldrin-isaac/newtron/pkg/cli"
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check the whole spec for common modeling mistakes",
+	Long: `Check every spec in the network — network, zone, and node scope — for
+modeling mistakes that per-spec validation does not catch:
+
+  error    a reference to a spec that does not exist
+  error    a reference to a prefix list that is empty
+  error    the same VNI used by two IP-VPNs / MAC-VPNs
+  warning  an IP-VPN with an L3VNI but no route targets
+
+Each issue is reported at the scope that defines the offending spec. Exits
+non-zero when any error is found; warnings alone exit zero.
+
+Examples:
+  newtron lint
+  newtron lint --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issues, err := app.client.Lint()
+		if err != nil {
+			return err
+		}
+
+		errors := 0
+		for _, is := range issues {
+			if is.Severity == "error" {
+				errors++
+			}
+		}
+
+		if app.jsonOutput {
+			if err := json.NewEncoder(os.Stdout).Encode(issues); err != nil {
+				return err
+			}
+		} else if len(issues) == 0 {
+			fmt.Println("No lint issues")
+		} else {
+			t := cli.NewTable("SEVERITY", "SCOPE", "KIND", "NAME", "MESSAGE")
+			for _, is := range issues {
+				scope := is.Scope
+				if is.ScopeInstance != "" {
+					scope += "/" + is.ScopeInstance
+				}
+				sev := yellow(is.Severity)
+				if is.Severity == "error" {
+					sev = red(is.Severity)
+				}
+				t.Row(sev, scope, is.Kind, is.Name, is.Message)
+			}
+			t.Flush()
+			fmt.Printf("\n%d error(s), %d warning(s)\n", errors, len(issues)-errors)
+		}
+
+		if errors > 0 {
+			return fmt.Errorf("lint found %d error(s)", errors)
+		}
+		return nil
+	},
+}
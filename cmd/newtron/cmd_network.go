@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/aldrin-isaac/newtron/pkg/cli"
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/api"
 )
 
@@ -168,6 +169,72 @@ Examples:
 	},
 }
 
+var (
+	mtuPolicyFabric int
+	mtuPolicyServer int
+)
+
+var networkMTUPolicyCmd = &cobra.Command{
+	Use:   "mtu-policy",
+	Short: "Apply a fabric/server MTU policy across the topology",
+	Long: `Set interface MTUs on every switch in the topology by role.
+
+Fabric interfaces (switch-to-switch links) get --fabric; server-facing
+interfaces (links to host devices) get --server. A role whose flag is omitted
+is left alone. PortChannel members are set through their PortChannel.
+
+Devices are changed one at a time; a device that fails is reported and the
+rest still run.
+
+Examples:
+  newtron network mtu-policy --fabric 9100 --server 1500
+  newtron network mtu-policy --fabric 9100 --server 1500 -x`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := app.client.ApplyMTUPolicy(newtron.MTUPolicy{
+			Fabric: mtuPolicyFabric,
+			Server: mtuPolicyServer,
+		}, execOpts())
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(result)
+		}
+
+		t := cli.NewTable("DEVICE", "INTERFACE", "MTU", "STATUS")
+		failed := 0
+		for _, dcs := range result.Devices {
+			if dcs.Error != "" {
+				failed++
+				t.Row(dcs.Device, "-", "-", red("error: "+dcs.Error))
+				continue
+			}
+			for _, ic := range dcs.Interfaces {
+				name := ic.Interface
+				if ic.Member != "" {
+					name = fmt.Sprintf("%s (via %s)", ic.Member, ic.Interface)
+				}
+				status := "unchanged"
+				switch {
+				case ic.Skipped != "":
+					status = yellow("skipped: " + ic.Skipped)
+				case ic.Changed:
+					status = green("changed")
+				}
+				t.Row(dcs.Device, name, fmt.Sprintf("%s → %s", dash(ic.From), ic.To), status)
+			}
+		}
+		t.Flush()
+
+		if failed > 0 {
+			fmt.Printf("\n%d of %d devices failed\n", failed, len(result.Devices))
+		}
+		printDryRunNotice()
+		return nil
+	},
+}
+
 // networkInfoFields references api.NetworkInfo to satisfy the import.
 // ListNetworks returns []api.NetworkInfo; fields are accessed by name in networkListCmd.
 var _ api.NetworkInfo
@@ -178,4 +245,9 @@ func init() {
 	networkCmd.AddCommand(networkReloadCmd)
 	networkCmd.AddCommand(networkTopologyCmd)
 	networkCmd.AddCommand(networkHostCmd)
+
+	networkMTUPolicyCmd.Flags().IntVar(&mtuPolicyFabric, "fabric", 0, "MTU for fabric (switch-to-switch) interfaces")
+	networkMTUPolicyCmd.Flags().IntVar(&mtuPolicyServer, "server", 0, "MTU for server-facing interfaces")
+	addWriteFlags(networkMTUPolicyCmd)
+	networkCmd.AddCommand(networkMTUPolicyCmd)
 }
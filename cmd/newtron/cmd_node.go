@@ -17,6 +17,7 @@ var (
 	nodeCreateZone        string
 	nodeCreatePlatform    string
 	nodeCreateUnderlayASN int
+	nodeCreateTransport   string
 )
 
 var nodeCmd = &cobra.Command{
@@ -95,6 +96,9 @@ var nodeShowCmd = &cobra.Command{
 		if p.UnderlayASN > 0 {
 			fmt.Printf("Underlay ASN: %d\n", p.UnderlayASN)
 		}
+		if p.Transport != "" {
+			fmt.Printf("Transport: %s\n", p.Transport)
+		}
 		if p.MAC != "" {
 			fmt.Printf("MAC: %s\n", p.MAC)
 		}
@@ -155,6 +159,7 @@ Examples:
 			Zone:        nodeCreateZone,
 			Platform:    nodeCreatePlatform,
 			UnderlayASN: nodeCreateUnderlayASN,
+			Transport:   nodeCreateTransport,
 		}, execOpts()); err != nil {
 			return err
 		}
@@ -196,6 +201,7 @@ func init() {
 	nodeCreateCmd.Flags().StringVar(&nodeCreateZone, "zone", "", "Zone name (required)")
 	nodeCreateCmd.Flags().StringVar(&nodeCreatePlatform, "platform", "", "Platform name")
 	nodeCreateCmd.Flags().IntVar(&nodeCreateUnderlayASN, "underlay-asn", 0, "BGP underlay AS number")
+	nodeCreateCmd.Flags().StringVar(&nodeCreateTransport, "transport", "", "How to reach the device's Redis: ssh-redis (default), direct-redis, gnmi")
 
 	nodeCmd.AddCommand(nodeListCmd)
 	nodeCmd.AddCommand(nodeShowCmd)
@@ -45,6 +45,11 @@ Examples:
 	},
 }
 
+var (
+	reloadForce bool
+	reloadCheck bool
+)
+
 var reloadConfigCmd = &cobra.Command{
 	Use:   "reload-config",
 	Short: "Reload CONFIG_DB from config_db.json",
@@ -54,16 +59,40 @@ This runs the SONiC config reload command on the device, which restores
 CONFIG_DB from the saved config_db.json file. Use this to recover from
 drift or to apply a previously saved configuration.
 
+The reload is refused, with the reason, when it would discard work:
+intents changed since the last save, another session holding the device
+lock, or newtron-owned CONFIG_DB entries that differ from config_db.json.
+--force reloads anyway; --check only reports whether a reload is safe.
+After reloading, waits for the device's Redis to answer again.
+
 Requires -D (device) flag.
 
 Examples:
-  newtron -D leaf1 reload-config`,
+  newtron -D leaf1 reload-config
+  newtron -D leaf1 reload-config --check
+  newtron -D leaf1 reload-config --force`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if err := requireDevice(); err != nil {
 			return err
 		}
 
-		if err := app.client.ConfigReload(app.deviceName); err != nil {
+		if reloadCheck {
+			status, err := app.client.ConfigReloadStatus(app.deviceName)
+			if err != nil {
+				return err
+			}
+			if app.jsonOutput {
+				return json.NewEncoder(os.Stdout).Encode(status)
+			}
+			if status.Safe {
+				fmt.Println("Reload is safe.")
+			} else {
+				fmt.Printf("Reload blocked: %s\n", status.Reason)
+			}
+			return nil
+		}
+
+		if err := app.client.ConfigReload(app.deviceName, reloadForce); err != nil {
 			return err
 		}
 
@@ -127,3 +156,8 @@ Examples:
 		return nil
 	},
 }
+
+func init() {
+	reloadConfigCmd.Flags().BoolVar(&reloadForce, "force", false, "Reload even if unsaved work would be discarded")
+	reloadConfigCmd.Flags().BoolVar(&reloadCheck, "check", false, "Only report whether a reload is safe, and why not")
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aldrin-isaac/newtron/pkg/cli"
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
 )
 
@@ -24,7 +26,8 @@ Requires -D (device) flag.
 Examples:
   newtron -D leaf1 route get default 10.0.0.0/24
   newtron -D leaf1 route get Vrf_CUST1 192.168.1.0/24
-  newtron -D leaf1 route get-asic 10.0.0.0/24`,
+  newtron -D leaf1 route get-asic 10.0.0.0/24
+  newtron -D leaf1 route nhg`,
 }
 
 var routeGetCmd = &cobra.Command{
@@ -97,6 +100,62 @@ Examples:
 	},
 }
 
+var routeNHGCmd = &cobra.Command{
+	Use:   "nhg",
+	Short: "List ECMP next-hop groups in ASIC_DB",
+	Long: `List the next-hop groups orchagent has programmed into the ASIC: each
+group's member next hops (with weights) and how many routes point at it.
+
+When a route's ECMP width is wrong, this is the ground truth — a group with
+fewer members than BGP paths means the missing paths never reached the ASIC.
+Platforms without ASIC_DB (VPP) report that the check was skipped.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 route nhg
+  newtron -D leaf1 route nhg --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		result, err := app.client.GetNextHopGroups(app.deviceName)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(result)
+		}
+
+		if result.Skipped != "" {
+			fmt.Printf("Next-hop groups skipped: %s\n", result.Skipped)
+			return nil
+		}
+		if len(result.Groups) == 0 {
+			fmt.Println("No next-hop groups programmed")
+			return nil
+		}
+
+		t := cli.NewTable("GROUP", "ROUTES", "WIDTH", "MEMBERS")
+		for _, g := range result.Groups {
+			var members []string
+			for _, m := range g.Members {
+				member := dash(m.Address)
+				if m.Weight > 1 {
+					member += fmt.Sprintf(" (w%d)", m.Weight)
+				}
+				members = append(members, member)
+			}
+			t.Row(g.OID, fmt.Sprintf("%d", g.RefCount), fmt.Sprintf("%d", len(g.Members)), strings.Join(members, ", "))
+		}
+		t.Flush()
+		return nil
+	},
+}
+
 // printRouteEntry formats a RouteEntry for human-readable output.
 func printRouteEntry(entry *newtron.RouteEntry) {
 	fmt.Printf("Prefix:   %s\n", bold(entry.Prefix))
@@ -120,4 +179,5 @@ func printRouteEntry(entry *newtron.RouteEntry) {
 func init() {
 	routeCmd.AddCommand(routeGetCmd)
 	routeCmd.AddCommand(routeGetAsicCmd)
+	routeCmd.AddCommand(routeNHGCmd)
 }
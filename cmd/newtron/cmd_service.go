@@ -4,7 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -212,10 +214,14 @@ var serviceShowCmd = &cobra.Command{
 }
 
 var (
-	applyIP     string
-	applyVLAN   int
-	applyParams string
-	peerAS      int
+	applyIP            string
+	applySecondaryIPs  []string
+	applyVLAN          int
+	applyParams        string
+	peerAS             int
+	applyVerify        bool
+	applyOnVerifyFail  string
+	applyVerifyTimeout time.Duration
 )
 
 var serviceApplyCmd = &cobra.Command{
@@ -235,14 +241,28 @@ Requires -D (device) flag.
 
 Options:
   --ip <addr/prefix>        IP address for routed/IRB services
+  --secondary-ip <addr/prefix>
+                            Additional address on a routed port; repeat or
+                            comma-separate for several
   --vlan <id>               VLAN ID for local bridged/IRB services
   --peer-as <asn>           BGP peer AS number (for services with routing.peer_as="request")
   --params <key=val,...>    Topology params (peer_as, route_reflector_client, next_hop_self)
+  --verify                  Wait for the service to settle (interface oper-up,
+                            its BGP session Established); requires -x
+  --on-verify-fail <mode>   leave (default) or rollback (remove the service)
+  --verify-timeout <dur>    How long to wait for it to settle (default 60s)
+
+--ip, --vlan and --peer-as may be omitted when the network's network.json
+declares service_defaults for the service; -v shows which values came from
+the defaults.
 
 Examples:
   newtron leaf1 service apply Ethernet0 customer-l3 --ip 10.1.1.1/30 -x
+  newtron leaf1 service apply Ethernet0 customer-l3 --ip 10.1.1.1/30 --secondary-ip 10.2.0.1/24 -x
   newtron leaf1 service apply Ethernet0 server-l2 --vlan 100 -x
-  newtron leaf1 service apply Ethernet0 transit --ip 192.168.1.1/31 --params peer_as=65002 -x`,
+  newtron leaf1 service apply Ethernet0 transit --ip 192.168.1.1/31 --params peer_as=65002 -x
+  newtron leaf1 service apply Ethernet0 transit --ip 192.168.1.1/31 --verify --on-verify-fail rollback -x
+  newtron -v leaf1 service apply Ethernet4 transit -x      # ip and peer AS from service_defaults`,
 	Args: cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		intfName := args[0]
@@ -276,9 +296,10 @@ Examples:
 		fmt.Println()
 
 		opts := newtron.ApplyServiceOpts{
-			IPAddress: applyIP,
-			VLAN:      applyVLAN,
-			PeerAS:    peerAS,
+			IPAddress:    applyIP,
+			SecondaryIPs: applySecondaryIPs,
+			VLAN:         applyVLAN,
+			PeerAS:       peerAS,
 		}
 		if applyParams != "" {
 			opts.Params = make(map[string]string)
@@ -290,7 +311,35 @@ Examples:
 			}
 		}
 
-		return displayWriteResult(app.client.ApplyService(app.deviceName, intfName, serviceName, opts, execOpts()))
+		if !applyVerify {
+			return displayWriteResult(app.client.ApplyService(app.deviceName, intfName, serviceName, opts, execOpts()))
+		}
+		if !app.executeMode {
+			return fmt.Errorf("--verify needs the service applied; add -x")
+		}
+		verify := newtron.ServiceVerifyOpts{Timeout: applyVerifyTimeout}
+		switch applyOnVerifyFail {
+		case "leave":
+		case "rollback":
+			verify.Rollback = true
+		default:
+			return fmt.Errorf("--on-verify-fail must be leave or rollback, got %q", applyOnVerifyFail)
+		}
+		result, err := app.client.ApplyServiceChecked(app.deviceName, intfName, serviceName, opts, verify, execOpts())
+		if err != nil {
+			return err
+		}
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(result)
+		}
+		if err := displayWriteResult(result.Apply, nil); err != nil {
+			return err
+		}
+		fmt.Printf("Service settled in %s:\n", result.Elapsed.Round(time.Second))
+		for _, c := range result.Checks {
+			fmt.Printf("  %s %s\n", green(c.Status), c.Message)
+		}
+		return nil
 	},
 }
 
@@ -315,6 +364,127 @@ Examples:
 	},
 }
 
+var serviceRolloutCmd = &cobra.Command{
+	Use:   "rollout <service> <manifest>",
+	Short: "Apply a service to many interfaces from a manifest",
+	Long: `Apply a service to every circuit listed in a rollout manifest.
+
+The manifest is CSV (.csv) with a header row, or YAML/JSON (.yaml, .yml,
+.json) as a list of objects. Columns/keys: device, interface, ip, peer_as;
+ip and peer_as may be left empty.
+
+Every row is validated before anything is changed — unknown devices, host
+devices, bad addresses and duplicate interfaces are all reported at once.
+Each device then applies all of its circuits as one change: they all commit
+or none do. A device that fails is reported and the rest still run.
+
+Does not use -D; devices come from the manifest.
+
+Example manifest (circuits.csv):
+  device,interface,ip,peer_as
+  leaf1,Ethernet8,10.1.1.1/30,65101
+  leaf2,Ethernet8,10.1.2.1/30,65102
+
+Examples:
+  newtron service rollout customer-l3 circuits.csv
+  newtron service rollout customer-l3 circuits.csv -x`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		serviceName, path := args[0], args[1]
+		format := "yaml"
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = "csv"
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rows, err := newtron.ParseRolloutManifest(data, format)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		result, err := app.client.RolloutService(newtron.RolloutSpec{Service: serviceName, Rows: rows}, execOpts())
+		if err != nil {
+			return err
+		}
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(result)
+		}
+
+		perDevice := map[string]int{}
+		for _, row := range rows {
+			perDevice[row.Device]++
+		}
+		t := cli.NewTable("DEVICE", "INTERFACE", "SERVICE", "STATUS")
+		applied, failedDevices, failedCircuits := 0, 0, 0
+		for _, dcs := range result.Devices {
+			if dcs.Error != "" {
+				failedDevices++
+				failedCircuits += perDevice[dcs.Device]
+				t.Row(dcs.Device, "-", "-", red("error: "+dcs.Error))
+				continue
+			}
+			for _, ic := range dcs.Interfaces {
+				applied++
+				t.Row(dcs.Device, ic.Interface, ic.To, green("applied"))
+			}
+		}
+		t.Flush()
+
+		fmt.Printf("\n%d circuits on %d devices: %d applied", len(rows), len(result.Devices), applied)
+		if failedDevices > 0 {
+			fmt.Printf(", %d not applied (%d devices failed)", failedCircuits, failedDevices)
+		}
+		fmt.Println()
+		printDryRunNotice()
+		return nil
+	},
+}
+
+var serviceBindingsCmd = &cobra.Command{
+	Use:   "bindings",
+	Short: "List the services applied on a device",
+	Long: `List every service applied on a device, one row per interface.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny service bindings`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		bindings, err := app.client.ListServiceBindings(app.deviceName)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(bindings)
+		}
+
+		if len(bindings) == 0 {
+			fmt.Println("No services applied")
+			return nil
+		}
+
+		t := cli.NewTable("INTERFACE", "SERVICE", "IP", "VRF", "VLAN", "ACL IN/OUT", "QOS")
+		for _, b := range bindings {
+			ips := strings.Join(append([]string{b.IPAddress}, b.SecondaryIPs...), ",")
+			acls := ""
+			if b.IngressACL != "" || b.EgressACL != "" {
+				acls = dash(b.IngressACL) + "/" + dash(b.EgressACL)
+			}
+			t.Row(b.Interface, b.Service, dash(strings.Trim(ips, ",")), dash(b.VRF), dashInt(b.VLAN), dash(acls), dash(b.QoSPolicy))
+		}
+		t.Flush()
+		return nil
+	},
+}
+
 var serviceGetCmd = &cobra.Command{
 	Use:   "get <interface>",
 	Short: "Get the service bound to an interface",
@@ -499,9 +669,13 @@ Examples:
 
 func init() {
 	serviceApplyCmd.Flags().StringVar(&applyIP, "ip", "", "IP address for L3 service (CIDR notation)")
+	serviceApplyCmd.Flags().StringSliceVar(&applySecondaryIPs, "secondary-ip", nil, "Secondary IP address for a routed service (CIDR notation, repeatable)")
 	serviceApplyCmd.Flags().IntVar(&applyVLAN, "vlan", 0, "VLAN ID for local bridged/IRB services")
 	serviceApplyCmd.Flags().IntVar(&peerAS, "peer-as", 0, "BGP peer AS number")
 	serviceApplyCmd.Flags().StringVar(&applyParams, "params", "", "Topology params as key=value pairs (comma-separated)")
+	serviceApplyCmd.Flags().BoolVar(&applyVerify, "verify", false, "Wait for the service to settle (interface oper-up, BGP session Established)")
+	serviceApplyCmd.Flags().StringVar(&applyOnVerifyFail, "on-verify-fail", "leave", "When --verify times out: leave or rollback")
+	serviceApplyCmd.Flags().DurationVar(&applyVerifyTimeout, "verify-timeout", 60*time.Second, "How long --verify waits for the service to settle")
 
 	serviceCreateCmd.Flags().StringVar(&svcCreateType, "type", "", "Service type (evpn-irb, evpn-bridged, evpn-routed, irb, bridged, routed)")
 	serviceCreateCmd.Flags().StringVar(&svcCreateIPVPN, "ipvpn", "", "IP-VPN reference name")
@@ -515,7 +689,9 @@ func init() {
 	serviceCmd.AddCommand(serviceListCmd)
 	serviceCmd.AddCommand(serviceShowCmd)
 	serviceCmd.AddCommand(serviceGetCmd)
+	serviceCmd.AddCommand(serviceBindingsCmd)
 	serviceCmd.AddCommand(serviceApplyCmd)
+	serviceCmd.AddCommand(serviceRolloutCmd)
 	serviceCmd.AddCommand(serviceRemoveCmd)
 	serviceCmd.AddCommand(serviceRefreshCmd)
 	serviceCmd.AddCommand(serviceCreateCmd)
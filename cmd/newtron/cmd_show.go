@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/aldrin-isaac/newtron/pkg/cli"
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
 )
 
@@ -35,6 +38,127 @@ Examples:
 	},
 }
 
+// showRunningConfigCmd prints one section of the device's running config.
+var showRunningConfigCmd = &cobra.Command{
+	Use:   "running-config [section]",
+	Short: "Show the device's running config, or one section of it",
+	Long: `Show a focused section of the device's running config as text.
+
+Sections:
+  frr          FRR's whole running config (vtysh show running-config)
+  bgp          just the 'router bgp' stanzas, every VRF
+  route-map    just the route-map stanzas
+  prefix-list  just the ip/ipv6 prefix-list lines
+  <table>      one CONFIG_DB table as a config_db.json fragment
+               (case-insensitive: port, vlan_member, bgp_neighbor, ...)
+
+With no section, prints the whole CONFIG_DB followed by the FRR running
+config. FRR sections need SSH access to the device.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 show running-config bgp
+  newtron -D leaf1 show running-config port
+  newtron -D leaf1 show running-config`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		section := ""
+		if len(args) == 1 {
+			section = args[0]
+		}
+
+		text, err := app.client.RunningConfig(app.deviceName, section)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(map[string]string{"section": section, "config": text})
+		}
+		fmt.Print(text)
+		return nil
+	},
+}
+
+// showBGPConvergenceCmd prints the one-glance BGP convergence summary.
+var showBGPConvergenceCmd = &cobra.Command{
+	Use:   "bgp-convergence",
+	Short: "Show how many of the device's BGP peers are up, per VRF",
+	Long: `Show a one-glance summary of the device's BGP sessions: of the peers
+its intents expect, how many are Established, how many sit in each other
+state, and whether initial convergence (every expected peer up) is reached.
+One row per VRF, then the total. A peer FRR does not list at all is counted
+as NotFound.
+
+For the per-neighbor detail, use "bgp check".
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1 show bgp-convergence
+  newtron -D leaf1 show bgp-convergence --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		conv, err := app.client.GetBGPConvergenceState(app.deviceName)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(conv)
+		}
+
+		if conv.Total == 0 {
+			fmt.Println("No BGP neighbors configured")
+			return nil
+		}
+
+		t := cli.NewTable("VRF", "ESTABLISHED", "OTHER STATES", "CONVERGED")
+		for _, v := range conv.VRFs {
+			t.Row(v.VRF, fmt.Sprintf("%d/%d", v.Established, v.Total), formatStateCounts(v.States), convergedLabel(v.Converged))
+		}
+		t.Row("total", fmt.Sprintf("%d/%d", conv.Established, conv.Total), formatStateCounts(conv.States), convergedLabel(conv.Converged))
+		t.Flush()
+		return nil
+	},
+}
+
+// formatStateCounts renders BGP state counts as "Active 2, Connect 1".
+func formatStateCounts(states map[string]int) string {
+	if len(states) == 0 {
+		return "-"
+	}
+	names := make([]string, 0, len(states))
+	for s := range states {
+		names = append(names, s)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, s := range names {
+		parts[i] = fmt.Sprintf("%s %d", s, states[s])
+	}
+	return strings.Join(parts, ", ")
+}
+
+func convergedLabel(converged bool) string {
+	if converged {
+		return green("yes")
+	}
+	return red("no")
+}
+
+func init() {
+	showCmd.AddCommand(showRunningConfigCmd)
+	showCmd.AddCommand(showBGPConvergenceCmd)
+}
+
 func showDevice(info *newtron.DeviceInfo) error {
 	if app.jsonOutput {
 		return json.NewEncoder(os.Stdout).Encode(info)
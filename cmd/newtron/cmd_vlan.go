@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -24,7 +26,10 @@ Requires -D (device) flag.
 Examples:
   newtron -D leaf1-ny vlan list
   newtron -D leaf1-ny vlan show 100
+  newtron -D leaf1-ny vlan membership
   newtron -D leaf1-ny vlan create 100
+  newtron -D leaf1-ny vlan configure-dhcp-server 100 --range 10.1.100.50-10.1.100.99 -x
+  newtron -D leaf1-ny vlan enable-igmp-snooping 100 -x
   newtron -D leaf1-ny vlan status`,
 }
 
@@ -133,6 +138,59 @@ Examples:
 	},
 }
 
+var vlanMembershipCmd = &cobra.Command{
+	Use:   "membership",
+	Short: "Show which ports are in each VLAN and how",
+	Long: `Show every VLAN with its tagged and untagged members and SVI.
+
+Read from the device's CONFIG_DB (VLAN, VLAN_MEMBER, VLAN_INTERFACE) rather
+than from intents, so members added out of band are listed too — the
+equivalent of "show vlan brief".
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny vlan membership
+  newtron -D leaf1-ny vlan membership --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		vlans, err := app.client.VLANMembership(app.deviceName)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(vlans)
+		}
+
+		if len(vlans) == 0 {
+			fmt.Println("No VLANs configured")
+			return nil
+		}
+
+		t := cli.NewTable("VLAN ID", "NAME", "UNTAGGED", "TAGGED", "SVI", "VRF")
+
+		for _, v := range vlans {
+			svi := "-"
+			if v.SVI {
+				svi = green("yes")
+				if len(v.SVIAddresses) > 0 {
+					svi = green(strings.Join(v.SVIAddresses, ","))
+				}
+			}
+			t.Row(fmt.Sprintf("%d", v.ID), dash(v.Description),
+				dash(strings.Join(v.Untagged, ",")), dash(strings.Join(v.Tagged, ",")),
+				svi, dash(v.VRF))
+		}
+		t.Flush()
+
+		return nil
+	},
+}
+
 var vlanStatusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show all VLANs with operational state",
@@ -233,9 +291,10 @@ Examples:
 }
 
 var (
-	sviVRF       string
-	sviIP        string
-	sviAnycastGW string
+	sviVRF            string
+	sviIP             string
+	sviAnycastGW      string
+	sviAnycastPerVLAN bool
 )
 
 var vlanConfigureIRBCmd = &cobra.Command{
@@ -248,14 +307,21 @@ and optionally sets up SAG (Static Anycast Gateway) for anycast MAC.
 
 Requires -D (device) flag.
 
+The anycast gateway MAC must be unicast. By default it is the device-wide
+SAG_GLOBAL value, shared by every anycast IRB — a different MAC than the one
+already configured is refused. --anycast-per-vlan writes it to the VLAN's
+own SAG entry instead, so IRBs can use distinct MACs.
+
 Options:
   --vrf <name>         VRF to bind the IRB to
   --ip <addr/prefix>   IP address with prefix length
   --anycast-gw <mac>   Anycast gateway MAC address (SAG)
+  --anycast-per-vlan   Configure the anycast MAC for this VLAN only
 
 Examples:
   newtron -D leaf1-ny vlan configure-irb 100 --vrf Vrf_CUST1 --ip 10.1.100.1/24 -x
-  newtron -D leaf1-ny vlan configure-irb 100 --ip 10.1.100.1/24 --anycast-gw 00:00:00:00:01:01 -x`,
+  newtron -D leaf1-ny vlan configure-irb 100 --ip 10.1.100.1/24 --anycast-gw 00:00:00:00:01:01 -x
+  newtron -D leaf1-ny vlan configure-irb 200 --ip 10.1.200.1/24 --anycast-gw 00:00:00:00:02:02 --anycast-per-vlan -x`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vlanID, err := parseVLANID(args[0])
@@ -266,10 +332,11 @@ Examples:
 			return err
 		}
 		return displayWriteResult(app.client.ConfigureIRB(app.deviceName, newtron.IRBConfigureRequest{
-			VlanID:     vlanID,
-			VRF:        sviVRF,
-			IPAddress:  sviIP,
-			AnycastMAC: sviAnycastGW,
+			VlanID:            vlanID,
+			VRF:               sviVRF,
+			IPAddress:         sviIP,
+			AnycastMAC:        sviAnycastGW,
+			AnycastMACPerVLAN: sviAnycastPerVLAN,
 		}, execOpts()))
 	},
 }
@@ -284,10 +351,11 @@ Two fields are updatable: the gateway IP (delivered as a keyed sub-entry
 move) and the anycast gateway MAC (a SAG field edit, refused while other
 anycast IRBs share the device-wide value). A VRF move is refused — rebinding
 an SVI re-originates its routes, which is a teardown: use unconfigure-irb
-then configure-irb.
+then configure-irb. Moving the anycast MAC between SAG_GLOBAL and a
+per-VLAN SAG entry is refused the same way.
 
-Pass the full desired identity: the same VRF it has, plus the new IP and/or
-anycast MAC.
+Pass the full desired identity: the same VRF and --anycast-per-vlan setting
+it has, plus the new IP and/or anycast MAC.
 
 Requires -D (device) flag.
 
@@ -304,10 +372,11 @@ Examples:
 			return err
 		}
 		return displayWriteResult(app.client.UpdateIRB(app.deviceName, newtron.IRBConfigureRequest{
-			VlanID:     vlanID,
-			VRF:        sviVRF,
-			IPAddress:  sviIP,
-			AnycastMAC: sviAnycastGW,
+			VlanID:            vlanID,
+			VRF:               sviVRF,
+			IPAddress:         sviIP,
+			AnycastMAC:        sviAnycastGW,
+			AnycastMACPerVLAN: sviAnycastPerVLAN,
 		}, execOpts()))
 	},
 }
@@ -405,6 +474,252 @@ Examples:
 	},
 }
 
+var (
+	dhcpRanges    []string
+	dhcpPorts     []string
+	dhcpGateway   string
+	dhcpLeaseTime int
+	dhcpOptions   []string
+)
+
+var vlanConfigureDHCPServerCmd = &cobra.Command{
+	Use:   "configure-dhcp-server <vlan-id>",
+	Short: "Serve a VLAN from the built-in DHCP server",
+	Long: `Serve a VLAN's member ports from SONiC's built-in DHCP server.
+
+Writes DHCP_SERVER_IPV4 (gateway, netmask, lease time), one
+DHCP_SERVER_IPV4_RANGE row per range, and one DHCP_SERVER_IPV4_PORT row per
+served port. The VLAN must have an IRB address (vlan configure-irb): every
+range must fall inside its subnet, clear of the IRB and gateway addresses,
+and ranges must not overlap. Configuring again replaces the settings.
+
+The image must have the dhcp_server feature enabled.
+
+Requires -D (device) flag.
+
+Options:
+  --range <first-last>    Address range to lease (repeatable; a single address is allowed)
+  --port <name>           Member port to serve (repeatable; default: every current member)
+  --gateway <ip>          Router option (default: the IRB address)
+  --lease-time <secs>     Lease time in seconds (default: 900)
+  --option <code=value>   Custom DHCP option sent as text (repeatable)
+
+Examples:
+  newtron -D leaf1-ny vlan configure-dhcp-server 100 --range 10.1.100.50-10.1.100.99 -x
+  newtron -D leaf1-ny vlan configure-dhcp-server 100 --range 10.1.100.50-10.1.100.99 \
+      --port Ethernet4 --lease-time 3600 --option 66=tftp.example -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vlanID, err := parseVLANID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		if len(dhcpRanges) == 0 {
+			return fmt.Errorf("at least one --range is required")
+		}
+		var options map[int]string
+		for _, opt := range dhcpOptions {
+			code, value, ok := strings.Cut(opt, "=")
+			c, err := strconv.Atoi(code)
+			if !ok || err != nil {
+				return fmt.Errorf("--option %q: expected <code>=<value>", opt)
+			}
+			if options == nil {
+				options = map[int]string{}
+			}
+			options[c] = value
+		}
+		return displayWriteResult(app.client.ConfigureDHCPServer(app.deviceName, api.DHCPServerRequest{
+			VlanID:    vlanID,
+			Ranges:    dhcpRanges,
+			Ports:     dhcpPorts,
+			Gateway:   dhcpGateway,
+			LeaseTime: dhcpLeaseTime,
+			Options:   options,
+		}, execOpts()))
+	},
+}
+
+var vlanUnconfigureDHCPServerCmd = &cobra.Command{
+	Use:   "unconfigure-dhcp-server <vlan-id>",
+	Short: "Stop serving a VLAN from the built-in DHCP server",
+	Long: `Stop serving a VLAN from the built-in DHCP server.
+
+Removes the DHCP_SERVER_IPV4, range, option, and port rows written by
+'vlan configure-dhcp-server'. Leases already granted run out on their own.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny vlan unconfigure-dhcp-server 100 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vlanID, err := parseVLANID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.UnconfigureDHCPServer(app.deviceName, vlanID, execOpts()))
+	},
+}
+
+var vlanDHCPLeasesCmd = &cobra.Command{
+	Use:   "dhcp-leases [vlan-id]",
+	Short: "Show leases granted by the built-in DHCP server",
+	Long: `Show the leases the built-in DHCP server has granted, read from
+STATE_DB DHCP_SERVER_IPV4_LEASE — on one VLAN, or on every VLAN.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny vlan dhcp-leases
+  newtron -D leaf1-ny vlan dhcp-leases 100 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vlanID := 0
+		if len(args) == 1 {
+			id, err := parseVLANID(args[0])
+			if err != nil {
+				return err
+			}
+			vlanID = id
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		leases, err := app.client.DHCPLeases(app.deviceName, vlanID)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(leases)
+		}
+
+		if len(leases) == 0 {
+			fmt.Println("No DHCP leases")
+			return nil
+		}
+
+		t := cli.NewTable("VLAN", "MAC", "IP", "EXPIRES")
+		for _, l := range leases {
+			expires := "-"
+			if !l.End.IsZero() {
+				expires = l.End.Local().Format(time.RFC1123)
+			}
+			t.Row(l.VLAN, l.MAC, l.IP, expires)
+		}
+		t.Flush()
+
+		return nil
+	},
+}
+
+var vlanEnableIGMPSnoopingCmd = &cobra.Command{
+	Use:   "enable-igmp-snooping <vlan-id>",
+	Short: "Turn on IGMP snooping for a VLAN",
+	Long: `Turn on IGMP snooping for a VLAN.
+
+Writes CFG_L2MC_TABLE|Vlan<N> (enabled, IGMPv2). The switch then forwards
+each multicast group only to the ports whose hosts joined it, instead of
+flooding the VLAN. The platform must support igmp-snooping.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny vlan enable-igmp-snooping 100 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vlanID, err := parseVLANID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.SetIGMPSnooping(app.deviceName, vlanID, true, execOpts()))
+	},
+}
+
+var vlanDisableIGMPSnoopingCmd = &cobra.Command{
+	Use:   "disable-igmp-snooping <vlan-id>",
+	Short: "Turn off IGMP snooping for a VLAN",
+	Long: `Turn off IGMP snooping for a VLAN, removing the CFG_L2MC_TABLE row
+written by 'vlan enable-igmp-snooping'. Multicast floods the VLAN again.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny vlan disable-igmp-snooping 100 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vlanID, err := parseVLANID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.DisableIGMPSnooping(app.deviceName, vlanID, execOpts()))
+	},
+}
+
+var vlanIGMPSnoopingCmd = &cobra.Command{
+	Use:   "igmp-snooping <vlan-id>",
+	Short: "Show a VLAN's IGMP snooping state and learned groups",
+	Long: `Show whether IGMP snooping is intended and configured on a VLAN, and
+the multicast groups the device has learned there (STATE_DB
+L2MC_MEMBER_TABLE).
+
+Requires -D (device) flag.
+
+Examples:
+  newtron -D leaf1-ny vlan igmp-snooping 100
+  newtron -D leaf1-ny vlan igmp-snooping 100 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vlanID, err := parseVLANID(args[0])
+		if err != nil {
+			return err
+		}
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		st, err := app.client.IGMPSnooping(app.deviceName, vlanID)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(st)
+		}
+
+		if !st.Supported {
+			fmt.Printf("%s: platform does not support IGMP snooping\n", st.VLAN)
+			return nil
+		}
+		fmt.Printf("%s: intended %v, configured %v\n", st.VLAN, st.Intended, st.Configured)
+		if len(st.Groups) == 0 {
+			fmt.Println("No multicast groups learned")
+			return nil
+		}
+		t := cli.NewTable("GROUP", "SOURCE", "PORTS")
+		for _, g := range st.Groups {
+			t.Row(g.Group, g.Source, strings.Join(g.Ports, ","))
+		}
+		t.Flush()
+
+		return nil
+	},
+}
+
 func init() {
 	vlanCreateCmd.Flags().StringVar(&vlanDescription, "description", "", "VLAN description")
 	vlanCreateCmd.Flags().IntVar(&vlanL2VNI, "l2-vni", 0, "Map the VLAN to this L2VNI at creation (the create-vlan operation's vni param; bind-macvpn is the spec-driven path)")
@@ -412,18 +727,33 @@ func init() {
 	vlanConfigureIRBCmd.Flags().StringVar(&sviVRF, "vrf", "", "VRF to bind the IRB to")
 	vlanConfigureIRBCmd.Flags().StringVar(&sviIP, "ip", "", "IP address with prefix (e.g., 10.1.100.1/24)")
 	vlanConfigureIRBCmd.Flags().StringVar(&sviAnycastGW, "anycast-gw", "", "Anycast gateway MAC (SAG)")
+	vlanConfigureIRBCmd.Flags().BoolVar(&sviAnycastPerVLAN, "anycast-per-vlan", false, "Configure the anycast MAC for this VLAN only (SAG) instead of device-wide (SAG_GLOBAL)")
 	vlanUpdateIRBCmd.Flags().StringVar(&sviVRF, "vrf", "", "The IRB's current VRF (VRF moves are refused)")
 	vlanUpdateIRBCmd.Flags().StringVar(&sviIP, "ip", "", "New gateway IP with prefix (e.g., 10.1.100.254/24)")
 	vlanUpdateIRBCmd.Flags().StringVar(&sviAnycastGW, "anycast-gw", "", "New anycast gateway MAC (SAG)")
+	vlanUpdateIRBCmd.Flags().BoolVar(&sviAnycastPerVLAN, "anycast-per-vlan", false, "The IRB's anycast MAC is per VLAN (scope changes are refused)")
+
+	vlanConfigureDHCPServerCmd.Flags().StringArrayVar(&dhcpRanges, "range", nil, "Address range first-last to lease (repeatable)")
+	vlanConfigureDHCPServerCmd.Flags().StringArrayVar(&dhcpPorts, "port", nil, "Member port to serve (repeatable; default: every current member)")
+	vlanConfigureDHCPServerCmd.Flags().StringVar(&dhcpGateway, "gateway", "", "Router option (default: the IRB address)")
+	vlanConfigureDHCPServerCmd.Flags().IntVar(&dhcpLeaseTime, "lease-time", 0, "Lease time in seconds (default: 900)")
+	vlanConfigureDHCPServerCmd.Flags().StringArrayVar(&dhcpOptions, "option", nil, "Custom DHCP option <code>=<value>, sent as text (repeatable)")
 
 	vlanCmd.AddCommand(vlanListCmd)
 	vlanCmd.AddCommand(vlanShowCmd)
+	vlanCmd.AddCommand(vlanMembershipCmd)
 	vlanCmd.AddCommand(vlanStatusCmd)
 	vlanCmd.AddCommand(vlanCreateCmd)
 	vlanCmd.AddCommand(vlanDeleteCmd)
 	vlanCmd.AddCommand(vlanConfigureIRBCmd)
 	vlanCmd.AddCommand(vlanUpdateIRBCmd)
 	vlanCmd.AddCommand(vlanUnconfigureIRBCmd)
+	vlanCmd.AddCommand(vlanConfigureDHCPServerCmd)
+	vlanCmd.AddCommand(vlanUnconfigureDHCPServerCmd)
+	vlanCmd.AddCommand(vlanDHCPLeasesCmd)
+	vlanCmd.AddCommand(vlanEnableIGMPSnoopingCmd)
+	vlanCmd.AddCommand(vlanDisableIGMPSnoopingCmd)
+	vlanCmd.AddCommand(vlanIGMPSnoopingCmd)
 	vlanCmd.AddCommand(vlanBindMacvpnCmd)
 	vlanCmd.AddCommand(vlanUnbindMacvpnCmd)
 }
@@ -196,12 +196,16 @@ var vrfDeleteCmd = &cobra.Command{
 	Short: "Delete a VRF",
 	Long: `Delete a VRF from the device.
 
-The VRF must have no interfaces bound before it can be deleted.
+Refused while anything still depends on the VRF — a bound interface or
+service, a static route, a route leak, an IP-VPN binding. Remove those first.
+--force deletes past interfaces bound with no intent (listed as set-vrf),
+unbinding them; it does not override any other dependent.
 
 Requires -D (device) flag.
 
 Examples:
-  newtron leaf1 vrf delete Vrf_CUST1 -x`,
+  newtron leaf1 vrf delete Vrf_CUST1 -x
+  newtron leaf1 vrf delete Vrf_CUST1 --force -x`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vrfName := args[0]
@@ -336,6 +340,8 @@ Examples:
 var (
 	vrfNeighborIP          string
 	vrfNeighborDescription string
+	vrfNeighborMaxPrefix   int
+	vrfNeighborMaxAction   string
 )
 
 var vrfAddNeighborCmd = &cobra.Command{
@@ -349,7 +355,8 @@ Requires -D (device) flag.
 
 Examples:
   newtron leaf1 vrf add-neighbor Vrf_CUST1 Ethernet4 65200 -x
-  newtron leaf1 vrf add-neighbor Vrf_CUST1 Ethernet4 65200 --neighbor 10.1.1.2 --description "customer-a" -x`,
+  newtron leaf1 vrf add-neighbor Vrf_CUST1 Ethernet4 65200 --neighbor 10.1.1.2 --description "customer-a" -x
+  newtron leaf1 vrf add-neighbor Vrf_CUST1 Ethernet4 65200 --max-prefix 1000 --max-prefix-action restart -x`,
 	Args: cobra.ExactArgs(3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vrfName := args[0]
@@ -368,6 +375,9 @@ Examples:
 			NeighborIP:  vrfNeighborIP,
 			RemoteAS:    asn,
 			Description: vrfNeighborDescription,
+
+			MaxPrefix:       vrfNeighborMaxPrefix,
+			MaxPrefixAction: vrfNeighborMaxAction,
 		}, execOpts()))
 	},
 }
@@ -376,7 +386,7 @@ var vrfUpdateNeighborCmd = &cobra.Command{
 	Use:   "update-neighbor <vrf-name> <interface> <remote-asn>",
 	Short: "Atomically update a BGP neighbor's fields on a VRF interface",
 	Long: `Atomically update a BGP neighbor's fields (remote AS, description,
-multihop) on a VRF interface. The composite key (vrf + neighbor IP)
+multihop, max prefix) on a VRF interface. The composite key (vrf + neighbor IP)
 identifies the row; this verb mutates fields only.
 
 To change the BGP destination IP, use remove-neighbor + add-neighbor —
@@ -405,6 +415,9 @@ Examples:
 			NeighborIP:  vrfNeighborIP,
 			RemoteAS:    asn,
 			Description: vrfNeighborDescription,
+
+			MaxPrefix:       vrfNeighborMaxPrefix,
+			MaxPrefixAction: vrfNeighborMaxAction,
 		}, execOpts()))
 	},
 }
@@ -444,37 +457,58 @@ Examples:
 	},
 }
 
-var vrfRouteMetric int
+var (
+	vrfRouteMetric     int
+	vrfRouteInterfaces []string
+	vrfRouteBlackhole  bool
+)
 
 var vrfAddRouteCmd = &cobra.Command{
-	Use:   "add-route <vrf-name> <prefix> <next-hop>",
+	Use:   "add-route <vrf-name> <prefix> [next-hop[,next-hop...]]",
 	Short: "Add a static route to a VRF",
 	Long: `Add a static route to a VRF routing table.
 
+Several comma-separated next-hops install an ECMP route. --interface sends
+the route out an interface — alone, or paired by position with the
+next-hops. --blackhole discards traffic to the prefix and takes neither.
+
 Requires -D (device) flag.
 
 Examples:
   newtron leaf1 vrf add-route Vrf_CUST1 10.0.0.0/8 10.1.1.1 -x
-  newtron leaf1 vrf add-route Vrf_CUST1 0.0.0.0/0 10.1.1.1 --metric 100 -x`,
-	Args: cobra.ExactArgs(3),
+  newtron leaf1 vrf add-route Vrf_CUST1 0.0.0.0/0 10.1.1.1 --metric 100 -x
+  newtron leaf1 vrf add-route Vrf_CUST1 0.0.0.0/0 10.1.1.1,10.1.2.1 -x
+  newtron leaf1 vrf add-route Vrf_CUST1 10.5.0.0/16 --interface Ethernet8 -x
+  newtron leaf1 vrf add-route Vrf_CUST1 192.0.2.0/24 --blackhole -x`,
+	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vrfName := args[0]
 		prefix := args[1]
-		nextHop := args[2]
+		config := newtron.StaticRouteConfig{
+			Interfaces: vrfRouteInterfaces,
+			Blackhole:  vrfRouteBlackhole,
+			Distance:   vrfRouteMetric,
+		}
+		if len(args) == 3 {
+			config.NextHops = strings.Split(args[2], ",")
+		}
 
 		if err := requireDevice(); err != nil {
 			return err
 		}
-		return displayWriteResult(app.client.AddStaticRoute(app.deviceName, vrfName, prefix, nextHop, vrfRouteMetric, execOpts()))
+		return displayWriteResult(app.client.AddStaticRouteMulti(app.deviceName, vrfName, prefix, config, execOpts()))
 	},
 }
 
 var vrfUpdateRouteCmd = &cobra.Command{
-	Use:   "update-route <vrf-name> <prefix> <next-hop>",
+	Use:   "update-route <vrf-name> <prefix> [next-hop[,next-hop...]]",
 	Short: "Atomically update a static route's fields",
-	Long: `Atomically update a static route's fields (nexthop, metric).
-The composite key (vrf + prefix) identifies the row; this verb mutates
-fields only.
+	Long: `Atomically replace a static route's paths and metric. The paths take
+the same forms as add-route — comma-separated next-hops for ECMP,
+--interface, --blackhole — and replace the route's paths whole, so an ECMP
+route updated with one next-hop becomes a single-path route. The
+composite key (vrf + prefix) identifies the row; this verb mutates fields
+only.
 
 To change the prefix, use remove-route + add-route — changing the
 prefix changes the row's identity at the CONFIG_DB layer (§47).
@@ -485,16 +519,25 @@ Requires -D (device) flag.
 
 Examples:
   newtron leaf1 vrf update-route Vrf_CUST1 10.0.0.0/8 10.1.1.2 -x
-  newtron leaf1 vrf update-route Vrf_CUST1 10.0.0.0/8 10.1.1.1 --metric 100 -x`,
-	Args: cobra.ExactArgs(3),
+  newtron leaf1 vrf update-route Vrf_CUST1 10.0.0.0/8 10.1.1.1 --metric 100 -x
+  newtron leaf1 vrf update-route Vrf_CUST1 0.0.0.0/0 10.1.1.1,10.1.2.1 -x
+  newtron leaf1 vrf update-route Vrf_CUST1 192.0.2.0/24 --blackhole -x`,
+	Args: cobra.RangeArgs(2, 3),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		vrfName := args[0]
 		prefix := args[1]
-		nextHop := args[2]
+		config := newtron.StaticRouteConfig{
+			Interfaces: vrfRouteInterfaces,
+			Blackhole:  vrfRouteBlackhole,
+			Distance:   vrfRouteMetric,
+		}
+		if len(args) == 3 {
+			config.NextHops = strings.Split(args[2], ",")
+		}
 		if err := requireDevice(); err != nil {
 			return err
 		}
-		return displayWriteResult(app.client.UpdateStaticRoute(app.deviceName, vrfName, prefix, nextHop, vrfRouteMetric, execOpts()))
+		return displayWriteResult(app.client.UpdateStaticRoute(app.deviceName, vrfName, prefix, config, execOpts()))
 	},
 }
 
@@ -519,6 +562,200 @@ Examples:
 	},
 }
 
+var vrfLeakPrefixes []string
+
+var vrfAddRouteLeakCmd = &cobra.Command{
+	Use:   "add-route-leak <src-vrf> <dst-vrf>",
+	Short: "Leak routes from one VRF into another",
+	Long: `Leak routes from one VRF into another — the shared-services pattern,
+where tenant VRFs import a services VRF's routes. The destination VRF
+imports the source VRF's IPv4 routes over BGP ('import vrf'); with
+--prefix, an import route-map limits the leak to exactly those prefixes.
+
+The destination VRF must be bound to an IP-VPN (it needs a BGP instance).
+The source VRF may be "default". A VRF imports from one source at a time.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron leaf1 vrf add-route-leak Vrf_SHARED Vrf_CUST1 -x
+  newtron leaf1 vrf add-route-leak Vrf_SHARED Vrf_CUST1 --prefix 10.50.0.0/24 --prefix 10.51.0.0/24 -x`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.AddRouteLeak(app.deviceName, args[0], args[1], vrfLeakPrefixes, execOpts()))
+	},
+}
+
+var vrfRemoveRouteLeakCmd = &cobra.Command{
+	Use:   "remove-route-leak <dst-vrf>",
+	Short: "Remove the route leak into a VRF",
+	Long: `Remove the route leak into a VRF, including its import route-map.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron leaf1 vrf remove-route-leak Vrf_CUST1 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.RemoveRouteLeak(app.deviceName, args[0], execOpts()))
+	},
+}
+
+var vrfRouteLeaksCmd = &cobra.Command{
+	Use:   "route-leaks",
+	Short: "List inter-VRF route leaks",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		leaks, err := app.client.RouteLeaks(app.deviceName)
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(leaks)
+		}
+
+		if len(leaks) == 0 {
+			fmt.Println("No route leaks configured")
+			return nil
+		}
+
+		t := cli.NewTable("SOURCE", "DESTINATION", "PREFIXES", "ROUTE-MAP")
+		for _, l := range leaks {
+			prefixes := "(all)"
+			if len(l.Prefixes) > 0 {
+				prefixes = strings.Join(l.Prefixes, ",")
+			}
+			t.Row(l.SrcVRF, l.DstVRF, prefixes, dash(l.RouteMap))
+		}
+		t.Flush()
+
+		return nil
+	},
+}
+
+var vrfRouteLeakHealthCmd = &cobra.Command{
+	Use:   "route-leak-health <dst-vrf>",
+	Short: "Check that a route leak actually imports",
+	Long: `Check the route leak into a VRF against the routes that arrived there.
+Leaked routes are read from zebra: installed routes in the VRF whose next
+hops resolve in the leak's source VRF. The leak is healthy when it imports
+at least one route and every prefix it is limited to. A leak that is
+configured but imports nothing (for example, the source VRF has no BGP
+table) otherwise fails silently.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron leaf1 vrf route-leak-health Vrf_CUST1`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+
+		h, err := app.client.RouteLeakHealth(app.deviceName, args[0])
+		if err != nil {
+			return err
+		}
+
+		if app.jsonOutput {
+			return json.NewEncoder(os.Stdout).Encode(h)
+		}
+
+		status := green("healthy")
+		if !h.Healthy {
+			status = red("unhealthy")
+		}
+		fmt.Printf("Route leak %s → %s: %s (%d leaked, %d missing)\n",
+			h.SrcVRF, h.DstVRF, status, h.LeakedCount, h.MissingCount)
+		if len(h.Missing) > 0 {
+			fmt.Printf("Missing: %s\n", strings.Join(h.Missing, ", "))
+		}
+		if len(h.Leaked) == 0 {
+			return nil
+		}
+
+		fmt.Println()
+		t := cli.NewTable("PREFIX", "PROTOCOL", "NEXT HOPS")
+		for _, r := range h.Leaked {
+			var hops []string
+			for _, nh := range r.NextHops {
+				hop := nh.Interface
+				if nh.Address != "" {
+					hop = nh.Address + " " + nh.Interface
+				}
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+			t.Row(r.Prefix, r.Protocol, dash(strings.Join(hops, ", ")))
+		}
+		t.Flush()
+
+		return nil
+	},
+}
+
+var (
+	vrfImportRTs []string
+	vrfExportRTs []string
+)
+
+var vrfSetRouteTargetsCmd = &cobra.Command{
+	Use:   "set-route-targets <vrf-name>",
+	Short: "Set a VRF's EVPN route targets",
+	Long: `Set the EVPN import and export route targets of an existing VRF in
+place, without re-applying the service or re-binding the IP-VPN. The VRF's
+route targets become exactly the given lists: an RT given to both --import
+and --export is written as "both", and RTs on the VRF that are in neither
+list are removed. Route targets are ASN:NN or IPv4:NN.
+
+The VRF must be bound to an IP-VPN (it needs a BGP instance). While route
+targets are set, the IP-VPN cannot be unbound; clear-route-targets first.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron leaf1 vrf set-route-targets Vrf_CUST1 --import 65000:100 --import 65000:900 --export 65000:100 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		if len(vrfImportRTs) == 0 && len(vrfExportRTs) == 0 {
+			return fmt.Errorf("--import or --export is required")
+		}
+		return displayWriteResult(app.client.SetVRFRouteTargets(app.deviceName, args[0], vrfImportRTs, vrfExportRTs, execOpts()))
+	},
+}
+
+var vrfClearRouteTargetsCmd = &cobra.Command{
+	Use:   "clear-route-targets <vrf-name>",
+	Short: "Restore a VRF's route targets to its IP-VPN's",
+	Long: `Remove the route targets set-route-targets set on a VRF, restoring
+those of the IP-VPN bound to it.
+
+Requires -D (device) flag.
+
+Examples:
+  newtron leaf1 vrf clear-route-targets Vrf_CUST1 -x`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := requireDevice(); err != nil {
+			return err
+		}
+		return displayWriteResult(app.client.ClearVRFRouteTargets(app.deviceName, args[0], execOpts()))
+	},
+}
+
 func init() {
 	vrfAddInterfaceCmd.Flags().StringVar(&vrfIntfIP, "ip", "", "IP address in CIDR notation (routed mode)")
 	vrfAddInterfaceCmd.Flags().IntVar(&vrfIntfVLAN, "vlan", 0, "VLAN ID (bridged mode)")
@@ -528,9 +765,20 @@ func init() {
 	vrfAddNeighborCmd.Flags().StringVar(&vrfNeighborDescription, "description", "", "Neighbor description")
 	vrfUpdateNeighborCmd.Flags().StringVar(&vrfNeighborIP, "neighbor", "", "Existing neighbor IP (auto-derived if not specified)")
 	vrfUpdateNeighborCmd.Flags().StringVar(&vrfNeighborDescription, "description", "", "Neighbor description")
+	vrfAddNeighborCmd.Flags().IntVar(&vrfNeighborMaxPrefix, "max-prefix", 0, "Maximum IPv4 prefixes accepted from the neighbor (0 = no limit)")
+	vrfAddNeighborCmd.Flags().StringVar(&vrfNeighborMaxAction, "max-prefix-action", "", "Past the limit: warning or restart (default: tear the session down)")
+	vrfUpdateNeighborCmd.Flags().IntVar(&vrfNeighborMaxPrefix, "max-prefix", 0, "Maximum IPv4 prefixes accepted from the neighbor (0 = no limit)")
+	vrfUpdateNeighborCmd.Flags().StringVar(&vrfNeighborMaxAction, "max-prefix-action", "", "Past the limit: warning or restart (default: tear the session down)")
 
 	vrfAddRouteCmd.Flags().IntVar(&vrfRouteMetric, "metric", 0, "Route metric")
+	vrfAddRouteCmd.Flags().StringSliceVar(&vrfRouteInterfaces, "interface", nil, "Outgoing interface(s), paired by position with the next-hops")
+	vrfAddRouteCmd.Flags().BoolVar(&vrfRouteBlackhole, "blackhole", false, "Discard traffic to the prefix")
 	vrfUpdateRouteCmd.Flags().IntVar(&vrfRouteMetric, "metric", 0, "Route metric")
+	vrfUpdateRouteCmd.Flags().StringSliceVar(&vrfRouteInterfaces, "interface", nil, "Outgoing interface(s), paired by position with the next-hops")
+	vrfUpdateRouteCmd.Flags().BoolVar(&vrfRouteBlackhole, "blackhole", false, "Discard traffic to the prefix")
+	vrfAddRouteLeakCmd.Flags().StringArrayVar(&vrfLeakPrefixes, "prefix", nil, "Leak only this IPv4 prefix (repeatable; default: all routes)")
+	vrfSetRouteTargetsCmd.Flags().StringArrayVar(&vrfImportRTs, "import", nil, "Import route target (repeatable)")
+	vrfSetRouteTargetsCmd.Flags().StringArrayVar(&vrfExportRTs, "export", nil, "Export route target (repeatable)")
 
 	vrfCmd.AddCommand(vrfListCmd)
 	vrfCmd.AddCommand(vrfShowCmd)
@@ -547,4 +795,10 @@ func init() {
 	vrfCmd.AddCommand(vrfAddRouteCmd)
 	vrfCmd.AddCommand(vrfUpdateRouteCmd)
 	vrfCmd.AddCommand(vrfRemoveRouteCmd)
+	vrfCmd.AddCommand(vrfAddRouteLeakCmd)
+	vrfCmd.AddCommand(vrfRemoveRouteLeakCmd)
+	vrfCmd.AddCommand(vrfRouteLeaksCmd)
+	vrfCmd.AddCommand(vrfRouteLeakHealthCmd)
+	vrfCmd.AddCommand(vrfSetRouteTargetsCmd)
+	vrfCmd.AddCommand(vrfClearRouteTargetsCmd)
 }
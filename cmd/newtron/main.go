@@ -57,6 +57,7 @@ type App struct {
 	networkID   string // --network-id flag
 	executeMode bool
 	noSave      bool
+	force       bool // --force: apply past the network's change_guard
 	verbose     bool
 	jsonOutput  bool
 	topology    bool // --topology flag: use topology mode (?mode=topology)
@@ -139,6 +140,9 @@ Examples:
 		if app.noSave && !app.executeMode {
 			return fmt.Errorf("--no-save requires --execute (-x)")
 		}
+		if app.force && !app.executeMode {
+			return fmt.Errorf("--force requires --execute (-x)")
+		}
 
 		// Set log level: quiet by default, verbose on -v
 		if app.verbose {
@@ -244,6 +248,9 @@ func init() {
 		addOutputFlags(cmd)
 	}
 	addOutputFlags(sshCmd)
+	addOutputFlags(lintCmd)
+	addOutputFlags(impactCmd)
+	addOutputFlags(diffDevicesCmd)
 
 	// Top-level commands that need their own flags
 	addOutputFlags(showCmd)
@@ -271,14 +278,14 @@ func init() {
 	for _, cmd := range []*cobra.Command{
 		showCmd, healthCmd, initCmd, deviceCmd, intentCmd,
 		configdbCmd, dbCmd, routeCmd,
-		sshCmd, reloadConfigCmd, saveConfigCmd, restartDaemonCmd,
+		sshCmd, reloadConfigCmd, saveConfigCmd, restartDaemonCmd, diffDevicesCmd,
 	} {
 		cmd.GroupID = "device"
 		rootCmd.AddCommand(cmd)
 	}
 
 	// Configuration & Meta
-	for _, cmd := range []*cobra.Command{settingsCmd, auditCmd, platformCmd, nodeCmd, zoneCmd, versionCmd, networkCmd, topologyCmd, secretsCmd, sshCredentialsCmd} {
+	for _, cmd := range []*cobra.Command{settingsCmd, auditCmd, platformCmd, nodeCmd, zoneCmd, versionCmd, networkCmd, topologyCmd, secretsCmd, sshCredentialsCmd, lintCmd, impactCmd} {
 		cmd.GroupID = "meta"
 		rootCmd.AddCommand(cmd)
 	}
@@ -314,7 +321,7 @@ func requireDevice() error {
 
 // execOpts returns ExecOpts from the current app flags.
 func execOpts() newtron.ExecOpts {
-	return newtron.ExecOpts{Execute: app.executeMode, NoSave: app.noSave}
+	return newtron.ExecOpts{Execute: app.executeMode, NoSave: app.noSave, Force: app.force}
 }
 
 // ============================================================================
@@ -345,6 +352,11 @@ func displayWriteResult(result *newtron.WriteResult, err error) error {
 	if result.Preview != "" {
 		fmt.Print(result.Preview)
 	}
+	if app.verbose {
+		for _, d := range result.Defaults {
+			fmt.Printf("%s: %s %s (from the network's service_defaults)\n", d.Interface, d.Param, d.Value)
+		}
+	}
 	if result.Applied {
 		fmt.Println(green("Changes applied successfully."))
 	}
@@ -417,6 +429,7 @@ func addWriteFlags(cmd *cobra.Command) {
 	}
 	flags.BoolVarP(&app.executeMode, "execute", "x", false, "Execute changes (default is dry-run)")
 	flags.BoolVar(&app.noSave, "no-save", false, "Skip config save after execution (requires -x)")
+	flags.BoolVar(&app.force, "force", false, "Apply even when the change exceeds the network's change_guard, or cascade a delete that supports it (requires -x)")
 }
 
 // addOutputFlags registers --json as a local flag.
@@ -131,6 +131,249 @@ var actionMeta = map[newtrun.StepAction]actionMetadata{
   devices: [switch1, switch2]
   snapshot: post-setup`,
 	},
+	newtrun.ActionWaitConverged: {
+		short:    "Poll until BGP, health, and/or routes have all converged",
+		long:     "Polls each device via newtron's WaitForConvergence until every selected condition (params: bgp, health, routes) holds together, then reports which condition came true last. One event-driven gate in place of a fixed wait followed by separate verify steps. Requires poll: timeout and interval.",
+		required: "devices, poll, params (at least one of bgp, health, routes)",
+		devices:  "one or more switches",
+		example: `- name: converged
+  action: wait-converged
+  devices: all
+  poll: {timeout: 3m, interval: 5s}
+  params:
+    bgp: true
+    health: true`,
+	},
+	newtrun.ActionVerifyAnycast: {
+		short:    "Assert the anycast gateway MAC and SVI IPs match across leaves",
+		long:     "Reads SAG_GLOBAL and VLAN_INTERFACE from every target and compares them across devices: one anycast MAC, and the same gateway IPs per VLAN on every device serving it. Devices that differ from the majority FAIL with their own and the expected value. params.vlans restricts the check to listed VLANs and requires each target to serve them.",
+		required: "devices",
+		devices:  "two or more leaves (compared against each other)",
+		example: `- name: anycast-consistent
+  action: verify-anycast
+  devices: [leaf1, leaf2]
+  params:
+    vlans: [100]`,
+	},
+	newtrun.ActionVerifyVLANMembership: {
+		short:    "Assert a VLAN's tagged/untagged members on each device",
+		long:     "Reads each target's VLAN membership (CONFIG_DB VLAN, VLAN_MEMBER, VLAN_INTERFACE) and compares params.vlan's members against params.tagged and params.untagged. FAILs listing missing members, members with the wrong tagging, and — with match: exact, the default — extra members. match: at-least allows members beyond the expected sets.",
+		required: "devices, params.vlan",
+		devices:  "one or more switches",
+		example: `- name: rack-joined
+  action: verify-vlan-membership
+  devices: [leaf1]
+  params:
+    vlan: 100
+    untagged: [Ethernet0, Ethernet4]
+    tagged: [PortChannel1]`,
+	},
+	newtrun.ActionVerifyEnvironment: {
+		short:    "Assert PSU, fan, and thermal sensors are in normal range",
+		long:     "Reads each target's platform sensors (STATE_DB PSU_INFO, FAN_INFO, TEMPERATURE_INFO) and FAILs naming every out-of-range sensor: a PSU absent or without power good, a fan absent, faulted, or under/over speed, a thermal at or above its high or critical-high threshold or with its warning flag set. Devices without sensors (virtual platforms) are SKIPPED.",
+		required: "devices",
+		devices:  "one or more switches",
+		example: `- name: hardware-healthy
+  action: verify-environment
+  devices: all`,
+	},
+	newtrun.ActionGenerateTraffic: {
+		short:    "Run iperf3 between two hosts and assert throughput/loss",
+		long:     "Starts a one-shot iperf3 server in params.target's namespace, runs the client from the step's device against it (params.target_ip, or the target's first global IPv4), and stops the server afterwards. Reports throughput, plus loss for protocol: udp. expect.min_throughput (bits/s, K/M/G suffixes) and expect.max_loss (percent, udp only) turn the measurement into a pass/fail assertion. Both devices must be hosts.",
+		required: "devices (exactly one host), params.target; params.bandwidth for udp",
+		devices:  "exactly one host (the traffic source)",
+		example: `- name: line-rate
+  action: generate-traffic
+  devices: [host1]
+  params:
+    target: host2
+    duration: 10s
+  expect:
+    min_throughput: 400M`,
+	},
+	newtrun.ActionVerifyRouteLeak: {
+		short:    "Assert routes leaked from one VRF appear in another",
+		long:     "Checks each target's route-leak configuration (params.dst_vrf must import from params.src_vrf), then looks each prefix up in dst_vrf's APP_DB routing table. FAILs naming the prefixes that did not arrive, noting any missing from src_vrf too. params.prefixes defaults to the leak's configured prefixes; an unfiltered leak needs them listed. Single-shot — precede with wait-converged after creating the leak.",
+		required: "devices, params.src_vrf, params.dst_vrf",
+		devices:  "one or more switches",
+		example: `- name: shared-services-leaked
+  action: verify-route-leak
+  devices: [leaf1, leaf2]
+  params:
+    src_vrf: Vrf_SHARED
+    dst_vrf: Vrf_CUST
+    prefixes: [10.50.0.0/24]`,
+	},
+	newtrun.ActionVerifyPing6: {
+		short:    "Assert IPv6 reachability by ping from each device",
+		long:     "Pings params.target over IPv6 from each target device — a switch through newtron's ssh-command, a host inside its namespace — and compares the success rate from ping's packet-loss summary with expect.success_rate (default 1.0). A device-name target resolves to its IPv6 loopback: a switch's Loopback0 IPv6 address, a host's first global IPv6 address. params.vrf pings inside a VRF (switch sources only); params.source sets the source interface or address. With poll:, re-pings until the rate is met or the timeout expires.",
+		required: "devices, params.target",
+		devices:  "one or more switches or hosts (the ping sources)",
+		example: `- name: v6-reachable
+  action: verify-ping6
+  devices: [leaf1, host1]
+  params:
+    target: leaf2
+    count: 5
+  expect:
+    success_rate: 0.8`,
+	},
+	newtrun.ActionVerifyVXLANStats: {
+		short:    "Assert VNI traffic went through the VXLAN tunnels",
+		long:     "Reads each target's VXLAN tunnel counters (GET .../evpn/vxlan-stats) and sums encap and decap packets over the tunnels carrying params.vni — SAI counts per tunnel, so other VNIs sharing a tunnel add to the sum. FAILs when a counted direction (params.direction: encap, decap, or both, the default) is below params.min_packets (default 1), or when no tunnel carries the VNI. With params.record: true the step stores the counters under params.baseline and passes; a later step naming the same baseline asserts the delta since. Tunnels without counters are an ERROR — enable the TUNNEL counter group first.",
+		required: "devices, params.vni",
+		devices:  "one or more switches",
+		example: `- name: traffic-used-tunnel
+  action: verify-vxlan-stats
+  devices: [leaf1, leaf2]
+  params:
+    vni: 10100
+    baseline: pre-traffic
+    min_packets: 100`,
+	},
+	newtrun.ActionVerifyEgressShaper: {
+		short:    "Assert a port's configured egress shaper rate",
+		long:     "Reads params.interface's egress shaper on each target (GET .../interfaces/{name}/egress-shaper) and compares the rate in the device's CONFIG_DB SCHEDULER row with params.rate_kbps. FAILs when the rates differ or when PORT_QOS_MAP does not attach the shaper to the port. params.rate_kbps: 0 asserts the port is unshaped.",
+		required: "devices, params.interface, params.rate_kbps",
+		devices:  "one or more switches",
+		example: `- name: handoff-shaped
+  action: verify-egress-shaper
+  devices: [leaf1]
+  params:
+    interface: Ethernet0
+    rate_kbps: 2000000`,
+	},
+	newtrun.ActionVerifyBGP: {
+		short:    "Assert BGP sessions are Established, optionally in one VRF",
+		long:     "Runs newtron's BGP session check on each target (GET .../bgp/check) and FAILs on any configured session that is not Established, or when there are no sessions to check. params.vrf scopes the check to one VRF's sessions (e.g. default for the underlay, or a customer VRF), so a down peer in one VRF is not masked by healthy peers in another; omitted, every VRF is checked. The message names the VRFs checked. With poll:, re-checks until the sessions are up or the timeout expires.",
+		required: "devices",
+		devices:  "one or more switches",
+		example: `- name: customer-peers-up
+  action: verify-bgp
+  devices: [leaf1, leaf2]
+  params:
+    vrf: Vrf_CUST1
+  poll: {timeout: 2m, interval: 5s}`,
+	},
+	newtrun.ActionVerifyLog: {
+		short:    "Assert no error lines were logged during the scenario",
+		long:     "Reads each target's syslog from the scenario start on (GET .../syslog?since=) and FAILs on any line at or above params.severity (default err) whose program or message matches params.pattern (regex; omitted, every line) and whose message matches no params.allow regex — the known-benign messages. Lines without a severity keyword are not counted. params.lines sets the tail read (default 5000); a tail that does not reach back to the scenario start is an ERROR. Device clocks are assumed to be in sync with the runner.",
+		required: "devices",
+		devices:  "one or more switches",
+		example: `- name: no-orchagent-errors
+  action: verify-log
+  devices: all
+  params:
+    pattern: "orchagent|syncd"
+    allow:
+      - "Failed to get port oper speed"`,
+	},
+	newtrun.ActionVerifyDHCPLease: {
+		short:    "Assert the built-in DHCP server leased an address on a VLAN",
+		long:     "Reads params.vlan_id's leases on each target (GET .../dhcp-leases?vlan_id=, from STATE_DB DHCP_SERVER_IPV4_LEASE) and PASSes when an unexpired lease matches params.mac and params.ip — any lease when neither is given. FAILs naming the leases found otherwise. With poll:, re-reads until the lease appears or the timeout expires.",
+		required: "devices, params.vlan_id",
+		devices:  "one or more switches",
+		example: `- name: host-got-lease
+  action: verify-dhcp-lease
+  devices: [leaf1]
+  params:
+    vlan_id: 100
+    mac: "52:54:00:12:34:56"
+  poll: {timeout: 1m, interval: 5s}`,
+	},
+	newtrun.ActionVerifyLLDP: {
+		short:    "Assert a port's LLDP neighbor, to catch miscabling",
+		long:     "Reads params.interface's live status on each target (GET .../interfaces/{name}/status, whose LLDP far end comes from APPL_DB LLDP_ENTRY_TABLE) and FAILs when LLDP hears no neighbor, or one whose system name is not params.neighbor (case-insensitive). params.neighbor_port additionally matches the far end's port ID or port description. LLDP takes a while to populate after provisioning — use poll: to re-read until the neighbor appears or the timeout expires.",
+		required: "devices, params.interface, params.neighbor",
+		devices:  "one or more switches",
+		example: `- name: leaf1-cabled-to-spine1
+  action: verify-lldp
+  devices: [leaf1]
+  params:
+    interface: Ethernet0
+    neighbor: spine1
+    neighbor_port: Ethernet0
+  poll: {timeout: 2m, interval: 10s}`,
+	},
+	newtrun.ActionVerifyARPSuppression: {
+		short:    "Assert EVPN ARP suppression is in effect on a VLAN",
+		long:     "Reads every VLAN's ARP suppression state on each target (GET .../evpn/arp-suppression) and PASSes when params.vlan_id's CONFIG_DB SUPPRESS_VLAN_NEIGH row has suppress=on and vlanmgrd has taken it up into APPL_DB SUPPRESS_VLAN_NEIGH_TABLE. FAILs naming the layer that is missing — a row in CONFIG_DB alone means ARP still floods. With poll:, re-reads until the setting is applied or the timeout expires.",
+		required: "devices, params.vlan_id",
+		devices:  "one or more switches",
+		example: `- name: vlan100-suppressing
+  action: verify-arp-suppression
+  devices: [leaf1, leaf2]
+  params:
+    vlan_id: 100
+  poll: {timeout: 1m, interval: 5s}`,
+	},
+	newtrun.ActionVerifyIGMPSnooping: {
+		short:    "Assert IGMP snooping is on for a VLAN and groups are learned",
+		long:     "Reads params.vlan_id's IGMP snooping state on each target (GET .../vlans/{id}/igmp-snooping) and PASSes when CONFIG_DB CFG_L2MC_TABLE has enabled=true and every group in params.groups has at least one joined port in STATE_DB L2MC_MEMBER_TABLE. FAILs naming the missing setting or groups. A target whose platform does not support igmp-snooping is SKIPPED. With poll:, re-reads until the groups are learned or the timeout expires.",
+		required: "devices, params.vlan_id (params.groups optional)",
+		devices:  "one or more switches",
+		example: `- name: market-data-snooped
+  action: verify-igmp-snooping
+  devices: [leaf1, leaf2]
+  params:
+    vlan_id: 100
+    groups: [239.1.1.1, 239.1.1.2]
+  poll: {timeout: 1m, interval: 5s}`,
+	},
+	newtrun.ActionVerifyNoFlaps: {
+		short:    "Assert ports did not flap during the scenario",
+		long:     "Reads each port in params.interfaces's flap count (GET .../interfaces/{name}/flap-history, APPL_DB PORT_TABLE flap_count) on each target before the scenario's first step, reads it again when the step runs, and FAILs on any port whose count moved, giving the flaps and the last transition. A link that flapped and recovered passes end-state oper_status checks; this catches it. Devices and interfaces must be literal, since the baseline is read before any step runs. A target whose image does not track flaps is SKIPPED.",
+		required: "devices, params.interfaces",
+		devices:  "one or more switches",
+		example: `- name: uplinks-stable
+  action: verify-no-flaps
+  devices: [leaf1, leaf2]
+  params:
+    interfaces: [Ethernet0, Ethernet4]`,
+	},
+	newtrun.ActionVerifyInterfaceCounters: {
+		short:    "Assert a port's link state and counters are within bounds",
+		long:     "Reads params.interface's live status on each target (GET .../interfaces/{name}/status, whose counters come from COUNTERS_DB) and checks each predicate: oper_status (case-insensitive) and any number of <counter>_min / <counter>_max bounds. Counters are rx_/tx_ octets, unicast_packets, non_unicast_packets, packets, discards and errors; in_/out_ stand for rx_/tx_. FAILs naming each violated predicate; a port without counters is an ERROR. With poll:, re-reads until every predicate holds or the timeout expires.",
+		required: "devices, params.interface, at least one predicate",
+		devices:  "one or more switches",
+		example: `- name: uplink-clean
+  action: verify-interface-counters
+  devices: [leaf1]
+  params:
+    interface: Ethernet0
+    oper_status: up
+    tx_packets_min: 1
+    in_errors_max: 0
+  poll: {timeout: 1m, interval: 5s}`,
+	},
+	newtrun.ActionVerifyEVPNType2: {
+		short:    "Assert an EVPN type-2 (MAC/IP) route is in a VNI's table",
+		long:     "Reads params.vni's type-2 routes from FRR on each target (GET .../evpn/macip-routes?vni=) and PASSes when one matches params.mac and/or params.ip (at least one required). On the leaf that learned the MAC the route is local; on a remote leaf it is imported, next hop the advertising VTEP — the message names which. params.present: false asserts the route is absent instead (a withdrawn MAC). With poll:, re-reads until the assertion holds or the timeout expires.",
+		required: "devices, params.vni, params.mac or params.ip",
+		devices:  "one or more switches",
+		example: `- name: host1-mac-imported
+  action: verify-evpn-type2
+  devices: [leaf2]
+  params:
+    vni: 10100
+    mac: "52:54:00:12:34:56"
+    ip: 10.1.100.10
+  poll: {timeout: 1m, interval: 5s}`,
+	},
+	newtrun.ActionVerifyLAGDistribution: {
+		short:    "Assert a LAG spreads its traffic across members (no polarization)",
+		long:     "Samples the TX counters of params.portchannel's members over params.window (default 10s, 1s-1m) on each target (GET .../lags/{name}/hash-distribution) and PASSes when the skew — the percentage points the busiest member's share sits above an even split — is at most params.max_skew. A window carrying fewer than params.min_packets packets (default 1) FAILs: with no traffic there is nothing to judge, so run it while traffic flows. The message lists each member's share.",
+		required: "devices, params.portchannel, params.max_skew",
+		devices:  "one or more switches",
+		example: `- name: uplink-lag-balanced
+  action: verify-lag-distribution
+  devices: [leaf1]
+  params:
+    portchannel: PortChannel100
+    max_skew: 20
+    window: 10s
+    min_packets: 1000`,
+	},
 }
 
 // init enforces that every StepAction constant has matching metadata.
@@ -145,6 +388,25 @@ func init() {
 		newtrun.ActionNewtronCLI,
 		newtrun.ActionSnapshot,
 		newtrun.ActionVerifySnapshot,
+		newtrun.ActionWaitConverged,
+		newtrun.ActionVerifyAnycast,
+		newtrun.ActionVerifyVLANMembership,
+		newtrun.ActionVerifyEnvironment,
+		newtrun.ActionGenerateTraffic,
+		newtrun.ActionVerifyRouteLeak,
+		newtrun.ActionVerifyPing6,
+		newtrun.ActionVerifyVXLANStats,
+		newtrun.ActionVerifyEgressShaper,
+		newtrun.ActionVerifyBGP,
+		newtrun.ActionVerifyLog,
+		newtrun.ActionVerifyDHCPLease,
+		newtrun.ActionVerifyLLDP,
+		newtrun.ActionVerifyARPSuppression,
+		newtrun.ActionVerifyInterfaceCounters,
+		newtrun.ActionVerifyEVPNType2,
+		newtrun.ActionVerifyLAGDistribution,
+		newtrun.ActionVerifyIGMPSnooping,
+		newtrun.ActionVerifyNoFlaps,
 	}
 	for _, a := range known {
 		if _, ok := actionMeta[a]; !ok {
@@ -168,6 +430,25 @@ var orderedActions = []newtrun.StepAction{
 	newtrun.ActionNewtronCLI,
 	newtrun.ActionSnapshot,
 	newtrun.ActionVerifySnapshot,
+	newtrun.ActionWaitConverged,
+	newtrun.ActionVerifyAnycast,
+	newtrun.ActionVerifyVLANMembership,
+	newtrun.ActionVerifyEnvironment,
+	newtrun.ActionGenerateTraffic,
+	newtrun.ActionVerifyRouteLeak,
+	newtrun.ActionVerifyPing6,
+	newtrun.ActionVerifyVXLANStats,
+	newtrun.ActionVerifyEgressShaper,
+	newtrun.ActionVerifyBGP,
+	newtrun.ActionVerifyLog,
+	newtrun.ActionVerifyDHCPLease,
+	newtrun.ActionVerifyLLDP,
+	newtrun.ActionVerifyARPSuppression,
+	newtrun.ActionVerifyInterfaceCounters,
+	newtrun.ActionVerifyEVPNType2,
+	newtrun.ActionVerifyLAGDistribution,
+	newtrun.ActionVerifyIGMPSnooping,
+	newtrun.ActionVerifyNoFlaps,
 }
 
 func listActions() error {
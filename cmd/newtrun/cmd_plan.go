@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aldrin-isaac/newtron/pkg/cli"
+	"github.com/aldrin-isaac/newtron/pkg/newtrun"
+)
+
+func newPlanCmd() *cobra.Command {
+	var scenario, target, platform string
+
+	cmd := &cobra.Command{
+		Use:   "plan <suite>",
+		Short: "Show what a suite run would do, without running it",
+		Long: `Prints the static execution plan of a suite: scenarios in the order a run
+executes them (dependency order), the steps of each with the devices they
+target, and the scenarios the run would skip — unmet requires, platform
+features the platform lacks, suite parameters left unset. Devices named in a
+step that the topology does not have are flagged.
+
+Nothing is deployed or run. --scenario and --target narrow the plan the way
+they narrow 'newtrun start'.
+
+  newtrun plan 2node-ngdp-incremental
+  newtrun plan 2node-ngdp-incremental --target evpn-l3
+  newtrun plan 1node-vs-basic --platform sonic-vpp`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if scenario != "" && target != "" {
+				return fmt.Errorf("--scenario and --target are mutually exclusive")
+			}
+			c := newClient()
+			ctx := cmd.Context()
+			if err := requireServer(ctx, c); err != nil {
+				return err
+			}
+			plan, err := c.GetSuitePlan(ctx, args[0], scenario, target, platform)
+			if err != nil {
+				return err
+			}
+			renderPlan(os.Stdout, plan)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&scenario, "scenario", "", "plan only this scenario")
+	cmd.Flags().StringVar(&target, "target", "", "plan this scenario and everything it requires")
+	cmd.Flags().StringVar(&platform, "platform", "", "platform override (default: the suite's)")
+
+	return cmd
+}
+
+// renderPlan writes the plan header, any warnings, and the scenario tree.
+func renderPlan(w io.Writer, plan *newtrun.Plan) {
+	skipped := 0
+	for _, sc := range plan.Scenarios {
+		if sc.SkipReason != "" {
+			skipped++
+		}
+	}
+	header := fmt.Sprintf("Suite: %s  topology=%s", plan.Suite, plan.Network)
+	if plan.Platform != "" {
+		header += "  platform=" + plan.Platform
+	}
+	fmt.Fprintf(w, "%s  (%d scenarios, %d skipped)\n", header, len(plan.Scenarios), skipped)
+	for _, warning := range plan.Warnings {
+		fmt.Fprintf(w, "%s %s\n", cli.Yellow("warning:"), warning)
+	}
+	fmt.Fprintln(w)
+
+	tree := cli.NewTree("")
+	for i, sc := range plan.Scenarios {
+		label := fmt.Sprintf("%d. %s", i+1, cli.Bold(sc.Name))
+		var notes []string
+		if len(sc.Requires) > 0 {
+			notes = append(notes, "requires "+strings.Join(sc.Requires, ", "))
+		}
+		if len(sc.After) > 0 {
+			notes = append(notes, "after "+strings.Join(sc.After, ", "))
+		}
+		if sc.Iterations > 1 {
+			notes = append(notes, fmt.Sprintf("×%d targets", sc.Iterations))
+		}
+		if sc.Repeat > 1 {
+			notes = append(notes, fmt.Sprintf("repeat %d", sc.Repeat))
+		}
+		if len(notes) > 0 {
+			label += "  " + cli.Dim(strings.Join(notes, "; "))
+		}
+		if sc.SkipReason != "" {
+			label += "\n" + cli.Yellow("SKIP: "+sc.SkipReason)
+		}
+		node := tree.Add(label)
+		for _, step := range sc.Steps {
+			node.Add(planStepLabel(step, ""))
+		}
+		for _, step := range sc.Cleanup {
+			node.Add(planStepLabel(step, "cleanup: "))
+		}
+	}
+	fmt.Fprint(w, tree.String())
+}
+
+// planStepLabel renders one step: name, action, target devices, problem.
+func planStepLabel(step newtrun.StepPlan, prefix string) string {
+	label := fmt.Sprintf("%s%s  %s", prefix, step.Name, cli.Dim(string(step.Action)))
+	if len(step.Devices) > 0 {
+		label += " → " + strings.Join(step.Devices, ", ")
+	}
+	if step.Problem != "" {
+		label += "  " + cli.Red(step.Problem)
+	}
+	return label
+}
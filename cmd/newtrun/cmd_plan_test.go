@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtrun"
+)
+
+func TestRenderPlan(t *testing.T) {
+	plan := &newtrun.Plan{
+		Suite:    "fabric",
+		Network:  "2node-vs",
+		Warnings: []string{"no platform set; requires_features are checked at run time"},
+		Scenarios: []newtrun.ScenarioPlan{
+			{Name: "boot", Iterations: 1, Steps: []newtrun.StepPlan{
+				{Name: "converged", Action: newtrun.ActionWaitConverged, Devices: []string{"leaf1", "leaf2"}},
+			}},
+			{Name: "provision", Requires: []string{"boot"}, Iterations: 1, SkipReason: "requires 'boot' which will be skipped",
+				Steps: []newtrun.StepPlan{
+					{Name: "reconcile", Action: newtrun.ActionProvision, Devices: []string{"leaf9"}, Problem: "not in topology: leaf9"},
+				}},
+		},
+	}
+	var buf bytes.Buffer
+	renderPlan(&buf, plan)
+	out := buf.String()
+
+	for _, want := range []string{
+		"Suite: fabric  topology=2node-vs  (2 scenarios, 1 skipped)",
+		"no platform set",
+		"├── 1. ",
+		"└── 2. ",
+		"requires boot",
+		"SKIP: requires 'boot' which will be skipped",
+		"converged",
+		"→ leaf1, leaf2",
+		"not in topology: leaf9",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n--- got ---\n%s", want, out)
+		}
+	}
+}
@@ -25,20 +25,26 @@ func newReportCmd() *cobra.Command {
 	)
 	cmd := &cobra.Command{
 		Use:   "report <suite>",
-		Short: "Render a JUnit XML or markdown report from a finished run",
+		Short: "Render a JUnit XML, markdown, HTML or JSON report from a finished run",
 		Long: `Fetch the most recent run state for <suite> from newtrun-server
 and render a report file locally. Useful for CI integrations that
-consume JUnit XML, or for sharing markdown summaries.
+consume JUnit XML, or for sharing markdown summaries. The HTML report adds
+a timeline of every scenario and step and the slowest steps, for finding
+where a long suite spends its time. The JSON report is the full result
+tree (steps, per-device details, repeat iterations, skip reasons) with a
+schema_version, for dashboards and scripts.
 
   newtrun report 2node-vs-primitive --format junit --out report.xml
   newtrun report 2node-vs-primitive --format markdown --out report.md
+  newtrun report 2node-vs-primitive --format html --out report.html
+  newtrun report 2node-vs-primitive --format json --out report.json
 
 If --out is omitted, the report is written to stdout.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			suite := args[0]
-			if format != "junit" && format != "markdown" {
-				return fmt.Errorf("--format must be junit or markdown, got %q", format)
+			if format != "junit" && format != "markdown" && format != "html" && format != "json" {
+				return fmt.Errorf("--format must be junit, markdown, html or json, got %q", format)
 			}
 			c := newClient()
 			ctx := cmd.Context()
@@ -71,12 +77,20 @@ If --out is omitted, the report is written to stdout.`,
 				if err := gen.WriteMarkdown(path); err != nil {
 					return fmt.Errorf("write markdown report: %w", err)
 				}
+			case "html":
+				if err := gen.WriteHTML(path); err != nil {
+					return fmt.Errorf("write HTML report: %w", err)
+				}
+			case "json":
+				if err := gen.WriteJSON(path); err != nil {
+					return fmt.Errorf("write JSON report: %w", err)
+				}
 			}
 			fmt.Fprintf(cmd.OutOrStderr(), "wrote %s report to %s\n", format, path)
 			return nil
 		},
 	}
-	cmd.Flags().StringVar(&format, "format", "junit", "report format: junit or markdown")
+	cmd.Flags().StringVar(&format, "format", "junit", "report format: junit, markdown, html or json")
 	cmd.Flags().StringVarP(&out, "out", "o", "", "output path (required)")
 	return cmd
 }
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtrun"
+)
+
+func newRerunCmd() *cobra.Command {
+	var opts startOptions
+
+	cmd := &cobra.Command{
+		Use:   "rerun [<run-id>]",
+		Short: "Re-run only the scenarios a finished run failed",
+		Long: `Read a finished run's manifest.json and re-run only the scenarios that
+failed or errored, each with every scenario it transitively requires, in
+dependency order. The run ID defaults to the latest run in --results-dir.
+
+  newtrun rerun                              # failures of the latest run
+  newtrun rerun 20260530-100711-3f9a1c       # failures of a named run
+
+A rerun is a new run with its own run ID and results directory; its report
+and manifest.json name the original run (rerun_of). A multi-suite run is
+re-run suite by suite, skipping suites with no failures. Flags are those of
+'newtrun start'; suite parameters are not carried over from the original
+run, so pass the same --param values again.
+
+Exit code: 0 on success; 1 on test failure; 2 on infrastructure error.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runID := newtrun.LatestResultsLink
+			if len(args) == 1 {
+				runID = args[0]
+			}
+			prev, err := newtrun.ReadRunManifest(opts.resultsDir, runID)
+			if err != nil {
+				return err
+			}
+			suites, perSuite, err := rerunSelection(prev)
+			if err != nil {
+				return err
+			}
+			if len(suites) == 0 {
+				fmt.Fprintf(os.Stderr, "newtrun: run %s had no failed or errored scenarios; nothing to rerun\n", prev.RunID)
+				return nil
+			}
+			for _, suite := range suites {
+				fmt.Fprintf(os.Stderr, "newtrun: rerunning %d failed scenario(s) of %s from run %s: %s\n",
+					len(perSuite[suite]), suite, prev.RunID, strings.Join(perSuite[suite], ", "))
+			}
+			return executeRun(cmd.Context(), suites, opts, runSelection{perSuite: perSuite, rerunOf: prev.RunID})
+		},
+	}
+
+	opts.addFlags(cmd)
+	return cmd
+}
+
+// rerunSelection returns the suites of prev that had failures, in prev's
+// order, and the failed scenarios of each.
+func rerunSelection(prev *newtrun.RunManifest) ([]string, map[string][]string, error) {
+	failed, err := prev.FailedScenarios()
+	if err != nil {
+		return nil, nil, err
+	}
+	var suites []string
+	for _, suite := range prev.Suites {
+		if len(failed[suite]) > 0 {
+			suites = append(suites, suite)
+		}
+	}
+	return suites, failed, nil
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtrun"
+)
+
+func TestRerunSelection(t *testing.T) {
+	prev := &newtrun.RunManifest{
+		RunID:     "run-1",
+		Suites:    []string{"b-suite", "clean-suite", "a-suite"},
+		Scenarios: 4,
+		Results: []newtrun.ManifestResult{
+			{Suite: "b-suite", Scenario: "verify", Status: newtrun.StepStatusFailed},
+			{Suite: "clean-suite", Scenario: "boot", Status: newtrun.StepStatusPassed},
+			{Suite: "a-suite", Scenario: "probe", Status: newtrun.StepStatusError},
+			{Suite: "a-suite", Scenario: "after", Status: newtrun.StepStatusSkipped},
+		},
+	}
+	suites, perSuite, err := rerunSelection(prev)
+	if err != nil {
+		t.Fatalf("rerunSelection: %v", err)
+	}
+	if want := []string{"b-suite", "a-suite"}; !reflect.DeepEqual(suites, want) {
+		t.Errorf("suites = %v, want %v (original order, clean suite dropped)", suites, want)
+	}
+	if got := perSuite["a-suite"]; !reflect.DeepEqual(got, []string{"probe"}) {
+		t.Errorf("a-suite = %v, want [probe]", got)
+	}
+}
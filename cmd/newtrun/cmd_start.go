@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -15,251 +16,489 @@ import (
 	newtronclient "github.com/aldrin-isaac/newtron/pkg/newtron/client"
 	"github.com/aldrin-isaac/newtron/pkg/newtrun"
 	"github.com/aldrin-isaac/newtron/pkg/newtrun/api"
+	"github.com/aldrin-isaac/newtron/pkg/newtrun/client"
 )
 
+// startOptions are the flags `newtrun start` and `newtrun rerun` share.
+type startOptions struct {
+	platform   string
+	serverURL  string // newtron-server URL (original --server semantics)
+	networkID  string
+	junitPath  string
+	resultsDir string
+	monitor    bool
+	noDeploy   bool
+	params     []string
+	onFailure  string
+	golden     string
+	preview    bool
+	parallel   int
+	deadline   time.Duration
+	maxWrites  int
+}
+
+// addFlags registers the shared run flags on cmd.
+func (o *startOptions) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.platform, "platform", "", "override platform")
+	cmd.Flags().StringVar(&o.junitPath, "junit", "", "also write the JUnit XML report to this path")
+	cmd.Flags().StringVar(&o.resultsDir, "results-dir", newtrun.DefaultResultsDir, "directory holding per-run results (<dir>/<run-id>/, <dir>/latest)")
+	cmd.Flags().StringVar(&o.serverURL, "server", "", "newtron-server URL (default: http://127.0.0.1:18080, env: NEWTRON_SERVER)")
+	cmd.Flags().StringVar(&o.networkID, "network-id", "", "newtron network identifier (env: NEWTRON_NETWORK_ID). Empty by default — newtrun-server derives the id from suite.Network so concurrent suites don't compete for one 'default' slot (#116).")
+	cmd.Flags().BoolVarP(&o.monitor, "monitor", "m", false, "show live status dashboard during run")
+	cmd.Flags().BoolVar(&o.noDeploy, "no-deploy", false, "skip topology deployment (for loopback/offline mode)")
+	cmd.Flags().StringArrayVar(&o.params, "param", nil, "override a suite-level parameter; repeatable, format key=value (e.g. --param alice_basic_auth=$(echo -n alice:pw | base64))")
+	cmd.Flags().StringVar(&o.onFailure, "on-verify-failure", "", "on a failed verify step, capture artifacts: dump-tables writes the asserted tables under the suite state dir")
+	cmd.Flags().StringVar(&o.golden, "golden", "", "golden mode for observe: steps: record writes what they observed to the suite's golden/ files, compare fails a step that drifted from them")
+	cmd.Flags().BoolVar(&o.preview, "preview", false, "build each write step's ChangeSet without delivering it (dry run) and record it in the step result; verify, wait and host steps are skipped")
+	cmd.Flags().IntVar(&o.parallel, "parallel", 0, "max devices a step works on at once (0 = all target devices concurrently)")
+	cmd.Flags().DurationVar(&o.deadline, "deadline", 0, "cap each suite's wall time (e.g. 45m); scenarios not finished by then are skipped and the run fails (0 = no cap)")
+	cmd.Flags().IntVar(&o.maxWrites, "max-total-writes", 0, "cap the config entries each suite's run may deliver across all scenarios and devices; the write that crosses it stops the run, which fails (0 = no cap)")
+}
+
+// runSelection narrows what each suite of a run executes. The zero value
+// runs every scenario.
+type runSelection struct {
+	scenario string
+	target   string
+	// perSuite, when set, runs only the listed scenarios of each suite
+	// (and what they require) — a rerun of run rerunOf.
+	perSuite map[string][]string
+	rerunOf  string
+}
+
 func newStartCmd() *cobra.Command {
 	var (
-		scenario  string
-		target    string
-		platform  string
-		serverURL string // newtron-server URL (original --server semantics)
-		networkID string
-		junitPath string
-		monitor   bool
-		noDeploy  bool
-		params    []string
+		scenario string
+		target   string
+		withDeps bool
+		opts     startOptions
 	)
 
 	cmd := &cobra.Command{
-		Use:   "start <suite>",
-		Short: "Start or resume a suite run",
+		Use:   "start <suite> [<suite>...]",
+		Short: "Start or resume one or more suite runs",
 		Long: `Submit a run of the named file-backed suite to newtrun-server, then
 stream scenario and step events back to the terminal as they arrive.
 
   newtrun start 2node-ngdp-primitive                        # run all scenarios
   newtrun start 2node-ngdp-primitive --scenario boot-ssh    # run one
   newtrun start 2node-ngdp-primitive --target cross-switch  # run dependency chain
+  newtrun start 2node-ngdp-primitive --scenario cross-switch --with-deps  # same
   newtrun start 2node-ngdp-primitive --monitor              # live dashboard
   newtrun start 2node-ngdp-primitive --junit out.xml        # JUnit XML report
+  newtrun start 2node-ngdp-primitive --on-verify-failure dump-tables
+  newtrun start 2node-ngdp-primitive --golden record        # baseline observe: steps
+  newtrun start 2node-ngdp-primitive --golden compare       # fail on drift from it
+  newtrun start 2node-ngdp-primitive --preview              # ChangeSets only, nothing delivered
+  newtrun start 1node-vs-basic 2node-ngdp-primitive         # chain suites, one report
+
+Every invocation gets a run ID and writes its reports (report.md,
+junit.xml, report.json) and a manifest.json to
+.newtrun/results/<run-id>/ (see --results-dir);
+.newtrun/results/latest points at the newest run. The run ID
+is recorded in the suite's state.json, and failure artifacts are filed under
+it, so no run overwrites another's output.
 
 If the suite is paused (previous run completed pause cleanly), newtrun-server
 resumes from where it stopped — scenarios already passed are skipped.
 
+Several suites run in sequence, each against its own topology, and produce
+one aggregated report with every scenario tagged by its originating suite.
+When the next suite needs a different topology than the one just used, the
+previous topology is destroyed before the next suite deploys its own. A test
+failure in one suite does not stop the chain; an infrastructure error does.
+--scenario and --target select within a single suite and cannot be combined
+with several suites. --with-deps turns --scenario X into --target X: X runs
+after every scenario it transitively requires, and the scenarios pulled in
+as dependencies are listed when the run starts.
+
 The topology and per-Node atomicity model are determined by newtron-server.
 Pause with 'newtrun pause <suite>'; tear down with 'newtrun stop <suite>'.
+To re-run only a finished run's failures, use 'newtrun rerun'.
 
 Exit code: 0 on success; 1 on test failure; 2 on infrastructure error.`,
-		Args: cobra.ExactArgs(1),
+		Args: cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			suiteName := args[0]
-			if suiteName == "" {
-				return fmt.Errorf("provide a suite name")
+			for _, suiteName := range args {
+				if suiteName == "" {
+					return fmt.Errorf("provide a suite name")
+				}
 			}
-
-			c := newClient()
-			ctx, cancel := context.WithCancel(cmd.Context())
-			defer cancel()
-
-			if err := requireServer(ctx, c); err != nil {
+			if len(args) > 1 && (scenario != "" || target != "") {
+				return fmt.Errorf("--scenario and --target select within one suite; run several suites without them")
+			}
+			var err error
+			scenario, target, err = resolveSelection(scenario, target, withDeps)
+			if err != nil {
 				return err
 			}
+			return executeRun(cmd.Context(), args, opts, runSelection{scenario: scenario, target: target})
+		},
+	}
 
-			// Resolve newtron-server URL: --server flag wins, else env,
-			// else built-in default. Matches the original --server semantics.
-			if serverURL == "" {
-				serverURL = os.Getenv("NEWTRON_SERVER")
-			}
-			// Resolve network ID similarly.
-			if networkID == "" {
-				networkID = os.Getenv("NEWTRON_NETWORK_ID")
-			}
+	cmd.Flags().StringVar(&scenario, "scenario", "", "run specific scenario (default: all)")
+	cmd.Flags().StringVar(&target, "target", "", "run minimal dependency chain to reach scenario")
+	cmd.Flags().BoolVar(&withDeps, "with-deps", false, "with --scenario, also run the scenarios it transitively requires, in dependency order")
+	opts.addFlags(cmd)
+	return cmd
+}
 
-			// Parse repeated --param flags into a map. Each entry is
-			// "key=value"; the value is stored verbatim as a string —
-			// newtrun-server's EffectiveParameters() runs the typed
-			// Coerce path (int / bool / enum / ipv4 / cidr) on the
-			// raw string per the suite's ParameterSpec.
-			paramOverrides := make(map[string]any, len(params))
-			for _, kv := range params {
-				eq := strings.IndexByte(kv, '=')
-				if eq < 0 {
-					return fmt.Errorf("--param %q is not in key=value form", kv)
-				}
-				k := kv[:eq]
-				v := kv[eq+1:]
-				if k == "" {
-					return fmt.Errorf("--param %q has empty key", kv)
-				}
-				paramOverrides[k] = v
-			}
+// executeRun runs suites in sequence against newtrun-server, streams their
+// events, and writes the run's results directory. It returns the error
+// that sets the process exit code.
+func executeRun(ctx context.Context, suites []string, opts startOptions, sel runSelection) error {
+	c := newClient()
 
-			// Pull every cached session into UserSessions so any
-			// scenario with `as: <user>` can authenticate as
-			// that user. The runner ignores entries no scenario
-			// uses, so over-supplying is harmless. Under-supplying
-			// is what fails — a scenario's `as: mallory` against
-			// a map without mallory becomes a clear "no session"
-			// error.
-			userSessions := make(map[string]string)
-			if sessions, _, err := newtronclient.ListSessions(); err == nil {
-				for _, rec := range sessions {
-					if _, dup := userSessions[rec.User]; dup {
-						// Multiple servers can cache the same user — prefer the
-						// first one. Operators running suites against a single
-						// server normally see one entry per user.
-						continue
-					}
-					userSessions[rec.User] = rec.Key
-				}
-			}
+	if err := requireServer(ctx, c); err != nil {
+		return err
+	}
+
+	// Resolve newtron-server URL: --server flag wins, else env,
+	// else built-in default. Matches the original --server semantics.
+	serverURL := opts.serverURL
+	if serverURL == "" {
+		serverURL = os.Getenv("NEWTRON_SERVER")
+	}
+	// Resolve network ID similarly.
+	networkID := opts.networkID
+	if networkID == "" {
+		networkID = os.Getenv("NEWTRON_NETWORK_ID")
+	}
+
+	// Parse repeated --param flags into a map. Each entry is
+	// "key=value"; the value is stored verbatim as a string —
+	// newtrun-server's EffectiveParameters() runs the typed
+	// Coerce path (int / bool / enum / ipv4 / cidr) on the
+	// raw string per the suite's ParameterSpec.
+	paramOverrides := make(map[string]any, len(opts.params))
+	for _, kv := range opts.params {
+		eq := strings.IndexByte(kv, '=')
+		if eq < 0 {
+			return fmt.Errorf("--param %q is not in key=value form", kv)
+		}
+		k := kv[:eq]
+		v := kv[eq+1:]
+		if k == "" {
+			return fmt.Errorf("--param %q has empty key", kv)
+		}
+		paramOverrides[k] = v
+	}
 
-			req := api.StartRunRequest{
-				Suite:         suiteName,
-				Scenario:      scenario,
-				Target:        target,
-				Platform:      platform,
-				NoDeploy:      noDeploy,
-				Verbose:       verboseFlag,
-				NewtronServer: serverURL,
-				NetworkID:     networkID,
-				JUnitPath:     junitPath,
-				Parameters:    paramOverrides,
-				UserSessions:  userSessions,
+	// Pull every cached session into UserSessions so any
+	// scenario with `as: <user>` can authenticate as
+	// that user. The runner ignores entries no scenario
+	// uses, so over-supplying is harmless. Under-supplying
+	// is what fails — a scenario's `as: mallory` against
+	// a map without mallory becomes a clear "no session"
+	// error.
+	userSessions := make(map[string]string)
+	if sessions, _, err := newtronclient.ListSessions(); err == nil {
+		for _, rec := range sessions {
+			if _, dup := userSessions[rec.User]; dup {
+				// Multiple servers can cache the same user — prefer the
+				// first one. Operators running suites against a single
+				// server normally see one entry per user.
+				continue
 			}
+			userSessions[rec.User] = rec.Key
+		}
+	}
 
-			started, err := c.StartRun(ctx, req)
-			if err != nil {
-				return err
+	manifest := &newtrun.RunManifest{
+		RunID:   newtrun.NewRunID(time.Now()),
+		Suites:  suites,
+		Started: time.Now(),
+		RerunOf: sel.rerunOf,
+	}
+	if sel.rerunOf != "" {
+		fmt.Fprintf(os.Stderr, "newtrun: run %s (partial rerun of run %s)\n", manifest.RunID, sel.rerunOf)
+	} else {
+		fmt.Fprintf(os.Stderr, "newtrun: run %s\n", manifest.RunID)
+	}
+
+	var (
+		results              []*newtrun.ScenarioResult
+		hasFailure, hasError bool
+		runErr               error
+	)
+	for i, suiteName := range suites {
+		if len(suites) > 1 {
+			fmt.Fprintf(os.Stderr, "newtrun: suite %d/%d: %s\n", i+1, len(suites), suiteName)
+		}
+		if i > 0 && !opts.noDeploy {
+			if err := transitionTopology(ctx, c, suites[i-1], suiteName); err != nil {
+				runErr = err
+				break
 			}
-			fmt.Fprintf(os.Stderr, "newtrun: started suite %s at %s\n",
-				started.Suite, started.Started.Format(time.RFC3339))
-
-			// Subscribe to events; render them; cancel the stream when
-			// SuiteEnd arrives. Cancellation makes the stream return
-			// context.Canceled which we treat as the normal terminal
-			// condition.
-			//
-			// In monitor mode, the renderer skips per-event terminal
-			// output and the monitor loop is what the operator watches
-			// (auto-refresh dashboard reading state.json directly). The
-			// terminal exit code still derives from the same atomic
-			// flags so behavior matches the non-monitor case.
-			var hasFailure, hasError, suiteEndSeen, suiteAborted atomic.Bool
-			scenarioResults := make([]*newtrun.ScenarioResult, 0)
-			var resultsMu sync.Mutex
-
-			// markSuiteEnd is called from the SSE handler whenever a
-			// SuiteEnd arrives. The status field on the wire payload
-			// distinguishes "the run completed normally with N failures"
-			// (the suite was actually buggy) from "aborted" (the server
-			// shut down mid-run) — exit-code mapping below uses that
-			// distinction to avoid blaming tests for an infrastructure
-			// outage.
-			markSuiteEnd := func(ev api.Event) {
-				if ev.Type != api.EventSuiteEnd {
-					return
-				}
-				suiteEndSeen.Store(true)
-				if payload, err := json.Marshal(ev.Payload); err == nil {
-					var p api.SuiteEndPayload
-					if err := json.Unmarshal(payload, &p); err == nil {
-						if p.Status == newtrun.SuiteStatusAborted {
-							suiteAborted.Store(true)
-						}
-					}
-				}
+		}
+		req := api.StartRunRequest{
+			Suite:           suiteName,
+			Scenario:        sel.scenario,
+			Target:          sel.target,
+			Scenarios:       sel.perSuite[suiteName],
+			Platform:        opts.platform,
+			NoDeploy:        opts.noDeploy,
+			Verbose:         verboseFlag,
+			NewtronServer:   serverURL,
+			NetworkID:       networkID,
+			JUnitPath:       opts.junitPath,
+			Parameters:      paramOverrides,
+			OnVerifyFailure: opts.onFailure,
+			Golden:          opts.golden,
+			Preview:         opts.preview,
+			Parallelism:     opts.parallel,
+			DeadlineSeconds: int((opts.deadline + time.Second - 1) / time.Second),
+			MaxTotalWrites:  opts.maxWrites,
+			UserSessions:    userSessions,
+			RunID:           manifest.RunID,
+		}
+		out, err := runSuite(ctx, c, req, opts.monitor)
+		if out.resumedFrom != "" {
+			if manifest.ResumedFrom == nil {
+				manifest.ResumedFrom = make(map[string]string)
 			}
+			manifest.ResumedFrom[suiteName] = out.resumedFrom
+		}
+		for _, r := range out.results {
+			r.Suite = suiteName
+		}
+		results = append(results, out.results...)
+		hasFailure = hasFailure || out.hasFailure
+		hasError = hasError || out.hasError
+		if err != nil {
+			// Infrastructure errors end the chain — later suites
+			// would run against a server or lab in unknown state.
+			runErr = err
+			break
+		}
+	}
 
-			var streamDone chan struct{}
-			if monitor {
-				streamDone = make(chan struct{})
-				go func() {
-					defer close(streamDone)
-					_ = c.StreamEvents(ctx, started.Suite, func(ev api.Event) {
-						trackStatus(ev, &hasFailure, &hasError)
-						collectResult(ev, &scenarioResults, &resultsMu)
-						markSuiteEnd(ev)
-						if ev.Type == api.EventSuiteEnd {
-							cancel()
-						}
-					})
-				}()
-				// Brief delay so the run's initial state file lands before
-				// the monitor loop tries to read it.
-				time.Sleep(500 * time.Millisecond)
-				_ = monitorSuite(started.Suite, true)
-				<-streamDone
-			} else {
-				streamErr := c.StreamEvents(ctx, started.Suite, func(ev api.Event) {
-					renderEvent(ev, &hasFailure, &hasError)
-					collectResult(ev, &scenarioResults, &resultsMu)
-					markSuiteEnd(ev)
-					if ev.Type == api.EventSuiteEnd {
-						cancel()
-					}
-				})
-				if streamErr != nil && ctx.Err() == nil {
-					// The SSE stream broke before we asked it to. This
-					// is almost always the server going away mid-run
-					// (SIGKILL with no drain; network blip). Report it
-					// as infrastructure error with a clearer message
-					// than the raw transport error, so an operator
-					// doesn't mistake it for a tool bug.
-					return fmt.Errorf("%w: newtrun-server connection lost mid-run; check state.json for the last persisted status",
-						errInfraError)
-				}
+	// Write the run's results directory (and JUnit to --junit if
+	// set) over every suite that ran — including a chain cut short
+	// by an infrastructure error, so the suites that finished still
+	// report.
+	if len(results) > 0 {
+		gen := &newtrun.ReportGenerator{Results: results, RerunOf: sel.rerunOf}
+		manifest.Finished = time.Now()
+		if runErr != nil {
+			manifest.Status = newtrun.SuiteStatusFromOutcome(runErr, results)
+		}
+		if dir, err := newtrun.WriteRunResults(opts.resultsDir, manifest, gen); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: failed to write run results: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "newtrun: results in %s\n", dir)
+		}
+		if opts.junitPath != "" {
+			if err := gen.WriteJUnit(opts.junitPath); err != nil {
+				fmt.Fprintf(os.Stderr, "warning: failed to write JUnit report: %v\n", err)
 			}
+		}
+	}
 
-			// If the stream ended cleanly but we never saw SuiteEnd, the
-			// server-side run was reaped (server shut down between events)
-			// — surface it the same way as the broken-stream case.
-			if !suiteEndSeen.Load() {
-				return fmt.Errorf("%w: stream ended without SuiteEnd; the server may have been shut down mid-run",
-					errInfraError)
-			}
-			// Aborted suites are infrastructure errors, not test failures —
-			// don't blame the suite for the server's shutdown.
-			if suiteAborted.Load() {
-				return fmt.Errorf("%w: run was aborted (server shut down)", errInfraError)
-			}
+	if runErr != nil {
+		return runErr
+	}
+	if hasError {
+		return errInfraError
+	}
+	if hasFailure {
+		return errTestFailure
+	}
+	return nil
+}
 
-			// Write reports (markdown always; JUnit if --junit set). Mirrors
-			// the original cmd_start.go behavior.
-			resultsMu.Lock()
-			results := append([]*newtrun.ScenarioResult(nil), scenarioResults...)
-			resultsMu.Unlock()
-			if len(results) > 0 {
-				gen := &newtrun.ReportGenerator{Results: results}
-				if err := gen.WriteMarkdown(".newtrun/reports/report.md"); err != nil {
-					fmt.Fprintf(os.Stderr, "warning: failed to write markdown report: %v\n", err)
-				}
-				if junitPath != "" {
-					if err := gen.WriteJUnit(junitPath); err != nil {
-						fmt.Fprintf(os.Stderr, "warning: failed to write JUnit report: %v\n", err)
-					}
+// resolveSelection folds --with-deps into the scenario selection: the
+// dependency closure of a scenario is exactly the chain --target runs, so
+// --scenario X --with-deps is sent as target X.
+func resolveSelection(scenario, target string, withDeps bool) (string, string, error) {
+	if !withDeps {
+		return scenario, target, nil
+	}
+	if scenario == "" {
+		return "", "", fmt.Errorf("--with-deps needs --scenario")
+	}
+	if target != "" {
+		return "", "", fmt.Errorf("--with-deps and --target both select a dependency chain; use one")
+	}
+	return "", scenario, nil
+}
+
+// pulledInDependencies returns the scenarios of a dependency-chain run other
+// than its targets — the ones the chain pulled in — in run order.
+func pulledInDependencies(scenarios []api.ScenarioSummary, targets ...string) []string {
+	var deps []string
+	for _, s := range scenarios {
+		if !slices.Contains(targets, s.Name) {
+			deps = append(deps, s.Name)
+		}
+	}
+	return deps
+}
+
+// suiteOutcome is what one suite run contributes to a (possibly
+// multi-suite) start: its scenario results and the FAIL/ERROR flags
+// that feed the process exit code.
+type suiteOutcome struct {
+	results              []*newtrun.ScenarioResult
+	hasFailure, hasError bool
+	resumedFrom          string // run ID of the paused run this one resumed
+}
+
+// runSuite submits one suite run, streams its events to the terminal (or
+// the monitor dashboard) until SuiteEnd, and returns the scenario results.
+// The returned error is always an infrastructure error (stream lost, run
+// aborted); test failures are reported through the outcome flags.
+func runSuite(parent context.Context, c *client.Client, req api.StartRunRequest, monitor bool) (suiteOutcome, error) {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	started, err := c.StartRun(ctx, req)
+	if err != nil {
+		return suiteOutcome{}, err
+	}
+	fmt.Fprintf(os.Stderr, "newtrun: started suite %s at %s\n",
+		started.Suite, started.Started.Format(time.RFC3339))
+	if started.ResumedFrom != "" {
+		fmt.Fprintf(os.Stderr, "newtrun: resuming paused run %s\n", started.ResumedFrom)
+	}
+
+	// Subscribe to events; render them; cancel the stream when
+	// SuiteEnd arrives. Cancellation makes the stream return
+	// context.Canceled which we treat as the normal terminal
+	// condition.
+	//
+	// In monitor mode, the renderer skips per-event terminal
+	// output and the monitor loop is what the operator watches
+	// (auto-refresh dashboard reading state.json directly). The
+	// terminal exit code still derives from the same atomic
+	// flags so behavior matches the non-monitor case.
+	var hasFailure, hasError, suiteEndSeen, suiteAborted atomic.Bool
+	scenarioResults := make([]*newtrun.ScenarioResult, 0)
+	var resultsMu sync.Mutex
+
+	// markSuiteEnd is called from the SSE handler whenever a
+	// SuiteEnd arrives. The status field on the wire payload
+	// distinguishes "the run completed normally with N failures"
+	// (the suite was actually buggy) from "aborted" (the server
+	// shut down mid-run) — exit-code mapping below uses that
+	// distinction to avoid blaming tests for an infrastructure
+	// outage.
+	markSuiteEnd := func(ev api.Event) {
+		if ev.Type != api.EventSuiteEnd {
+			return
+		}
+		suiteEndSeen.Store(true)
+		if payload, err := json.Marshal(ev.Payload); err == nil {
+			var p api.SuiteEndPayload
+			if err := json.Unmarshal(payload, &p); err == nil {
+				if p.Status == newtrun.SuiteStatusAborted {
+					suiteAborted.Store(true)
 				}
 			}
+		}
+	}
+
+	outcome := func() suiteOutcome {
+		resultsMu.Lock()
+		defer resultsMu.Unlock()
+		return suiteOutcome{
+			results:     append([]*newtrun.ScenarioResult(nil), scenarioResults...),
+			hasFailure:  hasFailure.Load(),
+			hasError:    hasError.Load(),
+			resumedFrom: started.ResumedFrom,
+		}
+	}
 
-			if hasError.Load() {
-				return errInfraError
+	if monitor {
+		streamDone := make(chan struct{})
+		go func() {
+			defer close(streamDone)
+			_ = c.StreamEvents(ctx, started.Suite, func(ev api.Event) {
+				trackStatus(ev, &hasFailure, &hasError)
+				collectResult(ev, &scenarioResults, &resultsMu)
+				markSuiteEnd(ev)
+				if ev.Type == api.EventSuiteEnd {
+					cancel()
+				}
+			})
+		}()
+		// Brief delay so the run's initial state file lands before
+		// the monitor loop tries to read it.
+		time.Sleep(500 * time.Millisecond)
+		_ = monitorSuite(started.Suite, true)
+		<-streamDone
+	} else {
+		streamErr := c.StreamEvents(ctx, started.Suite, func(ev api.Event) {
+			renderEvent(ev, &hasFailure, &hasError)
+			if req.Target != "" {
+				renderDependencies(ev, req.Target)
+			} else if len(req.Scenarios) > 0 {
+				renderDependencies(ev, req.Scenarios...)
 			}
-			if hasFailure.Load() {
-				return errTestFailure
+			collectResult(ev, &scenarioResults, &resultsMu)
+			markSuiteEnd(ev)
+			if ev.Type == api.EventSuiteEnd {
+				cancel()
 			}
-			return nil
-		},
+		})
+		if streamErr != nil && ctx.Err() == nil {
+			// The SSE stream broke before we asked it to. This
+			// is almost always the server going away mid-run
+			// (SIGKILL with no drain; network blip). Report it
+			// as infrastructure error with a clearer message
+			// than the raw transport error, so an operator
+			// doesn't mistake it for a tool bug.
+			return outcome(), fmt.Errorf("%w: newtrun-server connection lost mid-run; check state.json for the last persisted status",
+				errInfraError)
+		}
 	}
 
-	cmd.Flags().StringVar(&scenario, "scenario", "", "run specific scenario (default: all)")
-	cmd.Flags().StringVar(&target, "target", "", "run minimal dependency chain to reach scenario")
-	cmd.Flags().StringVar(&platform, "platform", "", "override platform")
-	cmd.Flags().StringVar(&junitPath, "junit", "", "JUnit XML output path")
-	cmd.Flags().StringVar(&serverURL, "server", "", "newtron-server URL (default: http://127.0.0.1:18080, env: NEWTRON_SERVER)")
-	cmd.Flags().StringVar(&networkID, "network-id", "", "newtron network identifier (env: NEWTRON_NETWORK_ID). Empty by default — newtrun-server derives the id from suite.Network so concurrent suites don't compete for one 'default' slot (#116).")
-	cmd.Flags().BoolVarP(&monitor, "monitor", "m", false, "show live status dashboard during run")
-	cmd.Flags().BoolVar(&noDeploy, "no-deploy", false, "skip topology deployment (for loopback/offline mode)")
-	cmd.Flags().StringArrayVar(&params, "param", nil, "override a suite-level parameter; repeatable, format key=value (e.g. --param alice_basic_auth=$(echo -n alice:pw | base64))")
-	return cmd
+	// If the stream ended cleanly but we never saw SuiteEnd, the
+	// server-side run was reaped (server shut down between events)
+	// — surface it the same way as the broken-stream case.
+	if !suiteEndSeen.Load() {
+		return outcome(), fmt.Errorf("%w: stream ended without SuiteEnd; the server may have been shut down mid-run",
+			errInfraError)
+	}
+	// Aborted suites are infrastructure errors, not test failures —
+	// don't blame the suite for the server's shutdown.
+	if suiteAborted.Load() {
+		return outcome(), fmt.Errorf("%w: run was aborted (server shut down)", errInfraError)
+	}
+	return outcome(), nil
+}
+
+// transitionTopology prepares the lab for the next suite in a multi-suite
+// start. When next runs on the same topology as prev, the lab is reused
+// as-is (the runner's EnsureTopology skips a redeploy). When it needs a
+// different topology, prev's lab is destroyed first so the two never hold
+// host resources at once. Mirrors `newtrun stop`'s destroy path: lab state
+// is newtlab's (§27), consulted through its HTTP surface.
+func transitionTopology(ctx context.Context, c *client.Client, prev, next string) error {
+	prevState, err := c.GetRun(ctx, prev)
+	if err != nil || prevState == nil {
+		return nil // nothing recorded for prev — nothing to tear down
+	}
+	prevTopology := resolveTopologyFromState(prevState)
+	if prevTopology == "" {
+		return nil
+	}
+	nextSuite, err := c.ListSuiteScenarios(ctx, next)
+	if err != nil {
+		return fmt.Errorf("%w: reading suite %s: %v", errInfraError, next, err)
+	}
+	if nextSuite.Network == prevTopology {
+		return nil
+	}
+	lc := newNewtlabClient()
+	if _, err := lc.LabStatus(ctx, prevTopology); err != nil {
+		return nil // not deployed
+	}
+	fmt.Fprintf(os.Stderr, "newtrun: destroying topology %s (suite %s needs %s)\n", prevTopology, next, nextSuite.Network)
+	if err := lc.Destroy(ctx, prevTopology); err != nil {
+		return fmt.Errorf("%w: destroy topology %s: %v", errInfraError, prevTopology, err)
+	}
+	return nil
 }
 
 // collectResult builds ScenarioResults from SSE events so the CLI can
@@ -316,14 +555,25 @@ func collectResult(ev api.Event, results *[]*newtrun.ScenarioResult, mu *sync.Mu
 		SkipReason: p.SkipReason,
 	}
 	for _, s := range p.Steps {
-		r.Steps = append(r.Steps, newtrun.StepResult{
+		step := newtrun.StepResult{
 			Name:      s.Name,
 			Action:    s.Action,
 			Status:    s.Status,
 			Duration:  parseDuration(s.Duration),
 			Message:   s.Message,
+			Output:    s.Output,
 			Iteration: s.Iteration,
-		})
+			Item:      s.Item,
+			Artifacts: s.Artifacts,
+			Observed:  s.Observed,
+			Changes:   s.Changes,
+		}
+		for _, d := range s.Details {
+			step.Details = append(step.Details, newtrun.DeviceResult{
+				Device: d.Device, Status: d.Status, Message: d.Message, Output: d.Output,
+			})
+		}
+		r.Steps = append(r.Steps, step)
 	}
 	mu.Lock()
 	*results = append(*results, r)
@@ -382,15 +632,34 @@ func renderEvent(ev api.Event, hasFailure, hasError *atomic.Bool) {
 	case api.EventStepEnd:
 		var p api.StepEndPayload
 		_ = json.Unmarshal(payload, &p)
+		name := p.Result.Name
+		if p.Result.Item != "" {
+			name += " [item " + p.Result.Item + "]"
+		}
+		if note := newtrun.AttemptNote(p.Result.Status, p.Result.Attempts, p.Result.MaxAttempts); note != "" {
+			name += " (" + note + ")"
+		}
 		if verboseFlag {
 			fmt.Fprintf(os.Stderr, "          [%d/%d] %s %s (%s)\n",
-				p.Index+1, p.Total, p.Result.Name, p.Result.Status, p.Result.Duration)
+				p.Index+1, p.Total, name, p.Result.Status, p.Result.Duration)
 		}
 		// A failing step's message streams too — the live summary must be
 		// diagnosable without re-running with --junit (the message is the
 		// same one the junit report carries).
-		if !verboseFlag && (string(p.Result.Status) == "FAIL" || string(p.Result.Status) == "ERROR") {
-			fmt.Fprintf(os.Stderr, "          ✗ %s: %s\n", p.Result.Name, firstLines(p.Result.Message, 3))
+		failed := string(p.Result.Status) == "FAIL" || string(p.Result.Status) == "ERROR"
+		if !verboseFlag && failed {
+			fmt.Fprintf(os.Stderr, "          ✗ %s: %s\n", name, firstLines(p.Result.Message, 3))
+		}
+		// So does the command output it captured, whole (the runner has
+		// already capped it at the step's max_output_bytes).
+		if failed {
+			printIndented(p.Result.Output)
+			for _, d := range p.Result.Details {
+				if d.Output != "" {
+					fmt.Fprintf(os.Stderr, "            %s:\n", d.Device)
+					printIndented(d.Output)
+				}
+			}
 		}
 
 	case api.EventScenarioEnd:
@@ -426,11 +695,30 @@ func renderEvent(ev api.Event, hasFailure, hasError *atomic.Bool) {
 	}
 }
 
+// renderDependencies names, on SuiteStart, the scenarios a dependency-chain
+// run pulled in as dependencies of its targets.
+func renderDependencies(ev api.Event, targets ...string) {
+	if ev.Type != api.EventSuiteStart {
+		return
+	}
+	payload, err := json.Marshal(ev.Payload)
+	if err != nil {
+		return
+	}
+	var p api.SuiteStartPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return
+	}
+	if deps := pulledInDependencies(p.Scenarios, targets...); len(deps) > 0 {
+		fmt.Fprintf(os.Stderr, "newtrun: %s pulled in %d dependencies: %s\n\n", strings.Join(targets, ", "), len(deps), strings.Join(deps, ", "))
+	}
+}
+
 // firstLines returns at most n lines of s (trailing whitespace trimmed),
 // continuation lines indented to align under the step name, appending an
-// ellipsis marker when truncated — failure messages can carry multi-page
-// command output; the live stream wants the head, the junit report keeps
-// the whole thing.
+// ellipsis marker when truncated — failure messages can run to many
+// lines; the live stream wants the head, the junit report keeps the whole
+// thing.
 func firstLines(s string, n int) string {
 	lines := strings.Split(strings.TrimSpace(s), "\n")
 	if len(lines) > n {
@@ -438,3 +726,15 @@ func firstLines(s string, n int) string {
 	}
 	return strings.Join(lines, "\n            ")
 }
+
+// printIndented writes captured output to stderr, each line indented
+// under the failed step it came from.
+func printIndented(out string) {
+	out = strings.TrimRight(out, "\n")
+	if out == "" {
+		return
+	}
+	for _, line := range strings.Split(out, "\n") {
+		fmt.Fprintf(os.Stderr, "            | %s\n", line)
+	}
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtrun"
+	"github.com/aldrin-isaac/newtron/pkg/newtrun/api"
+)
+
+func TestResolveSelection(t *testing.T) {
+	tests := []struct {
+		name              string
+		scenario, target  string
+		withDeps          bool
+		wantScen, wantTgt string
+		wantErr           bool
+	}{
+		{"plain scenario", "verify-service", "", false, "verify-service", "", false},
+		{"plain target", "", "verify-service", false, "", "verify-service", false},
+		{"with-deps becomes target", "verify-service", "", true, "", "verify-service", false},
+		{"with-deps needs scenario", "", "", true, "", "", true},
+		{"with-deps and target", "verify-service", "provision", true, "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scen, tgt, err := resolveSelection(tt.scenario, tt.target, tt.withDeps)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if scen != tt.wantScen || tgt != tt.wantTgt {
+				t.Errorf("got scenario %q target %q, want %q %q", scen, tgt, tt.wantScen, tt.wantTgt)
+			}
+		})
+	}
+}
+
+func TestPulledInDependencies(t *testing.T) {
+	scenarios := []api.ScenarioSummary{{Name: "provision"}, {Name: "setup-evpn"}, {Name: "verify-service"}}
+	got := pulledInDependencies(scenarios, "verify-service")
+	if want := []string{"provision", "setup-evpn"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pulledInDependencies = %v, want %v", got, want)
+	}
+	if got := pulledInDependencies(scenarios[:1], "provision"); len(got) != 0 {
+		t.Errorf("root target pulled in %v, want none", got)
+	}
+}
+
+func TestPulledInDependencies_MultipleTargets(t *testing.T) {
+	scenarios := []api.ScenarioSummary{{Name: "provision"}, {Name: "verify-a"}, {Name: "verify-b"}}
+	got := pulledInDependencies(scenarios, "verify-a", "verify-b")
+	if want := []string{"provision"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pulledInDependencies = %v, want %v", got, want)
+	}
+}
+
+// TestCollectResult_KeepsOutput: the client-side reports (junit.xml's
+// <system-out>) are built from collected results, so a step's captured
+// output and its devices' must survive the wire.
+func TestCollectResult_KeepsOutput(t *testing.T) {
+	ev := api.Event{Type: api.EventScenarioEnd, Payload: api.ScenarioEndPayload{Name: "ping", Steps: []api.StepResultPayload{{
+		Name: "ping-host", Status: newtrun.StepStatusFailed, Output: "100% packet loss\n",
+		Details: []api.DeviceResultPayload{{Device: "leaf1", Status: newtrun.StepStatusFailed, Output: "unreachable\n"}},
+	}}}}
+	var results []*newtrun.ScenarioResult
+	collectResult(ev, &results, &sync.Mutex{})
+	if len(results) != 1 || len(results[0].Steps) != 1 {
+		t.Fatalf("collected %+v", results)
+	}
+	s := results[0].Steps[0]
+	if s.Output != "100% packet loss\n" || len(s.Details) != 1 || s.Details[0].Output != "unreachable\n" {
+		t.Errorf("step = %+v, want its output and leaf1's", s)
+	}
+}
@@ -38,11 +38,13 @@ Lifecycle:
   newtrun status                     # check progress
   newtrun pause                      # stop after current scenario
   newtrun start <suite>              # resume from where it left off
+  newtrun rerun [<run-id>]           # re-run only a finished run's failures
   newtrun stop                       # tear down topology and clean state
 
 Discovery:
   newtrun list                       # show available suites
   newtrun list <suite>               # show scenarios in a suite
+  newtrun plan <suite>               # show what a run would do, without running
   newtrun topologies                 # show available topologies`,
 		SilenceUsage:      true,
 		SilenceErrors:     true,
@@ -57,10 +59,12 @@ Discovery:
 
 	rootCmd.AddCommand(
 		startCmd,
+		newRerunCmd(),
 		newPauseCmd(),
 		newStopCmd(),
 		newStatusCmd(),
 		newListCmd(),
+		newPlanCmd(),
 		newSuitesCmd(),
 		newSuiteCmd(),
 		newScenarioCmd(),
@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Tree produces an indented hierarchy drawn with box-drawing connectors:
+//
+//	root
+//	├── child
+//	│   └── grandchild
+//	└── child
+//
+// A root with an empty label prints only its children, as a forest. A
+// multi-line label continues under its own connector column.
+type Tree struct {
+	label    string
+	children []*Tree
+}
+
+// NewTree creates a tree with the given root label.
+func NewTree(label string) *Tree {
+	return &Tree{label: label}
+}
+
+// Add appends a child with the given label and returns it, so callers can
+// add grandchildren.
+func (t *Tree) Add(label string) *Tree {
+	child := &Tree{label: label}
+	t.children = append(t.children, child)
+	return child
+}
+
+// String renders the tree, one line per label line.
+func (t *Tree) String() string {
+	var b strings.Builder
+	if t.label != "" {
+		for _, line := range strings.Split(t.label, "\n") {
+			b.WriteString(line + "\n")
+		}
+	}
+	t.writeChildren(&b, "")
+	return b.String()
+}
+
+func (t *Tree) writeChildren(b *strings.Builder, prefix string) {
+	for i, child := range t.children {
+		connector, childPrefix := "├── ", prefix+"│   "
+		if i == len(t.children)-1 {
+			connector, childPrefix = "└── ", prefix+"    "
+		}
+		lines := strings.Split(child.label, "\n")
+		b.WriteString(prefix + connector + lines[0] + "\n")
+		for _, line := range lines[1:] {
+			cont := childPrefix
+			if len(child.children) > 0 {
+				cont += "│ "
+			}
+			b.WriteString(strings.TrimRight(cont+line, " ") + "\n")
+		}
+		child.writeChildren(b, childPrefix)
+	}
+}
+
+// Flush writes the rendered tree to stdout.
+func (t *Tree) Flush() {
+	fmt.Fprint(os.Stdout, t.String())
+}
@@ -0,0 +1,34 @@
+package cli
+
+import "testing"
+
+func TestTree_String(t *testing.T) {
+	root := NewTree("suite")
+	a := root.Add("a")
+	a.Add("a1")
+	a.Add("a2\nsecond line")
+	b := root.Add("b\nnote")
+	b.Add("b1")
+
+	want := `suite
+├── a
+│   ├── a1
+│   └── a2
+│       second line
+└── b
+    │ note
+    └── b1
+`
+	if got := root.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestTree_EmptyRootIsForest(t *testing.T) {
+	root := NewTree("")
+	root.Add("x")
+	root.Add("y")
+	if got, want := root.String(), "├── x\n└── y\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -101,6 +101,9 @@ func (s *Server) handleDeploy(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	lab.Force = req.Force
+	if req.Retries != nil {
+		lab.DeployRetries = *req.Retries
+	}
 	lab.OrchestratorURL = s.cfg.OrchestratorURL
 	if req.Host != "" {
 		lab.FilterHost(req.Host)
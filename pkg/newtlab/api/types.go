@@ -51,6 +51,11 @@ type DeployRequest struct {
 	// Parallel sets the parallelism for the provisioning pass (only
 	// applied when Provision is true). Zero = newtlab default (1).
 	Parallel int `json:"parallel,omitempty"`
+
+	// Retries bounds retries on transient launch failures (a taken port,
+	// a locked disk). Nil = newtlab default (2); 0 fails on the first error.
+	// Equivalent to --retries on the CLI.
+	Retries *int `json:"retries,omitempty"`
 }
 
 // LabOpResponse is the HTTP 202 Accepted ack for an async long-running lab
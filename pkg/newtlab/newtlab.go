@@ -74,6 +74,11 @@ type Lab struct {
 	Force        bool
 	DeviceFilter []string // if non-empty, only provision these devices
 
+	// DeployRetries bounds how many times Deploy retries a transient launch
+	// failure (a taken port, a locked disk) after tearing the partial deploy
+	// down; 0 fails on the first error. NewLab sets DefaultDeployRetries.
+	DeployRetries int
+
 	// OrchestratorURL is the base URL of newtlab-server (or the
 	// composed newt-server). newtlink processes started by setupBridges
 	// push their BridgeStats here every pushInterval — see #118 and
@@ -130,6 +135,7 @@ func NewLab(ctx context.Context, client NewtronClient, networkID string) (*Lab,
 		StateDir:      LabDir(networkID),
 		Profiles:      make(map[string]*spec.NodeSpec),
 		Nodes:         make(map[string]*NodeConfig),
+		DeployRetries: DefaultDeployRetries,
 		newtronClient: client,
 	}
 
@@ -377,6 +383,18 @@ func (l *Lab) buildHostMap() map[string]HostMapping {
 func (l *Lab) Deploy(ctx context.Context) error {
 	util.Logger.Infof("newtlab: deploying lab %s", l.NetworkID)
 
+	retries, err := l.deployWithRetry(ctx)
+	if retries > 0 && l.State != nil {
+		l.State.DeployRetries = retries
+		SaveState(l.State)
+	}
+	return err
+}
+
+// deployAttempt is one pass of Deploy: probe ports, create disks, start the
+// bridge workers and VMs, bootstrap and patch them. A failed attempt leaves
+// its partial state behind for deployWithRetry (or the operator) to tear down.
+func (l *Lab) deployAttempt(ctx context.Context) error {
 	// Check for stale state
 	if existing, err := LoadState(l.NetworkID); err == nil && existing != nil {
 		if !l.Force {
@@ -933,6 +951,16 @@ func (l *Lab) startNodes(ctx context.Context) error {
 		SaveState(l.State)
 		l.progress("start", fmt.Sprintf("booted %s (pid %d)", name, pid))
 	}
+
+	// A QEMU that cannot bind its console or SSH-forward port exits at once;
+	// catch it here, with its log line, rather than as a bootstrap timeout.
+	select {
+	case <-ctx.Done():
+	case <-time.After(launchSettle):
+		if err := l.checkLaunchedNodes(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
 	return firstErr
 }
 
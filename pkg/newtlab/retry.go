@@ -0,0 +1,230 @@
+package newtlab
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// Deploy retry. A lab bring-up can fail for reasons that have nothing to do
+// with the lab: a port taken between the probe and QEMU's bind, a disk lock
+// still held by a QEMU that is exiting, a console socket that a loaded host
+// is slow to open. Deploy retries those — a bounded number of times, tearing
+// the partial deploy down first so no VM, bridge worker, overlay disk or
+// state ledger from the failed attempt survives into the next one — and
+// fails immediately on everything else: a missing image, a bad platform, a
+// failed login are config errors that no retry fixes.
+
+// DefaultDeployRetries is how many times Deploy retries a transient failure
+// unless the caller sets Lab.DeployRetries.
+const DefaultDeployRetries = 2
+
+// launchSettle is how long startNodes waits after launching local QEMU
+// processes before checking that none exited at launch. A bind failure
+// (console or SSH-forward port taken) kills QEMU within milliseconds. A var
+// so tests can shorten it.
+var launchSettle = time.Second
+
+// transientFailure is one recognized transient deploy failure: a substring of
+// the (lower-cased) error and whether it means a port was taken, in which case
+// the retry allocates fresh ports.
+type transientFailure struct {
+	match        string
+	portConflict bool
+}
+
+var transientFailures = []transientFailure{
+	{"address already in use", true},                // QEMU or newtlink bind
+	{"could not set up host forwarding rule", true}, // QEMU hostfwd (SSH) bind
+	{"port conflicts", true},                        // ProbeAllPorts
+	{"bridge not ready", true},                      // newtlink never listened: usually a taken link port
+	{`failed to get "write" lock`, false},           // overlay still locked by an exiting QEMU
+	{"resource temporarily unavailable", false},
+	{"serial console connect timeout", false}, // QEMU slow to open its console on a loaded host
+	{"connection reset by peer", false},
+}
+
+// classifyDeployError reports whether err is a recognized transient deploy
+// failure, and whether it is a port conflict.
+func classifyDeployError(err error) (transient, portConflict bool) {
+	if err == nil {
+		return false, false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, f := range transientFailures {
+		if strings.Contains(msg, f.match) {
+			transient = true
+			portConflict = portConflict || f.portConflict
+		}
+	}
+	return transient, portConflict
+}
+
+// runDeployAttempt and cleanupPartialDeploy are package vars so the retry
+// loop can be tested without launching VMs.
+var (
+	runDeployAttempt     = (*Lab).deployAttempt
+	cleanupPartialDeploy = (*Lab).cleanupPartialDeploy
+)
+
+// deployWithRetry runs deploy attempts until one succeeds, one fails for a
+// reason that is not transient, or l.DeployRetries retries are spent. It
+// returns the last attempt's error and the number of retries taken.
+func (l *Lab) deployWithRetry(ctx context.Context) (int, error) {
+	retries := 0
+	for {
+		err := runDeployAttempt(l, ctx)
+		if err == nil || ctx.Err() != nil {
+			return retries, err
+		}
+		transient, portConflict := classifyDeployError(err)
+		if !transient {
+			return retries, err
+		}
+		if retries >= l.DeployRetries {
+			if retries > 0 {
+				err = fmt.Errorf("%w (gave up after %d retries)", err, retries)
+			}
+			return retries, err
+		}
+		retries++
+
+		util.Logger.Warnf("newtlab: deploy of %s failed transiently, retrying (%d/%d): %v", l.NetworkID, retries, l.DeployRetries, err)
+		l.progress("retry", fmt.Sprintf("transient failure, retrying (%d/%d): %s", retries, l.DeployRetries, firstLine(err.Error())))
+		if cerr := cleanupPartialDeploy(l); cerr != nil {
+			return retries, fmt.Errorf("%w; cleanup before retry failed: %v", err, cerr)
+		}
+		if portConflict {
+			if perr := l.reallocatePorts(); perr != nil {
+				return retries, fmt.Errorf("%w; port reallocation failed: %v", err, perr)
+			}
+		}
+	}
+}
+
+// cleanupPartialDeploy tears down whatever a failed attempt started — VMs,
+// bridge workers, the state ledger and the lab directory with its overlay
+// disks — so the next attempt starts from nothing. An attempt that failed
+// before writing state may still have created directories; those go too.
+func (l *Lab) cleanupPartialDeploy() error {
+	if l.State != nil {
+		if err := l.teardown(l.State, false); err != nil {
+			return err
+		}
+		l.State = nil
+		return nil
+	}
+	return RemoveState(l.NetworkID)
+}
+
+// reallocatePorts gives every node and link fresh ports, none of which the
+// failed attempt used, and rewrites the NIC connect addresses that name the
+// link ports.
+func (l *Lab) reallocatePorts() error {
+	used := map[int]bool{}
+	for _, node := range l.Nodes {
+		used[node.SSHPort] = true
+		used[node.ConsolePort] = true
+	}
+	for _, lc := range l.Links {
+		used[lc.APort] = true
+		used[lc.ZPort] = true
+	}
+
+	for _, name := range sortedNodeNames(l.Nodes) {
+		node := l.Nodes[name]
+		sshPort, err := findFreeLocalPort(node.SSHPort, used)
+		if err != nil {
+			return fmt.Errorf("newtlab: reallocate SSH port for %s: %w", name, err)
+		}
+		used[sshPort] = true
+		consolePort, err := findFreeLocalPort(node.ConsolePort, used)
+		if err != nil {
+			return fmt.Errorf("newtlab: reallocate console port for %s: %w", name, err)
+		}
+		used[consolePort] = true
+		util.WithDevice(name).Infof("newtlab: ports reallocated (ssh %d→%d, console %d→%d)",
+			node.SSHPort, sshPort, node.ConsolePort, consolePort)
+		node.SSHPort, node.ConsolePort = sshPort, consolePort
+	}
+
+	for i, lc := range l.Links {
+		aPort, err := findFreeLocalPort(lc.APort, used)
+		if err != nil {
+			return fmt.Errorf("newtlab: reallocate link %d A-side port: %w", i, err)
+		}
+		used[aPort] = true
+		zPort, err := findFreeLocalPort(lc.ZPort, used)
+		if err != nil {
+			return fmt.Errorf("newtlab: reallocate link %d Z-side port: %w", i, err)
+		}
+		used[zPort] = true
+		lc.APort, lc.ZPort = aPort, zPort
+		l.reconnectNIC(lc.A, lc.WorkerHost, aPort)
+		l.reconnectNIC(lc.Z, lc.WorkerHost, zPort)
+	}
+	return nil
+}
+
+// reconnectNIC points the data NIC behind a link endpoint at the link's new
+// bridge port.
+func (l *Lab) reconnectNIC(ep LinkEndpoint, workerHost string, port int) {
+	node := l.Nodes[ep.Device]
+	if node == nil {
+		return
+	}
+	for i := range node.NICs {
+		if node.NICs[i].Index == ep.NICIndex && node.NICs[i].ConnectAddr != "" {
+			node.NICs[i].ConnectAddr = connectAddr(node.Host, workerHost, port, l.Config)
+		}
+	}
+}
+
+// checkLaunchedNodes marks local nodes whose QEMU already exited as errored
+// and returns an error carrying the tail of the first one's log — the line
+// that names the cause ("Address already in use", a locked disk). Remote
+// QEMU output goes to /dev/null; a remote launch failure surfaces later, at
+// bootstrap.
+func (l *Lab) checkLaunchedNodes() error {
+	var firstErr error
+	for _, name := range sortedNodeNames(l.Nodes) {
+		node, ns := l.Nodes[name], l.State.Nodes[name]
+		if node.Host != "" || ns == nil || ns.Status != "running" || isRunningLocal(ns.PID) {
+			continue
+		}
+		ns.Status = "error"
+		ns.Phase = ""
+		if firstErr == nil {
+			firstErr = fmt.Errorf("newtlab: %s exited at launch: %s", name,
+				lastLogLine(filepath.Join(l.StateDir, "logs", name+".log")))
+		}
+	}
+	return firstErr
+}
+
+// lastLogLine returns the last non-empty line of a log file, or a placeholder
+// when there is none.
+func lastLogLine(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "(no log)"
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+	return "(empty log)"
+}
+
+// firstLine returns s up to its first newline.
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
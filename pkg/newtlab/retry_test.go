@@ -0,0 +1,161 @@
+package newtlab
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestClassifyDeployError(t *testing.T) {
+	tests := []struct {
+		err                     string
+		transient, portConflict bool
+	}{
+		{"newtlab: leaf1 exited at launch: qemu-system-x86_64: -serial tcp::30000,server,nowait: Failed to bind socket: Address already in use", true, true},
+		{"newtlab: spine1 exited at launch: Could not set up host forwarding rule 'tcp::40000-:22'", true, true},
+		{"newtlab: port conflicts:\nleaf1 SSH port 40001 in use", true, true},
+		{`newtlab: leaf1 exited at launch: Failed to get "write" lock`, true, false},
+		{"newtlab: serial console connect timeout for 127.0.0.1:30000", true, false},
+		{"newtlab: bootstrap: login failed (user=admin)", false, false},
+		{"newtlab: create overlay: image /images/missing.qcow2 not found", false, false},
+	}
+	for _, tt := range tests {
+		transient, portConflict := classifyDeployError(errors.New(tt.err))
+		if transient != tt.transient || portConflict != tt.portConflict {
+			t.Errorf("classify(%q) = %v, %v; want %v, %v", tt.err, transient, portConflict, tt.transient, tt.portConflict)
+		}
+	}
+}
+
+// retryHarness stubs the attempt and cleanup hooks: each attempt returns the
+// next error from errs (nil once they run out), and cleanups are counted.
+func retryHarness(t *testing.T, errs ...error) (attempts, cleanups *int) {
+	t.Helper()
+	realAttempt, realCleanup := runDeployAttempt, cleanupPartialDeploy
+	t.Cleanup(func() { runDeployAttempt, cleanupPartialDeploy = realAttempt, realCleanup })
+	attempts, cleanups = new(int), new(int)
+	runDeployAttempt = func(l *Lab, ctx context.Context) error {
+		*attempts++
+		if len(errs) == 0 {
+			return nil
+		}
+		err := errs[0]
+		errs = errs[1:]
+		return err
+	}
+	cleanupPartialDeploy = func(l *Lab) error {
+		*cleanups++
+		return nil
+	}
+	return attempts, cleanups
+}
+
+func TestDeployWithRetry(t *testing.T) {
+	stubPortFinder(t)
+	inUse := errors.New("newtlab: leaf1 exited at launch: Address already in use")
+
+	t.Run("transient then success", func(t *testing.T) {
+		attempts, cleanups := retryHarness(t, inUse)
+		l := &Lab{DeployRetries: 2, Nodes: map[string]*NodeConfig{"leaf1": {Name: "leaf1", SSHPort: 40000, ConsolePort: 30000}}}
+		retries, err := l.deployWithRetry(context.Background())
+		if err != nil || retries != 1 || *attempts != 2 || *cleanups != 1 {
+			t.Fatalf("err=%v retries=%d attempts=%d cleanups=%d; want nil 1 2 1", err, retries, *attempts, *cleanups)
+		}
+		if n := l.Nodes["leaf1"]; n.SSHPort == 40000 || n.ConsolePort == 30000 {
+			t.Errorf("ports not reallocated after address-in-use: ssh %d console %d", n.SSHPort, n.ConsolePort)
+		}
+	})
+
+	t.Run("config error fails at once", func(t *testing.T) {
+		attempts, cleanups := retryHarness(t, errors.New("newtlab: bootstrap: login failed (user=admin)"))
+		retries, err := (&Lab{DeployRetries: 2}).deployWithRetry(context.Background())
+		if err == nil || retries != 0 || *attempts != 1 || *cleanups != 0 {
+			t.Fatalf("err=%v retries=%d attempts=%d cleanups=%d; want error 0 1 0", err, retries, *attempts, *cleanups)
+		}
+	})
+
+	t.Run("retries are bounded", func(t *testing.T) {
+		attempts, _ := retryHarness(t, inUse, inUse, inUse, inUse)
+		retries, err := (&Lab{DeployRetries: 2}).deployWithRetry(context.Background())
+		if err == nil || !strings.Contains(err.Error(), "gave up after 2 retries") || retries != 2 || *attempts != 3 {
+			t.Fatalf("err=%v retries=%d attempts=%d; want give-up after 2 retries, 3 attempts", err, retries, *attempts)
+		}
+	})
+}
+
+// TestReallocatePorts pins that a retry's ports are all fresh, distinct, and
+// that each data NIC is re-pointed at its link's new bridge port.
+func TestReallocatePorts(t *testing.T) {
+	stubPortFinder(t)
+	l := &Lab{
+		Config: &VMLabConfig{},
+		Nodes: map[string]*NodeConfig{
+			"leaf1":  {Name: "leaf1", SSHPort: 40000, ConsolePort: 30000, NICs: []NICConfig{{Index: 0}, {Index: 1, ConnectAddr: "127.0.0.1:20000"}}},
+			"spine1": {Name: "spine1", SSHPort: 40001, ConsolePort: 30001, NICs: []NICConfig{{Index: 0}, {Index: 1, ConnectAddr: "127.0.0.1:20001"}}},
+		},
+		Links: []*LinkConfig{{
+			A:     LinkEndpoint{Device: "leaf1", Interface: "Ethernet0", NICIndex: 1},
+			Z:     LinkEndpoint{Device: "spine1", Interface: "Ethernet0", NICIndex: 1},
+			APort: 20000, ZPort: 20001,
+		}},
+	}
+	if err := l.reallocatePorts(); err != nil {
+		t.Fatalf("reallocatePorts: %v", err)
+	}
+
+	old := map[int]bool{40000: true, 40001: true, 30000: true, 30001: true, 20000: true, 20001: true}
+	seen := map[int]bool{}
+	for _, p := range []int{l.Nodes["leaf1"].SSHPort, l.Nodes["leaf1"].ConsolePort, l.Nodes["spine1"].SSHPort,
+		l.Nodes["spine1"].ConsolePort, l.Links[0].APort, l.Links[0].ZPort} {
+		if old[p] || seen[p] {
+			t.Errorf("port %d reused", p)
+		}
+		seen[p] = true
+	}
+	if got := l.Nodes["leaf1"].NICs[1].ConnectAddr; got != "127.0.0.1:20002" {
+		t.Errorf("leaf1 NIC connect = %q, want 127.0.0.1:20002", got)
+	}
+	if got := l.Nodes["spine1"].NICs[1].ConnectAddr; got != "127.0.0.1:20003" {
+		t.Errorf("spine1 NIC connect = %q, want 127.0.0.1:20003", got)
+	}
+}
+
+// TestCheckLaunchedNodes pins launch-failure detection: a local node whose
+// process is gone is marked errored and reported with its last log line.
+func TestCheckLaunchedNodes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "logs"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	log := "qemu-system-x86_64: starting\nqemu-system-x86_64: -serial tcp::30000: Failed to bind socket: Address already in use\n"
+	if err := os.WriteFile(filepath.Join(dir, "logs", "leaf1.log"), []byte(log), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dead := exec.Command("true")
+	if err := dead.Run(); err != nil {
+		t.Skipf("cannot run true: %v", err)
+	}
+
+	l := &Lab{
+		StateDir: dir,
+		Nodes:    map[string]*NodeConfig{"leaf1": {Name: "leaf1"}, "leaf2": {Name: "leaf2"}},
+		State: &LabState{Nodes: map[string]*NodeState{
+			"leaf1": {PID: dead.Process.Pid, Status: "running", Phase: "booting"},
+			"leaf2": {PID: os.Getpid(), Status: "running", Phase: "booting"},
+		}},
+	}
+	err := l.checkLaunchedNodes()
+	if err == nil || !strings.Contains(err.Error(), "leaf1 exited at launch") || !strings.Contains(err.Error(), "Address already in use") {
+		t.Fatalf("err = %v", err)
+	}
+	if s := l.State.Nodes["leaf1"].Status; s != "error" {
+		t.Errorf("leaf1 status = %q, want error", s)
+	}
+	if s := l.State.Nodes["leaf2"].Status; s != "running" {
+		t.Errorf("leaf2 status = %q, want running", s)
+	}
+}
@@ -58,6 +58,10 @@ type LabState struct {
 	// redeploy. It authorizes only this lab's stats push (least privilege), not
 	// any user-facing operation. See handlePushBridgeStats.
 	TelemetryToken string `json:"telemetry_token,omitempty"`
+	// DeployRetries is how many transient failures the deploy that created
+	// this lab retried past (see Lab.DeployRetries); 0 when the first attempt
+	// succeeded.
+	DeployRetries int `json:"deploy_retries,omitempty"`
 }
 
 // NodeState tracks per-node runtime state.
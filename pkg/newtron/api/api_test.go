@@ -82,6 +82,8 @@ func TestAPICompleteness(t *testing.T) {
 		"Network": {
 			// Spec reads
 			"ListSpecInstances":       true,
+			"Lint":                    true,
+			"SpecImpact":              true,
 			"ListServices":            true,
 			"ShowService":             true,
 			"ListIPVPNs":              true,
@@ -162,9 +164,13 @@ func TestAPICompleteness(t *testing.T) {
 			"AddTopologyLink":      true, // #16: POST /networks/{netID}/topology/create-link
 			"DeleteTopologyLink":   true, // #16: POST /networks/{netID}/topology/delete-link
 			"TopologyNodeNames":    true,
+			"InterfacesByRole":     true,
 			"IsHostDevice":         true,
 			"GetHostConnection":    true,
 			"InitDevice":           true,
+			"ApplyMTUPolicy":       true,
+			"ApplyServiceChecked":  true, // POST .../apply-service with verify: true
+			"RolloutService":       true,
 			// Connection
 			"ListNodes": true,
 			// Platform-supported interface inventory (issue #403)
@@ -172,6 +178,7 @@ func TestAPICompleteness(t *testing.T) {
 			// Device status (issue #75A+B)
 			"ProbeOnline":   true, // GET /networks/{netID}/nodes/{device}/status
 			"TopologyDrift": true, // GET /networks/{netID}/nodes/{device}/intent/topology-drift
+			"DiffDevices":   true, // GET /networks/{netID}/diff-devices
 			// Authorization-table inspector (issue #150)
 			"GetAuthorization": true, // GET /networks/{netID}/authorization
 			"AddSuperUser":     true, // POST /networks/{netID}/super-users
@@ -188,29 +195,50 @@ func TestAPICompleteness(t *testing.T) {
 			"DeviceInfo":              true,
 			"ShowInterfaceDetail":     true,
 			"GetServiceBindingDetail": true,
+			"GetServiceBindings":      true, // GET /networks/{netID}/nodes/{device}/service-bindings
 			"VLANStatus":              true,
 			"ShowVLAN":                true,
+			"GetVLANMembership":       true,
 			"VRFStatus":               true,
 			"ShowVRF":                 true,
+			"RouteLeaks":              true,
+			"GetRouteLeakHealth":      true,
+			"GetLeakedRoutes":         true,
 			"ListACLs":                true,
 			"ShowACL":                 true,
 			"BGPStatus":               true,
 			"EVPNStatus":              true,
 			"LAGStatus":               true,
 			"ShowLAGDetail":           true,
+			"GetLAGHashDistribution":  true,
 			"HealthCheck":             true,
+			"GetEnvironment":          true,
+			"GetVXLANStats":           true,
+			"GetBreakoutCapabilities": true, // GET /networks/{netID}/nodes/{device}/breakout-modes
+			"GetSyslogTail":           true,
+			"GetDHCPLeases":           true,
+			"GetIGMPSnooping":         true,
+			"GetFlapHistory":          true, // GET .../interfaces/{name}/flap-history
+			"GetARPSuppressionState":  true,
+			"GetEVPNMACIPRoutes":      true,
 			"CheckBGPSessions":        true,
+			"GetBGPConvergenceState":  true,
+			"GetConfigErrors":         true,
 			"GetRoute":                true,
 			"GetRouteASIC":            true,
+			"GetNextHopGroups":        true,
 			// DB queries
-			"QueryConfigDB":       true,
-			"ConfigDBTableKeys":   true,
-			"ConfigDBEntryExists": true,
-			"ConfigDBSnapshot":    true, // #17: GET /networks/{netID}/nodes/{device}/configdb
-			"IntentSnapshot":      true, // GET /networks/{netID}/nodes/{device}/intent/snapshot
-			"OperDBSnapshot":      true, // GET /networks/{netID}/nodes/{device}/db/{db}
-			"OperDBTable":         true, // GET /networks/{netID}/nodes/{device}/db/{db}/{table}
-			"OperDBEntry":         true, // GET /networks/{netID}/nodes/{device}/db/{db}/{table}/{key...}
+			"QueryConfigDB":           true,
+			"ConfigDBTableKeys":       true,
+			"ConfigDBEntryExists":     true,
+			"ConfigDBSnapshot":        true, // #17: GET /networks/{netID}/nodes/{device}/configdb
+			"ExportConfigDB":          true, // GET /networks/{netID}/nodes/{device}/configdb/export
+			"RenderConfigDB":          true, // GET /networks/{netID}/nodes/{device}/configdb/export?mode=topology
+			"GetRunningConfigSection": true, // GET /networks/{netID}/nodes/{device}/running-config
+			"IntentSnapshot":          true, // GET /networks/{netID}/nodes/{device}/intent/snapshot
+			"OperDBSnapshot":          true, // GET /networks/{netID}/nodes/{device}/db/{db}
+			"OperDBTable":             true, // GET /networks/{netID}/nodes/{device}/db/{db}/{table}
+			"OperDBEntry":             true, // GET /networks/{netID}/nodes/{device}/db/{db}/{table}/{key...}
 			// Write operations
 			"AddBGPEVPNPeer":          true,
 			"UpdateBGPEVPNPeer":       true,
@@ -223,13 +251,21 @@ func TestAPICompleteness(t *testing.T) {
 			"ConfigureIRB":            true,
 			"UpdateIRB":               true, // POST /networks/{netID}/nodes/{device}/update-irb
 			"UnconfigureIRB":          true,
+			"ConfigureDHCPServer":     true,
+			"UnconfigureDHCPServer":   true,
+			"SetIGMPSnooping":         true,
 			"CreateVRF":               true,
 			"DeleteVRF":               true,
 			"BindIPVPN":               true,
 			"UnbindIPVPN":             true,
 			"AddStaticRoute":          true,
+			"AddStaticRouteMulti":     true, // POST /networks/{netID}/nodes/{device}/add-static-route
 			"UpdateStaticRoute":       true,
 			"RemoveStaticRoute":       true,
+			"AddRouteLeak":            true,
+			"RemoveRouteLeak":         true,
+			"SetVRFRouteTargets":      true,
+			"ClearVRFRouteTargets":    true,
 			"CreateACL":               true,
 			"DeleteACL":               true,
 			"AddACLRule":              true,
@@ -239,7 +275,19 @@ func TestAPICompleteness(t *testing.T) {
 			"DeletePortChannel":       true,
 			"AddPortChannelMember":    true,
 			"RemovePortChannelMember": true,
+			"SetLAGHashPolicy":        true,
+			"ClearLAGHashPolicy":      true,
+			"SetBGPMaxPaths":          true,
+			"ClearBGPMaxPaths":        true,
+			"SetCounterPolling":       true,
+			"ClearCounterPolling":     true,
+			"SetBanner":               true,
+			"ClearBanner":             true,
+			"CreateMirrorSession":     true,
+			"DeleteMirrorSession":     true,
 			"ConfigReload":            true,
+			"ConfigReloadStatus":      true, // GET /networks/{netID}/nodes/{device}/reload-status
+			"ImportConfigDB":          true, // POST /networks/{netID}/nodes/{device}/configdb/import
 			"RestartService":          true,
 			"RefreshBGP":              true, // POST /networks/{netID}/nodes/{device}/refresh-bgp
 			"ExecCommand":             true,
@@ -248,6 +296,7 @@ func TestAPICompleteness(t *testing.T) {
 			"ProjectionDiff": true, // #4: POST /networks/{netID}/nodes/{device}/intent/projection-diff
 			"Tree":           true,
 			"Drift":          true,
+			"ImportIntent":   true, // GET /networks/{netID}/nodes/{device}/intent/import
 			"Reconcile":      true,
 		},
 		"Interface": {
@@ -263,9 +312,17 @@ func TestAPICompleteness(t *testing.T) {
 			"ClearProperty":        true,
 			"ConfigureInterface":   true,
 			"RemoveTrunkVLAN":      true,
+			"SetVLANTranslation":   true,
+			"ClearVLANTranslation": true,
 			"UnconfigureInterface": true,
 			"BindQoS":              true,
 			"UnbindQoS":            true,
+			"SetEgressShaper":      true,
+			"ClearEgressShaper":    true,
+			"Isolate":              true,
+			"Unisolate":            true,
+			"EgressShaper":         true, // GET .../interfaces/{name}/egress-shaper
+			"ServiceHealth":        true, // GET .../interfaces/{name}/service-health
 			"Status":               true, // GET .../interfaces/{name}/status
 		},
 	}
@@ -280,27 +337,34 @@ func TestAPICompleteness(t *testing.T) {
 			"BuildEmptyTopologyNode": "intent save/reload helpers — invoked via intent/save and intent/reload handlers",
 			"BuildTopologyNode":      "intent save/reload helpers — invoked via intent/save and intent/reload handlers",
 			"InitFromDeviceIntent":   "intent mode initialization — invoked by NodeActor.ensureActuatedIntent",
+			"ConnectAll":             "in-process warm-up for embedding callers; newtron-server connects per NodeActor on first request",
 			"SaveDeviceIntents":      "intent save — invoked by handleSave via nodeActor.execute",
 			"CheckAuthReadGate":      "auth gate helper invoked by handleGetAuthorization for the engage-when-configured PermAuthRead check (#187); not a request-handled action",
 			"CheckAuditReadGate":     "auth gate helper invoked by handleAuditEvents and handleAuditIntegrity for the engage-when-configured PermAuditRead check (#196); not a request-handled action",
 		},
 		"Node": {
-			"BindsService":        "internal helper for /service/{name}/projection — pre-check before ServiceProjection",
-			"ServiceProjection":   "internal helper used by /service/{name}/projection handler per-actor; no separate per-Node endpoint",
-			"SetDeviceMetadata":   "used internally by InitDevice; no direct HTTP endpoint",
-			"Interface":           "interface access is via URL path, not a method call",
-			"Lock":                "server handles locking internally via connectAndExecute",
-			"Unlock":              "server handles locking internally via connectAndExecute",
-			"Close":               "server handles connection lifecycle",
-			"Commit":              "server handles commit via Execute/connectAndExecute",
-			"PendingPreview":      "exposed through WriteResult.Preview in Execute",
-			"PendingCount":        "exposed through WriteResult.ChangeCount",
-			"Ping":                "server-internal connectivity check in NodeActor",
-			"HasActuatedIntent":   "server-internal check for node initialization state",
-			"HasUnsavedIntents":   "server-internal state tracking",
-			"ClearUnsavedIntents": "server-internal state management",
-			"DisconnectTransport": "server-internal lifecycle management",
-			"RebuildProjection":   "called by execute() at start of each operation",
+			"BindsService":          "internal helper for /service/{name}/projection — pre-check before ServiceProjection",
+			"ServiceProjection":     "internal helper used by /service/{name}/projection handler per-actor; no separate per-Node endpoint",
+			"SetDeviceMetadata":     "used internally by InitDevice; no direct HTTP endpoint",
+			"Interface":             "interface access is via URL path, not a method call",
+			"ResolveInterfaceAlias": "interface URLs resolve aliases server-side through GetInterface; no separate endpoint",
+			"Lock":                  "server handles locking internally via connectAndExecute",
+			"Unlock":                "server handles locking internally via connectAndExecute",
+			"Close":                 "server handles connection lifecycle",
+			"Commit":                "server handles commit via Execute/connectAndExecute",
+			"PendingPreview":        "exposed through WriteResult.Preview in Execute",
+			"PendingCount":          "exposed through WriteResult.ChangeCount",
+			"Ping":                  "server-internal connectivity check in NodeActor",
+			"HasActuatedIntent":     "server-internal check for node initialization state",
+			"HasUnsavedIntents":     "server-internal state tracking",
+			"CanSafelyReload":       "exposed as ConfigReloadStatus (GET reload-status); consulted by ConfigReload",
+			"ClearUnsavedIntents":   "server-internal state management",
+			"DisconnectTransport":   "server-internal lifecycle management",
+			"RebuildProjection":     "called by execute() at start of each operation",
+			"UplinkInterfaces":      "in-process convenience over Network.InterfacesByRole (GET topology/nodes/{name}/interfaces?role=uplink)",
+			"DownlinkInterfaces":    "in-process convenience over Network.InterfacesByRole (GET topology/nodes/{name}/interfaces?role=downlink)",
+			"FabricInterfaces":      "in-process convenience over Network.InterfacesByRole (GET topology/nodes/{name}/interfaces?role=fabric)",
+			"ApplyServiceBatch":     "used by RolloutService (POST rollout); gated per interface like ApplyService",
 		},
 		"Interface": {},
 	}
@@ -372,6 +436,9 @@ func TestAPICompleteness(t *testing.T) {
 			"AddTopologyLink":      auth.PermSpecAuthor,
 			"DeleteTopologyLink":   auth.PermSpecAuthor,
 			"InitDevice":           auth.PermDeviceWrite,
+			"ApplyMTUPolicy":       auth.PermInterfaceModify, // gated per interface inside SetProperty
+			"ApplyServiceChecked":  auth.PermServiceApply,    // gated in ApplyService (rollback: RemoveService)
+			"RolloutService":       auth.PermServiceApply,    // gated per interface in ApplyService
 		},
 		"Node": {
 			"AddBGPEVPNPeer":          auth.PermEVPNPeer,
@@ -385,13 +452,21 @@ func TestAPICompleteness(t *testing.T) {
 			"ConfigureIRB":            auth.PermVLANModify,
 			"UpdateIRB":               auth.PermVLANModify,
 			"UnconfigureIRB":          auth.PermVLANModify,
+			"ConfigureDHCPServer":     auth.PermVLANModify,
+			"UnconfigureDHCPServer":   auth.PermVLANModify,
+			"SetIGMPSnooping":         auth.PermVLANModify,
 			"CreateVRF":               auth.PermVRFCreate,
 			"DeleteVRF":               auth.PermVRFDelete,
 			"BindIPVPN":               auth.PermVRFBind,
 			"UnbindIPVPN":             auth.PermVRFBind,
 			"AddStaticRoute":          auth.PermVRFRoute,
+			"AddStaticRouteMulti":     auth.PermVRFRoute,
 			"UpdateStaticRoute":       auth.PermVRFRoute,
 			"RemoveStaticRoute":       auth.PermVRFRoute,
+			"AddRouteLeak":            auth.PermVRFRoute,
+			"RemoveRouteLeak":         auth.PermVRFRoute,
+			"SetVRFRouteTargets":      auth.PermVRFBind,
+			"ClearVRFRouteTargets":    auth.PermVRFBind,
 			"CreateACL":               auth.PermACLCreate,
 			"DeleteACL":               auth.PermACLDelete,
 			"AddACLRule":              auth.PermACLModify,
@@ -401,7 +476,18 @@ func TestAPICompleteness(t *testing.T) {
 			"DeletePortChannel":       auth.PermLAGDelete,
 			"AddPortChannelMember":    auth.PermLAGModify,
 			"RemovePortChannelMember": auth.PermLAGModify,
+			"SetLAGHashPolicy":        auth.PermLAGModify,
+			"ClearLAGHashPolicy":      auth.PermLAGModify,
+			"SetBGPMaxPaths":          auth.PermDeviceWrite,
+			"ClearBGPMaxPaths":        auth.PermDeviceWrite,
+			"SetCounterPolling":       auth.PermDeviceWrite,
+			"ClearCounterPolling":     auth.PermDeviceWrite,
+			"SetBanner":               auth.PermDeviceWrite,
+			"ClearBanner":             auth.PermDeviceWrite,
+			"CreateMirrorSession":     auth.PermDeviceWrite,
+			"DeleteMirrorSession":     auth.PermDeviceWrite,
 			"ConfigReload":            auth.PermDeviceWrite,
+			"ImportConfigDB":          auth.PermDeviceWrite,
 			"RestartService":          auth.PermDeviceWrite,
 			"RefreshBGP":              auth.PermDeviceWrite,
 			"ExecCommand":             auth.PermDeviceWrite,
@@ -421,9 +507,15 @@ func TestAPICompleteness(t *testing.T) {
 			"ClearProperty":        auth.PermInterfaceModify,
 			"ConfigureInterface":   auth.PermInterfaceModify,
 			"RemoveTrunkVLAN":      auth.PermInterfaceModify,
+			"SetVLANTranslation":   auth.PermInterfaceModify,
+			"ClearVLANTranslation": auth.PermInterfaceModify,
 			"UnconfigureInterface": auth.PermInterfaceModify,
 			"BindQoS":              auth.PermQoSModify,
 			"UnbindQoS":            auth.PermQoSModify,
+			"SetEgressShaper":      auth.PermQoSModify,
+			"ClearEgressShaper":    auth.PermQoSModify,
+			"Isolate":              auth.PermInterfaceModify,
+			"Unisolate":            auth.PermInterfaceModify,
 		},
 	}
 
@@ -435,6 +527,8 @@ func TestAPICompleteness(t *testing.T) {
 	readOnlyMethods := map[string]map[string]string{
 		"Network": {
 			"ListSpecInstances":       "spec read",
+			"Lint":                    "spec read",
+			"SpecImpact":              "spec read",
 			"ListServices":            "spec read",
 			"ShowService":             "spec read",
 			"ListIPVPNs":              "spec read",
@@ -449,7 +543,7 @@ func TestAPICompleteness(t *testing.T) {
 			"ListPlatforms":           "spec read",
 			"ShowPlatform":            "spec read",
 			"NodeInterfaceInventory":  "spec read — platform-supported interface inventory (#403)",
-			"PlatformPortDefaults":           "spec read — default port-config authoring template (#301)",
+			"PlatformPortDefaults":    "spec read — default port-config authoring template (#301)",
 			"ListRoutePolicies":       "spec read",
 			"ListPrefixLists":         "spec read",
 			"ShowPrefixList":          "spec read",
@@ -466,35 +560,59 @@ func TestAPICompleteness(t *testing.T) {
 			"GetTopology":             "spec read",
 			"TopologyView":            "spec read",
 			"TopologyNodeNames":       "spec read",
+			"InterfacesByRole":        "spec read (topology links)",
 			"IsHostDevice":            "spec read",
 			"GetHostConnection":       "spec read",
 			"ListNodes":               "spec read",
 			"ProbeOnline":             "device read (TCP probe + newtlab port resolve)",
 			"TopologyDrift":           "device read (diff topology against device CONFIG_DB)",
+			"DiffDevices":             "device read (normalized CONFIG_DB diff of two devices)",
 			"GetAuthorization":        "spec read (authorization-table inspector)",
 		},
 		"Node": {
 			"DeviceInfo":              "device read",
 			"ShowInterfaceDetail":     "device read",
 			"GetServiceBindingDetail": "device read",
+			"GetServiceBindings":      "device read",
 			"VLANStatus":              "device read",
 			"ShowVLAN":                "device read",
+			"GetVLANMembership":       "device read",
 			"VRFStatus":               "device read",
 			"ShowVRF":                 "device read",
+			"RouteLeaks":              "device read",
+			"GetRouteLeakHealth":      "device read",
+			"GetLeakedRoutes":         "device read",
 			"ListACLs":                "device read",
 			"ShowACL":                 "device read",
 			"BGPStatus":               "device read",
 			"EVPNStatus":              "device read",
 			"LAGStatus":               "device read",
 			"ShowLAGDetail":           "device read",
+			"GetLAGHashDistribution":  "device read",
 			"HealthCheck":             "device read",
+			"ConfigReloadStatus":      "device read — reload safety check; reloads nothing",
+			"GetEnvironment":          "device read",
+			"GetVXLANStats":           "device read",
+			"GetBreakoutCapabilities": "device read",
+			"GetSyslogTail":           "device read",
+			"GetDHCPLeases":           "device read",
+			"GetIGMPSnooping":         "device read",
+			"GetFlapHistory":          "device read",
+			"GetARPSuppressionState":  "device read",
+			"GetEVPNMACIPRoutes":      "device read",
 			"CheckBGPSessions":        "device read",
+			"GetBGPConvergenceState":  "device read",
+			"GetConfigErrors":         "device read",
 			"GetRoute":                "device read",
 			"GetRouteASIC":            "device read",
+			"GetNextHopGroups":        "device read",
 			"QueryConfigDB":           "device read",
 			"ConfigDBTableKeys":       "device read",
 			"ConfigDBEntryExists":     "device read",
 			"ConfigDBSnapshot":        "device read",
+			"ExportConfigDB":          "device read",
+			"RenderConfigDB":          "device read",
+			"GetRunningConfigSection": "device read",
 			"IntentSnapshot":          "device read",
 			"OperDBSnapshot":          "device read",
 			"OperDBTable":             "device read",
@@ -503,10 +621,13 @@ func TestAPICompleteness(t *testing.T) {
 			"ProjectionDiff":          "intent dry-run preview (no device writes)",
 			"Tree":                    "intent read",
 			"Drift":                   "intent + device read",
+			"ImportIntent":            "device read (brownfield report, writes nothing)",
 			"Execute":                 "orchestration wrapper — gates fire on each mutation inside fn",
 		},
 		"Interface": {
-			"Status": "device read",
+			"Status":        "device read",
+			"EgressShaper":  "device read",
+			"ServiceHealth": "device read",
 		},
 	}
 
@@ -723,6 +844,43 @@ func TestHandleProjection_ReturnsRawConfigDB(t *testing.T) {
 	}
 }
 
+// TestHandleExportConfigDB_TopologyRendersOffline: in topology mode there is
+// no device to export, so configdb/export renders the projection as
+// config_db.json — same rows, NEWTRON_INTENT included — without a
+// connection. 1node-vs has no reachable device here, so an attempt to read
+// one would fail the request.
+func TestHandleExportConfigDB_TopologyRendersOffline(t *testing.T) {
+	s := newTestServer(t)
+
+	w := httpDo(t, s, http.MethodGet,
+		"/newtron/v1/networks/default/nodes/switch1/configdb/export?mode=topology")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	resp := decodeAPIResponse(t, w)
+	doc, _ := json.Marshal(resp.Data)
+	rendered, err := sonic.DecodeConfigDBJSON(strings.NewReader(string(doc)))
+	if err != nil {
+		t.Fatalf("export is not config_db.json: %v", err)
+	}
+
+	w = httpDo(t, s, http.MethodGet,
+		"/newtron/v1/networks/default/nodes/switch1/intent/projection?mode=topology")
+	raw, _ := json.Marshal(decodeAPIResponse(t, w).Data)
+	var proj map[string]map[string]map[string]string
+	if err := json.Unmarshal(raw, &proj); err != nil {
+		t.Fatalf("projection: %v", err)
+	}
+	for _, table := range []string{"DEVICE_METADATA", "NEWTRON_INTENT"} {
+		if len(rendered[table]) == 0 {
+			t.Errorf("%s missing from the rendered config_db.json", table)
+		}
+		if !reflect.DeepEqual(rendered[table], proj[table]) {
+			t.Errorf("%s rendered = %v, want the projection's %v", table, rendered[table], proj[table])
+		}
+	}
+}
+
 // TestHandleConfigDBSnapshot_RouteRegistered — newtron#17 (Cluster D).
 // Confirms the configdb snapshot route is registered and returns either a
 // successful snapshot (200 + Data envelope) when a device is reachable on
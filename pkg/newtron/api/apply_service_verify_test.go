@@ -0,0 +1,40 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestApplyService_VerifyValidation covers the verify: options that are
+// rejected before the device is contacted — each would otherwise apply a
+// service the handler then cannot check.
+func TestApplyService_VerifyValidation(t *testing.T) {
+	s := newTestServer(t)
+	const path = "/newtron/v1/networks/default/nodes/switch1/interfaces/Ethernet0/apply-service"
+
+	tests := []struct {
+		name  string
+		query string
+		body  map[string]any
+		want  string
+	}{
+		{"on_verify_fail without verify", "", map[string]any{"service": "transit", "on_verify_fail": "rollback"}, "require verify: true"},
+		{"verify_timeout without verify", "", map[string]any{"service": "transit", "verify_timeout": 30}, "require verify: true"},
+		{"dry run", "?dry_run=true", map[string]any{"service": "transit", "verify": true}, "not supported with dry_run"},
+		{"topology mode", "?mode=topology", map[string]any{"service": "transit", "verify": true}, "not supported in topology"},
+		{"timeout too long", "", map[string]any{"service": "transit", "verify": true, "verify_timeout": 600}, "must be 1-240 seconds"},
+		{"unknown on_verify_fail", "", map[string]any{"service": "transit", "verify": true, "on_verify_fail": "retry"}, "must be leave or rollback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httpPostJSON(t, s, path+tt.query, tt.body)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400; body: %s", w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("body %s, want it to mention %q", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
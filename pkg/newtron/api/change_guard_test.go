@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestChangeGuard refuses a write over the network's change_guard with a 409
+// carrying the counts and the refused preview, and lets the same write
+// through with force=true. Dry runs are never guarded.
+func TestChangeGuard(t *testing.T) {
+	specDir := copyTestSpecDir(t)
+	path := filepath.Join(specDir, "network.json")
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read network.json: %v", err)
+	}
+	var netSpec map[string]any
+	if err := json.Unmarshal(raw, &netSpec); err != nil {
+		t.Fatalf("parse network.json: %v", err)
+	}
+	netSpec["change_guard"] = map[string]any{"max_changes": 1}
+	if raw, err = json.Marshal(netSpec); err != nil {
+		t.Fatalf("marshal network.json: %v", err)
+	}
+	if err := os.WriteFile(path, raw, 0o644); err != nil {
+		t.Fatalf("write network.json: %v", err)
+	}
+	s := NewServer(Config{})
+	if err := s.RegisterNetwork("default", specDir); err != nil {
+		t.Fatalf("RegisterNetwork: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Stop(t.Context()) })
+
+	const url = "/newtron/v1/networks/default/nodes/switch1/create-vlan?mode=topology"
+	body := map[string]any{"id": 993, "vni": 10993}
+
+	w := httpPostJSON(t, s, url+"&dry_run=true", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("dry run: got %d, want 201; body: %s", w.Code, w.Body.String())
+	}
+
+	w = httpPostJSON(t, s, url, body)
+	if w.Code != http.StatusConflict {
+		t.Fatalf("guarded write: got %d, want 409; body: %s", w.Code, w.Body.String())
+	}
+	var env struct {
+		Error string `json:"error"`
+		Data  struct {
+			Changes    int      `json:"changes"`
+			Tables     []string `json:"tables"`
+			MaxChanges int      `json:"max_changes"`
+			Result     struct {
+				ChangeCount int  `json:"change_count"`
+				Applied     bool `json:"applied"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &env); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !strings.Contains(env.Error, "exceed max_changes 1") || env.Data.MaxChanges != 1 ||
+		env.Data.Changes < 2 || env.Data.Result.ChangeCount != env.Data.Changes || env.Data.Result.Applied {
+		t.Errorf("refusal = %+v", env)
+	}
+
+	// Nothing was written: the forced retry creates the VLAN afresh.
+	w = httpPostJSON(t, s, url+"&force=true", body)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("forced write: got %d, want 201; body: %s", w.Code, w.Body.String())
+	}
+}
@@ -74,8 +74,12 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/prefix-lists", s.handleListPrefixLists)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/prefix-lists/{name}", s.handleShowPrefixList)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/spec-instances", s.handleSpecInstances)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/lint", s.handleLint)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/impact", s.handleSpecImpact)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/diff-devices", s.handleDiffDevices)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/topology", s.handleTopology)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/topology/nodes", s.handleTopologyDeviceNames)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/topology/nodes/{name}/interfaces", s.handleTopologyInterfacesByRole)
 	// Node placement in topology follows the node definition (#393):
 	// create-node auto-scaffolds the /setup-device entry and delete-node
 	// removes it, so there is no standalone topology create-node endpoint.
@@ -123,6 +127,8 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/remove-qos-queue", s.handleRemoveQoSQueue)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/create-filter", s.handleCreateFilter)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/delete-filter", s.handleDeleteFilter)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/apply-mtu-policy", s.handleApplyMTUPolicy)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/rollout-service", s.handleRolloutService)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/add-filter-rule", s.handleAddFilterRule)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/update-filter-rule", s.handleUpdateFilterRule)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/remove-filter-rule", s.handleRemoveFilterRule)
@@ -167,24 +173,43 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}", s.handleShowInterface)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/binding", s.handleShowServiceBinding)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/status", s.handleInterfaceStatus)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/flap-history", s.handleFlapHistory)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/egress-shaper", s.handleEgressShaper)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/service-health", s.handleServiceHealth)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/service-bindings", s.handleListServiceBindings)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/vlans", s.handleListVLANs)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/vlans/{id}", s.handleShowVLAN)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/vlans/membership", s.handleVLANMembership)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/vlans/{id}/igmp-snooping", s.handleIGMPSnooping)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/dhcp-leases", s.handleDHCPLeases)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/vrfs", s.handleListVRFs)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/vrfs/{name}", s.handleShowVRF)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/route-leaks", s.handleListRouteLeaks)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/route-leaks/{vrf}/health", s.handleRouteLeakHealth)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/vrfs/{name}/leaked-routes", s.handleLeakedRoutes)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/acls", s.handleListACLs)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/acls/{name}", s.handleShowACL)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/bgp/status", s.handleBGPStatus)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/evpn/status", s.handleEVPNStatus)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/evpn/vxlan-stats", s.handleVXLANStats)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/evpn/arp-suppression", s.handleARPSuppression)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/evpn/macip-routes", s.handleEVPNMACIPRoutes)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/health", s.handleHealthCheck)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/environment", s.handleEnvironment)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/breakout-modes", s.handleBreakoutModes)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/syslog", s.handleSyslog)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/config-errors", s.handleConfigErrors)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/lags", s.handleListLAGs)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/routes/{vrf}/{prefix...}", s.handleGetRoute)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/routes-asic/{prefix...}", s.handleGetRouteASIC)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/next-hop-groups", s.handleNextHopGroups)
 
 	// ====================================================================
 	// Node write operations (RPC-style: verb in URL, POST for all writes)
 	// ====================================================================
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/bind-macvpn", s.handleNodeBindMACVPN)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/unbind-macvpn", s.handleNodeUnbindMACVPN)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/reload-status", s.handleReloadStatus)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/reload-config", s.handleReloadConfig)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/save-config", s.handleSaveConfig)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/ssh-command", s.handleSSHCommand)
@@ -193,6 +218,10 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/configure-irb", s.handleConfigureIRB)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/update-irb", s.handleUpdateIRB)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/unconfigure-irb", s.handleUnconfigureIRB)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/configure-dhcp-server", s.handleConfigureDHCPServer)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/unconfigure-dhcp-server", s.handleUnconfigureDHCPServer)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/set-igmp-snooping", s.handleSetIGMPSnooping)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/disable-igmp-snooping", s.handleDisableIGMPSnooping)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/create-vrf", s.handleCreateVRF)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/delete-vrf", s.handleDeleteVRF)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/bind-ipvpn", s.handleBindIPVPN)
@@ -200,6 +229,10 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/add-static-route", s.handleAddStaticRoute)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/update-static-route", s.handleUpdateStaticRoute)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/remove-static-route", s.handleRemoveStaticRoute)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/add-route-leak", s.handleAddRouteLeak)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/remove-route-leak", s.handleRemoveRouteLeak)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/set-vrf-route-targets", s.handleSetVRFRouteTargets)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/clear-vrf-route-targets", s.handleClearVRFRouteTargets)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/create-acl", s.handleCreateACL)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/delete-acl", s.handleDeleteACL)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/add-acl-rule", s.handleAddACLRule)
@@ -209,6 +242,16 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/delete-portchannel", s.handleDeletePortChannel)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/add-portchannel-member", s.handleAddPortChannelMember)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/remove-portchannel-member", s.handleRemovePortChannelMember)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/set-lag-hash-policy", s.handleSetLAGHashPolicy)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/clear-lag-hash-policy", s.handleClearLAGHashPolicy)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/set-bgp-max-paths", s.handleSetBGPMaxPaths)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/clear-bgp-max-paths", s.handleClearBGPMaxPaths)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/set-counter-polling", s.handleSetCounterPolling)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/clear-counter-polling", s.handleClearCounterPolling)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/set-banner", s.handleSetBanner)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/clear-banner", s.handleClearBanner)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/create-mirror-session", s.handleCreateMirrorSession)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/delete-mirror-session", s.handleDeleteMirrorSession)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/add-bgp-evpn-peer", s.handleAddBGPEVPNPeer)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/update-bgp-evpn-peer", s.handleUpdateBGPEVPNPeer)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/remove-bgp-evpn-peer", s.handleRemoveBGPEVPNPeer)
@@ -216,6 +259,9 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/refresh-bgp", s.handleRefreshBGP)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/setup-device", s.handleSetupDevice)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/configdb", s.handleConfigDBSnapshot)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/configdb/export", s.handleExportConfigDB)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/running-config", s.handleRunningConfig)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/configdb/import", s.handleImportConfigDB)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/configdb/{table}", s.handleConfigDBTableKeys)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/configdb/{table}/{key}", s.handleQueryConfigDB)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/configdb/{table}/{key}/exists", s.handleConfigDBEntryExists)
@@ -223,7 +269,9 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/db/{db}/{table}", s.handleOperDBTable)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/db/{db}/{table}/{key...}", s.handleOperDBEntry)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/bgp/check", s.handleCheckBGPSessions)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/bgp/convergence", s.handleGetBGPConvergence)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/lags/{name}", s.handleShowLAGDetail)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/lags/{name}/hash-distribution", s.handleLAGHashDistribution)
 
 	// ====================================================================
 	// Intent operations
@@ -234,6 +282,7 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/intent/snapshot", s.handleIntentSnapshot)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/status", s.handleNodeStatus)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/intent/drift", s.handleDrift)
+	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/intent/import", s.handleImportIntent)
 	mux.HandleFunc("GET /newtron/v1/networks/{netID}/nodes/{node}/intent/topology-drift", s.handleTopologyDrift)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/intent/reconcile", s.handleReconcile)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/intent/save", s.handleSave)
@@ -256,8 +305,14 @@ func (s *Server) buildMux() http.Handler {
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/clear-property", s.handleClearProperty)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/configure-interface", s.handleConfigureInterface)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/remove-trunk-vlan", s.handleRemoveTrunkVLAN)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/set-vlan-translation", s.handleSetVLANTranslation)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/clear-vlan-translation", s.handleClearVLANTranslation)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/bind-qos", s.handleBindQoS)
 	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/unbind-qos", s.handleUnbindQoS)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/set-egress-shaper", s.handleSetEgressShaper)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/clear-egress-shaper", s.handleClearEgressShaper)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/isolate-interface", s.handleIsolateInterface)
+	mux.HandleFunc("POST /newtron/v1/networks/{netID}/nodes/{node}/interfaces/{name}/unisolate-interface", s.handleUnisolateInterface)
 
 	// Apply middleware chain (outermost → innermost):
 	//   recovery → logger → requestID → caller → audit → timeout → persist → mode → mux
@@ -294,10 +349,11 @@ func (s *Server) buildMux() http.Handler {
 //
 // For typed errors that carry actionable substrate, the typed payload is
 // propagated as the Data field of the envelope so consumers see what newtron
-// computed — §46 (HTTP API Boundary) on the failure path. Today two error
-// kinds carry typed Data:
+// computed — §46 (HTTP API Boundary) on the failure path. The error kinds
+// that carry typed Data:
 //
 //   - VerificationFailedError → WriteResult (Verification, DeviceOps, Changes)
+//   - ServiceVerifyFailedError → ServiceResult (apply, failing checks, rollback)
 //   - AuthorizationError → the AuthorizationError itself (Caller, Permission,
 //     Resource) per auth-design.md L3
 //
@@ -311,6 +367,14 @@ func writeError(w http.ResponseWriter, err error) {
 	if errors.As(err, &verFailed) && verFailed.Result != nil {
 		envelope.Data = verFailed.Result
 	}
+	var svcFailed *newtron.ServiceVerifyFailedError
+	if errors.As(err, &svcFailed) && svcFailed.Result != nil {
+		envelope.Data = svcFailed.Result
+	}
+	var guard *newtron.ChangeGuardError
+	if errors.As(err, &guard) {
+		envelope.Data = guard
+	}
 	var authz *newtron.AuthorizationError
 	if errors.As(err, &authz) {
 		envelope.Data = authz
@@ -368,13 +432,15 @@ func (s *Server) requireNodeActor(w http.ResponseWriter, r *http.Request) (*netw
 	return ne, ne.getNodeActor(device)
 }
 
-// execOpts reads dry_run and no_save query params.
+// execOpts reads dry_run, no_save and force query params.
 func execOpts(r *http.Request) newtron.ExecOpts {
 	dryRun := r.URL.Query().Get("dry_run") == "true"
 	noSave := r.URL.Query().Get("no_save") == "true"
+	force := r.URL.Query().Get("force") == "true"
 	return newtron.ExecOpts{
 		Execute: !dryRun,
 		NoSave:  noSave,
+		Force:   force,
 	}
 }
 
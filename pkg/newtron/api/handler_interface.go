@@ -2,7 +2,9 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/httputil"
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
@@ -13,7 +15,7 @@ import (
 // ============================================================================
 
 func (s *Server) handleApplyService(w http.ResponseWriter, r *http.Request) {
-	_, nodeActor := s.requireNodeActor(w, r)
+	ne, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
@@ -28,16 +30,21 @@ func (s *Server) handleApplyService(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	opts := execOpts(r)
+	if req.Verify || req.OnVerifyFail != "" || req.VerifyTimeout != 0 {
+		applyServiceVerified(w, r, ne, nodeActor, ifName, req, opts)
+		return
+	}
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
 		iface, err := n.Interface(ifName)
 		if err != nil {
 			return err
 		}
 		return iface.ApplyService(ctx, req.Service, newtron.ApplyServiceOpts{
-			IPAddress: req.IPAddress,
-			VLAN:      req.VLAN,
-			PeerAS:    req.PeerAS,
-			Params:    req.Params,
+			IPAddress:    req.IPAddress,
+			SecondaryIPs: req.SecondaryIPs,
+			VLAN:         req.VLAN,
+			PeerAS:       req.PeerAS,
+			Params:       req.Params,
 		})
 	})
 	if err != nil {
@@ -47,6 +54,81 @@ func (s *Server) handleApplyService(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+// maxVerifyTimeout keeps a verified apply inside the server's 5-minute write
+// timeout, with room for the apply and a rollback.
+const maxVerifyTimeout = 240
+
+// applyServiceVerified is apply-service with verify: apply, wait for the
+// service to settle, roll back on request when it does not. Each phase is
+// its own actor turn, so the wait never holds the device.
+func applyServiceVerified(w http.ResponseWriter, r *http.Request, ne *networkEntity, nodeActor *NodeActor, ifName string, req ApplyServiceRequest, opts newtron.ExecOpts) {
+	switch {
+	case !req.Verify:
+		writeError(w, &newtron.ValidationError{Field: "verify", Message: "on_verify_fail and verify_timeout require verify: true"})
+		return
+	case !opts.Execute:
+		writeError(w, &newtron.ValidationError{Field: "verify", Message: "verify needs an applied service; not supported with dry_run"})
+		return
+	case modeFromCtx(r.Context()) != ModeIntent:
+		writeError(w, &newtron.ValidationError{Field: "verify", Message: "verify reads device state; not supported in topology or loopback mode"})
+		return
+	case req.VerifyTimeout < 0 || req.VerifyTimeout > maxVerifyTimeout:
+		writeError(w, &newtron.ValidationError{Field: "verify_timeout", Message: fmt.Sprintf("must be 1-%d seconds (omit for 60)", maxVerifyTimeout)})
+		return
+	}
+	verify := newtron.ServiceVerifyOpts{Timeout: time.Duration(req.VerifyTimeout) * time.Second}
+	switch req.OnVerifyFail {
+	case "", "leave":
+	case "rollback":
+		verify.Rollback = true
+	default:
+		writeError(w, &newtron.ValidationError{Field: "on_verify_fail", Message: fmt.Sprintf("must be leave or rollback, got %q", req.OnVerifyFail)})
+		return
+	}
+
+	result, err := ne.net.ApplyServiceChecked(r.Context(), nodeActor.device, ifName, req.Service,
+		newtron.ApplyServiceOpts{
+			IPAddress:    req.IPAddress,
+			SecondaryIPs: req.SecondaryIPs,
+			VLAN:         req.VLAN,
+			PeerAS:       req.PeerAS,
+			Params:       req.Params,
+		}, verify,
+		func(ctx context.Context, _ string, fn func(ctx context.Context, n *newtron.Node) error) (*newtron.WriteResult, error) {
+			val, err := nodeActor.connectAndExecute(ctx, opts, fn)
+			wr, _ := val.(*newtron.WriteResult)
+			return wr, err
+		},
+		func(ctx context.Context, _ string, fn func(ctx context.Context, n *newtron.Node) (any, error)) (any, error) {
+			return nodeActor.connectAndRead(ctx, func(n *newtron.Node) (any, error) { return fn(ctx, n) })
+		})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, result)
+}
+
+func (s *Server) handleServiceHealth(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return nil, err
+		}
+		return iface.ServiceHealth(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 func (s *Server) handleRemoveService(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
@@ -351,6 +433,70 @@ func (s *Server) handleRemoveTrunkVLAN(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+func (s *Server) handleSetVLANTranslation(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	var req VLANTranslationRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.InVLAN <= 0 {
+		writeError(w, &newtron.ValidationError{Field: "in_vlan", Message: "must be positive"})
+		return
+	}
+	if req.OutVLAN <= 0 {
+		writeError(w, &newtron.ValidationError{Field: "out_vlan", Message: "must be positive"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return err
+		}
+		return iface.SetVLANTranslation(ctx, req.InVLAN, req.OutVLAN)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleClearVLANTranslation(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	var req VLANTranslationRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.InVLAN <= 0 {
+		writeError(w, &newtron.ValidationError{Field: "in_vlan", Message: "must be positive"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return err
+		}
+		return iface.ClearVLANTranslation(ctx, req.InVLAN)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 func (s *Server) handleConfigureInterface(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
@@ -421,3 +567,134 @@ func (s *Server) handleInterfaceStatus(w http.ResponseWriter, r *http.Request) {
 	}
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
+
+// handleFlapHistory returns a physical port's link-flap record from APPL_DB
+// PORT_TABLE.
+func (s *Server) handleFlapHistory(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetFlapHistory(r.Context(), ifName)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleSetEgressShaper(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	var req EgressShaperRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.RateKbps <= 0 {
+		writeError(w, &newtron.ValidationError{Field: "rate_kbps", Message: "must be positive"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return err
+		}
+		return iface.SetEgressShaper(ctx, req.RateKbps)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleClearEgressShaper(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return err
+		}
+		return iface.ClearEgressShaper(ctx)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleIsolateInterface(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return err
+		}
+		return iface.Isolate(ctx)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleUnisolateInterface(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return err
+		}
+		return iface.Unisolate(ctx)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleEgressShaper(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	ifName := interfaceName(r)
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		iface, err := n.Interface(ifName)
+		if err != nil {
+			return nil, err
+		}
+		return iface.EgressShaper(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -188,6 +189,65 @@ func (s *Server) handleSpecInstances(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, instances)
 }
 
+// handleLint runs the whole-spec modeling checks and returns every issue,
+// errors first. An issue is a finding, not a request failure — the response
+// is 200 either way; the caller decides what an error-severity issue means.
+func (s *Server) handleLint(w http.ResponseWriter, r *http.Request) {
+	ne := s.requireNetwork(w, r)
+	if ne == nil {
+		return
+	}
+	issues, err := ne.net.Lint()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, issues)
+}
+
+// handleSpecImpact reports what editing one network-scope spec would touch:
+// the specs that reference it, transitively, and the device interfaces where
+// any of them is applied. Query parameters: name (required) and kind
+// (optional; needed when the name is used by more than one kind).
+func (s *Server) handleSpecImpact(w http.ResponseWriter, r *http.Request) {
+	ne := s.requireNetwork(w, r)
+	if ne == nil {
+		return
+	}
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		writeError(w, &newtron.ValidationError{Field: "name", Message: "required"})
+		return
+	}
+	impact, err := ne.net.SpecImpact(r.URL.Query().Get("kind"), name)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, impact)
+}
+
+// handleDiffDevices compares two devices' normalized CONFIG_DBs. Devices are
+// query parameters a and b; each repeated ignore parameter names a table,
+// TABLE|KEY entry, or trailing-"*" prefix to leave out. Each device is read
+// through its own actor.
+func (s *Server) handleDiffDevices(w http.ResponseWriter, r *http.Request) {
+	ne := s.requireNetwork(w, r)
+	if ne == nil {
+		return
+	}
+	q := r.URL.Query()
+	diff, err := ne.net.DiffDevices(r.Context(), q.Get("a"), q.Get("b"), q["ignore"],
+		func(ctx context.Context, device string, fn func(ctx context.Context, n *newtron.Node) (any, error)) (any, error) {
+			return ne.getNodeActor(device).connectAndRead(ctx, func(n *newtron.Node) (any, error) { return fn(ctx, n) })
+		})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, diff)
+}
+
 func (s *Server) handleListServices(w http.ResponseWriter, r *http.Request) {
 	ne := s.requireNetwork(w, r)
 	if ne == nil {
@@ -431,6 +491,21 @@ func (s *Server) handleTopologyDeviceNames(w http.ResponseWriter, r *http.Reques
 	httputil.WriteJSON(w, http.StatusOK, ne.net.TopologyNodeNames())
 }
 
+// handleTopologyInterfacesByRole returns a topology node's interfaces with the
+// role named by ?role= (uplink, downlink, fabric), derived from the links.
+func (s *Server) handleTopologyInterfacesByRole(w http.ResponseWriter, r *http.Request) {
+	ne := s.requireNetwork(w, r)
+	if ne == nil {
+		return
+	}
+	names, err := ne.net.InterfacesByRole(r.PathValue("name"), r.URL.Query().Get("role"))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, names)
+}
+
 // handleTopology returns the full topology spec (devices + links + metadata)
 // as `spec.TopologySpecFile`. §46: canonical substrate exposed directly,
 // alongside the names-only summary at /topology/node.
@@ -1525,3 +1600,57 @@ func (s *Server) handleUpdateZone(w http.ResponseWriter, r *http.Request) {
 // authored on-disk under --platforms-base, not via wire. Matches the
 // schema-metadata's existing "platforms are read-only via universal UI"
 // declaration; adding a platform requires backend coordination.
+
+// handleRolloutService applies one service across the circuits of a rollout
+// manifest. Like apply-mtu-policy, each device's writes run through its
+// NodeActor, so the fan-out serializes with concurrent writes to the device.
+func (s *Server) handleRolloutService(w http.ResponseWriter, r *http.Request) {
+	ne := s.requireNetwork(w, r)
+	if ne == nil {
+		return
+	}
+	var spec newtron.RolloutSpec
+	if err := decodeJSON(r, &spec); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	result, err := ne.net.RolloutService(r.Context(), spec,
+		func(ctx context.Context, device string, fn func(ctx context.Context, n *newtron.Node) error) (*newtron.WriteResult, error) {
+			val, err := ne.getNodeActor(device).connectAndExecute(ctx, opts, fn)
+			wr, _ := val.(*newtron.WriteResult)
+			return wr, err
+		})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, result)
+}
+
+// handleApplyMTUPolicy sets interface MTU fleet-wide from topology roles. Each
+// device's writes run through its NodeActor, exactly as a per-node write
+// would, so the fan-out serializes with concurrent writes to the same device.
+func (s *Server) handleApplyMTUPolicy(w http.ResponseWriter, r *http.Request) {
+	ne := s.requireNetwork(w, r)
+	if ne == nil {
+		return
+	}
+	var policy newtron.MTUPolicy
+	if err := decodeJSON(r, &policy); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	result, err := ne.net.ApplyMTUPolicy(r.Context(), policy,
+		func(ctx context.Context, device string, fn func(ctx context.Context, n *newtron.Node) error) (*newtron.WriteResult, error) {
+			val, err := ne.getNodeActor(device).connectAndExecute(ctx, opts, fn)
+			wr, _ := val.(*newtron.WriteResult)
+			return wr, err
+		})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, result)
+}
@@ -2,12 +2,18 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
 )
 
 // POST /newtron/v1/networks wire shape:
@@ -163,3 +169,186 @@ func TestCreateNetwork_NoBase_Rejected(t *testing.T) {
 		t.Errorf("network should not be registered without a base")
 	}
 }
+
+// TestApplyMTUPolicy_TopologyMode fans an MTU policy across a spine–leaf–host
+// fabric: the spine–leaf link keeps its fabric MTU on both ends (already
+// 9100), the leaf's host-facing port moves to the server MTU, and the host is
+// left out.
+func TestApplyMTUPolicy_TopologyMode(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, body string) {
+		t.Helper()
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("network.json", `{"version": "1.0"}`)
+	write("zones/amer.json", `{}`)
+	write("nodes/spine1.json", `{"mgmt_ip": "127.0.0.1", "loopback_ip": "10.0.0.1", "zone": "amer", "platform": "Force10-S6000_vs", "underlay_asn": 65001, "evpn": {"route_reflector": true}}`)
+	write("nodes/leaf1.json", `{"mgmt_ip": "127.0.0.1", "loopback_ip": "10.0.0.2", "zone": "amer", "platform": "Force10-S6000_vs", "underlay_asn": 65002}`)
+	write("nodes/host1.json", `{"mgmt_ip": "127.0.0.1", "platform": "alpine-host"}`)
+	write("topology.json", `{
+		"version": "1.0",
+		"nodes": {
+			"spine1": {"steps": [{"url": "/setup-device", "params": {"fields": {"hostname": "spine1", "bgp_asn": "65001"}}}], "ports": {"Ethernet0": {"admin_status": "up", "mtu": 9100}}},
+			"leaf1": {"steps": [{"url": "/setup-device", "params": {"fields": {"hostname": "leaf1", "bgp_asn": "65002"}}}], "ports": {"Ethernet0": {"admin_status": "up", "mtu": 9100}, "Ethernet4": {"admin_status": "up", "mtu": 9100}}},
+			"host1": {}
+		},
+		"links": [
+			{"a": "spine1:Ethernet0", "z": "leaf1:Ethernet0"},
+			{"a": "host1:eth0", "z": "leaf1:Ethernet4"}
+		]
+	}`)
+	platforms, err := spec.LoadPlatformsFromDir(filepath.Join(repoRoot(t), "platforms"))
+	if err != nil {
+		t.Fatalf("LoadPlatformsFromDir: %v", err)
+	}
+	s := NewServer(Config{Platforms: platforms})
+	if err := s.RegisterNetwork("default", dir); err != nil {
+		t.Fatalf("RegisterNetwork: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+	w := httpPostJSON(t, s, "/newtron/v1/networks/default/apply-mtu-policy?mode=topology&dry_run=true",
+		newtron.MTUPolicy{Fabric: 9100, Server: 1500})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data newtron.MultiChangeSet `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	want := []newtron.DeviceChangeSet{
+		{Device: "leaf1", Interfaces: []newtron.InterfaceChange{
+			{Interface: "Ethernet0", Property: "mtu", From: "9100", To: "9100"},
+			{Interface: "Ethernet4", Property: "mtu", From: "9100", To: "1500", Changed: true},
+		}},
+		{Device: "spine1", Interfaces: []newtron.InterfaceChange{
+			{Interface: "Ethernet0", Property: "mtu", From: "9100", To: "9100"},
+		}},
+	}
+	if len(resp.Data.Devices) != len(want) {
+		t.Fatalf("devices = %+v, want leaf1 and spine1", resp.Data.Devices)
+	}
+	for i, dcs := range resp.Data.Devices {
+		if dcs.Error != "" {
+			t.Errorf("%s: error %s", dcs.Device, dcs.Error)
+		}
+		if dcs.Device != want[i].Device || !reflect.DeepEqual(dcs.Interfaces, want[i].Interfaces) {
+			t.Errorf("device %d = %s %+v, want %s %+v", i, dcs.Device, dcs.Interfaces, want[i].Device, want[i].Interfaces)
+		}
+	}
+	if leaf := resp.Data.Devices[0]; leaf.Result == nil || leaf.Result.ChangeCount == 0 {
+		t.Errorf("leaf1 result = %+v, want a dry-run preview with changes", leaf.Result)
+	}
+
+	w = httpPostJSON(t, s, "/newtron/v1/networks/default/apply-mtu-policy?mode=topology&dry_run=true",
+		newtron.MTUPolicy{Fabric: 10000})
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("out-of-range MTU: status = %d, want 400; body: %s", w.Code, w.Body.String())
+	}
+}
+
+// TestRolloutService_TopologyMode previews a two-device rollout of a routed
+// service: each device gets its circuits as one change, and a manifest with
+// bad rows is refused whole, every problem named.
+func TestRolloutService_TopologyMode(t *testing.T) {
+	dir := t.TempDir()
+	write := func(rel, body string) {
+		t.Helper()
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("network.json", `{"version": "1.0", "services": {"TRANSIT": {"service_type": "routed", "routing": {"protocol": "bgp", "peer_as": "request"}}}}`)
+	write("zones/amer.json", `{}`)
+	write("nodes/leaf1.json", `{"mgmt_ip": "127.0.0.1", "loopback_ip": "10.0.0.1", "zone": "amer", "platform": "Force10-S6000_vs", "underlay_asn": 65001}`)
+	write("nodes/leaf2.json", `{"mgmt_ip": "127.0.0.1", "loopback_ip": "10.0.0.2", "zone": "amer", "platform": "Force10-S6000_vs", "underlay_asn": 65002}`)
+	write("nodes/host1.json", `{"mgmt_ip": "127.0.0.1", "platform": "alpine-host"}`)
+	write("topology.json", `{
+		"version": "1.0",
+		"nodes": {
+			"leaf1": {"steps": [{"url": "/setup-device", "params": {"fields": {"hostname": "leaf1", "bgp_asn": "65001"}}}], "ports": {"Ethernet0": {"admin_status": "up"}, "Ethernet4": {"admin_status": "up"}}},
+			"leaf2": {"steps": [{"url": "/setup-device", "params": {"fields": {"hostname": "leaf2", "bgp_asn": "65002"}}}], "ports": {"Ethernet0": {"admin_status": "up"}}},
+			"host1": {}
+		},
+		"links": [{"a": "host1:eth0", "z": "leaf1:Ethernet8"}]
+	}`)
+	platforms, err := spec.LoadPlatformsFromDir(filepath.Join(repoRoot(t), "platforms"))
+	if err != nil {
+		t.Fatalf("LoadPlatformsFromDir: %v", err)
+	}
+	s := NewServer(Config{Platforms: platforms})
+	if err := s.RegisterNetwork("default", dir); err != nil {
+		t.Fatalf("RegisterNetwork: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Stop(context.Background()) })
+
+	w := httpPostJSON(t, s, "/newtron/v1/networks/default/rollout-service?mode=topology&dry_run=true",
+		newtron.RolloutSpec{Service: "transit", Rows: []newtron.RolloutRow{
+			{Device: "leaf2", Interface: "Ethernet0", IP: "10.1.2.0/31", PeerAS: 65102},
+			{Device: "leaf1", Interface: "Ethernet0", IP: "10.1.1.0/31", PeerAS: 65101},
+			{Device: "leaf1", Interface: "Ethernet4", IP: "10.1.1.2/31", PeerAS: 65103},
+		}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body: %s", w.Code, w.Body.String())
+	}
+	var resp struct {
+		Data newtron.MultiChangeSet `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	circuit := func(intf string) newtron.InterfaceChange {
+		return newtron.InterfaceChange{Interface: intf, Property: "service", To: "TRANSIT", Changed: true}
+	}
+	want := []newtron.DeviceChangeSet{
+		{Device: "leaf1", Interfaces: []newtron.InterfaceChange{circuit("Ethernet0"), circuit("Ethernet4")}},
+		{Device: "leaf2", Interfaces: []newtron.InterfaceChange{circuit("Ethernet0")}},
+	}
+	if len(resp.Data.Devices) != len(want) {
+		t.Fatalf("devices = %+v, want leaf1 and leaf2", resp.Data.Devices)
+	}
+	for i, dcs := range resp.Data.Devices {
+		if dcs.Error != "" {
+			t.Errorf("%s: error %s", dcs.Device, dcs.Error)
+		}
+		if dcs.Device != want[i].Device || !reflect.DeepEqual(dcs.Interfaces, want[i].Interfaces) {
+			t.Errorf("device %d = %s %+v, want %s %+v", i, dcs.Device, dcs.Interfaces, want[i].Device, want[i].Interfaces)
+		}
+		if dcs.Result == nil || dcs.Result.ChangeCount == 0 || dcs.Result.Applied {
+			t.Errorf("%s result = %+v, want an unapplied preview with changes", dcs.Device, dcs.Result)
+		}
+	}
+
+	w = httpPostJSON(t, s, "/newtron/v1/networks/default/rollout-service?mode=topology&dry_run=true",
+		newtron.RolloutSpec{Service: "transit", Rows: []newtron.RolloutRow{
+			{Device: "leaf1", Interface: "Ethernet0", IP: "10.1.1.0/31"},
+			{Device: "leaf9", Interface: "Ethernet0"},
+			{Device: "host1", Interface: "eth0"},
+			{Device: "leaf1", Interface: "Ethernet0", IP: "10.1.1.300/31"},
+		}})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("bad manifest: status = %d, want 400; body: %s", w.Code, w.Body.String())
+	}
+	for _, problem := range []string{
+		"row 2: unknown device",
+		"row 3: host1 is a host, not a switch",
+		"row 4: leaf1 Ethernet0 already appears in row 1",
+		"row 4: ip",
+	} {
+		if !strings.Contains(w.Body.String(), problem) {
+			t.Errorf("bad manifest: body %s does not name %q", w.Body.String(), problem)
+		}
+	}
+}
@@ -1,11 +1,18 @@
 package api
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/httputil"
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 )
 
 // ============================================================================
@@ -78,6 +85,21 @@ func (s *Server) handleShowServiceBinding(w http.ResponseWriter, r *http.Request
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+func (s *Server) handleListServiceBindings(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetServiceBindings(), nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 func (s *Server) handleListVLANs(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
@@ -113,6 +135,42 @@ func (s *Server) handleShowVLAN(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+// handleIGMPSnooping returns a VLAN's IGMP snooping state and learned groups.
+func (s *Server) handleIGMPSnooping(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	id, err := pathInt(r, "id")
+	if err != nil {
+		writeError(w, &newtron.ValidationError{Field: "id", Message: "invalid VLAN ID"})
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetIGMPSnooping(r.Context(), id)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleVLANMembership(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetVLANMembership(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 func (s *Server) handleListVRFs(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
@@ -144,6 +202,56 @@ func (s *Server) handleShowVRF(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+func (s *Server) handleListRouteLeaks(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.RouteLeaks(), nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleRouteLeakHealth checks the route leak into a VRF against the routes
+// that actually arrived there.
+func (s *Server) handleRouteLeakHealth(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	vrf := r.PathValue("vrf")
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetRouteLeakHealth(r.Context(), vrf)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleLeakedRoutes returns the routes leaked into a VRF from other VRFs.
+func (s *Server) handleLeakedRoutes(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	vrf := r.PathValue("name")
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetLeakedRoutes(r.Context(), vrf)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 func (s *Server) handleListACLs(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
@@ -220,6 +328,169 @@ func (s *Server) handleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+func (s *Server) handleEnvironment(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetEnvironment(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleVXLANStats(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetVXLANStats(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleEVPNMACIPRoutes returns the EVPN type-2 routes in one VNI's table.
+func (s *Server) handleEVPNMACIPRoutes(w http.ResponseWriter, r *http.Request) {
+	vni, err := strconv.Atoi(r.URL.Query().Get("vni"))
+	if err != nil || vni < 1 || vni > 16777215 {
+		writeError(w, &newtron.ValidationError{Field: "vni", Message: "required, 1-16777215"})
+		return
+	}
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetEVPNMACIPRoutes(r.Context(), vni)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleARPSuppression returns every VLAN's ARP suppression state.
+func (s *Server) handleARPSuppression(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetARPSuppressionState(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleBreakoutModes(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetBreakoutCapabilities(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleSyslog tails the device's syslog.
+//
+// Query parameters:
+//
+//	lines (default 1000, max 20000)
+//	since (RFC3339; only lines stamped at or after it)
+func (s *Server) handleSyslog(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lines := 1000
+	if v := q.Get("lines"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > newtron.MaxSyslogLines {
+			writeError(w, &newtron.ValidationError{Field: "lines", Message: fmt.Sprintf("must be 1-%d", newtron.MaxSyslogLines)})
+			return
+		}
+		lines = n
+	}
+	var since time.Time
+	if v := q.Get("since"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, &newtron.ValidationError{Field: "since", Message: "expected RFC3339 timestamp"})
+			return
+		}
+		since = t
+	}
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetSyslogTail(r.Context(), lines, since)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleDHCPLeases returns the built-in DHCP server's leases; ?vlan_id=N
+// narrows them to one VLAN.
+func (s *Server) handleDHCPLeases(w http.ResponseWriter, r *http.Request) {
+	vlanID := 0
+	if v := r.URL.Query().Get("vlan_id"); v != "" {
+		id, err := strconv.Atoi(v)
+		if err != nil || id < 1 || id > 4094 {
+			writeError(w, &newtron.ValidationError{Field: "vlan_id", Message: "must be 1-4094"})
+			return
+		}
+		vlanID = id
+	}
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetDHCPLeases(r.Context(), vlanID)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleConfigErrors(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetConfigErrors(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 func (s *Server) handleListLAGs(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
@@ -268,6 +539,29 @@ func (s *Server) handleGetRouteASIC(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+// handleNextHopGroups returns the device's ASIC_DB next-hop groups. A device
+// with no ASIC_DB (VPP) is not an error: the response is 200 with skipped set,
+// so ECMP checks can skip the platform rather than fail on it.
+func (s *Server) handleNextHopGroups(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetNextHopGroups(r.Context())
+	})
+	if errors.Is(err, newtron.ErrASICDBUnavailable) {
+		httputil.WriteJSON(w, http.StatusOK, newtron.NextHopGroupsResult{Groups: []newtron.NextHopGroup{}, Skipped: err.Error()})
+		return
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	groups, _ := val.([]newtron.NextHopGroup)
+	httputil.WriteJSON(w, http.StatusOK, newtron.NextHopGroupsResult{Groups: groups})
+}
+
 // ============================================================================
 // Node write operations
 // ============================================================================
@@ -322,13 +616,33 @@ func (s *Server) handleNodeUnbindMACVPN(w http.ResponseWriter, r *http.Request)
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+// handleReloadConfig runs config reload on the device. Refused with 409 and
+// the reason when the reload would discard unsaved work, unless ?force=true.
 func (s *Server) handleReloadConfig(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	force := r.URL.Query().Get("force") == "true"
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return nil, n.ConfigReload(r.Context(), force)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleReloadStatus reports whether reload-config would run without force,
+// and the reason it would be refused.
+func (s *Server) handleReloadStatus(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
 	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
-		return nil, n.ConfigReload(r.Context())
+		return n.ConfigReloadStatus(r.Context()), nil
 	})
 	if err != nil {
 		writeError(w, err)
@@ -489,21 +803,331 @@ func (s *Server) handleCreateVRF(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusCreated, val)
 }
 
-func (s *Server) handleDeleteVRF(w http.ResponseWriter, r *http.Request) {
+// handleDeleteVRF deletes a VRF. 409 (ConflictError) while anything still
+// depends on it; ?force=true unbinds interfaces set-vrf bound without an
+// intent, and never reaches an intent dependent.
+func (s *Server) handleDeleteVRF(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.DeleteVRF(ctx, req.Name, opts.Force)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleCreateACL(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req ACLCreateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.CreateACL(ctx, req.Name, req.Config())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, val)
+}
+
+func (s *Server) handleDeleteACL(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.DeleteACL(ctx, req.Name)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleAddACLRule(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req newtron.ACLRuleAddRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.AddACLRule(ctx, req.ACL, req.RuleName, req.Config())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, val)
+}
+
+func (s *Server) handleUpdateACLRule(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req newtron.ACLRuleUpdateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.UpdateACLRule(ctx, req.ACL, req.RuleName, req.Config())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleRemoveACLRule(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req struct {
+		ACL  string `json:"acl"`
+		Rule string `json:"rule"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.RemoveACLRule(ctx, req.ACL, req.Rule)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleCreatePortChannel(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req PortChannelCreateRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.CreatePortChannel(ctx, req.Name, req.Config())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, val)
+}
+
+func (s *Server) handleDeletePortChannel(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.DeletePortChannel(ctx, req.Name)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleAddPortChannelMember(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req PortChannelMemberRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.AddPortChannelMember(ctx, req.PortChannel, req.Interface)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, val)
+}
+
+func (s *Server) handleRemovePortChannelMember(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req PortChannelMemberRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.RemovePortChannelMember(ctx, req.PortChannel, req.Interface)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleSetLAGHashPolicy(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req LAGHashPolicyRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if len(req.HashFields) == 0 {
+		writeError(w, &newtron.ValidationError{Field: "hash_fields", Message: "at least one hash field is required"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.SetLAGHashPolicy(ctx, req.HashFields)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleClearLAGHashPolicy(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.ClearLAGHashPolicy(ctx)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleSetBGPMaxPaths(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req BGPMaxPathsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.MaxPaths <= 0 {
+		writeError(w, &newtron.ValidationError{Field: "max_paths", Message: "max_paths must be a positive path count"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.SetBGPMaxPaths(ctx, req.MaxPaths)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleClearBGPMaxPaths(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.ClearBGPMaxPaths(ctx)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleSetCounterPolling(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req struct {
-		Name string `json:"name"`
-	}
+	var req CounterPollingRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	if req.CounterType == "" {
+		writeError(w, &newtron.ValidationError{Field: "counter_type", Message: "required"})
+		return
+	}
+	if req.IntervalMs < 0 {
+		writeError(w, &newtron.ValidationError{Field: "interval_ms", Message: "must not be negative"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.DeleteVRF(ctx, req.Name)
+		return n.SetCounterPolling(ctx, req.CounterType, req.Enable, time.Duration(req.IntervalMs)*time.Millisecond)
 	})
 	if err != nil {
 		writeError(w, err)
@@ -512,42 +1136,48 @@ func (s *Server) handleDeleteVRF(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleCreateACL(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleClearCounterPolling(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req ACLCreateRequest
+	var req CounterPollingRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	if req.CounterType == "" {
+		writeError(w, &newtron.ValidationError{Field: "counter_type", Message: "required"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.CreateACL(ctx, req.Name, req.Config())
+		return n.ClearCounterPolling(ctx, req.CounterType)
 	})
 	if err != nil {
 		writeError(w, err)
 		return
 	}
-	httputil.WriteJSON(w, http.StatusCreated, val)
+	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleDeleteACL(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleSetBanner(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req struct {
-		Name string `json:"name"`
-	}
+	var req BannerRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	if req.Login == "" && req.MOTD == "" && req.Logout == "" {
+		writeError(w, &newtron.ValidationError{Message: "at least one of login, motd, or logout is required"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.DeleteACL(ctx, req.Name)
+		return n.SetBanner(ctx, req.Config())
 	})
 	if err != nil {
 		writeError(w, err)
@@ -556,40 +1186,46 @@ func (s *Server) handleDeleteACL(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleAddACLRule(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleClearBanner(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req newtron.ACLRuleAddRequest
-	if err := decodeJSON(r, &req); err != nil {
-		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
-		return
-	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.AddACLRule(ctx, req.ACL, req.RuleName, req.Config())
+		return n.ClearBanner(ctx)
 	})
 	if err != nil {
 		writeError(w, err)
 		return
 	}
-	httputil.WriteJSON(w, http.StatusCreated, val)
+	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleUpdateACLRule(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleCreateMirrorSession(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req newtron.ACLRuleUpdateRequest
+	var req MirrorSessionRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	switch {
+	case req.Name == "":
+		writeError(w, &newtron.ValidationError{Field: "name", Message: "required"})
+		return
+	case req.DstIP == "":
+		writeError(w, &newtron.ValidationError{Field: "dst_ip", Message: "required"})
+		return
+	case len(req.Ports) == 0:
+		writeError(w, &newtron.ValidationError{Field: "ports", Message: "required"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.UpdateACLRule(ctx, req.ACL, req.RuleName, req.Config())
+		return n.CreateMirrorSession(ctx, req.Name, req.Config())
 	})
 	if err != nil {
 		writeError(w, err)
@@ -598,22 +1234,23 @@ func (s *Server) handleUpdateACLRule(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleRemoveACLRule(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleDeleteMirrorSession(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req struct {
-		ACL  string `json:"acl"`
-		Rule string `json:"rule"`
-	}
+	var req DeleteMirrorSessionRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	if req.Name == "" {
+		writeError(w, &newtron.ValidationError{Field: "name", Message: "required"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.RemoveACLRule(ctx, req.ACL, req.Rule)
+		return n.DeleteMirrorSession(ctx, req.Name)
 	})
 	if err != nil {
 		writeError(w, err)
@@ -622,42 +1259,44 @@ func (s *Server) handleRemoveACLRule(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleCreatePortChannel(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleUnconfigureIRB(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req PortChannelCreateRequest
+	var req UnconfigureIRBRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.CreatePortChannel(ctx, req.Name, req.Config())
+		return n.UnconfigureIRB(ctx, req.VlanID)
 	})
 	if err != nil {
 		writeError(w, err)
 		return
 	}
-	httputil.WriteJSON(w, http.StatusCreated, val)
+	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleDeletePortChannel(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleConfigureDHCPServer(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req struct {
-		Name string `json:"name"`
-	}
+	var req DHCPServerRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	if len(req.Ranges) == 0 {
+		writeError(w, &newtron.ValidationError{Field: "ranges", Message: "required"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.DeletePortChannel(ctx, req.Name)
+		return n.ConfigureDHCPServer(ctx, req.VlanID, req.Config())
 	})
 	if err != nil {
 		writeError(w, err)
@@ -666,40 +1305,44 @@ func (s *Server) handleDeletePortChannel(w http.ResponseWriter, r *http.Request)
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleAddPortChannelMember(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleUnconfigureDHCPServer(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req PortChannelMemberRequest
+	var req UnconfigureDHCPServerRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.AddPortChannelMember(ctx, req.PortChannel, req.Interface)
+		return n.UnconfigureDHCPServer(ctx, req.VlanID)
 	})
 	if err != nil {
 		writeError(w, err)
 		return
 	}
-	httputil.WriteJSON(w, http.StatusCreated, val)
+	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleRemovePortChannelMember(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleSetIGMPSnooping(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req PortChannelMemberRequest
+	var req IGMPSnoopingRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	if req.VlanID < 1 || req.VlanID > 4094 {
+		writeError(w, &newtron.ValidationError{Field: "vlan_id", Message: "must be 1-4094"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.RemovePortChannelMember(ctx, req.PortChannel, req.Interface)
+		return n.SetIGMPSnooping(ctx, req.VlanID, req.Enable)
 	})
 	if err != nil {
 		writeError(w, err)
@@ -708,19 +1351,23 @@ func (s *Server) handleRemovePortChannelMember(w http.ResponseWriter, r *http.Re
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
-func (s *Server) handleUnconfigureIRB(w http.ResponseWriter, r *http.Request) {
+func (s *Server) handleDisableIGMPSnooping(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
-	var req UnconfigureIRBRequest
+	var req DisableIGMPSnoopingRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
+	if req.VlanID < 1 || req.VlanID > 4094 {
+		writeError(w, &newtron.ValidationError{Field: "vlan_id", Message: "must be 1-4094"})
+		return
+	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.UnconfigureIRB(ctx, req.VlanID)
+		return n.SetIGMPSnooping(ctx, req.VlanID, false)
 	})
 	if err != nil {
 		writeError(w, err)
@@ -862,7 +1509,7 @@ func (s *Server) handleAddStaticRoute(w http.ResponseWriter, r *http.Request) {
 	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.AddStaticRoute(ctx, req.VRF, req.Prefix, req.NextHop, req.Metric)
+		return n.AddStaticRouteMulti(ctx, req.VRF, req.Prefix, req.Config())
 	})
 	if err != nil {
 		writeError(w, err)
@@ -876,19 +1523,14 @@ func (s *Server) handleUpdateStaticRoute(w http.ResponseWriter, r *http.Request)
 	if nodeActor == nil {
 		return
 	}
-	var req struct {
-		VRF     string `json:"vrf"`
-		Prefix  string `json:"prefix"`
-		NextHop string `json:"nexthop"`
-		Metric  int    `json:"metric"`
-	}
+	var req StaticRouteUpdateRequest
 	if err := decodeJSON(r, &req); err != nil {
 		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
 		return
 	}
 	opts := execOpts(r)
 	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
-		return n.UpdateStaticRoute(ctx, req.VRF, req.Prefix, req.NextHop, req.Metric)
+		return n.UpdateStaticRoute(ctx, req.VRF, req.Prefix, req.Config())
 	})
 	if err != nil {
 		writeError(w, err)
@@ -921,6 +1563,114 @@ func (s *Server) handleRemoveStaticRoute(w http.ResponseWriter, r *http.Request)
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+func (s *Server) handleAddRouteLeak(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req RouteLeakRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.SrcVRF == "" {
+		writeError(w, &newtron.ValidationError{Field: "src_vrf", Message: "required"})
+		return
+	}
+	if req.DstVRF == "" {
+		writeError(w, &newtron.ValidationError{Field: "dst_vrf", Message: "required"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.AddRouteLeak(ctx, req.SrcVRF, req.DstVRF, req.Prefixes)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusCreated, val)
+}
+
+func (s *Server) handleRemoveRouteLeak(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req RouteLeakRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.DstVRF == "" {
+		writeError(w, &newtron.ValidationError{Field: "dst_vrf", Message: "required"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.RemoveRouteLeak(ctx, req.DstVRF)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleSetVRFRouteTargets(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req VRFRouteTargetsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.VRF == "" {
+		writeError(w, &newtron.ValidationError{Field: "vrf", Message: "required"})
+		return
+	}
+	if len(req.Import) == 0 && len(req.Export) == 0 {
+		writeError(w, &newtron.ValidationError{Field: "import", Message: "import or export route targets required"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.SetVRFRouteTargets(ctx, req.VRF, req.Import, req.Export)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleClearVRFRouteTargets(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req VRFRouteTargetsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if req.VRF == "" {
+		writeError(w, &newtron.ValidationError{Field: "vrf", Message: "required"})
+		return
+	}
+	opts := execOpts(r)
+	val, err := nodeActor.connectAndExecute(r.Context(), opts, func(ctx context.Context, n *newtron.Node) error {
+		return n.ClearVRFRouteTargets(ctx, req.VRF)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 // ============================================================================
 // Device management operations
 // ============================================================================
@@ -1033,6 +1783,82 @@ func (s *Server) handleConfigDBSnapshot(w http.ResponseWriter, r *http.Request)
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+// handleRunningConfig returns one section of the device's running config as
+// text (?section=bgp, ?section=PORT, ...; omitted = all of it).
+func (s *Server) handleRunningConfig(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	section := r.URL.Query().Get("section")
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetRunningConfigSection(r.Context(), section)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleExportConfigDB returns the device's entire CONFIG_DB as a
+// config_db.json document — the shape `config save` writes, and the body
+// handleImportConfigDB accepts. In topology and loopback modes there is no
+// device to read: the document is rendered offline from the node's intents.
+func (s *Server) handleExportConfigDB(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	offline := modeFromCtx(r.Context()) != ModeIntent
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		var buf bytes.Buffer
+		export := func() error { return n.ExportConfigDB(r.Context(), &buf) }
+		if offline {
+			export = func() error { return n.RenderConfigDB(&buf) }
+		}
+		if err := export(); err != nil {
+			return nil, err
+		}
+		return json.RawMessage(buf.Bytes()), nil
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+// handleImportConfigDB applies a config_db.json document to the device's
+// CONFIG_DB, table by table. Re-importing an export is a no-op.
+func (s *Server) handleImportConfigDB(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	var req ImportConfigDBRequest
+	if err := decodeJSON(r, &req); err != nil {
+		writeError(w, &newtron.ValidationError{Message: "invalid JSON: " + err.Error()})
+		return
+	}
+	if len(req.Config) == 0 {
+		writeError(w, &newtron.ValidationError{Field: "config", Message: "required"})
+		return
+	}
+	if _, err := sonic.DecodeConfigDBJSON(bytes.NewReader(req.Config)); err != nil {
+		writeError(w, &newtron.ValidationError{Field: "config", Message: err.Error()})
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return nil, n.ImportConfigDB(r.Context(), bytes.NewReader(req.Config))
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 func (s *Server) handleQueryConfigDB(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
@@ -1153,12 +1979,28 @@ func (s *Server) handleOperDBEntry(w http.ResponseWriter, r *http.Request) {
 // ============================================================================
 
 func (s *Server) handleCheckBGPSessions(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	vrf := r.URL.Query().Get("vrf")
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.CheckBGPSessions(r.Context(), vrf)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
+func (s *Server) handleGetBGPConvergence(w http.ResponseWriter, r *http.Request) {
 	_, nodeActor := s.requireNodeActor(w, r)
 	if nodeActor == nil {
 		return
 	}
 	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
-		return n.CheckBGPSessions(r.Context())
+		return n.GetBGPConvergenceState(r.Context())
 	})
 	if err != nil {
 		writeError(w, err)
@@ -1183,6 +2025,34 @@ func (s *Server) handleShowLAGDetail(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+// handleLAGHashDistribution samples a LAG's member TX counters over ?window=
+// (a Go duration, default 10s, at most 1m — the read holds the node for the
+// whole window) and returns the per-member split.
+func (s *Server) handleLAGHashDistribution(w http.ResponseWriter, r *http.Request) {
+	window := 10 * time.Second
+	if v := r.URL.Query().Get("window"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil || d < time.Second || d > time.Minute {
+			writeError(w, &newtron.ValidationError{Field: "window", Message: "must be a duration from 1s to 1m"})
+			return
+		}
+		window = d
+	}
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	name := r.PathValue("name")
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.GetLAGHashDistribution(r.Context(), name, window)
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 // ============================================================================
 // Intent operations — Projection, Tree, Drift, Reconcile, Save, Reload, Clear
 // ============================================================================
@@ -1260,6 +2130,23 @@ func (s *Server) handleDrift(w http.ResponseWriter, r *http.Request) {
 	httputil.WriteJSON(w, http.StatusOK, val)
 }
 
+// handleImportIntent reports how a device's hand-built config maps onto the
+// network's service definitions — the brownfield-adoption read.
+func (s *Server) handleImportIntent(w http.ResponseWriter, r *http.Request) {
+	_, nodeActor := s.requireNodeActor(w, r)
+	if nodeActor == nil {
+		return
+	}
+	val, err := nodeActor.connectAndRead(r.Context(), func(n *newtron.Node) (any, error) {
+		return n.ImportIntent(r.Context())
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, val)
+}
+
 // handleTopologyDrift answers "does the device CONFIG_DB diverge from
 // topology.json?" with a freshly-built TopologyNode projection. Distinct
 // from handleDrift (issue #75B): that one drifts against the operator's
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"time"
@@ -88,12 +89,21 @@ type SSHCommandResponse struct {
 // ============================================================================
 
 // ApplyServiceRequest is the body for POST .../apply-service.
+//
+// With Verify the apply waits for the service to settle (interface oper-up,
+// its BGP session Established) and responds with a ServiceResult.
+// OnVerifyFail is "leave" (default) or "rollback"; VerifyTimeout is in
+// seconds (default 60).
 type ApplyServiceRequest struct {
-	Service   string            `json:"service"`
-	IPAddress string            `json:"ip_address,omitempty"`
-	VLAN      int               `json:"vlan,omitempty"`
-	PeerAS    int               `json:"peer_as,omitempty"`
-	Params    map[string]string `json:"params,omitempty"`
+	Service       string            `json:"service"`
+	IPAddress     string            `json:"ip_address,omitempty"`
+	SecondaryIPs  []string          `json:"secondary_ips,omitempty"`
+	VLAN          int               `json:"vlan,omitempty"`
+	PeerAS        int               `json:"peer_as,omitempty"`
+	Params        map[string]string `json:"params,omitempty"`
+	Verify        bool              `json:"verify,omitempty"`
+	OnVerifyFail  string            `json:"on_verify_fail,omitempty"`
+	VerifyTimeout int               `json:"verify_timeout,omitempty"`
 }
 
 // BindACLRequest is the body for POST .../bind-acl.
@@ -143,6 +153,20 @@ type RemoveTrunkVLANRequest struct {
 	VLAN int `json:"vlan_id"`
 }
 
+// VLANTranslationRequest is the body for POST .../set-vlan-translation and
+// .../clear-vlan-translation. in_vlan is the customer VLAN arriving on the
+// port; out_vlan is the provider VLAN it is rewritten to (set only).
+type VLANTranslationRequest struct {
+	InVLAN  int `json:"in_vlan"`
+	OutVLAN int `json:"out_vlan,omitempty"`
+}
+
+// EgressShaperRequest is the body for POST .../set-egress-shaper. rate_kbps
+// is the egress rate limit in kilobits per second.
+type EgressShaperRequest struct {
+	RateKbps int `json:"rate_kbps"`
+}
+
 // NodeBindMACVPNRequest is the body for POST .../bind-macvpn (node-level, maps VLAN to L2VNI).
 type NodeBindMACVPNRequest struct {
 	VlanID int    `json:"vlan_id"`
@@ -276,6 +300,110 @@ type PortChannelMemberRequest struct {
 	Interface   string `json:"interface"`
 }
 
+// LAGHashPolicyRequest is the body for POST .../set-lag-hash-policy. Fields
+// are SONiC hash field names (SRC_IP, DST_IP, L4_SRC_PORT, ...).
+type LAGHashPolicyRequest struct {
+	HashFields []string `json:"hash_fields"`
+}
+
+// BGPMaxPathsRequest is the body for POST .../set-bgp-max-paths.
+type BGPMaxPathsRequest struct {
+	MaxPaths int `json:"max_paths"`
+}
+
+// CounterPollingRequest is the body for POST .../set-counter-polling and
+// .../clear-counter-polling (which reads only counter_type). IntervalMs 0
+// selects the group's SONiC default.
+type CounterPollingRequest struct {
+	CounterType string `json:"counter_type"`
+	Enable      bool   `json:"enable"`
+	IntervalMs  int    `json:"interval_ms,omitempty"`
+}
+
+// BannerRequest is the body for POST .../set-banner. At least one message is
+// required; an omitted one is left blank.
+type BannerRequest struct {
+	Login  string `json:"login,omitempty"`
+	MOTD   string `json:"motd,omitempty"`
+	Logout string `json:"logout,omitempty"`
+}
+
+// Config converts the wire request to the domain config — see
+// VLANCreateRequest.Config.
+func (r BannerRequest) Config() newtron.BannerConfig { return newtron.BannerConfig(r) }
+
+// MirrorSessionRequest is the body for POST .../create-mirror-session.
+// Omitted fields take the session defaults: the loopback as source, both
+// directions, the default VRF, TTL 255, and GRE type 0x88be.
+type MirrorSessionRequest struct {
+	Name      string   `json:"name"`
+	DstIP     string   `json:"dst_ip"`
+	SrcIP     string   `json:"src_ip,omitempty"`
+	Ports     []string `json:"ports"`
+	Direction string   `json:"direction,omitempty"`
+	VRF       string   `json:"vrf,omitempty"`
+	DSCP      int      `json:"dscp,omitempty"`
+	TTL       int      `json:"ttl,omitempty"`
+	GREType   string   `json:"gre_type,omitempty"`
+}
+
+// Config converts the wire request to the domain config — see
+// VLANCreateRequest.Config.
+func (r MirrorSessionRequest) Config() newtron.MirrorConfig {
+	return newtron.MirrorConfig{
+		DstIP:     r.DstIP,
+		SrcIP:     r.SrcIP,
+		Ports:     r.Ports,
+		Direction: r.Direction,
+		VRF:       r.VRF,
+		DSCP:      r.DSCP,
+		TTL:       r.TTL,
+		GREType:   r.GREType,
+	}
+}
+
+// DeleteMirrorSessionRequest is the body for POST .../delete-mirror-session.
+type DeleteMirrorSessionRequest struct {
+	Name string `json:"name"`
+}
+
+// DHCPServerRequest is the body for POST .../configure-dhcp-server. Ranges
+// are "first-last" (or single) IPv4 addresses in the VLAN's IRB subnet;
+// omitted ports serve every current member.
+type DHCPServerRequest struct {
+	VlanID    int            `json:"vlan_id"`
+	Ranges    []string       `json:"ranges"`
+	Ports     []string       `json:"ports,omitempty"`
+	Gateway   string         `json:"gateway,omitempty"`
+	LeaseTime int            `json:"lease_time,omitempty"`
+	Options   map[int]string `json:"options,omitempty"`
+}
+
+// Config converts the wire request to the domain config — see
+// VLANCreateRequest.Config.
+func (r DHCPServerRequest) Config() newtron.DHCPServerConfig {
+	return newtron.DHCPServerConfig{
+		Ranges:    r.Ranges,
+		Ports:     r.Ports,
+		Gateway:   r.Gateway,
+		LeaseTime: r.LeaseTime,
+		Options:   r.Options,
+	}
+}
+
+// IGMPSnoopingRequest is the body for POST .../set-igmp-snooping: Enable
+// true turns snooping on for the VLAN, false turns it off.
+type IGMPSnoopingRequest struct {
+	VlanID int  `json:"vlan_id"`
+	Enable bool `json:"enable"`
+}
+
+// DisableIGMPSnoopingRequest is the body for POST .../disable-igmp-snooping,
+// the inverse of enabling snooping.
+type DisableIGMPSnoopingRequest struct {
+	VlanID int `json:"vlan_id"`
+}
+
 // ============================================================================
 // HTTP Request Types — Missing Node Operations
 // ============================================================================
@@ -285,6 +413,11 @@ type UnconfigureIRBRequest struct {
 	VlanID int `json:"vlan_id"`
 }
 
+// UnconfigureDHCPServerRequest is the body for POST .../unconfigure-dhcp-server.
+type UnconfigureDHCPServerRequest struct {
+	VlanID int `json:"vlan_id"`
+}
+
 // BindIPVPNRequest is the body for POST .../bind-ipvpn (and .../unbind-ipvpn).
 // VRF is the existing on-device VRF that joins the VPN (normalized to the "Vrf_"
 // prefix server-side); IPVPN is the IP-VPN spec name whose L3VNI/route-targets
@@ -294,23 +427,70 @@ type BindIPVPNRequest struct {
 	IPVPN string `json:"ipvpn"`
 }
 
-// StaticRouteRequest is the body for POST .../add-static-route.
+// StaticRouteRequest is the body for POST .../add-static-route. NextHop is
+// the single-path form; NextHops, Interfaces and Blackhole give several
+// paths (ECMP) or a blackhole. A NextHop given alongside NextHops is the
+// first path.
 type StaticRouteRequest struct {
-	VRF     string `json:"vrf"`
-	Prefix  string `json:"prefix"`
-	NextHop string `json:"nexthop"`
-	Metric  int    `json:"metric,omitempty"`
+	VRF        string   `json:"vrf"`
+	Prefix     string   `json:"prefix"`
+	NextHop    string   `json:"nexthop,omitempty"`
+	NextHops   []string `json:"nexthops,omitempty"`
+	Interfaces []string `json:"ifnames,omitempty"`
+	Blackhole  bool     `json:"blackhole,omitempty"`
+	Metric     int      `json:"metric,omitempty"`
+}
+
+// Config converts the request to a StaticRouteConfig, merging a NextHop
+// given alongside NextHops in as the first path.
+func (r StaticRouteRequest) Config() newtron.StaticRouteConfig {
+	cfg := newtron.StaticRouteConfig{
+		NextHops:   r.NextHops,
+		Interfaces: r.Interfaces,
+		Blackhole:  r.Blackhole,
+		Distance:   r.Metric,
+	}
+	if r.NextHop != "" {
+		cfg.NextHops = append([]string{r.NextHop}, r.NextHops...)
+	}
+	return cfg
 }
 
 // StaticRouteUpdateRequest is the body for POST .../update-static-route.
-// Updates fields of an existing static route. The composite key
-// (vrf + prefix) is the row's identity (§47) and is not mutable
-// through this verb — relocate via remove + add. #227.
+// Replaces the paths and metric of an existing static route, in the same
+// shapes as StaticRouteRequest. The composite key (vrf + prefix) is the
+// row's identity (§47) and is not mutable through this verb — relocate via
+// remove + add. #227.
 type StaticRouteUpdateRequest struct {
-	VRF     string `json:"vrf"`
-	Prefix  string `json:"prefix"`
-	NextHop string `json:"nexthop"`
-	Metric  int    `json:"metric,omitempty"`
+	VRF        string   `json:"vrf"`
+	Prefix     string   `json:"prefix"`
+	NextHop    string   `json:"nexthop,omitempty"`
+	NextHops   []string `json:"nexthops,omitempty"`
+	Interfaces []string `json:"ifnames,omitempty"`
+	Blackhole  bool     `json:"blackhole,omitempty"`
+	Metric     int      `json:"metric,omitempty"`
+}
+
+// Config converts the request to a StaticRouteConfig, as
+// StaticRouteRequest.Config does.
+func (r StaticRouteUpdateRequest) Config() newtron.StaticRouteConfig {
+	return StaticRouteRequest(r).Config()
+}
+
+// RouteLeakRequest is the body for POST .../add-route-leak and
+// .../remove-route-leak. Remove reads only DstVRF.
+type RouteLeakRequest struct {
+	SrcVRF   string   `json:"src_vrf,omitempty"`
+	DstVRF   string   `json:"dst_vrf"`
+	Prefixes []string `json:"prefixes,omitempty"`
+}
+
+// VRFRouteTargetsRequest is the body for POST .../set-vrf-route-targets and
+// .../clear-vrf-route-targets. Clear reads only VRF.
+type VRFRouteTargetsRequest struct {
+	VRF    string   `json:"vrf"`
+	Import []string `json:"import,omitempty"`
+	Export []string `json:"export,omitempty"`
 }
 
 // RestartDaemonRequest is the body for POST .../restart-daemon.
@@ -318,6 +498,12 @@ type RestartDaemonRequest struct {
 	Daemon string `json:"daemon"`
 }
 
+// ImportConfigDBRequest is the body for POST .../configdb/import. Config is a
+// config_db.json document, as GET .../configdb/export returns it.
+type ImportConfigDBRequest struct {
+	Config json.RawMessage `json:"config"`
+}
+
 // ============================================================================
 // Error mapping
 // ============================================================================
@@ -380,11 +566,25 @@ func httpStatusFromError(err error) int {
 		return http.StatusConflict
 	}
 
+	// An applied service that did not settle — the device refused the state
+	// the request asked for. Same family as a failed verify.
+	var serviceFailed *newtron.ServiceVerifyFailedError
+	if errors.As(err, &serviceFailed) {
+		return http.StatusConflict
+	}
+
 	var conflict *newtron.ConflictError
 	if errors.As(err, &conflict) {
 		return http.StatusConflict
 	}
 
+	// A write over the network's change_guard — refused before anything was
+	// applied; retry with force=true once the change set has been reviewed.
+	var guard *newtron.ChangeGuardError
+	if errors.As(err, &guard) {
+		return http.StatusConflict
+	}
+
 	// Write-control reservation refusal — a non-holder write, or a request when
 	// another caller holds it. 409 (consistent with the conflict family); the
 	// typed payload distinguishes it for clients.
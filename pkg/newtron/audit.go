@@ -66,11 +66,12 @@ func toAuditEvent(e *audit.Event, withBody bool) AuditEvent {
 	}
 	for _, c := range e.Changes {
 		ae.Changes = append(ae.Changes, AuditChange{
-			Table:  c.Table,
-			Key:    c.Key,
-			Type:   string(c.Type),
-			Fields: c.Fields,
-			From:   c.From,
+			Table:   c.Table,
+			Key:     c.Key,
+			Type:    string(c.Type),
+			Fields:  c.Fields,
+			From:    c.From,
+			Comment: c.Comment,
 		})
 	}
 	if withBody {
@@ -27,7 +27,10 @@ type Logger interface {
 	Close() error
 }
 
-// FileLogger logs audit events to a JSON-lines file
+// FileLogger logs audit events to a JSON-lines file. It is safe for
+// concurrent use: Log serializes on mu, so each event is one whole line,
+// the integrity chain follows file order, and rotation never runs while
+// another event is being written.
 type FileLogger struct {
 	path      string
 	file      *os.File
@@ -270,8 +273,7 @@ func (l *FileLogger) rotate() error {
 	}
 
 	// Rename current file with timestamp
-	timestamp := time.Now().Format("20060102-150405")
-	rotatedPath := l.path + "." + timestamp
+	rotatedPath := l.rotatedPath()
 
 	if err := os.Rename(l.path, rotatedPath); err != nil {
 		// Reopen the original file to avoid leaving the logger in a broken state
@@ -299,6 +301,21 @@ func (l *FileLogger) rotate() error {
 	return nil
 }
 
+// rotatedPath returns the name the current file is rotated to: the log
+// path plus a nanosecond timestamp. Under a burst of concurrent events a
+// small MaxSize rotates many times a second; with a coarser timestamp two
+// rotations would share a name and the rename would silently replace the
+// earlier backup, dropping its events. The fixed-width timestamp also makes
+// backup names sort oldest-first.
+func (l *FileLogger) rotatedPath() string {
+	for {
+		p := l.path + "." + time.Now().Format("20060102-150405.000000000")
+		if _, err := os.Lstat(p); os.IsNotExist(err) {
+			return p
+		}
+	}
+}
+
 func (l *FileLogger) cleanupOldFiles() {
 	dir := filepath.Dir(l.path)
 	base := filepath.Base(l.path)
@@ -309,31 +326,18 @@ func (l *FileLogger) cleanupOldFiles() {
 		return
 	}
 
-	// Sort by modification time, newest first
-	type fileInfo struct {
-		path    string
-		modTime time.Time
-	}
-	var files []fileInfo
-	for _, path := range matches {
-		info, err := os.Stat(path)
-		if err != nil {
-			continue
-		}
-		files = append(files, fileInfo{path, info.ModTime()})
-	}
-
-	// Keep only MaxBackups files
-	if len(files) > l.rotation.MaxBackups {
-		sort.Slice(files, func(i, j int) bool {
-			return files[i].modTime.Before(files[j].modTime)
-		})
+	// Keep only MaxBackups files. Backups are ordered by the timestamp in
+	// their names (rotatedPath), oldest first — not by modification time,
+	// which ties when rotations land within the filesystem's mtime
+	// granularity and would leave the choice of victim arbitrary.
+	if len(matches) > l.rotation.MaxBackups {
+		sort.Strings(matches)
 
 		// Remove oldest files
-		toRemove := len(files) - l.rotation.MaxBackups
-		for i := range toRemove {
-			if err := os.Remove(files[i].path); err != nil {
-				util.Logger.Warnf("audit: failed to remove old log file %s: %v", files[i].path, err)
+		toRemove := len(matches) - l.rotation.MaxBackups
+		for _, path := range matches[:toRemove] {
+			if err := os.Remove(path); err != nil {
+				util.Logger.Warnf("audit: failed to remove old log file %s: %v", path, err)
 			}
 		}
 	}
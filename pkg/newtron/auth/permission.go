@@ -44,7 +44,7 @@ const (
 	// PermDeviceWrite is the catch-all for operational Node-level
 	// mutations whose verb is not a create/modify/delete on a
 	// specific domain noun: SetupDevice, ConfigReload, RestartService,
-	// ExecCommand, SaveConfig, Reconcile. Operators who want to
+	// ExecCommand, SaveConfig, Reconcile, ImportConfigDB. Operators who want to
 	// restrict these specifically grant `device.write`; the verb-
 	// specific permissions don't apply because the action is a
 	// device-state operation rather than a config-table mutation.
@@ -74,8 +74,8 @@ const (
 	// peer IP. Splitting them lets a grant `where: {resource: ...}`
 	// match unambiguously — a VRF name and a peer IP cannot collide
 	// in the same lookup.
-	PermVRFBind  Permission = "vrf.bind"  // BindIPVPN/UnbindIPVPN; Resource = VRF name
-	PermVRFRoute Permission = "vrf.route" // AddStaticRoute/RemoveStaticRoute; Resource = VRF name
+	PermVRFBind  Permission = "vrf.bind"  // BindIPVPN/UnbindIPVPN, SetVRFRouteTargets/ClearVRFRouteTargets; Resource = VRF name
+	PermVRFRoute Permission = "vrf.route" // AddStaticRoute/RemoveStaticRoute, AddRouteLeak/RemoveRouteLeak; Resource = VRF name
 	PermBGPPeer  Permission = "bgp.peer"  // Interface AddBGPPeer/RemoveBGPPeer; Resource = peer IP
 
 	PermSpecAuthor Permission = "spec.author"
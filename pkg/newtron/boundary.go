@@ -31,6 +31,14 @@ func (c PortChannelConfig) internal() node.PortChannelConfig { return node.PortC
 
 func (c InterfaceConfig) internal() node.InterfaceConfig { return node.InterfaceConfig(c) }
 
+func (c BannerConfig) internal() node.BannerConfig { return node.BannerConfig(c) }
+
+func (c StaticRouteConfig) internal() node.StaticRouteConfig { return node.StaticRouteConfig(c) }
+
+func (c MirrorConfig) internal() node.MirrorConfig { return node.MirrorConfig(c) }
+
+func (c DHCPServerConfig) internal() node.DHCPServerConfig { return node.DHCPServerConfig(c) }
+
 func (o ReconcileOpts) internal() node.ReconcileOpts { return node.ReconcileOpts(o) }
 
 func (o ApplyServiceOpts) internal() node.ApplyServiceOpts { return node.ApplyServiceOpts(o) }
@@ -77,5 +85,8 @@ func (c BGPNeighborConfig) directPeer() node.DirectBGPPeerConfig {
 		RemoteAS:    c.RemoteAS,
 		Description: c.Description,
 		Multihop:    c.Multihop,
+
+		MaxPrefix:       c.MaxPrefix,
+		MaxPrefixAction: c.MaxPrefixAction,
 	}
 }
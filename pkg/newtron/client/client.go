@@ -201,6 +201,9 @@ func execQuery(opts newtron.ExecOpts) string {
 	if opts.NoSave {
 		parts = append(parts, "no_save=true")
 	}
+	if opts.Force {
+		parts = append(parts, "force=true")
+	}
 	if len(parts) == 0 {
 		return ""
 	}
@@ -209,7 +212,8 @@ func execQuery(opts newtron.ExecOpts) string {
 
 // withForce appends force=true to a path's query string when force is set,
 // choosing ? or & based on whether the path already carries parameters.
-// Used by the cascade-capable deletes (nodeSpec, spec bindings).
+// Used by the cascade-capable deletes (nodeSpec, spec bindings) and the
+// reload-config safety override.
 func withForce(path string, force bool) string {
 	if !force {
 		return path
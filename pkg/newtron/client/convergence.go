@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// WaitForConvergence polls a device until every condition in opts holds at
+// the same time, or opts.Timeout elapses. It replaces the "wait a fixed
+// interval, then verify BGP, then the route, then health" chain with one
+// event-driven gate: it returns as soon as the device has converged.
+//
+// The wait runs client-side over the ordinary read endpoints so that a long
+// wait never holds the device's server-side actor (every other request for
+// the device would queue behind it).
+//
+// The result is always returned. The error is non-nil when the device did
+// not converge (naming the conditions still unsatisfied), when opts selects
+// no condition, or when ctx is cancelled. A read that fails while polling
+// counts as "not yet satisfied" — a device mid-reconfiguration may briefly
+// refuse reads.
+func (c *Client) WaitForConvergence(ctx context.Context, device string, opts newtron.ConvergenceOpts) (*newtron.ConvergenceResult, error) {
+	checks := c.convergenceChecks(device, opts)
+	result := &newtron.ConvergenceResult{Device: device}
+	if len(checks) == 0 {
+		return result, fmt.Errorf("no convergence conditions selected (bgp, routes, or health)")
+	}
+	if opts.Interval <= 0 {
+		return result, fmt.Errorf("convergence poll interval must be positive")
+	}
+
+	start := time.Now()
+	deadline := start.Add(opts.Timeout)
+	conds := make([]newtron.ConvergenceCondition, len(checks))
+	for i, chk := range checks {
+		conds[i].Name = chk.name
+	}
+	result.Conditions = conds
+
+	for {
+		allHeld := true
+		for i, chk := range checks {
+			ok, msg := chk.eval()
+			switch {
+			case ok && !conds[i].Satisfied:
+				conds[i].Satisfied = true
+				conds[i].SatisfiedAfter = time.Since(start)
+				conds[i].Message = ""
+			case !ok:
+				conds[i].Satisfied = false
+				conds[i].SatisfiedAfter = 0
+				conds[i].Message = msg
+				allHeld = false
+			}
+		}
+		result.Elapsed = time.Since(start)
+		if allHeld {
+			result.Converged = true
+			last := conds[0]
+			for _, cd := range conds[1:] {
+				if cd.SatisfiedAfter > last.SatisfiedAfter {
+					last = cd
+				}
+			}
+			result.LastSatisfied = last.Name
+			return result, nil
+		}
+		if !time.Now().Add(opts.Interval).Before(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(opts.Interval):
+		}
+	}
+
+	var pending []string
+	for _, cd := range conds {
+		if !cd.Satisfied {
+			pending = append(pending, fmt.Sprintf("%s (%s)", cd.Name, cd.Message))
+		}
+	}
+	return result, fmt.Errorf("%s not converged after %s: %s", device, opts.Timeout, strings.Join(pending, "; "))
+}
+
+// convergenceCheck is one condition WaitForConvergence evaluates per poll.
+type convergenceCheck struct {
+	name string
+	eval func() (ok bool, msg string)
+}
+
+// convergenceChecks builds the checks opts selects, in a fixed order: bgp,
+// routes (as listed), health.
+func (c *Client) convergenceChecks(device string, opts newtron.ConvergenceOpts) []convergenceCheck {
+	var checks []convergenceCheck
+	if opts.BGP {
+		checks = append(checks, convergenceCheck{name: "bgp", eval: func() (bool, string) {
+			results, err := c.CheckBGPSessions(device, "")
+			if err != nil {
+				return false, err.Error()
+			}
+			for _, r := range results {
+				if r.Status == "fail" {
+					return false, r.Message
+				}
+			}
+			return true, ""
+		}})
+	}
+	for _, rt := range opts.Routes {
+		vrf := rt.VRF
+		if vrf == "" {
+			vrf = "default"
+		}
+		prefix := rt.Prefix
+		checks = append(checks, convergenceCheck{name: "route " + vrf + "|" + prefix, eval: func() (bool, string) {
+			entry, err := c.GetRoute(device, vrf, prefix)
+			if err != nil {
+				return false, err.Error()
+			}
+			if entry == nil || entry.Prefix == "" {
+				return false, "not in APP_DB"
+			}
+			return true, ""
+		}})
+	}
+	if opts.Health {
+		checks = append(checks, convergenceCheck{name: "health", eval: func() (bool, string) {
+			report, err := c.HealthCheck(device)
+			if err != nil {
+				return false, err.Error()
+			}
+			if report.Status == "fail" {
+				return false, "health status fail"
+			}
+			return true, ""
+		}})
+	}
+	return checks
+}
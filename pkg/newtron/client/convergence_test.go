@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/httputil"
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// convergenceServer answers the three reads WaitForConvergence polls. BGP
+// reports a failing session until bgpUpAfter polls have been served; the
+// route is present from the first poll; health always passes.
+func convergenceServer(t *testing.T, bgpUpAfter int32) *httptest.Server {
+	t.Helper()
+	var bgpPolls int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/bgp/check"):
+			status := "pass"
+			if atomic.AddInt32(&bgpPolls, 1) <= bgpUpAfter {
+				status = "fail"
+			}
+			data = []newtron.HealthCheckResult{{Check: "bgp", Status: status, Message: "10.0.0.1 " + status}}
+		case strings.Contains(r.URL.Path, "/routes/"):
+			data = newtron.RouteEntry{Prefix: "10.9.0.0/24", VRF: "default"}
+		case strings.HasSuffix(r.URL.Path, "/health"):
+			data = newtron.HealthReport{Device: "leaf1", Status: "pass"}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(httputil.APIResponse{Data: data})
+	}))
+}
+
+func TestWaitForConvergence_ReportsLastSatisfied(t *testing.T) {
+	ts := convergenceServer(t, 2)
+	defer ts.Close()
+
+	c := New(ts.URL, "demo")
+	res, err := c.WaitForConvergence(context.Background(), "leaf1", newtron.ConvergenceOpts{
+		BGP:      true,
+		Routes:   []newtron.ConvergenceRoute{{Prefix: "10.9.0.0/24"}},
+		Health:   true,
+		Timeout:  5 * time.Second,
+		Interval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("WaitForConvergence: %v", err)
+	}
+	if !res.Converged {
+		t.Fatal("Converged = false, want true")
+	}
+	if res.LastSatisfied != "bgp" {
+		t.Errorf("LastSatisfied = %q, want bgp", res.LastSatisfied)
+	}
+	if len(res.Conditions) != 3 || res.Conditions[1].Name != "route default|10.9.0.0/24" {
+		t.Errorf("Conditions = %+v", res.Conditions)
+	}
+}
+
+func TestWaitForConvergence_TimeoutNamesPending(t *testing.T) {
+	ts := convergenceServer(t, 1<<30)
+	defer ts.Close()
+
+	c := New(ts.URL, "demo")
+	res, err := c.WaitForConvergence(context.Background(), "leaf1", newtron.ConvergenceOpts{
+		BGP:      true,
+		Health:   true,
+		Timeout:  50 * time.Millisecond,
+		Interval: 10 * time.Millisecond,
+	})
+	if err == nil || !strings.Contains(err.Error(), "bgp") {
+		t.Fatalf("err = %v, want a not-converged error naming bgp", err)
+	}
+	if res.Converged || res.LastSatisfied != "" {
+		t.Errorf("result = %+v, want not converged", res)
+	}
+	if !res.Conditions[1].Satisfied {
+		t.Error("health should be reported satisfied even though bgp never was")
+	}
+}
+
+func TestWaitForConvergence_NoConditions(t *testing.T) {
+	c := New("http://unused", "demo")
+	if _, err := c.WaitForConvergence(context.Background(), "leaf1", newtron.ConvergenceOpts{Timeout: time.Second, Interval: time.Millisecond}); err == nil {
+		t.Error("expected error when no condition is selected")
+	}
+}
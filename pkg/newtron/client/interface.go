@@ -1,6 +1,8 @@
 package client
 
 import (
+	"time"
+
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/api"
 )
@@ -21,15 +23,43 @@ func (c *Client) interfaceWrite(device, iface, endpoint string, body any, opts n
 // ApplyService applies a service to an interface.
 func (c *Client) ApplyService(device, iface, service string, serviceOpts newtron.ApplyServiceOpts, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
 	body := api.ApplyServiceRequest{
-		Service:   service,
-		IPAddress: serviceOpts.IPAddress,
-		VLAN:      serviceOpts.VLAN,
-		PeerAS:    serviceOpts.PeerAS,
-		Params:    serviceOpts.Params,
+		Service:      service,
+		IPAddress:    serviceOpts.IPAddress,
+		SecondaryIPs: serviceOpts.SecondaryIPs,
+		VLAN:         serviceOpts.VLAN,
+		PeerAS:       serviceOpts.PeerAS,
+		Params:       serviceOpts.Params,
 	}
 	return c.interfaceWrite(device, iface, "apply-service", body, opts)
 }
 
+// ApplyServiceChecked applies a service and waits for it to settle — the
+// interface oper-up and, when the service peers over BGP, that session
+// Established. With verify.Rollback a service that does not settle is
+// removed again. The server polls every 2s (verify.Interval is not sent);
+// the error on a failed verify names the failing check and whether the
+// service was rolled back.
+func (c *Client) ApplyServiceChecked(device, iface, service string, serviceOpts newtron.ApplyServiceOpts, verify newtron.ServiceVerifyOpts, opts newtron.ExecOpts) (*newtron.ServiceResult, error) {
+	body := api.ApplyServiceRequest{
+		Service:       service,
+		IPAddress:     serviceOpts.IPAddress,
+		SecondaryIPs:  serviceOpts.SecondaryIPs,
+		VLAN:          serviceOpts.VLAN,
+		PeerAS:        serviceOpts.PeerAS,
+		Params:        serviceOpts.Params,
+		Verify:        true,
+		VerifyTimeout: int(verify.Timeout.Round(time.Second) / time.Second),
+	}
+	if verify.Rollback {
+		body.OnVerifyFail = "rollback"
+	}
+	var result newtron.ServiceResult
+	if err := c.doPost(c.interfacePath(device, iface)+"/apply-service"+execQuery(opts), body, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // RemoveService removes a service from an interface.
 func (c *Client) RemoveService(device, iface string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
 	return c.interfaceWrite(device, iface, "remove-service", nil, opts)
@@ -111,3 +141,39 @@ func (c *Client) RemoveTrunkVLAN(device, iface string, vlanID int, opts newtron.
 	return c.interfaceWrite(device, iface, "remove-trunk-vlan", api.RemoveTrunkVLANRequest{VLAN: vlanID}, opts)
 }
 
+// SetVLANTranslation rewrites customer VLAN inVLAN on an interface to
+// provider VLAN outVLAN.
+func (c *Client) SetVLANTranslation(device, iface string, inVLAN, outVLAN int, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.VLANTranslationRequest{InVLAN: inVLAN, OutVLAN: outVLAN}
+	return c.interfaceWrite(device, iface, "set-vlan-translation", body, opts)
+}
+
+// ClearVLANTranslation removes the translation for customer VLAN inVLAN on
+// an interface. Reverse of SetVLANTranslation per §15.
+func (c *Client) ClearVLANTranslation(device, iface string, inVLAN int, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.VLANTranslationRequest{InVLAN: inVLAN}
+	return c.interfaceWrite(device, iface, "clear-vlan-translation", body, opts)
+}
+
+// SetEgressShaper rate-limits egress traffic on an interface to rateKbps.
+func (c *Client) SetEgressShaper(device, iface string, rateKbps int, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.interfaceWrite(device, iface, "set-egress-shaper", api.EgressShaperRequest{RateKbps: rateKbps}, opts)
+}
+
+// ClearEgressShaper removes the egress shaper from an interface. Reverse of
+// SetEgressShaper per §15.
+func (c *Client) ClearEgressShaper(device, iface string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.interfaceWrite(device, iface, "clear-egress-shaper", nil, opts)
+}
+
+// Isolate takes an interface out of forwarding — BGP peer shut, LAG member
+// detached, port admin down — recording the prior state for Unisolate.
+func (c *Client) Isolate(device, iface string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.interfaceWrite(device, iface, "isolate-interface", nil, opts)
+}
+
+// Unisolate returns an isolated interface to forwarding. Reverse of Isolate
+// per §15.
+func (c *Client) Unisolate(device, iface string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.interfaceWrite(device, iface, "unisolate-interface", nil, opts)
+}
@@ -65,6 +65,17 @@ func (c *Client) TopologyNodeNames() ([]string, error) {
 	return result, nil
 }
 
+// InterfacesByRole returns a topology device's interfaces with the given role
+// (uplink, downlink, fabric), derived from the topology links.
+func (c *Client) InterfacesByRole(device, role string) ([]string, error) {
+	var result []string
+	path := c.networkPath() + "/topology/nodes/" + url.PathEscape(device) + "/interfaces?role=" + url.QueryEscape(role)
+	if err := c.doGet(path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // IsHostDevice checks if a device is a virtual host (non-SONiC).
 func (c *Client) IsHostDevice(name string) (bool, error) {
 	var result newtron.HostConnection
@@ -166,6 +177,48 @@ func (c *Client) DeleteZone(name string, opts newtron.ExecOpts) error {
 // Spec reads
 // ============================================================================
 
+// Lint runs the whole-spec modeling checks and returns every issue, errors
+// first.
+func (c *Client) Lint() ([]newtron.LintIssue, error) {
+	var result []newtron.LintIssue
+	if err := c.doGet(c.networkPath()+"/lint", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SpecImpact reports what editing the named network-scope spec would touch.
+// kind may be empty when the name is unique across spec kinds.
+func (c *Client) SpecImpact(kind, name string) (*newtron.SpecImpact, error) {
+	params := url.Values{}
+	params.Set("name", name)
+	if kind != "" {
+		params.Set("kind", kind)
+	}
+	var result newtron.SpecImpact
+	if err := c.doGet(c.networkPath()+"/impact?"+params.Encode(), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DiffDevices compares two devices' CONFIG_DBs with per-device values
+// normalized. ignore names tables, TABLE|KEY entries, or trailing-"*"
+// prefixes to leave out.
+func (c *Client) DiffDevices(a, b string, ignore []string) (*newtron.DeviceDiff, error) {
+	params := url.Values{}
+	params.Set("a", a)
+	params.Set("b", b)
+	for _, pattern := range ignore {
+		params.Add("ignore", pattern)
+	}
+	var result newtron.DeviceDiff
+	if err := c.doGet(c.networkPath()+"/diff-devices?"+params.Encode(), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ListServices returns all service names.
 func (c *Client) ListServices() ([]string, error) {
 	var result []string
@@ -547,3 +600,25 @@ func (c *Client) InitDevice(device string, force bool) (string, error) {
 	}
 	return result["status"], nil
 }
+
+// RolloutService applies one service to every circuit in a rollout
+// manifest. A manifest that fails validation is returned as an error and
+// nothing is changed; per-device failures are reported in the result.
+func (c *Client) RolloutService(spec newtron.RolloutSpec, opts newtron.ExecOpts) (*newtron.MultiChangeSet, error) {
+	var result newtron.MultiChangeSet
+	if err := c.doPost(c.networkPath()+"/rollout-service"+execQuery(opts), spec, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ApplyMTUPolicy sets fabric and server-facing interface MTUs across every
+// switch in the topology. Per-device failures are reported in the result,
+// not returned as an error.
+func (c *Client) ApplyMTUPolicy(policy newtron.MTUPolicy, opts newtron.ExecOpts) (*newtron.MultiChangeSet, error) {
+	var result newtron.MultiChangeSet
+	if err := c.doPost(c.networkPath()+"/apply-mtu-policy"+execQuery(opts), policy, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -1,8 +1,12 @@
 package client
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
+	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/api"
@@ -51,6 +55,26 @@ func (c *Client) InterfaceStatus(device, iface string) (*newtron.InterfaceStatus
 	return &result, nil
 }
 
+// EgressShaper returns an interface's egress shaper — intended rate and the
+// rate configured on the device.
+func (c *Client) EgressShaper(device, iface string) (*newtron.EgressShaper, error) {
+	var result newtron.EgressShaper
+	if err := c.doGet(c.interfacePath(device, iface)+"/egress-shaper", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ServiceHealth checks the operational state an interface's bound service
+// implies: the interface oper-up and the service's BGP session, if any.
+func (c *Client) ServiceHealth(device, iface string) ([]newtron.HealthCheckResult, error) {
+	var result []newtron.HealthCheckResult
+	if err := c.doGet(c.interfacePath(device, iface)+"/service-health", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // ShowServiceBinding returns the service binding on an interface.
 func (c *Client) ShowServiceBinding(device, iface string) (*newtron.ServiceBindingDetail, error) {
 	var result newtron.ServiceBindingDetail
@@ -60,6 +84,16 @@ func (c *Client) ShowServiceBinding(device, iface string) (*newtron.ServiceBindi
 	return &result, nil
 }
 
+// ListServiceBindings returns every service applied on the device, sorted
+// by interface name.
+func (c *Client) ListServiceBindings(device string) ([]newtron.ServiceBindingEntry, error) {
+	var result []newtron.ServiceBindingEntry
+	if err := c.doGet(c.nodePath(device)+"/service-bindings", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // ListVLANs returns VLAN status entries.
 func (c *Client) ListVLANs(device string) ([]newtron.VLANStatusEntry, error) {
 	var result []newtron.VLANStatusEntry
@@ -78,6 +112,44 @@ func (c *Client) ShowVLAN(device string, id int) (*newtron.VLANStatusEntry, erro
 	return &result, nil
 }
 
+// VLANMembership returns each VLAN's tagged/untagged members and SVI as read
+// from the device's CONFIG_DB.
+func (c *Client) VLANMembership(device string) ([]newtron.VLANMembership, error) {
+	var result []newtron.VLANMembership
+	if err := c.doGet(c.nodePath(device)+"/vlans/membership", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RouteLeaks returns the device's inter-VRF route leaks.
+func (c *Client) RouteLeaks(device string) ([]newtron.RouteLeak, error) {
+	var result []newtron.RouteLeak
+	if err := c.doGet(c.nodePath(device)+"/route-leaks", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RouteLeakHealth checks the route leak into dstVRF against the routes that
+// arrived there.
+func (c *Client) RouteLeakHealth(device, dstVRF string) (*newtron.RouteLeakHealth, error) {
+	var result newtron.RouteLeakHealth
+	if err := c.doGet(fmt.Sprintf("%s/route-leaks/%s/health", c.nodePath(device), url.PathEscape(dstVRF)), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// LeakedRoutes returns the routes leaked into vrf from other VRFs.
+func (c *Client) LeakedRoutes(device, vrf string) ([]newtron.RouteEntry, error) {
+	var result []newtron.RouteEntry
+	if err := c.doGet(fmt.Sprintf("%s/vrfs/%s/leaked-routes", c.nodePath(device), url.PathEscape(vrf)), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // ListVRFs returns VRF status entries.
 func (c *Client) ListVRFs(device string) ([]newtron.VRFStatusEntry, error) {
 	var result []newtron.VRFStatusEntry
@@ -164,6 +236,117 @@ func (c *Client) HealthCheck(device string) (*newtron.HealthReport, error) {
 	return &result, nil
 }
 
+// Environment returns the device's PSU, fan, and thermal sensor state.
+func (c *Client) Environment(device string) (*newtron.Environment, error) {
+	var result newtron.Environment
+	if err := c.doGet(c.nodePath(device)+"/environment", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// VXLANStats returns the device's VXLAN tunnel encap/decap counters.
+func (c *Client) VXLANStats(device string) ([]newtron.VXLANStat, error) {
+	var result []newtron.VXLANStat
+	if err := c.doGet(c.nodePath(device)+"/evpn/vxlan-stats", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ARPSuppression returns every VLAN's ARP suppression state on the device.
+func (c *Client) ARPSuppression(device string) ([]newtron.ARPSuppressionState, error) {
+	var result []newtron.ARPSuppressionState
+	if err := c.doGet(c.nodePath(device)+"/evpn/arp-suppression", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EVPNMACIPRoutes returns the EVPN type-2 routes in vni's table on the
+// device.
+func (c *Client) EVPNMACIPRoutes(device string, vni int) ([]newtron.EVPNMACIPRoute, error) {
+	var result []newtron.EVPNMACIPRoute
+	if err := c.doGet(fmt.Sprintf("%s/evpn/macip-routes?vni=%d", c.nodePath(device), vni), &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// BreakoutModes returns the breakout modes each port of the device supports,
+// keyed by parent port.
+func (c *Client) BreakoutModes(device string) (map[string][]string, error) {
+	var result map[string][]string
+	if err := c.doGet(c.nodePath(device)+"/breakout-modes", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DHCPLeases returns the built-in DHCP server's leases on a VLAN, or on
+// every VLAN when vlanID is 0.
+func (c *Client) DHCPLeases(device string, vlanID int) ([]newtron.DHCPLease, error) {
+	path := c.nodePath(device) + "/dhcp-leases"
+	if vlanID != 0 {
+		path += "?vlan_id=" + fmt.Sprint(vlanID)
+	}
+	var result []newtron.DHCPLease
+	if err := c.doGet(path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FlapHistory returns a physical port's link-flap record.
+func (c *Client) FlapHistory(device, iface string) (*newtron.FlapHistory, error) {
+	var result newtron.FlapHistory
+	if err := c.doGet(c.interfacePath(device, iface)+"/flap-history", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// IGMPSnooping returns a VLAN's IGMP snooping state and learned multicast
+// groups.
+func (c *Client) IGMPSnooping(device string, vlanID int) (*newtron.IGMPState, error) {
+	var result newtron.IGMPState
+	if err := c.doGet(fmt.Sprintf("%s/vlans/%d/igmp-snooping", c.nodePath(device), vlanID), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Syslog returns the last lines lines of the device's syslog (server default
+// when 0); with since set, only lines stamped at or after it.
+func (c *Client) Syslog(device string, lines int, since time.Time) ([]newtron.LogLine, error) {
+	path := c.nodePath(device) + "/syslog"
+	params := url.Values{}
+	if lines > 0 {
+		params.Set("lines", fmt.Sprint(lines))
+	}
+	if !since.IsZero() {
+		params.Set("since", since.UTC().Format(time.RFC3339Nano))
+	}
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+	var result []newtron.LogLine
+	if err := c.doGet(path, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetConfigErrors returns delivered CONFIG_DB entries the dataplane has not
+// accepted (no confirming STATE_DB row).
+func (c *Client) GetConfigErrors(device string) ([]newtron.ConfigError, error) {
+	var result []newtron.ConfigError
+	if err := c.doGet(c.nodePath(device)+"/config-errors", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // ListLAGs returns LAG status entries.
 func (c *Client) ListLAGs(device string) ([]newtron.LAGStatusEntry, error) {
 	var result []newtron.LAGStatusEntry
@@ -182,15 +365,45 @@ func (c *Client) ShowLAGDetail(device, name string) (*newtron.LAGStatusEntry, er
 	return &result, nil
 }
 
-// CheckBGPSessions returns BGP session health check results.
-func (c *Client) CheckBGPSessions(device string) ([]newtron.HealthCheckResult, error) {
+// LAGHashDistribution samples a LAG's member TX counters over window (1s to
+// 1m; 0 for the server's default of 10s) and returns the per-member split.
+// The call takes the whole window.
+func (c *Client) LAGHashDistribution(device, name string, window time.Duration) (*newtron.LAGHashDistribution, error) {
+	path := c.nodePath(device) + "/lags/" + url.PathEscape(name) + "/hash-distribution"
+	if window > 0 {
+		path += "?window=" + url.QueryEscape(window.String())
+	}
+	var result newtron.LAGHashDistribution
+	if err := c.doGet(path, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CheckBGPSessions returns BGP session health check results. A non-empty vrf
+// scopes the check to that VRF's sessions.
+func (c *Client) CheckBGPSessions(device, vrf string) ([]newtron.HealthCheckResult, error) {
+	path := c.nodePath(device) + "/bgp/check"
+	if vrf != "" {
+		path += "?vrf=" + url.QueryEscape(vrf)
+	}
 	var result []newtron.HealthCheckResult
-	if err := c.doGet(c.nodePath(device)+"/bgp/check", &result); err != nil {
+	if err := c.doGet(path, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
 }
 
+// GetBGPConvergenceState returns the aggregate state of the device's expected
+// BGP sessions, overall and per VRF.
+func (c *Client) GetBGPConvergenceState(device string) (*newtron.BGPConvergence, error) {
+	var result newtron.BGPConvergence
+	if err := c.doGet(c.nodePath(device)+"/bgp/convergence", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // GetRoute looks up a route in APP_DB.
 func (c *Client) GetRoute(device, vrf, prefix string) (*newtron.RouteEntry, error) {
 	var result newtron.RouteEntry
@@ -211,6 +424,16 @@ func (c *Client) GetRouteASIC(device, prefix string) (*newtron.RouteEntry, error
 	return &result, nil
 }
 
+// GetNextHopGroups reads the ASIC_DB next-hop groups. On a device without
+// ASIC_DB the result has Skipped set and no groups.
+func (c *Client) GetNextHopGroups(device string) (*newtron.NextHopGroupsResult, error) {
+	var result newtron.NextHopGroupsResult
+	if err := c.doGet(c.nodePath(device)+"/next-hop-groups", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // ============================================================================
 // DB query operations
 // ============================================================================
@@ -360,13 +583,35 @@ func (c *Client) UnconfigureIRB(device string, vlanID int, opts newtron.ExecOpts
 	return c.nodeWrite(device, "unconfigure-irb", body, opts)
 }
 
+// ConfigureDHCPServer serves a VLAN's member ports from the built-in DHCP server.
+func (c *Client) ConfigureDHCPServer(device string, req api.DHCPServerRequest, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "configure-dhcp-server", req, opts)
+}
+
+// UnconfigureDHCPServer stops serving a VLAN from the built-in DHCP server.
+func (c *Client) UnconfigureDHCPServer(device string, vlanID int, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "unconfigure-dhcp-server", api.UnconfigureDHCPServerRequest{VlanID: vlanID}, opts)
+}
+
+// SetIGMPSnooping turns IGMP snooping on or off for a VLAN.
+func (c *Client) SetIGMPSnooping(device string, vlanID int, enable bool, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "set-igmp-snooping", api.IGMPSnoopingRequest{VlanID: vlanID, Enable: enable}, opts)
+}
+
+// DisableIGMPSnooping turns IGMP snooping off for a VLAN — the inverse of
+// enabling it.
+func (c *Client) DisableIGMPSnooping(device string, vlanID int, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "disable-igmp-snooping", api.DisableIGMPSnoopingRequest{VlanID: vlanID}, opts)
+}
+
 // CreateVRF creates a VRF.
 func (c *Client) CreateVRF(device, name string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
 	body := api.VRFCreateRequest{Name: name}
 	return c.nodeWrite(device, "create-vrf", body, opts)
 }
 
-// DeleteVRF deletes a VRF.
+// DeleteVRF deletes a VRF. opts.Force unbinds interfaces set-vrf bound to it
+// without an intent.
 func (c *Client) DeleteVRF(device, name string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
 	body := struct {
 		Name string `json:"name"`
@@ -394,12 +639,27 @@ func (c *Client) AddStaticRoute(device, vrf, prefix, nexthop string, metric int,
 	return c.nodeWrite(device, "add-static-route", body, opts)
 }
 
-// UpdateStaticRoute atomically mutates fields on a static route under
-// the per-device intent lock. Per §47 the key (vrf, prefix) is
-// immutable. Closes the forwarding black hole remove + add exposes
-// today (#227).
-func (c *Client) UpdateStaticRoute(device, vrf, prefix, nexthop string, metric int, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
-	body := api.StaticRouteUpdateRequest{VRF: vrf, Prefix: prefix, NextHop: nexthop, Metric: metric}
+// AddStaticRouteMulti adds a static route to a VRF with several paths
+// (ECMP) or as a blackhole.
+func (c *Client) AddStaticRouteMulti(device, vrf, prefix string, config newtron.StaticRouteConfig, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.StaticRouteRequest{
+		VRF: vrf, Prefix: prefix,
+		NextHops: config.NextHops, Interfaces: config.Interfaces, Blackhole: config.Blackhole,
+		Metric: config.Distance,
+	}
+	return c.nodeWrite(device, "add-static-route", body, opts)
+}
+
+// UpdateStaticRoute atomically replaces a static route's paths and metric
+// with config under the per-device intent lock. Per §47 the key (vrf,
+// prefix) is immutable. Closes the forwarding black hole remove + add
+// exposes today (#227).
+func (c *Client) UpdateStaticRoute(device, vrf, prefix string, config newtron.StaticRouteConfig, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.StaticRouteUpdateRequest{
+		VRF: vrf, Prefix: prefix,
+		NextHops: config.NextHops, Interfaces: config.Interfaces, Blackhole: config.Blackhole,
+		Metric: config.Distance,
+	}
 	return c.nodeWrite(device, "update-static-route", body, opts)
 }
 
@@ -412,6 +672,29 @@ func (c *Client) RemoveStaticRoute(device, vrf, prefix string, opts newtron.Exec
 	return c.nodeWrite(device, "remove-static-route", body, opts)
 }
 
+// AddRouteLeak leaks routes from srcVRF into dstVRF — all of them, or only
+// the given prefixes.
+func (c *Client) AddRouteLeak(device, srcVRF, dstVRF string, prefixes []string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.RouteLeakRequest{SrcVRF: srcVRF, DstVRF: dstVRF, Prefixes: prefixes}
+	return c.nodeWrite(device, "add-route-leak", body, opts)
+}
+
+// RemoveRouteLeak removes the route leak into dstVRF.
+func (c *Client) RemoveRouteLeak(device, dstVRF string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "remove-route-leak", api.RouteLeakRequest{DstVRF: dstVRF}, opts)
+}
+
+// SetVRFRouteTargets sets a VRF's EVPN import and export route targets.
+func (c *Client) SetVRFRouteTargets(device, vrf string, importRTs, exportRTs []string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.VRFRouteTargetsRequest{VRF: vrf, Import: importRTs, Export: exportRTs}
+	return c.nodeWrite(device, "set-vrf-route-targets", body, opts)
+}
+
+// ClearVRFRouteTargets restores a VRF's route targets to its IP-VPN's.
+func (c *Client) ClearVRFRouteTargets(device, vrf string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "clear-vrf-route-targets", api.VRFRouteTargetsRequest{VRF: vrf}, opts)
+}
+
 // CreateACL creates an ACL table.
 func (c *Client) CreateACL(device string, config newtron.ACLCreateRequest, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
 	return c.nodeWrite(device, "create-acl", config, opts)
@@ -482,13 +765,78 @@ func (c *Client) RemovePortChannelMember(device, pc, member string, opts newtron
 	return c.nodeWrite(device, "remove-portchannel-member", body, opts)
 }
 
+// SetLAGHashPolicy selects the ECMP/LAG hash fields on a device.
+func (c *Client) SetLAGHashPolicy(device string, fields []string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "set-lag-hash-policy", api.LAGHashPolicyRequest{HashFields: fields}, opts)
+}
+
+// ClearLAGHashPolicy removes the ECMP/LAG hash field selection from a device.
+func (c *Client) ClearLAGHashPolicy(device string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "clear-lag-hash-policy", nil, opts)
+}
+
+// SetBGPMaxPaths sets the BGP multipath width on a device.
+func (c *Client) SetBGPMaxPaths(device string, paths int, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "set-bgp-max-paths", api.BGPMaxPathsRequest{MaxPaths: paths}, opts)
+}
+
+// ClearBGPMaxPaths removes the BGP multipath width from a device.
+func (c *Client) ClearBGPMaxPaths(device string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "clear-bgp-max-paths", nil, opts)
+}
+
+// SetCounterPolling turns flex counter polling for a counter group on or off
+// on a device. A zero interval selects the group's default.
+func (c *Client) SetCounterPolling(device, counterType string, enable bool, interval time.Duration, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.CounterPollingRequest{CounterType: counterType, Enable: enable, IntervalMs: int(interval / time.Millisecond)}
+	return c.nodeWrite(device, "set-counter-polling", body, opts)
+}
+
+// ClearCounterPolling returns a counter group to its factory polling state.
+func (c *Client) ClearCounterPolling(device, counterType string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "clear-counter-polling", api.CounterPollingRequest{CounterType: counterType}, opts)
+}
+
+// SetBanner sets a device's login, MOTD, and logout messages, replacing any
+// banner already set.
+func (c *Client) SetBanner(device string, cfg newtron.BannerConfig, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	body := api.BannerRequest{Login: cfg.Login, MOTD: cfg.MOTD, Logout: cfg.Logout}
+	return c.nodeWrite(device, "set-banner", body, opts)
+}
+
+// ClearBanner removes a device's banner, restoring the image defaults.
+func (c *Client) ClearBanner(device string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "clear-banner", nil, opts)
+}
+
+// CreateMirrorSession mirrors ports to a remote collector over ERSPAN,
+// replacing the session's settings if it already exists.
+func (c *Client) CreateMirrorSession(device string, req api.MirrorSessionRequest, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "create-mirror-session", req, opts)
+}
+
+// DeleteMirrorSession removes a mirror session.
+func (c *Client) DeleteMirrorSession(device, name string, opts newtron.ExecOpts) (*newtron.WriteResult, error) {
+	return c.nodeWrite(device, "delete-mirror-session", api.DeleteMirrorSessionRequest{Name: name}, opts)
+}
+
 // ============================================================================
 // Device lifecycle operations (no ChangeSet)
 // ============================================================================
 
-// ConfigReload runs config reload on the device.
-func (c *Client) ConfigReload(device string) error {
-	return c.doPost(c.nodePath(device)+"/reload-config", nil, nil)
+// ConfigReload runs config reload on the device. Without force the server
+// refuses a reload that would discard unsaved work, naming the reason.
+func (c *Client) ConfigReload(device string, force bool) error {
+	return c.doPost(withForce(c.nodePath(device)+"/reload-config", force), nil, nil)
+}
+
+// ConfigReloadStatus reports whether ConfigReload would run without force.
+func (c *Client) ConfigReloadStatus(device string) (*newtron.ConfigReloadStatus, error) {
+	var result newtron.ConfigReloadStatus
+	if err := c.doGet(c.nodePath(device)+"/reload-status", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // SaveConfig saves the running config to config_db.json.
@@ -564,6 +912,51 @@ func (c *Client) ConfigDBSnapshot(device string, ownedOnly bool) (sonic.RawConfi
 	return result, nil
 }
 
+// ExportConfigDB writes the device's entire CONFIG_DB to w as config_db.json,
+// in the same canonical encoding the server produces.
+func (c *Client) ExportConfigDB(device string, w io.Writer) error {
+	var doc json.RawMessage
+	if err := c.doGet(c.nodePath(device)+"/configdb/export", &doc); err != nil {
+		return err
+	}
+	// The response envelope re-encodes the document; restore its file shape.
+	raw, err := sonic.DecodeConfigDBJSON(bytes.NewReader(doc))
+	if err != nil {
+		return err
+	}
+	return sonic.EncodeConfigDBJSON(w, raw)
+}
+
+// RunningConfig returns one section of the device's running config as text
+// ("bgp", "frr", a CONFIG_DB table such as "PORT"); "" returns all of it.
+func (c *Client) RunningConfig(device, section string) (string, error) {
+	path := c.nodePath(device) + "/running-config"
+	if section != "" {
+		path += "?section=" + url.QueryEscape(section)
+	}
+	var result string
+	if err := c.doGet(path, &result); err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// ImportConfigDB applies the config_db.json document read from r to the
+// device's CONFIG_DB. The document is parsed locally first, so a malformed
+// file fails before anything is sent.
+func (c *Client) ImportConfigDB(device string, r io.Reader) error {
+	raw, err := sonic.DecodeConfigDBJSON(r)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := sonic.EncodeConfigDBJSON(&buf, raw); err != nil {
+		return err
+	}
+	body := api.ImportConfigDBRequest{Config: buf.Bytes()}
+	return c.doPost(c.nodePath(device)+"/configdb/import", body, nil)
+}
+
 // IntentDrift compares the node projection (expected state) against actual
 // CONFIG_DB. Mode selects the source of expected state: "" or "intent" uses
 // device NEWTRON_INTENT records; "topology" uses topology.json steps.
@@ -579,6 +972,16 @@ func (c *Client) IntentDrift(device, mode string) ([]newtron.DriftEntry, error)
 	return result, nil
 }
 
+// ImportIntent reports which of the device's hand-built interface config an
+// existing service definition reproduces, and what matched nothing.
+func (c *Client) ImportIntent(device string) (*newtron.ImportedIntent, error) {
+	var result newtron.ImportedIntent
+	if err := c.doGet(c.nodePath(device)+"/intent/import", &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // IntentSave persists the device's current intent DB back to topology.json.
 func (c *Client) IntentSave(device, mode string) (*newtron.TopologySnapshot, error) {
 	path := c.nodePath(device) + "/intent/save"
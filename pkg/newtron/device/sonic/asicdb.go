@@ -6,13 +6,21 @@ package sonic
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/go-redis/redis/v8"
 
 )
 
+// ErrASICDBUnavailable is returned by ASIC_DB reads on a device whose ASIC_DB
+// could not be reached at connect time — expected on platforms with no real
+// ASIC (VPP), where there is nothing to inspect.
+var ErrASICDBUnavailable = errors.New("ASIC_DB not available")
+
 // AsicDBClient wraps Redis client for ASIC_DB access (DB 1).
 // More complex than AppDBClient due to SAI OID chain resolution.
 type AsicDBClient struct {
@@ -220,6 +228,70 @@ func (c *AsicDBClient) resolveNextHops(oid string) ([]NextHop, error) {
 	return nextHops, nil
 }
 
+// GetNextHopGroups reads every SAI_NEXT_HOP_GROUP programmed in ASIC_DB with
+// its members' next-hop IPs and weights, and counts the route entries that
+// point at each group (RefCount). Groups are sorted by OID, members by IP.
+func (c *AsicDBClient) GetNextHopGroups() ([]NextHopGroupEntry, error) {
+	const groupPrefix = "ASIC_STATE:SAI_OBJECT_TYPE_NEXT_HOP_GROUP:"
+	groupKeys, err := c.scanKeys(groupPrefix + "*")
+	if err != nil {
+		return nil, fmt.Errorf("scanning next hop groups: %w", err)
+	}
+	groups := make(map[string]*NextHopGroupEntry, len(groupKeys))
+	for _, key := range groupKeys {
+		oid := strings.TrimPrefix(key, groupPrefix)
+		groups[oid] = &NextHopGroupEntry{OID: oid}
+	}
+	if len(groups) == 0 {
+		return nil, nil
+	}
+
+	memberKeys, err := c.scanKeys("ASIC_STATE:SAI_OBJECT_TYPE_NEXT_HOP_GROUP_MEMBER:*")
+	if err != nil {
+		return nil, fmt.Errorf("scanning next hop group members: %w", err)
+	}
+	for _, mk := range memberKeys {
+		memberVals, err := c.client.HGetAll(c.ctx, mk).Result()
+		if err != nil {
+			continue
+		}
+		group, ok := groups[memberVals["SAI_NEXT_HOP_GROUP_MEMBER_ATTR_NEXT_HOP_GROUP_ID"]]
+		if !ok {
+			continue
+		}
+		member := NextHopGroupMember{Weight: 1}
+		if w, err := strconv.Atoi(memberVals["SAI_NEXT_HOP_GROUP_MEMBER_ATTR_WEIGHT"]); err == nil && w > 0 {
+			member.Weight = w
+		}
+		if nhOID := memberVals["SAI_NEXT_HOP_GROUP_MEMBER_ATTR_NEXT_HOP_ID"]; nhOID != "" {
+			member.IP, _ = c.client.HGet(c.ctx, "ASIC_STATE:SAI_OBJECT_TYPE_NEXT_HOP:"+nhOID, "SAI_NEXT_HOP_ATTR_IP").Result()
+		}
+		group.Members = append(group.Members, member)
+	}
+
+	routeKeys, err := c.scanKeys("ASIC_STATE:SAI_OBJECT_TYPE_ROUTE_ENTRY:*")
+	if err != nil {
+		return nil, fmt.Errorf("scanning route entries: %w", err)
+	}
+	for _, rk := range routeKeys {
+		nhID, err := c.client.HGet(c.ctx, rk, "SAI_ROUTE_ENTRY_ATTR_NEXT_HOP_ID").Result()
+		if err != nil {
+			continue
+		}
+		if group, ok := groups[nhID]; ok {
+			group.RefCount++
+		}
+	}
+
+	out := make([]NextHopGroupEntry, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group.Members, func(i, j int) bool { return group.Members[i].IP < group.Members[j].IP })
+		out = append(out, *group)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OID < out[j].OID })
+	return out, nil
+}
+
 // scanKeys uses SCAN to find keys matching a pattern (avoids KEYS on large databases).
 func (c *AsicDBClient) scanKeys(pattern string) ([]string, error) {
 	var allKeys []string
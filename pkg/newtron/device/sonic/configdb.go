@@ -28,8 +28,19 @@ type ConfigDB struct {
 	VXLANTunnelMap       map[string]VXLANMapEntry      `json:"VXLAN_TUNNEL_MAP,omitempty"`
 	VXLANEVPNNVO         map[string]EVPNNVOEntry       `json:"VXLAN_EVPN_NVO,omitempty"`
 	SuppressVLANNeigh    map[string]map[string]string  `json:"SUPPRESS_VLAN_NEIGH,omitempty"`
+	VLANTranslation      map[string]map[string]string  `json:"VLAN_TRANSLATION,omitempty"`
 	SAG                  map[string]map[string]string  `json:"SAG,omitempty"`
 	SAGGlobal            map[string]map[string]string  `json:"SAG_GLOBAL,omitempty"`
+	Switch               map[string]map[string]string  `json:"SWITCH,omitempty"`
+	SwitchHash           map[string]map[string]string  `json:"SWITCH_HASH,omitempty"`
+	FlexCounterTable     map[string]map[string]string  `json:"FLEX_COUNTER_TABLE,omitempty"`
+	BannerMessage        map[string]map[string]string  `json:"BANNER_MESSAGE,omitempty"`
+	DHCPServerIPv4       map[string]map[string]string  `json:"DHCP_SERVER_IPV4,omitempty"`
+	DHCPServerIPv4Range  map[string]map[string]string  `json:"DHCP_SERVER_IPV4_RANGE,omitempty"`
+	DHCPServerIPv4Option map[string]map[string]string  `json:"DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS,omitempty"`
+	DHCPServerIPv4Port   map[string]map[string]string  `json:"DHCP_SERVER_IPV4_PORT,omitempty"`
+	MirrorSession        map[string]map[string]string  `json:"MIRROR_SESSION,omitempty"`
+	L2MC                 map[string]map[string]string  `json:"CFG_L2MC_TABLE,omitempty"`
 	BGPNeighbor          map[string]BGPNeighborEntry   `json:"BGP_NEIGHBOR,omitempty"`
 	BGPNeighborAF        map[string]BGPNeighborAFEntry `json:"BGP_NEIGHBOR_AF,omitempty"`
 	BGPGlobals           map[string]BGPGlobalsEntry    `json:"BGP_GLOBALS,omitempty"`
@@ -93,6 +104,24 @@ const (
 	OpConfigureInterface   = "configure-interface"
 	OpAddTrunkVLAN         = "add-trunk-vlan"    // per-VLAN intent record op (#224)
 	OpRemoveTrunkVLAN      = "remove-trunk-vlan" // wire verb tag; no intent (#224)
+	OpSetVLANTranslation   = "set-vlan-translation"
+	OpClearVLANTranslation = "clear-vlan-translation" // wire verb tag; no intent
+	OpSetEgressShaper      = "set-egress-shaper"
+	OpClearEgressShaper    = "clear-egress-shaper" // wire verb tag; no intent
+	OpIsolateInterface     = "isolate-interface"
+	OpUnisolateInterface   = "unisolate-interface" // wire verb tag; no intent
+	OpSetLAGHashPolicy     = "set-lag-hash-policy"
+	OpClearLAGHashPolicy   = "clear-lag-hash-policy" // wire verb tag; no intent
+	OpSetBGPMaxPaths       = "set-bgp-max-paths"
+	OpClearBGPMaxPaths     = "clear-bgp-max-paths" // wire verb tag; no intent
+	OpSetCounterPolling    = "set-counter-polling"
+	OpClearCounterPolling  = "clear-counter-polling" // wire verb tag; no intent
+	OpSetBanner            = "set-banner"
+	OpClearBanner          = "clear-banner" // wire verb tag; no intent
+	OpAddRouteLeak         = "add-route-leak"
+	OpRemoveRouteLeak      = "remove-route-leak" // wire verb tag; no intent
+	OpSetVRFRouteTargets   = "set-vrf-route-targets"
+	OpClearVRFRouteTargets = "clear-vrf-route-targets" // wire verb tag; no intent
 	OpAddBGPPeer           = "add-bgp-peer"
 	OpUpdateBGPPeer        = "update-bgp-peer" // in-place per-peer mutation (#227, §48)
 	OpApplyService         = "apply-service"
@@ -104,6 +133,15 @@ const (
 	OpAddPortChannelMember = "add-pc-member"
 	OpInterfaceInit        = "interface-init"
 	OpDeployService        = "deploy-service"
+
+	OpConfigureDHCPServer   = "configure-dhcp-server"
+	OpUnconfigureDHCPServer = "unconfigure-dhcp-server" // wire verb tag; no intent
+
+	OpCreateMirrorSession = "create-mirror-session"
+	OpDeleteMirrorSession = "delete-mirror-session" // wire verb tag; no intent
+
+	OpEnableIGMPSnooping  = "enable-igmp-snooping"
+	OpDisableIGMPSnooping = "disable-igmp-snooping" // wire verb tag; no intent
 )
 
 // Intent param field names — shared across intent construction, teardown reads,
@@ -113,6 +151,7 @@ const (
 	FieldServiceType    = "service_type"
 	FieldVRFName        = "vrf_name"
 	FieldIPAddress      = "ip_address"
+	FieldSecondaryIPs   = "secondary_ips"
 	FieldVLANID         = "vlan_id"
 	FieldL3VNI          = "l3vni"
 	FieldL3VNIVlan      = "l3vni_vlan"
@@ -123,6 +162,8 @@ const (
 	FieldPrefix         = "prefix"
 	FieldNextHop        = "next_hop"
 	FieldMetric         = "metric"
+	FieldIfname         = "ifname"
+	FieldBlackhole      = "blackhole"
 	FieldASN            = "asn"
 	FieldEVPN           = "evpn"
 	FieldDescription    = "description"
@@ -146,6 +187,41 @@ const (
 	FieldTagged         = "tagged"
 	FieldARPSuppression = "arp_suppression"
 	FieldRules          = "rules"
+	FieldInVLAN         = "in_vlan"
+	FieldOutVLAN        = "out_vlan"
+	FieldRateKbps       = "rate_kbps"
+	FieldHashFields     = "hash_fields"
+	FieldMaxPaths       = "max_paths"
+	FieldCounterType    = "counter_type"
+	FieldEnable         = "enable"
+	FieldPollInterval   = "poll_interval"
+	FieldLogin          = "login"
+	FieldMOTD           = "motd"
+	FieldLogout         = "logout"
+	FieldSrcVRF         = "src_vrf"
+	FieldPrefixes       = "prefixes"
+	FieldImportRTs      = "import_rts"
+	FieldExportRTs      = "export_rts"
+	FieldRanges         = "ranges"
+	FieldGateway        = "gateway"
+	FieldLeaseTime      = "lease_time"
+	FieldOptions        = "options"
+	FieldSrcIP          = "src_ip"
+	FieldDstIP          = "dst_ip"
+	FieldDSCP           = "dscp"
+	FieldTTL            = "ttl"
+	FieldGREType        = "gre_type"
+
+	// Prior state recorded by isolate-interface, restored by unisolate-interface.
+	FieldPriorAdminStatus = "prior_admin_status"
+	FieldPortChannel      = "portchannel"
+
+	// Anycast gateway MAC scope on configure-irb: "true" when the IRB's SAG
+	// MAC is its own SAG|Vlan<N>|IPv4 row rather than the SAG_GLOBAL singleton.
+	FieldAnycastMACPerVLAN = "anycast_mac_per_vlan"
+
+	FieldMaxPrefix       = "max_prefix"
+	FieldMaxPrefixAction = "max_prefix_action"
 	// FieldFilter records the source filter spec name on a service-derived
 	// create-acl intent. The ACL table itself is content-hash-named (§24/§25),
 	// so the hashed name can't be reversed to the filter; this preserves the
@@ -424,6 +500,11 @@ type BGPGlobalsAFEntry struct {
 	MaxIBGPPaths          string `json:"max_ibgp_paths,omitempty"`
 	RedistributeConnected string `json:"redistribute_connected,omitempty"`
 	RedistributeStatic    string `json:"redistribute_static,omitempty"`
+
+	// Inter-VRF route leaking: "import vrf <ImportVRF>", filtered by
+	// "import vrf route-map <ImportVRFRouteMap>".
+	ImportVRF         string `json:"import_vrf,omitempty"`
+	ImportVRFRouteMap string `json:"import_vrf_route_map,omitempty"`
 }
 
 // BGPGlobalsEVPNRTEntry represents a per-VRF EVPN route-target entry (frrcfgd managed).
@@ -473,6 +554,13 @@ type BGPNeighborAFEntry struct {
 	PrefixListOut    string `json:"prefix_list_out,omitempty"`
 	DefaultOriginate string `json:"default_originate,omitempty"`
 	AddpathTxAll     string `json:"addpath_tx_all_paths,omitempty"`
+
+	// Prefix limit: the session is torn down past MaxPrefixLimit received
+	// prefixes — unless MaxPrefixWarningOnly, which only logs — and
+	// re-established after MaxPrefixRestartInterval minutes when set.
+	MaxPrefixLimit           string `json:"max_prefix_limit,omitempty"`
+	MaxPrefixWarningOnly     string `json:"max_prefix_warning_only,omitempty"`
+	MaxPrefixRestartInterval string `json:"max_prefix_restart_interval,omitempty"`
 }
 
 // StaticRouteEntry represents a static route in CONFIG_DB's ROUTE_TABLE.
@@ -499,6 +587,8 @@ type ACLRuleEntry struct {
 	PacketAction   string `json:"PACKET_ACTION,omitempty"`
 	SrcIP          string `json:"SRC_IP,omitempty"`
 	DstIP          string `json:"DST_IP,omitempty"`
+	SrcIPv6        string `json:"SRC_IPV6,omitempty"`
+	DstIPv6        string `json:"DST_IPV6,omitempty"`
 	IPProtocol     string `json:"IP_PROTOCOL,omitempty"`
 	L4SrcPort      string `json:"L4_SRC_PORT,omitempty"`
 	L4DstPort      string `json:"L4_DST_PORT,omitempty"`
@@ -508,6 +598,8 @@ type ACLRuleEntry struct {
 	DSCP           string `json:"DSCP,omitempty"`
 	ICMPType       string `json:"ICMP_TYPE,omitempty"`
 	ICMPCode       string `json:"ICMP_CODE,omitempty"`
+	ICMPv6Type     string `json:"ICMPV6_TYPE,omitempty"`
+	ICMPv6Code     string `json:"ICMPV6_CODE,omitempty"`
 	EtherType      string `json:"ETHER_TYPE,omitempty"`
 	InPorts        string `json:"IN_PORTS,omitempty"`
 	RedirectPort   string `json:"REDIRECT_PORT,omitempty"`
@@ -515,8 +607,10 @@ type ACLRuleEntry struct {
 
 // SchedulerEntry represents a QoS scheduler
 type SchedulerEntry struct {
-	Type   string `json:"type"`             // DWRR, STRICT
-	Weight string `json:"weight,omitempty"` // For DWRR
+	Type      string `json:"type,omitempty"`       // DWRR, STRICT; absent on a port shaper
+	Weight    string `json:"weight,omitempty"`     // For DWRR
+	MeterType string `json:"meter_type,omitempty"` // "bytes" for a port shaper
+	PIR       string `json:"pir,omitempty"`        // Peak rate (bytes/s when meter_type is bytes)
 }
 
 // QueueEntry represents a queue configuration
@@ -543,6 +637,7 @@ type WREDProfileEntry struct {
 type PortQoSMapEntry struct {
 	DSCPToTCMap  string `json:"dscp_to_tc_map,omitempty"`
 	TCToQueueMap string `json:"tc_to_queue_map,omitempty"`
+	Scheduler    string `json:"scheduler,omitempty"` // Port egress shaper ([SCHEDULER|...])
 }
 
 // ============================================================================
@@ -677,6 +772,8 @@ func (db *ConfigDB) DeleteEntry(table, key string) {
 		delete(db.NewtronIntent, key)
 	case "SUPPRESS_VLAN_NEIGH":
 		delete(db.SuppressVLANNeigh, key)
+	case "VLAN_TRANSLATION":
+		delete(db.VLANTranslation, key)
 	case "ACL_TABLE":
 		delete(db.ACLTable, key)
 	case "ACL_RULE":
@@ -687,6 +784,26 @@ func (db *ConfigDB) DeleteEntry(table, key string) {
 		delete(db.RouteRedistribute, key)
 	case "SAG_GLOBAL":
 		delete(db.SAGGlobal, key)
+	case "SWITCH":
+		delete(db.Switch, key)
+	case "SWITCH_HASH":
+		delete(db.SwitchHash, key)
+	case "FLEX_COUNTER_TABLE":
+		delete(db.FlexCounterTable, key)
+	case "BANNER_MESSAGE":
+		delete(db.BannerMessage, key)
+	case "DHCP_SERVER_IPV4":
+		delete(db.DHCPServerIPv4, key)
+	case "DHCP_SERVER_IPV4_RANGE":
+		delete(db.DHCPServerIPv4Range, key)
+	case "DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS":
+		delete(db.DHCPServerIPv4Option, key)
+	case "DHCP_SERVER_IPV4_PORT":
+		delete(db.DHCPServerIPv4Port, key)
+	case "MIRROR_SESSION":
+		delete(db.MirrorSession, key)
+	case "CFG_L2MC_TABLE":
+		delete(db.L2MC, key)
 	case "ROUTE_MAP":
 		delete(db.RouteMap, key)
 	case "PREFIX_SET":
@@ -777,12 +894,45 @@ func (db *ConfigDB) ExportEntries() []Entry {
 	for k, v := range db.SuppressVLANNeigh {
 		appendRaw("SUPPRESS_VLAN_NEIGH", k, v)
 	}
+	for k, v := range db.VLANTranslation {
+		appendRaw("VLAN_TRANSLATION", k, v)
+	}
 	for k, v := range db.SAG {
 		appendRaw("SAG", k, v)
 	}
 	for k, v := range db.SAGGlobal {
 		appendRaw("SAG_GLOBAL", k, v)
 	}
+	for k, v := range db.Switch {
+		appendRaw("SWITCH", k, v)
+	}
+	for k, v := range db.SwitchHash {
+		appendRaw("SWITCH_HASH", k, v)
+	}
+	for k, v := range db.FlexCounterTable {
+		appendRaw("FLEX_COUNTER_TABLE", k, v)
+	}
+	for k, v := range db.BannerMessage {
+		appendRaw("BANNER_MESSAGE", k, v)
+	}
+	for k, v := range db.DHCPServerIPv4 {
+		appendRaw("DHCP_SERVER_IPV4", k, v)
+	}
+	for k, v := range db.DHCPServerIPv4Range {
+		appendRaw("DHCP_SERVER_IPV4_RANGE", k, v)
+	}
+	for k, v := range db.DHCPServerIPv4Option {
+		appendRaw("DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS", k, v)
+	}
+	for k, v := range db.DHCPServerIPv4Port {
+		appendRaw("DHCP_SERVER_IPV4_PORT", k, v)
+	}
+	for k, v := range db.MirrorSession {
+		appendRaw("MIRROR_SESSION", k, v)
+	}
+	for k, v := range db.L2MC {
+		appendRaw("CFG_L2MC_TABLE", k, v)
+	}
 	for k, v := range db.DSCPToTCMap {
 		appendRaw("DSCP_TO_TC_MAP", k, v)
 	}
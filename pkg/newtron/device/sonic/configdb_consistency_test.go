@@ -192,7 +192,10 @@ func TestDeleteEntry_CoversAllHydratedTables(t *testing.T) {
 		"DEVICE_METADATA", "NEWTRON_INTENT", "SUPPRESS_VLAN_NEIGH",
 		"LOOPBACK_INTERFACE", "SAG_GLOBAL", "VLAN_INTERFACE",
 		"PORTCHANNEL_MEMBER", "DSCP_TO_TC_MAP", "TC_TO_QUEUE_MAP",
-		"STATIC_ROUTE", "SAG",
+		"STATIC_ROUTE", "SAG", "VLAN_TRANSLATION", "SWITCH", "SWITCH_HASH",
+		"FLEX_COUNTER_TABLE", "BANNER_MESSAGE", "DHCP_SERVER_IPV4",
+		"DHCP_SERVER_IPV4_RANGE", "DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS", "DHCP_SERVER_IPV4_PORT",
+		"MIRROR_SESSION", "CFG_L2MC_TABLE",
 	}
 	for _, table := range rawTables {
 		t.Run(table, func(t *testing.T) {
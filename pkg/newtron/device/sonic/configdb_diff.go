@@ -28,11 +28,19 @@ type DriftEntry struct {
 //   - NEWTRON_INTENT, NEWTRON_HISTORY: ephemeral/rolling
 //   - PORT: factory-managed (all HWSKU ports exist in config_db.json)
 //   - DEVICE_METADATA: partially factory, partially newtron — too noisy
+//   - SWITCH: factory switch-global entry; newtron owns only the hash seeds
+//   - FLEX_COUNTER_TABLE: factory-populated counter groups; newtron owns
+//     only the groups it has set
+//   - BANNER_MESSAGE: factory default banners on newer images; newtron owns
+//     the entry only while a banner is set
 var excludedFromDrift = map[string]bool{
-	"NEWTRON_INTENT":  true,
-	"NEWTRON_HISTORY": true,
-	"PORT":            true,
-	"DEVICE_METADATA": true,
+	"NEWTRON_INTENT":     true,
+	"NEWTRON_HISTORY":    true,
+	"PORT":               true,
+	"DEVICE_METADATA":    true,
+	"SWITCH":             true,
+	"FLEX_COUNTER_TABLE": true,
+	"BANNER_MESSAGE":     true,
 }
 
 // DiffConfigDB compares expected vs actual CONFIG_DB, returning differences.
@@ -162,6 +170,10 @@ var tablePriority = map[string]int{
 	"SCHEDULER":           0,
 	"WRED_PROFILE":        0,
 	"SAG_GLOBAL":          0,
+	"SWITCH":              0,
+	"SWITCH_HASH":         0,
+	"FLEX_COUNTER_TABLE":  0,
+	"BANNER_MESSAGE":      0,
 	"SUPPRESS_VLAN_NEIGH": 0,
 	"STATIC_ROUTE":        0,
 	"PREFIX_SET":          0,
@@ -169,9 +181,13 @@ var tablePriority = map[string]int{
 	"NEWTRON_INTENT":      0,
 	"NEWTRON_HISTORY":     0,
 
+	"DHCP_SERVER_IPV4_RANGE":              0,
+	"DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS": 0,
+
 	// Tier 1 — depends on tier 0
 	"PORTCHANNEL_MEMBER":    1, // → PORTCHANNEL
 	"VLAN_MEMBER":           1, // → VLAN
+	"VLAN_TRANSLATION":      1, // → VLAN (translated_vlan)
 	"VLAN_INTERFACE":        1, // → VLAN
 	"INTERFACE":             1, // → VRF (vrf_name)
 	"PORTCHANNEL_INTERFACE": 1, // → PORTCHANNEL, VRF (vrf_name)
@@ -182,6 +198,10 @@ var tablePriority = map[string]int{
 	"QUEUE":                 1, // → SCHEDULER
 	"BGP_PEER_GROUP":        1, // → BGP_GLOBALS (implicit)
 	"ROUTE_MAP":             1, // → PREFIX_SET, COMMUNITY_SET
+	"DHCP_SERVER_IPV4":      1, // → VLAN, DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS
+	"MIRROR_SESSION":        1, // → PORT (src_port), VRF (vrf)
+	"CFG_L2MC_TABLE":        1, // → VLAN
+	"SAG":                   1, // → VLAN
 
 	// Tier 2 — depends on tier 1
 	"BGP_NEIGHBOR":        2, // → BGP_GLOBALS
@@ -192,6 +212,8 @@ var tablePriority = map[string]int{
 	"VXLAN_TUNNEL_MAP":    2, // → VXLAN_EVPN_NVO
 	"BGP_EVPN_VNI":        2, // → VXLAN_TUNNEL_MAP (implicit)
 
+	"DHCP_SERVER_IPV4_PORT": 2, // → DHCP_SERVER_IPV4, DHCP_SERVER_IPV4_RANGE
+
 	// Tier 3 — depends on tier 2
 	"BGP_NEIGHBOR_AF": 3, // → BGP_NEIGHBOR
 }
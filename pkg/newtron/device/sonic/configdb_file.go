@@ -0,0 +1,174 @@
+package sonic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+)
+
+// FileConfigDB is a CONFIG_DB held in a config_db.json file instead of Redis.
+// It answers the same Get / Exists / SetWithReply / HDelWithReply /
+// DeleteWithReply calls as ConfigDBClient, with the same replies, so a
+// ChangeSet can be delivered to it unchanged — offline rendering of what a
+// device would hold, without a device. Writes go to memory; Save writes the file.
+//
+// Rows follow Redis semantics: a row whose last field is removed is gone,
+// and a row set with no fields holds the "NULL" sentinel (dropped again by
+// EncodeConfigDBJSON, as `config save` does).
+type FileConfigDB struct {
+	path string
+	raw  RawConfigDB
+}
+
+// NewFileConfigDB returns an empty CONFIG_DB that Save writes to path. With
+// an empty path it lives in memory only — an offline render read back
+// through Raw — and Save refuses.
+func NewFileConfigDB(path string) *FileConfigDB {
+	return &FileConfigDB{path: path, raw: RawConfigDB{}}
+}
+
+// OpenFileConfigDB loads path as config_db.json. A file that does not exist
+// opens as an empty CONFIG_DB and is created by Save.
+func OpenFileConfigDB(path string) (*FileConfigDB, error) {
+	f := NewFileConfigDB(path)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	raw, err := DecodeConfigDBJSON(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for table, rows := range raw {
+		for key, fields := range rows {
+			if len(fields) == 0 {
+				fields[nullSentinel] = nullSentinel
+			}
+			f.row(table, key, true)
+			f.raw[table][key] = fields
+		}
+	}
+	return f, nil
+}
+
+// Raw returns the CONFIG_DB as it stands, including unsaved writes. The
+// caller must not modify it.
+func (f *FileConfigDB) Raw() RawConfigDB { return f.raw }
+
+// Get returns a copy of table|key's fields; empty when the row is absent.
+func (f *FileConfigDB) Get(table, key string) (map[string]string, error) {
+	out := maps.Clone(f.row(table, key, false))
+	if out == nil {
+		out = map[string]string{}
+	}
+	return out, nil
+}
+
+// Exists reports whether table|key is present.
+func (f *FileConfigDB) Exists(table, key string) (bool, error) {
+	return f.row(table, key, false) != nil, nil
+}
+
+// SetWithReply merges fields into table|key, creating the row. Like HSET it
+// returns the number of fields that were new.
+func (f *FileConfigDB) SetWithReply(table, key string, fields map[string]string) (int64, error) {
+	if len(fields) == 0 {
+		fields = map[string]string{nullSentinel: nullSentinel}
+	}
+	row := f.row(table, key, true)
+	var added int64
+	for k, v := range fields {
+		if _, ok := row[k]; !ok {
+			added++
+		}
+		row[k] = v
+	}
+	return added, nil
+}
+
+// HDelWithReply removes fields from table|key and returns how many were
+// present. A row left with no fields is removed.
+func (f *FileConfigDB) HDelWithReply(table, key string, fields []string) (int64, error) {
+	row := f.row(table, key, false)
+	var removed int64
+	for _, k := range fields {
+		if _, ok := row[k]; ok {
+			delete(row, k)
+			removed++
+		}
+	}
+	if row != nil && len(row) == 0 {
+		f.DeleteWithReply(table, key)
+	}
+	return removed, nil
+}
+
+// DeleteWithReply removes table|key; 1 if it existed, 0 if not.
+func (f *FileConfigDB) DeleteWithReply(table, key string) (int64, error) {
+	if f.row(table, key, false) == nil {
+		return 0, nil
+	}
+	delete(f.raw[table], key)
+	if len(f.raw[table]) == 0 {
+		delete(f.raw, table)
+	}
+	return 1, nil
+}
+
+// Save writes the CONFIG_DB to the file as config_db.json, through a
+// temporary file renamed into place, so a failed save leaves the old file.
+func (f *FileConfigDB) Save() error {
+	if f.path == "" {
+		return fmt.Errorf("in-memory CONFIG_DB has no file to save to")
+	}
+	var buf bytes.Buffer
+	if err := EncodeConfigDBJSON(&buf, f.raw); err != nil {
+		return err
+	}
+	dir := filepath.Dir(f.path)
+	tmp, err := os.CreateTemp(dir, "config_db-*.json.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("closing temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, f.path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("renaming temp file: %w", err)
+	}
+	return nil
+}
+
+// row returns table|key's field map, creating it when create is set; nil
+// when absent and not created.
+func (f *FileConfigDB) row(table, key string, create bool) map[string]string {
+	rows := f.raw[table]
+	if rows == nil {
+		if !create {
+			return nil
+		}
+		rows = map[string]map[string]string{}
+		f.raw[table] = rows
+	}
+	row := rows[key]
+	if row == nil && create {
+		row = map[string]string{}
+		rows[key] = row
+	}
+	return row
+}
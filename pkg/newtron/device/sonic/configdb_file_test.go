@@ -0,0 +1,100 @@
+package sonic
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileConfigDB_RedisReplies(t *testing.T) {
+	f, err := OpenFileConfigDB(filepath.Join(t.TempDir(), "config_db.json"))
+	if err != nil {
+		t.Fatalf("open missing file: %v", err)
+	}
+	if got, _ := f.Get("VLAN", "Vlan100"); len(got) != 0 {
+		t.Errorf("Get on empty = %v", got)
+	}
+
+	steps := []struct {
+		name string
+		do   func() (int64, error)
+		want int64
+	}{
+		{"HSET new row", func() (int64, error) {
+			return f.SetWithReply("VLAN", "Vlan100", map[string]string{"vlanid": "100", "mtu": "9100"})
+		}, 2},
+		{"HSET one new field", func() (int64, error) {
+			return f.SetWithReply("VLAN", "Vlan100", map[string]string{"mtu": "1500", "description": "x"})
+		}, 1},
+		{"HDEL present and absent", func() (int64, error) {
+			return f.HDelWithReply("VLAN", "Vlan100", []string{"description", "nope"})
+		}, 1},
+		{"DEL present", func() (int64, error) { return f.DeleteWithReply("VLAN", "Vlan100") }, 1},
+		{"DEL absent", func() (int64, error) { return f.DeleteWithReply("VLAN", "Vlan100") }, 0},
+	}
+	for _, s := range steps {
+		got, err := s.do()
+		if err != nil || got != s.want {
+			t.Errorf("%s = %d, %v; want %d", s.name, got, err, s.want)
+		}
+	}
+	if len(f.Raw()) != 0 {
+		t.Errorf("after DEL, Raw = %v, want empty (table dropped with its last row)", f.Raw())
+	}
+
+	// Removing a row's last field removes the row, as in Redis.
+	f.SetWithReply("VRF", "Vrf_a", map[string]string{"vni": "1000"})
+	f.HDelWithReply("VRF", "Vrf_a", []string{"vni"})
+	if _, ok := f.Raw()["VRF"]; ok {
+		t.Errorf("row with no fields left should be gone: %v", f.Raw())
+	}
+}
+
+// TestFileConfigDB_SaveRoundTrip — a field-less row and a list field survive
+// save and reopen, and the file is the config_db.json shape.
+func TestFileConfigDB_SaveRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config_db.json")
+	f, _ := OpenFileConfigDB(path)
+	f.SetWithReply("VLAN_MEMBER", "Vlan100|Ethernet0", map[string]string{"tagging_mode": "untagged"})
+	f.SetWithReply("LOOPBACK_INTERFACE", "Loopback0", nil)
+	f.SetWithReply("PORTCHANNEL", "PortChannel1", map[string]string{"members@": "Ethernet0,Ethernet4"})
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{
+    "LOOPBACK_INTERFACE": {
+        "Loopback0": {}
+    },
+    "PORTCHANNEL": {
+        "PortChannel1": {
+            "members": [
+                "Ethernet0",
+                "Ethernet4"
+            ]
+        }
+    },
+    "VLAN_MEMBER": {
+        "Vlan100|Ethernet0": {
+            "tagging_mode": "untagged"
+        }
+    }
+}
+`
+	if string(data) != want {
+		t.Errorf("file =\n%s\nwant\n%s", data, want)
+	}
+
+	g, err := OpenFileConfigDB(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if !reflect.DeepEqual(g.Raw(), f.Raw()) {
+		t.Errorf("reopened = %v, want %v", g.Raw(), f.Raw())
+	}
+}
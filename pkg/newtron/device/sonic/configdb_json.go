@@ -0,0 +1,348 @@
+package sonic
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ============================================================================
+// config_db.json — the serialization boundary for a whole CONFIG_DB.
+//
+// The file shape is SONiC's own (what `config save` writes and `config load`
+// reads): table → key → field → value, keys sorted, four-space indent. Two
+// Redis conventions are translated on the way through, exactly as
+// sonic-cfggen does:
+//
+//   - a list field is stored in Redis as "name@" = "a,b" and written to the
+//     file as "name": ["a", "b"];
+//   - a field-less row is stored with the "NULL" = "NULL" sentinel and
+//     written to the file as {}.
+//
+// Guarantee: export → import → export is stable. EncodeConfigDBJSON is
+// deterministic, DecodeConfigDBJSON inverts it exactly (modulo the sentinel,
+// which PipelineSet and ImportConfigDB re-create for empty rows), and
+// ImportConfigDB writes nothing for a row already equal to the file — so
+// re-importing a fresh export is a no-op, and exporting again yields the
+// same bytes.
+// ============================================================================
+
+// nullSentinel is the placeholder field SONiC writes for a field-less row.
+const nullSentinel = "NULL"
+
+// EncodeConfigDBJSON writes raw to w as config_db.json.
+func EncodeConfigDBJSON(w io.Writer, raw RawConfigDB) error {
+	doc := make(map[string]map[string]map[string]any, len(raw))
+	for table, rows := range raw {
+		out := make(map[string]map[string]any, len(rows))
+		for key, fields := range rows {
+			row := make(map[string]any, len(fields))
+			for f, v := range fields {
+				switch {
+				case f == nullSentinel && v == nullSentinel:
+					continue
+				case strings.HasSuffix(f, "@"):
+					list := []string{}
+					if v != "" {
+						list = strings.Split(v, ",")
+					}
+					row[strings.TrimSuffix(f, "@")] = list
+				default:
+					row[f] = v
+				}
+			}
+			out[key] = row
+		}
+		doc[table] = out
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	return enc.Encode(doc)
+}
+
+// DecodeConfigDBJSON reads a config_db.json document. A list value becomes a
+// "name@" field; numbers and booleans (hand-edited files carry them; SONiC
+// writes strings) keep their literal text. Any other shape is an error
+// naming the offending table|key.
+func DecodeConfigDBJSON(r io.Reader) (RawConfigDB, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	var doc map[string]map[string]map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parsing config_db.json: %w", err)
+	}
+	if dec.More() {
+		return nil, fmt.Errorf("parsing config_db.json: trailing data after the document")
+	}
+	raw := make(RawConfigDB, len(doc))
+	for table, rows := range doc {
+		raw[table] = make(map[string]map[string]string, len(rows))
+		for key, row := range rows {
+			fields := make(map[string]string, len(row))
+			for f, v := range row {
+				switch t := v.(type) {
+				case string:
+					fields[f] = t
+				case json.Number:
+					fields[f] = t.String()
+				case bool:
+					fields[f] = fmt.Sprint(t)
+				case []any:
+					items := make([]string, len(t))
+					for i, item := range t {
+						s, ok := item.(string)
+						if !ok {
+							return nil, fmt.Errorf("config_db.json %s|%s: field %s: list items must be strings", table, key, f)
+						}
+						items[i] = s
+					}
+					fields[f+"@"] = strings.Join(items, ",")
+				default:
+					return nil, fmt.Errorf("config_db.json %s|%s: field %s: unsupported value %v", table, key, f, v)
+				}
+			}
+			raw[table][key] = fields
+		}
+	}
+	return raw, nil
+}
+
+// ExportConfigDB writes the device's entire CONFIG_DB to w as config_db.json.
+func (d *Device) ExportConfigDB(ctx context.Context, w io.Writer) error {
+	if err := d.RequireConnected(); err != nil {
+		return err
+	}
+	raw, err := d.client.GetRawAllTables(ctx)
+	if err != nil {
+		return err
+	}
+	// Encode fully before writing so a failure leaves w untouched.
+	var buf bytes.Buffer
+	if err := EncodeConfigDBJSON(&buf, raw); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(w)
+	return err
+}
+
+// ImportConfigDB makes CONFIG_DB match a config_db.json document, table by
+// table: every table the document names ends up holding exactly the
+// document's rows, and tables it does not name are left alone — so a full
+// export restores the whole DB and a one-table file touches one table.
+// Rows already equal are not written; changed rows are updated in place
+// (HSET the new values, HDEL dropped fields), never deleted and re-added,
+// so a subscriber sees one update rather than a remove/add pair. PORT rows
+// are never deleted — ports come from the platform, not from a file.
+// The document is applied in one transaction. The device must be locked.
+func (d *Device) ImportConfigDB(ctx context.Context, r io.Reader) error {
+	if err := d.RequireLocked(); err != nil {
+		return err
+	}
+	doc, err := DecodeConfigDBJSON(r)
+	if err != nil {
+		return err
+	}
+	current, err := d.client.GetRawAllTables(ctx)
+	if err != nil {
+		return err
+	}
+	return d.client.applyImport(importPlan(doc, current))
+}
+
+// importWrite is one row's change in an import: delete it, or set and drop
+// fields. An empty set with no drops on a new row writes the sentinel.
+type importWrite struct {
+	table, key string
+	del        bool
+	set        map[string]string
+	drop       []string
+}
+
+// importPlan diffs doc against the device's current CONFIG_DB and returns
+// the writes that apply it: deletes children-first, then upserts
+// parents-first (TablePriority), each group in table|key order.
+func importPlan(doc, current RawConfigDB) []importWrite {
+	var deletes, upserts []importWrite
+	for table, rows := range doc {
+		have := current[table]
+		for key, want := range rows {
+			got, exists := have[key]
+			w := importWrite{table: table, key: key, set: map[string]string{}}
+			for f, v := range want {
+				if got[f] != v || !exists {
+					w.set[f] = v
+				}
+			}
+			for f := range got {
+				if _, keep := want[f]; !keep {
+					w.drop = append(w.drop, f)
+				}
+			}
+			if len(want) == 0 {
+				// A field-less row keeps (or gains) the sentinel.
+				delete(w.set, nullSentinel)
+				w.drop = slices.DeleteFunc(w.drop, func(f string) bool { return f == nullSentinel })
+				if got[nullSentinel] != nullSentinel {
+					w.set[nullSentinel] = nullSentinel
+				}
+			}
+			if len(w.set) > 0 || len(w.drop) > 0 {
+				sort.Strings(w.drop)
+				upserts = append(upserts, w)
+			}
+		}
+		if platformMergeTables[table] {
+			continue
+		}
+		for key := range have {
+			if _, keep := rows[key]; !keep {
+				deletes = append(deletes, importWrite{table: table, key: key, del: true})
+			}
+		}
+	}
+	order := func(ws []importWrite, childrenFirst bool) {
+		sort.Slice(ws, func(i, j int) bool {
+			pi, pj := TablePriority(ws[i].table), TablePriority(ws[j].table)
+			if pi != pj {
+				return (pi > pj) == childrenFirst
+			}
+			if ws[i].table != ws[j].table {
+				return ws[i].table < ws[j].table
+			}
+			return ws[i].key < ws[j].key
+		})
+	}
+	order(deletes, true)
+	order(upserts, false)
+	return append(deletes, upserts...)
+}
+
+// applyImport writes an import plan in one MULTI/EXEC transaction.
+func (c *ConfigDBClient) applyImport(writes []importWrite) error {
+	if len(writes) == 0 {
+		return nil
+	}
+	pipe := c.client.TxPipeline()
+	for _, w := range writes {
+		redisKey := fmt.Sprintf("%s|%s", w.table, w.key)
+		if w.del {
+			pipe.Del(c.ctx, redisKey)
+			continue
+		}
+		if len(w.set) > 0 {
+			args := make([]interface{}, 0, len(w.set)*2)
+			for f, v := range w.set {
+				args = append(args, f, v)
+			}
+			pipe.HSet(c.ctx, redisKey, args...)
+		}
+		if len(w.drop) > 0 {
+			pipe.HDel(c.ctx, redisKey, w.drop...)
+		}
+	}
+	if _, err := pipe.Exec(c.ctx); err != nil && err != redis.Nil {
+		return fmt.Errorf("import pipeline exec: %w", err)
+	}
+	return nil
+}
+
+// SavedConfigDBPath is where `config save` writes CONFIG_DB and where
+// `config reload` reads it back from.
+const SavedConfigDBPath = "/etc/sonic/config_db.json"
+
+// UnsavedChanges returns the newtron-owned CONFIG_DB rows — intent records
+// included — that differ from the saved config_db.json: the state a config
+// reload would discard. Reads the file over SSH; a device without an SSH
+// tunnel cannot be checked and returns an error.
+func (d *Device) UnsavedChanges(ctx context.Context) ([]DriftEntry, error) {
+	if err := d.RequireConnected(); err != nil {
+		return nil, err
+	}
+	tunnel := d.Tunnel()
+	if tunnel == nil {
+		return nil, fmt.Errorf("reading %s requires SSH connection (no SSH credentials configured)", SavedConfigDBPath)
+	}
+	output, err := tunnel.ExecCommandContext(ctx, "sudo cat "+SavedConfigDBPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w (output: %s)", SavedConfigDBPath, err, output)
+	}
+	saved, err := DecodeConfigDBJSON(strings.NewReader(output))
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", SavedConfigDBPath, err)
+	}
+	running, err := d.client.GetRawOwnedTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	intents, err := d.client.GetRawTable("NEWTRON_INTENT")
+	if err != nil {
+		return nil, err
+	}
+	if len(intents) > 0 {
+		running["NEWTRON_INTENT"] = intents
+	}
+	return unsavedEntries(saved, running, append(OwnedTables(), "NEWTRON_INTENT")), nil
+}
+
+// unsavedEntries compares running CONFIG_DB against the saved file over the
+// given tables. Unlike DiffConfigDB it is exact — a field set since the save
+// counts — and a field-less row matches its NULL sentinel. Expected is the
+// saved row, Actual the running one: "missing" rows were deleted since the
+// save, "extra" rows added, "modified" rows changed.
+func unsavedEntries(saved, running RawConfigDB, tables []string) []DriftEntry {
+	var diffs []DriftEntry
+	for _, table := range tables {
+		for key, want := range saved[table] {
+			got, exists := running[table][key]
+			switch {
+			case !exists:
+				diffs = append(diffs, DriftEntry{Table: table, Key: key, Type: "missing", Expected: copyMap(want)})
+			case !rowsEqual(want, got):
+				diffs = append(diffs, DriftEntry{Table: table, Key: key, Type: "modified", Expected: copyMap(want), Actual: copyMap(got)})
+			}
+		}
+		for key, got := range running[table] {
+			if _, exists := saved[table][key]; !exists {
+				diffs = append(diffs, DriftEntry{Table: table, Key: key, Type: "extra", Actual: copyMap(got)})
+			}
+		}
+	}
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Table != diffs[j].Table {
+			return diffs[i].Table < diffs[j].Table
+		}
+		return diffs[i].Key < diffs[j].Key
+	})
+	return diffs
+}
+
+// rowsEqual reports whether two rows hold the same fields, ignoring the NULL
+// sentinel a field-less row carries in Redis but not in the file.
+func rowsEqual(a, b map[string]string) bool {
+	count := func(m map[string]string) int {
+		if m[nullSentinel] == nullSentinel {
+			return len(m) - 1
+		}
+		return len(m)
+	}
+	if count(a) != count(b) {
+		return false
+	}
+	for f, v := range a {
+		if f == nullSentinel {
+			continue
+		}
+		if got, ok := b[f]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
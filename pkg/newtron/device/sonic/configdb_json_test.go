@@ -0,0 +1,175 @@
+package sonic
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testdata/config_db/sonic-vs.json is a trimmed `config save` of a
+// provisioned SONiC-VS switch — list fields, field-less rows, factory and
+// newtron-owned tables — in the canonical encoding.
+func readVSConfigDB(t *testing.T) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/config_db/sonic-vs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// withSentinels returns raw as Redis holds it: field-less rows carry NULL.
+func withSentinels(raw RawConfigDB) RawConfigDB {
+	out := make(RawConfigDB, len(raw))
+	for table, rows := range raw {
+		out[table] = make(map[string]map[string]string, len(rows))
+		for key, fields := range rows {
+			cp := copyFields(fields)
+			if len(cp) == 0 {
+				cp[nullSentinel] = nullSentinel
+			}
+			out[table][key] = cp
+		}
+	}
+	return out
+}
+
+func TestConfigDBJSON_RoundTripStable(t *testing.T) {
+	data := readVSConfigDB(t)
+	raw, err := DecodeConfigDBJSON(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := raw["VLAN"]["Vlan100"]["members@"]; got != "Ethernet8,PortChannel1" {
+		t.Errorf("VLAN|Vlan100 members@ = %q, want Ethernet8,PortChannel1", got)
+	}
+	if fields, ok := raw["INTERFACE"]["Ethernet0"]; !ok || len(fields) != 0 {
+		t.Errorf("INTERFACE|Ethernet0 = %v (present %v), want an empty row", fields, ok)
+	}
+
+	// What the device would hold after an import, read back and exported:
+	// byte-identical to the file it came from.
+	var buf bytes.Buffer
+	if err := EncodeConfigDBJSON(&buf, withSentinels(raw)); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	if buf.String() != string(data) {
+		t.Errorf("export → import → export is not stable:\n%s", buf.String())
+	}
+}
+
+func TestEncodeConfigDBJSON_RedisConventions(t *testing.T) {
+	raw := RawConfigDB{
+		"PORTCHANNEL_MEMBER": {"PortChannel1|Ethernet0": {"NULL": "NULL"}},
+		"ACL_TABLE":          {"A": {"ports@": "Ethernet0,Ethernet4", "stage": "ingress"}},
+		"VLAN":               {"Vlan10": {"members@": "", "vlanid": "10"}},
+	}
+	var buf bytes.Buffer
+	if err := EncodeConfigDBJSON(&buf, raw); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"PortChannel1|Ethernet0": {}`,
+		`"ports": [` + "\n" + `                "Ethernet0",`,
+		`"members": [],`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("encoding missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestDecodeConfigDBJSON(t *testing.T) {
+	raw, err := DecodeConfigDBJSON(strings.NewReader(`{"PORT": {"Ethernet0": {"mtu": 9100, "autoneg": true}}}`))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if want := (map[string]string{"mtu": "9100", "autoneg": "true"}); !reflect.DeepEqual(raw["PORT"]["Ethernet0"], want) {
+		t.Errorf("PORT|Ethernet0 = %v, want %v", raw["PORT"]["Ethernet0"], want)
+	}
+
+	for _, tt := range []struct {
+		doc, wantErr string
+	}{
+		{`{"VLAN": {"Vlan10": {"members": [10]}}}`, "VLAN|Vlan10: field members: list items must be strings"},
+		{`{"VLAN": {"Vlan10": {"vlanid": {"x": "1"}}}}`, "VLAN|Vlan10: field vlanid: unsupported value"},
+		{`{"VLAN": {"Vlan10": "x"}}`, "parsing config_db.json"},
+		{`{"VLAN": {}} {}`, "trailing data"},
+	} {
+		if _, err := DecodeConfigDBJSON(strings.NewReader(tt.doc)); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: error = %v, want containing %q", tt.doc, err, tt.wantErr)
+		}
+	}
+}
+
+func TestImportPlan_ReimportWritesNothing(t *testing.T) {
+	raw, err := DecodeConfigDBJSON(bytes.NewReader(readVSConfigDB(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan := importPlan(raw, withSentinels(raw)); len(plan) != 0 {
+		t.Errorf("re-importing an export planned %d writes, want none: %+v", len(plan), plan)
+	}
+}
+
+func TestImportPlan_AppliesDocTableByTable(t *testing.T) {
+	current := RawConfigDB{
+		"VLAN": {
+			"Vlan10": {"vlanid": "10", "description": "old"},
+			"Vlan20": {"vlanid": "20"},
+		},
+		"VLAN_MEMBER": {"Vlan20|Ethernet0": {"tagging_mode": "untagged"}},
+		"PORT": {
+			"Ethernet0": {"mtu": "9100", "admin_status": "down"},
+			"Ethernet4": {"mtu": "9100"},
+		},
+		"INTERFACE":   {"Ethernet8": {"vrf_name": "Vrf_A"}},
+		"BGP_GLOBALS": {"default": {"local_asn": "65001"}},
+	}
+	doc := RawConfigDB{
+		"VLAN":        {"Vlan10": {"vlanid": "10", "mtu": "9000"}},
+		"VLAN_MEMBER": {},
+		"PORT":        {"Ethernet0": {"mtu": "9100", "admin_status": "up"}},
+		"INTERFACE":   {"Ethernet8": {}, "Ethernet8|10.0.0.0/31": {}},
+	}
+	want := []importWrite{
+		// Deletes, children first: VLAN_MEMBER before VLAN. PORT|Ethernet4
+		// survives — ports are never deleted.
+		{table: "VLAN_MEMBER", key: "Vlan20|Ethernet0", del: true},
+		{table: "VLAN", key: "Vlan20", del: true},
+		// Upserts, parents first; BGP_GLOBALS is not in the doc.
+		{table: "PORT", key: "Ethernet0", set: map[string]string{"admin_status": "up"}},
+		{table: "VLAN", key: "Vlan10", set: map[string]string{"mtu": "9000"}, drop: []string{"description"}},
+		{table: "INTERFACE", key: "Ethernet8", set: map[string]string{"NULL": "NULL"}, drop: []string{"vrf_name"}},
+		{table: "INTERFACE", key: "Ethernet8|10.0.0.0/31", set: map[string]string{"NULL": "NULL"}},
+	}
+	if got := importPlan(doc, current); !reflect.DeepEqual(got, want) {
+		t.Errorf("importPlan =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestUnsavedEntries(t *testing.T) {
+	saved := RawConfigDB{
+		"VLAN":           {"Vlan10": {"vlanid": "10"}, "Vlan20": {"vlanid": "20"}},
+		"LOOPBACK":       {"Loopback0": {}},
+		"NEWTRON_INTENT": {"vlan|10": {"operation": "create-vlan"}},
+	}
+	running := RawConfigDB{
+		"VLAN":           {"Vlan10": {"vlanid": "10", "description": "set since save"}, "Vlan30": {"vlanid": "30"}},
+		"LOOPBACK":       {"Loopback0": {nullSentinel: nullSentinel}},
+		"NEWTRON_INTENT": {"vlan|10": {"operation": "create-vlan"}, "vlan|30": {"operation": "create-vlan"}},
+	}
+	got := unsavedEntries(saved, running, []string{"LOOPBACK", "NEWTRON_INTENT", "VLAN"})
+
+	want := []string{"NEWTRON_INTENT|vlan|30 extra", "VLAN|Vlan10 modified", "VLAN|Vlan20 missing", "VLAN|Vlan30 extra"}
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(got), len(want), got)
+	}
+	for i, e := range got {
+		if s := e.Table + "|" + e.Key + " " + e.Type; s != want[i] {
+			t.Errorf("entry %d = %s, want %s", i, s, want[i])
+		}
+	}
+}
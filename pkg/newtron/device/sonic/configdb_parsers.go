@@ -124,6 +124,10 @@ func init() {
 				PrefixListOut:       vals["prefix_list_out"],
 				DefaultOriginate:    vals["default_originate"],
 				AddpathTxAll:        vals["addpath_tx_all_paths"],
+
+				MaxPrefixLimit:           vals["max_prefix_limit"],
+				MaxPrefixWarningOnly:     vals["max_prefix_warning_only"],
+				MaxPrefixRestartInterval: vals["max_prefix_restart_interval"],
 			}
 		},
 		"BGP_GLOBALS": func(db *ConfigDB, entry string, vals map[string]string) {
@@ -157,6 +161,8 @@ func init() {
 				MaxIBGPPaths:          vals["max_ibgp_paths"],
 				RedistributeConnected: vals["redistribute_connected"],
 				RedistributeStatic:    vals["redistribute_static"],
+				ImportVRF:             vals["import_vrf"],
+				ImportVRFRouteMap:     vals["import_vrf_route_map"],
 			}
 		},
 		"BGP_EVPN_VNI": func(db *ConfigDB, entry string, vals map[string]string) {
@@ -196,6 +202,8 @@ func init() {
 				PacketAction:   vals["PACKET_ACTION"],
 				SrcIP:          vals["SRC_IP"],
 				DstIP:          vals["DST_IP"],
+				SrcIPv6:        vals["SRC_IPV6"],
+				DstIPv6:        vals["DST_IPV6"],
 				IPProtocol:     vals["IP_PROTOCOL"],
 				L4SrcPort:      vals["L4_SRC_PORT"],
 				L4DstPort:      vals["L4_DST_PORT"],
@@ -205,6 +213,8 @@ func init() {
 				DSCP:           vals["DSCP"],
 				ICMPType:       vals["ICMP_TYPE"],
 				ICMPCode:       vals["ICMP_CODE"],
+				ICMPv6Type:     vals["ICMPV6_TYPE"],
+				ICMPv6Code:     vals["ICMPV6_CODE"],
 				EtherType:      vals["ETHER_TYPE"],
 				InPorts:        vals["IN_PORTS"],
 				RedirectPort:   vals["REDIRECT_PORT"],
@@ -212,8 +222,10 @@ func init() {
 		},
 		"SCHEDULER": func(db *ConfigDB, entry string, vals map[string]string) {
 			db.Scheduler[entry] = SchedulerEntry{
-				Type:   vals["type"],
-				Weight: vals["weight"],
+				Type:      vals["type"],
+				Weight:    vals["weight"],
+				MeterType: vals["meter_type"],
+				PIR:       vals["pir"],
 			}
 		},
 		"QUEUE": func(db *ConfigDB, entry string, vals map[string]string) {
@@ -237,10 +249,20 @@ func init() {
 			}
 		},
 		"PORT_QOS_MAP": func(db *ConfigDB, entry string, vals map[string]string) {
-			db.PortQoSMap[entry] = PortQoSMapEntry{
-				DSCPToTCMap:  vals["dscp_to_tc_map"],
-				TCToQueueMap: vals["tc_to_queue_map"],
+			// Field-wise merge: the QoS maps (bind-qos) and the port shaper
+			// (set-egress-shaper) are written by separate ops onto the same
+			// row, and each HSET must leave the other's fields in place.
+			e := db.PortQoSMap[entry]
+			if v, ok := vals["dscp_to_tc_map"]; ok {
+				e.DSCPToTCMap = v
+			}
+			if v, ok := vals["tc_to_queue_map"]; ok {
+				e.TCToQueueMap = v
+			}
+			if v, ok := vals["scheduler"]; ok {
+				e.Scheduler = v
 			}
+			db.PortQoSMap[entry] = e
 		},
 		"NEWTRON_INTENT": mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.NewtronIntent }),
 		"STATIC_ROUTE": func(db *ConfigDB, entry string, vals map[string]string) {
@@ -305,7 +327,7 @@ func init() {
 				CommunityMember: vals["community_member"],
 			}
 		},
-		// ---- Hash-merge hydrators (9 tables) ----
+		// ---- Hash-merge hydrators (20 tables) ----
 
 		"DEVICE_METADATA":       mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.DeviceMetadata }),
 		"VLAN_INTERFACE":        mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.VLANInterface }),
@@ -313,10 +335,23 @@ func init() {
 		"LOOPBACK_INTERFACE":    mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.LoopbackInterface }),
 		"PORTCHANNEL_MEMBER":    mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.PortChannelMember }),
 		"SUPPRESS_VLAN_NEIGH":   mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.SuppressVLANNeigh }),
+		"VLAN_TRANSLATION":      mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.VLANTranslation }),
 		"SAG":                   mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.SAG }),
 		"SAG_GLOBAL":            mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.SAGGlobal }),
+		"SWITCH":                mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.Switch }),
+		"SWITCH_HASH":           mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.SwitchHash }),
+		"FLEX_COUNTER_TABLE":    mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.FlexCounterTable }),
+		"BANNER_MESSAGE":        mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.BannerMessage }),
 		"DSCP_TO_TC_MAP":        mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.DSCPToTCMap }),
 		"TC_TO_QUEUE_MAP":       mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.TCToQueueMap }),
+
+		"DHCP_SERVER_IPV4":                    mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.DHCPServerIPv4 }),
+		"DHCP_SERVER_IPV4_RANGE":              mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.DHCPServerIPv4Range }),
+		"DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS": mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.DHCPServerIPv4Option }),
+		"DHCP_SERVER_IPV4_PORT":               mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.DHCPServerIPv4Port }),
+
+		"MIRROR_SESSION": mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.MirrorSession }),
+		"CFG_L2MC_TABLE": mergeHydrator(func(db *ConfigDB) map[string]map[string]string { return db.L2MC }),
 	}
 }
 
@@ -80,7 +80,8 @@ func NewDevice(name string, nodeSpec *spec.ResolvedNodeSpec) *Device {
 	}
 }
 
-// Connect establishes connection to the device's config_db via Redis.
+// Connect establishes connection to the device's config_db via Redis,
+// reached through the device's Transport (transport.go).
 //
 // Failures partway through allocate resources (SSH tunnel goroutine,
 // Redis clients) without setting d.connected — and Disconnect gates
@@ -103,34 +104,15 @@ func (d *Device) Connect(ctx context.Context) error {
 		}
 	}()
 
-	var addr string
-	switch {
-	case d.NodeSpec.SSHUser != "" && d.NodeSpec.SSHPass != "":
-		sshPort, err := d.resolveSSHPort(ctx)
-		if err != nil {
-			return fmt.Errorf("resolving SSH port for %s: %w", d.Name, err)
-		}
-		tun, err := NewSSHTunnel(ctx, d.NodeSpec.MgmtIP, d.NodeSpec.SSHUser, d.NodeSpec.SSHPass, sshPort)
-		if err != nil {
-			return fmt.Errorf("SSH tunnel to %s: %w", d.Name, err)
-		}
-		d.tunnel = tun
-		addr = tun.LocalAddr()
-	case d.NodeSpec.SSHUser != "":
-		// ssh_user is set but ssh_pass is empty — the intent is clearly "reach
-		// this device over SSH," so an empty password is a misconfiguration
-		// (an unresolved ${secret:...}, or a missing secret store), not a cue to
-		// fall through to a direct Redis dial. Without this guard the else branch
-		// below silently connects to mgmt_ip:6379 — for a lab device that is
-		// 127.0.0.1:6379 on the newt-server host, NOT the device — and the read
-		// surfaces later as the baffling "DEVICE_METADATA|localhost not found in
-		// CONFIG_DB". Fail here, at the real cause.
-		return fmt.Errorf("%s: ssh_user %q is set but ssh_pass is empty — cannot open the SSH tunnel to read CONFIG_DB (unresolved ${secret:...} or missing secrets store?)", d.Name, d.NodeSpec.SSHUser)
-	default:
-		// No SSH credentials at all: the device's Redis is expected to be
-		// directly reachable at mgmt_ip:6379 (no tunnel).
-		addr = fmt.Sprintf("%s:6379", d.NodeSpec.MgmtIP)
+	tr, err := transportFor(d)
+	if err != nil {
+		return err
+	}
+	addr, tun, err := tr.Open(ctx, d)
+	if err != nil {
+		return err
 	}
+	d.tunnel = tun
 	d.redisAddr = addr
 
 	// Connect to CONFIG_DB (DB 4)
@@ -140,7 +122,6 @@ func (d *Device) Connect(ctx context.Context) error {
 	}
 
 	// Load config_db
-	var err error
 	d.ConfigDB, err = d.client.GetAll()
 	if err != nil {
 		return fmt.Errorf("loading config_db from %s: %w", d.Name, err)
@@ -349,6 +330,21 @@ func (d *Device) unlock() error {
 	return nil
 }
 
+// LockHolder returns who holds the device's distributed lock in STATE_DB —
+// this process or another — or "" when it is free.
+func (d *Device) LockHolder() (string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if !d.connected {
+		return "", util.ErrNotConnected
+	}
+	if d.stateClient == nil {
+		return d.lockHolder, nil
+	}
+	return d.stateClient.GetLockHolder(d.Name)
+}
+
 // IsLocked returns true if the device is locked
 func (d *Device) IsLocked() bool {
 	d.mu.RLock()
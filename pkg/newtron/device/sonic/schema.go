@@ -21,6 +21,8 @@ const (
 	FieldCIDR                    // IPv4 CIDR notation (e.g., "10.0.0.0/24")
 	FieldMAC                     // MAC address
 	FieldBool                    // "true" or "false"
+	FieldCIDRv6                  // IPv6 CIDR notation (e.g., "2001:db8::/32")
+	FieldIPAny                   // IPv4 or IPv6 address
 )
 
 // FieldConstraint defines validation rules for a single CONFIG_DB field.
@@ -65,10 +67,18 @@ func (fc FieldConstraint) Check(value string) error {
 		if !util.IsValidIPv4(value) {
 			return fmt.Errorf("must be valid IPv4 address, got %q", value)
 		}
+	case FieldIPAny:
+		if net.ParseIP(value) == nil {
+			return fmt.Errorf("must be valid IP address, got %q", value)
+		}
 	case FieldCIDR:
 		if !util.IsValidIPv4CIDR(value) {
 			return fmt.Errorf("must be valid CIDR, got %q", value)
 		}
+	case FieldCIDRv6:
+		if !util.IsValidIPv6CIDR(value) {
+			return fmt.Errorf("must be valid IPv6 CIDR, got %q", value)
+		}
 	case FieldMAC:
 		if _, err := net.ParseMAC(value); err != nil {
 			return fmt.Errorf("must be valid MAC address, got %q", value)
@@ -123,6 +133,68 @@ func intRange(min, max int) *[2]int {
 	return &[2]int{min, max}
 }
 
+// HashFields lists the packet fields SONiC can select for ECMP/LAG hashing
+// (sonic-hash.yang hash-field). A platform may support only a subset — see
+// spec.PlatformSpec.HashFields.
+var HashFields = []string{
+	"IN_PORT", "DST_MAC", "SRC_MAC", "ETHERTYPE", "VLAN_ID", "IP_PROTOCOL",
+	"DST_IP", "SRC_IP", "L4_DST_PORT", "L4_SRC_PORT",
+	"INNER_DST_MAC", "INNER_SRC_MAC", "INNER_ETHERTYPE", "INNER_IP_PROTOCOL",
+	"INNER_DST_IP", "INNER_SRC_IP", "INNER_L4_DST_PORT", "INNER_L4_SRC_PORT",
+	"IPV6_FLOW_LABEL",
+}
+
+// ipv4Pattern and distancePattern match one element of STATIC_ROUTE's
+// comma-joined nexthop and distance lists.
+const (
+	ipv4Pattern     = `(25[0-5]|2[0-4]\d|1?\d?\d)(\.(25[0-5]|2[0-4]\d|1?\d?\d)){3}`
+	distancePattern = `(25[0-5]|2[0-4]\d|1?\d?\d)`
+)
+
+// hashFieldListPattern matches a comma-separated list of hash field names.
+const hashFieldListPattern = `^[A-Z0-9_]+(,[A-Z0-9_]+)*$`
+
+// CounterGroup is one SONiC flex counter group — a FLEX_COUNTER_TABLE key —
+// with its factory polling state.
+type CounterGroup struct {
+	Name            string
+	DefaultEnabled  bool // enabled at boot by SONiC's counter defaults
+	DefaultInterval int  // POLL_INTERVAL in milliseconds
+}
+
+// CounterGroups lists the flex counter groups newtron can configure
+// (sonic-flex_counter.yang; defaults per sonic-utilities counterpoll). ACL,
+// TUNNEL, and the flow counters are absent until configured. PFCWD is left
+// out: its polling belongs to the PFC watchdog configuration.
+var CounterGroups = []CounterGroup{
+	{"PORT", true, 1000},
+	{"RIF", true, 1000},
+	{"QUEUE", true, 10000},
+	{"PG_DROP", true, 10000},
+	{"PORT_BUFFER_DROP", true, 60000},
+	{"PG_WATERMARK", true, 60000},
+	{"QUEUE_WATERMARK", true, 60000},
+	{"BUFFER_POOL_WATERMARK", true, 60000},
+	{"ACL", false, 10000},
+	{"TUNNEL", false, 10000},
+	{"FLOW_CNT_TRAP", false, 10000},
+	{"FLOW_CNT_ROUTE", false, 10000},
+}
+
+// Counter polling interval bounds in milliseconds, the widest range
+// counterpoll accepts across groups.
+const (
+	MinCounterPollInterval = 100
+	MaxCounterPollInterval = 60000
+)
+
+// BGP maximum-paths bounds — FRR accepts 1..MULTIPATH_NUM, which SONiC
+// builds as 256. A platform's max_ecmp_paths narrows the upper bound.
+const (
+	MinBGPMaxPaths = 1
+	MaxBGPMaxPaths = 256
+)
+
 // Schema maps CONFIG_DB table names to their schemas.
 // Derived from SONiC YANG models (see device/sonic/yang/constraints.md)
 // and cross-checked against newtron ops file usage.
@@ -155,6 +227,16 @@ var Schema = map[string]TableSchema{
 		},
 	},
 
+	"VLAN_TRANSLATION": {
+		// No YANG model in community SONiC — provider-edge VLAN rewrite.
+		// Key: "IntfName|InVLAN" — the customer VLAN arriving on the port;
+		// translated_vlan is the provider VLAN it is rewritten to.
+		KeyPattern: `^(Ethernet|PortChannel)\d+\|\d+$`,
+		Fields: map[string]FieldConstraint{
+			"translated_vlan": {Type: FieldInt, Range: intRange(1, 4094)},
+		},
+	},
+
 	"VLAN_INTERFACE": {
 		// YANG: sonic-vlan.yang — VLAN_INTERFACE_LIST + VLAN_INTERFACE_IPPREFIX_LIST
 		// Key: "VlanN" (base) or "VlanN|IP/mask" (IP sub-entry)
@@ -172,6 +254,143 @@ var Schema = map[string]TableSchema{
 		},
 	},
 
+	"SAG": {
+		// No YANG model — SONiC community extension. Per-VLAN anycast gateway
+		// MAC, overriding SAG_GLOBAL for that VLAN's SVI.
+		KeyPattern: `^Vlan\d+\|IPv4$`,
+		Fields: map[string]FieldConstraint{
+			"gwmac": {Type: FieldMAC},
+		},
+	},
+
+	"SWITCH": {
+		// YANG: sonic-switch.yang — SWITCH_LIST
+		// Key: "switch" (single entry). Factory-populated; newtron writes only
+		// the hash seeds and leaves the rest (fdb_aging_time, ...) alone.
+		KeyPattern: `^switch$`,
+		Fields: map[string]FieldConstraint{
+			"ecmp_hash_seed": {Type: FieldInt, Range: intRange(0, 1024)},
+			"lag_hash_seed":  {Type: FieldInt, Range: intRange(0, 1024)},
+		},
+	},
+
+	"SWITCH_HASH": {
+		// YANG: sonic-hash.yang — SWITCH_HASH_LIST
+		// Key: "GLOBAL" (single entry). Values are comma-separated hash fields.
+		KeyPattern: `^GLOBAL$`,
+		Fields: map[string]FieldConstraint{
+			"ecmp_hash": {Type: FieldString, Pattern: hashFieldListPattern},
+			"lag_hash":  {Type: FieldString, Pattern: hashFieldListPattern},
+		},
+	},
+
+	"FLEX_COUNTER_TABLE": {
+		// YANG: sonic-flex_counter.yang — FLEX_COUNTER_TABLE
+		// Key: counter group (see CounterGroups). Factory-populated for the
+		// port/queue/watermark groups; newtron merges only these two fields.
+		KeyPattern: `^(PORT|RIF|QUEUE|PG_DROP|PORT_BUFFER_DROP|PG_WATERMARK|QUEUE_WATERMARK|BUFFER_POOL_WATERMARK|ACL|TUNNEL|FLOW_CNT_TRAP|FLOW_CNT_ROUTE)$`,
+		Fields: map[string]FieldConstraint{
+			"FLEX_COUNTER_STATUS": {Type: FieldEnum, Enum: []string{"enable", "disable"}},
+			"POLL_INTERVAL":       {Type: FieldInt, Range: intRange(MinCounterPollInterval, MaxCounterPollInterval)},
+		},
+	},
+
+	"BANNER_MESSAGE": {
+		// YANG: sonic-banner.yang — BANNER_MESSAGE
+		// Single global entry; hostcfgd writes the messages to /etc/issue.net,
+		// /etc/motd, and the logout message while state is enabled.
+		KeyPattern: `^global$`,
+		Fields: map[string]FieldConstraint{
+			"state":  {Type: FieldEnum, Enum: []string{"enabled", "disabled"}},
+			"login":  {Type: FieldString, AllowEmpty: true},
+			"motd":   {Type: FieldString, AllowEmpty: true},
+			"logout": {Type: FieldString, AllowEmpty: true},
+		},
+	},
+
+	"DHCP_SERVER_IPV4": {
+		// YANG: sonic-dhcp-server-ipv4.yang — DHCP_SERVER_IPV4
+		// One entry per VLAN served by the built-in DHCP server (dhcp_server
+		// feature). List fields are stored with SONiC's "@" leaf-list suffix.
+		KeyPattern: `^Vlan\d+$`,
+		Fields: map[string]FieldConstraint{
+			"gateway":             {Type: FieldIP},
+			"lease_time":          {Type: FieldInt, Range: intRange(1, 2147483647)}, // YANG: uint32
+			"mode":                {Type: FieldEnum, Enum: []string{"PORT"}},
+			"netmask":             {Type: FieldIP},
+			"state":               {Type: FieldEnum, Enum: []string{"enabled", "disabled"}},
+			"customized_options@": {Type: FieldString}, // YANG: leaf-list leafref DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS
+		},
+	},
+
+	"DHCP_SERVER_IPV4_RANGE": {
+		// YANG: sonic-dhcp-server-ipv4.yang — DHCP_SERVER_IPV4_RANGE
+		// range@ is the first and last address ("a,b"), or one address.
+		KeyPattern: `^[A-Za-z0-9_.-]+$`,
+		Fields: map[string]FieldConstraint{
+			"range@": {Type: FieldString, Pattern: `^\d+\.\d+\.\d+\.\d+(,\d+\.\d+\.\d+\.\d+)?$`},
+		},
+	},
+
+	"DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS": {
+		// YANG: sonic-dhcp-server-ipv4.yang — DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS
+		KeyPattern: `^[A-Za-z0-9_.-]+$`,
+		Fields: map[string]FieldConstraint{
+			"id":          {Type: FieldInt, Range: intRange(1, 254)}, // YANG: uint8 1..254
+			"type":        {Type: FieldEnum, Enum: []string{"binary", "boolean", "ipv4-address", "string", "uint8", "uint16", "uint32"}},
+			"value":       {Type: FieldString},
+			"always_send": {Type: FieldBool},
+		},
+	},
+
+	"DHCP_SERVER_IPV4_PORT": {
+		// YANG: sonic-dhcp-server-ipv4.yang — DHCP_SERVER_IPV4_PORT
+		// Key: Vlan<N>|<member port>. The port is offered addresses from the
+		// named ranges (ranges@) or fixed addresses (ips@).
+		KeyPattern: `^Vlan\d+\|.+$`,
+		Fields: map[string]FieldConstraint{
+			"ranges@": {Type: FieldString}, // YANG: leaf-list leafref DHCP_SERVER_IPV4_RANGE
+			"ips@":    {Type: FieldString},
+		},
+	},
+
+	"MIRROR_SESSION": {
+		// YANG: sonic-mirror-session.yang — MIRROR_SESSION
+		// ERSPAN: mirrored packets are GRE-encapsulated from src_ip to dst_ip,
+		// marked with dscp and ttl. vrf is not in the YANG — images with
+		// mirror-over-VRF support resolve dst_ip in that VRF, others in the
+		// default VRF.
+		KeyPattern: `^[A-Za-z0-9_.-]+$`,
+		Fields: map[string]FieldConstraint{
+			"type":      {Type: FieldEnum, Enum: []string{"SPAN", "ERSPAN"}},
+			"src_ip":    {Type: FieldIP},
+			"dst_ip":    {Type: FieldIP},
+			"gre_type":  {Type: FieldString, Pattern: `^0[xX][0-9a-fA-F]{1,4}$`},
+			"dscp":      {Type: FieldInt, Range: intRange(0, 63)},
+			"ttl":       {Type: FieldInt, Range: intRange(1, 255)},
+			"queue":     {Type: FieldInt, Range: intRange(0, 255)},
+			"src_port":  {Type: FieldString}, // YANG: comma-separated port/LAG names
+			"dst_port":  {Type: FieldString}, // SPAN only
+			"direction": {Type: FieldEnum, Enum: []string{"RX", "TX", "BOTH"}},
+			"policer":   {Type: FieldString},
+			"vrf":       {Type: FieldString},
+		},
+	},
+
+	"CFG_L2MC_TABLE": {
+		// Not in community YANG — the L2 multicast (IGMP snooping) table of
+		// images that ship l2mcd. One row per snooping VLAN; no row means
+		// snooping off and multicast floods the VLAN.
+		KeyPattern: `^Vlan\d+$`,
+		Fields: map[string]FieldConstraint{
+			"enabled":        {Type: FieldBool},
+			"version":        {Type: FieldInt, Range: intRange(1, 3)},
+			"querier":        {Type: FieldBool},
+			"fast-leave":     {Type: FieldBool},
+			"query-interval": {Type: FieldInt, Range: intRange(1, 18000)},
+		},
+	},
+
 	"SUPPRESS_VLAN_NEIGH": {
 		// Not in sonic-vxlan.yang — SONiC community extension
 		KeyPattern: `^Vlan\d+$`,
@@ -199,9 +418,12 @@ var Schema = map[string]TableSchema{
 		// YANG: sonic-static-route.yang — key: vrf_name|prefix
 		// Key: "prefix" or "VRF|prefix"
 		KeyPattern: `^(([a-zA-Z][a-zA-Z0-9_-]*)\|)?\d{1,3}\.\d{1,3}\.\d{1,3}\.\d{1,3}/\d{1,2}$`,
+		// Path fields are comma-joined lists, one position per path (ECMP).
 		Fields: map[string]FieldConstraint{
-			"nexthop":  {Type: FieldIP},
-			"distance": {Type: FieldInt, Range: intRange(0, 255)}, // YANG: 0..255
+			"nexthop":   {Type: FieldString, Pattern: `^` + ipv4Pattern + `(,` + ipv4Pattern + `)*$`},
+			"ifname":    {Type: FieldString, Pattern: `^[A-Za-z][A-Za-z0-9._/-]*(,[A-Za-z][A-Za-z0-9._/-]*)*$`},
+			"blackhole": {Type: FieldString, Pattern: `^(true|false)(,(true|false))*$`},
+			"distance":  {Type: FieldString, Pattern: `^` + distancePattern + `(,` + distancePattern + `)*$`}, // YANG: 0..255 per path
 		},
 	},
 
@@ -230,7 +452,7 @@ var Schema = map[string]TableSchema{
 		Fields: map[string]FieldConstraint{
 			"asn":             {Type: FieldInt, Range: intRange(1, 4294967295)}, // YANG: uint32, refined >=1 in cmn-neigh
 			"admin_status":    {Type: FieldEnum, Enum: []string{"up", "down"}},
-			"local_addr":      {Type: FieldIP}, // YANG: union (IP, port, LAG, loopback, Vlan)
+			"local_addr":      {Type: FieldIPAny}, // YANG: union (IP, port, LAG, loopback, Vlan)
 			"name":            {Type: FieldString},
 			"ebgp_multihop":   {Type: FieldString}, // YANG: boolean; newtron writes "true"/TTL
 			"peer_group_name": {Type: FieldString}, // YANG: leafref → BGP_PEER_GROUP
@@ -247,6 +469,10 @@ var Schema = map[string]TableSchema{
 			"nexthop_unchanged": {Type: FieldBool},   // YANG: unchanged_nexthop (boolean); newtron uses nexthop_unchanged
 			"route_map_in":      {Type: FieldString}, // YANG: leafref list, max 1
 			"route_map_out":     {Type: FieldString}, // YANG: leafref list, max 1
+
+			"max_prefix_limit":            {Type: FieldInt, Range: &[2]int{1, 4294967295}}, // YANG: uint32
+			"max_prefix_warning_only":     {Type: FieldBool},
+			"max_prefix_restart_interval": {Type: FieldInt, Range: &[2]int{1, 65535}}, // YANG: uint16, minutes
 		},
 	},
 
@@ -279,6 +505,10 @@ var Schema = map[string]TableSchema{
 			"redistribute_static":    {Type: FieldBool},
 			"advertise-ipv4-unicast": {Type: FieldBool}, // YANG: boolean (hyphenated field name)
 			"advertise-all-vni":      {Type: FieldBool}, // YANG: boolean
+			"import_vrf":             {Type: FieldString},
+			"import_vrf_route_map":   {Type: FieldString},
+			"max_ebgp_paths":         {Type: FieldInt, Range: intRange(MinBGPMaxPaths, MaxBGPMaxPaths)}, // YANG: uint16; frrcfgd 'maximum-paths'
+			"max_ibgp_paths":         {Type: FieldInt, Range: intRange(MinBGPMaxPaths, MaxBGPMaxPaths)}, // YANG: uint16; frrcfgd 'maximum-paths ibgp'
 		},
 	},
 
@@ -446,6 +676,8 @@ var Schema = map[string]TableSchema{
 			"PACKET_ACTION":     {Type: FieldEnum, Enum: []string{"FORWARD", "DROP", "REDIRECT"}},
 			"SRC_IP":            {Type: FieldCIDR},
 			"DST_IP":            {Type: FieldCIDR},
+			"SRC_IPV6":          {Type: FieldCIDRv6},
+			"DST_IPV6":          {Type: FieldCIDRv6},
 			"IP_PROTOCOL":       {Type: FieldInt, Range: intRange(0, 255)},
 			"L4_SRC_PORT":       {Type: FieldString, Pattern: `^\d+(-\d+)?$`},
 			"L4_DST_PORT":       {Type: FieldString, Pattern: `^\d+(-\d+)?$`},
@@ -454,6 +686,8 @@ var Schema = map[string]TableSchema{
 			"TCP_FLAGS":         {Type: FieldString, Pattern: `^0x[0-9a-fA-F]+/0x[0-9a-fA-F]+$`},
 			"ICMP_TYPE":         {Type: FieldInt, Range: intRange(0, 255)},
 			"ICMP_CODE":         {Type: FieldInt, Range: intRange(0, 255)},
+			"ICMPV6_TYPE":       {Type: FieldInt, Range: intRange(0, 255)},
+			"ICMPV6_CODE":       {Type: FieldInt, Range: intRange(0, 255)},
 			"ETHER_TYPE":        {Type: FieldString, Pattern: `^(0x[0-9a-fA-F]+|\d+)$`},
 			"DSCP":              {Type: FieldInt, Range: intRange(0, 63)},
 			"TC":                {Type: FieldInt, Range: intRange(0, 7)},
@@ -476,6 +710,7 @@ var Schema = map[string]TableSchema{
 		Fields: map[string]FieldConstraint{
 			"dscp_to_tc_map":  {Type: FieldString},
 			"tc_to_queue_map": {Type: FieldString},
+			"scheduler":       {Type: FieldString}, // YANG: leafref SCHEDULER (port shaper)
 		},
 	},
 
@@ -499,8 +734,10 @@ var Schema = map[string]TableSchema{
 	"SCHEDULER": {
 		// YANG: sonic-scheduler.yang
 		Fields: map[string]FieldConstraint{
-			"type":   {Type: FieldEnum, Enum: []string{"DWRR", "WRR", "STRICT"}}, // YANG: enum {DWRR, WRR, STRICT}
-			"weight": {Type: FieldInt, Range: intRange(1, 100)},                  // YANG: uint8 1..100
+			"type":       {Type: FieldEnum, Enum: []string{"DWRR", "WRR", "STRICT"}}, // YANG: enum {DWRR, WRR, STRICT}
+			"weight":     {Type: FieldInt, Range: intRange(1, 100)},                  // YANG: uint8 1..100
+			"meter_type": {Type: FieldEnum, Enum: []string{"packets", "bytes"}},      // YANG: enum {packets, bytes}
+			"pir":        {Type: FieldInt},                                           // YANG: uint64 (bytes/s or packets/s)
 		},
 	},
 
@@ -539,7 +776,7 @@ var Schema = map[string]TableSchema{
 				OpSetupDevice, OpCreateVRF, OpBindIPVPN, OpCreateVLAN,
 				OpBindMACVPN, OpCreateACL, OpAddBGPEVPNPeer,
 				OpCreatePortChannel, OpConfigureIRB, OpAddStaticRoute,
-				OpSetProperty, OpConfigureInterface, OpAddTrunkVLAN, OpAddBGPPeer,
+				OpSetProperty, OpConfigureInterface, OpAddTrunkVLAN, OpSetVLANTranslation, OpSetEgressShaper, OpIsolateInterface, OpSetLAGHashPolicy, OpSetBGPMaxPaths, OpSetCounterPolling, OpSetBanner, OpAddRouteLeak, OpSetVRFRouteTargets, OpConfigureDHCPServer, OpCreateMirrorSession, OpEnableIGMPSnooping, OpAddBGPPeer,
 				OpUpdateStaticRoute, OpUpdateBGPPeer, OpUpdateBGPEVPNPeer, OpUpdateIRB,
 				OpApplyService, OpBindACL, OpBindQoS,
 				OpAddACLRule, OpUpdateACLRule, OpAddPortChannelMember, OpInterfaceInit, OpDeployService,
@@ -89,6 +89,20 @@ func TestCheck_FieldIP(t *testing.T) {
 	}
 }
 
+func TestCheck_FieldIPAny(t *testing.T) {
+	fc := FieldConstraint{Type: FieldIPAny}
+
+	if err := fc.Check("10.0.0.1"); err != nil {
+		t.Errorf("Check(10.0.0.1) = %v", err)
+	}
+	if err := fc.Check("2001:db8::1"); err != nil {
+		t.Errorf("Check(2001:db8::1) = %v", err)
+	}
+	if err := fc.Check("2001:db8::1/126"); err == nil {
+		t.Error("Check(2001:db8::1/126) with mask = nil, want error")
+	}
+}
+
 func TestCheck_FieldCIDR(t *testing.T) {
 	fc := FieldConstraint{Type: FieldCIDR}
 
@@ -106,6 +120,20 @@ func TestCheck_FieldCIDR(t *testing.T) {
 	}
 }
 
+func TestCheck_FieldCIDRv6(t *testing.T) {
+	fc := FieldConstraint{Type: FieldCIDRv6}
+
+	if err := fc.Check("2001:db8::/32"); err != nil {
+		t.Errorf("Check(2001:db8::/32) = %v", err)
+	}
+	if err := fc.Check("10.0.0.0/24"); err == nil {
+		t.Error("Check(10.0.0.0/24) = nil, want error")
+	}
+	if err := fc.Check("2001:db8::1"); err == nil {
+		t.Error("Check(2001:db8::1) without mask = nil, want error")
+	}
+}
+
 func TestCheck_FieldMAC(t *testing.T) {
 	fc := FieldConstraint{Type: FieldMAC}
 
@@ -288,6 +316,31 @@ func TestValidateEntry_INTERFACE_IPSubEntry(t *testing.T) {
 	}
 }
 
+func TestValidateEntry_STATIC_ROUTE_Lists(t *testing.T) {
+	valid := []map[string]string{
+		{"nexthop": "10.1.0.1"},
+		{"nexthop": "10.1.0.1,10.1.0.2", "ifname": "Ethernet0,PortChannel1", "distance": "10,10"},
+		{"ifname": "Vlan100"},
+		{"blackhole": "true"},
+	}
+	for _, fields := range valid {
+		if err := Schema["STATIC_ROUTE"].ValidateEntry("STATIC_ROUTE", "Vrf_A|10.0.0.0/24", fields); err != nil {
+			t.Errorf("%v should be valid: %v", fields, err)
+		}
+	}
+	invalid := []map[string]string{
+		{"nexthop": "10.1.0.1,"},
+		{"nexthop": "10.1.0.256"},
+		{"blackhole": "yes"},
+		{"nexthop": "10.1.0.1", "distance": "10,256"},
+	}
+	for _, fields := range invalid {
+		if err := Schema["STATIC_ROUTE"].ValidateEntry("STATIC_ROUTE", "Vrf_A|10.0.0.0/24", fields); err == nil {
+			t.Errorf("%v should fail", fields)
+		}
+	}
+}
+
 func TestValidateEntry_SAG_GLOBAL(t *testing.T) {
 	err := Schema["SAG_GLOBAL"].ValidateEntry("SAG_GLOBAL", "IPv4", map[string]string{
 		"gwmac": "00:11:22:33:44:55",
@@ -297,6 +350,19 @@ func TestValidateEntry_SAG_GLOBAL(t *testing.T) {
 	}
 }
 
+func TestValidateEntry_SAG(t *testing.T) {
+	if err := Schema["SAG"].ValidateEntry("SAG", "Vlan100|IPv4", map[string]string{
+		"gwmac": "00:11:22:33:44:55",
+	}); err != nil {
+		t.Errorf("valid SAG: %v", err)
+	}
+	if err := Schema["SAG"].ValidateEntry("SAG", "IPv4", map[string]string{
+		"gwmac": "00:11:22:33:44:55",
+	}); err == nil {
+		t.Error("SAG key without a VLAN should fail")
+	}
+}
+
 // ============================================================================
 // ValidateChanges tests
 // ============================================================================
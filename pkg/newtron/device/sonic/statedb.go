@@ -331,6 +331,21 @@ func (c *StateDBClient) ReleaseLock(device, holder string) error {
 	return nil
 }
 
+// GetLockHolder returns the holder of the device's distributed lock in
+// STATE_DB, or "" when no one holds it. An expired lock is gone: Redis drops
+// the key at its TTL.
+func (c *StateDBClient) GetLockHolder(device string) (string, error) {
+	key := fmt.Sprintf("NEWTRON_LOCK|%s", device)
+	holder, err := c.client.HGet(c.ctx, key, "holder").Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading lock for %s: %w", device, err)
+	}
+	return holder, nil
+}
+
 // ============================================================================
 // Operation Intent Records — crash recovery for multi-entry writes
 // ============================================================================
@@ -0,0 +1,56 @@
+package sonic
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func TestTransportFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		nodeSpec  spec.ResolvedNodeSpec
+		want      Transport
+		wantErrIn string
+	}{
+		{"unset with login", spec.ResolvedNodeSpec{SSHUser: "admin", SSHPass: "pw"}, sshRedisTransport{}, ""},
+		{"unset without login", spec.ResolvedNodeSpec{}, directRedisTransport{}, ""},
+		{"ssh-redis", spec.ResolvedNodeSpec{Transport: spec.TransportSSHRedis}, sshRedisTransport{}, ""},
+		// direct-redis wins over a resolved login (which is always set).
+		{"direct-redis", spec.ResolvedNodeSpec{Transport: spec.TransportDirectRedis, SSHUser: "admin", SSHPass: "pw"}, directRedisTransport{}, ""},
+		{"gnmi", spec.ResolvedNodeSpec{Transport: spec.TransportGNMI}, nil, "not implemented"},
+		{"unknown", spec.ResolvedNodeSpec{Transport: "telnet"}, nil, "unknown transport"},
+	}
+	for _, tt := range tests {
+		got, err := transportFor(NewDevice("switch1", &tt.nodeSpec))
+		if tt.wantErrIn != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrIn) {
+				t.Errorf("%s: err = %v, want it to mention %q", tt.name, err, tt.wantErrIn)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: transport = %T (err %v), want %T", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestDirectRedisTransport_Open(t *testing.T) {
+	d := NewDevice("switch1", &spec.ResolvedNodeSpec{MgmtIP: "10.0.0.5", Transport: spec.TransportDirectRedis})
+	addr, tun, err := directRedisTransport{}.Open(context.Background(), d)
+	if err != nil || addr != "10.0.0.5:6379" || tun != nil {
+		t.Errorf("Open = %q, %v, %v; want 10.0.0.5:6379 with no tunnel", addr, tun, err)
+	}
+}
+
+// TestSSHRedisTransport_RequiresPassword: ssh-redis with a user but no
+// password fails at the real cause instead of falling back to a direct dial.
+func TestSSHRedisTransport_RequiresPassword(t *testing.T) {
+	d := NewDevice("switch1", &spec.ResolvedNodeSpec{MgmtIP: "127.0.0.1", SSHUser: "admin"})
+	_, _, err := sshRedisTransport{}.Open(context.Background(), d)
+	if err == nil || !strings.Contains(err.Error(), "ssh_pass is empty") {
+		t.Errorf("err = %v, want the empty-password error", err)
+	}
+}
@@ -36,12 +36,18 @@ type Entry struct {
 // modify or delete is to set the key back to From. From is empty on an add
 // (nothing was there) and on any change against a previously-absent key, and is
 // omitted from the wire in those cases.
+//
+// Comment is an optional human-readable note of what the change is for
+// ("ingress ACL for customer-l3"), set by the operation that generated it. It
+// explains a generated key to a reviewer reading a dry-run preview or an
+// audit log; it is never written to the device.
 type ConfigChange struct {
-	Table  string            `json:"table"`
-	Key    string            `json:"key"`
-	Type   ChangeType        `json:"type"`
-	Fields map[string]string `json:"fields,omitempty"`
-	From   map[string]string `json:"from,omitempty"`
+	Table   string            `json:"table"`
+	Key     string            `json:"key"`
+	Type    ChangeType        `json:"type"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	From    map[string]string `json:"from,omitempty"`
+	Comment string            `json:"comment,omitempty"`
 }
 
 // ChangeType represents the type of configuration change
@@ -62,22 +68,25 @@ const (
 // Route Verification Types
 // ============================================================================
 
-// RouteSource indicates which Redis database a route was read from.
+// RouteSource indicates where a route was read from: a Redis database, or
+// zebra's RIB.
 type RouteSource string
 
 const (
 	RouteSourceAppDB  RouteSource = "APP_DB"
 	RouteSourceAsicDB RouteSource = "ASIC_DB"
+	RouteSourceFRR    RouteSource = "FRR"
 )
 
 // RouteEntry represents a route read from a device's routing table.
 // Returned by Device.GetRoute (APP_DB) and Device.GetRouteASIC (ASIC_DB).
 type RouteEntry struct {
-	Prefix   string // "10.1.0.0/31"
-	VRF      string // "default", "Vrf-customer"
-	Protocol string // "bgp", "connected", "static"
-	NextHops []NextHop
-	Source   RouteSource // AppDB or AsicDB
+	Prefix    string // "10.1.0.0/31"
+	VRF       string // "default", "Vrf-customer"
+	Protocol  string // "bgp", "connected", "static"
+	NextHops  []NextHop
+	Source    RouteSource // AppDB, AsicDB or FRR
+	OriginVRF string      // VRF a leaked route was imported from; FRR reads only
 }
 
 // NextHop represents a single next-hop in a route entry.
@@ -86,6 +95,20 @@ type NextHop struct {
 	Interface string // "Ethernet0", "Vlan500"
 }
 
+// NextHopGroupEntry is one ECMP next-hop group programmed in ASIC_DB.
+// Returned by AsicDBClient.GetNextHopGroups.
+type NextHopGroupEntry struct {
+	OID      string // SAI object ID, "oid:0x5000000000612"
+	Members  []NextHopGroupMember
+	RefCount int // route entries whose next hop is this group
+}
+
+// NextHopGroupMember is one next hop of a next-hop group.
+type NextHopGroupMember struct {
+	IP     string // "10.1.0.1"
+	Weight int    // 1 unless the route is weighted (UCMP)
+}
+
 // VerificationResult reports ChangeSet verification outcome.
 // Returned by Device.VerifyChangeSet after re-reading CONFIG_DB.
 type VerificationResult struct {
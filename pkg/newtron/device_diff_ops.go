@@ -0,0 +1,238 @@
+package newtron
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// Device diff entry types.
+const (
+	DeviceDiffOnlyA    = "only_a"
+	DeviceDiffOnlyB    = "only_b"
+	DeviceDiffModified = "modified"
+)
+
+// deviceConfig is one side of a device diff: the device's newtron-owned
+// CONFIG_DB and the per-device identity values normalized out of it.
+type deviceConfig struct {
+	config   sonic.RawConfigDB
+	identity []identityValue
+}
+
+// identityValue maps a per-device value to the placeholder both sides share.
+// wholeOnly values (the ASN) are replaced only when they are a field's entire
+// value, since short numbers collide with VLAN IDs, MTUs and the like.
+type identityValue struct {
+	value       string
+	placeholder string
+	wholeOnly   bool
+}
+
+// DiffDevices compares the newtron-owned CONFIG_DB of devices a and b after
+// normalizing the values expected to differ between any two devices —
+// hostname, loopback, router ID, VTEP source, management IP and ASN — so
+// what remains are real discrepancies between nominally-identical devices
+// (MC-LAG peers, ECMP-equivalent leaves). Each device is read through read.
+//
+// ignore drops expected differences: a bare table name ("BGP_NEIGHBOR")
+// ignores the table, "TABLE|KEY" one entry, and a trailing "*" matches by
+// prefix ("INTERFACE|Ethernet0*"). Entries are sorted by table, then key.
+func (net *Network) DiffDevices(ctx context.Context, a, b string, ignore []string, read DeviceReader) (*DeviceDiff, error) {
+	if a == "" || b == "" {
+		return nil, &ValidationError{Field: "device", Message: "two device names are required"}
+	}
+	if a == b {
+		return nil, &ValidationError{Field: "device", Message: fmt.Sprintf("cannot diff %s against itself", a)}
+	}
+	ca, err := readDeviceConfig(ctx, a, read)
+	if err != nil {
+		return nil, err
+	}
+	cb, err := readDeviceConfig(ctx, b, read)
+	if err != nil {
+		return nil, err
+	}
+	return &DeviceDiff{
+		DeviceA: a,
+		DeviceB: b,
+		Entries: diffDeviceConfigs(ca, cb, ignore),
+	}, nil
+}
+
+// readDeviceConfig reads one device's owned CONFIG_DB and identity values.
+func readDeviceConfig(ctx context.Context, device string, read DeviceReader) (*deviceConfig, error) {
+	val, err := read(ctx, device, func(ctx context.Context, n *Node) (any, error) {
+		config, err := n.ConfigDBSnapshot(ctx, true)
+		if err != nil {
+			return nil, err
+		}
+		info, err := n.DeviceInfo()
+		if err != nil {
+			return nil, err
+		}
+		return &deviceConfig{config: config, identity: deviceIdentity(info)}, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", device, err)
+	}
+	return val.(*deviceConfig), nil
+}
+
+// deviceIdentity lists a device's normalizable values. A value that two roles
+// share (router ID defaulting to the loopback) keeps the first placeholder.
+func deviceIdentity(info *DeviceInfo) []identityValue {
+	var ids []identityValue
+	seen := map[string]bool{}
+	add := func(value, placeholder string, wholeOnly bool) {
+		if value == "" || seen[value] {
+			return
+		}
+		seen[value] = true
+		ids = append(ids, identityValue{value: value, placeholder: placeholder, wholeOnly: wholeOnly})
+	}
+	add(info.Name, "<hostname>", false)
+	add(info.LoopbackIP, "<loopback_ip>", false)
+	add(info.RouterID, "<router_id>", false)
+	add(info.VTEPSourceIP, "<vtep_source_ip>", false)
+	add(info.MgmtIP, "<mgmt_ip>", false)
+	if info.BGPAS != 0 {
+		add(strconv.Itoa(info.BGPAS), "<asn>", true)
+	}
+	return ids
+}
+
+// normalize replaces every identity value in s with its placeholder. Values
+// match only on token boundaries, so loopback 10.0.0.1 leaves 10.0.0.11 alone.
+func normalize(s string, ids []identityValue) string {
+	for _, id := range ids {
+		if id.wholeOnly {
+			if s == id.value {
+				s = id.placeholder
+			}
+			continue
+		}
+		s = replaceToken(s, id.value, id.placeholder)
+	}
+	return s
+}
+
+// replaceToken replaces occurrences of old in s that are not embedded in a
+// longer name, number or address.
+func replaceToken(s, old, new string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, old)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(old)
+		if (i > 0 && isTokenByte(s[i-1])) || (end < len(s) && isTokenByte(s[end])) {
+			b.WriteString(s[:i+1])
+			s = s[i+1:]
+			continue
+		}
+		b.WriteString(s[:i])
+		b.WriteString(new)
+		s = s[end:]
+	}
+}
+
+func isTokenByte(c byte) bool {
+	return c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '.' || c == ':' || c == '_'
+}
+
+// normalizeConfig returns config with identity values replaced in keys and
+// field values, dropping ignored entries.
+func normalizeConfig(dc *deviceConfig, ignore []string) sonic.RawConfigDB {
+	out := sonic.RawConfigDB{}
+	for table, entries := range dc.config {
+		for key, fields := range entries {
+			nkey := normalize(key, dc.identity)
+			if ignoredEntry(table, nkey, ignore) {
+				continue
+			}
+			nfields := make(map[string]string, len(fields))
+			for f, v := range fields {
+				nfields[f] = normalize(v, dc.identity)
+			}
+			if out[table] == nil {
+				out[table] = map[string]map[string]string{}
+			}
+			out[table][nkey] = nfields
+		}
+	}
+	return out
+}
+
+// ignoredEntry reports whether TABLE|KEY matches an ignore pattern. Keys are
+// matched after normalization, so a pattern may name a placeholder
+// ("BGP_NEIGHBOR|default|<loopback_ip>").
+func ignoredEntry(table, key string, ignore []string) bool {
+	full := table + "|" + key
+	for _, p := range ignore {
+		switch {
+		case p == table || p == full:
+			return true
+		case strings.HasSuffix(p, "*") && strings.HasPrefix(full, strings.TrimSuffix(p, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// diffDeviceConfigs normalizes both sides and returns their differences
+// sorted by table, then key.
+func diffDeviceConfigs(a, b *deviceConfig, ignore []string) []DeviceDiffEntry {
+	na, nb := normalizeConfig(a, ignore), normalizeConfig(b, ignore)
+	var entries []DeviceDiffEntry
+	for table, rowsA := range na {
+		for key, fa := range rowsA {
+			fb, ok := nb[table][key]
+			if !ok {
+				entries = append(entries, DeviceDiffEntry{Table: table, Key: key, Type: DeviceDiffOnlyA, A: fa})
+				continue
+			}
+			if fields := differingFields(fa, fb); len(fields) > 0 {
+				entries = append(entries, DeviceDiffEntry{Table: table, Key: key, Type: DeviceDiffModified, A: fa, B: fb, Fields: fields})
+			}
+		}
+	}
+	for table, rowsB := range nb {
+		for key, fb := range rowsB {
+			if _, ok := na[table][key]; !ok {
+				entries = append(entries, DeviceDiffEntry{Table: table, Key: key, Type: DeviceDiffOnlyB, B: fb})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Table != entries[j].Table {
+			return entries[i].Table < entries[j].Table
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+// differingFields returns the sorted names of fields whose values differ or
+// that only one side sets.
+func differingFields(a, b map[string]string) []string {
+	var fields []string
+	for f, v := range a {
+		if w, ok := b[f]; !ok || w != v {
+			fields = append(fields, f)
+		}
+	}
+	for f := range b {
+		if _, ok := a[f]; !ok {
+			fields = append(fields, f)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,60 @@
+package newtron
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// TestDiffDeviceConfigs pins the normalized comparison behind DiffDevices:
+// per-device loopback, hostname and ASN values cancel out (including inside
+// keys), a value that merely starts with the loopback is not rewritten, and
+// ignore patterns drop a table, an exact entry, or a key prefix.
+func TestDiffDeviceConfigs(t *testing.T) {
+	leaf1 := &deviceConfig{
+		config: sonic.RawConfigDB{
+			"LOOPBACK_INTERFACE": {"Loopback0|10.0.0.1/32": {}},
+			"BGP_GLOBALS":        {"default": {"local_asn": "65001", "router_id": "10.0.0.1"}},
+			"PORT":               {"Ethernet0": {"mtu": "9100", "description": "leaf1-uplink"}},
+			"STATIC_ROUTE":       {"default|10.9.0.0/24": {"nexthop": "10.0.0.11"}},
+			"VLAN":               {"Vlan100": {"vlanid": "100"}},
+			"INTERFACE":          {"Ethernet4|10.1.0.0/31": {}},
+			"BGP_NEIGHBOR":       {"default|10.1.0.1": {"asn": "65100"}},
+		},
+		identity: deviceIdentity(&DeviceInfo{Name: "leaf1", LoopbackIP: "10.0.0.1", RouterID: "10.0.0.1", BGPAS: 65001}),
+	}
+	leaf2 := &deviceConfig{
+		config: sonic.RawConfigDB{
+			"LOOPBACK_INTERFACE": {"Loopback0|10.0.0.2/32": {}},
+			"BGP_GLOBALS":        {"default": {"local_asn": "65002", "router_id": "10.0.0.2"}},
+			"PORT":               {"Ethernet0": {"mtu": "1500", "description": "leaf2-uplink"}},
+			"STATIC_ROUTE":       {"default|10.9.0.0/24": {"nexthop": "10.0.0.21"}},
+			"VLAN":               {"Vlan200": {"vlanid": "200"}},
+			"INTERFACE":          {"Ethernet4|10.1.0.2/31": {}},
+			"BGP_NEIGHBOR":       {"default|10.1.0.3": {"asn": "65100"}},
+		},
+		identity: deviceIdentity(&DeviceInfo{Name: "leaf2", LoopbackIP: "10.0.0.2", RouterID: "10.0.0.2", BGPAS: 65002}),
+	}
+
+	got := diffDeviceConfigs(leaf1, leaf2, []string{"BGP_NEIGHBOR", "INTERFACE|Ethernet4*"})
+	want := []DeviceDiffEntry{
+		{Table: "PORT", Key: "Ethernet0", Type: DeviceDiffModified,
+			A:      map[string]string{"mtu": "9100", "description": "<hostname>-uplink"},
+			B:      map[string]string{"mtu": "1500", "description": "<hostname>-uplink"},
+			Fields: []string{"mtu"}},
+		{Table: "STATIC_ROUTE", Key: "default|10.9.0.0/24", Type: DeviceDiffModified,
+			A:      map[string]string{"nexthop": "10.0.0.11"},
+			B:      map[string]string{"nexthop": "10.0.0.21"},
+			Fields: []string{"nexthop"}},
+		{Table: "VLAN", Key: "Vlan100", Type: DeviceDiffOnlyA, A: map[string]string{"vlanid": "100"}},
+		{Table: "VLAN", Key: "Vlan200", Type: DeviceDiffOnlyB, B: map[string]string{"vlanid": "200"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("diff =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := diffDeviceConfigs(leaf1, leaf2, []string{"PORT", "STATIC_ROUTE", "VLAN", "BGP_NEIGHBOR", "INTERFACE"}); len(got) != 0 {
+		t.Errorf("everything ignored or normalized, got %+v", got)
+	}
+}
@@ -0,0 +1,70 @@
+package newtron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/network/node"
+)
+
+// TestExecute_ApplyFailureDropsPendingAndIntents: a failed apply is rolled
+// back, so the intents its operation recorded are dropped with it, and the
+// next Execute commits only its own changes — not the rolled-back ones again.
+func TestExecute_ApplyFailureDropsPendingAndIntents(t *testing.T) {
+	net, _ := loadServiceFixture(t)
+	n, err := net.BuildTopologyNode("switch1")
+	if err != nil {
+		t.Fatalf("BuildTopologyNode: %v", err)
+	}
+	ctx := context.Background()
+
+	var applied [][]string
+	fail := true
+	commit := func(ctx context.Context) (*WriteResult, error) {
+		return n.commit(ctx, func(cs *node.ChangeSet, opts node.ApplyOpts) error {
+			if fail {
+				return errors.New("redis: connection reset")
+			}
+			var keys []string
+			for _, c := range cs.Changes {
+				keys = append(keys, c.Table+"|"+c.Key)
+			}
+			applied = append(applied, keys)
+			return nil
+		})
+	}
+	opts := ExecOpts{Execute: true, NoSave: true}
+
+	_, err = n.execute(ctx, opts, func(ctx context.Context) error {
+		return n.CreateVLAN(ctx, 3001, VLANConfig{})
+	}, commit)
+	if err == nil || !strings.Contains(err.Error(), "apply failed, rolled back") {
+		t.Fatalf("err = %v, want a rolled-back apply failure", err)
+	}
+	if len(n.pending) != 0 {
+		t.Errorf("pending = %d changesets after a rolled-back apply, want none", len(n.pending))
+	}
+	if n.internal.GetIntent("vlan|3001") != nil {
+		t.Error("intent vlan|3001 survived a rolled-back apply")
+	}
+
+	fail = false
+	if _, err := n.execute(ctx, opts, func(ctx context.Context) error {
+		return n.CreateVLAN(ctx, 3002, VLANConfig{})
+	}, commit); err != nil {
+		t.Fatalf("second Execute: %v", err)
+	}
+	if len(applied) != 1 {
+		t.Fatalf("second Execute applied %d changesets, want 1: %v", len(applied), applied)
+	}
+	for _, key := range applied[0] {
+		if strings.Contains(key, "3001") {
+			t.Errorf("second Execute re-applied the rolled-back %s", key)
+		}
+	}
+	if n.internal.GetIntent("vlan|3002") == nil {
+		t.Error("intent vlan|3002 missing after a successful Execute")
+	}
+}
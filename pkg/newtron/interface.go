@@ -78,6 +78,32 @@ func (i *Interface) ApplyService(ctx context.Context, service string, opts Apply
 	return nil
 }
 
+// ApplyServiceBatch applies services to several interfaces as one
+// ChangeSet. Resources the bindings share — a shared VRF, a filter's
+// ACL_TABLE port list — are written once with their final value rather
+// than once per interface. Each binding is gated (service.apply) before
+// anything is applied, so a denied binding leaves nothing pending.
+func (n *Node) ApplyServiceBatch(ctx context.Context, bindings []ServiceBinding) error {
+	internal := make([]node.ServiceBinding, len(bindings))
+	for idx, b := range bindings {
+		intf, err := n.Interface(b.Interface)
+		if err != nil {
+			return err
+		}
+		service := util.NormalizeName(b.Service)
+		if err := intf.gateService(ctx, auth.PermServiceApply, service); err != nil {
+			return err
+		}
+		internal[idx] = node.ServiceBinding{Interface: intf.internal.Name(), Service: service, Opts: b.Opts.internal()}
+	}
+	cs, err := n.internal.ApplyServiceBatch(ctx, internal)
+	if err != nil {
+		return err
+	}
+	n.appendPending(cs)
+	return nil
+}
+
 // RemoveService removes the service from this interface. Recovers the
 // bound service name from the on-device binding (Interface.ServiceName,
 // interface.go:188) and stamps it on Context.Service so L5
@@ -207,6 +233,35 @@ func (i *Interface) RemoveTrunkVLAN(ctx context.Context, vlanID int) error {
 	return nil
 }
 
+// SetVLANTranslation rewrites customer VLAN inVLAN arriving on this
+// interface to provider VLAN outVLAN. The provider VLAN must exist and the
+// port must be bridged; routed ports are refused.
+func (i *Interface) SetVLANTranslation(ctx context.Context, inVLAN, outVLAN int) error {
+	if err := i.gate(ctx, auth.PermInterfaceModify, ""); err != nil {
+		return err
+	}
+	cs, err := i.internal.SetVLANTranslation(ctx, inVLAN, outVLAN)
+	if err != nil {
+		return err
+	}
+	i.node.appendPending(cs)
+	return nil
+}
+
+// ClearVLANTranslation removes the translation for customer VLAN inVLAN on
+// this interface. Reverse of SetVLANTranslation per §15.
+func (i *Interface) ClearVLANTranslation(ctx context.Context, inVLAN int) error {
+	if err := i.gate(ctx, auth.PermInterfaceModify, ""); err != nil {
+		return err
+	}
+	cs, err := i.internal.ClearVLANTranslation(ctx, inVLAN)
+	if err != nil {
+		return err
+	}
+	i.node.appendPending(cs)
+	return nil
+}
+
 // ConfigureInterface sets forwarding mode on an interface. Routed mode (VRF+IP)
 // and bridged mode (VLAN membership) are mutually exclusive.
 func (i *Interface) ConfigureInterface(ctx context.Context, cfg InterfaceConfig) error {
@@ -294,6 +349,93 @@ func (i *Interface) UnbindQoS(ctx context.Context) error {
 	return nil
 }
 
+// SetEgressShaper rate-limits all egress traffic on this interface to
+// rateKbps. The rate must not exceed the port speed; re-setting changes the
+// rate in place.
+func (i *Interface) SetEgressShaper(ctx context.Context, rateKbps int) error {
+	if err := i.gate(ctx, auth.PermQoSModify, ""); err != nil {
+		return err
+	}
+	cs, err := i.internal.SetEgressShaper(ctx, rateKbps)
+	if err != nil {
+		return err
+	}
+	i.node.appendPending(cs)
+	return nil
+}
+
+// ClearEgressShaper removes the egress shaper from this interface. A bound
+// QoS policy is untouched. Reverse of SetEgressShaper per §15.
+func (i *Interface) ClearEgressShaper(ctx context.Context) error {
+	if err := i.gate(ctx, auth.PermQoSModify, ""); err != nil {
+		return err
+	}
+	cs, err := i.internal.ClearEgressShaper(ctx)
+	if err != nil {
+		return err
+	}
+	i.node.appendPending(cs)
+	return nil
+}
+
+// Isolate takes a flapping link out of forwarding: shuts the interface's
+// direct BGP peer, detaches it from its PortChannel, and sets it admin down,
+// recording what it displaced so Unisolate can restore it.
+func (i *Interface) Isolate(ctx context.Context) error {
+	if err := i.gate(ctx, auth.PermInterfaceModify, ""); err != nil {
+		return err
+	}
+	cs, err := i.internal.Isolate(ctx)
+	if err != nil {
+		return err
+	}
+	i.node.appendPending(cs)
+	return nil
+}
+
+// Unisolate returns an isolated interface to forwarding, restoring its prior
+// admin status, PortChannel membership, and BGP peer. Reverse of Isolate
+// per §15.
+func (i *Interface) Unisolate(ctx context.Context) error {
+	if err := i.gate(ctx, auth.PermInterfaceModify, ""); err != nil {
+		return err
+	}
+	cs, err := i.internal.Unisolate(ctx)
+	if err != nil {
+		return err
+	}
+	i.node.appendPending(cs)
+	return nil
+}
+
+// EgressShaper returns the interface's egress shaper — the intended rate and
+// the rate configured on the device — so a caller can verify a
+// SetEgressShaper landed. No permission gate, matching the other read paths.
+func (i *Interface) EgressShaper(ctx context.Context) (*EgressShaper, error) {
+	sh, err := i.internal.GetEgressShaper(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := EgressShaper(*sh)
+	return &out, nil
+}
+
+// ServiceHealth checks the operational state the interface's bound service
+// implies — the interface oper-up and, when the service peers over BGP, that
+// session Established. It fails when no service is bound. No permission
+// gate, matching the other read paths.
+func (i *Interface) ServiceHealth(ctx context.Context) ([]HealthCheckResult, error) {
+	results, err := i.node.internal.CheckServiceHealth(ctx, i.internal.Name())
+	if err != nil {
+		return nil, err
+	}
+	out := make([]HealthCheckResult, len(results))
+	for j, r := range results {
+		out[j] = HealthCheckResult{Check: r.Check, VRF: r.VRF, Status: r.Status, Message: r.Message}
+	}
+	return out, nil
+}
+
 // Status returns the interface's composed live operational picture — link
 // state, counters, rates, resolved neighbors, LLDP far end, optics — read
 // across STATE_DB, APPL_DB, and COUNTERS_DB. Pure observation (§4); no
@@ -0,0 +1,120 @@
+package newtron
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// DeviceExecutor runs fn against one device's Node inside Execute and returns
+// the write result. Fleet operations take one so the caller decides how a
+// device is reached — the API server routes each call through that device's
+// actor, so a fleet write serializes with every other write to the device.
+type DeviceExecutor func(ctx context.Context, device string, fn func(ctx context.Context, n *Node) error) (*WriteResult, error)
+
+// ApplyMTUPolicy sets interface MTU across every switch in the topology from
+// the interfaces' topology roles, so both ends of a link always agree. Each
+// device is written through exec in name order and commits on its own; a
+// device that fails is reported in its DeviceChangeSet and the rest proceed.
+// Interfaces already at the policy MTU are reported unchanged. A topology link
+// on a PortChannel member sets the parent PortChannel's MTU instead.
+// Permission is checked per interface (interface.modify) as each MTU is set.
+func (net *Network) ApplyMTUPolicy(ctx context.Context, policy MTUPolicy, exec DeviceExecutor) (*MultiChangeSet, error) {
+	if policy.Fabric == 0 && policy.Server == 0 {
+		return nil, &ValidationError{Field: "policy", Message: "set at least one of fabric or server"}
+	}
+	for field, mtu := range map[string]int{"fabric": policy.Fabric, "server": policy.Server} {
+		if mtu == 0 {
+			continue
+		}
+		if err := util.ValidateMTU(mtu); err != nil {
+			return nil, &ValidationError{Field: field, Message: err.Error()}
+		}
+	}
+	if !net.HasTopology() {
+		return nil, &NotFoundError{Resource: "topology", Name: "topology.json"}
+	}
+	plan, err := net.internal.MTUPlan(policy.Fabric, policy.Server)
+	if err != nil {
+		return nil, err
+	}
+
+	devices := make([]string, 0, len(plan))
+	for device := range plan {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+
+	result := &MultiChangeSet{}
+	for _, device := range devices {
+		dcs := DeviceChangeSet{Device: device}
+		wr, err := exec(ctx, device, func(ctx context.Context, n *Node) error {
+			changes, err := n.applyInterfaceMTUs(ctx, plan[device])
+			dcs.Interfaces = changes
+			return err
+		})
+		dcs.Result = wr
+		if err != nil {
+			dcs.Error = err.Error()
+		}
+		result.Devices = append(result.Devices, dcs)
+	}
+	return result, nil
+}
+
+// applyInterfaceMTUs sets each interface's MTU, in name order, and reports
+// what it did. A PortChannel member is redirected to its parent; when two
+// members ask their parent for different MTUs, the first wins and the second
+// is reported skipped.
+func (n *Node) applyInterfaceMTUs(ctx context.Context, mtus map[string]int) ([]InterfaceChange, error) {
+	names := make([]string, 0, len(mtus))
+	for name := range mtus {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	set := map[string]int{} // target interface → MTU already applied
+	var changes []InterfaceChange
+	for _, name := range names {
+		mtu := mtus[name]
+		change := InterfaceChange{Interface: name, Property: "mtu", To: strconv.Itoa(mtu)}
+
+		intf, err := n.internal.GetInterface(name)
+		if err != nil {
+			change.Skipped = err.Error()
+			changes = append(changes, change)
+			continue
+		}
+		if parent := intf.PortChannelParent(); parent != "" {
+			if intf, err = n.internal.GetInterface(parent); err != nil {
+				return nil, err
+			}
+			change.Interface, change.Member = parent, name
+		}
+		if prev, done := set[change.Interface]; done {
+			if prev != mtu {
+				change.Skipped = fmt.Sprintf("%s already set to %d by another member", change.Interface, prev)
+				changes = append(changes, change)
+			}
+			continue
+		}
+		set[change.Interface] = mtu
+
+		if from := intf.MTU(); from != 0 {
+			change.From = strconv.Itoa(from)
+		}
+		if change.From == change.To {
+			changes = append(changes, change)
+			continue
+		}
+		if err := (&Interface{node: n, internal: intf}).SetProperty(ctx, "mtu", change.To); err != nil {
+			return nil, fmt.Errorf("%s: %w", change.Interface, err)
+		}
+		change.Changed = true
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
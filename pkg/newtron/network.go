@@ -124,6 +124,11 @@ var ErrAlreadyInitialized = errors.New("device already initialized")
 // The caller should warn the user and retry with Force=true.
 var ErrActiveConfiguration = errors.New("device has active BGP configuration; use --force to proceed (this will restart bgp, drop all sessions, and replace frr.conf — any vtysh-only config not in CONFIG_DB will be lost)")
 
+// ErrASICDBUnavailable is returned by ASIC_DB reads (Node.GetNextHopGroups)
+// on a device whose ASIC_DB is not reachable — expected on platforms with no
+// real ASIC, such as VPP. Callers treat it as "skip", not failure.
+var ErrASICDBUnavailable = sonic.ErrASICDBUnavailable
+
 func (net *Network) InitDevice(ctx context.Context, device string, force bool) error {
 	if err := net.checkPermission(ctx, auth.PermDeviceWrite, auth.NewContext().WithDevice(device)); err != nil {
 		return err
@@ -195,6 +200,15 @@ func (net *Network) ListNodes() []string {
 	return net.internal.ListNodes()
 }
 
+// ConnectAll opens transport to every switch in the topology up front, at
+// most parallelism at a time (<= 0: all at once), so a caller about to touch
+// every device does not pay for connection setup one device at a time. Each
+// device's connect time is logged; failures do not stop the rest and are
+// returned joined, one per device.
+func (net *Network) ConnectAll(ctx context.Context, parallelism int) error {
+	return net.internal.ConnectAll(ctx, parallelism)
+}
+
 // HasTopology returns true if a topology.json was loaded with the spec files.
 func (net *Network) HasTopology() bool {
 	return net.internal.HasTopology()
@@ -331,6 +345,33 @@ func (net *Network) TopologyNodeNames() []string {
 	return topo.DeviceNames()
 }
 
+// Interface roles accepted by InterfacesByRole — derived from topology links,
+// never authored. A host platform is a server, an EVPN route reflector is a
+// spine, any other switch is a leaf.
+const (
+	InterfaceRoleUplink   = "uplink"   // faces a higher tier (leaf → spine, server → leaf)
+	InterfaceRoleDownlink = "downlink" // faces a lower tier (spine → leaf, leaf → server)
+	InterfaceRoleFabric   = "fabric"   // switch-to-switch, either direction
+)
+
+// InterfacesByRole returns the device's interfaces whose topology link gives
+// them role (InterfaceRoleUplink, …), sorted by name — "all uplinks" without
+// enumerating interface names. An unlinked interface has no role. Returns
+// *ValidationError for an unknown role and *NotFoundError when the device is
+// not in the topology (or no topology is loaded).
+func (net *Network) InterfacesByRole(device, role string) ([]string, error) {
+	switch role {
+	case InterfaceRoleUplink, InterfaceRoleDownlink, InterfaceRoleFabric:
+	default:
+		return nil, &ValidationError{Field: "role", Message: fmt.Sprintf("unknown interface role %q (want uplink, downlink, or fabric)", role)}
+	}
+	topo := net.internal.GetTopology()
+	if topo == nil || !topo.HasDevice(device) {
+		return nil, &NotFoundError{Resource: "topology device", Name: device}
+	}
+	return net.internal.InterfacesByRole(device, role)
+}
+
 // IsHostDevice returns true if the named device is a virtual host (not a SONiC switch).
 func (net *Network) IsHostDevice(name string) bool {
 	return net.internal.IsHostDevice(name)
@@ -0,0 +1,109 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// ============================================================================
+// Eager connect — warm every switch's transport up front
+// ============================================================================
+
+// ConnectAll opens transport (SSH tunnel + Redis) to every switch in the
+// topology — every node spec when no topology is loaded — with at most
+// parallelism connects in flight (parallelism <= 0 means all at once). Each
+// Node is cached by GetNode, so later callers find it already connected
+// instead of serializing connection setup device by device. Hosts have no
+// SONiC and are skipped; already-connected nodes are a no-op.
+//
+// Every device is attempted: one failure does not stop the rest. Each
+// device's connect time is logged, and failures are returned joined, one
+// "connecting to <device>" error per failed device.
+func (n *Network) ConnectAll(ctx context.Context, parallelism int) error {
+	results := connectNodes(ctx, n.connectTargets(), parallelism, func(ctx context.Context, name string) error {
+		dev, err := n.GetNode(name)
+		if err != nil {
+			return err
+		}
+		return dev.ConnectTransport(ctx)
+	})
+
+	var errs []error
+	for _, r := range results {
+		if r.err != nil {
+			util.WithDevice(r.device).Warnf("Connect failed after %s: %v", r.elapsed.Round(time.Millisecond), r.err)
+			errs = append(errs, fmt.Errorf("connecting to %s: %w", r.device, r.err))
+			continue
+		}
+		util.WithDevice(r.device).Infof("Connected in %s", r.elapsed.Round(time.Millisecond))
+	}
+	return errors.Join(errs...)
+}
+
+// connectTargets returns the sorted names of the switches ConnectAll dials.
+func (n *Network) connectTargets() []string {
+	var names []string
+	if n.topology != nil {
+		names = n.topology.DeviceNames()
+	} else {
+		names = n.loader.ListNodeSpecs()
+	}
+	var switches []string
+	for _, name := range names {
+		if !n.IsHostDevice(name) {
+			switches = append(switches, name)
+		}
+	}
+	sort.Strings(switches)
+	return switches
+}
+
+// nodeConnect is one device's outcome from connectNodes.
+type nodeConnect struct {
+	device  string
+	elapsed time.Duration
+	err     error
+}
+
+// connectNodes runs connect for each name, at most parallelism at a time,
+// and returns the outcomes in names order. A device not yet dialed when ctx
+// ends is skipped; its outcome carries ctx's error.
+func connectNodes(ctx context.Context, names []string, parallelism int, connect func(context.Context, string) error) []nodeConnect {
+	if parallelism <= 0 || parallelism > len(names) {
+		parallelism = len(names)
+	}
+	results := make([]nodeConnect, len(names))
+	sem := make(chan struct{}, parallelism)
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			results[i].device = name
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				results[i].err = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			if err := ctx.Err(); err != nil {
+				results[i].err = err
+				return
+			}
+
+			start := time.Now()
+			results[i].err = connect(ctx, name)
+			results[i].elapsed = time.Since(start)
+		}(i, name)
+	}
+	wg.Wait()
+	return results
+}
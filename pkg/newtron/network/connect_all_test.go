@@ -0,0 +1,74 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestConnectTargets: ConnectAll dials the topology's switches, never hosts.
+func TestConnectTargets(t *testing.T) {
+	n := newRoleTestNetwork(t)
+	if got, want := n.connectTargets(), []string{"leaf1", "leaf2", "spine1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("connectTargets = %v, want %v", got, want)
+	}
+}
+
+// TestConnectNodes checks the parallelism bound, that one failure does not
+// stop the rest, and that outcomes come back in input order.
+func TestConnectNodes(t *testing.T) {
+	names := []string{"leaf1", "leaf2", "leaf3", "leaf4", "spine1"}
+	var inFlight, peak atomic.Int32
+	results := connectNodes(context.Background(), names, 2, func(_ context.Context, name string) error {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if name == "leaf3" {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrent connects = %d, want <= 2", p)
+	}
+	for i, r := range results {
+		if r.device != names[i] {
+			t.Errorf("results[%d].device = %s, want %s", i, r.device, names[i])
+		}
+		if failed := r.err != nil; failed != (r.device == "leaf3") {
+			t.Errorf("%s: err = %v", r.device, r.err)
+		}
+		if r.elapsed < 10*time.Millisecond {
+			t.Errorf("%s: elapsed = %s, want the connect time", r.device, r.elapsed)
+		}
+	}
+}
+
+// TestConnectNodes_Cancelled: nothing is dialed once ctx has ended.
+func TestConnectNodes_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var dialed atomic.Int32
+	results := connectNodes(ctx, []string{"leaf1", "leaf2", "leaf3"}, 1, func(context.Context, string) error {
+		dialed.Add(1)
+		return nil
+	})
+	if d := dialed.Load(); d != 0 {
+		t.Errorf("dialed %d devices after cancel, want 0", d)
+	}
+	for _, r := range results {
+		if !errors.Is(r.err, context.Canceled) {
+			t.Errorf("%s: err = %v, want context.Canceled", r.device, r.err)
+		}
+	}
+}
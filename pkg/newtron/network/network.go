@@ -398,6 +398,24 @@ func (n *Network) GetServiceAt(scope, instance, name string) (*spec.ServiceSpec,
 	return getSpecAt(n, scope, instance, "service", name, func(s *spec.OverridableSpecs) map[string]*spec.ServiceSpec { return s.Services })
 }
 
+// GetServiceDefaults returns the network's apply-service defaults for a
+// service (network.json service_defaults), or nil when it has none.
+func (n *Network) GetServiceDefaults(name string) *spec.ServiceDefaults {
+	mu := n.locks.lock(keyNetworkSpec)
+	mu.RLock()
+	defer mu.RUnlock()
+	return n.spec.ServiceDefaults[util.NormalizeName(name)]
+}
+
+// GetChangeGuard returns the network's change_guard limits, or nil when the
+// network sets none.
+func (n *Network) GetChangeGuard() *spec.ChangeGuard {
+	mu := n.locks.lock(keyNetworkSpec)
+	mu.RLock()
+	defer mu.RUnlock()
+	return n.spec.ChangeGuard
+}
+
 // GetFilter returns a filter specification by name (network base).
 func (n *Network) GetFilter(name string) (*spec.FilterSpec, error) {
 	return n.GetFilterAt("", "", name)
@@ -2264,6 +2282,7 @@ func (n *Network) resolveNodeSpec(name string, nodeSpec *spec.NodeSpec) (*spec.R
 		Zone:       nodeSpec.Zone,
 		Platform:   nodeSpec.Platform,
 		MAC:        nodeSpec.MAC,
+		Transport:  nodeSpec.Transport,
 	}
 
 	// Router ID and VTEP from loopback
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
@@ -12,12 +13,13 @@ import (
 // BGP is intentionally absent: BGP uses TCP (protocol 6) on port 179.
 // Filter rules for BGP should use protocol: "tcp" with dst_port: "179".
 var ProtoMap = map[string]int{
-	"tcp":  6,
-	"udp":  17,
-	"icmp": 1,
-	"gre":  47,
-	"ospf": 89,
-	"vrrp": 112,
+	"tcp":    6,
+	"udp":    17,
+	"icmp":   1,
+	"icmpv6": 58,
+	"gre":    47,
+	"ospf":   89,
+	"vrrp":   112,
 }
 
 // mapFilterType translates spec filter types to SONiC ACL_TABLE type values.
@@ -68,6 +70,18 @@ func createAclRuleConfig(tableName, ruleName string, opts ACLRuleConfig) []sonic
 	if opts.DstIP != "" {
 		fields["DST_IP"] = opts.DstIP
 	}
+	if opts.SrcIPv6 != "" {
+		fields["SRC_IPV6"] = opts.SrcIPv6
+	}
+	if opts.DstIPv6 != "" {
+		fields["DST_IPV6"] = opts.DstIPv6
+	}
+	if opts.ICMPv6Type != "" {
+		fields["ICMPV6_TYPE"] = opts.ICMPv6Type
+	}
+	if opts.EtherType != "" {
+		fields["ETHER_TYPE"] = opts.EtherType
+	}
 	if opts.Protocol != "" {
 		if proto, ok := ProtoMap[opts.Protocol]; ok {
 			fields["IP_PROTOCOL"] = fmt.Sprintf("%d", proto)
@@ -190,13 +204,79 @@ func deleteAclRuleConfig(tableName, ruleName string) []sonic.Entry {
 	return []sonic.Entry{{Table: "ACL_RULE", Key: fmt.Sprintf("%s|%s", tableName, ruleName)}}
 }
 
-// ACLRuleConfig holds configuration options for AddACLRule.
+// ACLRuleConfig holds configuration options for AddACLRule. SrcIP/DstIP are
+// IPv4 matches, SrcIPv6/DstIPv6/ICMPv6Type IPv6 ones; a rule uses one family.
 type ACLRuleConfig struct {
-	Priority int
-	Action   string // permit, deny (or FORWARD, DROP)
-	SrcIP    string
-	DstIP    string
-	Protocol string // tcp, udp, icmp, or number
-	SrcPort  string
-	DstPort  string
+	Priority   int
+	Action     string // permit, deny (or FORWARD, DROP)
+	SrcIP      string
+	DstIP      string
+	Protocol   string // tcp, udp, icmp, icmpv6, or number
+	SrcPort    string
+	DstPort    string
+	SrcIPv6    string
+	DstIPv6    string
+	ICMPv6Type string
+	EtherType  string // e.g. 0x0800, 0x86DD
+}
+
+// Ethertypes an ACL rule's ETHER_TYPE can pin to an address family.
+const (
+	etherTypeIPv4 = 0x0800
+	etherTypeIPv6 = 0x86DD
+)
+
+// aclRuleFamilyConflict describes why a rule mixes address families, "" when
+// it does not. SONiC ACL tables are family-scoped — L3 matches SRC_IP/DST_IP,
+// L3V6 SRC_IPV6/DST_IPV6 — so a rule carrying IPv4 and IPv6 matches, or an
+// ETHER_TYPE of the other family, can never match a packet.
+func aclRuleFamilyConflict(opts ACLRuleConfig) string {
+	v4 := opts.SrcIP != "" || opts.DstIP != ""
+	v6 := opts.SrcIPv6 != "" || opts.DstIPv6 != "" || opts.ICMPv6Type != ""
+	if v4 && v6 {
+		return "rule mixes IPv4 (src_ip/dst_ip) and IPv6 (src_ipv6/dst_ipv6/icmpv6_type) matches"
+	}
+	if opts.EtherType == "" {
+		return ""
+	}
+	et, err := strconv.ParseInt(opts.EtherType, 0, 32)
+	if err != nil {
+		return ""
+	}
+	if v4 && et == etherTypeIPv6 {
+		return fmt.Sprintf("ether_type %s is IPv6 but the rule matches IPv4 addresses", opts.EtherType)
+	}
+	if v6 && et == etherTypeIPv4 {
+		return fmt.Sprintf("ether_type %s is IPv4 but the rule matches IPv6 fields", opts.EtherType)
+	}
+	return ""
+}
+
+// aclRuleIntentParams is the NEWTRON_INTENT record of an ACL rule — the
+// add-acl-rule replay params, shared by AddACLRule and UpdateACLRule.
+func aclRuleIntentParams(tableName, ruleName string, opts ACLRuleConfig) map[string]string {
+	params := map[string]string{
+		sonic.FieldName: ruleName,
+		"acl":           tableName,
+	}
+	if opts.Priority > 0 {
+		params["priority"] = strconv.Itoa(opts.Priority)
+	}
+	for key, v := range map[string]string{
+		"action":      opts.Action,
+		"src_ip":      opts.SrcIP,
+		"dst_ip":      opts.DstIP,
+		"protocol":    opts.Protocol,
+		"src_port":    opts.SrcPort,
+		"dst_port":    opts.DstPort,
+		"src_ipv6":    opts.SrcIPv6,
+		"dst_ipv6":    opts.DstIPv6,
+		"icmpv6_type": opts.ICMPv6Type,
+		"ether_type":  opts.EtherType,
+	} {
+		if v != "" {
+			params[key] = v
+		}
+	}
+	return params
 }
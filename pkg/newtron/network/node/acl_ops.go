@@ -197,8 +197,12 @@ func (n *Node) CreateACL(ctx context.Context, name string, opts ACLConfig) (*Cha
 
 // AddACLRule adds a rule to an ACL table.
 func (n *Node) AddACLRule(ctx context.Context, tableName, ruleName string, opts ACLRuleConfig) (*ChangeSet, error) {
+	familyConflict := aclRuleFamilyConflict(opts)
 	cs, err := n.op("add-acl-rule", tableName, ChangeAdd,
-		func(pc *PreconditionChecker) { pc.RequireACLTableExists(tableName) },
+		func(pc *PreconditionChecker) {
+			pc.RequireACLTableExists(tableName).
+				Check(familyConflict == "", "single address family", familyConflict)
+		},
 		func() []sonic.Entry { return createAclRuleConfig(tableName, ruleName, opts) },
 		"device.remove-acl-rule")
 	if err != nil {
@@ -206,31 +210,7 @@ func (n *Node) AddACLRule(ctx context.Context, tableName, ruleName string, opts
 	}
 	cs.OperationParams = map[string]string{"table_name": tableName, "rule_name": ruleName}
 
-	intentParams := map[string]string{
-		sonic.FieldName: ruleName,
-		"acl":           tableName,
-	}
-	if opts.Priority > 0 {
-		intentParams["priority"] = strconv.Itoa(opts.Priority)
-	}
-	if opts.Action != "" {
-		intentParams["action"] = opts.Action
-	}
-	if opts.SrcIP != "" {
-		intentParams["src_ip"] = opts.SrcIP
-	}
-	if opts.DstIP != "" {
-		intentParams["dst_ip"] = opts.DstIP
-	}
-	if opts.Protocol != "" {
-		intentParams["protocol"] = opts.Protocol
-	}
-	if opts.SrcPort != "" {
-		intentParams["src_port"] = opts.SrcPort
-	}
-	if opts.DstPort != "" {
-		intentParams["dst_port"] = opts.DstPort
-	}
+	intentParams := aclRuleIntentParams(tableName, ruleName, opts)
 	if err := n.writeIntent(cs, sonic.OpAddACLRule, "acl|"+tableName+"|"+ruleName,
 		intentParams,
 		[]string{"acl|" + tableName}); err != nil {
@@ -261,8 +241,12 @@ func (n *Node) UpdateACLRule(ctx context.Context, tableName, ruleName string, op
 		return nil, fmt.Errorf("rule %s not found in ACL table %s", ruleName, tableName)
 	}
 
+	familyConflict := aclRuleFamilyConflict(opts)
 	cs, err := n.op(sonic.OpUpdateACLRule, tableName, ChangeAdd,
-		func(pc *PreconditionChecker) { pc.RequireACLTableExists(tableName) },
+		func(pc *PreconditionChecker) {
+			pc.RequireACLTableExists(tableName).
+				Check(familyConflict == "", "single address family", familyConflict)
+		},
 		func() []sonic.Entry { return nil })
 	if err != nil {
 		return nil, err
@@ -275,31 +259,7 @@ func (n *Node) UpdateACLRule(ctx context.Context, tableName, ruleName string, op
 		deleteAclRuleConfig(tableName, ruleName),
 		createAclRuleConfig(tableName, ruleName, opts))
 
-	intentParams := map[string]string{
-		sonic.FieldName: ruleName,
-		"acl":           tableName,
-	}
-	if opts.Priority > 0 {
-		intentParams["priority"] = strconv.Itoa(opts.Priority)
-	}
-	if opts.Action != "" {
-		intentParams["action"] = opts.Action
-	}
-	if opts.SrcIP != "" {
-		intentParams["src_ip"] = opts.SrcIP
-	}
-	if opts.DstIP != "" {
-		intentParams["dst_ip"] = opts.DstIP
-	}
-	if opts.Protocol != "" {
-		intentParams["protocol"] = opts.Protocol
-	}
-	if opts.SrcPort != "" {
-		intentParams["src_port"] = opts.SrcPort
-	}
-	if opts.DstPort != "" {
-		intentParams["dst_port"] = opts.DstPort
-	}
+	intentParams := aclRuleIntentParams(tableName, ruleName, opts)
 	if err := n.writeIntent(cs, sonic.OpAddACLRule, resource,
 		intentParams,
 		[]string{"acl|" + tableName}); err != nil {
@@ -353,7 +313,7 @@ func (n *Node) DeleteACL(ctx context.Context, name string) (*ChangeSet, error) {
 // UnbindACLFromInterface removes an interface from an ACL table's binding.
 // Node convenience method — delegates to Interface.UnbindACL.
 func (n *Node) UnbindACLFromInterface(ctx context.Context, aclName, interfaceName string) (*ChangeSet, error) {
-	interfaceName = util.NormalizeInterfaceName(interfaceName)
+	interfaceName = n.normalizeInterfaceName(interfaceName)
 	iface, err := n.GetInterface(interfaceName)
 	if err != nil {
 		return nil, err
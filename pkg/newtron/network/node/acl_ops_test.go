@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"sort"
 	"strings"
 	"testing"
@@ -104,3 +105,72 @@ func TestResourceInterfaceName(t *testing.T) {
 		}
 	}
 }
+
+// TestAddACLRule_IPv6 pins the IPv6 match fields: a pure-v6 rule lands on
+// SRC_IPV6/DST_IPV6/ICMPV6_TYPE/ETHER_TYPE and its intent carries them for
+// replay.
+func TestAddACLRule_IPv6(t *testing.T) {
+	n := testDevice()
+	ctx := context.Background()
+	if _, err := n.CreateACL(ctx, "EDGE_V6", ACLConfig{Type: "L3V6", Stage: "ingress"}); err != nil {
+		t.Fatalf("CreateACL: %v", err)
+	}
+
+	cs, err := n.AddACLRule(ctx, "EDGE_V6", "RULE_10", ACLRuleConfig{
+		Priority:   100,
+		Action:     "deny",
+		SrcIPv6:    "2001:db8::/32",
+		DstIPv6:    "2001:db8:1::/48",
+		Protocol:   "icmpv6",
+		ICMPv6Type: "128",
+		EtherType:  "0x86DD",
+	})
+	if err != nil {
+		t.Fatalf("AddACLRule: %v", err)
+	}
+	c := assertChange(t, cs, "ACL_RULE", "EDGE_V6|RULE_10", ChangeAdd)
+	assertField(t, c, "SRC_IPV6", "2001:db8::/32")
+	assertField(t, c, "DST_IPV6", "2001:db8:1::/48")
+	assertField(t, c, "IP_PROTOCOL", "58")
+	assertField(t, c, "ICMPV6_TYPE", "128")
+	assertField(t, c, "ETHER_TYPE", "0x86DD")
+	if _, ok := c.Fields["SRC_IP"]; ok {
+		t.Error("IPv6 rule wrote SRC_IP")
+	}
+
+	intent := n.GetIntent("acl|EDGE_V6|RULE_10")
+	if intent == nil {
+		t.Fatal("rule intent not written")
+	}
+	for key, want := range map[string]string{"src_ipv6": "2001:db8::/32", "dst_ipv6": "2001:db8:1::/48", "icmpv6_type": "128", "ether_type": "0x86DD"} {
+		if got := intent.Params[key]; got != want {
+			t.Errorf("intent %s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+// TestAddACLRule_MixedFamilyRejected: SONiC ACL tables are address-family
+// scoped, so a rule mixing v4 and v6 matches — or an ether_type of the other
+// family — is refused before anything is written.
+func TestAddACLRule_MixedFamilyRejected(t *testing.T) {
+	n := testDevice()
+	ctx := context.Background()
+	if _, err := n.CreateACL(ctx, "EDGE_IN", ACLConfig{Type: "L3", Stage: "ingress"}); err != nil {
+		t.Fatalf("CreateACL: %v", err)
+	}
+
+	for name, opts := range map[string]ACLRuleConfig{
+		"v4 src, v6 dst":       {Action: "deny", SrcIP: "10.0.0.0/8", DstIPv6: "2001:db8::/32"},
+		"v4 dst, icmpv6 type":  {Action: "deny", DstIP: "10.0.0.0/8", ICMPv6Type: "128"},
+		"v4 with v6 ethertype": {Action: "deny", SrcIP: "10.0.0.0/8", EtherType: "0x86DD"},
+		"v6 with v4 ethertype": {Action: "deny", SrcIPv6: "2001:db8::/32", EtherType: "2048"},
+	} {
+		_, err := n.AddACLRule(ctx, "EDGE_IN", "RULE_10", opts)
+		if err == nil || !strings.Contains(err.Error(), "single address family") {
+			t.Errorf("%s: err = %v, want single-address-family precondition", name, err)
+		}
+	}
+	if n.GetIntent("acl|EDGE_IN|RULE_10") != nil {
+		t.Error("rejected rule left an intent behind")
+	}
+}
@@ -0,0 +1,141 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func TestConfigureIRB_RejectsNonUnicastAnycastMAC(t *testing.T) {
+	for _, tc := range []struct{ mac, want string }{
+		{"01:00:5e:00:00:01", "multicast or broadcast"},
+		{"ff:ff:ff:ff:ff:ff", "multicast or broadcast"},
+		{"00:00:00:00:00:00", "all zeros"},
+		{"00:00:00:01:01", "invalid MAC address"},
+	} {
+		t.Run(tc.mac, func(t *testing.T) {
+			ctx := context.Background()
+			n, _ := testInterface()
+			if _, err := n.CreateVLAN(ctx, 100, VLANConfig{}); err != nil {
+				t.Fatalf("CreateVLAN: %v", err)
+			}
+			_, err := n.ConfigureIRB(ctx, 100, IRBConfig{IPAddress: "10.1.100.1/24", AnycastMAC: tc.mac})
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err = %v, want %q", err, tc.want)
+			}
+			if len(n.configDB.SAGGlobal) != 0 || n.GetIntent("interface|Vlan100") != nil {
+				t.Error("a refused configure-irb rendered the IRB")
+			}
+		})
+	}
+}
+
+// TestConfigureIRB_RefusesConflictingGlobalSAG: SAG_GLOBAL is one MAC for
+// the whole device, so a second IRB asking for a different global MAC is
+// refused, naming the MAC in place and the IRB that uses it. The same MAC,
+// or a different MAC held per-VLAN, is accepted.
+func TestConfigureIRB_RefusesConflictingGlobalSAG(t *testing.T) {
+	ctx := context.Background()
+	n := irbNode(t, IRBConfig{IPAddress: "10.1.100.1/24", AnycastMAC: "00:00:00:00:01:01"})
+	for _, id := range []int{200, 300} {
+		if _, err := n.CreateVLAN(ctx, id, VLANConfig{}); err != nil {
+			t.Fatalf("CreateVLAN %d: %v", id, err)
+		}
+	}
+
+	_, err := n.ConfigureIRB(ctx, 200, IRBConfig{IPAddress: "10.1.200.1/24", AnycastMAC: "00:00:00:00:02:02"})
+	if err == nil || !strings.Contains(err.Error(), "conflicts with the device-wide SAG_GLOBAL MAC 00:00:00:00:01:01 (used by interface|Vlan100)") {
+		t.Fatalf("err = %v, want a SAG_GLOBAL conflict naming interface|Vlan100", err)
+	}
+	if got := n.configDB.SAGGlobal["IPv4"]["gwmac"]; got != "00:00:00:00:01:01" {
+		t.Errorf("SAG_GLOBAL gwmac = %q after a refused configure-irb", got)
+	}
+
+	if _, err := n.ConfigureIRB(ctx, 200, IRBConfig{IPAddress: "10.1.200.1/24", AnycastMAC: "00-00-00-00-01-01"}); err != nil {
+		t.Fatalf("same global MAC: %v", err)
+	}
+	if _, err := n.ConfigureIRB(ctx, 300, IRBConfig{IPAddress: "10.1.30.1/24", AnycastMAC: "00:00:00:00:03:03", AnycastMACPerVLAN: true}); err != nil {
+		t.Fatalf("distinct per-VLAN MAC: %v", err)
+	}
+	if got := n.configDB.SAG["Vlan300|IPv4"]["gwmac"]; got != "00:00:00:00:03:03" {
+		t.Errorf("SAG Vlan300|IPv4 gwmac = %q, want 00:00:00:00:03:03", got)
+	}
+
+	// The per-VLAN IRB does not hold SAG_GLOBAL: once both global users are
+	// gone, the singleton is deleted while Vlan300's entry stays.
+	for _, id := range []int{100, 200} {
+		if _, err := n.UnconfigureIRB(ctx, id); err != nil {
+			t.Fatalf("UnconfigureIRB %d: %v", id, err)
+		}
+	}
+	if len(n.configDB.SAGGlobal) != 0 {
+		t.Errorf("SAG_GLOBAL = %v, want it deleted with its last user", n.configDB.SAGGlobal)
+	}
+	if _, ok := n.configDB.SAG["Vlan300|IPv4"]; !ok {
+		t.Error("Vlan300's per-VLAN SAG was deleted with the global users")
+	}
+}
+
+// TestApplyService_IRBPerVLANAnycastMACs: two irb services whose macvpns set
+// distinct per-VLAN anycast MACs each author their own SAG entry and leave
+// SAG_GLOBAL alone; removing one reaps only its entry. Without the per-VLAN
+// flag the second service is refused before anything is rendered.
+func TestApplyService_IRBPerVLANAnycastMACs(t *testing.T) {
+	ctx := context.Background()
+	n, _ := testInterface()
+	sp := n.SpecProvider.(*testSpecProvider)
+	sp.macvpn["GW100"] = &spec.MACVPNSpec{VlanID: 100, AnycastIP: "10.1.100.1/24", AnycastMAC: "00:00:00:00:01:00", AnycastMACPerVLAN: true}
+	sp.macvpn["GW200"] = &spec.MACVPNSpec{VlanID: 200, AnycastIP: "10.1.200.1/24", AnycastMAC: "00:00:00:00:02:00", AnycastMACPerVLAN: true}
+	sp.macvpn["GW300"] = &spec.MACVPNSpec{VlanID: 300, AnycastIP: "10.1.30.1/24", AnycastMAC: "00:00:00:00:03:00"}
+	sp.macvpn["GW400"] = &spec.MACVPNSpec{VlanID: 400, AnycastIP: "10.1.40.1/24", AnycastMAC: "00:00:00:00:04:00"}
+	for _, name := range []string{"GW100", "GW200", "GW300", "GW400"} {
+		sp.services[name] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeIRB, MACVPN: name}
+	}
+	irbs := map[int]*Interface{}
+	for _, id := range []int{100, 200, 300, 400} {
+		if _, err := n.CreateVLAN(ctx, id, VLANConfig{}); err != nil {
+			t.Fatalf("CreateVLAN %d: %v", id, err)
+		}
+		irb, err := n.GetInterface(VLANName(id))
+		if err != nil {
+			t.Fatalf("GetInterface(%s): %v", VLANName(id), err)
+		}
+		irbs[id] = irb
+	}
+
+	for id, svc := range map[int]string{100: "GW100", 200: "GW200"} {
+		if _, err := irbs[id].ApplyService(ctx, svc, ApplyServiceOpts{}); err != nil {
+			t.Fatalf("ApplyService(%s): %v", svc, err)
+		}
+	}
+	for key, want := range map[string]string{"Vlan100|IPv4": "00:00:00:00:01:00", "Vlan200|IPv4": "00:00:00:00:02:00"} {
+		if got := n.configDB.SAG[key]["gwmac"]; got != want {
+			t.Errorf("SAG %s gwmac = %q, want %q", key, got, want)
+		}
+	}
+	if len(n.configDB.SAGGlobal) != 0 {
+		t.Errorf("per-VLAN anycast MACs wrote SAG_GLOBAL: %v", n.configDB.SAGGlobal)
+	}
+
+	// Two global MACs cannot coexist: the second is refused up front.
+	if _, err := irbs[300].ApplyService(ctx, "GW300", ApplyServiceOpts{}); err != nil {
+		t.Fatalf("ApplyService(GW300): %v", err)
+	}
+	_, err := irbs[400].ApplyService(ctx, "GW400", ApplyServiceOpts{})
+	if err == nil || !strings.Contains(err.Error(), "conflicts with the device-wide SAG_GLOBAL MAC 00:00:00:00:03:00") {
+		t.Fatalf("err = %v, want a SAG_GLOBAL conflict", err)
+	}
+	if n.GetIntent("interface|Vlan400") != nil || n.GetIntent(bindingKey("Vlan400")) != nil {
+		t.Error("a refused apply-service left intents behind")
+	}
+
+	cs, err := irbs[100].RemoveService(ctx)
+	if err != nil {
+		t.Fatalf("RemoveService: %v", err)
+	}
+	assertChange(t, cs, "SAG", "Vlan100|IPv4", ChangeDelete)
+	assertNoChange(t, cs, "SAG", "Vlan200|IPv4")
+	assertNoChange(t, cs, "SAG_GLOBAL", "IPv4")
+}
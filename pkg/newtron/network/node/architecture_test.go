@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
@@ -275,6 +276,24 @@ func TestUnsavedIntents_ClearedByClear(t *testing.T) {
 	}
 }
 
+// TestCanSafelyReload_Offline pins the guard's in-memory checks: unsaved
+// intents block a reload first, and a node with no wire is never reported
+// safe — the guard fails closed.
+func TestCanSafelyReload_Offline(t *testing.T) {
+	ctx := context.Background()
+	n := newTestAbstractNode()
+
+	if safe, reason := n.CanSafelyReload(ctx); safe || reason != "device not connected" {
+		t.Errorf("clean offline node: safe=%v reason=%q; want false, device not connected", safe, reason)
+	}
+	if _, err := n.CreateVLAN(ctx, 100, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+	if safe, reason := n.CanSafelyReload(ctx); safe || !strings.Contains(reason, "since the last save") {
+		t.Errorf("after CreateVLAN: safe=%v reason=%q; want blocked on unsaved intents", safe, reason)
+	}
+}
+
 func TestUnsavedIntents_ClearedAfterReplay(t *testing.T) {
 	// In production, BuildAbstractNode calls ReplayStep (which sets
 	// unsavedIntents via writeIntent) then calls ClearUnsavedIntents.
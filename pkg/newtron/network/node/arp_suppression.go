@@ -0,0 +1,83 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// ARP suppression state — whether each VLAN's EVPN neighbor suppression is
+// intended, configured, and in effect. Pure observation (§4).
+//
+// A MAC-VPN binding with arp_suppression writes CONFIG_DB SUPPRESS_VLAN_NEIGH
+// (suppress=on). vlanmgrd copies the row to APPL_DB SUPPRESS_VLAN_NEIGH_TABLE,
+// from which fdbsyncd sets neigh_suppress on the VLAN's VXLAN bridge port —
+// so the APPL_DB row is the device's own record that the setting was taken
+// up. A row in CONFIG_DB but not APPL_DB means vlanmgrd never processed it
+// (typically: the VLAN has no VXLAN map yet), and ARP still floods.
+// ============================================================================
+
+// ARPSuppressionState is one VLAN's ARP suppression at each layer.
+type ARPSuppressionState struct {
+	VLAN       string
+	Intended   bool // the projection (intent replay) writes suppress=on
+	Configured bool // the device's CONFIG_DB SUPPRESS_VLAN_NEIGH has suppress=on
+	Applied    bool // APPL_DB SUPPRESS_VLAN_NEIGH_TABLE has suppress=on
+}
+
+// GetARPSuppressionState reads every VLAN's ARP suppression, sorted by VLAN
+// ID: each VLAN in the projection, plus any VLAN the device has a suppression
+// row for. Auto-connects transport if needed.
+func (n *Node) GetARPSuppressionState(ctx context.Context) ([]ARPSuppressionState, error) {
+	applied, err := n.OperDBTable(ctx, "APPL_DB", "SUPPRESS_VLAN_NEIGH_TABLE")
+	if err != nil {
+		return nil, fmt.Errorf("reading APPL_DB SUPPRESS_VLAN_NEIGH_TABLE: %w", err)
+	}
+	configured, err := n.conn.Client().GetRawTable("SUPPRESS_VLAN_NEIGH")
+	if err != nil {
+		return nil, fmt.Errorf("reading CONFIG_DB SUPPRESS_VLAN_NEIGH: %w", err)
+	}
+	vlans := make([]string, 0, len(n.configDB.VLAN))
+	for name := range n.configDB.VLAN {
+		vlans = append(vlans, name)
+	}
+	return buildARPSuppressionState(vlans, n.configDB.SuppressVLANNeigh, configured, applied), nil
+}
+
+// buildARPSuppressionState joins the three SUPPRESS_VLAN_NEIGH reads, each
+// keyed by VLAN name, over vlans and every VLAN the reads name.
+func buildARPSuppressionState(vlans []string, intended, configured, applied map[string]map[string]string) []ARPSuppressionState {
+	names := map[string]bool{}
+	for _, v := range vlans {
+		names[v] = true
+	}
+	for _, rows := range []map[string]map[string]string{intended, configured, applied} {
+		for v := range rows {
+			if v != "" {
+				names[v] = true
+			}
+		}
+	}
+
+	out := make([]ARPSuppressionState, 0, len(names))
+	for v := range names {
+		out = append(out, ARPSuppressionState{
+			VLAN:       v,
+			Intended:   intended[v]["suppress"] == "on",
+			Configured: configured[v]["suppress"] == "on",
+			Applied:    applied[v]["suppress"] == "on",
+		})
+	}
+	sort.Slice(out, func(a, b int) bool {
+		ia, errA := strconv.Atoi(strings.TrimPrefix(out[a].VLAN, "Vlan"))
+		ib, errB := strconv.Atoi(strings.TrimPrefix(out[b].VLAN, "Vlan"))
+		if errA != nil || errB != nil || ia == ib {
+			return out[a].VLAN < out[b].VLAN
+		}
+		return ia < ib
+	})
+	return out
+}
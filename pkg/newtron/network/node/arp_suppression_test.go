@@ -0,0 +1,34 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildARPSuppressionState(t *testing.T) {
+	vlans := []string{"Vlan100", "Vlan20", "Vlan300"}
+	intended := map[string]map[string]string{
+		"Vlan100": {"suppress": "on"},
+		"Vlan20":  {"suppress": "on"},
+	}
+	configured := map[string]map[string]string{
+		"Vlan100": {"suppress": "on"},
+		"Vlan20":  {"suppress": "on"},
+		"Vlan400": {"suppress": "off"}, // out-of-band row, no VLAN in the projection
+	}
+	applied := map[string]map[string]string{
+		"Vlan100": {"suppress": "on"},
+		"":        {"flat": "hash"},
+	}
+
+	got := buildARPSuppressionState(vlans, intended, configured, applied)
+	want := []ARPSuppressionState{
+		{VLAN: "Vlan20", Intended: true, Configured: true},
+		{VLAN: "Vlan100", Intended: true, Configured: true, Applied: true},
+		{VLAN: "Vlan300"},
+		{VLAN: "Vlan400"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildARPSuppressionState =\n  %+v\nwant\n  %+v", got, want)
+	}
+}
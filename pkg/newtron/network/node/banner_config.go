@@ -0,0 +1,23 @@
+package node
+
+import (
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// setBannerConfig returns the BANNER_MESSAGE entry for cfg. Every message
+// field is written — an unset one as "" — so a later set replaces the
+// previous banner rather than merging into it.
+func setBannerConfig(cfg BannerConfig) []sonic.Entry {
+	return []sonic.Entry{{Table: "BANNER_MESSAGE", Key: "global", Fields: map[string]string{
+		"state":  "enabled",
+		"login":  cfg.Login,
+		"motd":   cfg.MOTD,
+		"logout": cfg.Logout,
+	}}}
+}
+
+// deleteBannerConfig returns the BANNER_MESSAGE delete. With no entry,
+// hostcfgd leaves the image's default /etc/issue.net and /etc/motd.
+func deleteBannerConfig() []sonic.Entry {
+	return []sonic.Entry{{Table: "BANNER_MESSAGE", Key: "global"}}
+}
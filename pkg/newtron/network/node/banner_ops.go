@@ -0,0 +1,78 @@
+package node
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// bannerResource is the intent key of the device banner. One intent per
+// device: setting the banner again replaces it.
+const bannerResource = "banner"
+
+// BannerConfig holds the device's login banners. Login is shown before
+// authentication (/etc/issue.net), MOTD after a successful login, Logout at
+// session end. An empty message is left blank.
+type BannerConfig struct {
+	Login  string
+	MOTD   string
+	Logout string
+}
+
+// SetBanner sets the device's login, MOTD, and logout messages. At least one
+// must be non-empty. Setting the banner again replaces all three messages;
+// setting the same banner is a no-op on the device.
+func (n *Node) SetBanner(ctx context.Context, cfg BannerConfig) (*ChangeSet, error) {
+	if err := n.precondition(sonic.OpSetBanner, bannerResource).
+		Check(cfg.Login != "" || cfg.MOTD != "" || cfg.Logout != "", "banner message",
+			"at least one of login, motd, or logout is required").
+		Result(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{}
+	for field, msg := range map[string]string{
+		sonic.FieldLogin:  cfg.Login,
+		sonic.FieldMOTD:   cfg.MOTD,
+		sonic.FieldLogout: cfg.Logout,
+	} {
+		if msg != "" {
+			params[field] = msg
+		}
+	}
+	cs := NewChangeSet(n.name, "device."+sonic.OpSetBanner)
+	cs.ReverseOp = "device." + sonic.OpClearBanner
+	cs.OperationParams = params
+	if err := n.writeIntent(cs, sonic.OpSetBanner, bannerResource, params, []string{"device"}); err != nil {
+		return nil, err
+	}
+	cs.Updates(setBannerConfig(cfg))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Set banner")
+	return cs, nil
+}
+
+// ClearBanner removes the banner set by SetBanner, returning the device to
+// its image's default messages. Reverse of SetBanner (§15).
+func (n *Node) ClearBanner(ctx context.Context) (*ChangeSet, error) {
+	if err := n.precondition(sonic.OpClearBanner, bannerResource).Result(); err != nil {
+		return nil, err
+	}
+	if n.GetIntent(bannerResource) == nil {
+		return nil, fmt.Errorf("no banner is set")
+	}
+	cs := NewChangeSet(n.name, "device."+sonic.OpClearBanner)
+	cs.Deletes(deleteBannerConfig())
+	if err := n.deleteIntent(cs, bannerResource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Cleared banner")
+	return cs, nil
+}
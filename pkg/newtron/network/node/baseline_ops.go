@@ -27,12 +27,33 @@ type SetupDeviceOpts struct {
 // The sub-operations (SetDeviceMetadata, ConfigureLoopback, ConfigureBGP,
 // SetupVXLAN, ConfigureBGPOverlay, ConfigureRouteReflector) remain available as individual methods
 // but do NOT write intent records — SetupDevice is the intent-producing entry point.
+//
+// Re-running SetupDevice is safe and cheap: rows the device already holds
+// with the same fields are pruned from the ChangeSet (dropUnchanged, over the
+// ChangeSet's Diff), so a repeat with identical opts returns an empty
+// ChangeSet and a changed field delivers only the rows it touches. The diff
+// reads the live CONFIG_DB when connected, so a row missing from the device
+// is rewritten even though the projection still holds it — re-running is the
+// repair. Offline, and while replaying intents, the projection is all there
+// is to diff against.
 func (n *Node) SetupDevice(ctx context.Context, opts SetupDeviceOpts) (*ChangeSet, error) {
+	var live configDBReader
+	if client := n.ConfigDBClient(); client != nil && !n.reconstructing {
+		live = client
+	}
+	return n.setupDevice(ctx, opts, live)
+}
+
+// setupDevice holds SetupDevice against any configDBReader for the live
+// CONFIG_DB (nil offline), so tests can inject a fake.
+func (n *Node) setupDevice(ctx context.Context, opts SetupDeviceOpts, live configDBReader) (*ChangeSet, error) {
 	if err := n.precondition(sonic.OpSetupDevice, n.name).Result(); err != nil {
 		return nil, err
 	}
 
 	cs := NewChangeSet(n.name, "device."+sonic.OpSetupDevice)
+	before := n.Projection()
+	wasUnsaved := n.unsavedIntents
 
 	// Intent record — captures the input params for reconstruction.
 	//
@@ -118,7 +139,22 @@ func (n *Node) SetupDevice(ctx context.Context, opts SetupDeviceOpts) (*ChangeSe
 		cs.Merge(rrCS)
 	}
 
-	util.WithDevice(n.name).Infof("Device setup complete")
+	reader := live
+	if reader == nil {
+		reader = rawReader(before)
+	}
+	d, err := diffWithReader(reader, cs.Changes)
+	if err != nil {
+		return nil, fmt.Errorf("diffing setup-device against CONFIG_DB: %w", err)
+	}
+	cs.dropUnchanged(d)
+	if cs.IsEmpty() {
+		// Identical params re-recorded — not a mutation worth saving.
+		n.unsavedIntents = wasUnsaved
+		util.WithDevice(n.name).Infof("Device setup unchanged — nothing to apply")
+		return cs, nil
+	}
+	util.WithDevice(n.name).Infof("Device setup complete (%d changes)", len(cs.Changes))
 	return cs, nil
 }
 
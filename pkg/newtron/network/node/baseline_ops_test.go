@@ -0,0 +1,98 @@
+package node
+
+import (
+	"context"
+	"testing"
+)
+
+// TestSetupDevice_Idempotent: re-running setup-device with the same opts
+// delivers nothing, and a changed field delivers only the rows it touches.
+func TestSetupDevice_Idempotent(t *testing.T) {
+	ctx := context.Background()
+	n := testDevice()
+	opts := SetupDeviceOpts{
+		Fields:   map[string]string{"hostname": "leaf1", "bgp_asn": "65001"},
+		SourceIP: "10.255.0.1",
+	}
+	cs, err := n.SetupDevice(ctx, opts)
+	if err != nil {
+		t.Fatalf("SetupDevice: %v", err)
+	}
+	if cs.IsEmpty() {
+		t.Fatal("first SetupDevice produced no changes")
+	}
+	n.ClearUnsavedIntents()
+
+	cs, err = n.SetupDevice(ctx, opts)
+	if err != nil {
+		t.Fatalf("repeated SetupDevice: %v", err)
+	}
+	if !cs.IsEmpty() {
+		t.Fatalf("repeated SetupDevice: want empty ChangeSet, got:\n%s", cs)
+	}
+	if n.HasUnsavedIntents() {
+		t.Error("repeated SetupDevice marked intents unsaved")
+	}
+
+	opts.Fields = map[string]string{"hostname": "leaf1-new", "bgp_asn": "65001"}
+	cs, err = n.SetupDevice(ctx, opts)
+	if err != nil {
+		t.Fatalf("SetupDevice with new hostname: %v", err)
+	}
+	md := assertChange(t, cs, "DEVICE_METADATA", "localhost", ChangeModify)
+	assertField(t, md, "hostname", "leaf1-new")
+	assertChange(t, cs, "NEWTRON_INTENT", "device", ChangeAdd)
+	for _, c := range cs.Changes {
+		if c.Table != "DEVICE_METADATA" && c.Table != "NEWTRON_INTENT" {
+			t.Errorf("hostname change also delivered %s|%s", c.Table, c.Key)
+		}
+	}
+}
+
+// TestSetupDevice_RewritesRowMissingOnDevice: the re-run prunes against the
+// live CONFIG_DB, not the projection, so a DEVICE_METADATA row deleted on the
+// device behind newtron's back is rewritten — and only that row.
+func TestSetupDevice_RewritesRowMissingOnDevice(t *testing.T) {
+	ctx := context.Background()
+	n := testDevice()
+	opts := SetupDeviceOpts{
+		Fields:   map[string]string{"hostname": "leaf1", "bgp_asn": "65001"},
+		SourceIP: "10.255.0.1",
+	}
+	if _, err := n.SetupDevice(ctx, opts); err != nil {
+		t.Fatalf("SetupDevice: %v", err)
+	}
+
+	// The device as Redis holds it: the projection, with a field-less row
+	// stored under the NULL sentinel.
+	device := map[string]map[string]string{}
+	for table, rows := range n.Projection() {
+		for key, fields := range rows {
+			if len(fields) == 0 {
+				fields = map[string]string{"NULL": "NULL"}
+			}
+			device[table+"|"+key] = fields
+		}
+	}
+	cs, err := n.setupDevice(ctx, opts, newFakeReader(device))
+	if err != nil {
+		t.Fatalf("re-run against a matching device: %v", err)
+	}
+	if !cs.IsEmpty() {
+		t.Fatalf("re-run against a matching device: want empty ChangeSet, got:\n%s", cs)
+	}
+
+	delete(device, "DEVICE_METADATA|localhost")
+	cs, err = n.setupDevice(ctx, opts, newFakeReader(device))
+	if err != nil {
+		t.Fatalf("re-run against a device missing DEVICE_METADATA: %v", err)
+	}
+	md := assertChange(t, cs, "DEVICE_METADATA", "localhost", ChangeModify)
+	assertField(t, md, "hostname", "leaf1")
+	assertField(t, md, "bgp_asn", "65001")
+	for _, c := range cs.Changes {
+		if c.Table != "DEVICE_METADATA" {
+			t.Errorf("repair also delivered %s|%s", c.Table, c.Key)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package node
 
 import (
 	"fmt"
+	"maps"
+	"strconv"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 	"github.com/aldrin-isaac/newtron/pkg/util"
@@ -23,6 +25,30 @@ type BGPNeighborOpts struct {
 	NextHopSelfIPv6  bool   // nhself on ipv6_unicast AF
 	RRClientEVPN     bool   // rrclient on l2vpn_evpn AF
 	PeerGroup        string // peer group name (for service-level BGP neighbors, per Principle 36)
+	MaxPrefix        int    // ipv4_unicast received-prefix limit (0 = none)
+	MaxPrefixAction  string // past the limit: "" tears the session down, "warning" or "restart"
+}
+
+// Max-prefix actions. With neither, FRR tears the session down and leaves it
+// down until cleared; "restart" brings it back after
+// bgpMaxPrefixRestartMinutes, "warning" only logs.
+const (
+	BGPMaxPrefixWarning = "warning"
+	BGPMaxPrefixRestart = "restart"
+
+	bgpMaxPrefixRestartMinutes = 5
+)
+
+// maxPrefixFields returns the BGP_NEIGHBOR_AF fields for a prefix limit.
+func maxPrefixFields(limit int, action string) map[string]string {
+	fields := map[string]string{"max_prefix_limit": strconv.Itoa(limit)}
+	switch action {
+	case BGPMaxPrefixWarning:
+		fields["max_prefix_warning_only"] = "true"
+	case BGPMaxPrefixRestart:
+		fields["max_prefix_restart_interval"] = strconv.Itoa(bgpMaxPrefixRestartMinutes)
+	}
+	return fields
 }
 
 // CreateBGPNeighborConfig returns sonic.Entry for a BGP_NEIGHBOR + BGP_NEIGHBOR_AF.
@@ -70,6 +96,9 @@ func CreateBGPNeighborConfig(neighborIP string, asn int, localAddr string, opts
 		if opts.NextHopSelf {
 			afFields["nhself"] = "true"
 		}
+		if opts.MaxPrefix > 0 {
+			maps.Copy(afFields, maxPrefixFields(opts.MaxPrefix, opts.MaxPrefixAction))
+		}
 		entries = append(entries, createBgpNeighborAFConfig(vrf, neighborIP, "ipv4_unicast", afFields))
 	}
 
@@ -126,6 +155,16 @@ func DeleteBGPNeighborConfig(vrf, neighborIP string) []sonic.Entry {
 	return entries
 }
 
+// bgpNeighborAdminConfig returns the entry that shuts ("down") or re-enables
+// ("up") a BGP neighbor without touching the rest of its configuration.
+func bgpNeighborAdminConfig(vrf, neighborIP, status string) []sonic.Entry {
+	if vrf == "" {
+		vrf = "default"
+	}
+	return []sonic.Entry{{Table: "BGP_NEIGHBOR", Key: BGPNeighborKey(vrf, neighborIP),
+		Fields: map[string]string{"admin_status": status}}}
+}
+
 // BGPNeighborKey returns the CONFIG_DB key for a BGP_NEIGHBOR entry.
 // Format: vrf|neighborIP (e.g., "default|10.0.0.1").
 func BGPNeighborKey(vrf, neighborIP string) string {
@@ -170,6 +209,57 @@ func revertRedistributionConfig(vrfKey string) []sonic.Entry {
 	})
 }
 
+// importVRFConfig returns the BGP_GLOBALS_AF ipv4_unicast row for dstVRF
+// with route leaking from srcVRF turned on — frrcfgd renders import_vrf as
+// 'import vrf <src>' and import_vrf_route_map as 'import vrf route-map <rm>'.
+// current is the row as it stands; the leak fields are merged into a copy,
+// because the projection replaces a row on write rather than merging it.
+func importVRFConfig(current map[string]string, dstVRF, srcVRF, routeMap string) []sonic.Entry {
+	fields := maps.Clone(current)
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	fields["import_vrf"] = srcVRF
+	delete(fields, "import_vrf_route_map")
+	if routeMap != "" {
+		fields["import_vrf_route_map"] = routeMap
+	}
+	return CreateBGPGlobalsAFConfig(dstVRF, "ipv4_unicast", fields)
+}
+
+// clearImportVRFConfig returns dstVRF's BGP_GLOBALS_AF ipv4_unicast row with
+// the route-leak fields dropped — the reverse of importVRFConfig. An empty
+// row means the leak created it and it should be deleted.
+func clearImportVRFConfig(current map[string]string, dstVRF string) []sonic.Entry {
+	fields := maps.Clone(current)
+	delete(fields, "import_vrf")
+	delete(fields, "import_vrf_route_map")
+	return CreateBGPGlobalsAFConfig(dstVRF, "ipv4_unicast", fields)
+}
+
+// bgpMaxPathsConfig returns the default VRF's BGP_GLOBALS_AF ipv4_unicast row
+// with eBGP and iBGP multipath set to paths — frrcfgd renders them as
+// 'maximum-paths <n>' and 'maximum-paths ibgp <n>'. current is the row as it
+// stands; the fields are merged into a copy, as in importVRFConfig.
+func bgpMaxPathsConfig(current map[string]string, paths int) []sonic.Entry {
+	fields := maps.Clone(current)
+	if fields == nil {
+		fields = map[string]string{}
+	}
+	fields["max_ebgp_paths"] = strconv.Itoa(paths)
+	fields["max_ibgp_paths"] = strconv.Itoa(paths)
+	return CreateBGPGlobalsAFConfig("default", "ipv4_unicast", fields)
+}
+
+// clearBGPMaxPathsConfig returns the default VRF's ipv4_unicast row with the
+// multipath fields dropped, so FRR falls back to its compiled default.
+func clearBGPMaxPathsConfig(current map[string]string) []sonic.Entry {
+	fields := maps.Clone(current)
+	delete(fields, "max_ebgp_paths")
+	delete(fields, "max_ibgp_paths")
+	return CreateBGPGlobalsAFConfig("default", "ipv4_unicast", fields)
+}
+
 // RouteRedistributeKey returns the CONFIG_DB key for a ROUTE_REDISTRIBUTE entry.
 func RouteRedistributeKey(vrf, protocol, af string) string {
 	return fmt.Sprintf("%s|%s|bgp|%s", vrf, protocol, af)
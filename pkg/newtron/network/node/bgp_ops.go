@@ -296,6 +296,78 @@ func (n *Node) RemoveBGPGlobals(ctx context.Context) (*ChangeSet, error) {
 	return cs, nil
 }
 
+// ============================================================================
+// BGP Multipath
+// ============================================================================
+
+// bgpMaxPathsResource is the intent key of the device-global BGP multipath width.
+const bgpMaxPathsResource = "bgp-max-paths"
+
+// SetBGPMaxPaths sets how many equal-cost BGP paths the default VRF installs
+// for one prefix — FRR 'maximum-paths' for eBGP and iBGP alike, written to
+// BGP_GLOBALS_AF|default|ipv4_unicast. paths must be within 1..256 and no
+// wider than the platform's max_ecmp_paths. Device-global: setting it again
+// replaces the previous width. The prior value is reported as the change's
+// from-row and logged.
+func (n *Node) SetBGPMaxPaths(ctx context.Context, paths int) (*ChangeSet, error) {
+	limit := sonic.MaxBGPMaxPaths
+	resolved := n.Resolved()
+	if resolved.Platform != "" {
+		if platform, err := n.GetPlatform(resolved.Platform); err == nil && platform.MaxECMPPaths > 0 {
+			limit = min(limit, platform.MaxECMPPaths)
+		}
+	}
+	pc := n.precondition(sonic.OpSetBGPMaxPaths, bgpMaxPathsResource).
+		Check(n.BGPConfigured(), "BGP configured", fmt.Sprintf("BGP is not configured on %s", n.name)).
+		Check(paths >= sonic.MinBGPMaxPaths && paths <= limit, "max-paths within platform limit",
+			fmt.Sprintf("max-paths %d out of range %d..%d", paths, sonic.MinBGPMaxPaths, limit))
+	if err := pc.Result(); err != nil {
+		return nil, err
+	}
+
+	current := n.Projection()["BGP_GLOBALS_AF"][BGPGlobalsAFKey("default", "ipv4_unicast")]
+	prior := current["max_ebgp_paths"]
+	if prior == "" {
+		prior = "default"
+	}
+
+	value := strconv.Itoa(paths)
+	cs := NewChangeSet(n.name, "device."+sonic.OpSetBGPMaxPaths)
+	cs.ReverseOp = "device." + sonic.OpClearBGPMaxPaths
+	cs.OperationParams = map[string]string{sonic.FieldMaxPaths: value}
+	if err := n.writeIntent(cs, sonic.OpSetBGPMaxPaths, bgpMaxPathsResource,
+		map[string]string{sonic.FieldMaxPaths: value}, []string{"device"}); err != nil {
+		return nil, err
+	}
+	cs.Replace(n, nil, bgpMaxPathsConfig(current, paths))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Set BGP maximum-paths %d (was %s)", paths, prior)
+	return cs, nil
+}
+
+// ClearBGPMaxPaths removes the multipath width, returning the default VRF to
+// FRR's compiled maximum-paths. Reverse of SetBGPMaxPaths (§15).
+func (n *Node) ClearBGPMaxPaths(ctx context.Context) (*ChangeSet, error) {
+	if err := n.precondition(sonic.OpClearBGPMaxPaths, bgpMaxPathsResource).Result(); err != nil {
+		return nil, err
+	}
+	if n.GetIntent(bgpMaxPathsResource) == nil {
+		return nil, fmt.Errorf("no BGP max-paths is set")
+	}
+	cs := NewChangeSet(n.name, "device."+sonic.OpClearBGPMaxPaths)
+	cs.Replace(n, nil, clearBGPMaxPathsConfig(n.Projection()["BGP_GLOBALS_AF"][BGPGlobalsAFKey("default", "ipv4_unicast")]))
+	if err := n.deleteIntent(cs, bgpMaxPathsResource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Cleared BGP maximum-paths")
+	return cs, nil
+}
+
 // ============================================================================
 // BGP Overlay (EVPN control plane)
 // ============================================================================
@@ -531,7 +603,7 @@ func generateBGPPeeringConfig(svc *spec.ServiceSpec, ipAddress string,
 			peerAS = optsPeerAS
 		}
 		if peerAS == 0 {
-			return nil, fmt.Errorf("service requires peer_as parameter")
+			return nil, fmt.Errorf("service requires peer_as parameter — pass peer_as, or set peer_as in the network's service_defaults")
 		}
 	} else if routing.PeerAS != "" {
 		fmt.Sscanf(routing.PeerAS, "%d", &peerAS)
@@ -547,11 +619,23 @@ func generateBGPPeeringConfig(svc *spec.ServiceSpec, ipAddress string,
 
 	localIP, _ := util.SplitIPMask(ipAddress)
 
-	return CreateBGPNeighborConfig(peerIP, peerAS, localIP, BGPNeighborOpts{
-		VRF:          vrfName,
-		ActivateIPv4: true,
-		RRClient:     params["route_reflector_client"] == "true",
-		NextHopSelf:  params["next_hop_self"] == "true",
-		PeerGroup:    peerGroup,
-	}), nil
+	// The session's address family follows the interface address.
+	opts := BGPNeighborOpts{VRF: vrfName, PeerGroup: peerGroup}
+	rrClient := params["route_reflector_client"] == "true"
+	nextHopSelf := params["next_hop_self"] == "true"
+	if util.IsValidIPv6CIDR(ipAddress) {
+		opts.ActivateIPv6, opts.RRClientIPv6, opts.NextHopSelfIPv6 = true, rrClient, nextHopSelf
+	} else {
+		opts.ActivateIPv4, opts.RRClient, opts.NextHopSelf = true, rrClient, nextHopSelf
+	}
+	return CreateBGPNeighborConfig(peerIP, peerAS, localIP, opts), nil
+}
+
+// routingHasPolicy reports whether a service's routing block carries anything
+// delivered on the IPv4 unicast address family beyond the session itself.
+func routingHasPolicy(r *spec.RoutingSpec) bool {
+	return r.ImportPolicy != "" || r.ExportPolicy != "" ||
+		r.ImportCommunity != "" || r.ExportCommunity != "" ||
+		r.ImportPrefixList != "" || r.ExportPrefixList != "" ||
+		r.Redistribute != nil
 }
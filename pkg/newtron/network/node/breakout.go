@@ -0,0 +1,75 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// ============================================================================
+// Breakout capabilities — which breakout modes each port accepts. Pure
+// observation (§4); the introspection primitive breakout validation checks a
+// requested mode against.
+//
+// Two sources, in order of authority. The platform spec names the modes a
+// port's hardware supports (platform.json breakout_modes, per port when the
+// inventory carries them, the platform-wide Breakouts list otherwise). On a
+// connected device CONFIG_DB BREAKOUT_CFG names the ports that are breakout
+// parents at all — a port absent from it cannot be broken out whatever the
+// platform says — and each row's brkout_mode, which is always reported even
+// when the platform data predates it.
+// ============================================================================
+
+// GetBreakoutCapabilities returns the supported breakout modes per port,
+// keyed by parent port name with each list sorted. Ports with no known mode
+// are omitted. Reads BREAKOUT_CFG only when transport is already up — offline
+// the platform data alone answers.
+func (n *Node) GetBreakoutCapabilities(ctx context.Context) (map[string][]string, error) {
+	resolved := n.Resolved()
+	if resolved == nil || resolved.Platform == "" {
+		return nil, fmt.Errorf("%s has no platform; breakout modes are unknown", n.name)
+	}
+	platform, err := n.GetPlatform(resolved.Platform)
+	if err != nil {
+		return nil, err
+	}
+	var breakoutCfg map[string]map[string]string
+	if n.conn != nil {
+		breakoutCfg, err = n.conn.Client().GetRawTable("BREAKOUT_CFG")
+		if err != nil {
+			return nil, fmt.Errorf("reading CONFIG_DB BREAKOUT_CFG: %w", err)
+		}
+	}
+	return buildBreakoutCapabilities(platform, breakoutCfg), nil
+}
+
+// buildBreakoutCapabilities merges platform modes with BREAKOUT_CFG rows.
+// An empty breakoutCfg means "not read" (offline, or a device image without
+// the table) and leaves every platform port in play.
+func buildBreakoutCapabilities(platform *spec.PlatformSpec, breakoutCfg map[string]map[string]string) map[string][]string {
+	out := map[string][]string{}
+	add := func(port string, modes ...string) {
+		for _, m := range modes {
+			if m != "" && !slices.Contains(out[port], m) {
+				out[port] = append(out[port], m)
+			}
+		}
+	}
+	for _, port := range platform.Ports {
+		if len(breakoutCfg) > 0 {
+			if _, ok := breakoutCfg[port.Name]; !ok {
+				continue
+			}
+		}
+		add(port.Name, platform.PortBreakouts(port.Name)...)
+	}
+	for port, row := range breakoutCfg {
+		add(port, row["brkout_mode"])
+	}
+	for port := range out {
+		slices.Sort(out[port])
+	}
+	return out
+}
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func TestBuildBreakoutCapabilities(t *testing.T) {
+	platform := &spec.PlatformSpec{
+		Breakouts: []string{"1x100G", "4x25G"},
+		Ports: []spec.PortSpec{
+			{Name: "Ethernet0", Breakouts: []string{"4x100G[50G]", "1x400G", "2x200G"}},
+			{Name: "Ethernet8"},
+			{Name: "Ethernet16"},
+		},
+	}
+
+	offline := buildBreakoutCapabilities(platform, nil)
+	want := map[string][]string{
+		"Ethernet0":  {"1x400G", "2x200G", "4x100G[50G]"},
+		"Ethernet8":  {"1x100G", "4x25G"},
+		"Ethernet16": {"1x100G", "4x25G"},
+	}
+	if !reflect.DeepEqual(offline, want) {
+		t.Errorf("offline = %v, want %v", offline, want)
+	}
+
+	// BREAKOUT_CFG restricts to its parent ports and always reports the
+	// configured mode, even one the platform data does not list.
+	online := buildBreakoutCapabilities(platform, map[string]map[string]string{
+		"Ethernet0": {"brkout_mode": "1x400G"},
+		"Ethernet8": {"brkout_mode": "1x50G(2)+2x25G(2)"},
+	})
+	want = map[string][]string{
+		"Ethernet0": {"1x400G", "2x200G", "4x100G[50G]"},
+		"Ethernet8": {"1x100G", "1x50G(2)+2x25G(2)", "4x25G"},
+	}
+	if !reflect.DeepEqual(online, want) {
+		t.Errorf("online = %v, want %v", online, want)
+	}
+}
+
+func TestGetBreakoutCapabilities_Offline(t *testing.T) {
+	d := testDevice()
+	ctx := context.Background()
+	if _, err := d.GetBreakoutCapabilities(ctx); err == nil || !strings.Contains(err.Error(), "no platform") {
+		t.Errorf("without platform: err = %v, want no-platform error", err)
+	}
+
+	d.resolved.Platform = "vs"
+	d.SpecProvider.(*testSpecProvider).platforms["vs"] = &spec.PlatformSpec{
+		Breakouts: []string{"1x100G"},
+		Ports:     []spec.PortSpec{{Name: "Ethernet0"}},
+	}
+	got, err := d.GetBreakoutCapabilities(ctx)
+	if err != nil {
+		t.Fatalf("GetBreakoutCapabilities: %v", err)
+	}
+	if want := map[string][]string{"Ethernet0": {"1x100G"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -1,8 +1,10 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -79,6 +81,15 @@ type ChangeSet struct {
 	// sets ReverseOp="device.delete-vlan"). Empty for terminal/reverse
 	// operations that have nothing to undo.
 	ReverseOp string `json:"reverse_op,omitempty"`
+
+	// Defaults lists the parameters the operation took from the network's
+	// service_defaults rather than the caller (apply-service only). Surfaced
+	// on WriteResult so a caller can see which values it did not choose.
+	Defaults []ParamDefault `json:"defaults,omitempty"`
+
+	// journal holds the pre-images Apply read, for Inverse. nil before
+	// Apply, and after a cancelled apply has rolled itself back.
+	journal *applyJournal
 }
 
 // NewChangeSet creates a new ChangeSet.
@@ -93,11 +104,16 @@ func NewChangeSet(device, operation string) *ChangeSet {
 
 // add appends a change of any type (internal use by buildChangeSet, op).
 func (cs *ChangeSet) add(table, key string, changeType sonic.ChangeType, fields map[string]string) {
+	cs.addWithComment(table, key, changeType, "", fields)
+}
+
+func (cs *ChangeSet) addWithComment(table, key string, changeType sonic.ChangeType, comment string, fields map[string]string) {
 	cs.Changes = append(cs.Changes, Change{
-		Table:  table,
-		Key:    key,
-		Type:   changeType,
-		Fields: fields,
+		Table:   table,
+		Key:     key,
+		Type:    changeType,
+		Fields:  fields,
+		Comment: comment,
 	})
 }
 
@@ -106,11 +122,22 @@ func (cs *ChangeSet) Add(table, key string, fields map[string]string) {
 	cs.add(table, key, ChangeAdd, fields)
 }
 
+// AddWithComment creates a new entry annotated with what it is for
+// (Change.Comment), shown in previews and audit logs.
+func (cs *ChangeSet) AddWithComment(table, key, comment string, fields map[string]string) {
+	cs.addWithComment(table, key, ChangeAdd, comment, fields)
+}
+
 // Update modifies an existing entry.
 func (cs *ChangeSet) Update(table, key string, fields map[string]string) {
 	cs.add(table, key, ChangeModify, fields)
 }
 
+// UpdateWithComment modifies an existing entry, annotated like AddWithComment.
+func (cs *ChangeSet) UpdateWithComment(table, key, comment string, fields map[string]string) {
+	cs.addWithComment(table, key, ChangeModify, comment, fields)
+}
+
 // Delete removes an entry.
 func (cs *ChangeSet) Delete(table, key string) {
 	cs.add(table, key, ChangeDelete, nil)
@@ -123,6 +150,13 @@ func (cs *ChangeSet) Adds(entries []sonic.Entry) {
 	}
 }
 
+// AddsWithComment is Adds with every entry annotated by comment.
+func (cs *ChangeSet) AddsWithComment(comment string, entries []sonic.Entry) {
+	for _, e := range entries {
+		cs.AddWithComment(e.Table, e.Key, comment, e.Fields)
+	}
+}
+
 // Updates bridges config function output ([]sonic.Entry) for batch modifies.
 func (cs *ChangeSet) Updates(entries []sonic.Entry) {
 	for _, e := range entries {
@@ -204,6 +238,57 @@ func removedFields(from, fields map[string]string) []string {
 	return removed
 }
 
+// dropUnchanged prunes the changes d — the ChangeSet's Diff — finds change
+// nothing: every no-op, and every change to a key whose changes together
+// leave it as it was, such as the DEL+SET pair writeIntent emits when
+// re-recording identical params. A key with any real difference keeps the
+// rest of its changes, in order — partial pruning could break a DEL+SET pair.
+//
+// This is Replace's unchanged-row skip for composites whose sub-operations
+// build with Adds: re-running one against a device that already holds its
+// config delivers nothing, instead of re-writing every row and waking every
+// daemon that subscribes to them.
+func (cs *ChangeSet) dropUnchanged(d *ChangeSetDiff) {
+	before := make(map[string]map[string]string) // "table|key" → row before its first change; nil = absent
+	after := make(map[string]map[string]string)  // "table|key" → row after its last change; nil = absent
+	for _, e := range d.Entries {
+		id := e.Change.Table + "|" + e.Change.Key
+		if _, seen := before[id]; !seen {
+			before[id] = e.Live
+		}
+		after[id] = e.After
+	}
+
+	kept := cs.Changes[:0]
+	for _, e := range d.Entries {
+		id := e.Change.Table + "|" + e.Change.Key
+		if e.Class == DiffNoOp || sameRow(before[id], after[id]) {
+			continue
+		}
+		kept = append(kept, e.Change)
+	}
+	cs.Changes = kept
+}
+
+// sameRow reports whether two rows are equal, an absent row (nil) differing
+// from a present one with no fields.
+func sameRow(a, b map[string]string) bool {
+	return (a == nil) == (b == nil) && maps.Equal(a, b)
+}
+
+// rawReader reads rows from raw the way Redis would — through a
+// sonic.FileConfigDB, so a field-less row holds the "NULL" sentinel — for
+// diffing a ChangeSet against the projection offline.
+func rawReader(raw sonic.RawConfigDB) configDBReader {
+	f := sonic.NewFileConfigDB("")
+	for table, rows := range raw {
+		for key, fields := range rows {
+			f.SetWithReply(table, key, fields)
+		}
+	}
+	return f
+}
+
 // Prepend inserts a change at the beginning of the ChangeSet.
 // Used by writeIntent to ensure the intent entry is always first,
 // regardless of when intent recording is called.
@@ -217,6 +302,123 @@ func (cs *ChangeSet) Merge(other *ChangeSet) {
 	cs.Changes = append(cs.Changes, other.Changes...)
 }
 
+// MergeChecked appends other's changes to cs like Merge, but checks each one
+// against the last change cs already holds for the same key — for sequential
+// operations on one entity, where a later ChangeSet re-writes what an earlier
+// one removed (RefreshService's remove + re-apply):
+//
+//   - a delete followed by an add of exactly the row the delete removed
+//     (the From render recorded; none for a row without fields) nets to
+//     nothing: both are dropped, and the key is never DELeted, so no daemon
+//     sees it vanish and come back. Only CONFIG_DB tables qualify — render
+//     records no From for NEWTRON_INTENT (fromUndoable). The collapse is per
+//     entity (table and first key segment): if any row of it is re-added
+//     with different fields, all of its rows keep their delete+add, so the
+//     daemon still observes the teardown it rebuilds from (§48) — an
+//     unchanged IP row must not outlive its interface's VRF rebind;
+//   - two modifies that set the same field to different values conflict —
+//     the result would depend on write order, so MergeChecked returns an
+//     error naming the key and field, leaving cs unchanged.
+//
+// Every other change is appended in order, so changes to distinct keys keep
+// their relative order. Composites whose sub-operations extend one shared
+// row (foldUpdates) use Merge.
+func (cs *ChangeSet) MergeChecked(other *ChangeSet) error {
+	merged := slices.Clone(cs.Changes)
+	last := make(map[string]int) // "table|key" → index in merged of its last change
+	for i, c := range merged {
+		last[c.Table+"|"+c.Key] = i
+	}
+	restored := make(map[int]int) // index of a delete → index of the add restoring its row
+	torn := make(map[string]bool) // entities with a row re-added with different fields
+	for _, c := range other.Changes {
+		id := c.Table + "|" + c.Key
+		if at, ok := last[id]; ok {
+			prev := merged[at]
+			switch {
+			case prev.Type == ChangeDelete && c.Type == ChangeAdd:
+				if fromUndoable(c.Table) && maps.Equal(prev.From, c.Fields) {
+					restored[at] = len(merged)
+				} else {
+					torn[changeEntity(c)] = true
+				}
+			case prev.Type == ChangeModify && c.Type == ChangeModify:
+				for field, v := range c.Fields {
+					if was, set := prev.Fields[field]; set && was != v {
+						return fmt.Errorf("conflicting modifies of %s: %s set to %q, then %q", id, field, was, v)
+					}
+				}
+			}
+		}
+		last[id] = len(merged)
+		merged = append(merged, c)
+	}
+	dropped := make(map[int]bool)
+	for del, add := range restored {
+		if !torn[changeEntity(merged[del])] {
+			dropped[del], dropped[add] = true, true
+		}
+	}
+	kept := merged[:0]
+	for i, c := range merged {
+		if !dropped[i] {
+			kept = append(kept, c)
+		}
+	}
+	cs.Changes = kept
+	return nil
+}
+
+// changeEntity names the entity a change's row belongs to: its table and the
+// first segment of its key — INTERFACE|Ethernet0 for both the interface row
+// and its INTERFACE|Ethernet0|10.1.1.1/31 address.
+func changeEntity(c Change) string {
+	first, _, _ := strings.Cut(c.Key, "|")
+	return c.Table + "|" + first
+}
+
+// foldUpdates folds each field update into the add or update of the same key
+// before it, when no delete or replace of the key comes between — the HSETs
+// merge, so the key ends the same, written once. A composite whose
+// sub-operations each extend one shared row (an ACL's ports, an intent's
+// _children) then writes the row once, with its final fields, at the
+// position of its first write.
+func (cs *ChangeSet) foldUpdates() {
+	open := make(map[string]int) // "table|key" → index in kept of the write still open to folding
+	kept := cs.Changes[:0]
+	for _, c := range cs.Changes {
+		id := c.Table + "|" + c.Key
+		switch c.Type {
+		case ChangeModify:
+			if at, ok := open[id]; ok {
+				fields := maps.Clone(kept[at].Fields)
+				maps.Copy(fields, c.Fields)
+				kept[at].Fields = fields
+				continue
+			}
+			open[id] = len(kept)
+		case ChangeAdd:
+			open[id] = len(kept)
+		default:
+			delete(open, id)
+		}
+		kept = append(kept, c)
+	}
+	cs.Changes = kept
+}
+
+// Annotate sets comment on every change that has none yet and returns cs —
+// for a sub-operation's ChangeSet about to be merged into a composite:
+// cs.Merge(vrfCS.Annotate("VRF for service customer-l3")).
+func (cs *ChangeSet) Annotate(comment string) *ChangeSet {
+	for i := range cs.Changes {
+		if cs.Changes[i].Comment == "" {
+			cs.Changes[i].Comment = comment
+		}
+	}
+	return cs
+}
+
 // IsEmpty returns true if there are no changes.
 func (cs *ChangeSet) IsEmpty() bool {
 	return len(cs.Changes) == 0
@@ -298,6 +500,9 @@ func (cs *ChangeSet) String() string {
 		if c.Fields != nil && len(c.Fields) > 0 {
 			sb.WriteString(fmt.Sprintf(" → %v", c.Fields))
 		}
+		if c.Comment != "" {
+			sb.WriteString("  # " + c.Comment)
+		}
 		sb.WriteString("\n")
 	}
 
@@ -319,8 +524,40 @@ func (cs *ChangeSet) validate() error {
 	return sonic.ValidateChanges(cs.Changes)
 }
 
+// ConfigBackend is where a ChangeSet is delivered: the write surface ApplyTo
+// needs, plus the Get it uses to journal pre-images. Replies follow Redis
+// (HSET: fields added; HDEL: fields removed; DEL: keys removed) and land in
+// DeviceOp.DeviceResponse.
+//
+// *sonic.ConfigDBClient — the device's Redis CONFIG_DB — is the backend Apply
+// uses. *sonic.FileConfigDB delivers to a config_db.json file instead, for
+// offline rendering; tests inject an in-memory fake.
+type ConfigBackend interface {
+	Get(table, key string) (map[string]string, error)
+	SetWithReply(table, key string, fields map[string]string) (int64, error)
+	HDelWithReply(table, key string, fields []string) (int64, error)
+	DeleteWithReply(table, key string) (int64, error)
+}
+
+var (
+	_ ConfigBackend = (*sonic.ConfigDBClient)(nil)
+	_ ConfigBackend = (*sonic.FileConfigDB)(nil)
+)
+
+// ApplyOpts tunes ApplyWithOpts. The zero value behaves exactly like Apply.
+type ApplyOpts struct {
+	// Ctx, when cancellable, is checked before every write. On cancellation
+	// the writes already made are rolled back and the context error is
+	// returned.
+	Ctx context.Context
+}
+
 // Apply writes the changes to the device's config_db via Redis.
 //
+// Before the first write to each key, Apply reads the key's pre-image (one
+// HGETALL per distinct key) and keeps it on the ChangeSet, so Inverse can
+// undo the apply — all of it, or as much as was written before a failure.
+//
 // Each change becomes one Device I/O Operation (HSET or DEL) and one
 // corresponding DeviceOp record on cs.DeviceOps — substrate-grade
 // per-operation outcome captured at the moment of execution (§46). On
@@ -328,6 +565,12 @@ func (cs *ChangeSet) validate() error {
 // verbatim error in DeviceResponse, then Apply returns the wrapped error
 // without attempting subsequent writes.
 func (cs *ChangeSet) Apply(n *Node) error {
+	return cs.ApplyWithOpts(n, ApplyOpts{})
+}
+
+// ApplyWithOpts is Apply with cancellation. See
+// ApplyOpts.
+func (cs *ChangeSet) ApplyWithOpts(n *Node, opts ApplyOpts) error {
 	// Transport guard — entries were already rendered into the projection
 	// by render(cs) in op(). Without transport, skip Redis delivery.
 	if n.conn == nil {
@@ -346,9 +589,37 @@ func (cs *ChangeSet) Apply(n *Node) error {
 	if client == nil {
 		return fmt.Errorf("CONFIG_DB client not connected")
 	}
+	return cs.ApplyTo(client, opts)
+}
+
+// ApplyTo delivers the changes to backend — the apply logic behind Apply,
+// against any ConfigBackend. It performs no precondition or transport check;
+// those belong to the device path (ApplyWithOpts).
+func (cs *ChangeSet) ApplyTo(client ConfigBackend, opts ApplyOpts) error {
+	// The journal of pre-images serves both the in-apply rollback on
+	// cancellation and Inverse afterwards.
+	journal := &applyJournal{seen: map[string]bool{}}
+	cs.journal = journal
+	cancellable := opts.Ctx != nil && opts.Ctx.Done() != nil
 
 	seq := len(cs.DeviceOps)
-	for _, change := range cs.Changes {
+	for i, change := range cs.Changes {
+		if cancellable {
+			if err := opts.Ctx.Err(); err != nil {
+				rbErr := cs.rollback(client)
+				if rbErr != nil {
+					return fmt.Errorf("apply cancelled after %d of %d changes: %w (rollback failed: %v)", i, len(cs.Changes), err, rbErr)
+				}
+				cs.journal = nil // nothing left to invert
+				return fmt.Errorf("apply cancelled after %d of %d changes, rolled back: %w", i, len(cs.Changes), err)
+			}
+		}
+		if err := journal.record(client, change.Table, change.Key); err != nil {
+			cs.AppliedCount = i
+			return fmt.Errorf("reading pre-image of %s|%s: %w", change.Table, change.Key, err)
+		}
+		journal.attempted = i + 1
+
 		var err error
 		var kind string
 		var reply int64
@@ -383,6 +654,7 @@ func (cs *ChangeSet) Apply(n *Node) error {
 			op.Result = sonic.DeviceOpsResultRejected
 			op.DeviceResponse = err.Error()
 			cs.DeviceOps = append(cs.DeviceOps, op)
+			cs.AppliedCount = i
 			return fmt.Errorf("applying change to %s|%s: %w", change.Table, change.Key, err)
 		}
 		op.Result = sonic.DeviceOpsResultApplied
@@ -395,6 +667,55 @@ func (cs *ChangeSet) Apply(n *Node) error {
 	return nil
 }
 
+// applyJournal remembers the CONFIG_DB content of each key as it was before
+// the apply first touched it, in first-touch order.
+type applyJournal struct {
+	seen    map[string]bool
+	entries []journalEntry
+
+	// attempted counts the changes whose write was started, including one
+	// that failed part-way (a replace whose HSET landed but HDEL did not).
+	attempted int
+}
+
+type journalEntry struct {
+	table, key string
+	prior      map[string]string // empty when the key did not exist
+}
+
+// record reads and keeps the pre-image of table|key unless already kept.
+// Only the first touch matters: later changes to the same key must roll back
+// to the state before the whole apply, not to an intermediate one.
+func (j *applyJournal) record(client ConfigBackend, table, key string) error {
+	id := table + "|" + key
+	if j.seen[id] {
+		return nil
+	}
+	prior, err := client.Get(table, key)
+	if err != nil {
+		return err
+	}
+	j.seen[id] = true
+	j.entries = append(j.entries, journalEntry{table: table, key: key, prior: prior})
+	return nil
+}
+
+// rollback undoes a cancelled apply by applying its Inverse — the same undo
+// Commit uses for a failed bundle — and appends the restoring writes to
+// cs.DeviceOps.
+func (cs *ChangeSet) rollback(client ConfigBackend) error {
+	inv, err := cs.Inverse()
+	if err != nil {
+		return err
+	}
+	err = inv.ApplyTo(client, ApplyOpts{})
+	for _, op := range inv.DeviceOps {
+		op.Seq = len(cs.DeviceOps)
+		cs.DeviceOps = append(cs.DeviceOps, op)
+	}
+	return err
+}
+
 // Verify re-reads CONFIG_DB via a fresh connection and compares against the
 // ChangeSet to confirm that writes were persisted. Stores the result in
 // cs.Verification and appends one verify_read DeviceOp per change to
@@ -0,0 +1,157 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// fakeConfigDBWriter is an in-memory CONFIG_DB; nothing talks to Redis.
+// onSet, if set, runs after every SetWithReply — tests use it to cancel a
+// context mid-apply. A SetWithReply to failOn ("TABLE|KEY") is rejected.
+type fakeConfigDBWriter struct {
+	data   map[string]map[string]string // keyed by "TABLE|KEY"
+	gets   int
+	onSet  func()
+	failOn string
+}
+
+func (f *fakeConfigDBWriter) Get(table, key string) (map[string]string, error) {
+	f.gets++
+	return maps.Clone(f.data[table+"|"+key]), nil
+}
+
+func (f *fakeConfigDBWriter) SetWithReply(table, key string, fields map[string]string) (int64, error) {
+	id := table + "|" + key
+	if id == f.failOn {
+		return 0, errors.New("rejected")
+	}
+	if f.data[id] == nil {
+		f.data[id] = map[string]string{}
+	}
+	maps.Copy(f.data[id], fields)
+	if f.onSet != nil {
+		f.onSet()
+	}
+	return int64(len(fields)), nil
+}
+
+func (f *fakeConfigDBWriter) HDelWithReply(table, key string, fields []string) (int64, error) {
+	for _, name := range fields {
+		delete(f.data[table+"|"+key], name)
+	}
+	return int64(len(fields)), nil
+}
+
+func (f *fakeConfigDBWriter) DeleteWithReply(table, key string) (int64, error) {
+	delete(f.data, table+"|"+key)
+	return 1, nil
+}
+
+func TestApplyTo_JournalsEachKeyOnce(t *testing.T) {
+	w := &fakeConfigDBWriter{data: map[string]map[string]string{}}
+	cs := &ChangeSet{Changes: []Change{
+		{Table: "VLAN", Key: "Vlan100", Type: sonic.ChangeTypeAdd, Fields: map[string]string{"vlanid": "100"}},
+		{Table: "VLAN", Key: "Vlan200", Type: sonic.ChangeTypeAdd, Fields: map[string]string{"vlanid": "200"}},
+		{Table: "VLAN", Key: "Vlan200", Type: sonic.ChangeTypeModify, Fields: map[string]string{"mtu": "9100"}},
+	}}
+
+	if err := cs.ApplyTo(w, ApplyOpts{}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if w.gets != 2 {
+		t.Errorf("apply read %d pre-images, want one per key (2)", w.gets)
+	}
+	if cs.AppliedCount != 3 {
+		t.Errorf("AppliedCount = %d, want 3", cs.AppliedCount)
+	}
+}
+
+// TestApplyTo_CancelRollsBack cancels after the second write: both
+// writes are undone — the new key deleted, the modified key restored to its
+// pre-image including the field the apply added — and the third never runs.
+func TestApplyTo_CancelRollsBack(t *testing.T) {
+	w := &fakeConfigDBWriter{data: map[string]map[string]string{
+		"PORT|Ethernet0": {"admin_status": "down", "mtu": "9100"},
+	}}
+	before := map[string]map[string]string{"PORT|Ethernet0": maps.Clone(w.data["PORT|Ethernet0"])}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sets := 0
+	w.onSet = func() {
+		if sets++; sets == 2 {
+			cancel()
+		}
+	}
+
+	cs := &ChangeSet{Changes: []Change{
+		{Table: "VLAN", Key: "Vlan100", Type: sonic.ChangeTypeAdd, Fields: map[string]string{"vlanid": "100"}},
+		{Table: "PORT", Key: "Ethernet0", Type: sonic.ChangeTypeModify, Fields: map[string]string{"admin_status": "up", "description": "uplink"}},
+		{Table: "VLAN", Key: "Vlan200", Type: sonic.ChangeTypeAdd, Fields: map[string]string{"vlanid": "200"}},
+	}}
+
+	err := cs.ApplyTo(w, ApplyOpts{Ctx: ctx})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	if !reflect.DeepEqual(w.data, before) {
+		t.Errorf("after rollback data = %v, want %v", w.data, before)
+	}
+	if cs.AppliedCount != 0 {
+		t.Errorf("AppliedCount = %d, want 0 after cancel", cs.AppliedCount)
+	}
+	// Two forward writes, then two restoring writes, newest first.
+	if len(cs.DeviceOps) != 4 {
+		t.Fatalf("DeviceOps = %d, want 4: %+v", len(cs.DeviceOps), cs.DeviceOps)
+	}
+	if op := cs.DeviceOps[2]; op.Key != "Ethernet0" || op.Kind != sonic.DeviceOpsKindRedisWrite {
+		t.Errorf("first rollback op = %+v, want redis_write of Ethernet0", op)
+	}
+	if op := cs.DeviceOps[3]; op.Key != "Vlan100" || op.Kind != sonic.DeviceOpsKindRedisDelete {
+		t.Errorf("second rollback op = %+v, want redis_delete of Vlan100", op)
+	}
+}
+
+// TestApplyTo_FileBackend delivers a ChangeSet to a config_db.json file
+// instead of Redis — offline rendering — and reads the result back.
+func TestApplyTo_FileBackend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config_db.json")
+	f, err := sonic.OpenFileConfigDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetWithReply("BGP_GLOBALS", "default", map[string]string{"local_asn": "65000", "router_id": "10.0.0.1"})
+
+	cs := &ChangeSet{Changes: []Change{
+		{Table: "VLAN", Key: "Vlan100", Type: sonic.ChangeTypeAdd, Fields: map[string]string{"vlanid": "100"}},
+		{Table: "BGP_GLOBALS", Key: "default", Type: sonic.ChangeTypeReplace,
+			Fields: map[string]string{"local_asn": "65001"}, From: map[string]string{"local_asn": "65000", "router_id": "10.0.0.1"}},
+	}}
+	if err := cs.ApplyTo(f, ApplyOpts{}); err != nil {
+		t.Fatalf("ApplyTo: %v", err)
+	}
+	if err := f.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	g, err := sonic.OpenFileConfigDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := sonic.RawConfigDB{
+		"VLAN":        {"Vlan100": {"vlanid": "100"}},
+		"BGP_GLOBALS": {"default": {"local_asn": "65001"}},
+	}
+	if !reflect.DeepEqual(g.Raw(), want) {
+		t.Errorf("file holds %v, want %v", g.Raw(), want)
+	}
+	if op := cs.DeviceOps[0]; op.Result != sonic.DeviceOpsResultApplied || op.DeviceResponse != "(integer) 1" {
+		t.Errorf("DeviceOps[0] = %+v, want applied with HSET reply 1", op)
+	}
+}
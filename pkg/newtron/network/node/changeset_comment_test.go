@@ -0,0 +1,78 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func TestChangeSet_Comments(t *testing.T) {
+	cs := NewChangeSet("leaf1", "test")
+	cs.Add("VLAN", "Vlan100", map[string]string{"vlanid": "100"})
+	cs.AddWithComment("ACL_TABLE", "svc-in", "ingress ACL for customer-l3", map[string]string{"stage": "ingress"})
+
+	sub := NewChangeSet("leaf1", "sub")
+	sub.Add("VRF", "Vrf_CUST", nil)
+	sub.AddWithComment("VRF", "Vrf_OTHER", "kept", nil)
+	cs.Merge(sub.Annotate("VRF for service customer-l3"))
+
+	want := []string{"", "ingress ACL for customer-l3", "VRF for service customer-l3", "kept"}
+	for i, c := range cs.Changes {
+		if c.Comment != want[i] {
+			t.Errorf("%s|%s comment = %q, want %q", c.Table, c.Key, c.Comment, want[i])
+		}
+	}
+
+	preview := cs.String()
+	if !strings.Contains(preview, "[ADD] ACL_TABLE|svc-in → map[stage:ingress]  # ingress ACL for customer-l3\n") {
+		t.Errorf("preview does not show the comment:\n%s", preview)
+	}
+	if !strings.Contains(preview, "[ADD] VLAN|Vlan100 → map[vlanid:100]\n") {
+		t.Errorf("uncommented change rendered differently:\n%s", preview)
+	}
+}
+
+// TestApplyService_AnnotatesChanges: every CONFIG_DB change a service apply
+// generates for its ACL says what it is for.
+func TestApplyService_AnnotatesChanges(t *testing.T) {
+	n := newTestAbstract()
+	sp := n.SpecProvider.(*testSpecProvider)
+	sp.filterSpecs["mgmt-in"] = &spec.FilterSpec{
+		Type:  "ipv4",
+		Rules: []*spec.FilterRule{{Sequence: 10, SrcIP: "10.0.0.0/8", Action: "permit"}},
+	}
+	sp.services["ACLSVC"] = &spec.ServiceSpec{ServiceType: "routed", IngressFilter: "mgmt-in"}
+
+	iface, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+	cs, err := iface.ApplyService(context.Background(), "ACLSVC", ApplyServiceOpts{IPAddress: "10.1.0.1/31"})
+	if err != nil {
+		t.Fatalf("ApplyService: %v", err)
+	}
+
+	want := map[string]string{
+		"ACL_TABLE": "ingress ACL for ACLSVC",
+		"ACL_RULE":  "ingress ACL for ACLSVC (filter mgmt-in)",
+		"INTERFACE": "routed port for service ACLSVC",
+	}
+	seen := map[string]bool{}
+	for _, c := range cs.Changes {
+		w, ok := want[c.Table]
+		if !ok {
+			continue
+		}
+		seen[c.Table] = true
+		if c.Comment != w {
+			t.Errorf("%s|%s comment = %q, want %q", c.Table, c.Key, c.Comment, w)
+		}
+	}
+	for table := range want {
+		if !seen[table] {
+			t.Errorf("no %s change generated", table)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package node
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// DiffClass says what one change would do to the live CONFIG_DB.
+type DiffClass string
+
+const (
+	DiffCreate   DiffClass = "create"   // the key is absent; the change adds it
+	DiffModify   DiffClass = "modify"   // the key exists; the change alters fields
+	DiffDelete   DiffClass = "delete"   // the key exists; the change removes it
+	DiffNoOp     DiffClass = "no-op"    // the key already is what the change makes it
+	DiffConflict DiffClass = "conflict" // the key is not what the change expected (From) to replace
+)
+
+// ChangeDiff classifies one change of a ChangeSet.
+type ChangeDiff struct {
+	Change Change
+	Class  DiffClass
+	Live   map[string]string // the key's fields just before this change; nil when absent
+	After  map[string]string // the key's fields just after this change; nil when absent
+}
+
+// DiffCounts counts a table's changes by class.
+type DiffCounts struct {
+	Create   int
+	Modify   int
+	Delete   int
+	NoOp     int
+	Conflict int
+}
+
+func (c *DiffCounts) count(class DiffClass) {
+	switch class {
+	case DiffCreate:
+		c.Create++
+	case DiffModify:
+		c.Modify++
+	case DiffDelete:
+		c.Delete++
+	case DiffNoOp:
+		c.NoOp++
+	case DiffConflict:
+		c.Conflict++
+	}
+}
+
+// ChangeSetDiff is a ChangeSet compared with the live CONFIG_DB: every change
+// classified, in ChangeSet order, with per-table counts and the no-op changes
+// pulled out so a caller can prune them.
+type ChangeSetDiff struct {
+	Entries []ChangeDiff
+	Tables  map[string]*DiffCounts
+	NoOps   []Change
+}
+
+// Diff reads the live CONFIG_DB entry behind every change and classifies what
+// applying cs would do, without writing anything. Unlike the projection, the
+// live read sees what the device actually holds — including edits made
+// outside newtron.
+//
+// Changes are judged in order against the live rows as the earlier changes
+// would leave them, so a DEL+SET pair on one key reads as the delete and the
+// create it is. A replace whose From (the row it expected to replace) no
+// longer matches the live row is a conflict: someone changed the row since the
+// ChangeSet was built.
+func (cs *ChangeSet) Diff(n *Node) (*ChangeSetDiff, error) {
+	if n.conn == nil {
+		return nil, util.ErrNotConnected
+	}
+	client := n.ConfigDBClient()
+	if client == nil {
+		return nil, fmt.Errorf("CONFIG_DB client not connected")
+	}
+	return diffWithReader(client, cs.Changes)
+}
+
+// diffWithReader holds the diff logic against any configDBReader, so tests
+// can inject a fake. A field-less row reads as present with no fields: the
+// "NULL" sentinel Redis stores it with is not a field the change could set.
+func diffWithReader(reader configDBReader, changes []Change) (*ChangeSetDiff, error) {
+	d := &ChangeSetDiff{
+		Entries: make([]ChangeDiff, 0, len(changes)),
+		Tables:  make(map[string]*DiffCounts),
+	}
+	state := make(map[string]map[string]string) // "table|key" → row as the changes so far leave it; nil = absent
+	for _, c := range changes {
+		id := c.Table + "|" + c.Key
+		cur, read := state[id]
+		if !read {
+			live, err := reader.Get(c.Table, c.Key)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", id, err)
+			}
+			if len(live) > 0 {
+				if live["NULL"] == "NULL" {
+					delete(live, "NULL")
+				}
+				cur = live
+			}
+		}
+
+		class, next := classifyChange(c, cur)
+		state[id] = next
+
+		d.Entries = append(d.Entries, ChangeDiff{Change: c, Class: class, Live: maps.Clone(cur), After: maps.Clone(next)})
+		counts := d.Tables[c.Table]
+		if counts == nil {
+			counts = &DiffCounts{}
+			d.Tables[c.Table] = counts
+		}
+		counts.count(class)
+		if class == DiffNoOp {
+			d.NoOps = append(d.NoOps, c)
+		}
+	}
+	return d, nil
+}
+
+// classifyChange judges c against cur (nil when the key is absent) and
+// returns the row c leaves behind.
+func classifyChange(c Change, cur map[string]string) (DiffClass, map[string]string) {
+	switch c.Type {
+	case ChangeDelete:
+		if cur == nil {
+			return DiffNoOp, nil
+		}
+		return DiffDelete, nil
+	case ChangeReplace:
+		next := maps.Clone(c.Fields)
+		switch {
+		case c.From != nil && !maps.Equal(cur, c.From):
+			return DiffConflict, next
+		case cur == nil:
+			return DiffCreate, next
+		case maps.Equal(cur, c.Fields):
+			return DiffNoOp, next
+		}
+		return DiffModify, next
+	}
+	// Add and modify are HSETs: fields merge into the row.
+	if cur == nil {
+		return DiffCreate, maps.Clone(c.Fields)
+	}
+	next := maps.Clone(cur)
+	maps.Copy(next, c.Fields)
+	if maps.Equal(cur, next) {
+		return DiffNoOp, next
+	}
+	return DiffModify, next
+}
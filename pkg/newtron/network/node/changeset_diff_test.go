@@ -0,0 +1,127 @@
+package node
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+func TestDiffWithReader(t *testing.T) {
+	reader := newFakeReader(map[string]map[string]string{
+		"VLAN|Vlan100":             {"vlanid": "100"},
+		"VLAN|Vlan200":             {"vlanid": "200", "description": "old"},
+		"VRF|Vrf_a":                {"vni": "1000"},
+		"ROUTE_MAP|RM|10":          {"route_operation": "permit"},
+		"BGP_GLOBALS|default":      {"local_asn": "65001"},
+		"VLAN_MEMBER|Vlan100|Eth0": {"tagging_mode": "untagged"},
+	})
+	changes := []Change{
+		// matches
+		{Table: "VLAN", Key: "Vlan100", Type: ChangeAdd, Fields: map[string]string{"vlanid": "100"}},
+		// differs
+		{Table: "VLAN", Key: "Vlan200", Type: ChangeModify, Fields: map[string]string{"description": "new"}},
+		// absent
+		{Table: "VLAN", Key: "Vlan300", Type: ChangeAdd, Fields: map[string]string{"vlanid": "300"}},
+		// already gone
+		{Table: "VRF", Key: "Vrf_b", Type: ChangeDelete},
+		// present
+		{Table: "VRF", Key: "Vrf_a", Type: ChangeDelete},
+		// same row
+		{Table: "ROUTE_MAP", Key: "RM|10", Type: ChangeReplace, Fields: map[string]string{"route_operation": "permit"}},
+		// edited since
+		{Table: "BGP_GLOBALS", Key: "default", Type: ChangeReplace, Fields: map[string]string{"local_asn": "65002"}, From: map[string]string{"local_asn": "65000"}},
+		// DEL+SET pair
+		{Table: "VLAN_MEMBER", Key: "Vlan100|Eth0", Type: ChangeDelete},
+		{Table: "VLAN_MEMBER", Key: "Vlan100|Eth0", Type: ChangeAdd, Fields: map[string]string{"tagging_mode": "tagged"}},
+	}
+	d, err := diffWithReader(reader, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []DiffClass{DiffNoOp, DiffModify, DiffCreate, DiffNoOp, DiffDelete, DiffNoOp, DiffConflict, DiffDelete, DiffCreate}
+	if len(d.Entries) != len(want) {
+		t.Fatalf("len(Entries) = %d, want %d", len(d.Entries), len(want))
+	}
+	for i, e := range d.Entries {
+		if e.Class != want[i] {
+			t.Errorf("Entries[%d] %s|%s = %s, want %s", i, e.Change.Table, e.Change.Key, e.Class, want[i])
+		}
+	}
+	if live := d.Entries[1].Live; live["description"] != "old" {
+		t.Errorf("Entries[1].Live = %v, want the pre-change row", live)
+	}
+	if d.Entries[2].Live != nil || d.Entries[8].Live != nil {
+		t.Errorf("absent keys should have nil Live: %v, %v", d.Entries[2].Live, d.Entries[8].Live)
+	}
+
+	wantCounts := map[string]DiffCounts{
+		"VLAN":        {Create: 1, Modify: 1, NoOp: 1},
+		"VRF":         {Delete: 1, NoOp: 1},
+		"ROUTE_MAP":   {NoOp: 1},
+		"BGP_GLOBALS": {Conflict: 1},
+		"VLAN_MEMBER": {Create: 1, Delete: 1},
+	}
+	if len(d.Tables) != len(wantCounts) {
+		t.Errorf("Tables = %d entries, want %d", len(d.Tables), len(wantCounts))
+	}
+	for table, wc := range wantCounts {
+		if got := d.Tables[table]; got == nil || *got != wc {
+			t.Errorf("Tables[%s] = %+v, want %+v", table, got, wc)
+		}
+	}
+
+	if len(d.NoOps) != 3 {
+		t.Fatalf("NoOps = %d, want 3", len(d.NoOps))
+	}
+	for i, key := range []string{"Vlan100", "Vrf_b", "RM|10"} {
+		if d.NoOps[i].Key != key {
+			t.Errorf("NoOps[%d] = %s, want %s", i, d.NoOps[i].Key, key)
+		}
+	}
+}
+
+func TestDiffWithReader_ReplaceFromMatches(t *testing.T) {
+	reader := newFakeReader(map[string]map[string]string{
+		"BGP_GLOBALS|default": {"local_asn": "65000"},
+	})
+	d, err := diffWithReader(reader, []Change{
+		{Table: "BGP_GLOBALS", Key: "default", Type: ChangeReplace, Fields: map[string]string{"local_asn": "65002"}, From: map[string]string{"local_asn": "65000"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Entries[0].Class; got != DiffModify {
+		t.Errorf("Class = %s, want %s", got, DiffModify)
+	}
+}
+
+// TestDiffWithReader_FieldlessRow: Redis holds a field-less row as
+// {"NULL": "NULL"}. Re-adding it with no fields is a no-op, not a modify,
+// and the row reads as present with no fields.
+func TestDiffWithReader_FieldlessRow(t *testing.T) {
+	reader := newFakeReader(map[string]map[string]string{
+		"VLAN_MEMBER|Vlan100|Ethernet0": {"NULL": "NULL"},
+	})
+	d, err := diffWithReader(reader, []Change{
+		{Table: "VLAN_MEMBER", Key: "Vlan100|Ethernet0", Type: ChangeAdd, Fields: map[string]string{}},
+		{Table: "VLAN_MEMBER", Key: "Vlan100|Ethernet4", Type: ChangeAdd, Fields: map[string]string{}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := d.Entries[0]; e.Class != DiffNoOp || e.Live == nil || len(e.Live) != 0 {
+		t.Errorf("present field-less row: class %s, live %v; want no-op over an empty, non-nil row", e.Class, e.Live)
+	}
+	if e := d.Entries[1]; e.Class != DiffCreate || e.Live != nil {
+		t.Errorf("absent row: class %s, live %v; want create over nil", e.Class, e.Live)
+	}
+}
+
+func TestChangeSetDiff_NotConnected(t *testing.T) {
+	cs := NewChangeSet("leaf1", "test")
+	if _, err := cs.Diff(&Node{}); !errors.Is(err, util.ErrNotConnected) {
+		t.Errorf("Diff on unconnected node: err = %v, want ErrNotConnected", err)
+	}
+}
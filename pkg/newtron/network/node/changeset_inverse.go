@@ -0,0 +1,53 @@
+package node
+
+import (
+	"fmt"
+	"maps"
+)
+
+// Inverse returns the compensating ChangeSet of an applied one: applying it
+// puts every key the apply wrote back the way it was, newest first. A key that
+// did not exist before is deleted; any other is restored to its pre-image
+// with a replace, which also drops the fields the apply added. Pre-images come
+// from the journal Apply keeps, so a modify is restored to the values the
+// device actually held, not to what the projection believed.
+//
+// A failed apply inverts as far as it got, including the change that failed —
+// its write may have landed in part. Inverse errors on a ChangeSet that was
+// never applied, or whose cancelled apply already rolled itself back.
+func (cs *ChangeSet) Inverse() (*ChangeSet, error) {
+	j := cs.journal
+	if j == nil {
+		return nil, fmt.Errorf("changeset %q has no apply to invert", cs.Operation)
+	}
+
+	// Replay the attempted changes over the pre-images to learn what each
+	// key held afterwards — the From of the restoring replace.
+	after := make(map[string]map[string]string, len(j.entries))
+	for _, e := range j.entries {
+		if len(e.prior) > 0 {
+			after[e.table+"|"+e.key] = e.prior
+		}
+	}
+	for _, c := range cs.Changes[:j.attempted] {
+		id := c.Table + "|" + c.Key
+		_, after[id] = classifyChange(c, after[id])
+	}
+
+	inv := NewChangeSet(cs.Device, "rollback."+cs.Operation)
+	for i := len(j.entries) - 1; i >= 0; i-- {
+		e := j.entries[i]
+		if len(e.prior) == 0 {
+			inv.Delete(e.table, e.key)
+			continue
+		}
+		inv.Changes = append(inv.Changes, Change{
+			Table:  e.table,
+			Key:    e.key,
+			Type:   ChangeReplace,
+			Fields: maps.Clone(e.prior),
+			From:   after[e.table+"|"+e.key],
+		})
+	}
+	return inv, nil
+}
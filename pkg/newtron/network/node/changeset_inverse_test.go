@@ -0,0 +1,162 @@
+package node
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// applyAndInvert applies cs to w, then its inverse, checks that w ended where
+// it started, and returns the inverse.
+func applyAndInvert(t *testing.T, w *fakeConfigDBWriter, cs *ChangeSet) *ChangeSet {
+	t.Helper()
+	before := cloneData(w.data)
+	if err := cs.ApplyTo(w, ApplyOpts{}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	inv, err := cs.Inverse()
+	if err != nil {
+		t.Fatalf("Inverse: %v", err)
+	}
+	if err := inv.ApplyTo(w, ApplyOpts{}); err != nil {
+		t.Fatalf("apply inverse: %v", err)
+	}
+	if !reflect.DeepEqual(w.data, before) {
+		t.Errorf("after inverse data = %v, want %v", w.data, before)
+	}
+	return inv
+}
+
+func cloneData(data map[string]map[string]string) map[string]map[string]string {
+	out := make(map[string]map[string]string, len(data))
+	for k, v := range data {
+		out[k] = maps.Clone(v)
+	}
+	return out
+}
+
+func TestInverse_EachChangeType(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string]map[string]string
+		change   Change
+		wantType sonic.ChangeType
+	}{
+		{
+			name:     "add becomes delete",
+			data:     map[string]map[string]string{},
+			change:   Change{Table: "VLAN", Key: "Vlan100", Type: ChangeAdd, Fields: map[string]string{"vlanid": "100"}},
+			wantType: ChangeDelete,
+		},
+		{
+			name:     "add over an existing key restores it",
+			data:     map[string]map[string]string{"VLAN|Vlan100": {"vlanid": "100", "description": "old"}},
+			change:   Change{Table: "VLAN", Key: "Vlan100", Type: ChangeAdd, Fields: map[string]string{"vlanid": "100", "mtu": "9100"}},
+			wantType: ChangeReplace,
+		},
+		{
+			name:     "modify restores the prior values",
+			data:     map[string]map[string]string{"PORT|Ethernet0": {"admin_status": "down", "mtu": "9100"}},
+			change:   Change{Table: "PORT", Key: "Ethernet0", Type: ChangeModify, Fields: map[string]string{"admin_status": "up", "description": "uplink"}},
+			wantType: ChangeReplace,
+		},
+		{
+			name:     "delete restores the row",
+			data:     map[string]map[string]string{"VRF|Vrf_a": {"vni": "1000"}},
+			change:   Change{Table: "VRF", Key: "Vrf_a", Type: ChangeDelete},
+			wantType: ChangeReplace,
+		},
+		{
+			name: "replace restores the fields it dropped",
+			data: map[string]map[string]string{"BGP_GLOBALS|default": {"local_asn": "65000", "router_id": "10.0.0.1"}},
+			change: Change{Table: "BGP_GLOBALS", Key: "default", Type: ChangeReplace,
+				Fields: map[string]string{"local_asn": "65001"}, From: map[string]string{"local_asn": "65000", "router_id": "10.0.0.1"}},
+			wantType: ChangeReplace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &fakeConfigDBWriter{data: tt.data}
+			inv := applyAndInvert(t, w, &ChangeSet{Operation: "test", Changes: []Change{tt.change}})
+			if len(inv.Changes) != 1 || inv.Changes[0].Type != tt.wantType {
+				t.Errorf("inverse = %+v, want one %s", inv.Changes, tt.wantType)
+			}
+		})
+	}
+}
+
+// TestInverse_Mixed inverts a set that touches one key twice: the inverse
+// restores the state before the whole apply, not the intermediate one, and
+// undoes keys newest first.
+func TestInverse_Mixed(t *testing.T) {
+	w := &fakeConfigDBWriter{data: map[string]map[string]string{
+		"PORT|Ethernet0":           {"admin_status": "down"},
+		"VLAN_MEMBER|Vlan100|Eth0": {"tagging_mode": "untagged"},
+	}}
+	cs := &ChangeSet{Operation: "apply-service", Changes: []Change{
+		{Table: "VLAN", Key: "Vlan100", Type: ChangeAdd, Fields: map[string]string{"vlanid": "100"}},
+		{Table: "PORT", Key: "Ethernet0", Type: ChangeModify, Fields: map[string]string{"admin_status": "up"}},
+		{Table: "VLAN_MEMBER", Key: "Vlan100|Eth0", Type: ChangeDelete},
+		{Table: "VLAN_MEMBER", Key: "Vlan100|Eth0", Type: ChangeAdd, Fields: map[string]string{"tagging_mode": "tagged"}},
+		{Table: "PORT", Key: "Ethernet0", Type: ChangeModify, Fields: map[string]string{"mtu": "9100"}},
+	}}
+	inv := applyAndInvert(t, w, cs)
+
+	if inv.Operation != "rollback.apply-service" {
+		t.Errorf("Operation = %q", inv.Operation)
+	}
+	var keys []string
+	for _, c := range inv.Changes {
+		keys = append(keys, c.Table+"|"+c.Key)
+	}
+	want := []string{"VLAN_MEMBER|Vlan100|Eth0", "PORT|Ethernet0", "VLAN|Vlan100"}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("inverse keys = %v, want %v", keys, want)
+	}
+}
+
+// TestInverse_PartialApply — a failed apply inverts what it wrote, including
+// the change that failed, and nothing after it.
+func TestInverse_PartialApply(t *testing.T) {
+	w := &fakeConfigDBWriter{
+		data:   map[string]map[string]string{"PORT|Ethernet0": {"admin_status": "down"}},
+		failOn: "VLAN|Vlan200",
+	}
+	before := cloneData(w.data)
+	cs := &ChangeSet{Operation: "test", Changes: []Change{
+		{Table: "VLAN", Key: "Vlan100", Type: ChangeAdd, Fields: map[string]string{"vlanid": "100"}},
+		{Table: "PORT", Key: "Ethernet0", Type: ChangeModify, Fields: map[string]string{"admin_status": "up"}},
+		{Table: "VLAN", Key: "Vlan200", Type: ChangeAdd, Fields: map[string]string{"vlanid": "200"}},
+		{Table: "VLAN", Key: "Vlan300", Type: ChangeAdd, Fields: map[string]string{"vlanid": "300"}},
+	}}
+	if err := cs.ApplyTo(w, ApplyOpts{}); err == nil {
+		t.Fatal("apply succeeded, want the Vlan200 write rejected")
+	}
+	if cs.AppliedCount != 2 {
+		t.Errorf("AppliedCount = %d, want 2", cs.AppliedCount)
+	}
+	inv, err := cs.Inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inv.Changes) != 3 || inv.Changes[0].Key != "Vlan200" {
+		t.Errorf("inverse = %+v, want Vlan200, Ethernet0, Vlan100", inv.Changes)
+	}
+	w.failOn = ""
+	if err := inv.ApplyTo(w, ApplyOpts{}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(w.data, before) {
+		t.Errorf("after inverse data = %v, want %v", w.data, before)
+	}
+}
+
+func TestInverse_NotApplied(t *testing.T) {
+	cs := NewChangeSet("leaf1", "create-vlan")
+	cs.Add("VLAN", "Vlan100", map[string]string{"vlanid": "100"})
+	if _, err := cs.Inverse(); err == nil {
+		t.Error("Inverse of an unapplied changeset: want error")
+	}
+}
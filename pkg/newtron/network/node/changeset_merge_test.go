@@ -0,0 +1,117 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func changeIDs(cs *ChangeSet) string {
+	ids := make([]string, len(cs.Changes))
+	for i, c := range cs.Changes {
+		ids[i] = string(c.Type) + " " + c.Table + "|" + c.Key
+	}
+	return strings.Join(ids, ", ")
+}
+
+// TestMergeChecked_CollapsesRestoredRow: a delete followed by an add of the
+// row it removed nets to nothing — both drop out. A re-add with different
+// fields is kept, and so is every restored row of the same entity (the
+// address of an interface whose VRF changed); NEWTRON_INTENT rows record no
+// prior row and are never collapsed.
+func TestMergeChecked_CollapsesRestoredRow(t *testing.T) {
+	removed := NewChangeSet("leaf1", "remove")
+	removed.Changes = []Change{
+		{Table: "VRF", Key: "Vrf_CUST", Type: ChangeDelete, From: map[string]string{"vni": "3001"}},
+		{Table: "INTERFACE", Key: "Ethernet0|10.1.1.1/31", Type: ChangeDelete},
+		{Table: "INTERFACE", Key: "Ethernet0", Type: ChangeDelete, From: map[string]string{"vrf_name": "Vrf_CUST"}},
+		{Table: "INTERFACE", Key: "Ethernet4", Type: ChangeDelete},
+		{Table: "NEWTRON_INTENT", Key: "Ethernet0", Type: ChangeDelete},
+	}
+	applied := NewChangeSet("leaf1", "apply")
+	applied.Add("VRF", "Vrf_CUST", map[string]string{"vni": "3001"})
+	applied.Add("INTERFACE", "Ethernet0", map[string]string{"vrf_name": "Vrf_OTHER"})
+	applied.Add("INTERFACE", "Ethernet0|10.1.1.1/31", map[string]string{})
+	applied.Add("INTERFACE", "Ethernet4", map[string]string{})
+	applied.Add("NEWTRON_INTENT", "Ethernet0", map[string]string{"operation": "apply-service"})
+
+	cs := NewChangeSet("leaf1", "refresh")
+	if err := cs.MergeChecked(removed); err != nil {
+		t.Fatalf("MergeChecked(removed): %v", err)
+	}
+	if err := cs.MergeChecked(applied); err != nil {
+		t.Fatalf("MergeChecked(applied): %v", err)
+	}
+	want := "delete INTERFACE|Ethernet0|10.1.1.1/31, delete INTERFACE|Ethernet0, delete NEWTRON_INTENT|Ethernet0, " +
+		"add INTERFACE|Ethernet0, add INTERFACE|Ethernet0|10.1.1.1/31, add NEWTRON_INTENT|Ethernet0"
+	if got := changeIDs(cs); got != want {
+		t.Errorf("changes = %s\nwant      %s", got, want)
+	}
+}
+
+// TestMergeChecked_ConflictingModify: two modifies setting one field to
+// different values are refused, and cs is left as it was. Modifies that
+// agree, or touch different fields, merge.
+func TestMergeChecked_ConflictingModify(t *testing.T) {
+	cs := NewChangeSet("leaf1", "composite")
+	cs.Update("PORT", "Ethernet0", map[string]string{"mtu": "9100", "admin_status": "up"})
+
+	agree := NewChangeSet("leaf1", "agree")
+	agree.Update("PORT", "Ethernet0", map[string]string{"mtu": "9100", "description": "uplink"})
+	if err := cs.MergeChecked(agree); err != nil {
+		t.Fatalf("agreeing modifies: %v", err)
+	}
+
+	conflict := NewChangeSet("leaf1", "conflict")
+	conflict.Add("VLAN", "Vlan100", map[string]string{"vlanid": "100"})
+	conflict.Update("PORT", "Ethernet0", map[string]string{"mtu": "1500"})
+	err := cs.MergeChecked(conflict)
+	if err == nil || !strings.Contains(err.Error(), `PORT|Ethernet0: mtu set to "9100", then "1500"`) {
+		t.Fatalf("err = %v, want a conflicting-modify error naming the key and field", err)
+	}
+	if got := changeIDs(cs); got != "modify PORT|Ethernet0, modify PORT|Ethernet0" {
+		t.Errorf("cs changed by a refused merge: %s", got)
+	}
+}
+
+// TestMergeChecked_PreservesOrder: changes to distinct keys keep their
+// order — cs's first, then other's, each in sequence.
+func TestMergeChecked_PreservesOrder(t *testing.T) {
+	cs := NewChangeSet("leaf1", "a")
+	cs.Delete("VLAN_MEMBER", "Vlan100|Ethernet0")
+	cs.Delete("VLAN", "Vlan100")
+	other := NewChangeSet("leaf1", "b")
+	other.Add("VLAN", "Vlan200", map[string]string{"vlanid": "200"})
+	other.Add("VLAN_MEMBER", "Vlan200|Ethernet0", map[string]string{"tagging_mode": "untagged"})
+	other.Delete("VLAN", "Vlan200")
+	if err := cs.MergeChecked(other); err != nil {
+		t.Fatalf("MergeChecked: %v", err)
+	}
+	want := "delete VLAN_MEMBER|Vlan100|Ethernet0, delete VLAN|Vlan100, add VLAN|Vlan200, add VLAN_MEMBER|Vlan200|Ethernet0, delete VLAN|Vlan200"
+	if got := changeIDs(cs); got != want {
+		t.Errorf("changes = %s\nwant      %s", got, want)
+	}
+}
+
+// TestRefreshService_UnchangedRowsNotRewritten: refreshing a service whose
+// definition did not change re-writes no CONFIG_DB row — every delete the
+// removal generated is matched by an identical re-add.
+func TestRefreshService_UnchangedRowsNotRewritten(t *testing.T) {
+	ctx := context.Background()
+	n, e0 := testInterface()
+	n.SpecProvider.(*testSpecProvider).services["ROUTED"] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeRouted}
+	if _, err := e0.ApplyService(ctx, "ROUTED", ApplyServiceOpts{IPAddress: "10.1.1.1/31"}); err != nil {
+		t.Fatalf("ApplyService: %v", err)
+	}
+	cs, err := e0.RefreshService(ctx)
+	if err != nil {
+		t.Fatalf("RefreshService: %v", err)
+	}
+	for _, c := range cs.Changes {
+		if fromUndoable(c.Table) {
+			t.Errorf("refresh of an unchanged service re-wrote %s %s|%s", c.Type, c.Table, c.Key)
+		}
+	}
+}
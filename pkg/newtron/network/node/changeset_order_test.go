@@ -0,0 +1,76 @@
+package node
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// buildTeardownChangeSets configures a fresh node with map-backed state — a
+// service ACL of several rules and a multi-member PortChannel — then tears it
+// down, returning each teardown ChangeSet's changes as "type table|key".
+func buildTeardownChangeSets(t *testing.T) [][]string {
+	t.Helper()
+	ctx := context.Background()
+	n := newTestAbstract()
+	for _, p := range []string{"Ethernet8", "Ethernet12", "Ethernet16", "Ethernet20", "Ethernet24"} {
+		n.RegisterPort(p, map[string]string{"admin_status": "up", "mtu": "9100"})
+	}
+	sp := n.SpecProvider.(*testSpecProvider)
+	sp.filterSpecs["edge-in"] = &spec.FilterSpec{
+		Type: "ipv4",
+		Rules: []*spec.FilterRule{
+			{Sequence: 10, SrcIP: "10.0.0.0/8", Action: "permit"},
+			{Sequence: 20, SrcIP: "172.16.0.0/12", Action: "permit"},
+			{Sequence: 30, SrcIP: "192.168.0.0/16", Action: "permit"},
+			{Sequence: 40, DstIP: "10.9.0.0/24", Action: "deny"},
+			{Sequence: 50, Action: "deny"},
+		},
+	}
+	sp.services["EDGE"] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeRouted, IngressFilter: "edge-in"}
+
+	eth0, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+	if _, err := eth0.ApplyService(ctx, "EDGE", ApplyServiceOpts{IPAddress: "10.1.0.1/31"}); err != nil {
+		t.Fatalf("ApplyService: %v", err)
+	}
+	members := []string{"Ethernet8", "Ethernet12", "Ethernet16", "Ethernet20", "Ethernet24"}
+	if _, err := n.CreatePortChannel(ctx, "PortChannel10", PortChannelConfig{Members: members}); err != nil {
+		t.Fatalf("CreatePortChannel: %v", err)
+	}
+
+	var out [][]string
+	record := func(cs *ChangeSet, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		var changes []string
+		for _, c := range cs.Changes {
+			changes = append(changes, string(c.Type)+" "+c.Table+"|"+c.Key)
+		}
+		out = append(out, changes)
+	}
+	record(eth0.RemoveService(ctx))
+	record(n.DeletePortChannel(ctx, "PortChannel10"))
+	return out
+}
+
+// TestChangeSet_TeardownOrderIsDeterministic builds the same delete-heavy
+// ChangeSets from identical nodes repeatedly and requires identical change
+// order every time. Map iteration order is randomized per range statement,
+// so an emitter that walks a map unsorted fails here within a few builds —
+// and would otherwise make dry-run previews and snapshot diffs flap.
+func TestChangeSet_TeardownOrderIsDeterministic(t *testing.T) {
+	want := buildTeardownChangeSets(t)
+	for i := 0; i < 20; i++ {
+		got := buildTeardownChangeSets(t)
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("build %d ordered changes differently:\n got %v\nwant %v", i+2, got, want)
+		}
+	}
+}
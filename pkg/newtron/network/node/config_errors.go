@@ -0,0 +1,130 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// ============================================================================
+// Config-apply errors — did the dataplane accept what newtron delivered?
+//
+// Drift answers "does CONFIG_DB hold what we wrote?". It cannot answer "did
+// the daemons accept it?": a Redis write succeeds even when vlanmgrd,
+// vrfmgrd, intfmgrd, or teammgrd later refuses the entry (the refusal goes to
+// syslog, not back to the writer). Each of those daemons publishes a STATE_DB
+// row once it has programmed an entry, so a projected CONFIG_DB key with no
+// STATE_DB counterpart — or one whose state field is not "ok" — is an entry
+// the dataplane has not accepted.
+//
+// This file owns the CONFIG_DB → STATE_DB acceptance map (§28). Only tables
+// whose owning daemon is known to publish a per-key STATE_DB row are listed;
+// a table absent here is simply not checked.
+// ============================================================================
+
+// ConfigError is one projected CONFIG_DB entry the dataplane has not accepted.
+type ConfigError struct {
+	Table      string // CONFIG_DB table (e.g., "VLAN")
+	Key        string // CONFIG_DB key (e.g., "Vlan100")
+	StateTable string // STATE_DB table that should confirm it (e.g., "VLAN_TABLE")
+	Reason     string // "missing" or "state=<value>"
+}
+
+// configAcceptanceTables maps a CONFIG_DB table to the STATE_DB table its
+// owning daemon writes when it accepts an entry. The keys match one-for-one
+// (STATE_DB and CONFIG_DB both use "|" as the key separator).
+var configAcceptanceTables = map[string]string{
+	"VLAN":                  "VLAN_TABLE",         // vlanmgrd
+	"VLAN_MEMBER":           "VLAN_MEMBER_TABLE",  // vlanmgrd
+	"VRF":                   "VRF_TABLE",          // vrfmgrd
+	"PORTCHANNEL":           "LAG_TABLE",          // teammgrd
+	"PORTCHANNEL_MEMBER":    "LAG_MEMBER_TABLE",   // teammgrd
+	"INTERFACE":             "INTERFACE_TABLE",    // intfmgrd
+	"VLAN_INTERFACE":        "INTERFACE_TABLE",    // intfmgrd
+	"PORTCHANNEL_INTERFACE": "INTERFACE_TABLE",    // intfmgrd
+	"VXLAN_TUNNEL":          "VXLAN_TUNNEL_TABLE", // vxlanmgrd
+}
+
+// GetConfigErrors reports every projected CONFIG_DB entry that the dataplane
+// daemons have not accepted, sorted by table then key. An empty result means
+// every checked entry has a confirming STATE_DB row. Entries written moments
+// ago may legitimately not be confirmed yet — callers asserting "nothing was
+// rejected" should poll. Auto-connects transport if needed.
+func (n *Node) GetConfigErrors(ctx context.Context) ([]ConfigError, error) {
+	projection := n.Projection()
+
+	state := make(sonic.RawConfigDB)
+	for cfgTable, stateTable := range configAcceptanceTables {
+		if len(projection[cfgTable]) == 0 {
+			continue
+		}
+		if _, read := state[stateTable]; read {
+			continue
+		}
+		rows, err := n.OperDBTable(ctx, "STATE_DB", stateTable)
+		if err != nil {
+			return nil, fmt.Errorf("reading STATE_DB %s: %w", stateTable, err)
+		}
+		state[stateTable] = rows
+	}
+	return findConfigErrors(projection, state), nil
+}
+
+// findConfigErrors is the pure comparison behind GetConfigErrors: projection
+// is the expected CONFIG_DB, state holds the STATE_DB tables read so far.
+func findConfigErrors(projection, state sonic.RawConfigDB) []ConfigError {
+	var out []ConfigError
+	for cfgTable, stateTable := range configAcceptanceTables {
+		for key := range projection[cfgTable] {
+			row, ok := state[stateTable][key]
+			switch {
+			case !ok:
+				out = append(out, ConfigError{Table: cfgTable, Key: key, StateTable: stateTable, Reason: "missing"})
+			case row["state"] != "" && row["state"] != "ok":
+				out = append(out, ConfigError{Table: cfgTable, Key: key, StateTable: stateTable, Reason: "state=" + row["state"]})
+			}
+		}
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].Table != out[b].Table {
+			return out[a].Table < out[b].Table
+		}
+		return out[a].Key < out[b].Key
+	})
+	return out
+}
+
+// CheckConfigApplied is the health sub-check form of GetConfigErrors: one
+// "config-apply" result per unaccepted entry, or a single pass result.
+func (n *Node) CheckConfigApplied(ctx context.Context) []HealthCheckResult {
+	errs, err := n.GetConfigErrors(ctx)
+	if err != nil {
+		return []HealthCheckResult{{Check: "config-apply", Status: "fail", Message: err.Error()}}
+	}
+	return configAppliedResults(errs)
+}
+
+// configAppliedResults grades config errors for the health report. A
+// rejected entry (state≠ok) fails. A missing STATE_DB row only warns: a
+// health check run right after a write can beat the daemons to it, and not
+// every platform's daemons publish each table in the acceptance map.
+func configAppliedResults(errs []ConfigError) []HealthCheckResult {
+	if len(errs) == 0 {
+		return []HealthCheckResult{{Check: "config-apply", Status: "pass", Message: "all delivered entries accepted by the dataplane"}}
+	}
+	results := make([]HealthCheckResult, 0, len(errs))
+	for _, e := range errs {
+		status := "fail"
+		if e.Reason == "missing" {
+			status = "warn"
+		}
+		results = append(results, HealthCheckResult{
+			Check:   "config-apply",
+			Status:  status,
+			Message: fmt.Sprintf("%s|%s: STATE_DB %s %s", e.Table, e.Key, e.StateTable, e.Reason),
+		})
+	}
+	return results
+}
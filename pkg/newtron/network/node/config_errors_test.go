@@ -0,0 +1,62 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+func TestFindConfigErrors(t *testing.T) {
+	projection := sonic.RawConfigDB{
+		"VLAN":        {"Vlan100": {"vlanid": "100"}, "Vlan200": {"vlanid": "200"}},
+		"VLAN_MEMBER": {"Vlan100|Ethernet0": {"tagging_mode": "untagged"}},
+		"VRF":         {"Vrf_red": {}},
+		"INTERFACE":   {"Ethernet4": {}, "Ethernet4|10.1.0.0/31": {}},
+		// Not in the acceptance map — never reported.
+		"BGP_GLOBALS": {"default": {"local_asn": "65001"}},
+	}
+	state := sonic.RawConfigDB{
+		"VLAN_TABLE":        {"Vlan100": {"state": "ok"}},
+		"VLAN_MEMBER_TABLE": {"Vlan100|Ethernet0": {"tagging_mode": "untagged"}},
+		"VRF_TABLE":         {"Vrf_red": {"state": "failed"}},
+		"INTERFACE_TABLE":   {"Ethernet4": {"state": "ok"}, "Ethernet4|10.1.0.0/31": {"state": "ok"}},
+	}
+
+	got := findConfigErrors(projection, state)
+	want := []ConfigError{
+		{Table: "VLAN", Key: "Vlan200", StateTable: "VLAN_TABLE", Reason: "missing"},
+		{Table: "VRF", Key: "Vrf_red", StateTable: "VRF_TABLE", Reason: "state=failed"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("findConfigErrors =\n  %+v\nwant\n  %+v", got, want)
+	}
+}
+
+func TestFindConfigErrors_AllAccepted(t *testing.T) {
+	projection := sonic.RawConfigDB{"PORTCHANNEL": {"PortChannel1": {}}}
+	state := sonic.RawConfigDB{"LAG_TABLE": {"PortChannel1": {"oper_status": "up"}}}
+	if got := findConfigErrors(projection, state); len(got) != 0 {
+		t.Errorf("findConfigErrors = %+v, want none", got)
+	}
+}
+
+// TestConfigAppliedResults: a rejected entry fails the health sub-check; one
+// with no STATE_DB row yet only warns, since the daemons may still be
+// catching up on a write made moments ago.
+func TestConfigAppliedResults(t *testing.T) {
+	got := configAppliedResults([]ConfigError{
+		{Table: "VLAN", Key: "Vlan200", StateTable: "VLAN_TABLE", Reason: "missing"},
+		{Table: "VRF", Key: "Vrf_red", StateTable: "VRF_TABLE", Reason: "state=failed"},
+	})
+	want := []HealthCheckResult{
+		{Check: "config-apply", Status: "warn", Message: "VLAN|Vlan200: STATE_DB VLAN_TABLE missing"},
+		{Check: "config-apply", Status: "fail", Message: "VRF|Vrf_red: STATE_DB VRF_TABLE state=failed"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("configAppliedResults =\n  %+v\nwant\n  %+v", got, want)
+	}
+	if got := configAppliedResults(nil); len(got) != 1 || got[0].Status != "pass" {
+		t.Errorf("no errors: %+v, want a single pass", got)
+	}
+}
@@ -0,0 +1,33 @@
+package node
+
+import (
+	"strconv"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// counterStatus returns the FLEX_COUNTER_STATUS value for enable.
+func counterStatus(enable bool) string {
+	if enable {
+		return "enable"
+	}
+	return "disable"
+}
+
+// setCounterPollingConfig returns the FLEX_COUNTER_TABLE entry for a counter
+// group. The port/queue/watermark entries are factory-owned; the fields are
+// merged into them, never replaced.
+func setCounterPollingConfig(group string, enable bool, intervalMs int) []sonic.Entry {
+	return []sonic.Entry{{Table: "FLEX_COUNTER_TABLE", Key: group, Fields: map[string]string{
+		"FLEX_COUNTER_STATUS": counterStatus(enable),
+		"POLL_INTERVAL":       strconv.Itoa(intervalMs),
+	}}}
+}
+
+// deleteCounterPollingConfig returns the FLEX_COUNTER_TABLE delete for a
+// group SONiC leaves unconfigured at boot (ACL, TUNNEL, ...). Groups it
+// enables at boot are reset with setCounterPollingConfig and their defaults
+// instead — deleting those entries would stop polling altogether.
+func deleteCounterPollingConfig(group string) []sonic.Entry {
+	return []sonic.Entry{{Table: "FLEX_COUNTER_TABLE", Key: group}}
+}
@@ -0,0 +1,113 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// counterPollingResource returns the intent key of a counter group's polling
+// setting. One intent per group: setting a group again replaces it.
+func counterPollingResource(group string) string {
+	return "counter-polling|" + group
+}
+
+// lookupCounterGroup returns the sonic.CounterGroups entry for a
+// case-insensitive group name.
+func lookupCounterGroup(name string) (sonic.CounterGroup, bool) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for _, g := range sonic.CounterGroups {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return sonic.CounterGroup{}, false
+}
+
+// counterGroupNames returns the configurable counter group names, for errors.
+func counterGroupNames() string {
+	names := make([]string, len(sonic.CounterGroups))
+	for i, g := range sonic.CounterGroups {
+		names[i] = g.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// SetCounterPolling turns flex counter polling for one counter group (ACL,
+// QUEUE, PORT, ...; see sonic.CounterGroups) on or off and sets its polling
+// interval. A zero interval selects the group's SONiC default; otherwise it
+// must be whole milliseconds within sonic.MinCounterPollInterval and
+// sonic.MaxCounterPollInterval. Setting a group again replaces its setting.
+func (n *Node) SetCounterPolling(ctx context.Context, counterType string, enable bool, interval time.Duration) (*ChangeSet, error) {
+	group, known := lookupCounterGroup(counterType)
+	intervalMs := group.DefaultInterval
+	if interval != 0 {
+		intervalMs = int(interval / time.Millisecond)
+	}
+
+	if err := n.precondition(sonic.OpSetCounterPolling, counterPollingResource(group.Name)).
+		Check(known, "known counter type",
+			fmt.Sprintf("unknown counter type %q — valid: %s", counterType, counterGroupNames())).
+		Check(interval%time.Millisecond == 0, "whole-millisecond interval",
+			fmt.Sprintf("interval %s is not a whole number of milliseconds", interval)).
+		Check(interval == 0 || (intervalMs >= sonic.MinCounterPollInterval && intervalMs <= sonic.MaxCounterPollInterval),
+			"interval in range", fmt.Sprintf("interval %s is outside %dms-%dms", interval,
+				sonic.MinCounterPollInterval, sonic.MaxCounterPollInterval)).
+		Result(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{
+		sonic.FieldCounterType:  group.Name,
+		sonic.FieldEnable:       strconv.FormatBool(enable),
+		sonic.FieldPollInterval: strconv.Itoa(intervalMs),
+	}
+	cs := NewChangeSet(n.name, "device."+sonic.OpSetCounterPolling)
+	cs.ReverseOp = "device." + sonic.OpClearCounterPolling
+	cs.OperationParams = params
+	if err := n.writeIntent(cs, sonic.OpSetCounterPolling, counterPollingResource(group.Name), params, []string{"device"}); err != nil {
+		return nil, err
+	}
+	cs.Updates(setCounterPollingConfig(group.Name, enable, intervalMs))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Set %s counter polling: %s every %dms", group.Name, counterStatus(enable), intervalMs)
+	return cs, nil
+}
+
+// ClearCounterPolling returns a counter group to its factory polling state.
+// Reverse of SetCounterPolling (§15).
+func (n *Node) ClearCounterPolling(ctx context.Context, counterType string) (*ChangeSet, error) {
+	group, known := lookupCounterGroup(counterType)
+	resource := counterPollingResource(group.Name)
+	if err := n.precondition(sonic.OpClearCounterPolling, resource).
+		Check(known, "known counter type",
+			fmt.Sprintf("unknown counter type %q — valid: %s", counterType, counterGroupNames())).
+		Result(); err != nil {
+		return nil, err
+	}
+	if n.GetIntent(resource) == nil {
+		return nil, fmt.Errorf("no %s counter polling is set", group.Name)
+	}
+	cs := NewChangeSet(n.name, "device."+sonic.OpClearCounterPolling)
+	cs.OperationParams = map[string]string{sonic.FieldCounterType: group.Name}
+	if group.DefaultEnabled {
+		cs.Updates(setCounterPollingConfig(group.Name, true, group.DefaultInterval))
+	} else {
+		cs.Deletes(deleteCounterPollingConfig(group.Name))
+	}
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Cleared %s counter polling", group.Name)
+	return cs, nil
+}
@@ -0,0 +1,101 @@
+package node
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// ============================================================================
+// Delete guards — refuse a delete that would orphan a dependent
+// ============================================================================
+//
+// Nearly everything that depends on a VLAN, a VRF, or a service's VRF is a
+// child of its intent record, so deleteIntent already refuses the delete
+// (I5). But deleteIntent runs after the operation has built its CONFIG_DB
+// deletes, and its error names raw intent keys. guardDependents runs the
+// same check where the operation decides to delete — before delete-vlan or
+// delete-vrf builds its teardown, before remove-service renders the teardown
+// of its VRF — and reports the refusal the way every other referential
+// conflict is reported: a *util.ConflictError (→ 409) listing each dependent
+// with the operation that created it.
+//
+// The exception is set-vrf, which binds an interface to a VRF in CONFIG_DB
+// without writing an intent. delete-vrf finds those bindings in the
+// projection (vrfUnrecordedBindings) and lists them alongside the children.
+//
+// Force reaches only the unrecorded dependents. A child intent is never
+// forced past: deleting its parent anyway would leave an intent that
+// reconstruction refuses to replay (I4), so the dependents are removed
+// first, each by its own reverse operation (§15). A set-vrf binding has no
+// intent to orphan, so delete-vrf with force unbinds those interfaces in the
+// same ChangeSet, as RemoveVRFInterface would.
+
+// guardDependents returns a *util.ConflictError naming the children of
+// resource's intent, followed by any unrecorded dependents the caller found
+// outside the DAG, or nil when there are none. With force, unrecorded
+// dependents alone do not refuse the delete — the caller removes them with
+// it — and the error offers force only when they are all that blocks it.
+// kind and name say what is being deleted ("VRF", "Vrf_CUST").
+func (n *Node) guardDependents(kind, name, resource string, force bool, unrecorded ...string) error {
+	var refs []string
+	if intent := n.GetIntent(resource); intent != nil {
+		for _, child := range intent.Children {
+			refs = append(refs, n.describeDependent(child))
+		}
+	}
+	if len(refs) == 0 && (force || len(unrecorded) == 0) {
+		return nil
+	}
+	return &util.ConflictError{Resource: kind, Name: name, References: append(refs, unrecorded...), Force: len(refs) == 0}
+}
+
+// vrfUnrecordedBindings returns the base L3 rows in CONFIG_DB that bind an
+// interface to vrf without an intent under vrf|<name> saying so — the
+// binding set-vrf writes (Interface.SetVRF records no intent). The intent DAG
+// cannot see these, so delete-vrf reads them off the projection. Sorted by
+// table, then interface.
+func (n *Node) vrfUnrecordedBindings(vrf string) []sonic.Entry {
+	recorded := map[string]bool{}
+	if intent := n.GetIntent("vrf|" + vrf); intent != nil {
+		for _, child := range intent.Children {
+			if name := resourceInterfaceName(child); name != "" {
+				recorded[name] = true
+			}
+		}
+	}
+	var rows []sonic.Entry
+	bound := func(table, name, vrfName string) {
+		if vrfName == vrf && !strings.Contains(name, "|") && !recorded[name] {
+			rows = append(rows, sonic.Entry{Table: table, Key: name})
+		}
+	}
+	for name, e := range n.configDB.Interface {
+		bound("INTERFACE", name, e.VRFName)
+	}
+	for name, fields := range n.configDB.PortChannelInterface {
+		bound("PORTCHANNEL_INTERFACE", name, fields[sonic.FieldVRFName])
+	}
+	for name, fields := range n.configDB.VLANInterface {
+		bound("VLAN_INTERFACE", name, fields[sonic.FieldVRFName])
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Table != rows[j].Table {
+			return rows[i].Table < rows[j].Table
+		}
+		return rows[i].Key < rows[j].Key
+	})
+	return rows
+}
+
+// describeDependent renders a dependent for a ConflictError: its intent key
+// and the operation that wrote it — "route|Vrf_CUST|10.9.0.0/16
+// (add-static-route)" — so the operator knows what to reverse.
+func (n *Node) describeDependent(resource string) string {
+	if intent := n.GetIntent(resource); intent != nil && intent.Operation != "" {
+		return resource + " (" + intent.Operation + ")"
+	}
+	return resource
+}
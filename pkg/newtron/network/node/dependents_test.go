@@ -0,0 +1,150 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// requireConflict asserts err is a *util.ConflictError for kind/name whose
+// references are exactly refs, and whether it offers force.
+func requireConflict(t *testing.T, err error, force bool, kind, name string, refs ...string) {
+	t.Helper()
+	var conflict *util.ConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("err = %v, want *util.ConflictError", err)
+	}
+	if conflict.Resource != kind || conflict.Name != name || !slices.Equal(conflict.References, refs) || conflict.Force != force {
+		t.Errorf("conflict = %+v, want %s %s references %v, force %v", conflict, kind, name, refs, force)
+	}
+}
+
+func TestDeleteVRF_RefusesWithDependents(t *testing.T) {
+	n := staticRouteSetup(t)
+	ctx := context.Background()
+
+	_, err := n.DeleteVRF(ctx, "Vrf_CUST1", false)
+	requireConflict(t, err, false, "VRF", "Vrf_CUST1", "route|Vrf_CUST1|10.0.0.0/24 (add-static-route)")
+	if n.GetIntent("vrf|Vrf_CUST1") == nil {
+		t.Fatal("refused delete-vrf removed the VRF intent")
+	}
+
+	if _, err := n.RemoveStaticRoute(ctx, "Vrf_CUST1", "10.0.0.0/24"); err != nil {
+		t.Fatalf("RemoveStaticRoute: %v", err)
+	}
+	if _, err := n.DeleteVRF(ctx, "Vrf_CUST1", false); err != nil {
+		t.Fatalf("DeleteVRF after its route is gone: %v", err)
+	}
+}
+
+// TestDeleteVRF_RefusesWithSetVRFBinding: set-vrf binds an interface in
+// CONFIG_DB without an intent, so the intent DAG cannot see it — delete-vrf
+// still refuses, naming the row, until the binding is removed.
+func TestDeleteVRF_RefusesWithSetVRFBinding(t *testing.T) {
+	ctx := context.Background()
+	n, e0 := testInterface()
+	if _, err := n.CreateVRF(ctx, "Vrf_CUST1", VRFConfig{}); err != nil {
+		t.Fatalf("CreateVRF: %v", err)
+	}
+	if _, err := e0.SetVRF(ctx, "Vrf_CUST1"); err != nil {
+		t.Fatalf("SetVRF: %v", err)
+	}
+
+	_, err := n.DeleteVRF(ctx, "Vrf_CUST1", false)
+	requireConflict(t, err, true, "VRF", "Vrf_CUST1", "INTERFACE|Ethernet0 (set-vrf)")
+	if _, ok := n.configDB.VRF["Vrf_CUST1"]; !ok {
+		t.Fatal("refused delete-vrf deleted the VRF from the projection")
+	}
+
+	if _, err := n.RemoveVRFInterface(ctx, "Vrf_CUST1", "Ethernet0"); err != nil {
+		t.Fatalf("RemoveVRFInterface: %v", err)
+	}
+	if _, err := n.DeleteVRF(ctx, "Vrf_CUST1", false); err != nil {
+		t.Fatalf("DeleteVRF after the binding is gone: %v", err)
+	}
+}
+
+// TestDeleteVRF_ForceUnbindsSetVRFBinding: force deletes the VRF past a
+// set-vrf binding, unbinding the interface in the same ChangeSet. It does
+// not reach an intent dependent — with a static route in the VRF the
+// conflict lists both and offers no force.
+func TestDeleteVRF_ForceUnbindsSetVRFBinding(t *testing.T) {
+	ctx := context.Background()
+	n, e0 := testInterface()
+	if _, err := n.CreateVRF(ctx, "Vrf_CUST1", VRFConfig{}); err != nil {
+		t.Fatalf("CreateVRF: %v", err)
+	}
+	if _, err := e0.SetVRF(ctx, "Vrf_CUST1"); err != nil {
+		t.Fatalf("SetVRF: %v", err)
+	}
+	if _, err := n.AddStaticRoute(ctx, "Vrf_CUST1", "10.9.0.0/16", "10.1.1.1", 0); err != nil {
+		t.Fatalf("AddStaticRoute: %v", err)
+	}
+
+	_, err := n.DeleteVRF(ctx, "Vrf_CUST1", true)
+	requireConflict(t, err, false, "VRF", "Vrf_CUST1",
+		"route|Vrf_CUST1|10.9.0.0/16 (add-static-route)", "INTERFACE|Ethernet0 (set-vrf)")
+
+	if _, err := n.RemoveStaticRoute(ctx, "Vrf_CUST1", "10.9.0.0/16"); err != nil {
+		t.Fatalf("RemoveStaticRoute: %v", err)
+	}
+	cs, err := n.DeleteVRF(ctx, "Vrf_CUST1", true)
+	if err != nil {
+		t.Fatalf("forced DeleteVRF: %v", err)
+	}
+	assertChange(t, cs, "INTERFACE", "Ethernet0", ChangeModify)
+	assertChange(t, cs, "VRF", "Vrf_CUST1", ChangeDelete)
+	if got := n.configDB.Interface["Ethernet0"].VRFName; got != "" {
+		t.Errorf("Ethernet0 vrf_name = %q after a forced delete-vrf, want unbound", got)
+	}
+	if n.GetIntent("vrf|Vrf_CUST1") != nil {
+		t.Error("forced delete-vrf left the VRF intent")
+	}
+}
+
+func TestDeleteVLAN_RefusesWithDependents(t *testing.T) {
+	ctx := context.Background()
+	n, e0 := testInterface()
+	if _, err := n.CreateVLAN(ctx, 100, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+	if _, err := e0.ConfigureInterface(ctx, InterfaceConfig{VLAN: 100}); err != nil {
+		t.Fatalf("member join: %v", err)
+	}
+	if _, err := n.ConfigureIRB(ctx, 100, IRBConfig{IPAddress: "10.1.100.1/24"}); err != nil {
+		t.Fatalf("ConfigureIRB: %v", err)
+	}
+
+	cs, err := n.DeleteVLAN(ctx, 100)
+	if cs != nil {
+		t.Errorf("refused delete-vlan returned a ChangeSet: %v", cs.Changes)
+	}
+	requireConflict(t, err, false, "VLAN", "Vlan100",
+		"interface|Ethernet0 (configure-interface)", "interface|Vlan100 (configure-irb)")
+}
+
+// TestRemoveService_RefusesToOrphanVRFRoute: removing the last user of an
+// interface-mode service reaps the service's VRF — refused while the
+// operator has added a static route in it, with nothing rendered.
+func TestRemoveService_RefusesToOrphanVRFRoute(t *testing.T) {
+	ctx := context.Background()
+	n, e0 := testInterface()
+	n.SpecProvider.(*testSpecProvider).services["CUST"] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeRouted, VRFType: spec.VRFTypeInterface}
+	if _, err := e0.ApplyService(ctx, "CUST", ApplyServiceOpts{IPAddress: "10.1.1.0/31"}); err != nil {
+		t.Fatalf("ApplyService: %v", err)
+	}
+	vrf := e0.binding()["vrf_name"]
+	if _, err := n.AddStaticRoute(ctx, vrf, "10.9.0.0/16", "10.1.1.1", 0); err != nil {
+		t.Fatalf("AddStaticRoute: %v", err)
+	}
+
+	_, err := e0.RemoveService(ctx)
+	requireConflict(t, err, false, "VRF", vrf, "route|"+vrf+"|10.9.0.0/16 (add-static-route)")
+	if _, ok := n.configDB.VRF[vrf]; !ok {
+		t.Errorf("refused remove-service deleted VRF %s from the projection", vrf)
+	}
+}
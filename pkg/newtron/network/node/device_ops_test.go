@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
@@ -24,6 +26,7 @@ type testSpecProvider struct {
 	platforms     map[string]*spec.PlatformSpec
 	prefixLists   map[string][]string
 	routePolicies map[string]*spec.RoutePolicy
+	defaults      map[string]*spec.ServiceDefaults
 }
 
 func (sp *testSpecProvider) GetService(name string) (*spec.ServiceSpec, error) {
@@ -33,6 +36,10 @@ func (sp *testSpecProvider) GetService(name string) (*spec.ServiceSpec, error) {
 	return nil, &spec.NotFoundError{Kind: "service", Name: name}
 }
 
+func (sp *testSpecProvider) GetServiceDefaults(name string) *spec.ServiceDefaults {
+	return sp.defaults[name]
+}
+
 func (sp *testSpecProvider) GetIPVPN(name string) (*spec.IPVPNSpec, error) {
 	if s, ok := sp.ipvpn[name]; ok {
 		return s, nil
@@ -82,6 +89,14 @@ func (sp *testSpecProvider) GetRoutePolicy(name string) (*spec.RoutePolicy, erro
 	return nil, &spec.NotFoundError{Kind: "route policy", Name: name}
 }
 
+func (sp *testSpecProvider) ListServices() []string {
+	names := make([]string, 0, len(sp.services))
+	for name := range sp.services {
+		names = append(names, name)
+	}
+	return names
+}
+
 func (sp *testSpecProvider) FindMACVPNByVNI(vni int) (string, *spec.MACVPNSpec) {
 	for name, m := range sp.macvpn {
 		if m.VNI == vni {
@@ -337,6 +352,255 @@ func TestRemovePortChannelMember(t *testing.T) {
 	assertChange(t, cs, "NEWTRON_INTENT", "portchannel|PortChannel100|Ethernet0", ChangeDelete)
 }
 
+func TestRoundTrip_SetClearLAGHashPolicy(t *testing.T) {
+	d := testDevice()
+	ctx := context.Background()
+
+	cs, err := d.SetLAGHashPolicy(ctx, []string{"src_ip", "dst_ip", "L4_SRC_PORT"})
+	if err != nil {
+		t.Fatalf("SetLAGHashPolicy: %v", err)
+	}
+	c := assertChange(t, cs, "SWITCH_HASH", "GLOBAL", ChangeModify)
+	assertField(t, c, "ecmp_hash", "SRC_IP,DST_IP,L4_SRC_PORT")
+	assertField(t, c, "lag_hash", "SRC_IP,DST_IP,L4_SRC_PORT")
+	c = assertChange(t, cs, "SWITCH", "switch", ChangeModify)
+	assertField(t, c, "lag_hash_seed", lagHashSeed("test-dev"))
+	if c.Fields["lag_hash_seed"] == "0" {
+		t.Error("derived seed must not be the factory 0")
+	}
+	assertChange(t, cs, "NEWTRON_INTENT", "lag-hash-policy", ChangeAdd)
+
+	// Re-setting replaces the field set in place.
+	if _, err := d.SetLAGHashPolicy(ctx, []string{"SRC_MAC", "DST_MAC"}); err != nil {
+		t.Fatalf("SetLAGHashPolicy (replace): %v", err)
+	}
+	if got := d.GetIntent("lag-hash-policy").Params[sonic.FieldHashFields]; got != "SRC_MAC,DST_MAC" {
+		t.Errorf("hash_fields = %q, want SRC_MAC,DST_MAC", got)
+	}
+
+	cs, err = d.ClearLAGHashPolicy(ctx)
+	if err != nil {
+		t.Fatalf("ClearLAGHashPolicy: %v", err)
+	}
+	assertChange(t, cs, "SWITCH_HASH", "GLOBAL", ChangeDelete)
+	assertField(t, assertChange(t, cs, "SWITCH", "switch", ChangeModify), "ecmp_hash_seed", "0")
+	assertChange(t, cs, "NEWTRON_INTENT", "lag-hash-policy", ChangeDelete)
+	if d.GetIntent("lag-hash-policy") != nil {
+		t.Error("lag-hash-policy intent should be removed")
+	}
+	if _, err := d.ClearLAGHashPolicy(ctx); err == nil {
+		t.Error("ClearLAGHashPolicy with no policy should fail")
+	}
+}
+
+func TestSetLAGHashPolicy_Validation(t *testing.T) {
+	d := testDevice()
+	d.resolved.Platform = "vs"
+	d.SpecProvider.(*testSpecProvider).platforms["vs"] = &spec.PlatformSpec{
+		HashFields: []string{"SRC_IP", "DST_IP", "L4_SRC_PORT", "L4_DST_PORT"},
+	}
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		fields []string
+		want   string
+	}{
+		{nil, "at least one hash field"},
+		{[]string{"SRC_IP", "SRC_PORT"}, "unknown hash field(s) SRC_PORT"},
+		{[]string{"SRC_IP", "src_ip"}, "duplicate hash field(s) SRC_IP"},
+		{[]string{"SRC_IP", "INNER_SRC_IP"}, "platform vs cannot hash on INNER_SRC_IP"},
+	} {
+		_, err := d.SetLAGHashPolicy(ctx, tt.fields)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SetLAGHashPolicy(%v) err = %v, want %q", tt.fields, err, tt.want)
+		}
+	}
+	if _, err := d.SetLAGHashPolicy(ctx, []string{"SRC_IP", "DST_IP"}); err != nil {
+		t.Errorf("supported fields refused: %v", err)
+	}
+}
+
+func TestRoundTrip_SetClearBGPMaxPaths(t *testing.T) {
+	d := testDevice()
+	d.configDB.BGPGlobalsAF["default|ipv4_unicast"] = sonic.BGPGlobalsAFEntry{RedistributeConnected: "true"}
+	ctx := context.Background()
+
+	cs, err := d.SetBGPMaxPaths(ctx, 4)
+	if err != nil {
+		t.Fatalf("SetBGPMaxPaths: %v", err)
+	}
+	c := assertChange(t, cs, "BGP_GLOBALS_AF", "default|ipv4_unicast", ChangeReplace)
+	assertField(t, c, "max_ebgp_paths", "4")
+	assertField(t, c, "max_ibgp_paths", "4")
+	assertField(t, c, "redistribute_connected", "true") // merged, not replaced
+	assertChange(t, cs, "NEWTRON_INTENT", "bgp-max-paths", ChangeAdd)
+
+	// Re-setting reports the prior width as the change's from-row.
+	cs, err = d.SetBGPMaxPaths(ctx, 8)
+	if err != nil {
+		t.Fatalf("SetBGPMaxPaths (replace): %v", err)
+	}
+	c = assertChange(t, cs, "BGP_GLOBALS_AF", "default|ipv4_unicast", ChangeReplace)
+	assertField(t, c, "max_ebgp_paths", "8")
+	if got := c.From["max_ebgp_paths"]; got != "4" {
+		t.Errorf("from max_ebgp_paths = %q, want 4", got)
+	}
+	if got := d.GetIntent("bgp-max-paths").Params[sonic.FieldMaxPaths]; got != "8" {
+		t.Errorf("max_paths = %q, want 8", got)
+	}
+
+	cs, err = d.ClearBGPMaxPaths(ctx)
+	if err != nil {
+		t.Fatalf("ClearBGPMaxPaths: %v", err)
+	}
+	c = assertChange(t, cs, "BGP_GLOBALS_AF", "default|ipv4_unicast", ChangeReplace)
+	if _, ok := c.Fields["max_ebgp_paths"]; ok {
+		t.Error("max_ebgp_paths should be removed")
+	}
+	assertField(t, c, "redistribute_connected", "true")
+	assertChange(t, cs, "NEWTRON_INTENT", "bgp-max-paths", ChangeDelete)
+	if _, err := d.ClearBGPMaxPaths(ctx); err == nil {
+		t.Error("ClearBGPMaxPaths with nothing set should fail")
+	}
+}
+
+func TestSetBGPMaxPaths_Validation(t *testing.T) {
+	d := testDevice()
+	d.resolved.Platform = "vs"
+	d.SpecProvider.(*testSpecProvider).platforms["vs"] = &spec.PlatformSpec{MaxECMPPaths: 16}
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		paths int
+		want  string
+	}{
+		{0, "max-paths 0 out of range 1..16"},
+		{32, "max-paths 32 out of range 1..16"},
+	} {
+		_, err := d.SetBGPMaxPaths(ctx, tt.paths)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SetBGPMaxPaths(%d) err = %v, want %q", tt.paths, err, tt.want)
+		}
+	}
+	if _, err := d.SetBGPMaxPaths(ctx, 16); err != nil {
+		t.Errorf("platform limit refused: %v", err)
+	}
+
+	delete(d.configDB.NewtronIntent, "device")
+	if _, err := d.SetBGPMaxPaths(ctx, 2); err == nil || !strings.Contains(err.Error(), "BGP is not configured") {
+		t.Errorf("SetBGPMaxPaths without BGP: err = %v", err)
+	}
+}
+
+func TestRoundTrip_SetClearCounterPolling(t *testing.T) {
+	d := testDevice()
+	ctx := context.Background()
+
+	cs, err := d.SetCounterPolling(ctx, "acl", true, 5*time.Second)
+	if err != nil {
+		t.Fatalf("SetCounterPolling: %v", err)
+	}
+	c := assertChange(t, cs, "FLEX_COUNTER_TABLE", "ACL", ChangeModify)
+	assertField(t, c, "FLEX_COUNTER_STATUS", "enable")
+	assertField(t, c, "POLL_INTERVAL", "5000")
+	assertChange(t, cs, "NEWTRON_INTENT", "counter-polling|ACL", ChangeAdd)
+
+	// ACL is unconfigured at boot: clearing deletes the entry.
+	cs, err = d.ClearCounterPolling(ctx, "ACL")
+	if err != nil {
+		t.Fatalf("ClearCounterPolling: %v", err)
+	}
+	assertChange(t, cs, "FLEX_COUNTER_TABLE", "ACL", ChangeDelete)
+	assertChange(t, cs, "NEWTRON_INTENT", "counter-polling|ACL", ChangeDelete)
+	if _, err := d.ClearCounterPolling(ctx, "ACL"); err == nil {
+		t.Error("ClearCounterPolling with nothing set should fail")
+	}
+
+	// QUEUE is enabled at boot: a zero interval selects the default, and
+	// clearing restores the factory state rather than deleting the entry.
+	if _, err := d.SetCounterPolling(ctx, "queue", false, 0); err != nil {
+		t.Fatalf("SetCounterPolling(queue): %v", err)
+	}
+	if got := d.GetIntent("counter-polling|QUEUE").Params[sonic.FieldPollInterval]; got != "10000" {
+		t.Errorf("poll_interval = %q, want the QUEUE default 10000", got)
+	}
+	cs, err = d.ClearCounterPolling(ctx, "QUEUE")
+	if err != nil {
+		t.Fatalf("ClearCounterPolling(queue): %v", err)
+	}
+	c = assertChange(t, cs, "FLEX_COUNTER_TABLE", "QUEUE", ChangeModify)
+	assertField(t, c, "FLEX_COUNTER_STATUS", "enable")
+	assertField(t, c, "POLL_INTERVAL", "10000")
+}
+
+func TestSetCounterPolling_Validation(t *testing.T) {
+	d := testDevice()
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		counterType string
+		interval    time.Duration
+		want        string
+	}{
+		{"FOO", 0, `unknown counter type "FOO"`},
+		{"PFCWD", 0, `unknown counter type "PFCWD"`},
+		{"ACL", 1500 * time.Microsecond, "not a whole number of milliseconds"},
+		{"ACL", 50 * time.Millisecond, "outside 100ms-60000ms"},
+		{"ACL", 2 * time.Minute, "outside 100ms-60000ms"},
+	} {
+		_, err := d.SetCounterPolling(ctx, tt.counterType, true, tt.interval)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("SetCounterPolling(%s, %s) err = %v, want %q", tt.counterType, tt.interval, err, tt.want)
+		}
+	}
+	if _, err := d.ClearCounterPolling(ctx, "FOO"); err == nil {
+		t.Error("ClearCounterPolling(FOO) accepted an unknown counter type")
+	}
+}
+
+func TestRoundTrip_SetClearBanner(t *testing.T) {
+	d := testDevice()
+	ctx := context.Background()
+
+	if _, err := d.SetBanner(ctx, BannerConfig{}); err == nil || !strings.Contains(err.Error(), "at least one of login, motd, or logout") {
+		t.Errorf("SetBanner with no messages err = %v", err)
+	}
+
+	cs, err := d.SetBanner(ctx, BannerConfig{Login: "Authorized access only.", MOTD: "Welcome to leaf1\nLab fabric."})
+	if err != nil {
+		t.Fatalf("SetBanner: %v", err)
+	}
+	c := assertChange(t, cs, "BANNER_MESSAGE", "global", ChangeModify)
+	assertField(t, c, "state", "enabled")
+	assertField(t, c, "login", "Authorized access only.")
+	assertField(t, c, "motd", "Welcome to leaf1\nLab fabric.")
+	assertField(t, c, "logout", "")
+	assertChange(t, cs, "NEWTRON_INTENT", "banner", ChangeAdd)
+
+	// Setting again replaces every message: the login banner is blanked,
+	// not left over from the first set.
+	cs, err = d.SetBanner(ctx, BannerConfig{MOTD: "Maintenance window tonight."})
+	if err != nil {
+		t.Fatalf("SetBanner (replace): %v", err)
+	}
+	c = assertChange(t, cs, "BANNER_MESSAGE", "global", ChangeModify)
+	assertField(t, c, "login", "")
+	assertField(t, c, "motd", "Maintenance window tonight.")
+	if _, ok := d.GetIntent("banner").Params[sonic.FieldLogin]; ok {
+		t.Error("replaced banner intent still records the old login message")
+	}
+
+	cs, err = d.ClearBanner(ctx)
+	if err != nil {
+		t.Fatalf("ClearBanner: %v", err)
+	}
+	assertChange(t, cs, "BANNER_MESSAGE", "global", ChangeDelete)
+	assertChange(t, cs, "NEWTRON_INTENT", "banner", ChangeDelete)
+	if _, err := d.ClearBanner(ctx); err == nil {
+		t.Error("ClearBanner with nothing set should fail")
+	}
+}
+
 // ============================================================================
 // VRF Operation Tests
 // ============================================================================
@@ -371,7 +635,7 @@ func TestDeleteVRF_NoInterfaces(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	cs, err := d.DeleteVRF(ctx, "Vrf_CUST1")
+	cs, err := d.DeleteVRF(ctx, "Vrf_CUST1", false)
 	if err != nil {
 		t.Fatalf("DeleteVRF: %v", err)
 	}
@@ -386,7 +650,7 @@ func TestDeleteVRF_BoundInterfacesBlocks(t *testing.T) {
 	d.configDB.Interface["Ethernet0"] = sonic.InterfaceEntry{VRFName: "Vrf_CUST1"}
 	ctx := context.Background()
 
-	_, err := d.DeleteVRF(ctx, "Vrf_CUST1")
+	_, err := d.DeleteVRF(ctx, "Vrf_CUST1", false)
 	if err == nil {
 		t.Fatal("expected error when VRF has interfaces bound")
 	}
@@ -597,6 +861,70 @@ func TestBindMACVPN(t *testing.T) {
 	assertChange(t, cs, "NEWTRON_INTENT", "macvpn|100", ChangeAdd)
 }
 
+// TestVNIUniqueness pins the duplicate-VNI guard: a second VLAN cannot take an
+// L2VNI another VLAN already maps, a VLAN cannot take a VRF's L3VNI, a second
+// VRF cannot take a bound L3VNI, and ValidateVNIs reports a duplicate that
+// reached the projection anyway.
+func TestVNIUniqueness(t *testing.T) {
+	setup := func() *Node {
+		d := testDevice()
+		d.configDB.VXLANTunnel["vtep1"] = sonic.VXLANTunnelEntry{SrcIP: "10.255.0.1"}
+		d.configDB.VLAN["Vlan200"] = sonic.VLANEntry{VLANID: "200"}
+		d.configDB.NewtronIntent["vlan|200"] = map[string]string{"operation": "create-vlan", "state": "actuated"}
+		d.configDB.VRF["Vrf_CUST1"] = sonic.VRFEntry{VNI: "30001"}
+		d.configDB.VRF["Vrf_CUST2"] = sonic.VRFEntry{}
+		d.configDB.NewtronIntent["vrf|Vrf_CUST2"] = map[string]string{"operation": "create-vrf", "state": "actuated"}
+		d.configDB.VXLANTunnelMap["vtep1|VNI20100_Vlan100"] = sonic.VXLANMapEntry{VLAN: "Vlan100", VNI: "20100"}
+		sp := d.SpecProvider.(*testSpecProvider)
+		sp.macvpn["DUP_L2"] = &spec.MACVPNSpec{VNI: 20100}
+		sp.macvpn["DUP_L3"] = &spec.MACVPNSpec{VNI: 30001}
+		sp.ipvpn["DUP_IPVPN"] = &spec.IPVPNSpec{L3VNI: 30001}
+		return d
+	}
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		op   func(d *Node) error
+		want string
+	}{
+		{"bind-macvpn L2VNI in use", func(d *Node) error { _, err := d.BindMACVPN(ctx, 200, "DUP_L2"); return err },
+			"VNI 20100 is already mapped to Vlan100"},
+		{"bind-macvpn onto an L3VNI", func(d *Node) error { _, err := d.BindMACVPN(ctx, 200, "DUP_L3"); return err },
+			"L3VNI 30001 is already bound to VRF Vrf_CUST1"},
+		{"create-vlan L2VNI in use", func(d *Node) error { _, err := d.CreateVLAN(ctx, 300, VLANConfig{L2VNI: 20100}); return err },
+			"VNI 20100 is already mapped to Vlan100"},
+		{"bind-ipvpn L3VNI in use", func(d *Node) error { _, err := d.BindIPVPN(ctx, "DUP_IPVPN", "Vrf_CUST2"); return err },
+			"L3VNI 30001 is already bound to VRF Vrf_CUST1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.op(setup())
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err = %v, want %q", err, tc.want)
+			}
+		})
+	}
+
+	d := setup()
+	if errs := d.ValidateVNIs(); len(errs) != 0 {
+		t.Fatalf("unique mappings reported: %v", errs)
+	}
+	d.configDB.VXLANTunnelMap["vtep1|VNI20100_Vlan200"] = sonic.VXLANMapEntry{VLAN: "Vlan200", VNI: "20100"}
+	d.configDB.VRF["Vrf_CUST2"] = sonic.VRFEntry{VNI: "30001"}
+	errs := d.ValidateVNIs()
+	if len(errs) != 2 {
+		t.Fatalf("ValidateVNIs = %v, want 2 errors", errs)
+	}
+	for i, want := range []string{
+		"L2VNI 20100 is mapped to 2 VLANs (Vlan100, Vlan200)",
+		"L3VNI 30001 is mapped to 2 VRFs (Vrf_CUST1, Vrf_CUST2)",
+	} {
+		if !strings.Contains(errs[i].Error(), want) {
+			t.Errorf("errs[%d] = %v, want %q", i, errs[i], want)
+		}
+	}
+}
+
 func TestConfigureIRB(t *testing.T) {
 	d := testDevice()
 	d.configDB.VLAN["Vlan100"] = sonic.VLANEntry{VLANID: "100"}
@@ -777,7 +1105,7 @@ func TestRoundTrip_CreateDeleteVRF(t *testing.T) {
 		t.Fatal("expected vrf|Vrf_CUST1 intent")
 	}
 
-	cs2, err := n.DeleteVRF(ctx, "Vrf_CUST1")
+	cs2, err := n.DeleteVRF(ctx, "Vrf_CUST1", false)
 	if err != nil {
 		t.Fatalf("DeleteVRF: %v", err)
 	}
@@ -1047,7 +1375,7 @@ func TestUpdateStaticRoute_NextHopSwap(t *testing.T) {
 	n := staticRouteSetup(t)
 	ctx := context.Background()
 
-	cs, err := n.UpdateStaticRoute(ctx, "Vrf_CUST1", "10.0.0.0/24", "10.1.0.2", 0)
+	cs, err := n.UpdateStaticRoute(ctx, "Vrf_CUST1", "10.0.0.0/24", StaticRouteConfig{NextHops: []string{"10.1.0.2"}})
 	if err != nil {
 		t.Fatalf("UpdateStaticRoute next-hop swap: %v", err)
 	}
@@ -1071,12 +1399,147 @@ func TestUpdateStaticRoute_NotFound(t *testing.T) {
 	n := staticRouteSetup(t)
 	ctx := context.Background()
 
-	_, err := n.UpdateStaticRoute(ctx, "Vrf_CUST1", "192.168.0.0/24", "10.1.0.1", 0)
+	_, err := n.UpdateStaticRoute(ctx, "Vrf_CUST1", "192.168.0.0/24", StaticRouteConfig{NextHops: []string{"10.1.0.1"}})
 	if err == nil {
 		t.Fatal("expected error for missing route")
 	}
 }
 
+// TestUpdateStaticRoute_MultiPath: an update takes the same path shapes as
+// AddStaticRouteMulti, so an ECMP or blackhole route keeps its shape rather
+// than collapsing to a single next-hop.
+func TestUpdateStaticRoute_MultiPath(t *testing.T) {
+	n := staticRouteSetup(t)
+	ctx := context.Background()
+
+	cs, err := n.UpdateStaticRoute(ctx, "Vrf_CUST1", "10.0.0.0/24", StaticRouteConfig{
+		NextHops: []string{"10.1.0.1", "10.1.0.2"},
+		Distance: 5,
+	})
+	if err != nil {
+		t.Fatalf("UpdateStaticRoute to ECMP: %v", err)
+	}
+	c := assertChange(t, cs, "STATIC_ROUTE", "Vrf_CUST1|10.0.0.0/24", ChangeReplace)
+	assertField(t, c, "nexthop", "10.1.0.1,10.1.0.2")
+	assertField(t, c, "distance", "5,5")
+	if got := n.GetIntent("route|Vrf_CUST1|10.0.0.0/24").Params["next_hop"]; got != "10.1.0.1,10.1.0.2" {
+		t.Errorf("intent next_hop = %q, want both next-hops", got)
+	}
+
+	cs, err = n.UpdateStaticRoute(ctx, "Vrf_CUST1", "10.0.0.0/24", StaticRouteConfig{Blackhole: true})
+	if err != nil {
+		t.Fatalf("UpdateStaticRoute to blackhole: %v", err)
+	}
+	c = assertChange(t, cs, "STATIC_ROUTE", "Vrf_CUST1|10.0.0.0/24", ChangeReplace)
+	assertField(t, c, "blackhole", "true")
+	if _, ok := c.Fields["nexthop"]; ok {
+		t.Errorf("blackhole update kept nexthop: %v", c.Fields)
+	}
+	intent := n.GetIntent("route|Vrf_CUST1|10.0.0.0/24")
+	if intent.Params["blackhole"] != "true" || intent.Params["next_hop"] != "" {
+		t.Errorf("intent params = %v, want blackhole only", intent.Params)
+	}
+
+	if _, err := n.UpdateStaticRoute(ctx, "Vrf_CUST1", "10.0.0.0/24", StaticRouteConfig{}); err == nil {
+		t.Error("update with no path should be refused")
+	}
+}
+
+// ============================================================================
+// AddStaticRouteMulti — ECMP, interface-only and blackhole paths
+// ============================================================================
+
+func TestAddStaticRouteMulti_ECMP(t *testing.T) {
+	n := staticRouteSetup(t)
+	ctx := context.Background()
+
+	cs, err := n.AddStaticRouteMulti(ctx, "Vrf_CUST1", "0.0.0.0/0", StaticRouteConfig{
+		NextHops: []string{"10.1.0.1", "10.1.0.2"},
+		Distance: 10,
+	})
+	if err != nil {
+		t.Fatalf("AddStaticRouteMulti: %v", err)
+	}
+	c := assertChange(t, cs, "STATIC_ROUTE", "Vrf_CUST1|0.0.0.0/0", ChangeAdd)
+	assertField(t, c, "nexthop", "10.1.0.1,10.1.0.2")
+	// One distance per path — SONiC requires every list to be the same length.
+	assertField(t, c, "distance", "10,10")
+	if _, ok := c.Fields["ifname"]; ok {
+		t.Errorf("gateway-only route wrote ifname: %v", c.Fields)
+	}
+
+	intent := n.GetIntent("route|Vrf_CUST1|0.0.0.0/0")
+	if intent == nil {
+		t.Fatal("route intent missing")
+	}
+	if got := intent.Params["next_hop"]; got != "10.1.0.1,10.1.0.2" {
+		t.Errorf("intent next_hop = %q, want both next-hops in order", got)
+	}
+}
+
+func TestAddStaticRouteMulti_InterfaceOnly(t *testing.T) {
+	n := staticRouteSetup(t)
+	ctx := context.Background()
+
+	cs, err := n.AddStaticRouteMulti(ctx, "Vrf_CUST1", "10.5.0.0/16", StaticRouteConfig{Interfaces: []string{"Ethernet0"}})
+	if err != nil {
+		t.Fatalf("AddStaticRouteMulti: %v", err)
+	}
+	c := assertChange(t, cs, "STATIC_ROUTE", "Vrf_CUST1|10.5.0.0/16", ChangeAdd)
+	assertField(t, c, "ifname", "Ethernet0")
+	if _, ok := c.Fields["nexthop"]; ok {
+		t.Errorf("interface-only route wrote nexthop: %v", c.Fields)
+	}
+
+	if _, err := n.AddStaticRouteMulti(ctx, "Vrf_CUST1", "10.6.0.0/16", StaticRouteConfig{Interfaces: []string{"Ethernet99"}}); err == nil {
+		t.Error("route out a missing interface should be refused")
+	}
+}
+
+func TestAddStaticRouteMulti_Blackhole(t *testing.T) {
+	n := staticRouteSetup(t)
+	ctx := context.Background()
+
+	cs, err := n.AddStaticRouteMulti(ctx, "", "192.0.2.0/24", StaticRouteConfig{Blackhole: true})
+	if err != nil {
+		t.Fatalf("AddStaticRouteMulti: %v", err)
+	}
+	c := assertChange(t, cs, "STATIC_ROUTE", "192.0.2.0/24", ChangeAdd)
+	assertField(t, c, "blackhole", "true")
+	if len(c.Fields) != 1 {
+		t.Errorf("blackhole route fields = %v, want blackhole only", c.Fields)
+	}
+	if got := n.GetIntent("route||192.0.2.0/24").Params["blackhole"]; got != "true" {
+		t.Errorf("intent blackhole = %q, want true", got)
+	}
+}
+
+func TestAddStaticRouteMulti_InvalidPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     StaticRouteConfig
+		wantErr string
+	}{
+		{"no path", StaticRouteConfig{}, "a next-hop, an interface, or blackhole is required"},
+		{"blackhole with next-hop", StaticRouteConfig{Blackhole: true, NextHops: []string{"10.1.0.1"}}, "takes no next-hop"},
+		{"unpaired interfaces", StaticRouteConfig{NextHops: []string{"10.1.0.1", "10.1.0.2"}, Interfaces: []string{"Ethernet0"}},
+			"2 next-hops but 1 interfaces"},
+		{"bad next-hop", StaticRouteConfig{NextHops: []string{"10.1.0.1", "gw"}}, `next-hop "gw"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := staticRouteSetup(t)
+			_, err := n.AddStaticRouteMulti(context.Background(), "Vrf_CUST1", "10.7.0.0/16", tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want %q", err, tt.wantErr)
+			}
+			if n.GetIntent("route|Vrf_CUST1|10.7.0.0/16") != nil {
+				t.Error("refused route left an intent")
+			}
+		})
+	}
+}
+
 func TestRoundTrip_BindUnbindMACVPN(t *testing.T) {
 	n := newTestAbstract()
 	ctx := context.Background()
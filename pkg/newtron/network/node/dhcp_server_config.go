@@ -0,0 +1,83 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// dhcpServerRangeName returns the DHCP_SERVER_IPV4_RANGE key of a VLAN's
+// index'th address range (1-based). Ranges are named for their VLAN and never
+// shared between VLANs.
+func dhcpServerRangeName(vlanID, index int) string {
+	return fmt.Sprintf("%s_range%d", VLANName(vlanID), index)
+}
+
+// dhcpServerOptionName returns the DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS key of
+// a VLAN's option code.
+func dhcpServerOptionName(vlanID, code int) string {
+	return fmt.Sprintf("%s_option%d", VLANName(vlanID), code)
+}
+
+// createDHCPServerConfig returns the CONFIG_DB entries that serve a VLAN from
+// the built-in DHCP server, parents first: the VLAN's custom options, its
+// address ranges, the DHCP_SERVER_IPV4 row (gateway, netmask, lease time,
+// options), and one DHCP_SERVER_IPV4_PORT row per served port offering every
+// range. cfg carries the resolved gateway, lease time, and ports. List fields
+// use SONiC's "@" leaf-list encoding.
+func createDHCPServerConfig(vlanID int, cfg DHCPServerConfig, netmask string) []sonic.Entry {
+	vlanName := VLANName(vlanID)
+	var entries []sonic.Entry
+
+	codes := make([]int, 0, len(cfg.Options))
+	for code := range cfg.Options {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	optionNames := make([]string, 0, len(codes))
+	for _, code := range codes {
+		name := dhcpServerOptionName(vlanID, code)
+		optionNames = append(optionNames, name)
+		entries = append(entries, sonic.Entry{Table: "DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS", Key: name, Fields: map[string]string{
+			"id":    strconv.Itoa(code),
+			"type":  "string",
+			"value": cfg.Options[code],
+		}})
+	}
+
+	rangeNames := make([]string, 0, len(cfg.Ranges))
+	for i, r := range cfg.Ranges {
+		name := dhcpServerRangeName(vlanID, i+1)
+		rangeNames = append(rangeNames, name)
+		first, last, _ := strings.Cut(r, "-")
+		bounds := first
+		if last != "" && last != first {
+			bounds += "," + last
+		}
+		entries = append(entries, sonic.Entry{Table: "DHCP_SERVER_IPV4_RANGE", Key: name, Fields: map[string]string{
+			"range@": bounds,
+		}})
+	}
+
+	server := map[string]string{
+		"gateway":    cfg.Gateway,
+		"lease_time": strconv.Itoa(cfg.LeaseTime),
+		"mode":       "PORT",
+		"netmask":    netmask,
+		"state":      "enabled",
+	}
+	if len(optionNames) > 0 {
+		server["customized_options@"] = strings.Join(optionNames, ",")
+	}
+	entries = append(entries, sonic.Entry{Table: "DHCP_SERVER_IPV4", Key: vlanName, Fields: server})
+
+	for _, port := range cfg.Ports {
+		entries = append(entries, sonic.Entry{Table: "DHCP_SERVER_IPV4_PORT", Key: vlanName + "|" + port, Fields: map[string]string{
+			"ranges@": strings.Join(rangeNames, ","),
+		}})
+	}
+	return entries
+}
@@ -0,0 +1,374 @@
+package node
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"maps"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// ============================================================================
+// Built-in DHCP server — SONiC's dhcp_server feature answering on a VLAN's
+// member ports from address ranges inside the VLAN's SVI subnet. This file
+// owns the DHCP_SERVER_IPV4* vocabulary (§28); the image must have the
+// dhcp_server feature enabled for the rows to take effect.
+// ============================================================================
+
+// defaultDHCPLeaseTime is the lease time, in seconds, when none is given.
+const defaultDHCPLeaseTime = 900
+
+// dhcpReservedOptions are the option codes the server derives itself — from
+// the netmask, gateway, and lease time, or as protocol framing — and that a
+// custom option must not override.
+var dhcpReservedOptions = map[int]string{
+	1: "subnet mask", 3: "router", 51: "lease time", 53: "message type", 54: "server identifier",
+}
+
+// DHCPServerConfig holds a VLAN's built-in DHCP server settings.
+type DHCPServerConfig struct {
+	Ranges    []string       // "first-last" (or single) IPv4 addresses inside the SVI subnet
+	Ports     []string       // member ports to serve; empty serves every current member
+	Gateway   string         // router option; empty means the SVI address
+	LeaseTime int            // seconds; 0 means defaultDHCPLeaseTime
+	Options   map[int]string // custom options by code, sent as text
+}
+
+// dhcpServerResource returns the intent key of a VLAN's DHCP server.
+func dhcpServerResource(vlanID int) string {
+	return "dhcp-server|" + VLANName(vlanID)
+}
+
+// ConfigureDHCPServer serves a VLAN's member ports from the built-in DHCP
+// server. The VLAN must have an IRB address (configure-irb): every range must
+// fall inside its subnet, clear of the network and broadcast addresses and of
+// the SVI and gateway addresses, and ranges must not overlap. The netmask is
+// the SVI's; the gateway defaults to the SVI address.
+//
+// Ports not given are resolved to the VLAN's members now and recorded, so a
+// port that joins the VLAN later is served only after configuring again.
+// Intent-idempotent: the same settings again return an empty ChangeSet;
+// different settings replace the VLAN's server rows in place.
+func (n *Node) ConfigureDHCPServer(ctx context.Context, vlanID int, cfg DHCPServerConfig) (*ChangeSet, error) {
+	resource := dhcpServerResource(vlanID)
+	if len(cfg.Ports) == 0 {
+		cfg.Ports = n.vlanMemberPorts(vlanID)
+	}
+	params := dhcpServerParams(vlanID, cfg)
+	if existing := n.GetIntent(resource); existing != nil && maps.Equal(existing.Params, params) {
+		return NewChangeSet(n.name, "device."+sonic.OpConfigureDHCPServer), nil
+	}
+
+	var sviCIDR string
+	if irb := n.GetIntent("interface|" + VLANName(vlanID)); irb != nil {
+		sviCIDR = irb.Params[sonic.FieldIPAddress]
+	}
+	var problems, nonMembers []string
+	if sviCIDR != "" {
+		problems = dhcpServerProblems(sviCIDR, cfg)
+	}
+	// Membership is checked when authored; replay may run before the
+	// membership intents it was authored against.
+	if !n.reconstructing {
+		for _, port := range cfg.Ports {
+			if _, ok := n.configDB.VLANMember[VLANMemberKey(vlanID, port)]; !ok {
+				nonMembers = append(nonMembers, port)
+			}
+		}
+	}
+	if err := n.precondition(sonic.OpConfigureDHCPServer, resource).
+		RequireVLANExists(vlanID).
+		Check(sviCIDR != "", "VLAN has an IRB address",
+			fmt.Sprintf("VLAN %d has no IRB address — configure-irb with an IP first", vlanID)).
+		Check(len(cfg.Ranges) > 0, "address ranges given", "at least one address range is required").
+		Check(len(cfg.Ports) > 0, "ports to serve", fmt.Sprintf("VLAN %d has no member ports to serve", vlanID)).
+		Check(len(nonMembers) == 0, "ports are VLAN members",
+			fmt.Sprintf("not members of VLAN %d: %s", vlanID, strings.Join(nonMembers, ", "))).
+		Check(len(problems) == 0, "valid DHCP server settings", strings.Join(problems, "; ")).
+		Result(); err != nil {
+		return nil, err
+	}
+
+	sviIP, subnet, _ := net.ParseCIDR(sviCIDR)
+	if cfg.Gateway == "" {
+		cfg.Gateway = sviIP.String()
+	}
+	if cfg.LeaseTime == 0 {
+		cfg.LeaseTime = defaultDHCPLeaseTime
+	}
+
+	cs := NewChangeSet(n.name, "device."+sonic.OpConfigureDHCPServer)
+	cs.ReverseOp = "device." + sonic.OpUnconfigureDHCPServer
+	cs.OperationParams = params
+	if err := n.writeIntent(cs, sonic.OpConfigureDHCPServer, resource, params, []string{"interface|" + VLANName(vlanID)}); err != nil {
+		return nil, err
+	}
+	cs.Replace(n, n.dhcpServerRows(vlanID), createDHCPServerConfig(vlanID, cfg, net.IP(subnet.Mask).String()))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Configured DHCP server on VLAN %d (%d range(s), %d port(s))", vlanID, len(cfg.Ranges), len(cfg.Ports))
+	return cs, nil
+}
+
+// UnconfigureDHCPServer stops serving a VLAN from the built-in DHCP server,
+// removing its server, range, option, and port rows. Leases already granted
+// run out on their own. Reverse of ConfigureDHCPServer (§15).
+func (n *Node) UnconfigureDHCPServer(ctx context.Context, vlanID int) (*ChangeSet, error) {
+	resource := dhcpServerResource(vlanID)
+	if err := n.precondition(sonic.OpUnconfigureDHCPServer, resource).Result(); err != nil {
+		return nil, err
+	}
+	if n.GetIntent(resource) == nil {
+		return nil, fmt.Errorf("no DHCP server on VLAN %d", vlanID)
+	}
+
+	cs := NewChangeSet(n.name, "device."+sonic.OpUnconfigureDHCPServer)
+	cs.OperationParams = map[string]string{sonic.FieldVLANID: strconv.Itoa(vlanID)}
+	rows := n.dhcpServerRows(vlanID)
+	for i := len(rows) - 1; i >= 0; i-- {
+		cs.Delete(rows[i].Table, rows[i].Key)
+	}
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Unconfigured DHCP server on VLAN %d", vlanID)
+	return cs, nil
+}
+
+// dhcpServerParams returns the intent params of a VLAN's DHCP server: the
+// settings as given (defaults are not recorded), with ports resolved. Lists
+// are CSV; options are "code=value" pairs sorted by code.
+func dhcpServerParams(vlanID int, cfg DHCPServerConfig) map[string]string {
+	params := map[string]string{
+		sonic.FieldVLANID: strconv.Itoa(vlanID),
+		sonic.FieldRanges: strings.Join(cfg.Ranges, ","),
+		sonic.FieldPorts:  strings.Join(cfg.Ports, ","),
+	}
+	if cfg.Gateway != "" {
+		params[sonic.FieldGateway] = cfg.Gateway
+	}
+	if cfg.LeaseTime != 0 {
+		params[sonic.FieldLeaseTime] = strconv.Itoa(cfg.LeaseTime)
+	}
+	if len(cfg.Options) > 0 {
+		codes := make([]int, 0, len(cfg.Options))
+		for code := range cfg.Options {
+			codes = append(codes, code)
+		}
+		sort.Ints(codes)
+		pairs := make([]string, 0, len(codes))
+		for _, code := range codes {
+			pairs = append(pairs, fmt.Sprintf("%d=%s", code, cfg.Options[code]))
+		}
+		params[sonic.FieldOptions] = strings.Join(pairs, ",")
+	}
+	return params
+}
+
+// parseDHCPOptions is the inverse of the options param: "code=value" pairs,
+// comma-separated.
+func parseDHCPOptions(s string) map[int]string {
+	if s == "" {
+		return nil
+	}
+	opts := map[int]string{}
+	for _, pair := range strings.Split(s, ",") {
+		code, value, _ := strings.Cut(pair, "=")
+		if c, err := strconv.Atoi(code); err == nil {
+			opts[c] = value
+		}
+	}
+	return opts
+}
+
+// dhcpServerProblems returns every way cfg does not fit the SVI subnet
+// sviCIDR, or nil.
+func dhcpServerProblems(sviCIDR string, cfg DHCPServerConfig) []string {
+	sviIP, subnet, err := net.ParseCIDR(sviCIDR)
+	if err != nil || sviIP.To4() == nil {
+		return []string{fmt.Sprintf("IRB address %q is not an IPv4 prefix", sviCIDR)}
+	}
+	ones, _ := subnet.Mask.Size()
+	lo, hi := ipv4Uint(subnet.IP), ipv4Uint(subnet.IP)|^binary.BigEndian.Uint32(subnet.Mask)
+	if ones < 31 {
+		lo, hi = lo+1, hi-1 // network and broadcast addresses are not leasable
+	}
+	inSubnet := func(ip uint32) bool { return ip >= lo && ip <= hi }
+
+	var problems []string
+	svi := ipv4Uint(sviIP)
+	var gw uint32
+	if cfg.Gateway != "" {
+		ip := net.ParseIP(cfg.Gateway).To4()
+		if ip == nil || !inSubnet(ipv4Uint(ip)) {
+			problems = append(problems, fmt.Sprintf("gateway %s is not an address in %s", cfg.Gateway, subnet))
+		} else {
+			gw = ipv4Uint(ip)
+		}
+	}
+
+	type span struct {
+		text        string
+		first, last uint32
+	}
+	var spans []span
+	for _, r := range cfg.Ranges {
+		first, last, ok := parseDHCPRange(r)
+		switch {
+		case !ok:
+			problems = append(problems, fmt.Sprintf("range %q is not first-last IPv4 addresses", r))
+			continue
+		case first > last:
+			problems = append(problems, fmt.Sprintf("range %s ends before it starts", r))
+			continue
+		case !inSubnet(first) || !inSubnet(last):
+			problems = append(problems, fmt.Sprintf("range %s is not within the leasable addresses of %s", r, subnet))
+			continue
+		case svi >= first && svi <= last:
+			problems = append(problems, fmt.Sprintf("range %s includes the SVI address %s", r, sviIP))
+			continue
+		case gw != 0 && gw >= first && gw <= last:
+			problems = append(problems, fmt.Sprintf("range %s includes the gateway %s", r, cfg.Gateway))
+			continue
+		}
+		spans = append(spans, span{r, first, last})
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].first < spans[j].first })
+	for i := 1; i < len(spans); i++ {
+		if spans[i].first <= spans[i-1].last {
+			problems = append(problems, fmt.Sprintf("ranges %s and %s overlap", spans[i-1].text, spans[i].text))
+		}
+	}
+
+	if cfg.LeaseTime < 0 {
+		problems = append(problems, fmt.Sprintf("lease time must not be negative, got %d", cfg.LeaseTime))
+	}
+	for code, value := range cfg.Options {
+		switch {
+		case code < 1 || code > 254:
+			problems = append(problems, fmt.Sprintf("option code %d is not 1-254", code))
+		case dhcpReservedOptions[code] != "":
+			problems = append(problems, fmt.Sprintf("option %d (%s) is set by the server", code, dhcpReservedOptions[code]))
+		case value == "" || strings.ContainsAny(value, ",="):
+			problems = append(problems, fmt.Sprintf("option %d value %q must be non-empty text without ',' or '='", code, value))
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// parseDHCPRange parses "first-last" or a single address.
+func parseDHCPRange(r string) (first, last uint32, ok bool) {
+	a, b, found := strings.Cut(r, "-")
+	if !found {
+		b = a
+	}
+	ipA, ipB := net.ParseIP(strings.TrimSpace(a)).To4(), net.ParseIP(strings.TrimSpace(b)).To4()
+	if ipA == nil || ipB == nil {
+		return 0, 0, false
+	}
+	return ipv4Uint(ipA), ipv4Uint(ipB), true
+}
+
+func ipv4Uint(ip net.IP) uint32 {
+	return binary.BigEndian.Uint32(ip.To4())
+}
+
+// dhcpServerRows returns the VLAN's DHCP server rows as they stand in the
+// projection, in createDHCPServerConfig's parents-first order.
+func (n *Node) dhcpServerRows(vlanID int) []sonic.Entry {
+	proj := n.Projection()
+	vlanName := VLANName(vlanID)
+	var rows []sonic.Entry
+	collect := func(table, prefix string) {
+		var keys []string
+		for key := range proj[table] {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			rows = append(rows, sonic.Entry{Table: table, Key: key, Fields: proj[table][key]})
+		}
+	}
+	collect("DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS", vlanName+"_option")
+	collect("DHCP_SERVER_IPV4_RANGE", vlanName+"_range")
+	if fields, ok := proj["DHCP_SERVER_IPV4"][vlanName]; ok {
+		rows = append(rows, sonic.Entry{Table: "DHCP_SERVER_IPV4", Key: vlanName, Fields: fields})
+	}
+	collect("DHCP_SERVER_IPV4_PORT", vlanName+"|")
+	return rows
+}
+
+// ============================================================================
+// Leases
+// ============================================================================
+
+// DHCPLease is one address the built-in DHCP server has leased, as recorded
+// in STATE_DB DHCP_SERVER_IPV4_LEASE.
+type DHCPLease struct {
+	VLAN  string
+	MAC   string
+	IP    string
+	Start time.Time
+	End   time.Time
+}
+
+// GetDHCPLeases reads the built-in DHCP server's leases on a VLAN — every
+// VLAN when vlanID is 0 — sorted by VLAN, then IP. Pure observation (§4).
+func (n *Node) GetDHCPLeases(ctx context.Context, vlanID int) ([]DHCPLease, error) {
+	rows, err := n.OperDBTable(ctx, "STATE_DB", "DHCP_SERVER_IPV4_LEASE")
+	if err != nil {
+		return nil, fmt.Errorf("reading STATE_DB DHCP_SERVER_IPV4_LEASE: %w", err)
+	}
+	return buildDHCPLeases(rows, vlanID), nil
+}
+
+// buildDHCPLeases converts DHCP_SERVER_IPV4_LEASE rows, keyed
+// "Vlan<N>|<mac>" with epoch-second lease_start/lease_end, filtered to vlanID
+// unless it is 0.
+func buildDHCPLeases(rows map[string]map[string]string, vlanID int) []DHCPLease {
+	leases := []DHCPLease{}
+	for key, f := range rows {
+		vlan, mac, ok := strings.Cut(key, "|")
+		if !ok || (vlanID != 0 && vlan != VLANName(vlanID)) {
+			continue
+		}
+		lease := DHCPLease{VLAN: vlan, MAC: strings.ToLower(mac), IP: f["ip"]}
+		if s, err := strconv.ParseInt(f["lease_start"], 10, 64); err == nil {
+			lease.Start = time.Unix(s, 0).UTC()
+		}
+		if e, err := strconv.ParseInt(f["lease_end"], 10, 64); err == nil {
+			lease.End = time.Unix(e, 0).UTC()
+		}
+		leases = append(leases, lease)
+	}
+	sort.Slice(leases, func(i, j int) bool {
+		if leases[i].VLAN != leases[j].VLAN {
+			return leases[i].VLAN < leases[j].VLAN
+		}
+		return ipLess(leases[i].IP, leases[j].IP)
+	})
+	return leases
+}
+
+// ipLess orders dotted IPv4 addresses numerically, unparsable ones last.
+func ipLess(a, b string) bool {
+	ipA, ipB := net.ParseIP(a).To4(), net.ParseIP(b).To4()
+	if ipA == nil || ipB == nil {
+		return ipA != nil || (ipB == nil && a < b)
+	}
+	return ipv4Uint(ipA) < ipv4Uint(ipB)
+}
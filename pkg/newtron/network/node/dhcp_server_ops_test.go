@@ -0,0 +1,148 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// dhcpServerDevice returns testDevice with VLAN 100 carrying IRB address
+// 192.168.100.1/24 and access member Ethernet4, built through the ops so the
+// intents ConfigureDHCPServer reads are the ones they write.
+func dhcpServerDevice(t *testing.T) *Node {
+	t.Helper()
+	ctx := context.Background()
+	n := testDevice()
+	if _, err := n.CreateVLAN(ctx, 100, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+	if _, err := n.ConfigureIRB(ctx, 100, IRBConfig{IPAddress: "192.168.100.1/24"}); err != nil {
+		t.Fatalf("ConfigureIRB: %v", err)
+	}
+	i, err := n.GetInterface("Ethernet4")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+	if _, err := i.ConfigureInterface(ctx, InterfaceConfig{VLAN: 100}); err != nil {
+		t.Fatalf("ConfigureInterface: %v", err)
+	}
+	return n
+}
+
+func TestDHCPServer_ConfigureUnconfigure(t *testing.T) {
+	ctx := context.Background()
+	n := dhcpServerDevice(t)
+
+	cfg := DHCPServerConfig{
+		Ranges:  []string{"192.168.100.50-192.168.100.99", "192.168.100.200"},
+		Options: map[int]string{66: "tftp.example"},
+	}
+	cs, err := n.ConfigureDHCPServer(ctx, 100, cfg)
+	if err != nil {
+		t.Fatalf("ConfigureDHCPServer: %v", err)
+	}
+	server := assertChange(t, cs, "DHCP_SERVER_IPV4", "Vlan100", ChangeReplace)
+	assertField(t, server, "gateway", "192.168.100.1")
+	assertField(t, server, "netmask", "255.255.255.0")
+	assertField(t, server, "lease_time", "900")
+	assertField(t, server, "mode", "PORT")
+	assertField(t, server, "customized_options@", "Vlan100_option66")
+	assertField(t, assertChange(t, cs, "DHCP_SERVER_IPV4_RANGE", "Vlan100_range1", ChangeReplace), "range@", "192.168.100.50,192.168.100.99")
+	assertField(t, assertChange(t, cs, "DHCP_SERVER_IPV4_RANGE", "Vlan100_range2", ChangeReplace), "range@", "192.168.100.200")
+	assertField(t, assertChange(t, cs, "DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS", "Vlan100_option66", ChangeReplace), "value", "tftp.example")
+	assertField(t, assertChange(t, cs, "DHCP_SERVER_IPV4_PORT", "Vlan100|Ethernet4", ChangeReplace), "ranges@", "Vlan100_range1,Vlan100_range2")
+	assertChange(t, cs, "NEWTRON_INTENT", "dhcp-server|Vlan100", ChangeAdd)
+
+	// The same settings again are intent-idempotent.
+	cs, err = n.ConfigureDHCPServer(ctx, 100, cfg)
+	if err != nil || !cs.IsEmpty() {
+		t.Fatalf("repeated ConfigureDHCPServer: cs=%v err=%v, want empty ChangeSet", cs, err)
+	}
+
+	// New settings replace the VLAN's rows: the dropped range and option go.
+	cs, err = n.ConfigureDHCPServer(ctx, 100, DHCPServerConfig{Ranges: []string{"192.168.100.50-192.168.100.99"}, LeaseTime: 3600})
+	if err != nil {
+		t.Fatalf("second ConfigureDHCPServer: %v", err)
+	}
+	assertNoChange(t, cs, "DHCP_SERVER_IPV4_RANGE", "Vlan100_range1")
+	assertChange(t, cs, "DHCP_SERVER_IPV4_RANGE", "Vlan100_range2", ChangeDelete)
+	assertChange(t, cs, "DHCP_SERVER_IPV4_CUSTOMIZED_OPTIONS", "Vlan100_option66", ChangeDelete)
+	assertField(t, assertChange(t, cs, "DHCP_SERVER_IPV4", "Vlan100", ChangeReplace), "lease_time", "3600")
+
+	cs, err = n.UnconfigureDHCPServer(ctx, 100)
+	if err != nil {
+		t.Fatalf("UnconfigureDHCPServer: %v", err)
+	}
+	assertChange(t, cs, "DHCP_SERVER_IPV4", "Vlan100", ChangeDelete)
+	assertChange(t, cs, "DHCP_SERVER_IPV4_RANGE", "Vlan100_range1", ChangeDelete)
+	assertChange(t, cs, "DHCP_SERVER_IPV4_PORT", "Vlan100|Ethernet4", ChangeDelete)
+	assertChange(t, cs, "NEWTRON_INTENT", "dhcp-server|Vlan100", ChangeDelete)
+
+	if _, err := n.UnconfigureDHCPServer(ctx, 100); err == nil {
+		t.Error("UnconfigureDHCPServer with no server should fail")
+	}
+}
+
+func TestDHCPServer_Preconditions(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		vlanID  int
+		cfg     DHCPServerConfig
+		wantErr string
+	}{
+		{name: "unknown VLAN", vlanID: 300, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.50"}}, wantErr: "300"},
+		{name: "no ranges", vlanID: 100, wantErr: "at least one address range"},
+		{name: "outside subnet", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.101.10-192.168.101.20"}}, wantErr: "not within the leasable addresses"},
+		{name: "broadcast", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.200-192.168.100.255"}}, wantErr: "not within the leasable addresses"},
+		{name: "reversed", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.20-192.168.100.10"}}, wantErr: "ends before it starts"},
+		{name: "includes SVI", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.1-192.168.100.20"}}, wantErr: "includes the SVI address"},
+		{name: "includes gateway", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.2-192.168.100.20"}, Gateway: "192.168.100.10"}, wantErr: "includes the gateway"},
+		{name: "overlap", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.10-192.168.100.30", "192.168.100.30-192.168.100.40"}}, wantErr: "overlap"},
+		{name: "reserved option", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.50"}, Options: map[int]string{3: "192.168.100.9"}}, wantErr: "option 3 (router) is set by the server"},
+		{name: "non-member port", vlanID: 100, cfg: DHCPServerConfig{Ranges: []string{"192.168.100.50"}, Ports: []string{"Ethernet0"}}, wantErr: "not members of VLAN 100: Ethernet0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := dhcpServerDevice(t).ConfigureDHCPServer(ctx, tt.vlanID, tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ConfigureDHCPServer(%d) error = %v, want %q", tt.vlanID, err, tt.wantErr)
+			}
+		})
+	}
+
+	// A VLAN without an IRB address has no subnet to serve from.
+	n := testDevice()
+	if _, err := n.CreateVLAN(ctx, 100, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+	if _, err := n.ConfigureDHCPServer(ctx, 100, DHCPServerConfig{Ranges: []string{"192.168.100.50"}}); err == nil || !strings.Contains(err.Error(), "no IRB address") {
+		t.Errorf("ConfigureDHCPServer without IRB error = %v, want no IRB address", err)
+	}
+}
+
+func TestBuildDHCPLeases(t *testing.T) {
+	rows := map[string]map[string]string{
+		"Vlan100|aa:bb:cc:dd:ee:02": {"ip": "192.168.100.60", "lease_start": "1700000000", "lease_end": "1700000900"},
+		"Vlan100|aa:bb:cc:dd:ee:01": {"ip": "192.168.100.9", "lease_start": "1700000000", "lease_end": "1700000900"},
+		"Vlan200|aa:bb:cc:dd:ee:03": {"ip": "10.2.0.5"},
+		"malformed":                 {"ip": "10.9.9.9"},
+	}
+
+	all := buildDHCPLeases(rows, 0)
+	if len(all) != 3 {
+		t.Fatalf("buildDHCPLeases(0) returned %d leases, want 3: %+v", len(all), all)
+	}
+	// Sorted by VLAN, then numerically by address (.9 before .60).
+	if all[0].IP != "192.168.100.9" || all[1].IP != "192.168.100.60" || all[2].VLAN != "Vlan200" {
+		t.Errorf("lease order = %+v", all)
+	}
+	if all[0].MAC != "aa:bb:cc:dd:ee:01" || !all[0].End.Equal(time.Unix(1700000900, 0)) {
+		t.Errorf("lease[0] = %+v", all[0])
+	}
+
+	if got := buildDHCPLeases(rows, 200); len(got) != 1 || got[0].IP != "10.2.0.5" {
+		t.Errorf("buildDHCPLeases(200) = %+v", got)
+	}
+}
@@ -0,0 +1,76 @@
+package node
+
+import (
+	"context"
+	"maps"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// cloneRaw deep-copies a RawConfigDB so a test can edit the "device" side
+// without touching the projection it was taken from.
+func cloneRaw(raw sonic.RawConfigDB) sonic.RawConfigDB {
+	out := make(sonic.RawConfigDB, len(raw))
+	for table, rows := range raw {
+		out[table] = make(map[string]map[string]string, len(rows))
+		for key, fields := range rows {
+			out[table][key] = maps.Clone(fields)
+		}
+	}
+	return out
+}
+
+// TestDrift_DeletedBGPNeighbor: a BGP peer removed out of band (redis-cli
+// DEL on the device) is reported as missing, and nothing else is.
+func TestDrift_DeletedBGPNeighbor(t *testing.T) {
+	n := newTestAbstract()
+	n.SpecProvider.(*testSpecProvider).services["TRANSIT"] = &spec.ServiceSpec{
+		ServiceType: spec.ServiceTypeRouted,
+		Routing:     &spec.RoutingSpec{Protocol: spec.RoutingProtocolBGP, PeerAS: "65002"},
+	}
+	intf, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+	if _, err := intf.ApplyService(context.Background(), "TRANSIT", ApplyServiceOpts{IPAddress: "10.1.0.0/31"}); err != nil {
+		t.Fatalf("ApplyService: %v", err)
+	}
+
+	actual := cloneRaw(n.configDB.ExportRaw())
+	if drift := n.driftFrom(actual); len(drift) != 0 {
+		t.Fatalf("device matching the projection drifted: %+v", drift)
+	}
+
+	key := BGPNeighborKey("default", "10.1.0.1")
+	if _, ok := actual["BGP_NEIGHBOR"][key]; !ok {
+		t.Fatalf("projection has no BGP_NEIGHBOR|%s", key)
+	}
+	delete(actual["BGP_NEIGHBOR"], key)
+
+	drift := n.driftFrom(actual)
+	if len(drift) != 1 {
+		t.Fatalf("drift = %+v, want exactly the deleted neighbor", drift)
+	}
+	if d := drift[0]; d.Table != "BGP_NEIGHBOR" || d.Key != key || d.Type != "missing" || d.Expected["asn"] != "65002" {
+		t.Errorf("drift entry = %+v, want missing BGP_NEIGHBOR|%s with asn 65002", d, key)
+	}
+}
+
+// TestDrift_IgnoresDeviceNativeTables: entries newtron does not own — a
+// factory PORT row, platform DEVICE_METADATA, a table outside the schema —
+// are not reported, while an out-of-band entry in an owned table is.
+func TestDrift_IgnoresDeviceNativeTables(t *testing.T) {
+	n := newTestAbstract()
+	actual := cloneRaw(n.configDB.ExportRaw())
+	actual["PORT"] = map[string]map[string]string{"Ethernet124": {"admin_status": "down"}}
+	actual["DEVICE_METADATA"] = map[string]map[string]string{"localhost": {"hwsku": "Force10-S6000"}}
+	actual["FEATURE"] = map[string]map[string]string{"lldp": {"state": "enabled"}}
+	actual["VLAN"] = map[string]map[string]string{"Vlan999": {"vlanid": "999"}}
+
+	drift := n.driftFrom(actual)
+	if len(drift) != 1 || drift[0].Table != "VLAN" || drift[0].Type != "extra" {
+		t.Errorf("drift = %+v, want only the extra VLAN|Vlan999", drift)
+	}
+}
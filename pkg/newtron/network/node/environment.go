@@ -0,0 +1,140 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// Platform environment — PSU, fan, and thermal sensor state as pmon's daemons
+// (psud, thermalctld) publish it to STATE_DB. Pure observation (§4): values
+// are reported as written; judging them is the caller's business. This file
+// owns the PSU_INFO / FAN_INFO / TEMPERATURE_INFO field knowledge (§28).
+//
+// Virtual platforms run no sensor daemons: the tables are absent and the
+// read returns an empty Environment, not an error.
+// ============================================================================
+
+// Environment is a device's PSU, fan, and thermal sensor state.
+type Environment struct {
+	PSUs     []PSUStatus
+	Fans     []FanStatus
+	Thermals []ThermalStatus
+}
+
+// Empty reports whether the device published no sensors at all — the
+// virtual-platform case.
+func (e *Environment) Empty() bool {
+	return len(e.PSUs) == 0 && len(e.Fans) == 0 && len(e.Thermals) == 0
+}
+
+// PSUStatus is one STATE_DB PSU_INFO entry.
+type PSUStatus struct {
+	Name      string
+	Model     string
+	Present   bool
+	PowerGood bool // PSU_INFO status
+	Voltage   float64
+	Power     float64
+}
+
+// FanStatus is one STATE_DB FAN_INFO entry. Speed is a percentage of full.
+type FanStatus struct {
+	Name       string
+	Drawer     string
+	Present    bool
+	OK         bool // FAN_INFO status
+	Speed      int
+	UnderSpeed bool
+	OverSpeed  bool
+}
+
+// ThermalStatus is one STATE_DB TEMPERATURE_INFO entry, in °C. A zero
+// threshold means the sensor reports none.
+type ThermalStatus struct {
+	Name                  string
+	Temperature           float64
+	HighThreshold         float64
+	CriticalHighThreshold float64
+	Warning               bool // thermalctld's warning_status
+}
+
+// GetEnvironment reads the device's PSU, fan, and thermal sensors from
+// STATE_DB, each list sorted by name.
+func (n *Node) GetEnvironment(ctx context.Context) (*Environment, error) {
+	tables := make(map[string]map[string]map[string]string, 3)
+	for _, table := range []string{"PSU_INFO", "FAN_INFO", "TEMPERATURE_INFO"} {
+		entries, err := n.OperDBTable(ctx, "STATE_DB", table)
+		if err != nil {
+			return nil, fmt.Errorf("reading STATE_DB %s: %w", table, err)
+		}
+		tables[table] = entries
+	}
+	return buildEnvironment(tables["PSU_INFO"], tables["FAN_INFO"], tables["TEMPERATURE_INFO"]), nil
+}
+
+// buildEnvironment curates the three sensor tables. Keys "" (a table's
+// flat-hash form) carry no sensor and are skipped. Readings a daemon reports
+// as "N/A" parse as zero.
+func buildEnvironment(psus, fans, temps map[string]map[string]string) *Environment {
+	env := &Environment{}
+	for _, name := range sortedSensorNames(psus) {
+		f := psus[name]
+		env.PSUs = append(env.PSUs, PSUStatus{
+			Name:      name,
+			Model:     f["model"],
+			Present:   sensorBool(f["presence"]),
+			PowerGood: sensorBool(f["status"]),
+			Voltage:   sensorFloat(f["voltage"]),
+			Power:     sensorFloat(f["power"]),
+		})
+	}
+	for _, name := range sortedSensorNames(fans) {
+		f := fans[name]
+		env.Fans = append(env.Fans, FanStatus{
+			Name:       name,
+			Drawer:     f["drawer_name"],
+			Present:    sensorBool(f["presence"]),
+			OK:         sensorBool(f["status"]),
+			Speed:      int(sensorFloat(f["speed"])),
+			UnderSpeed: sensorBool(f["is_under_speed"]),
+			OverSpeed:  sensorBool(f["is_over_speed"]),
+		})
+	}
+	for _, name := range sortedSensorNames(temps) {
+		f := temps[name]
+		env.Thermals = append(env.Thermals, ThermalStatus{
+			Name:                  name,
+			Temperature:           sensorFloat(f["temperature"]),
+			HighThreshold:         sensorFloat(f["high_threshold"]),
+			CriticalHighThreshold: sensorFloat(f["critical_high_threshold"]),
+			Warning:               sensorBool(f["warning_status"]),
+		})
+	}
+	return env
+}
+
+func sortedSensorNames(entries map[string]map[string]string) []string {
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sensorBool parses pmon's booleans, which are written as "True"/"False" by
+// some daemons and "true"/"false" by others.
+func sensorBool(v string) bool {
+	return strings.EqualFold(v, "true")
+}
+
+func sensorFloat(v string) float64 {
+	f, _ := strconv.ParseFloat(v, 64)
+	return f
+}
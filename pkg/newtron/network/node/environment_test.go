@@ -0,0 +1,49 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildEnvironment(t *testing.T) {
+	psus := map[string]map[string]string{
+		"PSU 2": {"presence": "false", "status": "false"},
+		"PSU 1": {"presence": "true", "status": "true", "model": "PWR-500AC", "voltage": "12.02", "power": "181.5"},
+	}
+	fans := map[string]map[string]string{
+		"fan1": {"presence": "True", "status": "True", "speed": "42", "drawer_name": "drawer1",
+			"is_under_speed": "False", "is_over_speed": "False"},
+		"fan2": {"presence": "True", "status": "False", "speed": "N/A", "is_under_speed": "True"},
+	}
+	temps := map[string]map[string]string{
+		"":     {"flat": "hash"},
+		"ASIC": {"temperature": "92.5", "high_threshold": "90.0", "critical_high_threshold": "105.0", "warning_status": "True"},
+	}
+
+	got := buildEnvironment(psus, fans, temps)
+	want := &Environment{
+		PSUs: []PSUStatus{
+			{Name: "PSU 1", Model: "PWR-500AC", Present: true, PowerGood: true, Voltage: 12.02, Power: 181.5},
+			{Name: "PSU 2"},
+		},
+		Fans: []FanStatus{
+			{Name: "fan1", Drawer: "drawer1", Present: true, OK: true, Speed: 42},
+			{Name: "fan2", Present: true, UnderSpeed: true},
+		},
+		Thermals: []ThermalStatus{
+			{Name: "ASIC", Temperature: 92.5, HighThreshold: 90, CriticalHighThreshold: 105, Warning: true},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildEnvironment =\n  %+v\nwant\n  %+v", got, want)
+	}
+	if got.Empty() {
+		t.Error("Empty() = true for a populated environment")
+	}
+}
+
+func TestBuildEnvironment_VirtualPlatform(t *testing.T) {
+	if env := buildEnvironment(nil, nil, nil); !env.Empty() {
+		t.Errorf("buildEnvironment(no tables) = %+v, want empty", env)
+	}
+}
@@ -3,7 +3,9 @@ package node
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 	"github.com/aldrin-isaac/newtron/pkg/util"
@@ -20,6 +22,67 @@ func (n *Node) VTEPSourceIP() string {
 	return n.resolved.LoopbackIP
 }
 
+// ============================================================================
+// VNI Uniqueness
+// ============================================================================
+
+// vniOwners indexes the projection's VNI mappings: l2 maps each VNI to the
+// VLANs a VXLAN_TUNNEL_MAP entry binds to it, l3 to the VRFs that carry it as
+// their L3VNI (VRF|vni, or a VRF-targeted tunnel map). An L3VNI's transit VLAN
+// is an L2 owner of the same VNI — that pairing is how BindIPVPN builds it.
+func (n *Node) vniOwners() (l2, l3 map[string][]string) {
+	l2, l3 = map[string][]string{}, map[string][]string{}
+	if n.configDB == nil {
+		return l2, l3
+	}
+	for _, m := range n.configDB.VXLANTunnelMap {
+		switch {
+		case m.VLAN != "":
+			l2[m.VNI] = append(l2[m.VNI], m.VLAN)
+		case m.VRF != "":
+			l3[m.VNI] = appendUnique(l3[m.VNI], m.VRF)
+		}
+	}
+	for name, vrf := range n.configDB.VRF {
+		if vrf.VNI != "" {
+			l3[vrf.VNI] = appendUnique(l3[vrf.VNI], name)
+		}
+	}
+	return l2, l3
+}
+
+// ValidateVNIs reports every VNI the device maps more than once: an L2VNI
+// bound to two VLANs, or an L3VNI carried by two VRFs. EVPN forwards a VNI
+// into exactly one bridge domain or VRF, so a duplicate silently blackholes
+// one side. Checks the projection; nil means the mappings are unique.
+func (n *Node) ValidateVNIs() []error {
+	l2, l3 := n.vniOwners()
+	var errs []error
+	for _, c := range []struct {
+		kind, what string
+		owners     map[string][]string
+	}{{"L2VNI", "VLANs", l2}, {"L3VNI", "VRFs", l3}} {
+		vnis := make([]string, 0, len(c.owners))
+		for vni, owners := range c.owners {
+			if len(owners) > 1 {
+				vnis = append(vnis, vni)
+			}
+		}
+		sort.Slice(vnis, func(i, j int) bool {
+			a, _ := strconv.Atoi(vnis[i])
+			b, _ := strconv.Atoi(vnis[j])
+			return a < b
+		})
+		for _, vni := range vnis {
+			owners := c.owners[vni]
+			sort.Strings(owners)
+			errs = append(errs, fmt.Errorf("%s %s is mapped to %d %s (%s); a VNI must map to exactly one",
+				c.kind, vni, len(owners), c.what, strings.Join(owners, ", ")))
+		}
+	}
+	return errs
+}
+
 // ============================================================================
 // EVPN Operations
 // ============================================================================
@@ -37,7 +100,8 @@ func (n *Node) BindMACVPN(ctx context.Context, vlanID int, macvpnName string) (*
 	vni := macvpnDef.VNI
 	cs, err := n.op(sonic.OpBindMACVPN, vlanResource(vlanID), ChangeAdd,
 		func(pc *PreconditionChecker) {
-			pc.RequireVTEPConfigured().RequireVLANExists(vlanID)
+			pc.RequireVTEPConfigured().RequireVLANExists(vlanID).
+				RequireVNIUnmapped(vni, VLANName(vlanID)).RequireL3VNIUnbound(vni, "")
 			// Check platform support for EVPN VXLAN
 			resolved := n.Resolved()
 			if resolved.Platform != "" {
@@ -0,0 +1,205 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// EVPN MAC/IP (type-2) routes — what BGP holds in one L2VNI's table. Pure
+// observation (§4).
+//
+// A MAC learned on a local port is advertised by zebra/bgpd as a type-2
+// route (MAC, optionally with the host's IP); a remote leaf imports it into
+// the same VNI's table, next hop the advertising VTEP. Only FRR sees both
+// halves — APP_DB VXLAN_FDB_TABLE has the imported MACs but neither the
+// locally originated routes nor the IPs — so the read is vtysh.
+// ============================================================================
+
+// EVPNMACIPRoute is one type-2 route in a VNI's EVPN table.
+type EVPNMACIPRoute struct {
+	VNI     int
+	MAC     string // lower-case, colon-separated
+	IP      string // empty for a MAC-only route
+	NextHop string // the advertising VTEP; empty for a locally originated route
+	Local   bool   // originated by this device
+}
+
+// GetEVPNMACIPRoutes reads the type-2 routes in vni's EVPN table ("show bgp
+// l2vpn evpn route vni <vni> type macip json"), sorted by MAC then IP. A
+// route with several paths is listed once, from its best path.
+// Auto-connects transport if needed.
+func (n *Node) GetEVPNMACIPRoutes(ctx context.Context, vni int) ([]EVPNMACIPRoute, error) {
+	if vni < 1 || vni > 16777215 {
+		return nil, fmt.Errorf("vni %d out of range (1-16777215)", vni)
+	}
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return nil, fmt.Errorf("connecting transport: %w", err)
+		}
+	}
+	tunnel := n.conn.Tunnel()
+	if tunnel == nil {
+		return nil, fmt.Errorf("no SSH tunnel for vtysh on %s", n.name)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	output, err := tunnel.ExecCommandContext(ctx, fmt.Sprintf("sudo vtysh -c 'show bgp l2vpn evpn route vni %d type macip json'", vni))
+	if err != nil {
+		return nil, fmt.Errorf("vtysh show bgp l2vpn evpn route: %w", err)
+	}
+	return parseEVPNMACIPRoutes(output, vni)
+}
+
+// evpnPath is the part of an FRR EVPN path entry the type-2 read uses.
+type evpnPath struct {
+	Bestpath json.RawMessage `json:"bestpath"` // true (older FRR) or {"overall": true}
+	PeerID   string          `json:"peerId"`
+	Local    bool            `json:"local"`
+	Nexthops []struct {
+		IP string `json:"ip"`
+	} `json:"nexthops"`
+}
+
+func (p evpnPath) best() bool {
+	if len(p.Bestpath) == 0 {
+		return false
+	}
+	var b bool
+	if json.Unmarshal(p.Bestpath, &b) == nil {
+		return b
+	}
+	var overall struct {
+		Overall bool `json:"overall"`
+	}
+	return json.Unmarshal(p.Bestpath, &overall) == nil && overall.Overall
+}
+
+// parseEVPNMACIPRoutes parses FRR's type-2 route JSON. Prefixes sit at the
+// top level of a per-VNI read, or one level down under an RD in a global
+// one; both are accepted. Paths are a list, or a list of lists (one per
+// path group) depending on the FRR version.
+func parseEVPNMACIPRoutes(output string, vni int) ([]EVPNMACIPRoute, error) {
+	// Strip null bytes and any banner before the JSON, as checkBGP does.
+	cleaned := strings.ReplaceAll(output, "\x00", "")
+	if idx := strings.Index(cleaned, "{"); idx > 0 {
+		cleaned = cleaned[idx:]
+	}
+	var top map[string]json.RawMessage
+	if err := json.NewDecoder(strings.NewReader(cleaned)).Decode(&top); err != nil {
+		return nil, fmt.Errorf("parsing vtysh output: %w", err)
+	}
+
+	var routes []EVPNMACIPRoute
+	add := func(prefix string, raw json.RawMessage) {
+		mac, ip, ok := parseEVPNType2Prefix(prefix)
+		if !ok {
+			return
+		}
+		var entry struct {
+			Paths json.RawMessage `json:"paths"`
+		}
+		if json.Unmarshal(raw, &entry) != nil {
+			return
+		}
+		r := EVPNMACIPRoute{VNI: vni, MAC: mac, IP: ip}
+		if p, ok := chooseEVPNPath(entry.Paths); ok {
+			if len(p.Nexthops) > 0 {
+				r.NextHop = p.Nexthops[0].IP
+			}
+			r.Local = p.Local || p.PeerID == "(unspec)" || r.NextHop == "" || r.NextHop == "0.0.0.0"
+			if r.Local {
+				r.NextHop = ""
+			}
+		}
+		routes = append(routes, r)
+	}
+	for key, raw := range top {
+		if strings.HasPrefix(key, "[") {
+			add(key, raw)
+			continue
+		}
+		var rd map[string]json.RawMessage
+		if json.Unmarshal(raw, &rd) != nil {
+			continue // numPrefix, vni, ...
+		}
+		for prefix, praw := range rd {
+			add(prefix, praw)
+		}
+	}
+
+	sort.Slice(routes, func(a, b int) bool {
+		if routes[a].MAC != routes[b].MAC {
+			return routes[a].MAC < routes[b].MAC
+		}
+		return routes[a].IP < routes[b].IP
+	})
+	return routes, nil
+}
+
+// chooseEVPNPath returns a route's best path, or its first when none is
+// marked best.
+func chooseEVPNPath(raw json.RawMessage) (evpnPath, bool) {
+	var flat []evpnPath
+	if json.Unmarshal(raw, &flat) != nil {
+		var groups [][]evpnPath
+		if json.Unmarshal(raw, &groups) != nil {
+			return evpnPath{}, false
+		}
+		for _, g := range groups {
+			flat = append(flat, g...)
+		}
+	}
+	if len(flat) == 0 {
+		return evpnPath{}, false
+	}
+	for _, p := range flat {
+		if p.best() {
+			return p, true
+		}
+	}
+	return flat[0], true
+}
+
+// parseEVPNType2Prefix extracts the MAC and IP of a type-2 prefix:
+// "[2]:[0]:[48]:[00:11:22:33:44:55]" or with ":[32]:[10.1.1.10]" (or a
+// /128 IPv6) appended; older FRR adds an Ethernet tag field. ok is false for
+// any other route type.
+func parseEVPNType2Prefix(prefix string) (mac, ip string, ok bool) {
+	fields := evpnPrefixFields(prefix)
+	if len(fields) == 0 || fields[0] != "2" {
+		return "", "", false
+	}
+	for _, f := range fields[1:] {
+		if hw, err := net.ParseMAC(f); err == nil && len(hw) == 6 && mac == "" {
+			mac = hw.String()
+			continue
+		}
+		if addr := net.ParseIP(f); addr != nil && mac != "" {
+			ip = addr.String()
+		}
+	}
+	return mac, ip, mac != ""
+}
+
+// evpnPrefixFields splits an FRR EVPN prefix into its bracketed fields.
+func evpnPrefixFields(prefix string) []string {
+	var fields []string
+	for {
+		open := strings.IndexByte(prefix, '[')
+		if open < 0 {
+			return fields
+		}
+		end := strings.IndexByte(prefix[open:], ']')
+		if end < 0 {
+			return fields
+		}
+		fields = append(fields, prefix[open+1:open+end])
+		prefix = prefix[open+end+1:]
+	}
+}
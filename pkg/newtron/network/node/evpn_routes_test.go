@@ -0,0 +1,58 @@
+package node
+
+import "testing"
+
+func TestParseEVPNMACIPRoutes(t *testing.T) {
+	// Per-VNI read, FRR 8+: prefixes at the top level, paths as a list of
+	// path groups, bestpath as an object.
+	const output = `{
+  "vni": 10100,
+  "[2]:[0]:[48]:[52:54:00:12:34:56]": {
+    "prefix": "[2]:[0]:[48]:[52:54:00:12:34:56]",
+    "paths": [[{"valid": true, "bestpath": {"overall": true}, "peerId": "(unspec)", "nexthops": [{"ip": "10.0.0.1"}]}]]
+  },
+  "[2]:[0]:[48]:[52:54:00:ab:cd:ef]:[32]:[10.1.100.20]": {
+    "prefix": "[2]:[0]:[48]:[52:54:00:ab:cd:ef]:[32]:[10.1.100.20]",
+    "paths": [[{"valid": true, "peerId": "10.0.0.3", "nexthops": [{"ip": "10.0.0.3"}]},
+               {"valid": true, "bestpath": {"overall": true}, "peerId": "10.0.0.2", "nexthops": [{"ip": "10.0.0.2"}]}]]
+  },
+  "[3]:[0]:[32]:[10.0.0.2]": {"paths": [[{"valid": true}]]},
+  "numPrefix": 3,
+  "numPaths": 4
+}`
+	routes, err := parseEVPNMACIPRoutes("banner\n"+output, 10100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []EVPNMACIPRoute{
+		{VNI: 10100, MAC: "52:54:00:12:34:56", Local: true},
+		{VNI: 10100, MAC: "52:54:00:ab:cd:ef", IP: "10.1.100.20", NextHop: "10.0.0.2"},
+	}
+	if len(routes) != len(want) {
+		t.Fatalf("routes = %+v, want %+v", routes, want)
+	}
+	for i := range want {
+		if routes[i] != want[i] {
+			t.Errorf("routes[%d] = %+v, want %+v", i, routes[i], want[i])
+		}
+	}
+}
+
+func TestParseEVPNType2Prefix(t *testing.T) {
+	tests := []struct {
+		prefix, mac, ip string
+		ok              bool
+	}{
+		{"[2]:[0]:[48]:[52:54:00:12:34:56]", "52:54:00:12:34:56", "", true},
+		{"[2]:[0]:[48]:[52:54:00:12:34:56]:[32]:[10.1.100.10]", "52:54:00:12:34:56", "10.1.100.10", true},
+		{"[2]:[0]:[0]:[48]:[52:54:00:12:34:56]:[128]:[2001:db8::10]", "52:54:00:12:34:56", "2001:db8::10", true},
+		{"[3]:[0]:[32]:[10.0.0.2]", "", "", false},
+		{"[5]:[0]:[24]:[10.1.100.0]", "", "", false},
+	}
+	for _, tt := range tests {
+		mac, ip, ok := parseEVPNType2Prefix(tt.prefix)
+		if mac != tt.mac || ip != tt.ip || ok != tt.ok {
+			t.Errorf("%s: got (%q, %q, %v), want (%q, %q, %v)", tt.prefix, mac, ip, ok, tt.mac, tt.ip, tt.ok)
+		}
+	}
+}
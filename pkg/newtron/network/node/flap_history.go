@@ -0,0 +1,81 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ============================================================================
+// Link-flap history — how often a physical port's link has gone down, and
+// when it last went down and came back. A link that flapped and recovered
+// passes an end-state oper_status check; its flap counter is what gives it
+// away. Pure observation (§4).
+//
+// portsyncd keeps the record in APPL_DB PORT_TABLE alongside oper_status:
+// flap_count (cumulative since the port was created), last_down_time and
+// last_up_time, the times formatted like "Wed Mar 13 06:27:05 2024". Images
+// older than 202305 write none of them.
+// ============================================================================
+
+// FlapHistory is one physical port's link-flap record.
+type FlapHistory struct {
+	Interface string
+	Supported bool // the image records flaps (flap_count present); false leaves the rest empty
+	FlapCount int
+	Events    []FlapEvent // the last down and last up, oldest first
+}
+
+// FlapEvent is one recorded link transition.
+type FlapEvent struct {
+	Status string // "down" or "up"
+	Time   string // as portsyncd wrote it
+}
+
+// flapTimeLayout is how portsyncd formats last_down_time / last_up_time.
+const flapTimeLayout = "Mon Jan _2 15:04:05 2006"
+
+// GetFlapHistory reads a physical port's link-flap record from APPL_DB
+// PORT_TABLE. Accepts short names and PORT aliases like every interface
+// read. Auto-connects transport if needed.
+func (n *Node) GetFlapHistory(ctx context.Context, iface string) (*FlapHistory, error) {
+	name := n.normalizeInterfaceName(iface)
+	if interfaceKindOf(name) != KindEthernet {
+		return nil, fmt.Errorf("flap history is kept for physical ports; %s is not one", name)
+	}
+	port, err := n.OperDBEntry(ctx, "APPL_DB", "PORT_TABLE", name)
+	if err != nil {
+		return nil, fmt.Errorf("reading APPL_DB PORT_TABLE for %s: %w", name, err)
+	}
+	if len(port) == 0 {
+		return nil, fmt.Errorf("%s: no APPL_DB PORT_TABLE row", name)
+	}
+	return buildFlapHistory(name, port), nil
+}
+
+// buildFlapHistory reduces a port's APPL_DB PORT_TABLE row to its flap
+// record. The two events are ordered by their timestamps when both parse,
+// down before up otherwise.
+func buildFlapHistory(name string, port map[string]string) *FlapHistory {
+	h := &FlapHistory{Interface: name}
+	count, ok := port["flap_count"]
+	if !ok {
+		return h
+	}
+	h.Supported = true
+	h.FlapCount, _ = strconv.Atoi(count)
+	for _, ev := range []FlapEvent{{"down", port["last_down_time"]}, {"up", port["last_up_time"]}} {
+		if ev.Time != "" && ev.Time != "never" {
+			h.Events = append(h.Events, ev)
+		}
+	}
+	if len(h.Events) == 2 {
+		down, errDown := time.Parse(flapTimeLayout, h.Events[0].Time)
+		up, errUp := time.Parse(flapTimeLayout, h.Events[1].Time)
+		if errDown == nil && errUp == nil && up.Before(down) {
+			h.Events[0], h.Events[1] = h.Events[1], h.Events[0]
+		}
+	}
+	return h
+}
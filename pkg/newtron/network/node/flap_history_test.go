@@ -0,0 +1,54 @@
+package node
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestBuildFlapHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		port map[string]string
+		want *FlapHistory
+	}{
+		{
+			name: "down then up",
+			port: map[string]string{"oper_status": "up", "flap_count": "3",
+				"last_down_time": "Wed Mar 13 06:27:05 2024", "last_up_time": "Wed Mar 13 06:27:09 2024"},
+			want: &FlapHistory{Interface: "Ethernet0", Supported: true, FlapCount: 3, Events: []FlapEvent{
+				{"down", "Wed Mar 13 06:27:05 2024"}, {"up", "Wed Mar 13 06:27:09 2024"}}},
+		},
+		{
+			name: "currently down",
+			port: map[string]string{"oper_status": "down", "flap_count": "1",
+				"last_down_time": "Wed Mar 13 06:30:00 2024", "last_up_time": "Wed Mar 13 06:00:00 2024"},
+			want: &FlapHistory{Interface: "Ethernet0", Supported: true, FlapCount: 1, Events: []FlapEvent{
+				{"up", "Wed Mar 13 06:00:00 2024"}, {"down", "Wed Mar 13 06:30:00 2024"}}},
+		},
+		{
+			name: "never flapped",
+			port: map[string]string{"oper_status": "up", "flap_count": "0", "last_down_time": "never", "last_up_time": "Wed Mar 13 06:00:00 2024"},
+			want: &FlapHistory{Interface: "Ethernet0", Supported: true, Events: []FlapEvent{{"up", "Wed Mar 13 06:00:00 2024"}}},
+		},
+		{
+			name: "image without flap tracking",
+			port: map[string]string{"oper_status": "up"},
+			want: &FlapHistory{Interface: "Ethernet0"},
+		},
+	}
+	for _, tt := range tests {
+		if got := buildFlapHistory("Ethernet0", tt.port); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: buildFlapHistory =\n  %+v\nwant\n  %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestGetFlapHistory_PhysicalPortsOnly(t *testing.T) {
+	n := testDevice()
+	for _, name := range []string{"PortChannel100", "Vlan100", "Loopback0"} {
+		if _, err := n.GetFlapHistory(context.Background(), name); err == nil {
+			t.Errorf("GetFlapHistory(%s) succeeded; want a physical-port error", name)
+		}
+	}
+}
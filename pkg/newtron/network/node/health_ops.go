@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 )
 
 // ============================================================================
@@ -20,30 +23,52 @@ import (
 
 // HealthCheckResult represents the result of a single health check.
 type HealthCheckResult struct {
-	Check   string `json:"check"`   // Check name (e.g., "bgp", "interface-oper")
-	Status  string `json:"status"`  // "pass", "warn", "fail"
-	Message string `json:"message"` // Human-readable message
+	Check   string `json:"check"`         // Check name (e.g., "bgp", "interface-oper")
+	VRF     string `json:"vrf,omitempty"` // VRF of a per-neighbor "bgp" result
+	Status  string `json:"status"`        // "pass", "warn", "fail"
+	Message string `json:"message"`       // Human-readable message
 }
 
 // CheckBGPSessions checks that all configured BGP neighbors are Established.
 // Reads expected neighbors from intent DB (evpn-peer and bgp-peer intents),
-// then checks STATE_DB (with vtysh fallback).
+// then checks STATE_DB (with vtysh fallback). A non-empty vrf scopes the
+// check to that VRF's neighbors ("default" is the underlay/overlay VRF);
+// empty checks every VRF.
 // Auto-connects transport if needed.
-func (n *Node) CheckBGPSessions(ctx context.Context) ([]HealthCheckResult, error) {
+func (n *Node) CheckBGPSessions(ctx context.Context, vrf string) ([]HealthCheckResult, error) {
 	if n.conn == nil {
 		if err := n.ConnectTransport(ctx); err != nil {
 			return nil, fmt.Errorf("connecting transport: %w", err)
 		}
 	}
 
-	// Build expected neighbor set from intents.
-	//
-	// Three sources of BGP peers:
-	// 1. Overlay peers from ConfigureBGPOverlay: derived from device intent (source_ip) +
-	//    resolved.BGPNeighbors. ConfigureBGPOverlay creates BGP_NEIGHBOR entries as part of
-	//    the device intent's sub-operations — no separate evpn-peer intents.
-	// 2. Standalone overlay peers: evpn-peer|{ip} intents from AddBGPEVPNPeer.
-	// 3. Underlay peers: interface|{name}|bgp-peer intents from AddBGPPeer.
+	expected := n.expectedBGPNeighbors()
+
+	if vrf != "" {
+		expected = map[string][]string{vrf: expected[vrf]}
+		if len(expected[vrf]) == 0 {
+			return []HealthCheckResult{{Check: "bgp", Status: "warn", Message: fmt.Sprintf("No BGP neighbors configured in vrf %s", vrf)}}, nil
+		}
+	}
+
+	if len(expected) == 0 {
+		return []HealthCheckResult{{Check: "bgp", Status: "warn", Message: "No BGP neighbors configured"}}, nil
+	}
+
+	return n.checkBGP(expected), nil
+}
+
+// expectedBGPNeighbors builds the expected neighbor set (VRF → neighbor IPs)
+// from intents.
+//
+// Four sources of BGP peers:
+//  1. Overlay peers from ConfigureBGPOverlay: derived from device intent (source_ip) +
+//     resolved.BGPNeighbors. ConfigureBGPOverlay creates BGP_NEIGHBOR entries as part of
+//     the device intent's sub-operations — no separate evpn-peer intents.
+//  2. Standalone overlay peers: evpn-peer|{ip} intents from AddBGPEVPNPeer.
+//  3. Underlay peers: interface|{name}|bgp-peer intents from AddBGPPeer.
+//  4. Peers established by a routed service (see below).
+func (n *Node) expectedBGPNeighbors() map[string][]string {
 	expected := make(map[string][]string)
 	seenOverlay := make(map[string]bool)
 
@@ -92,30 +117,183 @@ func (n *Node) CheckBGPSessions(ctx context.Context) ([]HealthCheckResult, error
 			continue
 		}
 		vrf := "default"
-		if v := intent.Params["vrf"]; v != "" {
+		if v := intent.Params[sonic.FieldVRFName]; v != "" {
 			vrf = v
 		}
 		expected[vrf] = append(expected[vrf], nbr)
 	}
 
+	return expected
+}
+
+// checkBGP checks the expected neighbors (VRF → neighbor IPs) in STATE_DB,
+// falling back to vtysh. The transport must be connected.
+func (n *Node) checkBGP(expected map[string][]string) []HealthCheckResult {
+	peers, err := n.bgpPeerStates(expected)
+	if err != nil {
+		return []HealthCheckResult{{Check: "bgp", Status: "fail", Message: err.Error()}}
+	}
+	results := make([]HealthCheckResult, 0, len(peers))
+	for _, p := range peers {
+		r := HealthCheckResult{Check: "bgp", VRF: p.VRF, Status: "fail"}
+		switch p.State {
+		case "":
+			r.Message = fmt.Sprintf("BGP neighbor %s (vrf %s): not found in FRR", p.Neighbor, p.VRF)
+		case "Established":
+			r.Status = "pass"
+			r.Message = fmt.Sprintf("BGP neighbor %s (vrf %s): Established", p.Neighbor, p.VRF)
+		default:
+			r.Message = fmt.Sprintf("BGP neighbor %s (vrf %s): %s", p.Neighbor, p.VRF, p.State)
+		}
+		results = append(results, r)
+	}
+	return results
+}
+
+// bgpPeerState is the session state of one expected BGP neighbor. An empty
+// State means FRR has no such peer.
+type bgpPeerState struct {
+	VRF      string
+	Neighbor string
+	State    string
+}
+
+// bgpPeerStates reads the session state of the expected neighbors from
+// STATE_DB (populated by bgpmon on hardware SONiC), falling back to vtysh
+// (VPP and images without bgpmon). The transport must be connected.
+func (n *Node) bgpPeerStates(expected map[string][]string) ([]bgpPeerState, error) {
+	if peers := n.bgpPeerStatesFromStateDB(expected); peers != nil {
+		return peers, nil
+	}
+	return n.bgpPeerStatesFromVtysh(expected)
+}
+
+// BGPConvergence is the aggregate session state of a device's expected BGP
+// neighbors — overall and per VRF.
+type BGPConvergence struct {
+	Total       int                 `json:"total"`
+	Established int                 `json:"established"`
+	States      map[string]int      `json:"states,omitempty"` // non-Established state → peer count
+	Converged   bool                `json:"converged"`        // every expected peer Established
+	VRFs        []BGPVRFConvergence `json:"vrfs,omitempty"`
+}
+
+// BGPVRFConvergence is the aggregate session state of one VRF's expected
+// neighbors.
+type BGPVRFConvergence struct {
+	VRF         string         `json:"vrf"`
+	Total       int            `json:"total"`
+	Established int            `json:"established"`
+	States      map[string]int `json:"states,omitempty"`
+	Converged   bool           `json:"converged"`
+}
+
+// bgpStateNotFound counts an expected neighbor FRR does not list at all.
+const bgpStateNotFound = "NotFound"
+
+// GetBGPConvergenceState reads the session state of every expected BGP
+// neighbor (the same set CheckBGPSessions checks) and aggregates it: how
+// many peers, how many Established, how many in each other state, and
+// whether initial convergence — every expected peer up — is reached.
+// A device with no expected neighbors has nothing to converge and reports
+// Converged. VRFs are in name order.
+// Auto-connects transport if needed.
+func (n *Node) GetBGPConvergenceState(ctx context.Context) (*BGPConvergence, error) {
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return nil, fmt.Errorf("connecting transport: %w", err)
+		}
+	}
+
+	expected := n.expectedBGPNeighbors()
 	if len(expected) == 0 {
-		return []HealthCheckResult{{Check: "bgp", Status: "warn", Message: "No BGP neighbors configured"}}, nil
+		return &BGPConvergence{Converged: true}, nil
+	}
+	peers, err := n.bgpPeerStates(expected)
+	if err != nil {
+		return nil, fmt.Errorf("reading BGP state: %w", err)
+	}
+	return aggregateBGPConvergence(expected, peers), nil
+}
+
+// aggregateBGPConvergence folds per-peer states into a BGPConvergence. A
+// neighbor in expected with no entry in peers (STATE_DB lists only some of
+// them) counts as NotFound.
+func aggregateBGPConvergence(expected map[string][]string, peers []bgpPeerState) *BGPConvergence {
+	state := make(map[string]string) // vrf|neighbor → state
+	for _, p := range peers {
+		state[p.VRF+"|"+p.Neighbor] = p.State
 	}
 
-	// Try STATE_DB first (populated by bgpmon on hardware SONiC)
-	if results := n.checkBGPFromStateDB(expected); results != nil {
-		return results, nil
+	vrfs := make([]string, 0, len(expected))
+	for vrf := range expected {
+		vrfs = append(vrfs, vrf)
 	}
+	sort.Strings(vrfs)
 
-	// Fall back to vtysh (VPP and images without bgpmon)
-	return n.checkBGPFromVtysh(expected), nil
+	conv := &BGPConvergence{}
+	for _, vrf := range vrfs {
+		v := BGPVRFConvergence{VRF: vrf}
+		for _, neighbor := range expected[vrf] {
+			v.Total++
+			s := state[vrf+"|"+neighbor]
+			switch s {
+			case "Established":
+				v.Established++
+				continue
+			case "":
+				s = bgpStateNotFound
+			}
+			if v.States == nil {
+				v.States = make(map[string]int)
+			}
+			v.States[s]++
+			if conv.States == nil {
+				conv.States = make(map[string]int)
+			}
+			conv.States[s]++
+		}
+		v.Converged = v.Established == v.Total
+		conv.Total += v.Total
+		conv.Established += v.Established
+		conv.VRFs = append(conv.VRFs, v)
+	}
+	conv.Converged = conv.Established == conv.Total
+	return conv
 }
 
-// checkBGPFromStateDB checks BGP state via STATE_DB BGP_NEIGHBOR_TABLE.
+// CheckServiceHealth checks the operational state the service bound to intf
+// implies: the interface oper-up and, when the service peers over BGP on
+// the interface, that session Established in the service's VRF. It is the
+// settle check of an applied service — not a full health check.
+// Auto-connects transport if needed.
+func (n *Node) CheckServiceHealth(ctx context.Context, intf string) ([]HealthCheckResult, error) {
+	binding := n.GetIntent(bindingKey(intf))
+	if binding == nil {
+		return nil, fmt.Errorf("interface %s has no service to check", intf)
+	}
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return nil, fmt.Errorf("connecting transport: %w", err)
+		}
+	}
+
+	results := n.CheckInterfaceOper([]string{intf})
+	if nbr := binding.Params["bgp_neighbor"]; nbr != "" {
+		vrf := "default"
+		if v := binding.Params[sonic.FieldVRFName]; v != "" {
+			vrf = v
+		}
+		results = append(results, n.checkBGP(map[string][]string{vrf: {nbr}})...)
+	}
+	return results, nil
+}
+
+// bgpPeerStatesFromStateDB reads BGP state from STATE_DB BGP_NEIGHBOR_TABLE.
 // Returns nil if STATE_DB has no BGP neighbor entries (caller should fall back).
-func (n *Node) checkBGPFromStateDB(expected map[string][]string) []HealthCheckResult {
+func (n *Node) bgpPeerStatesFromStateDB(expected map[string][]string) []bgpPeerState {
 	stateClient := n.conn.StateClient()
-	var results []HealthCheckResult
+	var peers []bgpPeerState
 	anyFound := false
 
 	for vrf, neighbors := range expected {
@@ -126,35 +304,24 @@ func (n *Node) checkBGPFromStateDB(expected map[string][]string) []HealthCheckRe
 				continue
 			}
 			anyFound = true
-			if entry.State == "Established" {
-				results = append(results, HealthCheckResult{
-					Check:   "bgp",
-					Status:  "pass",
-					Message: fmt.Sprintf("BGP neighbor %s (vrf %s): Established", neighbor, vrf),
-				})
-			} else {
-				results = append(results, HealthCheckResult{
-					Check:   "bgp",
-					Status:  "fail",
-					Message: fmt.Sprintf("BGP neighbor %s (vrf %s): %s", neighbor, vrf, entry.State),
-				})
-			}
+			peers = append(peers, bgpPeerState{VRF: vrf, Neighbor: neighbor, State: entry.State})
 		}
 	}
 
 	if !anyFound {
 		return nil // No STATE_DB entries at all — fall back to vtysh
 	}
-	return results
+	return peers
 }
 
-// checkBGPFromVtysh checks BGP state via "vtysh -c 'show bgp summary json'".
+// bgpPeerStatesFromVtysh reads BGP state via "vtysh -c 'show bgp summary json'".
 // Used when STATE_DB has no BGP_NEIGHBOR_TABLE entries (e.g., SONiC VPP
-// images that don't ship bgpmon).
-func (n *Node) checkBGPFromVtysh(expected map[string][]string) []HealthCheckResult {
+// images that don't ship bgpmon). A neighbor FRR does not list gets an
+// empty State.
+func (n *Node) bgpPeerStatesFromVtysh(expected map[string][]string) ([]bgpPeerState, error) {
 	tunnel := n.conn.Tunnel()
 	if tunnel == nil {
-		return []HealthCheckResult{{Check: "bgp", Status: "fail", Message: "no SSH tunnel for vtysh fallback"}}
+		return nil, fmt.Errorf("no SSH tunnel for vtysh fallback")
 	}
 
 	// Use a 30-second timeout for vtysh command execution to prevent indefinite hangs
@@ -163,7 +330,7 @@ func (n *Node) checkBGPFromVtysh(expected map[string][]string) []HealthCheckResu
 
 	output, err := tunnel.ExecCommandContext(ctx, "sudo vtysh -c 'show bgp summary json'")
 	if err != nil {
-		return []HealthCheckResult{{Check: "bgp", Status: "fail", Message: fmt.Sprintf("vtysh: %s", err)}}
+		return nil, fmt.Errorf("vtysh: %s", err)
 	}
 
 	// Strip null bytes — CiscoVS/Silicon One vtysh occasionally emits \x00 in JSON output.
@@ -180,7 +347,7 @@ func (n *Node) checkBGPFromVtysh(expected map[string][]string) []HealthCheckResu
 	var summary map[string]json.RawMessage
 	decoder := json.NewDecoder(strings.NewReader(cleaned))
 	if err := decoder.Decode(&summary); err != nil {
-		return []HealthCheckResult{{Check: "bgp", Status: "fail", Message: fmt.Sprintf("vtysh parse: %s", err)}}
+		return nil, fmt.Errorf("vtysh parse: %s", err)
 	}
 
 	// Collect peer states from all address families.
@@ -218,33 +385,14 @@ func (n *Node) checkBGPFromVtysh(expected map[string][]string) []HealthCheckResu
 		}
 	}
 
-	var results []HealthCheckResult
+	var peers []bgpPeerState
 	for vrf, neighbors := range expected {
 		for _, neighbor := range neighbors {
-			state, ok := peerStates[neighbor]
-			if !ok {
-				results = append(results, HealthCheckResult{
-					Check:   "bgp",
-					Status:  "fail",
-					Message: fmt.Sprintf("BGP neighbor %s (vrf %s): not found in FRR", neighbor, vrf),
-				})
-			} else if state == "Established" {
-				results = append(results, HealthCheckResult{
-					Check:   "bgp",
-					Status:  "pass",
-					Message: fmt.Sprintf("BGP neighbor %s (vrf %s): Established", neighbor, vrf),
-				})
-			} else {
-				results = append(results, HealthCheckResult{
-					Check:   "bgp",
-					Status:  "fail",
-					Message: fmt.Sprintf("BGP neighbor %s (vrf %s): %s", neighbor, vrf, state),
-				})
-			}
+			peers = append(peers, bgpPeerState{VRF: vrf, Neighbor: neighbor, State: peerStates[neighbor]})
 		}
 	}
 
-	return results
+	return peers, nil
 }
 
 // CheckInterfaceOper reads STATE_DB PORT_TABLE and checks oper_status for
@@ -0,0 +1,46 @@
+package node
+
+import "testing"
+
+func TestAggregateBGPConvergence(t *testing.T) {
+	expected := map[string][]string{
+		"default":   {"10.0.0.2", "10.0.0.3", "10.1.0.1"},
+		"Vrf_CUST1": {"192.168.1.1", "192.168.1.5"},
+	}
+	peers := []bgpPeerState{
+		{VRF: "default", Neighbor: "10.0.0.2", State: "Established"},
+		{VRF: "default", Neighbor: "10.0.0.3", State: "Established"},
+		{VRF: "default", Neighbor: "10.1.0.1", State: "Established"},
+		{VRF: "Vrf_CUST1", Neighbor: "192.168.1.1", State: "Active"},
+		// 192.168.1.5: STATE_DB has no entry for it
+	}
+
+	conv := aggregateBGPConvergence(expected, peers)
+	if conv.Total != 5 || conv.Established != 3 || conv.Converged {
+		t.Errorf("total = %d/%d converged=%v, want 3/5 not converged", conv.Established, conv.Total, conv.Converged)
+	}
+	if conv.States["Active"] != 1 || conv.States[bgpStateNotFound] != 1 || len(conv.States) != 2 {
+		t.Errorf("States = %v, want Active 1, NotFound 1", conv.States)
+	}
+	if len(conv.VRFs) != 2 || conv.VRFs[0].VRF != "Vrf_CUST1" || conv.VRFs[1].VRF != "default" {
+		t.Fatalf("VRFs = %+v, want Vrf_CUST1 then default", conv.VRFs)
+	}
+	if cust := conv.VRFs[0]; cust.Total != 2 || cust.Established != 0 || cust.Converged {
+		t.Errorf("Vrf_CUST1 = %+v, want 0/2 not converged", cust)
+	}
+	if def := conv.VRFs[1]; def.Total != 3 || def.Established != 3 || !def.Converged || def.States != nil {
+		t.Errorf("default = %+v, want 3/3 converged with no other states", def)
+	}
+}
+
+func TestAggregateBGPConvergence_NotFoundInFRR(t *testing.T) {
+	// The vtysh path lists every expected neighbor, with an empty state for
+	// one FRR does not know.
+	conv := aggregateBGPConvergence(
+		map[string][]string{"default": {"10.0.0.2"}},
+		[]bgpPeerState{{VRF: "default", Neighbor: "10.0.0.2"}},
+	)
+	if conv.Converged || conv.States[bgpStateNotFound] != 1 {
+		t.Errorf("conv = %+v, want NotFound 1, not converged", conv)
+	}
+}
@@ -0,0 +1,21 @@
+package node
+
+import (
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// enableIGMPSnoopingConfig returns the CFG_L2MC_TABLE entry turning on IGMP
+// snooping for a VLAN: IGMPv2, no querier — the multicast router on the
+// VLAN (or an upstream querier) sends the queries hosts answer.
+func enableIGMPSnoopingConfig(vlanName string) []sonic.Entry {
+	return []sonic.Entry{{Table: "CFG_L2MC_TABLE", Key: vlanName, Fields: map[string]string{
+		"enabled": "true",
+		"version": "2",
+	}}}
+}
+
+// disableIGMPSnoopingConfig returns the CFG_L2MC_TABLE delete for a VLAN.
+// With no row l2mcd stops snooping and the VLAN floods multicast again.
+func disableIGMPSnoopingConfig(vlanName string) []sonic.Entry {
+	return []sonic.Entry{{Table: "CFG_L2MC_TABLE", Key: vlanName}}
+}
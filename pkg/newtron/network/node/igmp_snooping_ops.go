@@ -0,0 +1,180 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// ============================================================================
+// IGMP snooping — per-VLAN L2 multicast. With snooping on, the switch listens
+// to hosts' IGMP reports and forwards each group's traffic only to the ports
+// that joined it; with it off, multicast floods the whole VLAN. This file
+// owns the CFG_L2MC_TABLE vocabulary (§28).
+//
+// Snooping needs an image that ships l2mcd; platforms without it list
+// "igmp-snooping" in unsupported_features. l2mcd records each learned
+// membership in STATE_DB L2MC_MEMBER_TABLE, keyed
+// "Vlan<N>|<source>|<group>|<port>" — source 0.0.0.0 for an any-source join.
+// ============================================================================
+
+// igmpSnoopingFeature is the platform feature IGMP snooping requires.
+const igmpSnoopingFeature = "igmp-snooping"
+
+// igmpSnoopingResource returns the intent key of a VLAN's IGMP snooping.
+func igmpSnoopingResource(vlanID int) string {
+	return "igmp-snooping|" + VLANName(vlanID)
+}
+
+// igmpSnoopingSupported reports whether the device's platform supports IGMP
+// snooping. A device with no platform, or one the spec does not know, is
+// assumed to — as for the other feature checks.
+func (n *Node) igmpSnoopingSupported() (bool, string) {
+	resolved := n.Resolved()
+	if resolved == nil || resolved.Platform == "" {
+		return true, ""
+	}
+	platform, err := n.GetPlatform(resolved.Platform)
+	if err != nil {
+		return true, resolved.Platform
+	}
+	return platform.SupportsFeature(igmpSnoopingFeature), resolved.Platform
+}
+
+// SetIGMPSnooping turns IGMP snooping on or off for a VLAN. Enabling requires
+// the VLAN to exist and the platform to support igmp-snooping; disabling
+// removes what enabling wrote. Both are idempotent: enabling a snooping VLAN,
+// or disabling one that is not, returns an empty ChangeSet.
+func (n *Node) SetIGMPSnooping(ctx context.Context, vlanID int, enable bool) (*ChangeSet, error) {
+	if !enable {
+		return n.disableIGMPSnooping(vlanID)
+	}
+	resource := igmpSnoopingResource(vlanID)
+	if n.GetIntent(resource) != nil {
+		return NewChangeSet(n.name, "device."+sonic.OpEnableIGMPSnooping), nil
+	}
+	supported, platform := n.igmpSnoopingSupported()
+	if err := n.precondition(sonic.OpEnableIGMPSnooping, resource).
+		RequireVLANExists(vlanID).
+		Check(supported, "platform supports IGMP snooping",
+			fmt.Sprintf("platform %s does not support IGMP snooping", platform)).
+		Result(); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{sonic.FieldVLANID: strconv.Itoa(vlanID)}
+	cs := NewChangeSet(n.name, "device."+sonic.OpEnableIGMPSnooping)
+	cs.ReverseOp = "device." + sonic.OpDisableIGMPSnooping
+	cs.OperationParams = params
+	if err := n.writeIntent(cs, sonic.OpEnableIGMPSnooping, resource, params, []string{"vlan|" + strconv.Itoa(vlanID)}); err != nil {
+		return nil, err
+	}
+	cs.Adds(enableIGMPSnoopingConfig(VLANName(vlanID)))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Enabled IGMP snooping on VLAN %d", vlanID)
+	return cs, nil
+}
+
+// disableIGMPSnooping removes a VLAN's IGMP snooping. Reverse of enabling
+// (§15).
+func (n *Node) disableIGMPSnooping(vlanID int) (*ChangeSet, error) {
+	resource := igmpSnoopingResource(vlanID)
+	if err := n.precondition(sonic.OpDisableIGMPSnooping, resource).Result(); err != nil {
+		return nil, err
+	}
+	cs := NewChangeSet(n.name, "device."+sonic.OpDisableIGMPSnooping)
+	if n.GetIntent(resource) == nil {
+		return cs, nil
+	}
+	cs.OperationParams = map[string]string{sonic.FieldVLANID: strconv.Itoa(vlanID)}
+	cs.Deletes(disableIGMPSnoopingConfig(VLANName(vlanID)))
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Disabled IGMP snooping on VLAN %d", vlanID)
+	return cs, nil
+}
+
+// ============================================================================
+// State
+// ============================================================================
+
+// IGMPState is one VLAN's IGMP snooping: whether it is intended and
+// configured, and the multicast groups l2mcd has learned on it.
+type IGMPState struct {
+	VLAN       string
+	Supported  bool // the platform supports igmp-snooping; false leaves the rest unread
+	Intended   bool // the projection (intent replay) enables snooping
+	Configured bool // the device's CONFIG_DB CFG_L2MC_TABLE has enabled=true
+	Groups     []IGMPGroup
+}
+
+// IGMPGroup is one multicast group learned on a VLAN and the ports that
+// joined it.
+type IGMPGroup struct {
+	Group  string
+	Source string // 0.0.0.0 for an any-source (IGMPv2) join
+	Ports  []string
+}
+
+// GetIGMPSnooping reads a VLAN's IGMP snooping state. On a platform without
+// igmp-snooping it reads nothing and returns Supported false. Pure
+// observation (§4); auto-connects transport if needed.
+func (n *Node) GetIGMPSnooping(ctx context.Context, vlanID int) (*IGMPState, error) {
+	vlan := VLANName(vlanID)
+	if supported, _ := n.igmpSnoopingSupported(); !supported {
+		return &IGMPState{VLAN: vlan}, nil
+	}
+	members, err := n.OperDBTable(ctx, "STATE_DB", "L2MC_MEMBER_TABLE")
+	if err != nil {
+		return nil, fmt.Errorf("reading STATE_DB L2MC_MEMBER_TABLE: %w", err)
+	}
+	configured, err := n.conn.Client().GetRawTable("CFG_L2MC_TABLE")
+	if err != nil {
+		return nil, fmt.Errorf("reading CONFIG_DB CFG_L2MC_TABLE: %w", err)
+	}
+	return &IGMPState{
+		VLAN:       vlan,
+		Supported:  true,
+		Intended:   n.configDB.L2MC[vlan]["enabled"] == "true",
+		Configured: configured[vlan]["enabled"] == "true",
+		Groups:     buildIGMPGroups(members, vlan),
+	}, nil
+}
+
+// buildIGMPGroups folds L2MC_MEMBER_TABLE rows, keyed
+// "Vlan<N>|<source>|<group>|<port>", into vlan's groups sorted by group then
+// source, each with its ports sorted.
+func buildIGMPGroups(rows map[string]map[string]string, vlan string) []IGMPGroup {
+	byGroup := map[[2]string][]string{}
+	for key := range rows {
+		parts := strings.SplitN(key, "|", 4)
+		if len(parts) != 4 || parts[0] != vlan {
+			continue
+		}
+		id := [2]string{parts[2], parts[1]}
+		byGroup[id] = append(byGroup[id], parts[3])
+	}
+	groups := make([]IGMPGroup, 0, len(byGroup))
+	for id, ports := range byGroup {
+		sort.Strings(ports)
+		groups = append(groups, IGMPGroup{Group: id[0], Source: id[1], Ports: ports})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Group != groups[j].Group {
+			return groups[i].Group < groups[j].Group
+		}
+		return groups[i].Source < groups[j].Source
+	})
+	return groups
+}
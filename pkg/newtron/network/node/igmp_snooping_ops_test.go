@@ -0,0 +1,109 @@
+package node
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func TestIGMPSnooping_EnableDisable(t *testing.T) {
+	ctx := context.Background()
+	n := testDevice()
+	if _, err := n.CreateVLAN(ctx, 100, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+
+	cs, err := n.SetIGMPSnooping(ctx, 100, true)
+	if err != nil {
+		t.Fatalf("SetIGMPSnooping on: %v", err)
+	}
+	row := assertChange(t, cs, "CFG_L2MC_TABLE", "Vlan100", ChangeAdd)
+	assertField(t, row, "enabled", "true")
+	assertChange(t, cs, "NEWTRON_INTENT", "igmp-snooping|Vlan100", ChangeAdd)
+	if cs.ReverseOp != "device.disable-igmp-snooping" {
+		t.Errorf("ReverseOp = %q, want device.disable-igmp-snooping", cs.ReverseOp)
+	}
+
+	// Enabling again is a no-op.
+	cs, err = n.SetIGMPSnooping(ctx, 100, true)
+	if err != nil || !cs.IsEmpty() {
+		t.Fatalf("second enable = %v, %v; want empty ChangeSet", cs, err)
+	}
+
+	// The VLAN cannot be deleted while snooping hangs off it.
+	if _, err := n.DeleteVLAN(ctx, 100); err == nil {
+		t.Error("DeleteVLAN succeeded with IGMP snooping enabled")
+	}
+
+	cs, err = n.SetIGMPSnooping(ctx, 100, false)
+	if err != nil {
+		t.Fatalf("SetIGMPSnooping off: %v", err)
+	}
+	assertChange(t, cs, "CFG_L2MC_TABLE", "Vlan100", ChangeDelete)
+	if n.GetIntent("igmp-snooping|Vlan100") != nil {
+		t.Error("intent left after disabling")
+	}
+	if _, ok := n.configDB.L2MC["Vlan100"]; ok {
+		t.Error("CFG_L2MC_TABLE row left in the projection after disabling")
+	}
+
+	// Disabling again is a no-op.
+	cs, err = n.SetIGMPSnooping(ctx, 100, false)
+	if err != nil || !cs.IsEmpty() {
+		t.Fatalf("second disable = %v, %v; want empty ChangeSet", cs, err)
+	}
+}
+
+func TestIGMPSnooping_Rejected(t *testing.T) {
+	ctx := context.Background()
+	n := testDevice()
+	if _, err := n.SetIGMPSnooping(ctx, 200, true); err == nil {
+		t.Error("enabled snooping on a VLAN that does not exist")
+	}
+
+	if _, err := n.CreateVLAN(ctx, 100, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+	n.resolved.Platform = "vs-no-mcast"
+	n.SpecProvider.(*testSpecProvider).platforms["vs-no-mcast"] = &spec.PlatformSpec{
+		UnsupportedFeatures: []string{"igmp-snooping"},
+	}
+	_, err := n.SetIGMPSnooping(ctx, 100, true)
+	if err == nil || !strings.Contains(err.Error(), "does not support IGMP snooping") {
+		t.Fatalf("err = %v, want platform support rejection", err)
+	}
+
+	st, err := n.GetIGMPSnooping(ctx, 100)
+	if err != nil {
+		t.Fatalf("GetIGMPSnooping: %v", err)
+	}
+	if st.Supported || st.VLAN != "Vlan100" {
+		t.Errorf("state = %+v, want unsupported Vlan100", st)
+	}
+}
+
+func TestBuildIGMPGroups(t *testing.T) {
+	rows := map[string]map[string]string{
+		"Vlan100|0.0.0.0|239.1.1.2|Ethernet8":  {"type": "dynamic"},
+		"Vlan100|0.0.0.0|239.1.1.1|Ethernet4":  {"type": "dynamic"},
+		"Vlan100|0.0.0.0|239.1.1.1|Ethernet0":  {"type": "dynamic"},
+		"Vlan100|10.1.0.5|239.1.1.1|Ethernet0": {"type": "dynamic"},
+		"Vlan200|0.0.0.0|239.1.1.1|Ethernet12": {"type": "dynamic"},
+		"malformed":                            {},
+	}
+	got := buildIGMPGroups(rows, "Vlan100")
+	want := []IGMPGroup{
+		{Group: "239.1.1.1", Source: "0.0.0.0", Ports: []string{"Ethernet0", "Ethernet4"}},
+		{Group: "239.1.1.1", Source: "10.1.0.5", Ports: []string{"Ethernet0"}},
+		{Group: "239.1.1.2", Source: "0.0.0.0", Ports: []string{"Ethernet8"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildIGMPGroups =\n  %+v\nwant\n  %+v", got, want)
+	}
+	if got := buildIGMPGroups(rows, "Vlan300"); len(got) != 0 {
+		t.Errorf("groups on a VLAN with none = %+v", got)
+	}
+}
@@ -0,0 +1,328 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// import_intent.go — brownfield adoption. ImportIntent reads a device that was
+// configured without newtron and reverse-engineers what it can into newtron
+// terms: which interfaces carry something that looks like a service binding,
+// and which service definition (if any) would reproduce it.
+//
+// The importer is read-only and best-effort. It never writes intent; a
+// recognized binding comes with the apply-service topology step that, once
+// added to topology.json and reconciled, brings the interface under newtron.
+// Names cannot be matched — a hand-built VRF or ACL is not named the way
+// newtron derives them — so matching is by shape: service type, VRF presence,
+// VNIs, which directions carry a filter, and BGP presence.
+
+// ImportedIntent is the result of ImportIntent: per-interface bindings newtron
+// can reproduce (Recognized) and service-like config it could not place
+// (Unrecognized), each with the CONFIG_DB entries it accounts for.
+type ImportedIntent struct {
+	Device       string
+	Recognized   []ImportedBinding
+	Unrecognized []ImportedBinding
+}
+
+// ImportedBinding is one interface's service-like configuration.
+type ImportedBinding struct {
+	Interface   string
+	ServiceType string             // inferred shape (spec.ServiceType*); "" when not service-like
+	Service     string             // matched service definition; "" when unmatched
+	Step        *spec.TopologyStep // apply-service step reproducing it; nil when unmatched
+	Entries     []string           // "TABLE|KEY" entries this binding explains
+	Note        string             // why it was not matched, or what was left out
+}
+
+// ImportIntent reads the device's CONFIG_DB and classifies every interface
+// with L3 or access-VLAN configuration. Interfaces already under newtron
+// (an interface|<name> intent exists) are skipped. Auto-connects transport.
+func (n *Node) ImportIntent(ctx context.Context) (*ImportedIntent, error) {
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return nil, fmt.Errorf("connecting transport for import: %w", err)
+		}
+	}
+	raw, err := n.conn.Client().GetRawAllTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading CONFIG_DB: %w", err)
+	}
+	out := importIntent(raw, n.SpecProvider)
+	out.Device = n.name
+	return out, nil
+}
+
+// observedBinding is what the importer saw on one interface.
+type observedBinding struct {
+	name    string
+	routed  bool              // has INTERFACE / PORTCHANNEL_INTERFACE config
+	vrf     string            // VRF of the interface (routed) or of the SVI (irb)
+	vrfVNI  string            // VRF's L3VNI; "" when none
+	ips     []string          // interface IPs (routed) or SVI IPs (irb), sorted
+	vlan    int               // access VLAN; 0 when routed
+	vlanVNI string            // VLAN's L2VNI; "" when none
+	acls    map[string]string // "ingress"/"egress" → ACL table name
+	bgpPeer string            // BGP_NEIGHBOR address matching the interface's peer; "" when none
+	bgpASN  int               // that neighbor's asn
+	entries []string
+}
+
+func importIntent(raw sonic.RawConfigDB, sp SpecProvider) *ImportedIntent {
+	out := &ImportedIntent{}
+	managed := map[string]bool{}
+	for key := range raw["NEWTRON_INTENT"] {
+		if name, ok := strings.CutPrefix(key, "interface|"); ok && !strings.Contains(name, "|") {
+			managed[name] = true
+		}
+	}
+
+	observed := map[string]*observedBinding{}
+	get := func(name string) *observedBinding {
+		if o := observed[name]; o != nil {
+			return o
+		}
+		o := &observedBinding{name: name, acls: map[string]string{}}
+		observed[name] = o
+		return o
+	}
+
+	// L3 interfaces: "<intf>" carries vrf_name, "<intf>|<ip>" each address.
+	for _, table := range []string{"INTERFACE", "PORTCHANNEL_INTERFACE"} {
+		for key, fields := range raw[table] {
+			name, ip, hasIP := strings.Cut(key, "|")
+			if managed[name] {
+				continue
+			}
+			o := get(name)
+			o.routed = true
+			o.entries = append(o.entries, table+"|"+key)
+			if hasIP {
+				o.ips = append(o.ips, ip)
+			} else {
+				o.vrf = fields[sonic.FieldVRFName]
+			}
+		}
+	}
+
+	// Access VLANs: an interface with exactly one untagged membership. Any
+	// other membership is reported, not guessed at.
+	trunks := map[string][]string{}
+	for key, fields := range raw["VLAN_MEMBER"] {
+		vlanName, name, ok := strings.Cut(key, "|")
+		if !ok || managed[name] {
+			continue
+		}
+		o := get(name)
+		o.entries = append(o.entries, "VLAN_MEMBER|"+key)
+		if fields["tagging_mode"] == "tagged" || o.vlan != 0 {
+			trunks[name] = append(trunks[name], vlanName)
+			continue
+		}
+		o.vlan, _ = strconv.Atoi(strings.TrimPrefix(vlanName, "Vlan"))
+	}
+
+	vlanVNIs := map[string]string{}
+	for _, fields := range raw["VXLAN_TUNNEL_MAP"] {
+		vlanVNIs[fields["vlan"]] = fields["vni"]
+	}
+
+	for _, o := range observed {
+		if o.vlan == 0 {
+			continue
+		}
+		vlanName := VLANName(o.vlan)
+		o.vlanVNI = vlanVNIs[vlanName]
+		for key, fields := range raw["VLAN_INTERFACE"] {
+			svi, ip, hasIP := strings.Cut(key, "|")
+			if svi != vlanName {
+				continue
+			}
+			if hasIP {
+				o.ips = append(o.ips, ip)
+			} else {
+				o.vrf = fields[sonic.FieldVRFName]
+			}
+		}
+	}
+
+	for tableName, fields := range raw["ACL_TABLE"] {
+		for _, port := range strings.Split(fields["ports"], ",") {
+			if o := observed[port]; o != nil {
+				stage := strings.ToLower(fields["stage"])
+				if stage == "" {
+					stage = "ingress"
+				}
+				o.acls[stage] = tableName
+				o.entries = append(o.entries, "ACL_TABLE|"+tableName)
+			}
+		}
+	}
+
+	for _, o := range observed {
+		sort.Strings(o.ips)
+		if o.vrf != "" {
+			o.vrfVNI = raw["VRF"][o.vrf]["vni"]
+		}
+		if o.vlan == 0 && len(o.ips) > 0 {
+			if peer, err := util.DeriveNeighborIP(o.ips[0]); err == nil {
+				vrf := o.vrf
+				if vrf == "" {
+					vrf = "default"
+				}
+				for _, key := range []string{BGPNeighborKey(vrf, peer), peer} {
+					if nbr, ok := raw["BGP_NEIGHBOR"][key]; ok {
+						o.bgpPeer = peer
+						o.bgpASN, _ = strconv.Atoi(nbr["asn"])
+						o.entries = append(o.entries, "BGP_NEIGHBOR|"+key)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(observed))
+	for name := range observed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		o := observed[name]
+		sort.Strings(o.entries)
+		b := ImportedBinding{Interface: name, Entries: o.entries}
+
+		if vlans := trunks[name]; len(vlans) > 0 {
+			if o.vlan != 0 {
+				vlans = append(vlans, VLANName(o.vlan))
+			}
+			sort.Strings(vlans)
+			b.Note = fmt.Sprintf("tagged or multi-VLAN membership (%s) — bind each VLAN's service explicitly", strings.Join(vlans, ", "))
+			out.Unrecognized = append(out.Unrecognized, b)
+			continue
+		}
+		if o.vlan != 0 && o.routed {
+			b.Note = "both routed and a VLAN member — not a single service"
+			out.Unrecognized = append(out.Unrecognized, b)
+			continue
+		}
+		if o.vlan == 0 && len(o.ips) == 0 {
+			b.Note = "L3 interface with no IP address"
+			out.Unrecognized = append(out.Unrecognized, b)
+			continue
+		}
+
+		b.ServiceType = o.shape()
+		matches := matchServices(o, b.ServiceType, sp)
+		switch len(matches) {
+		case 0:
+			b.Note = fmt.Sprintf("looks like a %s service; no service definition matches", b.ServiceType)
+			out.Unrecognized = append(out.Unrecognized, b)
+		case 1:
+			b.Service = matches[0]
+			b.Step = o.applyServiceStep(b.Service, sp)
+			if o.vlan == 0 && len(o.ips) > 1 {
+				b.Note = fmt.Sprintf("only %s carried over; additional addresses are not part of the service", o.ips[0])
+			}
+			out.Recognized = append(out.Recognized, b)
+		default:
+			b.Note = fmt.Sprintf("looks like a %s service; ambiguous between %s", b.ServiceType, strings.Join(matches, ", "))
+			out.Unrecognized = append(out.Unrecognized, b)
+		}
+	}
+	return out
+}
+
+// shape infers the service type the observed config would come from.
+func (o *observedBinding) shape() string {
+	switch {
+	case o.vlan == 0 && o.vrfVNI != "":
+		return spec.ServiceTypeEVPNRouted
+	case o.vlan == 0:
+		return spec.ServiceTypeRouted
+	case len(o.ips) > 0 && o.vlanVNI != "":
+		return spec.ServiceTypeEVPNIRB
+	case len(o.ips) > 0:
+		return spec.ServiceTypeIRB
+	case o.vlanVNI != "":
+		return spec.ServiceTypeEVPNBridged
+	default:
+		return spec.ServiceTypeBridged
+	}
+}
+
+// matchServices returns the sorted names of every service whose definition
+// would produce the observed shape.
+func matchServices(o *observedBinding, shape string, sp SpecProvider) []string {
+	var out []string
+	for _, name := range sp.ListServices() {
+		svc, err := sp.GetService(name)
+		if err != nil || svc.ServiceType != shape {
+			continue
+		}
+		hasL3 := shape != spec.ServiceTypeBridged && shape != spec.ServiceTypeEVPNBridged
+		if hasL3 && (svc.VRFType != "") != (o.vrf != "") {
+			continue
+		}
+		if svc.IPVPN != "" {
+			ipvpn, err := sp.GetIPVPN(svc.IPVPN)
+			if err != nil || fmt.Sprint(ipvpn.L3VNI) != o.vrfVNI {
+				continue
+			}
+		}
+		if svc.MACVPN != "" {
+			macvpn, err := sp.GetMACVPN(svc.MACVPN)
+			if err != nil || fmt.Sprint(macvpn.VNI) != o.vlanVNI {
+				continue
+			}
+		}
+		if !filterMatches(svc.IngressFilter, o.acls["ingress"], sp) || !filterMatches(svc.EgressFilter, o.acls["egress"], sp) {
+			continue
+		}
+		if o.vlan == 0 && (svc.Routing != nil && svc.Routing.Protocol == "bgp") != (o.bgpPeer != "") {
+			continue
+		}
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// filterMatches reports whether a service's filter reference agrees with the
+// ACL observed in that direction: both absent, or both present. The rule
+// count is not compared — a hand-built ACL rarely lines up rule for rule.
+func filterMatches(filter, acl string, sp SpecProvider) bool {
+	if filter == "" || acl == "" {
+		return filter == "" && acl == ""
+	}
+	_, err := sp.GetFilter(filter)
+	return err == nil
+}
+
+// applyServiceStep builds the topology step that would reproduce the binding.
+func (o *observedBinding) applyServiceStep(service string, sp SpecProvider) *spec.TopologyStep {
+	params := map[string]any{"service": service}
+	svc, _ := sp.GetService(service)
+	switch svc.ServiceType {
+	case spec.ServiceTypeRouted, spec.ServiceTypeEVPNRouted:
+		params["ip_address"] = o.ips[0]
+	case spec.ServiceTypeIRB:
+		params["vlan_id"] = o.vlan
+		params["ip_address"] = o.ips[0]
+	case spec.ServiceTypeBridged:
+		params["vlan_id"] = o.vlan
+	}
+	if svc.Routing != nil && svc.Routing.PeerAS == "request" && o.bgpASN != 0 {
+		params["peer_as"] = o.bgpASN
+	}
+	return &spec.TopologyStep{URL: "/interfaces/" + o.name + "/apply-service", Params: params}
+}
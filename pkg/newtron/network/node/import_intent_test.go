@@ -0,0 +1,90 @@
+package node
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// TestImportIntent_ClassifiesBrownfieldConfig feeds a hand-built CONFIG_DB
+// through the importer: a routed uplink with BGP and an ingress ACL, an EVPN
+// access port, a trunk, and a routed port no service matches.
+func TestImportIntent_ClassifiesBrownfieldConfig(t *testing.T) {
+	raw := sonic.RawConfigDB{
+		"INTERFACE": {
+			"Ethernet0":              {},
+			"Ethernet0|10.1.0.0/31":  {},
+			"Ethernet4":              {"vrf_name": "CUST"},
+			"Ethernet4|10.2.0.0/31":  {},
+			"Ethernet12":             {},
+			"Ethernet12|10.3.0.1/24": {},
+		},
+		"BGP_NEIGHBOR": {"default|10.1.0.1": {"asn": "65100"}},
+		"ACL_TABLE":    {"EDGE_IN": {"ports": "Ethernet0", "stage": "ingress", "type": "L3"}},
+		"VRF":          {"CUST": {}},
+		"VLAN_MEMBER": {
+			"Vlan100|Ethernet8":  {"tagging_mode": "untagged"},
+			"Vlan100|Ethernet16": {"tagging_mode": "tagged"},
+			"Vlan200|Ethernet16": {"tagging_mode": "tagged"},
+		},
+		"VXLAN_TUNNEL_MAP": {"vtep1|map_10100_Vlan100": {"vlan": "Vlan100", "vni": "10100"}},
+		"NEWTRON_INTENT":   {"interface|Ethernet20": {"operation": "apply-service"}},
+	}
+	raw["INTERFACE"]["Ethernet20|10.9.0.0/31"] = map[string]string{} // already managed
+
+	sp := &testSpecProvider{
+		services: map[string]*spec.ServiceSpec{
+			"TRANSIT": {ServiceType: spec.ServiceTypeRouted, IngressFilter: "EDGE",
+				Routing: &spec.RoutingSpec{Protocol: "bgp", PeerAS: "request"}},
+			"CUSTOMER":  {ServiceType: spec.ServiceTypeRouted, VRFType: spec.VRFTypeInterface},
+			"CUSTOMER2": {ServiceType: spec.ServiceTypeRouted, VRFType: spec.VRFTypeShared},
+			"SERVERS":   {ServiceType: spec.ServiceTypeEVPNBridged, MACVPN: "L2_100"},
+		},
+		filterSpecs: map[string]*spec.FilterSpec{"EDGE": {Type: "ipv4"}},
+		macvpn:      map[string]*spec.MACVPNSpec{"L2_100": {VlanID: 100, VNI: 10100}},
+	}
+
+	got := importIntent(raw, sp)
+
+	byIntf := map[string]ImportedBinding{}
+	for _, b := range append(got.Recognized, got.Unrecognized...) {
+		byIntf[b.Interface] = b
+	}
+	if _, ok := byIntf["Ethernet20"]; ok {
+		t.Error("Ethernet20 has an interface intent and must be skipped")
+	}
+
+	transit := byIntf["Ethernet0"]
+	if transit.Service != "TRANSIT" {
+		t.Fatalf("Ethernet0 = %+v, want TRANSIT", transit)
+	}
+	wantStep := &spec.TopologyStep{URL: "/interfaces/Ethernet0/apply-service", Params: map[string]any{
+		"service": "TRANSIT", "ip_address": "10.1.0.0/31", "peer_as": 65100,
+	}}
+	if !reflect.DeepEqual(transit.Step, wantStep) {
+		t.Errorf("Ethernet0 step = %+v, want %+v", transit.Step, wantStep)
+	}
+	wantEntries := []string{"ACL_TABLE|EDGE_IN", "BGP_NEIGHBOR|default|10.1.0.1", "INTERFACE|Ethernet0", "INTERFACE|Ethernet0|10.1.0.0/31"}
+	if !reflect.DeepEqual(transit.Entries, wantEntries) {
+		t.Errorf("Ethernet0 entries = %v, want %v", transit.Entries, wantEntries)
+	}
+
+	if b := byIntf["Ethernet8"]; b.Service != "SERVERS" || b.ServiceType != spec.ServiceTypeEVPNBridged {
+		t.Errorf("Ethernet8 = %+v, want SERVERS (evpn-bridged)", b)
+	}
+	if b := byIntf["Ethernet4"]; b.Service != "" || !strings.Contains(b.Note, "ambiguous between CUSTOMER, CUSTOMER2") {
+		t.Errorf("Ethernet4 = %+v, want ambiguous", b)
+	}
+	if b := byIntf["Ethernet12"]; b.Service != "" || !strings.Contains(b.Note, "no service definition matches") {
+		t.Errorf("Ethernet12 = %+v, want unmatched routed", b)
+	}
+	if b := byIntf["Ethernet16"]; !strings.Contains(b.Note, "Vlan100, Vlan200") {
+		t.Errorf("Ethernet16 = %+v, want trunk note", b)
+	}
+	if len(got.Recognized) != 2 || len(got.Unrecognized) != 3 {
+		t.Errorf("recognized %d, unrecognized %d; want 2 and 3", len(got.Recognized), len(got.Unrecognized))
+	}
+}
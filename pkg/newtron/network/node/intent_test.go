@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -494,6 +495,35 @@ func TestIntentToStep_ConfigureInterface(t *testing.T) {
 	}
 }
 
+// TestIntentToStep_ApplyServiceSecondaryIPs: the binding's comma-joined
+// secondary_ips survives export, and replay splits it back into the opts.
+func TestIntentToStep_ApplyServiceSecondaryIPs(t *testing.T) {
+	step := IntentToStep("interface|Ethernet0|service", map[string]string{
+		"state":         "actuated",
+		"operation":     "apply-service",
+		"service_name":  "CUST",
+		"service_type":  "routed",
+		"ip_address":    "10.1.0.1/30",
+		"secondary_ips": "10.2.0.1/24,10.3.0.1/24",
+	})
+	if step.Params["secondary_ips"] != "10.2.0.1/24,10.3.0.1/24" {
+		t.Fatalf("Params[secondary_ips] = %v", step.Params["secondary_ips"])
+	}
+
+	n := newTestAbstract()
+	n.SpecProvider.(*testSpecProvider).services["CUST"] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeRouted}
+	intf, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := replayApplyService(context.Background(), n, intf, step.Params); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if got := intf.IPAddresses(); len(got) != 3 || got[2] != "10.3.0.1/24" {
+		t.Errorf("IPAddresses after replay = %v, want primary plus two secondaries", got)
+	}
+}
+
 func TestIntentToStep_CreatePortChannel(t *testing.T) {
 	// A create-portchannel intent carries only the LAG's own config — members
 	// are recorded as separate portchannel|<pc>|<member> child intents that
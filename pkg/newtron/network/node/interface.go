@@ -164,10 +164,11 @@ func (i *Interface) IPAddresses() []string {
 			return []string{ip}
 		}
 	}
-	// Routed-service binding: the address is on the binding sub-resource.
+	// Routed-service binding: the addresses are on the binding sub-resource,
+	// the primary first.
 	if intent := i.node.GetIntent(bindingKey(i.name)); intent != nil {
 		if ip := intent.Params[sonic.FieldIPAddress]; ip != "" {
-			return []string{ip}
+			return append([]string{ip}, splitSecondaryIPs(intent.Params[sonic.FieldSecondaryIPs])...)
 		}
 	}
 	return nil
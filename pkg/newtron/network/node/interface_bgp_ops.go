@@ -27,6 +27,40 @@ type DirectBGPPeerConfig struct {
 	Password    string // Optional MD5 password
 	BFD         bool   // Enable BFD for fast failure detection
 	Multihop    int    // eBGP multihop TTL (0 = directly connected)
+
+	// MaxPrefix caps the IPv4 unicast prefixes accepted from the peer (0 = no
+	// limit), so a misbehaving customer peer cannot flood the RIB.
+	// MaxPrefixAction picks what happens past it: BGPMaxPrefixWarning,
+	// BGPMaxPrefixRestart, or "" to tear the session down.
+	MaxPrefix       int
+	MaxPrefixAction string
+}
+
+// validateMaxPrefix checks the prefix-limit pair of a direct peer config.
+func (cfg DirectBGPPeerConfig) validateMaxPrefix() error {
+	if cfg.MaxPrefix < 0 {
+		return fmt.Errorf("max prefix must be positive, got %d", cfg.MaxPrefix)
+	}
+	switch cfg.MaxPrefixAction {
+	case "", BGPMaxPrefixWarning, BGPMaxPrefixRestart:
+	default:
+		return fmt.Errorf("invalid max prefix action %q (want %s or %s)", cfg.MaxPrefixAction, BGPMaxPrefixWarning, BGPMaxPrefixRestart)
+	}
+	if cfg.MaxPrefixAction != "" && cfg.MaxPrefix == 0 {
+		return fmt.Errorf("max prefix action %q requires a max prefix", cfg.MaxPrefixAction)
+	}
+	return nil
+}
+
+// maxPrefixSummary renders the prefix limit for log lines ("" when unset).
+func (cfg DirectBGPPeerConfig) maxPrefixSummary() string {
+	if cfg.MaxPrefix == 0 {
+		return ""
+	}
+	if cfg.MaxPrefixAction != "" {
+		return fmt.Sprintf(", max-prefix %d %s", cfg.MaxPrefix, cfg.MaxPrefixAction)
+	}
+	return fmt.Sprintf(", max-prefix %d", cfg.MaxPrefix)
 }
 
 // AddBGPPeer adds a direct BGP peer on this interface.
@@ -41,6 +75,9 @@ func (i *Interface) AddBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig) (*C
 	if cfg.RemoteAS == 0 {
 		return nil, fmt.Errorf("remote AS number is required")
 	}
+	if err := cfg.validateMaxPrefix(); err != nil {
+		return nil, err
+	}
 
 	// Interface must have an IP address
 	ipAddresses := i.IPAddresses()
@@ -80,6 +117,9 @@ func (i *Interface) AddBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig) (*C
 		EBGPMultihop: cfg.Multihop > 0,
 		MultihopTTL:  fmt.Sprintf("%d", cfg.Multihop),
 		ActivateIPv4: true,
+
+		MaxPrefix:       cfg.MaxPrefix,
+		MaxPrefixAction: cfg.MaxPrefixAction,
 	})
 	cs := buildChangeSet(n.Name(), "interface."+sonic.OpAddBGPPeer, config, ChangeAdd)
 	if err := i.createInterfaceIntent(cs); err != nil {
@@ -95,6 +135,12 @@ func (i *Interface) AddBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig) (*C
 	if cfg.Multihop > 0 {
 		intentParams["multihop"] = strconv.Itoa(cfg.Multihop)
 	}
+	if cfg.MaxPrefix > 0 {
+		intentParams[sonic.FieldMaxPrefix] = strconv.Itoa(cfg.MaxPrefix)
+		if cfg.MaxPrefixAction != "" {
+			intentParams[sonic.FieldMaxPrefixAction] = cfg.MaxPrefixAction
+		}
+	}
 	if err := n.writeIntent(cs, sonic.OpAddBGPPeer, "interface|"+i.name+"|bgp-peer", intentParams, []string{"interface|" + i.name}); err != nil {
 		return nil, err
 	}
@@ -104,8 +150,8 @@ func (i *Interface) AddBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig) (*C
 	if err := n.render(cs); err != nil {
 		return nil, err
 	}
-	util.WithDevice(n.Name()).Infof("Adding direct BGP peer %s (AS %d) on interface %s",
-		neighborIP, cfg.RemoteAS, i.name)
+	util.WithDevice(n.Name()).Infof("Adding direct BGP peer %s (AS %d%s) on interface %s",
+		neighborIP, cfg.RemoteAS, cfg.maxPrefixSummary(), i.name)
 	return cs, nil
 }
 
@@ -133,6 +179,9 @@ func (i *Interface) UpdateBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig)
 	if cfg.RemoteAS == 0 {
 		return nil, fmt.Errorf("remote AS number is required")
 	}
+	if err := cfg.validateMaxPrefix(); err != nil {
+		return nil, err
+	}
 
 	intentKey := "interface|" + i.name + "|bgp-peer"
 	existing := n.GetIntent(intentKey)
@@ -159,6 +208,9 @@ func (i *Interface) UpdateBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig)
 		EBGPMultihop: cfg.Multihop > 0,
 		MultihopTTL:  fmt.Sprintf("%d", cfg.Multihop),
 		ActivateIPv4: true,
+
+		MaxPrefix:       cfg.MaxPrefix,
+		MaxPrefixAction: cfg.MaxPrefixAction,
 	})
 
 	// In-place replace of the same (vrf, neighbor_ip) key — the neighbor IP is
@@ -178,6 +230,12 @@ func (i *Interface) UpdateBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig)
 	if cfg.Multihop > 0 {
 		intentParams["multihop"] = strconv.Itoa(cfg.Multihop)
 	}
+	if cfg.MaxPrefix > 0 {
+		intentParams[sonic.FieldMaxPrefix] = strconv.Itoa(cfg.MaxPrefix)
+		if cfg.MaxPrefixAction != "" {
+			intentParams[sonic.FieldMaxPrefixAction] = cfg.MaxPrefixAction
+		}
+	}
 	if err := n.writeIntent(cs, sonic.OpAddBGPPeer, intentKey, intentParams, []string{"interface|" + i.name}); err != nil {
 		return nil, err
 	}
@@ -187,8 +245,8 @@ func (i *Interface) UpdateBGPPeer(ctx context.Context, cfg DirectBGPPeerConfig)
 	if err := n.render(cs); err != nil {
 		return nil, err
 	}
-	util.WithDevice(n.Name()).Infof("Updated BGP peer on interface %s (neighbor=%s, AS=%d)",
-		i.name, neighborIP, cfg.RemoteAS)
+	util.WithDevice(n.Name()).Infof("Updated BGP peer on interface %s (neighbor=%s, AS=%d%s)",
+		i.name, neighborIP, cfg.RemoteAS, cfg.maxPrefixSummary())
 	return cs, nil
 }
 
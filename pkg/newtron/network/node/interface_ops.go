@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -59,6 +60,60 @@ func (n *Node) vlanMemberPorts(vlanID int) []string {
 	return ports
 }
 
+// normalizeInterfaceName normalizes an interface name for this device's
+// platform: Eth0 -> Ethernet0 generically, but a name in the platform's port
+// inventory keeps the inventory's spelling (util.NormalizeInterfaceNameForPorts),
+// so a mixed-platform fabric normalizes each device by its own convention.
+// A PORT row's alias (portByAlias) resolves to that port first. A node with
+// no resolvable platform gets the generic rule.
+func (n *Node) normalizeInterfaceName(name string) string {
+	if port := n.portByAlias(name); port != "" {
+		return port
+	}
+	var ports []string
+	if n != nil && n.SpecProvider != nil && n.resolved != nil && n.resolved.Platform != "" {
+		if platform, err := n.GetPlatform(n.resolved.Platform); err == nil {
+			for _, p := range platform.Ports {
+				ports = append(ports, p.Name)
+			}
+		}
+	}
+	return util.NormalizeInterfaceNameForPorts(name, ports)
+}
+
+// portByAlias returns the PORT row whose alias field is name — a platform
+// breakout alias such as "etp1a" — or "" when there is none. Matching is
+// case-insensitive, and a subinterface ("etp1a.10") resolves by its parent.
+// A port's own name wins over another port's alias of the same spelling.
+func (n *Node) portByAlias(name string) string {
+	if n == nil || n.configDB == nil {
+		return ""
+	}
+	parent, sub, hasSub := strings.Cut(strings.TrimSpace(name), ".")
+	if _, ok := n.configDB.Port[parent]; ok || parent == "" {
+		return ""
+	}
+	for port, entry := range n.configDB.Port {
+		if entry.Alias != "" && strings.EqualFold(entry.Alias, parent) {
+			if hasSub {
+				return port + "." + sub
+			}
+			return port
+		}
+	}
+	return ""
+}
+
+// ResolveInterfaceAlias resolves a user-supplied interface name to the
+// device's canonical name: a PORT alias (etp1a -> Ethernet0), then the
+// platform's prefix rules (Eth0 -> Ethernet0, Po100 -> PortChannel100).
+// ok reports whether the result names an interface that exists on the
+// device, so a caller can reject bad input before an operation runs.
+func (n *Node) ResolveInterfaceAlias(name string) (canonical string, ok bool) {
+	canonical = n.normalizeInterfaceName(name)
+	return canonical, n.InterfaceExists(canonical)
+}
+
 // InterfaceExists checks if an interface exists.
 // Accepts both short (Eth0) and full (Ethernet0) interface names.
 // Existence is kind-specific: physical ports from the RegisterPort map,
@@ -67,7 +122,7 @@ func (n *Node) vlanMemberPorts(vlanID int) []string {
 // ListInterfaces enumerates from the same sources, so whatever exists
 // is also listed (§24).
 func (n *Node) InterfaceExists(name string) bool {
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 	switch interfaceKindOf(name) {
 	case KindEthernet:
 		_, ok := n.interfaces[name]
@@ -86,8 +141,18 @@ func (n *Node) InterfaceExists(name string) bool {
 // Interface Property Operations
 // ============================================================================
 
-// SetIP configures an IP address on this interface.
-func (i *Interface) SetIP(ctx context.Context, ipAddr string) (*ChangeSet, error) {
+// SetIPOpts controls how SetIP treats the interface's existing addresses.
+type SetIPOpts struct {
+	// Replace removes every other address on the interface in the same
+	// ChangeSet, so a re-IP lands atomically instead of stacking the new
+	// address beside the old ones.
+	Replace bool
+}
+
+// SetIP configures an IP address on this interface. By default the address
+// is added beside any already configured; with opts.Replace it becomes the
+// interface's only address.
+func (i *Interface) SetIP(ctx context.Context, ipAddr string, opts SetIPOpts) (*ChangeSet, error) {
 	n := i.node
 
 	if err := n.precondition("set-ip", i.name).
@@ -101,18 +166,26 @@ func (i *Interface) SetIP(ctx context.Context, ipAddr string) (*ChangeSet, error
 		return nil, fmt.Errorf("cannot configure IP on PortChannel member")
 	}
 
+	cs := NewChangeSet(n.Name(), "interface.set-ip")
+	if opts.Replace {
+		stale, err := i.replacedIPs(ipAddr)
+		if err != nil {
+			return nil, err
+		}
+		for _, addr := range stale {
+			cs.Deletes(deleteInterfaceIPConfig(i.name, addr))
+		}
+	}
+
 	// SONiC requires both the base interface entry and the IP entry.
 	// The base entry enables routing on the interface; the IP entry assigns the address.
 	// However, if the interface already has a VRF binding (INTERFACE|name with
 	// vrf_name set), the base entry already exists — re-writing it with NULL:NULL
 	// disrupts intfmgrd on CiscoVS (see RCA-037). Skip enableIpRouting in that case.
-	var entries []sonic.Entry
 	if i.VRF() == "" {
-		entries = append(enableIpRoutingConfig(i.name), assignIpAddressConfig(i.name,ipAddr)...)
-	} else {
-		entries = assignIpAddressConfig(i.name,ipAddr)
+		cs.Adds(enableIpRoutingConfig(i.name))
 	}
-	cs := buildChangeSet(n.Name(), "interface.set-ip", entries, ChangeAdd)
+	cs.Adds(assignIpAddressConfig(i.name, ipAddr))
 
 	if err := n.render(cs); err != nil {
 		return nil, err
@@ -121,6 +194,28 @@ func (i *Interface) SetIP(ctx context.Context, ipAddr string) (*ChangeSet, error
 	return cs, nil
 }
 
+// replacedIPs returns the addresses a replacing SetIP of ipAddr removes:
+// every IP sub-entry on the interface other than ipAddr itself, in key
+// order. An address an intent owns — configure-interface, configure-irb, a
+// routed service — is refused rather than removed behind its record's back;
+// change it through the operation that set it.
+func (i *Interface) replacedIPs(ipAddr string) ([]string, error) {
+	owned := i.IPAddresses()
+	var stale []string
+	for key := range i.node.Projection()[l3Table(i.name)] {
+		name, addr, ok := strings.Cut(key, "|")
+		if !ok || name != i.name || addr == ipAddr {
+			continue
+		}
+		if slices.Contains(owned, addr) {
+			return nil, fmt.Errorf("cannot replace IP %s on %s: it is owned by the interface's intent record — change it through the operation that configured it", addr, i.name)
+		}
+		stale = append(stale, addr)
+	}
+	sort.Strings(stale)
+	return stale, nil
+}
+
 // RemoveIP removes an IP address from this interface.
 func (i *Interface) RemoveIP(ctx context.Context, ipAddr string) (*ChangeSet, error) {
 	n := i.node
@@ -479,9 +574,106 @@ func (i *Interface) RemoveTrunkVLAN(ctx context.Context, vlanID int) (*ChangeSet
 	return cs, nil
 }
 
+// isRouted reports whether the interface carries L3 identity — an IP or VRF
+// from configure-interface, or a routed service binding's VRF.
+func (i *Interface) isRouted() bool {
+	if i.VRF() != "" || len(i.IPAddresses()) > 0 {
+		return true
+	}
+	return i.binding()[sonic.FieldVRFName] != ""
+}
+
+// SetVLANTranslation rewrites customer VLAN inVLAN arriving on this interface
+// to provider VLAN outVLAN (provider-edge VLAN mapping; distinct from a QinQ
+// service, which pushes an outer tag rather than replacing the customer one).
+// The provider VLAN must exist; the port must be bridged — translation on a
+// routed port is refused. Each customer VLAN gets its own intent record
+// (interface|{name}|vlan-translation|{in}), so several translations coexist
+// on one port and replay reconstructs them all, as for trunk membership.
+func (i *Interface) SetVLANTranslation(ctx context.Context, inVLAN, outVLAN int) (*ChangeSet, error) {
+	n := i.node
+	if err := n.precondition(sonic.OpSetVLANTranslation, i.name).
+		Check(inVLAN >= 1 && inVLAN <= 4094, "valid customer VLAN", fmt.Sprintf("must be 1-4094, got %d", inVLAN)).
+		Check(outVLAN >= 1 && outVLAN <= 4094, "valid provider VLAN", fmt.Sprintf("must be 1-4094, got %d", outVLAN)).
+		Check(inVLAN != outVLAN, "distinct VLANs", fmt.Sprintf("customer and provider VLAN are both %d", inVLAN)).
+		Result(); err != nil {
+		return nil, err
+	}
+	if i.IsPortChannelMember() {
+		return nil, fmt.Errorf("cannot set VLAN translation on PortChannel member %s — configure the PortChannel", i.name)
+	}
+	if i.isRouted() {
+		return nil, fmt.Errorf("interface %s is routed — VLAN translation requires a bridged port", i.name)
+	}
+	if n.GetIntent(fmt.Sprintf("vlan|%d", outVLAN)) == nil {
+		return nil, fmt.Errorf("VLAN %d does not exist", outVLAN)
+	}
+
+	resource := fmt.Sprintf("interface|%s|vlan-translation|%d", i.name, inVLAN)
+	opParams := map[string]string{"interface": i.name, "in_vlan": strconv.Itoa(inVLAN), "out_vlan": strconv.Itoa(outVLAN)}
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpSetVLANTranslation)
+	if existing := n.GetIntent(resource); existing != nil {
+		if existing.Params[sonic.FieldOutVLAN] == strconv.Itoa(outVLAN) {
+			// Same mapping already in place — idempotent no-op.
+			cs.OperationParams = opParams
+			if err := n.render(cs); err != nil {
+				return nil, err
+			}
+			return cs, nil
+		}
+		return nil, fmt.Errorf("VLAN %d on %s is already translated to VLAN %s — clear it first",
+			inVLAN, i.name, existing.Params[sonic.FieldOutVLAN])
+	}
+
+	if err := i.createInterfaceIntent(cs); err != nil {
+		return nil, err
+	}
+	params := map[string]string{
+		sonic.FieldInVLAN:  strconv.Itoa(inVLAN),
+		sonic.FieldOutVLAN: strconv.Itoa(outVLAN),
+	}
+	parents := []string{"interface|" + i.name, fmt.Sprintf("vlan|%d", outVLAN)}
+	if err := n.writeIntent(cs, sonic.OpSetVLANTranslation, resource, params, parents); err != nil {
+		return nil, err
+	}
+	cs.Adds(createVlanTranslationConfig(i.name, inVLAN, outVLAN))
+	cs.ReverseOp = "interface." + sonic.OpClearVLANTranslation
+	cs.OperationParams = opParams
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.Name()).Infof("Set VLAN translation %d -> %d on %s", inVLAN, outVLAN, i.name)
+	return cs, nil
+}
+
+// ClearVLANTranslation removes the translation for customer VLAN inVLAN on
+// this interface — its VLAN_TRANSLATION entry and intent record. Other
+// translations on the port are untouched. Reverse of SetVLANTranslation (§15).
+func (i *Interface) ClearVLANTranslation(ctx context.Context, inVLAN int) (*ChangeSet, error) {
+	n := i.node
+	if err := n.precondition(sonic.OpClearVLANTranslation, i.name).Result(); err != nil {
+		return nil, err
+	}
+	resource := fmt.Sprintf("interface|%s|vlan-translation|%d", i.name, inVLAN)
+	if n.GetIntent(resource) == nil {
+		return nil, fmt.Errorf("no VLAN translation for VLAN %d on %s", inVLAN, i.name)
+	}
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpClearVLANTranslation)
+	cs.Deletes(deleteVlanTranslationConfig(i.name, inVLAN))
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	cs.OperationParams = map[string]string{"interface": i.name, "in_vlan": strconv.Itoa(inVLAN)}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.Name()).Infof("Cleared VLAN translation for VLAN %d on %s", inVLAN, i.name)
+	return cs, nil
+}
+
 // UnconfigureInterface is the reverse of ConfigureInterface. Performs a complete
 // teardown: removes all sub-resources (BGP peer, QoS, ACL bindings, properties,
-// trunk VLAN memberships), then removes the interface role (access VLAN or
+// trunk VLAN memberships, VLAN translations), then removes the interface role (access VLAN or
 // VRF/IP binding). Parameterless — the intent records are self-sufficient for
 // teardown.
 func (i *Interface) UnconfigureInterface(ctx context.Context) (*ChangeSet, error) {
@@ -495,6 +687,9 @@ func (i *Interface) UnconfigureInterface(ctx context.Context) (*ChangeSet, error
 	if intent == nil {
 		return nil, fmt.Errorf("no configuration intent for %s", i.name)
 	}
+	if i.IsIsolated() {
+		return nil, fmt.Errorf("%s is isolated — unisolate it before unconfiguring", i.name)
+	}
 
 	cs := NewChangeSet(n.Name(), "interface.unconfigure-interface")
 
@@ -539,6 +734,13 @@ func (i *Interface) UnconfigureInterface(ctx context.Context) (*ChangeSet, error
 			}
 			cs.Merge(subCS)
 
+		case sonic.OpSetEgressShaper:
+			subCS, err := i.ClearEgressShaper(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("clear egress shaper on %s: %w", i.name, err)
+			}
+			cs.Merge(subCS)
+
 		case sonic.OpBindACL:
 			aclName := childIntent.Params[sonic.FieldACLName]
 			subCS, err := i.UnbindACL(ctx, aclName)
@@ -567,6 +769,15 @@ func (i *Interface) UnconfigureInterface(ctx context.Context) (*ChangeSet, error
 			if err := n.deleteIntent(cs, childKey); err != nil {
 				return nil, err
 			}
+
+		case sonic.OpSetVLANTranslation:
+			// Same inline form as trunk membership — one merged ChangeSet.
+			if inVLAN, _ := strconv.Atoi(childIntent.Params[sonic.FieldInVLAN]); inVLAN > 0 {
+				cs.Deletes(deleteVlanTranslationConfig(i.name, inVLAN))
+			}
+			if err := n.deleteIntent(cs, childKey); err != nil {
+				return nil, err
+			}
 		}
 	}
 
@@ -616,7 +827,12 @@ func (i *Interface) UnconfigureInterface(ctx context.Context) (*ChangeSet, error
 	// Now that every membership intent (access + trunk) is gone, re-render the
 	// irb-service ACLs of each VLAN this port left, and drop its QoS rows (the
 	// interface belongs to no serviced VLAN anymore) — §4.
+	vlanIDs := make([]int, 0, len(leftVLANs))
 	for v := range leftVLANs {
+		vlanIDs = append(vlanIDs, v)
+	}
+	sort.Ints(vlanIDs) // map order would reorder the ACL updates run to run
+	for _, v := range vlanIDs {
 		n.rebindMemberACLs(cs, v)
 	}
 	n.unbindMemberQoS(cs, i.name)
@@ -727,6 +943,9 @@ func (i *Interface) SetProperty(ctx context.Context, property, value string) (*C
 	if i.IsPortChannelMember() {
 		return nil, fmt.Errorf("cannot configure PortChannel member directly - configure the parent PortChannel")
 	}
+	if (property == "admin-status" || property == "admin_status") && i.IsIsolated() {
+		return nil, fmt.Errorf("%s is isolated — unisolate it to change admin-status", i.name)
+	}
 
 	cs := NewChangeSet(n.Name(), "interface."+sonic.OpSetProperty)
 	if err := i.createInterfaceIntent(cs); err != nil {
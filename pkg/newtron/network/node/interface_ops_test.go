@@ -265,7 +265,7 @@ func TestSetIP(t *testing.T) {
 	_, intf := testInterface()
 	ctx := context.Background()
 
-	cs, err := intf.SetIP(ctx, "10.1.0.0/31")
+	cs, err := intf.SetIP(ctx, "10.1.0.0/31", SetIPOpts{})
 	if err != nil {
 		t.Fatalf("SetIP: %v", err)
 	}
@@ -288,7 +288,7 @@ func TestSetIP_VRFBound(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	cs, err := intf.SetIP(ctx, "10.1.0.0/31")
+	cs, err := intf.SetIP(ctx, "10.1.0.0/31", SetIPOpts{})
 	if err != nil {
 		t.Fatalf("SetIP: %v", err)
 	}
@@ -306,12 +306,88 @@ func TestSetIP_Invalid(t *testing.T) {
 	_, intf := testInterface()
 	ctx := context.Background()
 
-	_, err := intf.SetIP(ctx, "not-an-ip")
+	_, err := intf.SetIP(ctx, "not-an-ip", SetIPOpts{})
 	if err == nil {
 		t.Fatal("expected error for invalid IP")
 	}
 }
 
+func TestSetIP_AppendKeepsExisting(t *testing.T) {
+	d, intf := testInterface()
+	ctx := context.Background()
+	if _, err := intf.SetIP(ctx, "10.1.0.0/31", SetIPOpts{}); err != nil {
+		t.Fatalf("first SetIP: %v", err)
+	}
+
+	cs, err := intf.SetIP(ctx, "10.2.0.0/31", SetIPOpts{})
+	if err != nil {
+		t.Fatalf("second SetIP: %v", err)
+	}
+	for _, c := range cs.Changes {
+		if c.Type == ChangeDelete {
+			t.Errorf("append emitted a delete: %s|%s", c.Table, c.Key)
+		}
+	}
+	for _, key := range []string{"Ethernet0|10.1.0.0/31", "Ethernet0|10.2.0.0/31"} {
+		if _, ok := d.Projection()["INTERFACE"][key]; !ok {
+			t.Errorf("INTERFACE|%s missing after append", key)
+		}
+	}
+}
+
+func TestSetIP_ReplaceDeletesExisting(t *testing.T) {
+	d, intf := testInterface()
+	ctx := context.Background()
+	for _, ip := range []string{"10.1.0.0/31", "10.3.0.0/31"} {
+		if _, err := intf.SetIP(ctx, ip, SetIPOpts{}); err != nil {
+			t.Fatalf("SetIP %s: %v", ip, err)
+		}
+	}
+
+	cs, err := intf.SetIP(ctx, "10.2.0.0/31", SetIPOpts{Replace: true})
+	if err != nil {
+		t.Fatalf("replacing SetIP: %v", err)
+	}
+	// One ChangeSet: the old addresses go first, then the new one lands.
+	want := "delete INTERFACE|Ethernet0|10.1.0.0/31, delete INTERFACE|Ethernet0|10.3.0.0/31, " +
+		"add INTERFACE|Ethernet0, add INTERFACE|Ethernet0|10.2.0.0/31"
+	if got := changeIDs(cs); got != want {
+		t.Errorf("changes = %s\nwant      %s", got, want)
+	}
+	var addrs []string
+	for key := range d.Projection()["INTERFACE"] {
+		if strings.HasPrefix(key, "Ethernet0|") {
+			addrs = append(addrs, key)
+		}
+	}
+	if len(addrs) != 1 || addrs[0] != "Ethernet0|10.2.0.0/31" {
+		t.Errorf("addresses after replace = %v, want only 10.2.0.0/31", addrs)
+	}
+
+	// Replacing with the address already there deletes nothing.
+	cs, err = intf.SetIP(ctx, "10.2.0.0/31", SetIPOpts{Replace: true})
+	if err != nil {
+		t.Fatalf("idempotent replace: %v", err)
+	}
+	assertNoChangeOfType(t, cs, "INTERFACE", "Ethernet0|10.2.0.0/31", ChangeDelete)
+}
+
+func TestSetIP_ReplaceRefusesIntentOwnedAddress(t *testing.T) {
+	d, intf := testInterface()
+	ctx := context.Background()
+	if _, err := intf.ConfigureInterface(ctx, InterfaceConfig{IP: "10.1.0.0/31"}); err != nil {
+		t.Fatalf("ConfigureInterface: %v", err)
+	}
+
+	_, err := intf.SetIP(ctx, "10.2.0.0/31", SetIPOpts{Replace: true})
+	if err == nil || !strings.Contains(err.Error(), "owned by the interface's intent record") {
+		t.Fatalf("err = %v, want refusal naming the intent-owned address", err)
+	}
+	if _, ok := d.Projection()["INTERFACE"]["Ethernet0|10.1.0.0/31"]; !ok {
+		t.Error("refused replace removed the intent-owned address")
+	}
+}
+
 func TestSetVRF(t *testing.T) {
 	d, intf := testInterface()
 	d.configDB.VRF["Vrf_CUST1"] = sonic.VRFEntry{}
@@ -436,6 +512,58 @@ func TestAddBGPPeer(t *testing.T) {
 	assertChange(t, cs, "NEWTRON_INTENT", "interface|Ethernet0", ChangeAdd)
 }
 
+func TestAddBGPPeer_MaxPrefix(t *testing.T) {
+	ctx := context.Background()
+	setup := func() *Interface {
+		d, intf := testInterface()
+		d.configDB.NewtronIntent["interface|Ethernet0"] = map[string]string{
+			"operation": "configure-interface",
+			"state":     "actuated",
+			"ip":        "10.1.0.0/31",
+		}
+		d.configDB.DeviceMetadata["localhost"] = map[string]string{"bgp_asn": "64512"}
+		return intf
+	}
+
+	tests := []struct {
+		action string
+		want   map[string]string // BGP_NEIGHBOR_AF fields; "" = must be absent
+	}{
+		{"", map[string]string{"max_prefix_limit": "500", "max_prefix_warning_only": "", "max_prefix_restart_interval": ""}},
+		{BGPMaxPrefixWarning, map[string]string{"max_prefix_limit": "500", "max_prefix_warning_only": "true", "max_prefix_restart_interval": ""}},
+		{BGPMaxPrefixRestart, map[string]string{"max_prefix_limit": "500", "max_prefix_warning_only": "", "max_prefix_restart_interval": "5"}},
+	}
+	for _, tt := range tests {
+		t.Run("action="+tt.action, func(t *testing.T) {
+			intf := setup()
+			cs, err := intf.AddBGPPeer(ctx, DirectBGPPeerConfig{RemoteAS: 64513, MaxPrefix: 500, MaxPrefixAction: tt.action})
+			if err != nil {
+				t.Fatalf("AddBGPPeer: %v", err)
+			}
+			af := assertChange(t, cs, "BGP_NEIGHBOR_AF", "default|10.1.0.1|ipv4_unicast", ChangeAdd)
+			for field, want := range tt.want {
+				if got := af.Fields[field]; got != want {
+					t.Errorf("BGP_NEIGHBOR_AF %s = %q, want %q", field, got, want)
+				}
+			}
+			intent := intf.node.GetIntent("interface|Ethernet0|bgp-peer")
+			if intent.Params[sonic.FieldMaxPrefix] != "500" || intent.Params[sonic.FieldMaxPrefixAction] != tt.action {
+				t.Errorf("intent params = %v, want max_prefix 500 action %q", intent.Params, tt.action)
+			}
+		})
+	}
+
+	for _, cfg := range []DirectBGPPeerConfig{
+		{RemoteAS: 64513, MaxPrefix: -1},
+		{RemoteAS: 64513, MaxPrefix: 500, MaxPrefixAction: "drop"},
+		{RemoteAS: 64513, MaxPrefixAction: BGPMaxPrefixWarning},
+	} {
+		if _, err := setup().AddBGPPeer(ctx, cfg); err == nil {
+			t.Errorf("AddBGPPeer(max prefix %d, action %q) succeeded, want error", cfg.MaxPrefix, cfg.MaxPrefixAction)
+		}
+	}
+}
+
 func TestRemoveBGPPeer(t *testing.T) {
 	d, intf := testInterface()
 	d.configDB.Interface["Ethernet0|10.1.0.0/31"] = sonic.InterfaceEntry{}
@@ -483,7 +611,7 @@ func TestInterface_NotConnected(t *testing.T) {
 		name string
 		fn   func() error
 	}{
-		{"SetIP", func() error { _, err := intf.SetIP(ctx, "10.0.0.1/30"); return err }},
+		{"SetIP", func() error { _, err := intf.SetIP(ctx, "10.0.0.1/30", SetIPOpts{}); return err }},
 		{"SetVRF", func() error { _, err := intf.SetVRF(ctx, "default"); return err }},
 		{"BindACL", func() error { _, err := intf.BindACL(ctx, "ACL1", "ingress"); return err }},
 		{"AddBGPPeer", func() error {
@@ -515,11 +643,14 @@ func TestInterface_PortChannelMemberBlocksConfig(t *testing.T) {
 	}
 	ctx := context.Background()
 
-	// SetIP should fail for PortChannel member
-	_, err := intf.SetIP(ctx, "10.0.0.1/30")
+	// SetIP should fail for PortChannel member, appending or replacing
+	_, err := intf.SetIP(ctx, "10.0.0.1/30", SetIPOpts{})
 	if err == nil {
 		t.Fatal("expected error for PortChannel member SetIP")
 	}
+	if _, err := intf.SetIP(ctx, "10.0.0.1/30", SetIPOpts{Replace: true}); err == nil {
+		t.Fatal("expected error for PortChannel member replacing SetIP")
+	}
 
 	// SetVRF should fail for PortChannel member
 	_, err = intf.SetVRF(ctx, "default")
@@ -585,6 +716,111 @@ func TestApplyService_RoutingAllowedOnL3(t *testing.T) {
 	}
 }
 
+// TestApplyService_IPv6Routed applies a BGP routed service with an IPv6 /126:
+// the address lands on the port, the peer is the other usable host, and the
+// session is activated in ipv6_unicast only.
+func TestApplyService_IPv6Routed(t *testing.T) {
+	n := newTestAbstract()
+	n.SpecProvider.(*testSpecProvider).services["TRANSIT_V6"] = &spec.ServiceSpec{
+		ServiceType: spec.ServiceTypeRouted,
+		Routing:     &spec.RoutingSpec{Protocol: spec.RoutingProtocolBGP, PeerAS: "65002"},
+	}
+	intf, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+
+	cs, err := intf.ApplyService(context.Background(), "TRANSIT_V6", ApplyServiceOpts{IPAddress: "2001:db8::1/126"})
+	if err != nil {
+		t.Fatalf("ApplyService: %v", err)
+	}
+	assertChange(t, cs, "INTERFACE", "Ethernet0|2001:db8::1/126", ChangeAdd)
+	nbr := assertChange(t, cs, "BGP_NEIGHBOR", BGPNeighborKey("default", "2001:db8::2"), ChangeAdd)
+	assertField(t, nbr, "asn", "65002")
+	assertChange(t, cs, "BGP_NEIGHBOR_AF", BGPNeighborAFKey("default", "2001:db8::2", "ipv6_unicast"), ChangeAdd)
+	assertNoChange(t, cs, "BGP_NEIGHBOR_AF", BGPNeighborAFKey("default", "2001:db8::2", "ipv4_unicast"))
+	assertChange(t, cs, "BGP_GLOBALS_AF", BGPGlobalsAFKey("default", "ipv6_unicast"), ChangeAdd)
+}
+
+func TestApplyService_IPv6RejectsRoutePolicy(t *testing.T) {
+	n := newTestAbstract()
+	n.SpecProvider.(*testSpecProvider).services["TRANSIT_V6"] = &spec.ServiceSpec{
+		ServiceType: spec.ServiceTypeRouted,
+		Routing:     &spec.RoutingSpec{Protocol: spec.RoutingProtocolBGP, PeerAS: "65002", ImportPolicy: "CUSTOMER_IN"},
+	}
+	intf, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+
+	_, err = intf.ApplyService(context.Background(), "TRANSIT_V6", ApplyServiceOpts{IPAddress: "2001:db8::1/127"})
+	if err == nil || !strings.Contains(err.Error(), "IPv4 unicast only") {
+		t.Fatalf("err = %v, want IPv4-unicast-only policy rejection", err)
+	}
+}
+
+func TestApplyService_SecondaryIPs(t *testing.T) {
+	n := newTestAbstract()
+	n.SpecProvider.(*testSpecProvider).services["CUST"] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeRouted}
+	intf, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+	ctx := context.Background()
+
+	cs, err := intf.ApplyService(ctx, "CUST", ApplyServiceOpts{
+		IPAddress:    "10.1.0.1/30",
+		SecondaryIPs: []string{"10.2.0.1/24", "10.3.0.1/24"},
+	})
+	if err != nil {
+		t.Fatalf("ApplyService: %v", err)
+	}
+	for _, ip := range []string{"10.1.0.1/30", "10.2.0.1/24", "10.3.0.1/24"} {
+		assertChange(t, cs, "INTERFACE", "Ethernet0|"+ip, ChangeAdd)
+	}
+	binding := assertChange(t, cs, "NEWTRON_INTENT", "interface|Ethernet0|service", ChangeAdd)
+	assertField(t, binding, sonic.FieldSecondaryIPs, "10.2.0.1/24,10.3.0.1/24")
+	if got := intf.IPAddresses(); len(got) != 3 || got[0] != "10.1.0.1/30" {
+		t.Errorf("IPAddresses = %v, want the primary then both secondaries", got)
+	}
+
+	cs, err = intf.RemoveService(ctx)
+	if err != nil {
+		t.Fatalf("RemoveService: %v", err)
+	}
+	for _, ip := range []string{"10.1.0.1/30", "10.2.0.1/24", "10.3.0.1/24"} {
+		assertChange(t, cs, "INTERFACE", "Ethernet0|"+ip, ChangeDelete)
+	}
+}
+
+func TestApplyService_SecondaryIPsRejected(t *testing.T) {
+	n := newTestAbstract()
+	sp := n.SpecProvider.(*testSpecProvider)
+	sp.services["CUST"] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeRouted}
+	sp.services["L2"] = &spec.ServiceSpec{ServiceType: spec.ServiceTypeBridged}
+	intf, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+
+	tests := []struct {
+		name, service string
+		opts          ApplyServiceOpts
+		wantErr       string
+	}{
+		{"not a CIDR", "CUST", ApplyServiceOpts{IPAddress: "10.1.0.1/30", SecondaryIPs: []string{"10.2.0.1"}}, "invalid secondary IP"},
+		{"inside the primary", "CUST", ApplyServiceOpts{IPAddress: "10.1.0.1/24", SecondaryIPs: []string{"10.1.0.5/30"}}, "overlaps"},
+		{"overlapping secondaries", "CUST", ApplyServiceOpts{IPAddress: "10.1.0.1/30", SecondaryIPs: []string{"10.2.0.1/24", "10.2.0.129/25"}}, "overlaps"},
+		{"L2 service", "L2", ApplyServiceOpts{VLAN: 100, SecondaryIPs: []string{"10.2.0.1/24"}}, "routed or evpn-routed"},
+	}
+	for _, tt := range tests {
+		_, err := intf.ApplyService(context.Background(), tt.service, tt.opts)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: err = %v, want %q", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
 func TestRemoveService_NoServiceBound(t *testing.T) {
 	_, intf := testInterface()
 	ctx := context.Background()
@@ -973,6 +1209,132 @@ func TestRoundTrip_AddRemoveTrunkVLAN(t *testing.T) {
 	}
 }
 
+// ============================================================================
+// VLAN translation — per-customer-VLAN intent records + ClearVLANTranslation
+// ============================================================================
+
+func TestSetVLANTranslation_GeneratesEntries(t *testing.T) {
+	n, iface := trunkSetup(t)
+	ctx := context.Background()
+
+	cs, err := iface.SetVLANTranslation(ctx, 1010, 200)
+	if err != nil {
+		t.Fatalf("SetVLANTranslation: %v", err)
+	}
+	c := assertChange(t, cs, "VLAN_TRANSLATION", "Ethernet0|1010", ChangeAdd)
+	if c.Fields["translated_vlan"] != "200" {
+		t.Errorf("translated_vlan = %q, want 200", c.Fields["translated_vlan"])
+	}
+	assertChange(t, cs, "NEWTRON_INTENT", "interface|Ethernet0|vlan-translation|1010", ChangeAdd)
+	if cs.ReverseOp != "interface.clear-vlan-translation" {
+		t.Errorf("ReverseOp = %q, want interface.clear-vlan-translation", cs.ReverseOp)
+	}
+
+	intent := n.GetIntent("interface|Ethernet0|vlan-translation|1010")
+	if intent == nil {
+		t.Fatal("expected vlan-translation intent")
+	}
+	if intent.Params["in_vlan"] != "1010" || intent.Params["out_vlan"] != "200" {
+		t.Errorf("intent params = %v, want in_vlan=1010 out_vlan=200", intent.Params)
+	}
+}
+
+func TestSetVLANTranslation_Refusals(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		in, out int
+	}{
+		{"customer VLAN out of range", 0, 200},
+		{"provider VLAN out of range", 10, 4095},
+		{"same VLAN", 200, 200},
+		{"provider VLAN missing", 10, 300},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, iface := trunkSetup(t)
+			if _, err := iface.SetVLANTranslation(ctx, tt.in, tt.out); err == nil {
+				t.Errorf("SetVLANTranslation(%d, %d) should fail", tt.in, tt.out)
+			}
+		})
+	}
+}
+
+func TestSetVLANTranslation_RefusesRoutedPort(t *testing.T) {
+	_, iface := routedSetup(t)
+	ctx := context.Background()
+	if _, err := iface.Node().CreateVLAN(ctx, 200, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+	_, err := iface.SetVLANTranslation(ctx, 10, 200)
+	if err == nil || !strings.Contains(err.Error(), "routed") {
+		t.Fatalf("expected routed-port refusal, got %v", err)
+	}
+}
+
+func TestSetVLANTranslation_IdempotentAndConflict(t *testing.T) {
+	_, iface := trunkSetup(t)
+	ctx := context.Background()
+
+	if _, err := iface.SetVLANTranslation(ctx, 10, 200); err != nil {
+		t.Fatalf("first set: %v", err)
+	}
+	cs, err := iface.SetVLANTranslation(ctx, 10, 200)
+	if err != nil {
+		t.Fatalf("repeat set should be idempotent: %v", err)
+	}
+	assertNoChange(t, cs, "VLAN_TRANSLATION", "Ethernet0|10")
+
+	if _, err := iface.SetVLANTranslation(ctx, 10, 100); err == nil {
+		t.Error("re-mapping an already-translated VLAN should be refused")
+	}
+}
+
+func TestClearVLANTranslation_LeavesOthers(t *testing.T) {
+	n, iface := trunkSetup(t)
+	ctx := context.Background()
+
+	if _, err := iface.SetVLANTranslation(ctx, 10, 200); err != nil {
+		t.Fatalf("set 10: %v", err)
+	}
+	if _, err := iface.SetVLANTranslation(ctx, 20, 100); err != nil {
+		t.Fatalf("set 20: %v", err)
+	}
+	cs, err := iface.ClearVLANTranslation(ctx, 10)
+	if err != nil {
+		t.Fatalf("ClearVLANTranslation: %v", err)
+	}
+	assertChange(t, cs, "VLAN_TRANSLATION", "Ethernet0|10", ChangeDelete)
+	assertChange(t, cs, "NEWTRON_INTENT", "interface|Ethernet0|vlan-translation|10", ChangeDelete)
+	if n.GetIntent("interface|Ethernet0|vlan-translation|20") == nil {
+		t.Error("translation for VLAN 20 should survive")
+	}
+
+	if _, err := iface.ClearVLANTranslation(ctx, 10); err == nil {
+		t.Error("clearing a translation that does not exist should fail")
+	}
+}
+
+func TestUnconfigureInterface_ClearsVLANTranslations(t *testing.T) {
+	n, iface := trunkSetup(t)
+	ctx := context.Background()
+
+	if _, err := iface.ConfigureInterface(ctx, InterfaceConfig{VLAN: 200, Tagged: true}); err != nil {
+		t.Fatalf("trunk add 200: %v", err)
+	}
+	if _, err := iface.SetVLANTranslation(ctx, 10, 200); err != nil {
+		t.Fatalf("SetVLANTranslation: %v", err)
+	}
+	cs, err := iface.UnconfigureInterface(ctx)
+	if err != nil {
+		t.Fatalf("UnconfigureInterface: %v", err)
+	}
+	assertChange(t, cs, "VLAN_TRANSLATION", "Ethernet0|10", ChangeDelete)
+	if n.GetIntent("interface|Ethernet0|vlan-translation|10") != nil {
+		t.Error("vlan-translation intent should be cleared by unconfigure-interface")
+	}
+}
+
 // ============================================================================
 // ConfigureInterface within-mode field diff (#228) — no orphan IP subentries
 // when the same VRF is kept but the IP changes or is dropped.
@@ -1073,3 +1435,90 @@ func TestConfigureInterface_RoutedIPAdd_NoSpuriousDelete(t *testing.T) {
 		}
 	}
 }
+
+// TestNormalizeInterfaceName_PerPlatform: each device normalizes by its own
+// platform's naming convention — a mixed fabric of stride-4 SONiC, slotted
+// SONiC (Ethernet1/1/1), and a host platform (eth0).
+func TestNormalizeInterfaceName_PerPlatform(t *testing.T) {
+	platforms := map[string]*spec.PlatformSpec{
+		"stride":  {Ports: []spec.PortSpec{{Name: "Ethernet0"}, {Name: "Ethernet4"}}},
+		"slotted": {Ports: []spec.PortSpec{{Name: "Ethernet1/1/1"}, {Name: "Ethernet1/1/2"}}},
+		"host":    {Ports: []spec.PortSpec{{Name: "eth0"}, {Name: "eth1"}}},
+	}
+	device := func(platform string) *Node {
+		d := testDevice()
+		d.resolved.Platform = platform
+		d.SpecProvider.(*testSpecProvider).platforms = platforms
+		return d
+	}
+
+	tests := []struct {
+		platform, name, want string
+	}{
+		{"stride", "Eth0", "Ethernet0"},
+		{"stride", "ethernet4", "Ethernet4"},
+		{"stride", "Eth0.10", "Ethernet0.10"},
+		{"stride", "Po100", "PortChannel100"},
+		{"slotted", "Eth1/1/2", "Ethernet1/1/2"},
+		{"slotted", "ETHERNET1/1/1", "Ethernet1/1/1"},
+		{"slotted", "Vl100", "Vlan100"},
+		{"host", "eth1", "eth1"},
+		{"host", "ETH0", "eth0"},
+		{"host", "eth1.20", "eth1.20"},
+		{"", "eth0", "Ethernet0"},        // no platform: generic rule
+		{"missing", "eth0", "Ethernet0"}, // unknown platform: generic rule
+	}
+	for _, tt := range tests {
+		if got := device(tt.platform).normalizeInterfaceName(tt.name); got != tt.want {
+			t.Errorf("%s: normalizeInterfaceName(%q) = %q, want %q", tt.platform, tt.name, got, tt.want)
+		}
+	}
+
+	d := device("slotted")
+	d.RegisterPort("Ethernet1/1/1", nil)
+	intf, err := d.GetInterface("eth1/1/1")
+	if err != nil {
+		t.Fatalf("GetInterface(eth1/1/1): %v", err)
+	}
+	if intf.Name() != "Ethernet1/1/1" {
+		t.Errorf("GetInterface(eth1/1/1).Name() = %q, want Ethernet1/1/1", intf.Name())
+	}
+}
+
+// TestResolveInterfaceAlias: a PORT row's alias (a platform breakout alias)
+// resolves to its port, alongside the static prefix rules; a port's own name
+// wins over another port's alias spelled the same.
+func TestResolveInterfaceAlias(t *testing.T) {
+	d := testDevice()
+	d.RegisterPort("Ethernet0", map[string]string{"alias": "etp1a", "lanes": "0"})
+	d.RegisterPort("Ethernet2", map[string]string{"alias": "etp1b", "lanes": "2"})
+	d.RegisterPort("Ethernet4", map[string]string{"alias": "Ethernet0", "lanes": "4"})
+	d.RegisterPort("Ethernet8", nil)
+
+	tests := []struct {
+		name, want string
+		ok         bool
+	}{
+		{"etp1a", "Ethernet0", true},
+		{"ETP1B", "Ethernet2", true},
+		{"etp1a.10", "Ethernet0.10", false}, // resolves; no such subinterface
+		{"Ethernet0", "Ethernet0", true},    // the port, not Ethernet4's alias
+		{"Eth8", "Ethernet8", true},
+		{"etp9", "etp9", false},
+		{"Eth12", "Ethernet12", false},
+	}
+	for _, tt := range tests {
+		got, ok := d.ResolveInterfaceAlias(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ResolveInterfaceAlias(%q) = %q, %v; want %q, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	intf, err := d.GetInterface("etp1b")
+	if err != nil {
+		t.Fatalf("GetInterface(etp1b): %v", err)
+	}
+	if intf.Name() != "Ethernet2" {
+		t.Errorf("GetInterface(etp1b).Name() = %q, want Ethernet2", intf.Name())
+	}
+}
@@ -0,0 +1,130 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// ============================================================================
+// Link Isolation
+// ============================================================================
+//
+// Isolating a flapping link takes it out of forwarding cleanly, in drain
+// order: the direct BGP peer on the port is shut (its routes, and the port's
+// ECMP next-hops, are withdrawn before the link drops), a PortChannel member
+// is detached from its LAG, and the port is set admin down. The isolation is
+// an intent record (interface|<name>|isolation) carrying the state it
+// displaced, so Unisolate restores exactly that — the prior admin status, the
+// LAG membership, the peer — without the operator unwinding it by hand.
+//
+// The record hangs off what it displaced: the port's identity intent (or, for
+// a LAG member, its membership intent) and the BGP peer intent. Replay order
+// therefore re-creates the LAG and the peer before re-isolating, and neither
+// can be removed while the port is isolated (I5) — unisolate first.
+
+// isolationResource is the intent resource of a port's isolation.
+func isolationResource(intfName string) string {
+	return "interface|" + intfName + "|isolation"
+}
+
+// IsIsolated reports whether this interface is isolated.
+func (i *Interface) IsIsolated() bool {
+	return i.node.GetIntent(isolationResource(i.name)) != nil
+}
+
+// Isolate takes this interface out of forwarding: shuts its direct BGP peer,
+// detaches it from its PortChannel, and sets it admin down, recording the
+// prior state for Unisolate. The ChangeSet lists every change made.
+func (i *Interface) Isolate(ctx context.Context) (*ChangeSet, error) {
+	n := i.node
+	if err := n.precondition(sonic.OpIsolateInterface, i.name).Result(); err != nil {
+		return nil, err
+	}
+	if i.IsIsolated() {
+		return nil, fmt.Errorf("%s is already isolated", i.name)
+	}
+
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpIsolateInterface)
+	params := map[string]string{sonic.FieldPriorAdminStatus: i.AdminStatus()}
+	var parents, done []string
+	if pc := i.PortChannelParent(); pc != "" {
+		// A LAG member has no identity intent of its own (AddPortChannelMember
+		// refuses one); its membership is what isolation displaces.
+		parents = append(parents, "portchannel|"+pc+"|"+i.name)
+	} else {
+		if err := i.createInterfaceIntent(cs); err != nil {
+			return nil, err
+		}
+		parents = append(parents, "interface|"+i.name)
+	}
+	if peer := i.DirectBGPPeerIP(); peer != "" {
+		parents = append(parents, "interface|"+i.name+"|bgp-peer")
+		params[sonic.FieldNeighborIP] = peer
+		cs.Updates(bgpNeighborAdminConfig(i.VRF(), peer, "down"))
+		done = append(done, "shut BGP peer "+peer)
+	}
+	if pc := i.PortChannelParent(); pc != "" {
+		params[sonic.FieldPortChannel] = pc
+		cs.Deletes(deletePortChannelMemberConfig(pc, i.name))
+		done = append(done, "detached from "+pc)
+	}
+	cs.Updates(setPropertyConfig(propertyTable(i.name), i.name, map[string]string{"admin_status": "down"}))
+	done = append(done, "admin down")
+
+	if err := n.writeIntent(cs, sonic.OpIsolateInterface, isolationResource(i.name), params, parents); err != nil {
+		return nil, err
+	}
+	cs.ReverseOp = "interface." + sonic.OpUnisolateInterface
+	cs.OperationParams = map[string]string{"interface": i.name}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.Name()).Infof("Isolated %s: %s", i.name, strings.Join(done, ", "))
+	return cs, nil
+}
+
+// Unisolate returns an isolated interface to forwarding, restoring the state
+// its isolation record displaced: admin status, then LAG membership, then the
+// BGP peer — the reverse of Isolate's drain order. Reverse of Isolate (§15).
+func (i *Interface) Unisolate(ctx context.Context) (*ChangeSet, error) {
+	n := i.node
+	if err := n.precondition(sonic.OpUnisolateInterface, i.name).Result(); err != nil {
+		return nil, err
+	}
+	resource := isolationResource(i.name)
+	intent := n.GetIntent(resource)
+	if intent == nil {
+		return nil, fmt.Errorf("%s is not isolated", i.name)
+	}
+
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpUnisolateInterface)
+	var done []string
+	adminStatus := intent.Params[sonic.FieldPriorAdminStatus]
+	if adminStatus == "" {
+		adminStatus = spec.DefaultPortAdminStatus
+	}
+	cs.Updates(setPropertyConfig(propertyTable(i.name), i.name, map[string]string{"admin_status": adminStatus}))
+	done = append(done, "admin "+adminStatus)
+	if pc := intent.Params[sonic.FieldPortChannel]; pc != "" {
+		cs.Adds(createPortChannelMemberConfig(pc, i.name))
+		done = append(done, "rejoined "+pc)
+	}
+	if peer := intent.Params[sonic.FieldNeighborIP]; peer != "" {
+		cs.Updates(bgpNeighborAdminConfig(i.VRF(), peer, "up"))
+		done = append(done, "restored BGP peer "+peer)
+	}
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	cs.OperationParams = map[string]string{"interface": i.name}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.Name()).Infof("Unisolated %s: %s", i.name, strings.Join(done, ", "))
+	return cs, nil
+}
@@ -0,0 +1,121 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// TestIsolate_LAGMember: a member is detached from its LAG and shut, and
+// unisolate puts it back in the LAG at its prior admin status.
+func TestIsolate_LAGMember(t *testing.T) {
+	n := testDevice()
+	ctx := context.Background()
+	n.configDB.Port["Ethernet4"] = sonic.PortEntry{AdminStatus: "up"}
+	if _, err := n.CreatePortChannel(ctx, "PortChannel1", PortChannelConfig{Members: []string{"Ethernet4"}}); err != nil {
+		t.Fatalf("CreatePortChannel: %v", err)
+	}
+	iface := n.interfaces["Ethernet4"]
+
+	cs, err := iface.Isolate(ctx)
+	if err != nil {
+		t.Fatalf("Isolate: %v", err)
+	}
+	assertChange(t, cs, "PORTCHANNEL_MEMBER", "PortChannel1|Ethernet4", ChangeDelete)
+	assertField(t, assertChange(t, cs, "PORT", "Ethernet4", ChangeModify), "admin_status", "down")
+	if cs.ReverseOp != "interface.unisolate-interface" {
+		t.Errorf("ReverseOp = %q, want interface.unisolate-interface", cs.ReverseOp)
+	}
+	intent := n.GetIntent("interface|Ethernet4|isolation")
+	if intent == nil || intent.Params[sonic.FieldPortChannel] != "PortChannel1" || intent.Params[sonic.FieldPriorAdminStatus] != "up" {
+		t.Fatalf("isolation intent = %+v, want portchannel PortChannel1, prior admin up", intent)
+	}
+	if !iface.IsPortChannelMember() {
+		t.Error("isolated member no longer belongs to its LAG — membership intent must survive isolation")
+	}
+	if _, err := n.RemovePortChannelMember(ctx, "PortChannel1", "Ethernet4"); err == nil {
+		t.Error("RemovePortChannelMember of an isolated member should fail")
+	}
+
+	cs, err = iface.Unisolate(ctx)
+	if err != nil {
+		t.Fatalf("Unisolate: %v", err)
+	}
+	assertChange(t, cs, "PORTCHANNEL_MEMBER", "PortChannel1|Ethernet4", ChangeAdd)
+	assertField(t, assertChange(t, cs, "PORT", "Ethernet4", ChangeModify), "admin_status", "up")
+	if iface.IsIsolated() {
+		t.Error("still isolated after Unisolate")
+	}
+}
+
+// TestIsolate_BGPPeer: a routed port's direct peer is shut before the port,
+// and re-enabled on unisolate; the peer cannot be removed in between.
+func TestIsolate_BGPPeer(t *testing.T) {
+	n, iface := testInterface()
+	ctx := context.Background()
+	if _, err := iface.ConfigureInterface(ctx, InterfaceConfig{IP: "10.1.0.0/31"}); err != nil {
+		t.Fatalf("ConfigureInterface: %v", err)
+	}
+	if _, err := iface.AddBGPPeer(ctx, DirectBGPPeerConfig{NeighborIP: "10.1.0.1", RemoteAS: 65099}); err != nil {
+		t.Fatalf("AddBGPPeer: %v", err)
+	}
+
+	cs, err := iface.Isolate(ctx)
+	if err != nil {
+		t.Fatalf("Isolate: %v", err)
+	}
+	peer := -1
+	port := -1
+	for idx, c := range cs.Changes {
+		switch c.Table + "|" + c.Key {
+		case "BGP_NEIGHBOR|default|10.1.0.1":
+			peer = idx
+			assertField(t, &cs.Changes[idx], "admin_status", "down")
+		case "PORT|Ethernet0":
+			port = idx
+		}
+	}
+	if peer < 0 || port < 0 || peer > port {
+		t.Errorf("BGP peer shut at change %d, port down at %d — want the peer drained first", peer, port)
+	}
+	if got := n.GetIntent("interface|Ethernet0|isolation").Params[sonic.FieldNeighborIP]; got != "10.1.0.1" {
+		t.Errorf("isolation intent neighbor_ip = %q, want 10.1.0.1", got)
+	}
+	if _, err := iface.RemoveBGPPeer(ctx); err == nil {
+		t.Error("RemoveBGPPeer on an isolated port should fail")
+	}
+	if _, err := iface.UnconfigureInterface(ctx); err == nil || !strings.Contains(err.Error(), "unisolate") {
+		t.Errorf("UnconfigureInterface error = %v, want unisolate first", err)
+	}
+
+	cs, err = iface.Unisolate(ctx)
+	if err != nil {
+		t.Fatalf("Unisolate: %v", err)
+	}
+	assertField(t, assertChange(t, cs, "BGP_NEIGHBOR", "default|10.1.0.1", ChangeModify), "admin_status", "up")
+	// The port had no admin status of its own: it comes back at the default.
+	assertField(t, assertChange(t, cs, "PORT", "Ethernet0", ChangeModify), "admin_status", "up")
+}
+
+func TestIsolate_Refusals(t *testing.T) {
+	_, iface := testInterface()
+	ctx := context.Background()
+
+	if _, err := iface.Unisolate(ctx); err == nil || !strings.Contains(err.Error(), "not isolated") {
+		t.Errorf("Unisolate of a forwarding port: error = %v, want not isolated", err)
+	}
+	if _, err := iface.Isolate(ctx); err != nil {
+		t.Fatalf("Isolate: %v", err)
+	}
+	if _, err := iface.Isolate(ctx); err == nil || !strings.Contains(err.Error(), "already isolated") {
+		t.Errorf("second Isolate: error = %v, want already isolated", err)
+	}
+	if _, err := iface.SetProperty(ctx, "admin-status", "up"); err == nil || !strings.Contains(err.Error(), "unisolate") {
+		t.Errorf("SetProperty admin-status while isolated: error = %v, want unisolate first", err)
+	}
+	if _, err := iface.SetProperty(ctx, "mtu", "9100"); err != nil {
+		t.Errorf("SetProperty mtu while isolated: %v", err)
+	}
+}
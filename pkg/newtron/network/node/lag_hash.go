@@ -0,0 +1,132 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ============================================================================
+// LAG hash distribution — how a PortChannel spread the traffic it sent across
+// its members over a sample window. Pure observation (§4).
+//
+// A LAG can be up with every member active and still send everything over one
+// link: too few flows, or a hash policy whose fields do not vary across them
+// (polarization). Link state cannot show that; the members' TX counters can.
+// Counters are cumulative and flex counters poll on a timer (PORT_STAT, 1s by
+// default), so the read samples each member's COUNTERS:<oid> twice, a window
+// apart, and reports the deltas.
+// ============================================================================
+
+// LAGHashDistribution is the per-member split of the packets a PortChannel
+// sent during one sample window.
+type LAGHashDistribution struct {
+	Name      string
+	Window    time.Duration
+	TxPackets uint64           // all members, over Window
+	Members   []LAGMemberShare // sorted by name
+	// Skew is how many percentage points the busiest member's share sits
+	// above an even split: 0 for a perfectly even LAG, 50 for a
+	// two-member LAG sending everything over one link. 0 with no traffic.
+	Skew float64
+}
+
+// LAGMemberShare is one member's part of a LAGHashDistribution.
+type LAGMemberShare struct {
+	Name      string
+	TxPackets uint64
+	Share     float64 // percent of the LAG's TxPackets; 0 with no traffic
+}
+
+// GetLAGHashDistribution samples the TX packet counters (unicast +
+// non-unicast) of every member of the PortChannel name, waits window, samples
+// them again, and reports the split. Members come from the PortChannel's
+// intent; a member without a COUNTERS_DB OID is an error, since a
+// distribution that leaves out a link would be misleading.
+func (n *Node) GetLAGHashDistribution(ctx context.Context, name string, window time.Duration) (*LAGHashDistribution, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("sample window must be positive")
+	}
+	pc, err := n.GetPortChannel(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pc.Members) == 0 {
+		return nil, fmt.Errorf("PortChannel %s has no members", pc.Name)
+	}
+	before, err := n.sampleTxPackets(ctx, pc.Members)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(window):
+	}
+	after, err := n.sampleTxPackets(ctx, pc.Members)
+	if err != nil {
+		return nil, err
+	}
+	return buildLAGHashDistribution(pc.Name, window, before, after), nil
+}
+
+// sampleTxPackets reads each port's cumulative TX packet count.
+func (n *Node) sampleTxPackets(ctx context.Context, ports []string) (map[string]uint64, error) {
+	nameMap, err := n.OperDBEntry(ctx, "COUNTERS_DB", "COUNTERS_PORT_NAME_MAP", "")
+	if err != nil {
+		return nil, fmt.Errorf("reading COUNTERS_DB COUNTERS_PORT_NAME_MAP: %w", err)
+	}
+	out := make(map[string]uint64, len(ports))
+	for _, port := range ports {
+		oid := nameMap[port]
+		if oid == "" {
+			return nil, fmt.Errorf("no COUNTERS_DB counters for %s (PORT_STAT counter polling disabled?)", port)
+		}
+		raw, err := n.OperDBEntry(ctx, "COUNTERS_DB", "COUNTERS", oid)
+		if err != nil {
+			return nil, fmt.Errorf("reading COUNTERS_DB COUNTERS:%s (%s): %w", oid, port, err)
+		}
+		out[port] = txPackets(raw)
+	}
+	return out, nil
+}
+
+// txPackets sums a COUNTERS:<oid> hash's unicast and non-unicast TX packets.
+func txPackets(raw map[string]string) uint64 {
+	var total uint64
+	for _, field := range []string{"SAI_PORT_STAT_IF_OUT_UCAST_PKTS", "SAI_PORT_STAT_IF_OUT_NON_UCAST_PKTS"} {
+		if v, err := strconv.ParseUint(raw[field], 10, 64); err == nil {
+			total += v
+		}
+	}
+	return total
+}
+
+// buildLAGHashDistribution turns two counter samples into a distribution. A
+// counter that went backwards was cleared during the window; its delta is
+// the count since the clear.
+func buildLAGHashDistribution(name string, window time.Duration, before, after map[string]uint64) *LAGHashDistribution {
+	d := &LAGHashDistribution{Name: name, Window: window}
+	for member, end := range after {
+		delta := end
+		if start := before[member]; end >= start {
+			delta = end - start
+		}
+		d.Members = append(d.Members, LAGMemberShare{Name: member, TxPackets: delta})
+		d.TxPackets += delta
+	}
+	sort.Slice(d.Members, func(a, b int) bool { return d.Members[a].Name < d.Members[b].Name })
+	if d.TxPackets == 0 {
+		return d
+	}
+	var busiest float64
+	for i := range d.Members {
+		m := &d.Members[i]
+		m.Share = 100 * float64(m.TxPackets) / float64(d.TxPackets)
+		busiest = max(busiest, m.Share)
+	}
+	d.Skew = busiest - 100/float64(len(d.Members))
+	return d
+}
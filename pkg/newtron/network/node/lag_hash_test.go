@@ -0,0 +1,93 @@
+package node
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestBuildLAGHashDistribution(t *testing.T) {
+	tests := []struct {
+		name          string
+		before, after map[string]uint64
+		wantTotal     uint64
+		wantShares    map[string]float64
+		wantSkew      float64
+	}{
+		{
+			name:       "even",
+			before:     map[string]uint64{"Ethernet0": 100, "Ethernet4": 200},
+			after:      map[string]uint64{"Ethernet0": 600, "Ethernet4": 700},
+			wantTotal:  1000,
+			wantShares: map[string]float64{"Ethernet0": 50, "Ethernet4": 50},
+			wantSkew:   0,
+		},
+		{
+			name:       "polarized",
+			before:     map[string]uint64{"Ethernet0": 100, "Ethernet4": 200},
+			after:      map[string]uint64{"Ethernet0": 1100, "Ethernet4": 200},
+			wantTotal:  1000,
+			wantShares: map[string]float64{"Ethernet0": 100, "Ethernet4": 0},
+			wantSkew:   50,
+		},
+		{
+			name:       "four members, one hot",
+			before:     map[string]uint64{"Ethernet0": 0, "Ethernet4": 0, "Ethernet8": 0, "Ethernet12": 0},
+			after:      map[string]uint64{"Ethernet0": 700, "Ethernet4": 100, "Ethernet8": 100, "Ethernet12": 100},
+			wantTotal:  1000,
+			wantShares: map[string]float64{"Ethernet0": 70, "Ethernet4": 10, "Ethernet8": 10, "Ethernet12": 10},
+			wantSkew:   45,
+		},
+		{
+			name:       "counter cleared mid-window",
+			before:     map[string]uint64{"Ethernet0": 5000, "Ethernet4": 100},
+			after:      map[string]uint64{"Ethernet0": 300, "Ethernet4": 400},
+			wantTotal:  600,
+			wantShares: map[string]float64{"Ethernet0": 50, "Ethernet4": 50},
+			wantSkew:   0,
+		},
+		{
+			name:       "no traffic",
+			before:     map[string]uint64{"Ethernet0": 10, "Ethernet4": 10},
+			after:      map[string]uint64{"Ethernet0": 10, "Ethernet4": 10},
+			wantShares: map[string]float64{"Ethernet0": 0, "Ethernet4": 0},
+		},
+	}
+	for _, tt := range tests {
+		d := buildLAGHashDistribution("PortChannel100", 10*time.Second, tt.before, tt.after)
+		if d.Name != "PortChannel100" || d.Window != 10*time.Second {
+			t.Errorf("%s: identity = %s/%s", tt.name, d.Name, d.Window)
+		}
+		if d.TxPackets != tt.wantTotal {
+			t.Errorf("%s: TxPackets = %d, want %d", tt.name, d.TxPackets, tt.wantTotal)
+		}
+		if math.Abs(d.Skew-tt.wantSkew) > 1e-9 {
+			t.Errorf("%s: Skew = %v, want %v", tt.name, d.Skew, tt.wantSkew)
+		}
+		if len(d.Members) != len(tt.wantShares) {
+			t.Fatalf("%s: members = %+v", tt.name, d.Members)
+		}
+		for i, m := range d.Members {
+			if i > 0 && d.Members[i-1].Name >= m.Name {
+				t.Errorf("%s: members not sorted: %+v", tt.name, d.Members)
+			}
+			if math.Abs(m.Share-tt.wantShares[m.Name]) > 1e-9 {
+				t.Errorf("%s: %s share = %v, want %v", tt.name, m.Name, m.Share, tt.wantShares[m.Name])
+			}
+		}
+	}
+}
+
+func TestTxPackets(t *testing.T) {
+	raw := map[string]string{
+		"SAI_PORT_STAT_IF_OUT_UCAST_PKTS":     "1200",
+		"SAI_PORT_STAT_IF_OUT_NON_UCAST_PKTS": "34",
+		"SAI_PORT_STAT_IF_IN_UCAST_PKTS":      "999",
+	}
+	if got := txPackets(raw); got != 1234 {
+		t.Errorf("txPackets = %d, want 1234", got)
+	}
+	if got := txPackets(map[string]string{"SAI_PORT_STAT_IF_OUT_UCAST_PKTS": "N/A"}); got != 0 {
+		t.Errorf("txPackets(N/A) = %d, want 0", got)
+	}
+}
@@ -0,0 +1,34 @@
+package node
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// createMirrorSessionConfig returns the MIRROR_SESSION entry of an ERSPAN
+// session. cfg carries resolved defaults (source IP, TTL, GRE type) and an
+// upper-case direction. vrf is written only for a non-default VRF, so a
+// default-VRF session is the row any image accepts.
+func createMirrorSessionConfig(name string, cfg MirrorConfig) []sonic.Entry {
+	fields := map[string]string{
+		"type":      "ERSPAN",
+		"src_ip":    cfg.SrcIP,
+		"dst_ip":    cfg.DstIP,
+		"gre_type":  cfg.GREType,
+		"dscp":      strconv.Itoa(cfg.DSCP),
+		"ttl":       strconv.Itoa(cfg.TTL),
+		"src_port":  strings.Join(cfg.Ports, ","),
+		"direction": cfg.Direction,
+	}
+	if cfg.VRF != "" && cfg.VRF != "default" {
+		fields["vrf"] = cfg.VRF
+	}
+	return []sonic.Entry{{Table: "MIRROR_SESSION", Key: name, Fields: fields}}
+}
+
+// deleteMirrorSessionConfig returns the MIRROR_SESSION delete for a session.
+func deleteMirrorSessionConfig(name string) []sonic.Entry {
+	return []sonic.Entry{{Table: "MIRROR_SESSION", Key: name}}
+}
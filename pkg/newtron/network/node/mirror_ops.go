@@ -0,0 +1,275 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// ============================================================================
+// Mirror sessions — ERSPAN: copies of a set of ports' traffic, GRE-encapsulated
+// to a remote collector across the routed (or overlay) network. This file owns
+// the MIRROR_SESSION vocabulary (§28).
+//
+// Real deployments need more than src/dst: the collector usually sits in a
+// tenant or management VRF, the copies must be DSCP-marked to survive QoS on
+// the way, the TTL bounds how far they travel, and the collector decodes by
+// GRE protocol type (0x88be ERSPAN II, 0x22eb ERSPAN III). All four are part
+// of the session.
+// ============================================================================
+
+const (
+	// defaultMirrorTTL is the GRE packets' TTL when none is given.
+	defaultMirrorTTL = 255
+	// defaultMirrorGREType is ERSPAN type II, the type collectors decode by
+	// default.
+	defaultMirrorGREType = "0x88be"
+)
+
+var greTypePattern = regexp.MustCompile(`^0[xX][0-9a-fA-F]{1,4}$`)
+
+// MirrorConfig holds an ERSPAN mirror session's settings.
+type MirrorConfig struct {
+	DstIP     string   // collector the GRE-encapsulated copies are sent to
+	SrcIP     string   // GRE source address; empty means the device loopback
+	Ports     []string // ports whose traffic is mirrored
+	Direction string   // rx, tx, or both; empty means both
+	VRF       string   // VRF DstIP is reached in; empty means the default VRF
+	DSCP      int      // DSCP of the GRE packets, 0-63
+	TTL       int      // TTL of the GRE packets; 0 means defaultMirrorTTL
+	GREType   string   // GRE protocol type; empty means defaultMirrorGREType
+}
+
+// mirrorSessionResource returns the intent key of a mirror session.
+func mirrorSessionResource(name string) string {
+	return "mirror-session|" + name
+}
+
+// CreateMirrorSession mirrors cfg.Ports to a remote collector over ERSPAN.
+// The destination must be reachable in cfg.VRF as the projection stands: a
+// connected subnet or static route in the VRF covering it, or BGP running in
+// the VRF to learn one. A non-default VRF must exist.
+//
+// Intent-idempotent: the same settings again return an empty ChangeSet;
+// different settings replace the session in place, except a VRF change —
+// delete and recreate the session to move it.
+func (n *Node) CreateMirrorSession(ctx context.Context, name string, cfg MirrorConfig) (*ChangeSet, error) {
+	resource := mirrorSessionResource(name)
+	ports := make([]string, 0, len(cfg.Ports))
+	for _, p := range cfg.Ports {
+		ports = append(ports, n.normalizeInterfaceName(p))
+	}
+	cfg.Ports = ports
+	cfg.Direction = strings.ToLower(cfg.Direction)
+	params := mirrorSessionParams(cfg)
+	if existing := n.GetIntent(resource); existing != nil && maps.Equal(existing.Params, params) {
+		return NewChangeSet(n.name, "device."+sonic.OpCreateMirrorSession), nil
+	}
+
+	if cfg.SrcIP == "" && n.resolved != nil {
+		cfg.SrcIP = n.resolved.LoopbackIP
+	}
+	defaultVRF := cfg.VRF == "" || cfg.VRF == "default"
+	pc := n.precondition(sonic.OpCreateMirrorSession, resource).
+		Check(name != "", "session name", "a mirror session name is required").
+		Check(len(cfg.Ports) > 0, "ports to mirror", "at least one source port is required").
+		Check(cfg.SrcIP != "", "GRE source address",
+			"no source IP given and the device has no loopback address")
+	if problems := mirrorSessionProblems(cfg); len(problems) > 0 {
+		pc.Check(false, "valid mirror session settings", strings.Join(problems, "; "))
+	}
+	for _, port := range cfg.Ports {
+		pc.RequireInterfaceExists(port)
+	}
+	if !defaultVRF {
+		pc.RequireVRFExists(cfg.VRF)
+	}
+	// Reachability is checked when authored; replay may run before the
+	// interface and route intents it was authored against.
+	if !n.reconstructing && net.ParseIP(cfg.DstIP).To4() != nil {
+		vrf := cfg.VRF
+		if defaultVRF {
+			vrf = "default"
+		}
+		pc.Check(n.mirrorDestinationRoute(vrf, cfg.DstIP) != "", "destination reachable in VRF",
+			fmt.Sprintf("no route to %s in VRF %s — no connected subnet or static route covers it and the VRF runs no BGP", cfg.DstIP, vrf))
+	}
+	if err := pc.Result(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Direction == "" {
+		cfg.Direction = "both"
+	}
+	cfg.Direction = strings.ToUpper(cfg.Direction)
+	if cfg.TTL == 0 {
+		cfg.TTL = defaultMirrorTTL
+	}
+	if cfg.GREType == "" {
+		cfg.GREType = defaultMirrorGREType
+	}
+
+	parents := []string{"device"}
+	if !defaultVRF {
+		parents = []string{"vrf|" + cfg.VRF}
+	}
+	params[sonic.FieldName] = name
+	cs := NewChangeSet(n.name, "device."+sonic.OpCreateMirrorSession)
+	cs.ReverseOp = "device." + sonic.OpDeleteMirrorSession
+	cs.OperationParams = params
+	if err := n.writeIntent(cs, sonic.OpCreateMirrorSession, resource, params, parents); err != nil {
+		return nil, err
+	}
+	cs.Replace(n, n.mirrorSessionRows(name), createMirrorSessionConfig(name, cfg))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Created mirror session %s: %s → %s (VRF %s)",
+		name, strings.Join(cfg.Ports, ","), cfg.DstIP, cfg.VRF)
+	return cs, nil
+}
+
+// DeleteMirrorSession removes a mirror session; the ports stop being
+// mirrored. Reverse of CreateMirrorSession (§15).
+func (n *Node) DeleteMirrorSession(ctx context.Context, name string) (*ChangeSet, error) {
+	resource := mirrorSessionResource(name)
+	if err := n.precondition(sonic.OpDeleteMirrorSession, resource).Result(); err != nil {
+		return nil, err
+	}
+	if n.GetIntent(resource) == nil {
+		return nil, fmt.Errorf("no mirror session %q", name)
+	}
+
+	cs := NewChangeSet(n.name, "device."+sonic.OpDeleteMirrorSession)
+	cs.OperationParams = map[string]string{sonic.FieldName: name}
+	cs.Deletes(deleteMirrorSessionConfig(name))
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Deleted mirror session %s", name)
+	return cs, nil
+}
+
+// mirrorSessionParams returns the intent params of a mirror session, less
+// its name (an identity field Intent.Params omits): the settings as given —
+// defaults are not recorded, so a session without a source IP follows the
+// loopback — with ports as CSV.
+func mirrorSessionParams(cfg MirrorConfig) map[string]string {
+	params := map[string]string{
+		sonic.FieldDstIP: cfg.DstIP,
+		sonic.FieldPorts: strings.Join(cfg.Ports, ","),
+	}
+	for field, value := range map[string]string{
+		sonic.FieldSrcIP:     cfg.SrcIP,
+		sonic.FieldDirection: cfg.Direction,
+		sonic.FieldVRF:       cfg.VRF,
+		sonic.FieldGREType:   cfg.GREType,
+	} {
+		if value != "" {
+			params[field] = value
+		}
+	}
+	if cfg.DSCP != 0 {
+		params[sonic.FieldDSCP] = strconv.Itoa(cfg.DSCP)
+	}
+	if cfg.TTL != 0 {
+		params[sonic.FieldTTL] = strconv.Itoa(cfg.TTL)
+	}
+	return params
+}
+
+// mirrorSessionProblems returns every malformed setting in cfg, or nil.
+func mirrorSessionProblems(cfg MirrorConfig) []string {
+	var problems []string
+	if net.ParseIP(cfg.DstIP).To4() == nil {
+		problems = append(problems, fmt.Sprintf("destination %q is not an IPv4 address", cfg.DstIP))
+	}
+	if cfg.SrcIP != "" && net.ParseIP(cfg.SrcIP).To4() == nil {
+		problems = append(problems, fmt.Sprintf("source %q is not an IPv4 address", cfg.SrcIP))
+	}
+	switch cfg.Direction {
+	case "", "rx", "tx", "both":
+	default:
+		problems = append(problems, fmt.Sprintf("direction %q is not rx, tx, or both", cfg.Direction))
+	}
+	if cfg.DSCP < 0 || cfg.DSCP > 63 {
+		problems = append(problems, fmt.Sprintf("dscp %d is not 0-63", cfg.DSCP))
+	}
+	if cfg.TTL < 0 || cfg.TTL > 255 {
+		problems = append(problems, fmt.Sprintf("ttl %d is not 1-255", cfg.TTL))
+	}
+	if cfg.GREType != "" && !greTypePattern.MatchString(cfg.GREType) {
+		problems = append(problems, fmt.Sprintf("gre_type %q is not a hex protocol type like 0x88be", cfg.GREType))
+	}
+	return problems
+}
+
+// mirrorDestinationRoute returns how the projection reaches dst in vrf: the
+// longest connected subnet or static route prefix covering it, else "bgp"
+// when the VRF runs BGP and may learn a route. "" means unreachable.
+func (n *Node) mirrorDestinationRoute(vrf, dst string) string {
+	ip := net.ParseIP(dst)
+	proj := n.Projection()
+	best, bestLen := "", -1
+	consider := func(prefix string) {
+		_, subnet, err := net.ParseCIDR(prefix)
+		if err != nil || !subnet.Contains(ip) {
+			return
+		}
+		if ones, _ := subnet.Mask.Size(); ones > bestLen {
+			best, bestLen = subnet.String(), ones
+		}
+	}
+	inVRF := func(name string) bool {
+		if name == "" {
+			name = "default"
+		}
+		return name == vrf
+	}
+
+	for _, table := range []string{"INTERFACE", "VLAN_INTERFACE", "PORTCHANNEL_INTERFACE"} {
+		for key := range proj[table] {
+			base, prefix, ok := strings.Cut(key, "|")
+			if ok && inVRF(proj[table][base]["vrf_name"]) {
+				consider(prefix)
+			}
+		}
+	}
+	for key := range proj["STATIC_ROUTE"] {
+		routeVRF, prefix, ok := strings.Cut(key, "|")
+		if !ok {
+			routeVRF, prefix = "", key
+		}
+		// A blackhole route discards the copies rather than reaching the
+		// collector.
+		if inVRF(routeVRF) && proj["STATIC_ROUTE"][key]["blackhole"] != "true" {
+			consider(prefix)
+		}
+	}
+	if best != "" {
+		return best
+	}
+	if _, ok := proj["BGP_GLOBALS"][vrf]; ok {
+		return "bgp"
+	}
+	return ""
+}
+
+// mirrorSessionRows returns the session's MIRROR_SESSION row as it stands in
+// the projection, or nil.
+func (n *Node) mirrorSessionRows(name string) []sonic.Entry {
+	if fields, ok := n.Projection()["MIRROR_SESSION"][name]; ok {
+		return []sonic.Entry{{Table: "MIRROR_SESSION", Key: name, Fields: fields}}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package node
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// mirrorDevice returns testDevice with Ethernet0 routed on 10.1.0.0/31 in
+// the default VRF and VRF Vrf_MGMT reaching 172.16.0.0/24 by static route,
+// built through the ops so the projection CreateMirrorSession reads is
+// the one they write.
+func mirrorDevice(t *testing.T) *Node {
+	t.Helper()
+	ctx := context.Background()
+	n := testDevice()
+	i, err := n.GetInterface("Ethernet0")
+	if err != nil {
+		t.Fatalf("GetInterface: %v", err)
+	}
+	if _, err := i.ConfigureInterface(ctx, InterfaceConfig{IP: "10.1.0.0/31"}); err != nil {
+		t.Fatalf("ConfigureInterface: %v", err)
+	}
+	if _, err := n.CreateVRF(ctx, "Vrf_MGMT", VRFConfig{}); err != nil {
+		t.Fatalf("CreateVRF: %v", err)
+	}
+	if _, err := n.AddStaticRoute(ctx, "Vrf_MGMT", "172.16.0.0/24", "10.1.0.1", 0); err != nil {
+		t.Fatalf("AddStaticRoute: %v", err)
+	}
+	return n
+}
+
+func TestMirrorSession_CreateDelete(t *testing.T) {
+	ctx := context.Background()
+	n := mirrorDevice(t)
+
+	cfg := MirrorConfig{
+		DstIP: "172.16.0.10",
+		Ports: []string{"Ethernet4"},
+		VRF:   "Vrf_MGMT",
+		DSCP:  8,
+	}
+	cs, err := n.CreateMirrorSession(ctx, "COLLECTOR", cfg)
+	if err != nil {
+		t.Fatalf("CreateMirrorSession: %v", err)
+	}
+	session := assertChange(t, cs, "MIRROR_SESSION", "COLLECTOR", ChangeReplace)
+	assertField(t, session, "type", "ERSPAN")
+	assertField(t, session, "src_ip", "10.255.0.1")
+	assertField(t, session, "dst_ip", "172.16.0.10")
+	assertField(t, session, "vrf", "Vrf_MGMT")
+	assertField(t, session, "dscp", "8")
+	assertField(t, session, "ttl", "255")
+	assertField(t, session, "gre_type", "0x88be")
+	assertField(t, session, "src_port", "Ethernet4")
+	assertField(t, session, "direction", "BOTH")
+	assertChange(t, cs, "NEWTRON_INTENT", "mirror-session|COLLECTOR", ChangeAdd)
+
+	// The same settings again are intent-idempotent.
+	cs, err = n.CreateMirrorSession(ctx, "COLLECTOR", cfg)
+	if err != nil || !cs.IsEmpty() {
+		t.Fatalf("repeated CreateMirrorSession: cs=%v err=%v, want empty ChangeSet", cs, err)
+	}
+
+	// New settings replace the session in place.
+	cfg.Direction, cfg.TTL, cfg.GREType = "rx", 32, "0x22eb"
+	cs, err = n.CreateMirrorSession(ctx, "COLLECTOR", cfg)
+	if err != nil {
+		t.Fatalf("second CreateMirrorSession: %v", err)
+	}
+	session = assertChange(t, cs, "MIRROR_SESSION", "COLLECTOR", ChangeReplace)
+	assertField(t, session, "direction", "RX")
+	assertField(t, session, "ttl", "32")
+	assertField(t, session, "gre_type", "0x22eb")
+
+	cs, err = n.DeleteMirrorSession(ctx, "COLLECTOR")
+	if err != nil {
+		t.Fatalf("DeleteMirrorSession: %v", err)
+	}
+	assertChange(t, cs, "MIRROR_SESSION", "COLLECTOR", ChangeDelete)
+	assertChange(t, cs, "NEWTRON_INTENT", "mirror-session|COLLECTOR", ChangeDelete)
+
+	if _, err := n.DeleteMirrorSession(ctx, "COLLECTOR"); err == nil {
+		t.Error("DeleteMirrorSession with no session should fail")
+	}
+}
+
+// TestMirrorSession_DefaultVRF: a default-VRF session reached over a
+// connected subnet writes no vrf field.
+func TestMirrorSession_DefaultVRF(t *testing.T) {
+	cs, err := mirrorDevice(t).CreateMirrorSession(context.Background(), "UNDERLAY", MirrorConfig{
+		DstIP: "10.1.0.1",
+		Ports: []string{"Ethernet4"},
+	})
+	if err != nil {
+		t.Fatalf("CreateMirrorSession: %v", err)
+	}
+	session := assertChange(t, cs, "MIRROR_SESSION", "UNDERLAY", ChangeReplace)
+	if _, ok := session.Fields["vrf"]; ok {
+		t.Errorf("default-VRF session wrote vrf = %q", session.Fields["vrf"])
+	}
+}
+
+func TestMirrorSession_Preconditions(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name    string
+		cfg     MirrorConfig
+		wantErr string
+	}{
+		{name: "no ports", cfg: MirrorConfig{DstIP: "10.1.0.1"}, wantErr: "at least one source port"},
+		{name: "unknown port", cfg: MirrorConfig{DstIP: "10.1.0.1", Ports: []string{"Ethernet96"}}, wantErr: "Ethernet96"},
+		{name: "bad destination", cfg: MirrorConfig{DstIP: "collector", Ports: []string{"Ethernet4"}}, wantErr: "not an IPv4 address"},
+		{name: "bad direction", cfg: MirrorConfig{DstIP: "10.1.0.1", Ports: []string{"Ethernet4"}, Direction: "ingress"}, wantErr: "not rx, tx, or both"},
+		{name: "dscp range", cfg: MirrorConfig{DstIP: "10.1.0.1", Ports: []string{"Ethernet4"}, DSCP: 64}, wantErr: "dscp 64 is not 0-63"},
+		{name: "ttl range", cfg: MirrorConfig{DstIP: "10.1.0.1", Ports: []string{"Ethernet4"}, TTL: 256}, wantErr: "ttl 256 is not 1-255"},
+		{name: "bad gre type", cfg: MirrorConfig{DstIP: "10.1.0.1", Ports: []string{"Ethernet4"}, GREType: "88be"}, wantErr: "not a hex protocol type"},
+		{name: "unknown VRF", cfg: MirrorConfig{DstIP: "172.16.0.10", Ports: []string{"Ethernet4"}, VRF: "Vrf_NONE"}, wantErr: "Vrf_NONE"},
+		{name: "unreachable in default VRF", cfg: MirrorConfig{DstIP: "172.16.0.10", Ports: []string{"Ethernet4"}}, wantErr: "no route to 172.16.0.10 in VRF default"},
+		{name: "unreachable in VRF", cfg: MirrorConfig{DstIP: "10.1.0.1", Ports: []string{"Ethernet4"}, VRF: "Vrf_MGMT"}, wantErr: "no route to 10.1.0.1 in VRF Vrf_MGMT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := mirrorDevice(t).CreateMirrorSession(ctx, "COLLECTOR", tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("CreateMirrorSession error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
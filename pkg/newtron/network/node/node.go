@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"os"
 	"os/user"
@@ -24,6 +25,7 @@ const defaultLockTTL = 3600 // 1 hour
 // node.GetService("x") directly.
 type SpecProvider interface {
 	GetService(name string) (*spec.ServiceSpec, error)
+	GetServiceDefaults(name string) *spec.ServiceDefaults
 	GetIPVPN(name string) (*spec.IPVPNSpec, error)
 	GetMACVPN(name string) (*spec.MACVPNSpec, error)
 	GetQoSPolicy(name string) (*spec.QoSPolicy, error)
@@ -32,6 +34,7 @@ type SpecProvider interface {
 	GetPrefixList(name string) ([]string, error)
 	GetRoutePolicy(name string) (*spec.RoutePolicy, error)
 	FindMACVPNByVNI(vni int) (string, *spec.MACVPNSpec)
+	ListServices() []string
 }
 
 // Node represents a SONiC switch within the context of a Network.
@@ -392,8 +395,8 @@ func (n *Node) ProjectionDiff(ctx context.Context, ops []spec.TopologyStep) (*Pr
 // apply-service intent for the named service. Cheap pre-check before doing
 // the full snapshot/replay/diff cycle in ServiceProjection.
 func (n *Node) BindsService(serviceName string) bool {
-	for _, intent := range n.ServiceIntents() {
-		if intent.Params["service_name"] == serviceName {
+	for _, b := range n.GetServiceBindings() {
+		if b.Service == serviceName {
 			return true
 		}
 	}
@@ -616,6 +619,57 @@ func (n *Node) ConfigDBSnapshot(ctx context.Context, ownedOnly bool) (sonic.RawC
 	return n.conn.Client().GetRawAllTables(ctx)
 }
 
+// ExportConfigDB writes the device's entire CONFIG_DB to w in the canonical
+// config_db.json shape (see sonic.EncodeConfigDBJSON). Auto-connects
+// transport if needed.
+func (n *Node) ExportConfigDB(ctx context.Context, w io.Writer) error {
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return fmt.Errorf("connecting transport for configdb export: %w", err)
+		}
+	}
+	return n.conn.ExportConfigDB(ctx, w)
+}
+
+// RenderConfigDB writes the CONFIG_DB this node's intents render to — the
+// projection, NEWTRON_INTENT included — to w as config_db.json, without a
+// device. The projection is delivered as a ChangeSet to an in-memory
+// sonic.FileConfigDB through ApplyTo, the path Apply takes to Redis, so the
+// document holds what applying it to an empty CONFIG_DB would leave.
+func (n *Node) RenderConfigDB(w io.Writer) error {
+	file := sonic.NewFileConfigDB("")
+	if n.configDB != nil {
+		cs := NewChangeSet(n.name, "render-configdb")
+		cs.Adds(n.configDB.ExportEntries())
+		if err := cs.ApplyTo(file, ApplyOpts{}); err != nil {
+			return fmt.Errorf("rendering configdb: %w", err)
+		}
+	}
+	return sonic.EncodeConfigDBJSON(w, file.Raw())
+}
+
+// ImportConfigDB applies a config_db.json document to the device's CONFIG_DB,
+// table by table (see sonic.Device.ImportConfigDB). Re-importing a fresh
+// export writes nothing.
+//
+// Acquires the device lock directly when not already held — bypassing the
+// Node-level Lock() drift guard, as Reconcile does: restoring a saved
+// CONFIG_DB over a drifted device is the point. The import writes to
+// CONFIG_DB below the intent layer; the projection is rebuilt from the
+// imported NEWTRON_INTENT records on the next operation.
+func (n *Node) ImportConfigDB(ctx context.Context, r io.Reader) error {
+	if !n.connected {
+		return util.ErrNotConnected
+	}
+	if !n.locked {
+		if err := n.conn.Lock(BuildLockHolder(), defaultLockTTL); err != nil {
+			return fmt.Errorf("acquiring lock for configdb import: %w", err)
+		}
+		defer n.conn.Unlock()
+	}
+	return n.conn.ImportConfigDB(ctx, r)
+}
+
 // OperDBSnapshot reads an entire operational DB (STATE_DB, APPL_DB,
 // COUNTERS_DB, ASIC_DB) as table → key → fields. Pure observation of the
 // device's runtime state — no projection fallback exists or could: an
@@ -662,12 +716,19 @@ func (n *Node) Drift(ctx context.Context) ([]sonic.DriftEntry, error) {
 		}
 	}
 
-	expected := n.configDB.ExportRaw()
 	actual, err := n.conn.Client().GetRawOwnedTables(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("reading actual CONFIG_DB: %w", err)
 	}
-	return sonic.DiffConfigDB(expected, actual, sonic.OwnedTables()), nil
+	return n.driftFrom(actual), nil
+}
+
+// driftFrom diffs the projection against an actual CONFIG_DB read. Only
+// newtron-owned tables are compared (sonic.OwnedTables), so device-native
+// entries — factory PORT rows, DEVICE_METADATA, default banners — never
+// surface as extra.
+func (n *Node) driftFrom(actual sonic.RawConfigDB) []sonic.DriftEntry {
+	return sonic.DiffConfigDB(n.configDB.ExportRaw(), actual, sonic.OwnedTables())
 }
 
 // ReconcileOpts controls the Reconcile delivery mechanism.
@@ -1302,7 +1363,7 @@ func (n *Node) IsLocked() bool {
 // Accepts both short (Eth0, Po100) and full (Ethernet0, PortChannel100) interface names.
 func (n *Node) GetInterface(name string) (*Interface, error) {
 	// Normalize interface name (e.g., Eth0 -> Ethernet0, Po100 -> PortChannel100)
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 
 	// Return existing interface if already loaded
 	if intf, ok := n.interfaces[name]; ok {
@@ -1439,6 +1500,45 @@ func (n *Node) EnsureUnifiedConfigMode(ctx context.Context) error {
 	}
 }
 
+// CanSafelyReload reports whether a config reload would lose nothing, and
+// why not when it would. config reload replaces CONFIG_DB with the saved
+// config_db.json, so it is unsafe while:
+//   - intents were mutated since the last Save (HasUnsavedIntents);
+//   - another session holds the device lock — a write in flight would be
+//     reloaded out from under it;
+//   - CONFIG_DB holds newtron-owned rows or intent records that differ from
+//     config_db.json (written with no_save, or by hand).
+//
+// A check that cannot be made (no SSH to read the saved file) blocks: the
+// guard fails closed. Callers that mean to discard state pass force instead.
+func (n *Node) CanSafelyReload(ctx context.Context) (bool, string) {
+	if n.unsavedIntents {
+		return false, "intents changed since the last save"
+	}
+	if !n.connected {
+		return false, "device not connected"
+	}
+	if !n.locked {
+		holder, err := n.conn.LockHolder()
+		if err != nil {
+			return false, fmt.Sprintf("cannot read device lock: %v", err)
+		}
+		if holder != "" {
+			return false, fmt.Sprintf("device locked by %s (operation in progress)", holder)
+		}
+	}
+	unsaved, err := n.conn.UnsavedChanges(ctx)
+	if err != nil {
+		return false, fmt.Sprintf("cannot compare CONFIG_DB with saved config: %v", err)
+	}
+	if len(unsaved) > 0 {
+		e := unsaved[0]
+		return false, fmt.Sprintf("%d CONFIG_DB entries not saved to config_db.json (first: %s %s|%s)",
+			len(unsaved), e.Type, e.Table, e.Key)
+	}
+	return true, ""
+}
+
 // ConfigReload runs 'config reload -y' which stops all SONiC services,
 // flushes CONFIG_DB, re-reads config_db.json, and restarts all services.
 // This ensures all daemons process the config from a clean startup state,
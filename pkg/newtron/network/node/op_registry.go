@@ -3,6 +3,8 @@ package node
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 	"github.com/aldrin-isaac/newtron/pkg/util"
@@ -254,6 +256,135 @@ func buildOpRegistry() map[string]*OpSpec {
 			},
 		},
 
+		sonic.OpSetLAGHashPolicy: {
+			Op: sonic.OpSetLAGHashPolicy, Scope: ScopeNode, Inverse: "device." + sonic.OpClearLAGHashPolicy,
+			Params: []ParamSpec{required(sonic.FieldHashFields)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				fields := paramList(p, sonic.FieldHashFields)
+				if len(fields) == 0 {
+					return fmt.Errorf("set-lag-hash-policy: missing 'hash_fields' param")
+				}
+				_, err := n.SetLAGHashPolicy(ctx, fields)
+				return err
+			},
+		},
+
+		sonic.OpSetBGPMaxPaths: {
+			Op: sonic.OpSetBGPMaxPaths, Scope: ScopeNode, Inverse: "device." + sonic.OpClearBGPMaxPaths,
+			Params: []ParamSpec{required(sonic.FieldMaxPaths)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				_, err := n.SetBGPMaxPaths(ctx, paramInt(p, sonic.FieldMaxPaths))
+				return err
+			},
+		},
+
+		sonic.OpSetCounterPolling: {
+			Op: sonic.OpSetCounterPolling, Scope: ScopeNode, Inverse: "device." + sonic.OpClearCounterPolling,
+			Params: []ParamSpec{required(sonic.FieldCounterType), required(sonic.FieldEnable), caller(sonic.FieldPollInterval)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				interval := time.Duration(paramInt(p, sonic.FieldPollInterval)) * time.Millisecond
+				_, err := n.SetCounterPolling(ctx, paramString(p, sonic.FieldCounterType), paramBool(p, sonic.FieldEnable), interval)
+				return err
+			},
+		},
+
+		sonic.OpAddRouteLeak: {
+			Op: sonic.OpAddRouteLeak, Scope: ScopeNode, Inverse: "device." + sonic.OpRemoveRouteLeak,
+			Params: []ParamSpec{required(sonic.FieldVRFName), required(sonic.FieldSrcVRF), caller(sonic.FieldPrefixes)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				_, err := n.AddRouteLeak(ctx, paramString(p, sonic.FieldSrcVRF), paramString(p, sonic.FieldVRFName), paramList(p, sonic.FieldPrefixes))
+				return err
+			},
+		},
+
+		sonic.OpSetVRFRouteTargets: {
+			Op: sonic.OpSetVRFRouteTargets, Scope: ScopeNode, Inverse: "device." + sonic.OpClearVRFRouteTargets,
+			Params: []ParamSpec{required(sonic.FieldVRFName), caller(sonic.FieldImportRTs), caller(sonic.FieldExportRTs)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				// The intent stores each list as CSV; an authored topology
+				// step may give them as lists.
+				rts := func(key string) []string {
+					if l := paramStringSlice(p, key); l != nil {
+						return l
+					}
+					return parseRouteTargets(paramString(p, key))
+				}
+				_, err := n.SetVRFRouteTargets(ctx, paramString(p, sonic.FieldVRFName), rts(sonic.FieldImportRTs), rts(sonic.FieldExportRTs))
+				return err
+			},
+		},
+
+		sonic.OpConfigureDHCPServer: {
+			Op: sonic.OpConfigureDHCPServer, Scope: ScopeNode, Inverse: "device." + sonic.OpUnconfigureDHCPServer,
+			Params: []ParamSpec{required(sonic.FieldVLANID), required(sonic.FieldRanges), required(sonic.FieldPorts),
+				caller(sonic.FieldGateway), caller(sonic.FieldLeaseTime), caller(sonic.FieldOptions)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				// The intent stores options as "code=value" pairs; an authored
+				// topology step may give a map.
+				options := parseDHCPOptions(paramString(p, sonic.FieldOptions))
+				if m := paramStringMap(p, sonic.FieldOptions); m != nil {
+					options = map[int]string{}
+					for code, value := range m {
+						c, err := strconv.Atoi(code)
+						if err != nil {
+							return fmt.Errorf("configure-dhcp-server: option code %q is not a number", code)
+						}
+						options[c] = value
+					}
+				}
+				_, err := n.ConfigureDHCPServer(ctx, paramInt(p, sonic.FieldVLANID), DHCPServerConfig{
+					Ranges:    paramList(p, sonic.FieldRanges),
+					Ports:     paramList(p, sonic.FieldPorts),
+					Gateway:   paramString(p, sonic.FieldGateway),
+					LeaseTime: paramInt(p, sonic.FieldLeaseTime),
+					Options:   options,
+				})
+				return err
+			},
+		},
+
+		sonic.OpCreateMirrorSession: {
+			Op: sonic.OpCreateMirrorSession, Scope: ScopeNode, Inverse: "device." + sonic.OpDeleteMirrorSession,
+			Params: []ParamSpec{required(sonic.FieldName), required(sonic.FieldDstIP), required(sonic.FieldPorts),
+				caller(sonic.FieldSrcIP), caller(sonic.FieldDirection), caller(sonic.FieldVRF),
+				caller(sonic.FieldDSCP), caller(sonic.FieldTTL), caller(sonic.FieldGREType)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				_, err := n.CreateMirrorSession(ctx, paramString(p, sonic.FieldName), MirrorConfig{
+					DstIP:     paramString(p, sonic.FieldDstIP),
+					SrcIP:     paramString(p, sonic.FieldSrcIP),
+					Ports:     paramList(p, sonic.FieldPorts),
+					Direction: paramString(p, sonic.FieldDirection),
+					VRF:       paramString(p, sonic.FieldVRF),
+					DSCP:      paramInt(p, sonic.FieldDSCP),
+					TTL:       paramInt(p, sonic.FieldTTL),
+					GREType:   paramString(p, sonic.FieldGREType),
+				})
+				return err
+			},
+		},
+
+		sonic.OpEnableIGMPSnooping: {
+			Op: sonic.OpEnableIGMPSnooping, Scope: ScopeNode, Inverse: "device." + sonic.OpDisableIGMPSnooping,
+			Params: []ParamSpec{required(sonic.FieldVLANID)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				_, err := n.SetIGMPSnooping(ctx, paramInt(p, sonic.FieldVLANID), true)
+				return err
+			},
+		},
+
+		sonic.OpSetBanner: {
+			Op: sonic.OpSetBanner, Scope: ScopeNode, Inverse: "device." + sonic.OpClearBanner,
+			Params: []ParamSpec{caller(sonic.FieldLogin), caller(sonic.FieldMOTD), caller(sonic.FieldLogout)},
+			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
+				_, err := n.SetBanner(ctx, BannerConfig{
+					Login:  paramString(p, sonic.FieldLogin),
+					MOTD:   paramString(p, sonic.FieldMOTD),
+					Logout: paramString(p, sonic.FieldLogout),
+				})
+				return err
+			},
+		},
+
 		sonic.OpCreateACL: {
 			Op: sonic.OpCreateACL, Scope: ScopeNode, Inverse: "device.delete-acl",
 			Params: []ParamSpec{
@@ -307,6 +438,7 @@ func buildOpRegistry() map[string]*OpSpec {
 				required(sonic.FieldName), required("acl"),
 				caller("priority"), caller("action"), caller("src_ip"), caller("dst_ip"),
 				caller("protocol"), caller("src_port"), caller("dst_port"),
+				caller("src_ipv6"), caller("dst_ipv6"), caller("icmpv6_type"), caller("ether_type"),
 			},
 			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
 				aclName := paramString(p, "acl")
@@ -315,13 +447,17 @@ func buildOpRegistry() map[string]*OpSpec {
 					return fmt.Errorf("add-acl-rule: missing 'acl' or 'name' param")
 				}
 				_, err := n.AddACLRule(ctx, aclName, ruleName, ACLRuleConfig{
-					Priority: paramInt(p, "priority"),
-					Action:   paramString(p, "action"),
-					SrcIP:    paramString(p, "src_ip"),
-					DstIP:    paramString(p, "dst_ip"),
-					Protocol: paramString(p, "protocol"),
-					SrcPort:  paramString(p, "src_port"),
-					DstPort:  paramString(p, "dst_port"),
+					Priority:   paramInt(p, "priority"),
+					Action:     paramString(p, "action"),
+					SrcIP:      paramString(p, "src_ip"),
+					DstIP:      paramString(p, "dst_ip"),
+					Protocol:   paramString(p, "protocol"),
+					SrcPort:    paramString(p, "src_port"),
+					DstPort:    paramString(p, "dst_port"),
+					SrcIPv6:    paramString(p, "src_ipv6"),
+					DstIPv6:    paramString(p, "dst_ipv6"),
+					ICMPv6Type: paramString(p, "icmpv6_type"),
+					EtherType:  paramString(p, "ether_type"),
 				})
 				return err
 			},
@@ -334,6 +470,7 @@ func buildOpRegistry() map[string]*OpSpec {
 				required(sonic.FieldVLANID), ParamSpec{Key: sonic.FieldVRF, Source: SourceCaller, Required: true},
 				ParamSpec{Key: sonic.FieldIPAddress, Source: SourceCaller, Required: true},
 				ParamSpec{Key: sonic.FieldAnycastMAC, Source: SourceCaller, Required: true},
+				caller(sonic.FieldAnycastMACPerVLAN),
 			},
 			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
 				vlanID := paramInt(p, "vlan_id")
@@ -341,9 +478,10 @@ func buildOpRegistry() map[string]*OpSpec {
 					return fmt.Errorf("configure-irb: missing 'vlan_id' param")
 				}
 				_, err := n.ConfigureIRB(ctx, vlanID, IRBConfig{
-					VRF:        paramString(p, "vrf"),
-					IPAddress:  paramString(p, "ip_address"),
-					AnycastMAC: paramString(p, "anycast_mac"),
+					VRF:               paramString(p, "vrf"),
+					IPAddress:         paramString(p, "ip_address"),
+					AnycastMAC:        paramString(p, "anycast_mac"),
+					AnycastMACPerVLAN: paramBool(p, sonic.FieldAnycastMACPerVLAN),
 				})
 				return err
 			},
@@ -352,18 +490,21 @@ func buildOpRegistry() map[string]*OpSpec {
 		sonic.OpAddStaticRoute: {
 			Op: sonic.OpAddStaticRoute, Scope: ScopeNode, Inverse: "device.remove-static-route",
 			Params: []ParamSpec{
-				required(sonic.FieldVRF), required(sonic.FieldPrefix), required(sonic.FieldNextHop),
+				required(sonic.FieldVRF), required(sonic.FieldPrefix),
+				caller(sonic.FieldNextHop), caller(sonic.FieldIfname), caller(sonic.FieldBlackhole),
 				caller(sonic.FieldMetric),
 			},
 			Replay: func(ctx context.Context, n *Node, _ *Interface, p map[string]any) error {
-				vrfName := paramString(p, "vrf")
-				prefix := paramString(p, "prefix")
-				nextHop := paramString(p, "next_hop")
-				metric := paramInt(p, "metric")
-				if prefix == "" || nextHop == "" {
-					return fmt.Errorf("add-static-route: requires 'prefix' and 'next_hop' params")
+				prefix := paramString(p, sonic.FieldPrefix)
+				if prefix == "" {
+					return fmt.Errorf("add-static-route: requires 'prefix' param")
 				}
-				_, err := n.AddStaticRoute(ctx, vrfName, prefix, nextHop, metric)
+				_, err := n.AddStaticRouteMulti(ctx, paramString(p, sonic.FieldVRF), prefix, StaticRouteConfig{
+					NextHops:   paramList(p, sonic.FieldNextHop),
+					Interfaces: paramList(p, sonic.FieldIfname),
+					Blackhole:  paramBool(p, sonic.FieldBlackhole),
+					Distance:   paramInt(p, sonic.FieldMetric),
+				})
 				return err
 			},
 		},
@@ -394,7 +535,7 @@ func buildOpRegistry() map[string]*OpSpec {
 			Op: sonic.OpApplyService, Scope: ScopeInterface, Inverse: "interface.remove-service",
 			Params: []ParamSpec{
 				required(sonic.FieldServiceName),
-				caller(sonic.FieldIPAddress), caller(sonic.FieldBGPPeerAS), caller(sonic.FieldVLANID),
+				caller(sonic.FieldIPAddress), caller(sonic.FieldSecondaryIPs), caller(sonic.FieldBGPPeerAS), caller(sonic.FieldVLANID),
 				caller("route_reflector_client"), caller("next_hop_self"),
 				// Recorded for teardown self-sufficiency (§20): RemoveService reads
 				// these from the binding, never re-resolving specs.
@@ -405,7 +546,7 @@ func buildOpRegistry() map[string]*OpSpec {
 				recorded("bgp_neighbor"), recorded("qos_policy"), recorded("peer_group"),
 				recorded(sonic.FieldL3VNI), recorded(sonic.FieldL3VNIVlan), recorded(sonic.FieldRouteTargets),
 				recorded("redistribute_vrf"), recorded("l2vni"),
-				recorded("anycast_ip"), recorded(sonic.FieldAnycastMAC), recorded("arp_suppression"),
+				recorded("anycast_ip"), recorded(sonic.FieldAnycastMAC), recorded(sonic.FieldAnycastMACPerVLAN), recorded("arp_suppression"),
 			},
 			Replay: replayApplyService,
 			Export: exportApplyService,
@@ -442,12 +583,28 @@ func buildOpRegistry() map[string]*OpSpec {
 			},
 		},
 
+		sonic.OpSetVLANTranslation: {
+			Op: sonic.OpSetVLANTranslation, Scope: ScopeInterface, Inverse: "interface." + sonic.OpClearVLANTranslation,
+			Needs:  []InterfaceCapability{CapabilityVLANMembership},
+			Params: []ParamSpec{required(sonic.FieldInVLAN), required(sonic.FieldOutVLAN)},
+			Replay: func(ctx context.Context, _ *Node, i *Interface, p map[string]any) error {
+				inVLAN := paramInt(p, "in_vlan")
+				outVLAN := paramInt(p, "out_vlan")
+				if inVLAN == 0 || outVLAN == 0 {
+					return fmt.Errorf("set-vlan-translation: missing 'in_vlan' or 'out_vlan' param")
+				}
+				_, err := i.SetVLANTranslation(ctx, inVLAN, outVLAN)
+				return err
+			},
+		},
+
 		sonic.OpAddBGPPeer: {
 			Op: sonic.OpAddBGPPeer, Scope: ScopeInterface, Inverse: "device.remove-bgp-peer",
 			Needs: []InterfaceCapability{CapabilityBGPPeering},
 			Params: []ParamSpec{
 				required(sonic.FieldNeighborIP), required(sonic.FieldRemoteAS),
 				caller(sonic.FieldDescription), caller("multihop"),
+				caller(sonic.FieldMaxPrefix), caller(sonic.FieldMaxPrefixAction),
 			},
 			Replay: func(ctx context.Context, _ *Node, i *Interface, p map[string]any) error {
 				asn := paramInt(p, "remote_as")
@@ -459,6 +616,9 @@ func buildOpRegistry() map[string]*OpSpec {
 					RemoteAS:    asn,
 					Description: paramString(p, "description"),
 					Multihop:    paramInt(p, "multihop"),
+
+					MaxPrefix:       paramInt(p, sonic.FieldMaxPrefix),
+					MaxPrefixAction: paramString(p, sonic.FieldMaxPrefixAction),
 				})
 				return err
 			},
@@ -508,6 +668,34 @@ func buildOpRegistry() map[string]*OpSpec {
 			},
 		},
 
+		sonic.OpSetEgressShaper: {
+			Op: sonic.OpSetEgressShaper, Scope: ScopeInterface, Inverse: "interface." + sonic.OpClearEgressShaper,
+			Needs:  []InterfaceCapability{CapabilityQoSBinding},
+			Params: []ParamSpec{required(sonic.FieldRateKbps)},
+			Replay: func(ctx context.Context, _ *Node, i *Interface, p map[string]any) error {
+				rate := paramInt(p, "rate_kbps")
+				if rate == 0 {
+					return fmt.Errorf("set-egress-shaper: missing 'rate_kbps' param")
+				}
+				_, err := i.SetEgressShaper(ctx, rate)
+				return err
+			},
+		},
+
+		sonic.OpIsolateInterface: {
+			Op: sonic.OpIsolateInterface, Scope: ScopeInterface, Inverse: "interface." + sonic.OpUnisolateInterface,
+			Needs: []InterfaceCapability{CapabilityPortProperties},
+			// The displaced state is re-read from the replayed LAG, peer and
+			// port intents, so nothing need round-trip through steps.
+			Params: []ParamSpec{
+				recorded(sonic.FieldPriorAdminStatus), recorded(sonic.FieldPortChannel), recorded(sonic.FieldNeighborIP),
+			},
+			Replay: func(ctx context.Context, _ *Node, i *Interface, _ map[string]any) error {
+				_, err := i.Isolate(ctx)
+				return err
+			},
+		},
+
 		// -------------------------------------------------------- side-effect ops
 		// Intents written as children of another operation and re-created by that
 		// parent's replay. Never exported to steps; no Replay of their own.
@@ -564,6 +752,11 @@ func replayApplyService(ctx context.Context, _ *Node, i *Interface, p map[string
 		PeerAS:    paramInt(p, "peer_as"),
 		VLAN:      paramInt(p, "vlan_id"),
 	}
+	// secondary_ips is comma-joined in an exported step; a hand-written
+	// topology may give a list.
+	if opts.SecondaryIPs = paramStringSlice(p, sonic.FieldSecondaryIPs); opts.SecondaryIPs == nil {
+		opts.SecondaryIPs = splitSecondaryIPs(paramString(p, sonic.FieldSecondaryIPs))
+	}
 	// Topology BGP attributes (route_reflector_client, next_hop_self) flow
 	// through Params to ApplyService for correct BGP neighbor configuration.
 	if rrc := paramString(p, "route_reflector_client"); rrc != "" {
@@ -633,6 +826,9 @@ func exportApplyService(intent *sonic.Intent) map[string]any {
 	if v := params[sonic.FieldIPAddress]; v != "" {
 		result[sonic.FieldIPAddress] = v
 	}
+	if v := params[sonic.FieldSecondaryIPs]; v != "" {
+		result[sonic.FieldSecondaryIPs] = v
+	}
 	if v := params[sonic.FieldBGPPeerAS]; v != "" {
 		result["peer_as"] = v
 	}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
@@ -140,6 +141,18 @@ var roundTripSequence = []opInvocation{
 		_, err := n.BindIPVPN(ctx, "CUST", "Vrf_CUST_ETH9")
 		return err
 	}},
+	{"add-route-leak", func(ctx context.Context, n *Node) error {
+		// Leak Vrf_TEST into the IP-VPN-bound VRF, which carries the BGP
+		// instance the import is configured in.
+		_, err := n.AddRouteLeak(ctx, "Vrf_TEST", "Vrf_CUST_ETH9", []string{"10.9.0.0/24", "10.10.0.0/24"})
+		return err
+	}},
+	{"set-vrf-route-targets", func(ctx context.Context, n *Node) error {
+		// Keep the VPN's RT as "both" and add an import-only one, so replay
+		// must reproduce a mixed set from the recorded lists.
+		_, err := n.SetVRFRouteTargets(ctx, "Vrf_CUST_ETH9", []string{"65000:50400", "65000:999"}, []string{"65000:50400"})
+		return err
+	}},
 	{"create-portchannel", func(ctx context.Context, n *Node) error {
 		_, err := n.CreatePortChannel(ctx, "PortChannel10", PortChannelConfig{
 			Members:  []string{"Ethernet8"},
@@ -154,6 +167,22 @@ var roundTripSequence = []opInvocation{
 		_, err := n.AddPortChannelMember(ctx, "PortChannel10", "Ethernet12")
 		return err
 	}},
+	{"set-lag-hash-policy", func(ctx context.Context, n *Node) error {
+		_, err := n.SetLAGHashPolicy(ctx, []string{"src_ip", "DST_IP", "L4_SRC_PORT", "L4_DST_PORT"})
+		return err
+	}},
+	{"set-bgp-max-paths", func(ctx context.Context, n *Node) error {
+		_, err := n.SetBGPMaxPaths(ctx, 8)
+		return err
+	}},
+	{"set-counter-polling", func(ctx context.Context, n *Node) error {
+		_, err := n.SetCounterPolling(ctx, "acl", true, 5*time.Second)
+		return err
+	}},
+	{"set-banner", func(ctx context.Context, n *Node) error {
+		_, err := n.SetBanner(ctx, BannerConfig{Login: "Authorized access only.", MOTD: "leaf1 — lab fabric\nChanges via newtron only."})
+		return err
+	}},
 	{"create-acl", func(ctx context.Context, n *Node) error {
 		_, err := n.CreateACL(ctx, "EDGE_IN", ACLConfig{
 			Type:        "L3",
@@ -182,8 +211,25 @@ var roundTripSequence = []opInvocation{
 		})
 		return err
 	}},
+	{"configure-irb (per-VLAN SAG)", func(ctx context.Context, n *Node) error {
+		_, err := n.ConfigureIRB(ctx, 300, IRBConfig{
+			VRF:               "Vrf_TEST",
+			IPAddress:         "192.168.30.1/24",
+			AnycastMAC:        "00:00:00:aa:bb:dd",
+			AnycastMACPerVLAN: true,
+		})
+		return err
+	}},
 	{"add-static-route", func(ctx context.Context, n *Node) error {
-		_, err := n.AddStaticRoute(ctx, "Vrf_TEST", "10.9.0.0/24", "10.9.1.1", 50)
+		_, err := n.AddStaticRouteMulti(ctx, "Vrf_TEST", "10.9.0.0/24", StaticRouteConfig{
+			NextHops:   []string{"10.9.1.1", "10.9.2.1"},
+			Interfaces: []string{"Ethernet16", "Ethernet20"},
+			Distance:   50,
+		})
+		return err
+	}},
+	{"add-static-route (blackhole)", func(ctx context.Context, n *Node) error {
+		_, err := n.AddStaticRouteMulti(ctx, "Vrf_TEST", "10.10.0.0/16", StaticRouteConfig{Blackhole: true})
 		return err
 	}},
 	{"add-bgp-evpn-peer", func(ctx context.Context, n *Node) error {
@@ -208,6 +254,9 @@ var roundTripSequence = []opInvocation{
 			RemoteAS:    65099,
 			Description: "underlay peer",
 			Multihop:    2,
+
+			MaxPrefix:       1000,
+			MaxPrefixAction: BGPMaxPrefixRestart,
 		})
 		return err
 	}},
@@ -219,6 +268,35 @@ var roundTripSequence = []opInvocation{
 		_, err = i.ConfigureInterface(ctx, InterfaceConfig{VLAN: 100, Tagged: true})
 		return err
 	}},
+	{"configure-dhcp-server", func(ctx context.Context, n *Node) error {
+		// Ports resolve to the VLAN's members (Ethernet4) and are recorded,
+		// so replay needs no membership to serve the same set.
+		_, err := n.ConfigureDHCPServer(ctx, 100, DHCPServerConfig{
+			Ranges:    []string{"192.168.100.50-192.168.100.99", "192.168.100.200"},
+			LeaseTime: 3600,
+			Options:   map[int]string{66: "tftp.example", 150: "10.9.1.1"},
+		})
+		return err
+	}},
+	{"enable-igmp-snooping", func(ctx context.Context, n *Node) error {
+		_, err := n.SetIGMPSnooping(ctx, 100, true)
+		return err
+	}},
+	{"create-mirror-session", func(ctx context.Context, n *Node) error {
+		// The collector sits on Vrf_TEST's IRB subnet; every optional
+		// setting is given so replay must carry each one.
+		_, err := n.CreateMirrorSession(ctx, "ERSPAN_COLLECTOR", MirrorConfig{
+			DstIP:     "192.168.100.250",
+			SrcIP:     "10.0.0.1",
+			Ports:     []string{"Ethernet0", "Ethernet4"},
+			Direction: "rx",
+			VRF:       "Vrf_TEST",
+			DSCP:      8,
+			TTL:       64,
+			GREType:   "0x22eb",
+		})
+		return err
+	}},
 	{"add-trunk-vlan", func(ctx context.Context, n *Node) error {
 		i, err := iface(n, "Ethernet4")
 		if err != nil {
@@ -227,6 +305,14 @@ var roundTripSequence = []opInvocation{
 		_, err = i.ConfigureInterface(ctx, InterfaceConfig{VLAN: 200, Tagged: true})
 		return err
 	}},
+	{"set-vlan-translation", func(ctx context.Context, n *Node) error {
+		i, err := iface(n, "Ethernet4")
+		if err != nil {
+			return err
+		}
+		_, err = i.SetVLANTranslation(ctx, 1010, 200)
+		return err
+	}},
 	{"set-property", func(ctx context.Context, n *Node) error {
 		i, err := iface(n, "Ethernet20")
 		if err != nil {
@@ -251,6 +337,30 @@ var roundTripSequence = []opInvocation{
 		_, err = i.BindQoS(ctx, "GOLD")
 		return err
 	}},
+	{"set-egress-shaper", func(ctx context.Context, n *Node) error {
+		i, err := iface(n, "Ethernet20")
+		if err != nil {
+			return err
+		}
+		_, err = i.SetEgressShaper(ctx, 2000000)
+		return err
+	}},
+	{"isolate-interface (lag member)", func(ctx context.Context, n *Node) error {
+		i, err := iface(n, "Ethernet12")
+		if err != nil {
+			return err
+		}
+		_, err = i.Isolate(ctx)
+		return err
+	}},
+	{"isolate-interface (bgp peer)", func(ctx context.Context, n *Node) error {
+		i, err := iface(n, "Ethernet0")
+		if err != nil {
+			return err
+		}
+		_, err = i.Isolate(ctx)
+		return err
+	}},
 	{"apply-service", func(ctx context.Context, n *Node) error {
 		i, err := iface(n, "Ethernet16")
 		if err != nil {
@@ -320,10 +430,10 @@ func TestOpRoundTrip(t *testing.T) {
 	expectedOps := map[string]bool{
 		"setup-device": true, "create-vrf": true, "create-vlan": true,
 		"bind-macvpn": true, "bind-ipvpn": true, "create-portchannel": true,
-		"add-pc-member": true, "create-acl": true, "add-acl-rule": true,
-		"configure-irb": true, "add-static-route": true, "add-bgp-evpn-peer": true,
-		"configure-interface": true, "add-trunk-vlan": true, "add-bgp-peer": true,
-		"set-property": true, "bind-acl": true, "bind-qos": true, "apply-service": true,
+		"add-pc-member": true, "set-lag-hash-policy": true, "set-bgp-max-paths": true, "set-counter-polling": true, "set-banner": true, "add-route-leak": true, "set-vrf-route-targets": true, "create-acl": true, "add-acl-rule": true,
+		"configure-irb": true, "configure-dhcp-server": true, "create-mirror-session": true, "enable-igmp-snooping": true, "add-static-route": true, "add-bgp-evpn-peer": true,
+		"configure-interface": true, "add-trunk-vlan": true, "set-vlan-translation": true, "add-bgp-peer": true,
+		"set-property": true, "bind-acl": true, "bind-qos": true, "set-egress-shaper": true, "isolate-interface": true, "apply-service": true,
 		// Side-effect intents, re-created by their parents during replay:
 		"interface-init": true, "deploy-service": true,
 	}
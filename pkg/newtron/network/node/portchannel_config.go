@@ -2,6 +2,9 @@ package node
 
 import (
 	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 )
@@ -37,3 +40,38 @@ func createPortChannelMemberConfig(pcName, member string) []sonic.Entry {
 func deletePortChannelMemberConfig(pcName, member string) []sonic.Entry {
 	return []sonic.Entry{{Table: "PORTCHANNEL_MEMBER", Key: portChannelMemberKey(pcName, member)}}
 }
+
+// lagHashSeed derives a device's ECMP/LAG hash seed from its name. Every
+// SONiC device ships with seed 0; two tiers hashing the same fields with the
+// same seed pick correlated next-hops and polarize traffic. A per-device seed
+// (1-1024, never the factory 0) decorrelates them without operator input.
+func lagHashSeed(device string) string {
+	h := fnv.New32a()
+	h.Write([]byte(device))
+	return strconv.Itoa(int(h.Sum32()%1024) + 1)
+}
+
+// setLAGHashPolicyConfig returns the entries selecting the ECMP and LAG hash
+// fields (SWITCH_HASH|GLOBAL) and seeding both hashes (SWITCH|switch). The
+// SWITCH entry is factory-owned; the seeds are merged into it, never replaced.
+func setLAGHashPolicyConfig(device string, fields []string) []sonic.Entry {
+	csv := strings.Join(fields, ",")
+	seed := lagHashSeed(device)
+	return []sonic.Entry{
+		{Table: "SWITCH_HASH", Key: "GLOBAL", Fields: map[string]string{"ecmp_hash": csv, "lag_hash": csv}},
+		{Table: "SWITCH", Key: "switch", Fields: map[string]string{"ecmp_hash_seed": seed, "lag_hash_seed": seed}},
+	}
+}
+
+// clearLAGHashPolicyConfig returns the SWITCH_HASH|GLOBAL delete. The SWITCH
+// seeds are not deleted with it — see resetLAGHashSeedConfig.
+func clearLAGHashPolicyConfig() []sonic.Entry {
+	return []sonic.Entry{{Table: "SWITCH_HASH", Key: "GLOBAL"}}
+}
+
+// resetLAGHashSeedConfig returns the SWITCH|switch seeds at their factory
+// value (init_cfg.json: 0). The entry itself stays — it carries factory
+// fields newtron does not own.
+func resetLAGHashSeedConfig() []sonic.Entry {
+	return []sonic.Entry{{Table: "SWITCH", Key: "switch", Fields: map[string]string{"ecmp_hash_seed": "0", "lag_hash_seed": "0"}}}
+}
@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
@@ -26,7 +27,7 @@ type PortChannelConfig struct {
 // Intent-idempotent: if the portchannel intent already exists, returns empty ChangeSet.
 func (n *Node) CreatePortChannel(ctx context.Context, name string, opts PortChannelConfig) (*ChangeSet, error) {
 	// Normalize PortChannel name (e.g., Po100 -> PortChannel100)
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 
 	if n.GetIntent("portchannel|"+name) != nil {
 		return NewChangeSet(n.name, "device."+sonic.OpCreatePortChannel), nil
@@ -107,7 +108,7 @@ func (n *Node) CreatePortChannel(ctx context.Context, name string, opts PortChan
 // the PORTCHANNEL_MEMBER row and the child intent — else deleteIntent refuses the
 // parent for having children.
 func (n *Node) DeletePortChannel(ctx context.Context, name string) (*ChangeSet, error) {
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 
 	members := n.portChannelMembers(name)
 
@@ -147,13 +148,14 @@ func (n *Node) portChannelMembers(name string) []string {
 	for resource := range n.IntentsByPrefix(prefix) {
 		members = append(members, strings.TrimPrefix(resource, prefix))
 	}
+	slices.Sort(members) // DeletePortChannel emits one delete per member, in this order
 	return members
 }
 
 // AddPortChannelMember adds a member to a PortChannel.
 func (n *Node) AddPortChannelMember(ctx context.Context, pcName, member string) (*ChangeSet, error) {
-	pcName = util.NormalizeInterfaceName(pcName)
-	member = util.NormalizeInterfaceName(member)
+	pcName = n.normalizeInterfaceName(pcName)
+	member = n.normalizeInterfaceName(member)
 
 	// Member must be unconfigured — no active interface role (VRF, VLAN, service).
 	// A configured interface has an interface|{name} intent; reject if present.
@@ -196,8 +198,8 @@ func (n *Node) writePortChannelMemberIntent(cs *ChangeSet, pcName, member string
 
 // RemovePortChannelMember removes a member from a PortChannel.
 func (n *Node) RemovePortChannelMember(ctx context.Context, pcName, member string) (*ChangeSet, error) {
-	pcName = util.NormalizeInterfaceName(pcName)
-	member = util.NormalizeInterfaceName(member)
+	pcName = n.normalizeInterfaceName(pcName)
+	member = n.normalizeInterfaceName(member)
 
 	cs, err := n.op("remove-portchannel-member", pcName, ChangeDelete,
 		func(pc *PreconditionChecker) { pc.RequirePortChannelExists(pcName) },
@@ -215,6 +217,90 @@ func (n *Node) RemovePortChannelMember(ctx context.Context, pcName, member strin
 	return cs, nil
 }
 
+// lagHashPolicyResource is the intent key of the device-global hash policy.
+const lagHashPolicyResource = "lag-hash-policy"
+
+// SetLAGHashPolicy selects the packet fields the device hashes on to spread
+// flows across PortChannel members and ECMP next-hops (SWITCH_HASH|GLOBAL),
+// and seeds both hashes per device (SWITCH|switch) so neighbouring tiers do
+// not polarize. Fields are SONiC hash field names (sonic.HashFields),
+// case-insensitive; a platform that declares HashFields accepts only those.
+// Device-global: setting it again replaces the previous field set.
+func (n *Node) SetLAGHashPolicy(ctx context.Context, fields []string) (*ChangeSet, error) {
+	normalized := make([]string, 0, len(fields))
+	seen := map[string]bool{}
+	var unknown, duplicate []string
+	for _, f := range fields {
+		f = strings.ToUpper(strings.TrimSpace(f))
+		switch {
+		case !slices.Contains(sonic.HashFields, f):
+			unknown = append(unknown, f)
+		case seen[f]:
+			duplicate = append(duplicate, f)
+		}
+		seen[f] = true
+		normalized = append(normalized, f)
+	}
+
+	pc := n.precondition(sonic.OpSetLAGHashPolicy, lagHashPolicyResource).
+		Check(len(normalized) > 0, "hash fields", "at least one hash field is required").
+		Check(len(unknown) == 0, "known hash fields",
+			fmt.Sprintf("unknown hash field(s) %s — valid: %s", strings.Join(unknown, ", "), strings.Join(sonic.HashFields, ", "))).
+		Check(len(duplicate) == 0, "distinct hash fields", fmt.Sprintf("duplicate hash field(s) %s", strings.Join(duplicate, ", ")))
+	if resolved := n.Resolved(); resolved.Platform != "" {
+		if platform, err := n.GetPlatform(resolved.Platform); err == nil && len(platform.HashFields) > 0 {
+			var unsupported []string
+			for _, f := range normalized {
+				if !slices.Contains(platform.HashFields, f) {
+					unsupported = append(unsupported, f)
+				}
+			}
+			pc.Check(len(unsupported) == 0, "platform supports hash fields",
+				fmt.Sprintf("platform %s cannot hash on %s", resolved.Platform, strings.Join(unsupported, ", ")))
+		}
+	}
+	if err := pc.Result(); err != nil {
+		return nil, err
+	}
+
+	csv := strings.Join(normalized, ",")
+	cs := NewChangeSet(n.name, "device."+sonic.OpSetLAGHashPolicy)
+	cs.ReverseOp = "device." + sonic.OpClearLAGHashPolicy
+	cs.OperationParams = map[string]string{sonic.FieldHashFields: csv}
+	if err := n.writeIntent(cs, sonic.OpSetLAGHashPolicy, lagHashPolicyResource,
+		map[string]string{sonic.FieldHashFields: csv}, []string{"device"}); err != nil {
+		return nil, err
+	}
+	cs.Updates(setLAGHashPolicyConfig(n.name, normalized))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Set LAG/ECMP hash policy: %s", csv)
+	return cs, nil
+}
+
+// ClearLAGHashPolicy removes the hash field selection and returns both hash
+// seeds to the factory value. Reverse of SetLAGHashPolicy (§15).
+func (n *Node) ClearLAGHashPolicy(ctx context.Context) (*ChangeSet, error) {
+	if err := n.precondition(sonic.OpClearLAGHashPolicy, lagHashPolicyResource).Result(); err != nil {
+		return nil, err
+	}
+	if n.GetIntent(lagHashPolicyResource) == nil {
+		return nil, fmt.Errorf("no LAG hash policy is set")
+	}
+	cs := NewChangeSet(n.name, "device."+sonic.OpClearLAGHashPolicy)
+	cs.Deletes(clearLAGHashPolicyConfig())
+	cs.Updates(resetLAGHashSeedConfig())
+	if err := n.deleteIntent(cs, lagHashPolicyResource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Cleared LAG/ECMP hash policy")
+	return cs, nil
+}
+
 // ============================================================================
 // PortChannel Data Types and Queries
 // ============================================================================
@@ -235,7 +321,7 @@ func (n *Node) GetPortChannel(name string) (*PortChannelInfo, error) {
 	}
 
 	// Normalize PortChannel name (e.g., Po100 -> PortChannel100)
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 
 	pcIntent := n.GetIntent("portchannel|" + name)
 	if pcIntent == nil {
@@ -279,7 +365,7 @@ func (n *Node) ListPortChannels() []string {
 // Accepts both short (Eth0) and full (Ethernet0) interface names.
 // Scans portchannel member intents (resource: "portchannel|PC|MEMBER").
 func (n *Node) InterfaceIsPortChannelMember(name string) bool {
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 	for resource := range n.IntentsByPrefix("portchannel|") {
 		// Intent key format: "portchannel|PortChannel100|Ethernet0"
 		parts := strings.SplitN(resource, "|", 3)
@@ -294,7 +380,7 @@ func (n *Node) InterfaceIsPortChannelMember(name string) bool {
 // Accepts both short (Eth0) and full (Ethernet0) interface names.
 // Scans portchannel member intents (resource: "portchannel|PC|MEMBER").
 func (n *Node) GetInterfacePortChannel(name string) string {
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 	for resource := range n.IntentsByPrefix("portchannel|") {
 		// Intent key format: "portchannel|PortChannel100|Ethernet0"
 		parts := strings.SplitN(resource, "|", 3)
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/aldrin-isaac/newtron/pkg/util"
 )
@@ -56,7 +57,7 @@ func (n *Node) precondition(operation, resource string) *PreconditionChecker {
 // the designed path instead of denying the capability's existence (the one
 // case today: routed config on an IRB is authored via configure-irb).
 func (p *PreconditionChecker) RequireInterfaceCapabilities(name string, caps ...InterfaceCapability) *PreconditionChecker {
-	kind := interfaceKindOf(util.NormalizeInterfaceName(name))
+	kind := interfaceKindOf(p.node.normalizeInterfaceName(name))
 	for _, c := range caps {
 		if owner := authoringOwner(kind, c); owner != "" {
 			p.errors = append(p.errors, util.NewPreconditionError(
@@ -168,6 +169,37 @@ func (p *PreconditionChecker) RequireVTEPConfigured() *PreconditionChecker {
 	return p
 }
 
+// RequireVNIUnmapped checks that no VXLAN_TUNNEL_MAP entry maps vni to a VLAN
+// other than vlanName — each VNI maps to exactly one bridge domain (see
+// ValidateVNIs).
+func (p *PreconditionChecker) RequireVNIUnmapped(vni int, vlanName string) *PreconditionChecker {
+	l2, _ := p.node.vniOwners()
+	for _, vlan := range l2[strconv.Itoa(vni)] {
+		if vlan != vlanName {
+			p.errors = append(p.errors, util.NewPreconditionError(
+				p.operation, p.resource, "VNI must not be mapped to another VLAN",
+				fmt.Sprintf("VNI %d is already mapped to %s", vni, vlan)))
+			break
+		}
+	}
+	return p
+}
+
+// RequireL3VNIUnbound checks that no VRF other than vrfName carries vni as its
+// L3VNI. An empty vrfName refuses any VRF — for an L2VNI that must not collide.
+func (p *PreconditionChecker) RequireL3VNIUnbound(vni int, vrfName string) *PreconditionChecker {
+	_, l3 := p.node.vniOwners()
+	for _, vrf := range l3[strconv.Itoa(vni)] {
+		if vrf != vrfName {
+			p.errors = append(p.errors, util.NewPreconditionError(
+				p.operation, p.resource, "L3VNI must not be bound to another VRF",
+				fmt.Sprintf("L3VNI %d is already bound to VRF %s", vni, vrf)))
+			break
+		}
+	}
+	return p
+}
+
 // RequireACLTableExists checks that an ACL table exists by checking the intent DB.
 func (p *PreconditionChecker) RequireACLTableExists(name string) *PreconditionChecker {
 	if p.node.GetIntent(fmt.Sprintf("acl|%s", name)) == nil {
@@ -7,6 +7,7 @@ package node
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
@@ -144,6 +145,35 @@ func unbindQosConfig(intfName string, queueCount int) []sonic.Entry {
 	return entries
 }
 
+// egressShaperName returns the SCHEDULER key of a port's egress shaper —
+// one per port, named for it, never shared with a QoS policy's queue
+// schedulers.
+func egressShaperName(intfName string) string {
+	return intfName + "_SHAPER"
+}
+
+// createEgressShaperConfig produces the CONFIG_DB entries for a port egress
+// shaper: a byte-metered SCHEDULER whose pir is the rate in bytes/s, and the
+// PORT_QOS_MAP scheduler field that attaches it to the port. PORT_QOS_MAP is
+// the same row bind-qos writes its maps to — the shaper owns only its field.
+func createEgressShaperConfig(intfName string, rateKbps int) []sonic.Entry {
+	return []sonic.Entry{
+		{
+			Table: "SCHEDULER",
+			Key:   egressShaperName(intfName),
+			Fields: map[string]string{
+				"meter_type": "bytes",
+				"pir":        strconv.FormatInt(int64(rateKbps)*1000/8, 10),
+			},
+		},
+		{
+			Table:  "PORT_QOS_MAP",
+			Key:    intfName,
+			Fields: map[string]string{"scheduler": fmt.Sprintf("[SCHEDULER|%s]", egressShaperName(intfName))},
+		},
+	}
+}
+
 // deleteDeviceQoSConfig returns delete entries for the device-wide QoS tables
 // created by GenerateDeviceQoSConfig: DSCP_TO_TC_MAP, TC_TO_QUEUE_MAP,
 // SCHEDULER, and WRED_PROFILE. Deterministic from policy spec.
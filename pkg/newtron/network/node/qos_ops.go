@@ -88,7 +88,8 @@ func (i *Interface) UnbindQoS(ctx context.Context) (*ChangeSet, error) {
 		}
 	}
 
-	cs := buildChangeSet(n.Name(), "interface."+sonic.OpUnbindQoS, unbindQosConfig(i.name, queueCount), ChangeDelete)
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpUnbindQoS)
+	n.unbindQoSRows(cs, i.name, queueCount)
 
 	// Clean up device-wide entries if no other interface references this policy
 	if policyName != "" && !n.isQoSPolicyReferenced(policyName, i.name) {
@@ -105,6 +106,146 @@ func (i *Interface) UnbindQoS(ctx context.Context) (*ChangeSet, error) {
 	return cs, nil
 }
 
+// unbindQoSRows removes a port's QoS-policy rows: its QUEUE rows and the map
+// fields of its PORT_QOS_MAP row. The PORT_QOS_MAP row is shared with the
+// port's egress shaper — when one is attached, the row is replaced by the
+// shaper's field alone instead of deleted, so unbinding a policy never drops
+// the rate limit. The single owner of policy-row teardown for UnbindQoS,
+// RemoveService, and the per-member path.
+func (n *Node) unbindQoSRows(cs *ChangeSet, intfName string, queueCount int) {
+	shaper := n.configDB.PortQoSMap[intfName].Scheduler
+	for _, e := range unbindQosConfig(intfName, queueCount) {
+		if e.Table == "PORT_QOS_MAP" && shaper != "" {
+			cs.Replace(n, nil, []sonic.Entry{{Table: e.Table, Key: e.Key,
+				Fields: map[string]string{"scheduler": shaper}}})
+			continue
+		}
+		cs.Delete(e.Table, e.Key)
+	}
+}
+
+// ============================================================================
+// Egress Shaping (Per-Interface)
+// ============================================================================
+
+// egressShaperResource is the intent resource of a port's egress shaper.
+func egressShaperResource(intfName string) string {
+	return "interface|" + intfName + "|egress-shaper"
+}
+
+// SetEgressShaper rate-limits all egress traffic on this port to rateKbps —
+// a byte-metered SCHEDULER attached through PORT_QOS_MAP. The rate must not
+// exceed the port speed when the speed is known. Setting a shaper that is
+// already set changes its rate in place; the port is never briefly unshaped.
+func (i *Interface) SetEgressShaper(ctx context.Context, rateKbps int) (*ChangeSet, error) {
+	n := i.node
+	speedMbps, _ := strconv.Atoi(i.Speed())
+	if err := n.precondition(sonic.OpSetEgressShaper, i.name).
+		Check(rateKbps > 0, "positive rate", fmt.Sprintf("rate must be positive, got %d kbps", rateKbps)).
+		Check(speedMbps <= 0 || rateKbps <= speedMbps*1000, "rate within port speed",
+			fmt.Sprintf("%d kbps exceeds %s port speed %d Mbps", rateKbps, i.name, speedMbps)).
+		Result(); err != nil {
+		return nil, err
+	}
+	if i.IsPortChannelMember() {
+		return nil, fmt.Errorf("cannot shape PortChannel member %s — shaping is per member port, remove it from %s first",
+			i.name, i.PortChannelParent())
+	}
+
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpSetEgressShaper)
+	if err := i.createInterfaceIntent(cs); err != nil {
+		return nil, err
+	}
+	if err := n.writeIntent(cs, sonic.OpSetEgressShaper, egressShaperResource(i.name),
+		map[string]string{sonic.FieldRateKbps: strconv.Itoa(rateKbps)},
+		[]string{"interface|" + i.name}); err != nil {
+		return nil, err
+	}
+	cs.Updates(createEgressShaperConfig(i.name, rateKbps))
+	cs.ReverseOp = "interface." + sonic.OpClearEgressShaper
+	cs.OperationParams = map[string]string{"interface": i.name, sonic.FieldRateKbps: strconv.Itoa(rateKbps)}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.Name()).Infof("Set egress shaper on %s to %d kbps", i.name, rateKbps)
+	return cs, nil
+}
+
+// ClearEgressShaper removes this port's egress shaper — its SCHEDULER row and
+// the PORT_QOS_MAP scheduler field. A bound QoS policy's maps stay on the
+// row. Reverse of SetEgressShaper (§15).
+func (i *Interface) ClearEgressShaper(ctx context.Context) (*ChangeSet, error) {
+	n := i.node
+	if err := n.precondition(sonic.OpClearEgressShaper, i.name).Result(); err != nil {
+		return nil, err
+	}
+	resource := egressShaperResource(i.name)
+	if n.GetIntent(resource) == nil {
+		return nil, fmt.Errorf("no egress shaper on %s", i.name)
+	}
+
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpClearEgressShaper)
+	if m := n.configDB.PortQoSMap[i.name]; m.DSCPToTCMap != "" || m.TCToQueueMap != "" {
+		cs.Replace(n, nil, []sonic.Entry{{Table: "PORT_QOS_MAP", Key: i.name, Fields: map[string]string{
+			"dscp_to_tc_map":  m.DSCPToTCMap,
+			"tc_to_queue_map": m.TCToQueueMap,
+		}}})
+	} else {
+		cs.Delete("PORT_QOS_MAP", i.name)
+	}
+	cs.Delete("SCHEDULER", egressShaperName(i.name))
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	cs.OperationParams = map[string]string{"interface": i.name}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.Name()).Infof("Cleared egress shaper on %s", i.name)
+	return cs, nil
+}
+
+// EgressShaper is a port's egress shaper as intended and as configured on the
+// device — the verify counterpart of SetEgressShaper.
+type EgressShaper struct {
+	Interface      string
+	RateKbps       int  // intended rate (intent record); 0 when no shaper is set
+	ConfiguredKbps int  // rate in CONFIG_DB SCHEDULER pir; 0 when the row is absent
+	Attached       bool // PORT_QOS_MAP references the shaper
+}
+
+// GetEgressShaper reads this port's egress shaper. ConfiguredKbps and Attached
+// come from the device's CONFIG_DB when connected — the rows the shaper
+// actually left there — and from the projection otherwise.
+func (i *Interface) GetEgressShaper(ctx context.Context) (*EgressShaper, error) {
+	n := i.node
+	out := &EgressShaper{Interface: i.name}
+	if intent := n.GetIntent(egressShaperResource(i.name)); intent != nil {
+		out.RateKbps, _ = strconv.Atoi(intent.Params[sonic.FieldRateKbps])
+	}
+
+	name := egressShaperName(i.name)
+	var pir, attached string
+	if n.conn != nil {
+		sched, err := n.conn.Client().Get("SCHEDULER", name)
+		if err != nil {
+			return nil, fmt.Errorf("reading CONFIG_DB SCHEDULER|%s: %w", name, err)
+		}
+		qosMap, err := n.conn.Client().Get("PORT_QOS_MAP", i.name)
+		if err != nil {
+			return nil, fmt.Errorf("reading CONFIG_DB PORT_QOS_MAP|%s: %w", i.name, err)
+		}
+		pir, attached = sched["pir"], qosMap["scheduler"]
+	} else {
+		pir, attached = n.configDB.Scheduler[name].PIR, n.configDB.PortQoSMap[i.name].Scheduler
+	}
+	if bytesPerSec, err := strconv.ParseInt(pir, 10, 64); err == nil {
+		out.ConfiguredKbps = int(bytesPerSec * 8 / 1000)
+	}
+	out.Attached = attached == fmt.Sprintf("[SCHEDULER|%s]", name)
+	return out, nil
+}
+
 // ============================================================================
 // QoS Per-Member Derivation (IRB delivery-point) + policy-reference scans
 // ============================================================================
@@ -197,7 +338,7 @@ func (n *Node) deleteMemberQoSRows(cs *ChangeSet, member string) {
 		}
 	}
 	if queueCount > 0 || n.configDB.PortQoSMap[member].DSCPToTCMap != "" {
-		cs.Deletes(unbindQosConfig(member, queueCount))
+		n.unbindQoSRows(cs, member, queueCount)
 	}
 }
 
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"testing"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
@@ -51,3 +52,147 @@ func TestMemberPolicy_QoSBindsMembers(t *testing.T) {
 // irb service is refused on a VLAN with any trunk (multi-VLAN) member at apply/join
 // time, so a member reaching bindMemberQoS is always single-VLAN. That gate is
 // covered by TestMemberPolicy_TrunkGate (service_bridgedomain_test.go).
+
+// shaperSetup returns a 10G port with QoS policy GOLD available to bind.
+func shaperSetup(t *testing.T) (*Node, *Interface) {
+	t.Helper()
+	n := testDevice()
+	n.configDB.Port["Ethernet0"] = sonic.PortEntry{Speed: "10000"}
+	n.SpecProvider.(*testSpecProvider).qosPolicies["GOLD"] = &spec.QoSPolicy{
+		Queues: []*spec.QoSQueue{{Name: "be", Type: "dwrr", Weight: 100}},
+	}
+	return n, n.interfaces["Ethernet0"]
+}
+
+func TestSetEgressShaper_GeneratesEntries(t *testing.T) {
+	n, iface := shaperSetup(t)
+	ctx := context.Background()
+
+	cs, err := iface.SetEgressShaper(ctx, 2000000)
+	if err != nil {
+		t.Fatalf("SetEgressShaper: %v", err)
+	}
+	c := assertChange(t, cs, "SCHEDULER", "Ethernet0_SHAPER", ChangeModify)
+	if c.Fields["meter_type"] != "bytes" || c.Fields["pir"] != "250000000" {
+		t.Errorf("SCHEDULER fields = %v, want meter_type=bytes pir=250000000", c.Fields)
+	}
+	c = assertChange(t, cs, "PORT_QOS_MAP", "Ethernet0", ChangeModify)
+	if c.Fields["scheduler"] != "[SCHEDULER|Ethernet0_SHAPER]" {
+		t.Errorf("PORT_QOS_MAP scheduler = %q", c.Fields["scheduler"])
+	}
+	if cs.ReverseOp != "interface.clear-egress-shaper" {
+		t.Errorf("ReverseOp = %q, want interface.clear-egress-shaper", cs.ReverseOp)
+	}
+	if got := n.GetIntent("interface|Ethernet0|egress-shaper").Params["rate_kbps"]; got != "2000000" {
+		t.Errorf("intent rate_kbps = %q, want 2000000", got)
+	}
+
+	// Re-setting changes the rate in place — no delete, no unshaped window.
+	cs, err = iface.SetEgressShaper(ctx, 500000)
+	if err != nil {
+		t.Fatalf("re-set: %v", err)
+	}
+	for _, c := range cs.Changes {
+		if c.Type == ChangeDelete && c.Table != "NEWTRON_INTENT" {
+			t.Errorf("re-set emitted delete of %s|%s", c.Table, c.Key)
+		}
+	}
+	if got := n.configDB.Scheduler["Ethernet0_SHAPER"].PIR; got != "62500000" {
+		t.Errorf("pir after re-set = %q, want 62500000", got)
+	}
+}
+
+func TestSetEgressShaper_Refusals(t *testing.T) {
+	ctx := context.Background()
+	for _, rate := range []int{0, -1, 10000001} {
+		_, iface := shaperSetup(t)
+		if _, err := iface.SetEgressShaper(ctx, rate); err == nil {
+			t.Errorf("SetEgressShaper(%d) on a 10G port should fail", rate)
+		}
+	}
+}
+
+// TestEgressShaper_SharesPortQoSMap pins the shared PORT_QOS_MAP row: the
+// shaper and a bound QoS policy each own their fields, and removing either
+// leaves the other's in place.
+func TestEgressShaper_SharesPortQoSMap(t *testing.T) {
+	n, iface := shaperSetup(t)
+	ctx := context.Background()
+
+	if _, err := iface.BindQoS(ctx, "GOLD"); err != nil {
+		t.Fatalf("BindQoS: %v", err)
+	}
+	if _, err := iface.SetEgressShaper(ctx, 1000000); err != nil {
+		t.Fatalf("SetEgressShaper: %v", err)
+	}
+	if m := n.configDB.PortQoSMap["Ethernet0"]; m.DSCPToTCMap == "" || m.Scheduler == "" {
+		t.Fatalf("PORT_QOS_MAP = %+v, want maps and shaper together", m)
+	}
+
+	cs, err := iface.UnbindQoS(ctx)
+	if err != nil {
+		t.Fatalf("UnbindQoS: %v", err)
+	}
+	c := assertChange(t, cs, "PORT_QOS_MAP", "Ethernet0", ChangeReplace)
+	if len(c.Fields) != 1 || c.Fields["scheduler"] != "[SCHEDULER|Ethernet0_SHAPER]" {
+		t.Errorf("PORT_QOS_MAP after unbind = %v, want the shaper field alone", c.Fields)
+	}
+
+	if _, err := iface.BindQoS(ctx, "GOLD"); err != nil {
+		t.Fatalf("re-BindQoS: %v", err)
+	}
+	cs, err = iface.ClearEgressShaper(ctx)
+	if err != nil {
+		t.Fatalf("ClearEgressShaper: %v", err)
+	}
+	assertChange(t, cs, "SCHEDULER", "Ethernet0_SHAPER", ChangeDelete)
+	c = assertChange(t, cs, "PORT_QOS_MAP", "Ethernet0", ChangeReplace)
+	if c.Fields["scheduler"] != "" || c.Fields["dscp_to_tc_map"] != "[DSCP_TO_TC_MAP|GOLD]" {
+		t.Errorf("PORT_QOS_MAP after clear = %v, want the QoS maps alone", c.Fields)
+	}
+	if n.GetIntent("interface|Ethernet0|egress-shaper") != nil {
+		t.Error("egress-shaper intent should be deleted")
+	}
+}
+
+func TestClearEgressShaper_DeletesRowWithoutQoS(t *testing.T) {
+	_, iface := shaperSetup(t)
+	ctx := context.Background()
+
+	if _, err := iface.ClearEgressShaper(ctx); err == nil {
+		t.Error("ClearEgressShaper with no shaper set should fail")
+	}
+	if _, err := iface.SetEgressShaper(ctx, 1000000); err != nil {
+		t.Fatalf("SetEgressShaper: %v", err)
+	}
+	cs, err := iface.ClearEgressShaper(ctx)
+	if err != nil {
+		t.Fatalf("ClearEgressShaper: %v", err)
+	}
+	assertChange(t, cs, "PORT_QOS_MAP", "Ethernet0", ChangeDelete)
+	assertChange(t, cs, "SCHEDULER", "Ethernet0_SHAPER", ChangeDelete)
+}
+
+func TestGetEgressShaper(t *testing.T) {
+	_, iface := shaperSetup(t)
+	ctx := context.Background()
+
+	got, err := iface.GetEgressShaper(ctx)
+	if err != nil {
+		t.Fatalf("GetEgressShaper: %v", err)
+	}
+	if *got != (EgressShaper{Interface: "Ethernet0"}) {
+		t.Errorf("unshaped = %+v, want zero rates", got)
+	}
+	if _, err := iface.SetEgressShaper(ctx, 1000000); err != nil {
+		t.Fatalf("SetEgressShaper: %v", err)
+	}
+	got, err = iface.GetEgressShaper(ctx)
+	if err != nil {
+		t.Fatalf("GetEgressShaper: %v", err)
+	}
+	want := EgressShaper{Interface: "Ethernet0", RateKbps: 1000000, ConfiguredKbps: 1000000, Attached: true}
+	if *got != want {
+		t.Errorf("shaped = %+v, want %+v", *got, want)
+	}
+}
@@ -348,6 +348,18 @@ func paramStringSlice(p map[string]any, key string) []string {
 	return result
 }
 
+// paramList reads a list param that an intent stores as CSV but an authored
+// topology step may give as a list. nil when the key is absent or empty.
+func paramList(p map[string]any, key string) []string {
+	if l := paramStringSlice(p, key); l != nil {
+		return l
+	}
+	if csv := paramString(p, key); csv != "" {
+		return strings.Split(csv, ",")
+	}
+	return nil
+}
+
 // parseRouteReflectorOpts extracts RouteReflectorOpts from step params.
 func parseRouteReflectorOpts(p map[string]any) (RouteReflectorOpts, error) {
 	opts := RouteReflectorOpts{
@@ -119,6 +119,25 @@ func TestParamStringSlice(t *testing.T) {
 	}
 }
 
+func TestParamList(t *testing.T) {
+	p := map[string]any{
+		"list":  []any{"Ethernet0", "Ethernet4"},
+		"csv":   "Ethernet0,Ethernet4",
+		"empty": "",
+	}
+	for _, key := range []string{"list", "csv"} {
+		got := paramList(p, key)
+		if len(got) != 2 || got[0] != "Ethernet0" || got[1] != "Ethernet4" {
+			t.Errorf("paramList(%s) = %v", key, got)
+		}
+	}
+	for _, key := range []string{"empty", "missing"} {
+		if got := paramList(p, key); got != nil {
+			t.Errorf("paramList(%s) = %v, want nil", key, got)
+		}
+	}
+}
+
 // ============================================================================
 // ReplayStep Integration Tests (abstract Node)
 // ============================================================================
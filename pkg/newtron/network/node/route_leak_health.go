@@ -0,0 +1,159 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// ============================================================================
+// Route leak health — whether a configured leak actually imports. Pure
+// observation (§4).
+//
+// A leak is 'import vrf <src>' in the destination VRF's BGP instance. When it
+// does not import (the source VRF has no BGP table, the route-map filters
+// everything) nothing fails: CONFIG_DB is right and the destination VRF is
+// just missing routes. Only zebra knows which of the VRF's routes were leaked
+// — their next hops resolve in the source VRF — so the read is vtysh.
+// ============================================================================
+
+// RouteLeakHealth compares the leak into DstVRF with the routes that arrived.
+type RouteLeakHealth struct {
+	SrcVRF   string
+	DstVRF   string
+	Expected []string           // the leak's prefixes; empty when unfiltered
+	Leaked   []sonic.RouteEntry // installed routes in DstVRF imported from SrcVRF
+	Missing  []string           // Expected prefixes not among Leaked
+}
+
+// Healthy reports whether the leak imports anything, and every prefix it
+// is limited to.
+func (h *RouteLeakHealth) Healthy() bool {
+	return len(h.Leaked) > 0 && len(h.Missing) == 0
+}
+
+// GetLeakedRoutes reads dstVRF's routing table from zebra ("show ip route vrf
+// <dst> json") and returns the selected, installed routes leaked into it —
+// those whose next hops resolve in another VRF — sorted by prefix. Each
+// route's OriginVRF names the VRF it came from. IPv4 only, like route leaks.
+// Auto-connects transport if needed.
+func (n *Node) GetLeakedRoutes(ctx context.Context, dstVRF string) ([]sonic.RouteEntry, error) {
+	if dstVRF == "" {
+		return nil, fmt.Errorf("VRF name is required")
+	}
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return nil, fmt.Errorf("connecting transport: %w", err)
+		}
+	}
+	tunnel := n.conn.Tunnel()
+	if tunnel == nil {
+		return nil, fmt.Errorf("no SSH tunnel for vtysh on %s", n.name)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	output, err := tunnel.ExecCommandContext(ctx, fmt.Sprintf("sudo vtysh -c 'show ip route vrf %s json'", dstVRF))
+	if err != nil {
+		return nil, fmt.Errorf("vtysh show ip route vrf %s: %w", dstVRF, err)
+	}
+	return parseLeakedRoutes(output, dstVRF)
+}
+
+// GetRouteLeakHealth reads the routes leaked into dstVRF and checks them
+// against the leak configured there: something must arrive from its source
+// VRF, and every prefix the leak is limited to must be among them.
+func (n *Node) GetRouteLeakHealth(ctx context.Context, dstVRF string) (*RouteLeakHealth, error) {
+	var leak *RouteLeak
+	for _, l := range n.GetRouteLeaks() {
+		if l.DstVRF == dstVRF {
+			leak = &l
+			break
+		}
+	}
+	if leak == nil {
+		return nil, fmt.Errorf("no route leak into VRF %s", dstVRF)
+	}
+	routes, err := n.GetLeakedRoutes(ctx, dstVRF)
+	if err != nil {
+		return nil, err
+	}
+	return routeLeakHealth(*leak, routes), nil
+}
+
+// routeLeakHealth checks routes (read from the leak's destination VRF)
+// against leak.
+func routeLeakHealth(leak RouteLeak, routes []sonic.RouteEntry) *RouteLeakHealth {
+	h := &RouteLeakHealth{SrcVRF: leak.SrcVRF, DstVRF: leak.DstVRF, Expected: leak.Prefixes}
+	arrived := map[string]bool{}
+	for _, r := range routes {
+		if r.OriginVRF == leak.SrcVRF {
+			h.Leaked = append(h.Leaked, r)
+			arrived[r.Prefix] = true
+		}
+	}
+	for _, p := range leak.Prefixes {
+		if !arrived[p] {
+			h.Missing = append(h.Missing, p)
+		}
+	}
+	return h
+}
+
+// zebraRoute is the part of an FRR "show ip route json" entry the leak read
+// uses.
+type zebraRoute struct {
+	Prefix    string `json:"prefix"`
+	Protocol  string `json:"protocol"`
+	Selected  bool   `json:"selected"`
+	Installed bool   `json:"installed"`
+	Nexthops  []struct {
+		IP            string `json:"ip"`
+		InterfaceName string `json:"interfaceName"`
+		VRF           string `json:"vrf"`
+		Active        bool   `json:"active"`
+	} `json:"nexthops"`
+}
+
+// parseLeakedRoutes parses FRR's per-VRF route JSON — prefix → list of
+// routes, one per protocol — keeping each prefix's selected, installed route
+// when one of its active next hops is in a VRF other than dstVRF.
+func parseLeakedRoutes(output, dstVRF string) ([]sonic.RouteEntry, error) {
+	// Strip null bytes and any banner before the JSON, as checkBGP does.
+	cleaned := strings.ReplaceAll(output, "\x00", "")
+	if idx := strings.Index(cleaned, "{"); idx > 0 {
+		cleaned = cleaned[idx:]
+	}
+	var table map[string][]zebraRoute
+	if err := json.NewDecoder(strings.NewReader(cleaned)).Decode(&table); err != nil {
+		return nil, fmt.Errorf("parsing vtysh output: %w", err)
+	}
+
+	var leaked []sonic.RouteEntry
+	for prefix, candidates := range table {
+		for _, zr := range candidates {
+			if !zr.Selected || !zr.Installed {
+				continue
+			}
+			entry := sonic.RouteEntry{Prefix: prefix, VRF: dstVRF, Protocol: zr.Protocol, Source: sonic.RouteSourceFRR}
+			for _, nh := range zr.Nexthops {
+				if !nh.Active {
+					continue
+				}
+				if nh.VRF != "" && nh.VRF != dstVRF && entry.OriginVRF == "" {
+					entry.OriginVRF = nh.VRF
+				}
+				entry.NextHops = append(entry.NextHops, sonic.NextHop{IP: nh.IP, Interface: nh.InterfaceName})
+			}
+			if entry.OriginVRF != "" {
+				leaked = append(leaked, entry)
+			}
+		}
+	}
+	sort.Slice(leaked, func(i, j int) bool { return leaked[i].Prefix < leaked[j].Prefix })
+	return leaked, nil
+}
@@ -0,0 +1,69 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+func TestParseLeakedRoutes(t *testing.T) {
+	// Vrf_CUST's own connected route, a route leaked from Vrf_SHARED via BGP
+	// (next hop resolved in the source VRF), a leaked route that lost
+	// selection, and a route leaked from the default VRF.
+	const output = `{
+  "10.1.1.0/30": [{"prefix": "10.1.1.0/30", "protocol": "connected", "selected": true, "installed": true,
+    "nexthops": [{"directlyConnected": true, "interfaceName": "Ethernet0", "vrf": "Vrf_CUST", "active": true}]}],
+  "10.50.0.0/24": [{"prefix": "10.50.0.0/24", "protocol": "bgp", "selected": true, "installed": true,
+    "nexthops": [{"ip": "10.2.0.1", "interfaceName": "Ethernet8", "vrf": "Vrf_SHARED", "active": true},
+                 {"ip": "10.2.0.5", "interfaceName": "Ethernet12", "vrf": "Vrf_SHARED", "active": true}]}],
+  "10.51.0.0/24": [{"prefix": "10.51.0.0/24", "protocol": "bgp", "selected": false, "installed": false,
+    "nexthops": [{"ip": "10.2.0.1", "interfaceName": "Ethernet8", "vrf": "Vrf_SHARED", "active": true}]}],
+  "0.0.0.0/0": [{"prefix": "0.0.0.0/0", "protocol": "bgp", "selected": true, "installed": true,
+    "nexthops": [{"ip": "10.0.0.1", "interfaceName": "Ethernet4", "vrf": "default", "active": true}]}]
+}`
+	routes, err := parseLeakedRoutes("banner\n"+output, "Vrf_CUST")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []sonic.RouteEntry{
+		{Prefix: "0.0.0.0/0", VRF: "Vrf_CUST", Protocol: "bgp", Source: sonic.RouteSourceFRR, OriginVRF: "default",
+			NextHops: []sonic.NextHop{{IP: "10.0.0.1", Interface: "Ethernet4"}}},
+		{Prefix: "10.50.0.0/24", VRF: "Vrf_CUST", Protocol: "bgp", Source: sonic.RouteSourceFRR, OriginVRF: "Vrf_SHARED",
+			NextHops: []sonic.NextHop{{IP: "10.2.0.1", Interface: "Ethernet8"}, {IP: "10.2.0.5", Interface: "Ethernet12"}}},
+	}
+	if !reflect.DeepEqual(routes, want) {
+		t.Errorf("routes = %+v\nwant %+v", routes, want)
+	}
+}
+
+func TestRouteLeakHealth(t *testing.T) {
+	routes := []sonic.RouteEntry{
+		{Prefix: "0.0.0.0/0", OriginVRF: "default"},
+		{Prefix: "10.50.0.0/24", OriginVRF: "Vrf_SHARED"},
+	}
+	tests := []struct {
+		name        string
+		leak        RouteLeak
+		wantLeaked  int
+		wantMissing []string
+		wantHealthy bool
+	}{
+		{"filtered, all arrived",
+			RouteLeak{SrcVRF: "Vrf_SHARED", DstVRF: "Vrf_CUST", Prefixes: []string{"10.50.0.0/24"}}, 1, nil, true},
+		{"filtered, one missing",
+			RouteLeak{SrcVRF: "Vrf_SHARED", DstVRF: "Vrf_CUST", Prefixes: []string{"10.50.0.0/24", "10.51.0.0/24"}}, 1, []string{"10.51.0.0/24"}, false},
+		{"unfiltered, importing",
+			RouteLeak{SrcVRF: "Vrf_SHARED", DstVRF: "Vrf_CUST"}, 1, nil, true},
+		// Routes from another VRF do not count toward this leak.
+		{"unfiltered, nothing from the source",
+			RouteLeak{SrcVRF: "Vrf_OTHER", DstVRF: "Vrf_CUST"}, 0, nil, false},
+	}
+	for _, tt := range tests {
+		h := routeLeakHealth(tt.leak, routes)
+		if len(h.Leaked) != tt.wantLeaked || !reflect.DeepEqual(h.Missing, tt.wantMissing) || h.Healthy() != tt.wantHealthy {
+			t.Errorf("%s: leaked %d, missing %v, healthy %v; want %d, %v, %v",
+				tt.name, len(h.Leaked), h.Missing, h.Healthy(), tt.wantLeaked, tt.wantMissing, tt.wantHealthy)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// ============================================================================
+// Running config — a focused text view of one part of the device's config.
+// Pure observation (§4), complementing the full ExportConfigDB.
+//
+// A section is either an FRR section, read from vtysh's running config
+// (FRR-managed state is not all in CONFIG_DB), or a CONFIG_DB table rendered
+// as a config_db.json fragment.
+// ============================================================================
+
+// frrSections maps an FRR section name to the top-level stanzas it selects
+// (by line prefix). "frr" selects the whole FRR running config.
+var frrSections = map[string][]string{
+	"frr":         nil,
+	"bgp":         {"router bgp "},
+	"route-map":   {"route-map "},
+	"prefix-list": {"ip prefix-list ", "ipv6 prefix-list "},
+}
+
+// GetRunningConfigSection returns one section of the device's running
+// config as text:
+//
+//   - "frr": FRR's whole running config (vtysh show running-config)
+//   - "bgp", "route-map", "prefix-list": just those FRR stanzas
+//   - any other name: that CONFIG_DB table (case-insensitive, e.g. "port"),
+//     as a config_db.json fragment
+//   - "": the whole CONFIG_DB followed by the FRR running config
+//
+// FRR sections need the SSH tunnel. Auto-connects transport if needed.
+func (n *Node) GetRunningConfigSection(ctx context.Context, section string) (string, error) {
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return "", fmt.Errorf("connecting transport for running config: %w", err)
+		}
+	}
+	section = strings.ToLower(strings.TrimSpace(section))
+
+	if section == "" {
+		var buf bytes.Buffer
+		if err := n.conn.ExportConfigDB(ctx, &buf); err != nil {
+			return "", err
+		}
+		frr, err := n.frrRunningConfig(ctx)
+		if err != nil {
+			return "", err
+		}
+		return "! CONFIG_DB\n" + buf.String() + "! FRR\n" + frr, nil
+	}
+
+	if prefixes, ok := frrSections[section]; ok {
+		frr, err := n.frrRunningConfig(ctx)
+		if err != nil {
+			return "", err
+		}
+		if prefixes == nil {
+			return frr, nil
+		}
+		return frrStanzas(frr, prefixes), nil
+	}
+
+	table := strings.ToUpper(section)
+	rows, err := n.conn.Client().GetRawTable(table)
+	if err != nil {
+		return "", fmt.Errorf("reading CONFIG_DB %s: %w", table, err)
+	}
+	if len(rows) == 0 {
+		return "", fmt.Errorf("no section %q: CONFIG_DB has no %s table (FRR sections: frr, bgp, route-map, prefix-list)", section, table)
+	}
+	var buf bytes.Buffer
+	if err := sonic.EncodeConfigDBJSON(&buf, sonic.RawConfigDB{table: rows}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// frrRunningConfig reads FRR's running config over the SSH tunnel.
+func (n *Node) frrRunningConfig(ctx context.Context) (string, error) {
+	tunnel := n.conn.Tunnel()
+	if tunnel == nil {
+		return "", fmt.Errorf("no SSH tunnel for vtysh on %s", n.name)
+	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	output, err := tunnel.ExecCommandContext(ctx, "sudo vtysh -c 'show running-config'")
+	if err != nil {
+		return "", fmt.Errorf("vtysh show running-config: %w", err)
+	}
+	// CiscoVS/Silicon One vtysh occasionally emits \x00 (see checkBGPFromVtysh).
+	return strings.ReplaceAll(output, "\x00", ""), nil
+}
+
+// frrStanzas returns the top-level stanzas of an FRR running config whose
+// first line starts with one of prefixes. A stanza is an unindented line
+// plus the indented lines under it, through its closing "exit" (newer FRR)
+// or up to the next "!" or unindented line (older FRR). Multi-line stanzas
+// are followed by "!", as vtysh separates them.
+func frrStanzas(config string, prefixes []string) string {
+	var out strings.Builder
+	in, body := false, false
+	closeStanza := func() {
+		if in && body {
+			out.WriteString("!\n")
+		}
+		in, body = false, false
+	}
+	for _, line := range strings.Split(config, "\n") {
+		line = strings.TrimRight(line, " \r")
+		if strings.HasPrefix(line, " ") {
+			if in {
+				out.WriteString(line + "\n")
+				body = true
+			}
+			continue
+		}
+		if in && line == "exit" {
+			out.WriteString("exit\n")
+			body = true
+			closeStanza()
+			continue
+		}
+		closeStanza()
+		for _, p := range prefixes {
+			if strings.HasPrefix(line, p) {
+				out.WriteString(line + "\n")
+				in = true
+				break
+			}
+		}
+	}
+	closeStanza()
+	return out.String()
+}
@@ -0,0 +1,77 @@
+package node
+
+import "testing"
+
+func TestFRRStanzas(t *testing.T) {
+	// Newer FRR closes each stanza with "exit"; the route-map stanza here
+	// is in the older "!"-terminated form.
+	config := `Building configuration...
+
+Current configuration:
+!
+frr version 8.5.4
+hostname leaf1
+!
+ip prefix-list LOOPBACKS seq 5 permit 10.255.0.0/24 le 32
+ip prefix-list LOOPBACKS seq 10 deny any
+!
+router bgp 65001
+ bgp router-id 10.255.0.1
+ neighbor 10.1.0.0 remote-as 65000
+ !
+ address-family ipv4 unicast
+  network 10.255.0.1/32
+ exit-address-family
+exit
+!
+router bgp 65001 vrf Vrf_red
+ address-family l2vpn evpn
+  advertise ipv4 unicast
+ exit-address-family
+exit
+!
+route-map RM_SET_SRC permit 10
+ set src 10.255.0.1
+!
+line vty
+!
+end
+`
+	tests := []struct {
+		name     string
+		prefixes []string
+		want     string
+	}{
+		{"bgp", frrSections["bgp"], `router bgp 65001
+ bgp router-id 10.255.0.1
+ neighbor 10.1.0.0 remote-as 65000
+ !
+ address-family ipv4 unicast
+  network 10.255.0.1/32
+ exit-address-family
+exit
+!
+router bgp 65001 vrf Vrf_red
+ address-family l2vpn evpn
+  advertise ipv4 unicast
+ exit-address-family
+exit
+!
+`},
+		{"route-map", frrSections["route-map"], `route-map RM_SET_SRC permit 10
+ set src 10.255.0.1
+!
+`},
+		{"prefix-list", frrSections["prefix-list"], `ip prefix-list LOOPBACKS seq 5 permit 10.255.0.0/24 le 32
+ip prefix-list LOOPBACKS seq 10 deny any
+`},
+		{"absent", []string{"router ospf"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := frrStanzas(config, tt.prefixes); got != tt.want {
+				t.Errorf("frrStanzas:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package node
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// newBatchTestNode returns a node with three ports and a routed service that
+// owns a shared VRF and an ingress filter — the two resources its bindings
+// share.
+func newBatchTestNode() *Node {
+	n := newTestAbstract()
+	n.RegisterPort("Ethernet8", map[string]string{"admin_status": "up", "mtu": "9100"})
+	sp := n.SpecProvider.(*testSpecProvider)
+	sp.filterSpecs["mgmt-in"] = &spec.FilterSpec{
+		Type:  "ipv4",
+		Rules: []*spec.FilterRule{{Sequence: 10, SrcIP: "10.0.0.0/8", Action: "permit"}},
+	}
+	sp.services["ACCESS"] = &spec.ServiceSpec{
+		ServiceType:   spec.ServiceTypeRouted,
+		VRFType:       spec.VRFTypeShared,
+		IngressFilter: "mgmt-in",
+	}
+	return n
+}
+
+func TestApplyServiceBatch_SharedResourcesOnce(t *testing.T) {
+	n := newBatchTestNode()
+	cs, err := n.ApplyServiceBatch(context.Background(), []ServiceBinding{
+		{Interface: "Ethernet0", Service: "ACCESS", Opts: ApplyServiceOpts{IPAddress: "10.1.0.0/31"}},
+		{Interface: "Ethernet4", Service: "ACCESS", Opts: ApplyServiceOpts{IPAddress: "10.1.0.2/31"}},
+		{Interface: "Ethernet8", Service: "ACCESS", Opts: ApplyServiceOpts{IPAddress: "10.1.0.4/31"}},
+	})
+	if err != nil {
+		t.Fatalf("ApplyServiceBatch: %v", err)
+	}
+
+	var acls, vrfs []Change
+	for _, c := range cs.Changes {
+		switch c.Table {
+		case "ACL_TABLE":
+			acls = append(acls, c)
+		case "VRF":
+			vrfs = append(vrfs, c)
+		}
+	}
+	if len(acls) != 1 {
+		t.Fatalf("ACL_TABLE changes = %d, want 1: %+v", len(acls), acls)
+	}
+	if acls[0].Type != ChangeAdd {
+		t.Errorf("ACL_TABLE change type = %s, want add", acls[0].Type)
+	}
+	ports := strings.Split(acls[0].Fields["ports"], ",")
+	for _, want := range []string{"Ethernet0", "Ethernet4", "Ethernet8"} {
+		if !slices.Contains(ports, want) {
+			t.Errorf("ACL_TABLE ports = %v, missing %s", ports, want)
+		}
+	}
+	if len(vrfs) != 1 || vrfs[0].Key != "Vrf_ACCESS" {
+		t.Errorf("VRF changes = %+v, want one create of Vrf_ACCESS", vrfs)
+	}
+	for _, intf := range []string{"Ethernet0", "Ethernet4", "Ethernet8"} {
+		if n.GetIntent("interface|"+intf) == nil {
+			t.Errorf("no service intent for %s", intf)
+		}
+	}
+}
+
+// TestApplyServiceBatch_MatchesSequentialApply: the batch leaves the
+// projection exactly as the same applies made one by one.
+func TestApplyServiceBatch_MatchesSequentialApply(t *testing.T) {
+	ctx := context.Background()
+	bindings := []ServiceBinding{
+		{Interface: "Ethernet0", Service: "ACCESS", Opts: ApplyServiceOpts{IPAddress: "10.1.0.0/31"}},
+		{Interface: "Ethernet4", Service: "ACCESS", Opts: ApplyServiceOpts{IPAddress: "10.1.0.2/31"}},
+	}
+
+	seq := newBatchTestNode()
+	for _, b := range bindings {
+		iface, err := seq.GetInterface(b.Interface)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := iface.ApplyService(ctx, b.Service, b.Opts); err != nil {
+			t.Fatalf("ApplyService %s: %v", b.Interface, err)
+		}
+	}
+	batch := newBatchTestNode()
+	if _, err := batch.ApplyServiceBatch(ctx, bindings); err != nil {
+		t.Fatalf("ApplyServiceBatch: %v", err)
+	}
+
+	want, got := seq.Projection(), batch.Projection()
+	for table, rows := range want {
+		for key, fields := range rows {
+			if g, ok := got[table][key]; !ok || !maps.Equal(g, fields) {
+				t.Errorf("%s|%s = %v, want %v", table, key, got[table][key], fields)
+			}
+		}
+	}
+}
+
+func TestApplyServiceBatch_FailureNamesInterface(t *testing.T) {
+	n := newBatchTestNode()
+	_, err := n.ApplyServiceBatch(context.Background(), []ServiceBinding{
+		{Interface: "Ethernet0", Service: "ACCESS", Opts: ApplyServiceOpts{IPAddress: "10.1.0.0/31"}},
+		{Interface: "Ethernet4", Service: "NO_SUCH_SERVICE"},
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "Ethernet4: ") {
+		t.Fatalf("err = %v, want one naming Ethernet4", err)
+	}
+
+	_, err = newBatchTestNode().ApplyServiceBatch(context.Background(), []ServiceBinding{
+		{Interface: "Ethernet0", Service: "ACCESS"},
+		{Interface: "Ethernet0", Service: "ACCESS"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "appears twice") {
+		t.Fatalf("err = %v, want duplicate interface rejected", err)
+	}
+}
+
+// TestFoldUpdates: a later modify folds into the open add or modify of the
+// same key, but never across a delete.
+func TestFoldUpdates(t *testing.T) {
+	cs := &ChangeSet{Changes: []Change{
+		{Table: "ACL_TABLE", Key: "A", Type: ChangeAdd, Fields: map[string]string{"ports": "Ethernet0"}},
+		{Table: "VRF", Key: "Vrf_X", Type: ChangeAdd},
+		{Table: "ACL_TABLE", Key: "A", Type: ChangeModify, Fields: map[string]string{"ports": "Ethernet0,Ethernet4"}},
+		{Table: "ACL_TABLE", Key: "B", Type: ChangeDelete},
+		{Table: "ACL_TABLE", Key: "B", Type: ChangeModify, Fields: map[string]string{"ports": "Ethernet8"}},
+	}}
+	cs.foldUpdates()
+
+	if len(cs.Changes) != 4 {
+		t.Fatalf("changes = %d, want 4: %+v", len(cs.Changes), cs.Changes)
+	}
+	if c := cs.Changes[0]; c.Type != ChangeAdd || c.Fields["ports"] != "Ethernet0,Ethernet4" {
+		t.Errorf("folded change = %+v, want add with both ports", c)
+	}
+	if c := cs.Changes[3]; c.Key != "B" || c.Type != ChangeModify {
+		t.Errorf("last change = %+v, want the modify after the delete kept", c)
+	}
+}
@@ -0,0 +1,76 @@
+package node
+
+import (
+	"sort"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// ============================================================================
+// Service bindings — what is applied where
+// ============================================================================
+//
+// An interface's service binding is its apply-service sub-resource record
+// (interface|<name>|service); there is no separate binding table. These
+// readers turn those records into typed values so callers stop re-scanning
+// NEWTRON_INTENT for "apply-service" and picking params by string key.
+
+// ServiceBindingInfo is the service applied to one interface, as its binding
+// record states it.
+type ServiceBindingInfo struct {
+	Interface    string
+	Service      string
+	IPAddress    string   // routed/IRB services; the primary address
+	SecondaryIPs []string // routed services only
+	VRF          string
+	IPVPN        string
+	MACVPN       string
+	IngressACL   string
+	EgressACL    string
+	BGPNeighbor  string
+	QoSPolicy    string
+	VLAN         int // local and overlay bridged/IRB services
+}
+
+// GetServiceBindings returns every actuated service binding on the node,
+// sorted by interface name.
+func (n *Node) GetServiceBindings() []ServiceBindingInfo {
+	var out []ServiceBindingInfo
+	for resource, intent := range n.ServiceIntents() {
+		out = append(out, serviceBindingFromIntent(resourceInterfaceName(resource), intent))
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].Interface < out[b].Interface })
+	return out
+}
+
+// GetServiceBinding returns the service binding on iface, or nil when no
+// service is applied to it.
+func (n *Node) GetServiceBinding(iface string) *ServiceBindingInfo {
+	iface = n.normalizeInterfaceName(iface)
+	intent := n.GetIntent(bindingKey(iface))
+	if intent == nil || !intent.IsService() || !intent.IsActuated() {
+		return nil
+	}
+	b := serviceBindingFromIntent(iface, intent)
+	return &b
+}
+
+// serviceBindingFromIntent reads a binding record's params into a
+// ServiceBindingInfo.
+func serviceBindingFromIntent(iface string, intent *sonic.Intent) ServiceBindingInfo {
+	p := intent.Params
+	return ServiceBindingInfo{
+		Interface:    iface,
+		Service:      p[sonic.FieldServiceName],
+		IPAddress:    p[sonic.FieldIPAddress],
+		SecondaryIPs: splitSecondaryIPs(p[sonic.FieldSecondaryIPs]),
+		VRF:          p[sonic.FieldVRFName],
+		IPVPN:        p[sonic.FieldIPVPN],
+		MACVPN:       p[sonic.FieldMACVPN],
+		IngressACL:   p["ingress_acl"],
+		EgressACL:    p["egress_acl"],
+		BGPNeighbor:  p["bgp_neighbor"],
+		QoSPolicy:    p["qos_policy"],
+		VLAN:         bindingInt(p[sonic.FieldVLANID]),
+	}
+}
@@ -0,0 +1,54 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetServiceBindings(t *testing.T) {
+	n := testDevice()
+	for resource, fields := range map[string]map[string]string{
+		"interface|Ethernet4|service": {
+			"operation": "apply-service", "state": "actuated", "service_name": "TRANSIT",
+			"ip_address": "10.1.1.0/31", "secondary_ips": "10.2.2.0/31,10.3.3.0/31",
+			"vrf_name": "Vrf_TRANSIT", "ipvpn": "CUST", "bgp_neighbor": "10.1.1.1",
+			"ingress_acl": "TRANSIT_IN", "qos_policy": "GOLD",
+		},
+		"interface|Ethernet0|service": {
+			"operation": "apply-service", "state": "actuated", "service_name": "SERVERS",
+			"macvpn": "SERVERS", "vlan_id": "200", "egress_acl": "SERVERS_OUT",
+		},
+		// Not bindings: the interface's identity record, a binding still in
+		// flight, and a service-scoped record.
+		"interface|Ethernet0":         {"operation": "interface-init", "state": "actuated"},
+		"interface|Ethernet8|service": {"operation": "apply-service", "state": "in-flight", "service_name": "TRANSIT"},
+		"service|TRANSIT":             {"operation": "deploy-service", "state": "actuated", "service_name": "TRANSIT"},
+	} {
+		n.configDB.NewtronIntent[resource] = fields
+	}
+
+	want := []ServiceBindingInfo{
+		{Interface: "Ethernet0", Service: "SERVERS", MACVPN: "SERVERS", VLAN: 200, EgressACL: "SERVERS_OUT"},
+		{
+			Interface: "Ethernet4", Service: "TRANSIT",
+			IPAddress: "10.1.1.0/31", SecondaryIPs: []string{"10.2.2.0/31", "10.3.3.0/31"},
+			VRF: "Vrf_TRANSIT", IPVPN: "CUST", BGPNeighbor: "10.1.1.1",
+			IngressACL: "TRANSIT_IN", QoSPolicy: "GOLD",
+		},
+	}
+	if got := n.GetServiceBindings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetServiceBindings() =\n  %+v\nwant\n  %+v", got, want)
+	}
+
+	if got := n.GetServiceBinding("Ethernet4"); got == nil || !reflect.DeepEqual(*got, want[1]) {
+		t.Errorf("GetServiceBinding(Ethernet4) = %+v, want %+v", got, want[1])
+	}
+	for _, iface := range []string{"Ethernet8", "Ethernet12"} {
+		if got := n.GetServiceBinding(iface); got != nil {
+			t.Errorf("GetServiceBinding(%s) = %+v, want nil", iface, got)
+		}
+	}
+	if !n.BindsService("SERVERS") || n.BindsService("GOLD") {
+		t.Error("BindsService disagrees with the bindings")
+	}
+}
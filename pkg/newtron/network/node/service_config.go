@@ -145,6 +145,16 @@ func createInlineRoutePolicyConfig(serviceName, direction, community string, pre
 	return buildRouteMapConfig(baseRMName, leafEntries, rules)
 }
 
+// createRouteLeakPolicyConfig builds the import route-map that limits a route
+// leak from srcVRF into dstVRF to the given prefixes (exact-match PREFIX_SET
+// entries). Names are content-hashed like every other route policy
+// (Principle 35). Returns no entries and an empty name when prefixes is empty
+// — the leak then imports everything.
+func createRouteLeakPolicyConfig(srcVRF, dstVRF string, prefixes []string) ([]sonic.Entry, string) {
+	base := fmt.Sprintf("LEAK_%s_TO_%s", strings.ToUpper(srcVRF), strings.ToUpper(dstVRF))
+	return createInlineRoutePolicyConfig(base, "import", "", prefixes)
+}
+
 // buildRouteMapConfig computes the Merkle hash over rules and builds the final
 // route-map entries. Shared by createRoutePolicyConfig and createInlineRoutePolicyConfig.
 func buildRouteMapConfig(baseRMName string, leafEntries []sonic.Entry, rules []routeMapRule) ([]sonic.Entry, string) {
@@ -0,0 +1,133 @@
+package node
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// ParamDefault records one apply-service parameter that was taken from the
+// network's service_defaults because the caller left it out.
+type ParamDefault struct {
+	Interface string `json:"interface"`
+	Param     string `json:"param"`
+	Value     string `json:"value"`
+}
+
+// withServiceDefaults fills the apply-service parameters the caller omitted
+// from the network's service_defaults for the service, and returns the
+// completed opts with the values it filled. A default is used only where the
+// service would otherwise need the caller to supply the value: peer_as for
+// routing.peer_as="request", vlan for a local irb/bridged service, and an
+// address for a routed service or a local irb gateway.
+func (i *Interface) withServiceDefaults(serviceName string, svc *spec.ServiceSpec, opts ApplyServiceOpts) (ApplyServiceOpts, []ParamDefault, error) {
+	d := i.node.GetServiceDefaults(serviceName)
+	if d == nil {
+		return opts, nil, nil
+	}
+	var used []ParamDefault
+	use := func(param, value string) {
+		used = append(used, ParamDefault{Interface: i.name, Param: param, Value: value})
+	}
+
+	if d.PeerAS != 0 && opts.PeerAS == 0 && opts.Params["peer_as"] == "" &&
+		svc.Routing != nil && svc.Routing.PeerAS == spec.PeerASRequest {
+		opts.PeerAS = d.PeerAS
+		use("peer_as", strconv.Itoa(d.PeerAS))
+	}
+
+	local := svc.MACVPN == ""
+	if d.VLAN != 0 && opts.VLAN == 0 && local &&
+		(svc.ServiceType == spec.ServiceTypeIRB || svc.ServiceType == spec.ServiceTypeBridged) {
+		opts.VLAN = d.VLAN
+		use("vlan", strconv.Itoa(d.VLAN))
+	}
+
+	takesIP := svc.ServiceType == spec.ServiceTypeRouted || svc.ServiceType == spec.ServiceTypeEVPNRouted ||
+		(svc.ServiceType == spec.ServiceTypeIRB && local)
+	if d.IPPool != "" && opts.IPAddress == "" && takesIP {
+		ip, err := i.node.allocateFromPool(d.IPPool, d.IPPrefixLength)
+		if err != nil {
+			return opts, nil, fmt.Errorf("service '%s': default ip_pool: %w", serviceName, err)
+		}
+		opts.IPAddress = ip
+		use("ip_address", ip)
+	}
+	return opts, used, nil
+}
+
+// allocateFromPool returns the interface address of the first prefixLen block
+// (default 31) in pool that overlaps no address configured on this device —
+// any INTERFACE, PORTCHANNEL_INTERFACE, VLAN_INTERFACE or LOOPBACK_INTERFACE
+// address in the projection. The address is the lower one of a /31 and the
+// first host of a shorter prefix, so DeriveNeighborIP finds the peer.
+func (n *Node) allocateFromPool(pool string, prefixLen int) (string, error) {
+	_, poolNet, err := net.ParseCIDR(pool)
+	if err != nil || poolNet.IP.To4() == nil {
+		return "", fmt.Errorf("invalid pool %q (expected IPv4 CIDR)", pool)
+	}
+	if prefixLen == 0 {
+		prefixLen = 31
+	}
+	poolLen, _ := poolNet.Mask.Size()
+	if prefixLen < poolLen || prefixLen > 31 {
+		return "", fmt.Errorf("prefix length /%d does not fit pool %s", prefixLen, pool)
+	}
+
+	var used []*net.IPNet
+	addKey := func(key string) {
+		if _, addr, ok := strings.Cut(key, "|"); ok {
+			if _, ipNet, err := net.ParseCIDR(addr); err == nil {
+				used = append(used, ipNet)
+			}
+		}
+	}
+	if n.configDB != nil {
+		for key := range n.configDB.Interface {
+			addKey(key)
+		}
+		for _, table := range []map[string]map[string]string{
+			n.configDB.PortChannelInterface, n.configDB.VLANInterface, n.configDB.LoopbackInterface,
+		} {
+			for key := range table {
+				addKey(key)
+			}
+		}
+	}
+
+	base := binary.BigEndian.Uint32(poolNet.IP.To4())
+	size := uint32(1) << (32 - prefixLen)
+	blocks := uint64(1) << (prefixLen - poolLen)
+	for k := uint64(0); k < blocks; k++ {
+		start := base + uint32(k)*size
+		block := &net.IPNet{IP: uint32IP(start), Mask: net.CIDRMask(prefixLen, 32)}
+		if overlapsAny(block, used) {
+			continue
+		}
+		host := start
+		if prefixLen < 31 {
+			host++
+		}
+		return fmt.Sprintf("%s/%d", uint32IP(host), prefixLen), nil
+	}
+	return "", fmt.Errorf("pool %s has no free /%d on %s", pool, prefixLen, n.Name())
+}
+
+func uint32IP(v uint32) net.IP {
+	ip := make(net.IP, 4)
+	binary.BigEndian.PutUint32(ip, v)
+	return ip
+}
+
+func overlapsAny(block *net.IPNet, nets []*net.IPNet) bool {
+	for _, other := range nets {
+		if block.Contains(other.IP) || other.Contains(block.IP) {
+			return true
+		}
+	}
+	return false
+}
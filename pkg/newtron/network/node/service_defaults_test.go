@@ -0,0 +1,103 @@
+package node
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// TestApplyService_ServiceDefaults binds a peer_as="request" routed service
+// with neither --ip nor --peer-as: both come from the network's
+// service_defaults, land in the binding like caller values, and are reported
+// on the ChangeSet. A value the caller does pass is never overridden.
+func TestApplyService_ServiceDefaults(t *testing.T) {
+	ctx := context.Background()
+	setup := func() (*Node, *Interface) {
+		n, intf := testInterface()
+		sp := n.SpecProvider.(*testSpecProvider)
+		sp.services["TRANSIT"] = &spec.ServiceSpec{
+			ServiceType: spec.ServiceTypeRouted,
+			Routing:     &spec.RoutingSpec{Protocol: spec.RoutingProtocolBGP, PeerAS: spec.PeerASRequest},
+		}
+		sp.defaults = map[string]*spec.ServiceDefaults{
+			"TRANSIT": {PeerAS: 65002, IPPool: "10.2.0.0/29"},
+		}
+		// Ethernet4 already holds the pool's first /31.
+		n.configDB.Interface["Ethernet4|10.2.0.0/31"] = sonic.InterfaceEntry{}
+		return n, intf
+	}
+
+	t.Run("omitted values come from the defaults", func(t *testing.T) {
+		n, intf := setup()
+		cs, err := intf.ApplyService(ctx, "TRANSIT", ApplyServiceOpts{})
+		if err != nil {
+			t.Fatalf("ApplyService: %v", err)
+		}
+		want := []ParamDefault{
+			{Interface: "Ethernet0", Param: "peer_as", Value: "65002"},
+			{Interface: "Ethernet0", Param: "ip_address", Value: "10.2.0.2/31"},
+		}
+		if !reflect.DeepEqual(cs.Defaults, want) {
+			t.Errorf("Defaults = %+v, want %+v", cs.Defaults, want)
+		}
+		b := n.GetIntent(bindingKey("Ethernet0"))
+		if b == nil || b.Params["ip_address"] != "10.2.0.2/31" || b.Params["bgp_peer_as"] != "65002" {
+			t.Errorf("binding = %+v, want the defaulted ip_address and peer AS recorded", b)
+		}
+	})
+
+	t.Run("caller values win", func(t *testing.T) {
+		_, intf := setup()
+		cs, err := intf.ApplyService(ctx, "TRANSIT", ApplyServiceOpts{IPAddress: "10.9.0.0/31", PeerAS: 65010})
+		if err != nil {
+			t.Fatalf("ApplyService: %v", err)
+		}
+		if len(cs.Defaults) != 0 {
+			t.Errorf("Defaults = %+v, want none when the caller supplies every value", cs.Defaults)
+		}
+	})
+
+	t.Run("no defaults still requires the address", func(t *testing.T) {
+		n, intf := setup()
+		n.SpecProvider.(*testSpecProvider).defaults = nil
+		_, err := intf.ApplyService(ctx, "TRANSIT", ApplyServiceOpts{})
+		if err == nil || !strings.Contains(err.Error(), "service_defaults") {
+			t.Fatalf("want an IP-required refusal naming service_defaults, got %v", err)
+		}
+	})
+}
+
+func TestAllocateFromPool(t *testing.T) {
+	n := testDevice()
+	n.configDB.Interface["Ethernet0|10.2.0.0/31"] = sonic.InterfaceEntry{}
+	n.configDB.LoopbackInterface["Loopback0|10.2.0.4/32"] = map[string]string{}
+
+	tests := []struct {
+		pool      string
+		prefixLen int
+		want      string
+		wantErr   string
+	}{
+		{"10.2.0.0/29", 0, "10.2.0.2/31", ""},
+		{"10.2.0.0/29", 30, "", "no free /30"},
+		{"10.2.0.0/28", 30, "10.2.0.9/30", ""},
+		{"10.2.0.0/31", 31, "", "no free /31"},
+		{"10.2.0.0/29", 24, "", "does not fit"},
+	}
+	for _, tt := range tests {
+		got, err := n.allocateFromPool(tt.pool, tt.prefixLen)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("allocateFromPool(%s, %d) = %q, %v; want error %q", tt.pool, tt.prefixLen, got, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("allocateFromPool(%s, %d) = %q, %v; want %q", tt.pool, tt.prefixLen, got, err, tt.want)
+		}
+	}
+}
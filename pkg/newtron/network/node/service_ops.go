@@ -3,6 +3,8 @@ package node
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -14,7 +16,7 @@ import (
 // InterfaceHasService checks if an interface has a service bound.
 // Accepts both short (Eth0) and full (Ethernet0) interface names.
 func (n *Node) InterfaceHasService(name string) bool {
-	name = util.NormalizeInterfaceName(name)
+	name = n.normalizeInterfaceName(name)
 	if intf, ok := n.interfaces[name]; ok {
 		return intf.HasService()
 	}
@@ -27,10 +29,41 @@ func (n *Node) InterfaceHasService(name string) bool {
 
 // ApplyServiceOpts contains options for applying a service to an interface.
 type ApplyServiceOpts struct {
-	IPAddress string            // IP address for routed/IRB services (e.g., "10.1.1.1/30"); for a local irb, the SVI gateway the composite authors
-	VLAN      int               // VLAN ID for local types (irb, bridged) — overlay types use macvpnDef.VlanID
-	PeerAS    int               // BGP peer AS number (for services with routing.peer_as="request")
-	Params    map[string]string // topology params (peer_as, route_reflector_client, next_hop_self)
+	IPAddress    string            // IP address for routed/IRB services (e.g., "10.1.1.1/30"); for a local irb, the SVI gateway the composite authors
+	SecondaryIPs []string          // additional addresses on a routed port, each its own INTERFACE|<iface>|<ip> entry
+	VLAN         int               // VLAN ID for local types (irb, bridged) — overlay types use macvpnDef.VlanID
+	PeerAS       int               // BGP peer AS number (for services with routing.peer_as="request")
+	Params       map[string]string // topology params (peer_as, route_reflector_client, next_hop_self)
+}
+
+// validateSecondaryIPs checks the secondary addresses of a routed apply:
+// only a routed port carries them, each must be a CIDR, and none may
+// overlap the primary or another secondary.
+func validateSecondaryIPs(serviceName, serviceType string, opts ApplyServiceOpts) error {
+	if serviceType != spec.ServiceTypeRouted && serviceType != spec.ServiceTypeEVPNRouted {
+		return fmt.Errorf("service '%s' (%s) does not put an IP on the interface — secondary IPs need a routed or evpn-routed service", serviceName, serviceType)
+	}
+	_, primary, _ := net.ParseCIDR(opts.IPAddress)
+	seen := []*net.IPNet{primary}
+	for _, ip := range opts.SecondaryIPs {
+		_, ipNet, err := net.ParseCIDR(ip)
+		if err != nil || !util.IsValidIPCIDR(ip) {
+			return fmt.Errorf("invalid secondary IP address: %s (expected CIDR notation like 10.1.2.1/24)", ip)
+		}
+		if overlapsAny(ipNet, seen) {
+			return fmt.Errorf("secondary IP %s overlaps the primary %s or another secondary", ip, opts.IPAddress)
+		}
+		seen = append(seen, ipNet)
+	}
+	return nil
+}
+
+// splitSecondaryIPs reads the comma-joined secondary_ips binding field.
+func splitSecondaryIPs(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, ",")
 }
 
 // bindingInt parses a string field from a service binding record as int (0 if absent/invalid).
@@ -42,18 +75,20 @@ func bindingInt(s string) int {
 // irbGateway resolves the SVI gateway address the composite authors for an irb
 // service. The anycast IP/MAC are a subnet property — identical on every leaf,
 // authored once in the network-scoped macvpn (evpn-irb, §7) — so they come from
-// the macvpn spec. A local irb (no macvpn) takes its per-device gateway IP from
-// the apply-time opts and has no anycast MAC. Either may be empty (e.g. when the
-// operator pre-authored the SVI via configure-irb).
-func irbGateway(opts ApplyServiceOpts, macvpnDef *spec.MACVPNSpec) (ip, mac string) {
-	ip = opts.IPAddress
+// the macvpn spec, along with whether the MAC is per-VLAN. A local irb (no
+// macvpn) takes its per-device gateway IP from the apply-time opts and has no
+// anycast MAC. Either may be empty (e.g. when the operator pre-authored the SVI
+// via configure-irb). The caller fills in the VRF.
+func irbGateway(opts ApplyServiceOpts, macvpnDef *spec.MACVPNSpec) IRBConfig {
+	gw := IRBConfig{IPAddress: opts.IPAddress}
 	if macvpnDef != nil {
 		if macvpnDef.AnycastIP != "" {
-			ip = macvpnDef.AnycastIP
+			gw.IPAddress = macvpnDef.AnycastIP
 		}
-		mac = macvpnDef.AnycastMAC
+		gw.AnycastMAC = macvpnDef.AnycastMAC
+		gw.AnycastMACPerVLAN = macvpnDef.AnycastMACPerVLAN
 	}
-	return ip, mac
+	return gw
 }
 
 // createBridgeDomain creates the L2 bridge domain a bridge-domain service
@@ -194,6 +229,13 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 		return nil, fmt.Errorf("interface %s already has service '%s' - remove it first", i.name, i.ServiceName())
 	}
 
+	// Fill what the caller left out from the network's service_defaults; the
+	// filled values are recorded in the binding like caller-supplied ones.
+	opts, defaulted, err := i.withServiceDefaults(serviceName, svc, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Resolve VPN definitions from service references
 	var ipvpnDef *spec.IPVPNSpec
 	var macvpnDef *spec.MACVPNSpec
@@ -237,30 +279,36 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 				serviceName)
 		}
 		if opts.IPAddress == "" {
-			return nil, fmt.Errorf("service '%s' (evpn-routed) requires an IP address — use --ip flag", serviceName)
+			return nil, fmt.Errorf("service '%s' (evpn-routed) requires an IP address — use --ip flag or set ip_pool in the network's service_defaults", serviceName)
 		}
-		if !util.IsValidIPv4CIDR(opts.IPAddress) {
-			return nil, fmt.Errorf("invalid IP address: %s (expected CIDR notation like 10.1.1.1/30)", opts.IPAddress)
+		if !util.IsValidIPCIDR(opts.IPAddress) {
+			return nil, fmt.Errorf("invalid IP address: %s (expected CIDR notation like 10.1.1.1/30 or 2001:db8::1/126)", opts.IPAddress)
 		}
 	case spec.ServiceTypeRouted:
 		if opts.IPAddress == "" {
-			return nil, fmt.Errorf("service '%s' (routed) requires an IP address — use --ip flag", serviceName)
+			return nil, fmt.Errorf("service '%s' (routed) requires an IP address — use --ip flag or set ip_pool in the network's service_defaults", serviceName)
 		}
-		if !util.IsValidIPv4CIDR(opts.IPAddress) {
-			return nil, fmt.Errorf("invalid IP address: %s (expected CIDR notation like 10.1.1.1/30)", opts.IPAddress)
+		if !util.IsValidIPCIDR(opts.IPAddress) {
+			return nil, fmt.Errorf("invalid IP address: %s (expected CIDR notation like 10.1.1.1/30 or 2001:db8::1/126)", opts.IPAddress)
 		}
 	case spec.ServiceTypeIRB:
 		if opts.VLAN == 0 && macvpnDef == nil {
-			return nil, fmt.Errorf("service '%s' (irb) requires a VLAN — use --vlan flag or add a macvpn reference to the service definition",
+			return nil, fmt.Errorf("service '%s' (irb) requires a VLAN — use --vlan flag, set vlan in the network's service_defaults, or add a macvpn reference to the service definition",
 				serviceName)
 		}
 	case spec.ServiceTypeBridged:
 		if opts.VLAN == 0 && macvpnDef == nil {
-			return nil, fmt.Errorf("service '%s' (bridged) requires a VLAN — use --vlan flag or add a macvpn reference to the service definition",
+			return nil, fmt.Errorf("service '%s' (bridged) requires a VLAN — use --vlan flag, set vlan in the network's service_defaults, or add a macvpn reference to the service definition",
 				serviceName)
 		}
 	}
 
+	if len(opts.SecondaryIPs) > 0 {
+		if err := validateSecondaryIPs(serviceName, svc.ServiceType, opts); err != nil {
+			return nil, err
+		}
+	}
+
 	// Routing is L3-only. bridged / evpn-bridged are pure L2 — no routed
 	// interface to source a BGP session from, no routing table to install
 	// routes into — so a routing block is meaningless and would generate
@@ -273,6 +321,17 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 			serviceName, svc.ServiceType)
 	}
 
+	// An IPv6 address puts the service's BGP session in the ipv6_unicast
+	// address family. Route policies, prefix lists, communities and
+	// redistribution are delivered on ipv4_unicast (the peer group's
+	// BGP_PEER_GROUP_AF, BGP_GLOBALS_AF), where they would not touch an IPv6
+	// session — refuse them rather than deliver a policy that does nothing.
+	if svc.Routing != nil && svc.Routing.Protocol == spec.RoutingProtocolBGP &&
+		util.IsValidIPv6CIDR(opts.IPAddress) && routingHasPolicy(svc.Routing) {
+		return nil, fmt.Errorf("service '%s' routing policy (import/export policy, prefix list, community, redistribute) applies to IPv4 unicast only — it cannot be used with IPv6 address %s",
+			serviceName, opts.IPAddress)
+	}
+
 	// EVPN preconditions. Check the actual VTEP in the projection (HasVTEP), not a
 	// stored source_ip param: the VTEP source is re-derived from the node's
 	// loopback at replay and so is correctly NOT persisted on the device intent
@@ -417,9 +476,17 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 		}
 		irb := n.GetIntent("interface|" + i.name)
 		irbAuthored := irb != nil && irb.Operation == sonic.OpConfigureIRB
-		if gwIP, _ := irbGateway(opts, macvpnDef); gwIP == "" && !irbAuthored {
+		gw := irbGateway(opts, macvpnDef)
+		if gw.IPAddress == "" && !irbAuthored {
 			return nil, fmt.Errorf("irb-type service '%s' needs a gateway address — set anycast_ip on its macvpn, pass --ip, or run configure-irb first", serviceName)
 		}
+		// The composite will author the SVI with the macvpn's anycast MAC —
+		// refuse a bad or conflicting one now, before anything is rendered.
+		if !irbAuthored {
+			if err := n.checkAnycastMAC(vlanID, gw); err != nil {
+				return nil, fmt.Errorf("irb-type service '%s': %w", serviceName, err)
+			}
+		}
 		// No bridged branch: a bridged / evpn-bridged service is a composite that
 		// assembles the VLAN, its L2VNI overlay, and this port's access membership
 		// below (reusing any operator-pre-authored piece, §2). Nothing must pre-exist
@@ -521,6 +588,9 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 	if opts.IPAddress != "" {
 		bindingParams[sonic.FieldIPAddress] = opts.IPAddress
 	}
+	if len(opts.SecondaryIPs) > 0 {
+		bindingParams[sonic.FieldSecondaryIPs] = strings.Join(opts.SecondaryIPs, ",")
+	}
 	if vrfName != "" {
 		bindingParams[sonic.FieldVRFName] = vrfName
 	}
@@ -576,6 +646,9 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 		if macvpnDef.AnycastMAC != "" {
 			bindingParams[sonic.FieldAnycastMAC] = macvpnDef.AnycastMAC
 		}
+		if macvpnDef.AnycastMACPerVLAN {
+			bindingParams[sonic.FieldAnycastMACPerVLAN] = "true"
+		}
 		if macvpnDef.ARPSuppression {
 			bindingParams["arp_suppression"] = "true"
 		}
@@ -598,6 +671,7 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 	cs := NewChangeSet(n.Name(), "interface."+sonic.OpApplyService)
 	cs.ReverseOp = "interface.remove-service"
 	cs.OperationParams = map[string]string{"interface": i.name}
+	cs.Defaults = defaulted
 
 	// Compute parents based on service type for the Intent DAG.
 	var intentParents []string
@@ -664,7 +738,7 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 		if err != nil {
 			return nil, fmt.Errorf("create VRF %s: %w", vrfName, err)
 		}
-		cs.Merge(vrfCS)
+		cs.Merge(vrfCS.Annotate(fmt.Sprintf("VRF for service %s", serviceName)))
 	}
 
 	// IPVPN binding (intent-idempotent: BindIPVPN checks ipvpn intent).
@@ -677,7 +751,7 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 		if err != nil {
 			return nil, fmt.Errorf("bind IPVPN %s: %w", svc.IPVPN, err)
 		}
-		cs.Merge(ipvpnCS)
+		cs.Merge(ipvpnCS.Annotate(fmt.Sprintf("IP-VPN %s for service %s", svc.IPVPN, serviceName)))
 	}
 
 	// SVI gateway for an irb service — the delivery point. ConfigureIRB is
@@ -686,16 +760,13 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 	// above. It parents to vlan + vrf, both created above (I4). The precondition
 	// guaranteed an address is available (opts.IPAddress or a pre-authored SVI).
 	if isIRB {
-		gwIP, gwMAC := irbGateway(opts, macvpnDef)
-		irbCS, err := n.ConfigureIRB(ctx, vlanID, IRBConfig{
-			VRF:        vrfName,
-			IPAddress:  gwIP,
-			AnycastMAC: gwMAC,
-		})
+		gw := irbGateway(opts, macvpnDef)
+		gw.VRF = vrfName
+		irbCS, err := n.ConfigureIRB(ctx, vlanID, gw)
 		if err != nil {
 			return nil, fmt.Errorf("ensure IRB gateway for VLAN %d: %w", vlanID, err)
 		}
-		cs.Merge(irbCS)
+		cs.Merge(irbCS.Annotate(fmt.Sprintf("IRB gateway for service %s", serviceName)))
 	}
 
 	// =========================================================================
@@ -766,14 +837,15 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 			"bgp_asn": asnStr,
 			"type":    "LeafRouter",
 		})
-		cs.Update(bgpEnsureEntry.Table, bgpEnsureEntry.Key, bgpEnsureEntry.Fields)
-		cs.Adds(CreateBGPGlobalsConfig("default", resolved.UnderlayASN, resolved.RouterID, map[string]string{
+		bgpNote := fmt.Sprintf("default-VRF BGP for service %s", serviceName)
+		cs.UpdateWithComment(bgpEnsureEntry.Table, bgpEnsureEntry.Key, bgpNote, bgpEnsureEntry.Fields)
+		cs.AddsWithComment(bgpNote, CreateBGPGlobalsConfig("default", resolved.UnderlayASN, resolved.RouterID, map[string]string{
 			"ebgp_requires_policy": "false",
 			"suppress_fib_pending": "false",
 			"log_neighbor_changes": "true",
 		}))
-		cs.Adds(CreateBGPGlobalsAFConfig("default", "ipv4_unicast", nil))
-		cs.Adds(CreateRouteRedistributeConfig("default", "connected", "ipv4"))
+		cs.AddsWithComment(bgpNote, CreateBGPGlobalsAFConfig("default", "ipv4_unicast", nil))
+		cs.AddsWithComment(bgpNote, CreateRouteRedistributeConfig("default", "connected", "ipv4"))
 	}
 
 	// Per-interface entries by service type (config functions from owning
@@ -789,18 +861,35 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 		// above, and per-member policy is bound to the members (§4). Nothing to bind
 		// on the delivery interface here — the binding record is the delivery.
 	case spec.ServiceTypeEVPNRouted, spec.ServiceTypeRouted:
+		routedNote := fmt.Sprintf("routed port for service %s", serviceName)
 		if vrfName != "" {
-			cs.Adds(bindVrfConfig(i.name, vrfName))
+			cs.AddsWithComment(routedNote, bindVrfConfig(i.name, vrfName))
 		} else {
-			cs.Adds(enableIpRoutingConfig(i.name))
+			cs.AddsWithComment(routedNote, enableIpRoutingConfig(i.name))
 		}
 		if opts.IPAddress != "" {
-			cs.Adds(assignIpAddressConfig(i.name, opts.IPAddress))
+			cs.AddsWithComment(routedNote, assignIpAddressConfig(i.name, opts.IPAddress))
+		}
+		for _, ip := range opts.SecondaryIPs {
+			cs.AddsWithComment(fmt.Sprintf("secondary address for service %s", serviceName), assignIpAddressConfig(i.name, ip))
+		}
+	}
+
+	// An IPv6 peer needs ipv6_unicast in its VRF's BGP instance; the ensure
+	// above and VRF creation only write ipv4_unicast.
+	if len(bgpEntries) > 0 && util.IsValidIPv6CIDR(opts.IPAddress) {
+		bgpVRF := vrfName
+		if bgpVRF == "" {
+			bgpVRF = "default"
+		}
+		if _, ok := n.configDB.BGPGlobalsAF[BGPGlobalsAFKey(bgpVRF, "ipv6_unicast")]; !ok {
+			cs.AddsWithComment(fmt.Sprintf("IPv6 BGP for service %s", serviceName),
+				CreateBGPGlobalsAFConfig(bgpVRF, "ipv6_unicast", nil))
 		}
 	}
 
 	// BGP neighbor entries (pre-generated in step 5 for peer AS extraction)
-	cs.Adds(bgpEntries)
+	cs.AddsWithComment(fmt.Sprintf("BGP peer for service %s", serviceName), bgpEntries)
 
 	// ACL handling — skip if platform doesn't support ACLs
 	skipACL := false
@@ -816,7 +905,7 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 			// service intent was written above, so aclPortsFromIntents includes it)
 			currentPorts := n.aclPortsFromIntents(ingressACLName, "ingress")
 			merged := updateAclPorts(ingressACLName, currentPorts)
-			cs.Update(merged.Table, merged.Key, merged.Fields)
+			cs.UpdateWithComment(merged.Table, merged.Key, fmt.Sprintf("ingress ACL for %s: add %s", serviceName, i.name), merged.Fields)
 		} else {
 			filterSpec, _ := n.GetFilter(svc.IngressFilter)
 			if filterSpec != nil {
@@ -827,8 +916,11 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 				// for an irb service. The binding intent is written above, so it
 				// is already included.
 				ports := n.aclPortsFromIntents(ingressACLName, "ingress")
-				cs.Adds(createAclTableConfig(ingressACLName, mapFilterType(filterSpec.Type), "ingress", ports, desc))
-				n.addACLRulesFromFilterSpec(cs, ingressACLName, filterSpec)
+				note := fmt.Sprintf("ingress ACL for %s", serviceName)
+				cs.AddsWithComment(note, createAclTableConfig(ingressACLName, mapFilterType(filterSpec.Type), "ingress", ports, desc))
+				rules := NewChangeSet(cs.Device, cs.Operation)
+				n.addACLRulesFromFilterSpec(rules, ingressACLName, filterSpec)
+				cs.Merge(rules.Annotate(fmt.Sprintf("%s (filter %s)", note, svc.IngressFilter)))
 				// The acl intent records the DECISION (its source filter), not the
 				// derivation (§21): the rules are regenerated from the filter at
 				// replay and read from the projected ACL_RULE table at teardown —
@@ -853,14 +945,17 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 			// ACL already exists — update port list from intents
 			currentPorts := n.aclPortsFromIntents(egressACLName, "egress")
 			merged := updateAclPorts(egressACLName, currentPorts)
-			cs.Update(merged.Table, merged.Key, merged.Fields)
+			cs.UpdateWithComment(merged.Table, merged.Key, fmt.Sprintf("egress ACL for %s: add %s", serviceName, i.name), merged.Fields)
 		} else {
 			filterSpec, _ := n.GetFilter(svc.EgressFilter)
 			if filterSpec != nil {
 				desc := fmt.Sprintf("Egress filter for %s", serviceName)
 				ports := n.aclPortsFromIntents(egressACLName, "egress")
-				cs.Adds(createAclTableConfig(egressACLName, mapFilterType(filterSpec.Type), "egress", ports, desc))
-				n.addACLRulesFromFilterSpec(cs, egressACLName, filterSpec)
+				note := fmt.Sprintf("egress ACL for %s", serviceName)
+				cs.AddsWithComment(note, createAclTableConfig(egressACLName, mapFilterType(filterSpec.Type), "egress", ports, desc))
+				rules := NewChangeSet(cs.Device, cs.Operation)
+				n.addACLRulesFromFilterSpec(rules, egressACLName, filterSpec)
+				cs.Merge(rules.Annotate(fmt.Sprintf("%s (filter %s)", note, svc.EgressFilter)))
 				aclParams := map[string]string{
 					sonic.FieldName:        egressACLName,
 					sonic.FieldACLType:     mapFilterType(filterSpec.Type),
@@ -883,7 +978,7 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 	// (bindMemberQoS) — all single-VLAN, since the apply gate above refused a
 	// QoS-bearing service on a VLAN with any trunk member (§7).
 	if qosPolicy != nil {
-		cs.Adds(GenerateDeviceQoSConfig(qosPolicyName, qosPolicy))
+		cs.AddsWithComment(fmt.Sprintf("QoS policy %s for service %s", qosPolicyName, serviceName), GenerateDeviceQoSConfig(qosPolicyName, qosPolicy))
 		if isIRB {
 			// An irb delivers QoS to the VLAN's member ports (bindMemberQoS), NOT to
 			// the IRB — SONiC cannot bind QoS to a VLAN interface (RCA-051). Do NOT
@@ -899,7 +994,7 @@ func (i *Interface) ApplyService(ctx context.Context, serviceName string, opts A
 				[]string{"interface|" + i.name}); err != nil {
 				return nil, err
 			}
-			cs.Adds(bindQosConfig(i.name, qosPolicyName, qosPolicy))
+			cs.AddsWithComment(fmt.Sprintf("QoS policy %s on %s for service %s", qosPolicyName, i.name, serviceName), bindQosConfig(i.name, qosPolicyName, qosPolicy))
 		}
 	}
 
@@ -958,7 +1053,7 @@ func (i *Interface) addBGPRoutePolicies(cs *ChangeSet, serviceName string, svc *
 			util.WithDevice(i.node.Name()).Warnf("Route policy '%s' not found: %v", routing.ImportPolicy, err)
 		} else {
 			entries, rmName := createRoutePolicyConfig(serviceName, "import", policy, prefixLists, routing.ImportCommunity, routing.ImportPrefixList)
-			cs.Adds(entries)
+			cs.AddsWithComment(fmt.Sprintf("import policy for service %s", serviceName), entries)
 			if rmName != "" {
 				afFields["route_map_in"] = rmName
 				routeMapIn = rmName
@@ -966,7 +1061,7 @@ func (i *Interface) addBGPRoutePolicies(cs *ChangeSet, serviceName string, svc *
 		}
 	} else if routing.ImportCommunity != "" || routing.ImportPrefixList != "" {
 		entries, rmName := createInlineRoutePolicyConfig(serviceName, "import", routing.ImportCommunity, prefixLists[routing.ImportPrefixList])
-		cs.Adds(entries)
+		cs.AddsWithComment(fmt.Sprintf("import policy for service %s", serviceName), entries)
 		if rmName != "" {
 			afFields["route_map_in"] = rmName
 			routeMapIn = rmName
@@ -979,7 +1074,7 @@ func (i *Interface) addBGPRoutePolicies(cs *ChangeSet, serviceName string, svc *
 			util.WithDevice(i.node.Name()).Warnf("Route policy '%s' not found: %v", routing.ExportPolicy, err)
 		} else {
 			entries, rmName := createRoutePolicyConfig(serviceName, "export", policy, prefixLists, routing.ExportCommunity, routing.ExportPrefixList)
-			cs.Adds(entries)
+			cs.AddsWithComment(fmt.Sprintf("export policy for service %s", serviceName), entries)
 			if rmName != "" {
 				afFields["route_map_out"] = rmName
 				routeMapOut = rmName
@@ -987,7 +1082,7 @@ func (i *Interface) addBGPRoutePolicies(cs *ChangeSet, serviceName string, svc *
 		}
 	} else if routing.ExportCommunity != "" || routing.ExportPrefixList != "" {
 		entries, rmName := createInlineRoutePolicyConfig(serviceName, "export", routing.ExportCommunity, prefixLists[routing.ExportPrefixList])
-		cs.Adds(entries)
+		cs.AddsWithComment(fmt.Sprintf("export policy for service %s", serviceName), entries)
 		if rmName != "" {
 			afFields["route_map_out"] = rmName
 			routeMapOut = rmName
@@ -999,11 +1094,11 @@ func (i *Interface) addBGPRoutePolicies(cs *ChangeSet, serviceName string, svc *
 	// on the service intent DAG (first user of shared/default VRF) or VRF type
 	// (vrf_type:interface always creates — each interface has its own VRF).
 	if createPeerGroup {
-		cs.Adds(CreateBGPPeerGroupConfig(vrfKey, serviceName, afFields))
+		cs.AddsWithComment(fmt.Sprintf("BGP peer group for service %s", serviceName), CreateBGPPeerGroupConfig(vrfKey, serviceName, afFields))
 	} else if len(afFields) > 0 {
 		// Peer group exists — update AF with route map references if needed
 		e := UpdateBGPPeerGroupAF(vrfKey, serviceName, afFields)
-		cs.Update(e.Table, e.Key, e.Fields)
+		cs.UpdateWithComment(e.Table, e.Key, fmt.Sprintf("BGP peer group for service %s", serviceName), e.Fields)
 	}
 
 	// Override default redistribution if specified
@@ -1226,12 +1321,18 @@ func (i *Interface) removeSharedACL(cs *ChangeSet, aclName string) error {
 				// projected ACL_RULE table (which the create-acl replay rebuilds
 				// from the filter). Robust: it deletes exactly what is on the
 				// device, no dependence on a recorded derived list.
+				// Sorted so the deletes come out in the same order every run.
+				var ruleKeys []string
 				for ruleKey := range i.node.configDB.ACLRule {
 					if strings.HasPrefix(ruleKey, aclName+"|") {
-						parts := strings.SplitN(ruleKey, "|", 2)
-						if len(parts) == 2 {
-							cs.Deletes(deleteAclRuleConfig(parts[0], parts[1]))
-						}
+						ruleKeys = append(ruleKeys, ruleKey)
+					}
+				}
+				sort.Strings(ruleKeys)
+				for _, ruleKey := range ruleKeys {
+					parts := strings.SplitN(ruleKey, "|", 2)
+					if len(parts) == 2 {
+						cs.Deletes(deleteAclRuleConfig(parts[0], parts[1]))
 					}
 				}
 			}
@@ -1374,11 +1475,11 @@ func (i *Interface) removeService(ctx context.Context, deliveryOnly bool) (*Chan
 			// unless another irb-service binding (not this one) still binds it.
 			for _, member := range n.vlanMemberPorts(bindingInt(b[sonic.FieldVLANID])) {
 				if !n.isMemberServiceQoSBound(member, excludeKey) {
-					cs.Deletes(unbindQosConfig(member, queueCount))
+					n.unbindQoSRows(cs, member, queueCount)
 				}
 			}
 		} else {
-			cs.Deletes(unbindQosConfig(i.name, queueCount))
+			n.unbindQoSRows(cs, i.name, queueCount)
 		}
 		if !n.isQoSPolicyReferenced(qosPolicyName, i.name) {
 			cs.Deletes(deleteDeviceQoSConfig(qosPolicyName, qosPolicy))
@@ -1521,7 +1622,7 @@ func (i *Interface) removeService(ctx context.Context, deliveryOnly bool) (*Chan
 	// Reap the identity record now that the binding (its child) is gone.
 	//   - irb: the composite authored the SVI gateway (ConfigureIRB on apply),
 	//     so it reaps it on the last consumer — the binding just removed. Delete
-	//     the VLAN_INTERFACE IP/base and the reference-shared SAG, mirroring
+	//     the VLAN_INTERFACE IP/base and the SAG (per-VLAN or shared), mirroring
 	//     UnconfigureIRB, then the intent. (Reap-on-last-consumer, like routed's
 	//     VRF: an operator's standalone configure-irb that was never serviced
 	//     fires no last-consumer event and survives.)
@@ -1536,18 +1637,7 @@ func (i *Interface) removeService(ctx context.Context, deliveryOnly bool) (*Chan
 				cs.Deletes(deleteSviIPConfig(sviVLAN, ip))
 			}
 			cs.Deletes(deleteSviBaseConfig(sviVLAN))
-			if identity.Params[sonic.FieldAnycastMAC] != "" {
-				otherSAG := false
-				for resource, irbIntent := range n.IntentsByOp(sonic.OpConfigureIRB) {
-					if resource != "interface|"+i.name && irbIntent.Params[sonic.FieldAnycastMAC] != "" {
-						otherSAG = true
-						break
-					}
-				}
-				if !otherSAG {
-					cs.Deletes(deleteSagGlobalConfig())
-				}
-			}
+			cs.Deletes(n.releaseSagConfig("interface|"+i.name, identity))
 			if err := n.deleteIntent(cs, "interface|"+i.name); err != nil {
 				return nil, err
 			}
@@ -1603,6 +1693,12 @@ func (i *Interface) removeService(ctx context.Context, deliveryOnly bool) (*Chan
 				}
 			}
 		}
+		// What is left under the VRF was added by the operator, not the
+		// service — a static route, a route leak. Refuse rather than tear
+		// the VRF out from under it.
+		if err := n.guardDependents("VRF", destroyedVRF, "vrf|"+destroyedVRF, false); err != nil {
+			return nil, fmt.Errorf("removing service %s from %s: %w", serviceName, i.name, err)
+		}
 		if err := n.deleteIntent(cs, "vrf|"+destroyedVRF); err != nil {
 			return nil, err
 		}
@@ -1655,6 +1751,7 @@ func (i *Interface) RefreshService(ctx context.Context) (*ChangeSet, error) {
 	b := i.binding()
 	serviceName := b[sonic.FieldServiceName]
 	serviceIP := b[sonic.FieldIPAddress]
+	secondaryIPs := splitSecondaryIPs(b[sonic.FieldSecondaryIPs])
 	peerAS := bindingInt(b[sonic.FieldBGPPeerAS])
 	vlanID := bindingInt(b[sonic.FieldVLANID])
 
@@ -1692,21 +1789,26 @@ func (i *Interface) RefreshService(ctx context.Context) (*ChangeSet, error) {
 	// Reapply the service with preserved parameters. RemoveService deletes
 	// the BGP neighbor, so PeerAS must be passed to recreate it.
 	applyCS, err := i.ApplyService(ctx, serviceName, ApplyServiceOpts{
-		IPAddress: serviceIP,
-		PeerAS:    peerAS,
-		VLAN:      vlanID,
-		Params:    params,
+		IPAddress:    serviceIP,
+		SecondaryIPs: secondaryIPs,
+		PeerAS:       peerAS,
+		VLAN:         vlanID,
+		Params:       params,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("reapplying service: %w", err)
 	}
 
-	// Merge the change sets. The remove+apply creates overlapping delete/add
-	// operations on the same keys. verifyConfigChanges handles this correctly
-	// by computing final state per key (last operation wins).
+	// Merge the change sets. The remove+apply deletes and re-adds the same
+	// keys; MergeChecked collapses each delete+add that restores the row
+	// unchanged, so only the rows the service definition changed are written.
 	cs := NewChangeSet(n.Name(), "interface.refresh-service")
-	cs.Merge(removeCS)
-	cs.Merge(applyCS)
+	if err := cs.MergeChecked(removeCS); err != nil {
+		return nil, fmt.Errorf("merging removal: %w", err)
+	}
+	if err := cs.MergeChecked(applyCS); err != nil {
+		return nil, fmt.Errorf("merging reapply: %w", err)
+	}
 
 	// Clean up stale content-hashed route policy objects (Principle 35).
 	// Pure key diff: old keys from the service intent before removal vs new
@@ -1729,6 +1831,56 @@ func (i *Interface) RefreshService(ctx context.Context) (*ChangeSet, error) {
 	return cs, nil
 }
 
+// ServiceBinding is one interface, the service to apply to it, and the apply
+// options — an entry of ApplyServiceBatch.
+type ServiceBinding struct {
+	Interface string
+	Service   string
+	Opts      ApplyServiceOpts
+}
+
+// ApplyServiceBatch applies services to many interfaces as one operation: one
+// ChangeSet, delivered in one lock/deliver cycle. Each binding is an
+// ApplyService against the projection the bindings before it left, so a
+// resource the bindings share — the service's ACL, a shared VRF — is created
+// by the first and only extended by the rest: the ACL's ports accumulate
+// through the same aclPortsFromIntents/updateAclPorts merge a single apply
+// uses. Updates to a row an earlier binding wrote fold into that write
+// (foldUpdates), so the ACL_TABLE is written once, with every port.
+//
+// A binding that fails fails the batch, naming its interface; the caller
+// discards the batch as it would a failed ApplyService.
+func (n *Node) ApplyServiceBatch(ctx context.Context, bindings []ServiceBinding) (*ChangeSet, error) {
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("no service bindings to apply")
+	}
+	seen := make(map[string]bool, len(bindings))
+	for _, b := range bindings {
+		if seen[b.Interface] {
+			return nil, fmt.Errorf("interface %s appears twice in the batch", b.Interface)
+		}
+		seen[b.Interface] = true
+	}
+
+	cs := NewChangeSet(n.Name(), "interface."+sonic.OpApplyService)
+	for _, b := range bindings {
+		iface, err := n.GetInterface(b.Interface)
+		if err != nil {
+			return nil, err
+		}
+		applyCS, err := iface.ApplyService(ctx, b.Service, b.Opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", b.Interface, err)
+		}
+		cs.Merge(applyCS)
+		cs.Defaults = append(cs.Defaults, applyCS.Defaults...)
+	}
+	cs.foldUpdates()
+
+	util.WithDevice(n.Name()).Infof("Applied %d service bindings (%d changes)", len(bindings), len(cs.Changes))
+	return cs, nil
+}
+
 // serviceCapabilityNeeds derives the interface capabilities a service apply
 // requires from the resolved spec content — the content-derived half of the
 // capability gate. The delivery interface carries the service's access-side
@@ -0,0 +1,159 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ============================================================================
+// Syslog tail — the device's /var/log/syslog, parsed. Many failures never
+// reach CONFIG_DB or STATE_DB: orchagent rejecting an entry, a daemon
+// crashing and restarting. They show only in syslog, so a log scan catches
+// what a table check cannot. Pure observation (§4): lines are returned as
+// logged; deciding which matter is the caller's business.
+//
+// SONiC's rsyslog template stamps each line with the device's local time and
+// no zone ("2026 Oct 15 13:45:09.123456 sonic ERR swss#orchagent: ..."; older
+// images omit the year). Stamps are read as UTC, SONiC's default timezone, so
+// a since filter assumes the device clock is UTC and in sync with the caller.
+// ============================================================================
+
+// MaxSyslogLines caps one GetSyslogTail read.
+const MaxSyslogLines = 20000
+
+// LogLine is one parsed syslog line. Time is zero and only Message is set
+// when the line does not follow the syslog format.
+type LogLine struct {
+	Time     time.Time
+	Host     string
+	Severity string // EMERG, ALERT, CRIT, ERR, WARNING, NOTICE, INFO, DEBUG; "" if absent
+	Program  string // e.g. "swss#orchagent", pid stripped
+	Message  string
+}
+
+// syslogSeverities ranks the severity keywords SONiC's template writes,
+// most severe first (RFC 5424 numbering).
+var syslogSeverities = map[string]int{
+	"EMERG": 0, "ALERT": 1, "CRIT": 2, "ERR": 3, "WARNING": 4, "NOTICE": 5, "INFO": 6, "DEBUG": 7,
+}
+
+// SyslogSeverityRank returns the RFC 5424 rank of a severity keyword (0 most
+// severe), or -1 when it is not one.
+func SyslogSeverityRank(severity string) int {
+	if r, ok := syslogSeverities[strings.ToUpper(severity)]; ok {
+		return r
+	}
+	return -1
+}
+
+// GetSyslogTail returns the last lines lines of the device's syslog, oldest
+// first; with since set, only those stamped at or after it. Auto-connects
+// transport if needed.
+func (n *Node) GetSyslogTail(ctx context.Context, lines int, since time.Time) ([]LogLine, error) {
+	if lines <= 0 || lines > MaxSyslogLines {
+		return nil, fmt.Errorf("lines must be 1-%d, got %d", MaxSyslogLines, lines)
+	}
+	if n.conn == nil {
+		if err := n.ConnectTransport(ctx); err != nil {
+			return nil, fmt.Errorf("connecting transport for syslog read: %w", err)
+		}
+	}
+	tunnel := n.Tunnel()
+	if tunnel == nil {
+		return nil, fmt.Errorf("GetSyslogTail requires SSH connection")
+	}
+	output, err := tunnel.ExecCommandContext(ctx, fmt.Sprintf("sudo tail -n %d /var/log/syslog", lines))
+	if err != nil {
+		return nil, fmt.Errorf("reading syslog: %w", err)
+	}
+	return parseSyslog(output, since, time.Now().UTC()), nil
+}
+
+// parseSyslog parses tail output. now resolves the year of stamps that omit
+// it: the current year, or the previous one when that would put the line
+// more than a day in the future (a December line read in January). With
+// since set, lines before it — and lines with no stamp to place them — are
+// dropped.
+func parseSyslog(output string, since, now time.Time) []LogLine {
+	var out []LogLine
+	for _, raw := range strings.Split(output, "\n") {
+		raw = strings.TrimRight(raw, "\r")
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		line := parseSyslogLine(raw, now)
+		if !since.IsZero() && (line.Time.IsZero() || line.Time.Before(since)) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+func parseSyslogLine(raw string, now time.Time) LogLine {
+	fields := strings.Fields(raw)
+	stamp, rest, ok := syslogStamp(fields, now)
+	if !ok || len(rest) < 2 {
+		return LogLine{Message: raw}
+	}
+	line := LogLine{Time: stamp, Host: rest[0]}
+	rest = rest[1:]
+	if SyslogSeverityRank(rest[0]) >= 0 {
+		line.Severity = rest[0]
+		rest = rest[1:]
+	}
+	if len(rest) > 0 && strings.HasSuffix(rest[0], ":") {
+		prog := strings.TrimSuffix(rest[0], ":")
+		if i := strings.IndexByte(prog, '['); i > 0 {
+			prog = prog[:i]
+		}
+		line.Program = prog
+		rest = rest[1:]
+	}
+	line.Message = skipFields(raw, len(fields)-len(rest))
+	return line
+}
+
+// skipFields returns s after its first n whitespace-separated fields, with the
+// remainder's own spacing intact.
+func skipFields(s string, n int) string {
+	for ; n > 0; n-- {
+		s = strings.TrimLeft(s, " \t")
+		if i := strings.IndexAny(s, " \t"); i >= 0 {
+			s = s[i:]
+		} else {
+			return ""
+		}
+	}
+	return strings.TrimLeft(s, " \t")
+}
+
+// syslogStamp reads the leading timestamp — RFC 3339, "YYYY Mon D hh:mm:ss[.f]"
+// or "Mon D hh:mm:ss[.f]" — and returns it with the remaining fields.
+func syslogStamp(fields []string, now time.Time) (time.Time, []string, bool) {
+	if len(fields) == 0 {
+		return time.Time{}, nil, false
+	}
+	if t, err := time.Parse(time.RFC3339Nano, fields[0]); err == nil {
+		return t.UTC(), fields[1:], true
+	}
+	if len(fields) >= 4 && len(fields[0]) == 4 {
+		if t, err := time.ParseInLocation("2006 Jan 2 15:04:05.999999999", strings.Join(fields[:4], " "), time.UTC); err == nil {
+			return t, fields[4:], true
+		}
+	}
+	if len(fields) >= 3 {
+		t, err := time.ParseInLocation("Jan 2 15:04:05.999999999", strings.Join(fields[:3], " "), time.UTC)
+		if err != nil {
+			return time.Time{}, nil, false
+		}
+		t = t.AddDate(now.Year(), 0, 0)
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t, fields[3:], true
+	}
+	return time.Time{}, nil, false
+}
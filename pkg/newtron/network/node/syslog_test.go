@@ -0,0 +1,41 @@
+package node
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSyslog(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	output := "2026 Jan  2 09:59:58.123456 sonic ERR swss#orchagent: :- doTask: Failed  to create\n" +
+		"Dec 31 23:59:59.5 sonic NOTICE syncd#syncd[42]: year rollover\n" +
+		"2026-01-02T09:59:59.000001+00:00 sonic INFO bgp#bgpd: rfc3339\n" +
+		"not a syslog line\n" +
+		"\n"
+
+	lines := parseSyslog(output, time.Time{}, now)
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines, want 4: %+v", len(lines), lines)
+	}
+	first := lines[0]
+	if want := time.Date(2026, 1, 2, 9, 59, 58, 123456000, time.UTC); !first.Time.Equal(want) ||
+		first.Host != "sonic" || first.Severity != "ERR" || first.Program != "swss#orchagent" ||
+		first.Message != ":- doTask: Failed  to create" {
+		t.Errorf("line 0 = %+v", first)
+	}
+	if lines[1].Time.Year() != 2025 || lines[1].Program != "syncd#syncd" {
+		t.Errorf("line 1 = %+v, want the December line placed in 2025 with the pid stripped", lines[1])
+	}
+	if lines[2].Severity != "INFO" || lines[2].Message != "rfc3339" {
+		t.Errorf("line 2 = %+v", lines[2])
+	}
+	if !lines[3].Time.IsZero() || lines[3].Message != "not a syslog line" {
+		t.Errorf("line 3 = %+v, want the raw text with no stamp", lines[3])
+	}
+
+	since := time.Date(2026, 1, 2, 9, 59, 59, 0, time.UTC)
+	lines = parseSyslog(output, since, now)
+	if len(lines) != 1 || lines[0].Program != "bgp#bgpd" {
+		t.Errorf("since %s: got %+v, want only the bgpd line", since, lines)
+	}
+}
@@ -62,6 +62,37 @@ func TestUpdateIRBRefusesVRFMove(t *testing.T) {
 	}
 }
 
+// TestUpdateIRBPerVLANAnycastMAC: a per-VLAN anycast MAC is edited in the
+// VLAN's own SAG entry even while another IRB holds SAG_GLOBAL, and moving
+// the MAC between the two scopes is refused.
+func TestUpdateIRBPerVLANAnycastMAC(t *testing.T) {
+	ctx := context.Background()
+	n := irbNode(t, IRBConfig{IPAddress: "10.1.100.1/24", AnycastMAC: "00:00:00:00:01:01", AnycastMACPerVLAN: true})
+	if _, err := n.CreateVLAN(ctx, 200, VLANConfig{}); err != nil {
+		t.Fatalf("CreateVLAN: %v", err)
+	}
+	if _, err := n.ConfigureIRB(ctx, 200, IRBConfig{IPAddress: "10.1.200.1/24", AnycastMAC: "00:00:00:00:02:02"}); err != nil {
+		t.Fatalf("ConfigureIRB 200: %v", err)
+	}
+
+	cs, err := n.UpdateIRB(ctx, 100, IRBConfig{IPAddress: "10.1.100.1/24", AnycastMAC: "00:00:00:00:01:02", AnycastMACPerVLAN: true})
+	if err != nil {
+		t.Fatalf("UpdateIRB: %v", err)
+	}
+	assertNoChange(t, cs, "SAG_GLOBAL", "IPv4")
+	if got := n.ConfigDB().SAG["Vlan100|IPv4"]["gwmac"]; got != "00:00:00:00:01:02" {
+		t.Errorf("SAG Vlan100|IPv4 gwmac = %q, want 00:00:00:00:01:02", got)
+	}
+	if intent := n.GetIntent("interface|Vlan100"); intent.Params["anycast_mac_per_vlan"] != "true" {
+		t.Errorf("intent lost the per-VLAN flag: %+v", intent.Params)
+	}
+
+	_, err = n.UpdateIRB(ctx, 100, IRBConfig{IPAddress: "10.1.100.1/24", AnycastMAC: "00:00:00:00:02:02"})
+	if err == nil || !strings.Contains(err.Error(), "unconfigure-irb") {
+		t.Fatalf("moving the MAC to SAG_GLOBAL must be refused, got %v", err)
+	}
+}
+
 func TestUpdateIRBRequiresExistingIRB(t *testing.T) {
 	ctx := context.Background()
 	n, _ := testInterface()
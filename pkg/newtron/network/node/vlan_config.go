@@ -11,6 +11,10 @@ type IRBConfig struct {
 	VRF        string // VRF to bind the IRB to
 	IPAddress  string // IP address with prefix (e.g., "10.1.100.1/24")
 	AnycastMAC string // SAG anycast gateway MAC (e.g., "00:00:00:00:01:01")
+
+	// AnycastMACPerVLAN writes AnycastMAC to this VLAN's own SAG row instead
+	// of the device-wide SAG_GLOBAL singleton, so IRBs can use distinct MACs.
+	AnycastMACPerVLAN bool
 }
 
 // VLANConfig holds configuration options for CreateVLAN.
@@ -27,6 +31,11 @@ func VLANMemberKey(vlanID int, intfName string) string {
 	return fmt.Sprintf("%s|%s", VLANName(vlanID), intfName)
 }
 
+// VLANTranslationKey returns the CONFIG_DB key for a VLAN_TRANSLATION entry.
+func VLANTranslationKey(intfName string, inVLAN int) string {
+	return fmt.Sprintf("%s|%d", intfName, inVLAN)
+}
+
 // IRBIPKey returns the CONFIG_DB key for a VLAN_INTERFACE IP entry.
 func IRBIPKey(vlanID int, ipAddr string) string {
 	return fmt.Sprintf("%s|%s", VLANName(vlanID), ipAddr)
@@ -74,7 +83,7 @@ func createVlanMemberConfig(vlanID int, interfaceName string, tagged bool) []son
 
 // createSviConfig returns CONFIG_DB entries for an IRB: a VLAN_INTERFACE base entry
 // with optional VRF binding, an optional IP address entry, and an optional
-// SAG_GLOBAL entry for anycast gateway MAC.
+// anycast gateway MAC — a per-VLAN SAG entry or the SAG_GLOBAL singleton.
 func createSviConfig(vlanID int, opts IRBConfig) []sonic.Entry {
 	vlanName := VLANName(vlanID)
 
@@ -94,7 +103,11 @@ func createSviConfig(vlanID int, opts IRBConfig) []sonic.Entry {
 
 	// Anycast gateway MAC (SAG)
 	if opts.AnycastMAC != "" {
-		entries = append(entries, setSagGwmacConfig(opts.AnycastMAC)...)
+		if opts.AnycastMACPerVLAN {
+			entries = append(entries, setSagVlanConfig(vlanID, opts.AnycastMAC)...)
+		} else {
+			entries = append(entries, setSagGwmacConfig(opts.AnycastMAC)...)
+		}
 	}
 
 	return entries
@@ -118,11 +131,40 @@ func deleteSagGlobalConfig() []sonic.Entry {
 	return []sonic.Entry{{Table: "SAG_GLOBAL", Key: "IPv4"}}
 }
 
+// sagVlanKey returns the CONFIG_DB key for a VLAN's SAG entry (e.g., "Vlan100|IPv4").
+func sagVlanKey(vlanID int) string { return VLANName(vlanID) + "|IPv4" }
+
+// setSagVlanConfig returns the per-VLAN SAG entry — the anycast gateway MAC
+// for this VLAN's SVI alone, overriding SAG_GLOBAL.
+func setSagVlanConfig(vlanID int, mac string) []sonic.Entry {
+	return []sonic.Entry{{Table: "SAG", Key: sagVlanKey(vlanID), Fields: map[string]string{"gwmac": mac}}}
+}
+
+// deleteSagVlanConfig returns the delete entry for a VLAN's SAG entry.
+func deleteSagVlanConfig(vlanID int) []sonic.Entry {
+	return []sonic.Entry{{Table: "SAG", Key: sagVlanKey(vlanID)}}
+}
+
 // deleteVlanMemberConfig returns the delete entry for a single VLAN member.
 func deleteVlanMemberConfig(vlanID int, intfName string) []sonic.Entry {
 	return []sonic.Entry{{Table: "VLAN_MEMBER", Key: VLANMemberKey(vlanID, intfName)}}
 }
 
+// createVlanTranslationConfig returns the VLAN_TRANSLATION entry rewriting
+// customer VLAN inVLAN to provider VLAN outVLAN on an interface.
+func createVlanTranslationConfig(intfName string, inVLAN, outVLAN int) []sonic.Entry {
+	return []sonic.Entry{
+		{Table: "VLAN_TRANSLATION", Key: VLANTranslationKey(intfName, inVLAN), Fields: map[string]string{
+			"translated_vlan": fmt.Sprintf("%d", outVLAN),
+		}},
+	}
+}
+
+// deleteVlanTranslationConfig returns the delete entry for a single VLAN translation.
+func deleteVlanTranslationConfig(intfName string, inVLAN int) []sonic.Entry {
+	return []sonic.Entry{{Table: "VLAN_TRANSLATION", Key: VLANTranslationKey(intfName, inVLAN)}}
+}
+
 // deleteSviIPConfig returns the delete entry for a specific SVI IP binding.
 func deleteSviIPConfig(vlanID int, ipAddr string) []sonic.Entry {
 	return []sonic.Entry{{Table: "VLAN_INTERFACE", Key: IRBIPKey(vlanID, ipAddr)}}
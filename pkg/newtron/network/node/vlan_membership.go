@@ -0,0 +1,103 @@
+package node
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// ============================================================================
+// VLAN membership — "which ports are in VLAN 100, and how?"
+//
+// GetVLAN answers from intents: the VLANs and members newtron configured.
+// Membership answers from the device: a join of VLAN, VLAN_MEMBER, and
+// VLAN_INTERFACE as they stand in CONFIG_DB, so a member added out of band,
+// or an intent whose entries never landed, shows up as the device sees it.
+// This is the `show vlan brief` view.
+// ============================================================================
+
+// VLANMembership is one VLAN's ports and SVI as read from CONFIG_DB.
+type VLANMembership struct {
+	ID           int
+	Name         string   // "Vlan100"
+	Description  string   // VLAN description field
+	Tagged       []string // VLAN_MEMBER tagging_mode=tagged, sorted
+	Untagged     []string // VLAN_MEMBER tagging_mode=untagged (or unset), sorted
+	SVI          bool     // VLAN_INTERFACE|VlanN exists
+	SVIAddresses []string // VLAN_INTERFACE|VlanN|<prefix> keys, sorted
+	VRF          string   // SVI vrf_name; empty for the default VRF
+}
+
+// GetVLANMembership reads VLAN, VLAN_MEMBER, and VLAN_INTERFACE from the
+// device's CONFIG_DB and returns each VLAN with its members and SVI, sorted
+// by VLAN ID. Auto-connects transport if needed.
+func (n *Node) GetVLANMembership(ctx context.Context) ([]VLANMembership, error) {
+	raw, err := n.ConfigDBSnapshot(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return buildVLANMembership(raw), nil
+}
+
+// buildVLANMembership is the pure join behind GetVLANMembership. A VLAN that
+// appears only in VLAN_MEMBER or VLAN_INTERFACE (its VLAN entry is missing)
+// is still reported — that orphan is exactly what an operator needs to see.
+func buildVLANMembership(raw sonic.RawConfigDB) []VLANMembership {
+	byName := map[string]*VLANMembership{}
+	get := func(name string) *VLANMembership {
+		if m, ok := byName[name]; ok {
+			return m
+		}
+		id, err := strconv.Atoi(strings.TrimPrefix(name, "Vlan"))
+		if err != nil || !strings.HasPrefix(name, "Vlan") {
+			return nil
+		}
+		m := &VLANMembership{ID: id, Name: name}
+		byName[name] = m
+		return m
+	}
+
+	for name, fields := range raw["VLAN"] {
+		if m := get(name); m != nil {
+			m.Description = fields["description"]
+		}
+	}
+	for key, fields := range raw["VLAN_MEMBER"] {
+		vlan, member, ok := strings.Cut(key, "|")
+		m := get(vlan)
+		if !ok || m == nil {
+			continue
+		}
+		if fields["tagging_mode"] == "tagged" {
+			m.Tagged = append(m.Tagged, member)
+		} else {
+			m.Untagged = append(m.Untagged, member)
+		}
+	}
+	for key, fields := range raw["VLAN_INTERFACE"] {
+		vlan, prefix, hasPrefix := strings.Cut(key, "|")
+		m := get(vlan)
+		if m == nil {
+			continue
+		}
+		m.SVI = true
+		if hasPrefix {
+			m.SVIAddresses = append(m.SVIAddresses, prefix)
+		} else {
+			m.VRF = fields["vrf_name"]
+		}
+	}
+
+	out := make([]VLANMembership, 0, len(byName))
+	for _, m := range byName {
+		sort.Strings(m.Tagged)
+		sort.Strings(m.Untagged)
+		sort.Strings(m.SVIAddresses)
+		out = append(out, *m)
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].ID < out[b].ID })
+	return out
+}
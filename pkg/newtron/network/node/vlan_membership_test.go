@@ -0,0 +1,51 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+func TestBuildVLANMembership(t *testing.T) {
+	raw := sonic.RawConfigDB{
+		"VLAN": {
+			"Vlan100": {"vlanid": "100", "description": "servers"},
+			"Vlan20":  {"vlanid": "20"},
+		},
+		"VLAN_MEMBER": {
+			"Vlan100|Ethernet8":    {"tagging_mode": "untagged"},
+			"Vlan100|PortChannel1": {"tagging_mode": "tagged"},
+			"Vlan100|Ethernet4":    {"tagging_mode": "untagged"},
+			"Vlan300|Ethernet12":   {"tagging_mode": "tagged"}, // orphan: no VLAN entry
+			"Vlan20|Ethernet0":     {},
+		},
+		"VLAN_INTERFACE": {
+			"Vlan100":               {"vrf_name": "Vrf_red"},
+			"Vlan100|10.1.100.1/24": {},
+		},
+	}
+
+	got := buildVLANMembership(raw)
+	want := []VLANMembership{
+		{ID: 20, Name: "Vlan20", Untagged: []string{"Ethernet0"}},
+		{
+			ID: 100, Name: "Vlan100", Description: "servers",
+			Tagged:       []string{"PortChannel1"},
+			Untagged:     []string{"Ethernet4", "Ethernet8"},
+			SVI:          true,
+			SVIAddresses: []string{"10.1.100.1/24"},
+			VRF:          "Vrf_red",
+		},
+		{ID: 300, Name: "Vlan300", Tagged: []string{"Ethernet12"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildVLANMembership =\n  %+v\nwant\n  %+v", got, want)
+	}
+}
+
+func TestBuildVLANMembership_Empty(t *testing.T) {
+	if got := buildVLANMembership(sonic.RawConfigDB{}); len(got) != 0 {
+		t.Errorf("buildVLANMembership = %+v, want none", got)
+	}
+}
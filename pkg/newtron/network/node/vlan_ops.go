@@ -3,6 +3,8 @@ package node
 import (
 	"context"
 	"fmt"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -24,6 +26,9 @@ func (n *Node) CreateVLAN(ctx context.Context, vlanID int, opts VLANConfig) (*Ch
 	cs, err := n.op(sonic.OpCreateVLAN, vlanResource(vlanID), ChangeAdd,
 		func(pc *PreconditionChecker) {
 			pc.Check(vlanID >= 1 && vlanID <= 4094, "valid VLAN ID", fmt.Sprintf("must be 1-4094, got %d", vlanID))
+			if opts.L2VNI > 0 {
+				pc.RequireVNIUnmapped(opts.L2VNI, VLANName(vlanID)).RequireL3VNIUnbound(opts.L2VNI, "")
+			}
 		},
 		func() []sonic.Entry { return createVlanConfig(vlanID, opts) },
 		"device.delete-vlan")
@@ -48,15 +53,16 @@ func (n *Node) CreateVLAN(ctx context.Context, vlanID int, opts VLANConfig) (*Ch
 }
 
 // DeleteVLAN removes a VLAN from this device.
-// Checks DAG children first (I5) — if children exist, returns an error
-// before issuing any CONFIG_DB deletes.
+// Checks DAG children first (I5) — if children exist (members, the SVI, a
+// MAC-VPN binding), returns a *util.ConflictError listing them before issuing
+// any CONFIG_DB deletes.
 func (n *Node) DeleteVLAN(ctx context.Context, vlanID int) (*ChangeSet, error) {
 	intentKey := "vlan|" + strconv.Itoa(vlanID)
 
 	// Pre-check: if intent has children, deleteIntent would fail (I5).
 	// Check here so we fail before n.op() issues CONFIG_DB deletes.
-	if intent := n.GetIntent(intentKey); intent != nil && len(intent.Children) > 0 {
-		return nil, fmt.Errorf("deleteIntent %q: has children %v", intentKey, intent.Children)
+	if err := n.guardDependents("VLAN", VLANName(vlanID), intentKey, false); err != nil {
+		return nil, err
 	}
 
 	cs, err := n.op("delete-vlan", vlanResource(vlanID), ChangeDelete,
@@ -87,6 +93,13 @@ func (n *Node) ConfigureIRB(ctx context.Context, vlanID int, opts IRBConfig) (*C
 	if n.GetIntent("interface|"+VLANName(vlanID)) != nil {
 		return NewChangeSet(n.name, "device."+sonic.OpConfigureIRB), nil
 	}
+	// Operator input is checked when authored, not re-checked on replay
+	// against a half-rebuilt projection (§20).
+	if !n.reconstructing {
+		if err := n.checkAnycastMAC(vlanID, opts); err != nil {
+			return nil, err
+		}
+	}
 
 	cs, err := n.op(sonic.OpConfigureIRB, vlanResource(vlanID), ChangeAdd,
 		func(pc *PreconditionChecker) {
@@ -104,12 +117,7 @@ func (n *Node) ConfigureIRB(ctx context.Context, vlanID int, opts IRBConfig) (*C
 	if opts.VRF != "" {
 		irbParents = append(irbParents, "vrf|"+opts.VRF)
 	}
-	if err := n.writeIntent(cs, sonic.OpConfigureIRB, "interface|"+VLANName(vlanID), map[string]string{
-		sonic.FieldVLANID:     strconv.Itoa(vlanID),
-		sonic.FieldVRF:        opts.VRF,
-		sonic.FieldIPAddress:  opts.IPAddress,
-		sonic.FieldAnycastMAC: opts.AnycastMAC,
-	}, irbParents); err != nil {
+	if err := n.writeIntent(cs, sonic.OpConfigureIRB, "interface|"+VLANName(vlanID), irbIntentParams(vlanID, opts), irbParents); err != nil {
 		return nil, err
 	}
 	cs.OperationParams = map[string]string{"vlan_id": fmt.Sprintf("%d", vlanID)}
@@ -118,6 +126,97 @@ func (n *Node) ConfigureIRB(ctx context.Context, vlanID int, opts IRBConfig) (*C
 }
 
 
+// irbIntentParams returns the configure-irb intent params for opts. The
+// literal stores the four identity fields unconditionally (empty strings
+// kept); the per-VLAN SAG flag only when set.
+func irbIntentParams(vlanID int, opts IRBConfig) map[string]string {
+	params := map[string]string{
+		sonic.FieldVLANID:     strconv.Itoa(vlanID),
+		sonic.FieldVRF:        opts.VRF,
+		sonic.FieldIPAddress:  opts.IPAddress,
+		sonic.FieldAnycastMAC: opts.AnycastMAC,
+	}
+	if opts.AnycastMACPerVLAN {
+		params[sonic.FieldAnycastMACPerVLAN] = "true"
+	}
+	return params
+}
+
+// sagPerVLAN reports whether a configure-irb intent's anycast MAC lives in
+// the VLAN's own SAG entry rather than SAG_GLOBAL.
+func sagPerVLAN(intent *sonic.Intent) bool {
+	return intent.Params[sonic.FieldAnycastMACPerVLAN] == "true"
+}
+
+// sagGlobalUsers returns the configure-irb intents, other than except, whose
+// anycast MAC is the SAG_GLOBAL singleton, sorted.
+func (n *Node) sagGlobalUsers(except string) []string {
+	var users []string
+	for resource, intent := range n.IntentsByOp(sonic.OpConfigureIRB) {
+		if resource != except && intent.Params[sonic.FieldAnycastMAC] != "" && !sagPerVLAN(intent) {
+			users = append(users, resource)
+		}
+	}
+	sort.Strings(users)
+	return users
+}
+
+// checkAnycastMAC validates the anycast gateway MAC an IRB is about to
+// author: a unicast MAC, present when a per-VLAN SAG is asked for, and —
+// for SAG_GLOBAL — agreeing with the device-wide MAC already configured.
+func (n *Node) checkAnycastMAC(vlanID int, opts IRBConfig) error {
+	if opts.AnycastMAC == "" {
+		if opts.AnycastMACPerVLAN {
+			return fmt.Errorf("per-VLAN anycast gateway for VLAN %d needs an anycast MAC", vlanID)
+		}
+		return nil
+	}
+	if err := util.ValidateUnicastMAC(opts.AnycastMAC); err != nil {
+		return fmt.Errorf("anycast gateway MAC for VLAN %d: %w", vlanID, err)
+	}
+	if opts.AnycastMACPerVLAN {
+		return nil
+	}
+	return n.sagGlobalConflict(vlanID, opts.AnycastMAC)
+}
+
+// sagGlobalConflict refuses to write mac to SAG_GLOBAL when the device
+// already has a different global anycast MAC. The row is one device-wide
+// value: overwriting it would silently retarget every anycast gateway that
+// uses it. Distinct MACs need per-VLAN SAG entries.
+func (n *Node) sagGlobalConflict(vlanID int, mac string) error {
+	existing := n.configDB.SAGGlobal["IPv4"]["gwmac"]
+	if existing == "" {
+		return nil
+	}
+	have, errHave := net.ParseMAC(existing)
+	want, errWant := net.ParseMAC(mac)
+	if errHave == nil && errWant == nil && have.String() == want.String() {
+		return nil
+	}
+	owner := ""
+	if users := n.sagGlobalUsers("interface|" + VLANName(vlanID)); len(users) > 0 {
+		owner = " (used by " + strings.Join(users, ", ") + ")"
+	}
+	return fmt.Errorf("VLAN %d anycast MAC %s conflicts with the device-wide SAG_GLOBAL MAC %s%s — use the same MAC, or give the IRB a per-VLAN anycast MAC", vlanID, mac, existing, owner)
+}
+
+// releaseSagConfig returns the deletes that retire a configure-irb intent's
+// anycast MAC: the VLAN's own SAG entry, or SAG_GLOBAL once no other IRB
+// uses it. Nil when the IRB had no anycast MAC or SAG_GLOBAL is still shared.
+func (n *Node) releaseSagConfig(intentKey string, intent *sonic.Intent) []sonic.Entry {
+	if intent.Params[sonic.FieldAnycastMAC] == "" {
+		return nil
+	}
+	if sagPerVLAN(intent) {
+		return deleteSagVlanConfig(bindingInt(intent.Params[sonic.FieldVLANID]))
+	}
+	if len(n.sagGlobalUsers(intentKey)) > 0 {
+		return nil
+	}
+	return deleteSagGlobalConfig()
+}
+
 // UpdateIRB atomically mutates the operator-authored IRB identity for a
 // VLAN — the §48 in-place path: the VLAN_INTERFACE base row is never
 // touched, so intfmgrd observes an edit to the gateway's sub-entries, not
@@ -126,13 +225,16 @@ func (n *Node) ConfigureIRB(ctx context.Context, vlanID int, opts IRBConfig) (*C
 //   - Gateway IP: the IP is the sub-entry's key (§47), so a change is
 //     delivered as delete-old-key + add-new-key in one ChangeSet — a move,
 //     never a whole-SVI bounce.
-//   - Anycast MAC: a field edit on the SAG_GLOBAL singleton — refused when
-//     other anycast IRBs share it (device-wide value; changing it through
-//     one VLAN's update would silently retarget every anycast gateway).
+//   - Anycast MAC: a field edit on the VLAN's own SAG entry, or on the
+//     SAG_GLOBAL singleton — refused when other anycast IRBs share it
+//     (device-wide value; changing it through one VLAN's update would
+//     silently retarget every anycast gateway).
 //
 // A VRF move is refused: rebinding an SVI re-originates its routes, which
 // is a teardown-replace by nature — unconfigure-irb + configure-irb states
-// that intent honestly (§48: the delivery must match the intent).
+// that intent honestly (§48: the delivery must match the intent). Moving
+// the anycast MAC between SAG_GLOBAL and a per-VLAN entry is refused the
+// same way.
 func (n *Node) UpdateIRB(ctx context.Context, vlanID int, opts IRBConfig) (*ChangeSet, error) {
 	if err := n.precondition(sonic.OpUpdateIRB, vlanResource(vlanID)).Result(); err != nil {
 		return nil, err
@@ -147,6 +249,9 @@ func (n *Node) UpdateIRB(ctx context.Context, vlanID int, opts IRBConfig) (*Chan
 	if opts.VRF != oldVRF {
 		return nil, fmt.Errorf("update-irb cannot move VLAN %d between VRFs (%q → %q): a VRF move re-originates the SVI's routes and is a teardown-replace by nature — use unconfigure-irb then configure-irb", vlanID, oldVRF, opts.VRF)
 	}
+	if perVLAN := sagPerVLAN(intent); opts.AnycastMACPerVLAN != perVLAN {
+		return nil, fmt.Errorf("update-irb cannot move VLAN %d's anycast MAC between SAG_GLOBAL and a per-VLAN SAG entry (per-VLAN %v → %v) — use unconfigure-irb then configure-irb", vlanID, perVLAN, opts.AnycastMACPerVLAN)
+	}
 	oldIP := intent.Params[sonic.FieldIPAddress]
 	oldMAC := intent.Params[sonic.FieldAnycastMAC]
 	if opts.IPAddress == oldIP && opts.AnycastMAC == oldMAC {
@@ -155,6 +260,11 @@ func (n *Node) UpdateIRB(ctx context.Context, vlanID int, opts IRBConfig) (*Chan
 	if opts.IPAddress != "" && !util.IsValidIPv4CIDR(opts.IPAddress) {
 		return nil, fmt.Errorf("invalid IP address: %s", opts.IPAddress)
 	}
+	if opts.AnycastMAC != "" {
+		if err := util.ValidateUnicastMAC(opts.AnycastMAC); err != nil {
+			return nil, fmt.Errorf("anycast gateway MAC for VLAN %d: %w", vlanID, err)
+		}
+	}
 
 	cs := NewChangeSet(n.name, "device."+sonic.OpUpdateIRB)
 
@@ -167,16 +277,27 @@ func (n *Node) UpdateIRB(ctx context.Context, vlanID int, opts IRBConfig) (*Chan
 		}
 	}
 
-	if opts.AnycastMAC != oldMAC {
+	if opts.AnycastMAC != oldMAC && opts.AnycastMACPerVLAN {
+		// This VLAN's own SAG entry — no other gateway shares it.
+		switch {
+		case oldMAC == "":
+			cs.Adds(setSagVlanConfig(vlanID, opts.AnycastMAC))
+		case opts.AnycastMAC == "":
+			cs.Deletes(deleteSagVlanConfig(vlanID))
+		default:
+			cs.Replace(n, setSagVlanConfig(vlanID, oldMAC), setSagVlanConfig(vlanID, opts.AnycastMAC))
+		}
+	} else if opts.AnycastMAC != oldMAC {
 		// SAG_GLOBAL is one device-wide row. Only this VLAN's IRB may
 		// reference it, or the change silently retargets the others.
-		for resource, other := range n.IntentsByOp(sonic.OpConfigureIRB) {
-			if resource != intentKey && other.Params[sonic.FieldAnycastMAC] != "" {
-				return nil, fmt.Errorf("anycast MAC is the device-wide SAG_GLOBAL value and %s also references it — updating it through VLAN %d would retarget every anycast gateway", resource, vlanID)
-			}
+		if users := n.sagGlobalUsers(intentKey); len(users) > 0 {
+			return nil, fmt.Errorf("anycast MAC is the device-wide SAG_GLOBAL value and %s also references it — updating it through VLAN %d would retarget every anycast gateway", users[0], vlanID)
 		}
 		switch {
 		case oldMAC == "":
+			if err := n.sagGlobalConflict(vlanID, opts.AnycastMAC); err != nil {
+				return nil, err
+			}
 			cs.Adds(setSagGwmacConfig(opts.AnycastMAC))
 		case opts.AnycastMAC == "":
 			cs.Deletes(deleteSagGlobalConfig())
@@ -187,12 +308,7 @@ func (n *Node) UpdateIRB(ctx context.Context, vlanID int, opts IRBConfig) (*Chan
 
 	// Re-record under the creating verb on the same key (the update-verb
 	// convention — see UpdateBGPPeer): replay reproduces the updated state.
-	if err := n.writeIntent(cs, sonic.OpConfigureIRB, intentKey, map[string]string{
-		sonic.FieldVLANID:     strconv.Itoa(vlanID),
-		sonic.FieldVRF:        oldVRF,
-		sonic.FieldIPAddress:  opts.IPAddress,
-		sonic.FieldAnycastMAC: opts.AnycastMAC,
-	}, intent.Parents); err != nil {
+	if err := n.writeIntent(cs, sonic.OpConfigureIRB, intentKey, irbIntentParams(vlanID, opts), intent.Parents); err != nil {
 		return nil, err
 	}
 	cs.ReverseOp = "device.unconfigure-irb"
@@ -228,20 +344,9 @@ func (n *Node) UnconfigureIRB(ctx context.Context, vlanID int) (*ChangeSet, erro
 	// Remove base VLAN_INTERFACE entry
 	cs.Deletes(deleteSviBaseConfig(vlanID))
 
-	// Remove SAG_GLOBAL if anycast MAC was set and no other IRB intent uses it
-	if intent.Params[sonic.FieldAnycastMAC] != "" {
-		// SAG_GLOBAL is shared — only remove if no other IRB intent uses anycast MAC
-		otherSAG := false
-		for resource, irbIntent := range n.IntentsByOp(sonic.OpConfigureIRB) {
-			if resource != intentKey && irbIntent.Params[sonic.FieldAnycastMAC] != "" {
-				otherSAG = true
-				break
-			}
-		}
-		if !otherSAG {
-			cs.Deletes(deleteSagGlobalConfig())
-		}
-	}
+	// Remove the anycast MAC: the VLAN's own SAG entry, or SAG_GLOBAL when no
+	// other IRB intent still uses it
+	cs.Deletes(n.releaseSagConfig(intentKey, intent))
 
 	if err := n.deleteIntent(cs, intentKey); err != nil {
 		return nil, err
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
@@ -64,15 +65,7 @@ func bindIpvpnConfig(vrfName string, ipvpnDef *spec.IPVPNSpec, underlayASN int,
 	entries = append(entries, CreateRouteRedistributeConfig(vrfName, "connected", "ipv4")...)
 
 	// BGP_GLOBALS_EVPN_RT → 'route-target both {rt}' in 'address-family l2vpn evpn'.
-	// frrcfgd bgp_globals_evpn_rt_handler watches this table (NOT BGP_EVPN_VNI).
-	// Key: {vrf}|L2VPN_EVPN|{rt} (uppercase AF); field: route-target-type (HYPHEN).
-	for _, rt := range ipvpnDef.RouteTargets {
-		entries = append(entries, sonic.Entry{
-			Table:  "BGP_GLOBALS_EVPN_RT",
-			Key:    bgpGlobalsEvpnRTKey(vrfName, rt),
-			Fields: map[string]string{"route-target-type": "both"},
-		})
-	}
+	entries = append(entries, routeTargetConfig(vrfName, ipvpnDef.RouteTargets, ipvpnDef.RouteTargets)...)
 
 	// L3VNI transit VLAN infrastructure for VXLAN data plane decap.
 	// FRR knows the VNI (VRF|vni above) but the kernel/SAI needs a bridge domain
@@ -100,12 +93,25 @@ func createVrfConfig(name string) []sonic.Entry {
 
 // createStaticRouteConfig returns the CONFIG_DB entries for a static route.
 // Key format: "vrfName|prefix" for non-default VRF, just "prefix" for default.
-func createStaticRouteConfig(vrfName, prefix, nextHop string, metric int) []sonic.Entry {
-	fields := map[string]string{
-		"nexthop": nextHop,
+//
+// SONiC's STATIC_ROUTE carries one path per position of its comma-joined
+// lists: nexthop[i] via ifname[i] at distance[i]. Every list present must
+// be the same length, so the distance is repeated once per path.
+func createStaticRouteConfig(vrfName, prefix string, cfg StaticRouteConfig) []sonic.Entry {
+	fields := map[string]string{}
+	paths := max(len(cfg.NextHops), len(cfg.Interfaces))
+	if cfg.Blackhole {
+		fields["blackhole"] = "true"
+		paths = 1
 	}
-	if metric > 0 {
-		fields["distance"] = fmt.Sprintf("%d", metric)
+	if len(cfg.NextHops) > 0 {
+		fields["nexthop"] = strings.Join(cfg.NextHops, ",")
+	}
+	if len(cfg.Interfaces) > 0 {
+		fields["ifname"] = strings.Join(cfg.Interfaces, ",")
+	}
+	if cfg.Distance > 0 && paths > 0 {
+		fields["distance"] = strings.TrimSuffix(strings.Repeat(strconv.Itoa(cfg.Distance)+",", paths), ",")
 	}
 
 	return []sonic.Entry{
@@ -161,6 +167,41 @@ func unbindIpvpnConfig(vrfName string, routeTargets []string) []sonic.Entry {
 	return entries
 }
 
+// routeTargetConfig returns the BGP_GLOBALS_EVPN_RT entries for a VRF's EVPN
+// route targets — 'route-target {type} {rt}' in 'address-family l2vpn evpn'.
+// frrcfgd bgp_globals_evpn_rt_handler watches this table (NOT BGP_EVPN_VNI).
+// Key: {vrf}|L2VPN_EVPN|{rt} (uppercase AF); field: route-target-type (HYPHEN).
+// An RT in both lists is written once as "both"; entries follow importRTs,
+// then the export-only RTs, in the order given.
+func routeTargetConfig(vrfName string, importRTs, exportRTs []string) []sonic.Entry {
+	types := make(map[string]string)
+	var order []string
+	for _, rt := range importRTs {
+		if _, seen := types[rt]; !seen {
+			types[rt] = "import"
+			order = append(order, rt)
+		}
+	}
+	for _, rt := range exportRTs {
+		switch types[rt] {
+		case "":
+			types[rt] = "export"
+			order = append(order, rt)
+		case "import":
+			types[rt] = "both"
+		}
+	}
+	var entries []sonic.Entry
+	for _, rt := range order {
+		entries = append(entries, sonic.Entry{
+			Table:  "BGP_GLOBALS_EVPN_RT",
+			Key:    bgpGlobalsEvpnRTKey(vrfName, rt),
+			Fields: map[string]string{"route-target-type": types[rt]},
+		})
+	}
+	return entries
+}
+
 // bgpGlobalsEvpnRTKey returns the CONFIG_DB key for a BGP_GLOBALS_EVPN_RT entry
 // — the table frrcfgd's bgp_globals_evpn_rt_handler watches for VRF route
 // targets. Format: {vrf}|L2VPN_EVPN|{rt} (uppercase AF segment). One owner so
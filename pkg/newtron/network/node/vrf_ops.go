@@ -3,6 +3,8 @@ package node
 import (
 	"context"
 	"fmt"
+	"maps"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -38,23 +40,37 @@ func (n *Node) CreateVRF(ctx context.Context, name string, opts VRFConfig) (*Cha
 	return cs, nil
 }
 
-// DeleteVRF removes a VRF.
-func (n *Node) DeleteVRF(ctx context.Context, name string) (*ChangeSet, error) {
+// DeleteVRF removes a VRF. Refused with a *util.ConflictError listing the
+// dependents while any intent still depends on it, or while set-vrf still
+// binds an interface to it. force unbinds those interfaces in the same
+// ChangeSet; it never reaches an intent dependent.
+func (n *Node) DeleteVRF(ctx context.Context, name string, force bool) (*ChangeSet, error) {
 	if err := n.precondition("delete-vrf", name).
 		RequireVRFExists(name).
 		Result(); err != nil {
 		return nil, err
 	}
 
-	// Check no interfaces are bound to this VRF
-	vrfInfo, _ := n.GetVRF(name)
-	if vrfInfo != nil && len(vrfInfo.Interfaces) > 0 {
-		return nil, fmt.Errorf("cannot delete VRF %s — %d interface(s) still bound: %v — remove their services or VRF bindings first",
-			name, len(vrfInfo.Interfaces), vrfInfo.Interfaces)
+	// Refuse while anything still uses the VRF — bound interfaces and
+	// services, static routes, route leaks, an IP-VPN binding, and
+	// interfaces set-vrf bound with no intent.
+	unbound := n.vrfUnrecordedBindings(name)
+	refs := make([]string, len(unbound))
+	for i, row := range unbound {
+		refs[i] = row.Table + "|" + row.Key + " (set-vrf)"
+	}
+	if err := n.guardDependents("VRF", name, "vrf|"+name, force, refs...); err != nil {
+		return nil, err
 	}
 
 	cs := NewChangeSet(n.name, "device.delete-vrf")
 
+	// Forced past the set-vrf bindings: unbind them the way
+	// RemoveVRFInterface does, before the VRF goes.
+	for _, row := range unbound {
+		cs.Update(row.Table, row.Key, map[string]string{sonic.FieldVRFName: ""})
+	}
+
 	cs.Deletes(createVrfConfig(name))
 
 	// Remove BGP_GLOBALS entry written by BindIPVPN.
@@ -77,7 +93,7 @@ func (n *Node) DeleteVRF(ctx context.Context, name string) (*ChangeSet, error) {
 // AddVRFInterface binds an interface to a VRF.
 // Resolves the interface name and delegates to Interface.SetVRF.
 func (n *Node) AddVRFInterface(ctx context.Context, vrfName, intfName string) (*ChangeSet, error) {
-	intfName = util.NormalizeInterfaceName(intfName)
+	intfName = n.normalizeInterfaceName(intfName)
 	if n.GetIntent("vrf|"+vrfName) == nil {
 		return nil, fmt.Errorf("VRF '%s' does not exist", vrfName)
 	}
@@ -91,7 +107,7 @@ func (n *Node) AddVRFInterface(ctx context.Context, vrfName, intfName string) (*
 // RemoveVRFInterface removes a VRF binding from an interface.
 // Resolves the interface name and delegates to Interface.SetVRF with empty VRF.
 func (n *Node) RemoveVRFInterface(ctx context.Context, vrfName, intfName string) (*ChangeSet, error) {
-	intfName = util.NormalizeInterfaceName(intfName)
+	intfName = n.normalizeInterfaceName(intfName)
 	iface, err := n.GetInterface(intfName)
 	if err != nil {
 		return nil, err
@@ -142,7 +158,13 @@ func (n *Node) BindIPVPN(ctx context.Context, ipvpnName, vrfName string) (*Chang
 	}
 	resolved := n.Resolved()
 	cs, err := n.op(sonic.OpBindIPVPN, ipvpnName, ChangeModify,
-		func(pc *PreconditionChecker) { pc.RequireVTEPConfigured().RequireVRFExists(vrfName) },
+		func(pc *PreconditionChecker) {
+			pc.RequireVTEPConfigured().RequireVRFExists(vrfName).RequireL3VNIUnbound(ipvpnDef.L3VNI, vrfName)
+			if ipvpnDef.L3VNIVlan > 0 {
+				// The transit VLAN's tunnel map carries the L3VNI too.
+				pc.RequireVNIUnmapped(ipvpnDef.L3VNI, VLANName(ipvpnDef.L3VNIVlan))
+			}
+		},
 		func() []sonic.Entry { return bindIpvpnConfig(vrfName, ipvpnDef, resolved.UnderlayASN, resolved.RouterID) },
 		"device.unbind-ipvpn")
 	if err != nil {
@@ -257,38 +279,135 @@ func (n *Node) UnbindIPVPN(ctx context.Context, ipvpnName string) (*ChangeSet, e
 // Static Routes
 // ============================================================================
 
-// AddStaticRoute adds a static route to a VRF.
+// StaticRouteConfig holds a static route's paths. NextHops and Interfaces
+// pair by position when both are given — NextHops[i] is reached via
+// Interfaces[i]; either alone is a list of gateway or interface-only paths,
+// more than one of which is ECMP. Blackhole discards the prefix and takes
+// neither. Distance is the administrative distance; 0 leaves it unset.
+type StaticRouteConfig struct {
+	NextHops   []string
+	Interfaces []string
+	Blackhole  bool
+	Distance   int
+}
+
+// staticRouteProblems lists what makes cfg an invalid set of paths.
+func staticRouteProblems(cfg StaticRouteConfig) []string {
+	var problems []string
+	hasPaths := len(cfg.NextHops) > 0 || len(cfg.Interfaces) > 0
+	switch {
+	case cfg.Blackhole && hasPaths:
+		problems = append(problems, "a blackhole route takes no next-hop or interface")
+	case !cfg.Blackhole && !hasPaths:
+		problems = append(problems, "a next-hop, an interface, or blackhole is required")
+	}
+	if len(cfg.NextHops) > 0 && len(cfg.Interfaces) > 0 && len(cfg.NextHops) != len(cfg.Interfaces) {
+		problems = append(problems, fmt.Sprintf("%d next-hops but %d interfaces — each next-hop pairs with the interface at its position",
+			len(cfg.NextHops), len(cfg.Interfaces)))
+	}
+	for _, nh := range cfg.NextHops {
+		if !util.IsValidIPv4(nh) {
+			problems = append(problems, fmt.Sprintf("next-hop %q is not an IPv4 address", nh))
+		}
+	}
+	for _, ifname := range cfg.Interfaces {
+		if ifname == "" || strings.Contains(ifname, ",") {
+			problems = append(problems, fmt.Sprintf("invalid interface name %q", ifname))
+		}
+	}
+	if cfg.Distance < 0 || cfg.Distance > 255 {
+		problems = append(problems, fmt.Sprintf("distance %d is outside 0-255", cfg.Distance))
+	}
+	return problems
+}
+
+// staticRouteParams returns the intent params of a static route. The path
+// lists are stored as CSV, positions preserved.
+func staticRouteParams(vrfName, prefix string, cfg StaticRouteConfig) map[string]string {
+	params := map[string]string{
+		sonic.FieldVRF:    vrfName,
+		sonic.FieldPrefix: prefix,
+	}
+	if len(cfg.NextHops) > 0 {
+		params[sonic.FieldNextHop] = strings.Join(cfg.NextHops, ",")
+	}
+	if len(cfg.Interfaces) > 0 {
+		params[sonic.FieldIfname] = strings.Join(cfg.Interfaces, ",")
+	}
+	if cfg.Blackhole {
+		params[sonic.FieldBlackhole] = "true"
+	}
+	if cfg.Distance > 0 {
+		params[sonic.FieldMetric] = strconv.Itoa(cfg.Distance)
+	}
+	return params
+}
+
+// staticRouteParents returns the intent parents of a route in vrfName.
+// The default VRF always exists — no CreateVRF intent — so its routes hang
+// off the device.
+func staticRouteParents(vrfName string) []string {
+	if vrfName == "" || vrfName == "default" {
+		return []string{"device"}
+	}
+	return []string{"vrf|" + vrfName}
+}
+
+// describePaths renders a route's paths for a log line.
+func (cfg StaticRouteConfig) describePaths() string {
+	if cfg.Blackhole {
+		return "blackhole"
+	}
+	if len(cfg.NextHops) == 0 {
+		return "dev " + strings.Join(cfg.Interfaces, ",")
+	}
+	return "via " + strings.Join(cfg.NextHops, ",")
+}
+
+// AddStaticRoute adds a static route to a VRF through a single next-hop.
 func (n *Node) AddStaticRoute(ctx context.Context, vrfName, prefix, nextHop string, metric int) (*ChangeSet, error) {
+	var cfg StaticRouteConfig
+	if nextHop != "" {
+		cfg.NextHops = []string{nextHop}
+	}
+	cfg.Distance = metric
+	return n.AddStaticRouteMulti(ctx, vrfName, prefix, cfg)
+}
+
+// AddStaticRouteMulti adds a static route to a VRF with the paths in cfg:
+// several next-hops (ECMP), interface-only or gateway-via-interface paths,
+// or a blackhole.
+func (n *Node) AddStaticRouteMulti(ctx context.Context, vrfName, prefix string, cfg StaticRouteConfig) (*ChangeSet, error) {
+	ifnames := make([]string, 0, len(cfg.Interfaces))
+	for _, ifname := range cfg.Interfaces {
+		ifnames = append(ifnames, n.normalizeInterfaceName(ifname))
+	}
+	cfg.Interfaces = ifnames
 	cs, err := n.op(sonic.OpAddStaticRoute, prefix, ChangeAdd,
 		func(pc *PreconditionChecker) {
 			pc.Check(vrfName == "" || vrfName == "default" || n.GetIntent("vrf|"+vrfName) != nil,
 				"VRF must exist", fmt.Sprintf("VRF '%s' not found", vrfName))
+			if problems := staticRouteProblems(cfg); len(problems) > 0 {
+				pc.Check(false, "valid static route paths", strings.Join(problems, "; "))
+			}
+			// Replay may run before the interface a path leaves through.
+			if !n.reconstructing {
+				for _, ifname := range cfg.Interfaces {
+					pc.RequireInterfaceExists(ifname)
+				}
+			}
 		},
-		func() []sonic.Entry { return createStaticRouteConfig(vrfName, prefix, nextHop, metric) },
+		func() []sonic.Entry { return createStaticRouteConfig(vrfName, prefix, cfg) },
 		"device.remove-static-route")
 	if err != nil {
 		return nil, err
 	}
-	intentParams := map[string]string{
-		sonic.FieldVRF:     vrfName,
-		sonic.FieldPrefix:  prefix,
-		sonic.FieldNextHop: nextHop,
-	}
-	if metric > 0 {
-		intentParams[sonic.FieldMetric] = strconv.Itoa(metric)
-	}
-	// Default VRF always exists — no CreateVRF intent. Use device as parent.
-	var routeParents []string
-	if vrfName == "" || vrfName == "default" {
-		routeParents = []string{"device"}
-	} else {
-		routeParents = []string{"vrf|" + vrfName}
-	}
-	if err := n.writeIntent(cs, sonic.OpAddStaticRoute, "route|"+vrfName+"|"+prefix, intentParams, routeParents); err != nil {
+	if err := n.writeIntent(cs, sonic.OpAddStaticRoute, "route|"+vrfName+"|"+prefix,
+		staticRouteParams(vrfName, prefix, cfg), staticRouteParents(vrfName)); err != nil {
 		return nil, err
 	}
 	cs.OperationParams = map[string]string{"vrf": vrfName, "prefix": prefix}
-	util.WithDevice(n.name).Infof("Added static route %s via %s (VRF %s)", prefix, nextHop, vrfName)
+	util.WithDevice(n.name).Infof("Added static route %s %s (VRF %s)", prefix, cfg.describePaths(), vrfName)
 	return cs, nil
 }
 
@@ -299,23 +418,36 @@ func (n *Node) AddStaticRoute(ctx context.Context, vrfName, prefix, nextHop stri
 // DEL and ADD).
 //
 // Reads the existing intent record (keyed by vrf + prefix), validates,
-// and emits a single ChangeSet that deletes the prior STATIC_ROUTE entry
-// and writes the new one. The intent record is replaced via writeIntent's
-// idempotent path (DEL+HSET — #228 fix) so dropped params don't ghost.
+// and emits a single ChangeSet that replaces the STATIC_ROUTE entry with
+// cfg's paths — the same shapes AddStaticRouteMulti takes, so an ECMP,
+// interface or blackhole route stays one. cfg is the whole new route, not a
+// patch. The intent record is replaced via writeIntent's idempotent path
+// (DEL+HSET — #228 fix) so dropped params don't ghost.
 //
 // Per §47 (CONFIG_DB Composite Key Is the Identity) the key
 // (vrf, prefix) is immutable. Issue #227.
-func (n *Node) UpdateStaticRoute(ctx context.Context, vrfName, prefix, nextHop string, metric int) (*ChangeSet, error) {
+func (n *Node) UpdateStaticRoute(ctx context.Context, vrfName, prefix string, cfg StaticRouteConfig) (*ChangeSet, error) {
 	resource := "route|" + vrfName + "|" + prefix
 	existing := n.GetIntent(resource)
 	if existing == nil {
 		return nil, fmt.Errorf("static route %s not found in VRF %s", prefix, vrfName)
 	}
+	ifnames := make([]string, 0, len(cfg.Interfaces))
+	for _, ifname := range cfg.Interfaces {
+		ifnames = append(ifnames, n.normalizeInterfaceName(ifname))
+	}
+	cfg.Interfaces = ifnames
 
 	cs, err := n.op(sonic.OpUpdateStaticRoute, prefix, ChangeAdd,
 		func(pc *PreconditionChecker) {
 			pc.Check(vrfName == "" || vrfName == "default" || n.GetIntent("vrf|"+vrfName) != nil,
 				"VRF must exist", fmt.Sprintf("VRF '%s' not found", vrfName))
+			if problems := staticRouteProblems(cfg); len(problems) > 0 {
+				pc.Check(false, "valid static route paths", strings.Join(problems, "; "))
+			}
+			for _, ifname := range cfg.Interfaces {
+				pc.RequireInterfaceExists(ifname)
+			}
 		},
 		func() []sonic.Entry { return nil })
 	if err != nil {
@@ -327,28 +459,15 @@ func (n *Node) UpdateStaticRoute(ctx context.Context, vrfName, prefix, nextHop s
 	// so fpmsyncd never sees a FIB gap (§48).
 	cs.Replace(n,
 		deleteStaticRouteConfig(vrfName, prefix),
-		createStaticRouteConfig(vrfName, prefix, nextHop, metric))
+		createStaticRouteConfig(vrfName, prefix, cfg))
 
-	intentParams := map[string]string{
-		sonic.FieldVRF:     vrfName,
-		sonic.FieldPrefix:  prefix,
-		sonic.FieldNextHop: nextHop,
-	}
-	if metric > 0 {
-		intentParams[sonic.FieldMetric] = strconv.Itoa(metric)
-	}
-	var routeParents []string
-	if vrfName == "" || vrfName == "default" {
-		routeParents = []string{"device"}
-	} else {
-		routeParents = []string{"vrf|" + vrfName}
-	}
-	if err := n.writeIntent(cs, sonic.OpAddStaticRoute, resource, intentParams, routeParents); err != nil {
+	if err := n.writeIntent(cs, sonic.OpAddStaticRoute, resource,
+		staticRouteParams(vrfName, prefix, cfg), staticRouteParents(vrfName)); err != nil {
 		return nil, err
 	}
 
 	cs.OperationParams = map[string]string{"vrf": vrfName, "prefix": prefix}
-	util.WithDevice(n.name).Infof("Updated static route %s via %s (VRF %s)", prefix, nextHop, vrfName)
+	util.WithDevice(n.name).Infof("Updated static route %s %s (VRF %s)", prefix, cfg.describePaths(), vrfName)
 	return cs, nil
 }
 
@@ -368,6 +487,283 @@ func (n *Node) RemoveStaticRoute(ctx context.Context, vrfName, prefix string) (*
 	return cs, nil
 }
 
+// ============================================================================
+// Route Leaking (inter-VRF)
+// ============================================================================
+
+// routeLeakResource returns the intent key of the route leak into dstVRF.
+// BGP imports from one VRF per address family, so a VRF has at most one leak
+// into it and the leak is keyed by its destination.
+func routeLeakResource(dstVRF string) string {
+	return "route-leak|" + dstVRF
+}
+
+// RouteLeak is one inter-VRF route leak as its intent records it.
+type RouteLeak struct {
+	SrcVRF   string
+	DstVRF   string
+	Prefixes []string // empty: every route in SrcVRF is leaked
+	RouteMap string   // import route-map limiting the leak to Prefixes; empty when unfiltered
+}
+
+// AddRouteLeak leaks routes from srcVRF into dstVRF — the shared-services
+// pattern, where tenant VRFs import a services VRF's routes. It sets
+// 'import vrf <src>' in dstVRF's BGP ipv4 unicast address family; with
+// prefixes, an import route-map limits the leak to exactly those prefixes.
+// srcVRF may be "default". dstVRF must carry a BGP instance (BGP_GLOBALS,
+// written by BindIPVPN). IPv4 only.
+//
+// The intent records dstVRF as vrf_name, so UnbindIPVPN refuses to remove the
+// BGP instance the leak is configured in. Intent-idempotent: adding the same
+// leak again returns an empty ChangeSet; a different leak into the same VRF
+// must be removed first.
+func (n *Node) AddRouteLeak(ctx context.Context, srcVRF, dstVRF string, prefixes []string) (*ChangeSet, error) {
+	resource := routeLeakResource(dstVRF)
+	params := map[string]string{
+		sonic.FieldVRFName: dstVRF,
+		sonic.FieldSrcVRF:  srcVRF,
+	}
+	if len(prefixes) > 0 {
+		params[sonic.FieldPrefixes] = strings.Join(prefixes, ",")
+	}
+	if existing := n.GetIntent(resource); existing != nil && maps.Equal(existing.Params, params) {
+		return NewChangeSet(n.name, "device."+sonic.OpAddRouteLeak), nil
+	}
+
+	proj := n.Projection()
+	_, hasBGP := proj["BGP_GLOBALS"][dstVRF]
+	var badPrefixes []string
+	for _, p := range prefixes {
+		if !util.IsValidIPv4CIDR(p) {
+			badPrefixes = append(badPrefixes, p)
+		}
+	}
+	pc := n.precondition(sonic.OpAddRouteLeak, resource).
+		RequireVRFExists(dstVRF).
+		Check(srcVRF == "default" || n.GetIntent("vrf|"+srcVRF) != nil,
+			"source VRF must exist", fmt.Sprintf("VRF '%s' not found", srcVRF)).
+		Check(srcVRF != dstVRF, "distinct VRFs", fmt.Sprintf("cannot leak VRF %s into itself", dstVRF)).
+		Check(hasBGP, "destination VRF has a BGP instance",
+			fmt.Sprintf("VRF %s has no BGP instance — bind it to an IP-VPN first", dstVRF)).
+		Check(len(badPrefixes) == 0, "IPv4 prefixes",
+			fmt.Sprintf("not IPv4 prefixes: %s", strings.Join(badPrefixes, ", ")))
+	if existing := n.GetIntent(resource); existing != nil {
+		pc.Check(false, "no other route leak into the VRF",
+			fmt.Sprintf("VRF %s already imports from %s — remove that leak first", dstVRF, existing.Params[sonic.FieldSrcVRF]))
+	}
+	if err := pc.Result(); err != nil {
+		return nil, err
+	}
+
+	policy, routeMap := createRouteLeakPolicyConfig(srcVRF, dstVRF, prefixes)
+	cs := NewChangeSet(n.name, "device."+sonic.OpAddRouteLeak)
+	cs.ReverseOp = "device." + sonic.OpRemoveRouteLeak
+	cs.OperationParams = params
+	parents := []string{"vrf|" + dstVRF}
+	if srcVRF != "default" {
+		parents = append(parents, "vrf|"+srcVRF)
+	}
+	if err := n.writeIntent(cs, sonic.OpAddRouteLeak, resource, params, parents); err != nil {
+		return nil, err
+	}
+	// The route-map exists before the AF references it.
+	cs.Adds(policy)
+	cs.Replace(n, nil, importVRFConfig(proj["BGP_GLOBALS_AF"][BGPGlobalsAFKey(dstVRF, "ipv4_unicast")], dstVRF, srcVRF, routeMap))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Added route leak %s → %s (%s)", srcVRF, dstVRF, leakScope(prefixes))
+	return cs, nil
+}
+
+// RemoveRouteLeak removes the route leak into dstVRF. Reverse of AddRouteLeak
+// (§15): the import fields come off dstVRF's ipv4 unicast address family
+// (the row goes too if the leak created it), then the import route-map.
+func (n *Node) RemoveRouteLeak(ctx context.Context, dstVRF string) (*ChangeSet, error) {
+	resource := routeLeakResource(dstVRF)
+	if err := n.precondition(sonic.OpRemoveRouteLeak, resource).Result(); err != nil {
+		return nil, err
+	}
+	intent := n.GetIntent(resource)
+	if intent == nil {
+		return nil, fmt.Errorf("no route leak into VRF %s", dstVRF)
+	}
+	// Read the leak from the intent record, not CONFIG_DB — the route-map's
+	// content-hashed name is re-derived from the recorded prefixes.
+	srcVRF := intent.Params[sonic.FieldSrcVRF]
+	prefixes := splitPrefixes(intent.Params[sonic.FieldPrefixes])
+	policy, _ := createRouteLeakPolicyConfig(srcVRF, dstVRF, prefixes)
+
+	cs := NewChangeSet(n.name, "device."+sonic.OpRemoveRouteLeak)
+	cs.OperationParams = map[string]string{sonic.FieldVRFName: dstVRF}
+	af := clearImportVRFConfig(n.Projection()["BGP_GLOBALS_AF"][BGPGlobalsAFKey(dstVRF, "ipv4_unicast")], dstVRF)
+	if len(af[0].Fields) == 0 {
+		cs.Deletes(af)
+	} else {
+		cs.Replace(n, nil, af)
+	}
+	cs.Deletes(policy)
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Removed route leak %s → %s", srcVRF, dstVRF)
+	return cs, nil
+}
+
+// GetRouteLeaks returns the route leaks configured on this device, sorted by
+// destination VRF.
+func (n *Node) GetRouteLeaks() []RouteLeak {
+	var leaks []RouteLeak
+	for _, intent := range n.IntentsByOp(sonic.OpAddRouteLeak) {
+		leak := RouteLeak{
+			SrcVRF:   intent.Params[sonic.FieldSrcVRF],
+			DstVRF:   intent.Params[sonic.FieldVRFName],
+			Prefixes: splitPrefixes(intent.Params[sonic.FieldPrefixes]),
+		}
+		_, leak.RouteMap = createRouteLeakPolicyConfig(leak.SrcVRF, leak.DstVRF, leak.Prefixes)
+		leaks = append(leaks, leak)
+	}
+	sort.Slice(leaks, func(i, j int) bool { return leaks[i].DstVRF < leaks[j].DstVRF })
+	return leaks
+}
+
+// splitPrefixes parses a recorded prefixes param (comma-separated).
+func splitPrefixes(csv string) []string {
+	if csv == "" {
+		return nil
+	}
+	return strings.Split(csv, ",")
+}
+
+func leakScope(prefixes []string) string {
+	if len(prefixes) == 0 {
+		return "all routes"
+	}
+	return strings.Join(prefixes, ", ")
+}
+
+// ============================================================================
+// VRF Route Targets
+// ============================================================================
+
+// vrfRouteTargetsResource returns the intent key of a VRF's standalone route
+// targets.
+func vrfRouteTargetsResource(vrfName string) string {
+	return "vrf-route-targets|" + vrfName
+}
+
+// SetVRFRouteTargets sets the EVPN route targets of an existing VRF in place —
+// adjusting what it imports and exports without re-applying the service or
+// re-binding the IP-VPN that wrote them. The VRF's BGP_GLOBALS_EVPN_RT rows
+// become exactly importRTs and exportRTs (an RT in both is written once as
+// "both"); RTs on the VRF that are in neither list are removed. The VRF must
+// carry a BGP instance (BGP_GLOBALS, written by BindIPVPN). RTs are ASN:NN or
+// IPv4:NN.
+//
+// The intent records the VRF as vrf_name, so UnbindIPVPN refuses to tear the
+// VRF's EVPN config down underneath it. Intent-idempotent: setting the same
+// lists again returns an empty ChangeSet; different lists replace them.
+func (n *Node) SetVRFRouteTargets(ctx context.Context, vrfName string, importRTs, exportRTs []string) (*ChangeSet, error) {
+	resource := vrfRouteTargetsResource(vrfName)
+	params := map[string]string{sonic.FieldVRFName: vrfName}
+	if len(importRTs) > 0 {
+		params[sonic.FieldImportRTs] = strings.Join(importRTs, ",")
+	}
+	if len(exportRTs) > 0 {
+		params[sonic.FieldExportRTs] = strings.Join(exportRTs, ",")
+	}
+	if existing := n.GetIntent(resource); existing != nil && maps.Equal(existing.Params, params) {
+		return NewChangeSet(n.name, "device."+sonic.OpSetVRFRouteTargets), nil
+	}
+
+	_, hasBGP := n.Projection()["BGP_GLOBALS"][vrfName]
+	var badRTs []string
+	for _, rt := range append(append([]string{}, importRTs...), exportRTs...) {
+		if !util.IsValidRouteTarget(rt) {
+			badRTs = append(badRTs, rt)
+		}
+	}
+	if err := n.precondition(sonic.OpSetVRFRouteTargets, resource).
+		RequireVRFExists(vrfName).
+		Check(len(importRTs)+len(exportRTs) > 0, "route targets given",
+			"at least one import or export route target is required — clear-vrf-route-targets restores the IP-VPN's").
+		Check(hasBGP, "VRF has a BGP instance",
+			fmt.Sprintf("VRF %s has no BGP instance — bind it to an IP-VPN first", vrfName)).
+		Check(len(badRTs) == 0, "route target format",
+			fmt.Sprintf("not route targets (ASN:NN or IPv4:NN): %s", strings.Join(badRTs, ", "))).
+		Result(); err != nil {
+		return nil, err
+	}
+
+	cs := NewChangeSet(n.name, "device."+sonic.OpSetVRFRouteTargets)
+	cs.ReverseOp = "device." + sonic.OpClearVRFRouteTargets
+	cs.OperationParams = params
+	if err := n.writeIntent(cs, sonic.OpSetVRFRouteTargets, resource, params, []string{"vrf|" + vrfName}); err != nil {
+		return nil, err
+	}
+	cs.Replace(n, n.vrfRouteTargetRows(vrfName), routeTargetConfig(vrfName, importRTs, exportRTs))
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Set route targets on VRF %s (import %v, export %v)", vrfName, importRTs, exportRTs)
+	return cs, nil
+}
+
+// ClearVRFRouteTargets removes the route targets SetVRFRouteTargets set on a
+// VRF. Reverse of SetVRFRouteTargets (§15): the VRF goes back to the route
+// targets of the IP-VPN bound to it, recorded on the bind-ipvpn intent, or to
+// none when no IP-VPN is bound.
+func (n *Node) ClearVRFRouteTargets(ctx context.Context, vrfName string) (*ChangeSet, error) {
+	resource := vrfRouteTargetsResource(vrfName)
+	if err := n.precondition(sonic.OpClearVRFRouteTargets, resource).Result(); err != nil {
+		return nil, err
+	}
+	if n.GetIntent(resource) == nil {
+		return nil, fmt.Errorf("no route targets set on VRF %s", vrfName)
+	}
+	bound := n.boundRouteTargets(vrfName)
+
+	cs := NewChangeSet(n.name, "device."+sonic.OpClearVRFRouteTargets)
+	cs.OperationParams = map[string]string{sonic.FieldVRFName: vrfName}
+	cs.Replace(n, n.vrfRouteTargetRows(vrfName), routeTargetConfig(vrfName, bound, bound))
+	if err := n.deleteIntent(cs, resource); err != nil {
+		return nil, err
+	}
+	if err := n.render(cs); err != nil {
+		return nil, err
+	}
+	util.WithDevice(n.name).Infof("Cleared route targets on VRF %s (%d restored from IP-VPN)", vrfName, len(bound))
+	return cs, nil
+}
+
+// boundRouteTargets returns the route targets of the IP-VPN bound to vrfName,
+// read from its bind-ipvpn intent; nil when no IP-VPN is bound.
+func (n *Node) boundRouteTargets(vrfName string) []string {
+	for _, intent := range n.IntentsByOp(sonic.OpBindIPVPN) {
+		if intent.Params[sonic.FieldVRFName] == vrfName {
+			return parseRouteTargets(intent.Params[sonic.FieldRouteTargets])
+		}
+	}
+	return nil
+}
+
+// vrfRouteTargetRows returns the VRF's BGP_GLOBALS_EVPN_RT rows as they stand
+// in the projection, sorted by key.
+func (n *Node) vrfRouteTargetRows(vrfName string) []sonic.Entry {
+	prefix := vrfName + "|L2VPN_EVPN|"
+	var rows []sonic.Entry
+	for key, fields := range n.Projection()["BGP_GLOBALS_EVPN_RT"] {
+		if strings.HasPrefix(key, prefix) {
+			rows = append(rows, sonic.Entry{Table: "BGP_GLOBALS_EVPN_RT", Key: key, Fields: fields})
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Key < rows[j].Key })
+	return rows
+}
+
 // ============================================================================
 // VRF Data Types and Queries
 // ============================================================================
@@ -464,6 +860,19 @@ func (n *Node) GetRouteASIC(ctx context.Context, vrf, prefix string) (*sonic.Rou
 	return n.conn.AsicDBClient().GetRouteASIC(vrf, prefix, n.configDB)
 }
 
+// GetNextHopGroups reads every next-hop group programmed in ASIC_DB, with
+// members and route ref counts. Returns an error wrapping
+// sonic.ErrASICDBUnavailable on a device without a reachable ASIC_DB (VPP).
+func (n *Node) GetNextHopGroups(ctx context.Context) ([]sonic.NextHopGroupEntry, error) {
+	if !n.connected {
+		return nil, util.ErrNotConnected
+	}
+	if n.conn == nil || n.conn.AsicDBClient() == nil {
+		return nil, fmt.Errorf("%w on %s", sonic.ErrASICDBUnavailable, n.name)
+	}
+	return n.conn.AsicDBClient().GetNextHopGroups()
+}
+
 // GetNeighbor reads a neighbor (ARP/NDP) entry from STATE_DB.
 // Returns nil (not error) if the entry does not exist.
 func (n *Node) GetNeighbor(ctx context.Context, iface, ip string) (*sonic.NeighEntry, error) {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"strings"
 	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 )
 
 // TestUnbindIPVPN_SelfReferenceExcluded guards the reference scan in
@@ -58,3 +60,221 @@ func TestUnbindIPVPN_SelfReferenceExcluded(t *testing.T) {
 		}
 	})
 }
+
+// routeLeakDevice returns a device with a shared-services VRF and a tenant
+// VRF whose BGP instance (as BindIPVPN leaves it) the leak is configured in.
+func routeLeakDevice() *Node {
+	n := testDevice()
+	n.configDB.NewtronIntent["device"] = map[string]string{
+		"operation": "setup-device", "state": "actuated",
+		"_children": "vrf|Vrf_SHARED,vrf|Vrf_CUST",
+	}
+	for _, vrf := range []string{"Vrf_SHARED", "Vrf_CUST"} {
+		n.configDB.NewtronIntent["vrf|"+vrf] = map[string]string{
+			"operation": "create-vrf", "name": vrf, "state": "actuated", "_parents": "device",
+		}
+	}
+	n.configDB.BGPGlobals["Vrf_CUST"] = sonic.BGPGlobalsEntry{LocalASN: "64512", RouterID: "10.255.0.1"}
+	n.configDB.BGPGlobalsAF["Vrf_CUST|ipv4_unicast"] = sonic.BGPGlobalsAFEntry{RedistributeConnected: "true"}
+	return n
+}
+
+func TestRouteLeak_AddRemove(t *testing.T) {
+	ctx := context.Background()
+	n := routeLeakDevice()
+
+	cs, err := n.AddRouteLeak(ctx, "Vrf_SHARED", "Vrf_CUST", []string{"10.50.0.0/24"})
+	if err != nil {
+		t.Fatalf("AddRouteLeak: %v", err)
+	}
+	wantPolicy, routeMap := createRouteLeakPolicyConfig("Vrf_SHARED", "Vrf_CUST", []string{"10.50.0.0/24"})
+	for _, e := range wantPolicy {
+		assertChange(t, cs, e.Table, e.Key, ChangeAdd)
+	}
+	af := assertChange(t, cs, "BGP_GLOBALS_AF", "Vrf_CUST|ipv4_unicast", ChangeReplace)
+	assertField(t, af, "import_vrf", "Vrf_SHARED")
+	assertField(t, af, "import_vrf_route_map", routeMap)
+	assertField(t, af, "redistribute_connected", "true") // the AF's own fields survive
+	assertChange(t, cs, "NEWTRON_INTENT", "route-leak|Vrf_CUST", ChangeAdd)
+
+	leaks := n.GetRouteLeaks()
+	if len(leaks) != 1 || leaks[0].SrcVRF != "Vrf_SHARED" || leaks[0].DstVRF != "Vrf_CUST" || leaks[0].RouteMap != routeMap {
+		t.Fatalf("GetRouteLeaks = %+v", leaks)
+	}
+
+	// The leak is a child of both VRFs.
+	if _, err := n.DeleteVRF(ctx, "Vrf_SHARED", false); err == nil {
+		t.Error("DeleteVRF of the source VRF should be refused while the leak exists")
+	}
+
+	// The same leak again is intent-idempotent.
+	cs, err = n.AddRouteLeak(ctx, "Vrf_SHARED", "Vrf_CUST", []string{"10.50.0.0/24"})
+	if err != nil || !cs.IsEmpty() {
+		t.Fatalf("repeated AddRouteLeak: cs=%v err=%v, want empty ChangeSet", cs, err)
+	}
+
+	cs, err = n.RemoveRouteLeak(ctx, "Vrf_CUST")
+	if err != nil {
+		t.Fatalf("RemoveRouteLeak: %v", err)
+	}
+	af = assertChange(t, cs, "BGP_GLOBALS_AF", "Vrf_CUST|ipv4_unicast", ChangeReplace)
+	if _, ok := af.Fields["import_vrf"]; ok {
+		t.Errorf("import_vrf still set after RemoveRouteLeak: %v", af.Fields)
+	}
+	assertField(t, af, "redistribute_connected", "true")
+	assertNoChangeOfType(t, cs, "BGP_GLOBALS_AF", "Vrf_CUST|ipv4_unicast", ChangeDelete)
+	for _, e := range wantPolicy {
+		assertChange(t, cs, e.Table, e.Key, ChangeDelete)
+	}
+	assertChange(t, cs, "NEWTRON_INTENT", "route-leak|Vrf_CUST", ChangeDelete)
+	if leaks := n.GetRouteLeaks(); len(leaks) != 0 {
+		t.Errorf("GetRouteLeaks after remove = %+v", leaks)
+	}
+}
+
+// A leak that had to create the ipv4 unicast AF row removes it again.
+func TestRouteLeak_RemoveDeletesCreatedAF(t *testing.T) {
+	ctx := context.Background()
+	n := routeLeakDevice()
+	delete(n.configDB.BGPGlobalsAF, "Vrf_CUST|ipv4_unicast")
+
+	cs, err := n.AddRouteLeak(ctx, "default", "Vrf_CUST", nil)
+	if err != nil {
+		t.Fatalf("AddRouteLeak: %v", err)
+	}
+	af := assertChange(t, cs, "BGP_GLOBALS_AF", "Vrf_CUST|ipv4_unicast", ChangeReplace)
+	assertField(t, af, "import_vrf", "default")
+	if _, ok := af.Fields["import_vrf_route_map"]; ok {
+		t.Errorf("unfiltered leak set a route-map: %v", af.Fields)
+	}
+
+	cs, err = n.RemoveRouteLeak(ctx, "Vrf_CUST")
+	if err != nil {
+		t.Fatalf("RemoveRouteLeak: %v", err)
+	}
+	assertChange(t, cs, "BGP_GLOBALS_AF", "Vrf_CUST|ipv4_unicast", ChangeDelete)
+}
+
+func TestRouteLeak_Preconditions(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		setup    func(n *Node)
+		src, dst string
+		prefixes []string
+		wantErr  string
+	}{
+		{name: "unknown source", src: "Vrf_NOPE", dst: "Vrf_CUST", wantErr: "VRF 'Vrf_NOPE' not found"},
+		{name: "unknown destination", src: "Vrf_SHARED", dst: "Vrf_NOPE", wantErr: "VRF 'Vrf_NOPE' not found"},
+		{name: "into itself", src: "Vrf_CUST", dst: "Vrf_CUST", wantErr: "into itself"},
+		{name: "no BGP instance", src: "Vrf_CUST", dst: "Vrf_SHARED", wantErr: "no BGP instance"},
+		{name: "bad prefix", src: "Vrf_SHARED", dst: "Vrf_CUST", prefixes: []string{"2001:db8::/32"}, wantErr: "not IPv4 prefixes"},
+		{
+			name: "second source",
+			setup: func(n *Node) {
+				if _, err := n.AddRouteLeak(ctx, "default", "Vrf_CUST", nil); err != nil {
+					t.Fatalf("first leak: %v", err)
+				}
+			},
+			src: "Vrf_SHARED", dst: "Vrf_CUST", wantErr: "already imports from default",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := routeLeakDevice()
+			if tt.setup != nil {
+				tt.setup(n)
+			}
+			_, err := n.AddRouteLeak(ctx, tt.src, tt.dst, tt.prefixes)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("AddRouteLeak(%s → %s) error = %v, want %q", tt.src, tt.dst, err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := routeLeakDevice().RemoveRouteLeak(ctx, "Vrf_CUST"); err == nil {
+		t.Error("RemoveRouteLeak with no leak should fail")
+	}
+}
+
+// routeTargetDevice returns routeLeakDevice with IP-VPN CUST bound to
+// Vrf_CUST, carrying route target 65000:100 as BindIPVPN writes it.
+func routeTargetDevice() *Node {
+	n := routeLeakDevice()
+	n.configDB.NewtronIntent["vrf|Vrf_CUST"]["_children"] = "ipvpn|CUST"
+	n.configDB.NewtronIntent["ipvpn|CUST"] = map[string]string{
+		"operation": "bind-ipvpn", "state": "actuated", "_parents": "vrf|Vrf_CUST",
+		"ipvpn": "CUST", "vrf_name": "Vrf_CUST", "l3vni": "10100", "route_targets": "65000:100",
+	}
+	n.configDB.BGPGlobalsEVPNRT["Vrf_CUST|L2VPN_EVPN|65000:100"] = sonic.BGPGlobalsEVPNRTEntry{RouteTargetType: "both"}
+	return n
+}
+
+func TestVRFRouteTargets_SetClear(t *testing.T) {
+	ctx := context.Background()
+	n := routeTargetDevice()
+
+	cs, err := n.SetVRFRouteTargets(ctx, "Vrf_CUST", []string{"65000:200", "10.0.0.1:5"}, []string{"65000:200", "65000:300"})
+	if err != nil {
+		t.Fatalf("SetVRFRouteTargets: %v", err)
+	}
+	assertField(t, assertChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|65000:200", ChangeReplace), "route-target-type", "both")
+	assertField(t, assertChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|10.0.0.1:5", ChangeReplace), "route-target-type", "import")
+	assertField(t, assertChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|65000:300", ChangeReplace), "route-target-type", "export")
+	assertChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|65000:100", ChangeDelete)
+	assertChange(t, cs, "NEWTRON_INTENT", "vrf-route-targets|Vrf_CUST", ChangeAdd)
+
+	// The same lists again are intent-idempotent.
+	cs, err = n.SetVRFRouteTargets(ctx, "Vrf_CUST", []string{"65000:200", "10.0.0.1:5"}, []string{"65000:200", "65000:300"})
+	if err != nil || !cs.IsEmpty() {
+		t.Fatalf("repeated SetVRFRouteTargets: cs=%v err=%v, want empty ChangeSet", cs, err)
+	}
+
+	// New lists replace the set ones; an unchanged row is not rewritten.
+	cs, err = n.SetVRFRouteTargets(ctx, "Vrf_CUST", []string{"65000:200"}, []string{"65000:200"})
+	if err != nil {
+		t.Fatalf("second SetVRFRouteTargets: %v", err)
+	}
+	assertNoChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|65000:200")
+	assertChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|65000:300", ChangeDelete)
+
+	// The set RTs hold the VRF's EVPN config in place.
+	if _, err := n.UnbindIPVPN(ctx, "CUST"); err == nil {
+		t.Error("UnbindIPVPN should be refused while route targets are set on the VRF")
+	}
+
+	cs, err = n.ClearVRFRouteTargets(ctx, "Vrf_CUST")
+	if err != nil {
+		t.Fatalf("ClearVRFRouteTargets: %v", err)
+	}
+	assertField(t, assertChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|65000:100", ChangeReplace), "route-target-type", "both")
+	assertChange(t, cs, "BGP_GLOBALS_EVPN_RT", "Vrf_CUST|L2VPN_EVPN|65000:200", ChangeDelete)
+	assertChange(t, cs, "NEWTRON_INTENT", "vrf-route-targets|Vrf_CUST", ChangeDelete)
+}
+
+func TestVRFRouteTargets_Preconditions(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name            string
+		vrf             string
+		imports, export []string
+		wantErr         string
+	}{
+		{name: "unknown VRF", vrf: "Vrf_NOPE", imports: []string{"65000:1"}, wantErr: "VRF 'Vrf_NOPE' not found"},
+		{name: "no BGP instance", vrf: "Vrf_SHARED", imports: []string{"65000:1"}, wantErr: "no BGP instance"},
+		{name: "no route targets", vrf: "Vrf_CUST", wantErr: "at least one"},
+		{name: "bad format", vrf: "Vrf_CUST", imports: []string{"65000:1"}, export: []string{"target:1"}, wantErr: "not route targets (ASN:NN or IPv4:NN): target:1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := routeTargetDevice().SetVRFRouteTargets(ctx, tt.vrf, tt.imports, tt.export)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("SetVRFRouteTargets(%s) error = %v, want %q", tt.vrf, err, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := routeTargetDevice().ClearVRFRouteTargets(ctx, "Vrf_CUST"); err == nil {
+		t.Error("ClearVRFRouteTargets with nothing set should fail")
+	}
+}
@@ -0,0 +1,142 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ============================================================================
+// VXLAN tunnel counters — encap/decap packet and byte counts per tunnel, with
+// the VNIs each tunnel carries. Pure observation (§4). This file owns the
+// COUNTERS_TUNNEL_NAME_MAP / SAI_TUNNEL_STAT_* vocabulary (§28).
+//
+// SAI counts per tunnel object, not per VNI: the local source VTEP is one
+// tunnel, and under EVPN each remote VTEP gets its own (STATE_DB
+// VXLAN_TUNNEL_TABLE "EVPN_<ip>"). A tunnel's VNIs come from APPL_DB — the
+// source tunnel carries every locally mapped VNI (VXLAN_TUNNEL_MAP_TABLE), a
+// remote tunnel the VNIs learned for that VTEP (VXLAN_REMOTE_VNI_TABLE).
+//
+// Tunnel counters are only polled with the TUNNEL counter group enabled
+// (set-counter-polling TUNNEL); a tunnel without a COUNTERS row reports
+// Counted=false rather than zeros that look like "no traffic".
+// ============================================================================
+
+// VXLANStat is one VXLAN tunnel's counters. Encap is traffic sent into the
+// tunnel (SAI OUT), decap traffic received from it (SAI IN).
+type VXLANStat struct {
+	Tunnel       string
+	SourceIP     string
+	RemoteVTEP   string // "" for the local source tunnel
+	VNIs         []int
+	Counted      bool
+	EncapPackets uint64
+	EncapBytes   uint64
+	DecapPackets uint64
+	DecapBytes   uint64
+}
+
+// GetVXLANStats reads every VXLAN tunnel's counters, sorted by tunnel name.
+func (n *Node) GetVXLANStats(ctx context.Context) ([]VXLANStat, error) {
+	tunnels, err := n.OperDBTable(ctx, "STATE_DB", "VXLAN_TUNNEL_TABLE")
+	if err != nil {
+		return nil, fmt.Errorf("reading STATE_DB VXLAN_TUNNEL_TABLE: %w", err)
+	}
+	localMaps, err := n.OperDBTable(ctx, "APPL_DB", "VXLAN_TUNNEL_MAP_TABLE")
+	if err != nil {
+		return nil, fmt.Errorf("reading APPL_DB VXLAN_TUNNEL_MAP_TABLE: %w", err)
+	}
+	remoteVNIs, err := n.OperDBTable(ctx, "APPL_DB", "VXLAN_REMOTE_VNI_TABLE")
+	if err != nil {
+		return nil, fmt.Errorf("reading APPL_DB VXLAN_REMOTE_VNI_TABLE: %w", err)
+	}
+	nameMap, err := n.OperDBEntry(ctx, "COUNTERS_DB", "COUNTERS_TUNNEL_NAME_MAP", "")
+	if err != nil {
+		return nil, fmt.Errorf("reading COUNTERS_DB COUNTERS_TUNNEL_NAME_MAP: %w", err)
+	}
+	counters := make(map[string]map[string]string, len(nameMap))
+	for name, oid := range nameMap {
+		raw, err := n.OperDBEntry(ctx, "COUNTERS_DB", "COUNTERS", oid)
+		if err != nil {
+			return nil, fmt.Errorf("reading COUNTERS_DB COUNTERS:%s (%s): %w", oid, name, err)
+		}
+		counters[name] = raw
+	}
+	return buildVXLANStats(tunnels, localMaps, remoteVNIs, counters), nil
+}
+
+// buildVXLANStats joins the tunnel, VNI-map, and counter reads. A tunnel
+// appears if either STATE_DB or the counter name map knows it.
+func buildVXLANStats(tunnels, localMaps, remoteVNIs, counters map[string]map[string]string) []VXLANStat {
+	names := map[string]bool{}
+	for name := range tunnels {
+		if name != "" {
+			names[name] = true
+		}
+	}
+	for name := range counters {
+		names[name] = true
+	}
+
+	// Local maps are keyed "<tunnel>:map_<vni>_<vlan>"; remote VNIs
+	// "<vlan>:<remote ip>".
+	localByTunnel := map[string][]int{}
+	for key, f := range localMaps {
+		tunnel, _, _ := strings.Cut(key, ":")
+		if vni, err := strconv.Atoi(f["vni"]); err == nil {
+			localByTunnel[tunnel] = append(localByTunnel[tunnel], vni)
+		}
+	}
+	remoteByIP := map[string][]int{}
+	for key, f := range remoteVNIs {
+		_, ip, _ := strings.Cut(key, ":")
+		if vni, err := strconv.Atoi(f["vni"]); err == nil {
+			remoteByIP[ip] = append(remoteByIP[ip], vni)
+		}
+	}
+
+	stats := make([]VXLANStat, 0, len(names))
+	for name := range names {
+		t := tunnels[name]
+		st := VXLANStat{Tunnel: name, SourceIP: t["src_ip"], RemoteVTEP: t["dst_ip"]}
+		if st.RemoteVTEP == "" && strings.HasPrefix(name, "EVPN_") {
+			st.RemoteVTEP = strings.TrimPrefix(name, "EVPN_")
+		}
+		if st.RemoteVTEP == "" {
+			st.VNIs = uniqueSortedVNIs(localByTunnel[name])
+		} else {
+			st.VNIs = uniqueSortedVNIs(remoteByIP[st.RemoteVTEP])
+		}
+		if raw, ok := counters[name]; ok && len(raw) > 0 {
+			st.Counted = true
+			st.EncapPackets = counterUint(raw["SAI_TUNNEL_STAT_OUT_PACKETS"])
+			st.EncapBytes = counterUint(raw["SAI_TUNNEL_STAT_OUT_OCTETS"])
+			st.DecapPackets = counterUint(raw["SAI_TUNNEL_STAT_IN_PACKETS"])
+			st.DecapBytes = counterUint(raw["SAI_TUNNEL_STAT_IN_OCTETS"])
+		}
+		stats = append(stats, st)
+	}
+	sort.Slice(stats, func(a, b int) bool { return stats[a].Tunnel < stats[b].Tunnel })
+	return stats
+}
+
+func uniqueSortedVNIs(vnis []int) []int {
+	if len(vnis) == 0 {
+		return nil
+	}
+	sort.Ints(vnis)
+	out := vnis[:1]
+	for _, v := range vnis[1:] {
+		if v != out[len(out)-1] {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func counterUint(v string) uint64 {
+	u, _ := strconv.ParseUint(v, 10, 64)
+	return u
+}
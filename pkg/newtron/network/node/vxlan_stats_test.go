@@ -0,0 +1,42 @@
+package node
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildVXLANStats(t *testing.T) {
+	tunnels := map[string]map[string]string{
+		"vtep1":         {"src_ip": "10.0.0.1", "operstatus": "up"},
+		"EVPN_10.0.0.2": {"src_ip": "10.0.0.1", "dst_ip": "10.0.0.2", "tnl_src": "EVPN"},
+		"EVPN_10.0.0.3": {"src_ip": "10.0.0.1", "tnl_src": "EVPN"},
+		"":              {"flat": "hash"},
+	}
+	localMaps := map[string]map[string]string{
+		"vtep1:map_10200_Vlan200": {"vni": "10200", "vlan": "Vlan200"},
+		"vtep1:map_10100_Vlan100": {"vni": "10100", "vlan": "Vlan100"},
+	}
+	remoteVNIs := map[string]map[string]string{
+		"Vlan100:10.0.0.2": {"vni": "10100"},
+		"Vlan200:10.0.0.2": {"vni": "10200"},
+		"Vlan100:10.0.0.3": {"vni": "10100"},
+	}
+	counters := map[string]map[string]string{
+		"EVPN_10.0.0.2": {
+			"SAI_TUNNEL_STAT_OUT_PACKETS": "120", "SAI_TUNNEL_STAT_OUT_OCTETS": "15360",
+			"SAI_TUNNEL_STAT_IN_PACKETS": "118", "SAI_TUNNEL_STAT_IN_OCTETS": "15104",
+		},
+		"vtep1": {"SAI_TUNNEL_STAT_IN_PACKETS": "N/A"},
+	}
+
+	got := buildVXLANStats(tunnels, localMaps, remoteVNIs, counters)
+	want := []VXLANStat{
+		{Tunnel: "EVPN_10.0.0.2", SourceIP: "10.0.0.1", RemoteVTEP: "10.0.0.2", VNIs: []int{10100, 10200},
+			Counted: true, EncapPackets: 120, EncapBytes: 15360, DecapPackets: 118, DecapBytes: 15104},
+		{Tunnel: "EVPN_10.0.0.3", SourceIP: "10.0.0.1", RemoteVTEP: "10.0.0.3", VNIs: []int{10100}},
+		{Tunnel: "vtep1", SourceIP: "10.0.0.1", VNIs: []int{10100, 10200}, Counted: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("buildVXLANStats =\n  %+v\nwant\n  %+v", got, want)
+	}
+}
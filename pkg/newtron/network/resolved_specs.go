@@ -97,6 +97,12 @@ func (r *ResolvedSpecs) GetPrefixList(name string) ([]string, error) {
 	return r.network.GetPrefixList(name)
 }
 
+// GetServiceDefaults returns the network's defaults for a service.
+// service_defaults is network-scope only — there is no zone/node override.
+func (r *ResolvedSpecs) GetServiceDefaults(name string) *spec.ServiceDefaults {
+	return r.network.GetServiceDefaults(name)
+}
+
 func (r *ResolvedSpecs) GetPlatform(name string) (*spec.PlatformSpec, error) {
 	return r.network.GetPlatform(name)
 }
@@ -113,3 +119,22 @@ func (r *ResolvedSpecs) FindMACVPNByVNI(vni int) (string, *spec.MACVPNSpec) {
 	// (CreateMACVPN writes to n.spec.MACVPNs after merge was built).
 	return r.network.FindMACVPNByVNI(vni)
 }
+
+// ListServices returns the names of every service visible to this device:
+// the merged overrides plus any added at network level after the merge.
+func (r *ResolvedSpecs) ListServices() []string {
+	r.mu.RLock()
+	seen := make(map[string]bool, len(r.merged.Services))
+	for name := range r.merged.Services {
+		seen[name] = true
+	}
+	r.mu.RUnlock()
+	for _, name := range r.network.ListServices() {
+		seen[name] = true
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	return names
+}
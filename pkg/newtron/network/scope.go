@@ -89,3 +89,32 @@ func (n *Network) ListScopedSpecs() ([]ScopedSpec, error) {
 	})
 	return out, nil
 }
+
+// LintSpecs runs spec.Lint over the whole hierarchy — network, every zone, and
+// every node nodeSpec. A nodeSpec that fails to load is an error, as in
+// ListScopedSpecs: a lint that silently skipped a node would report a clean
+// spec it never read.
+func (n *Network) LintSpecs() ([]spec.LintIssue, error) {
+	nodes := map[string]*spec.NodeSpec{}
+	for _, name := range n.ListNodeSpecs() {
+		nodeSpec, err := n.GetNodeSpec(name)
+		if err != nil {
+			return nil, fmt.Errorf("loading node spec %q for lint: %w", name, err)
+		}
+		nodes[name] = nodeSpec
+	}
+
+	mu := n.locks.lock(keyNetworkSpec)
+	mu.RLock()
+	defer mu.RUnlock()
+	return spec.Lint(n.spec, n.loader.Zones(), nodes), nil
+}
+
+// SpecReferenceGraph builds the reference graph of the network-scope specs —
+// the definitions every zone and node falls through to.
+func (n *Network) SpecReferenceGraph() *spec.ReferenceGraph {
+	mu := n.locks.lock(keyNetworkSpec)
+	mu.RLock()
+	defer mu.RUnlock()
+	return spec.BuildReferenceGraph(&n.spec.OverridableSpecs)
+}
@@ -0,0 +1,137 @@
+package network
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// topology_roles.go — selecting a device's interfaces by their role in the
+// topology ("all uplinks") instead of by name.
+//
+// Roles are derived, never authored: topology.json links say which interface
+// faces which peer, and each peer's tier follows from its node spec — a host
+// platform is a server, an EVPN route reflector is a spine, any other switch is
+// a leaf. The same derivation deriveBGPNeighbors uses for overlay peering, so
+// "spine" means one thing everywhere.
+
+// Interface roles accepted by InterfacesByRole.
+const (
+	RoleUplink   = "uplink"   // faces a higher tier (leaf → spine)
+	RoleDownlink = "downlink" // faces a lower tier (leaf → server, spine → leaf)
+	RoleFabric   = "fabric"   // switch-to-switch, either direction
+)
+
+// Device tiers, lowest first. Only their order matters.
+const (
+	tierServer = iota
+	tierLeaf
+	tierSpine
+)
+
+// InterfacesByRole returns the device's interfaces whose topology link gives
+// them the named role, sorted by name. An interface with no link has no role.
+// Errors when no topology is loaded, the device is not in it, or the role is
+// unknown.
+func (n *Network) InterfacesByRole(device, role string) ([]string, error) {
+	topo := n.GetTopology()
+	if topo == nil {
+		return nil, fmt.Errorf("no topology loaded")
+	}
+	if !topo.HasDevice(device) {
+		return nil, fmt.Errorf("device '%s' not found in topology", device)
+	}
+	match, err := roleMatcher(role)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []string
+	for _, link := range topo.Links {
+		for _, pair := range [][2]string{{link.A, link.Z}, {link.Z, link.A}} {
+			selfDev, selfIntf, ok1 := strings.Cut(pair[0], ":")
+			peerDev, _, ok2 := strings.Cut(pair[1], ":")
+			if !ok1 || !ok2 || selfDev != device {
+				continue
+			}
+			if match(n.deviceTier(device), n.deviceTier(peerDev)) {
+				out = append(out, selfIntf)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// MTUPlan derives a per-interface MTU from topology roles: fabricMTU on every
+// switch-to-switch link end, serverMTU on every switch interface facing a
+// host. A zero MTU leaves that role out. Returns device → interface → MTU for
+// the switches with at least one assignment; hosts are never included.
+func (n *Network) MTUPlan(fabricMTU, serverMTU int) (map[string]map[string]int, error) {
+	topo := n.GetTopology()
+	if topo == nil {
+		return nil, fmt.Errorf("no topology loaded")
+	}
+	plan := map[string]map[string]int{}
+	for _, device := range topo.DeviceNames() {
+		if n.IsHostDevice(device) {
+			continue
+		}
+		fabric, err := n.InterfacesByRole(device, RoleFabric)
+		if err != nil {
+			return nil, err
+		}
+		downlinks, err := n.InterfacesByRole(device, RoleDownlink)
+		if err != nil {
+			return nil, err
+		}
+		mtus := map[string]int{}
+		isFabric := map[string]bool{}
+		for _, intf := range fabric {
+			isFabric[intf] = true
+			if fabricMTU != 0 {
+				mtus[intf] = fabricMTU
+			}
+		}
+		// Server-facing: downlinks that are not fabric links.
+		for _, intf := range downlinks {
+			if !isFabric[intf] && serverMTU != 0 {
+				mtus[intf] = serverMTU
+			}
+		}
+		if len(mtus) > 0 {
+			plan[device] = mtus
+		}
+	}
+	return plan, nil
+}
+
+// roleMatcher returns the test a link must pass, given the tiers of its local
+// and remote ends, for its local interface to have role.
+func roleMatcher(role string) (func(self, peer int) bool, error) {
+	switch role {
+	case RoleUplink:
+		return func(self, peer int) bool { return peer > self }, nil
+	case RoleDownlink:
+		return func(self, peer int) bool { return peer < self }, nil
+	case RoleFabric:
+		return func(self, peer int) bool { return self != tierServer && peer != tierServer }, nil
+	default:
+		return nil, fmt.Errorf("unknown interface role '%s' (want %s)", role,
+			strings.Join([]string{RoleUplink, RoleDownlink, RoleFabric}, ", "))
+	}
+}
+
+// deviceTier classifies a topology device. A device whose node spec fails to
+// load is treated as a leaf — the neutral tier, so it is neither anyone's
+// uplink nor downlink target by accident.
+func (n *Network) deviceTier(name string) int {
+	if n.IsHostDevice(name) {
+		return tierServer
+	}
+	nodeSpec, err := n.loadNodeSpec(name)
+	if err == nil && nodeSpec.EVPN != nil && nodeSpec.EVPN.RouteReflector {
+		return tierSpine
+	}
+	return tierLeaf
+}
@@ -0,0 +1,113 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// newRoleTestNetwork builds a spine–leaf–host fabric. Tiers come from the
+// node specs: spine1 is an EVPN route reflector, host1 runs a host platform.
+func newRoleTestNetwork(t *testing.T) *Network {
+	t.Helper()
+	dir := t.TempDir()
+	write := func(rel, body string) {
+		t.Helper()
+		p := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("network.json", `{"schema_version": "1.0"}`)
+	write("zones/amer.json", `{}`)
+	write("nodes/spine1.json", `{"mgmt_ip": "10.0.0.1", "loopback_ip": "10.255.0.1", "zone": "amer", "evpn": {"route_reflector": true}}`)
+	write("nodes/leaf1.json", `{"mgmt_ip": "10.0.0.2", "loopback_ip": "10.255.0.2", "zone": "amer"}`)
+	write("nodes/leaf2.json", `{"mgmt_ip": "10.0.0.3", "loopback_ip": "10.255.0.3", "zone": "amer"}`)
+	write("nodes/host1.json", `{"mgmt_ip": "10.0.0.9", "platform": "linux-host"}`)
+	write("topology.json", `{
+		"version": "1.0",
+		"nodes": {"spine1": {}, "leaf1": {}, "leaf2": {}, "host1": {}},
+		"links": [
+			{"a": "spine1:Ethernet0", "z": "leaf1:Ethernet0"},
+			{"a": "spine1:Ethernet4", "z": "leaf2:Ethernet0"},
+			{"a": "leaf1:Ethernet8", "z": "leaf2:Ethernet8"},
+			{"a": "host1:eth1", "z": "leaf1:Ethernet4"}
+		]
+	}`)
+	platforms := map[string]*spec.PlatformSpec{"linux-host": {Name: "linux-host", DeviceType: "host"}}
+
+	n, err := NewNetwork(dir, "", nil, nil, platforms)
+	if err != nil {
+		t.Fatalf("NewNetwork: %v", err)
+	}
+	return n
+}
+
+// TestInterfacesByRole checks each role from both a leaf's and the spine's
+// point of view.
+func TestInterfacesByRole(t *testing.T) {
+	n := newRoleTestNetwork(t)
+
+	tests := []struct {
+		device, role string
+		want         []string
+	}{
+		{"leaf1", RoleUplink, []string{"Ethernet0"}},
+		{"leaf1", RoleDownlink, []string{"Ethernet4"}},
+		{"leaf1", RoleFabric, []string{"Ethernet0", "Ethernet8"}},
+		{"spine1", RoleUplink, nil},
+		{"spine1", RoleDownlink, []string{"Ethernet0", "Ethernet4"}},
+		{"host1", RoleUplink, []string{"eth1"}},
+		{"host1", RoleFabric, nil},
+	}
+	for _, tt := range tests {
+		got, err := n.InterfacesByRole(tt.device, tt.role)
+		if err != nil {
+			t.Errorf("%s %s: %v", tt.device, tt.role, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %s = %v, want %v", tt.device, tt.role, got, tt.want)
+		}
+	}
+
+	if _, err := n.InterfacesByRole("leaf1", "sideways"); err == nil {
+		t.Error("unknown role should error")
+	}
+	if _, err := n.InterfacesByRole("nope", RoleUplink); err == nil {
+		t.Error("device not in topology should error")
+	}
+}
+
+// TestMTUPlan checks fabric links get the fabric MTU on both ends and only
+// the leaf's host-facing port gets the server MTU.
+func TestMTUPlan(t *testing.T) {
+	n := newRoleTestNetwork(t)
+
+	plan, err := n.MTUPlan(9100, 1500)
+	if err != nil {
+		t.Fatalf("MTUPlan: %v", err)
+	}
+	want := map[string]map[string]int{
+		"spine1": {"Ethernet0": 9100, "Ethernet4": 9100},
+		"leaf1":  {"Ethernet0": 9100, "Ethernet8": 9100, "Ethernet4": 1500},
+		"leaf2":  {"Ethernet0": 9100, "Ethernet8": 9100},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Errorf("MTUPlan(9100, 1500) = %v, want %v", plan, want)
+	}
+
+	plan, err = n.MTUPlan(0, 1500)
+	if err != nil {
+		t.Fatalf("MTUPlan: %v", err)
+	}
+	if want := map[string]map[string]int{"leaf1": {"Ethernet4": 1500}}; !reflect.DeepEqual(plan, want) {
+		t.Errorf("MTUPlan(0, 1500) = %v, want %v", plan, want)
+	}
+}
@@ -3,7 +3,10 @@ package newtron
 import (
 	"context"
 	"fmt"
+	"io"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/auth"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
@@ -110,6 +113,30 @@ func (n *Node) ConfigDBSnapshot(ctx context.Context, ownedOnly bool) (sonic.RawC
 	return n.internal.ConfigDBSnapshot(ctx, ownedOnly)
 }
 
+// ExportConfigDB writes the device's entire CONFIG_DB to w as config_db.json
+// — SONiC's own file shape, byte-stable across export → import → export.
+func (n *Node) ExportConfigDB(ctx context.Context, w io.Writer) error {
+	return n.internal.ExportConfigDB(ctx, w)
+}
+
+// RenderConfigDB writes the CONFIG_DB the node's intents render to as
+// config_db.json, offline — what ExportConfigDB would return once they are
+// applied to a device with an empty CONFIG_DB. Never touches the device.
+func (n *Node) RenderConfigDB(w io.Writer) error {
+	return n.internal.RenderConfigDB(w)
+}
+
+// ImportConfigDB applies a config_db.json document to the device's CONFIG_DB.
+// Every table the document names ends up holding exactly its rows; other
+// tables are untouched. Unchanged rows are not written, so re-importing an
+// export is a no-op.
+func (n *Node) ImportConfigDB(ctx context.Context, r io.Reader) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	return n.internal.ImportConfigDB(ctx, r)
+}
+
 // IntentSnapshot returns the device's NEWTRON_INTENT records in canonical form
 // (every record, DAG-link CSVs sorted) — the substrate for "is the device back
 // where it started?" before/after comparisons. NEWTRON_INTENT is drift-excluded
@@ -120,6 +147,36 @@ func (n *Node) IntentSnapshot(ctx context.Context) (map[string]map[string]string
 	return n.internal.IntentSnapshot(ctx)
 }
 
+// ImportIntent reads a device configured without newtron and reports which
+// interfaces carry config an existing service definition would reproduce,
+// each with the apply-service step to adopt it, and which service-like config
+// matched nothing. Read-only: nothing is written to the device or topology.
+func (n *Node) ImportIntent(ctx context.Context) (*ImportedIntent, error) {
+	imp, err := n.internal.ImportIntent(ctx)
+	if err != nil {
+		return nil, err
+	}
+	convert := func(in []node.ImportedBinding) []ImportedBinding {
+		out := make([]ImportedBinding, 0, len(in))
+		for _, b := range in {
+			out = append(out, ImportedBinding{
+				Interface:   b.Interface,
+				ServiceType: b.ServiceType,
+				Service:     b.Service,
+				Step:        b.Step,
+				Entries:     b.Entries,
+				Note:        b.Note,
+			})
+		}
+		return out
+	}
+	return &ImportedIntent{
+		Device:       imp.Device,
+		Recognized:   convert(imp.Recognized),
+		Unrecognized: convert(imp.Unrecognized),
+	}, nil
+}
+
 // OperDBSnapshot reads an entire operational DB (STATE_DB, APPL_DB,
 // COUNTERS_DB, ASIC_DB) as table → key → fields — the device's runtime
 // state, observed as-is (§1, §4). CONFIG_DB is not served here; it has its
@@ -158,6 +215,22 @@ func validateOperDBName(dbName string) error {
 	return nil
 }
 
+// UplinkInterfaces returns this node's interfaces that face a higher tier in
+// the topology (see Network.InterfacesByRole).
+func (n *Node) UplinkInterfaces() ([]string, error) {
+	return n.net.InterfacesByRole(n.internal.Name(), InterfaceRoleUplink)
+}
+
+// DownlinkInterfaces returns this node's interfaces that face a lower tier.
+func (n *Node) DownlinkInterfaces() ([]string, error) {
+	return n.net.InterfacesByRole(n.internal.Name(), InterfaceRoleDownlink)
+}
+
+// FabricInterfaces returns this node's switch-to-switch interfaces.
+func (n *Node) FabricInterfaces() ([]string, error) {
+	return n.net.InterfacesByRole(n.internal.Name(), InterfaceRoleFabric)
+}
+
 // BindsService reports whether this Node has at least one actuated
 // apply-service intent for the named service. Used by Network.ServiceProjection
 // (and api handlers iterating over nodes) to skip non-binders cheaply before
@@ -242,6 +315,13 @@ func (n *Node) Interface(name string) (*Interface, error) {
 	return &Interface{node: n, internal: intf}, nil
 }
 
+// ResolveInterfaceAlias resolves a user-supplied interface name — a short
+// form (Eth0) or a platform breakout alias from the PORT table (etp1a) — to
+// the device's canonical name. ok reports whether that interface exists.
+func (n *Node) ResolveInterfaceAlias(name string) (canonical string, ok bool) {
+	return n.internal.ResolveInterfaceAlias(name)
+}
+
 // QueryConfigDB reads a CONFIG_DB entry by table and key.
 // Returns an empty map (not error) if the entry does not exist.
 // Falls through to the projection when no device connection exists (loopback mode).
@@ -317,8 +397,76 @@ func (n *Node) PendingCount() int {
 	return count
 }
 
+// paramDefaults converts the service_defaults a changeset used to the
+// public shape.
+func paramDefaults(cs *node.ChangeSet) []ParamDefault {
+	var out []ParamDefault
+	for _, d := range cs.Defaults {
+		out = append(out, ParamDefault{Interface: d.Interface, Param: d.Param, Value: d.Value})
+	}
+	return out
+}
+
+// pendingResult is the dry-run view of the pending changesets: preview, typed
+// changes and the service defaults used, nothing applied.
+func (n *Node) pendingResult() *WriteResult {
+	result := &WriteResult{
+		Preview:     n.PendingPreview(),
+		ChangeCount: n.PendingCount(),
+	}
+	for _, cs := range n.pending {
+		result.Changes = append(result.Changes, cs.Changes...)
+		result.Defaults = append(result.Defaults, paramDefaults(cs)...)
+	}
+	return result
+}
+
+// checkChangeGuard refuses the pending changesets when, together, they exceed
+// the network's change_guard limits. The bundle is what one write commits, so
+// it is measured as a whole.
+func (n *Node) checkChangeGuard() error {
+	if n.net == nil {
+		return nil
+	}
+	g := n.net.internal.GetChangeGuard()
+	if g == nil || (g.MaxChanges == 0 && g.MaxTables == 0) {
+		return nil
+	}
+	changes := 0
+	seen := map[string]bool{}
+	var tables []string
+	for _, cs := range n.pending {
+		changes += len(cs.Changes)
+		for _, c := range cs.Changes {
+			if !seen[c.Table] {
+				seen[c.Table] = true
+				tables = append(tables, c.Table)
+			}
+		}
+	}
+	if (g.MaxChanges == 0 || changes <= g.MaxChanges) && (g.MaxTables == 0 || len(tables) <= g.MaxTables) {
+		return nil
+	}
+	sort.Strings(tables)
+	return &ChangeGuardError{
+		Device:     n.internal.Name(),
+		Changes:    changes,
+		Tables:     tables,
+		MaxChanges: g.MaxChanges,
+		MaxTables:  g.MaxTables,
+		Result:     n.pendingResult(),
+	}
+}
+
 // Commit applies all pending changesets, verifies them, and clears the pending list.
 func (n *Node) Commit(ctx context.Context) (*WriteResult, error) {
+	return n.commit(ctx, func(cs *node.ChangeSet, opts node.ApplyOpts) error {
+		return cs.ApplyWithOpts(n.internal, opts)
+	})
+}
+
+// commit holds Commit against any apply, so tests can inject a failing one.
+func (n *Node) commit(ctx context.Context, apply func(cs *node.ChangeSet, opts node.ApplyOpts) error) (*WriteResult, error) {
 	if len(n.pending) == 0 {
 		return &WriteResult{}, nil
 	}
@@ -328,15 +476,27 @@ func (n *Node) Commit(ctx context.Context) (*WriteResult, error) {
 		result.Preview += cs.Preview()
 		result.ChangeCount += len(cs.Changes)
 		result.Changes = append(result.Changes, cs.Changes...)
+		result.Defaults = append(result.Defaults, paramDefaults(cs)...)
 	}
 
 	// Apply all pending changesets. DeviceOps entries accumulated by each
 	// cs.Apply / cs.Verify are aggregated onto the public WriteResult so
 	// callers see the full per-substrate-op timeline for the whole bundle.
-	for _, cs := range n.pending {
-		if err := cs.Apply(n.internal); err != nil {
+	// A failed apply leaves nothing half-done: the changeset being written
+	// and every one applied before it are rolled back, newest first, and
+	// the pending list is dropped so a later Commit cannot re-apply them.
+	for i, cs := range n.pending {
+		if err := apply(cs, node.ApplyOpts{Ctx: ctx}); err != nil {
+			for _, done := range n.pending[:i] {
+				result.DeviceOps = append(result.DeviceOps, done.DeviceOps...)
+			}
 			result.DeviceOps = append(result.DeviceOps, cs.DeviceOps...)
-			return result, fmt.Errorf("apply failed: %w", err)
+			applied := n.pending[:i+1]
+			n.pending = nil
+			if rbErr := n.rollbackApplied(applied, result); rbErr != nil {
+				return result, fmt.Errorf("apply failed: %w (rollback failed: %v)", err, rbErr)
+			}
+			return result, fmt.Errorf("apply failed, rolled back: %w", err)
 		}
 	}
 	result.Applied = true
@@ -347,6 +507,7 @@ func (n *Node) Commit(ctx context.Context) (*WriteResult, error) {
 	for _, cs := range n.pending {
 		if err := cs.Verify(n.internal); err != nil {
 			result.DeviceOps = append(result.DeviceOps, cs.DeviceOps...)
+			n.pending = nil
 			return result, fmt.Errorf("verify failed: %w", err)
 		}
 		if cs.Verification != nil {
@@ -385,6 +546,26 @@ func (n *Node) Commit(ctx context.Context) (*WriteResult, error) {
 	return result, nil
 }
 
+// rollbackApplied undoes the given changesets newest first by applying each
+// one's Inverse, appending the restoring writes to result.DeviceOps. A
+// changeset with nothing to invert (a cancelled apply that already rolled
+// itself back) is skipped. Stops at the first failure: later restores could
+// depend on the one that failed.
+func (n *Node) rollbackApplied(applied []*node.ChangeSet, result *WriteResult) error {
+	for i := len(applied) - 1; i >= 0; i-- {
+		inv, err := applied[i].Inverse()
+		if err != nil {
+			continue
+		}
+		err = inv.Apply(n.internal)
+		result.DeviceOps = append(result.DeviceOps, inv.DeviceOps...)
+		if err != nil {
+			return fmt.Errorf("rolling back %s: %w", applied[i].Operation, err)
+		}
+	}
+	return nil
+}
+
 // ============================================================================
 // Execute (one-shot pattern)
 // ============================================================================
@@ -397,8 +578,15 @@ func (n *Node) Commit(ctx context.Context) (*WriteResult, error) {
 // fn, captures the preview, then restores the intent DB. The projection is
 // left dirty — execute() rebuilds it at the start of the next operation.
 //
-// If opts.NoSave is true, skips config save after commit.
+// If opts.NoSave is true, skips config save after commit. An apply that
+// fails is rolled back on the device, and the intent DB is restored to match.
 func (n *Node) Execute(ctx context.Context, opts ExecOpts, fn func(ctx context.Context) error) (*WriteResult, error) {
+	return n.execute(ctx, opts, fn, n.Commit)
+}
+
+// execute holds Execute against any commit, so tests can inject one whose
+// apply fails.
+func (n *Node) execute(ctx context.Context, opts ExecOpts, fn func(ctx context.Context) error, commit func(ctx context.Context) (*WriteResult, error)) (*WriteResult, error) {
 	if err := n.Lock(ctx); err != nil {
 		return nil, err
 	}
@@ -416,20 +604,27 @@ func (n *Node) Execute(ctx context.Context, opts ExecOpts, fn func(ctx context.C
 
 	if !opts.Execute {
 		// Dry-run: capture preview, typed changes, then restore intent DB.
-		result := &WriteResult{
-			Preview:     n.PendingPreview(),
-			ChangeCount: n.PendingCount(),
-		}
-		for _, cs := range n.pending {
-			result.Changes = append(result.Changes, cs.Changes...)
-		}
+		result := n.pendingResult()
 		n.internal.RestoreIntentDB(snapshot)
 		n.pending = nil
 		return result, nil
 	}
 
-	result, err := n.Commit(ctx)
+	if !opts.Force {
+		if err := n.checkChangeGuard(); err != nil {
+			n.internal.RestoreIntentDB(snapshot)
+			n.pending = nil
+			return nil, err
+		}
+	}
+
+	result, err := commit(ctx)
 	if err != nil {
+		if result != nil && !result.Applied {
+			// The apply was rolled back: the device no longer holds what fn
+			// recorded, so neither may the intent DB.
+			n.internal.RestoreIntentDB(snapshot)
+		}
 		return result, err
 	}
 
@@ -500,6 +695,41 @@ func (n *Node) UnconfigureIRB(ctx context.Context, id int) error {
 	return err
 }
 
+// ConfigureDHCPServer serves a VLAN's member ports from SONiC's built-in DHCP
+// server. The VLAN must have an IRB address; ranges must fall inside its
+// subnet, clear of the IRB and gateway addresses, and must not overlap.
+func (n *Node) ConfigureDHCPServer(ctx context.Context, id int, config DHCPServerConfig) error {
+	if err := n.gate(ctx, auth.PermVLANModify, fmt.Sprintf("VLAN%d", id)); err != nil {
+		return err
+	}
+	cs, err := n.internal.ConfigureDHCPServer(ctx, id, config.internal())
+	n.appendPending(cs)
+	return err
+}
+
+// UnconfigureDHCPServer stops serving a VLAN from the built-in DHCP server.
+// Reverse of ConfigureDHCPServer.
+func (n *Node) UnconfigureDHCPServer(ctx context.Context, id int) error {
+	if err := n.gate(ctx, auth.PermVLANModify, fmt.Sprintf("VLAN%d", id)); err != nil {
+		return err
+	}
+	cs, err := n.internal.UnconfigureDHCPServer(ctx, id)
+	n.appendPending(cs)
+	return err
+}
+
+// SetIGMPSnooping turns IGMP snooping on or off for a VLAN. Enabling needs a
+// platform that supports igmp-snooping; either direction is a no-op when the
+// VLAN is already that way.
+func (n *Node) SetIGMPSnooping(ctx context.Context, id int, enable bool) error {
+	if err := n.gate(ctx, auth.PermVLANModify, fmt.Sprintf("VLAN%d", id)); err != nil {
+		return err
+	}
+	cs, err := n.internal.SetIGMPSnooping(ctx, id, enable)
+	n.appendPending(cs)
+	return err
+}
+
 // ============================================================================
 // Device-level write ops — VRF
 // ============================================================================
@@ -514,12 +744,13 @@ func (n *Node) CreateVRF(ctx context.Context, name string, config VRFConfig) err
 	return err
 }
 
-// DeleteVRF deletes a VRF from the device.
-func (n *Node) DeleteVRF(ctx context.Context, name string) error {
+// DeleteVRF deletes a VRF from the device. force unbinds interfaces that
+// set-vrf bound to it without an intent; intent dependents always refuse.
+func (n *Node) DeleteVRF(ctx context.Context, name string, force bool) error {
 	if err := n.gate(ctx, auth.PermVRFDelete, name); err != nil {
 		return err
 	}
-	cs, err := n.internal.DeleteVRF(ctx, name)
+	cs, err := n.internal.DeleteVRF(ctx, name, force)
 	n.appendPending(cs)
 	return err
 }
@@ -564,11 +795,23 @@ func (n *Node) UnbindIPVPN(ctx context.Context, vrfName, ipvpnName string) error
 // Device-level write ops — BGP
 // ============================================================================
 
+// overlayOnly refuses the direct-peer-only fields on an EVPN overlay peer,
+// whose operations have no place to record them.
+func (c BGPNeighborConfig) overlayOnly() error {
+	if c.MaxPrefix != 0 || c.MaxPrefixAction != "" {
+		return fmt.Errorf("max prefix is supported on direct (interface) BGP peers only")
+	}
+	return nil
+}
+
 // AddBGPEVPNPeer adds a loopback BGP neighbor (indirect, multi-hop eBGP).
 func (n *Node) AddBGPEVPNPeer(ctx context.Context, config BGPNeighborConfig) error {
 	if err := n.gate(ctx, auth.PermEVPNPeer, config.NeighborIP); err != nil {
 		return err
 	}
+	if err := config.overlayOnly(); err != nil {
+		return err
+	}
 	cs, err := n.internal.AddBGPEVPNPeer(ctx, config.NeighborIP, config.RemoteAS, config.Description, config.EVPN)
 	n.appendPending(cs)
 	return err
@@ -583,6 +826,9 @@ func (n *Node) UpdateBGPEVPNPeer(ctx context.Context, neighborIP string, config
 	if err := n.gate(ctx, auth.PermEVPNPeer, neighborIP); err != nil {
 		return err
 	}
+	if err := config.overlayOnly(); err != nil {
+		return err
+	}
 	cs, err := n.internal.UpdateBGPEVPNPeer(ctx, neighborIP, config.RemoteAS, config.Description, config.EVPN)
 	n.appendPending(cs)
 	return err
@@ -612,16 +858,29 @@ func (n *Node) AddStaticRoute(ctx context.Context, vrf, prefix, nexthop string,
 	return err
 }
 
-// UpdateStaticRoute atomically mutates fields on an existing static
-// route. Per §47 the key (vrf, prefix) is immutable; to change the
-// prefix, remove and re-add. §15 mirror of AddStaticRoute that closes
-// the forwarding black hole the remove + add sequence exposes today
-// (#227).
-func (n *Node) UpdateStaticRoute(ctx context.Context, vrf, prefix, nexthop string, metric int) error {
+// AddStaticRouteMulti adds a static route to a VRF with several paths —
+// ECMP next-hops, interface-only or gateway-via-interface paths — or as a
+// blackhole.
+func (n *Node) AddStaticRouteMulti(ctx context.Context, vrf, prefix string, config StaticRouteConfig) error {
 	if err := n.gate(ctx, auth.PermVRFRoute, vrf); err != nil {
 		return err
 	}
-	cs, err := n.internal.UpdateStaticRoute(ctx, vrf, prefix, nexthop, metric)
+	cs, err := n.internal.AddStaticRouteMulti(ctx, vrf, prefix, config.internal())
+	n.appendPending(cs)
+	return err
+}
+
+// UpdateStaticRoute atomically replaces an existing static route's paths
+// and metric with config — next-hops (ECMP), interfaces, or a blackhole, as
+// AddStaticRouteMulti takes them. Per §47 the key (vrf, prefix) is
+// immutable; to change the prefix, remove and re-add. §15 mirror of
+// AddStaticRoute that closes the forwarding black hole the remove + add
+// sequence exposes today (#227).
+func (n *Node) UpdateStaticRoute(ctx context.Context, vrf, prefix string, config StaticRouteConfig) error {
+	if err := n.gate(ctx, auth.PermVRFRoute, vrf); err != nil {
+		return err
+	}
+	cs, err := n.internal.UpdateStaticRoute(ctx, vrf, prefix, config.internal())
 	n.appendPending(cs)
 	return err
 }
@@ -636,6 +895,52 @@ func (n *Node) RemoveStaticRoute(ctx context.Context, vrf, prefix string) error
 	return err
 }
 
+// AddRouteLeak leaks routes from srcVRF into dstVRF — every route, or only
+// the given IPv4 prefixes. dstVRF must carry a BGP instance (be bound to an
+// IP-VPN); srcVRF may be "default".
+func (n *Node) AddRouteLeak(ctx context.Context, srcVRF, dstVRF string, prefixes []string) error {
+	if err := n.gate(ctx, auth.PermVRFRoute, dstVRF); err != nil {
+		return err
+	}
+	cs, err := n.internal.AddRouteLeak(ctx, srcVRF, dstVRF, prefixes)
+	n.appendPending(cs)
+	return err
+}
+
+// RemoveRouteLeak removes the route leak into dstVRF, including its import
+// route-map.
+func (n *Node) RemoveRouteLeak(ctx context.Context, dstVRF string) error {
+	if err := n.gate(ctx, auth.PermVRFRoute, dstVRF); err != nil {
+		return err
+	}
+	cs, err := n.internal.RemoveRouteLeak(ctx, dstVRF)
+	n.appendPending(cs)
+	return err
+}
+
+// SetVRFRouteTargets sets the EVPN import and export route targets of an
+// existing VRF in place, without re-applying the service that created it.
+// The VRF must be bound to an IP-VPN; RTs are ASN:NN or IPv4:NN.
+func (n *Node) SetVRFRouteTargets(ctx context.Context, vrf string, importRTs, exportRTs []string) error {
+	if err := n.gate(ctx, auth.PermVRFBind, vrf); err != nil {
+		return err
+	}
+	cs, err := n.internal.SetVRFRouteTargets(ctx, vrf, importRTs, exportRTs)
+	n.appendPending(cs)
+	return err
+}
+
+// ClearVRFRouteTargets removes the route targets SetVRFRouteTargets set,
+// restoring those of the IP-VPN bound to the VRF.
+func (n *Node) ClearVRFRouteTargets(ctx context.Context, vrf string) error {
+	if err := n.gate(ctx, auth.PermVRFBind, vrf); err != nil {
+		return err
+	}
+	cs, err := n.internal.ClearVRFRouteTargets(ctx, vrf)
+	n.appendPending(cs)
+	return err
+}
+
 // ============================================================================
 // Device-level write ops — EVPN
 // ============================================================================
@@ -762,6 +1067,125 @@ func (n *Node) RemovePortChannelMember(ctx context.Context, pc, member string) e
 	return err
 }
 
+// SetLAGHashPolicy selects the packet fields hashed to spread flows across
+// PortChannel members and ECMP next-hops (e.g. SRC_IP, DST_IP, L4_SRC_PORT).
+// Device-global; a later call replaces the field set. Fields the platform
+// cannot hash on are refused.
+func (n *Node) SetLAGHashPolicy(ctx context.Context, fields []string) error {
+	if err := n.gate(ctx, auth.PermLAGModify, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.SetLAGHashPolicy(ctx, fields)
+	n.appendPending(cs)
+	return err
+}
+
+// ClearLAGHashPolicy removes the hash field selection and resets the hash
+// seeds. Reverse of SetLAGHashPolicy.
+func (n *Node) ClearLAGHashPolicy(ctx context.Context) error {
+	if err := n.gate(ctx, auth.PermLAGModify, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.ClearLAGHashPolicy(ctx)
+	n.appendPending(cs)
+	return err
+}
+
+// SetBGPMaxPaths sets the BGP multipath width — how many equal-cost paths
+// the default VRF installs per prefix (FRR 'maximum-paths', eBGP and iBGP).
+// Device-global; a later call replaces the width. A width beyond the
+// platform's ECMP limit is refused; the prior width is reported as the
+// change's from-row.
+func (n *Node) SetBGPMaxPaths(ctx context.Context, paths int) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.SetBGPMaxPaths(ctx, paths)
+	n.appendPending(cs)
+	return err
+}
+
+// ClearBGPMaxPaths removes the multipath width, returning BGP to FRR's
+// default. Reverse of SetBGPMaxPaths.
+func (n *Node) ClearBGPMaxPaths(ctx context.Context) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.ClearBGPMaxPaths(ctx)
+	n.appendPending(cs)
+	return err
+}
+
+// SetCounterPolling turns flex counter polling for one counter group (e.g.
+// ACL, QUEUE, PORT) on or off at the given interval; zero selects the
+// group's SONiC default. Counters in an unpolled group never move, so a
+// test enables polling before asserting on them.
+func (n *Node) SetCounterPolling(ctx context.Context, counterType string, enable bool, interval time.Duration) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.SetCounterPolling(ctx, counterType, enable, interval)
+	n.appendPending(cs)
+	return err
+}
+
+// ClearCounterPolling returns a counter group to its factory polling state.
+// Reverse of SetCounterPolling.
+func (n *Node) ClearCounterPolling(ctx context.Context, counterType string) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.ClearCounterPolling(ctx, counterType)
+	n.appendPending(cs)
+	return err
+}
+
+// SetBanner sets the device's login, MOTD, and logout messages. Setting it
+// again replaces all three; at least one must be non-empty.
+func (n *Node) SetBanner(ctx context.Context, cfg BannerConfig) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.SetBanner(ctx, cfg.internal())
+	n.appendPending(cs)
+	return err
+}
+
+// ClearBanner removes the banner, restoring the image's default messages.
+// Reverse of SetBanner.
+func (n *Node) ClearBanner(ctx context.Context) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.ClearBanner(ctx)
+	n.appendPending(cs)
+	return err
+}
+
+// CreateMirrorSession mirrors ports to a remote collector over ERSPAN. The
+// destination must be reachable in the session's VRF — a connected subnet or
+// static route covering it, or BGP running in the VRF. The same settings
+// again are a no-op; different ones replace the session.
+func (n *Node) CreateMirrorSession(ctx context.Context, name string, cfg MirrorConfig) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.CreateMirrorSession(ctx, name, cfg.internal())
+	n.appendPending(cs)
+	return err
+}
+
+// DeleteMirrorSession removes a mirror session. Reverse of
+// CreateMirrorSession.
+func (n *Node) DeleteMirrorSession(ctx context.Context, name string) error {
+	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
+		return err
+	}
+	cs, err := n.internal.DeleteMirrorSession(ctx, name)
+	n.appendPending(cs)
+	return err
+}
+
 // ============================================================================
 // Device-level write ops — Baseline
 // ============================================================================
@@ -809,15 +1233,58 @@ func (n *Node) DeviceInfo() (*DeviceInfo, error) {
 	}, nil
 }
 
-// CheckBGPSessions checks that all configured BGP neighbors are Established.
-func (n *Node) CheckBGPSessions(ctx context.Context) ([]HealthCheckResult, error) {
-	results, err := n.internal.CheckBGPSessions(ctx)
+// CheckBGPSessions checks that the configured BGP neighbors are Established —
+// all of them, or only those in vrf when it is non-empty.
+func (n *Node) CheckBGPSessions(ctx context.Context, vrf string) ([]HealthCheckResult, error) {
+	results, err := n.internal.CheckBGPSessions(ctx, vrf)
 	if err != nil {
 		return nil, err
 	}
 	out := make([]HealthCheckResult, len(results))
 	for i, r := range results {
-		out[i] = HealthCheckResult{Check: r.Check, Status: r.Status, Message: r.Message}
+		out[i] = HealthCheckResult{Check: r.Check, VRF: r.VRF, Status: r.Status, Message: r.Message}
+	}
+	return out, nil
+}
+
+// GetBGPConvergenceState aggregates the session state of the device's
+// expected BGP neighbors — the ones CheckBGPSessions checks — into counts
+// per state, overall and per VRF.
+func (n *Node) GetBGPConvergenceState(ctx context.Context) (*BGPConvergence, error) {
+	c, err := n.internal.GetBGPConvergenceState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &BGPConvergence{
+		Total:       c.Total,
+		Established: c.Established,
+		States:      c.States,
+		Converged:   c.Converged,
+	}
+	for _, v := range c.VRFs {
+		out.VRFs = append(out.VRFs, BGPVRFConvergence{
+			VRF:         v.VRF,
+			Total:       v.Total,
+			Established: v.Established,
+			States:      v.States,
+			Converged:   v.Converged,
+		})
+	}
+	return out, nil
+}
+
+// GetConfigErrors reports delivered CONFIG_DB entries that the dataplane has
+// not accepted — entries whose owning daemon has not confirmed them in
+// STATE_DB. Drift proves CONFIG_DB holds what was written; this proves the
+// daemons took it.
+func (n *Node) GetConfigErrors(ctx context.Context) ([]ConfigError, error) {
+	errs, err := n.internal.GetConfigErrors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ConfigError, len(errs))
+	for i, e := range errs {
+		out[i] = ConfigError{Table: e.Table, Key: e.Key, StateTable: e.StateTable, Reason: e.Reason}
 	}
 	return out, nil
 }
@@ -840,16 +1307,37 @@ func (n *Node) GetRouteASIC(ctx context.Context, vrf, prefix string) (*RouteEntr
 	return convertRouteEntry(re), nil
 }
 
+// GetNextHopGroups reads the ECMP next-hop groups programmed in ASIC_DB —
+// each group's members and how many routes use it. This is the ground truth
+// for ECMP width. On a platform without ASIC_DB (VPP) it returns an error
+// matching ErrASICDBUnavailable.
+func (n *Node) GetNextHopGroups(ctx context.Context) ([]NextHopGroup, error) {
+	entries, err := n.internal.GetNextHopGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]NextHopGroup, len(entries))
+	for i, e := range entries {
+		g := NextHopGroup{OID: e.OID, RefCount: e.RefCount}
+		for _, m := range e.Members {
+			g.Members = append(g.Members, NextHopGroupMember{Address: m.IP, Weight: m.Weight})
+		}
+		out[i] = g
+	}
+	return out, nil
+}
+
 // convertRouteEntry converts a *sonic.RouteEntry to a *RouteEntry.
 func convertRouteEntry(re *sonic.RouteEntry) *RouteEntry {
 	if re == nil {
 		return nil
 	}
 	entry := &RouteEntry{
-		Prefix:   re.Prefix,
-		VRF:      re.VRF,
-		Protocol: re.Protocol,
-		Source:   string(re.Source),
+		Prefix:    re.Prefix,
+		VRF:       re.VRF,
+		Protocol:  re.Protocol,
+		Source:    string(re.Source),
+		OriginVRF: re.OriginVRF,
 	}
 	for _, nh := range re.NextHops {
 		entry.NextHops = append(entry.NextHops, RouteNextHop{
@@ -877,12 +1365,47 @@ func (n *Node) ExecCommand(ctx context.Context, cmd string) (string, error) {
 	return tunnel.ExecCommand(cmd)
 }
 
-// ConfigReload runs 'config reload -y' on the device via SSH.
-func (n *Node) ConfigReload(ctx context.Context) error {
+// configReloadReadyTimeout bounds the wait for Redis to come back after a
+// config reload restarts the database container.
+const configReloadReadyTimeout = 60 * time.Second
+
+// CanSafelyReload reports whether a config reload would discard nothing and,
+// if it would, why: uncommitted change sets, unsaved intents, another
+// session's lock, or CONFIG_DB rows not in config_db.json.
+func (n *Node) CanSafelyReload(ctx context.Context) (bool, string) {
+	if len(n.pending) > 0 {
+		return false, fmt.Sprintf("%d uncommitted change set(s) pending", len(n.pending))
+	}
+	return n.internal.CanSafelyReload(ctx)
+}
+
+// ConfigReloadStatus is CanSafelyReload as a result value.
+func (n *Node) ConfigReloadStatus(ctx context.Context) *ConfigReloadStatus {
+	safe, reason := n.CanSafelyReload(ctx)
+	return &ConfigReloadStatus{Safe: safe, Reason: reason}
+}
+
+// ConfigReload runs 'config reload -y' on the device via SSH, then waits for
+// Redis to answer again. Unless force is set it first consults
+// CanSafelyReload and refuses — with the reason — a reload that would
+// discard work.
+func (n *Node) ConfigReload(ctx context.Context, force bool) error {
 	if err := n.gate(ctx, auth.PermDeviceWrite, ""); err != nil {
 		return err
 	}
-	return n.internal.ConfigReload(ctx)
+	if !force {
+		if safe, reason := n.CanSafelyReload(ctx); !safe {
+			return util.NewPreconditionError("config reload", n.internal.Name(), reason,
+				"save first, or force the reload to discard it")
+		}
+	}
+	if err := n.internal.ConfigReload(ctx); err != nil {
+		return err
+	}
+	if err := n.internal.PingWithRetry(ctx, configReloadReadyTimeout); err != nil {
+		return fmt.Errorf("waiting for Redis after config reload: %w", err)
+	}
+	return nil
 }
 
 // RestartService restarts a SONiC Docker container by name via SSH.
@@ -910,7 +1433,8 @@ func (n *Node) RefreshBGP(ctx context.Context) error {
 
 // HealthCheck runs health checks on this device using the unified pipeline.
 // Config check: compares the node's projection against actual CONFIG_DB (Drift).
-// Oper checks: BGP session state and wired interface oper-up.
+// Oper checks: BGP session state, wired interface oper-up, and dataplane
+// acceptance of the delivered config (config-apply).
 // Auto-connects transport if not already connected.
 func (n *Node) HealthCheck(ctx context.Context) (*HealthReport, error) {
 	// Config check: projection vs actual CONFIG_DB
@@ -920,7 +1444,7 @@ func (n *Node) HealthCheck(ctx context.Context) (*HealthReport, error) {
 	}
 
 	// BGP operational check
-	bgpResults, err := n.internal.CheckBGPSessions(ctx)
+	bgpResults, err := n.internal.CheckBGPSessions(ctx, "")
 	if err != nil {
 		bgpResults = []node.HealthCheckResult{{
 			Check: "bgp", Status: "fail",
@@ -935,6 +1459,9 @@ func (n *Node) HealthCheck(ctx context.Context) (*HealthReport, error) {
 		intfResults = n.internal.CheckInterfaceOper(wiredInterfaces)
 	}
 
+	// Dataplane acceptance check: every delivered entry confirmed in STATE_DB
+	applyResults := n.internal.CheckConfigApplied(ctx)
+
 	// Build report
 	report := &HealthReport{
 		Device: n.internal.Name(),
@@ -962,10 +1489,13 @@ func (n *Node) HealthCheck(ctx context.Context) (*HealthReport, error) {
 	// Oper checks
 	var operChecks []HealthCheckResult
 	for _, r := range bgpResults {
-		operChecks = append(operChecks, HealthCheckResult{Check: r.Check, Status: r.Status, Message: r.Message})
+		operChecks = append(operChecks, HealthCheckResult{Check: r.Check, VRF: r.VRF, Status: r.Status, Message: r.Message})
 	}
 	for _, r := range intfResults {
-		operChecks = append(operChecks, HealthCheckResult{Check: r.Check, Status: r.Status, Message: r.Message})
+		operChecks = append(operChecks, HealthCheckResult{Check: r.Check, VRF: r.VRF, Status: r.Status, Message: r.Message})
+	}
+	for _, r := range applyResults {
+		operChecks = append(operChecks, HealthCheckResult{Check: r.Check, VRF: r.VRF, Status: r.Status, Message: r.Message})
 	}
 	report.OperChecks = operChecks
 
@@ -982,6 +1512,207 @@ func (n *Node) HealthCheck(ctx context.Context) (*HealthReport, error) {
 	return report, nil
 }
 
+// GetEnvironment returns the device's PSU, fan, and thermal sensor state from
+// STATE_DB, each list sorted by name. Auto-connects transport if not already
+// connected.
+func (n *Node) GetEnvironment(ctx context.Context) (*Environment, error) {
+	env, err := n.internal.GetEnvironment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := &Environment{}
+	for _, p := range env.PSUs {
+		out.PSUs = append(out.PSUs, PSUStatus{
+			Name:      p.Name,
+			Model:     p.Model,
+			Present:   p.Present,
+			PowerGood: p.PowerGood,
+			Voltage:   p.Voltage,
+			Power:     p.Power,
+		})
+	}
+	for _, f := range env.Fans {
+		out.Fans = append(out.Fans, FanStatus{
+			Name:       f.Name,
+			Drawer:     f.Drawer,
+			Present:    f.Present,
+			OK:         f.OK,
+			Speed:      f.Speed,
+			UnderSpeed: f.UnderSpeed,
+			OverSpeed:  f.OverSpeed,
+		})
+	}
+	for _, t := range env.Thermals {
+		out.Thermals = append(out.Thermals, ThermalStatus{
+			Name:                  t.Name,
+			Temperature:           t.Temperature,
+			HighThreshold:         t.HighThreshold,
+			CriticalHighThreshold: t.CriticalHighThreshold,
+			Warning:               t.Warning,
+		})
+	}
+	return out, nil
+}
+
+// GetVXLANStats returns every VXLAN tunnel's encap/decap counters with the
+// VNIs it carries, sorted by tunnel name. Auto-connects transport if not
+// already connected.
+func (n *Node) GetVXLANStats(ctx context.Context) ([]VXLANStat, error) {
+	stats, err := n.internal.GetVXLANStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]VXLANStat, 0, len(stats))
+	for _, st := range stats {
+		out = append(out, VXLANStat{
+			Tunnel:       st.Tunnel,
+			SourceIP:     st.SourceIP,
+			RemoteVTEP:   st.RemoteVTEP,
+			VNIs:         st.VNIs,
+			Counted:      st.Counted,
+			EncapPackets: st.EncapPackets,
+			EncapBytes:   st.EncapBytes,
+			DecapPackets: st.DecapPackets,
+			DecapBytes:   st.DecapBytes,
+		})
+	}
+	return out, nil
+}
+
+// GetBreakoutCapabilities returns the breakout modes each port supports,
+// keyed by parent port, from the platform data narrowed by the device's
+// BREAKOUT_CFG when connected. Ports with no known mode are omitted.
+func (n *Node) GetBreakoutCapabilities(ctx context.Context) (map[string][]string, error) {
+	return n.internal.GetBreakoutCapabilities(ctx)
+}
+
+// GetDHCPLeases returns the built-in DHCP server's leases on a VLAN — every
+// VLAN when id is 0 — sorted by VLAN, then IP. Auto-connects transport if not
+// already connected.
+func (n *Node) GetDHCPLeases(ctx context.Context, id int) ([]DHCPLease, error) {
+	leases, err := n.internal.GetDHCPLeases(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]DHCPLease, 0, len(leases))
+	for _, l := range leases {
+		out = append(out, DHCPLease(l))
+	}
+	return out, nil
+}
+
+// GetIGMPSnooping returns a VLAN's IGMP snooping state and learned multicast
+// groups. Auto-connects transport if not already connected.
+func (n *Node) GetIGMPSnooping(ctx context.Context, id int) (*IGMPState, error) {
+	st, err := n.internal.GetIGMPSnooping(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	out := &IGMPState{
+		VLAN:       st.VLAN,
+		Supported:  st.Supported,
+		Intended:   st.Intended,
+		Configured: st.Configured,
+		Groups:     make([]IGMPGroup, 0, len(st.Groups)),
+	}
+	for _, g := range st.Groups {
+		out.Groups = append(out.Groups, IGMPGroup(g))
+	}
+	return out, nil
+}
+
+// GetFlapHistory returns a physical port's link-flap record: how many times
+// its link went down, and when it last went down and came back.
+func (n *Node) GetFlapHistory(ctx context.Context, iface string) (*FlapHistory, error) {
+	h, err := n.internal.GetFlapHistory(ctx, iface)
+	if err != nil {
+		return nil, err
+	}
+	out := &FlapHistory{
+		Interface: h.Interface,
+		Supported: h.Supported,
+		FlapCount: h.FlapCount,
+		Events:    make([]FlapEvent, 0, len(h.Events)),
+	}
+	for _, ev := range h.Events {
+		out.Events = append(out.Events, FlapEvent(ev))
+	}
+	return out, nil
+}
+
+// GetRunningConfigSection returns one section of the device's running config
+// as text: an FRR section ("frr", "bgp", "route-map", "prefix-list") from
+// vtysh, a CONFIG_DB table (e.g. "PORT") as a config_db.json fragment, or —
+// for "" — the whole CONFIG_DB followed by the FRR running config.
+// Auto-connects transport if not already connected.
+func (n *Node) GetRunningConfigSection(ctx context.Context, section string) (string, error) {
+	return n.internal.GetRunningConfigSection(ctx, section)
+}
+
+// GetARPSuppressionState returns every VLAN's ARP suppression — intended,
+// configured, and applied — sorted by VLAN ID. Auto-connects transport if not
+// already connected.
+func (n *Node) GetARPSuppressionState(ctx context.Context) ([]ARPSuppressionState, error) {
+	states, err := n.internal.GetARPSuppressionState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ARPSuppressionState, 0, len(states))
+	for _, st := range states {
+		out = append(out, ARPSuppressionState(st))
+	}
+	return out, nil
+}
+
+// GetEVPNMACIPRoutes returns the EVPN type-2 routes in vni's BGP table —
+// locally originated and imported — sorted by MAC then IP. Auto-connects
+// transport if not already connected.
+func (n *Node) GetEVPNMACIPRoutes(ctx context.Context, vni int) ([]EVPNMACIPRoute, error) {
+	routes, err := n.internal.GetEVPNMACIPRoutes(ctx, vni)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]EVPNMACIPRoute, 0, len(routes))
+	for _, rt := range routes {
+		out = append(out, EVPNMACIPRoute(rt))
+	}
+	return out, nil
+}
+
+// MaxSyslogLines caps the lines argument of GetSyslogTail.
+const MaxSyslogLines = node.MaxSyslogLines
+
+// GetSyslogTail returns the last lines lines of the device's syslog, oldest
+// first and parsed; with since set, only lines stamped at or after it (device
+// stamps are read as UTC). lines must be 1-MaxSyslogLines. Auto-connects
+// transport if not already connected.
+func (n *Node) GetSyslogTail(ctx context.Context, lines int, since time.Time) ([]LogLine, error) {
+	tail, err := n.internal.GetSyslogTail(ctx, lines, since)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LogLine, 0, len(tail))
+	for _, l := range tail {
+		out = append(out, LogLine{Time: l.Time, Host: l.Host, Severity: l.Severity, Program: l.Program, Message: l.Message})
+	}
+	return out, nil
+}
+
+// SyslogSeverityRank returns the RFC 5424 rank of a syslog severity keyword
+// (0 EMERG … 7 DEBUG, so lower is more severe), or -1 when it is not one.
+// Short forms are accepted: "error" for ERR, "critical" for CRIT.
+func SyslogSeverityRank(severity string) int {
+	switch strings.ToUpper(severity) {
+	case "ERROR":
+		severity = "ERR"
+	case "CRITICAL":
+		severity = "CRIT"
+	case "WARN":
+		severity = "WARNING"
+	}
+	return node.SyslogSeverityRank(severity)
+}
+
 // ============================================================================
 // Status views (read methods)
 // ============================================================================
@@ -1032,6 +1763,15 @@ func (n *Node) BGPStatus() (*BGPStatusResult, error) {
 			Admin:     adminStatus,
 			Name:      neighbor.Name,
 		}
+		if af, ok := configDB.BGPNeighborAF[key+"|ipv4_unicast"]; ok && af.MaxPrefixLimit != "" {
+			ns.MaxPrefix = af.MaxPrefixLimit
+			switch {
+			case af.MaxPrefixWarningOnly == "true":
+				ns.MaxPrefixAction = node.BGPMaxPrefixWarning
+			case af.MaxPrefixRestartInterval != "":
+				ns.MaxPrefixAction = node.BGPMaxPrefixRestart
+			}
+		}
 
 		// Get operational state from STATE_DB
 		if stateClient != nil && vrf != "" {
@@ -1169,6 +1909,29 @@ func (n *Node) ShowVLAN(id int) (*VLANStatusEntry, error) {
 	return entry, nil
 }
 
+// GetVLANMembership returns every VLAN with its tagged and untagged members
+// and SVI, joined from the device's CONFIG_DB and sorted by VLAN ID.
+func (n *Node) GetVLANMembership(ctx context.Context) ([]VLANMembership, error) {
+	vlans, err := n.internal.GetVLANMembership(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]VLANMembership, len(vlans))
+	for i, v := range vlans {
+		out[i] = VLANMembership{
+			ID:           v.ID,
+			Name:         v.Name,
+			Description:  v.Description,
+			Tagged:       v.Tagged,
+			Untagged:     v.Untagged,
+			SVI:          v.SVI,
+			SVIAddresses: v.SVIAddresses,
+			VRF:          v.VRF,
+		}
+	}
+	return out, nil
+}
+
 // VRFStatus returns all VRFs with operational state from STATE_DB.
 func (n *Node) VRFStatus() ([]VRFStatusEntry, error) {
 	var result []VRFStatusEntry
@@ -1194,6 +1957,63 @@ func (n *Node) VRFStatus() ([]VRFStatusEntry, error) {
 	return result, nil
 }
 
+// RouteLeaks returns the inter-VRF route leaks configured on the device,
+// sorted by destination VRF.
+func (n *Node) RouteLeaks() []RouteLeak {
+	leaks := n.internal.GetRouteLeaks()
+	out := make([]RouteLeak, len(leaks))
+	for i, l := range leaks {
+		out[i] = RouteLeak{SrcVRF: l.SrcVRF, DstVRF: l.DstVRF, Prefixes: l.Prefixes, RouteMap: l.RouteMap}
+	}
+	return out
+}
+
+// GetLeakedRoutes returns the routes leaked into dstVRF — installed routes
+// whose next hops resolve in another VRF, read from zebra — sorted by prefix.
+// Auto-connects transport if not already connected.
+func (n *Node) GetLeakedRoutes(ctx context.Context, dstVRF string) ([]RouteEntry, error) {
+	routes, err := n.internal.GetLeakedRoutes(ctx, dstVRF)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]RouteEntry, 0, len(routes))
+	for i := range routes {
+		out = append(out, *convertRouteEntry(&routes[i]))
+	}
+	return out, nil
+}
+
+// GetRouteLeakHealth checks the route leak into dstVRF against the routes
+// that arrived: which were leaked from its source VRF, and which of its
+// prefixes are missing. Auto-connects transport if not already connected.
+func (n *Node) GetRouteLeakHealth(ctx context.Context, dstVRF string) (*RouteLeakHealth, error) {
+	found := false
+	for _, l := range n.internal.GetRouteLeaks() {
+		found = found || l.DstVRF == dstVRF
+	}
+	if !found {
+		return nil, &NotFoundError{Resource: "route leak into VRF", Name: dstVRF}
+	}
+	h, err := n.internal.GetRouteLeakHealth(ctx, dstVRF)
+	if err != nil {
+		return nil, err
+	}
+	out := &RouteLeakHealth{
+		SrcVRF:       h.SrcVRF,
+		DstVRF:       h.DstVRF,
+		Healthy:      h.Healthy(),
+		Expected:     h.Expected,
+		Leaked:       make([]RouteEntry, 0, len(h.Leaked)),
+		Missing:      h.Missing,
+		LeakedCount:  len(h.Leaked),
+		MissingCount: len(h.Missing),
+	}
+	for i := range h.Leaked {
+		out.Leaked = append(out.Leaked, *convertRouteEntry(&h.Leaked[i]))
+	}
+	return out, nil
+}
+
 // ShowVRF returns VRF info including BGP neighbors from CONFIG_DB.
 func (n *Node) ShowVRF(name string) (*VRFDetail, error) {
 	vrf, err := n.internal.GetVRF(name)
@@ -1270,6 +2090,27 @@ func (n *Node) ShowLAGDetail(name string) (*LAGStatusEntry, error) {
 	return entry, nil
 }
 
+// GetLAGHashDistribution samples the TX counters of every member of the
+// PortChannel name over window and reports how the LAG split its traffic.
+// Auto-connects transport if not already connected.
+func (n *Node) GetLAGHashDistribution(ctx context.Context, name string, window time.Duration) (*LAGHashDistribution, error) {
+	d, err := n.internal.GetLAGHashDistribution(ctx, name, window)
+	if err != nil {
+		return nil, err
+	}
+	out := &LAGHashDistribution{
+		Name:      d.Name,
+		Window:    d.Window.String(),
+		TxPackets: d.TxPackets,
+		Members:   make([]LAGMemberShare, len(d.Members)),
+		Skew:      d.Skew,
+	}
+	for i, m := range d.Members {
+		out.Members[i] = LAGMemberShare(m)
+	}
+	return out, nil
+}
+
 // ListACLs returns all ACL tables with summary info.
 func (n *Node) ListACLs() ([]ACLTableSummary, error) {
 	configDB := n.internal.ConfigDB()
@@ -1319,14 +2160,18 @@ func (n *Node) ShowACL(name string) (*ACLTableDetail, error) {
 			continue
 		}
 		detail.Rules = append(detail.Rules, ACLRuleInfo{
-			Name:     strings.TrimPrefix(ruleKey, prefix),
-			Priority: rule.Priority,
-			Action:   rule.PacketAction,
-			SrcIP:    rule.SrcIP,
-			DstIP:    rule.DstIP,
-			Protocol: rule.IPProtocol,
-			SrcPort:  rule.L4SrcPort,
-			DstPort:  rule.L4DstPort,
+			Name:       strings.TrimPrefix(ruleKey, prefix),
+			Priority:   rule.Priority,
+			Action:     rule.PacketAction,
+			SrcIP:      rule.SrcIP,
+			DstIP:      rule.DstIP,
+			Protocol:   rule.IPProtocol,
+			SrcPort:    rule.L4SrcPort,
+			DstPort:    rule.L4DstPort,
+			SrcIPv6:    rule.SrcIPv6,
+			DstIPv6:    rule.DstIPv6,
+			ICMPv6Type: rule.ICMPv6Type,
+			EtherType:  rule.EtherType,
 		})
 	}
 	return detail, nil
@@ -1345,6 +2190,17 @@ func (n *Node) GetServiceBindingDetail(iface string) (*ServiceBindingDetail, err
 	}, nil
 }
 
+// GetServiceBindings returns every service applied on the node, sorted by
+// interface name.
+func (n *Node) GetServiceBindings() []ServiceBindingEntry {
+	bindings := n.internal.GetServiceBindings()
+	out := make([]ServiceBindingEntry, 0, len(bindings))
+	for _, b := range bindings {
+		out = append(out, ServiceBindingEntry(b))
+	}
+	return out
+}
+
 // ShowInterfaceDetail returns all properties of a single interface.
 func (n *Node) ShowInterfaceDetail(name string) (*InterfaceDetail, error) {
 	intf, err := n.internal.GetInterface(name)
@@ -1352,7 +2208,7 @@ func (n *Node) ShowInterfaceDetail(name string) (*InterfaceDetail, error) {
 		return nil, err
 	}
 	return &InterfaceDetail{
-		Name:        name,
+		Name:        intf.Name(),
 		AdminStatus: intf.AdminStatus(),
 		OperStatus:  intf.OperStatus(),
 		Speed:       intf.Speed(),
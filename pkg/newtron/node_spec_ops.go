@@ -53,6 +53,7 @@ func (net *Network) CreateNodeSpec(ctx context.Context, req CreateNodeSpecReques
 		Platform:    req.Platform,
 		MAC:         req.MAC,
 		UnderlayASN: req.UnderlayASN,
+		Transport:   req.Transport,
 	}
 	if req.EVPN != nil {
 		nodeSpec.EVPN = &spec.EVPNConfig{
@@ -169,6 +170,7 @@ func (net *Network) UpdateNodeSpec(ctx context.Context, req CreateNodeSpecReques
 		Platform:    req.Platform,
 		MAC:         req.MAC,
 		UnderlayASN: req.UnderlayASN,
+		Transport:   req.Transport,
 	}
 	if req.EVPN != nil {
 		nodeSpec.EVPN = &spec.EVPNConfig{
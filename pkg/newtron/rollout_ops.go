@@ -0,0 +1,236 @@
+package newtron
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// RolloutService applies one service to every interface a rollout manifest
+// names — the fleet-scale counterpart of apply-service, for onboarding many
+// customer circuits at once. Every row is validated before any device is
+// touched; one bad row rejects the whole rollout with a ValidationError
+// listing each problem by row number.
+//
+// Rows are grouped by device. Each device is written through exec in name
+// order, its rows applied as one ApplyServiceBatch inside one Execute, so a
+// device commits (or, with dry_run, previews) all of its circuits or none of
+// them, and a shared VRF or filter is written once per device rather than
+// once per row. A device that fails is reported in its DeviceChangeSet and
+// the rest proceed. Permission is checked per interface (service.apply)
+// before any service is applied.
+func (net *Network) RolloutService(ctx context.Context, spec RolloutSpec, exec DeviceExecutor) (*MultiChangeSet, error) {
+	if err := validateRollout(spec, net.rolloutDeviceCheck); err != nil {
+		return nil, err
+	}
+	return runRollout(ctx, spec, exec), nil
+}
+
+// rolloutDeviceCheck rejects a device the network has no node spec for, or
+// one that is a host rather than a switch.
+func (net *Network) rolloutDeviceCheck(device string) error {
+	if _, err := net.internal.GetNodeSpec(device); err != nil {
+		return fmt.Errorf("unknown device %q", device)
+	}
+	if net.internal.IsHostDevice(device) {
+		return fmt.Errorf("%s is a host, not a switch", device)
+	}
+	return nil
+}
+
+// validateRollout checks every row of spec — required fields, the IP
+// prefix and peer AS, devices via checkDevice, no interface named twice —
+// and returns all problems at once.
+func validateRollout(spec RolloutSpec, checkDevice func(device string) error) error {
+	if spec.Service == "" {
+		return &ValidationError{Field: "service", Message: "required"}
+	}
+	if len(spec.Rows) == 0 {
+		return &ValidationError{Field: "rows", Message: "the manifest has no rows"}
+	}
+	var problems []string
+	checked := map[string]error{}
+	seen := map[string]int{}
+	for i, row := range spec.Rows {
+		n := i + 1
+		if row.Device == "" || row.Interface == "" {
+			problems = append(problems, fmt.Sprintf("row %d: device and interface are required", n))
+			continue
+		}
+		err, done := checked[row.Device]
+		if !done {
+			err = checkDevice(row.Device)
+			checked[row.Device] = err
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", n, err))
+		}
+		key := row.Device + ":" + util.NormalizeInterfaceName(row.Interface)
+		if first, dup := seen[key]; dup {
+			problems = append(problems, fmt.Sprintf("row %d: %s %s already appears in row %d", n, row.Device, row.Interface, first))
+		} else {
+			seen[key] = n
+		}
+		if row.IP != "" && !util.IsValidIPv4CIDR(row.IP) && !util.IsValidIPv6CIDR(row.IP) {
+			problems = append(problems, fmt.Sprintf("row %d: ip %q is not an address/prefix-length", n, row.IP))
+		}
+		if row.PeerAS < 0 || row.PeerAS > 4294967295 {
+			problems = append(problems, fmt.Sprintf("row %d: peer_as %d is out of range", n, row.PeerAS))
+		}
+	}
+	if len(problems) > 0 {
+		return &ValidationError{Field: "rows", Message: strings.Join(problems, "; ")}
+	}
+	return nil
+}
+
+// runRollout applies a validated spec, one exec per device.
+func runRollout(ctx context.Context, spec RolloutSpec, exec DeviceExecutor) *MultiChangeSet {
+	byDevice := map[string][]RolloutRow{}
+	for _, row := range spec.Rows {
+		byDevice[row.Device] = append(byDevice[row.Device], row)
+	}
+	devices := make([]string, 0, len(byDevice))
+	for device := range byDevice {
+		devices = append(devices, device)
+	}
+	sort.Strings(devices)
+
+	service := util.NormalizeName(spec.Service)
+	result := &MultiChangeSet{}
+	for _, device := range devices {
+		dcs := DeviceChangeSet{Device: device}
+		wr, err := exec(ctx, device, func(ctx context.Context, n *Node) error {
+			rows := byDevice[device]
+			bindings := make([]ServiceBinding, len(rows))
+			for idx, row := range rows {
+				bindings[idx] = ServiceBinding{
+					Interface: row.Interface, Service: service,
+					Opts: ApplyServiceOpts{IPAddress: row.IP, PeerAS: row.PeerAS},
+				}
+			}
+			if err := n.ApplyServiceBatch(ctx, bindings); err != nil {
+				return err
+			}
+			for _, row := range rows {
+				intf, err := n.Interface(row.Interface)
+				if err != nil {
+					return err
+				}
+				dcs.Interfaces = append(dcs.Interfaces, InterfaceChange{
+					Interface: intf.internal.Name(), Property: "service", To: service, Changed: true,
+				})
+			}
+			return nil
+		})
+		dcs.Result = wr
+		if err != nil {
+			dcs.Error = err.Error()
+			dcs.Interfaces = nil // the device's changes were not committed
+		}
+		result.Devices = append(result.Devices, dcs)
+	}
+	return result
+}
+
+// rolloutColumns are the manifest columns, in the order ParseRolloutManifest
+// documents them.
+var rolloutColumns = []string{"device", "interface", "ip", "peer_as"}
+
+// ParseRolloutManifest reads a rollout manifest's rows. format is "csv" — a
+// header row naming the columns (device, interface, ip, peer_as, in any
+// order; ip and peer_as may be omitted), then one circuit per line — or
+// "yaml", a list of objects with the same keys (JSON is valid YAML).
+func ParseRolloutManifest(data []byte, format string) ([]RolloutRow, error) {
+	switch format {
+	case "csv":
+		return parseRolloutCSV(data)
+	case "yaml":
+		var raw []map[string]any
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parsing manifest: %w", err)
+		}
+		// Round-trip through JSON so RolloutRow's json tags are the one
+		// field-name contract.
+		js, err := json.Marshal(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing manifest: %w", err)
+		}
+		dec := json.NewDecoder(bytes.NewReader(js))
+		dec.DisallowUnknownFields()
+		var rows []RolloutRow
+		if err := dec.Decode(&rows); err != nil {
+			return nil, fmt.Errorf("parsing manifest: %w", err)
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unknown manifest format %q (csv or yaml)", format)
+	}
+}
+
+func parseRolloutCSV(data []byte) ([]RolloutRow, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.TrimLeadingSpace = true
+	r.Comment = '#'
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parsing manifest: empty")
+		}
+		return nil, fmt.Errorf("parsing manifest: %w", err)
+	}
+	col := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, c := range rolloutColumns {
+			known = known || c == name
+		}
+		if !known {
+			return nil, fmt.Errorf("parsing manifest: unknown column %q (columns: %s)", name, strings.Join(rolloutColumns, ", "))
+		}
+		col[name] = i
+	}
+	for _, required := range []string{"device", "interface"} {
+		if _, ok := col[required]; !ok {
+			return nil, fmt.Errorf("parsing manifest: missing column %q", required)
+		}
+	}
+
+	var rows []RolloutRow
+	for {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing manifest: %w", err)
+		}
+		line, _ := r.FieldPos(0)
+		get := func(name string) string {
+			if i, ok := col[name]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		row := RolloutRow{Device: get("device"), Interface: get("interface"), IP: get("ip")}
+		if s := get("peer_as"); s != "" {
+			if row.PeerAS, err = strconv.Atoi(s); err != nil {
+				return nil, fmt.Errorf("parsing manifest: line %d: peer_as %q is not a number", line, s)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -0,0 +1,100 @@
+package newtron
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRolloutManifest(t *testing.T) {
+	want := []RolloutRow{
+		{Device: "leaf1", Interface: "Ethernet8", IP: "10.1.1.1/30", PeerAS: 65101},
+		{Device: "leaf2", Interface: "Ethernet8"},
+	}
+	csv := "# customer circuits\ninterface, device, peer_as, ip\nEthernet8, leaf1, 65101, 10.1.1.1/30\nEthernet8, leaf2, ,\n"
+	yaml := "- {device: leaf1, interface: Ethernet8, ip: 10.1.1.1/30, peer_as: 65101}\n- {device: leaf2, interface: Ethernet8}\n"
+	for format, data := range map[string]string{"csv": csv, "yaml": yaml} {
+		rows, err := ParseRolloutManifest([]byte(data), format)
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+		if !reflect.DeepEqual(rows, want) {
+			t.Errorf("%s: rows = %+v, want %+v", format, rows, want)
+		}
+	}
+
+	for _, tt := range []struct {
+		format, data, wantErr string
+	}{
+		{"csv", "device,port\nleaf1,Ethernet0\n", `unknown column "port"`},
+		{"csv", "device,ip\nleaf1,10.0.0.1/31\n", `missing column "interface"`},
+		{"csv", "device,interface,peer_as\nleaf1,Ethernet0,65001\nleaf2,Ethernet0,AS65002\n", `line 3: peer_as "AS65002" is not a number`},
+		{"yaml", "- {device: leaf1, interface: Ethernet0, vlan: 100}\n", `unknown field "vlan"`},
+		{"toml", "", `unknown manifest format "toml"`},
+	} {
+		if _, err := ParseRolloutManifest([]byte(tt.data), tt.format); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s %q: err = %v, want %q", tt.format, tt.data, err, tt.wantErr)
+		}
+	}
+}
+
+// TestRunRollout_DeviceFailure: devices are written in name order, one exec
+// each, and a device whose exec fails reports the error with no circuits
+// while the rest proceed.
+func TestRunRollout_DeviceFailure(t *testing.T) {
+	var order []string
+	exec := func(ctx context.Context, device string, fn func(ctx context.Context, n *Node) error) (*WriteResult, error) {
+		order = append(order, device)
+		if device == "leaf2" {
+			return nil, errors.New("connecting: timeout")
+		}
+		return &WriteResult{ChangeCount: 1}, nil
+	}
+	result := runRollout(context.Background(), RolloutSpec{Service: "transit", Rows: []RolloutRow{
+		{Device: "leaf3", Interface: "Ethernet0"},
+		{Device: "leaf2", Interface: "Ethernet0"},
+		{Device: "leaf3", Interface: "Ethernet4"},
+	}}, exec)
+
+	if want := []string{"leaf2", "leaf3"}; !reflect.DeepEqual(order, want) {
+		t.Errorf("exec order = %v, want %v (one per device, sorted)", order, want)
+	}
+	if len(result.Devices) != 2 {
+		t.Fatalf("devices = %+v, want 2", result.Devices)
+	}
+	if d := result.Devices[0]; d.Device != "leaf2" || d.Error != "connecting: timeout" || d.Interfaces != nil {
+		t.Errorf("leaf2 = %+v, want the error and no circuits", d)
+	}
+	if d := result.Devices[1]; d.Device != "leaf3" || d.Error != "" || d.Result == nil {
+		t.Errorf("leaf3 = %+v, want its write result", d)
+	}
+}
+
+func TestValidateRollout(t *testing.T) {
+	known := func(device string) error { return nil }
+	if err := validateRollout(RolloutSpec{Rows: []RolloutRow{{Device: "leaf1", Interface: "Ethernet0"}}}, known); err == nil || !strings.Contains(err.Error(), "service") {
+		t.Errorf("no service: err = %v", err)
+	}
+	if err := validateRollout(RolloutSpec{Service: "transit"}, known); err == nil || !strings.Contains(err.Error(), "no rows") {
+		t.Errorf("no rows: err = %v", err)
+	}
+	err := validateRollout(RolloutSpec{Service: "transit", Rows: []RolloutRow{
+		{Device: "leaf1", Interface: "Ethernet0", IP: "2001:db8::1/127"},
+		{Device: "leaf1"},
+		{Device: "leaf1", Interface: "Eth0", PeerAS: -1},
+	}}, known)
+	if err == nil {
+		t.Fatal("bad rows accepted")
+	}
+	for _, problem := range []string{
+		"row 2: device and interface are required",
+		"row 3: leaf1 Eth0 already appears in row 1",
+		"row 3: peer_as -1 is out of range",
+	} {
+		if !strings.Contains(err.Error(), problem) {
+			t.Errorf("err = %v, does not name %q", err, problem)
+		}
+	}
+}
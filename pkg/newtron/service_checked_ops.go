@@ -0,0 +1,114 @@
+package newtron
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// DeviceReader runs a read-only fn against one device's Node and returns its
+// value. Like DeviceExecutor it lets the caller decide how the device is
+// reached — the API server routes each call through the device's actor as
+// one short turn, so a long poll never holds the device.
+type DeviceReader func(ctx context.Context, device string, fn func(ctx context.Context, n *Node) (any, error)) (any, error)
+
+// ApplyServiceChecked applies service to iface on device, waits for the
+// operational state the service implies to settle — the interface oper-up
+// and, when the service peers over BGP, that session Established in the
+// service's VRF — and returns the verified result. It bundles the common
+// apply → wait → verify sequence into one call.
+//
+// The apply and any rollback go through exec; the settle check is a series of
+// short reads through read, every verify.Interval until verify.Timeout. A
+// read that fails while polling counts as "not settled yet". When the service
+// does not settle, the error is a *ServiceVerifyFailedError carrying the
+// result; with verify.Rollback the service is removed first (remove-service),
+// otherwise it is left applied for inspection.
+func (net *Network) ApplyServiceChecked(ctx context.Context, device, iface, service string, opts ApplyServiceOpts, verify ServiceVerifyOpts, exec DeviceExecutor, read DeviceReader) (*ServiceResult, error) {
+	if verify.Timeout < 0 || verify.Interval < 0 {
+		return nil, &ValidationError{Field: "verify", Message: "timeout and interval must not be negative"}
+	}
+	if verify.Timeout == 0 {
+		verify.Timeout = 60 * time.Second
+	}
+	if verify.Interval == 0 {
+		verify.Interval = 2 * time.Second
+	}
+
+	result := &ServiceResult{Interface: iface, Service: util.NormalizeName(service)}
+	wr, err := exec(ctx, device, func(ctx context.Context, n *Node) error {
+		intf, err := n.Interface(iface)
+		if err != nil {
+			return err
+		}
+		return intf.ApplyService(ctx, service, opts)
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Apply = wr
+
+	start := time.Now()
+	deadline := start.Add(verify.Timeout)
+	for {
+		val, err := read(ctx, device, func(ctx context.Context, n *Node) (any, error) {
+			intf, err := n.Interface(iface)
+			if err != nil {
+				return nil, err
+			}
+			return intf.ServiceHealth(ctx)
+		})
+		checks, _ := val.([]HealthCheckResult)
+		if err != nil {
+			checks = []HealthCheckResult{{Check: "service", Status: "fail", Message: err.Error()}}
+		}
+		result.Checks = checks
+		result.Elapsed = time.Since(start)
+		if serviceSettled(checks) {
+			result.Converged = true
+			return result, nil
+		}
+		if !time.Now().Add(verify.Interval).Before(deadline) {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(verify.Interval):
+		}
+	}
+
+	failed := &ServiceVerifyFailedError{Device: device, Result: result}
+	if verify.Rollback {
+		rb, err := exec(ctx, device, func(ctx context.Context, n *Node) error {
+			intf, err := n.Interface(iface)
+			if err != nil {
+				return err
+			}
+			return intf.RemoveService(ctx)
+		})
+		result.Rollback = rb
+		if err != nil {
+			failed.RollbackErr = fmt.Errorf("remove-service: %w", err)
+		} else {
+			result.RolledBack = true
+		}
+	}
+	return result, failed
+}
+
+// serviceSettled reports whether every settle check passed. A "warn" — e.g.
+// the port not yet in STATE_DB — is not settled.
+func serviceSettled(checks []HealthCheckResult) bool {
+	if len(checks) == 0 {
+		return false
+	}
+	for _, c := range checks {
+		if c.Status != "pass" {
+			return false
+		}
+	}
+	return true
+}
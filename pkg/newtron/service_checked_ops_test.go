@@ -0,0 +1,109 @@
+package newtron
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeServiceDevice stands in for the actor-routed exec and read of
+// ApplyServiceChecked: writes are counted, not run, and each read returns
+// the next canned set of settle checks.
+type fakeServiceDevice struct {
+	writes int
+	polls  [][]HealthCheckResult
+	reads  int
+}
+
+func (f *fakeServiceDevice) exec(ctx context.Context, device string, fn func(ctx context.Context, n *Node) error) (*WriteResult, error) {
+	f.writes++
+	return &WriteResult{Applied: true, ChangeCount: f.writes}, nil
+}
+
+func (f *fakeServiceDevice) read(ctx context.Context, device string, fn func(ctx context.Context, n *Node) (any, error)) (any, error) {
+	checks := f.polls[min(f.reads, len(f.polls)-1)]
+	f.reads++
+	if checks == nil {
+		return nil, errors.New("device busy")
+	}
+	return checks, nil
+}
+
+var (
+	operUp   = HealthCheckResult{Check: "interface-oper", Status: "pass", Message: "Ethernet0: oper-up"}
+	bgpUp    = HealthCheckResult{Check: "bgp", VRF: "default", Status: "pass", Message: "BGP neighbor 10.1.0.1 (vrf default): Established"}
+	bgpIdle  = HealthCheckResult{Check: "bgp", VRF: "default", Status: "fail", Message: "BGP neighbor 10.1.0.1 (vrf default): Active"}
+	fastPoll = ServiceVerifyOpts{Timeout: 50 * time.Millisecond, Interval: time.Millisecond}
+)
+
+func TestApplyServiceChecked_Settles(t *testing.T) {
+	dev := &fakeServiceDevice{polls: [][]HealthCheckResult{
+		nil, // a failed read is "not settled yet"
+		{operUp, bgpIdle},
+		{operUp, bgpUp},
+	}}
+	res, err := (&Network{}).ApplyServiceChecked(context.Background(), "leaf1", "Ethernet0", "Transit",
+		ApplyServiceOpts{}, fastPoll, dev.exec, dev.read)
+	if err != nil {
+		t.Fatalf("ApplyServiceChecked: %v", err)
+	}
+	if !res.Converged || res.RolledBack || dev.writes != 1 || dev.reads != 3 {
+		t.Errorf("converged=%v rolled_back=%v writes=%d reads=%d, want settled on the third read with one write",
+			res.Converged, res.RolledBack, dev.writes, dev.reads)
+	}
+	if res.Service != "TRANSIT" || res.Apply == nil || len(res.Checks) != 2 {
+		t.Errorf("result = %+v", res)
+	}
+}
+
+func TestApplyServiceChecked_VerifyFails(t *testing.T) {
+	for _, rollback := range []bool{false, true} {
+		dev := &fakeServiceDevice{polls: [][]HealthCheckResult{{operUp, bgpIdle}}}
+		verify := fastPoll
+		verify.Rollback = rollback
+		res, err := (&Network{}).ApplyServiceChecked(context.Background(), "leaf1", "Ethernet0", "transit",
+			ApplyServiceOpts{}, verify, dev.exec, dev.read)
+
+		var failed *ServiceVerifyFailedError
+		if !errors.As(err, &failed) {
+			t.Fatalf("rollback=%v: err = %v, want *ServiceVerifyFailedError", rollback, err)
+		}
+		if failed.Result != res || res.Converged {
+			t.Errorf("rollback=%v: error must carry the unconverged result", rollback)
+		}
+		if !strings.Contains(err.Error(), "(vrf default): Active") {
+			t.Errorf("rollback=%v: error %q does not name the failing check", rollback, err)
+		}
+		wantWrites, wantTail := 1, "left applied"
+		if rollback {
+			wantWrites, wantTail = 2, "rolled back"
+		}
+		if dev.writes != wantWrites || res.RolledBack != rollback || (res.Rollback != nil) != rollback {
+			t.Errorf("rollback=%v: writes=%d rolled_back=%v rollback=%v", rollback, dev.writes, res.RolledBack, res.Rollback)
+		}
+		if !strings.HasSuffix(err.Error(), wantTail) {
+			t.Errorf("rollback=%v: error %q, want it to end %q", rollback, err, wantTail)
+		}
+	}
+}
+
+func TestServiceSettled(t *testing.T) {
+	warn := HealthCheckResult{Check: "interface-oper", Status: "warn", Message: "Ethernet0: not found in STATE_DB PORT_TABLE"}
+	tests := []struct {
+		checks []HealthCheckResult
+		want   bool
+	}{
+		{nil, false},
+		{[]HealthCheckResult{operUp}, true},
+		{[]HealthCheckResult{operUp, bgpUp}, true},
+		{[]HealthCheckResult{operUp, bgpIdle}, false},
+		{[]HealthCheckResult{warn}, false},
+	}
+	for _, tt := range tests {
+		if got := serviceSettled(tt.checks); got != tt.want {
+			t.Errorf("serviceSettled(%v) = %v, want %v", tt.checks, got, tt.want)
+		}
+	}
+}
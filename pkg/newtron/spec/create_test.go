@@ -5,6 +5,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -117,6 +118,22 @@ func TestPortConfig_SpeedBoundary(t *testing.T) {
 	}
 }
 
+func TestNodeSpec_TransportValidation(t *testing.T) {
+	base := NodeSpec{MgmtIP: "10.0.0.1", LoopbackIP: "10.1.0.1", Zone: "amer"}
+	for _, tr := range []string{"", TransportSSHRedis, TransportDirectRedis, TransportGNMI} {
+		ns := base
+		ns.Transport = tr
+		if err := ns.ValidateConstraints(false, nil); err != nil {
+			t.Errorf("transport %q must validate: %v", tr, err)
+		}
+	}
+	ns := base
+	ns.Transport = "telnet"
+	if err := ns.ValidateConstraints(false, nil); err == nil || !strings.Contains(err.Error(), "unknown transport") {
+		t.Errorf("transport telnet: err = %v, want unknown transport", err)
+	}
+}
+
 // TestScaffoldTopologyNode_HostAware pins the host half: a host platform's
 // placement is bare — /setup-device is a SONiC operation and provisioning it
 // against an alpine VM fails.
@@ -0,0 +1,156 @@
+package spec
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// lint.go — whole-repo modeling checks ("spec lint").
+//
+// Load/write validation (validate.go, references.go) rejects a spec that is
+// malformed on its own. Lint is broader and advisory: it looks across every
+// definition in the hierarchy for modeling smells an operator wants caught
+// before committing a spec change — a VNI reused by two VPNs, a route target
+// left off an IP-VPN, a filter matching against an empty prefix list. Each
+// scope (network, each zone, each node) is linted against its effective view
+// (node > zone > network), and an issue is reported only at the scope that
+// defines the offending spec, so a network-level smell is reported once rather
+// than once per zone and node.
+//
+// Dangling references are checked with the same declarative MissingRefs the
+// loader uses, so a reference kind added via a `ref:` tag (e.g. a future
+// filter → policer reference) is linted with no change here.
+
+// LintSeverity classifies a lint issue. Errors are modeling mistakes that break
+// the dataplane when applied; warnings are smells worth a second look.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is one finding of Lint, located by the scope that defines the
+// offending spec. ScopeInstance is the zone or node name (empty at network).
+type LintIssue struct {
+	Severity      LintSeverity `json:"severity"`
+	Scope         string       `json:"scope"`
+	ScopeInstance string       `json:"scope_instance,omitempty"`
+	Kind          string       `json:"kind"`
+	Name          string       `json:"name"`
+	Message       string       `json:"message"`
+}
+
+// Lint checks the whole spec hierarchy — the network file, its zones, and its
+// node specs — and returns every issue found, errors first, in a stable order.
+// nil zones or nodes lint the network scope only. A node whose zone is not in
+// zones is linted against the network scope alone.
+func Lint(net *NetworkSpecFile, zones map[string]*ZoneSpec, nodes map[string]*NodeSpec) []LintIssue {
+	var issues []LintIssue
+	base := &net.OverridableSpecs
+	issues = append(issues, lintScope(ScopeNetwork, "", base, base)...)
+	for name, z := range zones {
+		merged := mergeOverridableSpecs(base, &z.OverridableSpecs)
+		issues = append(issues, lintScope(ScopeZone, name, merged, &z.OverridableSpecs)...)
+	}
+	for name, n := range nodes {
+		merged := base
+		if z, ok := zones[n.Zone]; ok {
+			merged = mergeOverridableSpecs(merged, &z.OverridableSpecs)
+		}
+		merged = mergeOverridableSpecs(merged, &n.OverridableSpecs)
+		issues = append(issues, lintScope(ScopeNode, name, merged, &n.OverridableSpecs)...)
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		switch {
+		case a.Severity != b.Severity:
+			return a.Severity == LintError
+		case a.Scope != b.Scope:
+			return a.Scope < b.Scope
+		case a.ScopeInstance != b.ScopeInstance:
+			return a.ScopeInstance < b.ScopeInstance
+		case a.Kind != b.Kind:
+			return a.Kind < b.Kind
+		case a.Name != b.Name:
+			return a.Name < b.Name
+		default:
+			return a.Message < b.Message
+		}
+	})
+	return issues
+}
+
+// lintScope lints the specs defined at one scope (own) against that scope's
+// effective view (merged).
+func lintScope(scope, instance string, merged, own *OverridableSpecs) []LintIssue {
+	var issues []LintIssue
+	add := func(sev LintSeverity, kind, name, format string, args ...any) {
+		issues = append(issues, LintIssue{
+			Severity: sev, Scope: scope, ScopeInstance: instance,
+			Kind: kind, Name: name, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	own.EachSpec(func(kind, name string, value any) {
+		// Dangling references — a service naming an ipvpn/macvpn that does not
+		// exist, a filter rule naming a missing prefix list, and so on.
+		for _, ref := range merged.MissingRefs(value) {
+			add(LintError, kind, name, "%s references %s '%s' which does not exist", ref.Field, ref.Kind, ref.Name)
+		}
+		// A referenced prefix list that exists but is empty matches nothing:
+		// the ACL rule expands to no entries, the route-map clause never hits.
+		for _, ref := range CollectRefs(value) {
+			if ref.Kind != "PrefixListSpec" {
+				continue
+			}
+			if entries, ok := merged.PrefixLists[ref.Name]; ok && len(entries) == 0 {
+				add(LintError, kind, name, "%s references prefix list '%s' which is empty", ref.Field, ref.Name)
+			}
+		}
+	})
+
+	for name, vpn := range own.IPVPNs {
+		if vpn.L3VNI != 0 && len(vpn.RouteTargets) == 0 {
+			add(LintWarning, "IPVPNSpec", name, "l3vni %d is set but route_targets is empty — type-5 routes are neither exported nor imported", vpn.L3VNI)
+		}
+	}
+
+	// Duplicate VNIs: every L3VNI and L2VNI in the effective view must be
+	// distinct, since the VNI alone selects the VRF or bridge domain on decap.
+	// Reported on each colliding definition this scope owns.
+	users := map[int][]string{}
+	for name, vpn := range merged.IPVPNs {
+		if vpn.L3VNI != 0 {
+			users[vpn.L3VNI] = append(users[vpn.L3VNI], "ipvpn '"+name+"'")
+		}
+	}
+	for name, vpn := range merged.MACVPNs {
+		if vpn.VNI != 0 {
+			users[vpn.VNI] = append(users[vpn.VNI], "macvpn '"+name+"'")
+		}
+	}
+	dupWith := func(vni int, self string) string {
+		var others []string
+		for _, u := range users[vni] {
+			if u != self {
+				others = append(others, u)
+			}
+		}
+		sort.Strings(others)
+		return strings.Join(others, ", ")
+	}
+	for name, vpn := range own.IPVPNs {
+		if vpn.L3VNI != 0 && len(users[vpn.L3VNI]) > 1 {
+			add(LintError, "IPVPNSpec", name, "l3vni %d is also used by %s", vpn.L3VNI, dupWith(vpn.L3VNI, "ipvpn '"+name+"'"))
+		}
+	}
+	for name, vpn := range own.MACVPNs {
+		if vpn.VNI != 0 && len(users[vpn.VNI]) > 1 {
+			add(LintError, "MACVPNSpec", name, "vni %d is also used by %s", vpn.VNI, dupWith(vpn.VNI, "macvpn '"+name+"'"))
+		}
+	}
+	return issues
+}
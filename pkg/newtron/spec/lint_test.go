@@ -0,0 +1,97 @@
+package spec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLint_FindsModelingSmells(t *testing.T) {
+	net := &NetworkSpecFile{OverridableSpecs: OverridableSpecs{
+		PrefixLists: map[string][]string{"EMPTY": {}, "BOGONS": {"10.0.0.0/8"}},
+		Filters: map[string]*FilterSpec{
+			"MGMT": {Type: "ipv4", Rules: []*FilterRule{{Sequence: 10, SrcPrefixList: "EMPTY", Action: "deny"}}},
+		},
+		IPVPNs: map[string]*IPVPNSpec{
+			"Vrf_A": {L3VNI: 5000, RouteTargets: []string{"65000:5000"}},
+			"Vrf_B": {L3VNI: 6000}, // no route targets
+		},
+		MACVPNs: map[string]*MACVPNSpec{"L2": {VlanID: 100, VNI: 5000}}, // collides with Vrf_A
+		Services: map[string]*ServiceSpec{
+			"GHOST": {ServiceType: ServiceTypeEVPNBridged, MACVPN: "NOPE"},
+		},
+	}}
+
+	issues := Lint(net, nil, nil)
+	want := []struct {
+		sev  LintSeverity
+		name string
+		msg  string
+	}{
+		{LintError, "MGMT", "prefix list 'EMPTY' which is empty"},
+		{LintError, "Vrf_A", "l3vni 5000 is also used by macvpn 'L2'"},
+		{LintError, "L2", "vni 5000 is also used by ipvpn 'Vrf_A'"},
+		{LintError, "GHOST", "MACVPNSpec 'NOPE' which does not exist"},
+		{LintWarning, "Vrf_B", "route_targets is empty"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("got %d issues, want %d: %+v", len(issues), len(want), issues)
+	}
+	for _, w := range want {
+		found := false
+		for _, got := range issues {
+			if got.Severity == w.sev && got.Name == w.name && strings.Contains(got.Message, w.msg) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing %s on %s containing %q in %+v", w.sev, w.name, w.msg, issues)
+		}
+	}
+	if issues[len(issues)-1].Severity != LintWarning {
+		t.Error("errors must sort before warnings")
+	}
+}
+
+// TestLint_ReportsAtDefiningScope: a network-level smell is reported once, not
+// once per zone and node; a zone override is linted against its effective view.
+func TestLint_ReportsAtDefiningScope(t *testing.T) {
+	net := &NetworkSpecFile{OverridableSpecs: OverridableSpecs{
+		IPVPNs: map[string]*IPVPNSpec{"Vrf_A": {L3VNI: 5000}},
+	}}
+	zones := map[string]*ZoneSpec{
+		"amer": {OverridableSpecs: OverridableSpecs{
+			MACVPNs: map[string]*MACVPNSpec{"L2": {VlanID: 100, VNI: 5000}},
+		}},
+	}
+	nodes := map[string]*NodeSpec{"leaf1": {Zone: "amer"}}
+
+	issues := Lint(net, zones, nodes)
+	var rtWarnings, zoneDups int
+	for _, i := range issues {
+		if strings.Contains(i.Message, "route_targets") {
+			rtWarnings++
+			if i.Scope != ScopeNetwork {
+				t.Errorf("route-target warning reported at %s/%s, want network", i.Scope, i.ScopeInstance)
+			}
+		}
+		if i.Name == "L2" && i.Scope == ScopeZone && i.ScopeInstance == "amer" {
+			zoneDups++
+		}
+	}
+	if rtWarnings != 1 {
+		t.Errorf("route-target warnings = %d, want 1 (reported once): %+v", rtWarnings, issues)
+	}
+	if zoneDups != 1 {
+		t.Errorf("zone VNI collision not reported on macvpn L2 at zone amer: %+v", issues)
+	}
+}
+
+func TestLint_CleanSpec(t *testing.T) {
+	net := &NetworkSpecFile{OverridableSpecs: *sampleSpecs()}
+	net.IPVPNs["IRB"].RouteTargets = []string{"65000:1001"}
+	net.MACVPNs = map[string]*MACVPNSpec{"L2": {VlanID: 100, VNI: 10100}}
+	net.Services["TRANSIT"].MACVPN = "L2"
+	if issues := Lint(net, nil, nil); len(issues) != 0 {
+		t.Errorf("clean spec: got %+v, want no issues", issues)
+	}
+}
@@ -190,6 +190,7 @@ func (l *Loader) loadNetworkSpec() (*NetworkSpecFile, error) {
 	// time. Zones are loaded and normalized separately (loadZones) from their
 	// own files — they are no longer part of network.json.
 	normalizeOverridableSpecs(&spec.OverridableSpecs)
+	spec.ServiceDefaults = normalizeMap(spec.ServiceDefaults)
 
 	return &spec, nil
 }
@@ -208,6 +209,15 @@ func (l *Loader) validate() error {
 	// network-floor) — the same checks, moved to where zones now live.
 	net.ValidateConstraints(v, "")
 	addMissingRefs(v, "", net.MissingRefsIn(net))
+	for name, d := range l.network.ServiceDefaults {
+		if d == nil {
+			continue
+		}
+		d.validateConstraints(v, name)
+	}
+	if g := l.network.ChangeGuard; g != nil {
+		g.validateConstraints(v)
+	}
 
 	return v.Build()
 }
@@ -2,6 +2,8 @@ package spec
 
 import (
 	"encoding/json"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -42,3 +44,49 @@ func TestNetworkSpecFile_DescriptionRoundTrips(t *testing.T) {
 		t.Errorf("empty Description should be omitted; got %s", empty)
 	}
 }
+
+// TestNetworkSpecFile_ServiceDefaults pins that service_defaults load under
+// canonical service names and that a default ApplyService could never use is
+// rejected at load time rather than at the first bind.
+func TestNetworkSpecFile_ServiceDefaults(t *testing.T) {
+	load := func(defaults string) (*Loader, error) {
+		dir := t.TempDir()
+		body := `{"version": "1.0", "service_defaults": ` + defaults + `}`
+		if err := os.WriteFile(filepath.Join(dir, "network.json"), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		l := NewLoader(dir, nil)
+		return l, l.Load()
+	}
+
+	l, err := load(`{"transit": {"peer_as": 65002, "ip_pool": "10.2.0.0/24"}}`)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if d := l.GetNetwork().ServiceDefaults["TRANSIT"]; d == nil || d.PeerAS != 65002 || d.IPPool != "10.2.0.0/24" {
+		t.Errorf("ServiceDefaults[TRANSIT] = %+v, want the transit defaults under the canonical name", d)
+	}
+
+	for _, tt := range []struct{ defaults, want string }{
+		{`{"transit": {"vlan": 5000}}`, "vlan 5000 out of range"},
+		{`{"transit": {"ip_pool": "10.2.0.0"}}`, "invalid ip_pool"},
+		{`{"transit": {"ip_pool": "10.2.0.0/24", "ip_prefix_length": 16}}`, "ip_prefix_length 16 must be 24-31"},
+		{`{"transit": {"ip_prefix_length": 31}}`, "ip_prefix_length requires ip_pool"},
+	} {
+		if _, err := load(tt.defaults); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("service_defaults %s: err = %v, want %q", tt.defaults, err, tt.want)
+		}
+	}
+}
+
+func TestNetworkSpecFile_ChangeGuard(t *testing.T) {
+	dir := t.TempDir()
+	body := `{"version": "1.0", "change_guard": {"max_changes": -1, "max_tables": 12}}`
+	if err := os.WriteFile(filepath.Join(dir, "network.json"), []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	err := NewLoader(dir, nil).Load()
+	if err == nil || !strings.Contains(err.Error(), "max_changes -1 must not be negative") {
+		t.Errorf("Load: err = %v, want the negative max_changes rejected", err)
+	}
+}
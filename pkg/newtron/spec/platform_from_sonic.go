@@ -16,8 +16,8 @@
 //   - Breakouts: sorted union of every breakout_modes key across
 //     every interface
 //   - Ports: one PortSpec per interface, sorted by front-panel index;
-//     NIC slots assigned 1..N by that order. Carries name and lanes
-//     (per-port speed is not in platform.json — it falls back to
+//     NIC slots assigned 1..N by that order. Carries name, lanes and
+//     the port's own breakout_modes keys (per-port speed is not in platform.json — it falls back to
 //     default_speed). The explicit name → NIC mapping newtlab resolves
 //     topology ports against.
 //
@@ -160,21 +160,24 @@ func FromSONiCPlatformJSON(data []byte, opts SONiCImportOptions) (*PlatformSpec,
 // platform.json interfaces map. The map has no inherent order, so ports are
 // sorted by front-panel index (the leading value of the `index` field), then
 // by name as a stable tiebreak. NIC slots are assigned by that sorted order
-// (1..N; NIC 0 is management). Lanes come from the `lanes` field; platform.json
-// carries no per-port speed (it lives in breakout_modes / the headline), so
+// (1..N; NIC 0 is management). Lanes come from the `lanes` field and Breakouts
+// from the port's breakout_modes keys, sorted; platform.json carries no
+// per-port speed (it lives in breakout_modes / the headline), so
 // PortSpec.Speed is left empty and the consumer falls back to default_speed.
 func buildPortsFromInterfaces(interfaces map[string]sonicInterface) []PortSpec {
 	type entry struct {
-		name  string
-		index int
-		lanes []int
+		name      string
+		index     int
+		lanes     []int
+		breakouts []string
 	}
 	entries := make([]entry, 0, len(interfaces))
 	for name, iface := range interfaces {
 		entries = append(entries, entry{
-			name:  name,
-			index: leadingInt(iface.Index),
-			lanes: parseLanes(iface.Lanes),
+			name:      name,
+			index:     leadingInt(iface.Index),
+			lanes:     parseLanes(iface.Lanes),
+			breakouts: unionBreakouts(map[string]sonicInterface{name: iface}),
 		})
 	}
 	sort.Slice(entries, func(i, j int) bool {
@@ -185,7 +188,11 @@ func buildPortsFromInterfaces(interfaces map[string]sonicInterface) []PortSpec {
 	})
 	ports := make([]PortSpec, 0, len(entries))
 	for i, e := range entries {
-		ports = append(ports, PortSpec{Name: e.name, NICIndex: i + 1, Lanes: e.lanes})
+		port := PortSpec{Name: e.name, NICIndex: i + 1, Lanes: e.lanes}
+		if len(e.breakouts) > 0 {
+			port.Breakouts = e.breakouts
+		}
+		ports = append(ports, port)
 	}
 	return ports
 }
@@ -141,7 +141,8 @@ func TestFromSONiCPlatformJSON_Z9332f_RealFixture(t *testing.T) {
 	}
 	// Lowest front-panel index (Ethernet0, index "1,1,1,…") sorts to NIC 1;
 	// Ethernet8 (index 2) follows. Hand-verified from the fixture.
-	wantFirst := PortSpec{Name: "Ethernet0", NICIndex: 1, Lanes: []int{33, 34, 35, 36, 37, 38, 39, 40}}
+	wantFirst := PortSpec{Name: "Ethernet0", NICIndex: 1, Lanes: []int{33, 34, 35, 36, 37, 38, 39, 40},
+		Breakouts: []string{"1x100G(4)", "1x400G", "2x200G[100G,40G]", "4x100G[50G]"}}
 	if !reflect.DeepEqual(got.Ports[0], wantFirst) {
 		t.Errorf("Ports[0]: got %+v, want %+v", got.Ports[0], wantFirst)
 	}
@@ -0,0 +1,149 @@
+package spec
+
+import (
+	"sort"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// reference_graph.go — the whole spec set's references as a graph, for impact
+// analysis ("what does editing this spec touch?").
+//
+// FindConsumers answers one hop: who references the target directly. Editing a
+// shared spec reaches further — a prefix list edited under a filter changes
+// every service that uses the filter — so the graph walks consumers
+// transitively and keeps, for each spec reached, the chain it was reached
+// through. Edges come from the same `ref:` tags as MissingRefs and
+// FindConsumers, so a reference kind added declaratively is in the graph with
+// no change here.
+
+// SpecNode identifies one spec: its kind (the `kind:` tag, e.g. "FilterSpec")
+// and canonical name.
+type SpecNode struct {
+	Kind string
+	Name string
+}
+
+func (n SpecNode) String() string { return n.Kind + "/" + n.Name }
+
+// SpecEdge is one reference: From's Field names To.
+type SpecEdge struct {
+	From  SpecNode
+	To    SpecNode
+	Field string
+}
+
+// ReferenceGraph holds every spec in a set and the references between them,
+// indexed both ways.
+type ReferenceGraph struct {
+	nodes     map[SpecNode]bool
+	refs      map[SpecNode][]SpecEdge // From → its references
+	consumers map[SpecNode][]SpecEdge // To → the references naming it
+}
+
+// Dependent is a spec reached by walking consumers from an impact target. Via
+// is the chain from the target to it: Via[0] is the target, Via[len-1] the
+// dependent. Field is the field of the dependent that holds the last hop.
+type Dependent struct {
+	SpecNode
+	Via   []SpecNode
+	Field string
+}
+
+// BuildReferenceGraph builds the reference graph of a spec set. A reference to
+// a spec that is not in the set is kept as an edge (it is what the spec says),
+// but the missing spec is not a node.
+func BuildReferenceGraph(o *OverridableSpecs) *ReferenceGraph {
+	g := &ReferenceGraph{
+		nodes:     map[SpecNode]bool{},
+		refs:      map[SpecNode][]SpecEdge{},
+		consumers: map[SpecNode][]SpecEdge{},
+	}
+	o.EachSpec(func(kind, name string, value any) {
+		from := SpecNode{Kind: kind, Name: name}
+		g.nodes[from] = true
+		for _, ref := range CollectRefs(value) {
+			e := SpecEdge{From: from, To: SpecNode{Kind: ref.Kind, Name: ref.Name}, Field: ref.Field}
+			g.refs[from] = append(g.refs[from], e)
+			g.consumers[e.To] = append(g.consumers[e.To], e)
+		}
+	})
+	for _, m := range []map[SpecNode][]SpecEdge{g.refs, g.consumers} {
+		for _, edges := range m {
+			sortEdges(edges)
+		}
+	}
+	return g
+}
+
+// Has reports whether the set holds the spec.
+func (g *ReferenceGraph) Has(n SpecNode) bool { return g.nodes[n] }
+
+// Lookup returns every spec, of any kind, with the given name, sorted by kind.
+// Spec names are unique per kind only, so a bare name can match several.
+func (g *ReferenceGraph) Lookup(name string) []SpecNode {
+	name = util.NormalizeName(name)
+	var out []SpecNode
+	for n := range g.nodes {
+		if n.Name == name {
+			out = append(out, n)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Kind < out[j].Kind })
+	return out
+}
+
+// References returns the references n declares, one hop.
+func (g *ReferenceGraph) References(n SpecNode) []SpecEdge { return g.refs[n] }
+
+// Consumers returns the references naming n, one hop.
+func (g *ReferenceGraph) Consumers(n SpecNode) []SpecEdge { return g.consumers[n] }
+
+// Dependents returns every spec that references target directly or through
+// other specs, each once, reached by its shortest chain. Ordered by chain
+// length, then kind and name. The graph has no cycles today (references only
+// point from services down to the specs they compose), but the walk does not
+// rely on that.
+func (g *ReferenceGraph) Dependents(target SpecNode) []Dependent {
+	seen := map[SpecNode]bool{target: true}
+	var out []Dependent
+	frontier := []Dependent{{SpecNode: target, Via: []SpecNode{target}}}
+	for len(frontier) > 0 {
+		var next []Dependent
+		for _, d := range frontier {
+			for _, e := range g.consumers[d.SpecNode] {
+				if seen[e.From] {
+					continue
+				}
+				seen[e.From] = true
+				via := append(append([]SpecNode{}, d.Via...), e.From)
+				next = append(next, Dependent{SpecNode: e.From, Via: via, Field: e.Field})
+			}
+		}
+		sort.Slice(next, func(i, j int) bool { return lessNode(next[i].SpecNode, next[j].SpecNode) })
+		out = append(out, next...)
+		frontier = next
+	}
+	return out
+}
+
+func lessNode(a, b SpecNode) bool {
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	return a.Name < b.Name
+}
+
+func sortEdges(edges []SpecEdge) {
+	sort.Slice(edges, func(i, j int) bool {
+		a, b := edges[i], edges[j]
+		switch {
+		case a.From != b.From:
+			return lessNode(a.From, b.From)
+		case a.To != b.To:
+			return lessNode(a.To, b.To)
+		default:
+			return a.Field < b.Field
+		}
+	})
+}
@@ -0,0 +1,64 @@
+package spec
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReferenceGraph_Dependents(t *testing.T) {
+	o := sampleSpecs()
+	// EDGE reaches BOGONS only through the filter: PL ← filter ← service.
+	o.Services["EDGE"] = &ServiceSpec{ServiceType: "routed", IngressFilter: "MGMT"}
+	g := BuildReferenceGraph(o)
+
+	got := map[string][]string{}
+	for _, d := range g.Dependents(SpecNode{Kind: "PrefixListSpec", Name: "BOGONS"}) {
+		var via []string
+		for _, n := range d.Via {
+			via = append(via, n.String())
+		}
+		got[d.String()] = via
+	}
+	want := map[string][]string{
+		"FilterSpec/MGMT":     {"PrefixListSpec/BOGONS", "FilterSpec/MGMT"},
+		"RoutePolicy/RP1":     {"PrefixListSpec/BOGONS", "RoutePolicy/RP1"},
+		"ServiceSpec/TRANSIT": {"PrefixListSpec/BOGONS", "ServiceSpec/TRANSIT"}, // direct beats via MGMT
+		"ServiceSpec/EDGE":    {"PrefixListSpec/BOGONS", "FilterSpec/MGMT", "ServiceSpec/EDGE"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Dependents(BOGONS) =\n  %v\nwant\n  %v", got, want)
+	}
+
+	deps := g.Dependents(SpecNode{Kind: "PrefixListSpec", Name: "BOGONS"})
+	if last := deps[len(deps)-1]; last.Name != "EDGE" || last.Field != "ingress_filter" {
+		t.Errorf("last dependent = %+v, want the two-hop EDGE via ingress_filter", last)
+	}
+	if got := g.Dependents(SpecNode{Kind: "ServiceSpec", Name: "TRANSIT"}); len(got) != 0 {
+		t.Errorf("Dependents(TRANSIT) = %v, want none", got)
+	}
+}
+
+func TestReferenceGraph_LookupAndEdges(t *testing.T) {
+	o := sampleSpecs()
+	o.IPVPNs["MGMT"] = &IPVPNSpec{L3VNI: 2001} // same name, another kind
+	g := BuildReferenceGraph(o)
+
+	if got := g.Lookup("mgmt"); !reflect.DeepEqual(got, []SpecNode{{"FilterSpec", "MGMT"}, {"IPVPNSpec", "MGMT"}}) {
+		t.Errorf("Lookup(mgmt) = %v", got)
+	}
+	if !g.Has(SpecNode{"ServiceSpec", "TRANSIT"}) || g.Has(SpecNode{"ServiceSpec", "NOPE"}) {
+		t.Error("Has: wrong membership")
+	}
+
+	var refs []string
+	for _, e := range g.References(SpecNode{"ServiceSpec", "TRANSIT"}) {
+		refs = append(refs, e.To.String()+" ("+e.Field+")")
+	}
+	want := []string{"FilterSpec/MGMT (ingress_filter)", "IPVPNSpec/IRB (ipvpn)", "PrefixListSpec/BOGONS (import_prefix_list)"}
+	if !reflect.DeepEqual(refs, want) {
+		t.Errorf("References(TRANSIT) = %v, want %v", refs, want)
+	}
+	if got := g.Consumers(SpecNode{"IPVPNSpec", "IRB"}); len(got) != 1 || got[0].From.Name != "TRANSIT" {
+		t.Errorf("Consumers(IRB) = %v", got)
+	}
+}
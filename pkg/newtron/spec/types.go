@@ -95,6 +95,15 @@ type NetworkSpecFile struct {
 	// serialized to JSON. (Node-scope ssh IS form-authored — see NodeSpec.)
 	SSHCredentials `schema:"-"`
 
+	// ServiceDefaults maps a service name to the apply-service parameters an
+	// operator may omit when binding it on this network. `schema:"-"` like the
+	// fields above: network.json-authored. See ServiceDefaults.
+	ServiceDefaults map[string]*ServiceDefaults `json:"service_defaults,omitempty" schema:"-"`
+
+	// ChangeGuard caps how large one device write may be before it needs
+	// force. `schema:"-"`: network.json-authored. See ChangeGuard.
+	ChangeGuard *ChangeGuard `json:"change_guard,omitempty" schema:"-"`
+
 	// Zones are NOT stored here — each lives in its own zones/<name>.json,
 	// loaded and owned by spec.Loader (mirroring nodes/<name>.json). Access
 	// them through the Loader (Zone/Zones/CreateZoneSpec/…), never a field on
@@ -102,6 +111,35 @@ type NetworkSpecFile struct {
 	// its zone file instead of churning the whole network.json (DPN §7/§28).
 }
 
+// ServiceDefaults holds a service's default apply-service parameters for one
+// network. ApplyService uses a default only when the caller leaves the value
+// out; the values actually used are recorded in the binding like any
+// caller-supplied value, so remove, refresh and replay never consult the
+// defaults again.
+//
+// IPPool is allocated per device: the interface address is the first block of
+// IPPrefixLength (default 31) in the pool that overlaps no address already
+// configured on the device — the lower address of a /31, the first host of a
+// shorter prefix. The pool is not coordinated across devices.
+type ServiceDefaults struct {
+	PeerAS         int    `json:"peer_as,omitempty"`
+	VLAN           int    `json:"vlan,omitempty"`
+	IPPool         string `json:"ip_pool,omitempty"`
+	IPPrefixLength int    `json:"ip_prefix_length,omitempty"`
+}
+
+// ChangeGuard is the blast-radius guardrail for device writes. A write whose
+// pending changes number more than MaxChanges, or touch more than MaxTables
+// distinct CONFIG_DB tables, is refused unless the caller forces it — a
+// generated change set that size is more likely a bug (a non-idempotent
+// refresh deleting and re-adding everything) than intent. 0 leaves that
+// limit off. Reconcile, which delivers whole projections by design, is not
+// guarded.
+type ChangeGuard struct {
+	MaxChanges int `json:"max_changes,omitempty"`
+	MaxTables  int `json:"max_tables,omitempty"`
+}
+
 // ZoneSpec defines zone settings (AS number, defaults).
 type ZoneSpec struct {
 	// Embedded — zone-level overrides. `schema:"-"`: overrides are authored via
@@ -147,14 +185,18 @@ type IPVPNSpec struct {
 // this MAC-VPN is instantiated (opinionated choice for simplicity).
 // AnycastIP is the shared gateway IP configured on all leafs (EVPN
 // symmetric IRB anycast gateway). Omit for pure L2 (no routing).
+// AnycastMAC is written to the device-wide SAG_GLOBAL unless
+// AnycastMACPerVLAN is set, in which case it goes to the VLAN's own SAG
+// entry — needed when IRBs on one leaf use distinct gateway MACs.
 type MACVPNSpec struct {
-	Description    string   `json:"description,omitempty" label:"Description" tooltip:"Operator-facing description of this MAC-VPN"`
-	VlanID         int      `json:"vlan_id" label:"VLAN ID" tooltip:"Local bridge-domain VLAN ID, identical on every leaf in this MAC-VPN" min:"1" max:"4094"`
-	VNI            int      `json:"vni" label:"L2VNI" tooltip:"VXLAN Network Identifier for the L2 EVPN overlay" min:"1" max:"16777215"`
-	AnycastIP      string   `json:"anycast_ip,omitempty" label:"Anycast Gateway IP" tooltip:"Shared anycast gateway IP for symmetric IRB; omit for pure L2" format:"cidr"`
-	AnycastMAC     string   `json:"anycast_mac,omitempty" label:"Anycast Gateway MAC" tooltip:"Shared anycast gateway MAC for symmetric IRB" format:"mac"`
-	RouteTargets   []string `json:"route_targets,omitempty" label:"Route Targets" tooltip:"BGP extended-community route targets controlling import/export"`
-	ARPSuppression bool     `json:"arp_suppression,omitempty" label:"Enable ARP Suppression" tooltip:"Suppress ARP flooding by answering from the EVPN MAC/IP table"`
+	Description       string   `json:"description,omitempty" label:"Description" tooltip:"Operator-facing description of this MAC-VPN"`
+	VlanID            int      `json:"vlan_id" label:"VLAN ID" tooltip:"Local bridge-domain VLAN ID, identical on every leaf in this MAC-VPN" min:"1" max:"4094"`
+	VNI               int      `json:"vni" label:"L2VNI" tooltip:"VXLAN Network Identifier for the L2 EVPN overlay" min:"1" max:"16777215"`
+	AnycastIP         string   `json:"anycast_ip,omitempty" label:"Anycast Gateway IP" tooltip:"Shared anycast gateway IP for symmetric IRB; omit for pure L2" format:"cidr"`
+	AnycastMAC        string   `json:"anycast_mac,omitempty" label:"Anycast Gateway MAC" tooltip:"Shared anycast gateway MAC for symmetric IRB" format:"mac"`
+	RouteTargets      []string `json:"route_targets,omitempty" label:"Route Targets" tooltip:"BGP extended-community route targets controlling import/export"`
+	ARPSuppression    bool     `json:"arp_suppression,omitempty" label:"Enable ARP Suppression" tooltip:"Suppress ARP flooding by answering from the EVPN MAC/IP table"`
+	AnycastMACPerVLAN bool     `json:"anycast_mac_per_vlan,omitempty" label:"Per-VLAN Anycast MAC" tooltip:"Configure the anycast gateway MAC on this VLAN's SVI only (SAG) instead of the device-wide SAG_GLOBAL"`
 }
 
 // ============================================================================
@@ -352,6 +394,8 @@ type PlatformSpec struct {
 	VMImageRelease      string       `json:"vm_image_release,omitempty" label:"VM Image Release" tooltip:"Release tag selecting release-specific boot patches (e.g. \"202405\")"`
 	VMSkipBootstrap     bool         `json:"vm_skip_bootstrap,omitempty" label:"Skip Bootstrap" tooltip:"Image is pre-bootstrapped — skip console-driven network bring-up"`
 	UnsupportedFeatures []string     `json:"unsupported_features,omitempty" label:"Unsupported Features" tooltip:"Features this platform cannot handle (e.g. \"acl\", \"evpn-vxlan\")"`
+	HashFields          []string     `json:"hash_fields,omitempty" label:"Hash Fields" tooltip:"Packet fields the ASIC can select for ECMP/LAG hashing (e.g. \"SRC_IP\", \"L4_DST_PORT\"); empty means every SONiC hash field"`
+	MaxECMPPaths        int          `json:"max_ecmp_paths,omitempty" label:"Max ECMP Paths" tooltip:"Widest ECMP group the ASIC programs — the upper bound for BGP maximum-paths; 0 means the FRR limit (256)"`
 }
 
 // PortSpec is one interface in a platform's port inventory — the device-native
@@ -373,6 +417,10 @@ type PortSpec struct {
 	NICIndex int    `json:"nic_index" label:"NIC Index" tooltip:"NIC slot backing this interface (1-based; NIC 0 is management). Absolute QEMU slot for a dedicated VM; per-host ordinal (offset by NICBase) for a coalesced host" min:"1"`
 	Speed    string `json:"speed,omitempty" label:"Speed" tooltip:"Port speed (e.g. \"40G\"); defaults to the platform default_speed when omitted"`
 	Lanes    []int  `json:"lanes,omitempty" label:"Lanes" tooltip:"SerDes lanes backing this port, when known (informational)"`
+	// Breakouts is the breakout modes this port accepts, when the port
+	// authority says (platform.json breakout_modes). Empty means the
+	// platform-wide Breakouts list applies.
+	Breakouts []string `json:"breakouts,omitempty" label:"Breakouts" tooltip:"Breakout modes this port accepts; empty means the platform-wide list applies"`
 }
 
 // PrefixListEntry describes the form shape of one entry inside a prefix
@@ -407,6 +455,23 @@ func (p *PlatformSpec) IsHost() bool {
 	return p.DeviceType == "host"
 }
 
+// PortBreakouts returns the breakout modes the named port accepts: its own
+// list when the inventory carries one, the platform-wide Breakouts
+// otherwise. Nil when the platform declares no port by that name — a port
+// outside the inventory has no known capabilities.
+func (p *PlatformSpec) PortBreakouts(name string) []string {
+	for _, port := range p.Ports {
+		if port.Name != name {
+			continue
+		}
+		if len(port.Breakouts) > 0 {
+			return port.Breakouts
+		}
+		return p.Breakouts
+	}
+	return nil
+}
+
 // featureDependencies maps each feature to its required dependencies.
 // A feature is only supported if all its dependencies are also supported.
 // This creates a dependency graph where base features (like evpn-vxlan) are
@@ -420,8 +485,9 @@ var featureDependencies = map[string][]string{
 	"ipvpn": {"evpn-vxlan"},
 
 	// Base features with no dependencies
-	"evpn-vxlan": {},
-	"acl":        {},
+	"evpn-vxlan":    {},
+	"acl":           {},
+	"igmp-snooping": {},
 }
 
 // GetAllFeatures returns all known features from the dependency map.
@@ -523,6 +589,10 @@ type NodeSpec struct {
 	MAC      string `json:"mac,omitempty" label:"Base MAC" tooltip:"Override the device base MAC (otherwise derived)" format:"mac"`
 	Platform string `json:"platform,omitempty" label:"Platform" tooltip:"Reference to a platforms.json entry; determines HWSKU, ports, and VM image" ref:"PlatformSpec"`
 
+	// OPTIONAL - how newtron reaches the device's Redis. Empty means
+	// ssh-redis (see the Transport* constants).
+	Transport string `json:"transport,omitempty" label:"Transport" tooltip:"How newtron reaches the device's Redis: ssh-redis (SSH tunnel, the default), direct-redis (mgmt_ip:6379, no tunnel — vtysh reads and config save are unavailable), or gnmi (reserved)" enum:"ssh-redis,direct-redis,gnmi"`
+
 	// Embedded — node-level overrides. `schema:"-"`: overrides are authored via
 	// the flat create-<kind>?scope=node API, not by editing these maps, so they
 	// are storage, not authoring-schema fields. Still serialized to JSON.
@@ -577,6 +647,9 @@ type ResolvedNodeSpec struct {
 	// From nodeSpec (optional)
 	MAC string
 
+	// Transport is the nodeSpec's transport, as authored ("" = ssh-redis).
+	Transport string
+
 	// SSH credentials for Redis tunnel. SSH port is runtime state
 	// owned by newtlab (§27) — resolved through newtron's PortResolver
 	// at Connect time; not part of ResolvedNodeSpec.
@@ -617,6 +690,13 @@ const (
 	PeerASRequest = "request" // Must be provided at apply time
 )
 
+// Transport constants — NodeSpec.Transport values
+const (
+	TransportSSHRedis    = "ssh-redis"    // Redis through an SSH tunnel to mgmt_ip (default)
+	TransportDirectRedis = "direct-redis" // Redis dialed directly at mgmt_ip:6379
+	TransportGNMI        = "gnmi"         // reserved; not implemented yet
+)
+
 // ============================================================================
 // Topology Specification (v4)
 // ============================================================================
@@ -182,6 +182,11 @@ func (n *NodeSpec) ValidateConstraints(isHost bool, knownZones map[string]*ZoneS
 			v.AddErrorf("unknown zone: %s", n.Zone)
 		}
 	}
+	switch n.Transport {
+	case "", TransportSSHRedis, TransportDirectRedis, TransportGNMI:
+	default:
+		v.AddErrorf("unknown transport %q (want %s, %s or %s)", n.Transport, TransportSSHRedis, TransportDirectRedis, TransportGNMI)
+	}
 
 	return v.Build()
 }
@@ -199,3 +204,39 @@ func (o *OverridableSpecs) ValidateConstraints(v *util.ValidationBuilder, prefix
 		svc.validateConstraints(v, prefix, name)
 	}
 }
+
+// validateConstraints checks the network's change_guard limits.
+func (g *ChangeGuard) validateConstraints(v *util.ValidationBuilder) {
+	if g.MaxChanges < 0 {
+		v.AddErrorf("change_guard: max_changes %d must not be negative", g.MaxChanges)
+	}
+	if g.MaxTables < 0 {
+		v.AddErrorf("change_guard: max_tables %d must not be negative", g.MaxTables)
+	}
+}
+
+// validateConstraints checks one service's network defaults: the values must be
+// ones ApplyService would accept from a caller, and the pool must hold at least
+// one block of the prefix length it hands out.
+func (d *ServiceDefaults) validateConstraints(v *util.ValidationBuilder, name string) {
+	if d.PeerAS < 0 || d.PeerAS > 4294967295 {
+		v.AddErrorf("service_defaults '%s': peer_as %d out of range", name, d.PeerAS)
+	}
+	if d.VLAN < 0 || d.VLAN > 4094 {
+		v.AddErrorf("service_defaults '%s': vlan %d out of range (1-4094)", name, d.VLAN)
+	}
+	if d.IPPool == "" {
+		if d.IPPrefixLength != 0 {
+			v.AddErrorf("service_defaults '%s': ip_prefix_length requires ip_pool", name)
+		}
+		return
+	}
+	if !util.IsValidIPv4CIDR(d.IPPool) {
+		v.AddErrorf("service_defaults '%s': invalid ip_pool %q (expected IPv4 CIDR like 10.200.0.0/24)", name, d.IPPool)
+		return
+	}
+	_, poolLen := util.SplitIPMask(d.IPPool)
+	if d.IPPrefixLength != 0 && (d.IPPrefixLength < poolLen || d.IPPrefixLength > 31) {
+		v.AddErrorf("service_defaults '%s': ip_prefix_length %d must be %d-31 for pool %s", name, d.IPPrefixLength, poolLen, d.IPPool)
+	}
+}
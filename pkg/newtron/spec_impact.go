@@ -0,0 +1,111 @@
+package newtron
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// specKindAliases maps the CLI nouns for spec kinds to their spec-graph kind,
+// so `newtron impact --kind ipvpn` and `--kind IPVPNSpec` mean the same.
+var specKindAliases = map[string]string{
+	"prefix-list":  "PrefixListSpec",
+	"filter":       "FilterSpec",
+	"qos-policy":   "QoSPolicy",
+	"route-policy": "RoutePolicy",
+	"ipvpn":        "IPVPNSpec",
+	"macvpn":       "MACVPNSpec",
+	"service":      "ServiceSpec",
+}
+
+// bindingKinds maps a spec-graph kind to the DeriveSpecRef provenance kind its
+// topology steps carry. Prefix lists and route policies are never bound on
+// their own — they reach interfaces through the specs that reference them.
+var bindingKinds = map[string]string{
+	"ServiceSpec": SpecKindService,
+	"IPVPNSpec":   SpecKindIPVPN,
+	"MACVPNSpec":  SpecKindMACVPN,
+	"QoSPolicy":   SpecKindQoS,
+	"FilterSpec":  SpecKindFilter,
+}
+
+// SpecImpact reports what editing the network-scope spec name would touch:
+// every spec that references it, directly or through other specs, and every
+// device interface where it or one of those specs is applied (topology
+// steps, the same record the delete guards read). kind may be empty when the
+// name is unique across kinds; it accepts a spec-graph kind ("IPVPNSpec") or
+// a CLI noun ("ipvpn").
+//
+// The analysis covers the network scope. A device whose zone or node
+// overrides a spec in the chain is still listed; the chain shows which spec
+// to check there.
+func (net *Network) SpecImpact(kind, name string) (*SpecImpact, error) {
+	g := net.internal.SpecReferenceGraph()
+
+	if alias, ok := specKindAliases[kind]; ok {
+		kind = alias
+	}
+	var target spec.SpecNode
+	matches := g.Lookup(name)
+	if kind != "" {
+		var kept []spec.SpecNode
+		for _, m := range matches {
+			if m.Kind == kind {
+				kept = append(kept, m)
+			}
+		}
+		matches = kept
+	}
+	switch len(matches) {
+	case 0:
+		resource := "spec"
+		if kind != "" {
+			resource = kind
+		}
+		return nil, &NotFoundError{Resource: resource, Name: name}
+	case 1:
+		target = matches[0]
+	default:
+		kinds := make([]string, len(matches))
+		for i, m := range matches {
+			kinds[i] = m.Kind
+		}
+		return nil, &ValidationError{Field: "kind", Message: fmt.Sprintf("'%s' names more than one spec (%s); choose a kind", name, strings.Join(kinds, ", "))}
+	}
+
+	out := &SpecImpact{Kind: target.Kind, Name: target.Name}
+	reached := []spec.Dependent{{SpecNode: target, Via: []spec.SpecNode{target}}}
+	for _, d := range g.Dependents(target) {
+		out.Dependents = append(out.Dependents, SpecDependent{
+			Kind:  d.Kind,
+			Name:  d.Name,
+			Field: d.Field,
+			Via:   specChain(d.Via),
+		})
+		reached = append(reached, d)
+	}
+	for _, d := range reached {
+		bk, ok := bindingKinds[d.Kind]
+		if !ok {
+			continue
+		}
+		for _, b := range net.bindingsFor(bk, d.Name) {
+			out.Bindings = append(out.Bindings, SpecImpactBinding{
+				Device:    b.Device,
+				Interface: b.Interface,
+				Spec:      d.SpecNode.String(),
+				Via:       specChain(d.Via),
+			})
+		}
+	}
+	return out, nil
+}
+
+func specChain(via []spec.SpecNode) []string {
+	out := make([]string, len(via))
+	for i, n := range via {
+		out[i] = n.String()
+	}
+	return out
+}
@@ -0,0 +1,68 @@
+package newtron
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestSpecImpact — editing IP-VPN IRB in the 2node-vs-service fixture reaches
+// the two EVPN IRB services that name it, the devices that bind the IP-VPN
+// directly (bind-ipvpn), and the SVIs those services are applied on.
+func TestSpecImpact(t *testing.T) {
+	net, _ := loadServiceFixture(t)
+
+	imp, err := net.SpecImpact("ipvpn", "irb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imp.Kind != "IPVPNSpec" || imp.Name != "IRB" {
+		t.Errorf("target = %s/%s, want IPVPNSpec/IRB", imp.Kind, imp.Name)
+	}
+	wantDeps := []SpecDependent{
+		{Kind: "ServiceSpec", Name: "EIRB1", Field: "ipvpn", Via: []string{"IPVPNSpec/IRB", "ServiceSpec/EIRB1"}},
+		{Kind: "ServiceSpec", Name: "EIRB2", Field: "ipvpn", Via: []string{"IPVPNSpec/IRB", "ServiceSpec/EIRB2"}},
+	}
+	if !reflect.DeepEqual(imp.Dependents, wantDeps) {
+		t.Errorf("dependents = %+v, want %+v", imp.Dependents, wantDeps)
+	}
+	wantBindings := []SpecImpactBinding{
+		{Device: "switch1", Spec: "IPVPNSpec/IRB", Via: []string{"IPVPNSpec/IRB"}},
+		{Device: "switch2", Spec: "IPVPNSpec/IRB", Via: []string{"IPVPNSpec/IRB"}},
+		{Device: "switch1", Interface: "Vlan400", Spec: "ServiceSpec/EIRB1", Via: []string{"IPVPNSpec/IRB", "ServiceSpec/EIRB1"}},
+		{Device: "switch2", Interface: "Vlan401", Spec: "ServiceSpec/EIRB2", Via: []string{"IPVPNSpec/IRB", "ServiceSpec/EIRB2"}},
+	}
+	if !reflect.DeepEqual(imp.Bindings, wantBindings) {
+		t.Errorf("bindings = %+v, want %+v", imp.Bindings, wantBindings)
+	}
+
+	// The kind accepts the spec-graph name as well as the CLI noun.
+	if imp2, err := net.SpecImpact("IPVPNSpec", "IRB"); err != nil || !reflect.DeepEqual(imp2, imp) {
+		t.Errorf("SpecImpact(IPVPNSpec, IRB) = %+v, %v; want the same result", imp2, err)
+	}
+}
+
+func TestSpecImpact_NameResolution(t *testing.T) {
+	net, _ := loadServiceFixture(t)
+
+	// "irb" names both an IP-VPN and a service.
+	var verr *ValidationError
+	if _, err := net.SpecImpact("", "irb"); !errors.As(err, &verr) || verr.Field != "kind" {
+		t.Errorf("ambiguous name: err = %v, want ValidationError on kind", err)
+	}
+	// Unique names need no kind; a spec nothing references has no dependents.
+	imp, err := net.SpecImpact("", "rtd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imp.Kind != "ServiceSpec" || len(imp.Dependents) != 0 || len(imp.Bindings) != 2 {
+		t.Errorf("rtd impact = %+v, want ServiceSpec with 2 bindings and no dependents", imp)
+	}
+	var nf *NotFoundError
+	if _, err := net.SpecImpact("", "no-such-spec"); !errors.As(err, &nf) {
+		t.Errorf("unknown name: err = %v, want NotFoundError", err)
+	}
+	if _, err := net.SpecImpact("filter", "rtd"); !errors.As(err, &nf) {
+		t.Errorf("wrong kind: err = %v, want NotFoundError", err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/aldrin-isaac/newtron/pkg/newtron/auth"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+	"github.com/aldrin-isaac/newtron/pkg/util"
 )
 
 // ============================================================================
@@ -68,6 +69,30 @@ func (net *Network) ListSpecInstances() ([]SpecInstance, error) {
 	return out, nil
 }
 
+// Lint runs the whole-spec modeling checks (spec.Lint) across every scope —
+// services naming missing VPNs, duplicate VNIs, IP-VPNs without route targets,
+// references to empty prefix lists. It is broader than the per-spec validation
+// the write path runs: an operator runs it before committing a spec change.
+// Issues are ordered errors first.
+func (net *Network) Lint() ([]LintIssue, error) {
+	issues, err := net.internal.LintSpecs()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]LintIssue, len(issues))
+	for i, is := range issues {
+		out[i] = LintIssue{
+			Severity:      string(is.Severity),
+			Scope:         is.Scope,
+			ScopeInstance: is.ScopeInstance,
+			Kind:          is.Kind,
+			Name:          is.Name,
+			Message:       is.Message,
+		}
+	}
+	return out, nil
+}
+
 // ============================================================================
 // Services
 // ============================================================================
@@ -279,11 +304,29 @@ func (net *Network) ShowMACVPN(sel ScopeSelector, name string) (*MACVPNDetail, e
 	return convertMACVPNDetail(name, s), nil
 }
 
+// validateAnycastMAC checks a MAC-VPN's anycast gateway MAC: a unicast MAC
+// (it is the SVI's router MAC), and present when it is to be per-VLAN.
+func validateAnycastMAC(req CreateMACVPNRequest) error {
+	if req.AnycastMAC == "" {
+		if req.AnycastMACPerVLAN {
+			return &ValidationError{Field: "anycast_mac_per_vlan", Message: "requires anycast_mac"}
+		}
+		return nil
+	}
+	if err := util.ValidateUnicastMAC(req.AnycastMAC); err != nil {
+		return &ValidationError{Field: "anycast_mac", Message: err.Error()}
+	}
+	return nil
+}
+
 // CreateMACVPN creates a new MAC-VPN definition.
 func (net *Network) CreateMACVPN(ctx context.Context, req CreateMACVPNRequest, opts ExecOpts) error {
 	if req.VNI <= 0 {
 		return &ValidationError{Field: "vni", Message: "required"}
 	}
+	if err := validateAnycastMAC(req); err != nil {
+		return err
+	}
 	if err := validateScopeSelector(req.ScopeSelector); err != nil {
 		return err
 	}
@@ -304,13 +347,14 @@ func (net *Network) CreateMACVPN(ctx context.Context, req CreateMACVPNRequest, o
 		return nil
 	}
 	macvpn := &spec.MACVPNSpec{
-		Description:    req.Description,
-		VNI:            req.VNI,
-		VlanID:         req.VlanID,
-		AnycastIP:      req.AnycastIP,
-		AnycastMAC:     req.AnycastMAC,
-		RouteTargets:   req.RouteTargets,
-		ARPSuppression: req.ARPSuppression,
+		Description:       req.Description,
+		VNI:               req.VNI,
+		VlanID:            req.VlanID,
+		AnycastIP:         req.AnycastIP,
+		AnycastMAC:        req.AnycastMAC,
+		RouteTargets:      req.RouteTargets,
+		ARPSuppression:    req.ARPSuppression,
+		AnycastMACPerVLAN: req.AnycastMACPerVLAN,
 	}
 	return net.internal.CreateMACVPN(req.Scope, req.ScopeInstance, req.Name, macvpn)
 }
@@ -1118,14 +1162,15 @@ func convertIPVPNDetail(name string, s *spec.IPVPNSpec) *IPVPNDetail {
 
 func convertMACVPNDetail(name string, s *spec.MACVPNSpec) *MACVPNDetail {
 	return &MACVPNDetail{
-		Name:           name,
-		Description:    s.Description,
-		VNI:            s.VNI,
-		VlanID:         s.VlanID,
-		AnycastIP:      s.AnycastIP,
-		AnycastMAC:     s.AnycastMAC,
-		RouteTargets:   s.RouteTargets,
-		ARPSuppression: s.ARPSuppression,
+		Name:              name,
+		Description:       s.Description,
+		VNI:               s.VNI,
+		VlanID:            s.VlanID,
+		AnycastIP:         s.AnycastIP,
+		AnycastMAC:        s.AnycastMAC,
+		RouteTargets:      s.RouteTargets,
+		ARPSuppression:    s.ARPSuppression,
+		AnycastMACPerVLAN: s.AnycastMACPerVLAN,
 	}
 }
 
@@ -1293,6 +1338,9 @@ func (net *Network) UpdateMACVPN(ctx context.Context, req CreateMACVPNRequest, o
 	if req.VNI <= 0 {
 		return &ValidationError{Field: "vni", Message: "required"}
 	}
+	if err := validateAnycastMAC(req); err != nil {
+		return err
+	}
 	if err := validateScopeSelector(req.ScopeSelector); err != nil {
 		return err
 	}
@@ -1305,13 +1353,14 @@ func (net *Network) UpdateMACVPN(ctx context.Context, req CreateMACVPNRequest, o
 		return nil
 	}
 	macvpn := &spec.MACVPNSpec{
-		Description:    req.Description,
-		VNI:            req.VNI,
-		VlanID:         req.VlanID,
-		AnycastIP:      req.AnycastIP,
-		AnycastMAC:     req.AnycastMAC,
-		RouteTargets:   req.RouteTargets,
-		ARPSuppression: req.ARPSuppression,
+		Description:       req.Description,
+		VNI:               req.VNI,
+		VlanID:            req.VlanID,
+		AnycastIP:         req.AnycastIP,
+		AnycastMAC:        req.AnycastMAC,
+		RouteTargets:      req.RouteTargets,
+		ARPSuppression:    req.ARPSuppression,
+		AnycastMACPerVLAN: req.AnycastMACPerVLAN,
 	}
 	return translateInternalError(net.internal.UpdateMACVPN(req.Scope, req.ScopeInstance, req.Name, macvpn))
 }
@@ -7,6 +7,7 @@ package newtron
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/aldrin-isaac/newtron/pkg/httputil"
@@ -36,6 +37,7 @@ const (
 type ExecOpts struct {
 	Execute bool // true = apply; false = dry-run preview
 	NoSave  bool // skip config save after apply
+	Force   bool // apply even when the change exceeds the network's change_guard
 }
 
 // ============================================================================
@@ -55,6 +57,9 @@ type ExecOpts struct {
 // (no black boxes) operationalizes through the Concrete success vision:
 // the operator sees exactly which Redis command landed, what the device
 // returned verbatim, and which was rejected. §11 + §46.
+//
+// Defaults lists the apply-service parameters taken from the network's
+// service_defaults because the caller left them out.
 type WriteResult struct {
 	Preview      string               `json:"preview,omitempty"`
 	Changes      []sonic.ConfigChange `json:"changes,omitempty"`
@@ -64,6 +69,68 @@ type WriteResult struct {
 	Verified     bool                 `json:"verified"`
 	Saved        bool                 `json:"saved"`
 	Verification *VerificationResult  `json:"verification,omitempty"`
+	Defaults     []ParamDefault       `json:"defaults,omitempty"`
+}
+
+// ParamDefault is one apply-service parameter filled from the network's
+// service_defaults: which interface, which parameter, and the value used.
+type ParamDefault struct {
+	Interface string `json:"interface"`
+	Param     string `json:"param"`
+	Value     string `json:"value"`
+}
+
+// MultiChangeSet is the outcome of one fleet-wide operation: one entry per
+// device touched, sorted by device name. Each device commits (or previews)
+// independently — a device that fails carries Error and its changes are
+// rolled back, while the others stand.
+type MultiChangeSet struct {
+	Devices []DeviceChangeSet `json:"devices"`
+}
+
+// DeviceChangeSet is one device's part of a MultiChangeSet.
+type DeviceChangeSet struct {
+	Device     string            `json:"device"`
+	Interfaces []InterfaceChange `json:"interfaces,omitempty"`
+	Result     *WriteResult      `json:"result,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+// InterfaceChange reports one interface property a fleet operation set or
+// left alone. Member names the topology-linked PortChannel member the
+// operation reached Interface through; Skipped says why nothing was set.
+type InterfaceChange struct {
+	Interface string `json:"interface"`
+	Member    string `json:"member,omitempty"`
+	Property  string `json:"property"`
+	From      string `json:"from,omitempty"`
+	To        string `json:"to"`
+	Changed   bool   `json:"changed"`
+	Skipped   string `json:"skipped,omitempty"`
+}
+
+// MTUPolicy sets interface MTU by topology role across the fleet: Fabric on
+// both ends of every switch-to-switch link, Server on every switch interface
+// facing a host. A zero MTU leaves that role untouched.
+type MTUPolicy struct {
+	Fabric int `json:"fabric,omitempty"`
+	Server int `json:"server,omitempty"`
+}
+
+// RolloutSpec applies one service to many interfaces across the fleet: each
+// row is one circuit (see Network.RolloutService).
+type RolloutSpec struct {
+	Service string       `json:"service"`
+	Rows    []RolloutRow `json:"rows"`
+}
+
+// RolloutRow is one circuit of a RolloutSpec — the interface to apply the
+// service on and its per-circuit apply-service parameters.
+type RolloutRow struct {
+	Device    string `json:"device"`
+	Interface string `json:"interface"`
+	IP        string `json:"ip,omitempty"`      // interface address for routed services (e.g. "10.1.1.1/30")
+	PeerAS    int    `json:"peer_as,omitempty"` // BGP peer AS, for services with routing.peer_as="request"
 }
 
 // VerificationResult reports ChangeSet verification outcome.
@@ -168,6 +235,63 @@ func (e *VerificationFailedError) Error() string {
 	return fmt.Sprintf("verification failed on %s: %d/%d entries did not persist", e.Device, e.Failed, e.Total)
 }
 
+// ChangeGuardError is returned (→ HTTP 409) when a write exceeds the network's
+// change_guard: more pending changes, or more distinct CONFIG_DB tables, than
+// the limits allow. Nothing was applied. Result is the preview of the refused
+// change set, so the caller can see what it would have written before
+// retrying with force.
+type ChangeGuardError struct {
+	Device     string       `json:"device"`
+	Changes    int          `json:"changes"`
+	Tables     []string     `json:"tables"`
+	MaxChanges int          `json:"max_changes,omitempty"`
+	MaxTables  int          `json:"max_tables,omitempty"`
+	Result     *WriteResult `json:"result,omitempty"`
+}
+
+func (e *ChangeGuardError) Error() string {
+	var over []string
+	if e.MaxChanges > 0 && e.Changes > e.MaxChanges {
+		over = append(over, fmt.Sprintf("max_changes %d", e.MaxChanges))
+	}
+	if e.MaxTables > 0 && len(e.Tables) > e.MaxTables {
+		over = append(over, fmt.Sprintf("max_tables %d", e.MaxTables))
+	}
+	return fmt.Sprintf("change guard: %d changes across %d tables on %s exceed %s; review with a dry run, then force to apply anyway",
+		e.Changes, len(e.Tables), e.Device, strings.Join(over, " and "))
+}
+
+// ServiceVerifyFailedError indicates an applied service did not settle within
+// its verify timeout. Result carries the ServiceResult — the apply, the
+// failing checks, and the rollback when one ran — so the wire envelope on
+// 409 responses shows what was left on the device. RollbackErr is set when
+// the rollback itself failed, leaving the service applied.
+type ServiceVerifyFailedError struct {
+	Device      string
+	RollbackErr error
+	Result      *ServiceResult
+}
+
+func (e *ServiceVerifyFailedError) Error() string {
+	r := e.Result
+	msg := fmt.Sprintf("service %s on %s %s did not settle within %s", r.Service, e.Device, r.Interface, r.Elapsed.Round(time.Second))
+	for _, c := range r.Checks {
+		if c.Status != "pass" {
+			msg += ": " + c.Message
+			break
+		}
+	}
+	switch {
+	case e.RollbackErr != nil:
+		msg += fmt.Sprintf("; rollback failed, service left applied: %s", e.RollbackErr)
+	case r.RolledBack:
+		msg += "; rolled back"
+	default:
+		msg += "; left applied"
+	}
+	return msg
+}
+
 // AuthorizationError is the public-API error returned when a
 // permission check denies an operation (auth-design.md L3). The
 // pkg/newtron/api layer maps this to HTTP 403; the typed payload on
@@ -217,6 +341,10 @@ type IRBConfig struct {
 	VRF        string
 	IPAddress  string
 	AnycastMAC string
+
+	// AnycastMACPerVLAN writes AnycastMAC to this VLAN's own SAG entry
+	// instead of the device-wide SAG_GLOBAL.
+	AnycastMACPerVLAN bool
 }
 
 // VRFConfig holds parameters for creating a VRF. Identity (the VRF name)
@@ -237,6 +365,12 @@ type BGPNeighborConfig struct {
 	// (wrappers hardcoded false), so no wire-created overlay peer could
 	// activate the AF; found while authoring the §48 evpn continuity check.
 	EVPN bool `json:"evpn,omitempty"`
+
+	// MaxPrefix limits the IPv4 unicast prefixes accepted from a direct
+	// (interface) peer; MaxPrefixAction is "warning", "restart", or empty to
+	// tear the session down past it. Not supported on EVPN overlay peers.
+	MaxPrefix       int    `json:"max_prefix,omitempty"`
+	MaxPrefixAction string `json:"max_prefix_action,omitempty"`
 }
 
 // ACLConfig holds parameters for creating an ACL table. Identity (the table
@@ -252,13 +386,17 @@ type ACLConfig struct {
 // rule name) travels as method arguments (§47 — the composite key is not
 // part of the mutable payload).
 type ACLRuleConfig struct {
-	Priority int
-	Action   string
-	SrcIP    string
-	DstIP    string
-	Protocol string
-	SrcPort  string
-	DstPort  string
+	Priority   int
+	Action     string
+	SrcIP      string
+	DstIP      string
+	Protocol   string
+	SrcPort    string
+	DstPort    string
+	SrcIPv6    string
+	DstIPv6    string
+	ICMPv6Type string
+	EtherType  string
 }
 
 // InterfaceConfig holds parameters for ConfigureInterface. Routed mode (VRF+IP)
@@ -280,6 +418,54 @@ type PortChannelConfig struct {
 	MTU      int
 }
 
+// BannerConfig holds the device's login banners: Login before authentication,
+// MOTD after login, Logout at session end. An empty message is left blank.
+type BannerConfig struct {
+	Login  string
+	MOTD   string
+	Logout string
+}
+
+// StaticRouteConfig holds a static route's paths. NextHops and Interfaces
+// pair by position when both are given; either alone lists gateway or
+// interface-only paths, more than one of which is ECMP. Blackhole discards
+// the prefix and takes neither. Distance 0 leaves the distance unset.
+type StaticRouteConfig struct {
+	NextHops   []string `json:"nexthops,omitempty"`
+	Interfaces []string `json:"ifnames,omitempty"`
+	Blackhole  bool     `json:"blackhole,omitempty"`
+	Distance   int      `json:"distance,omitempty"`
+}
+
+// MirrorConfig holds an ERSPAN mirror session's settings: copies of Ports'
+// traffic in Direction (rx, tx, or both; empty means both) are
+// GRE-encapsulated to DstIP, reached in VRF (empty means default). Empty
+// SrcIP means the device loopback; TTL 0 means 255; empty GREType means
+// 0x88be (ERSPAN II). DSCP marks the GRE packets, 0-63.
+type MirrorConfig struct {
+	DstIP     string
+	SrcIP     string
+	Ports     []string
+	Direction string
+	VRF       string
+	DSCP      int
+	TTL       int
+	GREType   string
+}
+
+// DHCPServerConfig holds a VLAN's built-in DHCP server settings. Ranges are
+// "first-last" (or single) IPv4 addresses inside the VLAN's IRB subnet. Empty
+// Ports serves every current member; empty Gateway means the IRB address;
+// LeaseTime 0 means 900 seconds. Options are custom DHCP options by code,
+// sent as text.
+type DHCPServerConfig struct {
+	Ranges    []string
+	Ports     []string
+	Gateway   string
+	LeaseTime int
+	Options   map[int]string
+}
+
 // ServiceProjectionNode reports the projection-slice contribution of a service
 // on a single Node. Diff is the canonical sonic.DriftEntry vocabulary per §11:
 // "missing" entries are exclusively the service's contribution; "modified"
@@ -302,10 +488,18 @@ type ServiceProjectionResult struct {
 
 // ApplyServiceOpts contains options for applying a service to an interface.
 type ApplyServiceOpts struct {
-	IPAddress string            // IP address for routed/IRB services (e.g., "10.1.1.1/30"); for a local irb, the SVI gateway the composite authors
-	VLAN      int               // VLAN ID for local types (irb, bridged) — overlay types use macvpnDef.VlanID
-	PeerAS    int               // BGP peer AS number (for services with routing.peer_as="request")
-	Params    map[string]string // topology params (peer_as, route_reflector_client, next_hop_self)
+	IPAddress    string            // IP address for routed/IRB services (e.g., "10.1.1.1/30"); for a local irb, the SVI gateway the composite authors
+	SecondaryIPs []string          // additional addresses on a routed port (routed, evpn-routed); must not overlap IPAddress
+	VLAN         int               // VLAN ID for local types (irb, bridged) — overlay types use macvpnDef.VlanID
+	PeerAS       int               // BGP peer AS number (for services with routing.peer_as="request")
+	Params       map[string]string // topology params (peer_as, route_reflector_client, next_hop_self)
+}
+
+// ServiceBinding is one interface's entry in a Node.ApplyServiceBatch call.
+type ServiceBinding struct {
+	Interface string
+	Service   string
+	Opts      ApplyServiceOpts
 }
 
 // ============================================================================
@@ -462,6 +656,30 @@ type VRFStatusEntry struct {
 	State      string `json:"state,omitempty"`
 }
 
+// RouteLeak is an inter-VRF route leak: DstVRF imports SrcVRF's routes,
+// limited to Prefixes when set.
+type RouteLeak struct {
+	SrcVRF   string   `json:"src_vrf"`
+	DstVRF   string   `json:"dst_vrf"`
+	Prefixes []string `json:"prefixes,omitempty"`
+	RouteMap string   `json:"route_map,omitempty"` // import route-map filtering the leak
+}
+
+// RouteLeakHealth compares the route leak into DstVRF with the routes that
+// actually arrived there. Healthy means the leak imports something and every
+// prefix it is limited to; a leak that is configured but imports nothing is
+// otherwise a silent failure.
+type RouteLeakHealth struct {
+	SrcVRF       string       `json:"src_vrf"`
+	DstVRF       string       `json:"dst_vrf"`
+	Healthy      bool         `json:"healthy"`
+	Expected     []string     `json:"expected,omitempty"` // the leak's prefixes; empty when unfiltered
+	Leaked       []RouteEntry `json:"leaked"`             // installed routes imported from SrcVRF
+	Missing      []string     `json:"missing,omitempty"`  // Expected prefixes that did not arrive
+	LeakedCount  int          `json:"leaked_count"`
+	MissingCount int          `json:"missing_count"`
+}
+
 // ACLTableSummary is a row in ACL list output.
 type ACLTableSummary struct {
 	Name       string `json:"name"`
@@ -473,14 +691,18 @@ type ACLTableSummary struct {
 
 // ACLRuleInfo is a single ACL rule.
 type ACLRuleInfo struct {
-	Name     string `json:"name"`
-	Priority string `json:"priority"`
-	Action   string `json:"action"`
-	SrcIP    string `json:"src_ip,omitempty"`
-	DstIP    string `json:"dst_ip,omitempty"`
-	Protocol string `json:"protocol,omitempty"`
-	SrcPort  string `json:"src_port,omitempty"`
-	DstPort  string `json:"dst_port,omitempty"`
+	Name       string `json:"name"`
+	Priority   string `json:"priority"`
+	Action     string `json:"action"`
+	SrcIP      string `json:"src_ip,omitempty"`
+	DstIP      string `json:"dst_ip,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+	SrcPort    string `json:"src_port,omitempty"`
+	DstPort    string `json:"dst_port,omitempty"`
+	SrcIPv6    string `json:"src_ipv6,omitempty"`
+	DstIPv6    string `json:"dst_ipv6,omitempty"`
+	ICMPv6Type string `json:"icmpv6_type,omitempty"`
+	EtherType  string `json:"ether_type,omitempty"`
 }
 
 // ACLTableDetail is an ACL table with all its rules.
@@ -506,6 +728,9 @@ type BGPNeighborStatus struct {
 	PfxRcvd   string `json:"pfx_rcvd,omitempty"`
 	PfxSent   string `json:"pfx_sent,omitempty"`
 	Uptime    string `json:"uptime,omitempty"`
+
+	MaxPrefix       string `json:"max_prefix,omitempty"`        // ipv4_unicast prefix limit
+	MaxPrefixAction string `json:"max_prefix_action,omitempty"` // "warning", "restart"; empty = session teardown
 }
 
 // BGPStatusResult is the complete BGP status view.
@@ -556,6 +781,23 @@ type ServiceBindingDetail struct {
 	VRF         string   `json:"vrf,omitempty"`
 }
 
+// ServiceBindingEntry is the service applied to one interface, as its
+// binding record states it.
+type ServiceBindingEntry struct {
+	Interface    string   `json:"interface"`
+	Service      string   `json:"service"`
+	IPAddress    string   `json:"ip_address,omitempty"`
+	SecondaryIPs []string `json:"secondary_ips,omitempty"`
+	VRF          string   `json:"vrf,omitempty"`
+	IPVPN        string   `json:"ipvpn,omitempty"`
+	MACVPN       string   `json:"macvpn,omitempty"`
+	IngressACL   string   `json:"ingress_acl,omitempty"`
+	EgressACL    string   `json:"egress_acl,omitempty"`
+	BGPNeighbor  string   `json:"bgp_neighbor,omitempty"`
+	QoSPolicy    string   `json:"qos_policy,omitempty"`
+	VLAN         int      `json:"vlan,omitempty"`
+}
+
 // LAGStatusEntry is a LAG with operational state.
 type LAGStatusEntry struct {
 	Name          string   `json:"name"`
@@ -578,6 +820,21 @@ type VLANStatusEntry struct {
 	MACVPNInfo  *VLANMACVPNDetail `json:"macvpn_detail,omitempty"`
 }
 
+// VLANMembership is one VLAN's ports and SVI as they stand in the device's
+// CONFIG_DB (VLAN ⨝ VLAN_MEMBER ⨝ VLAN_INTERFACE) — the `show vlan brief`
+// view. Unlike VLANStatusEntry, which is built from intents, it reports
+// members added out of band and VLANs whose VLAN entry is missing.
+type VLANMembership struct {
+	ID           int      `json:"id"`
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Tagged       []string `json:"tagged,omitempty"`
+	Untagged     []string `json:"untagged,omitempty"`
+	SVI          bool     `json:"svi"`
+	SVIAddresses []string `json:"svi_addresses,omitempty"`
+	VRF          string   `json:"vrf,omitempty"`
+}
+
 // VLANMACVPNDetail holds MAC-VPN binding details for a VLAN.
 type VLANMACVPNDetail struct {
 	Name           string `json:"name,omitempty"`
@@ -605,9 +862,275 @@ type ConfigDriftResult struct {
 
 // HealthCheckResult represents the result of a single operational health check.
 type HealthCheckResult struct {
-	Check   string `json:"check"`   // Check name (e.g., "bgp", "interface-oper")
-	Status  string `json:"status"`  // "pass", "warn", "fail"
-	Message string `json:"message"` // Human-readable message
+	Check   string `json:"check"`         // Check name (e.g., "bgp", "interface-oper")
+	VRF     string `json:"vrf,omitempty"` // VRF of a per-neighbor "bgp" result
+	Status  string `json:"status"`        // "pass", "warn", "fail"
+	Message string `json:"message"`       // Human-readable message
+}
+
+// BGPConvergence is a one-glance summary of a device's BGP sessions: how
+// many of the expected peers are Established, how many sit in each other
+// state, and whether initial convergence (every expected peer up) is
+// reached — overall and per VRF.
+type BGPConvergence struct {
+	Total       int                 `json:"total"`
+	Established int                 `json:"established"`
+	States      map[string]int      `json:"states,omitempty"` // non-Established state (e.g. "Active", "NotFound") → peer count
+	Converged   bool                `json:"converged"`
+	VRFs        []BGPVRFConvergence `json:"vrfs,omitempty"`
+}
+
+// BGPVRFConvergence is the BGPConvergence summary of one VRF.
+type BGPVRFConvergence struct {
+	VRF         string         `json:"vrf"`
+	Total       int            `json:"total"`
+	Established int            `json:"established"`
+	States      map[string]int `json:"states,omitempty"`
+	Converged   bool           `json:"converged"`
+}
+
+// Environment is a device's platform sensor state — PSUs, fans, and
+// thermals — as pmon publishes it to STATE_DB (PSU_INFO, FAN_INFO,
+// TEMPERATURE_INFO). Readings are reported as-is; a virtual platform
+// without sensor daemons returns all three lists empty.
+type Environment struct {
+	PSUs     []PSUStatus     `json:"psus,omitempty"`
+	Fans     []FanStatus     `json:"fans,omitempty"`
+	Thermals []ThermalStatus `json:"thermals,omitempty"`
+}
+
+// PSUStatus is one power supply. Voltage is in volts, Power in watts.
+type PSUStatus struct {
+	Name      string  `json:"name"`
+	Model     string  `json:"model,omitempty"`
+	Present   bool    `json:"present"`
+	PowerGood bool    `json:"power_good"`
+	Voltage   float64 `json:"voltage,omitempty"`
+	Power     float64 `json:"power,omitempty"`
+}
+
+// FanStatus is one fan. Speed is a percentage of full speed.
+type FanStatus struct {
+	Name       string `json:"name"`
+	Drawer     string `json:"drawer,omitempty"`
+	Present    bool   `json:"present"`
+	OK         bool   `json:"ok"`
+	Speed      int    `json:"speed"`
+	UnderSpeed bool   `json:"under_speed"`
+	OverSpeed  bool   `json:"over_speed"`
+}
+
+// ThermalStatus is one temperature sensor, in °C. A zero threshold means the
+// sensor reports none.
+type ThermalStatus struct {
+	Name                  string  `json:"name"`
+	Temperature           float64 `json:"temperature"`
+	HighThreshold         float64 `json:"high_threshold,omitempty"`
+	CriticalHighThreshold float64 `json:"critical_high_threshold,omitempty"`
+	Warning               bool    `json:"warning"`
+}
+
+// VXLANStat is one VXLAN tunnel's encap/decap counters (COUNTERS_DB
+// SAI_TUNNEL_STAT_*) with the VNIs it carries. SAI counts per tunnel, not per
+// VNI: the local source VTEP is one tunnel and each EVPN remote VTEP another.
+// Counted is false when the device has no counters for the tunnel — the
+// TUNNEL counter group is not polling — so zeros are never mistaken for "no
+// traffic".
+type VXLANStat struct {
+	Tunnel       string `json:"tunnel"`
+	SourceIP     string `json:"source_ip,omitempty"`
+	RemoteVTEP   string `json:"remote_vtep,omitempty"`
+	VNIs         []int  `json:"vnis,omitempty"`
+	Counted      bool   `json:"counted"`
+	EncapPackets uint64 `json:"encap_packets"`
+	EncapBytes   uint64 `json:"encap_bytes"`
+	DecapPackets uint64 `json:"decap_packets"`
+	DecapBytes   uint64 `json:"decap_bytes"`
+}
+
+// LAGHashDistribution is how a PortChannel spread the packets it sent across
+// its members over one sample window (the members' TX counter deltas). Skew is
+// the percentage points the busiest member's share sits above an even split —
+// 0 for a perfectly even LAG, 50 for a two-member LAG polarized onto one link.
+type LAGHashDistribution struct {
+	Name      string           `json:"name"`
+	Window    string           `json:"window"` // e.g. "10s"
+	TxPackets uint64           `json:"tx_packets"`
+	Members   []LAGMemberShare `json:"members"`
+	Skew      float64          `json:"skew"`
+}
+
+// LAGMemberShare is one member's part of a LAGHashDistribution.
+type LAGMemberShare struct {
+	Name      string  `json:"name"`
+	TxPackets uint64  `json:"tx_packets"`
+	Share     float64 `json:"share"` // percent of the LAG's tx_packets
+}
+
+// LogLine is one line of a device's syslog. Severity is the keyword SONiC's
+// template writes (ERR, CRIT, ...); Time is zero and only Message is set for a
+// line that does not follow the syslog format.
+type LogLine struct {
+	Time     time.Time `json:"time,omitzero"`
+	Host     string    `json:"host,omitempty"`
+	Severity string    `json:"severity,omitempty"`
+	Program  string    `json:"program,omitempty"`
+	Message  string    `json:"message"`
+}
+
+// DHCPLease is one lease granted by the built-in DHCP server (STATE_DB
+// DHCP_SERVER_IPV4_LEASE). Start and End are zero when the device does not
+// report them.
+type DHCPLease struct {
+	VLAN  string    `json:"vlan"`
+	MAC   string    `json:"mac"`
+	IP    string    `json:"ip"`
+	Start time.Time `json:"start,omitzero"`
+	End   time.Time `json:"end,omitzero"`
+}
+
+// ARPSuppressionState is one VLAN's EVPN ARP suppression at each layer:
+// Intended when newtron's intents write it, Configured when the device's
+// CONFIG_DB SUPPRESS_VLAN_NEIGH has suppress=on, and Applied when vlanmgrd
+// has taken it up into APPL_DB SUPPRESS_VLAN_NEIGH_TABLE. Configured without
+// Applied means ARP is still flooding.
+type ARPSuppressionState struct {
+	VLAN       string `json:"vlan"`
+	Intended   bool   `json:"intended"`
+	Configured bool   `json:"configured"`
+	Applied    bool   `json:"applied"`
+}
+
+// IGMPState is one VLAN's IGMP snooping: Intended when newtron's intents
+// enable it, Configured when the device's CONFIG_DB CFG_L2MC_TABLE has
+// enabled=true, and the multicast groups l2mcd has learned (STATE_DB
+// L2MC_MEMBER_TABLE). Supported is false on a platform without
+// igmp-snooping, and then nothing else is read.
+type IGMPState struct {
+	VLAN       string      `json:"vlan"`
+	Supported  bool        `json:"supported"`
+	Intended   bool        `json:"intended"`
+	Configured bool        `json:"configured"`
+	Groups     []IGMPGroup `json:"groups"`
+}
+
+// IGMPGroup is one multicast group learned on a VLAN and the ports that
+// joined it. Source is 0.0.0.0 for an any-source (IGMPv2) join.
+type IGMPGroup struct {
+	Group  string   `json:"group"`
+	Source string   `json:"source"`
+	Ports  []string `json:"ports"`
+}
+
+// FlapHistory is one physical port's link-flap record as portsyncd keeps it
+// in APPL_DB PORT_TABLE: the cumulative flap count and the last down and up
+// transitions, oldest first. Supported is false on an image that does not
+// track flaps, and then nothing else is set.
+type FlapHistory struct {
+	Interface string      `json:"interface"`
+	Supported bool        `json:"supported"`
+	FlapCount int         `json:"flap_count"`
+	Events    []FlapEvent `json:"events"`
+}
+
+// FlapEvent is one recorded link transition; Time is as the device wrote it.
+type FlapEvent struct {
+	Status string `json:"status"`
+	Time   string `json:"time"`
+}
+
+// EVPNMACIPRoute is one EVPN type-2 (MAC/IP) route in a VNI's BGP table.
+// NextHop is the VTEP that advertised an imported route; a route this device
+// originated has Local set and no NextHop.
+type EVPNMACIPRoute struct {
+	VNI     int    `json:"vni"`
+	MAC     string `json:"mac"`
+	IP      string `json:"ip,omitempty"`
+	NextHop string `json:"next_hop,omitempty"`
+	Local   bool   `json:"local"`
+}
+
+// EgressShaper is one interface's egress shaper. RateKbps is the intended
+// rate (0 when no shaper is set); ConfiguredKbps is the rate the device's
+// CONFIG_DB SCHEDULER row carries, and Attached whether PORT_QOS_MAP points
+// the port at it — the two agree with RateKbps once the shaper has landed.
+type EgressShaper struct {
+	Interface      string `json:"interface"`
+	RateKbps       int    `json:"rate_kbps"`
+	ConfiguredKbps int    `json:"configured_kbps"`
+	Attached       bool   `json:"attached"`
+}
+
+// ConfigError is one delivered CONFIG_DB entry the dataplane has not accepted:
+// its owning daemon has published no STATE_DB row for it, or the row's state
+// is not "ok". Reason is "missing" or "state=<value>".
+type ConfigError struct {
+	Table      string `json:"table"`
+	Key        string `json:"key"`
+	StateTable string `json:"state_table"`
+	Reason     string `json:"reason"`
+}
+
+// ConvergenceOpts selects the conditions WaitForConvergence polls and how
+// long it waits for them to hold together. At least one condition is needed.
+type ConvergenceOpts struct {
+	BGP      bool               `json:"bgp,omitempty"`    // every configured BGP session Established
+	Routes   []ConvergenceRoute `json:"routes,omitempty"` // each route present in APP_DB
+	Health   bool               `json:"health,omitempty"` // health check not failing
+	Timeout  time.Duration      `json:"timeout"`
+	Interval time.Duration      `json:"interval"`
+}
+
+// ConvergenceRoute names one route that must be present for convergence.
+// An empty VRF means "default".
+type ConvergenceRoute struct {
+	VRF    string `json:"vrf,omitempty"`
+	Prefix string `json:"prefix"`
+}
+
+// ConvergenceResult reports the outcome of WaitForConvergence. LastSatisfied
+// names the condition that was the last to come true — the one that gated
+// convergence — and is empty when the device did not converge.
+type ConvergenceResult struct {
+	Device        string                 `json:"device"`
+	Converged     bool                   `json:"converged"`
+	Elapsed       time.Duration          `json:"elapsed"`
+	LastSatisfied string                 `json:"last_satisfied,omitempty"`
+	Conditions    []ConvergenceCondition `json:"conditions"`
+}
+
+// ConvergenceCondition is the final state of one polled condition.
+// SatisfiedAfter is the time from the start of the wait until the condition
+// last became true; Message explains the latest unsatisfied observation.
+type ConvergenceCondition struct {
+	Name           string        `json:"name"` // "bgp", "health", or "route <vrf>|<prefix>"
+	Satisfied      bool          `json:"satisfied"`
+	SatisfiedAfter time.Duration `json:"satisfied_after,omitempty"`
+	Message        string        `json:"message,omitempty"`
+}
+
+// ServiceVerifyOpts configures the settle check of ApplyServiceChecked. Zero
+// Timeout and Interval mean 60s and 2s. With Rollback, a service that does not
+// settle is removed again; without it, it is left applied for inspection.
+type ServiceVerifyOpts struct {
+	Timeout  time.Duration `json:"timeout"`
+	Interval time.Duration `json:"interval"`
+	Rollback bool          `json:"rollback,omitempty"`
+}
+
+// ServiceResult is the outcome of ApplyServiceChecked: the apply, the last
+// settle check (interface oper-up, and the service's BGP session when it
+// peers), and — when the service did not settle and rollback was asked
+// for — the remove-service that undid it.
+type ServiceResult struct {
+	Interface  string              `json:"interface"`
+	Service    string              `json:"service"`
+	Apply      *WriteResult        `json:"apply"`
+	Converged  bool                `json:"converged"`
+	Elapsed    time.Duration       `json:"elapsed"`
+	Checks     []HealthCheckResult `json:"checks,omitempty"`
+	RolledBack bool                `json:"rolled_back,omitempty"`
+	Rollback   *WriteResult        `json:"rollback,omitempty"`
 }
 
 // ============================================================================
@@ -628,6 +1151,71 @@ type SpecInstance struct {
 	ScopeInstance string `json:"scope_instance"`
 }
 
+// LintIssue is one finding of a whole-spec lint (Network.Lint): a modeling
+// smell located by the scope that defines the offending spec. Severity is
+// "error" (breaks the dataplane when applied) or "warning" (worth a second
+// look); `newtron lint` exits non-zero only on errors.
+type LintIssue struct {
+	Severity      string `json:"severity"`
+	Scope         string `json:"scope"`
+	ScopeInstance string `json:"scope_instance,omitempty"`
+	Kind          string `json:"kind"`
+	Name          string `json:"name"`
+	Message       string `json:"message"`
+}
+
+// SpecImpact is the blast radius of editing one network-scope spec
+// (Network.SpecImpact): the specs that reference it, directly or through other
+// specs, and the device interfaces where it or any of them is applied.
+type SpecImpact struct {
+	Kind       string              `json:"kind"`
+	Name       string              `json:"name"`
+	Dependents []SpecDependent     `json:"dependents,omitempty"`
+	Bindings   []SpecImpactBinding `json:"bindings,omitempty"`
+}
+
+// SpecDependent is a spec an edit reaches. Via is the reference chain from the
+// edited spec to it, as "Kind/Name" entries; Field is the field of this spec
+// that holds the last reference.
+type SpecDependent struct {
+	Kind  string   `json:"kind"`
+	Name  string   `json:"name"`
+	Field string   `json:"field"`
+	Via   []string `json:"via"`
+}
+
+// SpecImpactBinding is an interface an edit reaches: Spec ("Kind/Name") is
+// applied there per the topology steps, and Via is the chain from the edited
+// spec to Spec. Interface is empty for a device-level binding.
+type SpecImpactBinding struct {
+	Device    string   `json:"device"`
+	Interface string   `json:"interface,omitempty"`
+	Spec      string   `json:"spec"`
+	Via       []string `json:"via"`
+}
+
+// DeviceDiff is the normalized CONFIG_DB comparison of two devices
+// (Network.DiffDevices). Per-device values — hostname, loopback, router ID,
+// VTEP source, management IP, ASN — appear as placeholders such as
+// "<loopback_ip>", so Entries lists only the real discrepancies.
+type DeviceDiff struct {
+	DeviceA string            `json:"device_a"`
+	DeviceB string            `json:"device_b"`
+	Entries []DeviceDiffEntry `json:"entries"`
+}
+
+// DeviceDiffEntry is one CONFIG_DB entry that differs between the two devices.
+// Type is "only_a", "only_b" or "modified"; Fields names the differing fields
+// of a modified entry.
+type DeviceDiffEntry struct {
+	Table  string            `json:"table"`
+	Key    string            `json:"key"`
+	Type   string            `json:"type"`
+	A      map[string]string `json:"a,omitempty"`
+	B      map[string]string `json:"b,omitempty"`
+	Fields []string          `json:"fields,omitempty"`
+}
+
 // ServiceDetail is the API view of a service definition.
 type ServiceDetail struct {
 	Name          string         `json:"name"`
@@ -671,14 +1259,15 @@ type IPVPNDetail struct {
 
 // MACVPNDetail is the API view of a MAC-VPN definition.
 type MACVPNDetail struct {
-	Name           string   `json:"name"`
-	Description    string   `json:"description,omitempty"`
-	AnycastIP      string   `json:"anycast_ip,omitempty"`
-	AnycastMAC     string   `json:"anycast_mac,omitempty"`
-	VNI            int      `json:"vni"`
-	VlanID         int      `json:"vlan_id"`
-	RouteTargets   []string `json:"route_targets,omitempty"`
-	ARPSuppression bool     `json:"arp_suppression,omitempty"`
+	Name              string   `json:"name"`
+	Description       string   `json:"description,omitempty"`
+	AnycastIP         string   `json:"anycast_ip,omitempty"`
+	AnycastMAC        string   `json:"anycast_mac,omitempty"`
+	VNI               int      `json:"vni"`
+	VlanID            int      `json:"vlan_id"`
+	RouteTargets      []string `json:"route_targets,omitempty"`
+	ARPSuppression    bool     `json:"arp_suppression,omitempty"`
+	AnycastMACPerVLAN bool     `json:"anycast_mac_per_vlan,omitempty"`
 }
 
 // QoSPolicyDetail is the API view of a QoS policy.
@@ -786,6 +1375,7 @@ type CreateNodeSpecRequest struct {
 	Platform    string                   `json:"platform,omitempty"`
 	MAC         string                   `json:"mac,omitempty"`
 	UnderlayASN int                      `json:"underlay_asn,omitempty"`
+	Transport   string                   `json:"transport,omitempty"` // ssh-redis (default), direct-redis, or gnmi
 	EVPN        *CreateEVPNConfigRequest `json:"evpn,omitempty"`
 	// No ssh_user/ssh_pass: the device SSH login is authored uniformly at any
 	// scope via set-ssh-credentials (network/zone/node), not on the node body —
@@ -900,14 +1490,15 @@ type CreateIPVPNRequest struct {
 // CreateMACVPNRequest is the request for creating a MAC-VPN definition.
 type CreateMACVPNRequest struct {
 	ScopeSelector
-	Name           string   `json:"name"`
-	VNI            int      `json:"vni"`
-	VlanID         int      `json:"vlan_id,omitempty"`
-	AnycastIP      string   `json:"anycast_ip,omitempty"`
-	AnycastMAC     string   `json:"anycast_mac,omitempty"`
-	RouteTargets   []string `json:"route_targets,omitempty"`
-	ARPSuppression bool     `json:"arp_suppression,omitempty"`
-	Description    string   `json:"description,omitempty"`
+	Name              string   `json:"name"`
+	VNI               int      `json:"vni"`
+	VlanID            int      `json:"vlan_id,omitempty"`
+	AnycastIP         string   `json:"anycast_ip,omitempty"`
+	AnycastMAC        string   `json:"anycast_mac,omitempty"`
+	RouteTargets      []string `json:"route_targets,omitempty"`
+	ARPSuppression    bool     `json:"arp_suppression,omitempty"`
+	AnycastMACPerVLAN bool     `json:"anycast_mac_per_vlan,omitempty"`
+	Description       string   `json:"description,omitempty"`
 }
 
 // CreateQoSPolicyRequest is the request for creating a QoS policy.
@@ -1158,11 +1749,12 @@ type AuditEvent struct {
 // (the values overwritten or deleted; empty on an add) — together they make the
 // change reversible without re-reading the device (issue #236).
 type AuditChange struct {
-	Table  string            `json:"table"`
-	Key    string            `json:"key"`
-	Type   string            `json:"type"`
-	Fields map[string]string `json:"fields,omitempty"`
-	From   map[string]string `json:"from,omitempty"`
+	Table   string            `json:"table"`
+	Key     string            `json:"key"`
+	Type    string            `json:"type"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	From    map[string]string `json:"from,omitempty"`
+	Comment string            `json:"comment,omitempty"` // what the change is for, when the operation annotated it
 }
 
 // AuditEventPage is the wire shape returned by GET /audit/events
@@ -1277,6 +1869,14 @@ type ReconcileResult struct {
 	Message  string `json:"message,omitempty"`
 }
 
+// ConfigReloadStatus reports whether a config reload is safe — whether it
+// would discard nothing — and, when not, why. Reason is what ConfigReload
+// returns when it refuses an unforced reload.
+type ConfigReloadStatus struct {
+	Safe   bool   `json:"safe"`
+	Reason string `json:"reason,omitempty"`
+}
+
 // ============================================================================
 // Drift Detection Types
 // ============================================================================
@@ -1290,6 +1890,26 @@ type DriftEntry struct {
 	Actual   map[string]string `json:"actual,omitempty"`
 }
 
+// ImportedIntent is the brownfield-adoption report returned by ImportIntent:
+// interfaces whose hand-built config a service definition reproduces
+// (Recognized), and service-like config no definition matched (Unrecognized).
+type ImportedIntent struct {
+	Device       string            `json:"device"`
+	Recognized   []ImportedBinding `json:"recognized"`
+	Unrecognized []ImportedBinding `json:"unrecognized"`
+}
+
+// ImportedBinding is one interface's service-like configuration. Step is the
+// apply-service topology step that reproduces it, set only when Service matched.
+type ImportedBinding struct {
+	Interface   string             `json:"interface"`
+	ServiceType string             `json:"service_type,omitempty"` // inferred shape
+	Service     string             `json:"service,omitempty"`
+	Step        *spec.TopologyStep `json:"step,omitempty"`
+	Entries     []string           `json:"entries"` // "TABLE|KEY" CONFIG_DB entries it explains
+	Note        string             `json:"note,omitempty"`
+}
+
 // TopologySnapshot is the device's actuated intents projected as topology steps.
 // Returned by Snapshot() — the export direction: device reality → topology format.
 type TopologySnapshot struct {
@@ -1378,11 +1998,12 @@ type HostConnection struct {
 
 // RouteEntry represents a route read from a device's routing table.
 type RouteEntry struct {
-	Prefix   string         `json:"prefix"`
-	VRF      string         `json:"vrf"`
-	Protocol string         `json:"protocol"`
-	NextHops []RouteNextHop `json:"next_hops,omitempty"`
-	Source   string         `json:"source"` // "APP_DB" or "ASIC_DB"
+	Prefix    string         `json:"prefix"`
+	VRF       string         `json:"vrf"`
+	Protocol  string         `json:"protocol"`
+	NextHops  []RouteNextHop `json:"next_hops,omitempty"`
+	Source    string         `json:"source"`               // "APP_DB", "ASIC_DB" or "FRR"
+	OriginVRF string         `json:"origin_vrf,omitempty"` // VRF a leaked route was imported from
 }
 
 // RouteNextHop represents a single next-hop in a route entry.
@@ -1391,6 +2012,26 @@ type RouteNextHop struct {
 	Interface string `json:"interface"`
 }
 
+// NextHopGroup is one ECMP next-hop group programmed in ASIC_DB.
+type NextHopGroup struct {
+	OID      string               `json:"oid"`
+	Members  []NextHopGroupMember `json:"members"`
+	RefCount int                  `json:"ref_count"` // routes using the group
+}
+
+// NextHopGroupMember is one next hop of a next-hop group.
+type NextHopGroupMember struct {
+	Address string `json:"address"`
+	Weight  int    `json:"weight"`
+}
+
+// NextHopGroupsResult is the API view of a device's next-hop groups. Skipped
+// is set, and Groups empty, when the device has no ASIC_DB to read.
+type NextHopGroupsResult struct {
+	Groups  []NextHopGroup `json:"groups"`
+	Skipped string         `json:"skipped,omitempty"`
+}
+
 // ============================================================================
 // Request types used by the HTTP client and server.
 // These live in the public API package so that consumers (CLI, newtrun) do not
@@ -1399,10 +2040,11 @@ type RouteNextHop struct {
 
 // IRBConfigureRequest is the request body for configuring an IRB.
 type IRBConfigureRequest struct {
-	VlanID     int    `json:"vlan_id"`
-	VRF        string `json:"vrf,omitempty"`
-	IPAddress  string `json:"ip_address,omitempty"`
-	AnycastMAC string `json:"anycast_mac,omitempty"`
+	VlanID            int    `json:"vlan_id"`
+	VRF               string `json:"vrf,omitempty"`
+	IPAddress         string `json:"ip_address,omitempty"`
+	AnycastMAC        string `json:"anycast_mac,omitempty"`
+	AnycastMACPerVLAN bool   `json:"anycast_mac_per_vlan,omitempty"`
 }
 
 // Config converts the wire request to the domain config the Node API takes
@@ -1410,9 +2052,10 @@ type IRBConfigureRequest struct {
 // conversion has exactly one site.
 func (r IRBConfigureRequest) Config() IRBConfig {
 	return IRBConfig{
-		VRF:        r.VRF,
-		IPAddress:  r.IPAddress,
-		AnycastMAC: r.AnycastMAC,
+		VRF:               r.VRF,
+		IPAddress:         r.IPAddress,
+		AnycastMAC:        r.AnycastMAC,
+		AnycastMACPerVLAN: r.AnycastMACPerVLAN,
 	}
 }
 
@@ -1440,15 +2083,19 @@ func (r ACLCreateRequest) Config() ACLConfig {
 // It is the single owner of the wire shape — the client sends it verbatim
 // and the handler decodes it verbatim (§25: no per-site field copies).
 type ACLRuleAddRequest struct {
-	ACL      string `json:"acl"`
-	RuleName string `json:"rule_name"`
-	Priority int    `json:"priority"`
-	Action   string `json:"action"`
-	SrcIP    string `json:"src_ip,omitempty"`
-	DstIP    string `json:"dst_ip,omitempty"`
-	Protocol string `json:"protocol,omitempty"`
-	SrcPort  string `json:"src_port,omitempty"`
-	DstPort  string `json:"dst_port,omitempty"`
+	ACL        string `json:"acl"`
+	RuleName   string `json:"rule_name"`
+	Priority   int    `json:"priority"`
+	Action     string `json:"action"`
+	SrcIP      string `json:"src_ip,omitempty"`
+	DstIP      string `json:"dst_ip,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+	SrcPort    string `json:"src_port,omitempty"`
+	DstPort    string `json:"dst_port,omitempty"`
+	SrcIPv6    string `json:"src_ipv6,omitempty"`
+	DstIPv6    string `json:"dst_ipv6,omitempty"`
+	ICMPv6Type string `json:"icmpv6_type,omitempty"`
+	EtherType  string `json:"ether_type,omitempty"`
 }
 
 // Config converts the wire request to the domain config the Node API takes
@@ -1457,13 +2104,17 @@ type ACLRuleAddRequest struct {
 // RCA-049 documented. Enforced by TestHandlersUseConfigConverters.
 func (r ACLRuleAddRequest) Config() ACLRuleConfig {
 	return ACLRuleConfig{
-		Priority: r.Priority,
-		Action:   r.Action,
-		SrcIP:    r.SrcIP,
-		DstIP:    r.DstIP,
-		Protocol: r.Protocol,
-		SrcPort:  r.SrcPort,
-		DstPort:  r.DstPort,
+		Priority:   r.Priority,
+		Action:     r.Action,
+		SrcIP:      r.SrcIP,
+		DstIP:      r.DstIP,
+		Protocol:   r.Protocol,
+		SrcPort:    r.SrcPort,
+		DstPort:    r.DstPort,
+		SrcIPv6:    r.SrcIPv6,
+		DstIPv6:    r.DstIPv6,
+		ICMPv6Type: r.ICMPv6Type,
+		EtherType:  r.EtherType,
 	}
 }
 
@@ -1472,28 +2123,36 @@ func (r ACLRuleAddRequest) Config() ACLRuleConfig {
 // (table + rule_name) is the row's identity (§47) and is not mutable
 // through this verb — rename via remove-acl-rule + add-acl-rule. #227.
 type ACLRuleUpdateRequest struct {
-	ACL      string `json:"acl"`
-	RuleName string `json:"rule_name"`
-	Priority int    `json:"priority"`
-	Action   string `json:"action"`
-	SrcIP    string `json:"src_ip,omitempty"`
-	DstIP    string `json:"dst_ip,omitempty"`
-	Protocol string `json:"protocol,omitempty"`
-	SrcPort  string `json:"src_port,omitempty"`
-	DstPort  string `json:"dst_port,omitempty"`
+	ACL        string `json:"acl"`
+	RuleName   string `json:"rule_name"`
+	Priority   int    `json:"priority"`
+	Action     string `json:"action"`
+	SrcIP      string `json:"src_ip,omitempty"`
+	DstIP      string `json:"dst_ip,omitempty"`
+	Protocol   string `json:"protocol,omitempty"`
+	SrcPort    string `json:"src_port,omitempty"`
+	DstPort    string `json:"dst_port,omitempty"`
+	SrcIPv6    string `json:"src_ipv6,omitempty"`
+	DstIPv6    string `json:"dst_ipv6,omitempty"`
+	ICMPv6Type string `json:"icmpv6_type,omitempty"`
+	EtherType  string `json:"ether_type,omitempty"`
 }
 
 // Config converts the wire request to the domain config — see
 // ACLRuleAddRequest.Config.
 func (r ACLRuleUpdateRequest) Config() ACLRuleConfig {
 	return ACLRuleConfig{
-		Priority: r.Priority,
-		Action:   r.Action,
-		SrcIP:    r.SrcIP,
-		DstIP:    r.DstIP,
-		Protocol: r.Protocol,
-		SrcPort:  r.SrcPort,
-		DstPort:  r.DstPort,
+		Priority:   r.Priority,
+		Action:     r.Action,
+		SrcIP:      r.SrcIP,
+		DstIP:      r.DstIP,
+		Protocol:   r.Protocol,
+		SrcPort:    r.SrcPort,
+		DstPort:    r.DstPort,
+		SrcIPv6:    r.SrcIPv6,
+		DstIPv6:    r.DstIPv6,
+		ICMPv6Type: r.ICMPv6Type,
+		EtherType:  r.EtherType,
 	}
 }
 
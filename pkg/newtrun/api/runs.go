@@ -38,11 +38,41 @@ func (s *Server) handleStartRun(w http.ResponseWriter, r *http.Request) {
 		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid suite name %q", req.Suite))
 		return
 	}
+	if len(req.Scenarios) > 0 && (req.Scenario != "" || req.Target != "" || req.All) {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("scenarios cannot be combined with scenario, target, or all"))
+		return
+	}
 	// Default: All=true when neither Scenario nor Target is set, matching
 	// the CLI's default behavior.
-	if req.Scenario == "" && req.Target == "" && !req.All {
+	if req.Scenario == "" && req.Target == "" && len(req.Scenarios) == 0 && !req.All {
 		req.All = true
 	}
+	if req.OnVerifyFailure != "" && req.OnVerifyFailure != newtrun.OnVerifyFailureDumpTables {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("unknown on_verify_failure %q (want %q)", req.OnVerifyFailure, newtrun.OnVerifyFailureDumpTables))
+		return
+	}
+	if req.Golden != "" && req.Golden != newtrun.GoldenRecord && req.Golden != newtrun.GoldenCompare {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("unknown golden mode %q (want %q or %q)", req.Golden, newtrun.GoldenRecord, newtrun.GoldenCompare))
+		return
+	}
+	if req.Parallelism < 0 {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("parallelism must be >= 0, got %d", req.Parallelism))
+		return
+	}
+	if req.DeadlineSeconds < 0 {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("deadline_seconds must be >= 0, got %d", req.DeadlineSeconds))
+		return
+	}
+	if req.MaxTotalWrites < 0 {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("max_total_writes must be >= 0, got %d", req.MaxTotalWrites))
+		return
+	}
+	if req.RunID != "" {
+		if err := newtrun.ValidateRunID(req.RunID); err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
 
 	// Resolve the suite name to an on-disk location. Suite directories
 	// live under NetworksBase by convention
@@ -80,6 +110,12 @@ func (s *Server) handleStartRun(w http.ResponseWriter, r *http.Request) {
 		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("parameters override: %w", err))
 		return
 	}
+	if len(req.Scenarios) > 0 {
+		if _, err := newtrun.ComputeTargetChain(suite.Scenarios, req.Scenarios...); err != nil {
+			httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("scenarios: %w", err))
+			return
+		}
+	}
 
 	// Reserve the suite key. Same-suite re-run rejected as 409.
 	entry, err := s.registry.Acquire(suiteKey)
@@ -100,6 +136,7 @@ func (s *Server) handleStartRun(w http.ResponseWriter, r *http.Request) {
 		Scenario:   req.Scenario,
 		Target:     req.Target,
 		All:        req.All,
+		Scenarios:  req.Scenarios,
 		Platform:   req.Platform,
 		NoDeploy:   req.NoDeploy,
 		Verbose:    req.Verbose,
@@ -108,14 +145,27 @@ func (s *Server) handleStartRun(w http.ResponseWriter, r *http.Request) {
 		Keep:       true,
 		Targets:    req.Targets,
 		Parameters: req.Parameters,
+
+		OnVerifyFailure: req.OnVerifyFailure,
+		Golden:          req.Golden,
+		Preview:         req.Preview,
+		RunID:           req.RunID,
+		Parallelism:     req.Parallelism,
+		Deadline:        time.Duration(req.DeadlineSeconds) * time.Second,
+		MaxTotalWrites:  req.MaxTotalWrites,
+	}
+	if opts.RunID == "" {
+		opts.RunID = newtrun.NewRunID(entry.Started)
 	}
 
 	// Resume from paused state: if a previous run was paused, populate
 	// opts.Resume and opts.Completed so the runner skips already-passed
 	// scenarios. Mirrors the original CLI behavior — the source of truth
 	// is the on-disk state.json, which survives server restarts.
+	var resumedFrom string
 	if existing, err := newtrun.LoadRunState(suiteKey); err == nil && existing != nil {
 		if existing.Status == newtrun.SuiteStatusPaused {
+			resumedFrom = existing.RunID
 			opts.Resume = true
 			opts.Completed = make(map[string]newtrun.StepStatus, len(existing.Scenarios))
 			for _, sc := range existing.Scenarios {
@@ -130,11 +180,13 @@ func (s *Server) handleStartRun(w http.ResponseWriter, r *http.Request) {
 	// this point — the runner discovers it from the server and the
 	// state reporter fills it in via SuiteStart.
 	state := &newtrun.RunState{
-		Suite:    suiteKey,
-		Platform: req.Platform,
-		Target:   req.Target,
-		Status:   newtrun.SuiteStatusRunning,
-		Started:  entry.Started,
+		Suite:       suiteKey,
+		RunID:       opts.RunID,
+		ResumedFrom: resumedFrom,
+		Platform:    req.Platform,
+		Target:      req.Target,
+		Status:      newtrun.SuiteStatusRunning,
+		Started:     entry.Started,
 	}
 	if err := newtrun.SaveRunState(state); err != nil {
 		s.registry.Release(suiteKey, &RunResult{Err: err})
@@ -189,8 +241,10 @@ func (s *Server) handleStartRun(w http.ResponseWriter, r *http.Request) {
 	}()
 
 	httputil.WriteJSON(w, http.StatusAccepted, StartRunResponse{
-		Suite:   suiteKey,
-		Started: entry.Started,
+		Suite:       suiteKey,
+		RunID:       opts.RunID,
+		ResumedFrom: resumedFrom,
+		Started:     entry.Started,
 	})
 }
 
@@ -372,6 +426,7 @@ func (s *Server) handleStartInlineRun(w http.ResponseWriter, r *http.Request) {
 	// Inline-namespaced initial state.
 	state := &newtrun.RunState{
 		Suite:    runID,
+		RunID:    runID,
 		Network: scenario.Network,
 		Status:   newtrun.SuiteStatusRunning,
 		Started:  entry.Started,
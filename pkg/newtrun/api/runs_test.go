@@ -131,6 +131,70 @@ func TestStartRunReturns202AndRegistersEntry(t *testing.T) {
 	t.Log("registry entry registered + released before assertion; not a failure")
 }
 
+// TestStartRunRecordsRunID checks that the caller's run ID lands in the
+// response and state.json, and that resuming a paused run names the run it
+// resumed.
+func TestStartRunRecordsRunID(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+	writeMinimalSuite(t, suitesRoot(srv), "resumed-suite", scenarioYAMLBody)
+	if err := newtrun.SaveRunState(&newtrun.RunState{
+		Suite:  "resumed-suite",
+		RunID:  "20260101-000000-aaaaaa",
+		Status: newtrun.SuiteStatusPaused,
+	}); err != nil {
+		t.Fatalf("SaveRunState: %v", err)
+	}
+
+	ts := httptest.NewServer(srv.buildHandler())
+	defer ts.Close()
+
+	body, _ := json.Marshal(StartRunRequest{Suite: "resumed-suite", NoDeploy: true, RunID: "20260102-000000-bbbbbb"})
+	resp, err := http.Post(ts.URL+"/newtrun/v1/runs", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("status: got %d, want 202", resp.StatusCode)
+	}
+	var envelope struct {
+		Data StartRunResponse `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&envelope); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	started := envelope.Data
+	if started.RunID != "20260102-000000-bbbbbb" || started.ResumedFrom != "20260101-000000-aaaaaa" {
+		t.Errorf("response = %+v, want run 20260102-000000-bbbbbb resumed from 20260101-000000-aaaaaa", started)
+	}
+	state, err := newtrun.LoadRunState("resumed-suite")
+	if err != nil || state == nil {
+		t.Fatalf("LoadRunState: %v, %v", state, err)
+	}
+	if state.RunID != started.RunID || state.ResumedFrom != started.ResumedFrom {
+		t.Errorf("state run_id=%q resumed_from=%q, want %q/%q", state.RunID, state.ResumedFrom, started.RunID, started.ResumedFrom)
+	}
+}
+
+func TestStartRunReturns400OnBadRunID(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+	writeMinimalSuite(t, suitesRoot(srv), "some-suite", scenarioYAMLBody)
+	ts := httptest.NewServer(srv.buildHandler())
+	defer ts.Close()
+
+	body, _ := json.Marshal(StartRunRequest{Suite: "some-suite", RunID: "../escape"})
+	resp, err := http.Post(ts.URL+"/newtrun/v1/runs", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status: got %d, want 400", resp.StatusCode)
+	}
+}
+
 // TestStartRunReturns400OnBadTargetsOverride and the param-override
 // variant guard the pre-flight validation in handleStartRun. Without
 // it, override-validation errors would land in the goroutine's
@@ -187,6 +251,29 @@ func TestStartRunReturns400OnTargetWhitelistViolation(t *testing.T) {
 	}
 }
 
+func TestStartRunReturns400OnScenariosSelection(t *testing.T) {
+	srv, cleanup := newTestServer(t)
+	defer cleanup()
+	writeMinimalSuite(t, suitesRoot(srv), "demo-suite", scenarioYAMLBody)
+	ts := httptest.NewServer(srv.buildHandler())
+	defer ts.Close()
+
+	for name, req := range map[string]StartRunRequest{
+		"with target":      {Suite: "demo-suite", Scenarios: []string{"a"}, Target: "a"},
+		"unknown scenario": {Suite: "demo-suite", Scenarios: []string{"made-up"}},
+	} {
+		body, _ := json.Marshal(req)
+		resp, err := http.Post(ts.URL+"/newtrun/v1/runs", "application/json", bytes.NewReader(body))
+		if err != nil {
+			t.Fatalf("%s: POST: %v", name, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: status: got %d, want 400", name, resp.StatusCode)
+		}
+	}
+}
+
 func TestStartRunReturns400OnUnknownParameterOverride(t *testing.T) {
 	srv, cleanup := newTestServer(t)
 	defer cleanup()
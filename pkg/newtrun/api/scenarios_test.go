@@ -12,6 +12,8 @@ import (
 	"strings"
 	"sync"
 	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtrun"
 )
 
 // buildValidScenarioYAML is the minimum body ParseScenarioBytes accepts for
@@ -399,6 +401,69 @@ func TestSuite_RejectsBadName(t *testing.T) {
 	}
 }
 
+// TestSuite_Plan covers GET /suites/{suite}/plan: the plan resolves
+// step devices against the network's topology (read from newtron-server)
+// and flags a device the topology does not have, without running a step.
+func TestSuite_Plan(t *testing.T) {
+	srv, _ := newTestServer(t)
+	newtron := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var data any
+		switch {
+		case r.URL.Path == "/newtron/v1/networks":
+			data = []map[string]any{{"id": "synthetic", "topology": "synthetic"}}
+		case strings.HasSuffix(r.URL.Path, "/topology/nodes"):
+			data = []string{"leaf2", "leaf1"}
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer newtron.Close()
+	srv.cfg.NewtronServer = newtron.URL
+	ts := httptest.NewServer(srv.buildHandler())
+	defer ts.Close()
+
+	writeMinimalSuite(t, suitesRoot(srv), "planned", `name: check
+description: plan fixture
+steps:
+  - name: reach-all
+    action: host-exec
+    devices: all
+    command: "true"
+  - name: reach-missing
+    action: host-exec
+    devices: [leaf9]
+    command: "true"
+`)
+	resp, body := doRequest(t, ts, http.MethodGet, "/newtrun/v1/suites/planned/plan", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d, want 200; body=%s", resp.StatusCode, body)
+	}
+	var env struct {
+		Data newtrun.Plan `json:"data"`
+	}
+	if err := json.Unmarshal(body, &env); err != nil {
+		t.Fatalf("decode Plan: %v; body=%s", err, body)
+	}
+	plan := env.Data
+	if len(plan.Warnings) != 0 || len(plan.Scenarios) != 1 || len(plan.Scenarios[0].Steps) != 2 {
+		t.Fatalf("plan = %+v", plan)
+	}
+	steps := plan.Scenarios[0].Steps
+	if got := strings.Join(steps[0].Devices, ","); got != "leaf1,leaf2" {
+		t.Errorf("devices: all resolved to %q, want leaf1,leaf2", got)
+	}
+	if steps[1].Problem != "not in topology: leaf9" {
+		t.Errorf("problem = %q, want leaf9 flagged", steps[1].Problem)
+	}
+
+	resp, _ = doRequest(t, ts, http.MethodGet, "/newtrun/v1/suites/planned/plan?scenario=nope", nil)
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("unknown scenario: got %d, want 400", resp.StatusCode)
+	}
+}
+
 // TestScenario_RejectsBadName covers the scenario-level name guard.
 // requireScenarioParams runs the same nameRE on both the suite and
 // the scenario path segments; this asserts the scenario segment is
@@ -150,6 +150,7 @@ func (s *Server) buildHandler() http.Handler {
 	mux.HandleFunc("POST /newtrun/v1/suites", s.handleCreateSuite)
 	mux.HandleFunc("DELETE /newtrun/v1/suites/{suite}", s.handleDeleteSuite)
 	mux.HandleFunc("GET /newtrun/v1/suites/{suite}/scenarios", s.handleListSuiteScenarios)
+	mux.HandleFunc("GET /newtrun/v1/suites/{suite}/plan", s.handleGetSuitePlan)
 	mux.HandleFunc("GET /newtrun/v1/suites/{suite}/scenarios/{name}", s.handleGetScenario)
 	mux.HandleFunc("PUT /newtrun/v1/suites/{suite}/scenarios/{name}", s.handlePutScenario)
 	mux.HandleFunc("DELETE /newtrun/v1/suites/{suite}/scenarios/{name}", s.handleDeleteScenario)
@@ -213,3 +213,51 @@ func (s *Server) handleListSuiteScenarios(w http.ResponseWriter, r *http.Request
 	}
 	httputil.WriteJSON(w, http.StatusOK, resp)
 }
+
+// handleGetSuitePlan returns the suite's static execution plan
+// (newtrun.Plan): scenarios in dependency order with their steps, resolved
+// devices, and predicted skips. Nothing runs. Query parameters select
+// like a run: scenario=, target=, platform=. Backs `newtrun plan`.
+func (s *Server) handleGetSuitePlan(w http.ResponseWriter, r *http.Request) {
+	suite := r.PathValue("suite")
+	if !nameRE.MatchString(suite) {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("invalid suite name %q", suite))
+		return
+	}
+	dir, err := newtrun.ResolveSuiteDir(s.cfg.NetworksBase, suite)
+	if err != nil {
+		httputil.WriteError(w, mapFSErrorToStatus(err), fmt.Errorf("suite %q not found", suite))
+		return
+	}
+	q := r.URL.Query()
+	if q.Get("scenario") != "" && q.Get("target") != "" {
+		httputil.WriteError(w, http.StatusBadRequest, fmt.Errorf("scenario and target are mutually exclusive"))
+		return
+	}
+	loaded, err := newtrun.LoadSuite(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			httputil.WriteError(w, http.StatusNotFound, fmt.Errorf("suite %q not found", suite))
+			return
+		}
+		httputil.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	runner := newtrun.NewRunner(dir)
+	runner.NetworksBase = s.cfg.NetworksBase
+	runner.ServerURL = s.cfg.NewtronServer
+	runner.NetworkID = resolveNetworkID("", loaded.Network, s.cfg.NetworkID)
+	runner.NewtronClientTLS = s.cfg.NewtronClientTLS
+	runner.OperatorBearer = operatorBearer(r)
+	plan, err := runner.Plan(newtrun.PlanOptions{
+		Scenario: q.Get("scenario"),
+		Target:   q.Get("target"),
+		Platform: q.Get("platform"),
+	})
+	if err != nil {
+		httputil.WriteError(w, http.StatusBadRequest, err)
+		return
+	}
+	httputil.WriteJSON(w, http.StatusOK, plan)
+}
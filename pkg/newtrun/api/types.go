@@ -153,14 +153,21 @@ type SuiteEndPayload struct {
 // duration (Go's time.Duration serializes as nanoseconds by default, which
 // is awkward for browser consumers).
 type StepResultPayload struct {
-	Name          string                `json:"name"`
-	Action        newtrun.StepAction    `json:"action"`
-	Status        newtrun.StepStatus    `json:"status"`
-	Duration      string                `json:"duration"`
-	Message       string                `json:"message,omitempty"`
-	Details       []DeviceResultPayload `json:"details,omitempty"`
-	Iteration     int                   `json:"iteration,omitempty"`
-	TargetBinding map[string]string     `json:"target_binding,omitempty"`
+	Name          string                          `json:"name"`
+	Action        newtrun.StepAction              `json:"action"`
+	Status        newtrun.StepStatus              `json:"status"`
+	Duration      string                          `json:"duration"`
+	Message       string                          `json:"message,omitempty"`
+	Output        string                          `json:"output,omitempty"`
+	Details       []DeviceResultPayload           `json:"details,omitempty"`
+	Iteration     int                             `json:"iteration,omitempty"`
+	Item          string                          `json:"item,omitempty"`
+	TargetBinding map[string]string               `json:"target_binding,omitempty"`
+	Artifacts     []string                        `json:"artifacts,omitempty"`
+	Observed      map[string]any                  `json:"observed,omitempty"`
+	Changes       map[string][]sonic.ConfigChange `json:"changes,omitempty"`
+	Attempts      int                             `json:"attempts,omitempty"`
+	MaxAttempts   int                             `json:"max_attempts,omitempty"`
 }
 
 // DeviceResultPayload mirrors newtrun.DeviceResult.
@@ -168,6 +175,7 @@ type DeviceResultPayload struct {
 	Device  string             `json:"device"`
 	Status  newtrun.StepStatus `json:"status"`
 	Message string             `json:"message,omitempty"`
+	Output  string             `json:"output,omitempty"`
 }
 
 // scenarioSummaryFrom converts a *newtrun.Scenario to its summary form.
@@ -217,6 +225,7 @@ func stepResultFrom(r *newtrun.StepResult) StepResultPayload {
 			Device:  d.Device,
 			Status:  d.Status,
 			Message: d.Message,
+			Output:  d.Output,
 		})
 	}
 	return StepResultPayload{
@@ -225,9 +234,16 @@ func stepResultFrom(r *newtrun.StepResult) StepResultPayload {
 		Status:        r.Status,
 		Duration:      durationString(r.Duration),
 		Message:       r.Message,
+		Output:        r.Output,
 		Details:       details,
 		Iteration:     r.Iteration,
+		Item:          r.Item,
 		TargetBinding: r.TargetBinding,
+		Artifacts:     r.Artifacts,
+		Observed:      r.Observed,
+		Changes:       r.Changes,
+		Attempts:      r.Attempts,
+		MaxAttempts:   r.MaxAttempts,
 	}
 }
 
@@ -311,10 +327,16 @@ type StartRunRequest struct {
 	// scenario. Mutually exclusive with Scenario and All.
 	Target string `json:"target,omitempty"`
 
-	// All requests every scenario in the suite. Default when Scenario and
-	// Target are both unset.
+	// All requests every scenario in the suite. Default when Scenario,
+	// Target, and Scenarios are all unset.
 	All bool `json:"all,omitempty"`
 
+	// Scenarios, if set, runs the named scenarios and everything they
+	// transitively require, in dependency order — the partial rerun
+	// `newtrun rerun` submits for a previous run's failures. Mutually
+	// exclusive with Scenario, Target, and All.
+	Scenarios []string `json:"scenarios,omitempty"`
+
 	// Platform overrides the per-scenario platform.
 	Platform string `json:"platform,omitempty"`
 
@@ -342,6 +364,42 @@ type StartRunRequest struct {
 	// here for CLI compatibility with the original --junit flag.
 	JUnitPath string `json:"junit_path,omitempty"`
 
+	// OnVerifyFailure selects what the run captures when a verify step
+	// fails: "" nothing, "dump-tables" the tables the step asserted on,
+	// written under the suite's state directory (artifacts/). Any other
+	// value is rejected with 400.
+	OnVerifyFailure string `json:"on_verify_failure,omitempty"`
+
+	// Golden is the run's golden mode for observe: steps
+	// (RunOptions.Golden): "record" writes the suite's golden files,
+	// "compare" fails a step that drifted from them. Any other non-empty
+	// value is rejected with 400.
+	Golden string `json:"golden,omitempty"`
+
+	// Preview builds each write step's ChangeSet without delivering it
+	// (RunOptions.Preview); steps that cannot be previewed are skipped.
+	Preview bool `json:"preview,omitempty"`
+
+	// Parallelism caps how many devices each step works on at once
+	// (RunOptions.Parallelism). 0 means no cap; negative is rejected
+	// with 400.
+	Parallelism int `json:"parallelism,omitempty"`
+
+	// DeadlineSeconds caps the run's wall time (RunOptions.Deadline).
+	// 0 means no cap; negative is rejected with 400.
+	DeadlineSeconds int `json:"deadline_seconds,omitempty"`
+
+	// MaxTotalWrites caps the config entries the run may deliver
+	// (RunOptions.MaxTotalWrites). 0 means no cap; negative is rejected
+	// with 400.
+	MaxTotalWrites int `json:"max_total_writes,omitempty"`
+
+	// RunID names this run. The CLI generates one per invocation so its
+	// local results directory and the server-side state agree; when
+	// empty the server generates one. Must be a safe directory name
+	// (newtrun.ValidateRunID), else 400.
+	RunID string `json:"run_id,omitempty"`
+
 	// Targets overrides per-dimension entries of the suite's targets
 	// block at run time. Keys must match dimensions declared in
 	// suite.yaml; omitted keys inherit the suite default. Values
@@ -371,8 +429,10 @@ type StartRunRequest struct {
 
 // StartRunResponse is the body returned by POST /api/runs.
 type StartRunResponse struct {
-	Suite   string    `json:"suite"`
-	Started time.Time `json:"started"`
+	Suite       string    `json:"suite"`
+	RunID       string    `json:"run_id"`
+	ResumedFrom string    `json:"resumed_from,omitempty"` // ID of the paused run being resumed
+	Started     time.Time `json:"started"`
 }
 
 // InlineRunRequest is the body for POST /api/runs/inline. Per §46 the
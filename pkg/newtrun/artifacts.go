@@ -0,0 +1,182 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/util"
+)
+
+// Failure artifacts. A failed verify step's message names the one field
+// that did not match; the rest of the table — what else the device holds,
+// whether the entry exists under another key — is usually what explains
+// the failure. With OnVerifyFailure set to OnVerifyFailureDumpTables the
+// runner reads every table the step asserted on, on every device that
+// failed, and writes each as JSON under the artifacts directory:
+//
+//	<artifacts>/<scenario>/<step>/<device>_<DB>_<TABLE>.json
+//
+// The paths are attached to the step result (StepResult.Artifacts), so the
+// report and state.json point straight at them. Field values pass through
+// the step's redactor before they are written. Dumping is best-effort: a
+// read or write error is logged and never changes the step's outcome.
+
+// OnVerifyFailureDumpTables is the RunOptions.OnVerifyFailure value that
+// dumps the asserted tables of a failed verify step.
+const OnVerifyFailureDumpTables = "dump-tables"
+
+// tableRef names one table in one Redis DB.
+type tableRef struct {
+	db    string // "CONFIG_DB", "STATE_DB", ...
+	table string
+}
+
+// actionTables lists the tables built-in verify actions read; newtron steps
+// derive theirs from the URL.
+var actionTables = map[StepAction][]tableRef{
+	ActionVerifyAnycast:           {{"CONFIG_DB", "SAG_GLOBAL"}, {"CONFIG_DB", "VLAN_INTERFACE"}},
+	ActionVerifyVLANMembership:    {{"CONFIG_DB", "VLAN"}, {"CONFIG_DB", "VLAN_MEMBER"}},
+	ActionVerifyEnvironment:       {{"STATE_DB", "PSU_INFO"}, {"STATE_DB", "FAN_INFO"}, {"STATE_DB", "TEMPERATURE_INFO"}},
+	ActionVerifyRouteLeak:         {{"CONFIG_DB", "BGP_GLOBALS_AF"}, {"CONFIG_DB", "ROUTE_MAP"}, {"CONFIG_DB", "PREFIX_SET"}},
+	ActionVerifyVXLANStats:        {{"STATE_DB", "VXLAN_TUNNEL_TABLE"}, {"APPL_DB", "VXLAN_REMOTE_VNI_TABLE"}, {"COUNTERS_DB", "COUNTERS_TUNNEL_NAME_MAP"}},
+	ActionVerifyEgressShaper:      {{"CONFIG_DB", "SCHEDULER"}, {"CONFIG_DB", "PORT_QOS_MAP"}},
+	ActionVerifyBGP:               {{"CONFIG_DB", "BGP_NEIGHBOR"}, {"STATE_DB", "BGP_NEIGHBOR_TABLE"}},
+	ActionVerifyDHCPLease:         {{"STATE_DB", "DHCP_SERVER_IPV4_LEASE"}},
+	ActionVerifyLLDP:              {{"APPL_DB", "LLDP_ENTRY_TABLE"}},
+	ActionVerifyInterfaceCounters: {{"APPL_DB", "PORT_TABLE"}, {"COUNTERS_DB", "COUNTERS_PORT_NAME_MAP"}},
+	ActionVerifyARPSuppression:    {{"CONFIG_DB", "SUPPRESS_VLAN_NEIGH"}, {"APPL_DB", "SUPPRESS_VLAN_NEIGH_TABLE"}},
+	ActionVerifyIGMPSnooping:      {{"CONFIG_DB", "CFG_L2MC_TABLE"}, {"STATE_DB", "L2MC_MEMBER_TABLE"}},
+	ActionVerifyNoFlaps:           {{"APPL_DB", "PORT_TABLE"}},
+}
+
+// tableURL matches the device-scoped table reads of a newtron step:
+// /nodes/{{device}}/configdb/{table}[/...] and /nodes/{{device}}/db/{db}/{table}[/...].
+var tableURL = regexp.MustCompile(`^/nodes/\{\{device\}\}/(?:configdb|db/([^/]+))/([^/?]+)`)
+
+// assertedTables returns the tables a step verifies. Only reads count — a
+// failed write is not a verification failure, and its table says nothing
+// the error does not.
+func assertedTables(step *Step) []tableRef {
+	if refs, ok := actionTables[step.Action]; ok {
+		return refs
+	}
+	if step.Action != ActionNewtron {
+		return nil
+	}
+
+	type call struct{ method, url string }
+	calls := []call{{step.Method, step.URL}}
+	for _, b := range step.Batch {
+		calls = append(calls, call{b.Method, b.URL})
+	}
+
+	var refs []tableRef
+	seen := map[tableRef]bool{}
+	for _, c := range calls {
+		if m := strings.ToUpper(c.method); m != "" && m != "GET" {
+			continue
+		}
+		match := tableURL.FindStringSubmatch(c.url)
+		if match == nil {
+			continue
+		}
+		ref := tableRef{db: "CONFIG_DB", table: match[2]}
+		if match[1] != "" {
+			ref.db = strings.ToUpper(match[1])
+		}
+		if t, err := url.PathUnescape(ref.table); err == nil {
+			ref.table = t
+		}
+		if !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
+// dumpFailureTables writes the asserted tables of a failed step to dir and
+// records the written paths on result. Devices come from the failed entries
+// in result.Details; a step without details (a single-device capture call)
+// falls back to every device it targeted.
+func (r *Runner) dumpFailureTables(scenario string, step *Step, result *StepResult, dir string, rd *redactor) {
+	refs := assertedTables(step)
+	if len(refs) == 0 {
+		return
+	}
+
+	var devices []string
+	for _, d := range result.Details {
+		if d.Status == StepStatusFailed || d.Status == StepStatusError {
+			devices = append(devices, d.Device)
+		}
+	}
+	if len(result.Details) == 0 {
+		devices = r.resolveDevices(step)
+	}
+
+	stepDir := filepath.Join(dir, artifactName(scenario), artifactName(step.Name))
+	for _, dev := range devices {
+		if _, isHost := r.HostConns[dev]; isHost {
+			continue
+		}
+		for _, ref := range refs {
+			path := filepath.Join(stepDir, fmt.Sprintf("%s_%s_%s.json", artifactName(dev), ref.db, artifactName(ref.table)))
+			if err := r.dumpTable(dev, ref, path, rd); err != nil {
+				util.Logger.Warnf("newtrun: dump %s %s/%s for step %s: %v", dev, ref.db, ref.table, step.Name, err)
+				continue
+			}
+			result.Artifacts = append(result.Artifacts, path)
+		}
+	}
+}
+
+// dumpTable reads one table from a device and writes it to path as JSON,
+// masking field values with rd.
+func (r *Runner) dumpTable(dev string, ref tableRef, path string, rd *redactor) error {
+	var table map[string]map[string]string
+	if ref.db == "CONFIG_DB" {
+		snapshot, err := r.Client.ConfigDBSnapshot(dev, false)
+		if err != nil {
+			return err
+		}
+		table = snapshot[ref.table]
+	} else {
+		var err error
+		if table, err = r.Client.OperDBTable(dev, ref.db, ref.table); err != nil {
+			return err
+		}
+	}
+	if table == nil {
+		table = map[string]map[string]string{}
+	}
+	rd.table(table)
+
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// artifactName makes a step, scenario, or table name safe as a path element.
+func artifactName(s string) string {
+	s = strings.Map(func(c rune) rune {
+		if c == '/' || c == '\\' || c == '|' || c == ':' || c == ' ' {
+			return '_'
+		}
+		return c
+	}, s)
+	if s == "" || s == "." || s == ".." {
+		s = "_" + s
+	}
+	return s
+}
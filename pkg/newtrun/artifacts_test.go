@@ -0,0 +1,120 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestAssertedTables(t *testing.T) {
+	tests := []struct {
+		name string
+		step Step
+		want []tableRef
+	}{
+		{
+			name: "configdb entry",
+			step: Step{Action: ActionNewtron, URL: "/nodes/{{device}}/configdb/VLAN/Vlan100"},
+			want: []tableRef{{"CONFIG_DB", "VLAN"}},
+		},
+		{
+			name: "oper db table",
+			step: Step{Action: ActionNewtron, Method: "get", URL: "/nodes/{{device}}/db/state_db/PORT_TABLE"},
+			want: []tableRef{{"STATE_DB", "PORT_TABLE"}},
+		},
+		{
+			name: "batch reads deduplicated, writes ignored",
+			step: Step{Action: ActionNewtron, Batch: []BatchCall{
+				{Method: "POST", URL: "/nodes/{{device}}/configdb/VRF/Vrf1"},
+				{Method: "GET", URL: "/nodes/{{device}}/configdb/VRF/Vrf1"},
+				{Method: "GET", URL: "/nodes/{{device}}/configdb/VRF/Vrf2"},
+			}},
+			want: []tableRef{{"CONFIG_DB", "VRF"}},
+		},
+		{
+			name: "non-table read",
+			step: Step{Action: ActionNewtron, URL: "/nodes/{{device}}/bgp/status"},
+		},
+		{
+			name: "built-in verify action",
+			step: Step{Action: ActionVerifyAnycast},
+			want: []tableRef{{"CONFIG_DB", "SAG_GLOBAL"}, {"CONFIG_DB", "VLAN_INTERFACE"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := assertedTables(&tt.step); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("assertedTables = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestDumpFailureTables fails a step on leaf2 only: its asserted tables are
+// written for leaf2 and listed on the result; leaf1, which passed, is not
+// dumped.
+func TestDumpFailureTables(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		var data any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/configdb"):
+			data = map[string]map[string]map[string]string{
+				"VLAN": {"Vlan100": {"vlanid": "100"}},
+				"PORT": {"Ethernet0": {"mtu": "9100"}},
+			}
+		case strings.HasSuffix(r.URL.Path, "/db/STATE_DB/VLAN_TABLE"):
+			data = map[string]map[string]string{"Vlan100": {"state": "ok"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	step := &Step{Name: "vlan/present", Action: ActionNewtron, Batch: []BatchCall{
+		{Method: "GET", URL: "/nodes/{{device}}/configdb/VLAN/Vlan100"},
+		{Method: "GET", URL: "/nodes/{{device}}/db/STATE_DB/VLAN_TABLE/Vlan100"},
+	}}
+	result := &StepResult{Status: StepStatusFailed, Details: []DeviceResult{
+		{Device: "leaf1", Status: StepStatusPassed},
+		{Device: "leaf2", Status: StepStatusFailed, Message: "vlanid: got 100, want 200"},
+	}}
+
+	dir := t.TempDir()
+	r.dumpFailureTables("vlans", step, result, dir, nil)
+
+	stepDir := filepath.Join(dir, "vlans", "vlan_present")
+	want := []string{
+		filepath.Join(stepDir, "leaf2_CONFIG_DB_VLAN.json"),
+		filepath.Join(stepDir, "leaf2_STATE_DB_VLAN_TABLE.json"),
+	}
+	if !reflect.DeepEqual(result.Artifacts, want) {
+		t.Fatalf("Artifacts = %v, want %v", result.Artifacts, want)
+	}
+	for _, p := range paths {
+		if strings.Contains(p, "/leaf1/") {
+			t.Errorf("passing device leaf1 was read: %s", p)
+		}
+	}
+
+	raw, err := os.ReadFile(want[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]map[string]string
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("artifact is not JSON: %v", err)
+	}
+	if !reflect.DeepEqual(got, map[string]map[string]string{"Vlan100": {"vlanid": "100"}}) {
+		t.Errorf("CONFIG_DB dump = %v, want only the VLAN table", got)
+	}
+}
@@ -54,7 +54,8 @@ func applyCaptures(captured map[string]any, captures map[string]string, raw json
 }
 
 // stepReferencesCaptured reports whether any field on step contains
-// a {{captured.X}} token. The runner uses this to decide whether to
+// a {{captured.X}} token — or a {{steps.NAME.output.KEY}} or {{var.X}}
+// one, which read the same map. The runner uses this to decide whether to
 // route a step through ExpandStep when no other reason (target /
 // param substitution, populated captured map) already requires
 // expansion — captured-only references in a non-parameterized
@@ -85,7 +86,7 @@ func stepReferencesCaptured(step Step) bool {
 }
 
 func containsCapturedToken(s string) bool {
-	return strings.Contains(s, "{{captured.")
+	return strings.Contains(s, "{{captured.") || strings.Contains(s, "{{"+stepOutputPrefix) || strings.Contains(s, "{{"+varPrefix)
 }
 
 func anyReferencesCaptured(v any) bool {
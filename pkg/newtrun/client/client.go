@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
@@ -190,6 +191,27 @@ func (c *Client) ListSuiteScenarios(ctx context.Context, suite string) (*api.Sui
 	return &resp, nil
 }
 
+// GetSuitePlan returns the suite's static execution plan. scenario and
+// target narrow it as for a run (at most one may be set); platform
+// overrides the suite's platform. Nothing runs.
+func (c *Client) GetSuitePlan(ctx context.Context, suite, scenario, target, platform string) (*newtrun.Plan, error) {
+	q := url.Values{}
+	for k, v := range map[string]string{"scenario": scenario, "target": target, "platform": platform} {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	path := "/newtrun/v1/suites/" + suite + "/plan"
+	if len(q) > 0 {
+		path += "?" + q.Encode()
+	}
+	var plan newtrun.Plan
+	if err := c.get(ctx, path, &plan); err != nil {
+		return nil, err
+	}
+	return &plan, nil
+}
+
 // CreateSuite creates a suite directory + suite.yaml manifest on the
 // server. Returns 409 if the suite already exists. Topology is the
 // topology this suite targets; the runner uses it as a guard against
@@ -0,0 +1,119 @@
+package newtrun
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Step loops. A step with for_each: runs once per item of a list, with the
+// item substituted wherever the step says {{item}} — one "apply the service
+// to each of these interfaces" step instead of N copy-pasted ones. Each
+// iteration records its own StepResult tagged with the item; the loop stops
+// at the first failing iteration, like the steps around it. An empty list
+// runs nothing and records a single SKIP.
+//
+// The item rides the template engine's captured map under itemBinding — a
+// key no capture can produce, since capture names are referenced as
+// {{captured.NAME}} — so {{item}} is encoded per context exactly like the
+// other tokens (path-escaped in a URL, shell-quoted in a command, typed in
+// a full-token JSON param).
+
+// itemBinding is the captured-map key holding the current for_each item.
+const itemBinding = "{{item}}"
+
+// forEachSource handles the two YAML forms for the "for_each" field:
+//
+//	for_each: [Ethernet0, Ethernet4]   → Items (literal; items may use templates)
+//	for_each: "{{captured.prefixes}}"  → Ref (one token resolving to a list)
+type forEachSource struct {
+	Items []any
+	Ref   string
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *forEachSource) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Decode(&f.Ref)
+	case yaml.SequenceNode:
+		f.Items = []any{}
+		return node.Decode(&f.Items)
+	}
+	return fmt.Errorf("for_each: expected a list or a {{param.X}}/{{captured.X}} reference")
+}
+
+// MarshalYAML implements yaml.Marshaler, emitting the form that was parsed.
+func (f forEachSource) MarshalYAML() (any, error) {
+	if f.Ref != "" {
+		return f.Ref, nil
+	}
+	return f.Items, nil
+}
+
+// validateForEach checks a step's for_each: a reference must be a single
+// {{param.X}} or {{captured.X}} token, literal items must be scalars, and
+// {{item}} may only appear in a step that loops.
+func validateForEach(prefix string, step *Step) error {
+	if step.ForEach == nil {
+		if stepText, _ := yaml.Marshal(step); strings.Contains(string(stepText), itemBinding) {
+			return fmt.Errorf("%s: {{item}} is only valid in a step with for_each", prefix)
+		}
+		return nil
+	}
+	if ref := step.ForEach.Ref; ref != "" {
+		m := fullTokenRe.FindStringSubmatch(ref)
+		if m == nil || (m[1] != "param" && m[1] != "captured") {
+			return fmt.Errorf("%s: for_each %q must be a list or a single {{param.X}} / {{captured.X}} reference", prefix, ref)
+		}
+		return nil
+	}
+	for i, item := range step.ForEach.Items {
+		switch item.(type) {
+		case string, int, int64, float64, bool:
+		default:
+			return fmt.Errorf("%s: for_each[%d] must be a scalar, got %T", prefix, i, item)
+		}
+	}
+	return nil
+}
+
+// resolveForEach returns the items src iterates over, with templates in
+// them expanded. A reference that does not resolve to a list is an error.
+func resolveForEach(src *forEachSource, target map[string]string, params map[string]any, captured map[string]any) ([]any, error) {
+	if src.Ref == "" {
+		v, err := expandAny(src.Items, target, params, captured)
+		if err != nil {
+			return nil, fmt.Errorf("for_each%w", err)
+		}
+		return v.([]any), nil
+	}
+	v, err := expandAny(src.Ref, target, params, captured)
+	if err != nil {
+		return nil, fmt.Errorf("for_each: %w", err)
+	}
+	switch list := v.(type) {
+	case []any:
+		return list, nil
+	case []string:
+		items := make([]any, len(list))
+		for i, s := range list {
+			items[i] = s
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("for_each: %s resolved to %T, not a list", src.Ref, v)
+}
+
+// withForEachItem returns a copy of captured with item bound to {{item}}.
+// The runner's own captured map is left alone: captures a loop iteration
+// makes still land there and are visible to later iterations and steps.
+func withForEachItem(captured map[string]any, item any) map[string]any {
+	out := make(map[string]any, len(captured)+1)
+	for k, v := range captured {
+		out[k] = v
+	}
+	out[itemBinding] = item
+	return out
+}
@@ -0,0 +1,175 @@
+package newtrun
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseScenario_ForEach(t *testing.T) {
+	tests := []struct {
+		name    string
+		step    string
+		wantErr string
+	}{
+		{"literal list", "for_each: [Ethernet0, Ethernet4]\n    url: /ports/{{item}}", ""},
+		{"empty list", "for_each: []\n    url: /ports/{{item}}", ""},
+		{"captured reference", "for_each: \"{{captured.ports}}\"\n    url: /ports/{{item}}", ""},
+		{"item without for_each", "url: /ports/{{item}}", "{{item}} is only valid in a step with for_each"},
+		{"target reference", "for_each: \"{{target.device}}\"\n    url: /x", "must be a list or a single"},
+		{"partial reference", "for_each: \"ports-{{param.n}}\"\n    url: /x", "must be a list or a single"},
+		{"nested item", "for_each: [[a, b]]\n    url: /x", "for_each[0] must be a scalar"},
+		{"map source", "for_each: {a: b}\n    url: /x", "expected a list"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "name: s\ndescription: d\nsteps:\n  - name: loop\n    action: newtron\n    method: POST\n    " + tt.step + "\n"
+			_, err := ParseScenarioBytes([]byte(yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("ParseScenarioBytes: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	cleanup := "name: s\ndescription: d\nsteps: []\ncleanup:\n  - name: loop\n    action: newtron\n    method: POST\n    for_each: [a]\n    url: /x/{{item}}\n"
+	if _, err := ParseScenarioBytes([]byte(cleanup)); err == nil || !strings.Contains(err.Error(), "not supported on cleanup steps") {
+		t.Errorf("for_each on cleanup: err = %v", err)
+	}
+}
+
+// TestRun_ForEach runs a scenario whose steps loop over a literal list, a
+// captured list, and an empty list, and checks each iteration hit the server
+// with its item and was recorded as its own tagged result.
+func TestRun_ForEach(t *testing.T) {
+	dir := t.TempDir()
+	suiteYAML := "name: foreach\ndescription: d\nnetwork: synthetic\n"
+	scenarioYAML := `name: loops
+description: for_each over literal, captured, and empty lists
+steps:
+  - name: shut
+    action: newtron
+    method: POST
+    for_each: [Ethernet0, Ethernet4]
+    url: /ports/{{item}}/shutdown
+  - name: list
+    action: newtron
+    method: GET
+    url: /prefixes
+    capture:
+      prefixes: .prefixes
+  - name: route
+    action: newtron
+    method: POST
+    for_each: "{{captured.prefixes}}"
+    url: /add-route
+    params:
+      prefix: "{{item}}"
+  - name: nothing
+    action: newtron
+    method: POST
+    for_each: []
+    url: /never/{{item}}
+`
+	for name, body := range map[string]string{"suite.yaml": suiteYAML, "00-loops.yaml": scenarioYAML} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/newtron/v1/networks":
+			_, _ = w.Write([]byte(`{"data":[{"id":"test-net","topology":"synthetic","has_topology":true,"dir":""}]}`))
+			return
+		case strings.Contains(r.URL.Path, "/topology"):
+			_, _ = w.Write([]byte(`{"data":[]}`))
+			return
+		case strings.HasSuffix(r.URL.Path, "/prefixes"):
+			_, _ = w.Write([]byte(`{"data":{"prefixes":["10.1.0.0/24","10.2.0.0/24"]}}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, r.URL.Path[strings.LastIndex(r.URL.Path, "/networks/test-net")+len("/networks/test-net"):]+" "+strings.TrimSpace(string(body)))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+	defer srv.Close()
+
+	runner := NewRunner(dir)
+	runner.ServerURL = srv.URL
+	runner.NetworkID = "test-net"
+	results, err := runner.Run(context.Background(), RunOptions{All: true, NoDeploy: true, Keep: true})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(results) != 1 || results[0].Status != StepStatusPassed {
+		t.Fatalf("results = %+v", results)
+	}
+
+	want := []struct {
+		name, item string
+		status     StepStatus
+	}{
+		{"shut", "Ethernet0", StepStatusPassed},
+		{"shut", "Ethernet4", StepStatusPassed},
+		{"list", "", StepStatusPassed},
+		{"route", "10.1.0.0/24", StepStatusPassed},
+		{"route", "10.2.0.0/24", StepStatusPassed},
+		{"nothing", "", StepStatusSkipped},
+	}
+	steps := results[0].Steps
+	if len(steps) != len(want) {
+		t.Fatalf("steps = %+v, want %d results", steps, len(want))
+	}
+	for i, w := range want {
+		if steps[i].Name != w.name || steps[i].Item != w.item || steps[i].Status != w.status {
+			t.Errorf("step %d = %s [%s] %s, want %s [%s] %s", i, steps[i].Name, steps[i].Item, steps[i].Status, w.name, w.item, w.status)
+		}
+	}
+	if got := stepDisplayName(steps[0]); got != "shut [item Ethernet0]" {
+		t.Errorf("display name = %q", got)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	wantReqs := []string{
+		"/ports/Ethernet0/shutdown",
+		"/ports/Ethernet4/shutdown",
+		`/add-route {"prefix":"10.1.0.0/24"}`,
+		`/add-route {"prefix":"10.2.0.0/24"}`,
+	}
+	if len(requests) != len(wantReqs) {
+		t.Fatalf("requests = %q, want %q", requests, wantReqs)
+	}
+	for i, w := range wantReqs {
+		if !strings.HasPrefix(requests[i], w) {
+			t.Errorf("request %d = %q, want %q", i, requests[i], w)
+		}
+	}
+}
+
+// TestResolveForEach_NotAList pins the runtime check: a reference that
+// resolves to a scalar errors instead of looping once.
+func TestResolveForEach_NotAList(t *testing.T) {
+	_, err := resolveForEach(&forEachSource{Ref: "{{captured.port}}"}, nil, nil, map[string]any{"port": "Ethernet0"})
+	if err == nil || !strings.Contains(err.Error(), "resolved to string, not a list") {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,325 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Golden verification. A verify step with observe: true reports what it
+// observed on each device — the BGP sessions, routes, LLDP neighbor, VLAN
+// members, or drift entries it judged — as structured data in
+// StepResult.Observed, not just PASS/FAIL. A run in a golden mode turns
+// those observations into a regression baseline:
+//
+//	newtrun start <suite> --golden record    # write golden/<scenario>.json
+//	newtrun start <suite> --golden compare   # FAIL any step that drifted
+//
+// The golden file lives in the suite directory, one per scenario, keyed by
+// step (stepDisplayName) then device, JSON with sorted keys — stable
+// enough to commit and review. A record run rewrites a scenario's file
+// from that run's observations. A compare run checks every observe: step
+// that passed its own assertions; one whose golden is missing is an ERROR
+// (nothing to compare against), one that differs FAILs, naming each
+// changed field.
+
+// RunOptions.Golden modes.
+const (
+	GoldenRecord  = "record"
+	GoldenCompare = "compare"
+)
+
+// goldenDir is the suite subdirectory golden files are written to.
+const goldenDir = "golden"
+
+// maxGoldenDiffs caps the differences listed per device.
+const maxGoldenDiffs = 5
+
+// observableActions are the actions whose executors report observations;
+// observe: is rejected on any other.
+var observableActions = map[StepAction]bool{
+	ActionVerifyProvisioning:   true,
+	ActionVerifyBGP:            true,
+	ActionVerifyRouteLeak:      true,
+	ActionVerifyLLDP:           true,
+	ActionVerifyVLANMembership: true,
+}
+
+// observations collects what one step execution observed, per device.
+type observations struct {
+	mu       sync.Mutex
+	byDevice map[string]any
+}
+
+type observationsKey struct{}
+
+// withObservations returns ctx carrying a fresh collector for a step.
+func withObservations(ctx context.Context) (context.Context, *observations) {
+	obs := &observations{byDevice: map[string]any{}}
+	return context.WithValue(ctx, observationsKey{}, obs), obs
+}
+
+// observe records v as the state a verify executor observed on device. A
+// later call for the same device replaces it, so a polled step keeps its
+// last attempt. A no-op unless the step set observe:. Safe under the
+// per-device goroutines of checkForDevices and pollForDevices.
+func observe(ctx context.Context, device string, v any) {
+	obs, _ := ctx.Value(observationsKey{}).(*observations)
+	if obs == nil {
+		return
+	}
+	obs.mu.Lock()
+	defer obs.mu.Unlock()
+	obs.byDevice[device] = v
+}
+
+// state returns the observations as plain JSON values (maps, slices,
+// strings, float64s) — the form golden files hold, so a fresh observation
+// and a loaded golden compare field by field.
+func (o *observations) state() (map[string]any, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.byDevice) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(o.byDevice)
+	if err != nil {
+		return nil, err
+	}
+	var state map[string]any
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// goldenFile is a scenario's golden: step → device → observed state.
+type goldenFile map[string]map[string]any
+
+// goldenPath is where a scenario's golden file lives.
+func goldenPath(suiteDir, scenario string) string {
+	return filepath.Join(suiteDir, goldenDir, scenario+".json")
+}
+
+// finishObserved attaches an observe: step's observations to its result,
+// then records or compares them per the run's golden mode. Only a step
+// that passed its own assertions is recorded or compared: a failing
+// observation is no baseline, and the step has failed already.
+func (r *Runner) finishObserved(mode, scenario string, sr *StepResult, obs *observations) {
+	state, err := obs.state()
+	if err != nil {
+		sr.Status = StepStatusError
+		sr.Message = strings.TrimPrefix(sr.Message+"; ", "; ") + "observe: " + err.Error()
+		return
+	}
+	sr.Observed = state
+	if sr.Status != StepStatusPassed {
+		return
+	}
+	switch mode {
+	case GoldenRecord:
+		if err := r.recordGolden(scenario, stepDisplayName(*sr), state); err != nil {
+			sr.Status = StepStatusError
+			sr.Message = strings.TrimPrefix(sr.Message+"; ", "; ") + "golden: " + err.Error()
+		}
+	case GoldenCompare:
+		compareGolden(r.loadGolden(scenario), sr)
+	}
+}
+
+// recordGolden stores one step's observations in the scenario's golden and
+// writes the file. The first step a run records for a scenario starts the
+// file afresh, so steps that no longer exist drop out.
+func (r *Runner) recordGolden(scenario, step string, state map[string]any) error {
+	r.goldensMu.Lock()
+	defer r.goldensMu.Unlock()
+	if r.goldens == nil {
+		r.goldens = map[string]goldenFile{}
+	}
+	g := r.goldens[scenario]
+	if g == nil {
+		g = goldenFile{}
+		r.goldens[scenario] = g
+	}
+	g[step] = state
+	data, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := goldenPath(r.SuiteDir, scenario)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// goldenLoad is a scenario's golden as read from disk, or why it could not be.
+type goldenLoad struct {
+	file goldenFile
+	err  error
+}
+
+// loadGolden reads a scenario's golden file, once per run.
+func (r *Runner) loadGolden(scenario string) goldenLoad {
+	r.goldensMu.Lock()
+	defer r.goldensMu.Unlock()
+	if l, ok := r.goldenLoads[scenario]; ok {
+		return l
+	}
+	var l goldenLoad
+	path := goldenPath(r.SuiteDir, scenario)
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		l.err = fmt.Errorf("no golden file %s (record one with --golden record)", path)
+	case err != nil:
+		l.err = err
+	default:
+		if err := json.Unmarshal(data, &l.file); err != nil {
+			l.err = fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if r.goldenLoads == nil {
+		r.goldenLoads = map[string]goldenLoad{}
+	}
+	r.goldenLoads[scenario] = l
+	return l
+}
+
+// compareGolden checks a passed step's observations against the scenario's
+// golden, marking each drifted device FAIL and the step with it.
+func compareGolden(l goldenLoad, sr *StepResult) {
+	if l.err != nil {
+		sr.Status = StepStatusError
+		sr.Message = "golden: " + l.err.Error()
+		return
+	}
+	key := stepDisplayName(*sr)
+	want, ok := l.file[key]
+	if !ok {
+		sr.Status = StepStatusError
+		sr.Message = fmt.Sprintf("golden: no entry for step %q (record one with --golden record)", key)
+		return
+	}
+
+	drift := map[string][]string{}
+	for _, dev := range sortedKeys(union(want, sr.Observed)) {
+		w, inGolden := want[dev]
+		g, observed := sr.Observed[dev]
+		switch {
+		case !inGolden:
+			drift[dev] = []string{"not in the golden"}
+		case !observed:
+			drift[dev] = []string{"in the golden but not observed"}
+		default:
+			if diffs := diffJSON(w, g); len(diffs) > 0 {
+				drift[dev] = diffs
+			}
+		}
+	}
+	if len(drift) == 0 {
+		sr.Message = strings.TrimPrefix(sr.Message+"; ", "; ") + "matches golden"
+		return
+	}
+
+	drifted := len(drift)
+	details := make([]DeviceResult, len(sr.Details))
+	copy(details, sr.Details)
+	for i, d := range details {
+		if diffs, ok := drift[d.Device]; ok {
+			details[i].Status = StepStatusFailed
+			details[i].Message = "drift from golden: " + strings.Join(diffs, "; ")
+			delete(drift, d.Device)
+		}
+	}
+	// A golden device the step no longer ran on has no detail of its own.
+	for _, dev := range sortedKeys(drift) {
+		details = append(details, DeviceResult{Device: dev, Status: StepStatusFailed,
+			Message: "drift from golden: " + strings.Join(drift[dev], "; ")})
+	}
+	sr.Details = details
+	sr.Status = StepStatusFailed
+	sr.Message = fmt.Sprintf("drift from golden on %d device(s)", drifted)
+}
+
+// union returns the keys of both a and b.
+func union(a, b map[string]any) map[string]bool {
+	out := make(map[string]bool, len(a)+len(b))
+	for k := range a {
+		out[k] = true
+	}
+	for k := range b {
+		out[k] = true
+	}
+	return out
+}
+
+// diffJSON lists the differences between two plain JSON values as
+// ".path: golden X, observed Y", at most maxGoldenDiffs of them.
+func diffJSON(want, got any) []string {
+	var diffs []string
+	walkDiff(".", want, got, &diffs)
+	if len(diffs) > maxGoldenDiffs {
+		more := len(diffs) - maxGoldenDiffs
+		diffs = append(diffs[:maxGoldenDiffs], fmt.Sprintf("and %d more", more))
+	}
+	return diffs
+}
+
+func walkDiff(path string, want, got any, diffs *[]string) {
+	if reflect.DeepEqual(want, got) {
+		return
+	}
+	switch w := want.(type) {
+	case map[string]any:
+		if g, ok := got.(map[string]any); ok {
+			for _, k := range sortedKeys(union(w, g)) {
+				walkDiff(joinPath(path, k), w[k], g[k], diffs)
+			}
+			return
+		}
+	case []any:
+		if g, ok := got.([]any); ok && len(g) == len(w) {
+			for i := range w {
+				walkDiff(indexPath(path, i), w[i], g[i], diffs)
+			}
+			return
+		}
+	}
+	*diffs = append(*diffs, fmt.Sprintf("%s: golden %s, observed %s", path, compactJSON(want), compactJSON(got)))
+}
+
+// indexPath appends a list index to a diff path.
+func indexPath(path string, i int) string {
+	if path == "." {
+		return fmt.Sprintf(".[%d]", i)
+	}
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+// joinPath appends a map key to a diff path.
+func joinPath(path, key string) string {
+	if path == "." {
+		return "." + key
+	}
+	return path + "." + key
+}
+
+// compactJSON renders a plain JSON value for a diff line; absent is "none".
+func compactJSON(v any) string {
+	if v == nil {
+		return "none"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
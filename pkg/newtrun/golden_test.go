@@ -0,0 +1,166 @@
+package newtrun
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestDiffJSON(t *testing.T) {
+	golden := map[string]any{
+		"vrf":   "Vrf_CUST1",
+		"peers": []any{"10.0.0.1", "10.0.0.2"},
+		"nh":    map[string]any{"interface": "Ethernet0"},
+	}
+	tests := []struct {
+		name     string
+		observed any
+		want     []string
+	}{
+		{"equal", map[string]any{"vrf": "Vrf_CUST1", "peers": []any{"10.0.0.1", "10.0.0.2"}, "nh": map[string]any{"interface": "Ethernet0"}}, nil},
+		{"nested field", map[string]any{"vrf": "Vrf_CUST1", "peers": []any{"10.0.0.1", "10.0.0.2"}, "nh": map[string]any{"interface": "Ethernet4"}},
+			[]string{`.nh.interface: golden "Ethernet0", observed "Ethernet4"`}},
+		{"list element", map[string]any{"vrf": "Vrf_CUST1", "peers": []any{"10.0.0.1", "10.0.0.9"}, "nh": map[string]any{"interface": "Ethernet0"}},
+			[]string{`.peers[1]: golden "10.0.0.2", observed "10.0.0.9"`}},
+		{"list length", map[string]any{"vrf": "Vrf_CUST1", "peers": []any{"10.0.0.1"}, "nh": map[string]any{"interface": "Ethernet0"}},
+			[]string{`.peers: golden ["10.0.0.1","10.0.0.2"], observed ["10.0.0.1"]`}},
+		{"key gone", map[string]any{"peers": []any{"10.0.0.1", "10.0.0.2"}, "nh": map[string]any{"interface": "Ethernet0"}},
+			[]string{`.vrf: golden "Vrf_CUST1", observed none`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffJSON(golden, tt.observed)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("diffJSON = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	many := map[string]any{}
+	for _, k := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		many[k] = 1.0
+	}
+	if got := diffJSON(map[string]any{}, many); len(got) != maxGoldenDiffs+1 || got[maxGoldenDiffs] != "and 2 more" {
+		t.Errorf("capped diffJSON = %q", got)
+	}
+}
+
+func TestObserve_WithoutCollectorIsNoop(t *testing.T) {
+	observe(context.Background(), "leaf1", "ignored") // must not panic
+
+	ctx, obs := withObservations(context.Background())
+	observe(ctx, "leaf1", newtron.LLDPPeer{SystemName: "spine1", PortID: "Ethernet0"})
+	observe(ctx, "leaf1", newtron.LLDPPeer{SystemName: "spine2", PortID: "Ethernet0"}) // last wins
+	state, err := obs.state()
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer, _ := state["leaf1"].(map[string]any)
+	if peer["system_name"] != "spine2" {
+		t.Errorf("state = %v, want leaf1's last observation as plain JSON", state)
+	}
+}
+
+// TestGolden_RecordThenCompare: a record run writes what an observe:
+// verify-bgp step saw to golden/<scenario>.json; a compare run against the
+// same state passes, and one against a changed session FAILs naming the
+// field that drifted.
+func TestGolden_RecordThenCompare(t *testing.T) {
+	sessions := []newtron.HealthCheckResult{
+		bgpResult("default", "10.1.0.0", "Established"),
+		bgpResult("default", "10.1.0.2", "Established"),
+	}
+	dir := t.TempDir()
+	scenario := &Scenario{Name: "underlay", Steps: []Step{{
+		Name: "bgp-up", Action: ActionVerifyBGP, Observe: true,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+	}}}
+	run := func(mode string, sessions []newtron.HealthCheckResult) StepResult {
+		t.Helper()
+		srv := bgpCheckServer(t, map[string][]newtron.HealthCheckResult{"leaf1": sessions})
+		defer srv.Close()
+		r := &Runner{Client: client.New(srv.URL, "test-net"), SuiteDir: dir}
+		result := &ScenarioResult{Name: scenario.Name}
+		r.runScenarioSteps(context.Background(), scenario, RunOptions{Golden: mode}, result)
+		return result.Steps[0]
+	}
+
+	if sr := run(GoldenCompare, sessions); sr.Status != StepStatusError || !strings.Contains(sr.Message, "no golden file") {
+		t.Errorf("compare before record: %s %q, want ERROR naming the missing golden", sr.Status, sr.Message)
+	}
+
+	sr := run(GoldenRecord, sessions)
+	if sr.Status != StepStatusPassed || len(sr.Observed["leaf1"].([]any)) != 2 {
+		t.Fatalf("record: %s %q observed %v", sr.Status, sr.Message, sr.Observed)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "golden", "underlay.json"))
+	if err != nil {
+		t.Fatalf("golden not written: %v", err)
+	}
+	if !strings.Contains(string(data), `"bgp-up": {`) || !strings.Contains(string(data), "10.1.0.2") {
+		t.Errorf("golden file:\n%s", data)
+	}
+
+	if sr := run(GoldenCompare, sessions); sr.Status != StepStatusPassed || !strings.HasSuffix(sr.Message, "matches golden") {
+		t.Errorf("compare unchanged: %s %q, want PASS matching the golden", sr.Status, sr.Message)
+	}
+
+	moved := []newtron.HealthCheckResult{sessions[0], bgpResult("default", "10.1.0.4", "Established")}
+	sr = run(GoldenCompare, moved)
+	if sr.Status != StepStatusFailed || sr.Message != "drift from golden on 1 device(s)" {
+		t.Fatalf("compare drifted: %s %q", sr.Status, sr.Message)
+	}
+	if d := sr.Details[0]; d.Status != StepStatusFailed || !strings.Contains(d.Message, ".[1].message: golden") || !strings.Contains(d.Message, "10.1.0.4") {
+		t.Errorf("leaf1 detail = %s %q", d.Status, d.Message)
+	}
+}
+
+// TestGolden_FailedStepNotRecorded: a step that fails its own assertions
+// is no baseline.
+func TestGolden_FailedStepNotRecorded(t *testing.T) {
+	srv := bgpCheckServer(t, map[string][]newtron.HealthCheckResult{"leaf1": {bgpResult("default", "10.1.0.0", "Active")}})
+	defer srv.Close()
+	dir := t.TempDir()
+	r := &Runner{Client: client.New(srv.URL, "test-net"), SuiteDir: dir}
+	scenario := &Scenario{Name: "underlay", Steps: []Step{{
+		Name: "bgp-up", Action: ActionVerifyBGP, Observe: true,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+	}}}
+	result := &ScenarioResult{Name: scenario.Name}
+	r.runScenarioSteps(context.Background(), scenario, RunOptions{Golden: GoldenRecord}, result)
+	if sr := result.Steps[0]; sr.Status != StepStatusFailed || sr.Observed == nil {
+		t.Errorf("step = %s observed %v, want FAIL with its observation", sr.Status, sr.Observed)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "golden", "underlay.json")); !os.IsNotExist(err) {
+		t.Errorf("golden written for a failed step (stat err %v)", err)
+	}
+}
+
+func TestParseScenarioBytes_Observe(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"unsupported action", "name: s\nsteps:\n  - name: x\n    action: host-exec\n    devices: [host1]\n    command: date\n    observe: true\n",
+			"'observe' is only valid for actions verify-bgp"},
+		{"cleanup step", "name: s\nsteps:\n  - name: w\n    action: wait\n    duration: 1s\ncleanup:\n  - name: x\n    action: verify-bgp\n    devices: [leaf1]\n    observe: true\n",
+			"observe is not supported on cleanup steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScenarioBytes([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want %q", err, tt.want)
+			}
+		})
+	}
+	if _, err := ParseScenarioBytes([]byte("name: s\nsteps:\n  - name: x\n    action: verify-bgp\n    devices: [leaf1]\n    observe: true\n")); err != nil {
+		t.Errorf("observe on verify-bgp: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestParseScenario_InterfaceSelector(t *testing.T) {
+	yaml := `
+name: x
+steps:
+  - name: by-role
+    action: newtron
+    devices: [leaf1]
+    method: POST
+    url: /nodes/{{device}}/interfaces/{{interface}}/set-property
+    interfaces: {role: uplink}
+  - name: by-name
+    action: newtron
+    devices: [leaf1]
+    method: POST
+    url: /nodes/{{device}}/interfaces/{{interface}}/set-property
+    interfaces: [Ethernet0, Ethernet4]
+`
+	s, err := ParseScenarioBytes([]byte(yaml))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if got := s.Steps[0].Interfaces; got == nil || got.Role != "uplink" {
+		t.Errorf("step 0 selector = %+v, want role uplink", got)
+	}
+	if got := s.Steps[1].Interfaces; got == nil || !reflect.DeepEqual(got.Names, []string{"Ethernet0", "Ethernet4"}) {
+		t.Errorf("step 1 selector = %+v, want two names", got)
+	}
+}
+
+func TestParseScenario_InterfaceSelectorRejected(t *testing.T) {
+	tests := []struct {
+		name, step, want string
+	}{
+		{"token without selector", `
+    action: newtron
+    devices: [leaf1]
+    url: /nodes/{{device}}/interfaces/{{interface}}`, "no interfaces: selector"},
+		{"selector without token", `
+    action: newtron
+    devices: [leaf1]
+    url: /nodes/{{device}}/interfaces
+    interfaces: {role: uplink}`, "{{interface}}"},
+		{"unknown role", `
+    action: newtron
+    devices: [leaf1]
+    url: /nodes/{{device}}/interfaces/{{interface}}
+    interfaces: {role: sideways}`, "unknown interface role"},
+		{"non-newtron action", `
+    action: wait
+    duration: 1s
+    interfaces: {role: uplink}`, "only valid for action newtron"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "name: x\nsteps:\n  - name: s" + tt.step + "\n"
+			_, err := ParseScenarioBytes([]byte(yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestNewtronStep_FansOutOverRoleInterfaces drives a role-selected step
+// against a faux newtron-server: the role is resolved per device, and one call
+// is made per resolved interface.
+func TestNewtronStep_FansOutOverRoleInterfaces(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var data any
+		if strings.HasSuffix(r.URL.Path, "/topology/nodes/leaf1/interfaces") {
+			if r.URL.Query().Get("role") != "uplink" {
+				t.Errorf("role query = %q, want uplink", r.URL.Query().Get("role"))
+			}
+			data = []string{"Ethernet0", "Ethernet4"}
+		} else if r.Method == http.MethodPost {
+			mu.Lock()
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	step := &Step{
+		Action:     ActionNewtron,
+		Devices:    deviceSelector{Devices: []string{"leaf1"}},
+		Method:     "POST",
+		URL:        "/nodes/{{device}}/interfaces/{{interface}}/set-property",
+		Interfaces: &interfaceSelector{Role: "uplink"},
+	}
+	out := (&newtronExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusPassed {
+		t.Fatalf("step should PASS, got %+v", out.Result)
+	}
+	sort.Strings(calls)
+	want := []string{
+		"POST /newtron/v1/networks/test-net/nodes/leaf1/interfaces/Ethernet0/set-property",
+		"POST /newtron/v1/networks/test-net/nodes/leaf1/interfaces/Ethernet4/set-property",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+}
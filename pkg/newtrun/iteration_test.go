@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"sort"
+	"sync"
 	"testing"
 )
 
@@ -226,3 +227,71 @@ func TestRunScenarioSteps_RepeatTimesIterationsCrossProduct(t *testing.T) {
 		t.Fatalf("got %d step results, want 4 (2 repeat × 2 iterations)", got)
 	}
 }
+
+func TestRunScenarioSteps_RepeatParallelRecordsIterationOrder(t *testing.T) {
+	r := &Runner{}
+	scenario := &Scenario{
+		Name:           "soak",
+		Repeat:         6,
+		ParallelSafe:   true,
+		RepeatParallel: 3,
+		Steps: []Step{
+			{Name: "a", Action: ActionWait, Duration: 0},
+			{Name: "b", Action: ActionWait, Duration: 0},
+		},
+	}
+	result := &ScenarioResult{Name: "soak"}
+	r.runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+
+	if got := len(result.Steps); got != 12 {
+		t.Fatalf("got %d step results, want 12 (6 repeat × 2 steps)", got)
+	}
+	for i, sr := range result.Steps {
+		if want := i/2 + 1; sr.Iteration != want {
+			t.Errorf("Steps[%d].Iteration = %d, want %d", i, sr.Iteration, want)
+		}
+	}
+	if result.Status != StepStatusPassed || result.FailedIteration != 0 {
+		t.Errorf("Status = %v, FailedIteration = %d; want PASS, 0", result.Status, result.FailedIteration)
+	}
+}
+
+func TestRunScenarioSteps_RepeatParallelStopsAfterFailure(t *testing.T) {
+	r := &Runner{}
+	scenario := &Scenario{
+		Name:           "soak",
+		Repeat:         10,
+		ParallelSafe:   true,
+		RepeatParallel: 2,
+		Steps:          []Step{{Name: "always-fail", Action: "nonexistent-action"}},
+	}
+	result := &ScenarioResult{Name: "soak"}
+	r.runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+
+	// Iteration 1 fails; at most the other worker's in-flight iteration
+	// also ran before dispatch stopped.
+	if got := len(result.Steps); got < 1 || got > 2 {
+		t.Fatalf("got %d step results, want 1 or 2", got)
+	}
+	if result.FailedIteration != 1 {
+		t.Errorf("FailedIteration = %d, want 1", result.FailedIteration)
+	}
+	if result.Steps[0].Iteration != 1 {
+		t.Errorf("Steps[0].Iteration = %d, want 1", result.Steps[0].Iteration)
+	}
+}
+
+func TestRunConcurrently(t *testing.T) {
+	var mu sync.Mutex
+	var ran []int
+	failedIter := runConcurrently(5, 8, func(iter int) bool {
+		mu.Lock()
+		ran = append(ran, iter)
+		mu.Unlock()
+		return false
+	})
+	sort.Ints(ran)
+	if failedIter != 0 || !reflect.DeepEqual(ran, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("failed = %d, ran = %v; want 0, [1 2 3 4 5]", failedIter, ran)
+	}
+}
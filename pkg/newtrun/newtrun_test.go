@@ -488,6 +488,27 @@ func TestComputeTargetChain_IgnoresAfter(t *testing.T) {
 	}
 }
 
+func TestComputeTargetChain_MultipleTargets(t *testing.T) {
+	// a → b, a → c, c → d; targeting b and c shares a once and leaves d out
+	scenarios := []*Scenario{
+		{Name: "a"},
+		{Name: "b", Requires: []string{"a"}},
+		{Name: "c", Requires: []string{"a"}},
+		{Name: "d", Requires: []string{"c"}},
+	}
+	chain, err := ComputeTargetChain(scenarios, "b", "c")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := scenarioNames(chain)
+	if len(names) != 3 || names[0] != "a" {
+		t.Fatalf("expected a first then b and c, got %v", names)
+	}
+	if _, err := ComputeTargetChain(scenarios, "b", "nonexistent"); err == nil {
+		t.Fatal("expected error for unknown target")
+	}
+}
+
 func scenarioNames(scenarios []*Scenario) []string {
 	names := make([]string, len(scenarios))
 	for i, s := range scenarios {
@@ -867,6 +888,12 @@ func TestExecutorCountMatchesActionConstants(t *testing.T) {
 		ActionProvision, ActionWait, ActionVerifyProvisioning,
 		ActionHostExec, ActionNewtron, ActionNewtronCLI,
 		ActionRunSuite, ActionSnapshot, ActionVerifySnapshot,
+		ActionWaitConverged, ActionVerifyAnycast, ActionVerifyVLANMembership,
+		ActionVerifyEnvironment, ActionGenerateTraffic, ActionVerifyRouteLeak, ActionVerifyPing, ActionVerifyPing6,
+		ActionVerifyVXLANStats, ActionVerifyEgressShaper, ActionVerifyBGP, ActionVerifyLog,
+		ActionVerifyDHCPLease, ActionVerifyChangeSet, ActionVerifyLLDP,
+		ActionVerifyARPSuppression, ActionVerifyInterfaceCounters, ActionVerifyEVPNType2,
+		ActionVerifyLAGDistribution, ActionVerifyIGMPSnooping, ActionVerifyNoFlaps,
 	}
 	// Verify the constant values match the expected action names
 	if ActionProvision != "topology-reconcile" {
@@ -936,6 +963,42 @@ func TestExecuteStep_SetsNameAndAction(t *testing.T) {
 	}
 }
 
+// TestExecuteStep_MaxDuration: expect.max_duration is a soft bound — the
+// step completes, and only a step that ran past it fails, with the overage
+// recorded. A step that already failed keeps its own failure.
+func TestExecuteStep_MaxDuration(t *testing.T) {
+	r := &Runner{}
+	slow := &Step{
+		Action:   ActionWait,
+		Name:     "slow-wait",
+		Duration: 20 * time.Millisecond,
+		Expect:   &ExpectBlock{MaxDuration: time.Millisecond},
+	}
+	out := r.executeStep(context.Background(), slow, 0, 1, RunOptions{})
+	if out.Result.Status != StepStatusFailed {
+		t.Errorf("Status = %v, want FAIL for a step over max_duration", out.Result.Status)
+	}
+	if out.Result.Overage <= 0 || out.Result.Overage != out.Result.Duration-time.Millisecond {
+		t.Errorf("Overage = %v, want Duration (%v) - 1ms", out.Result.Overage, out.Result.Duration)
+	}
+	if !strings.Contains(out.Result.Message, "over max_duration 1ms") {
+		t.Errorf("Message = %q, want the overage named", out.Result.Message)
+	}
+
+	fast := &Step{Action: ActionWait, Name: "fast-wait", Expect: &ExpectBlock{MaxDuration: time.Minute}}
+	out = r.executeStep(context.Background(), fast, 0, 1, RunOptions{})
+	if out.Result.Status != StepStatusPassed || out.Result.Overage != 0 || out.Result.MaxDuration != time.Minute {
+		t.Errorf("within bound: status=%v overage=%v max=%v, want PASS/0/1m",
+			out.Result.Status, out.Result.Overage, out.Result.MaxDuration)
+	}
+
+	failed := &StepResult{Status: StepStatusError, Message: "boom", Duration: 3 * time.Second}
+	applyMaxDuration(failed, time.Second)
+	if failed.Status != StepStatusError || failed.Overage != 2*time.Second || !strings.HasPrefix(failed.Message, "boom; took 3s") {
+		t.Errorf("failed step: %+v, want ERROR kept with overage 2s noted", failed)
+	}
+}
+
 // Scenario-path filename-prefix resolution moved entirely to
 // pkg/newtrun/api/scenarios.go (the GET /scenario CRUD endpoint).
 // Runner.Run matches --scenario against the scenario's `name:` field
@@ -1368,3 +1431,51 @@ func writeScenario(t *testing.T, dir, filename, content string) {
 		t.Fatal(err)
 	}
 }
+
+func TestParseScenario_RepeatParallel(t *testing.T) {
+	const base = `
+name: soak
+repeat: 10
+parallel_safe: true
+repeat_parallel: 4
+steps:
+  - name: verify
+    action: verify-environment
+    devices: all
+`
+	s, err := ParseScenarioBytes([]byte(base))
+	if err != nil {
+		t.Fatalf("ParseScenarioBytes error: %v", err)
+	}
+	if !s.ParallelSafe || s.RepeatParallel != 4 {
+		t.Errorf("ParallelSafe = %v, RepeatParallel = %d; want true, 4", s.ParallelSafe, s.RepeatParallel)
+	}
+
+	tests := []struct {
+		name, yaml, wantErr string
+	}{
+		{"not parallel_safe", strings.Replace(base, "parallel_safe: true\n", "", 1), "requires parallel_safe: true"},
+		{"no repeat", strings.Replace(base, "repeat: 10\n", "", 1), "requires repeat > 1"},
+		{"negative", strings.Replace(base, "repeat_parallel: 4", "repeat_parallel: -1", 1), "must not be negative"},
+		{"capture", base + `  - name: read
+    action: newtron
+    devices: [leaf1]
+    url: /nodes/{{device}}/info
+    capture:
+      mac: .data.mac
+`, "capture is not allowed with repeat_parallel"},
+		{"snapshot", base + `  - name: baseline
+    action: snapshot
+    devices: all
+    snapshot: base
+`, "snapshot is not allowed with repeat_parallel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScenarioBytes([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package newtrun
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// Captured output. Executors that run a command — host-exec, the pings of
+// verify-ping and verify-ping6 — keep its combined stdout/stderr in StepResult.Output (or
+// DeviceResult.Output, per device) so a failed CI run can be debugged from
+// its report: the console prints it under a failed step, JUnit carries it
+// in <system-out>. The runner redacts it with the rest of the result, then
+// caps it at the step's max_output_bytes — redacting first, so a secret the
+// cut would split is still masked whole.
+
+// defaultMaxOutputBytes caps captured output when a step sets no
+// max_output_bytes.
+const defaultMaxOutputBytes = 4096
+
+// truncateOutput returns s cut to its last max bytes (max <= 0 means
+// defaultMaxOutputBytes), prefixed with a note of how much was dropped.
+// The cut never splits a UTF-8 sequence.
+func truncateOutput(s string, max int) string {
+	if max <= 0 {
+		max = defaultMaxOutputBytes
+	}
+	if len(s) <= max {
+		return s
+	}
+	start := len(s) - max
+	for start < len(s) && !utf8.RuneStart(s[start]) {
+		start++
+	}
+	return fmt.Sprintf("[... %d bytes truncated]\n", start) + s[start:]
+}
+
+// capOutput truncates the captured output of a step result in place.
+func capOutput(sr *StepResult, max int) {
+	sr.Output = truncateOutput(sr.Output, max)
+	if len(sr.Details) == 0 {
+		return
+	}
+	// Details may share a backing array with the executor's output.
+	details := make([]DeviceResult, len(sr.Details))
+	for i, d := range sr.Details {
+		d.Output = truncateOutput(d.Output, max)
+		details[i] = d
+	}
+	sr.Details = details
+}
@@ -0,0 +1,130 @@
+package newtrun
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTruncateOutput(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		max  int
+		want string
+	}{
+		{"under the cap", "PING ok\n", 64, "PING ok\n"},
+		{"at the cap", "abcd", 4, "abcd"},
+		{"keeps the tail", "line1\nline2\nboom\n", 5, "[... 12 bytes truncated]\nboom\n"},
+		{"never splits a rune", "ab→cd", 4, "[... 5 bytes truncated]\ncd"},
+		{"default cap", strings.Repeat("x", defaultMaxOutputBytes), 0, strings.Repeat("x", defaultMaxOutputBytes)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateOutput(tt.in, tt.max); got != tt.want {
+				t.Errorf("truncateOutput(%q, %d) = %q, want %q", tt.in, tt.max, got, tt.want)
+			}
+		})
+	}
+	if got := truncateOutput(strings.Repeat("x", defaultMaxOutputBytes+10), 0); !strings.HasPrefix(got, "[... 10 bytes truncated]\n") {
+		t.Errorf("default cap: got prefix %q", got[:30])
+	}
+}
+
+// TestEvaluateHostExpect_CapturesOutput: the command's output rides in
+// Output whatever the verdict, and no longer in the message.
+func TestEvaluateHostExpect_CapturesOutput(t *testing.T) {
+	out := "64 bytes from 10.1.100.20\n"
+	tests := []struct {
+		name   string
+		step   *Step
+		err    error
+		status StepStatus
+	}{
+		{"contains", &Step{Expect: &ExpectBlock{Contains: "64 bytes"}}, nil, StepStatusPassed},
+		{"does not contain", &Step{Expect: &ExpectBlock{Contains: "unreachable"}}, nil, StepStatusFailed},
+		{"exit code", &Step{}, errors.New("Process exited with status 1"), StepStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := evaluateHostExpect(tt.step, out, tt.err)
+			if sr.Status != tt.status || sr.Output != out {
+				t.Errorf("got %s output %q, want %s output %q", sr.Status, sr.Output, tt.status, out)
+			}
+			if strings.Contains(sr.Message, "64 bytes from") {
+				t.Errorf("message %q repeats the output", sr.Message)
+			}
+		})
+	}
+}
+
+// outputExecutor returns a fixed result with command output.
+type outputExecutor struct{ result StepResult }
+
+func (e *outputExecutor) Execute(_ context.Context, _ *Runner, _ *Step) *StepOutput {
+	sr := e.result
+	return &StepOutput{Result: &sr}
+}
+
+// TestRunScenarioSteps_OutputRedactedThenCapped: a failed step's output
+// reaches its result with the step's redact list applied, then cut to its
+// max_output_bytes — a secret near the cut is masked, not half-kept.
+func TestRunScenarioSteps_OutputRedactedThenCapped(t *testing.T) {
+	withExecutor(t, ActionHostExec, &outputExecutor{result: StepResult{
+		Status:  StepStatusFailed,
+		Message: "command failed: exit 1",
+		Output:  "connecting\npassword=hunter2\nauth failed\n",
+		Details: []DeviceResult{{Device: "host1", Status: StepStatusFailed, Output: "token=hunter2\n"}},
+	}})
+	scenario := &Scenario{
+		Name: "login",
+		Steps: []Step{{Name: "login", Action: ActionHostExec, Command: "login",
+			Redact: []string{`hunter2`}, MaxOutputBytes: 32}},
+	}
+	result := &ScenarioResult{Name: "login"}
+	(&Runner{}).runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+
+	sr := result.Steps[0]
+	if want := "[... 11 bytes truncated]\npassword=[REDACTED]\nauth failed\n"; sr.Output != want {
+		t.Errorf("Output = %q, want %q", sr.Output, want)
+	}
+	if want := "token=[REDACTED]\n"; sr.Details[0].Output != want {
+		t.Errorf("device Output = %q, want %q", sr.Details[0].Output, want)
+	}
+}
+
+func TestWriteJUnit_SystemOutCarriesOutput(t *testing.T) {
+	results := []*ScenarioResult{{Name: "ping", Status: StepStatusFailed, Steps: []StepResult{
+		{Name: "ping-host", Action: ActionHostExec, Status: StepStatusFailed, Message: "0% success",
+			Output: "100% packet loss\n", Attempts: 2, MaxAttempts: 2},
+		{Name: "ping6", Action: ActionVerifyPing6, Status: StepStatusFailed,
+			Details: []DeviceResult{{Device: "leaf1", Status: StepStatusFailed, Output: "Network is unreachable\n"}}},
+	}}}
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := (&ReportGenerator{Results: results}).WriteJUnit(path); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xml := string(data)
+	for _, want := range []string{ // encoding/xml escapes newlines in text
+		"<system-out>failed on attempt 2/2&#xA;&#xA;100% packet loss</system-out>",
+		"<system-out>leaf1:&#xA;Network is unreachable</system-out>",
+	} {
+		if !strings.Contains(xml, want) {
+			t.Errorf("junit missing %q:\n%s", want, xml)
+		}
+	}
+}
+
+func TestParseScenarioBytes_NegativeMaxOutputBytes(t *testing.T) {
+	_, err := ParseScenarioBytes([]byte("name: s\nsteps:\n  - name: x\n    action: host-exec\n    devices: [host1]\n    command: date\n    max_output_bytes: -1\n"))
+	if err == nil || !strings.Contains(err.Error(), "max_output_bytes must be > 0") {
+		t.Errorf("err = %v, want max_output_bytes rejected", err)
+	}
+}
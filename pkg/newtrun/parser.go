@@ -63,6 +63,9 @@ func ParseScenarioBytes(data []byte) (*Scenario, error) {
 		return nil, fmt.Errorf("parsing scenario: %w", err)
 	}
 	applyDefaults(&s)
+	if err := validateRedact("scenario "+s.Name, s.Redact); err != nil {
+		return nil, fmt.Errorf("validating scenario: %w", err)
+	}
 	for i, step := range s.Steps {
 		if err := validateStepFields(s.Name, i, &step); err != nil {
 			return nil, fmt.Errorf("validating scenario: %w", err)
@@ -71,6 +74,18 @@ func ParseScenarioBytes(data []byte) (*Scenario, error) {
 	if err := validateCleanupSteps(&s); err != nil {
 		return nil, fmt.Errorf("validating scenario: %w", err)
 	}
+	if err := validateRepeatParallel(&s); err != nil {
+		return nil, fmt.Errorf("validating scenario: %w", err)
+	}
+	if err := validateStepOutputRefs(&s); err != nil {
+		return nil, fmt.Errorf("validating scenario: %w", err)
+	}
+	if s.Timeout < 0 {
+		return nil, fmt.Errorf("validating scenario: scenario %q: timeout must not be negative", s.Name)
+	}
+	if err := validateSkipIf(&s); err != nil {
+		return nil, fmt.Errorf("validating scenario: %w", err)
+	}
 	return &s, nil
 }
 
@@ -133,6 +148,9 @@ func parseScenarioFile(path string) ([]*Scenario, error) {
 			return nil, fmt.Errorf("%s: %w", path, err)
 		}
 		applyDefaults(&s)
+		if err := validateRedact("scenario "+s.Name, s.Redact); err != nil {
+			return nil, fmt.Errorf("%s: validating scenario: %w", path, err)
+		}
 		for i, step := range s.Steps {
 			if err := validateStepFields(s.Name, i, &step); err != nil {
 				return nil, fmt.Errorf("%s: validating scenario: %w", path, err)
@@ -141,6 +159,18 @@ func parseScenarioFile(path string) ([]*Scenario, error) {
 		if err := validateCleanupSteps(&s); err != nil {
 			return nil, fmt.Errorf("%s: validating scenario: %w", path, err)
 		}
+		if err := validateRepeatParallel(&s); err != nil {
+			return nil, fmt.Errorf("%s: validating scenario: %w", path, err)
+		}
+		if err := validateStepOutputRefs(&s); err != nil {
+			return nil, fmt.Errorf("%s: validating scenario: %w", path, err)
+		}
+		if s.Timeout < 0 {
+			return nil, fmt.Errorf("%s: validating scenario: scenario %q: timeout must not be negative", path, s.Name)
+		}
+		if err := validateSkipIf(&s); err != nil {
+			return nil, fmt.Errorf("%s: validating scenario: %w", path, err)
+		}
 		out = append(out, &s)
 	}
 	if len(out) == 0 {
@@ -185,6 +215,36 @@ func requireSnapshotName(prefix string, step *Step) error {
 	return nil
 }
 
+// validateInterfaceSelector checks a newtron step's interfaces: selector and
+// its {{interface}} URL token against each other: either both or neither.
+// The fan-out is one-shot and device-templated only — batch, poll, and
+// capture have no per-interface shape.
+func validateInterfaceSelector(prefix string, step *Step) error {
+	hasToken := strings.Contains(step.URL, "{{interface}}")
+	if step.Interfaces == nil {
+		if hasToken {
+			return fmt.Errorf("%s: url uses {{interface}} but the step has no interfaces: selector", prefix)
+		}
+		return nil
+	}
+	if !hasToken || !strings.Contains(step.URL, "{{device}}") {
+		return fmt.Errorf("%s: interfaces: requires a url templated with both {{device}} and {{interface}}", prefix)
+	}
+	if len(step.Batch) > 0 || step.Poll != nil || len(step.Capture) > 0 {
+		return fmt.Errorf("%s: interfaces: is not supported with batch, poll, or capture", prefix)
+	}
+	switch step.Interfaces.Role {
+	case "":
+		if len(step.Interfaces.Names) == 0 {
+			return fmt.Errorf("%s: interfaces: needs a list of names or a role", prefix)
+		}
+	case "uplink", "downlink", "fabric":
+	default:
+		return fmt.Errorf("%s: unknown interface role %q (want uplink, downlink, or fabric)", prefix, step.Interfaces.Role)
+	}
+	return nil
+}
+
 // stepValidations is the declarative validation table for all step actions.
 // Actions not listed here have no field requirements.
 var stepValidations = map[StepAction]stepValidation{
@@ -194,15 +254,39 @@ var stepValidations = map[StepAction]stepValidation{
 		}
 		return nil
 	}},
-	ActionProvision:          {needsDevices: true},
-	ActionVerifyProvisioning: {needsDevices: true},
-	ActionHostExec:           {singleDevice: true, fields: []string{"command"}},
-	ActionSnapshot:           {needsDevices: true, custom: requireSnapshotName},
-	ActionVerifySnapshot:     {needsDevices: true, custom: requireSnapshotName},
+	ActionProvision:               {needsDevices: true},
+	ActionVerifyProvisioning:      {needsDevices: true},
+	ActionHostExec:                {singleDevice: true, fields: []string{"command"}},
+	ActionSnapshot:                {needsDevices: true, custom: requireSnapshotName},
+	ActionVerifySnapshot:          {needsDevices: true, custom: requireSnapshotName},
+	ActionWaitConverged:           {needsDevices: true, custom: requireConvergence},
+	ActionVerifyAnycast:           {needsDevices: true, custom: requireAnycastParams},
+	ActionVerifyVLANMembership:    {needsDevices: true, custom: requireVLANMembershipParams},
+	ActionVerifyEnvironment:       {needsDevices: true},
+	ActionGenerateTraffic:         {singleDevice: true, custom: requireTrafficParams},
+	ActionVerifyRouteLeak:         {needsDevices: true, custom: requireRouteLeakParams},
+	ActionVerifyPing:              {needsDevices: true, custom: requirePingParams},
+	ActionVerifyPing6:             {needsDevices: true, custom: requirePing6Params},
+	ActionVerifyVXLANStats:        {needsDevices: true, custom: requireVXLANStatsParams},
+	ActionVerifyEgressShaper:      {needsDevices: true, custom: requireEgressShaperParams},
+	ActionVerifyBGP:               {needsDevices: true, custom: requireBGPParams},
+	ActionVerifyLog:               {needsDevices: true, custom: requireLogParams},
+	ActionVerifyDHCPLease:         {needsDevices: true, custom: requireDHCPLeaseParams},
+	ActionVerifyChangeSet:         {needsDevices: true, custom: requireChangeSetParams},
+	ActionVerifyLLDP:              {needsDevices: true, custom: requireLLDPParams},
+	ActionVerifyInterfaceCounters: {needsDevices: true, custom: requireInterfaceCountersParams},
+	ActionVerifyARPSuppression:    {needsDevices: true, custom: requireARPSuppressionParams},
+	ActionVerifyEVPNType2:         {needsDevices: true, custom: requireEVPNType2Params},
+	ActionVerifyLAGDistribution:   {needsDevices: true, custom: requireLAGDistributionParams},
+	ActionVerifyIGMPSnooping:      {needsDevices: true, custom: requireIGMPSnoopingParams},
+	ActionVerifyNoFlaps:           {needsDevices: true, custom: requireNoFlapsParams},
 	ActionNewtron: {custom: func(prefix string, step *Step) error {
 		if step.URL == "" && len(step.Batch) == 0 {
 			return fmt.Errorf("%s: newtron requires url or batch", prefix)
 		}
+		if err := validateInterfaceSelector(prefix, step); err != nil {
+			return err
+		}
 		// Capture extracts values from a single response body. Batch
 		// emits multiple responses with no canonical "the response";
 		// poll loops until an assertion holds and reports only the
@@ -249,6 +333,38 @@ var stepValidations = map[StepAction]stepValidation{
 	}},
 }
 
+// retryableActions are the actions a step may retry: one-shot calls whose
+// failure can be transient. Verifications poll until their own timeout, and
+// a retry around a poll would multiply it.
+var retryableActions = map[StepAction]bool{
+	ActionHostExec:   true,
+	ActionNewtron:    true,
+	ActionNewtronCLI: true,
+}
+
+// validateRetries checks a step's retries and retry_interval.
+func validateRetries(prefix string, step *Step) error {
+	if step.Retries < 0 {
+		return fmt.Errorf("%s: retries must be >= 0", prefix)
+	}
+	if step.RetryInterval < 0 {
+		return fmt.Errorf("%s: retry_interval must be > 0", prefix)
+	}
+	if step.Retries == 0 {
+		if step.RetryInterval > 0 {
+			return fmt.Errorf("%s: retry_interval requires retries", prefix)
+		}
+		return nil
+	}
+	if !retryableActions[step.Action] {
+		return fmt.Errorf("%s: 'retries' is only valid for actions host-exec, newtron, and newtron-cli (got action %q, which polls or is not a one-shot call)", prefix, step.Action)
+	}
+	if step.Poll != nil {
+		return fmt.Errorf("%s: retries is not supported on poll steps (poll already re-runs until its timeout)", prefix)
+	}
+	return nil
+}
+
 // stepFieldGetter maps step-level field names to their accessor functions.
 var stepFieldGetter = map[string]func(*Step) string{
 	"command": func(s *Step) string { return s.Command },
@@ -280,6 +396,16 @@ func validateStepFields(scenario string, index int, step *Step) error {
 	if step.Action != ActionNewtron && len(step.Capture) > 0 {
 		return fmt.Errorf("%s: 'capture' is only valid for action newtron (got action %q)", prefix, step.Action)
 	}
+	if step.Action != ActionNewtron && step.Interfaces != nil {
+		return fmt.Errorf("%s: 'interfaces' is only valid for action newtron (got action %q)", prefix, step.Action)
+	}
+
+	if err := validateRedact(prefix, step.Redact); err != nil {
+		return err
+	}
+	if err := validateForEach(prefix, step); err != nil {
+		return err
+	}
 
 	// Poll needs both knobs — pollUntil has no defaults, and a zero
 	// timeout silently degenerates to a single attempt.
@@ -287,6 +413,22 @@ func validateStepFields(scenario string, index int, step *Step) error {
 		return fmt.Errorf("%s: poll requires timeout and interval (both > 0)", prefix)
 	}
 
+	if step.Expect != nil && step.Expect.MaxDuration < 0 {
+		return fmt.Errorf("%s: expect.max_duration must be > 0", prefix)
+	}
+	if step.Expect != nil && step.Expect.NextHopCount != nil && *step.Expect.NextHopCount < 0 {
+		return fmt.Errorf("%s: expect.next_hop_count must not be negative", prefix)
+	}
+	if step.MaxOutputBytes < 0 {
+		return fmt.Errorf("%s: max_output_bytes must be > 0", prefix)
+	}
+	if step.Observe && !observableActions[step.Action] {
+		return fmt.Errorf("%s: 'observe' is only valid for actions verify-bgp, verify-lldp, verify-route-leak, verify-topology, and verify-vlan-membership (got action %q)", prefix, step.Action)
+	}
+	if err := validateRetries(prefix, step); err != nil {
+		return err
+	}
+
 	v, ok := stepValidations[step.Action]
 	if !ok {
 		return nil // no validation rules for this action
@@ -430,22 +572,24 @@ func topologicalSort(scenarios []*Scenario) ([]*Scenario, error) {
 }
 
 // ComputeTargetChain returns the minimal set of scenarios needed to reach the
-// target, including all transitive requires dependencies, in dependency order.
+// targets, including all transitive requires dependencies, in dependency order.
 // Only hard dependencies (Requires) are traversed — soft dependencies (After)
 // are not included unless they are also in the requires chain.
-func ComputeTargetChain(scenarios []*Scenario, target string) ([]*Scenario, error) {
+func ComputeTargetChain(scenarios []*Scenario, targets ...string) ([]*Scenario, error) {
 	byName := make(map[string]*Scenario, len(scenarios))
 	for _, s := range scenarios {
 		byName[s.Name] = s
 	}
 
-	if _, ok := byName[target]; !ok {
-		return nil, fmt.Errorf("target scenario %q not found", target)
+	for _, target := range targets {
+		if _, ok := byName[target]; !ok {
+			return nil, fmt.Errorf("target scenario %q not found", target)
+		}
 	}
 
 	// BFS backwards through requires to collect the full chain
 	needed := make(map[string]bool)
-	queue := []string{target}
+	queue := append([]string(nil), targets...)
 	for len(queue) > 0 {
 		name := queue[0]
 		queue = queue[1:]
@@ -477,6 +621,37 @@ func applyDefaults(s *Scenario) {
 	// No defaults needed for the remaining 5 actions.
 }
 
+// validateRepeatParallel validates a scenario's repeat_parallel: setting.
+// Concurrent repeat iterations are only sound for a scenario its author has
+// declared parallel_safe, and only for steps that leave the runner's own
+// state alone: a capture: writes the iteration's captured map, which the
+// concurrent iterations would share, and a snapshot overwrites a run-scoped
+// baseline.
+func validateRepeatParallel(s *Scenario) error {
+	switch {
+	case s.RepeatParallel < 0:
+		return fmt.Errorf("scenario %q: repeat_parallel must not be negative", s.Name)
+	case s.RepeatParallel <= 1:
+		return nil
+	case !s.ParallelSafe:
+		return fmt.Errorf("scenario %q: repeat_parallel requires parallel_safe: true — concurrent iterations must not mutate shared state", s.Name)
+	case s.Repeat <= 1:
+		return fmt.Errorf("scenario %q: repeat_parallel requires repeat > 1", s.Name)
+	}
+	for i, step := range s.Steps {
+		if len(step.Capture) > 0 {
+			return fmt.Errorf("scenario %q step %d (%s): capture is not allowed with repeat_parallel — concurrent iterations would share the captured map", s.Name, i, step.Name)
+		}
+		if step.Action == ActionSnapshot {
+			return fmt.Errorf("scenario %q step %d (%s): snapshot is not allowed with repeat_parallel — it overwrites run-scoped state", s.Name, i, step.Name)
+		}
+		if len(stepOutputRefs(step)) > 0 {
+			return fmt.Errorf("scenario %q step %d (%s): step outputs are not available with repeat_parallel — concurrent iterations would share the captured map", s.Name, i, step.Name)
+		}
+	}
+	return nil
+}
+
 // validateCleanupSteps validates a scenario's cleanup: block. Cleanup steps
 // use the same per-action validation as main steps, plus one restriction:
 // no {{target.X}} references — cleanup runs once per scenario (after all
@@ -486,6 +661,12 @@ func validateCleanupSteps(s *Scenario) error {
 		if err := validateStepFields(s.Name+" cleanup", i, &step); err != nil {
 			return err
 		}
+		if step.ForEach != nil {
+			return fmt.Errorf("scenario %q cleanup step %d (%s): for_each is not supported on cleanup steps", s.Name, i, step.Name)
+		}
+		if step.Observe {
+			return fmt.Errorf("scenario %q cleanup step %d (%s): observe is not supported on cleanup steps", s.Name, i, step.Name)
+		}
 		if stepText, _ := yaml.Marshal(step); strings.Contains(string(stepText), "{{target.") {
 			return fmt.Errorf("scenario %q cleanup step %d (%s): cleanup steps cannot reference {{target.X}} — cleanup runs once per scenario, after all target iterations", s.Name, i, step.Name)
 		}
@@ -108,3 +108,30 @@ steps:
 		t.Fatalf("host-exec with poll should parse, got %v", err)
 	}
 }
+
+func TestParse_Retries(t *testing.T) {
+	tests := []struct {
+		name, step, want string
+	}{
+		{"host-exec", "action: host-exec\n    devices: [host1]\n    command: ping -c1 10.0.0.1\n    retries: 2\n    retry_interval: 2s", ""},
+		{"newtron", "action: newtron\n    url: /x\n    retries: 1", ""},
+		{"negative", "action: newtron\n    url: /x\n    retries: -1", "retries must be >= 0"},
+		{"interval without retries", "action: newtron\n    url: /x\n    retry_interval: 2s", "retry_interval requires retries"},
+		{"polling action", "action: verify-bgp\n    devices: [leaf1]\n    retries: 2", "'retries' is only valid for actions host-exec, newtron, and newtron-cli"},
+		{"poll step", "action: newtron\n    url: /x\n    poll:\n      timeout: 30s\n      interval: 5s\n    retries: 2", "retries is not supported on poll steps"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := parseOne(t, "name: s\ndescription: d\nsteps:\n  - name: step\n    "+tt.step+"\n")
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("want accepted, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("want %q, got %v", tt.want, err)
+			}
+		})
+	}
+}
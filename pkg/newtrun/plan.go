@@ -0,0 +1,247 @@
+package newtrun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// Plan is the static execution plan of a suite: what a run with the same
+// options would do — scenarios in execution order, the steps of each and
+// the devices they target, and the scenarios the run would skip — computed
+// without touching a device. PlanSuite builds it; `newtrun plan` renders it.
+type Plan struct {
+	Suite     string         `json:"suite"`
+	Network   string         `json:"network"`
+	Platform  string         `json:"platform,omitempty"`
+	Scenarios []ScenarioPlan `json:"scenarios"`
+	// Warnings are plan-wide gaps, e.g. the topology could not be read so
+	// device selectors are left unresolved.
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ScenarioPlan is one scenario of a Plan. Iterations is the number of
+// suite-target bindings a parameterized scenario runs over (1 otherwise);
+// SkipReason is set when the run would skip the scenario, in the words the
+// run would report.
+type ScenarioPlan struct {
+	Name             string     `json:"name"`
+	Description      string     `json:"description,omitempty"`
+	Requires         []string   `json:"requires,omitempty"`
+	After            []string   `json:"after,omitempty"`
+	RequiresFeatures []string   `json:"requires_features,omitempty"`
+	SkipIf           string     `json:"skip_if,omitempty"`
+	Repeat           int        `json:"repeat,omitempty"`
+	Iterations       int        `json:"iterations"`
+	SkipReason       string     `json:"skip_reason,omitempty"`
+	Steps            []StepPlan `json:"steps"`
+	Cleanup          []StepPlan `json:"cleanup,omitempty"`
+}
+
+// StepPlan is one step of a ScenarioPlan. Devices is the step's resolved
+// device list ("all" expanded against the topology); Problem flags a target
+// the run would trip over, such as a device the topology does not have.
+type StepPlan struct {
+	Name    string     `json:"name"`
+	Action  StepAction `json:"action"`
+	Devices []string   `json:"devices,omitempty"`
+	Problem string     `json:"problem,omitempty"`
+}
+
+// PlanOptions selects and resolves a Plan the way RunOptions does a run.
+type PlanOptions struct {
+	Scenario   string              // plan only this scenario
+	Target     string              // plan this scenario and its requires chain
+	Platform   string              // overrides the suite's platform
+	Targets    map[string][]string // target overrides, as for a run
+	Parameters map[string]any      // parameter overrides, as for a run
+
+	// Devices is the topology's node names. Nil leaves "all" selectors
+	// unresolved and explicit device names unchecked.
+	Devices []string
+
+	// SupportsFeature reports whether a platform supports a feature, for
+	// requires_features skips. Nil leaves those skips to run time.
+	SupportsFeature func(platform, feature string) (bool, error)
+
+	// ShowPlatform reads a platform's spec, for skip_if skips. Nil leaves
+	// those skips to run time.
+	ShowPlatform func(name string) (*spec.PlatformSpec, error)
+}
+
+// PlanSuite computes the execution plan for a loaded suite. It applies the
+// same scenario selection and the same static skip checks as Runner.Run —
+// requires_features, requires_params, skip_if, and requires on a scenario
+// that would itself be skipped — but runs nothing.
+func PlanSuite(suite *Suite, opts PlanOptions) (*Plan, error) {
+	scenarios, err := selectScenarios(suite, opts.Scenario, opts.Target)
+	if err != nil {
+		return nil, err
+	}
+	params, err := suite.EffectiveParameters(opts.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	targets, err := suite.EffectiveTargets(opts.Targets)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *suite
+	resolved.Targets = targets
+	iterations := len(resolved.TargetIterations())
+
+	plan := &Plan{Suite: suite.Name, Network: suite.Network, Platform: opts.Platform}
+	if plan.Platform == "" {
+		plan.Platform = suite.Platform
+	}
+	var known map[string]bool
+	if opts.Devices != nil {
+		known = make(map[string]bool, len(opts.Devices))
+		for _, d := range opts.Devices {
+			known[d] = true
+		}
+	}
+
+	skipped := map[string]bool{}
+	planned := map[string]bool{}
+	for _, sc := range scenarios {
+		sp := ScenarioPlan{
+			Name:             sc.Name,
+			Description:      sc.Description,
+			Requires:         sc.Requires,
+			After:            sc.After,
+			RequiresFeatures: sc.RequiresFeatures,
+			SkipIf:           sc.SkipIf,
+			Repeat:           sc.Repeat,
+			Iterations:       1,
+		}
+		if ScenarioIsParameterized(sc) {
+			sp.Iterations = iterations
+		}
+		sp.SkipReason = planSkipReason(sc, plan.Platform, params, planned, skipped, opts.SupportsFeature)
+		if sp.SkipReason == "" {
+			reason, err := planSkipIf(sc, plan.Platform, params, opts.ShowPlatform)
+			if err != nil {
+				plan.Warnings = append(plan.Warnings, fmt.Sprintf("scenario %s: %v", sc.Name, err))
+			}
+			sp.SkipReason = reason
+		}
+		if sp.SkipReason != "" {
+			skipped[sc.Name] = true
+		}
+		planned[sc.Name] = true
+		sp.Steps = planSteps(sc.Steps, opts.Devices, known)
+		sp.Cleanup = planSteps(sc.Cleanup, opts.Devices, known)
+		plan.Scenarios = append(plan.Scenarios, sp)
+	}
+	return plan, nil
+}
+
+// planSkipReason predicts iterateScenarios' skip checks, in its order.
+func planSkipReason(sc *Scenario, platform string, params map[string]any, planned, skipped map[string]bool,
+	supports func(platform, feature string) (bool, error)) string {
+	for _, req := range sc.Requires {
+		if !planned[req] {
+			return fmt.Sprintf("requires '%s' which has not run yet", req)
+		}
+		if skipped[req] {
+			return fmt.Sprintf("requires '%s' which will be skipped", req)
+		}
+	}
+	if len(sc.RequiresFeatures) > 0 && supports != nil && platform != "" {
+		var unsupported []string
+		for _, feature := range sc.RequiresFeatures {
+			if ok, err := supports(platform, feature); err != nil || !ok {
+				unsupported = append(unsupported, feature)
+			}
+		}
+		if len(unsupported) > 0 {
+			return fmt.Sprintf("platform '%s' does not support required features: %v", platform, unsupported)
+		}
+	}
+	return requiredParamsSkipReason(sc, params)
+}
+
+// planSkipIf predicts the run's skip_if check. Without a platform to read
+// it is left to run time.
+func planSkipIf(sc *Scenario, platform string, params map[string]any,
+	show func(name string) (*spec.PlatformSpec, error)) (string, error) {
+	if sc.SkipIf == "" || show == nil || platform == "" {
+		return "", nil
+	}
+	p, err := show(platform)
+	if err != nil {
+		return "", fmt.Errorf("skip_if: reading platform %s: %w", platform, err)
+	}
+	return skipIfReason(sc, p, params)
+}
+
+// planSteps resolves each step's device selector against the topology.
+func planSteps(steps []Step, devices []string, known map[string]bool) []StepPlan {
+	var out []StepPlan
+	for i := range steps {
+		step := &steps[i]
+		sp := StepPlan{Name: step.Name, Action: step.Action}
+		if step.Devices.All && devices == nil {
+			sp.Devices = []string{"all"}
+		} else {
+			sp.Devices = step.Devices.Resolve(devices)
+		}
+		if known != nil && !step.Devices.All {
+			var missing []string
+			for _, d := range step.Devices.Devices {
+				if !known[d] {
+					missing = append(missing, d)
+				}
+			}
+			if len(missing) > 0 {
+				sp.Problem = "not in topology: " + strings.Join(missing, ", ")
+			}
+		}
+		out = append(out, sp)
+	}
+	return out
+}
+
+// Plan loads the runner's suite and computes its plan. When ServerURL is
+// set, devices are resolved against the network's topology and
+// requires_features against the platform, as the run would; an unreachable
+// server leaves both unresolved and says so in Plan.Warnings.
+func (r *Runner) Plan(opts PlanOptions) (*Plan, error) {
+	suite, err := LoadSuite(r.SuiteDir)
+	if err != nil {
+		return nil, err
+	}
+	var warnings []string
+	if r.ServerURL != "" {
+		if err := r.connectToServer(); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%v; devices and platform features are unresolved", err))
+		} else {
+			if suite.Network != "" && suite.Network != r.Network {
+				warnings = append(warnings, fmt.Sprintf("suite requires topology %q but server has %q loaded", suite.Network, r.Network))
+			}
+			if names, err := r.Client.TopologyNodeNames(); err != nil {
+				warnings = append(warnings, fmt.Sprintf("reading topology: %v; devices are unresolved", err))
+			} else {
+				opts.Devices = names
+			}
+			opts.SupportsFeature = r.Client.PlatformSupportsFeature
+			opts.ShowPlatform = r.Client.ShowPlatform
+		}
+	}
+	plan, err := PlanSuite(suite, opts)
+	if err != nil {
+		return nil, err
+	}
+	if plan.Platform == "" {
+		for _, sc := range plan.Scenarios {
+			if len(sc.RequiresFeatures) > 0 || sc.SkipIf != "" {
+				warnings = append(warnings, "no platform set; requires_features and skip_if are checked at run time")
+				break
+			}
+		}
+	}
+	plan.Warnings = append(warnings, plan.Warnings...)
+	return plan, nil
+}
@@ -0,0 +1,124 @@
+package newtrun
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func planTestSuite() *Suite {
+	return &Suite{
+		Name:     "fabric",
+		Network:  "2node-vs",
+		Platform: "sonic-vs",
+		Parameters: map[string]ParameterSpec{
+			"token": {Type: "string"},
+		},
+		Scenarios: []*Scenario{
+			{Name: "boot", Steps: []Step{
+				{Name: "wait-boot", Action: ActionWait},
+				{Name: "converged", Action: ActionWaitConverged, Devices: deviceSelector{All: true}},
+			}},
+			{Name: "provision", Requires: []string{"boot"}, Steps: []Step{
+				{Name: "reconcile", Action: ActionNewtron, Devices: deviceSelector{Devices: []string{"leaf1", "leaf9"}}},
+			}},
+			{Name: "acl", Requires: []string{"provision"}, RequiresFeatures: []string{"acl"}},
+			{Name: "acl-report", Requires: []string{"acl"}},
+			{Name: "auth", After: []string{"boot"}, RequiresParams: []string{"token"}},
+		},
+	}
+}
+
+func TestPlanSuite(t *testing.T) {
+	plan, err := PlanSuite(planTestSuite(), PlanOptions{
+		Devices: []string{"leaf2", "leaf1"},
+		SupportsFeature: func(platform, feature string) (bool, error) {
+			return feature != "acl", nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("PlanSuite: %v", err)
+	}
+
+	skips := map[string]string{}
+	for _, sc := range plan.Scenarios {
+		skips[sc.Name] = sc.SkipReason
+	}
+	want := map[string]string{
+		"boot":       "",
+		"provision":  "",
+		"acl":        "platform 'sonic-vs' does not support required features: [acl]",
+		"acl-report": "requires 'acl' which will be skipped",
+		"auth":       `requires parameter "token" (not set by operator)`,
+	}
+	if !reflect.DeepEqual(skips, want) {
+		t.Errorf("skip reasons = %v, want %v", skips, want)
+	}
+
+	converged := plan.Scenarios[0].Steps[1]
+	if !reflect.DeepEqual(converged.Devices, []string{"leaf1", "leaf2"}) {
+		t.Errorf("devices: all resolved to %v, want [leaf1 leaf2]", converged.Devices)
+	}
+	if got := plan.Scenarios[1].Steps[0].Problem; got != "not in topology: leaf9" {
+		t.Errorf("problem = %q, want the unknown device named", got)
+	}
+}
+
+func TestPlanSuite_Selection(t *testing.T) {
+	suite := planTestSuite()
+
+	plan, err := PlanSuite(suite, PlanOptions{Target: "provision"})
+	if err != nil {
+		t.Fatalf("PlanSuite(target): %v", err)
+	}
+	var names []string
+	for _, sc := range plan.Scenarios {
+		names = append(names, sc.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"boot", "provision"}) {
+		t.Errorf("target chain = %v, want [boot provision]", names)
+	}
+	// Without a topology, "all" stays unresolved rather than empty.
+	if got := plan.Scenarios[0].Steps[1].Devices; !reflect.DeepEqual(got, []string{"all"}) {
+		t.Errorf("unresolved all = %v, want [all]", got)
+	}
+
+	// A lone scenario with requires is skipped by the run — and the plan.
+	plan, err = PlanSuite(suite, PlanOptions{Scenario: "provision", Parameters: map[string]any{"token": "x"}})
+	if err != nil {
+		t.Fatalf("PlanSuite(scenario): %v", err)
+	}
+	if got := plan.Scenarios[0].SkipReason; got != "requires 'boot' which has not run yet" {
+		t.Errorf("skip reason = %q", got)
+	}
+
+	if _, err := PlanSuite(suite, PlanOptions{Scenario: "nope"}); err == nil || !strings.Contains(err.Error(), `scenario "nope" not found`) {
+		t.Errorf("unknown scenario err = %v", err)
+	}
+}
+
+// TestPlanSuite_SkipIf: the plan predicts skip_if against the platform
+// spec, and names a condition the run would fail on.
+func TestPlanSuite_SkipIf(t *testing.T) {
+	suite := planTestSuite()
+	suite.Scenarios = []*Scenario{
+		{Name: "traffic", SkipIf: `.platform.dataplane == "sonic-vs"`},
+		{Name: "broken", SkipIf: `.platform.dataplane`},
+	}
+	plan, err := PlanSuite(suite, PlanOptions{
+		ShowPlatform: func(name string) (*spec.PlatformSpec, error) {
+			return &spec.PlatformSpec{Name: name, Dataplane: "sonic-vs"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("PlanSuite: %v", err)
+	}
+	if got := plan.Scenarios[0].SkipReason; got != `skip_if holds on platform 'sonic-vs': .platform.dataplane == "sonic-vs"` {
+		t.Errorf("traffic: skip reason = %q", got)
+	}
+	if plan.Scenarios[1].SkipReason != "" || len(plan.Warnings) != 1 || !strings.Contains(plan.Warnings[0], "scenario broken: skip_if") {
+		t.Errorf("broken: skip reason %q, warnings %v", plan.Scenarios[1].SkipReason, plan.Warnings)
+	}
+}
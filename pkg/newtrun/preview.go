@@ -0,0 +1,125 @@
+package newtrun
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// Preview runs. With RunOptions.Preview a run shows what a suite would
+// change without changing anything: each newtron write step goes out with
+// dry_run=true, so newtron builds the ChangeSet against the device's real
+// CONFIG_DB and delivers none of it; the step PASSes with a "preview only"
+// message and its rendered changes attached (StepResult.Changes).
+// topology-reconcile runs newtron's dry-run reconcile the same way.
+//
+// Nothing else can run meaningfully. Verify, wait and snapshot steps would
+// check state that was never delivered, and host-exec, newtron-cli and
+// generate-traffic have no dry run — each is recorded as a SKIP giving the
+// reason. Two kinds of step still run: a newtron read that captures (a
+// later preview step may need the value, and a read changes nothing), and
+// run-suite, whose child suite inherits the preview.
+
+// previewStep runs step the way a preview does, or returns nil when the
+// step runs as usual.
+func (r *Runner) previewStep(ctx context.Context, step *Step) *StepOutput {
+	switch step.Action {
+	case ActionNewtron:
+		if stepWrites(step) {
+			return r.previewNewtron(ctx, step)
+		}
+		if len(step.Capture) > 0 && step.Poll == nil {
+			return nil
+		}
+	case ActionProvision:
+		return r.executeForDevices(step, func(name string) (string, error) {
+			result, err := r.Client.Reconcile(name, "topology", "", newtron.ExecOpts{})
+			if err != nil {
+				return "", fmt.Errorf("reconcile (dry run): %s", err)
+			}
+			return fmt.Sprintf("preview only: %d entries would be applied", result.Applied), nil
+		})
+	case ActionRunSuite:
+		return nil
+	}
+	return &StepOutput{Result: &StepResult{Status: StepStatusSkipped, Message: previewSkipReason(step.Action)}}
+}
+
+// previewSkipReason says why a preview run skips a step of action.
+func previewSkipReason(action StepAction) string {
+	switch action {
+	case ActionHostExec, ActionNewtronCLI, ActionGenerateTraffic:
+		return fmt.Sprintf("preview: %s has no dry run", action)
+	}
+	return "preview: nothing was delivered to verify or wait for"
+}
+
+// stepWrites reports whether a newtron step makes any write call.
+func stepWrites(step *Step) bool {
+	if len(step.Batch) > 0 {
+		for _, call := range step.Batch {
+			if isWriteMethod(call.Method) {
+				return true
+			}
+		}
+		return false
+	}
+	return isWriteMethod(step.Method)
+}
+
+// previewNewtron runs a newtron write step with dry_run=true on every call
+// and attaches the ChangeSets newtron rendered.
+func (r *Runner) previewNewtron(ctx context.Context, step *Step) *StepOutput {
+	dry := *step
+	dry.URL = withDryRun(step.URL)
+	if len(step.Batch) > 0 {
+		dry.Batch = make([]BatchCall, len(step.Batch))
+		for i, call := range step.Batch {
+			dry.Batch[i] = call
+			dry.Batch[i].URL = withDryRun(call.URL)
+		}
+	}
+	out := (&newtronExecutor{}).Execute(ctx, r, &dry)
+	if out.Result.Status != StepStatusPassed {
+		return out
+	}
+	changes := r.stepChangeSets(step.Name)
+	n := 0
+	for _, c := range changes {
+		n += len(c)
+	}
+	if len(changes) > 0 {
+		out.Result.Changes = changes
+	}
+	out.Result.Message = fmt.Sprintf("preview only: %d changes not delivered", n)
+	if len(changes) > 1 {
+		devs := make([]string, 0, len(changes))
+		for dev, c := range changes {
+			devs = append(devs, fmt.Sprintf("%s %d", dev, len(c)))
+		}
+		sort.Strings(devs)
+		out.Result.Message += " (" + strings.Join(devs, ", ") + ")"
+	}
+	return out
+}
+
+var dryRunParamRe = regexp.MustCompile(`([?&])dry_run=[^&]*`)
+
+// withDryRun sets dry_run=true on a newtron URL — overriding a dry_run the
+// step set itself, so no preview call can deliver.
+func withDryRun(u string) string {
+	if u == "" {
+		return u
+	}
+	if dryRunParamRe.MatchString(u) {
+		return dryRunParamRe.ReplaceAllString(u, "${1}dry_run=true")
+	}
+	if strings.Contains(u, "?") {
+		return u + "&dry_run=true"
+	}
+	return u + "?dry_run=true"
+}
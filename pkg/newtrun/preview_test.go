@@ -0,0 +1,111 @@
+package newtrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// TestPreview_NoDelivery runs a write step, a verify step and a wait in a
+// preview run: every write goes out with dry_run=true, the write step
+// carries the rendered ChangeSet, and the other two are skipped.
+func TestPreview_NoDelivery(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/topology/nodes") { // device resolution
+			mu.Lock()
+			requests = append(requests, r.Method+" "+r.URL.RequestURI())
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"change_count":2,"applied":false,"changes":[
+			{"table":"VLAN","key":"Vlan100","type":"add","fields":{"vlanid":"100"}},
+			{"table":"VLAN_MEMBER","key":"Vlan100|Ethernet0","type":"add","fields":{"tagging_mode":"untagged"}}]}}`))
+	}))
+	defer srv.Close()
+
+	leaf1 := deviceSelector{Devices: []string{"leaf1"}}
+	scenario := &Scenario{
+		Name: "preview",
+		Steps: []Step{
+			{Name: "create-vlan", Action: ActionNewtron, Devices: leaf1, Method: "POST",
+				URL: "/nodes/{{device}}/create-vlan?dry_run=false", Params: map[string]any{"id": 100}},
+			{Name: "bgp-up", Action: ActionVerifyBGP, Devices: leaf1},
+			{Name: "settle", Action: ActionWait, Duration: time.Hour},
+		},
+	}
+	result := &ScenarioResult{Name: scenario.Name}
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	r.runScenarioSteps(context.Background(), scenario, RunOptions{Preview: true}, result)
+
+	if result.Status != StepStatusPassed || len(result.Steps) != 3 {
+		t.Fatalf("scenario status = %s, steps: %+v", result.Status, result.Steps)
+	}
+	write := result.Steps[0]
+	if write.Status != StepStatusPassed || !strings.HasPrefix(write.Message, "preview only: 2 changes") {
+		t.Errorf("write step = %s %q, want PASS preview only", write.Status, write.Message)
+	}
+	if got := write.Changes["leaf1"]; len(got) != 2 || got[1].Key != "Vlan100|Ethernet0" {
+		t.Errorf("write Changes = %+v, want the 2 rendered changes under leaf1", write.Changes)
+	}
+	for _, sr := range result.Steps[1:] {
+		if sr.Status != StepStatusSkipped || !strings.HasPrefix(sr.Message, "preview:") {
+			t.Errorf("%s = %s %q, want SKIP with a preview reason", sr.Name, sr.Status, sr.Message)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("requests = %v, want only the dry-run write (no readiness probe, no BGP check)", requests)
+	}
+	if !strings.HasSuffix(requests[0], "/create-vlan?dry_run=true") {
+		t.Errorf("write request = %q, want dry_run=true", requests[0])
+	}
+}
+
+func TestPreviewStep_Skips(t *testing.T) {
+	r := &Runner{}
+	for _, tt := range []struct {
+		step Step
+		want string // "" = the step runs as usual
+	}{
+		{Step{Action: ActionHostExec}, "preview: host-exec has no dry run"},
+		{Step{Action: ActionNewtronCLI}, "preview: newtron-cli has no dry run"},
+		{Step{Action: ActionVerifyLLDP}, "preview: nothing was delivered"},
+		{Step{Action: ActionSnapshot}, "preview: nothing was delivered"},
+		{Step{Action: ActionNewtron, URL: "/nodes/{{device}}/interfaces"}, "preview: nothing was delivered"},
+		{Step{Action: ActionNewtron, URL: "/nodes/{{device}}/interfaces", Capture: map[string]string{"n": ".name"}}, ""},
+		{Step{Action: ActionRunSuite}, ""},
+	} {
+		out := r.previewStep(context.Background(), &tt.step)
+		switch {
+		case tt.want == "" && out != nil:
+			t.Errorf("%s: previewStep = %+v, want nil (runs as usual)", tt.step.Action, out.Result)
+		case tt.want != "" && (out == nil || out.Result.Status != StepStatusSkipped || !strings.HasPrefix(out.Result.Message, tt.want)):
+			t.Errorf("%s: previewStep = %+v, want SKIP %q", tt.step.Action, out, tt.want)
+		}
+	}
+}
+
+func TestWithDryRun(t *testing.T) {
+	for _, tt := range []struct{ in, want string }{
+		{"/nodes/{{device}}/create-vlan", "/nodes/{{device}}/create-vlan?dry_run=true"},
+		{"/nodes/{{device}}/create-vlan?no_save=true", "/nodes/{{device}}/create-vlan?no_save=true&dry_run=true"},
+		{"/nodes/{{device}}/create-vlan?dry_run=false&no_save=true", "/nodes/{{device}}/create-vlan?dry_run=true&no_save=true"},
+		{"/nodes/{{device}}/create-vlan?no_save=true&dry_run=0", "/nodes/{{device}}/create-vlan?no_save=true&dry_run=true"},
+	} {
+		if got := withDryRun(tt.in); got != tt.want {
+			t.Errorf("withDryRun(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -40,6 +40,15 @@ type ProgressReporter interface {
 	SuiteEnd(results []*ScenarioResult, status SuiteStatus, duration time.Duration)
 }
 
+// optionalDuration renders d for state.json at report precision (sub-second
+// overages matter to a performance bound), "" when zero.
+func optionalDuration(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return formatReportDuration(d)
+}
+
 // formatDurationCompact formats a duration in a human-readable compact form.
 func formatDurationCompact(d time.Duration) string {
 	if d < time.Second {
@@ -180,12 +189,19 @@ func (r *StateReporter) StepEnd(scenario string, result *StepResult, index, tota
 		r.State.Scenarios[r.scenarioIndex].Steps = append(
 			r.State.Scenarios[r.scenarioIndex].Steps,
 			StepState{
-				Name:      result.Name,
-				Action:    string(result.Action),
-				Status:    string(result.Status),
-				Duration:  formatDurationCompact(result.Duration),
-				Message:   result.Message,
-				DeviceOps: r.currentStepDeviceOps,
+				Name:        result.Name,
+				Action:      string(result.Action),
+				Status:      string(result.Status),
+				Duration:    formatDurationCompact(result.Duration),
+				Message:     result.Message,
+				Output:      result.Output,
+				Item:        result.Item,
+				DeviceOps:   r.currentStepDeviceOps,
+				Artifacts:   result.Artifacts,
+				MaxDuration: optionalDuration(result.MaxDuration),
+				Overage:     optionalDuration(result.Overage),
+				Attempts:    result.Attempts,
+				MaxAttempts: result.MaxAttempts,
 			},
 		)
 		r.currentStepDeviceOps = nil
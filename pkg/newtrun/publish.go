@@ -0,0 +1,144 @@
+package newtrun
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+	"gopkg.in/yaml.v3"
+)
+
+// Step outputs. An executor may publish key/value pairs in its result's
+// Outputs — a value it derived at run time that a later step needs, and
+// that the suite author could not have written down in advance:
+//
+//	- name: peer-leaf2
+//	  action: newtron
+//	  devices: [leaf1]
+//	  method: POST
+//	  url: /nodes/{{device}}/interfaces/Ethernet0/add-bgp-peer
+//	  params: {remote_as: 65002}          # neighbor IP derived from the /31
+//
+//	- name: peer-up
+//	  action: host-exec
+//	  devices: [host1]
+//	  command: ping -c 3 {{steps.peer-leaf2.output.neighbor_ip}}
+//
+// Where capture: (capture.go) pulls a value out of a response with a JQ
+// expression the author writes, an output is published by the executor
+// itself, under a key its documentation names. When a step passes, the
+// runner copies its outputs into the iteration's captured map under
+// stepOutputKey, so {{steps.NAME.output.KEY}} is expanded by the same
+// engine, in the same contexts and with the same lifecycle as
+// {{captured.NAME}}. A failed step publishes nothing.
+//
+// The newtron executor publishes what a write step's ChangeSet derived:
+//
+//	neighbor_ip   the BGP_NEIGHBOR the step added (add-bgp-peer and friends)
+//	vtep_source   the VXLAN_TUNNEL src_ip the step set (setup-device)
+//
+// each only when the step derived exactly one such value — a step that
+// fanned out over several devices has no single neighbor to name.
+
+// stepOutputPrefix starts the captured-map key of a step output. No
+// capture: name can collide with it: those are identifiers, without dots.
+const stepOutputPrefix = "steps."
+
+// stepOutputKey is the captured-map key step's output key is published
+// under — the token's own text, minus the braces.
+func stepOutputKey(step, key string) string {
+	return stepOutputPrefix + step + ".output." + key
+}
+
+// publishOutputs copies a step's outputs into the captured map.
+func publishOutputs(captured map[string]any, step string, outputs map[string]string) {
+	for k, v := range outputs {
+		captured[stepOutputKey(step, k)] = v
+	}
+}
+
+// stepOutputRefs returns the names of the steps whose outputs step
+// references, in order of first appearance.
+func stepOutputRefs(step Step) []string {
+	text, _ := yaml.Marshal(step)
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range templateTokenRe.FindAllStringSubmatch(string(text), -1) {
+		if name := m[3]; name != "" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// validateStepOutputRefs checks that every {{steps.NAME.output.KEY}} in a
+// scenario names a step that runs before it: an earlier main step, or for a
+// cleanup step any main step. A forward reference would only ever fail at
+// run time, after the steps before it had already changed the device.
+func validateStepOutputRefs(s *Scenario) error {
+	earlier := map[string]bool{}
+	for i, step := range s.Steps {
+		for _, name := range stepOutputRefs(step) {
+			if !earlier[name] {
+				return fmt.Errorf("scenario %q step %d (%s): {{steps.%s.output...}} must name an earlier step", s.Name, i, step.Name, name)
+			}
+		}
+		earlier[step.Name] = true
+	}
+	for i, step := range s.Cleanup {
+		for _, name := range stepOutputRefs(step) {
+			if !earlier[name] {
+				return fmt.Errorf("scenario %q cleanup step %d (%s): {{steps.%s.output...}} must name a main step", s.Name, i, step.Name, name)
+			}
+		}
+	}
+	return nil
+}
+
+// changeSetOutputs returns the outputs a newtron write step publishes from
+// the ChangeSets it generated, device → changes. Nil when there are none.
+func changeSetOutputs(byDevice map[string][]sonic.ConfigChange) map[string]string {
+	neighbors := map[string]bool{}
+	sources := map[string]bool{}
+	for _, changes := range byDevice {
+		for _, c := range changes {
+			if c.Type == sonic.ChangeTypeDelete {
+				continue
+			}
+			switch c.Table {
+			case "BGP_NEIGHBOR":
+				// Keyed vrf|ip (node.BGPNeighborKey).
+				if _, ip, ok := strings.Cut(c.Key, "|"); ok {
+					neighbors[ip] = true
+				}
+			case "VXLAN_TUNNEL":
+				if src := c.Fields["src_ip"]; src != "" {
+					sources[src] = true
+				}
+			}
+		}
+	}
+	outputs := map[string]string{}
+	if v, ok := single(neighbors); ok {
+		outputs["neighbor_ip"] = v
+	}
+	if v, ok := single(sources); ok {
+		outputs["vtep_source"] = v
+	}
+	if len(outputs) == 0 {
+		return nil
+	}
+	return outputs
+}
+
+// single returns the one member of set, if it has exactly one.
+func single(set map[string]bool) (string, bool) {
+	if len(set) != 1 {
+		return "", false
+	}
+	for k := range set {
+		return k, true
+	}
+	return "", false
+}
@@ -0,0 +1,166 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// TestStepOutputs_TwoStepScenario runs add-bgp-peer, whose ChangeSet derives
+// the neighbor IP, then a step that feeds {{steps.peer.output.neighbor_ip}}
+// into its URL and params — the value reaches the second request.
+func TestStepOutputs_TwoStepScenario(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		gotPath string
+		gotBody map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/exists") { // the readiness gate after a device write
+			_, _ = w.Write([]byte(`{"data":{"exists":true}}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/add-bgp-peer") {
+			_, _ = w.Write([]byte(`{"data":{"change_count":2,"applied":true,"changes":[
+				{"table":"BGP_NEIGHBOR","key":"default|10.1.0.1","type":"add","fields":{"asn":"65002"}},
+				{"table":"BGP_NEIGHBOR_AF","key":"default|10.1.0.1|ipv4_unicast","type":"add","fields":{"admin_status":"true"}}]}}`))
+			return
+		}
+		mu.Lock()
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+	defer srv.Close()
+
+	leaf1 := deviceSelector{Devices: []string{"leaf1"}}
+	scenario := &Scenario{
+		Name: "peer-then-use",
+		Steps: []Step{
+			{Name: "peer", Action: ActionNewtron, Devices: leaf1, Method: "POST",
+				URL: "/nodes/{{device}}/interfaces/Ethernet0/add-bgp-peer", Params: map[string]any{"remote_as": 65002}},
+			{Name: "describe", Action: ActionNewtron, Method: "POST",
+				URL:    "/peers/{{steps.peer.output.neighbor_ip}}/describe",
+				Params: map[string]any{"neighbor_ip": "{{steps.peer.output.neighbor_ip}}", "note": "peer {{steps.peer.output.neighbor_ip}}"}},
+		},
+	}
+	result := &ScenarioResult{Name: scenario.Name}
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	r.runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+
+	if result.Status != StepStatusPassed {
+		t.Fatalf("scenario status = %s, steps: %+v", result.Status, result.Steps)
+	}
+	if got := result.Steps[0].Outputs["neighbor_ip"]; got != "10.1.0.1" {
+		t.Errorf("peer Outputs = %v, want neighbor_ip 10.1.0.1", result.Steps[0].Outputs)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !strings.HasSuffix(gotPath, "/peers/10.1.0.1/describe") {
+		t.Errorf("describe path = %q", gotPath)
+	}
+	if gotBody["neighbor_ip"] != "10.1.0.1" || gotBody["note"] != "peer 10.1.0.1" {
+		t.Errorf("describe body = %v", gotBody)
+	}
+}
+
+// TestStepOutputs_Undefined: a reference to an output no step published is
+// an expansion error; once published, it expands in the step's context.
+func TestStepOutputs_Undefined(t *testing.T) {
+	_, err := ExpandStep(Step{Action: ActionHostExec, Command: "ping {{steps.peer.output.neighbor_ip}}"}, nil, nil, map[string]any{})
+	if err == nil || !strings.Contains(err.Error(), "undefined step output {{steps.peer.output.neighbor_ip}}") {
+		t.Fatalf("err = %v, want undefined step output", err)
+	}
+
+	captured := map[string]any{}
+	publishOutputs(captured, "peer", map[string]string{"neighbor_ip": "10.1.0.1"})
+	got, err := ExpandStep(Step{Action: ActionHostExec, Command: "ping {{steps.peer.output.neighbor_ip}}"}, nil, nil, captured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Command != "ping '10.1.0.1'" {
+		t.Errorf("Command = %q, want shell-quoted neighbor IP", got.Command)
+	}
+}
+
+func TestChangeSetOutputs(t *testing.T) {
+	neighbor := func(ip string) sonic.ConfigChange {
+		return sonic.ConfigChange{Table: "BGP_NEIGHBOR", Key: "default|" + ip, Type: sonic.ChangeTypeAdd}
+	}
+	vtep := sonic.ConfigChange{Table: "VXLAN_TUNNEL", Key: "vtep1", Type: sonic.ChangeTypeAdd, Fields: map[string]string{"src_ip": "10.0.0.1"}}
+
+	tests := []struct {
+		name     string
+		byDevice map[string][]sonic.ConfigChange
+		want     map[string]string
+	}{
+		{"neighbor", map[string][]sonic.ConfigChange{"leaf1": {neighbor("10.1.0.1")}}, map[string]string{"neighbor_ip": "10.1.0.1"}},
+		{"vtep source", map[string][]sonic.ConfigChange{"leaf1": {vtep}}, map[string]string{"vtep_source": "10.0.0.1"}},
+		{"two neighbors", map[string][]sonic.ConfigChange{"leaf1": {neighbor("10.1.0.1")}, "leaf2": {neighbor("10.1.0.3")}}, nil},
+		{"removed neighbor", map[string][]sonic.ConfigChange{"leaf1": {{Table: "BGP_NEIGHBOR", Key: "default|10.1.0.1", Type: sonic.ChangeTypeDelete}}}, nil},
+		{"unrelated", map[string][]sonic.ConfigChange{"leaf1": {{Table: "VLAN", Key: "Vlan100", Type: sonic.ChangeTypeAdd}}}, nil},
+	}
+	for _, tt := range tests {
+		got := changeSetOutputs(tt.byDevice)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: outputs = %v, want %v", tt.name, got, tt.want)
+			continue
+		}
+		for k, v := range tt.want {
+			if got[k] != v {
+				t.Errorf("%s: outputs[%s] = %q, want %q", tt.name, k, got[k], v)
+			}
+		}
+	}
+}
+
+func TestValidateStepOutputRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		want string
+	}{
+		{"earlier step", `name: s
+steps:
+  - {name: a, action: wait, duration: 1s}
+  - {name: b, action: host-exec, devices: [h1], command: "ping {{steps.a.output.neighbor_ip}}"}
+`, ""},
+		{"forward reference", `name: s
+steps:
+  - {name: a, action: host-exec, devices: [h1], command: "ping {{steps.b.output.neighbor_ip}}"}
+  - {name: b, action: wait, duration: 1s}
+`, "must name an earlier step"},
+		{"cleanup reads main step", `name: s
+steps:
+  - {name: a, action: wait, duration: 1s}
+cleanup:
+  - {name: c, action: host-exec, devices: [h1], command: "ping {{steps.a.output.neighbor_ip}}"}
+`, ""},
+		{"repeat_parallel", `name: s
+parallel_safe: true
+repeat: 2
+repeat_parallel: 2
+steps:
+  - {name: a, action: wait, duration: 1s}
+  - {name: b, action: host-exec, devices: [h1], command: "ping {{steps.a.output.neighbor_ip}}"}
+`, "not available with repeat_parallel"},
+	}
+	for _, tt := range tests {
+		_, err := ParseScenarioBytes([]byte(tt.yaml))
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: err = %v, want containing %q", tt.name, err, tt.want)
+		}
+	}
+}
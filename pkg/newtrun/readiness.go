@@ -0,0 +1,148 @@
+package newtrun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Readiness gate. After a step that changes device config — provision, or a
+// newtron step that writes through a {{device}} URL — the runner asks a
+// ReadinessChecker whether each target device is ready before the next step
+// runs. The checker is chosen by the scenario's platform: a site registers
+// platform-specific logic (wait for a VPP STATE_DB key, a daemon's warm-boot
+// flag) with RegisterReadinessChecker from init, the same way custom actions
+// register; platforms without one get the default (SSH and CONFIG_DB
+// reachable). Like the action registry, this one is init-time only.
+
+// ReadinessChecker reports whether one device is ready for the next step.
+// Check returns nil when it is; otherwise an error saying what is not ready
+// yet. The runner polls Check until it returns nil or readinessTimeout
+// expires, so one call should be a single quick probe, not a wait.
+type ReadinessChecker interface {
+	Check(ctx context.Context, r *Runner, device string) error
+}
+
+// How long the gate waits for a device, and how often it asks.
+var (
+	readinessTimeout  = 2 * time.Minute
+	readinessInterval = 2 * time.Second
+)
+
+// readinessCheckers maps a platform name to its registered checker.
+var readinessCheckers = map[string]ReadinessChecker{}
+
+// defaultReadiness gates platforms with no registered checker.
+var defaultReadiness ReadinessChecker = &reachableChecker{}
+
+// RegisterReadinessChecker makes c the readiness gate for devices of the
+// given platform (a platforms.json name). Call it from an init function:
+// registering after a run has started is a data race. Registering a
+// platform twice is an error.
+func RegisterReadinessChecker(platform string, c ReadinessChecker) error {
+	if platform == "" {
+		return fmt.Errorf("register readiness checker: empty platform name")
+	}
+	if c == nil {
+		return fmt.Errorf("register readiness checker %q: nil checker", platform)
+	}
+	if _, dup := readinessCheckers[platform]; dup {
+		return fmt.Errorf("register readiness checker %q: already registered", platform)
+	}
+	readinessCheckers[platform] = c
+	return nil
+}
+
+// readinessCheckerFor returns the checker for platform, or the default.
+func readinessCheckerFor(platform string) ReadinessChecker {
+	if c, ok := readinessCheckers[platform]; ok {
+		return c
+	}
+	return defaultReadiness
+}
+
+// reachableChecker is the default gate: the server can reach the device over
+// SSH (every device read pings the tunnel first) and read its CONFIG_DB.
+type reachableChecker struct{}
+
+func (c *reachableChecker) Check(ctx context.Context, r *Runner, device string) error {
+	exists, err := r.Client.ConfigDBEntryExists(device, "DEVICE_METADATA", "localhost")
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("CONFIG_DB has no DEVICE_METADATA|localhost")
+	}
+	return nil
+}
+
+// changesDeviceConfig reports whether step writes device config, and so is
+// followed by the readiness gate.
+func changesDeviceConfig(step *Step) bool {
+	switch step.Action {
+	case ActionProvision:
+		return true
+	case ActionNewtron:
+		if len(step.Batch) > 0 {
+			for _, call := range step.Batch {
+				if isWriteMethod(call.Method) && hasDeviceTemplate(call.URL) {
+					return true
+				}
+			}
+			return false
+		}
+		return isWriteMethod(step.Method) && hasDeviceTemplate(step.URL)
+	}
+	return false
+}
+
+func isWriteMethod(method string) bool {
+	return method != "" && !strings.EqualFold(method, "GET")
+}
+
+// awaitReadiness polls the platform's ReadinessChecker for every SONiC device
+// step targets, in parallel, and fails result when any device is not ready
+// within readinessTimeout.
+func (r *Runner) awaitReadiness(ctx context.Context, step *Step, platform string, result *StepResult) {
+	checker := readinessCheckerFor(platform)
+	var (
+		mu       sync.Mutex
+		notReady []string
+		wg       sync.WaitGroup
+	)
+	for _, name := range r.resolveDevices(step) {
+		if _, isHost := r.HostConns[name]; isHost {
+			continue
+		}
+		wg.Add(1)
+		go func(dev string) {
+			defer wg.Done()
+			var lastErr error
+			pollErr := pollUntil(ctx, readinessTimeout, readinessInterval, func() (bool, error) {
+				lastErr = checker.Check(ctx, r, dev)
+				return lastErr == nil, nil
+			})
+			if pollErr == nil {
+				return
+			}
+			msg := pollErr.Error()
+			if lastErr != nil {
+				msg += ": " + lastErr.Error()
+			}
+			mu.Lock()
+			notReady = append(notReady, dev+": "+msg)
+			mu.Unlock()
+		}(name)
+	}
+	wg.Wait()
+
+	if len(notReady) == 0 {
+		return
+	}
+	sort.Strings(notReady)
+	result.Status = StepStatusFailed
+	result.Message = "device not ready: " + strings.Join(notReady, "; ")
+}
@@ -0,0 +1,128 @@
+package newtrun
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// countingChecker reports not-ready until it has been asked readyAfter times.
+type countingChecker struct {
+	mu         sync.Mutex
+	calls      map[string]int
+	readyAfter int
+}
+
+func (c *countingChecker) Check(ctx context.Context, r *Runner, device string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls[device]++
+	if c.calls[device] < c.readyAfter {
+		return errors.New("dataplane still programming")
+	}
+	return nil
+}
+
+// readinessHarness serves a device write and the default checker's CONFIG_DB
+// probe; metadata controls whether DEVICE_METADATA|localhost exists.
+func readinessHarness(t *testing.T, metadata bool) *Runner {
+	t.Helper()
+	readinessTimeout, readinessInterval = 200*time.Millisecond, time.Millisecond
+	t.Cleanup(func() { readinessTimeout, readinessInterval = 2*time.Minute, 2*time.Second })
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/configdb/DEVICE_METADATA/localhost/exists") {
+			if metadata {
+				_, _ = w.Write([]byte(`{"data":{"exists":true}}`))
+			} else {
+				_, _ = w.Write([]byte(`{"data":{"exists":false}}`))
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+	t.Cleanup(srv.Close)
+	return &Runner{Client: client.New(srv.URL, "test-net")}
+}
+
+var writeStep = Step{
+	Name:    "add-vlan",
+	Action:  ActionNewtron,
+	Method:  "POST",
+	URL:     "/nodes/{{device}}/create-vlan",
+	Devices: deviceSelector{Devices: []string{"switch1", "switch2"}},
+}
+
+// TestReadiness_PlatformCheckerGatesWrites pins the hook: a write step on a
+// platform with a registered checker polls it per device until ready, and a
+// read step is not gated.
+func TestReadiness_PlatformCheckerGatesWrites(t *testing.T) {
+	r := readinessHarness(t, true)
+	checker := &countingChecker{calls: map[string]int{}, readyAfter: 3}
+	if err := RegisterReadinessChecker("vpp-test", checker); err != nil {
+		t.Fatalf("RegisterReadinessChecker: %v", err)
+	}
+	t.Cleanup(func() { delete(readinessCheckers, "vpp-test") })
+
+	step := writeStep
+	out := r.executeStep(context.Background(), &step, 0, 1, RunOptions{Platform: "vpp-test"})
+	if out.Result.Status != StepStatusPassed {
+		t.Fatalf("status = %v (%s), want PASSED once ready", out.Result.Status, out.Result.Message)
+	}
+	if checker.calls["switch1"] != 3 || checker.calls["switch2"] != 3 {
+		t.Errorf("checker calls = %v, want 3 per device", checker.calls)
+	}
+
+	read := writeStep
+	read.Method = ""
+	checker.calls = map[string]int{}
+	r.executeStep(context.Background(), &read, 0, 1, RunOptions{Platform: "vpp-test"})
+	if len(checker.calls) != 0 {
+		t.Errorf("read step consulted the checker: %v", checker.calls)
+	}
+}
+
+// TestReadiness_DefaultCheckerFailsStep pins the default gate: a platform with
+// no registered checker waits for CONFIG_DB to answer, and a device that never
+// does fails the step with the last reason.
+func TestReadiness_DefaultCheckerFailsStep(t *testing.T) {
+	step := writeStep
+	out := readinessHarness(t, true).executeStep(context.Background(), &step, 0, 1, RunOptions{Platform: "vs"})
+	if out.Result.Status != StepStatusPassed {
+		t.Fatalf("reachable device: status = %v (%s), want PASSED", out.Result.Status, out.Result.Message)
+	}
+
+	step = writeStep
+	out = readinessHarness(t, false).executeStep(context.Background(), &step, 0, 1, RunOptions{Platform: "vs"})
+	if out.Result.Status != StepStatusFailed {
+		t.Fatalf("unready device: status = %v, want FAILED", out.Result.Status)
+	}
+	for _, want := range []string{"device not ready", "switch1:", "switch2:", "DEVICE_METADATA|localhost"} {
+		if !strings.Contains(out.Result.Message, want) {
+			t.Errorf("message %q missing %q", out.Result.Message, want)
+		}
+	}
+}
+
+func TestRegisterReadinessChecker_Rejects(t *testing.T) {
+	c := &countingChecker{calls: map[string]int{}}
+	if err := RegisterReadinessChecker("", c); err == nil {
+		t.Error("empty platform accepted")
+	}
+	if err := RegisterReadinessChecker("p", nil); err == nil {
+		t.Error("nil checker accepted")
+	}
+	if err := RegisterReadinessChecker("twice", c); err != nil {
+		t.Fatalf("RegisterReadinessChecker: %v", err)
+	}
+	t.Cleanup(func() { delete(readinessCheckers, "twice") })
+	if err := RegisterReadinessChecker("twice", c); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("duplicate platform: err = %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Output redaction. A scenario or step redact: list names content that
+// must never be persisted in clear — session keys captured from a login
+// response, passwords echoed by a host-exec command. Each entry is one of:
+//
+//	captured.NAME   the runtime value of {{captured.NAME}}, masked literally
+//	<regex>         a Go regular expression; when it has capture groups only
+//	                the groups are masked, otherwise the whole match is
+//
+// Redaction is applied where results leave the runner — step and device
+// messages and captured output before they reach the progress reporters (console, state.json,
+// SSE) and the scenario result (reports), and dumped table values before
+// they are written as artifacts. The captured map itself is never touched,
+// so {{captured.NAME}} still interpolates the raw value at runtime.
+// Scenario-level entries apply to every step, cleanup steps included.
+
+// redactMask replaces every redacted span.
+const redactMask = "[REDACTED]"
+
+// redactCapturedPrefix marks a redact: entry that names a captured variable.
+const redactCapturedPrefix = "captured."
+
+// redactor masks a fixed set of literals and pattern matches. A nil
+// redactor masks nothing.
+type redactor struct {
+	literals []string
+	patterns []*regexp.Regexp
+}
+
+// validateRedact checks a redact: list at parse time so a bad pattern fails
+// the suite load rather than silently leaking at runtime.
+func validateRedact(prefix string, entries []string) error {
+	for _, e := range entries {
+		if name, ok := strings.CutPrefix(e, redactCapturedPrefix); ok {
+			if name == "" {
+				return fmt.Errorf("%s: redact entry %q names no captured variable (want captured.NAME)", prefix, e)
+			}
+			continue
+		}
+		if e == "" {
+			return fmt.Errorf("%s: redact has an empty entry", prefix)
+		}
+		if _, err := regexp.Compile(e); err != nil {
+			return fmt.Errorf("%s: redact pattern %q: %w", prefix, e, err)
+		}
+	}
+	return nil
+}
+
+// newRedactor builds the redactor for one step from the scenario and step
+// redact: lists and the current captured map. A captured.NAME entry whose
+// variable has not been captured yet masks nothing. Returns nil when there
+// is nothing to redact.
+func newRedactor(scenario, step []string, captured map[string]any) *redactor {
+	var rd redactor
+	for _, e := range append(append([]string{}, scenario...), step...) {
+		if name, ok := strings.CutPrefix(e, redactCapturedPrefix); ok {
+			if v := capturedText(captured[name]); v != "" {
+				rd.literals = append(rd.literals, v)
+			}
+			continue
+		}
+		// Validated at parse time; a programmatic Scenario that skipped
+		// the parser loses only the bad pattern.
+		if re, err := regexp.Compile(e); err == nil {
+			rd.patterns = append(rd.patterns, re)
+		}
+	}
+	if len(rd.literals) == 0 && len(rd.patterns) == 0 {
+		return nil
+	}
+	// Longest first, so a value that contains another is masked whole.
+	sort.SliceStable(rd.literals, func(i, j int) bool { return len(rd.literals[i]) > len(rd.literals[j]) })
+	return &rd
+}
+
+// capturedText renders a captured value the way it appears in output:
+// strings as-is, everything else as JSON.
+func capturedText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// redact returns s with every literal and pattern match masked.
+func (rd *redactor) redact(s string) string {
+	if rd == nil || s == "" {
+		return s
+	}
+	for _, lit := range rd.literals {
+		s = strings.ReplaceAll(s, lit, redactMask)
+	}
+	for _, re := range rd.patterns {
+		s = redactPattern(re, s)
+	}
+	return s
+}
+
+// redactPattern masks the matches of re in s — the capture groups when re
+// has any (outermost group wins when groups nest), else the whole match.
+func redactPattern(re *regexp.Regexp, s string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range re.FindAllStringSubmatchIndex(s, -1) {
+		groups := [][]int{m[0:2]}
+		if re.NumSubexp() > 0 {
+			groups = nil
+			for g := 1; g <= re.NumSubexp(); g++ {
+				groups = append(groups, m[2*g:2*g+2])
+			}
+		}
+		for _, span := range groups {
+			start, end := span[0], span[1]
+			if start < last || start == end { // unmatched (-1), nested, or empty
+				continue
+			}
+			b.WriteString(s[last:start])
+			b.WriteString(redactMask)
+			last = end
+		}
+	}
+	if b.Len() == 0 {
+		return s
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// result masks the messages and captured output of a step result in place.
+func (rd *redactor) result(sr *StepResult) {
+	if rd == nil {
+		return
+	}
+	sr.Message = rd.redact(sr.Message)
+	sr.Output = rd.redact(sr.Output)
+	if len(sr.Details) == 0 {
+		return
+	}
+	// Details may share a backing array with the executor's output.
+	details := make([]DeviceResult, len(sr.Details))
+	for i, d := range sr.Details {
+		d.Message = rd.redact(d.Message)
+		d.Output = rd.redact(d.Output)
+		details[i] = d
+	}
+	sr.Details = details
+}
+
+// table masks the field values of a dumped table in place.
+func (rd *redactor) table(t map[string]map[string]string) {
+	if rd == nil {
+		return
+	}
+	for _, fields := range t {
+		for f, v := range fields {
+			fields[f] = rd.redact(v)
+		}
+	}
+}
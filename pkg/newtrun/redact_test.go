@@ -0,0 +1,143 @@
+package newtrun
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	captured := map[string]any{"session_key": "s3cr3t-token", "port": 8443}
+	tests := []struct {
+		name     string
+		scenario []string
+		step     []string
+		in       string
+		want     string
+	}{
+		{"captured literal", []string{"captured.session_key"}, nil,
+			"Authorization: Bearer s3cr3t-token", "Authorization: Bearer [REDACTED]"},
+		{"captured non-string", nil, []string{"captured.port"},
+			"listening on 8443", "listening on [REDACTED]"},
+		{"uncaptured name masks nothing", []string{"captured.missing"}, nil,
+			"nothing secret", "nothing secret"},
+		{"whole match", nil, []string{`sk-[a-z0-9]+`},
+			"key sk-abc123 and sk-def456", "key [REDACTED] and [REDACTED]"},
+		{"group only", []string{`password=(\S+)`}, nil,
+			"user=admin password=hunter2 ok", "user=admin password=[REDACTED] ok"},
+		{"scenario and step merge", []string{"captured.session_key"}, []string{`pin (\d+)`},
+			"s3cr3t-token pin 1234", "[REDACTED] pin [REDACTED]"},
+		{"no match", nil, []string{`password=(\S+)`},
+			"all clear", "all clear"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rd := newRedactor(tt.scenario, tt.step, captured)
+			if got := rd.redact(tt.in); got != tt.want {
+				t.Errorf("redact(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactor_NilMasksNothing(t *testing.T) {
+	rd := newRedactor(nil, []string{"captured.unset"}, map[string]any{})
+	if rd != nil {
+		t.Fatalf("newRedactor with nothing to mask = %+v, want nil", rd)
+	}
+	if got := rd.redact("s3cr3t"); got != "s3cr3t" {
+		t.Errorf("nil redact = %q", got)
+	}
+	sr := StepResult{Message: "s3cr3t"}
+	rd.result(&sr)
+	if sr.Message != "s3cr3t" {
+		t.Errorf("nil result changed message to %q", sr.Message)
+	}
+}
+
+func TestRedactor_ResultDoesNotAliasDetails(t *testing.T) {
+	details := []DeviceResult{{Device: "host1", Message: "token s3cr3t"}}
+	orig := StepResult{Message: "ran: s3cr3t", Details: details}
+
+	sr := orig
+	newRedactor(nil, []string{"s3cr3t"}, nil).result(&sr)
+
+	if sr.Message != "ran: [REDACTED]" || sr.Details[0].Message != "token [REDACTED]" {
+		t.Errorf("redacted result = %+v", sr)
+	}
+	if details[0].Message != "token s3cr3t" {
+		t.Errorf("executor details mutated: %q", details[0].Message)
+	}
+}
+
+func TestRedactor_Table(t *testing.T) {
+	table := map[string]map[string]string{
+		"admin": {"password": "hunter2", "role": "rw"},
+	}
+	newRedactor([]string{"hunter2"}, nil, nil).table(table)
+	if table["admin"]["password"] != redactMask || table["admin"]["role"] != "rw" {
+		t.Errorf("table = %v", table)
+	}
+}
+
+func TestParse_RedactValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"valid", `name: s
+redact: ["captured.key", 'password=(\S+)']
+steps:
+  - name: w
+    action: wait
+    duration: 1s
+    redact: ['token \w+']
+`, ""},
+		{"bad scenario pattern", `name: s
+redact: ["("]
+steps:
+  - name: w
+    action: wait
+    duration: 1s
+`, `redact pattern "("`},
+		{"bad step pattern", `name: s
+steps:
+  - name: w
+    action: wait
+    duration: 1s
+    redact: ["[a-"]
+`, `step 0 (w): redact pattern`},
+		{"empty captured name", `name: s
+steps:
+  - name: w
+    action: wait
+    duration: 1s
+    redact: ["captured."]
+`, "names no captured variable"},
+		{"bad cleanup pattern", `name: s
+steps:
+  - name: w
+    action: wait
+    duration: 1s
+cleanup:
+  - name: c
+    action: wait
+    duration: 1s
+    redact: ["*"]
+`, "cleanup step 0 (c): redact pattern"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseScenarioBytes([]byte(tt.yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package newtrun
+
+import (
+	"context"
+	"fmt"
+)
+
+// Action registry. Every step action — built-in or site-specific — is an
+// entry here: an executor the runner dispatches to and the parse-time
+// validation the parser applies. The built-ins are registered by this
+// file's init through the same registerAction path RegisterAction uses, so
+// there is one dispatch table and one way into it.
+//
+// Site-specific actions (a proprietary traffic generator, a lab-only
+// power-cycle) live in their own package whose init calls RegisterAction;
+// a build-tagged file in the server binary blank-imports that package (see
+// docs/newtrun/howto.md "Custom step actions"). Registration is init-time
+// only — the registry is read without locking once runs start.
+
+// StepExecutor executes a single step and returns its output. Result must be
+// non-nil; the runner fills in Name, Action, and Duration.
+type StepExecutor interface {
+	Execute(ctx context.Context, r *Runner, step *Step) *StepOutput
+}
+
+// StepOutput is the return value from every executor.
+type StepOutput struct {
+	Result *StepResult
+}
+
+// executors maps each registered StepAction to its executor.
+var executors = map[StepAction]StepExecutor{}
+
+// validActions is the set of all registered step actions.
+var validActions = map[StepAction]bool{}
+
+// builtinExecutors lists the actions this package implements. Their parse-time
+// rules live in stepValidations (parser.go).
+var builtinExecutors = map[StepAction]StepExecutor{
+	ActionProvision:               &provisionExecutor{},
+	ActionWait:                    &waitExecutor{},
+	ActionVerifyProvisioning:      &verifyProvisioningExecutor{},
+	ActionHostExec:                &hostExecExecutor{},
+	ActionNewtron:                 &newtronExecutor{},
+	ActionNewtronCLI:              &newtronCLIExecutor{},
+	ActionRunSuite:                &runSuiteExecutor{},
+	ActionSnapshot:                &snapshotExecutor{},
+	ActionVerifySnapshot:          &verifySnapshotExecutor{},
+	ActionWaitConverged:           &waitConvergedExecutor{},
+	ActionVerifyAnycast:           &verifyAnycastExecutor{},
+	ActionVerifyVLANMembership:    &verifyVLANMembershipExecutor{},
+	ActionVerifyEnvironment:       &verifyEnvironmentExecutor{},
+	ActionGenerateTraffic:         &generateTrafficExecutor{},
+	ActionVerifyRouteLeak:         &verifyRouteLeakExecutor{},
+	ActionVerifyPing:              &verifyPingExecutor{},
+	ActionVerifyPing6:             &verifyPing6Executor{},
+	ActionVerifyVXLANStats:        &verifyVXLANStatsExecutor{},
+	ActionVerifyEgressShaper:      &verifyEgressShaperExecutor{},
+	ActionVerifyBGP:               &verifyBGPExecutor{},
+	ActionVerifyLog:               &verifyLogExecutor{},
+	ActionVerifyDHCPLease:         &verifyDHCPLeaseExecutor{},
+	ActionVerifyChangeSet:         &verifyChangeSetExecutor{},
+	ActionVerifyLLDP:              &verifyLLDPExecutor{},
+	ActionVerifyInterfaceCounters: &verifyInterfaceCountersExecutor{},
+	ActionVerifyARPSuppression:    &verifyARPSuppressionExecutor{},
+	ActionVerifyEVPNType2:         &verifyEVPNType2Executor{},
+	ActionVerifyLAGDistribution:   &verifyLAGDistributionExecutor{},
+	ActionVerifyIGMPSnooping:      &verifyIGMPSnoopingExecutor{},
+	ActionVerifyNoFlaps:           &verifyNoFlapsExecutor{},
+}
+
+func init() {
+	for action, exec := range builtinExecutors {
+		if err := registerAction(action, exec, stepValidations[action]); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// RegisterAction adds a custom step action. exec runs the step; validate,
+// when non-nil, checks the step's fields when a scenario is parsed — return
+// an error naming the offending field (the parser prefixes the scenario and
+// step). Call it from an init function: registering after a run has started
+// is a data race. Registering an action that already exists, built-in or
+// custom, is an error.
+func RegisterAction(action StepAction, exec StepExecutor, validate func(*Step) error) error {
+	var v stepValidation
+	if validate != nil {
+		v.custom = func(prefix string, step *Step) error {
+			if err := validate(step); err != nil {
+				return fmt.Errorf("%s: %w", prefix, err)
+			}
+			return nil
+		}
+	}
+	return registerAction(action, exec, v)
+}
+
+// registerAction is the single writer of the registry.
+func registerAction(action StepAction, exec StepExecutor, v stepValidation) error {
+	if action == "" {
+		return fmt.Errorf("register action: empty action name")
+	}
+	if exec == nil {
+		return fmt.Errorf("register action %q: nil executor", action)
+	}
+	if _, dup := executors[action]; dup {
+		return fmt.Errorf("register action %q: already registered", action)
+	}
+	executors[action] = exec
+	validActions[action] = true
+	stepValidations[action] = v
+	return nil
+}
+
+// ResolveDevices returns the device names a step targets — its devices:
+// list, or every topology device for devices: all. For custom executors.
+func (r *Runner) ResolveDevices(step *Step) []string {
+	return r.resolveDevices(step)
+}
@@ -0,0 +1,79 @@
+package newtrun
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// trafficExecutor stands in for a site-specific action.
+type trafficExecutor struct{ ran []string }
+
+func (e *trafficExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	e.ran = append(e.ran, r.ResolveDevices(step)...)
+	return &StepOutput{Result: &StepResult{Status: StepStatusPassed, Message: "line rate"}}
+}
+
+// registerTestAction registers action for the duration of the test, so the
+// package-wide registry (and TestExecutorCountMatchesActionConstants) sees
+// only the built-ins afterwards.
+func registerTestAction(t *testing.T, action StepAction, exec StepExecutor, validate func(*Step) error) {
+	t.Helper()
+	if err := RegisterAction(action, exec, validate); err != nil {
+		t.Fatalf("RegisterAction: %v", err)
+	}
+	t.Cleanup(func() {
+		delete(executors, action)
+		delete(validActions, action)
+		delete(stepValidations, action)
+	})
+}
+
+func TestRegisterAction_DispatchAndValidate(t *testing.T) {
+	exec := &trafficExecutor{}
+	registerTestAction(t, "send-traffic", exec, func(step *Step) error {
+		if _, ok := step.Params["rate"]; !ok {
+			return errors.New("params.rate is required")
+		}
+		return nil
+	})
+
+	_, err := ParseScenarioBytes([]byte("name: s\nsteps:\n  - name: blast\n    action: send-traffic\n    devices: [host1]\n"))
+	if err == nil || !strings.Contains(err.Error(), "scenario s step 0 (blast): params.rate is required") {
+		t.Fatalf("parse err = %v, want prefixed validator error", err)
+	}
+
+	sc, err := ParseScenarioBytes([]byte("name: s\nsteps:\n  - name: blast\n    action: send-traffic\n    devices: [host1]\n    params: {rate: 10g}\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	// Resolving devices always reads the topology; against an unreachable
+	// server that read fails and the explicit devices: list stands.
+	r := &Runner{Client: client.New("http://127.0.0.1:0", "test-net")}
+	out := r.executeStep(context.Background(), &sc.Steps[0], 0, 1, RunOptions{})
+	if out.Result.Status != StepStatusPassed || out.Result.Name != "blast" || out.Result.Action != "send-traffic" {
+		t.Errorf("result = %+v, want PASSED blast/send-traffic", out.Result)
+	}
+	if len(exec.ran) != 1 || exec.ran[0] != "host1" {
+		t.Errorf("executor ran on %v, want [host1]", exec.ran)
+	}
+}
+
+func TestRegisterAction_Rejects(t *testing.T) {
+	if err := RegisterAction(ActionWait, &trafficExecutor{}, nil); err == nil || !strings.Contains(err.Error(), "already registered") {
+		t.Errorf("re-registering a built-in: err = %v", err)
+	}
+	if err := RegisterAction("", &trafficExecutor{}, nil); err == nil {
+		t.Error("empty action name accepted")
+	}
+	if err := RegisterAction("no-exec", nil, nil); err == nil {
+		t.Error("nil executor accepted")
+	}
+	registerTestAction(t, "twice", &trafficExecutor{}, nil)
+	if err := RegisterAction("twice", &trafficExecutor{}, nil); err == nil {
+		t.Error("duplicate custom action accepted")
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 )
 
 // StepStatus represents the outcome of a step or scenario.
@@ -23,6 +25,7 @@ const (
 // ScenarioResult holds the result of a single scenario execution.
 type ScenarioResult struct {
 	Name        string
+	Suite       string // originating suite; set when a report aggregates several suites
 	Network    string
 	Platform    string
 	Status      StepStatus
@@ -42,8 +45,20 @@ type StepResult struct {
 	Status    StepStatus
 	Duration  time.Duration
 	Message   string
+	Output    string // captured command output, redacted and capped (Step.MaxOutputBytes)
 	Details   []DeviceResult
 	Iteration int // 1-based iteration number (0 = no repeat)
+	Item      string // for_each item this result ran with ("" = not a loop)
+
+	// MaxDuration is the step's expect.max_duration (0 = unbounded) and
+	// Overage how far Duration ran past it (0 = within the bound).
+	MaxDuration time.Duration
+	Overage     time.Duration
+
+	// Attempts is how many times a step with retries ran, out of
+	// MaxAttempts (retries + 1); both 0 when the step sets no retries.
+	Attempts    int
+	MaxAttempts int
 
 	// TargetBinding records the suite-level target values that produced
 	// this step result for parameterized scenarios — keys are singular
@@ -51,6 +66,23 @@ type StepResult struct {
 	// embedded-target scenarios. Reporting uses this to distinguish
 	// step results that share a Name but ran against different targets.
 	TargetBinding map[string]string
+
+	// Artifacts lists files captured when the step failed (see
+	// RunOptions.OnVerifyFailure). Nil when nothing was captured.
+	Artifacts []string
+
+	// Observed is what an observe: step saw, device → state as plain JSON
+	// values (see golden.go). Nil when the step did not observe.
+	Observed map[string]any
+
+	// Outputs are the key/value pairs the step published for later steps'
+	// {{steps.NAME.output.KEY}} (see publish.go). Nil when it published none.
+	Outputs map[string]string
+
+	// Changes is what a preview run's write step would have delivered,
+	// device → CONFIG_DB changes ("" for a network-scoped call). Nil
+	// outside preview runs (see preview.go).
+	Changes map[string][]sonic.ConfigChange
 }
 
 // DeviceResult holds the result for a single device within a multi-device step.
@@ -58,11 +90,15 @@ type DeviceResult struct {
 	Device  string
 	Status  StepStatus
 	Message string
+	Output  string // the device's captured command output, as StepResult.Output
 }
 
 // ReportGenerator produces test reports from scenario results.
 type ReportGenerator struct {
 	Results []*ScenarioResult
+	// RerunOf, when set, marks the report as a partial rerun of that run's
+	// failures.
+	RerunOf string
 }
 
 // ResultsFromRunState converts an HTTP-fetched RunState into the
@@ -81,6 +117,7 @@ func ResultsFromRunState(state *RunState) []*ScenarioResult {
 	for _, sc := range state.Scenarios {
 		r := &ScenarioResult{
 			Name:       sc.Name,
+			Suite:      state.Suite,
 			Network:   state.Network,
 			Platform:   state.Platform,
 			Status:     StepStatus(sc.Status),
@@ -89,11 +126,18 @@ func ResultsFromRunState(state *RunState) []*ScenarioResult {
 		}
 		for _, st := range sc.Steps {
 			r.Steps = append(r.Steps, StepResult{
-				Name:     st.Name,
-				Action:   StepAction(st.Action),
-				Status:   StepStatus(st.Status),
-				Duration: parseReportDuration(st.Duration),
-				Message:  st.Message,
+				Name:        st.Name,
+				Action:      StepAction(st.Action),
+				Status:      StepStatus(st.Status),
+				Duration:    parseReportDuration(st.Duration),
+				Message:     st.Message,
+				Output:      st.Output,
+				Item:        st.Item,
+				Artifacts:   st.Artifacts,
+				MaxDuration: parseReportDuration(st.MaxDuration),
+				Overage:     parseReportDuration(st.Overage),
+				Attempts:    st.Attempts,
+				MaxAttempts: st.MaxAttempts,
 			})
 		}
 		results = append(results, r)
@@ -131,16 +175,43 @@ func (g *ReportGenerator) WriteMarkdown(path string) error {
 	defer f.Close()
 
 	fmt.Fprintf(f, "# newtrun Report — %s\n\n", time.Now().Format(DateTimeFormat))
+	if g.RerunOf != "" {
+		fmt.Fprintf(f, "Partial rerun of run %s: its failed and errored scenarios, with the scenarios they require.\n\n", g.RerunOf)
+	}
 
-	// Summary table
-	fmt.Fprintln(f, "| Scenario | Topology | Platform | Result | Duration | Note |")
-	fmt.Fprintln(f, "|----------|----------|----------|--------|----------|------|")
+	// Summary table. A multi-suite report leads with the originating suite
+	// so same-named scenarios from different suites stay distinguishable.
+	multi := g.multiSuite()
+	if multi {
+		fmt.Fprintln(f, "| Suite | Scenario | Topology | Platform | Result | Duration | Note |")
+		fmt.Fprintln(f, "|-------|----------|----------|----------|--------|----------|------|")
+	} else {
+		fmt.Fprintln(f, "| Scenario | Topology | Platform | Result | Duration | Note |")
+		fmt.Fprintln(f, "|----------|----------|----------|--------|----------|------|")
+	}
 	for _, r := range g.Results {
+		if multi {
+			fmt.Fprintf(f, "| %s ", r.Suite)
+		}
 		fmt.Fprintf(f, "| %s | %s | %s | %s | %s | %s |\n",
 			r.Name, r.Network, r.Platform, r.Status,
 			r.Duration.Round(time.Second), scenarioNote(r))
 	}
 
+	// Performance regressions lead the detail: a step over its
+	// expect.max_duration also appears under Failures, but the overage is
+	// the news.
+	if regs := g.regressions(); len(regs) > 0 {
+		fmt.Fprintf(f, "\n## Performance Regressions\n\n")
+		fmt.Fprintln(f, "| Scenario | Step | Action | Duration | Max | Over |")
+		fmt.Fprintln(f, "|----------|------|--------|----------|-----|------|")
+		for _, reg := range regs {
+			fmt.Fprintf(f, "| %s | %s | %s | %s | %s | +%s |\n",
+				reg.Scenario, reg.Step, reg.Action, formatReportDuration(reg.Duration),
+				formatReportDuration(reg.MaxDuration), formatReportDuration(reg.Overage))
+		}
+	}
+
 	// Failures section
 	hasFailures := false
 	for _, r := range g.Results {
@@ -150,13 +221,19 @@ func (g *ReportGenerator) WriteMarkdown(path string) error {
 					fmt.Fprintf(f, "\n## Failures\n\n")
 					hasFailures = true
 				}
-				fmt.Fprintf(f, "### %s\n", r.Name)
+				fmt.Fprintf(f, "### %s\n", g.scenarioLabel(r))
 				fmt.Fprintf(f, "Step %s (%s): %s\n\n", stepDisplayName(s), s.Action, s.Message)
+				if note := AttemptNote(s.Status, s.Attempts, s.MaxAttempts); note != "" {
+					fmt.Fprintf(f, "  %s\n", note)
+				}
 				for _, d := range s.Details {
 					if d.Status == StepStatusFailed {
 						fmt.Fprintf(f, "  %s: %s\n", d.Device, d.Message)
 					}
 				}
+				for _, a := range s.Artifacts {
+					fmt.Fprintf(f, "  artifact: %s\n", a)
+				}
 			}
 		}
 	}
@@ -164,6 +241,39 @@ func (g *ReportGenerator) WriteMarkdown(path string) error {
 	return nil
 }
 
+// regression is a step that ran past its expect.max_duration.
+type regression struct {
+	Scenario    string
+	Step        string
+	Action      StepAction
+	Duration    time.Duration
+	MaxDuration time.Duration
+	Overage     time.Duration
+}
+
+// regressions returns every step over its expect.max_duration, largest
+// overage first.
+func (g *ReportGenerator) regressions() []regression {
+	var out []regression
+	for _, r := range g.Results {
+		for _, s := range r.Steps {
+			if s.Overage <= 0 {
+				continue
+			}
+			out = append(out, regression{
+				Scenario:    g.scenarioLabel(r),
+				Step:        stepDisplayName(s),
+				Action:      s.Action,
+				Duration:    s.Duration,
+				MaxDuration: s.MaxDuration,
+				Overage:     s.Overage,
+			})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Overage > out[j].Overage })
+	return out
+}
+
 // WriteJUnit writes a JUnit XML report for CI integration.
 func (g *ReportGenerator) WriteJUnit(path string) error {
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
@@ -173,8 +283,9 @@ func (g *ReportGenerator) WriteJUnit(path string) error {
 	suites := junitTestSuites{}
 
 	for _, r := range g.Results {
+		label := g.scenarioLabel(r)
 		suite := junitTestSuite{
-			Name: r.Name,
+			Name: label,
 			Time: r.Duration.Seconds(),
 		}
 
@@ -184,7 +295,7 @@ func (g *ReportGenerator) WriteJUnit(path string) error {
 			suite.Skipped = 1
 			suite.Cases = append(suite.Cases, junitTestCase{
 				Name:      r.Name,
-				ClassName: r.Name,
+				ClassName: label,
 				Time:      0,
 				Skipped:   &junitSkipped{Message: r.SkipReason},
 			})
@@ -196,8 +307,9 @@ func (g *ReportGenerator) WriteJUnit(path string) error {
 			suite.Tests++
 			tc := junitTestCase{
 				Name:      stepDisplayName(s),
-				ClassName: r.Name,
+				ClassName: label,
 				Time:      s.Duration.Seconds(),
+				SystemOut: junitSystemOut(s),
 			}
 
 			switch s.Status {
@@ -234,6 +346,32 @@ func (g *ReportGenerator) WriteJUnit(path string) error {
 	return os.WriteFile(path, append([]byte(xml.Header), data...), 0o644)
 }
 
+// multiSuite reports whether the results span more than one suite — the
+// aggregated report `newtrun start <suite1> <suite2> ...` produces.
+func (g *ReportGenerator) multiSuite() bool {
+	first := ""
+	for _, r := range g.Results {
+		if r.Suite == "" {
+			continue
+		}
+		if first == "" {
+			first = r.Suite
+		} else if r.Suite != first {
+			return true
+		}
+	}
+	return false
+}
+
+// scenarioLabel names a scenario in the report: "<suite>/<scenario>" in a
+// multi-suite report, the bare scenario name otherwise.
+func (g *ReportGenerator) scenarioLabel(r *ScenarioResult) string {
+	if r.Suite != "" && g.multiSuite() {
+		return r.Suite + "/" + r.Name
+	}
+	return r.Name
+}
+
 // scenarioNote composes the markdown summary row's Note column from
 // the result's skip reason, repeat-iteration outcome, and parameterized
 // target count. Returns the skip reason verbatim when the scenario was
@@ -286,9 +424,10 @@ func formatTargetBinding(binding map[string]string) string {
 // stepDisplayName composes the human-facing identifier for a step
 // result: the step's name, prefixed by the repeat iteration tag when
 // scenario.Repeat > 1 and by the target binding when the scenario is
-// parameterized. Both prefixes appear together when both apply.
+// parameterized, and suffixed by the for_each item when the step loops.
+// Every tag that applies appears.
 func stepDisplayName(s StepResult) string {
-	parts := make([]string, 0, 3)
+	parts := make([]string, 0, 4)
 	if s.Iteration > 0 {
 		parts = append(parts, fmt.Sprintf("[iter %d]", s.Iteration))
 	}
@@ -296,9 +435,43 @@ func stepDisplayName(s StepResult) string {
 		parts = append(parts, b)
 	}
 	parts = append(parts, s.Name)
+	if s.Item != "" {
+		parts = append(parts, fmt.Sprintf("[item %s]", s.Item))
+	}
 	return strings.Join(parts, " ")
 }
 
+// AttemptNote describes a retried step's outcome, e.g. "passed on attempt
+// 2/3"; "" when the step set no retries (maxAttempts 0).
+func AttemptNote(status StepStatus, attempts, maxAttempts int) string {
+	if maxAttempts == 0 {
+		return ""
+	}
+	verb := "passed"
+	if status != StepStatusPassed {
+		verb = statusVerb(status)
+	}
+	return fmt.Sprintf("%s on attempt %d/%d", verb, attempts, maxAttempts)
+}
+
+// junitSystemOut is a step's <system-out>: its AttemptNote, then its
+// captured output and each device's, the latter under the device's name.
+func junitSystemOut(s StepResult) string {
+	var parts []string
+	if note := AttemptNote(s.Status, s.Attempts, s.MaxAttempts); note != "" {
+		parts = append(parts, note)
+	}
+	if s.Output != "" {
+		parts = append(parts, strings.TrimRight(s.Output, "\n"))
+	}
+	for _, d := range s.Details {
+		if d.Output != "" {
+			parts = append(parts, d.Device+":\n"+strings.TrimRight(d.Output, "\n"))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
 // statusVerb returns a past-tense verb for a status, used in skip reasons.
 func statusVerb(s StepStatus) string {
 	switch s {
@@ -337,6 +510,7 @@ type junitTestCase struct {
 	Failure   *junitFailure `xml:"failure,omitempty"`
 	Skipped   *junitSkipped `xml:"skipped,omitempty"`
 	Error     *junitError   `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"` // junitSystemOut
 }
 
 type junitFailure struct {
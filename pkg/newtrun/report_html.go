@@ -0,0 +1,281 @@
+package newtrun
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// HTML report. The summary table of the markdown report plus a timeline —
+// an inline-SVG Gantt chart of every scenario and its steps — and the
+// slowest steps, so a long suite's bottlenecks are visible at a glance.
+// Steps over their expect.max_duration are listed ahead of the timeline.
+//
+// Results carry durations, not timestamps, so the timeline is derived:
+// scenarios are laid end to end in run order, and each scenario's steps end
+// to end within it. That is exactly how the runner executes them today; a
+// step that overruns its scenario's bar (repeat_parallel passes, whose
+// durations overlap) is clipped at the scenario's end.
+
+const (
+	timelineLabelWidth = 280 // px reserved for row labels
+	timelineChartWidth = 680 // px for the bars
+	timelineRowHeight  = 18
+	timelineAxisHeight = 24
+	slowestStepsShown  = 10
+)
+
+// timelineRow is one bar of the timeline: a scenario, or a step within one.
+type timelineRow struct {
+	Label    string
+	Tooltip  string
+	Scenario bool
+	Status   StepStatus
+	Start    time.Duration
+	Duration time.Duration
+	X, W, Y  int
+}
+
+// timelineTick is one labeled time-axis mark.
+type timelineTick struct {
+	X     int
+	Label string
+}
+
+// timeline is the laid-out chart the template renders.
+type timeline struct {
+	Rows   []timelineRow
+	Ticks  []timelineTick
+	Total  time.Duration
+	Width  int
+	Height int
+}
+
+// buildTimeline lays out results as timeline rows: each scenario followed by
+// its steps, positioned on one shared time axis.
+func (g *ReportGenerator) buildTimeline() timeline {
+	var rows []timelineRow
+	var clock time.Duration
+	for _, r := range g.Results {
+		label := g.scenarioLabel(r)
+		rows = append(rows, timelineRow{
+			Label:    label,
+			Tooltip:  fmt.Sprintf("%s — %s (%s)", label, formatReportDuration(r.Duration), r.Status),
+			Scenario: true,
+			Status:   r.Status,
+			Start:    clock,
+			Duration: r.Duration,
+		})
+		end := clock + r.Duration
+		stepClock := clock
+		for _, s := range r.Steps {
+			d := s.Duration
+			if stepClock+d > end {
+				d = max(end-stepClock, 0)
+			}
+			name := stepDisplayName(s)
+			rows = append(rows, timelineRow{
+				Label:    name,
+				Tooltip:  fmt.Sprintf("%s / %s (%s) — %s (%s)", label, name, s.Action, formatReportDuration(s.Duration), s.Status),
+				Status:   s.Status,
+				Start:    stepClock,
+				Duration: d,
+			})
+			stepClock += d
+		}
+		clock = end
+	}
+
+	tl := timeline{Rows: rows, Total: clock, Width: timelineLabelWidth + timelineChartWidth}
+	scale := func(d time.Duration) int {
+		if clock <= 0 {
+			return 0
+		}
+		return int(float64(d) / float64(clock) * timelineChartWidth)
+	}
+	for i := range tl.Rows {
+		row := &tl.Rows[i]
+		row.X = timelineLabelWidth + scale(row.Start)
+		row.W = max(scale(row.Duration), 1) // a zero-length step still shows
+		row.Y = timelineAxisHeight + i*timelineRowHeight
+	}
+	for i := 0; i <= 4; i++ {
+		at := clock * time.Duration(i) / 4
+		tl.Ticks = append(tl.Ticks, timelineTick{X: timelineLabelWidth + scale(at), Label: formatReportDuration(at)})
+	}
+	tl.Height = timelineAxisHeight + len(tl.Rows)*timelineRowHeight
+	return tl
+}
+
+// slowStep is one row of the slowest-steps table.
+type slowStep struct {
+	Scenario string
+	Step     string
+	Action   StepAction
+	Status   StepStatus
+	Duration string
+	Share    string // of the whole run
+}
+
+// slowestSteps returns the n longest steps across every scenario, longest
+// first, with each step's share of total run time.
+func (g *ReportGenerator) slowestSteps(total time.Duration, n int) []slowStep {
+	type ranked struct {
+		scenario string
+		step     StepResult
+	}
+	var all []ranked
+	for _, r := range g.Results {
+		for _, s := range r.Steps {
+			all = append(all, ranked{g.scenarioLabel(r), s})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].step.Duration > all[j].step.Duration })
+	if len(all) > n {
+		all = all[:n]
+	}
+	out := make([]slowStep, 0, len(all))
+	for _, a := range all {
+		share := "-"
+		if total > 0 {
+			share = fmt.Sprintf("%.1f%%", float64(a.step.Duration)/float64(total)*100)
+		}
+		out = append(out, slowStep{
+			Scenario: a.scenario,
+			Step:     stepDisplayName(a.step),
+			Action:   a.step.Action,
+			Status:   a.step.Status,
+			Duration: formatReportDuration(a.step.Duration),
+			Share:    share,
+		})
+	}
+	return out
+}
+
+// formatReportDuration rounds d for display: whole seconds from a second
+// up, milliseconds below.
+func formatReportDuration(d time.Duration) string {
+	if d >= time.Second {
+		return d.Round(time.Second).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// htmlReportData is the template's view of a report.
+type htmlReportData struct {
+	Generated   string
+	RerunOf     string
+	MultiSuite  bool
+	Results     []*ScenarioResult
+	Timeline    timeline
+	Regressions []regression
+	Slowest     []slowStep
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"note":     scenarioNote,
+	"duration": formatReportDuration,
+	"color":    statusColor,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>newtrun Report — {{.Generated}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+th { background: #f4f4f4; }
+svg text { font-size: 11px; }
+</style>
+</head>
+<body>
+<h1>newtrun Report — {{.Generated}}</h1>
+{{if .RerunOf}}<p>Partial rerun of run {{.RerunOf}}: its failed and errored scenarios, with the scenarios they require.</p>
+{{end}}
+<h2>Summary</h2>
+<table>
+<tr>{{if .MultiSuite}}<th>Suite</th>{{end}}<th>Scenario</th><th>Topology</th><th>Platform</th><th>Result</th><th>Duration</th><th>Note</th></tr>
+{{- range .Results}}
+<tr>{{if $.MultiSuite}}<td>{{.Suite}}</td>{{end}}<td>{{.Name}}</td><td>{{.Network}}</td><td>{{.Platform}}</td><td style="color:{{color .Status}}">{{.Status}}</td><td>{{duration .Duration}}</td><td>{{note .}}</td></tr>
+{{- end}}
+</table>
+{{if .Regressions}}
+<h2>Performance regressions</h2>
+<p>Steps that ran past their <code>expect.max_duration</code>.</p>
+<table>
+<tr><th>Scenario</th><th>Step</th><th>Action</th><th>Duration</th><th>Max</th><th>Over</th></tr>
+{{- range .Regressions}}
+<tr><td>{{.Scenario}}</td><td>{{.Step}}</td><td>{{.Action}}</td><td>{{duration .Duration}}</td><td>{{duration .MaxDuration}}</td><td style="color:#c62828">+{{duration .Overage}}</td></tr>
+{{- end}}
+</table>
+{{end}}
+<h2>Timeline</h2>
+<p>Total {{duration .Timeline.Total}}. Bars are laid end to end from recorded durations; hover for details.</p>
+<svg xmlns="http://www.w3.org/2000/svg" width="{{.Timeline.Width}}" height="{{.Timeline.Height}}" role="img" aria-label="suite timeline">
+{{- range .Timeline.Ticks}}
+<line x1="{{.X}}" y1="14" x2="{{.X}}" y2="{{$.Timeline.Height}}" stroke="#e0e0e0"/>
+<text x="{{.X}}" y="11" text-anchor="middle" fill="#666">{{.Label}}</text>
+{{- end}}
+{{- range .Timeline.Rows}}
+<g>
+<title>{{.Tooltip}}</title>
+<text x="{{if .Scenario}}4{{else}}20{{end}}" y="{{.Y}}" dy="13"{{if .Scenario}} font-weight="bold"{{end}}>{{.Label}}</text>
+<rect x="{{.X}}" y="{{.Y}}" width="{{.W}}" height="14" fill="{{color .Status}}" fill-opacity="{{if .Scenario}}0.9{{else}}0.55{{end}}"/>
+</g>
+{{- end}}
+</svg>
+
+{{if .Slowest}}<h2>Slowest steps</h2>
+<table>
+<tr><th>Scenario</th><th>Step</th><th>Action</th><th>Result</th><th>Duration</th><th>Share of run</th></tr>
+{{- range .Slowest}}
+<tr><td>{{.Scenario}}</td><td>{{.Step}}</td><td>{{.Action}}</td><td style="color:{{color .Status}}">{{.Status}}</td><td>{{.Duration}}</td><td>{{.Share}}</td></tr>
+{{- end}}
+</table>
+{{end}}
+</body>
+</html>
+`))
+
+// statusColor is the timeline and table color for a status.
+func statusColor(s StepStatus) string {
+	switch s {
+	case StepStatusPassed:
+		return "#2e7d32"
+	case StepStatusFailed:
+		return "#c62828"
+	case StepStatusError:
+		return "#ef6c00"
+	default:
+		return "#9e9e9e"
+	}
+}
+
+// WriteHTML writes a self-contained HTML report — summary, performance
+// regressions, timeline, and slowest steps — to the given path.
+func (g *ReportGenerator) WriteHTML(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tl := g.buildTimeline()
+	return htmlReportTemplate.Execute(f, htmlReportData{
+		Generated:   time.Now().Format(DateTimeFormat),
+		RerunOf:     g.RerunOf,
+		MultiSuite:  g.multiSuite(),
+		Results:     g.Results,
+		Timeline:    tl,
+		Regressions: g.regressions(),
+		Slowest:     g.slowestSteps(tl.Total, slowestStepsShown),
+	})
+}
@@ -0,0 +1,85 @@
+package newtrun
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func timelineResults() *ReportGenerator {
+	return &ReportGenerator{Results: []*ScenarioResult{
+		{Name: "boot", Status: StepStatusPassed, Duration: 10 * time.Second, Steps: []StepResult{
+			{Name: "wait-ssh", Action: ActionWait, Status: StepStatusPassed, Duration: 8 * time.Second},
+			{Name: "check", Action: ActionVerifyProvisioning, Status: StepStatusPassed, Duration: 2 * time.Second},
+		}},
+		{Name: "verify <bgp>", Status: StepStatusFailed, Duration: 30 * time.Second, Steps: []StepResult{
+			{Name: "converge", Action: ActionWaitConverged, Status: StepStatusFailed, Duration: 40 * time.Second},
+		}},
+	}}
+}
+
+// TestBuildTimeline pins the derived layout: scenarios end to end in run
+// order, steps end to end within their scenario, a step that overruns its
+// scenario clipped at the scenario's end, and bars scaled to one axis.
+func TestBuildTimeline(t *testing.T) {
+	tl := timelineResults().buildTimeline()
+	if tl.Total != 40*time.Second {
+		t.Fatalf("total = %s, want 40s", tl.Total)
+	}
+	want := []struct {
+		label    string
+		start    time.Duration
+		duration time.Duration
+	}{
+		{"boot", 0, 10 * time.Second},
+		{"wait-ssh", 0, 8 * time.Second},
+		{"check", 8 * time.Second, 2 * time.Second},
+		{"verify <bgp>", 10 * time.Second, 30 * time.Second},
+		{"converge", 10 * time.Second, 30 * time.Second}, // 40s clipped to the scenario
+	}
+	if len(tl.Rows) != len(want) {
+		t.Fatalf("rows = %d, want %d", len(tl.Rows), len(want))
+	}
+	for i, w := range want {
+		row := tl.Rows[i]
+		if row.Label != w.label || row.Start != w.start || row.Duration != w.duration {
+			t.Errorf("row %d = %s @%s +%s, want %s @%s +%s", i, row.Label, row.Start, row.Duration, w.label, w.start, w.duration)
+		}
+	}
+	if got := tl.Rows[3].X - timelineLabelWidth; got != timelineChartWidth/4 {
+		t.Errorf("verify bar x offset = %d, want %d (10s of 40s)", got, timelineChartWidth/4)
+	}
+	if last := tl.Ticks[len(tl.Ticks)-1]; last.Label != "40s" || last.X != timelineLabelWidth+timelineChartWidth {
+		t.Errorf("last tick = %+v, want 40s at chart end", last)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.html")
+	if err := timelineResults().WriteHTML(path); err != nil {
+		t.Fatalf("WriteHTML: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(data)
+	for _, want := range []string{
+		"<svg", "<h2>Timeline</h2>", "<h2>Slowest steps</h2>",
+		"verify &lt;bgp&gt;", // names are escaped
+		"<td>converge</td><td>wait-converged</td>",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("report missing %q", want)
+		}
+	}
+	if strings.Contains(html, "verify <bgp>") {
+		t.Error("scenario name rendered unescaped")
+	}
+	// Slowest first: converge (40s) leads the table.
+	if i, j := strings.Index(html, "<td>converge</td>"), strings.Index(html, "<td>wait-ssh</td>"); i < 0 || j < 0 || i > j {
+		t.Errorf("slowest steps not ordered longest first")
+	}
+}
@@ -0,0 +1,137 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// JSON report. The full result tree — scenarios, their steps, each step's
+// per-device details — for CI dashboards and other programs, where the
+// markdown and HTML reports are for people and JUnit flattens steps into
+// test cases. The wire types below are the contract, not ScenarioResult
+// itself: renaming a Go field must not rename a JSON one. Durations are
+// seconds, as in JUnit.
+//
+// JSONReportSchemaVersion is bumped on any change a reader could trip over
+// (a removed or renamed field, a changed meaning); adding a field is not one.
+
+// JSONReportSchemaVersion is the schema_version WriteJSON stamps.
+const JSONReportSchemaVersion = 1
+
+// JSONReport is the document WriteJSON writes.
+type JSONReport struct {
+	SchemaVersion int            `json:"schema_version"`
+	RerunOf       string         `json:"rerun_of,omitempty"` // run whose failures this run re-executed
+	Scenarios     []JSONScenario `json:"scenarios"`
+}
+
+// JSONScenario is one ScenarioResult.
+type JSONScenario struct {
+	Name            string     `json:"name"`
+	Suite           string     `json:"suite,omitempty"`
+	Network         string     `json:"network,omitempty"`
+	Platform        string     `json:"platform,omitempty"`
+	Status          StepStatus `json:"status"`
+	DurationSeconds float64    `json:"duration_seconds"`
+	SkipReason      string     `json:"skip_reason,omitempty"`
+	DeployError     string     `json:"deploy_error,omitempty"`
+	Repeat          int        `json:"repeat,omitempty"`           // iterations requested (0 = no repeat)
+	FailedIteration int        `json:"failed_iteration,omitempty"` // first iteration that failed (0 = none)
+	Steps           []JSONStep `json:"steps"`
+}
+
+// JSONStep is one StepResult.
+type JSONStep struct {
+	Name               string                          `json:"name"`
+	Action             StepAction                      `json:"action"`
+	Status             StepStatus                      `json:"status"`
+	DurationSeconds    float64                         `json:"duration_seconds"`
+	Message            string                          `json:"message,omitempty"`
+	Output             string                          `json:"output,omitempty"`    // captured command output
+	Iteration          int                             `json:"iteration,omitempty"` // 1-based repeat iteration (0 = no repeat)
+	Item               string                          `json:"item,omitempty"`      // for_each item
+	TargetBinding      map[string]string               `json:"target_binding,omitempty"`
+	MaxDurationSeconds float64                         `json:"max_duration_seconds,omitempty"` // expect.max_duration
+	OverageSeconds     float64                         `json:"overage_seconds,omitempty"`      // time past max_duration
+	Attempts           int                             `json:"attempts,omitempty"`
+	MaxAttempts        int                             `json:"max_attempts,omitempty"`
+	Artifacts          []string                        `json:"artifacts,omitempty"`
+	Observed           map[string]any                  `json:"observed,omitempty"` // observe: steps, device → state
+	Changes            map[string][]sonic.ConfigChange `json:"changes,omitempty"`  // preview runs, device → undelivered changes
+	Devices            []JSONDevice                    `json:"devices,omitempty"`
+}
+
+// JSONDevice is one DeviceResult.
+type JSONDevice struct {
+	Device  string     `json:"device"`
+	Status  StepStatus `json:"status"`
+	Message string     `json:"message,omitempty"`
+	Output  string     `json:"output,omitempty"`
+}
+
+// WriteJSON writes the JSON report (see JSONReport).
+func (g *ReportGenerator) WriteJSON(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(g.jsonReport(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// jsonReport converts the results to their wire form.
+func (g *ReportGenerator) jsonReport() JSONReport {
+	report := JSONReport{
+		SchemaVersion: JSONReportSchemaVersion,
+		RerunOf:       g.RerunOf,
+		Scenarios:     make([]JSONScenario, 0, len(g.Results)),
+	}
+	for _, r := range g.Results {
+		sc := JSONScenario{
+			Name:            r.Name,
+			Suite:           r.Suite,
+			Network:         r.Network,
+			Platform:        r.Platform,
+			Status:          r.Status,
+			DurationSeconds: r.Duration.Seconds(),
+			SkipReason:      r.SkipReason,
+			Repeat:          r.Repeat,
+			FailedIteration: r.FailedIteration,
+			Steps:           make([]JSONStep, 0, len(r.Steps)),
+		}
+		if r.DeployError != nil {
+			sc.DeployError = r.DeployError.Error()
+		}
+		for _, s := range r.Steps {
+			step := JSONStep{
+				Name:               s.Name,
+				Action:             s.Action,
+				Status:             s.Status,
+				DurationSeconds:    s.Duration.Seconds(),
+				Message:            s.Message,
+				Output:             s.Output,
+				Iteration:          s.Iteration,
+				Item:               s.Item,
+				TargetBinding:      s.TargetBinding,
+				MaxDurationSeconds: s.MaxDuration.Seconds(),
+				OverageSeconds:     s.Overage.Seconds(),
+				Attempts:           s.Attempts,
+				MaxAttempts:        s.MaxAttempts,
+				Artifacts:          s.Artifacts,
+				Observed:           s.Observed,
+				Changes:            s.Changes,
+			}
+			for _, d := range s.Details {
+				step.Devices = append(step.Devices, JSONDevice{Device: d.Device, Status: d.Status, Message: d.Message, Output: d.Output})
+			}
+			sc.Steps = append(sc.Steps, step)
+		}
+		report.Scenarios = append(report.Scenarios, sc)
+	}
+	return report
+}
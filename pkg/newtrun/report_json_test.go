@@ -0,0 +1,87 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestWriteJSON_RoundTrip: the JSON report unmarshals back into its wire
+// types with the result tree intact — a repeat scenario's FailedIteration,
+// per-iteration steps, device details, skip and deploy reasons.
+func TestWriteJSON_RoundTrip(t *testing.T) {
+	gen := &ReportGenerator{
+		RerunOf: "20261015-101500-abcd",
+		Results: []*ScenarioResult{
+			{
+				Name: "bgp-soak", Network: "2node", Platform: "vs", Status: StepStatusFailed,
+				Duration: 90 * time.Second, Repeat: 3, FailedIteration: 2,
+				Steps: []StepResult{
+					{Name: "verify-bgp", Action: ActionVerifyBGP, Status: StepStatusPassed, Duration: 2 * time.Second, Iteration: 1},
+					{
+						Name: "verify-bgp", Action: ActionVerifyBGP, Status: StepStatusFailed, Duration: 1500 * time.Millisecond,
+						Message: "1/2 devices failed", Iteration: 2, Attempts: 3, MaxAttempts: 3,
+						TargetBinding: map[string]string{"device": "leaf1"},
+						Details: []DeviceResult{
+							{Device: "leaf1", Status: StepStatusPassed, Message: "2 peers established"},
+							{Device: "leaf2", Status: StepStatusFailed, Message: "peer 10.0.0.1 Active"},
+						},
+					},
+				},
+			},
+			{Name: "evpn", Status: StepStatusSkipped, SkipReason: "requires 'bgp-soak' which failed"},
+			{Name: "deploy", Status: StepStatusError, DeployError: errors.New("newtlab: deploy failed")},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := gen.WriteJSON(path); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got JSONReport
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, data)
+	}
+
+	if got.SchemaVersion != JSONReportSchemaVersion || got.RerunOf != gen.RerunOf {
+		t.Errorf("schema_version=%d rerun_of=%q", got.SchemaVersion, got.RerunOf)
+	}
+	if len(got.Scenarios) != 3 {
+		t.Fatalf("got %d scenarios, want 3", len(got.Scenarios))
+	}
+	soak := got.Scenarios[0]
+	if soak.Repeat != 3 || soak.FailedIteration != 2 || soak.Status != StepStatusFailed || soak.DurationSeconds != 90 {
+		t.Errorf("bgp-soak = %+v, want repeat 3, failed_iteration 2, FAIL, 90s", soak)
+	}
+	if len(soak.Steps) != 2 {
+		t.Fatalf("bgp-soak has %d steps, want 2", len(soak.Steps))
+	}
+	failed := soak.Steps[1]
+	if failed.Iteration != 2 || failed.DurationSeconds != 1.5 || failed.Attempts != 3 || failed.MaxAttempts != 3 {
+		t.Errorf("failed step = %+v", failed)
+	}
+	if !reflect.DeepEqual(failed.TargetBinding, map[string]string{"device": "leaf1"}) {
+		t.Errorf("target_binding = %v", failed.TargetBinding)
+	}
+	wantDevices := []JSONDevice{
+		{Device: "leaf1", Status: StepStatusPassed, Message: "2 peers established"},
+		{Device: "leaf2", Status: StepStatusFailed, Message: "peer 10.0.0.1 Active"},
+	}
+	if !reflect.DeepEqual(failed.Devices, wantDevices) {
+		t.Errorf("devices = %+v", failed.Devices)
+	}
+	if sc := got.Scenarios[1]; sc.SkipReason != "requires 'bgp-soak' which failed" || len(sc.Steps) != 0 {
+		t.Errorf("skipped scenario = %+v", sc)
+	}
+	if sc := got.Scenarios[2]; sc.DeployError != "newtlab: deploy failed" {
+		t.Errorf("deploy_error = %q", sc.DeployError)
+	}
+}
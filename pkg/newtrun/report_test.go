@@ -1,6 +1,9 @@
 package newtrun
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -97,3 +100,99 @@ func TestParseReportDuration_Sentinels(t *testing.T) {
 		}
 	}
 }
+
+// TestReportGenerator_MultiSuiteLabels locks in the aggregated-report
+// shape: scenarios from several suites are tagged "<suite>/<scenario>" so
+// same-named scenarios stay distinct, while a single-suite report keeps
+// bare names.
+func TestReportGenerator_MultiSuiteLabels(t *testing.T) {
+	single := &ReportGenerator{Results: []*ScenarioResult{
+		{Name: "boot", Suite: "a"},
+		{Name: "verify", Suite: "a"},
+	}}
+	if single.multiSuite() {
+		t.Error("single-suite results reported as multi-suite")
+	}
+	if got := single.scenarioLabel(single.Results[0]); got != "boot" {
+		t.Errorf("single-suite label: got %q, want %q", got, "boot")
+	}
+
+	multi := &ReportGenerator{Results: []*ScenarioResult{
+		{Name: "boot", Suite: "a"},
+		{Name: "boot", Suite: "b"},
+	}}
+	if !multi.multiSuite() {
+		t.Error("multi-suite results not detected")
+	}
+	if got := multi.scenarioLabel(multi.Results[1]); got != "b/boot" {
+		t.Errorf("multi-suite label: got %q, want %q", got, "b/boot")
+	}
+}
+
+// TestReport_PerformanceRegressions: a step over its expect.max_duration
+// survives the state.json round-trip and leads the markdown report's detail.
+func TestReport_PerformanceRegressions(t *testing.T) {
+	results := ResultsFromRunState(&RunState{Scenarios: []ScenarioState{{
+		Name: "provision", Status: string(StepStatusFailed), Duration: "40s",
+		Steps: []StepState{
+			{Name: "setup", Action: "newtron", Status: string(StepStatusFailed), Duration: "30s",
+				Message: "took 30s, over max_duration 5s by 25s", MaxDuration: "5s", Overage: "25s"},
+			{Name: "apply", Action: "newtron", Status: string(StepStatusPassed), Duration: "2s", MaxDuration: "5s"},
+		},
+	}}})
+	step := results[0].Steps[0]
+	if step.MaxDuration != 5*time.Second || step.Overage != 25*time.Second {
+		t.Fatalf("round-trip: max=%v overage=%v, want 5s/25s", step.MaxDuration, step.Overage)
+	}
+
+	g := &ReportGenerator{Results: results}
+	if regs := g.regressions(); len(regs) != 1 || regs[0].Step != "setup" {
+		t.Fatalf("regressions = %+v, want only setup", regs)
+	}
+	path := filepath.Join(t.TempDir(), "report.md")
+	if err := g.WriteMarkdown(path); err != nil {
+		t.Fatalf("WriteMarkdown: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := string(data)
+	if !strings.Contains(md, "| provision | setup | newtron | 30s | 5s | +25s |") {
+		t.Errorf("markdown missing regression row:\n%s", md)
+	}
+	if i, j := strings.Index(md, "## Performance Regressions"), strings.Index(md, "## Failures"); i < 0 || j < 0 || i > j {
+		t.Errorf("regressions section should precede failures")
+	}
+}
+
+// TestReport_RetriedStepAttempts: a retried step's attempt count survives the
+// state.json round-trip and reaches the JUnit report as system-out.
+func TestReport_RetriedStepAttempts(t *testing.T) {
+	results := ResultsFromRunState(&RunState{Scenarios: []ScenarioState{{
+		Name: "provision", Status: string(StepStatusPassed), Duration: "10s",
+		Steps: []StepState{
+			{Name: "restart-bgp", Action: "host-exec", Status: string(StepStatusPassed), Duration: "2s", Attempts: 2, MaxAttempts: 3},
+			{Name: "apply", Action: "newtron", Status: string(StepStatusPassed), Duration: "1s"},
+		},
+	}}})
+	if s := results[0].Steps[0]; s.Attempts != 2 || s.MaxAttempts != 3 {
+		t.Fatalf("round-trip: attempts=%d/%d, want 2/3", s.Attempts, s.MaxAttempts)
+	}
+
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	if err := (&ReportGenerator{Results: results}).WriteJUnit(path); err != nil {
+		t.Fatalf("WriteJUnit: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	xml := string(data)
+	if !strings.Contains(xml, "<system-out>passed on attempt 2/3</system-out>") {
+		t.Errorf("junit missing attempt note:\n%s", xml)
+	}
+	if strings.Count(xml, "<system-out>") != 1 {
+		t.Errorf("only the retried step should carry system-out:\n%s", xml)
+	}
+}
@@ -0,0 +1,218 @@
+package newtrun
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+)
+
+// Run results. Every `newtrun start` invocation gets a run ID and its own
+// results directory, so successive or concurrent runs never overwrite each
+// other's reports:
+//
+//	<results>/<run-id>/report.md
+//	<results>/<run-id>/report.html
+//	<results>/<run-id>/junit.xml
+//	<results>/<run-id>/report.json
+//	<results>/<run-id>/manifest.json
+//	<results>/latest -> <run-id>
+//
+// The run ID also travels to newtrun-server (StartRunRequest.RunID), which
+// records it in state.json and files failure artifacts under
+// <state dir>/artifacts/<run-id>/; the manifest lists those paths.
+
+// DefaultResultsDir is where `newtrun start` writes run results unless
+// told otherwise.
+const DefaultResultsDir = ".newtrun/results"
+
+// LatestResultsLink is the name of the symlink in the results directory
+// that points at the most recent run.
+const LatestResultsLink = "latest"
+
+// runIDRe bounds run IDs to safe single path components: a run ID names a
+// directory on both the CLI and the server side.
+var runIDRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// NewRunID returns a fresh run ID: the UTC start time (so IDs sort in run
+// order) and a random suffix (so runs started in the same second differ),
+// e.g. 20260530-100711-3f9a1c.
+func NewRunID(t time.Time) string {
+	var b [3]byte
+	_, _ = rand.Read(b[:])
+	return t.UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b[:])
+}
+
+// ValidateRunID rejects a run ID that is not a safe directory name.
+func ValidateRunID(id string) error {
+	if !runIDRe.MatchString(id) || id == LatestResultsLink {
+		return fmt.Errorf("invalid run id %q (want letters, digits, '.', '_', '-'; at most 64)", id)
+	}
+	return nil
+}
+
+// RunManifest describes one run's results directory. It is written last,
+// so a directory with a manifest holds a finished run.
+type RunManifest struct {
+	RunID string `json:"run_id"`
+	// ResumedFrom maps each suite resumed from a paused run to that run's ID.
+	ResumedFrom map[string]string `json:"resumed_from,omitempty"`
+	Suites      []string          `json:"suites"`
+	Status      SuiteStatus       `json:"status"`
+	Started     time.Time         `json:"started"`
+	Finished    time.Time         `json:"finished"`
+	Scenarios   int               `json:"scenarios"`
+	Passed      int               `json:"passed"`
+	Failed      int               `json:"failed"`
+	Errored     int               `json:"errored"`
+	Skipped     int               `json:"skipped"`
+	Reports     []string          `json:"reports"`             // file names within the run directory
+	Artifacts   []string          `json:"artifacts,omitempty"` // failure dumps, as newtrun-server wrote them
+
+	// RerunOf is the ID of the run whose failures this partial run
+	// re-executed (`newtrun rerun`).
+	RerunOf string `json:"rerun_of,omitempty"`
+	// Results is each scenario's outcome, in run order — what a later
+	// `newtrun rerun` of this run selects from.
+	Results []ManifestResult `json:"results,omitempty"`
+}
+
+// ManifestResult is one scenario's outcome as the run manifest records it.
+type ManifestResult struct {
+	Suite    string     `json:"suite"`
+	Scenario string     `json:"scenario"`
+	Status   StepStatus `json:"status"`
+}
+
+// WriteRunResults writes the reports and manifest for one run into
+// <base>/<run-id>/ and points <base>/latest at it. The manifest's counts,
+// status, reports, and artifacts are filled from gen. Returns the run
+// directory.
+func WriteRunResults(base string, m *RunManifest, gen *ReportGenerator) (string, error) {
+	if err := ValidateRunID(m.RunID); err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, m.RunID)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	m.Reports = nil
+	if err := gen.WriteMarkdown(filepath.Join(dir, "report.md")); err != nil {
+		return dir, fmt.Errorf("markdown report: %w", err)
+	}
+	m.Reports = append(m.Reports, "report.md")
+	if err := gen.WriteHTML(filepath.Join(dir, "report.html")); err != nil {
+		return dir, fmt.Errorf("HTML report: %w", err)
+	}
+	m.Reports = append(m.Reports, "report.html")
+	if err := gen.WriteJUnit(filepath.Join(dir, "junit.xml")); err != nil {
+		return dir, fmt.Errorf("JUnit report: %w", err)
+	}
+	m.Reports = append(m.Reports, "junit.xml")
+	if err := gen.WriteJSON(filepath.Join(dir, "report.json")); err != nil {
+		return dir, fmt.Errorf("JSON report: %w", err)
+	}
+	m.Reports = append(m.Reports, "report.json")
+
+	m.Scenarios, m.Passed, m.Failed, m.Errored, m.Skipped = len(gen.Results), 0, 0, 0, 0
+	m.Artifacts, m.Results = nil, nil
+	for _, r := range gen.Results {
+		m.Results = append(m.Results, ManifestResult{Suite: r.Suite, Scenario: r.Name, Status: r.Status})
+		switch r.Status {
+		case StepStatusPassed:
+			m.Passed++
+		case StepStatusFailed:
+			m.Failed++
+		case StepStatusError:
+			m.Errored++
+		case StepStatusSkipped:
+			m.Skipped++
+		}
+		for _, s := range r.Steps {
+			m.Artifacts = append(m.Artifacts, s.Artifacts...)
+		}
+	}
+	if m.Status == "" {
+		m.Status = SuiteStatusFromOutcome(nil, gen.Results)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return dir, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest.json"), append(data, '\n'), 0o644); err != nil {
+		return dir, fmt.Errorf("manifest: %w", err)
+	}
+	if err := updateLatestLink(base, m.RunID); err != nil {
+		return dir, fmt.Errorf("%s link: %w", LatestResultsLink, err)
+	}
+	return dir, nil
+}
+
+// ReadRunManifest reads the manifest of run runID from the results
+// directory base. runID may be LatestResultsLink for the most recent run.
+func ReadRunManifest(base, runID string) (*RunManifest, error) {
+	if runID != LatestResultsLink {
+		if err := ValidateRunID(runID); err != nil {
+			return nil, err
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(base, runID, "manifest.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no finished run %s in %s", runID, base)
+		}
+		return nil, err
+	}
+	var m RunManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("run %s: manifest: %w", runID, err)
+	}
+	return &m, nil
+}
+
+// FailedScenarios returns the scenarios of the run that failed or errored,
+// per suite, in run order; suites with none are absent. A manifest with
+// scenarios but no per-scenario results — written before manifests recorded
+// them — is an error.
+func (m *RunManifest) FailedScenarios() (map[string][]string, error) {
+	if m.Scenarios > 0 && len(m.Results) == 0 {
+		return nil, fmt.Errorf("run %s: manifest has no per-scenario results (written by an older newtrun); rerun the suites in full", m.RunID)
+	}
+	failed := make(map[string][]string)
+	for _, r := range m.Results {
+		if r.Status != StepStatusFailed && r.Status != StepStatusError {
+			continue
+		}
+		suite := r.Suite
+		if suite == "" && len(m.Suites) == 1 {
+			suite = m.Suites[0]
+		}
+		if !slices.Contains(failed[suite], r.Scenario) {
+			failed[suite] = append(failed[suite], r.Scenario)
+		}
+	}
+	return failed, nil
+}
+
+// updateLatestLink points <base>/latest at runID. The link is relative, so
+// a results tree can be moved or archived whole, and is swapped in with a
+// rename, so a reader never sees it missing.
+func updateLatestLink(base, runID string) error {
+	tmp := filepath.Join(base, fmt.Sprintf(".%s.%d", LatestResultsLink, os.Getpid()))
+	_ = os.Remove(tmp)
+	if err := os.Symlink(runID, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(base, LatestResultsLink)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
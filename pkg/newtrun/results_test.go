@@ -0,0 +1,133 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNewRunID(t *testing.T) {
+	at := time.Date(2026, 5, 30, 10, 7, 11, 0, time.UTC)
+	a, b := NewRunID(at), NewRunID(at)
+	if !regexp.MustCompile(`^20260530-100711-[0-9a-f]{6}$`).MatchString(a) {
+		t.Errorf("NewRunID = %q, want 20260530-100711-xxxxxx", a)
+	}
+	if a == b {
+		t.Errorf("two run IDs in the same second collide: %q", a)
+	}
+	if err := ValidateRunID(a); err != nil {
+		t.Errorf("ValidateRunID(%q): %v", a, err)
+	}
+	for _, bad := range []string{"", "latest", "../x", "a/b", ".hidden"} {
+		if ValidateRunID(bad) == nil {
+			t.Errorf("ValidateRunID(%q) accepted", bad)
+		}
+	}
+}
+
+func TestWriteRunResults(t *testing.T) {
+	base := t.TempDir()
+	gen := &ReportGenerator{Results: []*ScenarioResult{
+		{Name: "boot", Status: StepStatusPassed},
+		{Name: "verify", Status: StepStatusFailed, Steps: []StepResult{
+			{Name: "check", Status: StepStatusFailed, Artifacts: []string{"/state/artifacts/r1/verify/check/leaf1_CONFIG_DB_VLAN.json"}},
+		}},
+		{Name: "after", Status: StepStatusSkipped},
+	}}
+
+	first := &RunManifest{RunID: "run-1", Suites: []string{"s"}}
+	dir, err := WriteRunResults(base, first, gen)
+	if err != nil {
+		t.Fatalf("WriteRunResults: %v", err)
+	}
+	second := &RunManifest{RunID: "run-2", Suites: []string{"s"}}
+	if _, err := WriteRunResults(base, second, &ReportGenerator{Results: gen.Results[:1]}); err != nil {
+		t.Fatalf("WriteRunResults: %v", err)
+	}
+
+	// The first run's directory survives the second run.
+	for _, f := range []string{"report.md", "report.html", "junit.xml", "report.json", "manifest.json"} {
+		if _, err := os.Stat(filepath.Join(dir, f)); err != nil {
+			t.Errorf("run-1/%s: %v", f, err)
+		}
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m RunManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatalf("manifest: %v", err)
+	}
+	if m.RunID != "run-1" || m.Status != SuiteStatusFailed || m.Scenarios != 3 ||
+		m.Passed != 1 || m.Failed != 1 || m.Skipped != 1 {
+		t.Errorf("manifest = %+v", m)
+	}
+	if !reflect.DeepEqual(m.Reports, []string{"report.md", "report.html", "junit.xml", "report.json"}) {
+		t.Errorf("reports = %v", m.Reports)
+	}
+	if len(m.Artifacts) != 1 {
+		t.Errorf("artifacts = %v, want the failed step's dump", m.Artifacts)
+	}
+
+	link, err := os.Readlink(filepath.Join(base, LatestResultsLink))
+	if err != nil || link != "run-2" {
+		t.Errorf("latest -> %q (%v), want run-2", link, err)
+	}
+
+	if _, err := WriteRunResults(base, &RunManifest{RunID: "../out"}, gen); err == nil {
+		t.Error("unsafe run ID accepted")
+	}
+}
+
+func TestRunManifestFailedScenarios(t *testing.T) {
+	base := t.TempDir()
+	gen := &ReportGenerator{Results: []*ScenarioResult{
+		{Suite: "s", Name: "boot", Status: StepStatusPassed},
+		{Suite: "s", Name: "verify", Status: StepStatusFailed},
+		{Suite: "s", Name: "after", Status: StepStatusSkipped},
+		{Suite: "t", Name: "probe", Status: StepStatusError},
+	}}
+	if _, err := WriteRunResults(base, &RunManifest{RunID: "run-1", Suites: []string{"s", "t"}}, gen); err != nil {
+		t.Fatalf("WriteRunResults: %v", err)
+	}
+
+	m, err := ReadRunManifest(base, LatestResultsLink)
+	if err != nil {
+		t.Fatalf("ReadRunManifest(latest): %v", err)
+	}
+	if m.RunID != "run-1" || len(m.Results) != 4 {
+		t.Fatalf("manifest = %+v, want run-1 with 4 results", m)
+	}
+	failed, err := m.FailedScenarios()
+	if err != nil {
+		t.Fatalf("FailedScenarios: %v", err)
+	}
+	want := map[string][]string{"s": {"verify"}, "t": {"probe"}}
+	if !reflect.DeepEqual(failed, want) {
+		t.Errorf("FailedScenarios = %v, want %v", failed, want)
+	}
+
+	// A single-suite manifest without suite names attributes results to its suite.
+	single := &RunManifest{RunID: "run-2", Suites: []string{"s"}, Scenarios: 1,
+		Results: []ManifestResult{{Scenario: "verify", Status: StepStatusFailed}}}
+	if got, _ := single.FailedScenarios(); !reflect.DeepEqual(got, map[string][]string{"s": {"verify"}}) {
+		t.Errorf("single-suite FailedScenarios = %v", got)
+	}
+
+	// A manifest from before per-scenario results cannot be rerun.
+	if _, err := (&RunManifest{RunID: "old", Scenarios: 3}).FailedScenarios(); err == nil {
+		t.Error("manifest without results accepted")
+	}
+
+	if _, err := ReadRunManifest(base, "run-9"); err == nil {
+		t.Error("missing run accepted")
+	}
+	if _, err := ReadRunManifest(base, "../run-1"); err == nil {
+		t.Error("unsafe run ID accepted")
+	}
+}
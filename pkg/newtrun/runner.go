@@ -3,16 +3,22 @@ package newtrun
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"golang.org/x/crypto/ssh"
 
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
 	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
 )
 
 // Runner is the top-level newtrun orchestrator.
@@ -111,6 +117,48 @@ type Runner struct {
 	snapshots   map[string]map[string]intentRecords
 	snapshotsMu sync.Mutex
 
+	// vxlanBaselines holds named per-device VXLAN tunnel counters recorded
+	// by verify-vxlan-stats (record: true); run-scoped like snapshots.
+	// name → device → counters.
+	vxlanBaselines   map[string]map[string][]newtron.VXLANStat
+	vxlanBaselinesMu sync.Mutex
+
+	// goldens holds the golden files a GoldenRecord run is writing, and
+	// goldenLoads those a GoldenCompare run has read: scenario → golden.
+	// Run-scoped; see golden.go.
+	goldens     map[string]goldenFile
+	goldenLoads map[string]goldenLoad
+	goldensMu   sync.Mutex
+
+	// changeSets holds the ChangeSets newtron write steps got back, for
+	// verify-changeset: step name → device → changes. Scenario-iteration
+	// scoped like captured; reset alongside it.
+	changeSets   map[string]map[string][]sonic.ConfigChange
+	changeSetsMu sync.Mutex
+
+	// writes is the run's write budget (RunOptions.MaxTotalWrites), nil
+	// when uncapped; a run-suite child shares its parent's. cancelSteps
+	// cancels the current scenario's main steps when it trips. Both are
+	// set by Run and runScenarioSteps; see write_budget.go.
+	writes      *writeBudget
+	cancelSteps context.CancelCauseFunc
+
+	// flapBaselines holds each port's flap count at scenario start for
+	// verify-no-flaps: device|interface → baseline. Scenario-scoped;
+	// recordFlapBaselines replaces it as each scenario starts.
+	flapBaselines   map[string]flapBaseline
+	flapBaselinesMu sync.Mutex
+
+	// scenarioStart is when the current scenario began — the start of the
+	// window verify-log scans. Set by runScenarioSteps.
+	scenarioStart time.Time
+
+	// progressMu serializes ProgressReporter callbacks. Reporters are
+	// written for one caller at a time; concurrent repeat iterations
+	// (repeat_parallel) are the one place steps report from several
+	// goroutines.
+	progressMu sync.Mutex
+
 	opts RunOptions
 
 	// scenario is the currently-executing scenario, set by the
@@ -132,6 +180,11 @@ type RunOptions struct {
 	Verbose   bool
 	JUnitPath string
 
+	// Scenarios runs these scenarios and everything they transitively
+	// require, in dependency order — how `newtrun rerun` re-executes a
+	// previous run's failures.
+	Scenarios []string
+
 	// Targets overrides per-dimension entries of the suite's targets
 	// block at run time. Keys must match dimensions declared in
 	// suite.yaml; omitted keys inherit the suite default.
@@ -142,6 +195,49 @@ type RunOptions struct {
 	// validated against each parameter's spec (type, constraints).
 	Parameters map[string]any
 
+	// OnVerifyFailure selects what the runner captures when a verify step
+	// fails: "" captures nothing; OnVerifyFailureDumpTables dumps the
+	// tables the step asserted on to ArtifactsDir (see artifacts.go).
+	OnVerifyFailure string
+	// ArtifactsDir is where failure artifacts are written. Empty means
+	// <state dir>/artifacts/<RunID> for the suite (<state dir>/artifacts
+	// when RunID is empty).
+	ArtifactsDir string
+
+	// RunID identifies this run (see results.go). It keeps one run's
+	// artifacts apart from the next's.
+	RunID string
+
+	// Parallelism caps how many devices a step works on at once
+	// (executeForDevices, checkForDevices, pollForDevices). 0 means no
+	// cap: every target device runs concurrently, as before the option
+	// existed. 1 serializes device by device.
+	Parallelism int
+
+	// Golden puts the run in a golden mode for its observe: steps:
+	// GoldenRecord writes what they observed to the suite's golden files,
+	// GoldenCompare FAILs a step that drifted from them (see golden.go).
+	// "" does neither.
+	Golden string
+
+	// Preview builds each newtron write step's ChangeSet without
+	// delivering it (dry_run=true) and records it in the step result;
+	// steps that cannot be previewed are skipped (see preview.go).
+	Preview bool
+
+	// Deadline caps the whole run's wall time, deploy included. When it
+	// expires the running step is canceled, the rest of its scenario is
+	// skipped (reason "suite deadline"), the scenarios that had not
+	// started are skipped, and Run returns an error. 0 means no cap.
+	Deadline time.Duration
+
+	// MaxTotalWrites caps the config entries the whole run may deliver,
+	// across every scenario, device and run-suite child. The write that
+	// crosses it cancels the rest of its scenario (reason "write
+	// budget"), whose cleanup still runs; later scenarios are skipped and
+	// Run returns an error with the cumulative count. 0 means no cap.
+	MaxTotalWrites int
+
 	// Lifecycle fields (set by `start` command, not by `run`)
 	Suite     string                // suite name for state tracking; empty disables lifecycle
 	Resume    bool                  // true when resuming a paused run
@@ -166,9 +262,42 @@ func NewRunner(suiteDir string) *Runner {
 // runs use this to cancel in-flight runners when the server shuts down or
 // when an operator POSTs to the stop endpoint.
 func (r *Runner) Run(ctx context.Context, opts RunOptions) (results []*ScenarioResult, err error) {
-	if opts.Scenario == "" && opts.Target == "" && !opts.All {
+	if opts.Scenario == "" && opts.Target == "" && len(opts.Scenarios) == 0 && !opts.All {
 		return nil, fmt.Errorf("specify --scenario <name>, --target <name>, or --all")
 	}
+	switch opts.OnVerifyFailure {
+	case "", OnVerifyFailureDumpTables:
+	default:
+		return nil, fmt.Errorf("unknown on_verify_failure %q (want %q)", opts.OnVerifyFailure, OnVerifyFailureDumpTables)
+	}
+	switch opts.Golden {
+	case "", GoldenRecord, GoldenCompare:
+	default:
+		return nil, fmt.Errorf("unknown golden mode %q (want %q or %q)", opts.Golden, GoldenRecord, GoldenCompare)
+	}
+	if opts.Parallelism < 0 {
+		return nil, fmt.Errorf("parallelism must be >= 0, got %d", opts.Parallelism)
+	}
+	if opts.Deadline < 0 {
+		return nil, fmt.Errorf("deadline must be >= 0, got %s", opts.Deadline)
+	}
+	if opts.MaxTotalWrites < 0 {
+		return nil, fmt.Errorf("max total writes must be >= 0, got %d", opts.MaxTotalWrites)
+	}
+	if r.writes == nil && opts.MaxTotalWrites > 0 {
+		r.writes = &writeBudget{max: int64(opts.MaxTotalWrites)}
+	}
+	if opts.OnVerifyFailure != "" && opts.ArtifactsDir == "" {
+		suiteName := opts.Suite
+		if suiteName == "" {
+			suiteName = SuiteName(r.SuiteDir)
+		}
+		dir, err := StateDir(suiteName)
+		if err != nil {
+			return nil, err
+		}
+		opts.ArtifactsDir = filepath.Join(dir, "artifacts", opts.RunID)
+	}
 
 	// Load the suite: suite.yaml + every scenario file in the dir.
 	// LoadSuite validates all template references against suite-level
@@ -200,27 +329,26 @@ func (r *Runner) Run(ctx context.Context, opts RunOptions) (results []*ScenarioR
 	r.resolvedIterations = resolved.TargetIterations()
 	r.resolvedParameters = effParams
 
-	// Filter scenarios by --scenario / --target / --all.
+	// Filter scenarios by --scenario / --target / --all, or to a rerun's
+	// scenarios and their requires chains.
+	scenario, target := opts.Scenario, opts.Target
+	if opts.All {
+		scenario, target = "", ""
+	}
 	var scenarios []*Scenario
-	switch {
-	case opts.All:
-		scenarios = suite.Scenarios
-	case opts.Target != "":
-		chain, err := ComputeTargetChain(suite.Scenarios, opts.Target)
-		if err != nil {
-			return nil, err
-		}
-		scenarios = chain
-	default:
-		scenarios = nil
-		for _, sc := range suite.Scenarios {
-			if sc.Name == opts.Scenario {
-				scenarios = []*Scenario{sc}
-				break
-			}
-		}
-		if len(scenarios) == 0 {
-			return nil, fmt.Errorf("scenario %q not found in %s", opts.Scenario, r.SuiteDir)
+	targets := []string{target}
+	if len(opts.Scenarios) > 0 && !opts.All {
+		targets = opts.Scenarios
+		scenarios, err = ComputeTargetChain(suite.Scenarios, targets...)
+	} else {
+		scenarios, err = selectScenarios(suite, scenario, target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w in %s", err, r.SuiteDir)
+	}
+	if target != "" || len(opts.Scenarios) > 0 {
+		if deps := dependenciesOf(scenarios, targets); len(deps) > 0 {
+			fmt.Fprintf(os.Stderr, "newtrun: %s pulled in %d dependencies: %s\n", strings.Join(targets, ", "), len(deps), strings.Join(deps, ", "))
 		}
 	}
 
@@ -246,6 +374,11 @@ func (r *Runner) Run(ctx context.Context, opts RunOptions) (results []*ScenarioR
 
 	r.progress(func(p ProgressReporter) { p.SuiteStart(suite.Network, suite.Platform, scenarios) })
 	suiteStart := time.Now()
+	if opts.Deadline > 0 {
+		var cancelDeadline context.CancelFunc
+		ctx, cancelDeadline = context.WithTimeoutCause(ctx, opts.Deadline, errSuiteDeadline)
+		defer cancelDeadline()
+	}
 
 	// Emit SuiteEnd from every path that returns after SuiteStart. The
 	// CLI client (cmd_start.go) cancels its SSE stream on the SuiteEnd
@@ -322,10 +455,14 @@ func (r *Runner) Run(ctx context.Context, opts RunOptions) (results []*ScenarioR
 	// computes its status from the final tuple.
 	results, err = r.iterateScenarios(ctx, scenarios, opts, deployedPlatform, func(ctx context.Context, sc *Scenario, platform string) (*ScenarioResult, error) {
 		r.opts = RunOptions{
-			Platform: platform,
-			NoDeploy: true,
-			Keep:     true,
-			Verbose:  opts.Verbose,
+			Platform:        platform,
+			NoDeploy:        true,
+			Keep:            true,
+			Verbose:         opts.Verbose,
+			OnVerifyFailure: opts.OnVerifyFailure,
+			ArtifactsDir:    opts.ArtifactsDir,
+			Parallelism:     opts.Parallelism,
+			Golden:          opts.Golden,
 		}
 		r.scenario = sc
 
@@ -343,6 +480,36 @@ func (r *Runner) Run(ctx context.Context, opts RunOptions) (results []*ScenarioR
 	return results, err
 }
 
+// selectScenarios returns the scenarios a run covers: the target and its
+// requires chain when target is set, the one named scenario when scenario is
+// set, otherwise the whole suite. All are in dependency order.
+func selectScenarios(suite *Suite, scenario, target string) ([]*Scenario, error) {
+	switch {
+	case target != "":
+		return ComputeTargetChain(suite.Scenarios, target)
+	case scenario != "":
+		for _, sc := range suite.Scenarios {
+			if sc.Name == scenario {
+				return []*Scenario{sc}, nil
+			}
+		}
+		return nil, fmt.Errorf("scenario %q not found", scenario)
+	}
+	return suite.Scenarios, nil
+}
+
+// dependenciesOf returns the names in chain other than the targets — the
+// scenarios a dependency-chain run pulled in — in run order.
+func dependenciesOf(chain []*Scenario, targets []string) []string {
+	var deps []string
+	for _, sc := range chain {
+		if !slices.Contains(targets, sc.Name) {
+			deps = append(deps, sc.Name)
+		}
+	}
+	return deps
+}
+
 // scenarioRunner is a callback that executes a single scenario within the
 // iteration loop. It receives the resolved platform name.
 type scenarioRunner func(ctx context.Context, sc *Scenario, platform string) (*ScenarioResult, error)
@@ -370,7 +537,39 @@ func (r *Runner) iterateScenarios(ctx context.Context, scenarios []*Scenario, op
 		// "N failed" that looks indistinguishable from a real bad
 		// suite. Returning ctx.Err() here routes through
 		// SuiteStatusFromOutcome to the aborted status.
+		//
+		// The suite deadline is not an abort: the scenarios it cut off
+		// are recorded as skipped and the run fails.
 		if err := ctx.Err(); err != nil {
+			if context.Cause(ctx) != errSuiteDeadline {
+				return results, err
+			}
+			for j, rest := range scenarios[i:] {
+				result := &ScenarioResult{
+					Name:       rest.Name,
+					Network:    r.Network,
+					Platform:   rest.Platform,
+					Status:     StepStatusSkipped,
+					SkipReason: fmt.Sprintf("%s (%s) exceeded", timeoutReasonSuite, opts.Deadline),
+				}
+				results = append(results, result)
+				r.progress(func(p ProgressReporter) { p.ScenarioEnd(result, i+j, len(scenarios)) })
+			}
+			return results, fmt.Errorf("suite deadline of %s exceeded", opts.Deadline)
+		}
+		// Nor is the write budget: once spent, nothing else runs.
+		if err := r.writes.err(); err != nil {
+			for j, rest := range scenarios[i:] {
+				result := &ScenarioResult{
+					Name:       rest.Name,
+					Network:    r.Network,
+					Platform:   rest.Platform,
+					Status:     StepStatusSkipped,
+					SkipReason: fmt.Sprintf("%s (%d) exceeded", writeBudgetReason, r.writes.max),
+				}
+				results = append(results, result)
+				r.progress(func(p ProgressReporter) { p.ScenarioEnd(result, i+j, len(scenarios)) })
+			}
 			return results, err
 		}
 
@@ -449,6 +648,27 @@ func (r *Runner) iterateScenarios(ctx context.Context, scenarios []*Scenario, op
 			continue
 		}
 
+		// skip_if: the scenario's own condition on the platform and
+		// parameters. A condition that cannot be evaluated fails the
+		// scenario rather than skipping it.
+		if reason, err := r.checkSkipIf(sc, deployedPlatform, platform); err != nil || reason != "" {
+			result := &ScenarioResult{
+				Name:       sc.Name,
+				Network:    r.Network,
+				Platform:   platform,
+				Status:     StepStatusSkipped,
+				SkipReason: reason,
+			}
+			if err != nil {
+				result.Status = StepStatusError
+				result.DeployError = err
+			}
+			results = append(results, result)
+			scenarioStatus[sc.Name] = result.Status
+			r.progress(func(p ProgressReporter) { p.ScenarioEnd(result, i, len(scenarios)) })
+			continue
+		}
+
 		r.progress(func(p ProgressReporter) { p.ScenarioStart(sc.Name, i, len(scenarios)) })
 
 		result, err := run(ctx, sc, platform)
@@ -461,7 +681,7 @@ func (r *Runner) iterateScenarios(ctx context.Context, scenarios []*Scenario, op
 		r.progress(func(p ProgressReporter) { p.ScenarioEnd(result, i, len(scenarios)) })
 	}
 
-	return results, nil
+	return results, r.writes.err()
 }
 
 // deployTopology deploys the lab topology by calling newtlab-server.
@@ -611,7 +831,9 @@ func (r *Runner) connectDevices() error {
 //
 //   - Repeat: when scenario.Repeat > 1, every step runs that many
 //     times. Repeat is fail-fast — the first failed repeat-iteration
-//     records FailedIteration and stops further repeats.
+//     records FailedIteration and stops further repeats. A
+//     parallel_safe scenario with repeat_parallel > 1 runs its repeat
+//     passes on a bounded worker pool instead (runConcurrently).
 //   - Target iteration: for parameterized scenarios, the runner
 //     enumerates the cross-product of suite-level Targets and binds
 //     {{target.X}} / {{param.X}} per iteration. Target iterations are
@@ -630,6 +852,11 @@ func (r *Runner) runScenarioSteps(ctx context.Context, scenario *Scenario, opts
 	}
 	result.Repeat = scenario.Repeat
 
+	// The scenario's log window opens now; verify-log scans syslog from here,
+	// and verify-no-flaps counts flaps from these baselines.
+	r.scenarioStart = time.Now()
+	r.recordFlapBaselines(scenario)
+
 	// A scenario is parameterized when any of its steps references
 	// {{target.X}} or {{param.X}}. Parameterized scenarios iterate
 	// the suite's resolved target cross-product; embedded-target
@@ -648,63 +875,177 @@ func (r *Runner) runScenarioSteps(ctx context.Context, scenario *Scenario, opts
 		iterations = []map[string]string{nil}
 	}
 
-	for repeatIter := 1; repeatIter <= repeat; repeatIter++ {
-		anyIterFailed := false
+	concurrent := scenario.RepeatParallel > 1 && repeat > 1
 
+	// stepsCtx bounds the main steps by the scenario's timeout. Cleanup
+	// runs under ctx, so a timed-out scenario still tears down.
+	stepsCtx := ctx
+	if scenario.Timeout > 0 {
+		var cancel context.CancelFunc
+		stepsCtx, cancel = context.WithTimeoutCause(ctx, scenario.Timeout, errScenarioTimeout)
+		defer cancel()
+	}
+	// The write budget cancels the main steps alone, too.
+	stepsCtx, cancelSteps := context.WithCancelCause(stepsCtx)
+	defer cancelSteps(nil)
+	r.cancelSteps = cancelSteps
+	var timedOut atomic.Bool
+
+	// runPass runs one repeat pass — every target binding through the step
+	// list — and returns its step results and whether any binding failed.
+	runPass := func(repeatIter int) (steps []StepResult, failed bool) {
 		for _, binding := range iterations {
 			iterFailed := false
 
 			// Fresh per-iteration captured map. Same-iteration
 			// step order in scenario.Steps fixes write-then-read so a
 			// {{captured.NAME}} reference in step N can see what step
-			// N-1 captured.
-			r.captured = map[string]any{}
+			// N-1 captured. Concurrent passes leave it alone: their
+			// steps capture nothing (validateRepeatParallel).
+			if !concurrent {
+				r.captured = map[string]any{}
+				r.resetChangeSets()
+			}
 
 			for i, step := range scenario.Steps {
-				stepToRun := step
-				var expandErr error
-				if isParameterized || len(r.captured) > 0 || stepReferencesCaptured(step) {
-					stepToRun, expandErr = ExpandStep(step, binding, effectiveParams, r.captured)
+				iter := 0
+				if repeat > 1 {
+					iter = repeatIter
+				}
+				if reason := timeoutReason(stepsCtx); reason != "" {
+					timedOut.Store(true)
+					return append(steps, skippedSteps(scenario.Steps[i:], reason, binding, iter)...), true
 				}
-				if expandErr != nil {
-					sr := StepResult{
-						Name:          step.Name,
-						Action:        step.Action,
-						Status:        StepStatusError,
-						Message:       fmt.Sprintf("template expansion: %v", expandErr),
-						TargetBinding: binding,
+
+				// A for_each step runs once per item; any other step is
+				// one untagged instance.
+				items := []any{nil}
+				if step.ForEach != nil {
+					var err error
+					items, err = resolveForEach(step.ForEach, binding, effectiveParams, r.captured)
+					if err != nil {
+						sr := StepResult{
+							Name:          step.Name,
+							Action:        step.Action,
+							Status:        StepStatusError,
+							Message:       err.Error(),
+							TargetBinding: binding,
+						}
+						if repeat > 1 {
+							sr.Iteration = repeatIter
+						}
+						steps = append(steps, sr)
+						iterFailed = true
+						break
 					}
-					if repeat > 1 {
-						sr.Iteration = repeatIter
+					if len(items) == 0 {
+						sr := StepResult{
+							Name:          step.Name,
+							Action:        step.Action,
+							Status:        StepStatusSkipped,
+							Message:       "for_each: empty list, nothing to run",
+							TargetBinding: binding,
+						}
+						if repeat > 1 {
+							sr.Iteration = repeatIter
+						}
+						steps = append(steps, sr)
+						continue
 					}
-					result.Steps = append(result.Steps, sr)
-					iterFailed = true
-					break
 				}
 
-				stepCopy := stepToRun
-				r.progress(func(p ProgressReporter) { p.StepStart(scenario.Name, &stepCopy, i, len(scenario.Steps)) })
+				for _, item := range items {
+					captured := r.captured
+					if step.ForEach != nil {
+						captured = withForEachItem(r.captured, item)
+					}
+					captured = withVars(captured, scopedVars(r.suite, scenario, &step))
+					stepToRun := step
+					var expandErr error
+					if isParameterized || len(captured) > 0 || stepReferencesCaptured(step) {
+						stepToRun, expandErr = ExpandStep(step, binding, effectiveParams, captured)
+					}
+					if expandErr != nil {
+						sr := StepResult{
+							Name:          step.Name,
+							Action:        step.Action,
+							Status:        StepStatusError,
+							Message:       fmt.Sprintf("template expansion: %v", expandErr),
+							TargetBinding: binding,
+						}
+						if repeat > 1 {
+							sr.Iteration = repeatIter
+						}
+						if step.ForEach != nil {
+							sr.Item = stringifyScalar(item)
+						}
+						newRedactor(scenario.Redact, step.Redact, r.captured).result(&sr)
+						steps = append(steps, sr)
+						iterFailed = true
+						break
+					}
 
-				output := r.executeStep(ctx, &stepToRun, i, len(scenario.Steps), opts)
+					stepCopy := stepToRun
+					r.progress(func(p ProgressReporter) { p.StepStart(scenario.Name, &stepCopy, i, len(scenario.Steps)) })
 
-				sr := *output.Result
-				if repeat > 1 {
-					sr.Iteration = repeatIter
-				}
-				sr.TargetBinding = binding
-				result.Steps = append(result.Steps, sr)
+					execCtx, obs := stepsCtx, (*observations)(nil)
+					if stepToRun.Observe {
+						execCtx, obs = withObservations(stepsCtx)
+					}
+					output := r.executeStepWithRetries(execCtx, &stepToRun, i, len(scenario.Steps), opts)
+					// Built after the step ran so a value it just captured is
+					// masked in its own output.
+					rd := newRedactor(scenario.Redact, step.Redact, r.captured)
+					if opts.OnVerifyFailure == OnVerifyFailureDumpTables && output.Result.Status == StepStatusFailed {
+						r.dumpFailureTables(scenario.Name, &stepToRun, output.Result, opts.ArtifactsDir, rd)
+					}
+
+					sr := *output.Result
+					if repeat > 1 {
+						sr.Iteration = repeatIter
+					}
+					if step.ForEach != nil {
+						sr.Item = stringifyScalar(item)
+					}
+					sr.TargetBinding = binding
+					// A step the timeout cut off did not fail on its own
+					// merits: it is an ERROR, and the steps after it never run.
+					reason := timeoutReason(stepsCtx)
+					if reason != "" && sr.Status != StepStatusPassed {
+						sr.Status = StepStatusError
+						sr.Message = strings.TrimSuffix(reason+": "+sr.Message, ": ")
+					}
+					if obs != nil {
+						r.finishObserved(opts.Golden, scenario.Name, &sr, obs)
+					}
+					rd.result(&sr)
+					capOutput(&sr, stepToRun.MaxOutputBytes)
+					steps = append(steps, sr)
+					// Concurrent passes share r.captured; their steps may
+					// not reference outputs (validateRepeatParallel).
+					if sr.Status == StepStatusPassed && !concurrent {
+						publishOutputs(r.captured, step.Name, sr.Outputs)
+					}
 
-				srCopy := sr
-				r.progress(func(p ProgressReporter) { p.StepEnd(scenario.Name, &srCopy, i, len(scenario.Steps)) })
+					srCopy := sr
+					r.progress(func(p ProgressReporter) { p.StepEnd(scenario.Name, &srCopy, i, len(scenario.Steps)) })
 
-				if output.Result.Status == StepStatusFailed || output.Result.Status == StepStatusError {
-					iterFailed = true
+					if reason != "" && sr.Status != StepStatusPassed {
+						timedOut.Store(true)
+						return append(steps, skippedSteps(scenario.Steps[i+1:], reason, binding, iter)...), true
+					}
+					if output.Result.Status == StepStatusFailed || output.Result.Status == StepStatusError {
+						iterFailed = true
+						break
+					}
+				}
+				if iterFailed {
 					break
 				}
 			}
 
 			if iterFailed {
-				anyIterFailed = true
+				failed = true
 				// Embedded-target scenarios have only one (nil) binding,
 				// so this break is moot. Parameterized scenarios
 				// continue to the next binding so the operator sees
@@ -716,15 +1057,40 @@ func (r *Runner) runScenarioSteps(ctx context.Context, scenario *Scenario, opts
 			}
 		}
 
-		// Repeat is fail-fast regardless of scenario shape: if any
-		// iteration in this repeat pass failed, record the index
-		// (when Repeat > 1 it's a useful provenance marker for soak
-		// runs) and stop further repeats.
-		if anyIterFailed {
-			if repeat > 1 {
-				result.FailedIteration = repeatIter
+		return steps, failed
+	}
+
+	if concurrent {
+		// Concurrent repeat (parallel_safe scenarios): passes run on a
+		// bounded worker pool; results are recorded in iteration order,
+		// not completion order.
+		r.captured = map[string]any{}
+		r.resetChangeSets()
+		passes := make([][]StepResult, repeat+1)
+		failedIter := runConcurrently(repeat, scenario.RepeatParallel, func(repeatIter int) bool {
+			steps, failed := runPass(repeatIter)
+			passes[repeatIter] = steps
+			return failed
+		})
+		for _, steps := range passes {
+			result.Steps = append(result.Steps, steps...)
+		}
+		result.FailedIteration = failedIter
+	} else {
+		for repeatIter := 1; repeatIter <= repeat; repeatIter++ {
+			steps, failed := runPass(repeatIter)
+			result.Steps = append(result.Steps, steps...)
+
+			// Repeat is fail-fast regardless of scenario shape: if any
+			// iteration in this repeat pass failed, record the index
+			// (when Repeat > 1 it's a useful provenance marker for soak
+			// runs) and stop further repeats.
+			if failed {
+				if repeat > 1 {
+					result.FailedIteration = repeatIter
+				}
+				break
 			}
-			break
 		}
 	}
 
@@ -734,24 +1100,41 @@ func (r *Runner) runScenarioSteps(ctx context.Context, scenario *Scenario, opts
 	// scenario). Expansion uses a nil target binding (validated at parse
 	// time: no {{target.X}}) and whatever the last iteration captured.
 	for i, step := range scenario.Cleanup {
-		stepToRun, expandErr := ExpandStep(step, nil, effectiveParams, r.captured)
+		// Only the suite deadline stops cleanup; the scenario timeout
+		// and the write budget bound the main steps alone.
+		if reason := timeoutReason(ctx); reason != "" {
+			sr := StepResult{
+				Name:    "cleanup/" + step.Name,
+				Action:  step.Action,
+				Status:  StepStatusSkipped,
+				Message: reason,
+			}
+			result.Steps = append(result.Steps, sr)
+			continue
+		}
+		stepToRun, expandErr := ExpandStep(step, nil, effectiveParams, withVars(r.captured, scopedVars(r.suite, scenario, &step)))
+		rd := newRedactor(scenario.Redact, step.Redact, r.captured)
 		if expandErr != nil {
-			result.Steps = append(result.Steps, StepResult{
+			sr := StepResult{
 				Name:    "cleanup/" + step.Name,
 				Action:  step.Action,
 				Status:  StepStatusError,
 				Message: fmt.Sprintf("template expansion: %v", expandErr),
-			})
+			}
+			rd.result(&sr)
+			result.Steps = append(result.Steps, sr)
 			continue // best-effort: later cleanup steps still run
 		}
 
 		stepCopy := stepToRun
 		r.progress(func(p ProgressReporter) { p.StepStart(scenario.Name, &stepCopy, i, len(scenario.Cleanup)) })
 
-		output := r.executeStep(ctx, &stepToRun, i, len(scenario.Cleanup), opts)
+		output := r.executeStepWithRetries(ctx, &stepToRun, i, len(scenario.Cleanup), opts)
 
 		sr := *output.Result
 		sr.Name = "cleanup/" + sr.Name
+		rd.result(&sr)
+		capOutput(&sr, stepToRun.MaxOutputBytes)
 		result.Steps = append(result.Steps, sr)
 
 		srCopy := sr
@@ -759,6 +1142,93 @@ func (r *Runner) runScenarioSteps(ctx context.Context, scenario *Scenario, opts
 	}
 
 	result.Status = computeOverallStatus(result.Steps)
+	if timedOut.Load() || context.Cause(stepsCtx) == errWriteBudget {
+		result.Status = StepStatusError
+	}
+}
+
+// Reasons a timeout gives for the steps it cut off.
+const (
+	timeoutReasonScenario = "scenario timeout"
+	timeoutReasonSuite    = "suite deadline"
+)
+
+// Cancellation causes that tell the scenario timeout (Scenario.Timeout) and
+// the suite deadline (RunOptions.Deadline) apart from a plain cancel.
+var (
+	errScenarioTimeout = errors.New(timeoutReasonScenario)
+	errSuiteDeadline   = errors.New(timeoutReasonSuite)
+)
+
+// timeoutReason returns the reason ctx was cut off by a timeout or the write
+// budget, or "" when it is live or was canceled some other way (SIGINT,
+// server shutdown).
+func timeoutReason(ctx context.Context) string {
+	switch context.Cause(ctx) {
+	case errScenarioTimeout:
+		return timeoutReasonScenario
+	case errSuiteDeadline:
+		return timeoutReasonSuite
+	case errWriteBudget:
+		return writeBudgetReason
+	}
+	return ""
+}
+
+// skippedSteps records steps that never started because reason ended the
+// pass early.
+func skippedSteps(steps []Step, reason string, binding map[string]string, iter int) []StepResult {
+	out := make([]StepResult, 0, len(steps))
+	for _, step := range steps {
+		out = append(out, StepResult{
+			Name:          step.Name,
+			Action:        step.Action,
+			Status:        StepStatusSkipped,
+			Message:       reason,
+			Iteration:     iter,
+			TargetBinding: binding,
+		})
+	}
+	return out
+}
+
+// runConcurrently calls run for iterations 1..n on up to workers goroutines
+// and returns the lowest iteration for which run reported failure (0 when
+// none did). After the first failure no further iterations start; those
+// already running finish.
+func runConcurrently(n, workers int, run func(iter int) (failed bool)) int {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		next   = 1
+		failed int
+	)
+	for range min(workers, n) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				mu.Lock()
+				iter := next
+				if iter > n || failed != 0 {
+					mu.Unlock()
+					return
+				}
+				next++
+				mu.Unlock()
+
+				if run(iter) {
+					mu.Lock()
+					if failed == 0 || iter < failed {
+						failed = iter
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return failed
 }
 
 // connectHostSSH establishes a plain SSH connection to a host device.
@@ -815,7 +1285,16 @@ func (r *Runner) executeStep(ctx context.Context, step *Step, index, total int,
 	}
 
 	start := time.Now()
-	output := executor.Execute(ctx, r, step)
+	var output *StepOutput
+	if opts.Preview {
+		output = r.previewStep(ctx, step)
+	}
+	if output == nil {
+		output = executor.Execute(ctx, r, step)
+		if output.Result.Status == StepStatusPassed && changesDeviceConfig(step) {
+			r.awaitReadiness(ctx, step, opts.Platform, output.Result)
+		}
+	}
 	output.Result.Duration = time.Since(start)
 	output.Result.Name = step.Name
 	output.Result.Action = step.Action
@@ -837,10 +1316,79 @@ func (r *Runner) executeStep(ctx context.Context, step *Step, index, total int,
 	if step.ExpectFailure {
 		output.Result = applyExpectFailure(output.Result, step)
 	}
+	if step.Expect != nil && step.Expect.MaxDuration > 0 {
+		applyMaxDuration(output.Result, step.Expect.MaxDuration)
+	}
+
+	return output
+}
+
+// defaultRetryInterval is the wait before a step's first retry when it sets
+// retries without retry_interval.
+const defaultRetryInterval = time.Second
+
+// executeStepWithRetries runs step, re-running it while it fails or errors
+// up to step.Retries more times, waiting before each retry — RetryInterval,
+// doubling each time. The last attempt's result stands (its Duration is that
+// attempt's, so expect.max_duration bounds one attempt), with Attempts and
+// MaxAttempts recorded for the reports. A step without retries runs once and
+// records neither.
+func (r *Runner) executeStepWithRetries(ctx context.Context, step *Step, index, total int, opts RunOptions) *StepOutput {
+	output := r.executeStep(ctx, step, index, total, opts)
+	if step.Retries <= 0 {
+		return output
+	}
 
+	interval := step.RetryInterval
+	if interval <= 0 {
+		interval = defaultRetryInterval
+	}
+	maxAttempts := step.Retries + 1
+	attempt := 1
+	for attempt < maxAttempts && stepFailed(output.Result.Status) {
+		select {
+		case <-ctx.Done():
+			output.Result.Attempts, output.Result.MaxAttempts = attempt, maxAttempts
+			return output
+		case <-time.After(interval):
+		}
+		interval *= 2
+		attempt++
+		output = r.executeStep(ctx, step, index, total, opts)
+	}
+	output.Result.Attempts, output.Result.MaxAttempts = attempt, maxAttempts
 	return output
 }
 
+// stepFailed reports whether status is a failure a retry may clear.
+func stepFailed(status StepStatus) bool {
+	return status == StepStatusFailed || status == StepStatusError
+}
+
+// applyMaxDuration checks the step's time against expect.max_duration. A step
+// over the bound records its overage; one that otherwise passed fails, so a
+// performance regression surfaces even though the step did its job. A step
+// that already failed keeps its own failure, with the overage noted.
+func applyMaxDuration(result *StepResult, bound time.Duration) {
+	result.MaxDuration = bound
+	if result.Duration <= bound {
+		return
+	}
+	result.Overage = result.Duration - bound
+	msg := fmt.Sprintf("took %s, over max_duration %s by %s",
+		formatReportDuration(result.Duration), bound, formatReportDuration(result.Overage))
+	if result.Status == StepStatusPassed {
+		result.Status = StepStatusFailed
+		result.Message = msg
+		return
+	}
+	if result.Message == "" {
+		result.Message = msg
+	} else {
+		result.Message += "; " + msg
+	}
+}
+
 // applyExpectFailure inverts the pass/fail result for steps with expect_failure: true.
 // If the step failed/errored → passes (expected). If it passed → fails (unexpected success).
 // When expect.contains is set, the error message must contain that substring.
@@ -874,6 +1422,8 @@ func applyExpectFailure(result *StepResult, step *Step) *StepResult {
 // progress calls fn with the ProgressReporter if one is set.
 func (r *Runner) progress(fn func(ProgressReporter)) {
 	if r.Progress != nil {
+		r.progressMu.Lock()
+		defer r.progressMu.Unlock()
 		fn(r.Progress)
 	}
 }
@@ -943,11 +1493,17 @@ func checkRequires(sc *Scenario, status map[string]StepStatus) string {
 // parameter the operator left at its default-empty value is treated
 // the same as one they omitted entirely.
 func (r *Runner) checkRequiredParams(sc *Scenario) string {
+	return requiredParamsSkipReason(sc, r.resolvedParameters)
+}
+
+// requiredParamsSkipReason is checkRequiredParams against an explicit
+// parameter map; PlanSuite shares it to predict the same skips.
+func requiredParamsSkipReason(sc *Scenario, params map[string]any) string {
 	if len(sc.RequiresParams) == 0 {
 		return ""
 	}
 	for _, name := range sc.RequiresParams {
-		v, ok := r.resolvedParameters[name]
+		v, ok := params[name]
 		if !ok {
 			return fmt.Sprintf("requires parameter %q (not set by operator)", name)
 		}
@@ -977,6 +1533,19 @@ func (r *Runner) checkRequiredParams(sc *Scenario) string {
 	return ""
 }
 
+// platformName is the platform a scenario's checks run against: the
+// deployed platform, then the per-scenario one, then the one discovered
+// from the connected devices.
+func (r *Runner) platformName(deployedPlatform, scenarioPlatform string) string {
+	if deployedPlatform != "" {
+		return deployedPlatform
+	}
+	if scenarioPlatform != "" {
+		return scenarioPlatform
+	}
+	return r.discoveredPlatform
+}
+
 // checkPlatformFeatures checks if the platform supports all required features.
 // Returns a skip reason if any required feature is unsupported, empty string otherwise.
 func (r *Runner) checkPlatformFeatures(sc *Scenario, deployedPlatform, scenarioPlatform string) string {
@@ -988,14 +1557,7 @@ func (r *Runner) checkPlatformFeatures(sc *Scenario, deployedPlatform, scenarioP
 		return "" // Cannot check features without server connection (proceed and let operations fail)
 	}
 
-	// Use deployed platform, then per-scenario, then discovered
-	platformName := deployedPlatform
-	if platformName == "" {
-		platformName = scenarioPlatform
-	}
-	if platformName == "" {
-		platformName = r.discoveredPlatform
-	}
+	platformName := r.platformName(deployedPlatform, scenarioPlatform)
 
 	var unsupported []string
 	for _, feature := range sc.RequiresFeatures {
@@ -0,0 +1,111 @@
+package newtrun
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// flakyExecutor fails its first failures calls, then passes.
+type flakyExecutor struct {
+	failures int
+	calls    int
+	at       []time.Time
+}
+
+func (e *flakyExecutor) Execute(_ context.Context, _ *Runner, _ *Step) *StepOutput {
+	e.calls++
+	e.at = append(e.at, time.Now())
+	if e.calls <= e.failures {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: "ssh: connection reset"}}
+	}
+	return &StepOutput{Result: &StepResult{Status: StepStatusPassed, Message: "ok"}}
+}
+
+// withExecutor swaps action's executor for the test's duration.
+func withExecutor(t *testing.T, action StepAction, exec StepExecutor) {
+	t.Helper()
+	orig := executors[action]
+	executors[action] = exec
+	t.Cleanup(func() { executors[action] = orig })
+}
+
+// TestRunScenarioSteps_RetryPassesOnThirdAttempt: a step that fails twice
+// then passes, with retries: 2, passes on attempt 3/3 and the scenario goes
+// on to its next step.
+func TestRunScenarioSteps_RetryPassesOnThirdAttempt(t *testing.T) {
+	flaky := &flakyExecutor{failures: 2}
+	withExecutor(t, ActionHostExec, flaky)
+
+	scenario := &Scenario{
+		Name: "flaky",
+		Steps: []Step{
+			{Name: "restart-bgp", Action: ActionHostExec, Command: "systemctl restart bgp",
+				Retries: 2, RetryInterval: 5 * time.Millisecond},
+			{Name: "settle", Action: ActionWait},
+		},
+	}
+	result := &ScenarioResult{Name: "flaky"}
+	(&Runner{}).runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+
+	if flaky.calls != 3 {
+		t.Fatalf("executor ran %d times, want 3", flaky.calls)
+	}
+	if len(result.Steps) != 2 {
+		t.Fatalf("got %d step results, want 2 (the retried step, then settle)", len(result.Steps))
+	}
+	sr := result.Steps[0]
+	if sr.Status != StepStatusPassed || sr.Attempts != 3 || sr.MaxAttempts != 3 {
+		t.Errorf("retried step: status=%s attempts=%d/%d, want PASS 3/3", sr.Status, sr.Attempts, sr.MaxAttempts)
+	}
+	if got := AttemptNote(sr.Status, sr.Attempts, sr.MaxAttempts); got != "passed on attempt 3/3" {
+		t.Errorf("AttemptNote = %q", got)
+	}
+	// The wait doubles: >= 5ms before the second attempt, >= 10ms before the third.
+	if d1, d2 := flaky.at[1].Sub(flaky.at[0]), flaky.at[2].Sub(flaky.at[1]); d1 < 5*time.Millisecond || d2 < 10*time.Millisecond {
+		t.Errorf("retry waits = %s, %s, want >= 5ms then >= 10ms", d1, d2)
+	}
+}
+
+// TestExecuteStepWithRetries_Exhausted: a step still failing after its
+// retries keeps the last attempt's failure, with every attempt counted.
+func TestExecuteStepWithRetries_Exhausted(t *testing.T) {
+	flaky := &flakyExecutor{failures: 5}
+	withExecutor(t, ActionHostExec, flaky)
+
+	step := &Step{Name: "x", Action: ActionHostExec, Retries: 1, RetryInterval: time.Millisecond}
+	out := (&Runner{}).executeStepWithRetries(context.Background(), step, 0, 1, RunOptions{})
+	if flaky.calls != 2 {
+		t.Errorf("executor ran %d times, want 2", flaky.calls)
+	}
+	if out.Result.Status != StepStatusError || out.Result.Attempts != 2 || out.Result.MaxAttempts != 2 {
+		t.Errorf("status=%s attempts=%d/%d, want ERROR 2/2", out.Result.Status, out.Result.Attempts, out.Result.MaxAttempts)
+	}
+}
+
+// TestExecuteStepWithRetries_NoRetries: without retries a failing step runs
+// once and records no attempts, so reports stay as they were.
+func TestExecuteStepWithRetries_NoRetries(t *testing.T) {
+	flaky := &flakyExecutor{failures: 1}
+	withExecutor(t, ActionHostExec, flaky)
+
+	out := (&Runner{}).executeStepWithRetries(context.Background(), &Step{Name: "x", Action: ActionHostExec}, 0, 1, RunOptions{})
+	if flaky.calls != 1 || out.Result.Attempts != 0 || out.Result.MaxAttempts != 0 {
+		t.Errorf("calls=%d attempts=%d/%d, want one run and no attempt count", flaky.calls, out.Result.Attempts, out.Result.MaxAttempts)
+	}
+}
+
+// TestExecuteStepWithRetries_Cancelled: a cancelled run stops retrying
+// during the wait instead of sleeping out the remaining attempts.
+func TestExecuteStepWithRetries_Cancelled(t *testing.T) {
+	flaky := &flakyExecutor{failures: 5}
+	withExecutor(t, ActionHostExec, flaky)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	step := &Step{Name: "x", Action: ActionHostExec, Retries: 3, RetryInterval: time.Hour}
+	out := (&Runner{}).executeStepWithRetries(ctx, step, 0, 1, RunOptions{})
+	if flaky.calls != 1 || out.Result.Attempts != 1 || out.Result.MaxAttempts != 4 {
+		t.Errorf("calls=%d attempts=%d/%d, want 1 run of 4", flaky.calls, out.Result.Attempts, out.Result.MaxAttempts)
+	}
+}
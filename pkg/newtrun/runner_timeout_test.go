@@ -0,0 +1,149 @@
+package newtrun
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingExecutor runs until its context is canceled.
+type blockingExecutor struct{}
+
+func (blockingExecutor) Execute(ctx context.Context, _ *Runner, _ *Step) *StepOutput {
+	<-ctx.Done()
+	return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: ctx.Err().Error()}}
+}
+
+// recordingExecutor passes, recording each step it ran.
+type recordingExecutor struct {
+	mu  sync.Mutex
+	ran []string
+}
+
+func (e *recordingExecutor) Execute(_ context.Context, _ *Runner, step *Step) *StepOutput {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.ran = append(e.ran, step.Name)
+	return &StepOutput{Result: &StepResult{Status: StepStatusPassed}}
+}
+
+// TestRunScenarioSteps_TimeoutMidStep: a scenario whose timeout expires
+// while a step is running cancels that step (ERROR), skips the steps after
+// it without executing them, still runs cleanup, and ends in ERROR.
+func TestRunScenarioSteps_TimeoutMidStep(t *testing.T) {
+	rec := &recordingExecutor{}
+	withExecutor(t, ActionHostExec, blockingExecutor{})
+	withExecutor(t, ActionWait, rec)
+
+	scenario := &Scenario{
+		Name:    "slow",
+		Timeout: 20 * time.Millisecond,
+		Steps: []Step{
+			{Name: "settle", Action: ActionWait},
+			{Name: "hang", Action: ActionHostExec, Command: "sleep infinity"},
+			{Name: "after-1", Action: ActionWait},
+			{Name: "after-2", Action: ActionWait},
+		},
+		Cleanup: []Step{{Name: "teardown", Action: ActionWait}},
+	}
+	result := &ScenarioResult{Name: "slow"}
+	start := time.Now()
+	(&Runner{}).runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("scenario took %s; the timeout did not cancel the running step", elapsed)
+	}
+	if want := []string{"settle", "teardown"}; strings.Join(rec.ran, ",") != strings.Join(want, ",") {
+		t.Errorf("executed %v, want %v", rec.ran, want)
+	}
+	if result.Status != StepStatusError {
+		t.Errorf("scenario status = %s, want ERROR", result.Status)
+	}
+
+	want := []struct {
+		name   string
+		status StepStatus
+	}{
+		{"settle", StepStatusPassed},
+		{"hang", StepStatusError},
+		{"after-1", StepStatusSkipped},
+		{"after-2", StepStatusSkipped},
+		{"cleanup/teardown", StepStatusPassed},
+	}
+	if len(result.Steps) != len(want) {
+		t.Fatalf("got %d step results, want %d: %+v", len(result.Steps), len(want), result.Steps)
+	}
+	for i, w := range want {
+		sr := result.Steps[i]
+		if sr.Name != w.name || sr.Status != w.status {
+			t.Errorf("step %d = %s %s, want %s %s", i, sr.Name, sr.Status, w.name, w.status)
+		}
+	}
+	if msg := result.Steps[1].Message; !strings.HasPrefix(msg, "scenario timeout") {
+		t.Errorf("timed-out step message = %q, want scenario timeout prefix", msg)
+	}
+	for _, sr := range result.Steps[2:4] {
+		if sr.Message != "scenario timeout" {
+			t.Errorf("%s: message = %q, want %q", sr.Name, sr.Message, "scenario timeout")
+		}
+	}
+}
+
+// TestRunScenarioSteps_TimeoutNotReached: a scenario that finishes inside
+// its timeout is unaffected by it.
+func TestRunScenarioSteps_TimeoutNotReached(t *testing.T) {
+	withExecutor(t, ActionWait, &recordingExecutor{})
+
+	scenario := &Scenario{
+		Name:    "quick",
+		Timeout: time.Minute,
+		Steps:   []Step{{Name: "a", Action: ActionWait}, {Name: "b", Action: ActionWait}},
+	}
+	result := &ScenarioResult{Name: "quick"}
+	(&Runner{}).runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+	if result.Status != StepStatusPassed || len(result.Steps) != 2 {
+		t.Errorf("status = %s with %d steps, want PASSED with 2", result.Status, len(result.Steps))
+	}
+}
+
+// TestIterateScenarios_SuiteDeadline: once the suite deadline has passed no
+// further scenario runs; each is recorded as skipped and the run fails
+// rather than aborts.
+func TestIterateScenarios_SuiteDeadline(t *testing.T) {
+	ctx, cancel := context.WithDeadlineCause(context.Background(), time.Now(), errSuiteDeadline)
+	defer cancel()
+
+	scenarios := []*Scenario{{Name: "a"}, {Name: "b"}}
+	ran := 0
+	r := &Runner{}
+	results, err := r.iterateScenarios(ctx, scenarios, RunOptions{Deadline: time.Minute}, "", func(_ context.Context, sc *Scenario, _ string) (*ScenarioResult, error) {
+		ran++
+		return &ScenarioResult{Name: sc.Name, Status: StepStatusPassed}, nil
+	})
+	if ran != 0 {
+		t.Errorf("ran %d scenarios after the deadline, want 0", ran)
+	}
+	if err == nil || !strings.Contains(err.Error(), "suite deadline of 1m0s exceeded") {
+		t.Errorf("err = %v, want suite deadline exceeded", err)
+	}
+	if got := SuiteStatusFromOutcome(err, results); got != SuiteStatusFailed {
+		t.Errorf("suite status = %s, want %s", got, SuiteStatusFailed)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	for _, res := range results {
+		if res.Status != StepStatusSkipped || res.SkipReason != "suite deadline (1m0s) exceeded" {
+			t.Errorf("%s: %s %q, want SKIPPED by suite deadline", res.Name, res.Status, res.SkipReason)
+		}
+	}
+}
+
+func TestParseScenarioBytes_NegativeTimeout(t *testing.T) {
+	_, err := ParseScenarioBytes([]byte("name: s\ntimeout: -1s\nsteps:\n  - name: w\n    action: wait\n    duration: 1s\n"))
+	if err == nil || !strings.Contains(err.Error(), "timeout must not be negative") {
+		t.Errorf("err = %v, want negative timeout rejected", err)
+	}
+}
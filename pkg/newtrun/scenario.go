@@ -40,6 +40,31 @@ type Scenario struct {
 	RequiresParams   []string `yaml:"requires_params,omitempty"`   // Suite-level parameters that must be set to a non-empty/non-zero value at run time; otherwise the scenario is skipped with a descriptive reason
 	Repeat           int      `yaml:"repeat,omitempty"`
 
+	// SkipIf is a jq expression over {"platform": <platform spec>,
+	// "params": <effective parameters>}, evaluated just before the
+	// scenario would run; true skips it with the condition as the reason
+	// (e.g. '.platform.dataplane == "sonic-vs"'). See skip_if.go.
+	SkipIf string `yaml:"skip_if,omitempty"`
+
+	// ParallelSafe declares that the scenario's steps mutate no shared
+	// state — pure reads and verifications — so its repeat iterations may
+	// overlap. RepeatParallel, when > 1, runs up to that many repeat
+	// iterations at once instead of one after another; it requires
+	// ParallelSafe and Repeat > 1 (validated at parse time). Concurrent
+	// iterations share the runner, so steps that write runner state
+	// (capture:, snapshot) are rejected too. Fail-fast is kept in spirit:
+	// once an iteration fails no further iterations start, the ones
+	// already in flight finish, and FailedIteration is the lowest failure.
+	ParallelSafe   bool `yaml:"parallel_safe,omitempty"`
+	RepeatParallel int  `yaml:"repeat_parallel,omitempty"`
+
+	// Timeout bounds the wall time of the scenario's steps (every repeat
+	// and target iteration; cleanup is not counted). When it expires the
+	// running step is canceled and reported ERROR, the steps that had not
+	// started are reported SKIPPED with reason "scenario timeout", and the
+	// scenario is ERROR. Cleanup still runs. 0 means no bound.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
 	// Cleanup steps run once per scenario, AFTER all iterations and repeats,
 	// regardless of pass/fail — fabric-state teardown must not depend on the
 	// scenario's outcome (a failed scenario that strands device state
@@ -80,6 +105,16 @@ type Scenario struct {
 	// all when the run was triggered without one.
 	As string `yaml:"as,omitempty"`
 
+	// Redact masks secrets in every step's output before it is reported
+	// or written as an artifact: captured.NAME entries mask a captured
+	// value, any other entry is a regex (see redact.go). Step.Redact adds
+	// to this list for a single step.
+	Redact []string `yaml:"redact,omitempty"`
+
+	// Vars overrides the suite's vars: for this scenario's steps, and
+	// Step.Vars overrides both for one step (vars.go).
+	Vars map[string]any `yaml:"vars,omitempty"`
+
 	Steps []Step `yaml:"steps"`
 }
 
@@ -107,6 +142,10 @@ type Step struct {
 	URL    string      `yaml:"url,omitempty"`    // URL template (e.g., /node/{{device}}/vlan)
 	Poll   *PollBlock  `yaml:"poll,omitempty"`   // polling configuration
 	Batch  []BatchCall `yaml:"batch,omitempty"`  // sequential batch of calls
+	// Interfaces fans a {{device}}/{{interface}}-templated call out over each
+	// device's interfaces — named explicitly, or selected by topology role
+	// (interfaces: {role: uplink}) and resolved per device by newtron-server.
+	Interfaces *interfaceSelector `yaml:"interfaces,omitempty"`
 	// Headers attaches per-step HTTP headers to outbound newtron
 	// requests. Under PR D the canonical "different identity" path
 	// is splitting scenarios with `as:`; Headers is reserved for
@@ -136,38 +175,72 @@ type Step struct {
 	Parameters map[string]any      `yaml:"parameters,omitempty"` // parameter overrides for the called suite
 	Targets    map[string][]string `yaml:"targets,omitempty"`    // target-dimension overrides for the called suite
 
+	// ForEach runs the step once per item of a list — a literal list or a
+	// {{param.X}} / {{captured.X}} reference resolving to one — with the
+	// item substituted for {{item}} (see foreach.go). Any action.
+	ForEach *forEachSource `yaml:"for_each,omitempty"`
+
 	// All actions
-	Expect        *ExpectBlock `yaml:"expect,omitempty"`
-	ExpectFailure bool         `yaml:"expect_failure,omitempty"`
+	Expect        *ExpectBlock   `yaml:"expect,omitempty"`
+	ExpectFailure bool           `yaml:"expect_failure,omitempty"`
+	Redact        []string       `yaml:"redact,omitempty"` // step-only additions to Scenario.Redact
+	Vars          map[string]any `yaml:"vars,omitempty"`   // step-only overrides of Scenario.Vars
+
+	// MaxOutputBytes caps the command output a step keeps in its result
+	// (StepResult.Output, DeviceResult.Output) — the tail is kept, where a
+	// failing command says why. 0 means defaultMaxOutputBytes.
+	MaxOutputBytes int `yaml:"max_output_bytes,omitempty"`
+
+	// Observe has a verify step report the state it judged per device
+	// (StepResult.Observed) — the input to a golden record/compare run
+	// (see golden.go). Only observableActions support it.
+	Observe bool `yaml:"observe,omitempty"`
+
+	// Retries re-runs a failing step up to that many more times before its
+	// status is final — for transient SSH or daemon-settle failures. The
+	// wait before the first retry is RetryInterval (default 1s) and doubles
+	// before each one after. Only one-shot actions retry (retryableActions):
+	// a step that already polls would retry its whole poll.
+	Retries       int           `yaml:"retries,omitempty"`
+	RetryInterval time.Duration `yaml:"retry_interval,omitempty"`
 }
 
 // StepAction identifies the type of step to execute.
 type StepAction string
 
 const (
-	ActionProvision          StepAction = "topology-reconcile"
-	ActionWait               StepAction = "wait"
-	ActionVerifyProvisioning StepAction = "verify-topology"
-	ActionHostExec           StepAction = "host-exec"
-	ActionNewtron            StepAction = "newtron"
-	ActionNewtronCLI         StepAction = "newtron-cli"
-	ActionRunSuite           StepAction = "run-suite"
-	ActionSnapshot           StepAction = "snapshot"
-	ActionVerifySnapshot     StepAction = "verify-snapshot"
+	ActionProvision               StepAction = "topology-reconcile"
+	ActionWait                    StepAction = "wait"
+	ActionVerifyProvisioning      StepAction = "verify-topology"
+	ActionHostExec                StepAction = "host-exec"
+	ActionNewtron                 StepAction = "newtron"
+	ActionNewtronCLI              StepAction = "newtron-cli"
+	ActionRunSuite                StepAction = "run-suite"
+	ActionSnapshot                StepAction = "snapshot"
+	ActionVerifySnapshot          StepAction = "verify-snapshot"
+	ActionWaitConverged           StepAction = "wait-converged"
+	ActionVerifyAnycast           StepAction = "verify-anycast"
+	ActionVerifyVLANMembership    StepAction = "verify-vlan-membership"
+	ActionVerifyEnvironment       StepAction = "verify-environment"
+	ActionGenerateTraffic         StepAction = "generate-traffic"
+	ActionVerifyRouteLeak         StepAction = "verify-route-leak"
+	ActionVerifyPing              StepAction = "verify-ping"
+	ActionVerifyPing6             StepAction = "verify-ping6"
+	ActionVerifyVXLANStats        StepAction = "verify-vxlan-stats"
+	ActionVerifyEgressShaper      StepAction = "verify-egress-shaper"
+	ActionVerifyBGP               StepAction = "verify-bgp"
+	ActionVerifyLog               StepAction = "verify-log"
+	ActionVerifyDHCPLease         StepAction = "verify-dhcp-lease"
+	ActionVerifyChangeSet         StepAction = "verify-changeset"
+	ActionVerifyLLDP              StepAction = "verify-lldp"
+	ActionVerifyARPSuppression    StepAction = "verify-arp-suppression"
+	ActionVerifyInterfaceCounters StepAction = "verify-interface-counters"
+	ActionVerifyEVPNType2         StepAction = "verify-evpn-type2"
+	ActionVerifyLAGDistribution   StepAction = "verify-lag-distribution"
+	ActionVerifyIGMPSnooping      StepAction = "verify-igmp-snooping"
+	ActionVerifyNoFlaps           StepAction = "verify-no-flaps"
 )
 
-// validActions is the set of all recognized step actions, derived from the
-// executors map in steps.go at init time. This avoids manual synchronization
-// between the two maps.
-var validActions map[StepAction]bool
-
-func init() {
-	validActions = make(map[StepAction]bool, len(executors))
-	for action := range executors {
-		validActions[action] = true
-	}
-}
-
 // deviceSelector handles the two YAML forms for the "devices" field:
 //
 //	devices: all           → All: true
@@ -202,6 +275,43 @@ func (ds *deviceSelector) Resolve(allDevices []string) []string {
 	return ds.Devices
 }
 
+// interfaceSelector handles the two YAML forms for the "interfaces" field:
+//   - list: interfaces: [Ethernet0, Ethernet4]
+//   - role: interfaces: {role: uplink}   (uplink, downlink, or fabric)
+//
+// A role is resolved per device against the topology links, so one step
+// reaches "every leaf's uplinks" without naming them.
+type interfaceSelector struct {
+	Names []string
+	Role  string
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (is *interfaceSelector) UnmarshalYAML(unmarshal func(any) error) error {
+	var names []string
+	if err := unmarshal(&names); err == nil {
+		is.Names = names
+		return nil
+	}
+	var m struct {
+		Role string `yaml:"role"`
+	}
+	if err := unmarshal(&m); err != nil {
+		return fmt.Errorf("invalid interface selector (expected a list of names or {role: uplink|downlink|fabric}): %w", err)
+	}
+	is.Role = m.Role
+	return nil
+}
+
+// Resolve returns the interfaces to target on device: the explicit names, or
+// the device's interfaces with the selector's role.
+func (is *interfaceSelector) Resolve(r *Runner, device string) ([]string, error) {
+	if is.Role == "" {
+		return is.Names, nil
+	}
+	return r.Client.InterfacesByRole(device, is.Role)
+}
+
 // PollBlock configures polling for the newtron and host-exec actions.
 type PollBlock struct {
 	Timeout  time.Duration `yaml:"timeout"`
@@ -225,6 +335,19 @@ type ExpectBlock struct {
 	SuccessRate *float64 `yaml:"success_rate,omitempty"`
 	Contains    string   `yaml:"contains,omitempty"`
 
+	// generate-traffic
+	MinThroughput string   `yaml:"min_throughput,omitempty"` // bits/s, K/M/G suffixes
+	MaxLoss       *float64 `yaml:"max_loss,omitempty"`       // percent, udp only
+
 	// newtron (generic server action) — jq expression evaluated against response body
 	JQ string `yaml:"jq,omitempty"`
+
+	// verify-route-leak — checked against each leaked route's next hops
+	NextHopCount     *int   `yaml:"next_hop_count,omitempty"`     // exact number of next hops (ECMP width)
+	NextHopInterface string `yaml:"next_hop_interface,omitempty"` // must appear among the next hops
+
+	// any action — soft performance bound. Unlike a timeout it aborts
+	// nothing: the step runs to completion, and if it passed but took longer
+	// it fails with the overage recorded (StepResult.Overage).
+	MaxDuration time.Duration `yaml:"max_duration,omitempty"`
 }
@@ -0,0 +1,90 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/itchyny/gojq"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// skip_if: a scenario-level skip condition, for scenarios that make no
+// sense on some platforms or parameter settings — one that needs real
+// forwarding, say, on a simulator. Where requires_features asks the
+// platform a fixed question, skip_if is a jq expression over the run's
+// platform spec and effective parameters:
+//
+//	skip_if: '.platform.dataplane == "sonic-vs"'
+//	skip_if: '.params.traffic_rate == 0 or (.platform.max_ecmp_paths // 256) < 4'
+//
+// A true result skips the scenario with the condition as its reason;
+// false runs it. Anything else — a non-boolean, a jq runtime error, an
+// unreadable platform — is the scenario's ERROR, not a skip: a broken
+// condition must not hide the scenario.
+
+// skipIfInput is the document a skip_if expression runs against.
+type skipIfInput struct {
+	Platform *spec.PlatformSpec `json:"platform"`
+	Params   map[string]any     `json:"params"`
+}
+
+// validateSkipIf checks at parse time that a scenario's skip_if parses.
+func validateSkipIf(sc *Scenario) error {
+	if sc.SkipIf == "" {
+		return nil
+	}
+	if _, err := gojq.Parse(sc.SkipIf); err != nil {
+		return fmt.Errorf("scenario %q: skip_if: %w", sc.Name, err)
+	}
+	return nil
+}
+
+// skipIfReason evaluates sc.SkipIf against platform and params. Returns the
+// skip reason when the condition holds, "" when it does not or is unset.
+func skipIfReason(sc *Scenario, platform *spec.PlatformSpec, params map[string]any) (string, error) {
+	if sc.SkipIf == "" {
+		return "", nil
+	}
+	if params == nil {
+		params = map[string]any{}
+	}
+	raw, err := json.Marshal(skipIfInput{Platform: platform, Params: params})
+	if err != nil {
+		return "", fmt.Errorf("skip_if: %w", err)
+	}
+	v, err := runJQ(sc.SkipIf, raw)
+	if err != nil {
+		return "", fmt.Errorf("skip_if: %w", err)
+	}
+	skip, ok := v.(bool)
+	if !ok {
+		return "", fmt.Errorf("skip_if: expression returned %v, want true or false", v)
+	}
+	if !skip {
+		return "", nil
+	}
+	if platform.Name == "" {
+		return fmt.Sprintf("skip_if holds: %s", sc.SkipIf), nil
+	}
+	return fmt.Sprintf("skip_if holds on platform '%s': %s", platform.Name, sc.SkipIf), nil
+}
+
+// checkSkipIf evaluates the scenario's skip_if against the run's platform
+// (resolved as checkPlatformFeatures resolves it) and effective parameters.
+// Without a server connection only the platform's name is known.
+func (r *Runner) checkSkipIf(sc *Scenario, deployedPlatform, scenarioPlatform string) (string, error) {
+	if sc.SkipIf == "" {
+		return "", nil
+	}
+	name := r.platformName(deployedPlatform, scenarioPlatform)
+	platform := &spec.PlatformSpec{Name: name}
+	if r.Client != nil && name != "" {
+		p, err := r.Client.ShowPlatform(name)
+		if err != nil {
+			return "", fmt.Errorf("skip_if: reading platform %s: %w", name, err)
+		}
+		platform = p
+	}
+	return skipIfReason(sc, platform, r.resolvedParameters)
+}
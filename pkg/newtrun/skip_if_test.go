@@ -0,0 +1,107 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+func TestSkipIfReason(t *testing.T) {
+	vs := &spec.PlatformSpec{Name: "Force10-S6000_vs", Dataplane: "sonic-vs"}
+	vpp := &spec.PlatformSpec{Name: "Force10-S6000_vpp", Dataplane: "vpp"}
+	tests := []struct {
+		name     string
+		skipIf   string
+		platform *spec.PlatformSpec
+		params   map[string]any
+		want     string
+		wantErr  string
+	}{
+		{"unset", "", vs, nil, "", ""},
+		{"dataplane matches", `.platform.dataplane == "sonic-vs"`, vs, nil,
+			`skip_if holds on platform 'Force10-S6000_vs': .platform.dataplane == "sonic-vs"`, ""},
+		{"dataplane differs", `.platform.dataplane == "sonic-vs"`, vpp, nil, "", ""},
+		{"param", `.params.rate == 0`, vpp, map[string]any{"rate": 0}, `skip_if holds on platform 'Force10-S6000_vpp': .params.rate == 0`, ""},
+		{"param unset", `.params.rate == null`, &spec.PlatformSpec{}, nil, "skip_if holds: .params.rate == null", ""},
+		{"not a boolean", `.platform.dataplane`, vs, nil, "", "expression returned sonic-vs, want true or false"},
+		{"runtime error", `.platform.name | tonumber`, vs, nil, "", "skip_if: jq eval"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := skipIfReason(&Scenario{Name: "s", SkipIf: tt.skipIf}, tt.platform, tt.params)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("skipIfReason = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+// TestIterateScenarios_SkipIf: skip_if reads the run's platform from the
+// server. A scenario whose condition holds is skipped with it as the
+// reason, one whose condition cannot be evaluated is ERROR — and either
+// way its dependents are skipped — and one whose condition is false runs.
+func TestIterateScenarios_SkipIf(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": spec.PlatformSpec{Name: "Force10-S6000_vs", Dataplane: "sonic-vs"}})
+	}))
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	scenarios := []*Scenario{
+		{Name: "traffic", SkipIf: `.platform.dataplane == "sonic-vs"`},
+		{Name: "traffic-report", Requires: []string{"traffic"}},
+		{Name: "broken", SkipIf: `.platform.dataplane`},
+		{Name: "control-plane", SkipIf: `.platform.dataplane == "vpp"`},
+	}
+	var ran []string
+	results, err := r.iterateScenarios(context.Background(), scenarios, RunOptions{}, "Force10-S6000_vs", func(_ context.Context, sc *Scenario, _ string) (*ScenarioResult, error) {
+		ran = append(ran, sc.Name)
+		return &ScenarioResult{Name: sc.Name, Status: StepStatusPassed}, nil
+	})
+	if err != nil {
+		t.Fatalf("iterateScenarios: %v", err)
+	}
+	if strings.Join(ran, ",") != "control-plane" {
+		t.Errorf("ran %v, want [control-plane]", ran)
+	}
+	want := []struct {
+		status StepStatus
+		reason string
+	}{
+		{StepStatusSkipped, `skip_if holds on platform 'Force10-S6000_vs': .platform.dataplane == "sonic-vs"`},
+		{StepStatusSkipped, "requires 'traffic' which was skipped"},
+		{StepStatusError, ""},
+		{StepStatusPassed, ""},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for i, w := range want {
+		if res := results[i]; res.Status != w.status || res.SkipReason != w.reason {
+			t.Errorf("%s: %s %q, want %s %q", res.Name, res.Status, res.SkipReason, w.status, w.reason)
+		}
+	}
+	if err := results[2].DeployError; err == nil || !strings.Contains(err.Error(), "want true or false") {
+		t.Errorf("broken: error = %v, want the non-boolean result named", err)
+	}
+}
+
+func TestParseScenarioBytes_BadSkipIf(t *testing.T) {
+	_, err := ParseScenarioBytes([]byte("name: s\nskip_if: '.platform.dataplane =='\nsteps:\n  - name: w\n    action: wait\n    duration: 1s\n"))
+	if err == nil || !strings.Contains(err.Error(), `scenario "s": skip_if`) {
+		t.Errorf("err = %v, want skip_if parse error", err)
+	}
+}
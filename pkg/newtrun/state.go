@@ -68,15 +68,17 @@ func SuiteStatusFromOutcome(runErr error, results []*ScenarioResult) SuiteStatus
 // name to bytes is server-internal and must not leak through the
 // wire shape (§33 Public API Boundary).
 type RunState struct {
-	Suite     string          `json:"suite"`
-	Network   string          `json:"network"`
-	Platform  string          `json:"platform"`
-	Target    string          `json:"target,omitempty"` // --target scenario (empty = all)
-	Status    SuiteStatus     `json:"status"`
-	Started   time.Time       `json:"started"`
-	Updated   time.Time       `json:"updated"`
-	Finished  time.Time       `json:"finished,omitempty"` // set when suite completes (pass/fail/error)
-	Scenarios []ScenarioState `json:"scenarios"`
+	Suite       string          `json:"suite"`
+	RunID       string          `json:"run_id,omitempty"`       // this run's ID (see results.go)
+	ResumedFrom string          `json:"resumed_from,omitempty"` // ID of the paused run this one resumed
+	Network     string          `json:"network"`
+	Platform    string          `json:"platform"`
+	Target      string          `json:"target,omitempty"` // --target scenario (empty = all)
+	Status      SuiteStatus     `json:"status"`
+	Started     time.Time       `json:"started"`
+	Updated     time.Time       `json:"updated"`
+	Finished    time.Time       `json:"finished,omitempty"` // set when suite completes (pass/fail/error)
+	Scenarios   []ScenarioState `json:"scenarios"`
 }
 
 // ScenarioState tracks the outcome of a single scenario within a suite run.
@@ -104,12 +106,19 @@ type ScenarioState struct {
 // Phase 2b which is upstream-deferred. The slice is empty when no
 // producer fed events.
 type StepState struct {
-	Name      string           `json:"name"`
-	Action    string           `json:"action"`
-	Status    string           `json:"status"`   // "PASS","FAIL","SKIP","ERROR"
-	Duration  string           `json:"duration"` // e.g. "2s", "<1s"
-	Message   string           `json:"message,omitempty"`
-	DeviceOps []sonic.DeviceOp `json:"device_ops,omitempty"`
+	Name        string           `json:"name"`
+	Action      string           `json:"action"`
+	Status      string           `json:"status"`   // "PASS","FAIL","SKIP","ERROR"
+	Duration    string           `json:"duration"` // e.g. "2s", "<1s"
+	Message     string           `json:"message,omitempty"`
+	Output      string           `json:"output,omitempty"`       // captured command output (StepResult.Output)
+	Item        string           `json:"item,omitempty"`         // for_each item (StepResult.Item)
+	MaxDuration string           `json:"max_duration,omitempty"` // expect.max_duration, when set
+	Overage     string           `json:"overage,omitempty"`      // time past max_duration, when over
+	Attempts    int              `json:"attempts,omitempty"`     // runs of a step with retries
+	MaxAttempts int              `json:"max_attempts,omitempty"` // retries + 1, when set
+	DeviceOps   []sonic.DeviceOp `json:"device_ops,omitempty"`
+	Artifacts   []string         `json:"artifacts,omitempty"` // failure dumps (RunOptions.OnVerifyFailure)
 }
 
 // StateDir returns the state directory path for a suite name.
@@ -12,31 +12,32 @@ import (
 	"github.com/aldrin-isaac/newtron/pkg/newtron"
 )
 
-// stepExecutor executes a single step and returns output.
-type stepExecutor interface {
-	Execute(ctx context.Context, r *Runner, step *Step) *StepOutput
-}
-
-// StepOutput is the return value from every executor.
-type StepOutput struct {
-	Result *StepResult
+// deviceLimiter bounds how many devices one step works on at once. nil
+// means no bound — every device's callback runs concurrently.
+type deviceLimiter chan struct{}
+
+// deviceLimiter returns a limiter with RunOptions.Parallelism slots, or nil
+// when Parallelism is 0.
+func (r *Runner) deviceLimiter() deviceLimiter {
+	if r.opts.Parallelism <= 0 {
+		return nil
+	}
+	return make(deviceLimiter, r.opts.Parallelism)
 }
 
-// executors maps each StepAction to its executor implementation.
-var executors = map[StepAction]stepExecutor{
-	ActionProvision:          &provisionExecutor{},
-	ActionWait:               &waitExecutor{},
-	ActionVerifyProvisioning: &verifyProvisioningExecutor{},
-	ActionHostExec:           &hostExecExecutor{},
-	ActionNewtron:            &newtronExecutor{},
-	ActionNewtronCLI:         &newtronCLIExecutor{},
-	ActionRunSuite:           &runSuiteExecutor{},
-	ActionSnapshot:           &snapshotExecutor{},
-	ActionVerifySnapshot:     &verifySnapshotExecutor{},
+// acquire waits for a slot and returns the func that releases it.
+func (l deviceLimiter) acquire() (release func()) {
+	if l == nil {
+		return func() {}
+	}
+	l <- struct{}{}
+	return func() { <-l }
 }
 
 // executeForDevices runs an operation on all target devices in parallel and collects results.
 // The callback fn receives the device name, returning a human-readable message and an error.
+// At most RunOptions.Parallelism callbacks run at once; details keep the resolved
+// device order, and one device's error never stops the others.
 func (r *Runner) executeForDevices(step *Step, fn func(name string) (string, error)) *StepOutput {
 	names := r.resolveDevices(step)
 	if len(names) == 0 {
@@ -46,6 +47,7 @@ func (r *Runner) executeForDevices(step *Step, fn func(name string) (string, err
 		}}
 	}
 	details := make([]DeviceResult, len(names))
+	limit := r.deviceLimiter()
 
 	var wg sync.WaitGroup
 	for i, name := range names {
@@ -58,6 +60,7 @@ func (r *Runner) executeForDevices(step *Step, fn func(name string) (string, err
 		wg.Add(1)
 		go func(idx int, dev string) {
 			defer wg.Done()
+			defer limit.acquire()()
 			msg, err := fn(dev)
 			if err != nil {
 				details[idx] = DeviceResult{Device: dev, Status: StepStatusError, Message: err.Error()}
@@ -102,6 +105,7 @@ pollLoop:
 
 // checkForDevices resolves devices, calls fn for each in parallel, and collects results.
 // Use for non-polling verification executors. The callback returns status and message.
+// Bounded and ordered like executeForDevices.
 func (r *Runner) checkForDevices(step *Step, fn func(name string) (StepStatus, string)) *StepOutput {
 	names := r.resolveDevices(step)
 	if len(names) == 0 {
@@ -111,6 +115,7 @@ func (r *Runner) checkForDevices(step *Step, fn func(name string) (StepStatus, s
 		}}
 	}
 	details := make([]DeviceResult, len(names))
+	limit := r.deviceLimiter()
 
 	var wg sync.WaitGroup
 	for i, name := range names {
@@ -123,6 +128,7 @@ func (r *Runner) checkForDevices(step *Step, fn func(name string) (StepStatus, s
 		wg.Add(1)
 		go func(idx int, dev string) {
 			defer wg.Done()
+			defer limit.acquire()()
 			st, msg := fn(dev)
 			details[idx] = DeviceResult{Device: dev, Status: st, Message: msg}
 		}(i, name)
@@ -154,6 +160,7 @@ func (r *Runner) pollForDevices(ctx context.Context, step *Step, fn func(name st
 
 	timeout := step.Expect.Timeout
 	interval := step.Expect.PollInterval
+	limit := r.deviceLimiter()
 
 	var wg sync.WaitGroup
 	for i, name := range names {
@@ -166,6 +173,7 @@ func (r *Runner) pollForDevices(ctx context.Context, step *Step, fn func(name st
 		wg.Add(1)
 		go func(idx int, dev string) {
 			defer wg.Done()
+			defer limit.acquire()()
 
 			var matched bool
 			var lastMsg string
@@ -261,6 +269,7 @@ func (e *verifyProvisioningExecutor) Execute(ctx context.Context, r *Runner, ste
 		if err != nil {
 			return StepStatusError, fmt.Sprintf("drift check error: %s", err)
 		}
+		observe(ctx, name, entries)
 		if len(entries) == 0 {
 			return StepStatusPassed, "no drift — device matches topology projection"
 		}
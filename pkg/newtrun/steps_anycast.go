@@ -0,0 +1,242 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The verify-anycast step is a fabric-level check: every leaf serving an
+// anycast IRB must present the same gateway to hosts.
+//
+//	- name: anycast-consistent
+//	  action: verify-anycast
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    vlans: [100, 200]   # optional; default: every VLAN with an SVI on any target
+//
+// Per device it reads the SAG_GLOBAL anycast MAC and the VLAN_INTERFACE
+// gateway IPs, then compares them across the targets. A per-device check
+// cannot see this class of bug — each leaf is self-consistent, they just
+// disagree — and a mismatched anycast MAC shows up as intermittent forwarding
+// loss when a host's traffic lands on the other leaf.
+//
+// The reference value for each comparison is the one most targets agree on
+// (ties broken by device name); every device that differs FAILs with its own
+// value and the expected one.
+
+// anycastParams is the params: shape of a verify-anycast step.
+type anycastParams struct {
+	VLANs []int `json:"vlans"`
+}
+
+// anycastView is one device's anycast gateway configuration.
+type anycastView struct {
+	mac  string              // SAG_GLOBAL|IPv4 gwmac; "" when absent
+	svis map[string][]string // VLAN name → sorted gateway IP prefixes
+}
+
+func decodeAnycastParams(step *Step) (anycastParams, error) {
+	var p anycastParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	for _, id := range p.VLANs {
+		if id < 1 || id > 4094 {
+			return p, fmt.Errorf("params.vlans: %d is not a valid VLAN ID", id)
+		}
+	}
+	return p, nil
+}
+
+// requireAnycastParams validates a verify-anycast step at parse time.
+func requireAnycastParams(prefix string, step *Step) error {
+	if _, err := decodeAnycastParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// readAnycastView reads a device's SAG_GLOBAL MAC and SVI gateway IPs.
+func (r *Runner) readAnycastView(dev string) (*anycastView, error) {
+	sag, err := r.Client.QueryConfigDB(dev, "SAG_GLOBAL", "IPv4")
+	if err != nil {
+		return nil, fmt.Errorf("reading SAG_GLOBAL: %w", err)
+	}
+	keys, err := r.Client.ConfigDBTableKeys(dev, "VLAN_INTERFACE")
+	if err != nil {
+		return nil, fmt.Errorf("reading VLAN_INTERFACE: %w", err)
+	}
+	v := &anycastView{mac: strings.ToLower(sag["gwmac"]), svis: map[string][]string{}}
+	for _, k := range keys {
+		vlan, ip, hasIP := strings.Cut(k, "|")
+		if _, ok := v.svis[vlan]; !ok {
+			v.svis[vlan] = nil
+		}
+		if hasIP {
+			v.svis[vlan] = append(v.svis[vlan], ip)
+		}
+	}
+	for _, ips := range v.svis {
+		sort.Strings(ips)
+	}
+	return v, nil
+}
+
+// verifyAnycastExecutor asserts anycast gateway consistency across devices.
+type verifyAnycastExecutor struct{}
+
+func (e *verifyAnycastExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeAnycastParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	// Read every target in parallel; the comparison needs them all.
+	var mu sync.Mutex
+	views := map[string]*anycastView{}
+	out := r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		v, err := r.readAnycastView(dev)
+		if err != nil {
+			return StepStatusError, err.Error()
+		}
+		mu.Lock()
+		views[dev] = v
+		mu.Unlock()
+		return StepStatusPassed, ""
+	})
+	if out.Result.Status != StepStatusPassed {
+		return out
+	}
+
+	vlans := make([]string, 0, len(params.VLANs))
+	for _, id := range params.VLANs {
+		vlans = append(vlans, fmt.Sprintf("Vlan%d", id))
+	}
+	if len(vlans) == 0 {
+		seen := map[string]bool{}
+		for _, v := range views {
+			for vlan := range v.svis {
+				if !seen[vlan] {
+					seen[vlan] = true
+					vlans = append(vlans, vlan)
+				}
+			}
+		}
+		sort.Strings(vlans)
+	}
+
+	problems := compareAnycastViews(views, vlans, len(params.VLANs) > 0)
+
+	status := StepStatusPassed
+	for i := range out.Result.Details {
+		d := &out.Result.Details[i]
+		if d.Status == StepStatusSkipped {
+			continue
+		}
+		v := views[d.Device]
+		if p := problems[d.Device]; len(p) > 0 {
+			d.Status = StepStatusFailed
+			d.Message = strings.Join(p, "; ")
+			status = StepStatusFailed
+			continue
+		}
+		served := 0
+		for _, vlan := range vlans {
+			if _, ok := v.svis[vlan]; ok {
+				served++
+			}
+		}
+		if served == 0 {
+			d.Message = "no anycast SVIs"
+		} else {
+			d.Message = fmt.Sprintf("gwmac %s, %d SVI(s) consistent", orNone(v.mac), served)
+		}
+	}
+	out.Result.Status = status
+	return out
+}
+
+// compareAnycastViews returns, per device, what it disagrees on. The MAC is
+// compared across devices serving at least one of vlans; each VLAN's gateway
+// IPs across the devices serving that VLAN. With required, a device lacking
+// a listed VLAN's SVI is itself a problem.
+func compareAnycastViews(views map[string]*anycastView, vlans []string, required bool) map[string][]string {
+	problems := map[string][]string{}
+
+	macs := map[string]string{}
+	for dev, v := range views {
+		for _, vlan := range vlans {
+			if _, ok := v.svis[vlan]; ok {
+				macs[dev] = v.mac
+				break
+			}
+		}
+	}
+	want, from := consensus(macs)
+	for _, dev := range sortedKeys(macs) {
+		if got := macs[dev]; got != want {
+			problems[dev] = append(problems[dev], fmt.Sprintf("gwmac %s, expected %s (as on %s)",
+				orNone(got), orNone(want), from))
+		}
+	}
+
+	for _, vlan := range vlans {
+		ips := map[string]string{}
+		for dev, v := range views {
+			if got, ok := v.svis[vlan]; ok {
+				ips[dev] = strings.Join(got, ",")
+			} else if required {
+				problems[dev] = append(problems[dev], fmt.Sprintf("%s: no SVI", vlan))
+			}
+		}
+		want, from := consensus(ips)
+		for _, dev := range sortedKeys(ips) {
+			if got := ips[dev]; got != want {
+				problems[dev] = append(problems[dev], fmt.Sprintf("%s gateway %s, expected %s (as on %s)",
+					vlan, orNone(got), orNone(want), from))
+			}
+		}
+	}
+	return problems
+}
+
+// consensus returns the value most devices hold and the first device (by
+// name) holding it. Ties go to the value held by the first device by name.
+func consensus(byDevice map[string]string) (value, device string) {
+	counts := map[string]int{}
+	for _, v := range byDevice {
+		counts[v]++
+	}
+	best := -1
+	for _, dev := range sortedKeys(byDevice) {
+		v := byDevice[dev]
+		if counts[v] > best {
+			best, value, device = counts[v], v, dev
+		}
+	}
+	return value, device
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "(none)"
+	}
+	return s
+}
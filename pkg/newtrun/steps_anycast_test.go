@@ -0,0 +1,98 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// anycastServer fakes newtron-server's CONFIG_DB reads: per device, the
+// SAG_GLOBAL gwmac and the VLAN_INTERFACE keys.
+func anycastServer(t *testing.T, macs map[string]string, sviKeys map[string][]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		// .../nodes/{dev}/configdb/{table}[/{key}]
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		parts := strings.Split(rest, "/")
+		dev, table := parts[0], parts[2]
+		var data any
+		switch table {
+		case "SAG_GLOBAL":
+			m := map[string]string{}
+			if mac := macs[dev]; mac != "" {
+				m["gwmac"] = mac
+			}
+			data = m
+		case "VLAN_INTERFACE":
+			data = sviKeys[dev]
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func TestVerifyAnycast_Consistent(t *testing.T) {
+	keys := []string{"Vlan100", "Vlan100|10.1.100.1/24"}
+	srv := anycastServer(t,
+		map[string]string{"leaf1": "00:00:00:00:01:01", "leaf2": "00:00:00:00:01:01"},
+		map[string][]string{"leaf1": keys, "leaf2": keys})
+	defer srv.Close()
+
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	step := &Step{Action: ActionVerifyAnycast, Devices: deviceSelector{Devices: []string{"leaf1", "leaf2"}}}
+	out := (&verifyAnycastExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusPassed {
+		t.Fatalf("verify-anycast should PASS, got %+v", out.Result)
+	}
+}
+
+// TestVerifyAnycast_ReportsOddOneOut: three leaves, one with a different MAC
+// and one missing a listed VLAN's gateway IP. Only those two FAIL, each naming
+// its own value and the majority's.
+func TestVerifyAnycast_ReportsOddOneOut(t *testing.T) {
+	good := []string{"Vlan100", "Vlan100|10.1.100.1/24"}
+	srv := anycastServer(t,
+		map[string]string{"leaf1": "00:00:00:00:01:01", "leaf2": "00:00:00:00:01:01", "leaf3": "00:00:00:00:01:02"},
+		map[string][]string{"leaf1": good, "leaf2": {"Vlan100"}, "leaf3": good})
+	defer srv.Close()
+
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	step := &Step{
+		Action:  ActionVerifyAnycast,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3"}},
+		Params:  map[string]any{"vlans": []any{100}},
+	}
+	out := (&verifyAnycastExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusFailed {
+		t.Fatalf("verify-anycast should FAIL, got %+v", out.Result)
+	}
+	want := map[string]string{
+		"leaf1": "",
+		"leaf2": "Vlan100 gateway (none), expected 10.1.100.1/24 (as on leaf1)",
+		"leaf3": "gwmac 00:00:00:00:01:02, expected 00:00:00:00:01:01 (as on leaf1)",
+	}
+	for _, d := range out.Result.Details {
+		msg := want[d.Device]
+		if msg == "" {
+			if d.Status != StepStatusPassed {
+				t.Errorf("%s: status %s (%s), want passed", d.Device, d.Status, d.Message)
+			}
+			continue
+		}
+		if d.Status != StepStatusFailed || d.Message != msg {
+			t.Errorf("%s: %s %q, want failed %q", d.Device, d.Status, d.Message, msg)
+		}
+	}
+}
+
+func TestVerifyAnycast_ParseValidation(t *testing.T) {
+	step := &Step{Params: map[string]any{"vlans": []any{5000}}}
+	if err := requireAnycastParams("step", step); err == nil || !strings.Contains(err.Error(), "not a valid VLAN ID") {
+		t.Errorf("err = %v, want invalid VLAN ID", err)
+	}
+}
@@ -0,0 +1,104 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-arp-suppression step asserts that EVPN ARP suppression is in
+// effect on a VLAN, on each target device:
+//
+//	- name: vlan100-suppressing
+//	  action: verify-arp-suppression
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    vlan_id: 100
+//	  poll: {timeout: 1m, interval: 5s}
+//
+// Per device it reads every VLAN's suppression state (GET .../evpn/arp-
+// suppression) and PASSes when the VLAN's CONFIG_DB SUPPRESS_VLAN_NEIGH row
+// says suppress=on and vlanmgrd has taken it up into APPL_DB
+// SUPPRESS_VLAN_NEIGH_TABLE. A binding that wrote the CONFIG_DB row is not
+// enough — until the APPL_DB row appears, ARP still floods. With poll:,
+// re-reads until the setting is applied or the timeout expires.
+
+// arpSuppressionParams is the params: shape of a verify-arp-suppression step.
+type arpSuppressionParams struct {
+	VlanID int `json:"vlan_id"`
+}
+
+func decodeARPSuppressionParams(step *Step) (arpSuppressionParams, error) {
+	var p arpSuppressionParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.VlanID < 1 || p.VlanID > 4094 {
+		return p, fmt.Errorf("params.vlan_id is required (1-4094)")
+	}
+	return p, nil
+}
+
+// requireARPSuppressionParams validates a verify-arp-suppression step at
+// parse time.
+func requireARPSuppressionParams(prefix string, step *Step) error {
+	if _, err := decodeARPSuppressionParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyARPSuppressionExecutor asserts a VLAN's ARP suppression per device.
+type verifyARPSuppressionExecutor struct{}
+
+func (e *verifyARPSuppressionExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeARPSuppressionParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		states, err := r.Client.ARPSuppression(dev)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading ARP suppression: %v", err)
+		}
+		return arpSuppressionStatus(states, params.VlanID)
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	return r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		return st == StepStatusPassed, msg, nil
+	})
+}
+
+// arpSuppressionStatus reduces one device's per-VLAN states to a step status
+// for vlanID: PASSED only when suppression is both configured and applied.
+func arpSuppressionStatus(states []newtron.ARPSuppressionState, vlanID int) (StepStatus, string) {
+	vlan := fmt.Sprintf("Vlan%d", vlanID)
+	for _, st := range states {
+		if st.VLAN != vlan {
+			continue
+		}
+		switch {
+		case !st.Configured && st.Intended:
+			return StepStatusFailed, fmt.Sprintf("%s: ARP suppression intended but not configured (no CONFIG_DB SUPPRESS_VLAN_NEIGH suppress=on)", vlan)
+		case !st.Configured:
+			return StepStatusFailed, fmt.Sprintf("%s: ARP suppression not configured", vlan)
+		case !st.Applied:
+			return StepStatusFailed, fmt.Sprintf("%s: ARP suppression configured but not applied (no APPL_DB SUPPRESS_VLAN_NEIGH_TABLE suppress=on)", vlan)
+		}
+		return StepStatusPassed, fmt.Sprintf("%s: ARP suppression on (configured and applied)", vlan)
+	}
+	return StepStatusFailed, fmt.Sprintf("%s not present", vlan)
+}
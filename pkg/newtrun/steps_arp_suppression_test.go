@@ -0,0 +1,87 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestVerifyARPSuppression(t *testing.T) {
+	byDevice := map[string][]newtron.ARPSuppressionState{
+		"leaf1": {{VLAN: "Vlan100", Intended: true, Configured: true, Applied: true}},
+		"leaf2": {{VLAN: "Vlan100", Intended: true, Configured: true}},
+		"leaf3": {{VLAN: "Vlan100", Intended: true}},
+		"leaf4": {{VLAN: "Vlan100"}},
+		"leaf5": {{VLAN: "Vlan200", Intended: true, Configured: true, Applied: true}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, path, _ := strings.Cut(rest, "/")
+		if dev != "" && path != "evpn/arp-suppression" {
+			t.Errorf("%s: unexpected path %q", dev, path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	want := map[string]string{ // device → message; "" = PASS
+		"leaf1": "",
+		"leaf2": "Vlan100: ARP suppression configured but not applied (no APPL_DB SUPPRESS_VLAN_NEIGH_TABLE suppress=on)",
+		"leaf3": "Vlan100: ARP suppression intended but not configured (no CONFIG_DB SUPPRESS_VLAN_NEIGH suppress=on)",
+		"leaf4": "Vlan100: ARP suppression not configured",
+		"leaf5": "Vlan100 not present",
+	}
+	step := &Step{
+		Action:  ActionVerifyARPSuppression,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3", "leaf4", "leaf5"}},
+		Params:  map[string]any{"vlan_id": 100},
+	}
+	out := (&verifyARPSuppressionExecutor{}).Execute(context.Background(), r, step)
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("got %d device results, want %d", len(out.Result.Details), len(want))
+	}
+	for _, d := range out.Result.Details {
+		w := want[d.Device]
+		if w == "" {
+			if d.Status != StepStatusPassed {
+				t.Errorf("%s: %s %q, want PASSED", d.Device, d.Status, d.Message)
+			}
+			continue
+		}
+		if d.Status != StepStatusFailed || d.Message != w {
+			t.Errorf("%s: %s %q, want FAILED %q", d.Device, d.Status, d.Message, w)
+		}
+	}
+}
+
+func TestRequireARPSuppressionParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"vlan_id": 100}, ""},
+		{map[string]any{}, "params.vlan_id is required"},
+		{map[string]any{"vlan_id": 4095}, "params.vlan_id is required"},
+		{map[string]any{"vlan_id": "Vlan100"}, "params:"},
+	}
+	for _, tt := range tests {
+		err := requireARPSuppressionParams("step", &Step{Action: ActionVerifyARPSuppression, Params: tt.params})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tt.params, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-bgp step asserts that BGP sessions are Established on each target
+// device — every configured session, or only one VRF's:
+//
+//	- name: customer-peers-up
+//	  action: verify-bgp
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    vrf: Vrf_CUST1             # omit to check every VRF
+//	  poll: {timeout: 2m, interval: 5s}
+//
+// Per device it runs newtron's BGP session check (GET .../bgp/check?vrf=)
+// and FAILs on any session that is not Established, or when the scope has no
+// configured sessions at all. Scoping to a VRF keeps a down customer-VRF peer
+// from being lost among healthy underlay peers, and the other way round. The
+// message names the VRFs whose sessions were checked. With poll:, re-checks
+// until the sessions are up or the timeout expires.
+
+// bgpParams is the params: shape of a verify-bgp step.
+type bgpParams struct {
+	VRF string `json:"vrf"`
+}
+
+func decodeBGPParams(step *Step) (bgpParams, error) {
+	var p bgpParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	return p, nil
+}
+
+// requireBGPParams validates a verify-bgp step at parse time.
+func requireBGPParams(prefix string, step *Step) error {
+	if _, err := decodeBGPParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyBGPExecutor asserts BGP session state per device, optionally scoped
+// to one VRF.
+type verifyBGPExecutor struct{}
+
+func (e *verifyBGPExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeBGPParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		results, err := r.Client.CheckBGPSessions(dev, params.VRF)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("checking BGP sessions: %v", err)
+		}
+		observe(ctx, dev, bgpSessions(results))
+		return bgpSessionsStatus(results, params.VRF)
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	return r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		return st == StepStatusPassed, msg, nil
+	})
+}
+
+// bgpSessions returns the per-session results of a BGP check, ordered by
+// VRF then message — the state an observe: step records.
+func bgpSessions(results []newtron.HealthCheckResult) []newtron.HealthCheckResult {
+	sessions := []newtron.HealthCheckResult{}
+	for _, res := range results {
+		if res.Check == "bgp" {
+			sessions = append(sessions, res)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		if sessions[i].VRF != sessions[j].VRF {
+			return sessions[i].VRF < sessions[j].VRF
+		}
+		return sessions[i].Message < sessions[j].Message
+	})
+	return sessions
+}
+
+// bgpSessionsStatus reduces one device's BGP check results to a step status.
+// A "warn" result with no session results means the scope has no configured
+// neighbors — nothing was verified, which a verify step must not pass.
+func bgpSessionsStatus(results []newtron.HealthCheckResult, vrf string) (StepStatus, string) {
+	scope := "all VRFs"
+	if vrf != "" {
+		scope = "vrf " + vrf
+	}
+	vrfs := map[string]bool{}
+	var down []string
+	up := 0
+	for _, res := range results {
+		if res.Check != "bgp" {
+			continue
+		}
+		if res.VRF != "" {
+			vrfs[res.VRF] = true
+		}
+		switch res.Status {
+		case "pass":
+			up++
+		case "fail":
+			down = append(down, res.Message)
+		}
+	}
+	if up == 0 && len(down) == 0 {
+		return StepStatusFailed, fmt.Sprintf("%s: no BGP sessions configured", scope)
+	}
+	checked := make([]string, 0, len(vrfs))
+	for v := range vrfs {
+		checked = append(checked, v)
+	}
+	sort.Strings(checked)
+	if vrf == "" && len(checked) > 0 {
+		scope = fmt.Sprintf("all VRFs (%s)", strings.Join(checked, ", "))
+	}
+	if len(down) > 0 {
+		return StepStatusFailed, fmt.Sprintf("%s: %d of %d sessions not Established: %s",
+			scope, len(down), up+len(down), strings.Join(down, "; "))
+	}
+	return StepStatusPassed, fmt.Sprintf("%s: %d sessions Established", scope, up)
+}
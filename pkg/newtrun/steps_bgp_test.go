@@ -0,0 +1,104 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// bgpCheckServer fakes newtron-server's GET .../bgp/check, honouring ?vrf=
+// the way the node check does: results outside the VRF are dropped, and an
+// empty scope is a single warn.
+func bgpCheckServer(t *testing.T, byDevice map[string][]newtron.HealthCheckResult) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		vrf := r.URL.Query().Get("vrf")
+		var out []newtron.HealthCheckResult
+		for _, res := range byDevice[dev] {
+			if vrf == "" || res.VRF == vrf {
+				out = append(out, res)
+			}
+		}
+		if len(out) == 0 {
+			out = []newtron.HealthCheckResult{{Check: "bgp", Status: "warn", Message: "No BGP neighbors configured"}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": out})
+	}))
+}
+
+func bgpResult(vrf, ip, state string) newtron.HealthCheckResult {
+	status := "fail"
+	if state == "Established" {
+		status = "pass"
+	}
+	return newtron.HealthCheckResult{Check: "bgp", VRF: vrf, Status: status,
+		Message: "BGP neighbor " + ip + " (vrf " + vrf + "): " + state}
+}
+
+func bgpStep(params map[string]any) *Step {
+	return &Step{
+		Action:  ActionVerifyBGP,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+		Params:  params,
+	}
+}
+
+func TestVerifyBGP_VRFScope(t *testing.T) {
+	srv := bgpCheckServer(t, map[string][]newtron.HealthCheckResult{
+		"leaf1": {
+			bgpResult("default", "10.1.0.0", "Established"),
+			bgpResult("default", "10.1.0.2", "Established"),
+			bgpResult("Vrf_CUST1", "192.168.1.1", "Active"),
+		},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	exec := &verifyBGPExecutor{}
+
+	tests := []struct {
+		vrf    string
+		status StepStatus
+		msg    string
+	}{
+		{"default", StepStatusPassed, "vrf default: 2 sessions Established"},
+		{"Vrf_CUST1", StepStatusFailed, "vrf Vrf_CUST1: 1 of 1 sessions not Established: BGP neighbor 192.168.1.1 (vrf Vrf_CUST1): Active"},
+		{"", StepStatusFailed, "all VRFs (Vrf_CUST1, default): 1 of 3 sessions not Established"},
+		{"Vrf_NONE", StepStatusFailed, "vrf Vrf_NONE: no BGP sessions configured"},
+	}
+	for _, tt := range tests {
+		params := map[string]any{}
+		if tt.vrf != "" {
+			params["vrf"] = tt.vrf
+		}
+		out := exec.Execute(context.Background(), r, bgpStep(params))
+		d := out.Result.Details[0]
+		if d.Status != tt.status || !strings.HasPrefix(d.Message, tt.msg) {
+			t.Errorf("vrf %q: %s %q, want %s %q", tt.vrf, d.Status, d.Message, tt.status, tt.msg)
+		}
+	}
+}
+
+func TestVerifyBGP_PollTimesOut(t *testing.T) {
+	srv := bgpCheckServer(t, map[string][]newtron.HealthCheckResult{
+		"leaf1": {bgpResult("Vrf_CUST1", "192.168.1.1", "Connect")},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := bgpStep(map[string]any{"vrf": "Vrf_CUST1"})
+	step.Poll = &PollBlock{Timeout: 50 * time.Millisecond, Interval: 10 * time.Millisecond}
+	out := (&verifyBGPExecutor{}).Execute(context.Background(), r, step)
+	if d := out.Result.Details[0]; d.Status != StepStatusFailed || !strings.Contains(d.Message, "Connect") {
+		t.Errorf("poll: %s %q, want FAILED with the last session state", d.Status, d.Message)
+	}
+}
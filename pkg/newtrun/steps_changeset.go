@@ -0,0 +1,269 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/device/sonic"
+)
+
+// The verify-changeset step asserts on what newtron generated, not on what
+// the device accepted: the ChangeSet a prior newtron step in the same
+// scenario iteration got back in its WriteResult.
+//
+//	- name: apply-transit
+//	  action: newtron
+//	  devices: [leaf1]
+//	  method: POST
+//	  url: /nodes/{{device}}/interfaces/Ethernet0/apply-service?dry_run=true
+//	  params: {service: transit}
+//
+//	- name: transit-generates-evpn
+//	  action: verify-changeset
+//	  devices: [leaf1]
+//	  params:
+//	    step: apply-transit        # the newtron step whose ChangeSet to check
+//	    contains:                  # each must match some change
+//	      - {type: add, table: VXLAN_TUNNEL_MAP, key: "vtep1|map_10100_Vlan100"}
+//	      - {table: BGP_EVPN_VNI, fields: {vni: "10100"}}
+//	    omits:                     # none may match any change
+//	      - {table: ACL_TABLE}
+//	    exact: true                # and contains accounts for every change
+//
+// An entry matches a change when every field it sets agrees: type (add,
+// modify or delete — modify also matches an in-place replace), table, key,
+// and fields (a subset of the change's fields). Leaving key out matches any
+// key in the table. With dry_run=true the config step applies nothing, so a
+// scenario built this way is an offline regression check of the generation
+// logic — it runs against a loopback-mode newtron-server with no device.
+//
+// Every write (POST, PUT, DELETE) a newtron step makes is recorded: a
+// device-templated call under its device, a fleet call's MultiChangeSet
+// under each device it touched. Batch and for_each calls append, so the
+// ChangeSet is everything the step generated. The record is scenario-
+// iteration scoped, like captured values.
+
+// changeSetParams is the params: shape of a verify-changeset step.
+type changeSetParams struct {
+	Step     string           `json:"step"`
+	Contains []expectedChange `json:"contains"`
+	Omits    []expectedChange `json:"omits"`
+	Exact    bool             `json:"exact"`
+}
+
+// expectedChange is one contains/omits entry. Empty fields match anything.
+type expectedChange struct {
+	Type   string            `json:"type"`
+	Table  string            `json:"table"`
+	Key    string            `json:"key"`
+	Fields map[string]string `json:"fields"`
+}
+
+func decodeChangeSetParams(step *Step) (changeSetParams, error) {
+	var p changeSetParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.Step == "" {
+		return p, fmt.Errorf("params.step is required (the newtron step whose ChangeSet to check)")
+	}
+	if len(p.Contains) == 0 && len(p.Omits) == 0 && !p.Exact {
+		return p, fmt.Errorf("params needs contains, omits or exact")
+	}
+	for name, list := range map[string][]expectedChange{"contains": p.Contains, "omits": p.Omits} {
+		for i, c := range list {
+			switch sonic.ChangeType(c.Type) {
+			case "", sonic.ChangeTypeAdd, sonic.ChangeTypeModify, sonic.ChangeTypeDelete:
+			default:
+				return p, fmt.Errorf("params.%s[%d].type must be add, modify or delete, got %q", name, i, c.Type)
+			}
+			if c.Table == "" {
+				return p, fmt.Errorf("params.%s[%d].table is required", name, i)
+			}
+		}
+	}
+	return p, nil
+}
+
+// requireChangeSetParams validates a verify-changeset step at parse time.
+func requireChangeSetParams(prefix string, step *Step) error {
+	if _, err := decodeChangeSetParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// matches reports whether a generated change satisfies the entry.
+func (e expectedChange) matches(c sonic.ConfigChange) bool {
+	switch sonic.ChangeType(e.Type) {
+	case "":
+	case sonic.ChangeTypeModify:
+		if c.Type != sonic.ChangeTypeModify && c.Type != sonic.ChangeTypeReplace {
+			return false
+		}
+	default:
+		if c.Type != sonic.ChangeType(e.Type) {
+			return false
+		}
+	}
+	if e.Table != c.Table || (e.Key != "" && e.Key != c.Key) {
+		return false
+	}
+	for f, v := range e.Fields {
+		if got, ok := c.Fields[f]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+func (e expectedChange) String() string {
+	s := e.Table
+	if e.Key != "" {
+		s += "|" + e.Key
+	}
+	if e.Type != "" {
+		s = e.Type + " " + s
+	}
+	if len(e.Fields) > 0 {
+		s += fmt.Sprintf(" %v", e.Fields)
+	}
+	return s
+}
+
+func changeString(c sonic.ConfigChange) string {
+	return fmt.Sprintf("%s %s|%s", c.Type, c.Table, c.Key)
+}
+
+// check returns the ways changes fail the step's expectations, empty when
+// they meet them all.
+func (p changeSetParams) check(changes []sonic.ConfigChange) []string {
+	var problems []string
+	used := make([]bool, len(changes))
+	for _, want := range p.Contains {
+		found := false
+		for i, c := range changes {
+			if want.matches(c) {
+				used[i], found = true, true
+			}
+		}
+		if !found {
+			problems = append(problems, "missing "+want.String())
+		}
+	}
+	for _, never := range p.Omits {
+		for _, c := range changes {
+			if never.matches(c) {
+				problems = append(problems, fmt.Sprintf("unexpected %s (omits %s)", changeString(c), never))
+			}
+		}
+	}
+	if p.Exact {
+		for i, c := range changes {
+			if !used[i] {
+				problems = append(problems, "unlisted "+changeString(c))
+			}
+		}
+	}
+	return problems
+}
+
+// recordChangeSet keeps the changes a newtron write returned, under the
+// step's name and each device the response covers. A response that is not a
+// WriteResult or MultiChangeSet (a read, a lifecycle call) records nothing.
+// Safe under the parallel per-device goroutines executeForDevices spawns.
+func (r *Runner) recordChangeSet(stepName, device string, data json.RawMessage) {
+	var resp struct {
+		ChangeCount *int                 `json:"change_count"`
+		Changes     []sonic.ConfigChange `json:"changes"`
+		Devices     []struct {
+			Device string `json:"device"`
+			Result *struct {
+				Changes []sonic.ConfigChange `json:"changes"`
+			} `json:"result"`
+		} `json:"devices"`
+	}
+	if json.Unmarshal(data, &resp) != nil {
+		return
+	}
+	r.changeSetsMu.Lock()
+	defer r.changeSetsMu.Unlock()
+	if r.changeSets == nil {
+		r.changeSets = map[string]map[string][]sonic.ConfigChange{}
+	}
+	if r.changeSets[stepName] == nil {
+		r.changeSets[stepName] = map[string][]sonic.ConfigChange{}
+	}
+	byDevice := r.changeSets[stepName]
+	if resp.ChangeCount != nil {
+		byDevice[device] = append(byDevice[device], resp.Changes...)
+	}
+	for _, d := range resp.Devices {
+		if d.Result != nil {
+			byDevice[d.Device] = append(byDevice[d.Device], d.Result.Changes...)
+		}
+	}
+}
+
+// loadChangeSet returns the changes a named step generated for device.
+func (r *Runner) loadChangeSet(stepName, device string) ([]sonic.ConfigChange, bool) {
+	r.changeSetsMu.Lock()
+	defer r.changeSetsMu.Unlock()
+	byDevice, ok := r.changeSets[stepName]
+	if !ok {
+		return nil, false
+	}
+	changes, ok := byDevice[device]
+	return changes, ok
+}
+
+// stepChangeSets returns a copy of every ChangeSet a named step generated,
+// device → changes.
+func (r *Runner) stepChangeSets(stepName string) map[string][]sonic.ConfigChange {
+	r.changeSetsMu.Lock()
+	defer r.changeSetsMu.Unlock()
+	out := make(map[string][]sonic.ConfigChange, len(r.changeSets[stepName]))
+	for dev, changes := range r.changeSets[stepName] {
+		out[dev] = append([]sonic.ConfigChange(nil), changes...)
+	}
+	return out
+}
+
+// resetChangeSets drops every recorded ChangeSet — at the start of each
+// scenario iteration, alongside the captured map.
+func (r *Runner) resetChangeSets() {
+	r.changeSetsMu.Lock()
+	defer r.changeSetsMu.Unlock()
+	r.changeSets = nil
+}
+
+// verifyChangeSetExecutor checks the ChangeSet a prior newtron step
+// generated against the step's contains/omits/exact expectations. It reads
+// only what the runner recorded; it makes no call of its own.
+type verifyChangeSetExecutor struct{}
+
+func (e *verifyChangeSetExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeChangeSetParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		changes, ok := r.loadChangeSet(params.Step, dev)
+		if !ok {
+			return StepStatusError, fmt.Sprintf("no ChangeSet recorded for step %q on %s — it must be an earlier newtron write step in this scenario", params.Step, dev)
+		}
+		problems := params.check(changes)
+		if len(problems) == 0 {
+			return StepStatusPassed, fmt.Sprintf("step %q ChangeSet (%d changes) matches", params.Step, len(changes))
+		}
+		sort.Strings(problems)
+		return StepStatusFailed, fmt.Sprintf("step %q ChangeSet (%d changes): %s", params.Step, len(changes), strings.Join(problems, "; "))
+	})
+}
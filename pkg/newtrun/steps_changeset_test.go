@@ -0,0 +1,84 @@
+package newtrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// TestVerifyChangeSet drives a dry-run newtron write against a faux server
+// and asserts on the ChangeSet it returned — no device behind it.
+func TestVerifyChangeSet(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"change_count":3,"applied":false,"changes":[
+			{"table":"VLAN","key":"Vlan100","type":"add","fields":{"vlanid":"100"}},
+			{"table":"VXLAN_TUNNEL_MAP","key":"vtep1|map_10100_Vlan100","type":"add","fields":{"vni":"10100","vlan":"Vlan100"}},
+			{"table":"BGP_GLOBALS","key":"default","type":"replace","fields":{"local_asn":"65001"}}]}}`))
+	}))
+	defer srv.Close()
+
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	ctx := context.Background()
+	devices := deviceSelector{Devices: []string{"leaf1"}}
+
+	write := &Step{Name: "create-vlan", Action: ActionNewtron, Devices: devices,
+		Method: "POST", URL: "/nodes/{{device}}/create-vlan?dry_run=true"}
+	if out := (&newtronExecutor{}).Execute(ctx, r, write); out.Result.Status != StepStatusPassed {
+		t.Fatalf("newtron step: %+v", out.Result)
+	}
+
+	tests := []struct {
+		name   string
+		params map[string]any
+		status StepStatus
+		want   string
+	}{
+		{"contains", map[string]any{"step": "create-vlan", "contains": []any{
+			map[string]any{"type": "add", "table": "VXLAN_TUNNEL_MAP", "fields": map[string]any{"vni": "10100"}},
+			map[string]any{"type": "modify", "table": "BGP_GLOBALS", "key": "default"},
+		}}, StepStatusPassed, ""},
+		{"missing", map[string]any{"step": "create-vlan", "contains": []any{
+			map[string]any{"table": "BGP_EVPN_VNI"},
+		}}, StepStatusFailed, "missing BGP_EVPN_VNI"},
+		{"omits", map[string]any{"step": "create-vlan", "omits": []any{
+			map[string]any{"type": "add", "table": "VLAN"},
+		}}, StepStatusFailed, "unexpected add VLAN|Vlan100"},
+		{"exact", map[string]any{"step": "create-vlan", "exact": true, "contains": []any{
+			map[string]any{"table": "VLAN"}, map[string]any{"table": "VXLAN_TUNNEL_MAP"},
+		}}, StepStatusFailed, "unlisted replace BGP_GLOBALS|default"},
+		{"unknown step", map[string]any{"step": "nope", "exact": true}, StepStatusError, `no ChangeSet recorded for step "nope"`},
+	}
+	for _, tt := range tests {
+		out := (&verifyChangeSetExecutor{}).Execute(ctx, r, &Step{Action: ActionVerifyChangeSet, Devices: devices, Params: tt.params})
+		d := out.Result.Details[0]
+		if d.Status != tt.status {
+			t.Errorf("%s: status = %s, want %s (%s)", tt.name, d.Status, tt.status, d.Message)
+			continue
+		}
+		if tt.want != "" && !strings.Contains(d.Message, tt.want) {
+			t.Errorf("%s: message = %q, want containing %q", tt.name, d.Message, tt.want)
+		}
+	}
+}
+
+func TestRequireChangeSetParams(t *testing.T) {
+	for _, tt := range []struct {
+		params map[string]any
+		want   string
+	}{
+		{map[string]any{"contains": []any{map[string]any{"table": "VLAN"}}}, "params.step is required"},
+		{map[string]any{"step": "s"}, "needs contains, omits or exact"},
+		{map[string]any{"step": "s", "omits": []any{map[string]any{"table": "VLAN", "type": "upsert"}}}, "params.omits[0].type"},
+		{map[string]any{"step": "s", "contains": []any{map[string]any{"key": "Vlan100"}}}, "params.contains[0].table is required"},
+	} {
+		err := requireChangeSetParams("step", &Step{Action: ActionVerifyChangeSet, Params: tt.params})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("requireChangeSetParams(%v) = %v, want error mentioning %q", tt.params, err, tt.want)
+		}
+	}
+}
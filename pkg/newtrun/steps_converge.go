@@ -0,0 +1,92 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The wait-converged step is one event-driven gate in place of the common
+// "wait 60s, then verify bgp, then the route, then health" chain:
+//
+//	- name: converged
+//	  action: wait-converged
+//	  devices: all
+//	  poll: {timeout: 3m, interval: 5s}   # one timeout for all conditions
+//	  params:
+//	    bgp: true                          # every BGP session Established
+//	    health: true                       # health check not failing
+//	    routes:                            # each route present in APP_DB
+//	      - {vrf: default, prefix: 10.0.0.0/24}
+//
+// It passes as soon as every selected condition holds together on a device,
+// reporting which condition was the last to come true. The polling itself is
+// newtron's (client.WaitForConvergence); newtrun owns only the step shape.
+
+// convergeParams is the params: shape of a wait-converged step.
+type convergeParams struct {
+	BGP    bool                       `json:"bgp"`
+	Health bool                       `json:"health"`
+	Routes []newtron.ConvergenceRoute `json:"routes"`
+}
+
+// convergenceOpts decodes a wait-converged step into newtron's options.
+func convergenceOpts(step *Step) (newtron.ConvergenceOpts, error) {
+	var p convergeParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return newtron.ConvergenceOpts{}, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return newtron.ConvergenceOpts{}, fmt.Errorf("params: %w", err)
+	}
+	if !p.BGP && !p.Health && len(p.Routes) == 0 {
+		return newtron.ConvergenceOpts{}, fmt.Errorf("params must select at least one condition (bgp, health, routes)")
+	}
+	for i, rt := range p.Routes {
+		if rt.Prefix == "" {
+			return newtron.ConvergenceOpts{}, fmt.Errorf("params.routes[%d]: prefix is required", i)
+		}
+	}
+	opts := newtron.ConvergenceOpts{BGP: p.BGP, Health: p.Health, Routes: p.Routes}
+	if step.Poll != nil {
+		opts.Timeout = step.Poll.Timeout
+		opts.Interval = step.Poll.Interval
+	}
+	return opts, nil
+}
+
+// requireConvergence validates a wait-converged step at parse time.
+func requireConvergence(prefix string, step *Step) error {
+	if step.Poll == nil {
+		return fmt.Errorf("%s: wait-converged requires poll (timeout and interval)", prefix)
+	}
+	if _, err := convergenceOpts(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// waitConvergedExecutor gates on device convergence.
+type waitConvergedExecutor struct{}
+
+func (e *waitConvergedExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	opts, err := convergenceOpts(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		res, err := r.Client.WaitForConvergence(ctx, dev, opts)
+		if ctx.Err() != nil {
+			return StepStatusError, "interrupted"
+		}
+		if err != nil {
+			return StepStatusFailed, err.Error()
+		}
+		return StepStatusPassed, fmt.Sprintf("converged in %s (last: %s)",
+			res.Elapsed.Round(time.Millisecond), res.LastSatisfied)
+	})
+}
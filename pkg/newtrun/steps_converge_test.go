@@ -0,0 +1,84 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// TestWaitConverged_PassesOnceAllHold drives the real wait-converged executor
+// against a faux newtron-server whose BGP check fails for the first polls:
+// the step must pass once BGP comes up, naming it as the last condition.
+func TestWaitConverged_PassesOnceAllHold(t *testing.T) {
+	var bgpPolls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var data any
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/bgp/check"):
+			status := "pass"
+			if atomic.AddInt32(&bgpPolls, 1) <= 2 {
+				status = "fail"
+			}
+			data = []map[string]string{{"check": "bgp", "status": status}}
+		case strings.HasSuffix(r.URL.Path, "/health"):
+			data = map[string]string{"device": "switch1", "status": "pass"}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+	defer srv.Close()
+
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	step := &Step{
+		Action:  ActionWaitConverged,
+		Devices: deviceSelector{Devices: []string{"switch1"}},
+		Poll:    &PollBlock{Timeout: 5 * time.Second, Interval: 10 * time.Millisecond},
+		Params:  map[string]any{"bgp": true, "health": true},
+	}
+	out := (&waitConvergedExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusPassed {
+		t.Fatalf("wait-converged should PASS, got %+v", out.Result)
+	}
+	if msg := out.Result.Details[0].Message; !strings.Contains(msg, "last: bgp") {
+		t.Errorf("message should name bgp as the last condition, got %q", msg)
+	}
+}
+
+func TestWaitConverged_ParseValidation(t *testing.T) {
+	poll := &PollBlock{Timeout: time.Minute, Interval: time.Second}
+	tests := []struct {
+		name    string
+		step    Step
+		wantErr string
+	}{
+		{"no poll", Step{Params: map[string]any{"bgp": true}}, "requires poll"},
+		{"no condition", Step{Poll: poll, Params: map[string]any{}}, "at least one condition"},
+		{"route without prefix", Step{Poll: poll, Params: map[string]any{
+			"routes": []any{map[string]any{"vrf": "Vrf_A"}},
+		}}, "prefix is required"},
+		{"valid", Step{Poll: poll, Params: map[string]any{
+			"routes": []any{map[string]any{"prefix": "10.0.0.0/24"}},
+		}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := requireConvergence("step", &tt.step)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
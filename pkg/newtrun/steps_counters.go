@@ -0,0 +1,203 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-interface-counters step asserts a port's link state and SAI
+// counters on each target device — that traffic actually crossed a link,
+// and that it did so cleanly:
+//
+//	- name: uplink-clean
+//	  action: verify-interface-counters
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    interface: Ethernet0
+//	    oper_status: up         # optional
+//	    tx_packets_min: 1       # <counter>_min: counter >= bound
+//	    in_errors_max: 0        # <counter>_max: counter <= bound
+//	  poll: {timeout: 1m, interval: 5s}
+//
+// Per device it reads the port's live status (GET .../interfaces/{name}/
+// status), whose counters come from COUNTERS_DB COUNTERS:<oid>, and checks
+// every predicate. Counters are named as newtron reports them (rx_octets,
+// tx_unicast_packets, rx_errors, ...), plus rx_packets and tx_packets
+// (unicast + non-unicast); an in_ or out_ prefix stands for rx_ or tx_.
+// Counters are cumulative and flex counters poll on a timer, so a _min
+// predicate on freshly sent traffic wants poll:.
+
+// counterPredicate bounds one counter from below (Min) or above.
+type counterPredicate struct {
+	Counter string // e.g. in_errors, from params key in_errors_max
+	Min     bool
+	Bound   uint64
+}
+
+// interfaceCountersParams is the params: shape of a verify-interface-counters step.
+type interfaceCountersParams struct {
+	Interface  string
+	OperStatus string
+	Predicates []counterPredicate // in key order
+}
+
+func decodeInterfaceCountersParams(step *Step) (interfaceCountersParams, error) {
+	var p interfaceCountersParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	for _, key := range sortedKeys(fields) {
+		val := fields[key]
+		switch key {
+		case "interface":
+			if err := json.Unmarshal(val, &p.Interface); err != nil {
+				return p, fmt.Errorf("params.interface: %w", err)
+			}
+			continue
+		case "oper_status":
+			if err := json.Unmarshal(val, &p.OperStatus); err != nil {
+				return p, fmt.Errorf("params.oper_status: %w", err)
+			}
+			continue
+		}
+		var pred counterPredicate
+		switch {
+		case strings.HasSuffix(key, "_min"):
+			pred.Counter, pred.Min = strings.TrimSuffix(key, "_min"), true
+		case strings.HasSuffix(key, "_max"):
+			pred.Counter = strings.TrimSuffix(key, "_max")
+		default:
+			return p, fmt.Errorf("params.%s: unknown key (want interface, oper_status, or <counter>_min/_max)", key)
+		}
+		if _, ok := interfaceCounterMap(&newtron.InterfaceCounters{})[pred.Counter]; !ok {
+			return p, fmt.Errorf("params.%s: unknown counter %q", key, pred.Counter)
+		}
+		if err := json.Unmarshal(val, &pred.Bound); err != nil {
+			return p, fmt.Errorf("params.%s: want a non-negative integer: %w", key, err)
+		}
+		p.Predicates = append(p.Predicates, pred)
+	}
+	if p.Interface == "" {
+		return p, fmt.Errorf("params.interface is required")
+	}
+	if p.OperStatus == "" && len(p.Predicates) == 0 {
+		return p, fmt.Errorf("params needs oper_status or at least one <counter>_min/_max")
+	}
+	return p, nil
+}
+
+// requireInterfaceCountersParams validates a verify-interface-counters step at parse time.
+func requireInterfaceCountersParams(prefix string, step *Step) error {
+	if _, err := decodeInterfaceCountersParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// interfaceCounterMap names every counter a predicate may bound: the
+// fields as newtron reports them, the rx_/tx_ packet totals, and in_/out_
+// aliases of each rx_/tx_ name.
+func interfaceCounterMap(c *newtron.InterfaceCounters) map[string]uint64 {
+	m := map[string]uint64{
+		"rx_octets":              c.RxOctets,
+		"rx_unicast_packets":     c.RxUnicastPackets,
+		"rx_non_unicast_packets": c.RxNonUnicastPkts,
+		"rx_packets":             c.RxUnicastPackets + c.RxNonUnicastPkts,
+		"rx_discards":            c.RxDiscards,
+		"rx_errors":              c.RxErrors,
+		"tx_octets":              c.TxOctets,
+		"tx_unicast_packets":     c.TxUnicastPackets,
+		"tx_non_unicast_packets": c.TxNonUnicastPkts,
+		"tx_packets":             c.TxUnicastPackets + c.TxNonUnicastPkts,
+		"tx_discards":            c.TxDiscards,
+		"tx_errors":              c.TxErrors,
+	}
+	for name, v := range m {
+		if rest, ok := strings.CutPrefix(name, "rx_"); ok {
+			m["in_"+rest] = v
+		} else if rest, ok := strings.CutPrefix(name, "tx_"); ok {
+			m["out_"+rest] = v
+		}
+	}
+	return m
+}
+
+// evaluateCounterPredicates returns one line per predicate counters
+// violates, in predicate order; none means all hold.
+func evaluateCounterPredicates(counters map[string]uint64, preds []counterPredicate) []string {
+	var problems []string
+	for _, pred := range preds {
+		got := counters[pred.Counter]
+		switch {
+		case pred.Min && got < pred.Bound:
+			problems = append(problems, fmt.Sprintf("%s %d, expected >= %d", pred.Counter, got, pred.Bound))
+		case !pred.Min && got > pred.Bound:
+			problems = append(problems, fmt.Sprintf("%s %d, expected <= %d", pred.Counter, got, pred.Bound))
+		}
+	}
+	return problems
+}
+
+// verifyInterfaceCountersExecutor asserts a port's link state and counters per device.
+type verifyInterfaceCountersExecutor struct{}
+
+func (e *verifyInterfaceCountersExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeInterfaceCountersParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		st, err := r.Client.InterfaceStatus(dev, params.Interface)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading %s status: %v", params.Interface, err)
+		}
+		return interfaceCountersStatus(st, params)
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	return r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		return st == StepStatusPassed, msg, nil
+	})
+}
+
+// interfaceCountersStatus checks one port's status against params, naming
+// each value checked. A port with no counters is an ERROR, not a FAIL: flex
+// counter polling is off, so no counter predicate can be judged.
+func interfaceCountersStatus(st *newtron.InterfaceStatus, params interfaceCountersParams) (StepStatus, string) {
+	var problems, observed []string
+	if params.OperStatus != "" {
+		if !strings.EqualFold(st.OperStatus, params.OperStatus) {
+			problems = append(problems, fmt.Sprintf("oper_status %s, expected %s", st.OperStatus, params.OperStatus))
+		}
+		observed = append(observed, "oper_status "+st.OperStatus)
+	}
+	if len(params.Predicates) > 0 {
+		if st.Counters == nil {
+			return StepStatusError, fmt.Sprintf("%s: no counters (is flex counter polling enabled?)", params.Interface)
+		}
+		counters := interfaceCounterMap(st.Counters)
+		problems = append(problems, evaluateCounterPredicates(counters, params.Predicates)...)
+		for _, pred := range params.Predicates {
+			observed = append(observed, fmt.Sprintf("%s %d", pred.Counter, counters[pred.Counter]))
+		}
+	}
+	if len(problems) > 0 {
+		return StepStatusFailed, params.Interface + ": " + strings.Join(problems, "; ")
+	}
+	return StepStatusPassed, params.Interface + ": " + strings.Join(observed, ", ")
+}
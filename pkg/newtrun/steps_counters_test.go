@@ -0,0 +1,114 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestEvaluateCounterPredicates(t *testing.T) {
+	counters := interfaceCounterMap(&newtron.InterfaceCounters{
+		RxUnicastPackets: 90, RxNonUnicastPkts: 10, RxErrors: 3,
+		TxUnicastPackets: 5, TxDiscards: 0,
+	})
+	tests := []struct {
+		name  string
+		preds []counterPredicate
+		want  []string
+	}{
+		{"all hold", []counterPredicate{
+			{Counter: "rx_packets", Min: true, Bound: 100},
+			{Counter: "tx_packets", Min: true, Bound: 1},
+			{Counter: "out_discards", Bound: 0},
+		}, nil},
+		{"below min", []counterPredicate{{Counter: "tx_packets", Min: true, Bound: 6}},
+			[]string{"tx_packets 5, expected >= 6"}},
+		{"above max via alias", []counterPredicate{{Counter: "in_errors", Bound: 0}},
+			[]string{"in_errors 3, expected <= 0"}},
+		{"every violation, in order", []counterPredicate{
+			{Counter: "rx_errors", Bound: 2},
+			{Counter: "rx_non_unicast_packets", Min: true, Bound: 10},
+			{Counter: "tx_octets", Min: true, Bound: 1},
+		}, []string{"rx_errors 3, expected <= 2", "tx_octets 0, expected >= 1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := evaluateCounterPredicates(counters, tt.preds); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequireInterfaceCountersParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"interface": "Ethernet0", "oper_status": "up", "tx_packets_min": 1, "in_errors_max": 0}, ""},
+		{map[string]any{"interface": "Ethernet0", "oper_status": "up"}, ""},
+		{map[string]any{"oper_status": "up"}, "params.interface is required"},
+		{map[string]any{"interface": "Ethernet0"}, "needs oper_status or at least one"},
+		{map[string]any{"interface": "Ethernet0", "crc_errors_max": 0}, `unknown counter "crc_errors"`},
+		{map[string]any{"interface": "Ethernet0", "errors": 0}, "params.errors: unknown key"},
+		{map[string]any{"interface": "Ethernet0", "rx_errors_max": -1}, "params.rx_errors_max: want a non-negative integer"},
+	}
+	for _, tt := range tests {
+		err := requireInterfaceCountersParams("step", &Step{Action: ActionVerifyInterfaceCounters, Params: tt.params})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tt.params, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
+
+func TestVerifyInterfaceCounters(t *testing.T) {
+	byDevice := map[string]newtron.InterfaceStatus{
+		"leaf1": {Name: "Ethernet0", OperStatus: "up", Counters: &newtron.InterfaceCounters{TxUnicastPackets: 42}},
+		"leaf2": {Name: "Ethernet0", OperStatus: "down", Counters: &newtron.InterfaceCounters{RxErrors: 7}},
+		"leaf3": {Name: "Ethernet0", OperStatus: "up"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyInterfaceCounters,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3"}},
+		Params:  map[string]any{"interface": "Ethernet0", "oper_status": "UP", "tx_packets_min": 1, "in_errors_max": 0},
+	}
+	out := (&verifyInterfaceCountersExecutor{}).Execute(context.Background(), r, step)
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "Ethernet0: oper_status up, in_errors 0, tx_packets 42"},
+		"leaf2": {StepStatusFailed, "Ethernet0: oper_status down, expected UP; in_errors 7, expected <= 0; tx_packets 0, expected >= 1"},
+		"leaf3": {StepStatusError, "Ethernet0: no counters (is flex counter polling enabled?)"},
+	}
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("details = %+v, want %d", out.Result.Details, len(want))
+	}
+	for _, d := range out.Result.Details {
+		if w := want[d.Device]; d.Status != w.status || d.Message != w.msg {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+}
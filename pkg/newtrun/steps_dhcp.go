@@ -0,0 +1,134 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-dhcp-lease step asserts that the built-in DHCP server granted a
+// lease on a VLAN, on each target device:
+//
+//	- name: host-got-lease
+//	  action: verify-dhcp-lease
+//	  devices: [leaf1]
+//	  params:
+//	    vlan_id: 100
+//	    mac: "52:54:00:12:34:56"   # optional: the client's lease
+//	    ip: 192.168.100.50         # optional: the address leased
+//	  poll: {timeout: 1m, interval: 5s}
+//
+// Per device it reads the VLAN's leases (GET .../dhcp-leases?vlan_id=) from
+// STATE_DB DHCP_SERVER_IPV4_LEASE and PASSes when an unexpired lease matches
+// mac and ip — any lease when neither is given. With poll:, re-reads until
+// the lease appears or the timeout expires, for a client still asking.
+
+// dhcpLeaseParams is the params: shape of a verify-dhcp-lease step.
+type dhcpLeaseParams struct {
+	VlanID int    `json:"vlan_id"`
+	MAC    string `json:"mac"`
+	IP     string `json:"ip"`
+}
+
+func decodeDHCPLeaseParams(step *Step) (dhcpLeaseParams, error) {
+	var p dhcpLeaseParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.VlanID < 1 || p.VlanID > 4094 {
+		return p, fmt.Errorf("params.vlan_id is required (1-4094)")
+	}
+	if p.MAC != "" {
+		hw, err := net.ParseMAC(p.MAC)
+		if err != nil {
+			return p, fmt.Errorf("params.mac %q is not a MAC address", p.MAC)
+		}
+		p.MAC = hw.String()
+	}
+	if p.IP != "" && net.ParseIP(p.IP).To4() == nil {
+		return p, fmt.Errorf("params.ip %q is not an IPv4 address", p.IP)
+	}
+	return p, nil
+}
+
+// requireDHCPLeaseParams validates a verify-dhcp-lease step at parse time.
+func requireDHCPLeaseParams(prefix string, step *Step) error {
+	if _, err := decodeDHCPLeaseParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyDHCPLeaseExecutor asserts a DHCP lease on a VLAN per device.
+type verifyDHCPLeaseExecutor struct{}
+
+func (e *verifyDHCPLeaseExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeDHCPLeaseParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		leases, err := r.Client.DHCPLeases(dev, params.VlanID)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading DHCP leases: %v", err)
+		}
+		return dhcpLeaseStatus(leases, params, time.Now())
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	return r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		return st == StepStatusPassed, msg, nil
+	})
+}
+
+// dhcpLeaseStatus reduces one device's leases to a step status: PASSED when
+// an unexpired lease matches params. A lease with no end time counts as
+// current.
+func dhcpLeaseStatus(leases []newtron.DHCPLease, params dhcpLeaseParams, now time.Time) (StepStatus, string) {
+	want := fmt.Sprintf("Vlan%d", params.VlanID)
+	if params.MAC != "" {
+		want += " mac " + params.MAC
+	}
+	if params.IP != "" {
+		want += " ip " + params.IP
+	}
+
+	var expired, others []string
+	for _, l := range leases {
+		if params.MAC != "" && !strings.EqualFold(l.MAC, params.MAC) {
+			others = append(others, l.MAC+"="+l.IP)
+			continue
+		}
+		if params.IP != "" && l.IP != params.IP {
+			others = append(others, l.MAC+"="+l.IP)
+			continue
+		}
+		if !l.End.IsZero() && !l.End.After(now) {
+			expired = append(expired, l.MAC+"="+l.IP)
+			continue
+		}
+		return StepStatusPassed, fmt.Sprintf("%s: %s leased %s", want, l.MAC, l.IP)
+	}
+	switch {
+	case len(expired) > 0:
+		return StepStatusFailed, fmt.Sprintf("%s: lease expired (%s)", want, strings.Join(expired, ", "))
+	case len(others) > 0:
+		return StepStatusFailed, fmt.Sprintf("%s: no matching lease; leased: %s", want, strings.Join(others, ", "))
+	}
+	return StepStatusFailed, fmt.Sprintf("%s: no leases", want)
+}
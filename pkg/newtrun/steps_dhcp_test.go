@@ -0,0 +1,112 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// dhcpLeaseServer fakes newtron-server's GET .../dhcp-leases per device.
+func dhcpLeaseServer(t *testing.T, byDevice map[string][]newtron.DHCPLease) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		if strings.HasSuffix(r.URL.Path, "/dhcp-leases") && r.URL.Query().Get("vlan_id") != "100" {
+			t.Errorf("%s: vlan_id = %q, want 100", dev, r.URL.Query().Get("vlan_id"))
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+}
+
+func TestVerifyDHCPLease(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	srv := dhcpLeaseServer(t, map[string][]newtron.DHCPLease{
+		"leaf1": {{VLAN: "Vlan100", MAC: "52:54:00:12:34:56", IP: "192.168.100.50", End: future}},
+		"leaf2": {{VLAN: "Vlan100", MAC: "52:54:00:12:34:56", IP: "192.168.100.50", End: time.Now().Add(-time.Minute)}},
+		"leaf3": {{VLAN: "Vlan100", MAC: "52:54:00:aa:bb:cc", IP: "192.168.100.51", End: future}},
+		"leaf4": nil,
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	tests := []struct {
+		name   string
+		params map[string]any
+		want   map[string]string // device → message; "" = PASS
+	}{
+		{"by mac", map[string]any{"vlan_id": 100, "mac": "52:54:00:12:34:56"}, map[string]string{
+			"leaf1": "",
+			"leaf2": "Vlan100 mac 52:54:00:12:34:56: lease expired (52:54:00:12:34:56=192.168.100.50)",
+			"leaf3": "Vlan100 mac 52:54:00:12:34:56: no matching lease; leased: 52:54:00:aa:bb:cc=192.168.100.51",
+			"leaf4": "Vlan100 mac 52:54:00:12:34:56: no leases",
+		}},
+		{"any lease", map[string]any{"vlan_id": 100}, map[string]string{
+			"leaf3": "",
+			"leaf4": "Vlan100: no leases",
+		}},
+		{"by ip", map[string]any{"vlan_id": 100, "ip": "192.168.100.51"}, map[string]string{
+			"leaf1": "Vlan100 ip 192.168.100.51: no matching lease; leased: 52:54:00:12:34:56=192.168.100.50",
+			"leaf3": "",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var devices []string
+			for dev := range tt.want {
+				devices = append(devices, dev)
+			}
+			step := &Step{
+				Action:  ActionVerifyDHCPLease,
+				Devices: deviceSelector{Devices: devices},
+				Params:  tt.params,
+			}
+			out := (&verifyDHCPLeaseExecutor{}).Execute(context.Background(), r, step)
+			for _, d := range out.Result.Details {
+				want := tt.want[d.Device]
+				if want == "" {
+					if d.Status != StepStatusPassed {
+						t.Errorf("%s: %s %q, want PASSED", d.Device, d.Status, d.Message)
+					}
+					continue
+				}
+				if d.Status != StepStatusFailed || d.Message != want {
+					t.Errorf("%s: %s %q, want FAILED %q", d.Device, d.Status, d.Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRequireDHCPLeaseParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"vlan_id": 100, "mac": "52:54:00:12:34:56", "ip": "192.168.100.50"}, ""},
+		{map[string]any{}, "params.vlan_id is required"},
+		{map[string]any{"vlan_id": 5000}, "params.vlan_id is required"},
+		{map[string]any{"vlan_id": 100, "mac": "nope"}, `params.mac "nope" is not a MAC address`},
+		{map[string]any{"vlan_id": 100, "ip": "2001:db8::1"}, `params.ip "2001:db8::1" is not an IPv4 address`},
+	}
+	for _, tt := range tests {
+		err := requireDHCPLeaseParams("step", &Step{Action: ActionVerifyDHCPLease, Params: tt.params})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tt.params, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package newtrun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-environment step asserts every platform sensor on each target
+// device is in its normal range.
+//
+//	- name: hardware-healthy
+//	  action: verify-environment
+//	  devices: all
+//
+// Per device it reads the environment view (GET .../environment: STATE_DB
+// PSU_INFO, FAN_INFO, TEMPERATURE_INFO) and FAILs naming each out-of-range
+// sensor: a PSU absent or without power good, a fan absent, faulted, or
+// under/over speed, a thermal at or above its high or critical-high
+// threshold or with its warning flag set. A device that publishes no
+// sensors — a virtual platform — is SKIPPED; so is the step when every
+// target is.
+
+// verifyEnvironmentExecutor asserts PSU, fan, and thermal health per device.
+type verifyEnvironmentExecutor struct{}
+
+func (e *verifyEnvironmentExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	out := r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		env, err := r.Client.Environment(dev)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading environment: %v", err)
+		}
+		if len(env.PSUs) == 0 && len(env.Fans) == 0 && len(env.Thermals) == 0 {
+			return StepStatusSkipped, "no PSU, fan, or thermal sensors (virtual platform)"
+		}
+		if problems := environmentProblems(env); len(problems) > 0 {
+			return StepStatusFailed, strings.Join(problems, "; ")
+		}
+		return StepStatusPassed, fmt.Sprintf("%d PSUs, %d fans, %d thermals in range",
+			len(env.PSUs), len(env.Fans), len(env.Thermals))
+	})
+	allSkipped := len(out.Result.Details) > 0
+	for _, d := range out.Result.Details {
+		if d.Status != StepStatusSkipped {
+			allSkipped = false
+			break
+		}
+	}
+	if allSkipped {
+		out.Result.Status = StepStatusSkipped
+	}
+	return out
+}
+
+// environmentProblems returns one message per out-of-range sensor, in the
+// view's order: PSUs, then fans, then thermals.
+func environmentProblems(env *newtron.Environment) []string {
+	var problems []string
+	for _, p := range env.PSUs {
+		switch {
+		case !p.Present:
+			problems = append(problems, fmt.Sprintf("%s not present", p.Name))
+		case !p.PowerGood:
+			problems = append(problems, fmt.Sprintf("%s power not good", p.Name))
+		}
+	}
+	for _, f := range env.Fans {
+		switch {
+		case !f.Present:
+			problems = append(problems, fmt.Sprintf("%s not present", f.Name))
+		case !f.OK:
+			problems = append(problems, fmt.Sprintf("%s status not OK", f.Name))
+		case f.UnderSpeed:
+			problems = append(problems, fmt.Sprintf("%s under speed (%d%%)", f.Name, f.Speed))
+		case f.OverSpeed:
+			problems = append(problems, fmt.Sprintf("%s over speed (%d%%)", f.Name, f.Speed))
+		}
+	}
+	for _, t := range env.Thermals {
+		switch {
+		case t.CriticalHighThreshold > 0 && t.Temperature >= t.CriticalHighThreshold:
+			problems = append(problems, fmt.Sprintf("%s %.1f°C at or above critical-high threshold %.1f°C",
+				t.Name, t.Temperature, t.CriticalHighThreshold))
+		case t.HighThreshold > 0 && t.Temperature >= t.HighThreshold:
+			problems = append(problems, fmt.Sprintf("%s %.1f°C at or above high threshold %.1f°C",
+				t.Name, t.Temperature, t.HighThreshold))
+		case t.Warning:
+			problems = append(problems, fmt.Sprintf("%s warning set at %.1f°C", t.Name, t.Temperature))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,84 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// environmentServer fakes newtron-server's GET .../environment per device.
+func environmentServer(t *testing.T, byDevice map[string]newtron.Environment) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+}
+
+func TestVerifyEnvironment(t *testing.T) {
+	healthy := newtron.Environment{
+		PSUs:     []newtron.PSUStatus{{Name: "PSU 1", Present: true, PowerGood: true}},
+		Fans:     []newtron.FanStatus{{Name: "fan1", Present: true, OK: true, Speed: 40}},
+		Thermals: []newtron.ThermalStatus{{Name: "ASIC", Temperature: 55, HighThreshold: 90, CriticalHighThreshold: 105}},
+	}
+	srv := environmentServer(t, map[string]newtron.Environment{
+		"leaf1": healthy,
+		"leaf2": {
+			PSUs: []newtron.PSUStatus{
+				{Name: "PSU 1", Present: true, PowerGood: true},
+				{Name: "PSU 2", Present: true},
+			},
+			Fans: []newtron.FanStatus{{Name: "fan3", Present: true, OK: true, Speed: 20, UnderSpeed: true}},
+			Thermals: []newtron.ThermalStatus{
+				{Name: "ASIC", Temperature: 92.5, HighThreshold: 90, CriticalHighThreshold: 105},
+				{Name: "CPU", Temperature: 60, Warning: true},
+			},
+		},
+		"vs1": {},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyEnvironment,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "vs1"}},
+	}
+	out := (&verifyEnvironmentExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusFailed {
+		t.Fatalf("status = %s, want FAILED: %+v", out.Result.Status, out.Result)
+	}
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "1 PSUs, 1 fans, 1 thermals in range"},
+		"leaf2": {StepStatusFailed, "PSU 2 power not good; fan3 under speed (20%); " +
+			"ASIC 92.5°C at or above high threshold 90.0°C; CPU warning set at 60.0°C"},
+		"vs1": {StepStatusSkipped, "no PSU, fan, or thermal sensors (virtual platform)"},
+	}
+	for _, d := range out.Result.Details {
+		if w := want[d.Device]; d.Status != w.status || d.Message != w.msg {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+}
+
+func TestVerifyEnvironment_AllVirtualSkips(t *testing.T) {
+	srv := environmentServer(t, map[string]newtron.Environment{})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{Action: ActionVerifyEnvironment, Devices: deviceSelector{Devices: []string{"vs1", "vs2"}}}
+	out := (&verifyEnvironmentExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusSkipped {
+		t.Errorf("status = %s, want SKIPPED: %+v", out.Result.Status, out.Result)
+	}
+}
@@ -0,0 +1,170 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-evpn-type2 step asserts that an EVPN type-2 (MAC/IP) route is —
+// or is not — in a VNI's BGP table, on each target device:
+//
+//	- name: host1-mac-imported
+//	  action: verify-evpn-type2
+//	  devices: [leaf2]
+//	  params:
+//	    vni: 10100
+//	    mac: "52:54:00:12:34:56"
+//	    ip: 10.1.100.10              # optional
+//	    present: true                # default; false asserts the route is gone
+//	  poll: {timeout: 1m, interval: 5s}
+//
+// Per device it reads the VNI's type-2 routes from FRR (GET .../evpn/macip-
+// routes?vni=) and looks for one matching every given field: mac and/or ip,
+// at least one required. On the leaf that learned the MAC the route is
+// locally originated; on a remote leaf it is the imported copy, next hop the
+// advertising VTEP — the message says which. With poll:, re-reads until the
+// route appears (or, with present: false, is withdrawn) or the timeout
+// expires.
+
+// evpnType2Params is the params: shape of a verify-evpn-type2 step.
+type evpnType2Params struct {
+	VNI     int    `json:"vni"`
+	MAC     string `json:"mac"`
+	IP      string `json:"ip"`
+	Present *bool  `json:"present"`
+}
+
+// wantPresent reports whether the step asserts the route is there.
+func (p evpnType2Params) wantPresent() bool {
+	return p.Present == nil || *p.Present
+}
+
+func decodeEVPNType2Params(step *Step) (evpnType2Params, error) {
+	var p evpnType2Params
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.VNI < 1 || p.VNI > 16777215 {
+		return p, fmt.Errorf("params.vni is required (1-16777215)")
+	}
+	if p.MAC == "" && p.IP == "" {
+		return p, fmt.Errorf("params.mac or params.ip is required")
+	}
+	if p.MAC != "" {
+		hw, err := net.ParseMAC(p.MAC)
+		if err != nil || len(hw) != 6 {
+			return p, fmt.Errorf("params.mac %q is not a MAC address", p.MAC)
+		}
+		p.MAC = hw.String()
+	}
+	if p.IP != "" {
+		ip := net.ParseIP(p.IP)
+		if ip == nil {
+			return p, fmt.Errorf("params.ip %q is not an IP address", p.IP)
+		}
+		p.IP = ip.String()
+	}
+	return p, nil
+}
+
+// requireEVPNType2Params validates a verify-evpn-type2 step at parse time.
+func requireEVPNType2Params(prefix string, step *Step) error {
+	if _, err := decodeEVPNType2Params(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyEVPNType2Executor asserts a type-2 route's presence per device.
+type verifyEVPNType2Executor struct{}
+
+func (e *verifyEVPNType2Executor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeEVPNType2Params(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		routes, err := r.Client.EVPNMACIPRoutes(dev, params.VNI)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading EVPN type-2 routes: %v", err)
+		}
+		return evpnType2Status(routes, params)
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	return r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		return st == StepStatusPassed, msg, nil
+	})
+}
+
+// matchEVPNType2 returns the routes matching every non-empty field of mac
+// and ip, both already in canonical form.
+func matchEVPNType2(routes []newtron.EVPNMACIPRoute, mac, ip string) []newtron.EVPNMACIPRoute {
+	var matched []newtron.EVPNMACIPRoute
+	for _, rt := range routes {
+		if mac != "" && !strings.EqualFold(rt.MAC, mac) {
+			continue
+		}
+		if ip != "" && rt.IP != ip {
+			continue
+		}
+		matched = append(matched, rt)
+	}
+	return matched
+}
+
+// evpnType2Status reduces one device's type-2 routes to a step status for
+// the route params describes.
+func evpnType2Status(routes []newtron.EVPNMACIPRoute, p evpnType2Params) (StepStatus, string) {
+	route := evpnType2Label(p.MAC, p.IP)
+	matched := matchEVPNType2(routes, p.MAC, p.IP)
+	switch {
+	case len(matched) == 0 && p.wantPresent():
+		return StepStatusFailed, fmt.Sprintf("VNI %d: no type-2 route for %s (%d type-2 routes in the table)", p.VNI, route, len(routes))
+	case len(matched) == 0:
+		return StepStatusPassed, fmt.Sprintf("VNI %d: no type-2 route for %s", p.VNI, route)
+	case p.wantPresent():
+		return StepStatusPassed, fmt.Sprintf("VNI %d: type-2 route %s", p.VNI, evpnType2Origins(matched))
+	}
+	return StepStatusFailed, fmt.Sprintf("VNI %d: type-2 route %s, expected absent", p.VNI, evpnType2Origins(matched))
+}
+
+// evpnType2Label names a route by its MAC and IP, whichever are set.
+func evpnType2Label(mac, ip string) string {
+	switch {
+	case mac != "" && ip != "":
+		return mac + " " + ip
+	case mac != "":
+		return mac
+	}
+	return ip
+}
+
+// evpnType2Origins describes matched routes with where each came from:
+// "52:54:00:12:34:56 10.1.100.10 via 10.0.0.1" or "... (local)".
+func evpnType2Origins(routes []newtron.EVPNMACIPRoute) string {
+	parts := make([]string, len(routes))
+	for i, rt := range routes {
+		origin := "(local)"
+		if !rt.Local {
+			origin = "via " + rt.NextHop
+		}
+		parts[i] = evpnType2Label(rt.MAC, rt.IP) + " " + origin
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,136 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestMatchEVPNType2(t *testing.T) {
+	routes := []newtron.EVPNMACIPRoute{
+		{VNI: 10100, MAC: "52:54:00:12:34:56", Local: true},
+		{VNI: 10100, MAC: "52:54:00:12:34:56", IP: "10.1.100.10", Local: true},
+		{VNI: 10100, MAC: "52:54:00:ab:cd:ef", IP: "10.1.100.20", NextHop: "10.0.0.2"},
+		{VNI: 10100, MAC: "52:54:00:ab:cd:ef", IP: "fe80::5054:ff:feab:cdef", NextHop: "10.0.0.2"},
+	}
+	tests := []struct {
+		name    string
+		mac, ip string
+		want    int
+	}{
+		{"mac matches MAC-only and MAC/IP routes", "52:54:00:12:34:56", "", 2},
+		{"mac and ip", "52:54:00:12:34:56", "10.1.100.10", 1},
+		{"ip alone", "", "10.1.100.20", 1},
+		{"ipv6", "", "fe80::5054:ff:feab:cdef", 1},
+		{"mac with another host's ip", "52:54:00:12:34:56", "10.1.100.20", 0},
+		{"unknown mac", "52:54:00:00:00:01", "", 0},
+		{"mac case-insensitive", "52:54:00:AB:CD:EF", "", 2},
+	}
+	for _, tt := range tests {
+		if got := matchEVPNType2(routes, tt.mac, tt.ip); len(got) != tt.want {
+			t.Errorf("%s: matched %+v, want %d routes", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestEVPNType2Status(t *testing.T) {
+	routes := []newtron.EVPNMACIPRoute{
+		{VNI: 10100, MAC: "52:54:00:ab:cd:ef", IP: "10.1.100.20", NextHop: "10.0.0.2"},
+	}
+	absent := false
+	tests := []struct {
+		name   string
+		params evpnType2Params
+		want   StepStatus
+		msg    string
+	}{
+		{"present", evpnType2Params{VNI: 10100, MAC: "52:54:00:ab:cd:ef"}, StepStatusPassed,
+			"VNI 10100: type-2 route 52:54:00:ab:cd:ef 10.1.100.20 via 10.0.0.2"},
+		{"missing", evpnType2Params{VNI: 10100, MAC: "52:54:00:12:34:56"}, StepStatusFailed,
+			"VNI 10100: no type-2 route for 52:54:00:12:34:56 (1 type-2 routes in the table)"},
+		{"withdrawn", evpnType2Params{VNI: 10100, MAC: "52:54:00:12:34:56", Present: &absent}, StepStatusPassed,
+			"VNI 10100: no type-2 route for 52:54:00:12:34:56"},
+		{"not withdrawn", evpnType2Params{VNI: 10100, IP: "10.1.100.20", Present: &absent}, StepStatusFailed,
+			"VNI 10100: type-2 route 52:54:00:ab:cd:ef 10.1.100.20 via 10.0.0.2, expected absent"},
+	}
+	for _, tt := range tests {
+		st, msg := evpnType2Status(routes, tt.params)
+		if st != tt.want || msg != tt.msg {
+			t.Errorf("%s: %s %q, want %s %q", tt.name, st, msg, tt.want, tt.msg)
+		}
+	}
+}
+
+func TestDecodeEVPNType2Params(t *testing.T) {
+	tests := []struct {
+		params map[string]any
+		want   string // error substring; "" = valid
+	}{
+		{map[string]any{"vni": 10100, "mac": "52:54:00:AB:CD:EF"}, ""},
+		{map[string]any{"vni": 10100, "ip": "10.1.100.20", "present": false}, ""},
+		{map[string]any{"mac": "52:54:00:ab:cd:ef"}, "params.vni is required"},
+		{map[string]any{"vni": 10100}, "params.mac or params.ip is required"},
+		{map[string]any{"vni": 10100, "mac": "not-a-mac"}, "is not a MAC address"},
+		{map[string]any{"vni": 10100, "ip": "10.1.100"}, "is not an IP address"},
+	}
+	for _, tt := range tests {
+		p, err := decodeEVPNType2Params(&Step{Params: tt.params})
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.params, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%v: err = %v, want containing %q", tt.params, err, tt.want)
+		case tt.want == "" && p.MAC != "" && p.MAC != "52:54:00:ab:cd:ef":
+			t.Errorf("%v: MAC = %q, want canonical lower-case", tt.params, p.MAC)
+		}
+	}
+}
+
+func TestVerifyEVPNType2(t *testing.T) {
+	byDevice := map[string][]newtron.EVPNMACIPRoute{
+		"leaf1": {{VNI: 10100, MAC: "52:54:00:12:34:56", IP: "10.1.100.10", Local: true}},
+		"leaf2": {{VNI: 10100, MAC: "52:54:00:12:34:56", IP: "10.1.100.10", NextHop: "10.0.0.1"}},
+		"leaf3": {},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, path, _ := strings.Cut(rest, "/")
+		if dev != "" && (path != "evpn/macip-routes" || r.URL.Query().Get("vni") != "10100") {
+			t.Errorf("%s: unexpected request %q", dev, r.URL.RequestURI())
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "VNI 10100: type-2 route 52:54:00:12:34:56 10.1.100.10 (local)"},
+		"leaf2": {StepStatusPassed, "VNI 10100: type-2 route 52:54:00:12:34:56 10.1.100.10 via 10.0.0.1"},
+		"leaf3": {StepStatusFailed, "VNI 10100: no type-2 route for 52:54:00:12:34:56 10.1.100.10 (0 type-2 routes in the table)"},
+	}
+	step := &Step{
+		Action:  ActionVerifyEVPNType2,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3"}},
+		Params:  map[string]any{"vni": 10100, "mac": "52:54:00:12:34:56", "ip": "10.1.100.10"},
+	}
+	out := (&verifyEVPNType2Executor{}).Execute(context.Background(), r, step)
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("got %d device results, want %d", len(out.Result.Details), len(want))
+	}
+	for _, d := range out.Result.Details {
+		w := want[d.Device]
+		if d.Status != w.status || d.Message != w.msg {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// The verify-no-flaps step asserts that ports did not flap during the
+// current scenario — a link that went down and came back passes every
+// end-state oper_status check, yet it is the instability a suite exists to
+// catch:
+//
+//	- name: uplinks-stable
+//	  action: verify-no-flaps
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    interfaces: [Ethernet0, Ethernet4]
+//
+// The flap window opens when the scenario starts. Before its first step the
+// runner reads each listed port's flap count (GET .../interfaces/{name}/flap-
+// history, APPL_DB PORT_TABLE flap_count) on each device a verify-no-flaps
+// step targets; the step reads it again and FAILs on any port whose count
+// moved. Device clocks play no part. Since the baseline is taken before any
+// step runs, devices and interfaces must be literal — no {{captured.X}}. A
+// port on an image that does not track flaps is not counted; a device where
+// none of the ports is tracked is SKIPPED.
+
+// noFlapsParams is the params: shape of a verify-no-flaps step.
+type noFlapsParams struct {
+	Interfaces []string `json:"interfaces"`
+}
+
+func decodeNoFlapsParams(step *Step) (noFlapsParams, error) {
+	var p noFlapsParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if len(p.Interfaces) == 0 {
+		return p, fmt.Errorf("params.interfaces is required (the ports that must not flap)")
+	}
+	for _, name := range p.Interfaces {
+		if name == "" || strings.Contains(name, "{{") {
+			return p, fmt.Errorf("params.interfaces: %q must be a literal port name (the baseline is read at scenario start)", name)
+		}
+	}
+	return p, nil
+}
+
+// requireNoFlapsParams validates a verify-no-flaps step at parse time.
+func requireNoFlapsParams(prefix string, step *Step) error {
+	if _, err := decodeNoFlapsParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// flapBaseline is one port's flap count at scenario start, or why it could
+// not be read.
+type flapBaseline struct {
+	count     int
+	supported bool
+	err       string
+}
+
+// recordFlapBaselines reads the flap count of every port a verify-no-flaps
+// step in scenario checks, replacing the previous scenario's baselines.
+// Called by runScenarioSteps before the first step; a scenario without
+// such a step reads nothing.
+func (r *Runner) recordFlapBaselines(scenario *Scenario) {
+	baselines := map[string]flapBaseline{}
+	var mu sync.Mutex
+	for i := range scenario.Steps {
+		step := &scenario.Steps[i]
+		if step.Action != ActionVerifyNoFlaps {
+			continue
+		}
+		params, err := decodeNoFlapsParams(step)
+		if err != nil {
+			continue // the step reports it when it runs
+		}
+		r.checkForDevices(step, func(dev string) (StepStatus, string) {
+			for _, name := range params.Interfaces {
+				b := flapBaseline{}
+				if h, err := r.Client.FlapHistory(dev, name); err != nil {
+					b.err = err.Error()
+				} else {
+					b.count, b.supported = h.FlapCount, h.Supported
+				}
+				mu.Lock()
+				baselines[dev+"|"+name] = b
+				mu.Unlock()
+			}
+			return StepStatusPassed, ""
+		})
+	}
+	r.flapBaselinesMu.Lock()
+	defer r.flapBaselinesMu.Unlock()
+	r.flapBaselines = baselines
+}
+
+// loadFlapBaseline returns a port's flap count at scenario start.
+func (r *Runner) loadFlapBaseline(device, iface string) (flapBaseline, bool) {
+	r.flapBaselinesMu.Lock()
+	defer r.flapBaselinesMu.Unlock()
+	b, ok := r.flapBaselines[device+"|"+iface]
+	return b, ok
+}
+
+// verifyNoFlapsExecutor asserts per device that no listed port flapped since
+// the scenario started.
+type verifyNoFlapsExecutor struct{}
+
+func (e *verifyNoFlapsExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeNoFlapsParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		var flapped, untracked []string
+		for _, name := range params.Interfaces {
+			before, ok := r.loadFlapBaseline(dev, name)
+			switch {
+			case !ok:
+				return StepStatusError, fmt.Sprintf("%s: no flap baseline recorded at scenario start", name)
+			case before.err != "":
+				return StepStatusError, fmt.Sprintf("%s: reading flap baseline at scenario start: %s", name, before.err)
+			}
+			h, err := r.Client.FlapHistory(dev, name)
+			if err != nil {
+				return StepStatusError, fmt.Sprintf("%s: reading flap history: %v", name, err)
+			}
+			if !h.Supported || !before.supported {
+				untracked = append(untracked, name)
+				continue
+			}
+			switch delta := h.FlapCount - before.count; {
+			case delta < 0:
+				flapped = append(flapped, fmt.Sprintf("%s (flap counter reset %d → %d: port re-created)", name, before.count, h.FlapCount))
+			case delta > 0:
+				msg := fmt.Sprintf("%s flapped %d time(s)", name, delta)
+				if len(h.Events) > 0 {
+					last := h.Events[len(h.Events)-1]
+					msg += fmt.Sprintf(", last %s at %s", last.Status, last.Time)
+				}
+				flapped = append(flapped, msg)
+			}
+		}
+		switch {
+		case len(flapped) > 0:
+			return StepStatusFailed, strings.Join(flapped, "; ")
+		case len(untracked) == len(params.Interfaces):
+			return StepStatusSkipped, "image does not track link flaps"
+		case len(untracked) > 0:
+			return StepStatusPassed, fmt.Sprintf("no flaps on %d port(s); %s not tracked", len(params.Interfaces)-len(untracked), strings.Join(untracked, ", "))
+		}
+		return StepStatusPassed, fmt.Sprintf("no flaps on %d port(s) during the scenario", len(params.Interfaces))
+	})
+}
@@ -0,0 +1,111 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestVerifyNoFlaps(t *testing.T) {
+	// Each port's flap count at scenario start, then when the step runs; -1
+	// is an image that does not track flaps.
+	counts := map[string][2]int{
+		"leaf1/Ethernet0": {2, 2},
+		"leaf1/Ethernet4": {0, 0},
+		"leaf2/Ethernet0": {5, 7},
+		"leaf2/Ethernet4": {1, 1},
+		"leaf3/Ethernet0": {-1, -1},
+		"leaf3/Ethernet4": {-1, -1},
+		"leaf4/Ethernet0": {3, 0},
+		"leaf4/Ethernet4": {-1, -1},
+	}
+	var mu sync.Mutex
+	reads := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, path, _ := strings.Cut(rest, "/")
+		iface := strings.TrimSuffix(strings.TrimPrefix(path, "interfaces/"), "/flap-history")
+		key := dev + "/" + iface
+		mu.Lock()
+		n := counts[key][min(reads[key], 1)]
+		reads[key]++
+		mu.Unlock()
+		h := newtron.FlapHistory{Interface: iface}
+		if n >= 0 {
+			h.Supported, h.FlapCount = true, n
+			h.Events = []newtron.FlapEvent{{Status: "down", Time: "Wed Mar 13 06:27:05 2024"}, {Status: "up", Time: "Wed Mar 13 06:27:09 2024"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": h})
+	}))
+	defer srv.Close()
+
+	step := Step{
+		Name:    "stable",
+		Action:  ActionVerifyNoFlaps,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3", "leaf4"}},
+		Params:  map[string]any{"interfaces": []any{"Ethernet0", "Ethernet4"}},
+	}
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	r.recordFlapBaselines(&Scenario{Steps: []Step{{Name: "settle", Action: ActionWait}, step}})
+
+	out := (&verifyNoFlapsExecutor{}).Execute(context.Background(), r, &step)
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "no flaps on 2 port(s) during the scenario"},
+		"leaf2": {StepStatusFailed, "Ethernet0 flapped 2 time(s), last up at Wed Mar 13 06:27:09 2024"},
+		"leaf3": {StepStatusSkipped, "image does not track link flaps"},
+		"leaf4": {StepStatusFailed, "Ethernet0 (flap counter reset 3 → 0: port re-created)"},
+	}
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("got %d device results, want %d", len(out.Result.Details), len(want))
+	}
+	for _, d := range out.Result.Details {
+		w := want[d.Device]
+		if d.Status != w.status || d.Message != w.msg {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+
+	// A port the scenario-start pass did not read has no baseline.
+	other := step
+	other.Params = map[string]any{"interfaces": []any{"Ethernet8"}}
+	other.Devices = deviceSelector{Devices: []string{"leaf1"}}
+	out = (&verifyNoFlapsExecutor{}).Execute(context.Background(), r, &other)
+	if d := out.Result.Details[0]; d.Status != StepStatusError || !strings.Contains(d.Message, "no flap baseline") {
+		t.Errorf("unbaselined port = %s %q, want ERROR no flap baseline", d.Status, d.Message)
+	}
+}
+
+func TestRequireNoFlapsParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"interfaces": []any{"Ethernet0"}}, ""},
+		{map[string]any{}, "params.interfaces is required"},
+		{map[string]any{"interfaces": []any{"{{captured.port}}"}}, "must be a literal port name"},
+		{map[string]any{"interfaces": "Ethernet0"}, "params:"},
+	}
+	for _, tt := range tests {
+		err := requireNoFlapsParams("step", &Step{Action: ActionVerifyNoFlaps, Params: tt.params})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tt.params, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
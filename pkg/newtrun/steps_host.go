@@ -39,9 +39,7 @@ func (e *hostExecExecutor) Execute(ctx context.Context, r *Runner, step *Step) *
 		}}
 	}
 
-	// Namespace is always the device name. Wrap in sh -c so that compound
-	// commands (semicolons, pipes) execute entirely inside the namespace.
-	cmd := fmt.Sprintf("ip netns exec %s sh -c %s", deviceName, shellQuote(step.Command))
+	cmd := netnsCommand(deviceName, step.Command)
 
 	attempt := func() *StepResult {
 		output, err := runSSHCommand(client, cmd)
@@ -70,9 +68,15 @@ func (e *hostExecExecutor) Execute(ctx context.Context, r *Runner, step *Step) *
 }
 
 // evaluateHostExpect applies a host-exec step's expectations to one command
-// execution and returns the step result. Shared by the one-shot and poll
-// paths so both judge an attempt identically.
+// execution and returns the step result, the command's output in Output.
+// Shared by the one-shot and poll paths so both judge an attempt identically.
 func evaluateHostExpect(step *Step, output string, err error) *StepResult {
+	res := judgeHostExpect(step, output, err)
+	res.Output = output
+	return res
+}
+
+func judgeHostExpect(step *Step, output string, err error) *StepResult {
 	if step.Expect != nil && step.Expect.SuccessRate != nil {
 		rate := parsePingSuccessRate(output)
 		expected := *step.Expect.SuccessRate
@@ -84,7 +88,7 @@ func evaluateHostExpect(step *Step, output string, err error) *StepResult {
 		}
 		return &StepResult{
 			Status:  StepStatusFailed,
-			Message: fmt.Sprintf("%.0f%% success (expected ≥ %.0f%%)\n%s", rate*100, expected*100, output),
+			Message: fmt.Sprintf("%.0f%% success (expected ≥ %.0f%%)", rate*100, expected*100),
 		}
 	}
 
@@ -97,7 +101,7 @@ func evaluateHostExpect(step *Step, output string, err error) *StepResult {
 		}
 		return &StepResult{
 			Status:  StepStatusFailed,
-			Message: fmt.Sprintf("output does not contain %q\n%s", step.Expect.Contains, output),
+			Message: fmt.Sprintf("output does not contain %q", step.Expect.Contains),
 		}
 	}
 
@@ -105,7 +109,7 @@ func evaluateHostExpect(step *Step, output string, err error) *StepResult {
 	if err != nil {
 		return &StepResult{
 			Status:  StepStatusFailed,
-			Message: fmt.Sprintf("command failed: %s\n%s", err, output),
+			Message: fmt.Sprintf("command failed: %s", err),
 		}
 	}
 	return &StepResult{
@@ -114,6 +118,13 @@ func evaluateHostExpect(step *Step, output string, err error) *StepResult {
 	}
 }
 
+// netnsCommand wraps cmd to run inside a host device's network namespace.
+// The namespace is always the device name. Wrapping in sh -c makes compound
+// commands (semicolons, pipes) execute entirely inside the namespace.
+func netnsCommand(dev, cmd string) string {
+	return fmt.Sprintf("ip netns exec %s sh -c %s", dev, shellQuote(cmd))
+}
+
 // shellQuote wraps s in single quotes, escaping any embedded single quotes.
 func shellQuote(s string) string {
 	return "'" + strings.ReplaceAll(s, "'", "'\"'\"'") + "'"
@@ -0,0 +1,134 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-igmp-snooping step asserts that IGMP snooping is on for a VLAN
+// and, optionally, that multicast groups have been learned there, on each
+// target device:
+//
+//	- name: market-data-snooped
+//	  action: verify-igmp-snooping
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    vlan_id: 100
+//	    groups: [239.1.1.1, 239.1.1.2]
+//	  poll: {timeout: 1m, interval: 5s}
+//
+// Per device it reads the VLAN's snooping state (GET .../vlans/{id}/igmp-
+// snooping) and PASSes when CONFIG_DB CFG_L2MC_TABLE has enabled=true and
+// every listed group has at least one joined port in STATE_DB
+// L2MC_MEMBER_TABLE. A device whose platform does not support igmp-snooping
+// is SKIPPED, not failed. With poll:, re-reads until the groups are learned
+// or the timeout expires — hosts join on their own schedule.
+
+// igmpSnoopingParams is the params: shape of a verify-igmp-snooping step.
+type igmpSnoopingParams struct {
+	VlanID int      `json:"vlan_id"`
+	Groups []string `json:"groups,omitempty"`
+}
+
+func decodeIGMPSnoopingParams(step *Step) (igmpSnoopingParams, error) {
+	var p igmpSnoopingParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.VlanID < 1 || p.VlanID > 4094 {
+		return p, fmt.Errorf("params.vlan_id is required (1-4094)")
+	}
+	for _, g := range p.Groups {
+		if ip := net.ParseIP(g).To4(); ip == nil || !ip.IsMulticast() {
+			return p, fmt.Errorf("params.groups: %q is not an IPv4 multicast address", g)
+		}
+	}
+	return p, nil
+}
+
+// requireIGMPSnoopingParams validates a verify-igmp-snooping step at parse
+// time.
+func requireIGMPSnoopingParams(prefix string, step *Step) error {
+	if _, err := decodeIGMPSnoopingParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyIGMPSnoopingExecutor asserts a VLAN's IGMP snooping per device.
+type verifyIGMPSnoopingExecutor struct{}
+
+func (e *verifyIGMPSnoopingExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeIGMPSnoopingParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		st, err := r.Client.IGMPSnooping(dev, params.VlanID)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading IGMP snooping: %v", err)
+		}
+		return igmpSnoopingStatus(st, params.Groups)
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	// An unsupported platform stays unsupported: stop polling it and report
+	// it SKIPPED rather than as the poll helper's PASSED.
+	var mu sync.Mutex
+	skipped := map[string]bool{}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	out := r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		if st == StepStatusSkipped {
+			mu.Lock()
+			skipped[dev] = true
+			mu.Unlock()
+		}
+		return st == StepStatusPassed || st == StepStatusSkipped, msg, nil
+	})
+	for i, d := range out.Result.Details {
+		if skipped[d.Device] {
+			out.Result.Details[i].Status = StepStatusSkipped
+		}
+	}
+	return out
+}
+
+// igmpSnoopingStatus reduces one device's VLAN state to a step status:
+// PASSED only when snooping is configured and every group in groups has
+// been learned; SKIPPED when the platform has no IGMP snooping.
+func igmpSnoopingStatus(st *newtron.IGMPState, groups []string) (StepStatus, string) {
+	switch {
+	case !st.Supported:
+		return StepStatusSkipped, fmt.Sprintf("%s: platform does not support IGMP snooping", st.VLAN)
+	case !st.Configured && st.Intended:
+		return StepStatusFailed, fmt.Sprintf("%s: IGMP snooping intended but not configured (no CONFIG_DB CFG_L2MC_TABLE enabled=true)", st.VLAN)
+	case !st.Configured:
+		return StepStatusFailed, fmt.Sprintf("%s: IGMP snooping not enabled", st.VLAN)
+	}
+	var missing []string
+	for _, want := range groups {
+		if !slices.ContainsFunc(st.Groups, func(g newtron.IGMPGroup) bool { return g.Group == want && len(g.Ports) > 0 }) {
+			missing = append(missing, want)
+		}
+	}
+	if len(missing) > 0 {
+		return StepStatusFailed, fmt.Sprintf("%s: IGMP snooping on, but no port has joined %s", st.VLAN, strings.Join(missing, ", "))
+	}
+	return StepStatusPassed, fmt.Sprintf("%s: IGMP snooping on, %d group(s) learned", st.VLAN, len(st.Groups))
+}
@@ -0,0 +1,104 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestVerifyIGMPSnooping(t *testing.T) {
+	learned := []newtron.IGMPGroup{
+		{Group: "239.1.1.1", Source: "0.0.0.0", Ports: []string{"Ethernet0", "Ethernet4"}},
+		{Group: "239.1.1.2", Source: "0.0.0.0", Ports: []string{"Ethernet4"}},
+	}
+	byDevice := map[string]newtron.IGMPState{
+		"leaf1": {VLAN: "Vlan100", Supported: true, Intended: true, Configured: true, Groups: learned},
+		"leaf2": {VLAN: "Vlan100", Supported: true, Intended: true, Configured: true, Groups: learned[:1]},
+		"leaf3": {VLAN: "Vlan100", Supported: true, Intended: true},
+		"leaf4": {VLAN: "Vlan100", Supported: true},
+		"leaf5": {VLAN: "Vlan100"},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, path, _ := strings.Cut(rest, "/")
+		if dev != "" && path != "vlans/100/igmp-snooping" {
+			t.Errorf("%s: unexpected path %q", dev, path)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "Vlan100: IGMP snooping on, 2 group(s) learned"},
+		"leaf2": {StepStatusFailed, "Vlan100: IGMP snooping on, but no port has joined 239.1.1.2"},
+		"leaf3": {StepStatusFailed, "Vlan100: IGMP snooping intended but not configured (no CONFIG_DB CFG_L2MC_TABLE enabled=true)"},
+		"leaf4": {StepStatusFailed, "Vlan100: IGMP snooping not enabled"},
+		"leaf5": {StepStatusSkipped, "Vlan100: platform does not support IGMP snooping"},
+	}
+	step := &Step{
+		Action:  ActionVerifyIGMPSnooping,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3", "leaf4", "leaf5"}},
+		Params:  map[string]any{"vlan_id": 100, "groups": []any{"239.1.1.1", "239.1.1.2"}},
+	}
+	out := (&verifyIGMPSnoopingExecutor{}).Execute(context.Background(), r, step)
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("got %d device results, want %d", len(out.Result.Details), len(want))
+	}
+	for _, d := range out.Result.Details {
+		w := want[d.Device]
+		if d.Status != w.status || d.Message != w.msg {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+
+	// Polling stops at an unsupported platform and still reports it skipped.
+	step.Devices = deviceSelector{Devices: []string{"leaf1", "leaf5"}}
+	step.Poll = &PollBlock{Timeout: time.Second, Interval: 10 * time.Millisecond}
+	out = (&verifyIGMPSnoopingExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusPassed {
+		t.Errorf("poll status = %s, want PASSED", out.Result.Status)
+	}
+	for _, d := range out.Result.Details {
+		if d.Status != want[d.Device].status {
+			t.Errorf("poll %s: %s, want %s", d.Device, d.Status, want[d.Device].status)
+		}
+	}
+}
+
+func TestRequireIGMPSnoopingParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"vlan_id": 100}, ""},
+		{map[string]any{"vlan_id": 100, "groups": []any{"239.1.1.1"}}, ""},
+		{map[string]any{}, "params.vlan_id is required"},
+		{map[string]any{"vlan_id": 4095}, "params.vlan_id is required"},
+		{map[string]any{"vlan_id": 100, "groups": []any{"10.1.1.1"}}, "not an IPv4 multicast address"},
+		{map[string]any{"vlan_id": 100, "groups": "239.1.1.1"}, "params:"},
+	}
+	for _, tt := range tests {
+		err := requireIGMPSnoopingParams("step", &Step{Action: ActionVerifyIGMPSnooping, Params: tt.params})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tt.params, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
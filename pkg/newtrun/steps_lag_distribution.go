@@ -0,0 +1,132 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-lag-distribution step asserts that a LAG spreads what it sends
+// across its members — that it is not polarized onto one link — on each
+// target device:
+//
+//	- name: uplink-lag-balanced
+//	  action: verify-lag-distribution
+//	  devices: [leaf1]
+//	  params:
+//	    portchannel: PortChannel100
+//	    max_skew: 20          # busiest member at most 20 points above even
+//	    window: 10s           # optional; sample window, 1s-1m
+//	    min_packets: 1000     # optional; default 1
+//
+// Per device it samples the members' TX counters over the window (GET
+// .../lags/{name}/hash-distribution?window=) and compares the skew — how
+// many percentage points the busiest member's share sits above an even
+// split — with max_skew. Run it while traffic flows (a generate-traffic
+// step before it, or traffic the scenario started): a window that carried
+// fewer than min_packets packets proves nothing and FAILs.
+
+// lagDistributionParams is the params: shape of a verify-lag-distribution step.
+type lagDistributionParams struct {
+	PortChannel string   `json:"portchannel"`
+	MaxSkew     *float64 `json:"max_skew"`
+	Window      string   `json:"window"`
+	MinPackets  *uint64  `json:"min_packets"`
+
+	window     time.Duration
+	minPackets uint64
+}
+
+const defaultLAGDistributionWindow = 10 * time.Second
+
+func decodeLAGDistributionParams(step *Step) (lagDistributionParams, error) {
+	var p lagDistributionParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.PortChannel == "" {
+		return p, fmt.Errorf("params.portchannel is required")
+	}
+	if p.MaxSkew == nil {
+		return p, fmt.Errorf("params.max_skew is required")
+	}
+	if *p.MaxSkew < 0 || *p.MaxSkew >= 100 {
+		return p, fmt.Errorf("params.max_skew %v: must be from 0 to 100 (percentage points)", *p.MaxSkew)
+	}
+	p.window = defaultLAGDistributionWindow
+	if p.Window != "" {
+		d, err := time.ParseDuration(p.Window)
+		if err != nil {
+			return p, fmt.Errorf("params.window: %w", err)
+		}
+		if d < time.Second || d > time.Minute {
+			return p, fmt.Errorf("params.window: %s is not from 1s to 1m", d)
+		}
+		p.window = d
+	}
+	p.minPackets = 1
+	if p.MinPackets != nil {
+		p.minPackets = max(*p.MinPackets, 1)
+	}
+	return p, nil
+}
+
+// requireLAGDistributionParams validates a verify-lag-distribution step at parse time.
+func requireLAGDistributionParams(prefix string, step *Step) error {
+	if _, err := decodeLAGDistributionParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyLAGDistributionExecutor asserts a LAG's traffic skew per device.
+type verifyLAGDistributionExecutor struct{}
+
+func (e *verifyLAGDistributionExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeLAGDistributionParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		d, err := r.Client.LAGHashDistribution(dev, params.PortChannel, params.window)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading %s hash distribution: %v", params.PortChannel, err)
+		}
+		return lagDistributionStatus(d, params)
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	return r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		return st == StepStatusPassed, msg, nil
+	})
+}
+
+// lagDistributionStatus judges one LAG's distribution against params.
+func lagDistributionStatus(d *newtron.LAGHashDistribution, p lagDistributionParams) (StepStatus, string) {
+	if d.TxPackets < p.minPackets {
+		return StepStatusFailed, fmt.Sprintf("%s: %d packets sent in %s, expected >= %d — is traffic flowing?", d.Name, d.TxPackets, d.Window, p.minPackets)
+	}
+	shares := make([]string, len(d.Members))
+	for i, m := range d.Members {
+		shares[i] = fmt.Sprintf("%s %.1f%%", m.Name, m.Share)
+	}
+	detail := fmt.Sprintf("%s; %d packets in %s", strings.Join(shares, ", "), d.TxPackets, d.Window)
+	if d.Skew > *p.MaxSkew {
+		return StepStatusFailed, fmt.Sprintf("%s: skew %.1f points, expected <= %v (%s)", d.Name, d.Skew, *p.MaxSkew, detail)
+	}
+	return StepStatusPassed, fmt.Sprintf("%s: skew %.1f points (%s)", d.Name, d.Skew, detail)
+}
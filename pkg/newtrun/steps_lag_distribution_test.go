@@ -0,0 +1,71 @@
+package newtrun
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+func TestDecodeLAGDistributionParams(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]any
+		want   string // error substring; "" = valid
+	}{
+		{"minimal", map[string]any{"portchannel": "PortChannel100", "max_skew": 20}, ""},
+		{"all fields", map[string]any{"portchannel": "PortChannel100", "max_skew": 12.5, "window": "30s", "min_packets": 1000}, ""},
+		{"no portchannel", map[string]any{"max_skew": 20}, "params.portchannel is required"},
+		{"no max_skew", map[string]any{"portchannel": "PortChannel100"}, "params.max_skew is required"},
+		{"skew out of range", map[string]any{"portchannel": "PortChannel100", "max_skew": 100}, "must be from 0 to 100"},
+		{"bad window", map[string]any{"portchannel": "PortChannel100", "max_skew": 20, "window": "soon"}, "params.window"},
+		{"window too long", map[string]any{"portchannel": "PortChannel100", "max_skew": 20, "window": "5m"}, "not from 1s to 1m"},
+	}
+	for _, tt := range tests {
+		p, err := decodeLAGDistributionParams(&Step{Params: tt.params})
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("%s: unexpected error %v", tt.name, err)
+		case tt.want != "" && (err == nil || !strings.Contains(err.Error(), tt.want)):
+			t.Errorf("%s: err = %v, want containing %q", tt.name, err, tt.want)
+		case tt.want == "" && tt.name == "minimal" && (p.window != defaultLAGDistributionWindow || p.minPackets != 1):
+			t.Errorf("%s: defaults = window %s, min_packets %d", tt.name, p.window, p.minPackets)
+		case tt.want == "" && tt.name == "all fields" && (p.window != 30*time.Second || p.minPackets != 1000):
+			t.Errorf("%s: window %s, min_packets %d", tt.name, p.window, p.minPackets)
+		}
+	}
+}
+
+func TestLAGDistributionStatus(t *testing.T) {
+	p, err := decodeLAGDistributionParams(&Step{Params: map[string]any{"portchannel": "PortChannel100", "max_skew": 20, "min_packets": 100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dist := func(skew float64, total uint64, shares ...float64) *newtron.LAGHashDistribution {
+		d := &newtron.LAGHashDistribution{Name: "PortChannel100", Window: "10s", TxPackets: total, Skew: skew}
+		for i, s := range shares {
+			d.Members = append(d.Members, newtron.LAGMemberShare{Name: []string{"Ethernet0", "Ethernet4"}[i], Share: s})
+		}
+		return d
+	}
+	tests := []struct {
+		name       string
+		d          *newtron.LAGHashDistribution
+		wantStatus StepStatus
+		wantMsg    string
+	}{
+		{"balanced", dist(4.2, 12000, 54.2, 45.8), StepStatusPassed,
+			"PortChannel100: skew 4.2 points (Ethernet0 54.2%, Ethernet4 45.8%; 12000 packets in 10s)"},
+		{"polarized", dist(50, 12000, 100, 0), StepStatusFailed,
+			"PortChannel100: skew 50.0 points, expected <= 20 (Ethernet0 100.0%, Ethernet4 0.0%; 12000 packets in 10s)"},
+		{"too little traffic", dist(0, 10, 50, 50), StepStatusFailed,
+			"PortChannel100: 10 packets sent in 10s, expected >= 100"},
+	}
+	for _, tt := range tests {
+		status, msg := lagDistributionStatus(tt.d, p)
+		if status != tt.wantStatus || !strings.HasPrefix(msg, tt.wantMsg) {
+			t.Errorf("%s: = %s %q, want %s %q", tt.name, status, msg, tt.wantStatus, tt.wantMsg)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-lldp step asserts which device LLDP hears on the far end of a
+// port, on each target device — the cabling check BGP convergence alone
+// cannot make (a swapped pair of uplinks still converges):
+//
+//	- name: leaf1-cabled-to-spine1
+//	  action: verify-lldp
+//	  devices: [leaf1]
+//	  params:
+//	    interface: Ethernet0
+//	    neighbor: spine1            # the far end's LLDP system name
+//	    neighbor_port: Ethernet0    # optional: its port ID or description
+//	  poll: {timeout: 2m, interval: 10s}
+//
+// Per device it reads the port's live status (GET .../interfaces/{name}/
+// status), whose LLDP far end comes from APPL_DB LLDP_ENTRY_TABLE, and
+// compares the system name (case-insensitive, as hostnames are) and, when
+// given, the port. lldpd advertises on a timer, so a freshly provisioned
+// fabric has no neighbors for a while — use poll: to re-read until the
+// neighbor appears or the timeout expires.
+
+// lldpParams is the params: shape of a verify-lldp step.
+type lldpParams struct {
+	Interface    string `json:"interface"`
+	Neighbor     string `json:"neighbor"`
+	NeighborPort string `json:"neighbor_port"`
+}
+
+func decodeLLDPParams(step *Step) (lldpParams, error) {
+	var p lldpParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.Interface == "" {
+		return p, fmt.Errorf("params.interface is required")
+	}
+	if p.Neighbor == "" {
+		return p, fmt.Errorf("params.neighbor is required (the expected far-end system name)")
+	}
+	return p, nil
+}
+
+// requireLLDPParams validates a verify-lldp step at parse time.
+func requireLLDPParams(prefix string, step *Step) error {
+	if _, err := decodeLLDPParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyLLDPExecutor asserts a port's LLDP neighbor per device.
+type verifyLLDPExecutor struct{}
+
+func (e *verifyLLDPExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeLLDPParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	check := func(dev string) (StepStatus, string) {
+		st, err := r.Client.InterfaceStatus(dev, params.Interface)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading %s status: %v", params.Interface, err)
+		}
+		observe(ctx, dev, st.LLDPPeer)
+		return lldpNeighborStatus(st.LLDPPeer, params)
+	}
+
+	if step.Poll == nil {
+		return r.checkForDevices(step, check)
+	}
+	pollStep := *step
+	pollStep.Expect = &ExpectBlock{Timeout: step.Poll.Timeout, PollInterval: step.Poll.Interval}
+	return r.pollForDevices(ctx, &pollStep, func(dev string) (bool, string, error) {
+		st, msg := check(dev)
+		return st == StepStatusPassed, msg, nil
+	})
+}
+
+// lldpNeighborStatus compares one port's LLDP far end against params. The
+// expected port matches either the far end's port ID or its port
+// description: SONiC advertises the interface name in one or the other
+// depending on lldpd's port-id subtype.
+func lldpNeighborStatus(peer *newtron.LLDPPeer, params lldpParams) (StepStatus, string) {
+	want := params.Neighbor
+	if params.NeighborPort != "" {
+		want += " port " + params.NeighborPort
+	}
+	if peer == nil || peer.SystemName == "" && peer.PortID == "" {
+		return StepStatusFailed, fmt.Sprintf("%s: no LLDP neighbor, expected %s", params.Interface, want)
+	}
+	got := peer.SystemName + " port " + peer.PortID
+	if !strings.EqualFold(peer.SystemName, params.Neighbor) {
+		return StepStatusFailed, fmt.Sprintf("%s: LLDP neighbor is %s, expected %s", params.Interface, got, want)
+	}
+	if params.NeighborPort != "" && peer.PortID != params.NeighborPort && peer.PortDescription != params.NeighborPort {
+		return StepStatusFailed, fmt.Sprintf("%s: LLDP neighbor is %s, expected %s", params.Interface, got, want)
+	}
+	return StepStatusPassed, fmt.Sprintf("%s: LLDP neighbor %s", params.Interface, got)
+}
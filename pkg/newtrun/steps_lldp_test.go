@@ -0,0 +1,130 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// lldpStatusServer fakes newtron-server's GET .../interfaces/{name}/status
+// per device, answering only for Ethernet0.
+func lldpStatusServer(t *testing.T, byDevice map[string]*newtron.LLDPPeer) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		if !strings.HasSuffix(r.URL.Path, "/interfaces/Ethernet0/status") {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(map[string]any{"error": "interface not found"})
+			return
+		}
+		st := newtron.InterfaceStatus{Name: "Ethernet0", OperStatus: "up", LLDPPeer: byDevice[dev]}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": st})
+	}))
+}
+
+func TestVerifyLLDP(t *testing.T) {
+	srv := lldpStatusServer(t, map[string]*newtron.LLDPPeer{
+		"leaf1": {SystemName: "spine1", PortID: "Ethernet0", PortDescription: "leaf1:Ethernet0"},
+		"leaf2": {SystemName: "spine2", PortID: "Ethernet4"},
+		"leaf3": {SystemName: "Spine1", PortID: "fc:01:02:03:04:05", PortDescription: "Ethernet8"},
+		"leaf4": nil,
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	tests := []struct {
+		name   string
+		params map[string]any
+		want   map[string]string // device → message; "" = PASS
+	}{
+		{"system name", map[string]any{"interface": "Ethernet0", "neighbor": "spine1"}, map[string]string{
+			"leaf1": "",
+			"leaf2": "Ethernet0: LLDP neighbor is spine2 port Ethernet4, expected spine1",
+			"leaf3": "",
+			"leaf4": "Ethernet0: no LLDP neighbor, expected spine1",
+		}},
+		{"port id", map[string]any{"interface": "Ethernet0", "neighbor": "spine1", "neighbor_port": "Ethernet0"}, map[string]string{
+			"leaf1": "",
+			"leaf3": "Ethernet0: LLDP neighbor is Spine1 port fc:01:02:03:04:05, expected spine1 port Ethernet0",
+		}},
+		{"port description", map[string]any{"interface": "Ethernet0", "neighbor": "spine1", "neighbor_port": "Ethernet8"}, map[string]string{
+			"leaf3": "",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var devices []string
+			for dev := range tt.want {
+				devices = append(devices, dev)
+			}
+			step := &Step{
+				Action:  ActionVerifyLLDP,
+				Devices: deviceSelector{Devices: devices},
+				Params:  tt.params,
+			}
+			out := (&verifyLLDPExecutor{}).Execute(context.Background(), r, step)
+			for _, d := range out.Result.Details {
+				want := tt.want[d.Device]
+				if want == "" {
+					if d.Status != StepStatusPassed {
+						t.Errorf("%s: %s %q, want PASSED", d.Device, d.Status, d.Message)
+					}
+					continue
+				}
+				if d.Status != StepStatusFailed || d.Message != want {
+					t.Errorf("%s: %s %q, want FAILED %q", d.Device, d.Status, d.Message, want)
+				}
+			}
+		})
+	}
+}
+
+// TestVerifyLLDP_UnknownInterface: a port the device does not have is an
+// ERROR, not a missing neighbor.
+func TestVerifyLLDP_UnknownInterface(t *testing.T) {
+	srv := lldpStatusServer(t, nil)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyLLDP,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+		Params:  map[string]any{"interface": "Ethernet96", "neighbor": "spine1"},
+	}
+	out := (&verifyLLDPExecutor{}).Execute(context.Background(), r, step)
+	if len(out.Result.Details) != 1 || out.Result.Details[0].Status != StepStatusError {
+		t.Fatalf("details = %+v, want one ERROR", out.Result.Details)
+	}
+}
+
+func TestRequireLLDPParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"interface": "Ethernet0", "neighbor": "spine1", "neighbor_port": "Ethernet0"}, ""},
+		{map[string]any{"neighbor": "spine1"}, "params.interface is required"},
+		{map[string]any{"interface": "Ethernet0"}, "params.neighbor is required"},
+		{map[string]any{"interface": "Ethernet0", "neighbor": 7}, "params:"},
+	}
+	for _, tt := range tests {
+		err := requireLLDPParams("step", &Step{Action: ActionVerifyLLDP, Params: tt.params})
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error %v", tt.params, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
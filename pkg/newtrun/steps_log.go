@@ -0,0 +1,165 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-log step asserts that no error lines appeared in each target
+// device's syslog during the current scenario — the failures that never reach
+// CONFIG_DB or STATE_DB (orchagent rejecting an entry, a daemon restarting):
+//
+//	- name: no-orchagent-errors
+//	  action: verify-log
+//	  devices: all
+//	  params:
+//	    pattern: "orchagent|syncd"   # regex; omit to check every program
+//	    severity: err                # this and more severe count (default err)
+//	    allow:                       # known-benign messages, regex
+//	      - "Failed to get port oper speed"
+//	    lines: 5000                  # syslog tail to read (default 5000)
+//
+// The log window opens when the scenario starts: per device the step reads
+// the syslog tail (GET .../syslog?since=) from that instant on, and FAILs on
+// any line at or above the severity whose program or message matches
+// pattern and whose message matches no allow entry. Lines without a severity
+// keyword are not counted. Device stamps are compared with the runner's
+// clock, so a skewed device clock shifts the window. When the tail is all
+// inside the window it may not reach back to the scenario start, and the
+// step is an ERROR rather than a pass over lines it never saw.
+
+// defaultLogLines is the syslog tail verify-log reads when params.lines is
+// unset.
+const defaultLogLines = 5000
+
+// maxLogLinesShown caps the offending lines one device's message lists.
+const maxLogLinesShown = 5
+
+// logParams is the params: shape of a verify-log step.
+type logParams struct {
+	Pattern  string   `json:"pattern"`
+	Severity string   `json:"severity"`
+	Allow    []string `json:"allow"`
+	Lines    int      `json:"lines"`
+
+	pattern *regexp.Regexp
+	allow   []*regexp.Regexp
+	rank    int
+}
+
+func decodeLogParams(step *Step) (logParams, error) {
+	var p logParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.Pattern != "" {
+		if p.pattern, err = regexp.Compile(p.Pattern); err != nil {
+			return p, fmt.Errorf("params.pattern: %w", err)
+		}
+	}
+	for i, a := range p.Allow {
+		re, err := regexp.Compile(a)
+		if err != nil {
+			return p, fmt.Errorf("params.allow[%d]: %w", i, err)
+		}
+		p.allow = append(p.allow, re)
+	}
+	if p.Severity == "" {
+		p.Severity = "err"
+	}
+	if p.rank = newtron.SyslogSeverityRank(p.Severity); p.rank < 0 {
+		return p, fmt.Errorf("params.severity must be a syslog severity (emerg, alert, crit, err, warning, notice, info, debug), got %q", p.Severity)
+	}
+	switch {
+	case p.Lines == 0:
+		p.Lines = defaultLogLines
+	case p.Lines < 0 || p.Lines > newtron.MaxSyslogLines:
+		return p, fmt.Errorf("params.lines must be 1-%d, got %d", newtron.MaxSyslogLines, p.Lines)
+	}
+	return p, nil
+}
+
+// requireLogParams validates a verify-log step at parse time.
+func requireLogParams(prefix string, step *Step) error {
+	if _, err := decodeLogParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyLogExecutor asserts that no unallowed error lines were logged on
+// each device since the scenario started.
+type verifyLogExecutor struct{}
+
+func (e *verifyLogExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeLogParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	since := r.scenarioStart
+
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		lines, err := r.Client.Syslog(dev, params.Lines, since)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading syslog: %v", err)
+		}
+		if len(lines) >= params.Lines {
+			return StepStatusError, fmt.Sprintf("the last %d syslog lines all fall in the scenario window, which may start earlier; raise params.lines", params.Lines)
+		}
+		bad := params.offending(lines)
+		scope := fmt.Sprintf("%s-or-worse lines", strings.ToUpper(params.Severity))
+		if params.pattern != nil {
+			scope += fmt.Sprintf(" matching %q", params.Pattern)
+		}
+		if len(bad) == 0 {
+			return StepStatusPassed, fmt.Sprintf("no %s since scenario start (%d lines scanned)", scope, len(lines))
+		}
+		shown := make([]string, 0, maxLogLinesShown)
+		for _, l := range bad[:min(len(bad), maxLogLinesShown)] {
+			shown = append(shown, fmt.Sprintf("%s %s: %s", l.Severity, l.Program, l.Message))
+		}
+		msg := fmt.Sprintf("%d %s since scenario start: %s", len(bad), scope, strings.Join(shown, "; "))
+		if len(bad) > maxLogLinesShown {
+			msg += fmt.Sprintf("; and %d more", len(bad)-maxLogLinesShown)
+		}
+		return StepStatusFailed, msg
+	})
+}
+
+// offending returns the lines that count against the step: severe enough,
+// matching pattern (program or message) when one is set, and allowed by no
+// allow entry.
+func (p logParams) offending(lines []newtron.LogLine) []newtron.LogLine {
+	var bad []newtron.LogLine
+	for _, l := range lines {
+		if rank := newtron.SyslogSeverityRank(l.Severity); rank < 0 || rank > p.rank {
+			continue
+		}
+		if p.pattern != nil && !p.pattern.MatchString(l.Program) && !p.pattern.MatchString(l.Message) {
+			continue
+		}
+		if p.allowed(l.Message) {
+			continue
+		}
+		bad = append(bad, l)
+	}
+	return bad
+}
+
+func (p logParams) allowed(msg string) bool {
+	for _, re := range p.allow {
+		if re.MatchString(msg) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// syslogServer fakes newtron-server's GET .../syslog per device, honouring
+// ?since= the way the node read does.
+func syslogServer(t *testing.T, byDevice map[string][]newtron.LogLine) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		var since time.Time
+		if v := r.URL.Query().Get("since"); v != "" {
+			since, _ = time.Parse(time.RFC3339Nano, v)
+		}
+		var out []newtron.LogLine
+		for _, l := range byDevice[dev] {
+			if !l.Time.Before(since) {
+				out = append(out, l)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": out})
+	}))
+}
+
+func TestVerifyLog(t *testing.T) {
+	start := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	at := func(sec int, sev, prog, msg string) newtron.LogLine {
+		return newtron.LogLine{Time: start.Add(time.Duration(sec) * time.Second), Host: "sonic",
+			Severity: sev, Program: prog, Message: msg}
+	}
+	srv := syslogServer(t, map[string][]newtron.LogLine{
+		"leaf1": {
+			at(-60, "ERR", "swss#orchagent", "before the scenario"),
+			at(5, "NOTICE", "swss#orchagent", "addVlan: created"),
+			at(6, "ERR", "syncd#syncd", "Failed to get port oper speed for Ethernet4"),
+		},
+		"leaf2": {
+			at(5, "ERR", "swss#orchagent", "doTask: failed to create route 10.1.0.0/24"),
+			at(6, "CRIT", "bgp#bgpd", "peer 10.0.0.1 reset"),
+			at(7, "WARNING", "swss#orchagent", "slow"),
+		},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net"), scenarioStart: start}
+
+	step := &Step{
+		Action:  ActionVerifyLog,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2"}},
+		Params:  map[string]any{"allow": []any{"oper speed"}},
+	}
+	out := (&verifyLogExecutor{}).Execute(context.Background(), r, step)
+	d := out.Result.Details
+	if d[0].Status != StepStatusPassed {
+		t.Errorf("leaf1: %s %q, want PASSED (the pre-scenario and allowed errors do not count)", d[0].Status, d[0].Message)
+	}
+	if d[1].Status != StepStatusFailed || !strings.HasPrefix(d[1].Message, "2 ERR-or-worse lines since scenario start") {
+		t.Errorf("leaf2: %s %q, want FAILED on the ERR and CRIT lines", d[1].Status, d[1].Message)
+	}
+
+	step.Params = map[string]any{"pattern": "orchagent", "severity": "crit"}
+	out = (&verifyLogExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusPassed {
+		t.Errorf("pattern orchagent, severity crit: %+v, want PASSED", out.Result.Details)
+	}
+
+	step.Params = map[string]any{"lines": 2}
+	out = (&verifyLogExecutor{}).Execute(context.Background(), r, step)
+	if d := out.Result.Details[1]; d.Status != StepStatusError || !strings.Contains(d.Message, "raise params.lines") {
+		t.Errorf("short tail: %s %q, want ERROR asking for more lines", d.Status, d.Message)
+	}
+}
+
+func TestRequireLogParams(t *testing.T) {
+	tests := []struct {
+		params map[string]any
+		want   string
+	}{
+		{map[string]any{"severity": "loud"}, "params.severity"},
+		{map[string]any{"pattern": "("}, "params.pattern"},
+		{map[string]any{"allow": []any{"ok", "["}}, "params.allow[1]"},
+		{map[string]any{"lines": 50000}, "params.lines must be 1-20000"},
+	}
+	for _, tt := range tests {
+		err := requireLogParams("step", &Step{Action: ActionVerifyLog, Params: tt.params})
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("requireLogParams(%v) = %v, want error mentioning %q", tt.params, err, tt.want)
+		}
+	}
+	if err := requireLogParams("step", &Step{Action: ActionVerifyLog}); err != nil {
+		t.Errorf("no params: %v", err)
+	}
+}
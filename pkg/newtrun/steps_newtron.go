@@ -19,7 +19,17 @@ import (
 // from the path after the network segment (e.g., /nodes/{{device}}/vlans).
 type newtronExecutor struct{}
 
+// Execute runs the step and, when it passes, publishes the outputs its
+// ChangeSets derived (publish.go).
 func (e *newtronExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	out := e.execute(ctx, r, step)
+	if out.Result.Status == StepStatusPassed {
+		out.Result.Outputs = changeSetOutputs(r.stepChangeSets(step.Name))
+	}
+	return out
+}
+
+func (e *newtronExecutor) execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
 	// Batch mode: sequential calls, no per-device expansion within batch.
 	if len(step.Batch) > 0 {
 		return e.executeBatch(ctx, r, step)
@@ -49,7 +59,7 @@ func (e *newtronExecutor) Execute(ctx context.Context, r *Runner, step *Step) *S
 					Message: fmt.Sprintf("capture on a device-templated step requires exactly one device, resolved %d", len(names)),
 				}}
 			}
-			msg, raw, err := e.doCall(r, step, method, step.URL, step.Params, names[0], step.Headers, step.Expect)
+			msg, raw, err := e.doCall(ctx, r, step, method, step.URL, step.Params, names[0], step.Headers, step.Expect)
 			if err != nil {
 				return &StepOutput{Result: &StepResult{
 					Status:  StepStatusFailed,
@@ -67,14 +77,17 @@ func (e *newtronExecutor) Execute(ctx context.Context, r *Runner, step *Step) *S
 				Message: msg,
 			}}
 		}
+		if step.Interfaces != nil {
+			return e.executeForInterfaces(ctx, r, step, method)
+		}
 		return r.executeForDevices(step, func(name string) (string, error) {
-			msg, _, err := e.doCall(r, step, method, step.URL, step.Params, name, step.Headers, step.Expect)
+			msg, _, err := e.doCall(ctx, r, step, method, step.URL, step.Params, name, step.Headers, step.Expect)
 			return msg, err
 		})
 	}
 
 	// No {{device}} template — network-scoped call (no device parallelism).
-	msg, raw, err := e.doCall(r, step, method, step.URL, step.Params, "", step.Headers, step.Expect)
+	msg, raw, err := e.doCall(ctx, r, step, method, step.URL, step.Params, "", step.Headers, step.Expect)
 	if err != nil {
 		return &StepOutput{Result: &StepResult{
 			Status:  StepStatusFailed,
@@ -101,6 +114,32 @@ func (e *newtronExecutor) Execute(ctx context.Context, r *Runner, step *Step) *S
 	}}
 }
 
+// executeForInterfaces runs one call per (device, interface): devices in
+// parallel, each device's interfaces in order, {{interface}} substituted into
+// the URL. A device whose selector resolves to no interfaces fails — a role
+// that matches nothing is almost always a topology or selector mistake.
+func (e *newtronExecutor) executeForInterfaces(ctx context.Context, r *Runner, step *Step, method string) *StepOutput {
+	return r.executeForDevices(step, func(name string) (string, error) {
+		intfs, err := step.Interfaces.Resolve(r, name)
+		if err != nil {
+			return "", fmt.Errorf("resolving interfaces: %w", err)
+		}
+		if len(intfs) == 0 {
+			return "", fmt.Errorf("no interfaces selected (role %q)", step.Interfaces.Role)
+		}
+		var msgs []string
+		for _, intf := range intfs {
+			u := strings.ReplaceAll(step.URL, "{{interface}}", url.PathEscape(intf))
+			msg, _, err := e.doCall(ctx, r, step, method, u, step.Params, name, step.Headers, step.Expect)
+			if err != nil {
+				return "", fmt.Errorf("%s: %w", intf, err)
+			}
+			msgs = append(msgs, msg)
+		}
+		return strings.Join(msgs, "; "), nil
+	})
+}
+
 // executeBatch runs a sequence of HTTP calls. If the URL contains {{device}},
 // the batch is executed in parallel across devices (each device runs the full
 // sequence). If not, the batch runs once with no device scoping. Fails on the
@@ -122,7 +161,7 @@ func (e *newtronExecutor) executeBatch(ctx context.Context, r *Runner, step *Ste
 				if method == "" {
 					method = "GET"
 				}
-				_, _, err := e.doCall(r, step, method, call.URL, call.Params, name, step.Headers, nil)
+				_, _, err := e.doCall(ctx, r, step, method, call.URL, call.Params, name, step.Headers, nil)
 				if err != nil {
 					return "", fmt.Errorf("batch[%d] %s %s: %s", i, method, call.URL, err)
 				}
@@ -137,7 +176,7 @@ func (e *newtronExecutor) executeBatch(ctx context.Context, r *Runner, step *Ste
 		if method == "" {
 			method = "GET"
 		}
-		_, _, err := e.doCall(r, step, method, call.URL, call.Params, "", step.Headers, nil)
+		_, _, err := e.doCall(ctx, r, step, method, call.URL, call.Params, "", step.Headers, nil)
 		if err != nil {
 			return &StepOutput{Result: &StepResult{
 				Status:  StepStatusFailed,
@@ -168,7 +207,7 @@ func (e *newtronExecutor) executePoll(ctx context.Context, r *Runner, step *Step
 		pollStep.Expect = &pollExpect
 
 		return r.pollForDevices(ctx, &pollStep, func(name string) (bool, string, error) {
-			msg, _, err := e.doCall(r, step, method, step.URL, step.Params, name, step.Headers, step.Expect)
+			msg, _, err := e.doCall(ctx, r, step, method, step.URL, step.Params, name, step.Headers, step.Expect)
 			if err != nil {
 				// For polling, errors mean "not ready yet" — keep polling.
 				return false, err.Error(), nil
@@ -180,7 +219,7 @@ func (e *newtronExecutor) executePoll(ctx context.Context, r *Runner, step *Step
 	// No device template — poll a network-scoped endpoint.
 	var lastMsg string
 	err := pollUntil(ctx, step.Poll.Timeout, step.Poll.Interval, func() (bool, error) {
-		msg, _, err := e.doCall(r, step, method, step.URL, step.Params, "", step.Headers, step.Expect)
+		msg, _, err := e.doCall(ctx, r, step, method, step.URL, step.Params, "", step.Headers, step.Expect)
 		if err != nil {
 			lastMsg = err.Error()
 			return false, nil
@@ -205,9 +244,16 @@ func (e *newtronExecutor) executePoll(ctx context.Context, r *Runner, step *Step
 // error). The raw body is propagated so the single-call path in
 // Execute can run response-capture against it. Step-level headers
 // are applied uniformly across every call from a step (including
-// each call in a batch) so one step = one caller identity.
-func (e *newtronExecutor) doCall(r *Runner, step *Step, method, urlTemplate string, params map[string]any, device string, headers map[string]string, expect *ExpectBlock) (string, json.RawMessage, error) {
+// each call in a batch) so one step = one caller identity. A write
+// is charged to the run's write budget, and refused once ctx's steps
+// were canceled by it (write_budget.go).
+func (e *newtronExecutor) doCall(ctx context.Context, r *Runner, step *Step, method, urlTemplate string, params map[string]any, device string, headers map[string]string, expect *ExpectBlock) (string, json.RawMessage, error) {
 	path := expandURL(urlTemplate, r.Client.NetworkID(), device)
+	if isWriteMethod(method) {
+		if err := refuseWrite(ctx); err != nil {
+			return "", nil, err
+		}
+	}
 
 	// Build body for POST/PUT/DELETE — only if params are provided.
 	var body any
@@ -239,6 +285,10 @@ func (e *newtronExecutor) doCall(r *Runner, step *Step, method, urlTemplate stri
 	if err != nil {
 		return "", nil, err
 	}
+	if method == "POST" || method == "PUT" || method == "DELETE" {
+		r.recordChangeSet(step.Name, device, data)
+		r.chargeWrites(data)
+	}
 
 	// If no jq assertion, success is simply a non-error response.
 	if expect == nil || expect.JQ == "" {
@@ -0,0 +1,38 @@
+package newtrun
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestEvalJQ_ConfigDBFieldAssertions pins the CONFIG_DB field checks
+// scenarios express in expect.jq (howto §11.22) beyond exact equality: a
+// field matching a pattern (an auto-generated RD), and a field that must
+// be absent. A pattern that does not compile is an error naming it, not a
+// panic.
+func TestEvalJQ_ConfigDBFieldAssertions(t *testing.T) {
+	entry := json.RawMessage(`{"vni": "10100", "route_distinguisher": "10.0.0.1:100", "admin_status": "up"}`)
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{"regex matches", `.route_distinguisher | test("^[0-9.]+:100$")`, ""},
+		{"regex does not match", `.route_distinguisher | test("^[0-9.]+:200$")`, "jq assertion failed"},
+		{"bad regex", `.route_distinguisher | test("([0-9")`, "([0-9"},
+		{"field absent", `has("import_rts") | not`, ""},
+		{"field present", `has("admin_status") | not`, "evaluated to false"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := evalJQ(tt.expr, entry, "GET", "/nodes/leaf1/configdb/VRF/Vrf_CUST")
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error %v", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
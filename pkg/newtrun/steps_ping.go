@@ -0,0 +1,294 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/netip"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The verify-ping step asserts IPv4 reachability from each target device —
+// the IPv4 twin of verify-ping6 (steps_ping6.go), with the same params.
+//
+//	- name: irb-reachable
+//	  action: verify-ping
+//	  devices: [leaf1, host1]   # ping sources: switches and/or hosts
+//	  params:
+//	    target: leaf2           # an IPv4 address, or a device name
+//	    count: 5                # default 5
+//	    vrf: Vrf_CUST           # switch sources only: ping inside the VRF
+//	    source: Vlan100         # optional; interface or address (ping -I)
+//	  expect:
+//	    success_rate: 0.8       # default 1.0
+//	  poll:                     # optional — re-ping until the rate is met
+//	    timeout: 60s
+//	    interval: 5s
+//
+// A device-name target resolves to a switch's Loopback0 IPv4 address
+// (LOOPBACK_INTERFACE), or a host's first global IPv4 address. Everything
+// else — sources, the vrf/source handling, the dataplane and
+// source-interface checks, the success-rate verdict — is shared with
+// verify-ping6 and lives in this file.
+
+// pingParams is the params: shape of a verify-ping or verify-ping6 step.
+type pingParams struct {
+	Target string `json:"target"`
+	Count  int    `json:"count"`
+	VRF    string `json:"vrf"`
+	Source string `json:"source"`
+
+	family      int // 4 or 6, from the action
+	successRate float64
+}
+
+const defaultPingCount = 5
+
+func decodePingParams(step *Step, family int) (pingParams, error) {
+	p := pingParams{family: family}
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.Target == "" {
+		return p, fmt.Errorf("params.target is required")
+	}
+	if addr, err := netip.ParseAddr(p.Target); err == nil && addrFamily(addr) != family {
+		return p, fmt.Errorf("params.target: %s is not an IPv%d address", p.Target, family)
+	}
+	if p.Count == 0 {
+		p.Count = defaultPingCount
+	}
+	if p.Count < 1 || p.Count > 100 {
+		return p, fmt.Errorf("params.count: %d is out of range (1-100)", p.Count)
+	}
+	p.successRate = 1.0
+	if step.Expect != nil && step.Expect.SuccessRate != nil {
+		p.successRate = *step.Expect.SuccessRate
+		if p.successRate <= 0 || p.successRate > 1 {
+			return p, fmt.Errorf("expect.success_rate: %g is out of range (0, 1]", p.successRate)
+		}
+	}
+	return p, nil
+}
+
+// addrFamily returns 4 for an IPv4 (or IPv4-mapped) address, else 6.
+func addrFamily(addr netip.Addr) int {
+	if addr.Unmap().Is4() {
+		return 4
+	}
+	return 6
+}
+
+// requirePingParams validates a verify-ping step at parse time.
+func requirePingParams(prefix string, step *Step) error {
+	if _, err := decodePingParams(step, 4); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyPingExecutor pings an IPv4 target from each device.
+type verifyPingExecutor struct{}
+
+func (e *verifyPingExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	return r.verifyPing(ctx, step, 4)
+}
+
+// verifyPing runs a verify-ping (family 4) or verify-ping6 (family 6) step.
+func (r *Runner) verifyPing(ctx context.Context, step *Step, family int) *StepOutput {
+	params, err := decodePingParams(step, family)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	addr, err := r.resolvePingTarget(params.Target, family)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	target := addr
+	if addr != params.Target {
+		target = fmt.Sprintf("%s (%s)", params.Target, addr)
+	}
+
+	names := r.resolveDevices(step)
+	details := make([]DeviceResult, len(names))
+	limit := r.deviceLimiter()
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(idx int, dev string) {
+			defer wg.Done()
+			defer limit.acquire()()
+			if _, isHost := r.HostConns[dev]; !isHost {
+				if st, msg := r.checkPingSource(dev, params.Source); st != "" {
+					details[idx] = DeviceResult{Device: dev, Status: st, Message: msg}
+					return
+				}
+			}
+			var output string
+			attempt := func() (StepStatus, string) {
+				var err error
+				output, err = r.pingFrom(dev, addr, params)
+				if err != nil && !packetLossRe.MatchString(output) {
+					return StepStatusError, fmt.Sprintf("ping %s: %v", target, err)
+				}
+				rate := parsePingSuccessRate(output)
+				if rate >= params.successRate {
+					return StepStatusPassed, fmt.Sprintf("%s: %.0f%% success (≥ %.0f%%)", target, rate*100, params.successRate*100)
+				}
+				return StepStatusFailed, fmt.Sprintf("%s: %.0f%% success (expected ≥ %.0f%%)", target, rate*100, params.successRate*100)
+			}
+			st, msg := attempt()
+			if step.Poll != nil && st != StepStatusPassed {
+				pollErr := pollUntil(ctx, step.Poll.Timeout, step.Poll.Interval, func() (bool, error) {
+					st, msg = attempt()
+					return st == StepStatusPassed, nil
+				})
+				if pollErr != nil && st != StepStatusPassed {
+					msg = fmt.Sprintf("poll %s: %s", pollErr, msg)
+				}
+			}
+			details[idx] = DeviceResult{Device: dev, Status: st, Message: msg, Output: output}
+		}(i, name)
+	}
+	wg.Wait()
+
+	status, skipped := StepStatusPassed, 0
+	for _, d := range details {
+		switch d.Status {
+		case StepStatusPassed:
+		case StepStatusSkipped:
+			skipped++
+		default:
+			status = StepStatusFailed
+		}
+	}
+	if skipped > 0 && skipped == len(details) {
+		status = StepStatusSkipped
+	}
+	return &StepOutput{Result: &StepResult{Status: status, Details: details}}
+}
+
+// checkPingSource vets a switch before it pings: SKIPPED when its platform
+// has no dataplane to forward the packets, ERROR when the named source
+// interface does not exist on it. An address source (ping -I <ip>) is not
+// checked. Returns "" when the switch can ping.
+func (r *Runner) checkPingSource(dev, source string) (StepStatus, string) {
+	info, err := r.Client.DeviceInfo(dev)
+	if err != nil {
+		return StepStatusError, fmt.Sprintf("reading %s device info: %v", dev, err)
+	}
+	if info.Platform != "" {
+		platform, err := r.Client.ShowPlatform(info.Platform)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading platform %s: %v", info.Platform, err)
+		}
+		if platform.Dataplane == "" {
+			return StepStatusSkipped, fmt.Sprintf("platform %s has no dataplane", info.Platform)
+		}
+	}
+	if source == "" {
+		return "", ""
+	}
+	if _, err := netip.ParseAddr(source); err == nil {
+		return "", ""
+	}
+	if _, err := r.Client.ShowInterface(dev, source); err != nil {
+		return StepStatusError, fmt.Sprintf("source interface %s not found on %s: %v", source, dev, err)
+	}
+	return "", ""
+}
+
+// pingCommand builds the ping command line for one source: -4 or -6 for the
+// step's family, -I for a source interface or address, and for a switch
+// inside a VRF, `ip vrf exec` around it. A host source has no VRFs to enter.
+func pingCommand(addr string, p pingParams, host bool) (string, error) {
+	cmd := fmt.Sprintf("ping -%d -c %d -W 2", p.family, p.Count)
+	if p.Source != "" {
+		cmd += " -I " + shellQuote(p.Source)
+	}
+	cmd += " " + addr
+	if p.VRF == "" {
+		return cmd, nil
+	}
+	if host {
+		return "", fmt.Errorf("params.vrf applies to switch sources only")
+	}
+	return "ip vrf exec " + shellQuote(p.VRF) + " " + cmd, nil
+}
+
+// pingFrom runs one ping from dev — inside its namespace for a host, via
+// newtron's ssh-command for a switch — and returns ping's output.
+func (r *Runner) pingFrom(dev, addr string, p pingParams) (string, error) {
+	conn, isHost := r.HostConns[dev]
+	cmd, err := pingCommand(addr, p, isHost)
+	if err != nil {
+		return "", fmt.Errorf("%w; %s is a host", err, dev)
+	}
+	if isHost {
+		return runSSHCommand(conn, netnsCommand(dev, cmd))
+	}
+	// ssh-command returns no output for a failing command, and ping fails
+	// on any loss; the packet-loss summary is the verdict, not the status.
+	return r.Client.SSHCommand(dev, "sudo "+cmd+" || true")
+}
+
+var inet6AddrRe = regexp.MustCompile(`\binet6 ([0-9a-fA-F:]+)/`)
+
+// resolvePingTarget returns target as an address of the given family: a
+// literal as is, a host by its first global address, a switch by its
+// Loopback0 address.
+func (r *Runner) resolvePingTarget(target string, family int) (string, error) {
+	if _, err := netip.ParseAddr(target); err == nil {
+		return target, nil
+	}
+	if conn, isHost := r.HostConns[target]; isHost {
+		out, err := runSSHCommand(conn, netnsCommand(target, fmt.Sprintf("ip -%d -o addr show scope global", family)))
+		if err != nil {
+			return "", fmt.Errorf("reading %s addresses: %v\n%s", target, err, out)
+		}
+		addr := firstIPv4(out)
+		if family == 6 {
+			addr = ""
+			if m := inet6AddrRe.FindStringSubmatch(out); m != nil {
+				addr = m[1]
+			}
+		}
+		if addr == "" {
+			return "", fmt.Errorf("host %s has no global IPv%d address", target, family)
+		}
+		return addr, nil
+	}
+	keys, err := r.Client.ConfigDBTableKeys(target, "LOOPBACK_INTERFACE")
+	if err != nil {
+		return "", fmt.Errorf("reading %s loopback addresses: %w", target, err)
+	}
+	if addr := loopbackAddr(keys, family); addr != "" {
+		return addr, nil
+	}
+	return "", fmt.Errorf("%s has no IPv%d address on Loopback0", target, family)
+}
+
+// loopbackAddr returns the address of the given family among
+// LOOPBACK_INTERFACE keys ("Loopback0|2001:db8::1/128") for Loopback0, or ""
+// when there is none. Keys are sorted first so the choice is stable.
+func loopbackAddr(keys []string, family int) string {
+	sorted := append([]string(nil), keys...)
+	sort.Strings(sorted)
+	for _, k := range sorted {
+		name, ip, ok := strings.Cut(k, "|")
+		if !ok || name != "Loopback0" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(ip); err == nil && addrFamily(prefix.Addr()) == family {
+			return prefix.Addr().String()
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package newtrun
+
+import (
+	"context"
+	"fmt"
+)
+
+// The verify-ping6 step asserts IPv6 reachability from each target device.
+//
+//	- name: v6-reachable
+//	  action: verify-ping6
+//	  devices: [leaf1, host1]   # ping sources: switches and/or hosts
+//	  params:
+//	    target: leaf2           # an IPv6 address, or a device name
+//	    count: 5                # default 5
+//	    vrf: Vrf_CUST           # switch sources only: ping inside the VRF
+//	    source: Ethernet4       # optional; interface or address (ping -I)
+//	  expect:
+//	    success_rate: 0.8       # default 1.0
+//	  poll:                     # optional — re-ping until the rate is met
+//	    timeout: 60s
+//	    interval: 5s
+//
+// A device-name target resolves to its IPv6 loopback: a switch's Loopback0
+// IPv6 address (LOOPBACK_INTERFACE), or a host's first global IPv6 address.
+// A switch source pings through newtron's ssh-command, a host source inside
+// its network namespace. The success rate is read from ping's packet-loss
+// summary, which is the same for IPv4 and IPv6 (parsePingSuccessRate); the
+// executor is shared with verify-ping (steps_ping.go).
+//
+// vrf and source are what make IRB and per-VRF checks meaningful: without
+// them the ping resolves over the global table and egresses wherever that
+// routes, a false negative (or positive) for the SVI under test. Before
+// pinging from a switch the step checks its platform forwards packets — a
+// platform with no dataplane SKIPS the device, and the step when every
+// source is skipped — and that a named source interface exists there, so a
+// typo ERRORs instead of reading as unreachability.
+
+// requirePing6Params validates a verify-ping6 step at parse time.
+func requirePing6Params(prefix string, step *Step) error {
+	if _, err := decodePingParams(step, 6); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyPing6Executor pings an IPv6 target from each device.
+type verifyPing6Executor struct{}
+
+func (e *verifyPing6Executor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	return r.verifyPing(ctx, step, 6)
+}
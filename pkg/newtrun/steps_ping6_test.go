@@ -0,0 +1,233 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/api"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/spec"
+)
+
+// ping6Server fakes newtron-server's GET .../configdb/LOOPBACK_INTERFACE and
+// POST .../ssh-command. output maps a source device to its ping output; the
+// commands each device ran are recorded. platforms maps a device to its
+// platform and dataplanes a platform to its dataplane (GET .../info,
+// .../platforms/{name}); interfaces lists each device's interfaces for GET
+// .../interfaces/{name}, every name existing when the device has no entry.
+type ping6Server struct {
+	loopbacks  map[string][]string
+	output     map[string]string
+	platforms  map[string]string
+	dataplanes map[string]string
+	interfaces map[string][]string
+
+	mu       sync.Mutex
+	commands map[string]string
+}
+
+func (s *ping6Server) start(t *testing.T) *httptest.Server {
+	t.Helper()
+	s.commands = map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, name, ok := strings.Cut(r.URL.Path, "/platforms/"); ok {
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": spec.PlatformSpec{Dataplane: s.dataplanes[name]}})
+			return
+		}
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, rest, _ := strings.Cut(rest, "/")
+		var data any
+		if name, ok := strings.CutPrefix(rest, "interfaces/"); ok {
+			if names, listed := s.interfaces[dev]; listed && !slices.Contains(names, name) {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": "interface " + name + " not found"})
+				return
+			}
+		}
+		switch rest {
+		case "info":
+			data = newtron.DeviceInfo{Name: dev, Platform: s.platforms[dev]}
+		case "configdb/LOOPBACK_INTERFACE":
+			data = s.loopbacks[dev]
+		case "ssh-command":
+			var req api.SSHCommandRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			s.mu.Lock()
+			s.commands[dev] = req.Command
+			s.mu.Unlock()
+			data = api.SSHCommandResponse{Output: s.output[dev]}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func pingOutput(loss string) string {
+	return "PING 2001:db8::2(2001:db8::2) 56 data bytes\n\n--- 2001:db8::2 ping statistics ---\n5 packets transmitted, 4 received, " + loss + " packet loss, time 4005ms\n"
+}
+
+func TestVerifyPing6(t *testing.T) {
+	fake := &ping6Server{
+		loopbacks: map[string][]string{"leaf2": {"Loopback0", "Loopback0|10.0.0.2/32", "Loopback0|2001:db8::2/128"}},
+		output: map[string]string{
+			"leaf1": pingOutput("0%"),
+			"leaf3": pingOutput("40%"),
+		},
+	}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	rate := 0.8
+	step := &Step{
+		Action:  ActionVerifyPing6,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf3"}},
+		Params:  map[string]any{"target": "leaf2", "vrf": "Vrf_CUST"},
+		Expect:  &ExpectBlock{SuccessRate: &rate},
+	}
+	out := (&verifyPing6Executor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusFailed {
+		t.Fatalf("status = %s, want FAILED: %+v", out.Result.Status, out.Result)
+	}
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "leaf2 (2001:db8::2): 100% success (≥ 80%)"},
+		"leaf3": {StepStatusFailed, "leaf2 (2001:db8::2): 60% success (expected ≥ 80%)"},
+	}
+	for _, d := range out.Result.Details {
+		w := want[d.Device]
+		if d.Status != w.status || !strings.HasPrefix(d.Message, w.msg) {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+	if got, want := fake.commands["leaf1"], "sudo ip vrf exec 'Vrf_CUST' ping -6 -c 5 -W 2 2001:db8::2 || true"; got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+}
+
+func TestVerifyPing6_TargetWithoutIPv6Loopback(t *testing.T) {
+	fake := &ping6Server{loopbacks: map[string][]string{"leaf2": {"Loopback0", "Loopback0|10.0.0.2/32"}}}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyPing6,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+		Params:  map[string]any{"target": "leaf2"},
+	}
+	out := (&verifyPing6Executor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusError || out.Result.Message != "leaf2 has no IPv6 address on Loopback0" {
+		t.Errorf("result = %s %q, want ERROR naming the missing loopback", out.Result.Status, out.Result.Message)
+	}
+	if len(fake.commands) != 0 {
+		t.Errorf("pinged without a target address: %v", fake.commands)
+	}
+}
+
+func TestPing6Command(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       pingParams
+		host    bool
+		want    string
+		wantErr bool
+	}{
+		{"plain", pingParams{family: 6, Count: 5}, false, "ping -6 -c 5 -W 2 2001:db8::2", false},
+		{"source", pingParams{family: 6, Count: 3, Source: "Vlan100"}, false, "ping -6 -c 3 -W 2 -I 'Vlan100' 2001:db8::2", false},
+		{"vrf", pingParams{family: 6, Count: 5, VRF: "Vrf_customer"}, false, "ip vrf exec 'Vrf_customer' ping -6 -c 5 -W 2 2001:db8::2", false},
+		{"vrf and source", pingParams{family: 6, Count: 5, VRF: "Vrf_customer", Source: "Vlan100"}, false,
+			"ip vrf exec 'Vrf_customer' ping -6 -c 5 -W 2 -I 'Vlan100' 2001:db8::2", false},
+		{"host source", pingParams{family: 6, Count: 5, Source: "eth1"}, true, "ping -6 -c 5 -W 2 -I 'eth1' 2001:db8::2", false},
+		{"host with vrf", pingParams{family: 6, Count: 5, VRF: "Vrf_customer"}, true, "", true},
+	}
+	for _, tt := range tests {
+		got, err := pingCommand("2001:db8::2", tt.p, tt.host)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: pingCommand = %q, %v; want %q (err %v)", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+// TestVerifyPing6_SourceChecks: a switch whose platform has no dataplane is
+// SKIPPED, a missing source interface is an ERROR, and neither pings.
+func TestVerifyPing6_SourceChecks(t *testing.T) {
+	fake := &ping6Server{
+		output:     map[string]string{"leaf1": pingOutput("0%")},
+		platforms:  map[string]string{"leaf1": "Force10-S6000_vpp", "leaf3": "Force10-S6000_vpp", "leaf4": "vjunos-router"},
+		dataplanes: map[string]string{"Force10-S6000_vpp": "vpp"},
+		interfaces: map[string][]string{"leaf1": {"Vlan100"}, "leaf3": {"Ethernet0"}},
+	}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyPing6,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf3", "leaf4"}},
+		Params:  map[string]any{"target": "2001:db8::2", "vrf": "Vrf_customer", "source": "Vlan100"},
+	}
+	out := (&verifyPing6Executor{}).Execute(context.Background(), r, step)
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "2001:db8::2: 100% success"},
+		"leaf3": {StepStatusError, "source interface Vlan100 not found on leaf3"},
+		"leaf4": {StepStatusSkipped, "platform vjunos-router has no dataplane"},
+	}
+	for _, d := range out.Result.Details {
+		w := want[d.Device]
+		if d.Status != w.status || !strings.HasPrefix(d.Message, w.msg) {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+	if out.Result.Status != StepStatusFailed {
+		t.Errorf("status = %s, want FAILED (leaf3 errored)", out.Result.Status)
+	}
+	if got, want := fake.commands["leaf1"], "sudo ip vrf exec 'Vrf_customer' ping -6 -c 5 -W 2 -I 'Vlan100' 2001:db8::2 || true"; got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+	if _, pinged := fake.commands["leaf3"]; pinged {
+		t.Error("leaf3 pinged from a missing source interface")
+	}
+
+	step.Devices = deviceSelector{Devices: []string{"leaf4"}}
+	if out := (&verifyPing6Executor{}).Execute(context.Background(), r, step); out.Result.Status != StepStatusSkipped {
+		t.Errorf("every source skipped: status = %s, want SKIPPED", out.Result.Status)
+	}
+}
+
+func TestRequirePing6Params(t *testing.T) {
+	half, zero := 0.5, 0.0
+	tests := []struct {
+		params  map[string]any
+		expect  *ExpectBlock
+		wantErr string
+	}{
+		{map[string]any{"target": "2001:db8::1"}, nil, ""},
+		{map[string]any{"target": "leaf2", "count": 3}, &ExpectBlock{SuccessRate: &half}, ""},
+		{map[string]any{}, nil, "params.target is required"},
+		{map[string]any{"target": "10.0.0.1"}, nil, "10.0.0.1 is not an IPv6 address"},
+		{map[string]any{"target": "leaf2", "count": 500}, nil, "params.count: 500 is out of range"},
+		{map[string]any{"target": "leaf2"}, &ExpectBlock{SuccessRate: &zero}, "expect.success_rate: 0 is out of range"},
+	}
+	for _, tt := range tests {
+		err := requirePing6Params("step", &Step{Action: ActionVerifyPing6, Params: tt.params, Expect: tt.expect})
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.params, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
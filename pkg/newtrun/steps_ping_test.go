@@ -0,0 +1,119 @@
+package newtrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func ping4Output(loss string) string {
+	return "PING 10.0.0.2 (10.0.0.2) 56(84) bytes of data.\n\n--- 10.0.0.2 ping statistics ---\n5 packets transmitted, 5 received, " + loss + " packet loss, time 4005ms\n"
+}
+
+// TestVerifyPing: an IPv4 target by device name resolves to the IPv4 address
+// on Loopback0, and the ping runs inside the VRF from the named source.
+func TestVerifyPing(t *testing.T) {
+	fake := &ping6Server{
+		loopbacks:  map[string][]string{"leaf2": {"Loopback0", "Loopback0|2001:db8::2/128", "Loopback0|10.0.0.2/32"}},
+		output:     map[string]string{"leaf1": ping4Output("0%"), "leaf3": ping4Output("60%")},
+		platforms:  map[string]string{"leaf1": "Force10-S6000_vpp", "leaf3": "Force10-S6000_vpp", "leaf4": "vjunos-router"},
+		dataplanes: map[string]string{"Force10-S6000_vpp": "vpp"},
+		interfaces: map[string][]string{"leaf1": {"Vlan100"}, "leaf3": {"Vlan100"}},
+	}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyPing,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf3", "leaf4"}},
+		Params:  map[string]any{"target": "leaf2", "vrf": "Vrf_customer", "source": "Vlan100"},
+	}
+	out := (&verifyPingExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusFailed {
+		t.Fatalf("status = %s, want FAILED: %+v", out.Result.Status, out.Result)
+	}
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "leaf2 (10.0.0.2): 100% success (≥ 100%)"},
+		"leaf3": {StepStatusFailed, "leaf2 (10.0.0.2): 40% success (expected ≥ 100%)"},
+		"leaf4": {StepStatusSkipped, "platform vjunos-router has no dataplane"},
+	}
+	for _, d := range out.Result.Details {
+		w := want[d.Device]
+		if d.Status != w.status || !strings.HasPrefix(d.Message, w.msg) {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+	if got, want := fake.commands["leaf1"], "sudo ip vrf exec 'Vrf_customer' ping -4 -c 5 -W 2 -I 'Vlan100' 10.0.0.2 || true"; got != want {
+		t.Errorf("command = %q, want %q", got, want)
+	}
+}
+
+func TestVerifyPing_SourceInterfaceMissing(t *testing.T) {
+	fake := &ping6Server{interfaces: map[string][]string{"leaf1": {"Ethernet0"}}}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyPing,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+		Params:  map[string]any{"target": "10.0.0.2", "source": "Vlan100"},
+	}
+	out := (&verifyPingExecutor{}).Execute(context.Background(), r, step)
+	if d := out.Result.Details[0]; d.Status != StepStatusError || !strings.HasPrefix(d.Message, "source interface Vlan100 not found on leaf1") {
+		t.Errorf("leaf1: %s %q, want ERROR naming the missing source", d.Status, d.Message)
+	}
+	if len(fake.commands) != 0 {
+		t.Errorf("pinged from a missing source interface: %v", fake.commands)
+	}
+}
+
+func TestPingCommand_IPv4(t *testing.T) {
+	tests := []struct {
+		name    string
+		p       pingParams
+		host    bool
+		want    string
+		wantErr bool
+	}{
+		{"plain", pingParams{family: 4, Count: 5}, false, "ping -4 -c 5 -W 2 10.0.0.2", false},
+		{"source", pingParams{family: 4, Count: 3, Source: "Vlan100"}, false, "ping -4 -c 3 -W 2 -I 'Vlan100' 10.0.0.2", false},
+		{"vrf", pingParams{family: 4, Count: 5, VRF: "Vrf_customer"}, false, "ip vrf exec 'Vrf_customer' ping -4 -c 5 -W 2 10.0.0.2", false},
+		{"vrf and source", pingParams{family: 4, Count: 5, VRF: "Vrf_customer", Source: "Vlan100"}, false,
+			"ip vrf exec 'Vrf_customer' ping -4 -c 5 -W 2 -I 'Vlan100' 10.0.0.2", false},
+		{"host with vrf", pingParams{family: 4, Count: 5, VRF: "Vrf_customer"}, true, "", true},
+	}
+	for _, tt := range tests {
+		got, err := pingCommand("10.0.0.2", tt.p, tt.host)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("%s: pingCommand = %q, %v; want %q (err %v)", tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRequirePingParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"target": "10.0.0.1"}, ""},
+		{map[string]any{"target": "leaf2", "vrf": "Vrf_customer", "source": "Vlan100"}, ""},
+		{map[string]any{"target": "2001:db8::1"}, "2001:db8::1 is not an IPv4 address"},
+		{map[string]any{}, "params.target is required"},
+	}
+	for _, tt := range tests {
+		err := requirePingParams("step", &Step{Action: ActionVerifyPing, Params: tt.params})
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.params, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
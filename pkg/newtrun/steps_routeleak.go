@@ -0,0 +1,247 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-route-leak step asserts that routes leaked from one VRF show up
+// in another, on each target device.
+//
+//	- name: shared-services-leaked
+//	  action: verify-route-leak
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    src_vrf: Vrf_SHARED
+//	    dst_vrf: Vrf_CUST
+//	    prefixes: [10.50.0.0/24]   # default: the leak's configured prefixes
+//
+// Per device it first checks the leak configuration (GET .../route-leaks):
+// dst_vrf must import from src_vrf, which also proves both VRFs exist. Then
+// it looks each prefix up in dst_vrf's APP_DB routing table and FAILs naming
+// the prefixes that did not arrive — noting those missing from src_vrf as
+// well, since those were never there to leak. On success the message lists
+// each leaked route with its next hops.
+//
+// An unfiltered leak with no prefixes to look up is checked through the
+// leak's health (GET .../route-leaks/{dst_vrf}/health, read from zebra): it
+// passes when at least one route arrived from src_vrf and FAILs when nothing
+// did — the leak is configured but not importing. expect: applies to every
+// leaked route.
+//
+// expect: can also pin each leaked route's next hops — next_hop_count is
+// the exact number (ECMP width), next_hop_interface one that must be among
+// them. A leaked route that misses either FAILs the device:
+//
+//	  expect:
+//	    next_hop_count: 2
+//	    next_hop_interface: Ethernet0
+//
+// The check is single-shot: leaked routes appear once BGP has converged, so
+// precede the step with wait-converged when it follows the leak's creation.
+
+// routeLeakParams is the params: shape of a verify-route-leak step.
+type routeLeakParams struct {
+	SrcVRF   string   `json:"src_vrf"`
+	DstVRF   string   `json:"dst_vrf"`
+	Prefixes []string `json:"prefixes"`
+}
+
+func decodeRouteLeakParams(step *Step) (routeLeakParams, error) {
+	var p routeLeakParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.SrcVRF == "" {
+		return p, fmt.Errorf("params.src_vrf is required")
+	}
+	if p.DstVRF == "" {
+		return p, fmt.Errorf("params.dst_vrf is required")
+	}
+	if p.SrcVRF == p.DstVRF {
+		return p, fmt.Errorf("params: src_vrf and dst_vrf are both %s", p.SrcVRF)
+	}
+	return p, nil
+}
+
+// requireRouteLeakParams validates a verify-route-leak step at parse time.
+func requireRouteLeakParams(prefix string, step *Step) error {
+	if _, err := decodeRouteLeakParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyRouteLeakExecutor asserts leaked routes are present per device.
+type verifyRouteLeakExecutor struct{}
+
+func (e *verifyRouteLeakExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeRouteLeakParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	leak := params.SrcVRF + " → " + params.DstVRF
+
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		leaks, err := r.Client.RouteLeaks(dev)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading route leaks: %v", err)
+		}
+		var configured *newtron.RouteLeak
+		for i := range leaks {
+			if leaks[i].DstVRF == params.DstVRF {
+				configured = &leaks[i]
+			}
+		}
+		switch {
+		case configured == nil:
+			return StepStatusFailed, fmt.Sprintf("no route leak into %s", params.DstVRF)
+		case configured.SrcVRF != params.SrcVRF:
+			return StepStatusFailed, fmt.Sprintf("%s imports from %s, not %s", params.DstVRF, configured.SrcVRF, params.SrcVRF)
+		}
+
+		prefixes := params.Prefixes
+		if len(prefixes) == 0 {
+			prefixes = configured.Prefixes
+		}
+		if len(prefixes) == 0 {
+			return unfilteredLeakStatus(ctx, r, dev, params, step.Expect, leak)
+		}
+
+		var leaked, missing, mismatched []string
+		observed := map[string]*newtron.RouteEntry{} // prefix → leaked route, nil when missing
+		for _, prefix := range prefixes {
+			route, err := r.Client.GetRoute(dev, params.DstVRF, prefix)
+			if err != nil {
+				return StepStatusError, fmt.Sprintf("reading %s route %s: %v", params.DstVRF, prefix, err)
+			}
+			observed[prefix] = nil
+			if route != nil && route.Prefix != "" {
+				observed[prefix] = sortedNextHops(route)
+				if ok, why := matchRoute(route, step.Expect); !ok {
+					mismatched = append(mismatched, formatLeakedRoute(route)+": "+why)
+					continue
+				}
+				leaked = append(leaked, formatLeakedRoute(route))
+				continue
+			}
+			if src, err := r.Client.GetRoute(dev, params.SrcVRF, prefix); err == nil && (src == nil || src.Prefix == "") {
+				prefix += fmt.Sprintf(" (not in %s either)", params.SrcVRF)
+			}
+			missing = append(missing, prefix)
+		}
+		observe(ctx, dev, observed)
+		var problems []string
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("missing from %s: %s", params.DstVRF, strings.Join(missing, ", ")))
+		}
+		if len(mismatched) > 0 {
+			problems = append(problems, strings.Join(mismatched, "; "))
+		}
+		if len(problems) > 0 {
+			return StepStatusFailed, fmt.Sprintf("%s: %s", leak, strings.Join(problems, "; "))
+		}
+		return StepStatusPassed, fmt.Sprintf("%s: %s", leak, strings.Join(leaked, "; "))
+	})
+}
+
+// maxListedLeakedRoutes caps the routes an unfiltered leak's message lists.
+const maxListedLeakedRoutes = 5
+
+// unfilteredLeakStatus checks an unfiltered leak through its health: some
+// route must have arrived from the source VRF.
+func unfilteredLeakStatus(ctx context.Context, r *Runner, dev string, params routeLeakParams, expect *ExpectBlock, leak string) (StepStatus, string) {
+	h, err := r.Client.RouteLeakHealth(dev, params.DstVRF)
+	if err != nil {
+		return StepStatusError, fmt.Sprintf("reading route leak health: %v", err)
+	}
+	observed := map[string]*newtron.RouteEntry{}
+	var listed, mismatched []string
+	for i := range h.Leaked {
+		route := &h.Leaked[i]
+		observed[route.Prefix] = sortedNextHops(route)
+		if ok, why := matchRoute(route, expect); !ok {
+			mismatched = append(mismatched, formatLeakedRoute(route)+": "+why)
+		}
+		if i < maxListedLeakedRoutes {
+			listed = append(listed, formatLeakedRoute(route))
+		}
+	}
+	observe(ctx, dev, observed)
+	if h.LeakedCount == 0 {
+		return StepStatusFailed, fmt.Sprintf("%s: nothing imported from %s", leak, params.SrcVRF)
+	}
+	if len(mismatched) > 0 {
+		return StepStatusFailed, fmt.Sprintf("%s: %s", leak, strings.Join(mismatched, "; "))
+	}
+	msg := fmt.Sprintf("%s: %d routes leaked: %s", leak, h.LeakedCount, strings.Join(listed, "; "))
+	if more := h.LeakedCount - len(listed); more > 0 {
+		msg += fmt.Sprintf("; and %d more", more)
+	}
+	return StepStatusPassed, msg
+}
+
+// sortedNextHops returns a copy of route with its next hops in address
+// order, so an observation does not change with ECMP member ordering.
+func sortedNextHops(route *newtron.RouteEntry) *newtron.RouteEntry {
+	c := *route
+	c.NextHops = append([]newtron.RouteNextHop(nil), route.NextHops...)
+	sort.Slice(c.NextHops, func(i, j int) bool {
+		if c.NextHops[i].Address != c.NextHops[j].Address {
+			return c.NextHops[i].Address < c.NextHops[j].Address
+		}
+		return c.NextHops[i].Interface < c.NextHops[j].Interface
+	})
+	return &c
+}
+
+// matchRoute reports whether route satisfies expect's next-hop fields —
+// exactly NextHopCount next hops, one of them via NextHopInterface — and if
+// not, why. A nil expect, or one without those fields, matches any route.
+func matchRoute(route *newtron.RouteEntry, expect *ExpectBlock) (bool, string) {
+	if expect == nil {
+		return true, ""
+	}
+	if expect.NextHopCount != nil && len(route.NextHops) != *expect.NextHopCount {
+		return false, fmt.Sprintf("%d next hops, want %d", len(route.NextHops), *expect.NextHopCount)
+	}
+	if expect.NextHopInterface != "" {
+		for _, nh := range route.NextHops {
+			if nh.Interface == expect.NextHopInterface {
+				return true, ""
+			}
+		}
+		return false, "no next hop via " + expect.NextHopInterface
+	}
+	return true, ""
+}
+
+// formatLeakedRoute renders a route as "prefix via nh1, nh2 (protocol)". A
+// next hop without an address is shown by its interface.
+func formatLeakedRoute(route *newtron.RouteEntry) string {
+	var hops []string
+	for _, nh := range route.NextHops {
+		if nh.Address != "" && nh.Address != "0.0.0.0" {
+			hops = append(hops, nh.Address)
+		} else if nh.Interface != "" {
+			hops = append(hops, nh.Interface)
+		}
+	}
+	s := route.Prefix
+	if len(hops) > 0 {
+		s += " via " + strings.Join(hops, ", ")
+	}
+	if route.Protocol != "" {
+		s += " (" + route.Protocol + ")"
+	}
+	return s
+}
@@ -0,0 +1,230 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// routeLeakDevice is one fake device's route-leak configuration, routing
+// tables (VRF → prefix → route) and leak health (destination VRF → health).
+type routeLeakDevice struct {
+	leaks  []newtron.RouteLeak
+	routes map[string]map[string]newtron.RouteEntry
+	health map[string]newtron.RouteLeakHealth
+}
+
+// routeLeakServer fakes newtron-server's GET .../route-leaks,
+// GET .../route-leaks/{vrf}/health and GET .../routes/{vrf}/{prefix} per
+// device. An absent route is null, as the server returns it.
+func routeLeakServer(t *testing.T, byDevice map[string]routeLeakDevice) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, rest, _ := strings.Cut(rest, "/")
+		d := byDevice[dev]
+		var data any
+		switch {
+		case rest == "route-leaks":
+			data = d.leaks
+		case strings.HasPrefix(rest, "route-leaks/"):
+			vrf := strings.TrimSuffix(strings.TrimPrefix(rest, "route-leaks/"), "/health")
+			data = d.health[vrf]
+		case strings.HasPrefix(rest, "routes/"):
+			vrf, prefix, _ := strings.Cut(strings.TrimPrefix(rest, "routes/"), "/")
+			if route, ok := d.routes[vrf][prefix]; ok {
+				data = route
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func TestVerifyRouteLeak(t *testing.T) {
+	leak := []newtron.RouteLeak{{SrcVRF: "Vrf_SHARED", DstVRF: "Vrf_CUST", Prefixes: []string{"10.50.0.0/24", "10.51.0.0/24"}}}
+	shared := map[string]newtron.RouteEntry{
+		"10.50.0.0/24": {Prefix: "10.50.0.0/24", Protocol: "bgp", NextHops: []newtron.RouteNextHop{{Address: "10.0.0.1"}}},
+		"10.51.0.0/24": {Prefix: "10.51.0.0/24", Protocol: "connected", NextHops: []newtron.RouteNextHop{{Interface: "Ethernet4"}}},
+	}
+	srv := routeLeakServer(t, map[string]routeLeakDevice{
+		"leaf1": {leaks: leak, routes: map[string]map[string]newtron.RouteEntry{
+			"Vrf_SHARED": shared,
+			"Vrf_CUST":   shared,
+		}},
+		"leaf2": {leaks: leak, routes: map[string]map[string]newtron.RouteEntry{
+			"Vrf_SHARED": {"10.50.0.0/24": shared["10.50.0.0/24"]},
+			"Vrf_CUST":   {"10.50.0.0/24": shared["10.50.0.0/24"]},
+		}},
+		"leaf3": {leaks: []newtron.RouteLeak{{SrcVRF: "Vrf_OTHER", DstVRF: "Vrf_CUST"}}},
+		"leaf4": {},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyRouteLeak,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3", "leaf4"}},
+		Params:  map[string]any{"src_vrf": "Vrf_SHARED", "dst_vrf": "Vrf_CUST"},
+	}
+	out := (&verifyRouteLeakExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusFailed {
+		t.Fatalf("status = %s, want FAILED: %+v", out.Result.Status, out.Result)
+	}
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "Vrf_SHARED → Vrf_CUST: 10.50.0.0/24 via 10.0.0.1 (bgp); 10.51.0.0/24 via Ethernet4 (connected)"},
+		"leaf2": {StepStatusFailed, "Vrf_SHARED → Vrf_CUST: missing from Vrf_CUST: 10.51.0.0/24 (not in Vrf_SHARED either)"},
+		"leaf3": {StepStatusFailed, "Vrf_CUST imports from Vrf_OTHER, not Vrf_SHARED"},
+		"leaf4": {StepStatusFailed, "no route leak into Vrf_CUST"},
+	}
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("details = %+v, want %d", out.Result.Details, len(want))
+	}
+	for _, d := range out.Result.Details {
+		if w := want[d.Device]; d.Status != w.status || d.Message != w.msg {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+}
+
+// TestVerifyRouteLeak_Unfiltered: an unfiltered leak without params.prefixes
+// is judged by its health — a leak that imports nothing FAILs.
+func TestVerifyRouteLeak_Unfiltered(t *testing.T) {
+	leaks := []newtron.RouteLeak{{SrcVRF: "default", DstVRF: "Vrf_CUST"}}
+	srv := routeLeakServer(t, map[string]routeLeakDevice{
+		"leaf1": {leaks: leaks, health: map[string]newtron.RouteLeakHealth{"Vrf_CUST": {
+			SrcVRF: "default", DstVRF: "Vrf_CUST", Healthy: true, LeakedCount: 1,
+			Leaked: []newtron.RouteEntry{{Prefix: "0.0.0.0/0", Protocol: "bgp", OriginVRF: "default",
+				NextHops: []newtron.RouteNextHop{{Address: "10.0.0.1", Interface: "Ethernet0"}}}},
+		}}},
+		"leaf2": {leaks: leaks, health: map[string]newtron.RouteLeakHealth{"Vrf_CUST": {
+			SrcVRF: "default", DstVRF: "Vrf_CUST", Leaked: []newtron.RouteEntry{},
+		}}},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := &Step{
+		Action:  ActionVerifyRouteLeak,
+		Devices: deviceSelector{Devices: []string{"leaf1", "leaf2"}},
+		Params:  map[string]any{"src_vrf": "default", "dst_vrf": "Vrf_CUST"},
+	}
+	out := (&verifyRouteLeakExecutor{}).Execute(context.Background(), r, step)
+	want := map[string]struct {
+		status StepStatus
+		msg    string
+	}{
+		"leaf1": {StepStatusPassed, "default → Vrf_CUST: 1 routes leaked: 0.0.0.0/0 via 10.0.0.1 (bgp)"},
+		"leaf2": {StepStatusFailed, "default → Vrf_CUST: nothing imported from default"},
+	}
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("details = %+v, want %d", out.Result.Details, len(want))
+	}
+	for _, d := range out.Result.Details {
+		if w := want[d.Device]; d.Status != w.status || d.Message != w.msg {
+			t.Errorf("%s: %s %q, want %s %q", d.Device, d.Status, d.Message, w.status, w.msg)
+		}
+	}
+}
+
+func TestRequireRouteLeakParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"src_vrf": "Vrf_A", "dst_vrf": "Vrf_B", "prefixes": []any{"10.0.0.0/24"}}, ""},
+		{map[string]any{"dst_vrf": "Vrf_B"}, "params.src_vrf is required"},
+		{map[string]any{"src_vrf": "Vrf_A"}, "params.dst_vrf is required"},
+		{map[string]any{"src_vrf": "Vrf_A", "dst_vrf": "Vrf_A"}, "src_vrf and dst_vrf are both Vrf_A"},
+	}
+	for _, tt := range tests {
+		err := requireRouteLeakParams("step", &Step{Action: ActionVerifyRouteLeak, Params: tt.params})
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.params, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%v: error = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMatchRoute(t *testing.T) {
+	ecmp := &newtron.RouteEntry{Prefix: "10.50.0.0/24", Protocol: "bgp", NextHops: []newtron.RouteNextHop{
+		{Address: "10.0.0.1", Interface: "Ethernet0"},
+		{Address: "10.0.0.3", Interface: "Ethernet4"},
+	}}
+	count := func(n int) *int { return &n }
+	tests := []struct {
+		name   string
+		expect *ExpectBlock
+		want   bool
+		why    string
+	}{
+		{"nil expect", nil, true, ""},
+		{"empty expect", &ExpectBlock{}, true, ""},
+		{"count 2", &ExpectBlock{NextHopCount: count(2)}, true, ""},
+		{"count 1", &ExpectBlock{NextHopCount: count(1)}, false, "2 next hops, want 1"},
+		{"count 3", &ExpectBlock{NextHopCount: count(3)}, false, "2 next hops, want 3"},
+		{"interface present", &ExpectBlock{NextHopInterface: "Ethernet4"}, true, ""},
+		{"interface absent", &ExpectBlock{NextHopInterface: "Ethernet8"}, false, "no next hop via Ethernet8"},
+		{"count and interface", &ExpectBlock{NextHopCount: count(2), NextHopInterface: "Ethernet0"}, true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, why := matchRoute(ecmp, tt.expect)
+			if got != tt.want || why != tt.why {
+				t.Errorf("matchRoute = %v %q, want %v %q", got, why, tt.want, tt.why)
+			}
+		})
+	}
+}
+
+// TestVerifyRouteLeak_NextHopExpect: a leaked route whose next hops miss
+// expect.next_hop_count FAILs the device, naming the route.
+func TestVerifyRouteLeak_NextHopExpect(t *testing.T) {
+	route := newtron.RouteEntry{Prefix: "10.50.0.0/24", Protocol: "bgp", NextHops: []newtron.RouteNextHop{
+		{Address: "10.0.0.1", Interface: "Ethernet0"},
+		{Address: "10.0.0.3", Interface: "Ethernet4"},
+	}}
+	srv := routeLeakServer(t, map[string]routeLeakDevice{
+		"leaf1": {
+			leaks:  []newtron.RouteLeak{{SrcVRF: "Vrf_SHARED", DstVRF: "Vrf_CUST", Prefixes: []string{"10.50.0.0/24"}}},
+			routes: map[string]map[string]newtron.RouteEntry{"Vrf_CUST": {"10.50.0.0/24": route}},
+		},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	for _, tt := range []struct {
+		count  int
+		status StepStatus
+		msg    string
+	}{
+		{2, StepStatusPassed, "Vrf_SHARED → Vrf_CUST: 10.50.0.0/24 via 10.0.0.1, 10.0.0.3 (bgp)"},
+		{1, StepStatusFailed, "Vrf_SHARED → Vrf_CUST: 10.50.0.0/24 via 10.0.0.1, 10.0.0.3 (bgp): 2 next hops, want 1"},
+	} {
+		count := tt.count
+		step := &Step{
+			Action:  ActionVerifyRouteLeak,
+			Devices: deviceSelector{Devices: []string{"leaf1"}},
+			Params:  map[string]any{"src_vrf": "Vrf_SHARED", "dst_vrf": "Vrf_CUST"},
+			Expect:  &ExpectBlock{NextHopCount: &count},
+		}
+		out := (&verifyRouteLeakExecutor{}).Execute(context.Background(), r, step)
+		if len(out.Result.Details) != 1 {
+			t.Fatalf("count %d: details = %+v, want 1", tt.count, out.Result.Details)
+		}
+		if d := out.Result.Details[0]; d.Status != tt.status || d.Message != tt.msg {
+			t.Errorf("count %d: %s %q, want %s %q", tt.count, d.Status, d.Message, tt.status, tt.msg)
+		}
+	}
+}
@@ -111,14 +111,18 @@ func (e *runSuiteExecutor) Execute(ctx context.Context, r *Runner, step *Step) *
 		Network:            r.Network,
 		Dir:            r.Dir,
 		discoveredPlatform: r.discoveredPlatform,
+		writes:             r.writes, // the run's write budget spans its children
 	}
 
 	childCtx := withRunSuiteDepth(ctx, depth)
 	results, err := child.Run(childCtx, RunOptions{
-		All:        true,
-		NoDeploy:   true, // parent already deployed
-		Targets:    step.Targets,
-		Parameters: step.Parameters,
+		All:         true,
+		NoDeploy:    true, // parent already deployed
+		Targets:     step.Targets,
+		Parameters:  step.Parameters,
+		Parallelism: r.opts.Parallelism, // the parent's device cap carries over
+		Golden:      r.opts.Golden,      // against the child suite's own goldens
+		Preview:     r.opts.Preview,     // a preview must not deliver from a child either
 	})
 	result.Duration = time.Since(start)
 	if err != nil {
@@ -0,0 +1,87 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// The verify-egress-shaper step asserts the egress rate limit configured on a
+// port, on each target device.
+//
+//	- name: handoff-shaped
+//	  action: verify-egress-shaper
+//	  devices: [leaf1]
+//	  params:
+//	    interface: Ethernet0
+//	    rate_kbps: 2000000     # 0 asserts the port is unshaped
+//
+// Per device it reads the port's shaper (GET .../interfaces/{name}/egress-
+// shaper) and compares the rate in the device's CONFIG_DB SCHEDULER row —
+// not the intent — against rate_kbps. A shaper row that PORT_QOS_MAP does not
+// attach to the port shapes nothing and FAILs.
+
+// egressShaperParams is the params: shape of a verify-egress-shaper step.
+type egressShaperParams struct {
+	Interface string `json:"interface"`
+	RateKbps  *int   `json:"rate_kbps"`
+}
+
+func decodeEgressShaperParams(step *Step) (egressShaperParams, error) {
+	var p egressShaperParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.Interface == "" {
+		return p, fmt.Errorf("params.interface is required")
+	}
+	if p.RateKbps == nil {
+		return p, fmt.Errorf("params.rate_kbps is required (0 asserts no shaper)")
+	}
+	if *p.RateKbps < 0 {
+		return p, fmt.Errorf("params.rate_kbps must not be negative, got %d", *p.RateKbps)
+	}
+	return p, nil
+}
+
+// requireEgressShaperParams validates a verify-egress-shaper step at parse
+// time.
+func requireEgressShaperParams(prefix string, step *Step) error {
+	if _, err := decodeEgressShaperParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyEgressShaperExecutor asserts a port's configured egress rate per device.
+type verifyEgressShaperExecutor struct{}
+
+func (e *verifyEgressShaperExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeEgressShaperParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	want := *params.RateKbps
+
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		sh, err := r.Client.EgressShaper(dev, params.Interface)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading egress shaper: %v", err)
+		}
+		switch {
+		case want == 0 && (sh.ConfiguredKbps != 0 || sh.Attached):
+			return StepStatusFailed, fmt.Sprintf("%s: shaped at %d kbps, expected no shaper", params.Interface, sh.ConfiguredKbps)
+		case want == 0:
+			return StepStatusPassed, fmt.Sprintf("%s: no egress shaper, as expected", params.Interface)
+		case sh.ConfiguredKbps != want:
+			return StepStatusFailed, fmt.Sprintf("%s: configured %d kbps, expected %d kbps", params.Interface, sh.ConfiguredKbps, want)
+		case !sh.Attached:
+			return StepStatusFailed, fmt.Sprintf("%s: %d kbps shaper is not attached to the port (PORT_QOS_MAP)", params.Interface, want)
+		}
+		return StepStatusPassed, fmt.Sprintf("%s: shaped at %d kbps", params.Interface, want)
+	})
+}
@@ -0,0 +1,101 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// egressShaperServer fakes newtron-server's GET .../interfaces/{name}/egress-shaper
+// per device.
+func egressShaperServer(t *testing.T, byDevice map[string]newtron.EgressShaper) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+}
+
+func TestVerifyEgressShaper(t *testing.T) {
+	srv := egressShaperServer(t, map[string]newtron.EgressShaper{
+		"leaf1": {Interface: "Ethernet0", RateKbps: 2000000, ConfiguredKbps: 2000000, Attached: true},
+		"leaf2": {Interface: "Ethernet0", RateKbps: 2000000, ConfiguredKbps: 1000000, Attached: true},
+		"leaf3": {Interface: "Ethernet0", RateKbps: 2000000, ConfiguredKbps: 2000000},
+		"leaf4": {Interface: "Ethernet0"},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	tests := []struct {
+		name string
+		rate int
+		want map[string]string // device → message; "" = PASS
+	}{
+		{"shaped", 2000000, map[string]string{
+			"leaf1": "",
+			"leaf2": "Ethernet0: configured 1000000 kbps, expected 2000000 kbps",
+			"leaf3": "Ethernet0: 2000000 kbps shaper is not attached to the port (PORT_QOS_MAP)",
+			"leaf4": "Ethernet0: configured 0 kbps, expected 2000000 kbps",
+		}},
+		{"unshaped", 0, map[string]string{
+			"leaf1": "Ethernet0: shaped at 2000000 kbps, expected no shaper",
+			"leaf4": "",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var devices []string
+			for dev := range tt.want {
+				devices = append(devices, dev)
+			}
+			step := &Step{
+				Action:  ActionVerifyEgressShaper,
+				Devices: deviceSelector{Devices: devices},
+				Params:  map[string]any{"interface": "Ethernet0", "rate_kbps": tt.rate},
+			}
+			out := (&verifyEgressShaperExecutor{}).Execute(context.Background(), r, step)
+			for _, d := range out.Result.Details {
+				want := tt.want[d.Device]
+				if want == "" {
+					if d.Status != StepStatusPassed {
+						t.Errorf("%s: %s %q, want PASSED", d.Device, d.Status, d.Message)
+					}
+					continue
+				}
+				if d.Status != StepStatusFailed || d.Message != want {
+					t.Errorf("%s: %s %q, want FAILED %q", d.Device, d.Status, d.Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRequireEgressShaperParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"interface": "Ethernet0", "rate_kbps": 2000000}, ""},
+		{map[string]any{"interface": "Ethernet0", "rate_kbps": 0}, ""},
+		{map[string]any{"rate_kbps": 1000}, "params.interface is required"},
+		{map[string]any{"interface": "Ethernet0"}, "params.rate_kbps is required"},
+		{map[string]any{"interface": "Ethernet0", "rate_kbps": -1}, "must not be negative"},
+	}
+	for _, tt := range tests {
+		err := requireEgressShaperParams("step", &Step{Action: ActionVerifyEgressShaper, Params: tt.params})
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.params, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%v: err = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package newtrun
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// limitedRunner returns a Runner capped at parallelism devices per step.
+// Steps in these tests name their devices, so the topology lookup behind
+// resolveDevices only has to answer.
+func limitedRunner(t *testing.T, parallelism int) *Runner {
+	t.Helper()
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+	return &Runner{Client: client.New(srv.URL, "test-net"), opts: RunOptions{Parallelism: parallelism}}
+}
+
+// TestExecuteForDevices_Parallelism: with RunOptions.Parallelism set, no more
+// than that many devices run at once, details keep the resolved order, and a
+// failing device does not stop the others.
+func TestExecuteForDevices_Parallelism(t *testing.T) {
+	r := limitedRunner(t, 2)
+	step := &Step{Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3", "leaf4", "spine1"}}}
+
+	var inFlight, peak atomic.Int32
+	out := r.executeForDevices(step, func(name string) (string, error) {
+		cur := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if cur <= p || peak.CompareAndSwap(p, cur) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		if name == "leaf2" {
+			return "", errors.New("ssh: connection refused")
+		}
+		return "provisioned " + name, nil
+	})
+
+	if p := peak.Load(); p > 2 {
+		t.Errorf("peak concurrent devices = %d, want <= 2", p)
+	}
+	if out.Result.Status != StepStatusFailed {
+		t.Errorf("status = %s, want FAILED", out.Result.Status)
+	}
+	want := []struct {
+		device string
+		status StepStatus
+	}{
+		{"leaf1", StepStatusPassed},
+		{"leaf2", StepStatusError},
+		{"leaf3", StepStatusPassed},
+		{"leaf4", StepStatusPassed},
+		{"spine1", StepStatusPassed},
+	}
+	if len(out.Result.Details) != len(want) {
+		t.Fatalf("got %d details, want %d", len(out.Result.Details), len(want))
+	}
+	for i, w := range want {
+		if d := out.Result.Details[i]; d.Device != w.device || d.Status != w.status {
+			t.Errorf("details[%d] = %s %s, want %s %s", i, d.Device, d.Status, w.device, w.status)
+		}
+	}
+}
+
+// TestCheckForDevices_Serial: Parallelism 1 runs devices one at a time.
+func TestCheckForDevices_Serial(t *testing.T) {
+	r := limitedRunner(t, 1)
+	step := &Step{Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3"}}}
+
+	var inFlight, peak atomic.Int32
+	out := r.checkForDevices(step, func(name string) (StepStatus, string) {
+		if cur := inFlight.Add(1); cur > peak.Load() {
+			peak.Store(cur)
+		}
+		defer inFlight.Add(-1)
+		time.Sleep(5 * time.Millisecond)
+		return StepStatusPassed, name
+	})
+	if p := peak.Load(); p != 1 {
+		t.Errorf("peak concurrent devices = %d, want 1", p)
+	}
+	if out.Result.Status != StepStatusPassed {
+		t.Errorf("status = %s, want PASSED", out.Result.Status)
+	}
+}
@@ -0,0 +1,310 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The generate-traffic step runs an iperf3 test between two host devices and
+// asserts on the measured throughput and loss — data-plane validation past
+// what ping can show.
+//
+//	- name: line-rate
+//	  action: generate-traffic
+//	  devices: [host1]          # the client (traffic source)
+//	  params:
+//	    target: host2           # the server (traffic sink)
+//	    target_ip: 10.1.100.20  # optional; default: target's first global IPv4
+//	    protocol: udp           # tcp (default) or udp
+//	    bandwidth: 500M         # udp send rate; required for udp
+//	    duration: 10s           # whole seconds; default 10s
+//	    port: 5201              # default 5201
+//	  expect:
+//	    min_throughput: 400M    # bits/s, K/M/G suffixes (decimal)
+//	    max_loss: 0.5           # percent; udp only
+//
+// The executor owns the iperf3 lifecycle: it starts a one-shot server
+// daemon in the target's namespace, waits for it to listen, runs the client
+// in the source's namespace, and kills the server on the way out whether or
+// not the client ran. Both devices must be host devices (have an SSH
+// connection in HostConns). Without expect, the step passes when the test
+// completes and reports what it measured.
+
+// trafficParams is the params: shape of a generate-traffic step.
+type trafficParams struct {
+	Target    string `json:"target"`
+	TargetIP  string `json:"target_ip"`
+	Protocol  string `json:"protocol"`
+	Bandwidth string `json:"bandwidth"`
+	Duration  string `json:"duration"`
+	Port      int    `json:"port"`
+
+	duration time.Duration
+}
+
+const (
+	defaultTrafficDuration = 10 * time.Second
+	defaultIperf3Port      = 5201
+
+	// iperf3ListenTimeout bounds the wait for the server daemon to listen.
+	iperf3ListenTimeout = 5 * time.Second
+)
+
+func decodeTrafficParams(step *Step) (trafficParams, error) {
+	var p trafficParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.Target == "" {
+		return p, fmt.Errorf("params.target is required")
+	}
+	if devs := step.Devices.Resolve(nil); len(devs) == 1 && devs[0] == p.Target {
+		return p, fmt.Errorf("params.target: %s is also the source device", p.Target)
+	}
+	switch p.Protocol {
+	case "":
+		p.Protocol = "tcp"
+	case "tcp", "udp":
+	default:
+		return p, fmt.Errorf("params.protocol: %q (want tcp or udp)", p.Protocol)
+	}
+	if p.Bandwidth != "" {
+		if _, err := parseBitRate(p.Bandwidth); err != nil {
+			return p, fmt.Errorf("params.bandwidth: %w", err)
+		}
+	} else if p.Protocol == "udp" {
+		return p, fmt.Errorf("params.bandwidth is required for udp")
+	}
+	p.duration = defaultTrafficDuration
+	if p.Duration != "" {
+		d, err := time.ParseDuration(p.Duration)
+		if err != nil {
+			return p, fmt.Errorf("params.duration: %w", err)
+		}
+		if d < time.Second || d%time.Second != 0 {
+			return p, fmt.Errorf("params.duration: %s is not a whole number of seconds", d)
+		}
+		p.duration = d
+	}
+	if p.Port == 0 {
+		p.Port = defaultIperf3Port
+	}
+	if p.Port < 1 || p.Port > 65535 {
+		return p, fmt.Errorf("params.port: %d is out of range", p.Port)
+	}
+	if e := step.Expect; e != nil {
+		if e.MinThroughput != "" {
+			if _, err := parseBitRate(e.MinThroughput); err != nil {
+				return p, fmt.Errorf("expect.min_throughput: %w", err)
+			}
+		}
+		if e.MaxLoss != nil && p.Protocol != "udp" {
+			return p, fmt.Errorf("expect.max_loss applies to udp only")
+		}
+	}
+	return p, nil
+}
+
+// requireTrafficParams validates a generate-traffic step at parse time.
+func requireTrafficParams(prefix string, step *Step) error {
+	if _, err := decodeTrafficParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// generateTrafficExecutor runs iperf3 between two host devices.
+type generateTrafficExecutor struct{}
+
+func (e *generateTrafficExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeTrafficParams(step)
+	if err != nil {
+		return trafficError(err.Error())
+	}
+	source := r.resolveDevices(step)[0]
+	srcConn, ok := r.HostConns[source]
+	if !ok {
+		return trafficError(fmt.Sprintf("source %s is not a host device", source))
+	}
+	dstConn, ok := r.HostConns[params.Target]
+	if !ok {
+		return trafficError(fmt.Sprintf("target %s is not a host device", params.Target))
+	}
+
+	targetIP := params.TargetIP
+	if targetIP == "" {
+		out, err := runSSHCommand(dstConn, netnsCommand(params.Target, "ip -4 -o addr show scope global"))
+		if err != nil {
+			return trafficError(fmt.Sprintf("reading %s addresses: %v\n%s", params.Target, err, out))
+		}
+		if targetIP = firstIPv4(out); targetIP == "" {
+			return trafficError(fmt.Sprintf("%s has no global IPv4 address; set params.target_ip", params.Target))
+		}
+	}
+
+	pidFile := fmt.Sprintf("/tmp/newtrun-iperf3-%s-%d.pid", params.Target, params.Port)
+	server := fmt.Sprintf("iperf3 -s -1 -D -p %d -I %s", params.Port, pidFile)
+	if out, err := runSSHCommand(dstConn, netnsCommand(params.Target, server)); err != nil {
+		return trafficError(fmt.Sprintf("starting iperf3 server on %s: %v\n%s", params.Target, err, out))
+	}
+	defer runSSHCommand(dstConn, netnsCommand(params.Target,
+		fmt.Sprintf("[ -f %[1]s ] && kill $(cat %[1]s); rm -f %[1]s", pidFile)))
+
+	listening := netnsCommand(params.Target, fmt.Sprintf("ss -Hltn 'sport = :%d'", params.Port))
+	if err := pollUntil(ctx, iperf3ListenTimeout, 200*time.Millisecond, func() (bool, error) {
+		out, err := runSSHCommand(dstConn, listening)
+		return err == nil && strings.TrimSpace(out) != "", nil
+	}); err != nil {
+		return trafficError(fmt.Sprintf("iperf3 server on %s not listening on port %d: %v", params.Target, params.Port, err))
+	}
+
+	client := fmt.Sprintf("iperf3 -c %s -p %d -t %d -J", targetIP, params.Port, int(params.duration/time.Second))
+	if params.Protocol == "udp" {
+		client += " -u"
+	}
+	if params.Bandwidth != "" {
+		client += " -b " + params.Bandwidth
+	}
+	out, runErr := runSSHCommand(srcConn, netnsCommand(source, client))
+	result, err := parseIperf3Result(out)
+	if err != nil {
+		if runErr != nil {
+			err = fmt.Errorf("%w (%v)", err, runErr)
+		}
+		return trafficError(fmt.Sprintf("iperf3 %s → %s: %v", source, params.Target, err))
+	}
+	return &StepOutput{Result: evaluateTraffic(step, params, source, targetIP, result)}
+}
+
+func trafficError(msg string) *StepOutput {
+	return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: msg}}
+}
+
+var inetAddrRe = regexp.MustCompile(`\binet (\d+\.\d+\.\d+\.\d+)/`)
+
+// firstIPv4 returns the first address in `ip -4 -o addr show` output.
+func firstIPv4(out string) string {
+	if m := inetAddrRe.FindStringSubmatch(out); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// iperf3Result is what a generate-traffic step measured. Loss fields are set
+// for udp only.
+type iperf3Result struct {
+	BitsPerSecond float64
+	LostPackets   int
+	Packets       int
+	LostPercent   float64
+	udp           bool
+}
+
+// parseIperf3Result reads the end-of-test summary of `iperf3 -J` output:
+// the receiver's rate for tcp, the server-reported sum for udp.
+func parseIperf3Result(out string) (*iperf3Result, error) {
+	var doc struct {
+		Start struct {
+			Test struct {
+				Protocol string `json:"protocol"`
+			} `json:"test_start"`
+		} `json:"start"`
+		End struct {
+			SumReceived struct {
+				BitsPerSecond float64 `json:"bits_per_second"`
+			} `json:"sum_received"`
+			Sum struct {
+				BitsPerSecond float64 `json:"bits_per_second"`
+				LostPackets   int     `json:"lost_packets"`
+				Packets       int     `json:"packets"`
+				LostPercent   float64 `json:"lost_percent"`
+			} `json:"sum"`
+		} `json:"end"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		return nil, fmt.Errorf("unparseable output: %v\n%s", err, out)
+	}
+	if doc.Error != "" {
+		return nil, fmt.Errorf("%s", doc.Error)
+	}
+	if strings.EqualFold(doc.Start.Test.Protocol, "UDP") {
+		s := doc.End.Sum
+		return &iperf3Result{BitsPerSecond: s.BitsPerSecond, LostPackets: s.LostPackets,
+			Packets: s.Packets, LostPercent: s.LostPercent, udp: true}, nil
+	}
+	return &iperf3Result{BitsPerSecond: doc.End.SumReceived.BitsPerSecond}, nil
+}
+
+// evaluateTraffic applies a generate-traffic step's expectations to one
+// iperf3 result.
+func evaluateTraffic(step *Step, p trafficParams, source, targetIP string, res *iperf3Result) *StepResult {
+	msg := fmt.Sprintf("%s → %s (%s) %s over %s: %s", source, p.Target, targetIP, p.Protocol, p.duration, formatBitRate(res.BitsPerSecond))
+	if res.udp {
+		msg += fmt.Sprintf(", %.2f%% loss (%d/%d)", res.LostPercent, res.LostPackets, res.Packets)
+	}
+
+	var problems []string
+	if e := step.Expect; e != nil {
+		if e.MinThroughput != "" {
+			minRate, _ := parseBitRate(e.MinThroughput) // validated at parse time
+			if res.BitsPerSecond < minRate {
+				problems = append(problems, fmt.Sprintf("throughput below minimum %s", formatBitRate(minRate)))
+			}
+		}
+		if e.MaxLoss != nil && res.LostPercent > *e.MaxLoss {
+			problems = append(problems, fmt.Sprintf("loss above maximum %.2f%%", *e.MaxLoss))
+		}
+	}
+	if len(problems) > 0 {
+		return &StepResult{Status: StepStatusFailed, Message: msg + " — " + strings.Join(problems, "; ")}
+	}
+	return &StepResult{Status: StepStatusPassed, Message: msg}
+}
+
+// parseBitRate parses an iperf-style rate in bits/s: a number with an
+// optional K, M, or G suffix (decimal multiples, case-insensitive).
+func parseBitRate(s string) (float64, error) {
+	num, mult := strings.TrimSpace(s), 1.0
+	if n := len(num); n > 0 {
+		switch num[n-1] {
+		case 'k', 'K':
+			mult = 1e3
+		case 'm', 'M':
+			mult = 1e6
+		case 'g', 'G':
+			mult = 1e9
+		}
+		if mult != 1 {
+			num = num[:n-1]
+		}
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil || v <= 0 {
+		return 0, fmt.Errorf("%q is not a bit rate (e.g. 500M, 1G)", s)
+	}
+	return v * mult, nil
+}
+
+// formatBitRate renders bits/s in the largest unit that keeps the value ≥ 1.
+func formatBitRate(bps float64) string {
+	switch {
+	case bps >= 1e9:
+		return fmt.Sprintf("%.2f Gbit/s", bps/1e9)
+	case bps >= 1e6:
+		return fmt.Sprintf("%.2f Mbit/s", bps/1e6)
+	case bps >= 1e3:
+		return fmt.Sprintf("%.2f Kbit/s", bps/1e3)
+	default:
+		return fmt.Sprintf("%.0f bit/s", bps)
+	}
+}
@@ -0,0 +1,130 @@
+package newtrun
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+func TestParse_GenerateTrafficParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		extra   string
+		wantErr string
+	}{
+		{"tcp defaults", "params: {target: host2}", ""},
+		{"udp with assertions", "params: {target: host2, protocol: udp, bandwidth: 500M, duration: 5s}\n    expect: {min_throughput: 400M, max_loss: 0.5}", ""},
+		{"missing target", "params: {protocol: tcp}", "params.target is required"},
+		{"target is source", "params: {target: host1}", "host1 is also the source device"},
+		{"bad protocol", "params: {target: host2, protocol: sctp}", `params.protocol: "sctp"`},
+		{"udp needs bandwidth", "params: {target: host2, protocol: udp}", "params.bandwidth is required for udp"},
+		{"fractional duration", "params: {target: host2, duration: 1500ms}", "not a whole number of seconds"},
+		{"bad min throughput", "params: {target: host2}\n    expect: {min_throughput: fast}", `expect.min_throughput: "fast" is not a bit rate`},
+		{"loss on tcp", "params: {target: host2}\n    expect: {max_loss: 1}", "expect.max_loss applies to udp only"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "name: s\nsteps:\n  - name: g\n    action: generate-traffic\n    devices: [host1]\n    " + tt.extra + "\n"
+			_, err := ParseScenarioBytes([]byte(yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseIperf3Result(t *testing.T) {
+	tcp := `{"start": {"test_start": {"protocol": "TCP"}},
+		"end": {"sum_sent": {"bits_per_second": 950000000}, "sum_received": {"bits_per_second": 941200000}}}`
+	res, err := parseIperf3Result(tcp)
+	if err != nil {
+		t.Fatalf("tcp: %v", err)
+	}
+	if res.udp || res.BitsPerSecond != 941200000 {
+		t.Errorf("tcp result = %+v, want receiver rate 941.2M", res)
+	}
+
+	udp := `{"start": {"test_start": {"protocol": "UDP"}},
+		"end": {"sum": {"bits_per_second": 499800000, "lost_packets": 3, "packets": 15000, "lost_percent": 0.02}}}`
+	res, err = parseIperf3Result(udp)
+	if err != nil {
+		t.Fatalf("udp: %v", err)
+	}
+	if !res.udp || res.BitsPerSecond != 499800000 || res.LostPackets != 3 || res.Packets != 15000 {
+		t.Errorf("udp result = %+v", res)
+	}
+
+	if _, err := parseIperf3Result(`{"start": {}, "end": {}, "error": "unable to connect to server: Connection refused"}`); err == nil ||
+		!strings.Contains(err.Error(), "Connection refused") {
+		t.Errorf("error output: err = %v", err)
+	}
+	if _, err := parseIperf3Result("iperf3: command not found"); err == nil {
+		t.Error("non-JSON output accepted")
+	}
+}
+
+func TestEvaluateTraffic(t *testing.T) {
+	maxLoss := 0.01
+	step := &Step{Expect: &ExpectBlock{MinThroughput: "500M", MaxLoss: &maxLoss}}
+	p := trafficParams{Target: "host2", Protocol: "udp", duration: defaultTrafficDuration}
+
+	got := evaluateTraffic(step, p, "host1", "10.1.100.20",
+		&iperf3Result{BitsPerSecond: 499.8e6, LostPackets: 3, Packets: 15000, LostPercent: 0.02, udp: true})
+	want := "host1 → host2 (10.1.100.20) udp over 10s: 499.80 Mbit/s, 0.02% loss (3/15000) — " +
+		"throughput below minimum 500.00 Mbit/s; loss above maximum 0.01%"
+	if got.Status != StepStatusFailed || got.Message != want {
+		t.Errorf("got %s %q\nwant FAILED %q", got.Status, got.Message, want)
+	}
+
+	got = evaluateTraffic(step, p, "host1", "10.1.100.20",
+		&iperf3Result{BitsPerSecond: 1.2e9, Packets: 15000, udp: true})
+	if got.Status != StepStatusPassed {
+		t.Errorf("got %s %q, want PASSED", got.Status, got.Message)
+	}
+}
+
+func TestParseBitRate(t *testing.T) {
+	for in, want := range map[string]float64{"500M": 500e6, "1g": 1e9, "64K": 64e3, "1000": 1000, "2.5G": 2.5e9} {
+		if got, err := parseBitRate(in); err != nil || got != want {
+			t.Errorf("parseBitRate(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "M", "-1M", "fast"} {
+		if _, err := parseBitRate(in); err == nil {
+			t.Errorf("parseBitRate(%q) accepted", in)
+		}
+	}
+}
+
+func TestGenerateTraffic_RequiresHostDevices(t *testing.T) {
+	step := &Step{
+		Action:  ActionGenerateTraffic,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+		Params:  map[string]any{"target": "host2"},
+	}
+	// Against an unreachable server the topology read fails and the explicit
+	// devices: list stands.
+	r := &Runner{Client: client.New("http://127.0.0.1:0", "test-net")}
+	out := (&generateTrafficExecutor{}).Execute(context.Background(), r, step)
+	if out.Result.Status != StepStatusError || out.Result.Message != "source leaf1 is not a host device" {
+		t.Errorf("result = %s %q", out.Result.Status, out.Result.Message)
+	}
+}
+
+func TestFirstIPv4(t *testing.T) {
+	out := "2: eth0    inet 10.1.100.20/24 brd 10.1.100.255 scope global eth0\\       valid_lft forever preferred_lft forever\n"
+	if got := firstIPv4(out); got != "10.1.100.20" {
+		t.Errorf("firstIPv4 = %q", got)
+	}
+	if got := firstIPv4(""); got != "" {
+		t.Errorf("firstIPv4(empty) = %q", got)
+	}
+}
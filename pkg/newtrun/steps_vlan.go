@@ -0,0 +1,165 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The verify-vlan-membership step asserts which ports are in a VLAN and how
+// they are tagged, on each target device.
+//
+//	- name: rack-joined
+//	  action: verify-vlan-membership
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    vlan: 100
+//	    untagged: [Ethernet0, Ethernet4]
+//	    tagged: [PortChannel1]
+//	    match: exact          # or at-least; default exact
+//
+// Per device it reads the VLAN membership view (GET .../vlans/membership, a
+// CONFIG_DB join of VLAN, VLAN_MEMBER, and VLAN_INTERFACE) and compares the
+// VLAN's members against the expected sets. A port expected in one tagging
+// mode and found in the other is reported as wrong tagging, not as one
+// missing and one extra member. With match: at-least, members beyond the
+// expected sets are allowed; with exact (the default) they FAIL as extra.
+
+// vlanMatchExact and vlanMatchAtLeast are the params.match values.
+const (
+	vlanMatchExact   = "exact"
+	vlanMatchAtLeast = "at-least"
+)
+
+// vlanMembershipParams is the params: shape of a verify-vlan-membership step.
+type vlanMembershipParams struct {
+	VLAN     int      `json:"vlan"`
+	Tagged   []string `json:"tagged"`
+	Untagged []string `json:"untagged"`
+	Match    string   `json:"match"`
+}
+
+func decodeVLANMembershipParams(step *Step) (vlanMembershipParams, error) {
+	var p vlanMembershipParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.VLAN < 1 || p.VLAN > 4094 {
+		return p, fmt.Errorf("params.vlan: %d is not a valid VLAN ID", p.VLAN)
+	}
+	switch p.Match {
+	case "":
+		p.Match = vlanMatchExact
+	case vlanMatchExact, vlanMatchAtLeast:
+	default:
+		return p, fmt.Errorf("params.match: %q (want %s or %s)", p.Match, vlanMatchExact, vlanMatchAtLeast)
+	}
+	if p.Match == vlanMatchAtLeast && len(p.Tagged) == 0 && len(p.Untagged) == 0 {
+		return p, fmt.Errorf("params: match %s needs at least one tagged or untagged member", vlanMatchAtLeast)
+	}
+	seen := map[string]bool{}
+	for _, m := range append(append([]string{}, p.Tagged...), p.Untagged...) {
+		if seen[m] {
+			return p, fmt.Errorf("params: member %s is listed more than once", m)
+		}
+		seen[m] = true
+	}
+	return p, nil
+}
+
+// requireVLANMembershipParams validates a verify-vlan-membership step at
+// parse time.
+func requireVLANMembershipParams(prefix string, step *Step) error {
+	if _, err := decodeVLANMembershipParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// verifyVLANMembershipExecutor asserts a VLAN's members and tagging per device.
+type verifyVLANMembershipExecutor struct{}
+
+func (e *verifyVLANMembershipExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeVLANMembershipParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+	vlanName := fmt.Sprintf("Vlan%d", params.VLAN)
+
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		vlans, err := r.Client.VLANMembership(dev)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading VLAN membership: %v", err)
+		}
+		got := map[string]string{} // member → "tagged" / "untagged"
+		found := false
+		for _, v := range vlans {
+			if v.ID != params.VLAN {
+				continue
+			}
+			found = true
+			for _, m := range v.Tagged {
+				got[m] = "tagged"
+			}
+			for _, m := range v.Untagged {
+				got[m] = "untagged"
+			}
+		}
+		observe(ctx, dev, got)
+		if !found {
+			return StepStatusFailed, fmt.Sprintf("%s not present", vlanName)
+		}
+		if problems := compareVLANMembers(got, params); len(problems) > 0 {
+			return StepStatusFailed, fmt.Sprintf("%s: %s", vlanName, strings.Join(problems, "; "))
+		}
+		return StepStatusPassed, fmt.Sprintf("%s: %d tagged, %d untagged as expected",
+			vlanName, len(params.Tagged), len(params.Untagged))
+	})
+}
+
+// compareVLANMembers returns the missing, wrong-tagging, and (for exact
+// matches) extra members of got against the expected sets in p, each group
+// sorted by member name.
+func compareVLANMembers(got map[string]string, p vlanMembershipParams) []string {
+	want := map[string]string{}
+	for _, m := range p.Tagged {
+		want[m] = "tagged"
+	}
+	for _, m := range p.Untagged {
+		want[m] = "untagged"
+	}
+
+	var missing, wrong, extra []string
+	for _, m := range sortedKeys(want) {
+		switch mode, ok := got[m]; {
+		case !ok:
+			missing = append(missing, m)
+		case mode != want[m]:
+			wrong = append(wrong, fmt.Sprintf("%s %s, expected %s", m, mode, want[m]))
+		}
+	}
+	if p.Match == vlanMatchExact {
+		for _, m := range sortedKeys(got) {
+			if _, ok := want[m]; !ok {
+				extra = append(extra, fmt.Sprintf("%s (%s)", m, got[m]))
+			}
+		}
+	}
+
+	var problems []string
+	if len(missing) > 0 {
+		problems = append(problems, "missing "+strings.Join(missing, ", "))
+	}
+	if len(wrong) > 0 {
+		problems = append(problems, "wrong tagging "+strings.Join(wrong, ", "))
+	}
+	if len(extra) > 0 {
+		problems = append(problems, "extra "+strings.Join(extra, ", "))
+	}
+	return problems
+}
@@ -0,0 +1,122 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// vlanMembershipServer fakes newtron-server's GET .../vlans/membership per device.
+func vlanMembershipServer(t *testing.T, byDevice map[string][]newtron.VLANMembership) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": byDevice[dev]})
+	}))
+}
+
+func TestVerifyVLANMembership(t *testing.T) {
+	vlan100 := newtron.VLANMembership{
+		ID: 100, Name: "Vlan100",
+		Tagged:   []string{"PortChannel1"},
+		Untagged: []string{"Ethernet0", "Ethernet4"},
+	}
+	srv := vlanMembershipServer(t, map[string][]newtron.VLANMembership{
+		"leaf1": {vlan100},
+		"leaf2": {{ID: 100, Name: "Vlan100",
+			Tagged:   []string{"Ethernet4", "PortChannel1"},
+			Untagged: []string{"Ethernet12"},
+		}},
+		"leaf3": {{ID: 200, Name: "Vlan200"}},
+	})
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	tests := []struct {
+		name  string
+		match string
+		want  map[string]string // device → message; "" = PASS
+	}{
+		{"exact", "", map[string]string{
+			"leaf1": "",
+			"leaf2": "Vlan100: missing Ethernet0; wrong tagging Ethernet4 tagged, expected untagged; extra Ethernet12 (untagged)",
+			"leaf3": "Vlan100 not present",
+		}},
+		{"at-least", "at-least", map[string]string{
+			"leaf1": "",
+			"leaf2": "Vlan100: missing Ethernet0; wrong tagging Ethernet4 tagged, expected untagged",
+			"leaf3": "Vlan100 not present",
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := map[string]any{
+				"vlan":     100,
+				"tagged":   []any{"PortChannel1"},
+				"untagged": []any{"Ethernet0", "Ethernet4"},
+			}
+			if tt.match != "" {
+				params["match"] = tt.match
+			}
+			step := &Step{
+				Action:  ActionVerifyVLANMembership,
+				Devices: deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3"}},
+				Params:  params,
+			}
+			out := (&verifyVLANMembershipExecutor{}).Execute(context.Background(), r, step)
+			if out.Result.Status != StepStatusFailed {
+				t.Fatalf("status = %s, want FAILED: %+v", out.Result.Status, out.Result)
+			}
+			for _, d := range out.Result.Details {
+				want := tt.want[d.Device]
+				if want == "" {
+					if d.Status != StepStatusPassed {
+						t.Errorf("%s: %s %q, want PASS", d.Device, d.Status, d.Message)
+					}
+					continue
+				}
+				if d.Status != StepStatusFailed || d.Message != want {
+					t.Errorf("%s: %s %q, want FAILED %q", d.Device, d.Status, d.Message, want)
+				}
+			}
+		})
+	}
+}
+
+func TestParse_VerifyVLANMembershipParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  string
+		wantErr string
+	}{
+		{"valid", "{vlan: 100, untagged: [Ethernet0]}", ""},
+		{"exact with no members", "{vlan: 100}", ""},
+		{"missing vlan", "{untagged: [Ethernet0]}", "params.vlan: 0 is not a valid VLAN ID"},
+		{"bad match", "{vlan: 100, untagged: [Ethernet0], match: some}", `params.match: "some"`},
+		{"at-least needs members", "{vlan: 100, match: at-least}", "needs at least one tagged or untagged member"},
+		{"duplicate member", "{vlan: 100, tagged: [Ethernet0], untagged: [Ethernet0]}", "Ethernet0 is listed more than once"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yaml := "name: s\nsteps:\n  - name: v\n    action: verify-vlan-membership\n    devices: [leaf1]\n    params: " + tt.params + "\n"
+			_, err := ParseScenarioBytes([]byte(yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("err = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+)
+
+// The verify-vxlan-stats step asserts that overlay traffic for a VNI actually
+// went through the VXLAN tunnels on each target device — the dataplane proof
+// a formed tunnel alone does not give.
+//
+//	- name: vxlan-before
+//	  action: verify-vxlan-stats
+//	  devices: [leaf1, leaf2]
+//	  params: {vni: 10100, baseline: pre-traffic, record: true}
+//
+//	- name: traffic ...            # generate-traffic, host-exec ping, ...
+//
+//	- name: traffic-used-tunnel
+//	  action: verify-vxlan-stats
+//	  devices: [leaf1, leaf2]
+//	  params:
+//	    vni: 10100
+//	    baseline: pre-traffic      # compare against the recorded counters
+//	    direction: both            # encap | decap | both (default)
+//	    min_packets: 100           # default 1
+//
+// Per device it reads the tunnel counters (GET .../evpn/vxlan-stats) and sums
+// encap and decap packets over the tunnels carrying the VNI — SAI counts per
+// tunnel, not per VNI, so other VNIs on the same tunnel add to the sum. With
+// record: true the step stores the counters under the baseline name (run-
+// scoped, like snapshots) and passes. Otherwise it FAILs when the encap or
+// decap packet count — the delta since the baseline, when one is named — is
+// below min_packets, or when no tunnel carries the VNI. Tunnels with no
+// counters (the TUNNEL counter group is not polling) are an ERROR, not a
+// zero.
+
+// vxlanStatsParams is the params: shape of a verify-vxlan-stats step.
+type vxlanStatsParams struct {
+	VNI        int    `json:"vni"`
+	Direction  string `json:"direction"`
+	MinPackets *int   `json:"min_packets"`
+	Baseline   string `json:"baseline"`
+	Record     bool   `json:"record"`
+
+	minPackets uint64
+}
+
+func decodeVXLANStatsParams(step *Step) (vxlanStatsParams, error) {
+	var p vxlanStatsParams
+	raw, err := json.Marshal(step.Params)
+	if err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return p, fmt.Errorf("params: %w", err)
+	}
+	if p.VNI < 1 || p.VNI > 16777215 {
+		return p, fmt.Errorf("params.vni must be 1-16777215, got %d", p.VNI)
+	}
+	switch p.Direction {
+	case "":
+		p.Direction = "both"
+	case "encap", "decap", "both":
+	default:
+		return p, fmt.Errorf("params.direction must be encap, decap, or both, got %q", p.Direction)
+	}
+	p.minPackets = 1
+	if p.MinPackets != nil {
+		if *p.MinPackets < 1 {
+			return p, fmt.Errorf("params.min_packets must be at least 1, got %d", *p.MinPackets)
+		}
+		p.minPackets = uint64(*p.MinPackets)
+	}
+	if p.Record && p.Baseline == "" {
+		return p, fmt.Errorf("params.record requires params.baseline")
+	}
+	return p, nil
+}
+
+// requireVXLANStatsParams validates a verify-vxlan-stats step at parse time.
+func requireVXLANStatsParams(prefix string, step *Step) error {
+	if _, err := decodeVXLANStatsParams(step); err != nil {
+		return fmt.Errorf("%s: %w", prefix, err)
+	}
+	return nil
+}
+
+// storeVXLANBaseline records a named per-device tunnel counter baseline.
+// Safe under checkForDevices' per-device goroutines.
+func (r *Runner) storeVXLANBaseline(name, device string, stats []newtron.VXLANStat) {
+	r.vxlanBaselinesMu.Lock()
+	defer r.vxlanBaselinesMu.Unlock()
+	if r.vxlanBaselines == nil {
+		r.vxlanBaselines = map[string]map[string][]newtron.VXLANStat{}
+	}
+	if r.vxlanBaselines[name] == nil {
+		r.vxlanBaselines[name] = map[string][]newtron.VXLANStat{}
+	}
+	r.vxlanBaselines[name][device] = stats
+}
+
+// loadVXLANBaseline returns a previously recorded per-device baseline.
+func (r *Runner) loadVXLANBaseline(name, device string) ([]newtron.VXLANStat, bool) {
+	r.vxlanBaselinesMu.Lock()
+	defer r.vxlanBaselinesMu.Unlock()
+	stats, ok := r.vxlanBaselines[name][device]
+	return stats, ok
+}
+
+// verifyVXLANStatsExecutor asserts per-VNI tunnel traffic per device.
+type verifyVXLANStatsExecutor struct{}
+
+func (e *verifyVXLANStatsExecutor) Execute(ctx context.Context, r *Runner, step *Step) *StepOutput {
+	params, err := decodeVXLANStatsParams(step)
+	if err != nil {
+		return &StepOutput{Result: &StepResult{Status: StepStatusError, Message: err.Error()}}
+	}
+
+	return r.checkForDevices(step, func(dev string) (StepStatus, string) {
+		stats, err := r.Client.VXLANStats(dev)
+		if err != nil {
+			return StepStatusError, fmt.Sprintf("reading VXLAN stats: %v", err)
+		}
+		var baseline []newtron.VXLANStat
+		if params.Baseline != "" && !params.Record {
+			var ok bool
+			if baseline, ok = r.loadVXLANBaseline(params.Baseline, dev); !ok {
+				return StepStatusError, fmt.Sprintf("no VXLAN baseline %q recorded for this device", params.Baseline)
+			}
+		}
+		sum, err := vniTraffic(stats, baseline, params.VNI)
+		if err != nil {
+			return StepStatusError, err.Error()
+		}
+		if len(sum.tunnels) == 0 {
+			return StepStatusFailed, fmt.Sprintf("no VXLAN tunnel carries VNI %d", params.VNI)
+		}
+		over := strings.Join(sum.tunnels, ", ")
+		if params.Record {
+			r.storeVXLANBaseline(params.Baseline, dev, stats)
+			return StepStatusPassed, fmt.Sprintf("recorded %q: VNI %d encap %d, decap %d packets over %s",
+				params.Baseline, params.VNI, sum.encap, sum.decap, over)
+		}
+
+		sign := ""
+		if baseline != nil {
+			sign = "+"
+		}
+		var short []string
+		if params.Direction != "decap" && sum.encap < params.minPackets {
+			short = append(short, fmt.Sprintf("encap %s%d packets (expected ≥ %d)", sign, sum.encap, params.minPackets))
+		}
+		if params.Direction != "encap" && sum.decap < params.minPackets {
+			short = append(short, fmt.Sprintf("decap %s%d packets (expected ≥ %d)", sign, sum.decap, params.minPackets))
+		}
+		if len(short) > 0 {
+			return StepStatusFailed, fmt.Sprintf("VNI %d: %s over %s", params.VNI, strings.Join(short, ", "), over)
+		}
+		return StepStatusPassed, fmt.Sprintf("VNI %d: encap %s%d, decap %s%d packets over %s",
+			params.VNI, sign, sum.encap, sign, sum.decap, over)
+	})
+}
+
+// vniSum is the packet traffic over the counted tunnels carrying one VNI.
+type vniSum struct {
+	tunnels      []string
+	encap, decap uint64
+}
+
+// vniTraffic sums encap and decap packets over the tunnels carrying vni, net
+// of baseline when one is given. A tunnel absent from the baseline counts
+// from zero (it formed since). Counters that went backwards mean a reset
+// between the reads, which makes any delta meaningless.
+func vniTraffic(stats, baseline []newtron.VXLANStat, vni int) (vniSum, error) {
+	before := make(map[string]newtron.VXLANStat, len(baseline))
+	for _, st := range baseline {
+		before[st.Tunnel] = st
+	}
+	var sum vniSum
+	var uncounted []string
+	for _, st := range stats {
+		if !carriesVNI(st, vni) {
+			continue
+		}
+		if !st.Counted {
+			uncounted = append(uncounted, st.Tunnel)
+			continue
+		}
+		b := before[st.Tunnel]
+		if st.EncapPackets < b.EncapPackets || st.DecapPackets < b.DecapPackets {
+			return sum, fmt.Errorf("%s counters went backwards since the baseline (cleared or device restarted)", st.Tunnel)
+		}
+		sum.tunnels = append(sum.tunnels, st.Tunnel)
+		sum.encap += st.EncapPackets - b.EncapPackets
+		sum.decap += st.DecapPackets - b.DecapPackets
+	}
+	if len(sum.tunnels) == 0 && len(uncounted) > 0 {
+		return sum, fmt.Errorf("no counters for %s (carrying VNI %d); enable the TUNNEL counter group",
+			strings.Join(uncounted, ", "), vni)
+	}
+	return sum, nil
+}
+
+func carriesVNI(st newtron.VXLANStat, vni int) bool {
+	for _, v := range st.VNIs {
+		if v == vni {
+			return true
+		}
+	}
+	return false
+}
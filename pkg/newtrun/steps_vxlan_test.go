@@ -0,0 +1,166 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron"
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// vxlanStatsServer fakes newtron-server's GET .../evpn/vxlan-stats per
+// device. Tests swap a device's counters between steps under mu.
+type vxlanStatsServer struct {
+	mu       sync.Mutex
+	byDevice map[string][]newtron.VXLANStat
+}
+
+func (f *vxlanStatsServer) set(dev string, stats []newtron.VXLANStat) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.byDevice[dev] = stats
+}
+
+func (f *vxlanStatsServer) start(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, rest, _ := strings.Cut(r.URL.Path, "/nodes/")
+		dev, _, _ := strings.Cut(rest, "/")
+		f.mu.Lock()
+		stats := f.byDevice[dev]
+		f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": stats})
+	}))
+}
+
+func remoteTunnel(ip string, vnis []int, encap, decap uint64) newtron.VXLANStat {
+	return newtron.VXLANStat{Tunnel: "EVPN_" + ip, RemoteVTEP: ip, VNIs: vnis, Counted: true,
+		EncapPackets: encap, DecapPackets: decap}
+}
+
+func vxlanStep(params map[string]any) *Step {
+	return &Step{
+		Action:  ActionVerifyVXLANStats,
+		Devices: deviceSelector{Devices: []string{"leaf1"}},
+		Params:  params,
+	}
+}
+
+func TestVerifyVXLANStats_BaselineDelta(t *testing.T) {
+	fake := &vxlanStatsServer{byDevice: map[string][]newtron.VXLANStat{
+		"leaf1": {
+			remoteTunnel("10.0.0.2", []int{10100}, 500, 400),
+			remoteTunnel("10.0.0.3", []int{10200}, 9000, 9000),
+		},
+	}}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+	exec := &verifyVXLANStatsExecutor{}
+
+	out := exec.Execute(context.Background(), r, vxlanStep(map[string]any{
+		"vni": 10100, "baseline": "pre", "record": true,
+	}))
+	if out.Result.Status != StepStatusPassed {
+		t.Fatalf("record: %s %+v", out.Result.Status, out.Result.Details)
+	}
+
+	// No traffic since the baseline: the absolute counts are non-zero, but
+	// the delta is what the step asserts.
+	verify := vxlanStep(map[string]any{"vni": 10100, "baseline": "pre"})
+	out = exec.Execute(context.Background(), r, verify)
+	if out.Result.Status != StepStatusFailed {
+		t.Fatalf("no traffic: status = %s, want FAILED", out.Result.Status)
+	}
+	if got, want := out.Result.Details[0].Message,
+		"VNI 10100: encap +0 packets (expected ≥ 1), decap +0 packets (expected ≥ 1) over EVPN_10.0.0.2"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+
+	fake.set("leaf1", []newtron.VXLANStat{
+		remoteTunnel("10.0.0.2", []int{10100}, 620, 518),
+		remoteTunnel("10.0.0.3", []int{10200}, 9000, 9000),
+		remoteTunnel("10.0.0.4", []int{10100}, 5, 0), // formed since the baseline
+	})
+	out = exec.Execute(context.Background(), r, verify)
+	if out.Result.Status != StepStatusPassed {
+		t.Fatalf("with traffic: status = %s: %+v", out.Result.Status, out.Result.Details)
+	}
+	if got, want := out.Result.Details[0].Message,
+		"VNI 10100: encap +125, decap +118 packets over EVPN_10.0.0.2, EVPN_10.0.0.4"; got != want {
+		t.Errorf("message = %q, want %q", got, want)
+	}
+
+	fake.set("leaf1", []newtron.VXLANStat{remoteTunnel("10.0.0.2", []int{10100}, 3, 3)})
+	out = exec.Execute(context.Background(), r, verify)
+	if d := out.Result.Details[0]; d.Status != StepStatusError || !strings.Contains(d.Message, "went backwards") {
+		t.Errorf("counter reset: %s %q, want ERROR naming the reset", d.Status, d.Message)
+	}
+}
+
+func TestVerifyVXLANStats_Absolute(t *testing.T) {
+	fake := &vxlanStatsServer{byDevice: map[string][]newtron.VXLANStat{
+		"leaf1": {remoteTunnel("10.0.0.2", []int{10100}, 50, 0)},
+		"leaf2": {{Tunnel: "EVPN_10.0.0.1", RemoteVTEP: "10.0.0.1", VNIs: []int{10100}}},
+		"leaf3": {remoteTunnel("10.0.0.1", []int{10200}, 50, 50)},
+	}}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	step := vxlanStep(map[string]any{"vni": 10100, "direction": "encap", "min_packets": 10})
+	step.Devices = deviceSelector{Devices: []string{"leaf1", "leaf2", "leaf3"}}
+	out := (&verifyVXLANStatsExecutor{}).Execute(context.Background(), r, step)
+
+	want := map[string]StepStatus{"leaf1": StepStatusPassed, "leaf2": StepStatusError, "leaf3": StepStatusFailed}
+	for _, d := range out.Result.Details {
+		if d.Status != want[d.Device] {
+			t.Errorf("%s: %s %q, want %s", d.Device, d.Status, d.Message, want[d.Device])
+		}
+	}
+}
+
+func TestVerifyVXLANStats_MissingBaseline(t *testing.T) {
+	fake := &vxlanStatsServer{byDevice: map[string][]newtron.VXLANStat{
+		"leaf1": {remoteTunnel("10.0.0.2", []int{10100}, 1, 1)},
+	}}
+	srv := fake.start(t)
+	defer srv.Close()
+	r := &Runner{Client: client.New(srv.URL, "test-net")}
+
+	out := (&verifyVXLANStatsExecutor{}).Execute(context.Background(), r,
+		vxlanStep(map[string]any{"vni": 10100, "baseline": "never-recorded"}))
+	if d := out.Result.Details[0]; d.Status != StepStatusError {
+		t.Errorf("status = %s %q, want ERROR for an unrecorded baseline", d.Status, d.Message)
+	}
+}
+
+func TestRequireVXLANStatsParams(t *testing.T) {
+	tests := []struct {
+		params  map[string]any
+		wantErr string
+	}{
+		{map[string]any{"vni": 10100}, ""},
+		{map[string]any{"vni": 10100, "baseline": "pre", "record": true}, ""},
+		{map[string]any{}, "params.vni must be 1-16777215"},
+		{map[string]any{"vni": 16777216}, "params.vni must be 1-16777215"},
+		{map[string]any{"vni": 10100, "direction": "up"}, "params.direction must be"},
+		{map[string]any{"vni": 10100, "min_packets": 0}, "params.min_packets must be at least 1"},
+		{map[string]any{"vni": 10100, "record": true}, "params.record requires params.baseline"},
+	}
+	for _, tt := range tests {
+		err := requireVXLANStatsParams("step", &Step{Action: ActionVerifyVXLANStats, Params: tt.params})
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%v: unexpected error %v", tt.params, err)
+		case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+			t.Errorf("%v: err = %v, want %q", tt.params, err, tt.wantErr)
+		}
+	}
+}
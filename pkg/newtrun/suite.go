@@ -32,6 +32,10 @@ type Suite struct {
 	Targets     map[string][]string      `yaml:"targets,omitempty"`
 	Parameters  map[string]ParameterSpec `yaml:"parameters,omitempty"`
 
+	// Vars is the suite level of the free-form {{var.X}} values its
+	// scenarios and steps may override (vars.go).
+	Vars map[string]any `yaml:"vars,omitempty"`
+
 	// Scenarios is the dependency-ordered list of scenarios loaded
 	// from the suite directory. Populated by LoadSuite. Not part of
 	// the wire format — scenarios are separate YAML files.
@@ -213,6 +217,8 @@ func pluralize(singular string) string {
 //     resolve to a suite-level declaration, the step must not also
 //     set devices: or use {{device}}, and the suite must declare a
 //     matching dimension/parameter
+//   - every {{var.X}} must be set by the step's, the scenario's or the
+//     suite's vars:
 func LoadSuite(dir string) (*Suite, error) {
 	suitePath := filepath.Join(dir, "suite.yaml")
 	data, err := os.ReadFile(suitePath)
@@ -248,6 +254,9 @@ func LoadSuite(dir string) (*Suite, error) {
 		if err := validateScenarioAgainstSuite(sc, &suite, path); err != nil {
 			return nil, err
 		}
+		if err := validateVarRefs(sc, &suite, path); err != nil {
+			return nil, err
+		}
 	}
 
 	if HasRequires(scenarios) {
@@ -36,7 +36,11 @@ import (
 // ipv4/cidr) from the suite's ParameterSpec.Coerce path; the engine
 // uses the Go type to decide how to render.
 
-var templateTokenRe = regexp.MustCompile(`\{\{(target|param|captured)\.([a-zA-Z0-9_]+)\}\}`)
+// templateTokenRe also matches the bare {{item}} token of a for_each step
+// (foreach.go), the {{steps.NAME.output.KEY}} token of a step output
+// (publish.go) and the {{var.X}} token of a suite variable (vars.go);
+// tokenRef sorts a match out.
+var templateTokenRe = regexp.MustCompile(`\{\{(?:(target|param|captured|var)\.([a-zA-Z0-9_]+)|item|steps\.([a-zA-Z0-9_-]+)\.output\.([a-zA-Z0-9_]+))\}\}`)
 
 var deviceTokenRe = regexp.MustCompile(`\{\{device\}\}`)
 
@@ -45,7 +49,17 @@ var deviceTokenRe = regexp.MustCompile(`\{\{device\}\}`)
 // string value `"{{param.mtu}}"` in YAML decodes to that literal
 // string; full-token replacement substitutes the typed Go value
 // (e.g., int 9100) so JSON marshal emits `9100`, not `"9100"`.
-var fullTokenRe = regexp.MustCompile(`^\{\{(target|param|captured)\.([a-zA-Z0-9_]+)\}\}$`)
+var fullTokenRe = regexp.MustCompile(`^\{\{(?:(target|param|captured|var)\.([a-zA-Z0-9_]+)|item|steps\.([a-zA-Z0-9_-]+)\.output\.([a-zA-Z0-9_]+))\}\}$`)
+
+// tokenRef returns the kind and name of a templateTokenRe / fullTokenRe
+// submatch. {{item}} has kind ""; a step output has kind "steps" and, as
+// its name, the captured-map key it was published under.
+func tokenRef(sub []string) (kind, name string) {
+	if sub[3] != "" {
+		return "steps", stepOutputKey(sub[3], sub[4])
+	}
+	return sub[1], sub[2]
+}
 
 // subContext selects the encoding strategy for substituted values.
 type subContext int
@@ -66,8 +80,9 @@ const (
 //
 // target and params come from the suite-level binding (parameterized
 // scenarios); captured comes from the runner's per-iteration response-
-// captured map (any scenario). All three may be nil for steps that
-// don't reference them.
+// captured map (any scenario), which also carries the for_each item and
+// published step outputs. All three may be nil for steps that don't
+// reference them.
 //
 // Each field is expanded under the substitution context appropriate
 // to its downstream consumer. Substitution errors (undefined token
@@ -176,10 +191,16 @@ func applyTemplate(s string, target map[string]string, params map[string]any, ca
 		if firstErr != nil {
 			return m
 		}
-		sub := templateTokenRe.FindStringSubmatch(m)
-		kind, name := sub[1], sub[2]
+		kind, name := tokenRef(templateTokenRe.FindStringSubmatch(m))
 		var raw any
 		switch kind {
+		case "":
+			v, ok := captured[itemBinding]
+			if !ok {
+				firstErr = fmt.Errorf("%s outside a for_each step", m)
+				return m
+			}
+			raw = v
 		case "target":
 			v, ok := target[name]
 			if !ok {
@@ -201,6 +222,20 @@ func applyTemplate(s string, target map[string]string, params map[string]any, ca
 				return m
 			}
 			raw = v
+		case "steps":
+			v, ok := captured[name]
+			if !ok {
+				firstErr = fmt.Errorf("undefined step output %s (no earlier step published it)", m)
+				return m
+			}
+			raw = v
+		case "var":
+			v, ok := captured[varPrefix+name]
+			if !ok {
+				firstErr = fmt.Errorf("undefined var reference %s", m)
+				return m
+			}
+			raw = v
 		}
 		return encodeForContext(raw, ctx)
 	})
@@ -291,8 +326,14 @@ func expandAny(v any, target map[string]string, params map[string]any, captured
 	switch t := v.(type) {
 	case string:
 		if m := fullTokenRe.FindStringSubmatch(t); m != nil {
-			kind, name := m[1], m[2]
+			kind, name := tokenRef(m)
 			switch kind {
+			case "":
+				val, ok := captured[itemBinding]
+				if !ok {
+					return nil, fmt.Errorf("%s outside a for_each step", t)
+				}
+				return val, nil
 			case "target":
 				val, ok := target[name]
 				if !ok {
@@ -311,6 +352,18 @@ func expandAny(v any, target map[string]string, params map[string]any, captured
 					return nil, fmt.Errorf("undefined captured reference %s (no prior step captured %q)", t, name)
 				}
 				return val, nil
+			case "steps":
+				val, ok := captured[name]
+				if !ok {
+					return nil, fmt.Errorf("undefined step output %s (no earlier step published it)", t)
+				}
+				return val, nil
+			case "var":
+				val, ok := captured[varPrefix+name]
+				if !ok {
+					return nil, fmt.Errorf("undefined var reference %s", t)
+				}
+				return val, nil
 			}
 		}
 		return applyTemplate(t, target, params, captured, ctxRaw)
@@ -376,7 +429,10 @@ func (r *refCollector) scan(s string) {
 		r.hasDevice = true
 	}
 	for _, m := range templateTokenRe.FindAllStringSubmatch(s, -1) {
-		kind, name := m[1], m[2]
+		kind, name := tokenRef(m)
+		if kind == "" || kind == "steps" || kind == "var" {
+			continue // {{item}}, step outputs and vars are bound at run time
+		}
 		key := kind + "." + name
 		if r.seen[key] {
 			continue
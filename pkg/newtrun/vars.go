@@ -0,0 +1,122 @@
+package newtrun
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suite variables. vars: is a free-form map of values a suite's scenarios
+// share — an ASN, a VLAN ID, a prefix — written once instead of pasted into
+// every step. It can be set at three levels, and the narrowest wins:
+//
+//	# suite.yaml
+//	vars: {asn: 65000, vlan: 100}
+//
+//	# 02-ebgp.yaml
+//	name: ebgp
+//	vars: {asn: 65001}                 # overrides the suite's asn
+//	steps:
+//	  - name: peer
+//	    action: newtron
+//	    url: /nodes/{{device}}/interfaces/Ethernet0/add-bgp-peer
+//	    params: {remote_as: "{{var.asn}}"}   # 65001, an int
+//	  - name: peer-ibgp
+//	    vars: {asn: 65100}             # overrides both, for this step only
+//	    ...
+//
+// so a step sees step > scenario > suite. Unlike suite parameters, vars are
+// not declared or typed and cannot be overridden per run; any key is
+// allowed, with the value YAML gave it. A {{var.X}} is expanded like the
+// other tokens — path-escaped in a URL, shell-quoted in a command, typed in
+// a full-token JSON param — and works in embedded-target and parameterized
+// scenarios alike: it does not make a scenario parameterized.
+//
+// Vars are not merged into a step's params: a newtron request body is
+// decoded strictly, so a key the endpoint does not know would fail the
+// call. A step names what it uses.
+//
+// The values ride the template engine's captured map under varPrefix, as a
+// for_each item does under itemBinding: no capture name has a dot in it.
+
+// varPrefix starts the captured-map key of a var — the token's own text,
+// minus the braces.
+const varPrefix = "var."
+
+// scopedVars returns the vars a step sees: suite vars, overridden by the
+// scenario's, overridden by the step's. Nil when none is set.
+func scopedVars(suite *Suite, sc *Scenario, step *Step) map[string]any {
+	var layers []map[string]any
+	if suite != nil {
+		layers = append(layers, suite.Vars)
+	}
+	layers = append(layers, sc.Vars, step.Vars)
+	var out map[string]any
+	for _, layer := range layers {
+		for k, v := range layer {
+			if out == nil {
+				out = map[string]any{}
+			}
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// withVars returns a copy of captured with vars bound to {{var.X}}, or
+// captured itself when there are none. As with withForEachItem, captures
+// the step makes still land in the runner's own map.
+func withVars(captured map[string]any, vars map[string]any) map[string]any {
+	if len(vars) == 0 {
+		return captured
+	}
+	out := make(map[string]any, len(captured)+len(vars))
+	for k, v := range captured {
+		out[k] = v
+	}
+	for k, v := range vars {
+		out[varPrefix+k] = v
+	}
+	return out
+}
+
+// varRefs returns the var names step references, in order of first
+// appearance.
+func varRefs(step Step) []string {
+	text, _ := yaml.Marshal(step)
+	var names []string
+	seen := map[string]bool{}
+	for _, m := range templateTokenRe.FindAllStringSubmatch(string(text), -1) {
+		if kind, name := tokenRef(m); kind == "var" && !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// validateVarRefs checks that every {{var.X}} in a suite's scenario is set
+// at some level the step sees, so a typo fails at load time rather than
+// after the steps before it have run.
+func validateVarRefs(sc *Scenario, suite *Suite, path string) error {
+	check := func(kind string, i int, step *Step) error {
+		vars := scopedVars(suite, sc, step)
+		for _, name := range varRefs(*step) {
+			if _, ok := vars[name]; !ok {
+				return fmt.Errorf("%s %s %d (%s): references {{var.%s}} but no vars: sets it (step, scenario or suite.yaml)", path, kind, i, step.Name, name)
+			}
+		}
+		return nil
+	}
+	for i := range sc.Steps {
+		if err := check("step", i, &sc.Steps[i]); err != nil {
+			return err
+		}
+	}
+	for i := range sc.Cleanup {
+		if err := check("cleanup step", i, &sc.Cleanup[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// TestVars_ScenarioOverridesSuite loads a suite whose suite.yaml sets
+// asn: 65000, runs a scenario that overrides it with asn: 65001 and one
+// that does not, and checks the value each step's request carried.
+func TestVars_ScenarioOverridesSuite(t *testing.T) {
+	dir := writeSuiteDir(t, map[string]string{
+		"suite.yaml": `name: demo
+network: synthetic
+vars:
+  asn: 65000
+  site: {region: east}   # any shape, any key
+`,
+		"00-ebgp.yaml": `name: ebgp
+vars: {asn: 65001}
+steps:
+  - name: peer
+    action: newtron
+    method: POST
+    url: /asn/{{var.asn}}/peer
+    params: {remote_as: "{{var.asn}}"}
+  - name: peer-ibgp
+    action: newtron
+    method: POST
+    url: /asn/{{var.asn}}/peer
+    params: {remote_as: "{{var.asn}}"}
+    vars: {asn: 65100}
+`,
+		"01-default.yaml": `name: default
+steps:
+  - name: peer
+    action: newtron
+    method: POST
+    url: /asn/{{var.asn}}/peer
+    params: {remote_as: "{{var.asn}}", note: "asn {{var.asn}}"}
+`,
+	})
+	suite, err := LoadSuite(dir)
+	if err != nil {
+		t.Fatalf("LoadSuite: %v", err)
+	}
+
+	var (
+		mu     sync.Mutex
+		paths  []string
+		bodies []map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":null}`))
+	}))
+	defer srv.Close()
+
+	r := &Runner{Client: client.New(srv.URL, "test-net"), suite: suite}
+	for _, sc := range suite.Scenarios {
+		result := &ScenarioResult{Name: sc.Name}
+		r.runScenarioSteps(context.Background(), sc, RunOptions{}, result)
+		if result.Status != StepStatusPassed {
+			t.Fatalf("%s: status = %s, steps: %+v", sc.Name, result.Status, result.Steps)
+		}
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"65001", "65100", "65000"}
+	if len(bodies) != len(want) {
+		t.Fatalf("requests = %v, want %d", paths, len(want))
+	}
+	for i, asn := range want {
+		if !strings.HasSuffix(paths[i], "/asn/"+asn+"/peer") {
+			t.Errorf("request %d path = %q, want asn %s", i, paths[i], asn)
+		}
+		// A full-token param keeps the int type: JSON 65001, not "65001".
+		if got, ok := bodies[i]["remote_as"].(float64); !ok || got != mustFloat(t, asn) {
+			t.Errorf("request %d remote_as = %#v, want %s", i, bodies[i]["remote_as"], asn)
+		}
+	}
+	if bodies[2]["note"] != "asn 65000" {
+		t.Errorf("note = %#v, want suite default inline", bodies[2]["note"])
+	}
+}
+
+func mustFloat(t *testing.T, s string) float64 {
+	t.Helper()
+	var f float64
+	if err := json.Unmarshal([]byte(s), &f); err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestScopedVars(t *testing.T) {
+	suite := &Suite{Vars: map[string]any{"asn": 65000, "vlan": 100}}
+	sc := &Scenario{Vars: map[string]any{"asn": 65001}}
+	step := &Step{Vars: map[string]any{"vlan": 200}}
+
+	got := scopedVars(suite, sc, step)
+	if got["asn"] != 65001 || got["vlan"] != 200 || len(got) != 2 {
+		t.Errorf("scopedVars = %v, want asn 65001 (scenario), vlan 200 (step)", got)
+	}
+	if got := scopedVars(nil, &Scenario{}, &Step{}); got != nil {
+		t.Errorf("scopedVars with none set = %v, want nil", got)
+	}
+	if suite.Vars["asn"] != 65000 || sc.Vars["asn"] != 65001 {
+		t.Error("scopedVars modified a level's own map")
+	}
+}
+
+func TestLoadSuite_UndefinedVar(t *testing.T) {
+	dir := writeSuiteDir(t, map[string]string{
+		"suite.yaml": `name: demo
+network: synthetic
+vars: {asn: 65000}
+`,
+		"00-typo.yaml": `name: typo
+steps:
+  - name: peer
+    action: host-exec
+    devices: [h1]
+    command: echo {{var.asm}}
+`,
+	})
+	_, err := LoadSuite(dir)
+	if err == nil || !strings.Contains(err.Error(), "references {{var.asm}} but no vars: sets it") {
+		t.Errorf("err = %v, want undefined var", err)
+	}
+}
+
+// TestVars_NotParameterized: a {{var.X}} next to a devices: selector and
+// {{device}} is fine — vars do not make a scenario parameterized.
+func TestVars_NotParameterized(t *testing.T) {
+	sc, err := ParseScenarioBytes([]byte(`name: s
+vars: {count: 3}
+steps:
+  - name: ping
+    action: host-exec
+    devices: [h1]
+    command: ping -c {{var.count}} {{device}}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ScenarioIsParameterized(sc) {
+		t.Error("scenario using only {{var.X}} reported as parameterized")
+	}
+	got, err := ExpandStep(sc.Steps[0], nil, nil, withVars(nil, scopedVars(nil, sc, &sc.Steps[0])))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Command != "ping -c '3' {{device}}" {
+		t.Errorf("Command = %q", got.Command)
+	}
+}
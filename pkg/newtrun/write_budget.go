@@ -0,0 +1,107 @@
+package newtrun
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// Run-level write budget. RunOptions.MaxTotalWrites caps the config entries
+// a whole run may deliver — every applied ChangeSet entry, across scenarios,
+// devices and run-suite children — as a backstop against a churn or rollout
+// scenario gone wrong on real hardware. It is a fleet-wide cap, separate
+// from anything newtron checks per ChangeSet.
+//
+// doCall charges each write after newtron answers: the response's
+// change_count when it was applied, summed over devices for a fleet call. A
+// dry_run write is not applied and costs nothing. The write that takes the
+// total past the cap has already been delivered, so the budget trips
+// rather than refuses it: the scenario's main steps are canceled (reason
+// "write budget"), any write still pending in them is refused, and the
+// scenario's cleanup steps — its own teardown — still run to roll back
+// what it changed. Every later scenario is skipped and the run fails with
+// the cumulative count.
+
+// writeBudgetReason is the reason the write budget gives for the steps and
+// scenarios it cut off.
+const writeBudgetReason = "write budget"
+
+// errWriteBudget is the cancellation cause of a scenario's steps when the
+// run's write budget trips.
+var errWriteBudget = errors.New(writeBudgetReason)
+
+// writeBudget counts a run's device writes against its cap. A run-suite
+// child shares its parent's, so the cap holds across the whole run.
+type writeBudget struct {
+	max     int64
+	written atomic.Int64
+}
+
+// charge adds n writes and reports whether the total is now over the cap.
+func (b *writeBudget) charge(n int) bool {
+	return b.written.Add(int64(n)) > b.max
+}
+
+// err returns the run's failure once the total is over the cap, else nil.
+// Safe on a nil budget (no cap).
+func (b *writeBudget) err() error {
+	if b == nil {
+		return nil
+	}
+	if n := b.written.Load(); n > b.max {
+		return fmt.Errorf("write budget exceeded: %d device writes, cap %d", n, b.max)
+	}
+	return nil
+}
+
+// chargeWrites charges the writes a newtron response applied to the run's
+// budget, canceling the current scenario's steps when it trips.
+func (r *Runner) chargeWrites(data json.RawMessage) {
+	if r.writes == nil {
+		return
+	}
+	if n := appliedWrites(data); n > 0 && r.writes.charge(n) && r.cancelSteps != nil {
+		r.cancelSteps(errWriteBudget)
+	}
+}
+
+// refuseWrite returns an error when ctx's steps were canceled by the write
+// budget: the write must not go out. Cleanup runs under the scenario's
+// parent context and is never refused.
+func refuseWrite(ctx context.Context) error {
+	if context.Cause(ctx) == errWriteBudget {
+		return fmt.Errorf("%s exceeded; write not sent", writeBudgetReason)
+	}
+	return nil
+}
+
+// appliedWrites returns how many config entries a newtron write response
+// delivered: a WriteResult's change_count when applied, or the sum over a
+// MultiChangeSet's devices. Any other response counts zero.
+func appliedWrites(data json.RawMessage) int {
+	type result struct {
+		ChangeCount int  `json:"change_count"`
+		Applied     bool `json:"applied"`
+	}
+	var resp struct {
+		result
+		Devices []struct {
+			Result *result `json:"result"`
+		} `json:"devices"`
+	}
+	if json.Unmarshal(data, &resp) != nil {
+		return 0
+	}
+	n := 0
+	if resp.Applied {
+		n += resp.ChangeCount
+	}
+	for _, d := range resp.Devices {
+		if d.Result != nil && d.Result.Applied {
+			n += d.Result.ChangeCount
+		}
+	}
+	return n
+}
@@ -0,0 +1,121 @@
+package newtrun
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aldrin-isaac/newtron/pkg/newtron/client"
+)
+
+// TestRunScenarioSteps_WriteBudget: the write that takes the run past its
+// cap trips the budget — the rest of that step's writes are refused, the
+// later steps are skipped, cleanup still runs, the scenario ends in ERROR,
+// and no later scenario runs.
+func TestRunScenarioSteps_WriteBudget(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{"change_count":3,"applied":true}}`))
+	}))
+	defer srv.Close()
+
+	scenario := &Scenario{
+		Name: "churn",
+		Steps: []Step{
+			{Name: "a", Action: ActionNewtron, Method: "POST", URL: "/a"},
+			{Name: "b", Action: ActionNewtron, Batch: []BatchCall{
+				{Method: "POST", URL: "/b1"},
+				{Method: "POST", URL: "/b2"},
+			}},
+			{Name: "c", Action: ActionNewtron, Method: "POST", URL: "/c"},
+		},
+		Cleanup: []Step{{Name: "undo", Action: ActionNewtron, Method: "DELETE", URL: "/undo"}},
+	}
+	r := &Runner{
+		Client: client.New(srv.URL, "test-net"),
+		writes: &writeBudget{max: 5},
+	}
+	result := &ScenarioResult{Name: scenario.Name}
+	r.runScenarioSteps(context.Background(), scenario, RunOptions{}, result)
+
+	if got := strings.Join(requests, ","); got != "a,b1,undo" {
+		t.Errorf("requests = %s, want a,b1,undo (b2 and c never sent)", got)
+	}
+	if result.Status != StepStatusError {
+		t.Errorf("scenario status = %s, want ERROR", result.Status)
+	}
+	want := []struct {
+		name   string
+		status StepStatus
+		msg    string
+	}{
+		{"a", StepStatusPassed, ""},
+		{"b", StepStatusError, "write budget: batch[1] POST /b2: write budget exceeded; write not sent"},
+		{"c", StepStatusSkipped, "write budget"},
+		{"cleanup/undo", StepStatusPassed, ""},
+	}
+	if len(result.Steps) != len(want) {
+		t.Fatalf("got %d step results, want %d: %+v", len(result.Steps), len(want), result.Steps)
+	}
+	for i, w := range want {
+		sr := result.Steps[i]
+		if sr.Name != w.name || sr.Status != w.status || (w.msg != "" && sr.Message != w.msg) {
+			t.Errorf("step %d = %s %s %q, want %s %s %q", i, sr.Name, sr.Status, sr.Message, w.name, w.status, w.msg)
+		}
+	}
+
+	ran := 0
+	results, err := r.iterateScenarios(context.Background(), []*Scenario{{Name: "next"}}, RunOptions{}, "", func(_ context.Context, sc *Scenario, _ string) (*ScenarioResult, error) {
+		ran++
+		return &ScenarioResult{Name: sc.Name, Status: StepStatusPassed}, nil
+	})
+	if ran != 0 {
+		t.Errorf("ran %d scenarios after the budget tripped, want 0", ran)
+	}
+	if err == nil || err.Error() != "write budget exceeded: 9 device writes, cap 5" {
+		t.Errorf("err = %v, want the cumulative count", err)
+	}
+	if len(results) != 1 || results[0].Status != StepStatusSkipped || results[0].SkipReason != "write budget (5) exceeded" {
+		t.Errorf("results = %+v, want next SKIPPED by the write budget", results)
+	}
+}
+
+// TestIterateScenarios_WriteBudgetLastScenario: a budget tripped by the
+// last scenario still fails the run.
+func TestIterateScenarios_WriteBudgetLastScenario(t *testing.T) {
+	r := &Runner{writes: &writeBudget{max: 1}}
+	_, err := r.iterateScenarios(context.Background(), []*Scenario{{Name: "only"}}, RunOptions{}, "", func(_ context.Context, sc *Scenario, _ string) (*ScenarioResult, error) {
+		r.writes.charge(2)
+		return &ScenarioResult{Name: sc.Name, Status: StepStatusError}, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "2 device writes, cap 1") {
+		t.Errorf("err = %v, want write budget exceeded", err)
+	}
+}
+
+func TestAppliedWrites(t *testing.T) {
+	for _, tt := range []struct {
+		body string
+		want int
+	}{
+		{`{"change_count":4,"applied":true}`, 4},
+		{`{"change_count":4,"applied":false}`, 0}, // dry run
+		{`{"devices":[{"device":"leaf1","result":{"change_count":2,"applied":true}},{"device":"leaf2","error":"boom"},{"device":"leaf3","result":{"change_count":5,"applied":true}}]}`, 7},
+		{`[{"name":"Vlan100"}]`, 0},
+		{`not json`, 0},
+	} {
+		if got := appliedWrites([]byte(tt.body)); got != tt.want {
+			t.Errorf("appliedWrites(%s) = %d, want %d", tt.body, got, tt.want)
+		}
+	}
+}
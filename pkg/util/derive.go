@@ -293,6 +293,30 @@ func NormalizeInterfaceName(name string) string {
 	return name
 }
 
+// NormalizeInterfaceNameForPorts normalizes name against a platform's port
+// inventory (device-native names, e.g. "Ethernet0", "Ethernet1/1/1",
+// "eth0"). A name the inventory holds — exactly, or differing only in case —
+// is returned as the inventory spells it, so a host's "eth0" is not rewritten
+// to "Ethernet0". A subinterface ("eth0.10") is matched by its parent port.
+// Anything else falls back to NormalizeInterfaceName; an empty inventory is
+// the generic rule.
+func NormalizeInterfaceNameForPorts(name string, ports []string) string {
+	name = strings.TrimSpace(name)
+	if len(ports) == 0 {
+		return NormalizeInterfaceName(name)
+	}
+	parent, sub, hasSub := strings.Cut(name, ".")
+	for _, p := range ports {
+		if strings.EqualFold(p, parent) {
+			if hasSub {
+				return p + "." + sub
+			}
+			return p
+		}
+	}
+	return NormalizeInterfaceName(name)
+}
+
 // MergeMaps merges maps with later maps overriding earlier ones
 func MergeMaps[K comparable, V any](maps ...map[K]V) map[K]V {
 	result := make(map[K]V)
@@ -302,6 +302,30 @@ func TestNormalizeInterfaceName(t *testing.T) {
 	}
 }
 
+func TestNormalizeInterfaceNameForPorts(t *testing.T) {
+	slotted := []string{"Ethernet1/1/1", "Ethernet1/1/2"}
+	host := []string{"eth0", "eth1"}
+	tests := []struct {
+		input string
+		ports []string
+		want  string
+	}{
+		{"Eth1/1/1", slotted, "Ethernet1/1/1"},
+		{"ethernet1/1/2", slotted, "Ethernet1/1/2"},
+		{"Po1", slotted, "PortChannel1"},
+		{"eth0", host, "eth0"},
+		{"Eth1", host, "eth1"},
+		{"eth1.100", host, "eth1.100"},
+		{"eth2", host, "Ethernet2"}, // not in the inventory: generic rule
+		{"eth0", nil, "Ethernet0"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeInterfaceNameForPorts(tt.input, tt.ports); got != tt.want {
+			t.Errorf("NormalizeInterfaceNameForPorts(%q, %v) = %q, want %q", tt.input, tt.ports, got, tt.want)
+		}
+	}
+}
+
 func TestMergeMaps(t *testing.T) {
 	tests := []struct {
 		name string
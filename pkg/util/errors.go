@@ -31,7 +31,7 @@ var (
 //
 // Force reports whether passing force=true would cascade past the conflict.
 // It is true only for the deletes that actually support a cascade (profile,
-// topology-device); spec deletes and existence collisions leave it false, so
+// topology-device, a delete-vrf blocked only by set-vrf bindings); spec deletes and existence collisions leave it false, so
 // the message and the wire payload never advertise a force that doesn't exist.
 // The json tags are the §46 structured wire shape the API serializes into the
 // error envelope's Data (resource / name / references[] / force_available).
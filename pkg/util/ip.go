@@ -18,34 +18,43 @@ func parseIPWithMask(cidr string) (net.IP, int, error) {
 	return ip, ones, nil
 }
 
-// ComputeNeighborIP returns the peer IP for point-to-point subnets (/30 or /31)
-// Returns empty string if not a point-to-point subnet
+// ComputeNeighborIP returns the peer IP for point-to-point subnets: /30 or
+// /31 for IPv4, /126 or /127 (RFC 6164) for IPv6. Returns empty string if not
+// a point-to-point subnet, or if localIP is the subnet's network or broadcast
+// address on a /30 (or the equivalent ::0 / ::3 on a /126).
 func ComputeNeighborIP(localIP string, maskLen int) string {
 	ip := net.ParseIP(localIP)
 	if ip == nil {
 		return ""
 	}
-	ip = ip.To4()
-	if ip == nil {
-		return "" // IPv6 not supported for this function
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	} else {
+		// IPv6 point-to-point lengths map onto the IPv4 ones: the last two
+		// bits of the address play the same part.
+		switch maskLen {
+		case 127:
+			maskLen = 31
+		case 126:
+			maskLen = 30
+		default:
+			return ""
+		}
 	}
+	last := len(ip) - 1
 
 	switch maskLen {
 	case 31: // RFC 3021 point-to-point
 		// /31: two usable IPs, neighbor is the other one
-		if ip[3]&1 == 0 {
-			ip[3]++
-		} else {
-			ip[3]--
-		}
+		ip[last] ^= 1
 	case 30: // Traditional point-to-point
 		// /30: .0=network, .1=first host, .2=second host, .3=broadcast
-		lastOctet := ip[3] & 0x03
-		if lastOctet == 1 {
-			ip[3]++
-		} else if lastOctet == 2 {
-			ip[3]--
-		} else {
+		switch ip[last] & 0x03 {
+		case 1:
+			ip[last]++
+		case 2:
+			ip[last]--
+		default:
 			return "" // Network or broadcast address
 		}
 	default:
@@ -72,6 +81,56 @@ func IsValidIPv4CIDR(cidr string) bool {
 	return ip != nil && ip.To4() != nil
 }
 
+// IsValidIPv6CIDR checks if a string is a valid IPv6 CIDR notation
+func IsValidIPv6CIDR(cidr string) bool {
+	ip, _, err := net.ParseCIDR(cidr)
+	return err == nil && ip.To4() == nil
+}
+
+// IsValidIPCIDR checks if a string is a valid IPv4 or IPv6 CIDR notation
+func IsValidIPCIDR(cidr string) bool {
+	return IsValidIPv4CIDR(cidr) || IsValidIPv6CIDR(cidr)
+}
+
+// IsValidRouteTarget checks if a string is a BGP route target in one of the
+// RFC 4360 forms FRR accepts: ASN:NN with a 2-byte ASN and 4-byte value, ASN:NN
+// with a 4-byte ASN and 2-byte value, or IPv4:NN with a 2-byte value.
+func IsValidRouteTarget(rt string) bool {
+	admin, assigned, ok := strings.Cut(rt, ":")
+	if !ok {
+		return false
+	}
+	nn, err := strconv.ParseUint(assigned, 10, 32)
+	if err != nil {
+		return false
+	}
+	if IsValidIPv4(admin) {
+		return nn <= 0xFFFF
+	}
+	asn, err := strconv.ParseUint(admin, 10, 32)
+	if err != nil {
+		return false
+	}
+	return asn <= 0xFFFF || nn <= 0xFFFF
+}
+
+// ValidateUnicastMAC checks that mac is an EUI-48 address usable as a
+// gateway MAC: not multicast (I/G bit set, which includes broadcast) and not
+// all zeros.
+func ValidateUnicastMAC(mac string) error {
+	hw, err := net.ParseMAC(mac)
+	if err != nil || len(hw) != 6 {
+		return fmt.Errorf("invalid MAC address %q", mac)
+	}
+	if hw[0]&0x01 != 0 {
+		return fmt.Errorf("MAC address %s is multicast or broadcast, not unicast", mac)
+	}
+	if hw.String() == "00:00:00:00:00:00" {
+		return fmt.Errorf("MAC address %s is all zeros", mac)
+	}
+	return nil
+}
+
 // ValidateMTU checks if MTU is within valid range
 func ValidateMTU(mtu int) error {
 	if mtu < 68 || mtu > 9216 {
@@ -95,7 +154,7 @@ func SplitIPMask(cidr string) (string, int) {
 }
 
 // DeriveNeighborIP derives the BGP neighbor IP from a local IP address with CIDR mask.
-// Works for point-to-point links (/30 and /31).
+// Works for point-to-point links (/30 and /31; /126 and /127 for IPv6).
 // Returns error if the subnet is not point-to-point.
 func DeriveNeighborIP(localIPWithMask string) (string, error) {
 	ipStr, maskLen := SplitIPMask(localIPWithMask)
@@ -105,7 +164,7 @@ func DeriveNeighborIP(localIPWithMask string) (string, error) {
 
 	neighborIP := ComputeNeighborIP(ipStr, maskLen)
 	if neighborIP == "" {
-		return "", fmt.Errorf("cannot derive neighbor IP: /%d is not a point-to-point subnet (use /30 or /31)", maskLen)
+		return "", fmt.Errorf("cannot derive neighbor IP: /%d is not a point-to-point subnet (use /30 or /31, or /126 or /127 for IPv6)", maskLen)
 	}
 	return neighborIP, nil
 }
@@ -211,6 +211,59 @@ func TestIsValidIPv4CIDR(t *testing.T) {
 	}
 }
 
+func TestIsValidIPv6CIDR(t *testing.T) {
+	tests := []struct {
+		name string
+		cidr string
+		want bool
+	}{
+		{"valid /32", "2001:db8::/32", true},
+		{"valid /128", "2001:db8::1/128", true},
+		{"valid /0", "::/0", true},
+		{"invalid - IPv4", "192.168.1.0/24", false},
+		{"invalid - no mask", "2001:db8::1", false},
+		{"invalid - bad mask", "2001:db8::/129", false},
+		{"invalid - empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsValidIPv6CIDR(tt.cidr); got != tt.want {
+				t.Errorf("IsValidIPv6CIDR(%q) = %v, want %v", tt.cidr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsValidRouteTarget(t *testing.T) {
+	tests := []struct {
+		name string
+		rt   string
+		want bool
+	}{
+		{"2-byte ASN", "65000:100", true},
+		{"2-byte ASN, 4-byte value", "65000:4294967295", true},
+		{"4-byte ASN", "4200000000:100", true},
+		{"IPv4 admin", "10.0.0.1:100", true},
+		{"invalid - 4-byte ASN and value", "4200000000:70000", false},
+		{"invalid - IPv4 with 4-byte value", "10.0.0.1:70000", false},
+		{"invalid - no colon", "65000", false},
+		{"invalid - ASN too large", "4294967296:1", false},
+		{"invalid - non-numeric", "abc:1", false},
+		{"invalid - empty value", "65000:", false},
+		{"invalid - empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := IsValidRouteTarget(tt.rt)
+			if got != tt.want {
+				t.Errorf("IsValidRouteTarget(%q) = %v, want %v", tt.rt, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestValidateMTU(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -236,6 +289,33 @@ func TestValidateMTU(t *testing.T) {
 	}
 }
 
+func TestValidateUnicastMAC(t *testing.T) {
+	tests := []struct {
+		name    string
+		mac     string
+		wantErr bool
+	}{
+		{"valid unicast", "00:00:00:00:01:01", false},
+		{"valid locally administered", "02:aa:bb:cc:dd:ee", false},
+		{"valid dash form", "00-11-22-33-44-55", false},
+		{"invalid multicast", "01:00:5e:00:00:01", true},
+		{"invalid broadcast", "ff:ff:ff:ff:ff:ff", true},
+		{"invalid all zeros", "00:00:00:00:00:00", true},
+		{"invalid EUI-64", "00:00:00:00:00:00:01:01", true},
+		{"invalid syntax", "00:00:00:01:01", true},
+		{"invalid empty", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUnicastMAC(tt.mac)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateUnicastMAC(%q) error = %v, wantErr %v", tt.mac, err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestSplitIPMask(t *testing.T) {
 	tests := []struct {
 		cidr     string
@@ -310,10 +390,27 @@ func TestDeriveNeighborIP(t *testing.T) {
 }
 
 func TestComputeNeighborIP_IPv6(t *testing.T) {
-	// IPv6 is not supported, should return empty string
-	got := ComputeNeighborIP("::1", 31)
-	if got != "" {
-		t.Errorf("ComputeNeighborIP(IPv6) = %q, want empty", got)
+	tests := []struct {
+		localIP string
+		maskLen int
+		want    string
+	}{
+		{"2001:db8::1", 126, "2001:db8::2"},
+		{"2001:db8::2", 126, "2001:db8::1"},
+		{"2001:db8::", 126, ""},  // network
+		{"2001:db8::3", 126, ""}, // last address of the /126
+		{"2001:db8::", 127, "2001:db8::1"},
+		{"2001:db8::1", 127, "2001:db8::"},
+		{"2001:db8::1", 64, ""}, // not point-to-point
+		{"::1", 31, ""},         // IPv4 lengths do not apply to IPv6
+	}
+	for _, tt := range tests {
+		if got := ComputeNeighborIP(tt.localIP, tt.maskLen); got != tt.want {
+			t.Errorf("ComputeNeighborIP(%q, %d) = %q, want %q", tt.localIP, tt.maskLen, got, tt.want)
+		}
+	}
+	if got, err := DeriveNeighborIP("2001:db8::1/126"); err != nil || got != "2001:db8::2" {
+		t.Errorf("DeriveNeighborIP(2001:db8::1/126) = %q, %v", got, err)
 	}
 }
 